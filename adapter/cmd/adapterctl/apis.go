@@ -0,0 +1,208 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/wso2/product-microgateway/adapter/pkg/client"
+)
+
+// runDeploy deploys an API project (an apictl project directory or its zip archive) to a running adapter. The API
+// is deployed through the same pipeline as the APIs deployed by the control plane, hence the command can be used to
+// test the APIs locally. The adapter rejects the deployments, if it is connected to a control plane.
+func runDeploy(args []string) int {
+	var connection connectionFlags
+	flagSet := flag.NewFlagSet("deploy", flag.ContinueOnError)
+	connection.register(flagSet)
+	project := flagSet.String("file", "", "API project directory or its zip archive")
+	override := flagSet.Bool("override", false, "Update the API, if it is already deployed")
+	if err := flagSet.Parse(args); err != nil {
+		return exitCodeError
+	}
+	if *project == "" {
+		fmt.Fprintln(os.Stderr, "-file is required")
+		return exitCodeError
+	}
+	archive, err := readAPIProject(*project)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading the API project: %v\n", err)
+		return exitCodeError
+	}
+	adapter, err := connection.newClient()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitCodeError
+	}
+
+	if _, err := adapter.DeployAPI(context.Background(), archive, *override); err != nil {
+		fmt.Fprintf(os.Stderr, "Error deploying the API project: %v\n", err)
+		return exitCodeFailure
+	}
+	fmt.Printf("API project %s is deployed\n", *project)
+	return exitCodeSuccess
+}
+
+// runUndeploy undeploys an API from a running adapter, from all the environments unless the environments are given.
+func runUndeploy(args []string) int {
+	var connection connectionFlags
+	flagSet := flag.NewFlagSet("undeploy", flag.ContinueOnError)
+	connection.register(flagSet)
+	name := flagSet.String("name", "", "Name of the API")
+	version := flagSet.String("version", "", "Version of the API")
+	vhost := flagSet.String("vhost", "", "Virtual host of the API. The API is undeployed from all the vhosts if empty.")
+	environments := flagSet.String("environments", "", "Comma separated gateway environments to undeploy the API from")
+	if err := flagSet.Parse(args); err != nil {
+		return exitCodeError
+	}
+	if *name == "" || *version == "" {
+		fmt.Fprintln(os.Stderr, "-name and -version are required")
+		return exitCodeError
+	}
+	adapter, err := connection.newClient()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitCodeError
+	}
+
+	undeployReq := client.UndeployRequest{APIName: *name, Version: *version, Vhost: *vhost,
+		Environments: splitList(*environments)}
+	if _, err := adapter.UndeployAPI(context.Background(), undeployReq); err != nil {
+		fmt.Fprintf(os.Stderr, "Error undeploying the API %s:%s: %v\n", *name, *version, err)
+		return exitCodeFailure
+	}
+	fmt.Printf("API %s:%s is undeployed\n", *name, *version)
+	return exitCodeSuccess
+}
+
+// runList lists the APIs deployed in a running adapter.
+func runList(args []string) int {
+	var connection connectionFlags
+	flagSet := flag.NewFlagSet("list", flag.ContinueOnError)
+	connection.register(flagSet)
+	apiQuery := flagSet.String("query", "", "Query to filter the APIs (ex: type:http)")
+	limit := flagSet.Int64("limit", 0, "Maximum number of APIs to list. All the APIs are listed if 0.")
+	output := flagSet.String("output", "text", "Format of the list: text or json")
+	if err := flagSet.Parse(args); err != nil {
+		return exitCodeError
+	}
+	if *output != "text" && *output != "json" {
+		fmt.Fprintf(os.Stderr, "Unsupported output format %q\n", *output)
+		return exitCodeError
+	}
+	if *limit < 0 {
+		fmt.Fprintln(os.Stderr, "-limit should not be negative")
+		return exitCodeError
+	}
+	adapter, err := connection.newClient()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitCodeError
+	}
+
+	apis, err := adapter.ListAPIs(context.Background(), client.ListAPIsOptions{Query: *apiQuery, Limit: *limit})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error listing the APIs: %v\n", err)
+		return exitCodeFailure
+	}
+	if *output == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		encoder.Encode(apis)
+	} else {
+		printAPIs(os.Stdout, apis)
+	}
+	return exitCodeSuccess
+}
+
+func printAPIs(writer io.Writer, apis *client.APIList) {
+	tab := tabwriter.NewWriter(writer, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tab, "NAME\tVERSION\tTYPE\tCONTEXT\tVHOST\tENVIRONMENTS")
+	for _, api := range apis.List {
+		fmt.Fprintf(tab, "%s\t%s\t%s\t%s\t%s\t%s\n", api.APIName, api.Version, api.APIType, api.Context, api.Vhost,
+			strings.Join(api.GatewayEnvs, ","))
+	}
+	tab.Flush()
+	fmt.Fprintf(writer, "\n%d of %d APIs\n", apis.Count, apis.Total)
+}
+
+// readAPIProject reads the zip archive of an API project, archiving the project if it is a directory.
+func readAPIProject(path string) ([]byte, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return os.ReadFile(path)
+	}
+	return zipDirectory(path)
+}
+
+// zipDirectory archives a directory, with the directory as the root entry of the archive (as archived by apictl).
+func zipDirectory(dir string) ([]byte, error) {
+	var archive bytes.Buffer
+	writer := zip.NewWriter(&archive)
+	root := filepath.Dir(filepath.Clean(dir))
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		name, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		entry, err := writer.Create(filepath.ToSlash(name))
+		if err != nil {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		_, err = entry.Write(content)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return archive.Bytes(), nil
+}
+
+// splitList splits a comma separated list, ignoring the empty values.
+func splitList(list string) []string {
+	values := []string{}
+	for _, value := range strings.Split(list, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
@@ -0,0 +1,82 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/wso2/product-microgateway/adapter/pkg/client"
+)
+
+// Exit codes of the commands
+const (
+	exitCodeSuccess int = 0
+	exitCodeFailure int = 1
+	exitCodeError   int = 2
+)
+
+// connectionFlags are the flags of the commands to connect to the adapter, authenticated with basic or bearer
+// authentication, and with a client certificate if the adapter requires mutual TLS.
+type connectionFlags struct {
+	adapterURL string
+	username   string
+	token      string
+	caCertFile string
+	certFile   string
+	keyFile    string
+	insecure   bool
+	timeout    time.Duration
+}
+
+func (flags *connectionFlags) register(flagSet *flag.FlagSet) {
+	flagSet.StringVar(&flags.adapterURL, "adapter", "https://localhost:9843", "URL of the adapter REST API")
+	flagSet.StringVar(&flags.username, "username", "", "Username for basic authentication. The password is read "+
+		"from the environment variable ADAPTER_PASSWORD.")
+	flagSet.StringVar(&flags.token, "token", "", "Access token with the admin scope for bearer authentication")
+	flagSet.StringVar(&flags.caCertFile, "cacert", "", "PEM file of the CA certificates trusted for the adapter")
+	flagSet.StringVar(&flags.certFile, "cert", "", "PEM file of the client certificate for mutual TLS")
+	flagSet.StringVar(&flags.keyFile, "key", "", "PEM file of the private key of the client certificate")
+	flagSet.BoolVar(&flags.insecure, "insecure", false, "Skip verifying the certificate of the adapter")
+	flagSet.DurationVar(&flags.timeout, "timeout", 30*time.Second, "Timeout of the requests to the adapter")
+}
+
+// newClient creates the client of the adapter REST API for the flags.
+func (flags *connectionFlags) newClient() (*client.Client, error) {
+	if flags.username == "" && flags.token == "" {
+		return nil, fmt.Errorf("either -username or -token is required")
+	}
+	if (flags.certFile == "") != (flags.keyFile == "") {
+		return nil, fmt.Errorf("both -cert and -key are required for mutual TLS")
+	}
+	return client.NewClient(client.Config{
+		BaseURL:  flags.adapterURL,
+		Username: flags.username,
+		Password: os.Getenv("ADAPTER_PASSWORD"),
+		Token:    flags.token,
+		TLS: client.TLSConfig{
+			CACertFile:         flags.caCertFile,
+			CertFile:           flags.certFile,
+			KeyFile:            flags.keyFile,
+			InsecureSkipVerify: flags.insecure,
+		},
+		Timeout: flags.timeout,
+	})
+}
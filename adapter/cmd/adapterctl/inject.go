@@ -0,0 +1,95 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/wso2/product-microgateway/adapter/pkg/client"
+)
+
+// runInject injects the synthetic events of a file to a running adapter, which has the event injection enabled.
+// The events are injected repeatedly (in the order of the file) until the count is reached, at the given rate,
+// hence the command can be used to load test the processing of the events. The exit code is 1 if any event fails.
+func runInject(args []string) int {
+	var connection connectionFlags
+	flagSet := flag.NewFlagSet("inject", flag.ContinueOnError)
+	connection.register(flagSet)
+	eventsFile := flagSet.String("file", "", "JSON file with an array of the events ({\"type\": ..., \"event\": {...}})")
+	count := flagSet.Int("count", 0, "Number of events to inject. Defaults to the number of events in the file.")
+	rate := flagSet.Float64("rate", 0, "Events injected per second. The events are injected without a delay if 0.")
+	if err := flagSet.Parse(args); err != nil {
+		return exitCodeError
+	}
+	if *eventsFile == "" {
+		fmt.Fprintln(os.Stderr, "-file is required")
+		return exitCodeError
+	}
+	if *count < 0 || *rate < 0 {
+		fmt.Fprintln(os.Stderr, "-count and -rate should not be negative")
+		return exitCodeError
+	}
+	content, err := os.ReadFile(*eventsFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading the events: %v\n", err)
+		return exitCodeError
+	}
+	var events []client.SyntheticEvent
+	if err := json.Unmarshal(content, &events); err != nil || len(events) == 0 {
+		fmt.Fprintf(os.Stderr, "No events found in %s: %v\n", *eventsFile, err)
+		return exitCodeError
+	}
+	if *count == 0 {
+		*count = len(events)
+	}
+	adapter, err := connection.newClient()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitCodeError
+	}
+
+	var ticker *time.Ticker
+	if *rate > 0 {
+		ticker = time.NewTicker(time.Duration(float64(time.Second) / *rate))
+		defer ticker.Stop()
+	}
+	failures := 0
+	startedAt := time.Now()
+	for i := 0; i < *count; i++ {
+		if ticker != nil && i > 0 {
+			<-ticker.C
+		}
+		event := events[i%len(events)]
+		if _, err := adapter.InjectEvents(context.Background(), []client.SyntheticEvent{event}); err != nil {
+			fmt.Fprintf(os.Stderr, "Error injecting the event %d (%s): %v\n", i+1, event.Type, err)
+			failures++
+		}
+	}
+	elapsed := time.Since(startedAt)
+	fmt.Printf("Injected %d of %d events in %v (%.1f events/s)\n", *count-failures, *count,
+		elapsed.Round(time.Millisecond), float64(*count)/elapsed.Seconds())
+	if failures > 0 {
+		return exitCodeFailure
+	}
+	return exitCodeSuccess
+}
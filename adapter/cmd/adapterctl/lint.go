@@ -0,0 +1,97 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/wso2/product-microgateway/adapter/internal/configdump"
+	"github.com/wso2/product-microgateway/adapter/internal/lint"
+)
+
+// runLint pulls the configuration of a running adapter and lints it. The exit code is 1 if the score is below the
+// minimum score, hence the command can be used as a gate check.
+func runLint(args []string) int {
+	var connection connectionFlags
+	flagSet := flag.NewFlagSet("lint", flag.ContinueOnError)
+	connection.register(flagSet)
+	certExpiryDays := flagSet.Int("cert-expiry-days", 30, "Certificates expiring within these days are reported")
+	minScore := flagSet.Int("min-score", 0, "Minimum score (0-100) required to pass")
+	output := flagSet.String("output", "text", "Format of the report: text or json")
+	if err := flagSet.Parse(args); err != nil {
+		return exitCodeError
+	}
+	if *output != "text" && *output != "json" {
+		fmt.Fprintf(os.Stderr, "Unsupported output format %q\n", *output)
+		return exitCodeError
+	}
+	adapter, err := connection.newClient()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitCodeError
+	}
+	var dump configdump.ConfigDump
+	rawDump, err := adapter.GetConfigDump(context.Background())
+	if err == nil {
+		err = json.Unmarshal(rawDump, &dump)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error pulling the configuration from the adapter: %v\n", err)
+		return exitCodeError
+	}
+
+	report := lint.Lint(dump, lint.Options{
+		CertificateExpiryThreshold: time.Duration(*certExpiryDays) * 24 * time.Hour,
+	})
+	if *output == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		encoder.Encode(report)
+	} else {
+		printReport(os.Stdout, report)
+	}
+	if report.Score < *minScore {
+		fmt.Fprintf(os.Stderr, "Score %d is below the minimum score %d\n", report.Score, *minScore)
+		return exitCodeFailure
+	}
+	return exitCodeSuccess
+}
+
+func printReport(writer io.Writer, report lint.Report) {
+	if len(report.Findings) > 0 {
+		tab := tabwriter.NewWriter(writer, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tab, "SEVERITY\tRULE\tENVIRONMENT\tSUBJECT\tMESSAGE")
+		for _, finding := range report.Findings {
+			fmt.Fprintf(tab, "%s\t%s\t%s\t%s\t%s\n", finding.Severity, finding.Rule, finding.Environment,
+				finding.Subject, finding.Message)
+		}
+		tab.Flush()
+		fmt.Fprintln(writer)
+	}
+	fmt.Fprintf(writer, "Store: %d APIs, %d applications, %d subscriptions, %d key mappings, %d key managers\n",
+		report.Store.APIs, report.Store.Applications, report.Store.Subscriptions,
+		report.Store.ApplicationKeyMappings, report.Store.KeyManagers)
+	fmt.Fprintf(writer, "Score: %d/100 (%d errors, %d warnings)\n", report.Score, report.Errors, report.Warnings)
+}
@@ -0,0 +1,58 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+// Package main is the command line client of the adapter admin endpoints
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+const usage = `Usage: adapterctl <command> [flags]
+
+Commands:
+  lint      Lint the configuration generated by a running adapter
+  inject    Inject synthetic control plane events to a running adapter
+  deploy    Deploy an API project to a running adapter, which is not connected to a control plane
+  undeploy  Undeploy an API from a running adapter, which is not connected to a control plane
+  list      List the APIs deployed in a running adapter
+
+Run 'adapterctl <command> -h' for the flags of a command.
+`
+
+// commands of the CLI, which return the exit code
+var commands = map[string]func(args []string) int{
+	"lint":     runLint,
+	"inject":   runInject,
+	"deploy":   runDeploy,
+	"undeploy": runUndeploy,
+	"list":     runList,
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(exitCodeError)
+	}
+	command, found := commands[os.Args[1]]
+	if !found {
+		fmt.Fprintf(os.Stderr, "Unknown command %q\n\n%s", os.Args[1], usage)
+		os.Exit(exitCodeError)
+	}
+	os.Exit(command(os.Args[2:]))
+}
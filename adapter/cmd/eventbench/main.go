@@ -0,0 +1,86 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+// Package main replays a captured control plane event stream against the event handlers of the adapter, in
+// process, and reports the events per second, the allocations and the snapshot generation latency. The config is
+// read from MGW_HOME as by the adapter, and the profiling endpoints are served while replaying if enabled, hence
+// the CPU and the heap profiles of a replay can be collected as well. The events are replayed against an empty
+// datastore, as the process is not connected to a control plane.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/wso2/product-microgateway/adapter/config"
+	"github.com/wso2/product-microgateway/adapter/internal/messaging"
+	"github.com/wso2/product-microgateway/adapter/internal/profiling"
+)
+
+func main() {
+	eventsFile := flag.String("file", "", "Captured events, as a JSON array or JSON lines of {\"type\": ..., \"event\": {...}}")
+	count := flag.Int("count", 0, "Number of events to replay. Defaults to the number of events in the file.")
+	rate := flag.Float64("rate", 0, "Events replayed per second. The events are replayed without a delay if 0.")
+	labels := flag.String("labels", "", "Comma separated gateway environments, whose snapshots are generated after each event.")
+	jsonOutput := flag.Bool("json", false, "Print the report as JSON.")
+	flag.Parse()
+	if *eventsFile == "" {
+		fmt.Fprintln(os.Stderr, "-file is required")
+		os.Exit(1)
+	}
+	if *count < 0 || *rate < 0 {
+		fmt.Fprintln(os.Stderr, "-count and -rate should not be negative")
+		os.Exit(1)
+	}
+
+	conf, err := config.ReadConfigs()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading the config: %v\n", err)
+		os.Exit(1)
+	}
+	file, err := os.Open(*eventsFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading the events: %v\n", err)
+		os.Exit(1)
+	}
+	events, err := messaging.ReadCapturedEvents(file)
+	file.Close()
+	if err != nil || len(events) == 0 {
+		fmt.Fprintf(os.Stderr, "No events found in %s: %v\n", *eventsFile, err)
+		os.Exit(1)
+	}
+	options := messaging.ReplayOptions{Count: *count, Rate: *rate}
+	if *labels != "" {
+		options.Labels = strings.Split(*labels, ",")
+	}
+
+	messaging.LoadEmptyDatastore()
+	profiling.Start(conf)
+	report := messaging.ReplayEvents(events, options)
+	if *jsonOutput {
+		encoded, _ := json.MarshalIndent(report, "", "  ")
+		fmt.Println(string(encoded))
+	} else {
+		fmt.Print(report)
+	}
+	if report.Failures > 0 {
+		os.Exit(2)
+	}
+}
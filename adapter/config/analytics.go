@@ -0,0 +1,66 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Analytics types supported by the enforcer
+const (
+	AnalyticsTypeDefault = "Default"
+	AnalyticsTypeChoreo  = "Choreo"
+	AnalyticsTypeELK     = "ELK"
+)
+
+// Analytics configuration properties passed to the analytics publisher of the enforcer
+const (
+	AnalyticsAuthURLProperty         = "authURL"
+	AnalyticsAuthTokenProperty       = "authToken"
+	AnalyticsReporterClassProperty   = "publisher.reporter.class"
+	AnalyticsPublishIntervalProperty = "client.flushing.delay"
+)
+
+// Validate validates the analytics configuration distributed to the enforcer. The auth endpoint and the token of the
+// analytics backend are required, unless the events are published to ELK or using a custom reporter.
+// The $env{} values of the properties are resolved by the enforcer, hence those are not resolved here.
+func (analyticsConfig *analytics) Validate() error {
+	if !analyticsConfig.Enabled {
+		return nil
+	}
+	switch {
+	case strings.EqualFold(analyticsConfig.Type, AnalyticsTypeELK):
+		return nil
+	case strings.EqualFold(analyticsConfig.Type, AnalyticsTypeDefault),
+		strings.EqualFold(analyticsConfig.Type, AnalyticsTypeChoreo):
+	default:
+		return fmt.Errorf("analytics type %q is not supported", analyticsConfig.Type)
+	}
+	properties := analyticsConfig.Enforcer.ConfigProperties
+	if strings.TrimSpace(properties[AnalyticsReporterClassProperty]) != "" {
+		return nil
+	}
+	if strings.TrimSpace(properties[AnalyticsAuthURLProperty]) == "" ||
+		strings.TrimSpace(properties[AnalyticsAuthTokenProperty]) == "" {
+		return errors.New("analytics " + AnalyticsAuthURLProperty + " and " + AnalyticsAuthTokenProperty +
+			" are required to publish the analytics events")
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateAnalytics(t *testing.T) {
+	tests := []struct {
+		name             string
+		enabled          bool
+		analyticsType    string
+		configProperties map[string]string
+		message          string
+	}{
+		{
+			name:          "Disabled analytics",
+			enabled:       false,
+			analyticsType: "Unknown",
+		},
+		{
+			name:             "Choreo analytics",
+			enabled:          true,
+			analyticsType:    "choreo",
+			configProperties: map[string]string{"authURL": "https://analytics-event-auth.choreo.dev/auth/v1", "authToken": "token"},
+		},
+		{
+			name:             "Default analytics without auth token",
+			enabled:          true,
+			analyticsType:    AnalyticsTypeDefault,
+			configProperties: map[string]string{"authURL": "https://analytics-event-auth.choreo.dev/auth/v1"},
+			message:          "analytics authURL and authToken are required",
+		},
+		{
+			name:             "Default analytics with custom reporter",
+			enabled:          true,
+			analyticsType:    AnalyticsTypeDefault,
+			configProperties: map[string]string{"publisher.reporter.class": "org.wso2.sample.CustomReporter"},
+		},
+		{
+			name:          "ELK analytics",
+			enabled:       true,
+			analyticsType: AnalyticsTypeELK,
+		},
+		{
+			name:          "Unsupported analytics type",
+			enabled:       true,
+			analyticsType: "Kafka",
+			message:       "analytics type \"Kafka\" is not supported",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			analyticsConfig := &analytics{
+				Enabled: test.enabled,
+				Type:    test.analyticsType,
+				Enforcer: analyticsEnforcer{
+					ConfigProperties: test.configProperties,
+				},
+			}
+			err := analyticsConfig.Validate()
+			if test.message == "" {
+				assert.Nil(t, err)
+			} else if assert.NotNil(t, err) {
+				assert.Contains(t, err.Error(), test.message)
+			}
+		})
+	}
+}
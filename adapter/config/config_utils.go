@@ -18,9 +18,24 @@
 package config
 
 import (
+	"strings"
+
 	stringutils "github.com/wso2/product-microgateway/adapter/internal/utils"
 )
 
+// Categories of the control plane events, which are consumed selectively
+const (
+	// EventCategoryAPI is the API deployment, lifecycle, scope and analytics configuration events
+	EventCategoryAPI = "api"
+	// EventCategorySubscription is the application, subscription and throttle policy events, and the subscription
+	// data pulled from the control plane
+	EventCategorySubscription      = "subscription"
+	EventCategoryKeyManager        = "keyManager"
+	EventCategoryThrottleData      = "throttleData"
+	EventCategoryTokenRevocation   = "tokenRevocation"
+	EventCategoryOrganizationPurge = "organizationPurge"
+)
+
 // WireLogConfig is the config holder for wire logs
 type WireLogConfig struct {
 	LogBodyEnabled     bool
@@ -39,3 +54,18 @@ func GetWireLogConfig() *WireLogConfig {
 		LogTrailersEnabled: wireLogsEnabled && stringutils.StringInSlice("Trailers", includes),
 	}
 }
+
+// IsEventCategoryEnabled returns whether the events of the category are consumed from the control plane. All the
+// categories are consumed if none is configured.
+func IsEventCategoryEnabled(conf *Config, category string) bool {
+	categories := conf.ControlPlane.BrokerConnectionParameters.EventCategories
+	if len(categories) == 0 {
+		return true
+	}
+	for _, enabledCategory := range categories {
+		if strings.EqualFold(strings.TrimSpace(enabledCategory), category) {
+			return true
+		}
+	}
+	return false
+}
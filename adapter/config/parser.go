@@ -32,14 +32,19 @@ import (
 	logger "github.com/sirupsen/logrus"
 	pkgconf "github.com/wso2/product-microgateway/adapter/pkg/config"
 	"github.com/wso2/product-microgateway/adapter/pkg/logging"
+	"github.com/wso2/product-microgateway/adapter/pkg/secrets"
 )
 
 var (
 	onceConfigRead      sync.Once
 	onceGetDefaultVhost sync.Once
 	adapterConfig       *Config
-	defaultVhost        map[string]string
-	e                   error
+	// adapterConfigMutex guards the adapter configuration, which is replaced (not changed) by the updates at runtime
+	adapterConfigMutex sync.RWMutex
+	// configUpdateMutex serializes the updates, hence an update is not lost by another update of the same copy
+	configUpdateMutex sync.Mutex
+	defaultVhost      map[string]string
+	e                 error
 )
 
 // DefaultGatewayName represents the name of the default gateway
@@ -112,24 +117,53 @@ func ReadConfigs() (*Config, error) {
 				})
 			}
 		}
-		pkgconf.ResolveConfigEnvValues(reflect.ValueOf(&(adapterConfig.Adapter)).Elem(), "Adapter", true)
-		pkgconf.ResolveConfigEnvValues(reflect.ValueOf(&(adapterConfig.ControlPlane)).Elem(), "ControlPlane", true)
-		pkgconf.ResolveConfigEnvValues(reflect.ValueOf(&(adapterConfig.Envoy)).Elem(), "Router", true)
-		pkgconf.ResolveConfigEnvValues(reflect.ValueOf(&(adapterConfig.GlobalAdapter)).Elem(), "GlobalAdapter", true)
-		pkgconf.ResolveConfigEnvValues(reflect.ValueOf(&(adapterConfig.Enforcer)).Elem(), "Enforcer", false)
-		pkgconf.ResolveConfigEnvValues(reflect.ValueOf(&(adapterConfig.Analytics)).Elem(), "Analytics", false)
+		adapterConfig.resolveEnvValues()
+		manager, secretsErr := newSecretManager(adapterConfig)
+		if secretsErr == nil {
+			secrets.SetManager(manager)
+			secretsErr = adapterConfig.resolveSecretValues()
+		}
+		if secretsErr != nil {
+			loggerConfig.ErrorC(logging.ErrorDetails{
+				Message:   fmt.Sprintf("Error resolving the secrets of the configurations: %s", secretsErr.Error()),
+				Severity:  logging.BLOCKER,
+				ErrorCode: 1004,
+			})
+			// the adapter does not start with the unresolved references as the values of the configurations
+			e = secretsErr
+			return
+		}
+		appliedConfigContent = content
+		resolvedAppliedConfig, _ = parseConfig(content)
 	})
+	adapterConfigMutex.RLock()
+	defer adapterConfigMutex.RUnlock()
 	return adapterConfig, e
 }
 
 // SetConfig sets the given configuration to the adapter configuration
 func SetConfig(conf *Config) {
+	adapterConfigMutex.Lock()
+	defer adapterConfigMutex.Unlock()
 	adapterConfig = conf
 }
 
 // SetDefaultConfig sets the default configuration to the adapter configuration
 func SetDefaultConfig() {
-	adapterConfig = defaultConfig
+	SetConfig(defaultConfig)
+}
+
+// UpdateConfig applies the update to a copy of the adapter configuration, and replaces the adapter configuration
+// with the copy, which is returned. Hence the configuration read by the other goroutines is not changed while it is
+// used. The update should replace the slices and the maps of the configuration, instead of changing them.
+func UpdateConfig(update func(conf *Config)) *Config {
+	configUpdateMutex.Lock()
+	defer configUpdateMutex.Unlock()
+	current, _ := ReadConfigs()
+	updated := *current
+	update(&updated)
+	SetConfig(&updated)
+	return &updated
 }
 
 // GetDefaultVhost returns the default vhost of given environment read from Adapter
@@ -155,6 +189,27 @@ func GetDefaultVhost(environment string) (string, bool, error) {
 	return vhost, ok, err
 }
 
+// GetCustomDomains returns the custom domains (in lower case) of the vhosts of the given environment, by the vhost.
+// The configuration is read each time, as the custom domains are applied when the environment is updated.
+func GetCustomDomains(environment string) map[string][]string {
+	customDomains := make(map[string][]string)
+	configs, err := ReadConfigs()
+	if err != nil {
+		return customDomains
+	}
+	for _, customDomain := range configs.Adapter.CustomDomains {
+		if customDomain.Environment != environment {
+			continue
+		}
+		for _, domain := range customDomain.Domains {
+			if domain = strings.ToLower(strings.TrimSpace(domain)); domain != "" {
+				customDomains[customDomain.Vhost] = append(customDomains[customDomain.Vhost], domain)
+			}
+		}
+	}
+	return customDomains
+}
+
 // ReadLogConfigs implements adapter/proxy log-configuration read operation.The read operation will happen only once, hence
 // the consistancy is ensured.
 //
@@ -199,6 +254,16 @@ func GetControlPlaneConnectedTenantDomain() string {
 	return superTenantDomain
 }
 
+// resolveEnvValues replaces the configuration values with the values of the environment variables.
+func (config *Config) resolveEnvValues() {
+	pkgconf.ResolveConfigEnvValues(reflect.ValueOf(&(config.Adapter)).Elem(), "Adapter", true)
+	pkgconf.ResolveConfigEnvValues(reflect.ValueOf(&(config.ControlPlane)).Elem(), "ControlPlane", true)
+	pkgconf.ResolveConfigEnvValues(reflect.ValueOf(&(config.Envoy)).Elem(), "Router", true)
+	pkgconf.ResolveConfigEnvValues(reflect.ValueOf(&(config.GlobalAdapter)).Elem(), "GlobalAdapter", true)
+	pkgconf.ResolveConfigEnvValues(reflect.ValueOf(&(config.Enforcer)).Elem(), "Enforcer", false)
+	pkgconf.ResolveConfigEnvValues(reflect.ValueOf(&(config.Analytics)).Elem(), "Analytics", false)
+}
+
 func (config *Config) resolveDeprecatedProperties() {
 	if config.ControlPlane.ServiceURLDeprecated != UnassignedAsDeprecated {
 		printDeprecatedWarningLog("controlPlane.serviceUrl", "controlPlane.serviceURL")
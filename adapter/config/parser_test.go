@@ -0,0 +1,36 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCustomDomains(t *testing.T) {
+	conf, err := ReadConfigs()
+	assert.Nil(t, err)
+	customDomains := conf.Adapter.CustomDomains
+	defer func() { conf.Adapter.CustomDomains = customDomains }()
+	conf.Adapter.CustomDomains = []customDomain{
+		{Environment: "Default", Vhost: "localhost", Domains: []string{" API.Example.com", "*.Apps.example.com", ""}},
+		{Environment: "Staging", Vhost: "localhost", Domains: []string{"staging.example.com"}},
+	}
+	assert.Equal(t, map[string][]string{"localhost": {"api.example.com", "*.apps.example.com"}},
+		GetCustomDomains("Default"), "Custom domains should be trimmed and in lower case")
+}
@@ -0,0 +1,297 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+
+	toml "github.com/pelletier/go-toml"
+	pkgconf "github.com/wso2/product-microgateway/adapter/pkg/config"
+)
+
+// Sections of the adapter configuration applied at runtime, when the configuration file is changed.
+const (
+	SectionEventListeningEndpoints = "controlPlane.brokerConnectionParameters.eventListeningEndpoints"
+	SectionEnvironmentLabels       = "controlPlane.environmentLabels"
+	SectionAnalytics               = "analytics.enforcer"
+	SectionCustomDomains           = "adapter.customDomains"
+)
+
+const (
+	brokerAMQP            = "amqp"
+	brokerNATS            = "nats"
+	brokerAzureServiceBus = "azureServiceBus"
+)
+
+var (
+	// defaultConfigSnapshot is the default configuration, taken before the configuration file is applied to it.
+	defaultConfigSnapshot, _ = json.Marshal(defaultConfig)
+	// appliedConfigContent is the content of the configuration file applied to the adapter configuration.
+	appliedConfigContent []byte
+	// resolvedAppliedConfig is the configuration file applied, with the values of the secrets applied.
+	resolvedAppliedConfig *Config
+	configReloadMutex     sync.Mutex
+)
+
+// ConfigReload represents the changes of the configuration file, compared to the configuration file applied.
+type ConfigReload struct {
+	// Reloaded are the sections changed, which are applied to the adapter configuration
+	Reloaded []string
+	// RestartRequired are the sections changed, which are applied once the adapter is restarted
+	RestartRequired []string
+	// PreviousEnvironmentLabels are the environment labels configured before the reload
+	PreviousEnvironmentLabels []string
+}
+
+// GetConfigPath returns the location of the adapter configuration file.
+func GetConfigPath() string {
+	return pkgconf.GetMgwHome() + relativeConfigPath
+}
+
+// ReloadConfigs applies the reloadable sections of the configuration file to the adapter configuration, if the
+// file is changed since it is applied. The configuration is validated first, hence the adapter configuration is
+// unchanged if an error is returned.
+func ReloadConfigs() (*ConfigReload, error) {
+	var reload *ConfigReload
+	var err error
+	UpdateConfig(func(conf *Config) {
+		reload, err = reloadConfigs(conf, GetConfigPath())
+	})
+	return reload, err
+}
+
+func reloadConfigs(conf *Config, configPath string) (*ConfigReload, error) {
+	configReloadMutex.Lock()
+	defer configReloadMutex.Unlock()
+	content, err := ioutil.ReadFile(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading configurations: %v", err)
+	}
+	if bytes.Equal(content, appliedConfigContent) {
+		return &ConfigReload{}, nil
+	}
+	updatedConfig, err := parseConfig(content)
+	if err != nil {
+		return nil, err
+	}
+	if err = validateReloadableConfig(updatedConfig); err != nil {
+		return nil, err
+	}
+	appliedConfig, err := parseConfig(appliedConfigContent)
+	if err != nil {
+		return nil, err
+	}
+	reload := applyReloadableSections(conf, appliedConfig, updatedConfig)
+	appliedConfigContent = content
+	resolvedAppliedConfig = updatedConfig
+	return reload, nil
+}
+
+// ReloadSecrets applies the secrets rotated in the secret stores to the reloadable sections of the configuration,
+// the same way the changes of the configuration file are applied. The configuration is validated first, hence the
+// adapter configuration is unchanged if an error is returned.
+func ReloadSecrets() (*ConfigReload, error) {
+	var reload *ConfigReload
+	var err error
+	UpdateConfig(func(conf *Config) {
+		reload, err = reloadSecrets(conf)
+	})
+	return reload, err
+}
+
+func reloadSecrets(conf *Config) (*ConfigReload, error) {
+	configReloadMutex.Lock()
+	defer configReloadMutex.Unlock()
+	if resolvedAppliedConfig == nil {
+		return &ConfigReload{}, nil
+	}
+	// the secrets are resolved with the rotated values, as those are already refreshed
+	updatedConfig, err := parseConfig(appliedConfigContent)
+	if err != nil {
+		return nil, err
+	}
+	if err = validateReloadableConfig(updatedConfig); err != nil {
+		return nil, err
+	}
+	reload := applyReloadableSections(conf, resolvedAppliedConfig, updatedConfig)
+	resolvedAppliedConfig = updatedConfig
+	return reload, nil
+}
+
+// applyReloadableSections applies the reloadable sections changed between the applied and the updated
+// configurations to a copy of the adapter configuration.
+func applyReloadableSections(conf, appliedConfig, updatedConfig *Config) *ConfigReload {
+	reload := &ConfigReload{
+		RestartRequired:           getChangedSections(appliedConfig, updatedConfig),
+		PreviousEnvironmentLabels: conf.ControlPlane.EnvironmentLabels,
+	}
+	if !reflect.DeepEqual(appliedConfig.ControlPlane.EnvironmentLabels, updatedConfig.ControlPlane.EnvironmentLabels) {
+		conf.ControlPlane.EnvironmentLabels = updatedConfig.ControlPlane.EnvironmentLabels
+		reload.Reloaded = append(reload.Reloaded, SectionEnvironmentLabels)
+	}
+	appliedEndpoints := appliedConfig.ControlPlane.BrokerConnectionParameters.EventListeningEndpoints
+	updatedEndpoints := updatedConfig.ControlPlane.BrokerConnectionParameters.EventListeningEndpoints
+	if !reflect.DeepEqual(appliedEndpoints, updatedEndpoints) {
+		// the consumers are reconnected to the endpoints of the same broker, while the other brokers are
+		// connected at the startup
+		if getBrokerType(appliedEndpoints) == brokerAzureServiceBus ||
+			getBrokerType(appliedEndpoints) != getBrokerType(updatedEndpoints) {
+			reload.RestartRequired = append(reload.RestartRequired, SectionEventListeningEndpoints)
+		} else {
+			conf.ControlPlane.BrokerConnectionParameters.EventListeningEndpoints = updatedEndpoints
+			reload.Reloaded = append(reload.Reloaded, SectionEventListeningEndpoints)
+		}
+	}
+	if !reflect.DeepEqual(getReloadableAnalytics(appliedConfig), getReloadableAnalytics(updatedConfig)) {
+		conf.Analytics.Type = updatedConfig.Analytics.Type
+		conf.Analytics.Enforcer.ConfigProperties = updatedConfig.Analytics.Enforcer.ConfigProperties
+		conf.Analytics.Enforcer.PublishIntervalInSeconds = updatedConfig.Analytics.Enforcer.PublishIntervalInSeconds
+		reload.Reloaded = append(reload.Reloaded, SectionAnalytics)
+	}
+	if !reflect.DeepEqual(appliedConfig.Adapter.CustomDomains, updatedConfig.Adapter.CustomDomains) {
+		conf.Adapter.CustomDomains = updatedConfig.Adapter.CustomDomains
+		reload.Reloaded = append(reload.Reloaded, SectionCustomDomains)
+	}
+	return reload
+}
+
+// parseConfig parses the content of a configuration file on top of the default configuration. The deprecated
+// properties are not resolved, as those are not reloadable.
+func parseConfig(content []byte) (*Config, error) {
+	conf := &Config{}
+	if err := json.Unmarshal(defaultConfigSnapshot, conf); err != nil {
+		return nil, fmt.Errorf("error copying the default configurations: %v", err)
+	}
+	if err := toml.Unmarshal(content, conf); err != nil {
+		return nil, fmt.Errorf("error parsing the configurations: %v", err)
+	}
+	conf.resolveEnvValues()
+	if err := conf.resolveSecretValues(); err != nil {
+		return nil, fmt.Errorf("error resolving the secrets of the configurations: %v", err)
+	}
+	return conf, nil
+}
+
+// validateReloadableConfig validates the sections of the configuration applied at runtime.
+func validateReloadableConfig(conf *Config) error {
+	if err := conf.Analytics.Validate(); err != nil {
+		return err
+	}
+	labels := make(map[string]struct{}, len(conf.ControlPlane.EnvironmentLabels))
+	for _, label := range conf.ControlPlane.EnvironmentLabels {
+		if strings.TrimSpace(label) == "" {
+			return errors.New("environment labels cannot be empty")
+		}
+		if _, found := labels[label]; found {
+			return fmt.Errorf("environment label %q is duplicated", label)
+		}
+		labels[label] = struct{}{}
+	}
+
+	if !conf.ControlPlane.Enabled || conf.ControlPlane.Webhook.Enabled {
+		return nil
+	}
+	endpoints := conf.ControlPlane.BrokerConnectionParameters.EventListeningEndpoints
+	if len(endpoints) == 0 {
+		return errors.New("event listening endpoints are not configured")
+	}
+	brokerType := getBrokerType(endpoints)
+	for _, endpoint := range endpoints {
+		if getBrokerType([]string{endpoint}) != brokerType {
+			return fmt.Errorf("event listening endpoints of the brokers %s and %s cannot be combined",
+				brokerType, getBrokerType([]string{endpoint}))
+		}
+		if brokerType == brokerAzureServiceBus {
+			continue
+		}
+		if _, err := url.Parse(endpoint); err != nil {
+			return fmt.Errorf("invalid event listening endpoint. %v", err)
+		}
+	}
+	return nil
+}
+
+// getBrokerType returns the type of the broker of the event listening endpoints, the same way the event source is
+// selected at the startup.
+func getBrokerType(endpoints []string) string {
+	if len(endpoints) == 0 {
+		return ""
+	}
+	if strings.Contains(endpoints[0], brokerAMQP) {
+		return brokerAMQP
+	}
+	if strings.HasPrefix(endpoints[0], brokerNATS) {
+		return brokerNATS
+	}
+	return brokerAzureServiceBus
+}
+
+// getChangedSections returns the top level sections (ex: router) changed between the configurations, which are
+// applied once the adapter is restarted.
+func getChangedSections(appliedConfig, updatedConfig *Config) []string {
+	// the reloadable properties are compared separately
+	appliedControlPlane, updatedControlPlane := appliedConfig.ControlPlane, updatedConfig.ControlPlane
+	appliedControlPlane.EnvironmentLabels, updatedControlPlane.EnvironmentLabels = nil, nil
+	appliedControlPlane.BrokerConnectionParameters.EventListeningEndpoints = nil
+	updatedControlPlane.BrokerConnectionParameters.EventListeningEndpoints = nil
+	appliedAdapter, updatedAdapter := appliedConfig.Adapter, updatedConfig.Adapter
+	appliedAdapter.CustomDomains, updatedAdapter.CustomDomains = nil, nil
+	appliedAnalytics, updatedAnalytics := appliedConfig.Analytics, updatedConfig.Analytics
+	appliedAnalytics.Type, updatedAnalytics.Type = "", ""
+	appliedAnalytics.Enforcer.ConfigProperties, updatedAnalytics.Enforcer.ConfigProperties = nil, nil
+	appliedAnalytics.Enforcer.PublishIntervalInSeconds, updatedAnalytics.Enforcer.PublishIntervalInSeconds = 0, 0
+
+	var sections []string
+	applied, updated := reflect.ValueOf(*appliedConfig), reflect.ValueOf(*updatedConfig)
+	for i := 0; i < applied.NumField(); i++ {
+		field := applied.Type().Field(i)
+		changed := !reflect.DeepEqual(applied.Field(i).Interface(), updated.Field(i).Interface())
+		switch field.Name {
+		case "Adapter":
+			changed = !reflect.DeepEqual(appliedAdapter, updatedAdapter)
+		case "ControlPlane":
+			changed = !reflect.DeepEqual(appliedControlPlane, updatedControlPlane)
+		case "Analytics":
+			changed = !reflect.DeepEqual(appliedAnalytics, updatedAnalytics)
+		}
+		if !changed {
+			continue
+		}
+		section := field.Tag.Get("toml")
+		if section == "" {
+			section = strings.ToLower(field.Name[:1]) + field.Name[1:]
+		}
+		sections = append(sections, section)
+	}
+	return sections
+}
+
+// getReloadableAnalytics returns the analytics configurations distributed to the enforcer at runtime. Enabling the
+// analytics changes the access log configuration of the router, hence it is applied once the adapter is restarted.
+func getReloadableAnalytics(conf *Config) []interface{} {
+	return []interface{}{conf.Analytics.Type, conf.Analytics.Enforcer.ConfigProperties,
+		conf.Analytics.Enforcer.PublishIntervalInSeconds}
+}
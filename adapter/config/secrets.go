@@ -0,0 +1,123 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package config
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/wso2/product-microgateway/adapter/pkg/secrets"
+)
+
+// newSecretManager creates the secret manager with the providers of the secret stores enabled, or returns nil if
+// none is enabled.
+func newSecretManager(conf *Config) (*secrets.Manager, error) {
+	secretsConf := conf.Adapter.Secrets
+	if !secretsConf.Vault.Enabled && !secretsConf.Kubernetes.Enabled && !secretsConf.AWS.Enabled {
+		return nil, nil
+	}
+	manager := secrets.NewManager(secretsConf.DefaultProvider)
+	if vault := secretsConf.Vault; vault.Enabled {
+		provider, err := secrets.NewVaultProvider(vault.Address, vault.Token, vault.TokenFile, vault.Namespace,
+			vault.KVVersion, vault.CACertFile)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Vault secret store configuration: %v", err)
+		}
+		manager.RegisterProvider(secrets.ProviderVault, provider)
+	}
+	if kubernetes := secretsConf.Kubernetes; kubernetes.Enabled {
+		provider, err := secrets.NewKubernetesProvider(kubernetes.APIServerURL, kubernetes.Namespace,
+			kubernetes.TokenFile, kubernetes.CACertFile)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Kubernetes secret store configuration: %v", err)
+		}
+		manager.RegisterProvider(secrets.ProviderKubernetes, provider)
+	}
+	if aws := secretsConf.AWS; aws.Enabled {
+		provider, err := secrets.NewAWSProvider(aws.Region, aws.Endpoint, aws.AccessKeyID, aws.SecretAccessKey,
+			aws.SessionToken)
+		if err != nil {
+			return nil, fmt.Errorf("invalid AWS secret store configuration: %v", err)
+		}
+		manager.RegisterProvider(secrets.ProviderAWS, provider)
+	}
+	return manager, nil
+}
+
+// resolveSecretValues replaces the $secret{} references of the string values of the configuration with the values
+// of the secrets. All the references are resolved, even if some of them fail, and the first error is returned.
+func (config *Config) resolveSecretValues() error {
+	return resolveSecretsOfValue(reflect.ValueOf(config).Elem())
+}
+
+func resolveSecretsOfValue(value reflect.Value) error {
+	var firstErr error
+	keepFirstErr := func(err error) {
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	switch value.Kind() {
+	case reflect.String:
+		if !secrets.HasReference(value.String()) || !value.CanSet() {
+			return nil
+		}
+		resolved, err := secrets.Resolve(value.String())
+		if err != nil {
+			return err
+		}
+		value.SetString(resolved)
+	case reflect.Struct:
+		for i := 0; i < value.NumField(); i++ {
+			if value.Type().Field(i).PkgPath != "" {
+				continue
+			}
+			if err := resolveSecretsOfValue(value.Field(i)); err != nil {
+				keepFirstErr(err)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			if err := resolveSecretsOfValue(value.Index(i)); err != nil {
+				keepFirstErr(err)
+			}
+		}
+	case reflect.Map:
+		// the map values are not addressable, hence only the string values are resolved
+		if value.Type().Elem().Kind() != reflect.String {
+			return nil
+		}
+		for _, key := range value.MapKeys() {
+			mapValue := value.MapIndex(key).String()
+			if !secrets.HasReference(mapValue) {
+				continue
+			}
+			resolved, err := secrets.Resolve(mapValue)
+			if err != nil {
+				keepFirstErr(err)
+				continue
+			}
+			value.SetMapIndex(key, reflect.ValueOf(resolved).Convert(value.Type().Elem()))
+		}
+	case reflect.Ptr:
+		if !value.IsNil() {
+			return resolveSecretsOfValue(value.Elem())
+		}
+	}
+	return firstErr
+}
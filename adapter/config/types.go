@@ -87,8 +87,12 @@ type adapter struct {
 	Server server
 	// VhostMapping represents default vhost of gateway environments
 	VhostMapping []vhostMapping
+	// CustomDomains represents the additional hostnames, on which the APIs of a vhost are exposed
+	CustomDomains []customDomain
 	// Consul represents the configuration required to connect to consul service discovery
 	Consul consul
+	// Eureka represents the configuration required to connect to eureka service discovery
+	Eureka eureka
 	// Keystore contains the keyFile and Cert File of the adapter
 	Keystore keystore
 	// Trusted Certificates
@@ -97,10 +101,219 @@ type adapter struct {
 	ArtifactsDirectory string
 	// SoapErrorInXMLEnabled is used to configure gateway error responses(local reply) as soap envelope
 	SoapErrorInXMLEnabled bool
+	// ArtifactsWatcher represents the configuration related to watching the artifacts directory for changes
+	ArtifactsWatcher artifactsWatcher
 	// SourceControl represents the configuration related to the repository where the api artifacts are stored
 	SourceControl sourceControl
+	// Operator represents the configuration related to deploying the APIs of the Kubernetes custom resources
+	Operator operator
 	// Metric represents configurations to expose/export go metrics
 	Metrics metrics
+	// RevisionTrafficSplit represents the canary configuration used when a new revision of a deployed API arrives
+	RevisionTrafficSplit revisionTrafficSplit
+	// Audit represents the configuration related to the API configuration change journal
+	Audit audit
+	// Jobs represents the configuration related to the admin operations run asynchronously
+	Jobs jobs
+	// Standby represents the configuration of a disaster recovery site, which serves the APIs only when activated
+	Standby standby
+	// Shutdown represents the configuration related to draining the connections when the adapter is stopped
+	Shutdown shutdown
+	// SyntheticMonitoring represents the probes invoking the health resources of the APIs through the router
+	SyntheticMonitoring syntheticMonitoring
+	// Compaction represents the periodic removal of the stale entries from the in-memory stores and the audit
+	// journal, of long-running adapters
+	Compaction compaction
+	// ConfigReload represents the configuration related to applying the changes of the configuration file at runtime
+	ConfigReload configReload
+	// APITokenValidation represents the token issuers and audiences accepted by the APIs, if the API definition
+	// does not restrict them
+	APITokenValidation []APITokenValidation
+	// Admission represents the checks applied to the API projects, before the APIs are deployed
+	Admission admission
+	// CapabilityCheck represents the handling of the API projects using features not supported by the gateway
+	CapabilityCheck capabilityCheck
+	// XdsBatching coalesces the changes of the APIs within a window into a single update of the router and the
+	// enforcer resources
+	XdsBatching xdsBatching
+	// UndeployDrain keeps the routes of the APIs removed from the gateway by the control plane, until the requests
+	// in progress complete
+	UndeployDrain undeployDrain
+	// EventInjection accepts synthetic control plane events via the REST API, to test the processing of the events
+	// without a message broker
+	EventInjection eventInjection
+	// Profiling exposes the pprof and the expvar endpoints, which report the runtime profiles and the event processing
+	// and the snapshot generation stats of the adapter
+	Profiling profiling
+	// HA represents running multiple adapters, where only the elected leader consumes the control plane events
+	HA ha
+	// RedisStore shares the applications, the subscriptions and the application key mappings among the adapters
+	// via a Redis server
+	RedisStore redisStore
+	// BotDetection tracks the authentication failures and the throttled out requests of the clients, reported by the
+	// router, and emits bot detection events for the clients exceeding the thresholds
+	BotDetection botDetection
+	// ResourceStores bounds the number of applications, subscriptions and application key mappings kept in memory
+	ResourceStores resourceStores
+	// QuotaSync aggregates the throttle counters reported by the enforcer replicas of a gateway, and distributes the
+	// aggregated counters back to the enforcers
+	QuotaSync quotaSync
+	// Secrets represents the secret stores, from which the $secret{provider:path#key} references of the
+	// configuration and the endpoint credentials of the API artifacts are resolved
+	Secrets secretStores
+}
+
+type resourceStores struct {
+	// MaxApplications, MaxSubscriptions and MaxKeyMappings are the entries kept in memory per store, beyond which
+	// the least recently used entries are evicted. The stores are unbounded if 0.
+	MaxApplications  int
+	MaxSubscriptions int
+	MaxKeyMappings   int
+	// SpillDirectory keeps the evicted entries on disk, which are read back on a lookup and for the enforcers. The
+	// stores are unbounded if empty.
+	SpillDirectory string
+}
+
+type secretStores struct {
+	// DefaultProvider (vault, kubernetes or aws) resolves the references which do not name a provider
+	DefaultProvider string
+	// RefreshIntervalInSeconds is the time between fetching the resolved secrets again, to apply the secrets rotated
+	// in the secret stores. The secrets are not refreshed if 0.
+	RefreshIntervalInSeconds int
+	// ArtifactSecretPaths are the paths (provider:path) of the secrets, which the endpoint credentials of the API
+	// artifacts may refer along with the secrets under those. The API artifacts can not refer any secret if empty.
+	ArtifactSecretPaths []string
+	Vault               vaultSecretStore
+	Kubernetes          kubernetesSecretStore
+	AWS                 awsSecretStore
+}
+
+type vaultSecretStore struct {
+	Enabled bool
+	Address string
+	// Token authenticates the adapter with Vault. The token is read from TokenFile instead, if it is set (ex: the
+	// token sink of the Vault agent).
+	Token     string
+	TokenFile string
+	Namespace string
+	// KVVersion is the version (1 or 2) of the KV secrets engine
+	KVVersion  int
+	CACertFile string
+}
+
+type kubernetesSecretStore struct {
+	Enabled bool
+	// APIServerURL, Namespace, TokenFile and CACertFile default to the service account of the pod, if empty
+	APIServerURL string
+	Namespace    string
+	TokenFile    string
+	CACertFile   string
+}
+
+type awsSecretStore struct {
+	Enabled bool
+	Region  string
+	// Endpoint defaults to the regional endpoint of AWS Secrets Manager, if empty
+	Endpoint string
+	// AccessKeyID, SecretAccessKey and SessionToken default to the AWS credentials of the environment, if empty
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+}
+
+type xdsBatching struct {
+	Enabled bool
+	// WindowInMilliseconds is the time the changes are coalesced, since the first change of a batch
+	WindowInMilliseconds int
+}
+
+type ha struct {
+	Enabled bool
+	// LeaseName is the Kubernetes lease (coordination.k8s.io/v1) held by the leader
+	LeaseName string
+	// Namespace of the lease
+	Namespace string
+	// Identity of the adapter in the election. Defaults to the hostname (ie: the pod name), if empty.
+	Identity string
+	// APIServerURL is the URL of the Kubernetes API server
+	APIServerURL string
+	// TokenFile is the service account token authenticating with the Kubernetes API server
+	TokenFile string
+	// CACertFile is the certificate of the Kubernetes API server
+	CACertFile string
+	// LeaseDurationInSeconds is the time the followers wait since the last renewal, before acquiring the lease
+	LeaseDurationInSeconds int
+	// RetryPeriodInSeconds is the interval the leader renews the lease, and the followers try to acquire the lease
+	RetryPeriodInSeconds int
+	// FollowerSyncIntervalInSeconds is the interval the followers pull the APIs and the subscriptions from the
+	// control plane
+	FollowerSyncIntervalInSeconds int
+}
+
+type botDetection struct {
+	Enabled bool
+	// WindowInSeconds is the sliding window the failures of a client are counted in
+	WindowInSeconds int
+	// AuthFailureThreshold is the number of authentication failures (401 and 403) of a client within the window,
+	// which emits an event. Disabled if zero.
+	AuthFailureThreshold int
+	// ThrottleOutThreshold is the number of throttled out requests (429) of a client within the window, which emits an
+	// event. Disabled if zero.
+	ThrottleOutThreshold int
+	// CooldownInSeconds is the time an event is not emitted again for the same client and the same failure
+	CooldownInSeconds int
+	// MaxTrackedClients bounds the number of clients tracked. The least recently seen clients are evicted.
+	MaxTrackedClients int
+	// MaxEvents is the number of the latest events kept for the admin API
+	MaxEvents int
+	// Topic of the control plane broker the events are published to. Not published if empty.
+	Topic string
+}
+
+type quotaSync struct {
+	Enabled bool
+	// PublishIntervalInMillis is the time between distributing the aggregated counters changed to the enforcers
+	PublishIntervalInMillis int
+	// MaxCounters bounds the number of counters aggregated per gateway. The counters of the new keys are not
+	// aggregated beyond the limit, until the windows of the existing counters end.
+	MaxCounters int
+	// MaxLabels bounds the number of gateway labels aggregated. The enforcers of the new labels are rejected beyond
+	// the limit, until the enforcers of an existing label are disconnected and the windows of its counters end.
+	MaxLabels int
+}
+
+type redisStore struct {
+	Enabled bool
+	// Address (host:port) of the Redis server
+	Address  string
+	Username string
+	Password string
+	Database int
+	// TLS connects to the Redis server over TLS, verifying the certificate with the adapter truststore
+	TLS bool
+	// KeyPrefix is prepended to the keys and the channel, hence a Redis server can be shared by multiple deployments
+	KeyPrefix string
+	// TimeoutInSeconds is the timeout of connecting and of each command
+	TimeoutInSeconds int
+	// ReconnectIntervalInSeconds is the time waited before reconnecting, once the connection is lost
+	ReconnectIntervalInSeconds int
+}
+
+type eventInjection struct {
+	Enabled bool
+}
+
+type profiling struct {
+	Enabled bool
+	Host    string
+	Port    int32
+}
+
+type undeployDrain struct {
+	Enabled bool
+	// PeriodInSeconds is the time the routes of an undeployed API are kept, responding with the Deprecation and
+	// the Sunset headers
+	PeriodInSeconds int
 }
 
 // Envoy Listener Component related configurations.
@@ -119,9 +332,17 @@ type envoy struct {
 	Downstream                       envoyDownstream
 	Connection                       connection
 	PayloadPassingToEnforcer         payloadPassingToEnforcer
+	PayloadLimits                    payloadLimits
 	AwsLambda                        awsLambda
 	UseRemoteAddress                 bool
 	Filters                          filters
+	APIDocs                          apiDocs
+	LocalRateLimit                   localRateLimit
+	GlobalRateLimit                  globalRateLimit
+	RateLimitHeaders                 rateLimitHeaders
+	GlobalPolicies                   globalPolicies
+	APIVersionStats                  apiVersionStats
+	IPRestrictions                   []ipRestriction
 }
 
 type connectionTimeouts struct {
@@ -131,8 +352,118 @@ type connectionTimeouts struct {
 	IdleTimeoutInSeconds           time.Duration // default 1hr
 }
 
+type apiDocs struct {
+	Enabled bool
+	// Protected secures the docs with the security of the API, if true
+	Protected bool
+	// MaxSizeInBytes is the maximum size of a doc served by the router
+	MaxSizeInBytes uint32
+}
+
+// localRateLimit translates the request count limits of the application and subscription policies to the
+// envoy local rate limits, hence the limits are enforced at the router without the enforcer.
+type localRateLimit struct {
+	Enabled bool
+	// Exemptions are the trusted clients, which are not rate limited. These can be updated via the admin API.
+	Exemptions []rateLimitExemption
+	// ExemptionsFilePath is the file, where the exemptions updated via the admin API are persisted. The persisted
+	// exemptions replace the exemptions of the config at startup. Not persisted if empty.
+	ExemptionsFilePath string
+}
+
+// globalRateLimit enforces the request count limits of the application and subscription policies using an envoy
+// rate limit service, hence the limits are shared by all the routers. The exemptions of the local rate limits are
+// applied to the global rate limits as well.
+type globalRateLimit struct {
+	Enabled bool
+	// Domain of the rate limit service config generated by the adapter
+	Domain string
+	// Host and Port of the gRPC server of the rate limit service
+	Host string
+	Port uint32
+	// FailureModeDeny denies the requests if the rate limit service is unavailable
+	FailureModeDeny        bool
+	RequestTimeoutInMillis uint32
+	// ConfigFilePath is the file where the adapter writes the config of the rate limit service. The file should be
+	// in the runtime directory of the rate limit service, hence the updates are loaded by the service.
+	ConfigFilePath string
+}
+
+// rateLimitHeaders adds the limit, the remaining requests and the reset time of the local or global rate limit
+// applied to a request as response headers, hence the clients can back off before being rate limited.
+type rateLimitHeaders struct {
+	Enabled bool
+	// Format is rateLimit (RateLimit-Limit, RateLimit-Remaining and RateLimit-Reset headers of the IETF draft) or
+	// xRateLimit (X-RateLimit-Limit, X-RateLimit-Remaining and X-RateLimit-Reset headers)
+	Format string
+}
+
+type rateLimitExemption struct {
+	// Type is one of consumerKey, applicationId, applicationAttribute or sourceCIDR
+	Type string
+	// Value of an applicationAttribute exemption is the name and the value of the attribute (ie: name=value)
+	Value string
+}
+
+// apiVersionStats enables the envoy route statistics per API version, which shows the usage of each version.
+type apiVersionStats struct {
+	Enabled bool
+}
+
+// ipRestriction restricts the source IPs of the requests to an API, in addition to the x-wso2-ip-restriction
+// extension of the API. The requests from the other sources are denied by the router.
+type ipRestriction struct {
+	// Context is the basepath of the API (ex: /pizzashack/1.0.0)
+	Context string
+	// Allow is the list of CIDRs (or IPs) allowed to invoke the API. All the sources are allowed if empty.
+	Allow []string
+	// Deny is the list of CIDRs (or IPs) denied, which takes precedence over the allowed CIDRs
+	Deny []string
+}
+
+// globalPolicies are applied to the routes of all the APIs, prior to the operation policies of the APIs. An API
+// opts out of the global policies using the x-wso2-disable-global-policies extension.
+type globalPolicies struct {
+	Request  []GlobalPolicy
+	Response []GlobalPolicy
+}
+
+// GlobalPolicy represents a policy applied to all the APIs.
+type GlobalPolicy struct {
+	// Name is used to opt out of the policy
+	Name string
+	// Action is one of SET_HEADER or REMOVE_HEADER. The logging and the WAF policies are not supported.
+	Action     string
+	Parameters map[string]string
+}
+
 type filters struct {
-	Compression compression
+	Compression     compression
+	GRPCTranscoding grpcTranscoding
+	ResponseCache   responseCache
+}
+
+// responseCache configures the cache filter, which serves the responses of the GET requests from an in-memory cache
+type responseCache struct {
+	// Enabled adds the cache filter, which caches the responses as allowed by the Cache-Control header of the
+	// responses. The resources with the x-wso2-response-cache extension are cached for the TTL of the extension.
+	Enabled bool
+	// DefaultTTLInSeconds is applied to the resources not specifying a TTL
+	DefaultTTLInSeconds uint32
+	// AllowedVaryHeaders are the request headers the responses could vary by (case insensitive). The responses
+	// varying by the other headers are not cached.
+	AllowedVaryHeaders []string
+}
+
+// grpcTranscoding configures the filters letting the REST and gRPC-Web clients call the gRPC backends
+type grpcTranscoding struct {
+	// Enabled adds the gRPC-JSON transcoder filter, which is applied to the APIs with the x-wso2-grpc-transcoding
+	// extension
+	Enabled bool
+	// GRPCWebEnabled adds the gRPC-Web filter, which translates the gRPC-Web requests to gRPC
+	GRPCWebEnabled             bool
+	AlwaysPrintPrimitiveFields bool
+	PreserveProtoFieldNames    bool
 }
 
 type compression struct {
@@ -141,6 +472,19 @@ type compression struct {
 	RequestDirection  requestDirection
 	ResponseDirection responseDirection
 	LibraryProperties map[string]interface{}
+	// Profiles are the response compression configurations selected by the APIs
+	Profiles []CompressionProfile
+}
+
+// CompressionProfile is a response compression configuration, applied to the APIs selecting the profile
+type CompressionProfile struct {
+	Name    string
+	Library string
+	// CompressionLevel is the gzip compression level (1 to 9) or the brotli quality (0 to 11).
+	// The value 0 applies the level of the library properties.
+	CompressionLevel     uint32
+	MinimumContentLength int
+	ContentType          []string
 }
 
 type requestDirection struct {
@@ -188,6 +532,18 @@ type server struct {
 	TokenPrivateKeyPath string
 }
 
+// APITokenValidation represents the token issuers and audiences accepted by an API.
+type APITokenValidation struct {
+	// APIName is the name of the API
+	APIName string
+	// APIVersion is the version of the API. All the versions of the API are matched if empty.
+	APIVersion string
+	// Issuers accepted by the API. Should be issuers configured under the enforcer token service.
+	Issuers []string
+	// Audiences accepted by the API
+	Audiences []string
+}
+
 type vhostMapping struct {
 	// Environment name of the gateway
 	Environment string
@@ -195,6 +551,15 @@ type vhostMapping struct {
 	Vhost string
 }
 
+type customDomain struct {
+	// Environment name of the gateway
+	Environment string
+	// Vhost of the APIs exposed on the domains
+	Vhost string
+	// Domains are the hostnames served in addition to the vhost. A domain may start with a wildcard (*.foo.com).
+	Domains []string
+}
+
 type consul struct {
 	// Deprecated: Use Enabled instead
 	Enable bool
@@ -218,6 +583,27 @@ type consul struct {
 	KeyFile string
 }
 
+type eureka struct {
+	// Enabled whether eureka service discovery should be enabled
+	Enabled bool
+	// URL url of the eureka server REST API in format: http(s)://host:port/eureka
+	URL string
+	// PollInterval how frequently the eureka server should be polled to get updates (in seconds)
+	PollInterval int
+	// Username used for the basic authentication with the eureka server, if required
+	Username string
+	// Password used for the basic authentication with the eureka server
+	Password string
+}
+
+type artifactsWatcher struct {
+	// Enabled deploys, redeploys and undeploys the APIs when the api artifacts in the artifacts directory are added,
+	// changed and removed
+	Enabled bool
+	// DebounceInMillis is the time to wait for further changes of the artifacts before applying them
+	DebounceInMillis int
+}
+
 type sourceControl struct {
 	// Enabled whether source control should be enabled
 	Enabled bool
@@ -233,6 +619,22 @@ type sourceControl struct {
 	Repository repository
 }
 
+type operator struct {
+	// Enabled deploys the APIs of the API custom resources of the Kubernetes cluster, when the control plane is
+	// disabled
+	Enabled bool
+	// Namespace of the API custom resources. The custom resources of all the namespaces are deployed if it is empty.
+	Namespace string
+	// APIServerURL is the URL of the Kubernetes API server
+	APIServerURL string
+	// TokenFile is the service account token authenticating with the Kubernetes API server
+	TokenFile string
+	// CACertFile is the certificate of the Kubernetes API server
+	CACertFile string
+	// ResyncIntervalInSeconds is the time between two reconciliations of all the custom resources
+	ResyncIntervalInSeconds int
+}
+
 // Global CORS configurations
 type globalCors struct {
 	Enabled          bool
@@ -251,6 +653,13 @@ type payloadPassingToEnforcer struct {
 	PackAsBytes         bool
 }
 
+// Default limits of the request and response payloads of the APIs, which are overridden by the
+// x-wso2-payload-limits extension of an API or a resource. 0 means unlimited (or the router default).
+type payloadLimits struct {
+	MaxRequestBodySizeInBytes uint32
+	MaxBufferSizeInBytes      uint32
+}
+
 // Configurations related to Aws lmabda endpoint support
 type awsLambda struct {
 	Enabled            bool
@@ -268,6 +677,7 @@ type envoyUpstream struct {
 	DNS      upstreamDNS
 	Retry    upstreamRetry
 	HTTP2    upstreamHTTP2Options
+	Warmup   upstreamWarmup
 }
 
 // Envoy Downstream Related Configurations
@@ -279,6 +689,23 @@ type envoyDownstream struct {
 type downstreamTLS struct {
 	TrustedCertPath string
 	MTLSAPIsEnabled bool
+	// TrustAPIClientCerts accepts the client certificates which are not signed by the trusted CAs, as the
+	// client certificates of the APIs are validated by the enforcer. Disabled by default, hence the untrusted
+	// client certificates are rejected by the router.
+	TrustAPIClientCerts bool
+	// ForwardClientCertDetails sets the x-forwarded-client-cert header sent to the backends. One of sanitize,
+	// forward_only, append_forward, sanitize_set or always_forward_only.
+	ForwardClientCertDetails string
+	SNI                      downstreamSNI
+}
+
+// SNI based certificate selection for the vhosts served by the secured listener
+type downstreamSNI struct {
+	// Enabled serves the certificate matching the SNI of the client hello, if available in the pool
+	Enabled bool
+	// CertPoolDirectory contains a sub directory per domain (ie: foo.com or *.foo.com) having the
+	// tls.crt and tls.key files. Hence a kubernetes TLS secret can be mounted directly.
+	CertPoolDirectory string
 }
 
 type upstreamTLS struct {
@@ -294,6 +721,8 @@ type upstreamTimeout struct {
 	RouteTimeoutInSeconds     uint32
 	MaxRouteTimeoutInSeconds  uint32
 	RouteIdleTimeoutInSeconds uint32
+	// StreamingRouteIdleTimeoutInSeconds is the default idle timeout of streaming (ie: Server-Sent Events) routes
+	StreamingRouteIdleTimeoutInSeconds uint32
 }
 
 type upstreamHealth struct {
@@ -308,6 +737,17 @@ type upstreamDNS struct {
 	RespectDNSTtl  bool
 }
 
+// upstreamWarmup holds the configurations of priming the new endpoints of an API, before the routes are switched
+// to them.
+type upstreamWarmup struct {
+	Enabled bool
+	// TimeoutInSeconds is the maximum time to wait until the new endpoints accept connections
+	TimeoutInSeconds int32
+	// FailOnTimeout rejects the update if the endpoints are not reachable within the timeout, keeping the
+	// existing routes. Otherwise the update is deployed after the timeout.
+	FailOnTimeout bool
+}
+
 type upstreamHTTP2Options struct {
 	HpackTableSize       uint32
 	MaxConcurrentStreams uint32
@@ -321,10 +761,22 @@ type upstreamRetry struct {
 
 type security struct {
 	TokenService []tokenService
+	APIKey       apiKey
 	AuthHeader   authHeader
 	MutualSSL    mutualSSL
 }
 
+// apiKey represents the validation of the API keys issued by the control plane. The API keys are self contained
+// JWTs, which are validated using the certificate of the issuer.
+type apiKey struct {
+	// Enabled replaces the token service named "APIM APIkey", if any, with this configuration
+	Enabled              bool
+	Issuer               string
+	CertificateAlias     string
+	CertificateFilePath  string
+	ValidateSubscription bool
+}
+
 type authService struct {
 	Port           int32
 	MaxMessageSize int32
@@ -370,6 +822,17 @@ type throttlingConfig struct {
 	// Deprecated: Use JmsConnectionProviderURL instead
 	JmsConnectionProviderURLDeprecated string `toml:"jmsConnectionProviderUrl"`
 	Publisher                          binaryPublisher
+	ConcurrencyLimits                  concurrencyLimits
+}
+
+// concurrencyLimits holds the limits of the in-flight requests of the APIs. The limits set via the
+// x-wso2-concurrency-limits extension of an API override the default limits.
+type concurrencyLimits struct {
+	Enabled bool
+	// Default maximum number of in-flight requests of an API. 0 means unlimited.
+	MaxConcurrentRequests uint32
+	// Default maximum number of in-flight requests of an application to an API. 0 means unlimited.
+	MaxConcurrentRequestsPerApplication uint32
 }
 
 type binaryPublisher struct {
@@ -433,6 +896,8 @@ type jwtGenerator struct {
 	Keypair                          []KeyPair
 	JwksRatelimitQuota               uint32
 	JwksRatelimitTimeWindowInSeconds uint32
+	// ApplicationAttributes are the application attributes included as claims. All are included if empty.
+	ApplicationAttributes []string
 }
 
 // KeyPair represents hthe rsa keypair used for signing JWTs
@@ -473,6 +938,148 @@ type metrics struct {
 	CollectionInterval int32
 }
 
+type revisionTrafficSplit struct {
+	Enabled bool
+	// NewRevisionWeight is the percentage of the traffic routed to the new revision during the split
+	NewRevisionWeight uint32
+	// TesterHeader is the request header used to route a request to a given revision, irrespective of the weights
+	TesterHeader string
+	// PromoteAfterInSeconds is the duration after which the new revision receives the complete traffic.
+	// The value 0 disables the automatic promotion.
+	PromoteAfterInSeconds uint32
+}
+
+type audit struct {
+	Enabled bool
+	// JournalFilePath is the file, where the change records are appended as json lines. The records are kept
+	// only in memory if the path is empty.
+	JournalFilePath string
+	// MaxRecordsInMemory is the number of latest change records kept in memory to serve the change reports
+	MaxRecordsInMemory int
+	// ArtifactEncryptionKey is the base64 encoded AES key (16, 24 or 32 bytes), by which the deployed API projects
+	// and the subscription data are encrypted in the journal file. Those are not persisted if empty, as those
+	// contain credentials, hence the APIs and the subscriptions cannot be rebuilt from the journal.
+	ArtifactEncryptionKey string
+	// Events represents the history of the control plane events processed by the adapter
+	Events auditEvents
+}
+
+type auditEvents struct {
+	Enabled bool
+	// MaxEventsInMemory is the number of latest events kept in memory, which are queried via the REST API
+	MaxEventsInMemory int
+	// FilePath is the file, where the events are appended as json lines. The events are not persisted if empty.
+	FilePath string
+}
+
+type standby struct {
+	// Enabled starts the adapter in the standby mode. The APIs are synced and the routes are configured, but the
+	// API requests are not routed to the backends until the site is activated.
+	Enabled bool
+	// RedirectURL is the URL of the primary site, to which the API requests are redirected (scheme, host and
+	// port). The API requests are responded with 503 if empty.
+	RedirectURL string
+	// PrimaryHealthCheck activates the site when the primary site is not healthy
+	PrimaryHealthCheck primaryHealthCheck
+	// StatusFilePath is the file the standby status is persisted to, hence an activated site remains active when
+	// the adapter is restarted. The site is started in the standby mode on every restart if empty.
+	StatusFilePath string
+}
+
+type primaryHealthCheck struct {
+	Enabled bool
+	// URL of the health endpoint of the primary site
+	URL string
+	// IntervalInSeconds is the time between two health checks
+	IntervalInSeconds int
+	// TimeoutInSeconds is the timeout of a health check
+	TimeoutInSeconds int
+	// FailureThreshold is the number of consecutive failed health checks to activate the site
+	FailureThreshold int
+}
+
+type shutdown struct {
+	// DrainTimeoutInSeconds is the time given to the REST API requests in progress and to the connected routers
+	// and enforcers to complete, before the connections are closed forcefully.
+	DrainTimeoutInSeconds int
+	// EventDrainTimeoutInSeconds is the part of the drain timeout given to the control plane events in progress to
+	// be processed and acknowledged, before the connection to the message broker is closed.
+	EventDrainTimeoutInSeconds int
+}
+
+type configReload struct {
+	Enabled bool
+	// DebounceInMillis is the time waited for the file to settle, after the last change is detected
+	DebounceInMillis int
+}
+
+type syntheticMonitoring struct {
+	Enabled bool
+	// RouterURL is the URL of the router (scheme, host and port), through which the APIs are invoked
+	RouterURL string
+	// IntervalInSeconds is the time between two probes of an API
+	IntervalInSeconds int
+	// TimeoutInSeconds is the timeout of a probe
+	TimeoutInSeconds int
+	// FailureThreshold is the number of consecutive failed probes to mark an API degraded
+	FailureThreshold int
+	// Probes are the health resources of the APIs to be invoked
+	Probes []APIProbe
+}
+
+type compaction struct {
+	Enabled bool
+	// IntervalInMinutes is the time between two compactions
+	IntervalInMinutes int
+	// EventTimestampRetentionInHours is the time the timestamps of the processed events are retained, to discard
+	// the events received out of order
+	EventTimestampRetentionInHours int
+	// TombstonesFilePath is the file, where the timestamps of the delete events older than the retention are
+	// persisted before those are removed from the memory. The timestamps of the delete events are retained in the
+	// memory if empty.
+	TombstonesFilePath string
+}
+
+type capabilityCheck struct {
+	// RejectIncompatible rejects the API projects using features not supported by the gateway, instead of deploying
+	// the APIs without those features
+	RejectIncompatible bool
+}
+
+type admission struct {
+	// Enabled rejects the API projects failing the admission checks, instead of deploying them
+	Enabled bool
+	// SchemaValidation validates the OpenAPI definitions of the APIs against the specification
+	SchemaValidation bool
+	// RejectionHistorySize is the number of recent rejections reported via the adapter REST API
+	RejectionHistorySize int
+}
+
+// APIProbe represents a health resource of an API, invoked by the synthetic monitoring
+type APIProbe struct {
+	APIName    string
+	APIVersion string
+	// Vhost of the API. The vhost of the default environment is used if empty.
+	Vhost string
+	// Method of the request. GET is used if empty.
+	Method string
+	// Resource is the path of the health resource, relative to the basepath of the API
+	Resource string
+	// ExpectedStatusCode of the response. Any 2xx status code is accepted if 0.
+	ExpectedStatusCode int
+	// Headers sent with the request (ex: credentials, if the health resource is secured)
+	Headers map[string]string
+}
+
+type jobs struct {
+	// MaxConcurrentJobs is the number of jobs run at a time. The other jobs are queued.
+	MaxConcurrentJobs int
+	// MaxRetainedJobs is the number of jobs kept in memory. The oldest completed jobs are discarded.
+	MaxRetainedJobs int
+	// MaxLogsPerJob is the number of latest log messages kept for a job
+	MaxLogsPerJob int
+}
+
 type analyticsAdapter struct {
 	BufferFlushInterval time.Duration
 	BufferSizeBytes     uint32
@@ -483,7 +1090,10 @@ type analyticsAdapter struct {
 type analyticsEnforcer struct {
 	// TODO: (VirajSalaka) convert it to map[string]{}interface
 	ConfigProperties map[string]string
-	LogReceiver      authService
+	// PublishIntervalInSeconds is the interval at which the enforcer publishes the collected events to the analytics
+	// backend. The value 0 keeps the default interval of the analytics publisher.
+	PublishIntervalInSeconds uint32
+	LogReceiver              authService
 }
 
 type analyticsCustomProperties struct {
@@ -547,6 +1157,66 @@ type controlPlane struct {
 	BrokerConnectionParameters brokerConnectionParameters
 	HTTPClient                 httpClient
 	RequestWorkerPool          requestWorkerPool
+	// Webhook receives the events of the control plane over HTTPS, instead of the message broker
+	Webhook webhook
+	// TenantResolution resolves the numeric tenant IDs of the subscription data sent to the enforcer
+	TenantResolution tenantResolution
+	// TenantJWKS caches the JWKS and the issuer of the resident key manager of the tenants for the enforcers
+	TenantJWKS tenantJWKS
+	// EventFilters decide whether the notification events are applied, only logged or dropped. The first rule
+	// matching an event decides, while the events not matching any rule are applied.
+	EventFilters []EventFilterRule
+	// SubscriptionValidationOverridesFilePath is the file, where the subscription validation of the APIs received
+	// with the deploy events is persisted, as the APIs fetched at startup do not carry it. Not persisted if empty.
+	SubscriptionValidationOverridesFilePath string
+}
+
+// EventFilterRule represents an action applied to the notification events matching a filter expression.
+type EventFilterRule struct {
+	// Name of the rule, which is recorded with the events logged or dropped
+	Name string
+	// Expression matched against the attributes of the event payload (ex: tenantDomain, apiProvider, apiContext),
+	// the eventType and the category of the event. ex: tenantDomain == "bu1.com" and apiContext startsWith "/bu1/"
+	Expression string
+	// Action is one of apply, log (logged, but not applied) or drop
+	Action string
+}
+
+type tenantJWKS struct {
+	Enabled bool
+	// IssuerTemplate and JWKSURLTemplate are resolved per tenant, where {tenantDomain} is the tenant domain and
+	// {tenantPath} is /t/<tenant domain> (empty for the super tenant)
+	IssuerTemplate  string
+	JWKSURLTemplate string
+	// RefreshIntervalInSeconds is the time to live of the cached JWKS
+	RefreshIntervalInSeconds int
+	// Tenants are the tenant domains, for which the resident key manager is trusted. The resident key manager is
+	// not trusted for the other tenants discovered from the events.
+	Tenants []string
+}
+
+type tenantResolution struct {
+	// Strategy is one of event (tenant ID in the event payload), controlPlane (looked up via the admin REST API
+	// of the control plane), file (static mapping file) or none (tenant IDs are not resolved)
+	Strategy string
+	// MappingFile is a YAML file mapping the tenant domains to the tenant IDs, used by the file strategy
+	MappingFile string
+}
+
+type webhook struct {
+	Enabled bool
+	Host    string
+	Port    uint32
+	// Secret is the shared secret used to sign the events (HMAC-SHA256 of the X-WSO2-Timestamp header and the body,
+	// in the X-WSO2-Signature header)
+	Secret string
+	// MutualTLS requires the senders to present a client certificate trusted by the adapter truststore
+	MutualTLS bool
+	// MaxEventSizeInBytes is the maximum size of an event accepted
+	MaxEventSizeInBytes int64
+	// MaxEventAgeInSeconds is the maximum difference between the signed timestamp of an event and the time it is
+	// received. The signed events received within the period are accepted once.
+	MaxEventAgeInSeconds int
 }
 
 type requestWorkerPool struct {
@@ -571,6 +1241,103 @@ type brokerConnectionParameters struct {
 	EventListeningEndpoints []string
 	ReconnectInterval       time.Duration
 	ReconnectRetryCount     int
+	// CatchUpOnReconnect fetches the APIs from the control plane when the connection to the broker is restored,
+	// to apply the events missed while the broker was unreachable.
+	CatchUpOnReconnect bool
+	// MaxRedeliveries is the number of times an event which cannot be processed is redelivered by the broker
+	MaxRedeliveries int
+	// AMQP configures the consumers, if the event listening endpoints are RabbitMQ brokers (amqp://)
+	AMQP amqpConsumers
+	// JetStream configures the consumers, if the event listening endpoints are NATS servers (nats://)
+	JetStream jetStream
+	// AzureServiceBus configures the consumers, if the event listening endpoint is an Azure Service Bus
+	// connection string or namespace (sb://)
+	AzureServiceBus azureServiceBus
+	// PayloadCodec decodes the payloads of the events consumed from the event listening endpoints
+	PayloadCodec payloadCodec
+	// EventCategories are the categories of the events consumed (api, subscription, keyManager, throttleData,
+	// tokenRevocation and organizationPurge). The topics of the categories not listed are not bound, and the
+	// events of those categories are ignored. All the categories are consumed if empty.
+	EventCategories []string
+}
+
+type payloadCodec struct {
+	// Type of the codec, which is json (JSON with base64 encoded embedded events), protobuf or avro
+	Type     string
+	Protobuf protobufCodec
+	Avro     avroCodec
+}
+
+type protobufCodec struct {
+	// DescriptorSetFile is the file descriptor set (protoc --descriptor_set_out) containing the messages
+	DescriptorSetFile string
+	// MessageTypes maps the topics (notification, keymanager, tokenRevocation, throttleData and
+	// organizationPurge) to the full names of the messages of the events
+	MessageTypes map[string]string
+}
+
+type avroCodec struct {
+	// SchemaRegistryURL is the URL of the schema registry, the schemas of the payloads are looked up from
+	SchemaRegistryURL string
+	Username          string
+	Password          string
+	// TimeoutInSeconds is the timeout of the schema lookups
+	TimeoutInSeconds int
+}
+
+type azureServiceBus struct {
+	// ManagedIdentityClientID is the client ID of the user assigned managed identity, used if the event listening
+	// endpoint is a namespace. The system assigned managed identity is used if empty.
+	ManagedIdentityClientID string
+	// SubscriptionName is the subscription of the topics. A subscription unique to the adapter is created per
+	// topic if empty.
+	SubscriptionName string
+	// SubscriptionIdleTime is the time (ISO 8601 duration) after which the idle subscriptions created by the
+	// adapter are deleted
+	SubscriptionIdleTime   string
+	NotificationTopic      string
+	TokenRevocationTopic   string
+	OrganizationPurgeTopic string
+}
+
+type amqpConsumers struct {
+	// PrefetchCount is the number of the unacknowledged events delivered to a consumer. The events are not
+	// limited if 0.
+	PrefetchCount int
+	// ConsumersPerQueue is the number of the handlers processing the notification events in parallel. The events
+	// of an entity (ex: an API or an application) are processed by the same handler, in the published order. The
+	// events of the other queues are processed by a single handler.
+	ConsumersPerQueue int
+	// DurableQueues survive the restarts of the broker, and retain the events while the adapter is disconnected.
+	// The queues should be named by the QueueNamePrefix or the QueueNames, to be reused by the adapter.
+	DurableQueues bool
+	// DurableQueueExpiry is the time in seconds, after which a durable queue without consumers is deleted by the
+	// broker (x-expires), hence the queues of the removed adapters do not retain the events forever. The queues
+	// are not expired if 0.
+	DurableQueueExpiry int
+	// ExclusiveQueues are used only by the connection of the adapter, and deleted when the connection is closed
+	ExclusiveQueues bool
+	// QueueNamePrefix is prepended to the topics to name the queues (ex: adapter-1-notification). The broker
+	// names the queues if empty.
+	QueueNamePrefix string
+	// QueueNames maps the topics (notification, keymanager, tokenRevocation and throttleData) to the names of
+	// the queues, overriding the QueueNamePrefix
+	QueueNames map[string]string
+}
+
+type jetStream struct {
+	// Stream containing the control plane events. The stream is looked up by the subjects if empty.
+	Stream string
+	// SubjectPrefix is prepended to the topics (notification, keymanager, tokenRevocation and throttleData) to
+	// derive the subjects of the events
+	SubjectPrefix string
+	// DurableName identifies the consumers of the adapter. The host name is used if empty.
+	DurableName string
+	// AckWaitInSeconds is the time the server waits for an event to be acknowledged, before redelivering it
+	AckWaitInSeconds int
+	// InactiveThresholdInSeconds is the time, after which a durable consumer without subscriptions is deleted by the
+	// server. The consumers are not deleted if 0.
+	InactiveThresholdInSeconds int
 }
 
 // Configuration for Enforcer admin rest api
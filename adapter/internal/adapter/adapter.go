@@ -28,11 +28,17 @@ import (
 	"github.com/wso2/product-microgateway/adapter/internal/api"
 	restserver "github.com/wso2/product-microgateway/adapter/internal/api/restserver"
 	"github.com/wso2/product-microgateway/adapter/internal/auth"
+	"github.com/wso2/product-microgateway/adapter/internal/botdetection"
 	"github.com/wso2/product-microgateway/adapter/internal/common"
+	"github.com/wso2/product-microgateway/adapter/internal/compaction"
 	enforcerCallbacks "github.com/wso2/product-microgateway/adapter/internal/discovery/xds/enforcercallbacks"
 	routercb "github.com/wso2/product-microgateway/adapter/internal/discovery/xds/routercallbacks"
 	"github.com/wso2/product-microgateway/adapter/internal/ga"
+	"github.com/wso2/product-microgateway/adapter/internal/jwks"
 	"github.com/wso2/product-microgateway/adapter/internal/messaging"
+	"github.com/wso2/product-microgateway/adapter/internal/operator"
+	"github.com/wso2/product-microgateway/adapter/internal/profiling"
+	"github.com/wso2/product-microgateway/adapter/internal/quotasync"
 	"github.com/wso2/product-microgateway/adapter/pkg/adapter"
 	apiservice "github.com/wso2/product-microgateway/adapter/pkg/discovery/api/wso2/discovery/service/api"
 	configservice "github.com/wso2/product-microgateway/adapter/pkg/discovery/api/wso2/discovery/service/config"
@@ -44,6 +50,7 @@ import (
 	healthservice "github.com/wso2/product-microgateway/adapter/pkg/health/api/wso2/health/service"
 	"github.com/wso2/product-microgateway/adapter/pkg/logging"
 	"github.com/wso2/product-microgateway/adapter/pkg/metrics"
+	"github.com/wso2/product-microgateway/adapter/pkg/secrets"
 	sync "github.com/wso2/product-microgateway/adapter/pkg/synchronizer"
 	"github.com/wso2/product-microgateway/adapter/pkg/tlsutils"
 
@@ -53,6 +60,7 @@ import (
 	"net"
 	"os"
 	"os/signal"
+	"syscall"
 
 	"github.com/fsnotify/fsnotify"
 	"github.com/wso2/product-microgateway/adapter/config"
@@ -82,6 +90,7 @@ var (
 const (
 	ads          = "ads"
 	amqpProtocol = "amqp"
+	natsProtocol = "nats"
 )
 
 func init() {
@@ -99,7 +108,7 @@ func runManagementServer(conf *config.Config, server xdsv3.Server, enforcerServe
 	enforcerAppDsSrv wso2_server.Server, enforcerAPIDsSrv wso2_server.Server, enforcerAppPolicyDsSrv wso2_server.Server,
 	enforcerSubPolicyDsSrv wso2_server.Server, enforcerAppKeyMappingDsSrv wso2_server.Server,
 	enforcerKeyManagerDsSrv wso2_server.Server, enforcerRevokedTokenDsSrv wso2_server.Server,
-	enforcerThrottleDataDsSrv wso2_server.Server, port uint) {
+	enforcerThrottleDataDsSrv wso2_server.Server, enforcerScopeDsSrv wso2_server.Server, port uint) *grpc.Server {
 	var grpcOptions []grpc.ServerOption
 	grpcOptions = append(grpcOptions, grpc.MaxConcurrentStreams(grpcMaxConcurrentStreams))
 	publicKeyLocation, privateKeyLocation, truststoreLocation := tlsutils.GetKeyLocations()
@@ -147,9 +156,13 @@ func runManagementServer(conf *config.Config, server xdsv3.Server, enforcerServe
 	subscriptionservice.RegisterApplicationPolicyDiscoveryServiceServer(grpcServer, enforcerAppPolicyDsSrv)
 	subscriptionservice.RegisterSubscriptionPolicyDiscoveryServiceServer(grpcServer, enforcerSubPolicyDsSrv)
 	subscriptionservice.RegisterApplicationKeyMappingDiscoveryServiceServer(grpcServer, enforcerAppKeyMappingDsSrv)
+	subscriptionservice.RegisterScopeDiscoveryServiceServer(grpcServer, enforcerScopeDsSrv)
 	keymanagerservice.RegisterKMDiscoveryServiceServer(grpcServer, enforcerKeyManagerDsSrv)
 	keymanagerservice.RegisterRevokedTokenDiscoveryServiceServer(grpcServer, enforcerRevokedTokenDsSrv)
 	throttleservice.RegisterThrottleDataDiscoveryServiceServer(grpcServer, enforcerThrottleDataDsSrv)
+	// register the access log service receiving the failures of the requests from the router
+	botdetection.RegisterAccessLogService(grpcServer)
+	quotasync.RegisterQuotaSyncService(grpcServer)
 
 	// register health service
 	healthservice.RegisterHealthServer(grpcServer, &health.Server{})
@@ -162,6 +175,7 @@ func runManagementServer(conf *config.Config, server xdsv3.Server, enforcerServe
 			health.WaitForControlPlane()
 		}
 		logger.LoggerMgw.Info("Starting XDS GRPC server.")
+		health.XdsService.SetStatus(true)
 		if err = grpcServer.Serve(lis); err != nil {
 			logger.LoggerMgw.ErrorC(logging.ErrorDetails{
 				Message:   fmt.Sprintf("Failed to start XDS GRPC server : %v", err.Error()),
@@ -170,12 +184,13 @@ func runManagementServer(conf *config.Config, server xdsv3.Server, enforcerServe
 			})
 		}
 	}()
+	return grpcServer
 }
 
 // Run starts the XDS server and Rest API server.
 func Run(conf *config.Config) {
 	sig := make(chan os.Signal, 2)
-	signal.Notify(sig, os.Interrupt)
+	signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
 	// TODO: (VirajSalaka) Support the REST API Configuration via flags only if it is a valid requirement
 	flag.Parse()
 
@@ -202,9 +217,12 @@ logger.LoggerMgw.Info("Starting adapter ....")
 	// Start the metrics server
 	if conf.Adapter.Metrics.Enabled && strings.EqualFold(conf.Adapter.Metrics.Type, metrics.PrometheusMetricType) {
 		logger.LoggerMgw.Info("Starting Prometheus Metrics Server ....")
+		// trace IDs of the requests link the latency metrics to the traces
+		metrics.EnableExemplars(conf.Tracing.Enabled)
 		go metrics.StartPrometheusMetricsServer(conf.Adapter.Metrics.Port, conf.Adapter.Metrics.CollectionInterval)
 
 	}
+	profiling.Start(conf)
 
 
 	cache := xds.GetXdsCache()
@@ -218,6 +236,7 @@ logger.LoggerMgw.Info("Starting adapter ....")
 	enforcerKeyManagerCache := xds.GetEnforcerKeyManagerCache()
 	enforcerRevokedTokenCache := xds.GetEnforcerRevokedTokenCache()
 	enforcerThrottleDataCache := xds.GetEnforcerThrottleDataCache()
+	enforcerScopeCache := xds.GetEnforcerScopeCache()
 
 	srv := xdsv3.NewServer(ctx, cache, &routercb.Callbacks{})
 	enforcerXdsSrv := wso2_server.NewServer(ctx, enforcerCache, &enforcerCallbacks.Callbacks{})
@@ -230,12 +249,22 @@ logger.LoggerMgw.Info("Starting adapter ....")
 	enforcerKeyManagerDsSrv := wso2_server.NewServer(ctx, enforcerKeyManagerCache, &enforcerCallbacks.Callbacks{})
 	enforcerRevokedTokenDsSrv := wso2_server.NewServer(ctx, enforcerRevokedTokenCache, &enforcerCallbacks.Callbacks{})
 	enforcerThrottleDataDsSrv := wso2_server.NewServer(ctx, enforcerThrottleDataCache, &enforcerCallbacks.Callbacks{})
+	enforcerScopeDsSrv := wso2_server.NewServer(ctx, enforcerScopeCache, &enforcerCallbacks.Callbacks{})
 
-	runManagementServer(conf, srv, enforcerXdsSrv, enforcerSdsSrv, enforcerAppDsSrv, enforcerAPIDsSrv,
+	botdetection.Start(conf)
+	quotasync.Start(conf)
+	grpcServer := runManagementServer(conf, srv, enforcerXdsSrv, enforcerSdsSrv, enforcerAppDsSrv, enforcerAPIDsSrv,
 		enforcerAppPolicyDsSrv, enforcerSubPolicyDsSrv, enforcerAppKeyMappingDsSrv, enforcerKeyManagerDsSrv,
-		enforcerRevokedTokenDsSrv, enforcerThrottleDataDsSrv, port)
+		enforcerRevokedTokenDsSrv, enforcerThrottleDataDsSrv, enforcerScopeDsSrv, port)
 
 	// Set enforcer startup configs
+	if err := conf.Analytics.Validate(); err != nil {
+		logger.LoggerMgw.ErrorC(logging.ErrorDetails{
+			Message:   fmt.Sprintf("Invalid analytics configuration. %v", err),
+			Severity:  logging.MAJOR,
+			ErrorCode: 1119,
+		})
+	}
 	xds.UpdateEnforcerConfig(conf)
 
 	envs := conf.ControlPlane.EnvironmentLabels
@@ -251,6 +280,22 @@ logger.LoggerMgw.Info("Starting adapter ....")
 		xds.UpdateXdsCacheWithLock(env, endpoints, clusters, routes, listeners)
 		xds.UpdateEnforcerApis(env, apis, "")
 	}
+	go xds.StartPrimaryHealthCheck()
+	go xds.StartSyntheticProbes()
+	xds.InitResourceStores(conf)
+	go xds.StartSharedStore()
+	if conf.Adapter.ConfigReload.Enabled {
+		go watchAdapterConfig(conf)
+	}
+	if conf.Envoy.Downstream.TLS.SNI.Enabled {
+		go watchSNICertPool(conf.Envoy.Downstream.TLS.SNI.CertPoolDirectory)
+	}
+	if manager := secrets.GetManager(); manager != nil && conf.Adapter.Secrets.RefreshIntervalInSeconds > 0 {
+		manager.OnRotation(applyRotatedSecrets)
+		go manager.StartRefresh(time.Duration(conf.Adapter.Secrets.RefreshIntervalInSeconds) * time.Second)
+	}
+	go compaction.StartPeriodicCompaction()
+	api.SetSubscriptionReplayer(messaging.RebuildSubscriptions)
 
 	// Adapter REST API
 	if conf.Adapter.Server.Enabled {
@@ -279,11 +324,12 @@ logger.LoggerMgw.Info("Starting adapter ....")
 			fetchAPIsOnStartUp(conf, nil)
 		}
 
-		var connectionURLList = conf.ControlPlane.BrokerConnectionParameters.EventListeningEndpoints
-		if strings.Contains(connectionURLList[0], amqpProtocol) {
-			go messaging.ProcessEvents(conf)
+		// started before the event listeners, hence the tenants of the events are cached
+		jwks.Start()
+		if conf.Adapter.HA.Enabled {
+			startHighAvailability(conf)
 		} else {
-			messaging.InitiateAndProcessEvents(conf)
+			startEventListeners(conf)
 		}
 
 		go synchronizer.UpdateRevokedTokens()
@@ -292,7 +338,17 @@ logger.LoggerMgw.Info("Starting adapter ....")
 		go synchronizer.UpdateKeyTemplates()
 		go synchronizer.UpdateBlockingConditions()
 	} else {
-		if conf.Adapter.SourceControl.Enabled {
+		if conf.Adapter.Operator.Enabled {
+			err := operator.Start()
+			if err != nil {
+				logger.LoggerMgw.ErrorC(logging.ErrorDetails{
+					Message:   fmt.Sprintf("Error while starting the operator of the API custom resources. %v", err.Error()),
+					Severity:  logging.CRITICAL,
+					ErrorCode: 1116,
+				})
+				return
+			}
+		} else if conf.Adapter.SourceControl.Enabled {
 			err := sourcewatcher.Start()
 			if err != nil {
 				logger.LoggerMgw.ErrorC(logging.ErrorDetails{
@@ -302,6 +358,16 @@ logger.LoggerMgw.Info("Starting adapter ....")
 				})
 				return
 			}
+		} else if conf.Adapter.ArtifactsWatcher.Enabled {
+			err := sourcewatcher.StartArtifactsWatcher()
+			if err != nil {
+				logger.LoggerMgw.ErrorC(logging.ErrorDetails{
+					Message:   fmt.Sprintf("Error while starting artifacts watcher. %v", err.Error()),
+					Severity:  logging.CRITICAL,
+					ErrorCode: 1117,
+				})
+				return
+			}
 		} else {
 			_, err := api.ProcessMountedAPIProjects()
 			if err != nil {
@@ -325,20 +391,42 @@ OUTER:
 			switch l.Op.String() {
 			case "WRITE":
 				logger.LoggerMgw.Info("Loading updated log config file...")
+				if err := logging.ValidateLogConfig(logConfigPath); err != nil {
+					logger.LoggerMgw.ErrorC(logging.ErrorDetails{
+						Message:   fmt.Sprintf("Updated log config file is rejected. %v", err),
+						Severity:  logging.MAJOR,
+						ErrorCode: 1112,
+					})
+					continue
+				}
 				config.ClearLogConfigInstance()
 				logger.UpdateLoggers()
 			}
 		case s := <-sig:
 			switch s {
-			case os.Interrupt:
+			case os.Interrupt, syscall.SIGTERM:
 				logger.LoggerMgw.Info("Shutting down...")
 				break OUTER
 			}
 		}
 	}
+	drainConnections(conf, grpcServer, cancel)
 	logger.LoggerMgw.Info("Bye!")
 }
 
+// startEventListeners starts consuming the events of the control plane, from the webhook or the message broker.
+func startEventListeners(conf *config.Config) {
+	var connectionURLList = conf.ControlPlane.BrokerConnectionParameters.EventListeningEndpoints
+	if conf.ControlPlane.Webhook.Enabled {
+		go messaging.StartWebhookReceiver(conf)
+	} else if strings.Contains(connectionURLList[0], amqpProtocol) ||
+		strings.HasPrefix(connectionURLList[0], natsProtocol) {
+		go messaging.ProcessEvents(conf)
+	} else {
+		messaging.InitiateAndProcessEvents(conf)
+	}
+}
+
 // fetch APIs from control plane during the server start up and push them
 // to the router and enforcer components.
 func fetchAPIsOnStartUp(conf *config.Config, apiUUIDList []string) {
@@ -347,6 +435,7 @@ func fetchAPIsOnStartUp(conf *config.Config, apiUUIDList []string) {
 
 	// Create a channel for the byte slice (response from the APIs from control plane)
 	c := make(chan sync.SyncAPIResponse)
+	pulledAt := time.Now().UnixMilli()
 
 	var queryParamMap map[string]string
 	queryParamMap = common.PopulateQueryParamForOrganizationID(queryParamMap)
@@ -362,7 +451,7 @@ func fetchAPIsOnStartUp(conf *config.Config, apiUUIDList []string) {
 		if data.Resp != nil {
 			// For successfull fetches, data.Resp would return a byte slice with API project(s)
 			logger.LoggerMgw.Debug("Pushing data to router and enforcer")
-			err := synchronizer.PushAPIProjects(data.Resp, envs)
+			err := synchronizer.PushAPIProjects(data.Resp, envs, pulledAt)
 			if err != nil {
 				logger.LoggerMgw.ErrorC(logging.ErrorDetails{
 					Message:   fmt.Sprintf("Error occurred while pushing API data to router and enforcer: %v ", err.Error()),
@@ -0,0 +1,223 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package adapter
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/wso2/product-microgateway/adapter/config"
+	"github.com/wso2/product-microgateway/adapter/internal/analytics"
+	"github.com/wso2/product-microgateway/adapter/internal/discovery/xds"
+	"github.com/wso2/product-microgateway/adapter/internal/eventhub"
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/internal/messaging"
+	"github.com/wso2/product-microgateway/adapter/internal/synchronizer"
+	"github.com/wso2/product-microgateway/adapter/pkg/logging"
+)
+
+// configMapDataDir is the directory a mounted config map links to, which is replaced when the config map is updated
+const configMapDataDir = "..data"
+
+// watchAdapterConfig applies the changes of the adapter configuration file at runtime. The directory of the file is
+// watched, as the file is replaced (not written) when a mounted config map is updated.
+func watchAdapterConfig(conf *config.Config) {
+	configPath := config.GetConfigPath()
+	watcher, err := fsnotify.NewWatcher()
+	if err == nil {
+		err = watcher.Add(filepath.Dir(configPath))
+	}
+	if err != nil {
+		logger.LoggerMgw.ErrorC(logging.ErrorDetails{
+			Message:   fmt.Sprintf("Error while watching the adapter configuration file. %v", err),
+			Severity:  logging.CRITICAL,
+			ErrorCode: 1113,
+		})
+		return
+	}
+	debounceInterval := time.Duration(conf.Adapter.ConfigReload.DebounceInMillis) * time.Millisecond
+	var reloadTimer *time.Timer
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			fileName := filepath.Base(event.Name)
+			if fileName != filepath.Base(configPath) && fileName != configMapDataDir {
+				continue
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+				continue
+			}
+			logger.LoggerMgw.Debugf("Adapter configuration file is changed (%s)", event.String())
+			// the file is reloaded once the changes are settled
+			if reloadTimer == nil {
+				reloadTimer = time.AfterFunc(debounceInterval, reloadAdapterConfig)
+			} else {
+				reloadTimer.Reset(debounceInterval)
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			logger.LoggerMgw.Errorf("Error while watching the adapter configuration file. %v", err)
+		}
+	}
+}
+
+// reloadAdapterConfig applies the reloadable sections of the adapter configuration file. An invalid configuration
+// file is rejected, and the adapter keeps running with the configuration applied.
+func reloadAdapterConfig() {
+	logger.LoggerMgw.Info("Loading updated adapter config file...")
+	reload, err := config.ReloadConfigs()
+	if err != nil {
+		logger.LoggerMgw.ErrorC(logging.ErrorDetails{
+			Message:   fmt.Sprintf("Updated adapter config file is rejected. %v", err),
+			Severity:  logging.MAJOR,
+			ErrorCode: 1114,
+		})
+		return
+	}
+	applyConfigReload(reload)
+}
+
+// applyRotatedSecrets applies the secrets rotated in the secret stores to the reloadable configurations, and to the
+// endpoint credentials of the APIs referring them.
+func applyRotatedSecrets(references []string) {
+	reload, err := config.ReloadSecrets()
+	if err != nil {
+		logger.LoggerMgw.ErrorC(logging.ErrorDetails{
+			Message:   fmt.Sprintf("Rotated secrets are not applied to the adapter configuration. %v", err),
+			Severity:  logging.MAJOR,
+			ErrorCode: 1123,
+		})
+	} else {
+		applyConfigReload(reload)
+	}
+	xds.RefreshEndpointSecrets(references)
+}
+
+// applyConfigReload applies the sections of the adapter configuration reloaded, to the components using them.
+func applyConfigReload(reload *config.ConfigReload) {
+	if len(reload.RestartRequired) > 0 {
+		logger.LoggerMgw.Warnf("Updated configurations %s are applied once the adapter is restarted",
+			strings.Join(reload.RestartRequired, ", "))
+	}
+	conf, _ := config.ReadConfigs()
+	for _, section := range reload.Reloaded {
+		logger.LoggerMgw.Infof("Applying the updated configuration %s", section)
+		switch section {
+		case config.SectionEventListeningEndpoints:
+			go messaging.ReconnectEventSource(conf)
+		case config.SectionEnvironmentLabels:
+			go applyEnvironmentLabels(conf, reload.PreviousEnvironmentLabels)
+		case config.SectionAnalytics:
+			if err := analytics.ApplyConfig(conf); err != nil {
+				logger.LoggerMgw.ErrorC(logging.ErrorDetails{
+					Message:   fmt.Sprintf("Updated analytics configuration is not applied. %v", err),
+					Severity:  logging.MAJOR,
+					ErrorCode: 1118,
+				})
+			}
+		case config.SectionCustomDomains:
+			go xds.UpdateXdsCacheForLabels(nil)
+		}
+	}
+}
+
+// applyEnvironmentLabels deploys the APIs to the environment labels added, and removes the APIs deployed from the
+// control plane to the environment labels removed.
+func applyEnvironmentLabels(conf *config.Config, previousLabels []string) {
+	labels := conf.ControlPlane.EnvironmentLabels
+	// If no environments are configured, default gateway label value is assigned.
+	if len(labels) == 0 {
+		labels = []string{config.DefaultGatewayName}
+	}
+	if len(previousLabels) == 0 {
+		previousLabels = []string{config.DefaultGatewayName}
+	}
+
+	for _, label := range labels {
+		if containsLabel(previousLabels, label) {
+			continue
+		}
+		logger.LoggerMgw.Infof("Environment label %s is added", label)
+		xds.GenerateGlobalClusters(label)
+		listeners, clusters, routes, endpoints, apis := xds.GenerateEnvoyResoucesForLabel(label)
+		xds.UpdateXdsCacheWithLock(label, endpoints, clusters, routes, listeners)
+		xds.UpdateEnforcerApis(label, apis, "")
+	}
+	var removedLabels []string
+	for _, label := range previousLabels {
+		if !containsLabel(labels, label) {
+			logger.LoggerMgw.Infof("Environment label %s is removed", label)
+			removedLabels = append(removedLabels, label)
+		}
+	}
+	if len(removedLabels) > 0 {
+		undeployAPIsOfLabels(removedLabels)
+	}
+
+	if !conf.ControlPlane.Enabled {
+		return
+	}
+	if !conf.GlobalAdapter.Enabled {
+		if err := synchronizer.ResyncAPIsFromControlPlane(); err != nil {
+			logger.LoggerMgw.ErrorC(logging.ErrorDetails{
+				Message:   fmt.Sprintf("Error while fetching the APIs of the updated environment labels. %v", err),
+				Severity:  logging.MAJOR,
+				ErrorCode: 1115,
+			})
+		}
+	}
+	eventhub.LoadSubscriptionData(conf, nil)
+}
+
+// undeployAPIsOfLabels removes the APIs deployed from the control plane to the given environment labels.
+func undeployAPIsOfLabels(labels []string) {
+	for organizationID, apiEnvironments := range xds.GetControlPlaneAPIEnvironments() {
+		for apiUUID, environments := range apiEnvironments {
+			var removedEnvironments []string
+			for _, environment := range environments {
+				if containsLabel(labels, environment) {
+					removedEnvironments = append(removedEnvironments, environment)
+				}
+			}
+			for _, environment := range removedEnvironments {
+				vhost, _ := xds.GetVhostOfAPI(apiUUID, environment)
+				if err := xds.DeleteAPIsWithUUID(vhost, apiUUID, []string{environment}, organizationID); err != nil {
+					logger.LoggerMgw.Errorf("Error while removing the API %s from the environment %s. %v",
+						apiUUID, environment, err)
+				}
+			}
+		}
+	}
+}
+
+func containsLabel(labels []string, label string) bool {
+	for _, l := range labels {
+		if l == label {
+			return true
+		}
+	}
+	return false
+}
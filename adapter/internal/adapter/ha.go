@@ -0,0 +1,97 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package adapter
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wso2/product-microgateway/adapter/config"
+	"github.com/wso2/product-microgateway/adapter/internal/eventhub"
+	"github.com/wso2/product-microgateway/adapter/internal/leaderelection"
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/internal/messaging"
+	"github.com/wso2/product-microgateway/adapter/internal/synchronizer"
+	"github.com/wso2/product-microgateway/adapter/pkg/logging"
+)
+
+// startHighAvailability joins the election of the leader among the adapters. The leader consumes the events of the
+// control plane, while the followers pull the APIs and the subscriptions from the control plane periodically.
+func startHighAvailability(conf *config.Config) {
+	// signalled once per term without blocking, as the lease may be acquired again after it is lost or released
+	elected := make(chan struct{}, 1)
+	err := leaderelection.Start(func() {
+		select {
+		case elected <- struct{}{}:
+		default:
+		}
+	}, func() {
+		// the events in progress are processed and the consumers are stopped, before the lease may be acquired by
+		// another adapter. The event listeners can not be started again, hence the adapter is restarted to rejoin
+		// as a follower.
+		retryPeriod := time.Duration(conf.Adapter.HA.RetryPeriodInSeconds) * time.Second
+		messaging.StopConsumingEvents(time.Now().Add(retryPeriod))
+		logger.LoggerMgw.ErrorC(logging.ErrorDetails{
+			Message:   "Adapter is no longer the leader, hence stopping to avoid processing the events twice",
+			Severity:  logging.BLOCKER,
+			ErrorCode: 1120,
+		})
+	})
+	if err != nil {
+		logger.LoggerMgw.ErrorC(logging.ErrorDetails{
+			Message:   fmt.Sprintf("Error while joining the election of the leader. %v", err),
+			Severity:  logging.BLOCKER,
+			ErrorCode: 1121,
+		})
+		return
+	}
+	go followControlPlane(conf, elected)
+}
+
+// followControlPlane syncs with the control plane periodically until the adapter is elected as the leader. The
+// leader syncs once more, as the events published since the last sync are not received, and consumes the events.
+func followControlPlane(conf *config.Config, elected <-chan struct{}) {
+	ticker := time.NewTicker(time.Duration(conf.Adapter.HA.FollowerSyncIntervalInSeconds) * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			logger.LoggerMgw.Debug("Syncing the follower with the control plane")
+			syncWithControlPlane(conf)
+		case <-elected:
+			logger.LoggerMgw.Info("Catching up with the control plane and consuming the events, as the leader")
+			syncWithControlPlane(conf)
+			startEventListeners(conf)
+			return
+		}
+	}
+}
+
+// syncWithControlPlane pulls the APIs and the subscriptions from the control plane, and applies the changes.
+func syncWithControlPlane(conf *config.Config) {
+	if !conf.GlobalAdapter.Enabled {
+		if err := synchronizer.CatchUpWithControlPlane(); err != nil {
+			logger.LoggerMgw.ErrorC(logging.ErrorDetails{
+				Message:   fmt.Sprintf("Error occurred while syncing the APIs with the control plane. %v", err),
+				Severity:  logging.MAJOR,
+				ErrorCode: 1122,
+			})
+		}
+	}
+	eventhub.LoadSubscriptionData(conf, nil)
+}
@@ -0,0 +1,100 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/wso2/product-microgateway/adapter/config"
+	"github.com/wso2/product-microgateway/adapter/internal/api/restserver"
+	"github.com/wso2/product-microgateway/adapter/internal/audit"
+	"github.com/wso2/product-microgateway/adapter/internal/discovery/xds"
+	"github.com/wso2/product-microgateway/adapter/internal/leaderelection"
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/internal/messaging"
+	"github.com/wso2/product-microgateway/adapter/pkg/health"
+	"github.com/wso2/product-microgateway/adapter/pkg/logging"
+	"google.golang.org/grpc"
+)
+
+// drainConnections stops the adapter in order, within the drain timeout.
+//  1. The control plane events are no longer consumed, and the events in progress are processed and acknowledged
+//     within the event drain timeout. The connection to the message broker is closed afterwards, hence the events
+//     not processed are redelivered (to another adapter). The lease is released then, if the adapter is the leader
+//     of the adapters.
+//  2. The REST API server stops accepting requests and completes the requests in progress, as those may update
+//     the configurations sent to the routers and enforcers.
+//  3. The changes of the APIs batched but not pushed yet are pushed to the routers and enforcers, and the audit
+//     journal is persisted as a snapshot, hence the adapter is rebuilt from the snapshot when started again.
+//  4. The adapter is reported as unhealthy and the gRPC server stops accepting connections. The connected
+//     routers and enforcers are notified (HTTP/2 GOAWAY) not to open new streams.
+//  5. The xDS streams, which do not complete by themselves, are ended once the responses in progress are sent,
+//     hence the routers and enforcers reconnect (to another adapter).
+//
+// The connections remaining after the drain timeout are closed forcefully.
+func drainConnections(conf *config.Config, grpcServer *grpc.Server, cancelStreams context.CancelFunc) {
+	drainTimeout := time.Duration(conf.Adapter.Shutdown.DrainTimeoutInSeconds) * time.Second
+	deadline := time.Now().Add(drainTimeout)
+	logger.LoggerMgw.Infof("Draining the connections to the adapter. Drain timeout: %v", drainTimeout)
+
+	eventDeadline := time.Now().Add(time.Duration(conf.Adapter.Shutdown.EventDrainTimeoutInSeconds) * time.Second)
+	if eventDeadline.After(deadline) {
+		eventDeadline = deadline
+	}
+	messaging.StopConsumingEvents(eventDeadline)
+
+	if conf.Adapter.HA.Enabled {
+		// a follower starts consuming the events, once the events in progress are processed
+		leaderelection.Release()
+	}
+
+	if conf.Adapter.Server.Enabled {
+		restserver.ShutdownRestServer()
+	}
+
+	xds.FlushXdsBatch()
+	if conf.Adapter.Audit.Enabled && conf.Adapter.Audit.JournalFilePath != "" {
+		if _, err := audit.CompactJournal(true); err != nil {
+			logger.LoggerMgw.ErrorC(logging.ErrorDetails{
+				Message:   fmt.Sprintf("Error while persisting the audit journal snapshot. %v", err),
+				Severity:  logging.MINOR,
+				ErrorCode: 1124,
+			})
+		} else {
+			logger.LoggerMgw.Info("Audit journal snapshot is persisted")
+		}
+	}
+
+	health.XdsService.SetStatus(false)
+	grpcServerStopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(grpcServerStopped)
+	}()
+	cancelStreams()
+
+	select {
+	case <-grpcServerStopped:
+		logger.LoggerMgw.Info("Connections to the adapter are drained")
+	case <-time.After(time.Until(deadline)):
+		logger.LoggerMgw.Warn("Drain timeout elapsed, hence closing the remaining connections to the adapter")
+		grpcServer.Stop()
+	}
+}
@@ -0,0 +1,111 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package adapter
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/wso2/product-microgateway/adapter/internal/discovery/xds"
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/envoyconf"
+	"github.com/wso2/product-microgateway/adapter/pkg/logging"
+)
+
+// sniCertPoolDebounceInterval is the time the changes of the SNI certificate pool are settled before those are
+// applied, as a mounted kubernetes secret is updated with several changes of the files
+const sniCertPoolDebounceInterval = time.Second
+
+// watchSNICertPool applies the certificates added, removed or rotated in the SNI certificate pool directory to the
+// routers. The domain directories are watched along with the pool directory, as a mounted kubernetes secret is
+// updated by replacing the ..data link within the domain directory.
+func watchSNICertPool(poolDirectory string) {
+	watcher, err := fsnotify.NewWatcher()
+	if err == nil {
+		err = watchSNICertPoolDirectories(watcher, poolDirectory)
+	}
+	if err != nil {
+		logger.LoggerMgw.ErrorC(logging.ErrorDetails{
+			Message:   fmt.Sprintf("Error while watching the SNI certificate pool directory %s. %v", poolDirectory, err),
+			Severity:  logging.MAJOR,
+			ErrorCode: 1125,
+		})
+		return
+	}
+	var reloadTimer *time.Timer
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			if event.Op == fsnotify.Chmod {
+				continue
+			}
+			logger.LoggerMgw.Debugf("SNI certificate pool is changed (%s)", event.String())
+			if event.Op&fsnotify.Create == fsnotify.Create && filepath.Dir(event.Name) == filepath.Clean(poolDirectory) {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					if err = watcher.Add(event.Name); err != nil {
+						logger.LoggerMgw.Errorf("Error while watching the SNI certificate directory %s. %v",
+							event.Name, err)
+					}
+				}
+			}
+			if reloadTimer == nil {
+				reloadTimer = time.AfterFunc(sniCertPoolDebounceInterval, reloadSNICertPool)
+			} else {
+				reloadTimer.Reset(sniCertPoolDebounceInterval)
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			logger.LoggerMgw.Errorf("Error while watching the SNI certificate pool directory. %v", err)
+		}
+	}
+}
+
+// watchSNICertPoolDirectories watches the pool directory and its domain directories.
+func watchSNICertPoolDirectories(watcher *fsnotify.Watcher, poolDirectory string) error {
+	if err := watcher.Add(poolDirectory); err != nil {
+		return err
+	}
+	entries, err := os.ReadDir(poolDirectory)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		domainDirectory := filepath.Join(poolDirectory, entry.Name())
+		if info, err := os.Stat(domainDirectory); err == nil && info.IsDir() {
+			if err = watcher.Add(domainDirectory); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// reloadSNICertPool reads the SNI certificate pool again, and updates the listeners of the routers.
+func reloadSNICertPool() {
+	logger.LoggerMgw.Info("Applying the changes of the SNI certificate pool")
+	envoyconf.ReloadSNICertPool()
+	xds.UpdateXdsCacheForLabels(nil)
+}
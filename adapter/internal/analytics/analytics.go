@@ -0,0 +1,98 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+// Package analytics distributes the analytics configuration to the enforcer, which publishes the request, fault and
+// throttle events of the gateway to the configured analytics backend (Choreo or ELK).
+package analytics
+
+import (
+	"sync"
+
+	"github.com/wso2/product-microgateway/adapter/config"
+	"github.com/wso2/product-microgateway/adapter/internal/discovery/xds"
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	msg "github.com/wso2/product-microgateway/adapter/pkg/messaging"
+)
+
+var (
+	analyticsMutex sync.Mutex
+	// timestamp of the last analytics configuration event applied, as the events are not guaranteed to be
+	// received in order
+	lastEventTimestamp int64
+)
+
+// ApplyConfig validates the analytics configuration of the adapter and distributes it to the enforcer.
+// An invalid configuration is not distributed, and the error is returned.
+func ApplyConfig(conf *config.Config) error {
+	analyticsMutex.Lock()
+	defer analyticsMutex.Unlock()
+	if err := conf.Analytics.Validate(); err != nil {
+		return err
+	}
+	xds.UpdateEnforcerConfig(conf)
+	logger.LoggerAnalytics.Infof("Analytics configuration of type %s is distributed to the enforcer",
+		conf.Analytics.Type)
+	return nil
+}
+
+// HandleConfigEvent applies an analytics configuration change event received from the control plane, and
+// distributes the updated configuration to the enforcer. The analytics configuration is unchanged if the updated
+// configuration is invalid, and the error is returned.
+func HandleConfigEvent(event *msg.AnalyticsConfigEvent) error {
+	analyticsMutex.Lock()
+	defer analyticsMutex.Unlock()
+	if event.TimeStamp < lastEventTimestamp {
+		logger.LoggerAnalytics.Debugf("Analytics configuration event %s is dropped as a later event is applied",
+			event.EventID)
+		return nil
+	}
+
+	var err error
+	conf := config.UpdateConfig(func(conf *config.Config) {
+		updated := conf.Analytics
+		if event.AnalyticsType != "" {
+			updated.Type = event.AnalyticsType
+		}
+		properties := make(map[string]string, len(conf.Analytics.Enforcer.ConfigProperties)+len(event.ConfigProperties))
+		for key, value := range conf.Analytics.Enforcer.ConfigProperties {
+			properties[key] = value
+		}
+		for key, value := range event.ConfigProperties {
+			if value == "" {
+				delete(properties, key)
+				continue
+			}
+			properties[key] = value
+		}
+		updated.Enforcer.ConfigProperties = properties
+		if event.PublishIntervalInSeconds != nil {
+			updated.Enforcer.PublishIntervalInSeconds = *event.PublishIntervalInSeconds
+		}
+		if err = updated.Validate(); err != nil {
+			return
+		}
+		conf.Analytics = updated
+	})
+	if err != nil {
+		return err
+	}
+	lastEventTimestamp = event.TimeStamp
+	xds.UpdateEnforcerConfig(conf)
+	logger.LoggerAnalytics.Infof("Analytics configuration of type %s is updated by the event %s",
+		conf.Analytics.Type, event.EventID)
+	return nil
+}
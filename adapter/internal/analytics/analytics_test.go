@@ -0,0 +1,131 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package analytics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-microgateway/adapter/config"
+	"github.com/wso2/product-microgateway/adapter/internal/discovery/xds"
+	enforcerconfig "github.com/wso2/product-microgateway/adapter/pkg/discovery/api/wso2/discovery/config/enforcer"
+	wso2_resource "github.com/wso2/product-microgateway/adapter/pkg/discovery/protocol/resource/v3"
+	msg "github.com/wso2/product-microgateway/adapter/pkg/messaging"
+)
+
+func setupAnalyticsConfig(t *testing.T) *config.Config {
+	conf, _ := config.ReadConfigs()
+	analyticsConfig := conf.Analytics
+	conf.Analytics.Enabled = true
+	conf.Analytics.Type = config.AnalyticsTypeDefault
+	conf.Analytics.Enforcer.ConfigProperties = map[string]string{
+		config.AnalyticsAuthURLProperty:   "https://analytics-event-auth.choreo.dev/auth/v1",
+		config.AnalyticsAuthTokenProperty: "token",
+	}
+	lastEventTimestamp = 0
+	t.Cleanup(func() {
+		conf.Analytics = analyticsConfig
+		config.SetConfig(conf)
+		lastEventTimestamp = 0
+	})
+	return conf
+}
+
+func getEnforcerAnalyticsConfig(t *testing.T) *enforcerconfig.Analytics {
+	snapshot, err := xds.GetEnforcerCache().GetSnapshot("commonEnforcerLabel")
+	assert.Nil(t, err)
+	for _, resource := range snapshot.GetResourcesAndTTL(wso2_resource.ConfigType) {
+		return resource.Resource.(*enforcerconfig.Config).Analytics
+	}
+	return nil
+}
+
+func TestHandleConfigEvent(t *testing.T) {
+	conf := setupAnalyticsConfig(t)
+	publishInterval := uint32(10)
+	event := &msg.AnalyticsConfigEvent{
+		AnalyticsType: config.AnalyticsTypeELK,
+		ConfigProperties: map[string]string{
+			config.AnalyticsAuthTokenProperty:     "",
+			config.AnalyticsReporterClassProperty: "org.wso2.am.analytics.publisher.reporter.elk.ELKMetricReporter",
+		},
+		PublishIntervalInSeconds: &publishInterval,
+		Event:                    msg.Event{EventID: "event-1", TimeStamp: 2000},
+	}
+
+	previousConf := conf
+	assert.Nil(t, HandleConfigEvent(event))
+	assert.Equal(t, config.AnalyticsTypeDefault, previousConf.Analytics.Type,
+		"Configuration read before the event should not be changed")
+	conf, _ = config.ReadConfigs()
+	assert.Equal(t, config.AnalyticsTypeELK, conf.Analytics.Type)
+	assert.Equal(t, uint32(10), conf.Analytics.Enforcer.PublishIntervalInSeconds)
+	assert.Equal(t, map[string]string{
+		config.AnalyticsAuthURLProperty:       "https://analytics-event-auth.choreo.dev/auth/v1",
+		config.AnalyticsReporterClassProperty: "org.wso2.am.analytics.publisher.reporter.elk.ELKMetricReporter",
+	}, conf.Analytics.Enforcer.ConfigProperties)
+
+	enforcerAnalytics := getEnforcerAnalyticsConfig(t)
+	if assert.NotNil(t, enforcerAnalytics) {
+		assert.Equal(t, config.AnalyticsTypeELK, enforcerAnalytics.Type)
+		assert.Equal(t, "10", enforcerAnalytics.ConfigProperties[config.AnalyticsPublishIntervalProperty])
+		assert.NotContains(t, enforcerAnalytics.ConfigProperties, config.AnalyticsAuthTokenProperty)
+	}
+
+	// an event older than the event applied is dropped
+	olderEvent := &msg.AnalyticsConfigEvent{
+		AnalyticsType: config.AnalyticsTypeChoreo,
+		Event:         msg.Event{EventID: "event-0", TimeStamp: 1000},
+	}
+	assert.Nil(t, HandleConfigEvent(olderEvent))
+	conf, _ = config.ReadConfigs()
+	assert.Equal(t, config.AnalyticsTypeELK, conf.Analytics.Type)
+}
+
+func TestHandleInvalidConfigEvent(t *testing.T) {
+	conf := setupAnalyticsConfig(t)
+	event := &msg.AnalyticsConfigEvent{
+		ConfigProperties: map[string]string{config.AnalyticsAuthURLProperty: ""},
+		Event:            msg.Event{EventID: "event-1", TimeStamp: 1000},
+	}
+
+	err := HandleConfigEvent(event)
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "authURL and authToken are required")
+	}
+	conf, _ = config.ReadConfigs()
+	assert.Equal(t, "https://analytics-event-auth.choreo.dev/auth/v1",
+		conf.Analytics.Enforcer.ConfigProperties[config.AnalyticsAuthURLProperty],
+		"Analytics configuration should not be changed by an invalid event")
+	assert.Equal(t, int64(0), lastEventTimestamp)
+}
+
+func TestApplyConfig(t *testing.T) {
+	conf := setupAnalyticsConfig(t)
+	conf.Analytics.Enforcer.PublishIntervalInSeconds = 30
+
+	assert.Nil(t, ApplyConfig(conf))
+	enforcerAnalytics := getEnforcerAnalyticsConfig(t)
+	if assert.NotNil(t, enforcerAnalytics) {
+		assert.Equal(t, "30", enforcerAnalytics.ConfigProperties[config.AnalyticsPublishIntervalProperty])
+		assert.Equal(t, "token", enforcerAnalytics.ConfigProperties[config.AnalyticsAuthTokenProperty])
+	}
+
+	conf.Analytics.Type = "Kafka"
+	assert.NotNil(t, ApplyConfig(conf))
+}
@@ -0,0 +1,260 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/go-openapi/loads"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/validate"
+	"github.com/wso2/product-microgateway/adapter/config"
+	"github.com/wso2/product-microgateway/adapter/internal/discovery/xds"
+	"github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/constants"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/utills"
+	"github.com/wso2/product-microgateway/adapter/pkg/logging"
+	"github.com/wso2/product-microgateway/adapter/pkg/metrics"
+)
+
+// Checks applied to the API projects before the deployment
+const (
+	admissionCheckSchema    string = "schema"
+	admissionCheckEndpoint  string = "endpoint"
+	admissionCheckCollision string = "collision"
+)
+
+// AdmissionViolation is a reason to reject an API project before the deployment.
+type AdmissionViolation struct {
+	Check    string `json:"check"`
+	Location string `json:"location,omitempty"`
+	Message  string `json:"message"`
+}
+
+// AdmissionRejection is an API project rejected by the admission checks.
+type AdmissionRejection struct {
+	APIID          string               `json:"apiId,omitempty"`
+	APIName        string               `json:"apiName"`
+	APIVersion     string               `json:"apiVersion"`
+	OrganizationID string               `json:"organizationId,omitempty"`
+	DeployedBy     string               `json:"deployedBy,omitempty"`
+	RejectedAt     time.Time            `json:"rejectedAt"`
+	Violations     []AdmissionViolation `json:"violations"`
+}
+
+// AdmissionError is returned when an API project is rejected by the admission checks.
+type AdmissionError struct {
+	Rejection AdmissionRejection
+}
+
+var (
+	// admissionRejections holds the recent rejections, the latest first
+	admissionRejections      []AdmissionRejection
+	admissionRejectionsMutex sync.RWMutex
+)
+
+func (err *AdmissionError) Error() string {
+	violations := make([]string, 0, len(err.Rejection.Violations))
+	for _, violation := range err.Rejection.Violations {
+		if violation.Location != "" {
+			violations = append(violations, fmt.Sprintf("[%s] %s: %s", violation.Check, violation.Location,
+				violation.Message))
+		} else {
+			violations = append(violations, fmt.Sprintf("[%s] %s", violation.Check, violation.Message))
+		}
+	}
+	return fmt.Sprintf("API %s:%s is rejected by the admission checks. %s", err.Rejection.APIName,
+		err.Rejection.APIVersion, strings.Join(violations, "; "))
+}
+
+// GetAdmissionRejections returns the API projects recently rejected by the admission checks, the latest first.
+func GetAdmissionRejections() []AdmissionRejection {
+	admissionRejectionsMutex.RLock()
+	defer admissionRejectionsMutex.RUnlock()
+	return append([]AdmissionRejection{}, admissionRejections...)
+}
+
+// admitAPIProject checks the API project to be deployed to the given vhosts, and returns an AdmissionError if the
+// project violates any of the checks. The project is admitted without checking, if the admission is disabled.
+func admitAPIProject(apiProject model.ProjectAPI, vhosts []string) error {
+	conf, _ := config.ReadConfigs()
+	if !conf.Adapter.Admission.Enabled {
+		return nil
+	}
+	apiProject.SetSOAPAPIType()
+	apiYaml := apiProject.APIYaml.Data
+	var violations []AdmissionViolation
+	if conf.Adapter.Admission.SchemaValidation && apiYaml.APIType == constants.HTTP {
+		violations = append(violations, checkAPIDefinitionSchema(apiProject.APIDefinition)...)
+	}
+
+	var mgwSwagger model.MgwSwagger
+	err := mgwSwagger.PopulateFromAPIYaml(apiProject.APIYaml)
+	if err == nil {
+		err = mgwSwagger.PopulateFromAPIDefinitions(&apiProject)
+	}
+	if err != nil {
+		violations = append(violations, AdmissionViolation{
+			Check:   admissionCheckSchema,
+			Message: "API project could not be parsed. " + err.Error(),
+		})
+	} else {
+		violations = append(violations, checkEndpoints(&mgwSwagger)...)
+		for _, vhost := range vhosts {
+			if err := xds.CheckAPICollision(apiYaml.OrganizationID, vhost, apiYaml.ID, apiYaml.Name,
+				apiYaml.Version, mgwSwagger.GetXWso2Basepath()); err != nil {
+				violations = append(violations, AdmissionViolation{
+					Check:    admissionCheckCollision,
+					Location: "vhost " + vhost,
+					Message:  err.Error(),
+				})
+			}
+		}
+	}
+
+	violatedChecks := make([]string, 0, len(violations))
+	for _, violation := range violations {
+		violatedChecks = append(violatedChecks, violation.Check)
+	}
+	metrics.ObserveAPIAdmission(violatedChecks)
+	if len(violations) == 0 {
+		return nil
+	}
+	rejection := AdmissionRejection{
+		APIID:          apiYaml.ID,
+		APIName:        apiYaml.Name,
+		APIVersion:     apiYaml.Version,
+		OrganizationID: apiYaml.OrganizationID,
+		DeployedBy:     apiProject.DeployedBy,
+		RejectedAt:     time.Now().UTC(),
+		Violations:     violations,
+	}
+	recordAdmissionRejection(rejection, conf.Adapter.Admission.RejectionHistorySize)
+	admissionErr := &AdmissionError{Rejection: rejection}
+	loggers.LoggerAPI.ErrorC(logging.ErrorDetails{
+		Message:   admissionErr.Error(),
+		Severity:  logging.MINOR,
+		ErrorCode: 1235,
+	})
+	return admissionErr
+}
+
+func recordAdmissionRejection(rejection AdmissionRejection, historySize int) {
+	admissionRejectionsMutex.Lock()
+	defer admissionRejectionsMutex.Unlock()
+	admissionRejections = append([]AdmissionRejection{rejection}, admissionRejections...)
+	if historySize < 0 {
+		historySize = 0
+	}
+	if len(admissionRejections) > historySize {
+		admissionRejections = admissionRejections[:historySize]
+	}
+}
+
+// checkAPIDefinitionSchema validates the OpenAPI definition of an HTTP API against the specification.
+func checkAPIDefinitionSchema(definition []byte) []AdmissionViolation {
+	definitionJSON, err := utills.ToJSON(definition)
+	if err == nil {
+		switch utills.FindAPIDefinitionVersion(definitionJSON) {
+		case constants.Swagger2:
+			var document *loads.Document
+			if document, err = loads.Analyzed(json.RawMessage(definitionJSON), ""); err == nil {
+				err = validate.Spec(document, strfmt.Default)
+			}
+		case constants.OpenAPI3:
+			var swagger *openapi3.Swagger
+			if swagger, err = openapi3.NewSwaggerLoader().LoadSwaggerFromData(definitionJSON); err == nil {
+				err = swagger.Validate(context.Background())
+			}
+		default:
+			err = errors.New("OpenAPI version is not specified or not supported")
+		}
+	}
+	if err != nil {
+		return []AdmissionViolation{{
+			Check:    admissionCheckSchema,
+			Location: "API definition",
+			Message:  strings.Join(strings.Fields(err.Error()), " "),
+		}}
+	}
+	return nil
+}
+
+// checkEndpoints checks the URLs of the API level and the resource level endpoints of the API.
+func checkEndpoints(mgwSwagger *model.MgwSwagger) []AdmissionViolation {
+	if mgwSwagger.EndpointImplementationType == constants.MockedOASEndpointType ||
+		mgwSwagger.EndpointType == constants.AwsLambda {
+		return nil
+	}
+	violations := checkEndpointCluster("API production endpoints", mgwSwagger.GetProdEndpoints())
+	violations = append(violations, checkEndpointCluster("API sandbox endpoints", mgwSwagger.GetSandEndpoints())...)
+	for _, resource := range mgwSwagger.GetResources() {
+		violations = append(violations, checkEndpointCluster(resource.GetPath()+" production endpoints",
+			resource.GetProdEndpoints())...)
+		violations = append(violations, checkEndpointCluster(resource.GetPath()+" sandbox endpoints",
+			resource.GetSandEndpoints())...)
+	}
+	return violations
+}
+
+func checkEndpointCluster(location string, endpointCluster *model.EndpointCluster) []AdmissionViolation {
+	if endpointCluster == nil {
+		return nil
+	}
+	var violations []AdmissionViolation
+	for _, endpoint := range endpointCluster.Endpoints {
+		// the endpoints resolved from the service registries are not known until they are discovered
+		if endpoint.ServiceDiscoveryString != "" {
+			continue
+		}
+		if err := checkEndpointURL(endpoint); err != nil {
+			violations = append(violations, AdmissionViolation{
+				Check:    admissionCheckEndpoint,
+				Location: location,
+				Message:  fmt.Sprintf("%s: %v", endpoint.RawURL, err),
+			})
+		}
+	}
+	return violations
+}
+
+func checkEndpointURL(endpoint model.Endpoint) error {
+	if endpoint.URLType == "" {
+		return errors.New("scheme is not supported, use http, https, ws or wss")
+	}
+	if endpoint.Host == "" {
+		return errors.New("host is empty")
+	}
+	if endpoint.Port == 0 || endpoint.Port > 65535 {
+		return errors.New("port should be between 1 and 65535")
+	}
+	if parsedURL, err := url.Parse(endpoint.RawURL); err == nil && (parsedURL.RawQuery != "" ||
+		parsedURL.Fragment != "") {
+		return errors.New("query parameters and fragments are not supported")
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package api
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-microgateway/adapter/config"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
+)
+
+func TestCheckAPIDefinitionSchema(t *testing.T) {
+	definition, err := ioutil.ReadFile(config.GetMgwHome() + "/../adapter/test-resources/envoycodegen/openapi.yaml")
+	assert.Nil(t, err)
+	assert.Empty(t, checkAPIDefinitionSchema(definition))
+
+	violations := checkAPIDefinitionSchema([]byte(`{"openapi": "3.0.0", "info": {"title": "PetStore"}, "paths": {}}`))
+	assert.Len(t, violations, 1)
+	assert.Equal(t, admissionCheckSchema, violations[0].Check)
+
+	violations = checkAPIDefinitionSchema([]byte(`{"info": {"title": "PetStore", "version": "1.0.0"}}`))
+	assert.Len(t, violations, 1)
+	assert.Contains(t, violations[0].Message, "not supported")
+}
+
+func TestCheckEndpointURL(t *testing.T) {
+	dataItems := []struct {
+		endpoint model.Endpoint
+		valid    bool
+	}{
+		{model.Endpoint{URLType: "https", Host: "petstore.example.com", Port: 443,
+			RawURL: "https://petstore.example.com/v1"}, true},
+		{model.Endpoint{URLType: "", Host: "petstore.example.com", Port: 21,
+			RawURL: "ftp://petstore.example.com"}, false},
+		{model.Endpoint{URLType: "http", Host: "petstore.example.com", Port: 0,
+			RawURL: "http://petstore.example.com:abc"}, false},
+		{model.Endpoint{URLType: "http", Host: "petstore.example.com", Port: 80,
+			RawURL: "http://petstore.example.com/v1?debug=true"}, false},
+	}
+	for _, item := range dataItems {
+		err := checkEndpointURL(item.endpoint)
+		assert.Equal(t, item.valid, err == nil, item.endpoint.RawURL)
+	}
+}
+
+func TestRecordAdmissionRejection(t *testing.T) {
+	defer func() {
+		admissionRejections = nil
+	}()
+	for _, version := range []string{"1.0.0", "2.0.0", "3.0.0"} {
+		recordAdmissionRejection(AdmissionRejection{APIName: "PetStore", APIVersion: version}, 2)
+	}
+	rejections := GetAdmissionRejections()
+	assert.Len(t, rejections, 2)
+	assert.Equal(t, "3.0.0", rejections[0].APIVersion, "Latest rejection should be listed first.")
+	assert.Equal(t, "2.0.0", rejections[1].APIVersion)
+
+	err := &AdmissionError{Rejection: AdmissionRejection{APIName: "PetStore", APIVersion: "1.0.0",
+		Violations: []AdmissionViolation{
+			{Check: admissionCheckEndpoint, Location: "API production endpoints", Message: "host is empty"},
+			{Check: admissionCheckCollision, Message: "the basepath /petstore is already used"},
+		}}}
+	assert.Equal(t, "API PetStore:1.0.0 is rejected by the admission checks. [endpoint] API production endpoints: "+
+		"host is empty; [collision] the basepath /petstore is already used", err.Error())
+}
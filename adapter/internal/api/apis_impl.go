@@ -30,6 +30,7 @@ import (
 
 	"github.com/wso2/product-microgateway/adapter/config"
 	apiModel "github.com/wso2/product-microgateway/adapter/internal/api/models"
+	"github.com/wso2/product-microgateway/adapter/internal/audit"
 	xds "github.com/wso2/product-microgateway/adapter/internal/discovery/xds"
 	"github.com/wso2/product-microgateway/adapter/internal/loggers"
 	"github.com/wso2/product-microgateway/adapter/internal/notifier"
@@ -44,6 +45,9 @@ const (
 	apisArtifactDir string = "apis"
 )
 
+// subscriptionReplayer rebuilds the subscriptions from the subscription records of the journal
+var subscriptionReplayer func(records []audit.ChangeRecord) error
+
 // extractAPIProject accepts the API project as a zip file and returns the extracted content.
 // The apictl project must be in zipped format.
 // API type is decided by the type field in the api.yaml file.
@@ -113,81 +117,89 @@ func ProcessMountedAPIProjects() (artifactsMap map[string]model.ProjectAPI, err
 		if strings.HasPrefix(apiProjectFile.Name(), ".") {
 			continue
 		}
+		if !apiProjectFile.IsDir() && !strings.HasSuffix(apiProjectFile.Name(), zipExt) {
+			continue
+		}
+		apiProject, err := ProcessMountedAPIProject(apisDirName, apiProjectFile)
+		if err != nil {
+			continue
+		}
+		artifactsMap[apiProjectFile.Name()] = apiProject
+	}
+	return artifactsMap, nil
+}
 
-		if apiProjectFile.IsDir() {
-			apiProject := model.ProjectAPI{
-				EndpointCerts: make(map[string]string),
-				UpstreamCerts: make(map[string][]byte),
-				Policies:      make(map[string]model.PolicyContainer),
-			}
-			err = filepath.Walk(filepath.FromSlash(apisDirName+"/"+apiProjectFile.Name()), func(path string, info os.FileInfo, err error) error {
-
-				if !info.IsDir() {
-					fileContent, err := ioutil.ReadFile(path)
-					if err != nil {
-						return err
-					}
-					return processFileInsideProject(&apiProject, fileContent, path)
-				}
-				return nil
-			})
-			if err != nil {
-				loggers.LoggerAPI.ErrorC(logging.ErrorDetails{
-					Message:   fmt.Sprintf("Error while processing api artifact - %s during startup : %s", apiProjectFile.Name(), err.Error()),
-					Severity:  logging.MAJOR,
-					ErrorCode: 1207,
-				})
-				continue
-			}
-			err = apiProject.APIYaml.ValidateAPIType()
-			if err != nil {
-				loggers.LoggerAPI.ErrorC(logging.ErrorDetails{
-					Message:   fmt.Sprintf("Error while validating the API type - %s during startup : %s", apiProjectFile.Name(), err.Error()),
-					Severity:  logging.MAJOR,
-					ErrorCode: 1208,
-				})
-				continue
-			}
+// ProcessMountedAPIProject applies the API project of the given directory or zip file located within the api
+// artifacts directory.
+func ProcessMountedAPIProject(apisDirName string, apiProjectFile os.FileInfo) (apiProject model.ProjectAPI, err error) {
+	if apiProjectFile.IsDir() {
+		apiProject = model.ProjectAPI{
+			EndpointCerts: make(map[string]string),
+			UpstreamCerts: make(map[string][]byte),
+			Policies:      make(map[string]model.PolicyContainer),
+		}
+		err = filepath.Walk(filepath.FromSlash(apisDirName+"/"+apiProjectFile.Name()), func(path string, info os.FileInfo, err error) error {
 
-			overrideValue := true
-			apiProject, err = validateAndUpdateXds(apiProject, &overrideValue)
-			if err != nil {
-				loggers.LoggerAPI.ErrorC(logging.ErrorDetails{
-					Message:   fmt.Sprintf("Error while processing(validate and update xds) api artifact - %s during startup : %v", apiProjectFile.Name(), err.Error()),
-					Severity:  logging.MAJOR,
-					ErrorCode: 1209,
-				})
-				continue
+			if !info.IsDir() {
+				fileContent, err := ioutil.ReadFile(path)
+				if err != nil {
+					return err
+				}
+				return processFileInsideProject(&apiProject, fileContent, path)
 			}
-			artifactsMap[apiProjectFile.Name()] = apiProject
-			continue
-		} else if !strings.HasSuffix(apiProjectFile.Name(), zipExt) {
-			continue
+			return nil
+		})
+		if err != nil {
+			loggers.LoggerAPI.ErrorC(logging.ErrorDetails{
+				Message:   fmt.Sprintf("Error while processing api artifact - %s during startup : %s", apiProjectFile.Name(), err.Error()),
+				Severity:  logging.MAJOR,
+				ErrorCode: 1207,
+			})
+			return apiProject, err
 		}
-		data, err := ioutil.ReadFile(filepath.FromSlash(apisDirName + "/" + apiProjectFile.Name()))
+		err = apiProject.APIYaml.ValidateAPIType()
 		if err != nil {
 			loggers.LoggerAPI.ErrorC(logging.ErrorDetails{
-				Message:   fmt.Sprintf("Error while reading api artifact - %s during startup : %v", apiProjectFile.Name(), err.Error()),
+				Message:   fmt.Sprintf("Error while validating the API type - %s during startup : %s", apiProjectFile.Name(), err.Error()),
 				Severity:  logging.MAJOR,
-				ErrorCode: 1210,
+				ErrorCode: 1208,
 			})
-			continue
+			return apiProject, err
 		}
 
-		// logger.LoggerMgw.Debugf("API artifact  - %s is read successfully.", file.Name())
-		overrideAPIParam := true
-		apiProject, err := ApplyAPIProjectInStandaloneMode(data, &overrideAPIParam)
+		overrideValue := true
+		apiProject.DeployedBy = audit.ActorArtifactsDirectory
+		apiProject, err = validateAndUpdateXds(apiProject, &overrideValue)
 		if err != nil {
 			loggers.LoggerAPI.ErrorC(logging.ErrorDetails{
-				Message:   fmt.Sprintf("Error while processing(apply api project in standalone mode) api artifact - %s during startup : %v", apiProjectFile.Name(), err.Error()),
+				Message:   fmt.Sprintf("Error while processing(validate and update xds) api artifact - %s during startup : %v", apiProjectFile.Name(), err.Error()),
 				Severity:  logging.MAJOR,
-				ErrorCode: 1211,
+				ErrorCode: 1209,
 			})
-			continue
 		}
-		artifactsMap[apiProjectFile.Name()] = apiProject
+		return apiProject, err
 	}
-	return artifactsMap, nil
+	data, err := ioutil.ReadFile(filepath.FromSlash(apisDirName + "/" + apiProjectFile.Name()))
+	if err != nil {
+		loggers.LoggerAPI.ErrorC(logging.ErrorDetails{
+			Message:   fmt.Sprintf("Error while reading api artifact - %s during startup : %v", apiProjectFile.Name(), err.Error()),
+			Severity:  logging.MAJOR,
+			ErrorCode: 1210,
+		})
+		return apiProject, err
+	}
+
+	// logger.LoggerMgw.Debugf("API artifact  - %s is read successfully.", file.Name())
+	overrideAPIParam := true
+	apiProject, err = ApplyAPIProjectInStandaloneMode(data, &overrideAPIParam, audit.ActorArtifactsDirectory)
+	if err != nil {
+		loggers.LoggerAPI.ErrorC(logging.ErrorDetails{
+			Message:   fmt.Sprintf("Error while processing(apply api project in standalone mode) api artifact - %s during startup : %v", apiProjectFile.Name(), err.Error()),
+			Severity:  logging.MAJOR,
+			ErrorCode: 1211,
+		})
+	}
+	return apiProject, err
 }
 
 func validateAndUpdateXds(apiProject model.ProjectAPI, override *bool) (updatedAPIProject model.ProjectAPI, err error) {
@@ -201,6 +213,10 @@ func validateAndUpdateXds(apiProject model.ProjectAPI, override *bool) (updatedA
 		}
 	}()
 
+	if err = checkAPIProjectCapabilities(apiProject); err != nil {
+		return updatedAPIProject, err
+	}
+
 	var overrideValue bool
 	if override == nil {
 		overrideValue = false
@@ -241,6 +257,9 @@ func validateAndUpdateXds(apiProject model.ProjectAPI, override *bool) (updatedA
 		vhostToEnvsMap[environment.DeploymentVhost] =
 			append(vhostToEnvsMap[environment.DeploymentVhost], environment.DeploymentEnvironment)
 	}
+	if err = admitAPIProject(apiProject, getVhosts(vhostToEnvsMap)); err != nil {
+		return updatedAPIProject, err
+	}
 
 	// Updating cache one API by one API, if one API failed to update cache continue with others.
 	for vhost, environments := range vhostToEnvsMap {
@@ -253,6 +272,23 @@ func validateAndUpdateXds(apiProject model.ProjectAPI, override *bool) (updatedA
 	return updatedAPIProject, nil
 }
 
+func getVhosts(vhostToEnvsMap map[string][]string) []string {
+	vhosts := make([]string, 0, len(vhostToEnvsMap))
+	for vhost := range vhostToEnvsMap {
+		vhosts = append(vhosts, vhost)
+	}
+	return vhosts
+}
+
+// GetAPIIDOfProject returns the UUID of the API in the API project (zip) received from the control plane.
+func GetAPIIDOfProject(payload []byte) (string, error) {
+	apiProject, err := extractAPIProject(payload)
+	if err != nil {
+		return "", err
+	}
+	return apiProject.APIYaml.Data.ID, nil
+}
+
 // ApplyAPIProjectFromAPIM accepts an apictl project (as a byte array), list of vhosts with respective environments
 // and updates the xds servers based upon the content.
 func ApplyAPIProjectFromAPIM(
@@ -264,10 +300,20 @@ func ApplyAPIProjectFromAPIM(
 	if err != nil {
 		return nil, err
 	}
+	apiProject.DeployedBy = audit.ActorControlPlane
+	if err = checkAPIProjectCapabilities(apiProject); err != nil {
+		return nil, err
+	}
 	apiYaml := &apiProject.APIYaml.Data
 	if apiEnvProps, found := apiEnvs[apiProject.APIYaml.Data.ID]; found {
 		loggers.LoggerAPI.Infof("Environment specific values found for the API %v ", apiProject.APIYaml.Data.ID)
-		apiProject.APIEnvProps = apiEnvProps
+		// the values of the control plane take precedence over the values of the API project
+		if apiProject.APIEnvProps == nil {
+			apiProject.APIEnvProps = make(map[string]synchronizer.APIEnvProps, len(apiEnvProps))
+		}
+		for envLabel, envProps := range apiEnvProps {
+			apiProject.APIEnvProps[envLabel] = envProps
+		}
 	}
 
 	// handle panic
@@ -279,6 +325,9 @@ func ApplyAPIProjectFromAPIM(
 	}()
 
 	loggers.LoggerAPI.Infof("Deploying api %s:%s in Organization %s", apiYaml.Name, apiYaml.Version, apiYaml.OrganizationID)
+	if err := admitAPIProject(apiProject, getVhosts(vhostToEnvsMap)); err != nil {
+		return nil, err
+	}
 
 	// vhostsToRemove contains vhosts and environments to undeploy
 	vhostsToRemove := make(map[string][]string)
@@ -336,15 +385,114 @@ func ApplyAPIProjectFromAPIM(
 }
 
 // ApplyAPIProjectInStandaloneMode is called by the rest implementation to differentiate
-// between create and update using the override param
-func ApplyAPIProjectInStandaloneMode(payload []byte, override *bool) (apiProject model.ProjectAPI, err error) {
+// between create and update using the override param. deployedBy is the user or the component
+// deploying the project.
+func ApplyAPIProjectInStandaloneMode(payload []byte, override *bool, deployedBy string) (apiProject model.ProjectAPI,
+	err error) {
 	apiProject, err = extractAPIProject(payload)
 	if err != nil {
 		return apiProject, err
 	}
+	apiProject.DeployedBy = deployedBy
 	return validateAndUpdateXds(apiProject, override)
 }
 
+// RebuildAPIsFromJournal replaces the deployed APIs with the latest deployment of each API in the audit
+// journal, and updates the caches once all the APIs are rebuilt. The subscriptions are rebuilt afterwards from the
+// subscription data in the journal. The APIs which would be rebuilt are returned without altering the deployed APIs,
+// if dryRun is true. onProgress is called after each API is rebuilt.
+func RebuildAPIsFromJournal(dryRun bool, onProgress func(rebuilt, total int)) (*audit.RebuildResult, error) {
+	compacted, err := audit.CompactJournal(!dryRun)
+	if err != nil {
+		return nil, err
+	}
+	deployments := compacted.Deployments
+	result := &audit.RebuildResult{
+		DryRun:              dryRun,
+		JournalRecords:      compacted.JournalRecords,
+		APIs:                []audit.RebuiltAPI{},
+		SubscriptionRecords: len(compacted.Subscriptions),
+	}
+	if dryRun {
+		for _, record := range deployments {
+			result.APIs = append(result.APIs, audit.NewRebuiltAPI(record))
+		}
+		return result, nil
+	}
+
+	xds.RebuildAPIs(func(replayAPI func(vHost string, apiProject model.ProjectAPI, environments []string) error) {
+		for i, record := range deployments {
+			rebuiltAPI := audit.NewRebuiltAPI(record)
+			err := replayDeployment(record, replayAPI)
+			if onProgress != nil {
+				onProgress(i+1, len(deployments))
+			}
+			if err != nil {
+				loggers.LoggerAPI.ErrorC(logging.ErrorDetails{
+					Message: fmt.Sprintf("Error while rebuilding the API %s of Organization %s from the journal. %v",
+						record.APIIdentifier, record.OrganizationID, err),
+					Severity:  logging.MAJOR,
+					ErrorCode: 1233,
+				})
+				rebuiltAPI.Error = err.Error()
+				result.FailedAPIs = append(result.FailedAPIs, rebuiltAPI)
+				continue
+			}
+			result.APIs = append(result.APIs, rebuiltAPI)
+		}
+	})
+	loggers.LoggerAPI.Infof("Rebuilt %d APIs from the journal, while %d APIs failed.", len(result.APIs),
+		len(result.FailedAPIs))
+
+	if subscriptionReplayer != nil && len(compacted.Subscriptions) > 0 {
+		if err := subscriptionReplayer(compacted.Subscriptions); err != nil {
+			loggers.LoggerAPI.ErrorC(logging.ErrorDetails{
+				Message:   fmt.Sprintf("Error while rebuilding the subscriptions from the journal. %v", err),
+				Severity:  logging.MAJOR,
+				ErrorCode: 1239,
+			})
+			result.SubscriptionError = err.Error()
+		}
+	}
+	return result, nil
+}
+
+// SetSubscriptionReplayer sets the function, by which the subscriptions are rebuilt from the subscription records
+// of the journal.
+func SetSubscriptionReplayer(replayer func(records []audit.ChangeRecord) error) {
+	subscriptionReplayer = replayer
+}
+
+// replayDeployment extracts the API project from the deployed artifact and updates the internal maps by replayAPI.
+func replayDeployment(record audit.ChangeRecord,
+	replayAPI func(vHost string, apiProject model.ProjectAPI, environments []string) error) (err error) {
+	// handle panic
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("recovered from panic. %v", r)
+		}
+	}()
+
+	apiProject := model.ProjectAPI{
+		UpstreamCerts:   make(map[string][]byte),
+		EndpointCerts:   make(map[string]string),
+		Policies:        make(map[string]model.PolicyContainer),
+		DownstreamCerts: make(map[string][]byte),
+	}
+	for _, file := range record.Artifact.Files {
+		if err = processFileInsideProject(&apiProject, file.Content, file.Name); err != nil {
+			return err
+		}
+	}
+	if err = apiProject.APIYaml.ValidateAPIType(); err != nil {
+		return err
+	}
+	apiProject.DeployedBy = record.Actor
+	apiProject.APIEnvProps = record.Artifact.APIEnvProps
+	apiProject.APIYaml.Data.IsDefaultVersion = record.Artifact.IsDefaultVersion
+	return replayAPI(record.VHost, apiProject, record.Environments)
+}
+
 // ListApis calls the ListApis method in xds_server.go
 func ListApis(query *string, limit *int64, organizationID string) *apiModel.APIMeta {
 	var apiType string
@@ -17,10 +17,15 @@
 package api
 
 import (
+	"archive/zip"
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
+	"path"
+	"path/filepath"
 	"strings"
 
 	"github.com/vektah/gqlparser/v2"
@@ -30,6 +35,7 @@ import (
 	"github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
 	"github.com/wso2/product-microgateway/adapter/internal/oasparser/utills"
 	"github.com/wso2/product-microgateway/adapter/pkg/logging"
+	"github.com/wso2/product-microgateway/adapter/pkg/synchronizer"
 	"github.com/wso2/product-microgateway/adapter/pkg/tlsutils"
 	"gopkg.in/yaml.v2"
 )
@@ -40,18 +46,28 @@ const (
 	asyncAPIFilename           string = "asyncapi."
 	graphQLAPIFilename         string = "schema."
 	graphQLComplexityFileName  string = "graphql-complexity"
+	protoDescriptorFile        string = "proto_descriptor."
+	wsdlDir                    string = "WSDL"
+	wsdlExt                    string = ".wsdl"
 	apiYAMLFile                string = "api.yaml"
 	deploymentsYAMLFile        string = "deployment_environments.yaml"
+	interceptorsFile           string = "interceptors"
+	envPropertiesFile          string = "env_properties"
 	endpointCertFile           string = "endpoint_certificates."
 	clientCertFile             string = "client_certificates."
 	apiJSONFile                string = "api.json"
 	endpointCertDir            string = "Endpoint-certificates"
 	clientCertDir              string = "Client-certificates"
 	interceptorCertDir         string = "Endpoint-certificates/interceptors"
+	endpointClientCertDir      string = "Endpoint-certificates/client"
+	endpointClientCertFile     string = "endpoint_client_certificates."
 	policiesDir                string = "Policies"
+	docsDir                    string = "Docs"
+	docMetadataFile            string = "document."
 	policyDefFileExtension     string = ".gotmpl"
 	crtExtension               string = ".crt"
 	pemExtension               string = ".pem"
+	keyExtension               string = ".key"
 	apiTypeFilterKey           string = "type"
 	apiTypeYamlKey             string = "type"
 	lifeCycleStatus            string = "lifeCycleStatus"
@@ -65,11 +81,15 @@ const (
 	jsonExt                    string = ".json"
 )
 
+// maxWSDLArchiveEntrySize is the maximum size of a WSDL file of a WSDL archive, which is read to the memory.
+const maxWSDLArchiveEntrySize int64 = 10 << 20
+
 // processFileInsideProject method process one file at a time and
 // update the apiProject instance appropriately. Files could be: /petstore,
 // /petstore/Definition, /petstore/Definition/swagger.yaml, /petstore/api.yaml, etc.
 func processFileInsideProject(apiProject *model.ProjectAPI, fileContent []byte, fileName string) (err error) {
 	newLineByteArray := []byte("\n")
+	apiProject.Files = append(apiProject.Files, model.ProjectFile{Name: fileName, Content: fileContent})
 
 	// Deployment file
 	if strings.Contains(fileName, deploymentsYAMLFile) {
@@ -85,6 +105,95 @@ func processFileInsideProject(apiProject *model.ProjectAPI, fileContent []byte,
 		apiProject.Deployments = deployments
 	}
 
+	// API docs. Checked first, as a doc file name may match the other project files (ie: api.yaml)
+	docsPath := string(os.PathSeparator) + docsDir + string(os.PathSeparator)
+	if docsIndex := strings.Index(fileName, docsPath); docsIndex >= 0 {
+		// document.yaml contains the metadata of the doc in the developer portal
+		if strings.HasPrefix(filepath.Base(fileName), docMetadataFile) {
+			return nil
+		}
+		loggers.LoggerAPI.Debugf("API doc file : %v", fileName)
+		if apiProject.APIDocs == nil {
+			apiProject.APIDocs = make(map[string][]byte)
+		}
+		// the docs are keyed by the path within the docs directory (ie: <doc name>/<file name>), as the files
+		// of different docs may have the same name
+		apiProject.APIDocs[filepath.ToSlash(fileName[docsIndex+len(docsPath):])] = fileContent
+		return nil
+	}
+
+	// Interceptor services of the API, at the root of the project
+	if baseName := filepath.Base(fileName); (baseName == interceptorsFile+yamlExt ||
+		baseName == interceptorsFile+jsonExt) && !strings.Contains(fileName, apiDefinitionDir) &&
+		!strings.Contains(fileName, endpointCertDir) {
+		var interceptorsYaml model.InterceptorsYaml
+		interceptorsJSON, conversionErr := utills.ToJSON(fileContent)
+		if conversionErr == nil {
+			conversionErr = json.Unmarshal(interceptorsJSON, &interceptorsYaml)
+		}
+		if conversionErr != nil {
+			loggers.LoggerAPI.ErrorC(logging.ErrorDetails{
+				Message:   fmt.Sprintf("Error while parsing the interceptors of the API project. %v", conversionErr),
+				Severity:  logging.MINOR,
+				ErrorCode: 1236,
+			})
+			return conversionErr
+		}
+		apiProject.Interceptors = interceptorsYaml
+		return nil
+	}
+
+	// Environment specific endpoints of the API, at the root of the project, keyed by the gateway label
+	if baseName := filepath.Base(fileName); (baseName == envPropertiesFile+yamlExt ||
+		baseName == envPropertiesFile+jsonExt) && !strings.Contains(fileName, apiDefinitionDir) {
+		var envConfigs map[string]synchronizer.APIConfigs
+		envPropsJSON, conversionErr := utills.ToJSON(fileContent)
+		if conversionErr == nil {
+			conversionErr = json.Unmarshal(envPropsJSON, &envConfigs)
+		}
+		if conversionErr != nil {
+			loggers.LoggerAPI.ErrorC(logging.ErrorDetails{
+				Message:   fmt.Sprintf("Error while parsing the environment specific properties of the API project. %v", conversionErr),
+				Severity:  logging.MINOR,
+				ErrorCode: 1237,
+			})
+			return conversionErr
+		}
+		if apiProject.APIEnvProps == nil {
+			apiProject.APIEnvProps = make(map[string]synchronizer.APIEnvProps, len(envConfigs))
+		}
+		for envLabel, envConfig := range envConfigs {
+			apiProject.APIEnvProps[envLabel] = synchronizer.APIEnvProps{APIConfigs: envConfig}
+		}
+		return nil
+	}
+
+	// Proto descriptor set of the gRPC services of the backend, which the requests of the API are transcoded to
+	if strings.Contains(fileName, apiDefinitionDir+string(os.PathSeparator)+protoDescriptorFile) {
+		loggers.LoggerAPI.Debugf("Proto descriptor set file : %v", fileName)
+		apiProject.ProtoDescriptor = fileContent
+		return nil
+	}
+
+	// WSDL definition of a SOAP API, as a single file or as an archive of the WSDL and the imported files
+	if strings.Contains(fileName, string(os.PathSeparator)+wsdlDir+string(os.PathSeparator)) &&
+		(strings.HasSuffix(fileName, wsdlExt) || strings.HasSuffix(fileName, zipExt)) {
+		loggers.LoggerAPI.Debugf("WSDL file : %v", fileName)
+		wsdl := fileContent
+		if strings.HasSuffix(fileName, zipExt) {
+			if wsdl, err = getWSDLFromArchive(fileContent); err != nil {
+				loggers.LoggerAPI.ErrorC(logging.ErrorDetails{
+					Message:   fmt.Sprintf("Error occurred while reading the WSDL archive %v: %v", fileName, err.Error()),
+					Severity:  logging.MAJOR,
+					ErrorCode: 1238,
+				})
+				return err
+			}
+		}
+		apiProject.WSDL = wsdl
+		return nil
+	}
+
 	// API definition file
 	if strings.Contains(fileName, apiDefinitionDir+string(os.PathSeparator)+openAPIFilename) ||
 		strings.Contains(fileName, apiDefinitionDir+string(os.PathSeparator)+asyncAPIFilename) {
@@ -100,6 +209,35 @@ func processFileInsideProject(apiProject *model.ProjectAPI, fileContent []byte,
 			return conversionErr
 		}
 		apiProject.APIDefinition = swaggerJsn
+		// Endpoint client certs, for mutual TLS with the endpoints
+	} else if strings.Contains(fileName, endpointClientCertDir+string(os.PathSeparator)) {
+		if strings.Contains(fileName, endpointClientCertFile) {
+			clCertJSON, conversionErr := utills.ToJSON(fileContent)
+			if conversionErr != nil {
+				loggers.LoggerAPI.ErrorC(logging.ErrorDetails{
+					Message:   fmt.Sprintf("Error converting %v file to json: %v", fileName, conversionErr.Error()),
+					Severity:  logging.MINOR,
+					ErrorCode: 1231,
+				})
+				return conversionErr
+			}
+			endpointClientCerts := &model.EndpointClientCertificatesDetails{}
+			if err := json.Unmarshal(clCertJSON, endpointClientCerts); err != nil {
+				loggers.LoggerAPI.ErrorC(logging.ErrorDetails{
+					Message:   fmt.Sprintf("Error parsing content of endpoint client certificates: %v", err.Error()),
+					Severity:  logging.MINOR,
+					ErrorCode: 1232,
+				})
+				return err
+			}
+			apiProject.EndpointClientCerts = endpointClientCerts.Data
+		} else if strings.HasSuffix(fileName, crtExtension) || strings.HasSuffix(fileName, pemExtension) ||
+			strings.HasSuffix(fileName, keyExtension) {
+			if apiProject.UpstreamClientCerts == nil {
+				apiProject.UpstreamClientCerts = make(map[string][]byte)
+			}
+			apiProject.UpstreamClientCerts[filepath.Base(fileName)] = fileContent
+		}
 		// Interceptor certs
 	} else if strings.Contains(fileName, interceptorCertDir+string(os.PathSeparator)) &&
 		(strings.HasSuffix(fileName, crtExtension) || strings.HasSuffix(fileName, pemExtension)) {
@@ -113,6 +251,10 @@ func processFileInsideProject(apiProject *model.ProjectAPI, fileContent []byte,
 		}
 		apiProject.InterceptorCerts = append(apiProject.InterceptorCerts, fileContent...)
 		apiProject.InterceptorCerts = append(apiProject.InterceptorCerts, newLineByteArray...)
+		if apiProject.InterceptorCertMap == nil {
+			apiProject.InterceptorCertMap = make(map[string][]byte)
+		}
+		apiProject.InterceptorCertMap[filepath.Base(fileName)] = fileContent
 		// Endpoint certs
 	} else if strings.Contains(fileName, endpointCertDir+string(os.PathSeparator)) {
 		if strings.Contains(fileName, endpointCertFile) {
@@ -341,3 +483,86 @@ func parseDeployments(data []byte) ([]model.Deployment, error) {
 	}
 	return deployments, nil
 }
+
+// getWSDLFromArchive returns the WSDL definition declaring the service, from a WSDL archive containing the WSDL and
+// the WSDL files imported by that. The imports of the definitions are resolved across the files of the archive. The
+// first WSDL definition of the archive is returned if none declares a service.
+func getWSDLFromArchive(archive []byte) ([]byte, error) {
+	zipReader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return nil, err
+	}
+	files := make(map[string]*zip.File)
+	var fileNames []string
+	for _, file := range zipReader.File {
+		if file.FileInfo().IsDir() || !strings.HasSuffix(file.Name, wsdlExt) {
+			continue
+		}
+		if file.UncompressedSize64 > uint64(maxWSDLArchiveEntrySize) {
+			return nil, fmt.Errorf("file %v of the WSDL archive exceeds the maximum size of %d bytes", file.Name,
+				maxWSDLArchiveEntrySize)
+		}
+		fileName := path.Clean(file.Name)
+		files[fileName] = file
+		fileNames = append(fileNames, fileName)
+	}
+	contents := make(map[string][]byte)
+	readFile := func(fileName string) ([]byte, error) {
+		if content, found := contents[fileName]; found {
+			return content, nil
+		}
+		file, found := files[fileName]
+		if !found {
+			return nil, fmt.Errorf("file %v is not found in the WSDL archive", fileName)
+		}
+		content, err := readWSDLArchiveEntry(file)
+		if err != nil {
+			return nil, err
+		}
+		contents[fileName] = content
+		return content, nil
+	}
+
+	var firstWSDL []byte
+	for _, fileName := range fileNames {
+		content, err := model.ResolveWSDLImports(fileName, readFile)
+		if err != nil {
+			loggers.LoggerAPI.Debugf("Skipping the file %v of the WSDL archive. %v", fileName, err)
+			continue
+		}
+		wsdl, err := model.ParseWSDL(content)
+		if err != nil {
+			loggers.LoggerAPI.Debugf("Skipping the file %v of the WSDL archive. %v", fileName, err)
+			continue
+		}
+		if len(wsdl.Services) > 0 {
+			return content, nil
+		}
+		if firstWSDL == nil {
+			firstWSDL = content
+		}
+	}
+	if firstWSDL == nil {
+		return nil, errors.New("no WSDL 1.1 definition with a SOAP binding is found in the archive")
+	}
+	return firstWSDL, nil
+}
+
+// readWSDLArchiveEntry reads a file of a WSDL archive, up to the maximum size of the files. The size declared in
+// the archive is not relied on, as it could differ from the decompressed content.
+func readWSDLArchiveEntry(file *zip.File) ([]byte, error) {
+	reader, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	content, err := io.ReadAll(io.LimitReader(reader, maxWSDLArchiveEntrySize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(content)) > maxWSDLArchiveEntrySize {
+		return nil, fmt.Errorf("file %v of the WSDL archive exceeds the maximum size of %d bytes", file.Name,
+			maxWSDLArchiveEntrySize)
+	}
+	return content, nil
+}
@@ -0,0 +1,39 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
+)
+
+func TestProcessAPIDocFiles(t *testing.T) {
+	var apiProject model.ProjectAPI
+	for fileName, content := range map[string]string{
+		"PetStore-1.0.0/Docs/Getting Started/readme.md":     "# Getting Started",
+		"PetStore-1.0.0/Docs/Getting Started/document.yaml": "type: document",
+		"PetStore-1.0.0/Docs/Reference/readme.md":           "# Reference",
+	} {
+		assert.Nil(t, processFileInsideProject(&apiProject, []byte(content), fileName))
+	}
+	assert.Equal(t, map[string][]byte{
+		"Getting Started/readme.md": []byte("# Getting Started"),
+		"Reference/readme.md":       []byte("# Reference"),
+	}, apiProject.APIDocs, "Docs with the same file name should not overwrite each other")
+}
@@ -22,9 +22,12 @@ package models
 
 import (
 	"context"
+	"encoding/json"
 
+	"github.com/go-openapi/errors"
 	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
 )
 
 // APIMetaListItem API meta list item
@@ -44,6 +47,14 @@ type APIMetaListItem struct {
 	// gateway envs
 	GatewayEnvs []string `json:"gateway-envs"`
 
+	// Lifecycle status of the API. Empty if the lifecycle status is not given in the API project.
+	// Enum: [PUBLISHED BLOCKED DEPRECATED]
+	LifecycleStatus string `json:"lifecycleStatus,omitempty"`
+
+	// Health status of the API reported by the synthetic monitoring. Empty if the API is not probed.
+	// Enum: [HEALTHY DEGRADED]
+	Status string `json:"status,omitempty"`
+
 	// version
 	Version string `json:"version,omitempty"`
 
@@ -53,6 +64,106 @@ type APIMetaListItem struct {
 
 // Validate validates this API meta list item
 func (m *APIMetaListItem) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateLifecycleStatus(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateStatus(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+var apiMetaListItemTypeLifecycleStatusPropEnum []interface{}
+
+func init() {
+	var res []string
+	if err := json.Unmarshal([]byte(`["PUBLISHED","BLOCKED","DEPRECATED"]`), &res); err != nil {
+		panic(err)
+	}
+	for _, v := range res {
+		apiMetaListItemTypeLifecycleStatusPropEnum = append(apiMetaListItemTypeLifecycleStatusPropEnum, v)
+	}
+}
+
+const (
+
+	// APIMetaListItemLifecycleStatusPUBLISHED captures enum value "PUBLISHED"
+	APIMetaListItemLifecycleStatusPUBLISHED string = "PUBLISHED"
+
+	// APIMetaListItemLifecycleStatusBLOCKED captures enum value "BLOCKED"
+	APIMetaListItemLifecycleStatusBLOCKED string = "BLOCKED"
+
+	// APIMetaListItemLifecycleStatusDEPRECATED captures enum value "DEPRECATED"
+	APIMetaListItemLifecycleStatusDEPRECATED string = "DEPRECATED"
+)
+
+// prop value enum
+func (m *APIMetaListItem) validateLifecycleStatusEnum(path, location string, value string) error {
+	if err := validate.EnumCase(path, location, value, apiMetaListItemTypeLifecycleStatusPropEnum, true); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *APIMetaListItem) validateLifecycleStatus(formats strfmt.Registry) error {
+	if swag.IsZero(m.LifecycleStatus) { // not required
+		return nil
+	}
+
+	// value enum
+	if err := m.validateLifecycleStatusEnum("lifecycleStatus", "body", m.LifecycleStatus); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var apiMetaListItemTypeStatusPropEnum []interface{}
+
+func init() {
+	var res []string
+	if err := json.Unmarshal([]byte(`["HEALTHY","DEGRADED"]`), &res); err != nil {
+		panic(err)
+	}
+	for _, v := range res {
+		apiMetaListItemTypeStatusPropEnum = append(apiMetaListItemTypeStatusPropEnum, v)
+	}
+}
+
+const (
+
+	// APIMetaListItemStatusHEALTHY captures enum value "HEALTHY"
+	APIMetaListItemStatusHEALTHY string = "HEALTHY"
+
+	// APIMetaListItemStatusDEGRADED captures enum value "DEGRADED"
+	APIMetaListItemStatusDEGRADED string = "DEGRADED"
+)
+
+// prop value enum
+func (m *APIMetaListItem) validateStatusEnum(path, location string, value string) error {
+	if err := validate.EnumCase(path, location, value, apiMetaListItemTypeStatusPropEnum, true); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *APIMetaListItem) validateStatus(formats strfmt.Registry) error {
+	if swag.IsZero(m.Status) { // not required
+		return nil
+	}
+
+	// value enum
+	if err := m.validateStatusEnum("status", "body", m.Status); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -0,0 +1,230 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package api
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/wso2/product-microgateway/adapter/config"
+	"github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/constants"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
+)
+
+// Features of the API project checked against the gateway capabilities
+const (
+	featureProject         string = "project"
+	featureAPIType         string = "apiType"
+	featureSecurityScheme  string = "securityScheme"
+	featureOperationPolicy string = "operationPolicy"
+	featureMediationPolicy string = "mediationPolicy"
+)
+
+// Severities of the capability issues
+const (
+	// severityError is reported when the project is not deployed or is deployed without the feature
+	severityError string = "ERROR"
+	// severityWarning is reported when the feature does not affect the behaviour of the API in the gateway
+	severityWarning string = "WARNING"
+)
+
+const sequencesDir string = "Sequences"
+
+var (
+	supportedAPITypes = []string{constants.HTTP, constants.WS, constants.SOAP, constants.GRAPHQL, constants.SSE,
+		constants.WEBSUB}
+	// security schemes applied from api.yaml. The others are ignored by the gateway.
+	supportedSecuritySchemes = []string{constants.APIMAPIKeyType, constants.APIMOauth2Type, constants.APIMMutualSSLType,
+		constants.APIMMutualSSLMandatoryType, constants.APIOauthBasicAuthAPIKeyMandatoryType}
+)
+
+// CapabilityReport lists the features of an API project, which are not supported by the gateway.
+type CapabilityReport struct {
+	APIName    string `json:"apiName,omitempty"`
+	APIVersion string `json:"apiVersion,omitempty"`
+	APIType    string `json:"apiType,omitempty"`
+	// Compatible is false if the project has at least one issue with the severity ERROR
+	Compatible bool              `json:"compatible"`
+	Issues     []CapabilityIssue `json:"issues"`
+}
+
+// CapabilityError is returned when an API project is rejected, as it uses features not supported by the gateway.
+type CapabilityError struct {
+	Report *CapabilityReport
+}
+
+// CapabilityIssue represents a feature of an API project, which is not supported by the gateway.
+type CapabilityIssue struct {
+	Feature  string `json:"feature"`
+	Value    string `json:"value,omitempty"`
+	Location string `json:"location,omitempty"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+// CheckAPIProjectCapabilities extracts the API project (zip archive) and reports the features of the project,
+// which are not supported by the gateway. The project is not deployed.
+func CheckAPIProjectCapabilities(payload []byte) *CapabilityReport {
+	apiProject, err := extractAPIProject(payload)
+	if err != nil {
+		report := &CapabilityReport{Issues: []CapabilityIssue{}}
+		report.addIssue(CapabilityIssue{
+			Feature:  featureProject,
+			Severity: severityError,
+			Message:  err.Error(),
+		})
+		return report
+	}
+	return checkCapabilities(apiProject)
+}
+
+// checkCapabilities reports the features of the API project, which are not supported by the gateway.
+func checkCapabilities(apiProject model.ProjectAPI) *CapabilityReport {
+	apiYaml := apiProject.APIYaml.Data
+	report := &CapabilityReport{
+		APIName:    apiYaml.Name,
+		APIVersion: apiYaml.Version,
+		APIType:    apiYaml.APIType,
+		Compatible: true,
+		Issues:     []CapabilityIssue{},
+	}
+
+	if !arrayContains(supportedAPITypes, apiYaml.APIType) {
+		report.addIssue(CapabilityIssue{
+			Feature:  featureAPIType,
+			Value:    apiYaml.APIType,
+			Severity: severityError,
+			Message:  "API type is not supported by the gateway",
+		})
+	}
+
+	for _, securityScheme := range apiYaml.SecurityScheme {
+		if !arrayContains(supportedSecuritySchemes, securityScheme) {
+			report.addIssue(CapabilityIssue{
+				Feature:  featureSecurityScheme,
+				Value:    securityScheme,
+				Severity: severityError,
+				Message:  "Security scheme is not enforced by the gateway",
+			})
+		}
+	}
+
+	// API level policies are checked along with the operation level policies, with the location "API"
+	operations := append(apiYaml.Operations[:len(apiYaml.Operations):len(apiYaml.Operations)],
+		model.OperationYaml{OperationPolicies: apiYaml.APIPolicies})
+	for _, operation := range operations {
+		policies := operation.OperationPolicies
+		if len(policies.Request) == 0 && len(policies.Response) == 0 && len(policies.Fault) == 0 {
+			continue
+		}
+		location := operation.Verb + " " + operation.Target
+		if operation.Verb == "" {
+			location = "API"
+		}
+		if apiYaml.APIType != constants.HTTP {
+			report.addIssue(CapabilityIssue{
+				Feature:  featureOperationPolicy,
+				Location: location,
+				Severity: severityError,
+				Message:  "Operation policies are supported only for HTTP APIs, hence the policies are not applied",
+			})
+			continue
+		}
+		for _, unsupportedPolicy := range apiProject.Policies.GetUnsupportedPolicies(policies) {
+			report.addIssue(CapabilityIssue{
+				Feature:  featureOperationPolicy,
+				Value:    unsupportedPolicy.Policy,
+				Location: location + " " + unsupportedPolicy.Flow + " flow",
+				Severity: severityError,
+				Message:  unsupportedPolicy.Reason,
+			})
+		}
+	}
+
+	for _, mediationPolicy := range apiYaml.MediationPolicies {
+		report.addIssue(CapabilityIssue{
+			Feature:  featureMediationPolicy,
+			Value:    mediationPolicy.Name,
+			Location: mediationPolicy.Type,
+			Severity: severityError,
+			Message:  "Mediation sequences are not supported by the gateway, hence the sequence is not applied",
+		})
+	}
+	for _, file := range apiProject.Files {
+		if strings.Contains(file.Name, string(os.PathSeparator)+sequencesDir+string(os.PathSeparator)) {
+			report.addIssue(CapabilityIssue{
+				Feature:  featureMediationPolicy,
+				Value:    file.Name,
+				Severity: severityWarning,
+				Message:  "Mediation sequences are not supported by the gateway, hence the file is ignored",
+			})
+		}
+	}
+	return report
+}
+
+func (report *CapabilityReport) addIssue(issue CapabilityIssue) {
+	if issue.Severity == severityError {
+		report.Compatible = false
+	}
+	report.Issues = append(report.Issues, issue)
+}
+
+func (err *CapabilityError) Error() string {
+	issues := make([]string, 0, len(err.Report.Issues))
+	for _, issue := range err.Report.Issues {
+		if issue.Severity != severityError {
+			continue
+		}
+		if issue.Location != "" {
+			issues = append(issues, fmt.Sprintf("[%s] %q at %s: %s", issue.Feature, issue.Value, issue.Location,
+				issue.Message))
+		} else {
+			issues = append(issues, fmt.Sprintf("[%s] %q: %s", issue.Feature, issue.Value, issue.Message))
+		}
+	}
+	return fmt.Sprintf("API %s:%s uses features not supported by the gateway. %s", err.Report.APIName,
+		err.Report.APIVersion, strings.Join(issues, "; "))
+}
+
+// checkAPIProjectCapabilities returns a CapabilityError if the API project uses features not supported by the
+// gateway, unless such projects are configured to be deployed without those features. The issues of the deployed
+// projects are logged.
+func checkAPIProjectCapabilities(apiProject model.ProjectAPI) error {
+	conf, _ := config.ReadConfigs()
+	report := checkCapabilities(apiProject)
+	if !report.Compatible && conf.Adapter.CapabilityCheck.RejectIncompatible {
+		return &CapabilityError{Report: report}
+	}
+	for _, issue := range report.Issues {
+		loggers.LoggerAPI.Warnf("Unsupported %s %q found at %q of the API %s:%s. %s", issue.Feature, issue.Value,
+			issue.Location, report.APIName, report.APIVersion, issue.Message)
+	}
+	return nil
+}
+
+func arrayContains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,53 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package api
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-microgateway/adapter/config"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
+)
+
+func TestCheckAPIProjectCapabilities(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	defer func(rejectIncompatible bool) {
+		conf.Adapter.CapabilityCheck.RejectIncompatible = rejectIncompatible
+	}(conf.Adapter.CapabilityCheck.RejectIncompatible)
+
+	var apiProject model.ProjectAPI
+	apiProject.APIYaml.Data.Name = "PetStore"
+	apiProject.APIYaml.Data.Version = "1.0.0"
+	apiProject.APIYaml.Data.APIType = "HTTP"
+	assert.Nil(t, checkAPIProjectCapabilities(apiProject))
+
+	apiProject.APIYaml.Data.SecurityScheme = []string{"basic_auth"}
+	conf.Adapter.CapabilityCheck.RejectIncompatible = true
+	err := checkAPIProjectCapabilities(apiProject)
+	var capabilityErr *CapabilityError
+	assert.True(t, errors.As(err, &capabilityErr), "Incompatible API project should be rejected")
+	assert.False(t, capabilityErr.Report.Compatible)
+	assert.Equal(t, `API PetStore:1.0.0 uses features not supported by the gateway. [securityScheme] "basic_auth": `+
+		"Security scheme is not enforced by the gateway", err.Error())
+
+	conf.Adapter.CapabilityCheck.RejectIncompatible = false
+	assert.Nil(t, checkAPIProjectCapabilities(apiProject),
+		"Incompatible API project should be deployed without the unsupported features")
+}
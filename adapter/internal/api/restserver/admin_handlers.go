@@ -0,0 +1,624 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package restserver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/runtime/middleware"
+
+	"github.com/wso2/product-microgateway/adapter/config"
+	apiServer "github.com/wso2/product-microgateway/adapter/internal/api"
+	"github.com/wso2/product-microgateway/adapter/internal/api/models"
+	"github.com/wso2/product-microgateway/adapter/internal/api/restserver/operations"
+	"github.com/wso2/product-microgateway/adapter/internal/api/restserver/operations/api_collection"
+	"github.com/wso2/product-microgateway/adapter/internal/api/restserver/operations/api_individual"
+	"github.com/wso2/product-microgateway/adapter/internal/api/restserver/operations/audit_trail"
+	"github.com/wso2/product-microgateway/adapter/internal/api/restserver/operations/bot_detection"
+	"github.com/wso2/product-microgateway/adapter/internal/api/restserver/operations/event_injection"
+	"github.com/wso2/product-microgateway/adapter/internal/api/restserver/operations/gateway"
+	"github.com/wso2/product-microgateway/adapter/internal/api/restserver/operations/job"
+	"github.com/wso2/product-microgateway/adapter/internal/api/restserver/operations/rate_limit"
+	"github.com/wso2/product-microgateway/adapter/internal/api/restserver/operations/resource_store"
+	"github.com/wso2/product-microgateway/adapter/internal/audit"
+	"github.com/wso2/product-microgateway/adapter/internal/botdetection"
+	"github.com/wso2/product-microgateway/adapter/internal/compaction"
+	"github.com/wso2/product-microgateway/adapter/internal/discovery/xds"
+	"github.com/wso2/product-microgateway/adapter/internal/jobs"
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/internal/messaging"
+	"github.com/wso2/product-microgateway/adapter/internal/synchronizer"
+	"github.com/wso2/product-microgateway/adapter/pkg/logging"
+)
+
+// configureAdminHandlers sets the handlers of the adapter admin operations, which are authenticated and bound by the
+// generated REST API the same way as the operations of /apis.
+func configureAdminHandlers(api *operations.RestapiAPI) {
+	api.AuditTrailGetAuditChangesHandler = audit_trail.GetAuditChangesHandlerFunc(handleGetAuditChanges)
+	api.AuditTrailGetAuditEventsHandler = audit_trail.GetAuditEventsHandlerFunc(handleGetAuditEvents)
+	api.GatewayGetStateHandler = gateway.GetStateHandlerFunc(handleGetState)
+	api.GatewayGetConfigHandler = gateway.GetConfigHandlerFunc(handleGetConfigDump)
+	api.GatewayGetStandbyHandler = gateway.GetStandbyHandlerFunc(handleGetStandby)
+	api.GatewayPostStandbyActivateHandler = gateway.PostStandbyActivateHandlerFunc(handlePostStandbyActivate)
+	api.GatewayPostStandbyDeactivateHandler = gateway.PostStandbyDeactivateHandlerFunc(handlePostStandbyDeactivate)
+	api.JobPostResyncHandler = job.PostResyncHandlerFunc(handlePostResync)
+	api.JobPostRebuildHandler = job.PostRebuildHandlerFunc(handlePostRebuild)
+	api.JobPostCompactionHandler = job.PostCompactionHandlerFunc(handlePostCompaction)
+	api.JobGetJobsHandler = job.GetJobsHandlerFunc(handleGetJobs)
+	api.JobGetJobsJobIDHandler = job.GetJobsJobIDHandlerFunc(handleGetJob)
+	api.APIIndividualPostApisValidateHandler = api_individual.PostApisValidateHandlerFunc(handlePostValidateAPI)
+	api.APIIndividualGetApisAdvisoriesHandler = api_individual.GetApisAdvisoriesHandlerFunc(handleGetAPIAdvisories)
+	api.APIIndividualPostApisAdvisoriesHandler = api_individual.PostApisAdvisoriesHandlerFunc(handlePostAPIAdvisory)
+	api.APIIndividualDeleteApisAdvisoriesHandler = api_individual.DeleteApisAdvisoriesHandlerFunc(
+		handleDeleteAPIAdvisory)
+	api.APIIndividualPostApisRevisionsPromoteHandler = api_individual.PostApisRevisionsPromoteHandlerFunc(
+		handlePostPromoteAPIRevision)
+	api.APIIndividualPostApisRevisionsRollbackHandler = api_individual.PostApisRevisionsRollbackHandlerFunc(
+		handlePostRollbackAPIRevision)
+	api.APICollectionGetApisProbesHandler = api_collection.GetApisProbesHandlerFunc(handleGetAPIProbes)
+	api.APICollectionGetApisAdmissionRejectionsHandler = api_collection.GetApisAdmissionRejectionsHandlerFunc(
+		handleGetAdmissionRejections)
+	api.APICollectionGetWebsubHubsHandler = api_collection.GetWebsubHubsHandlerFunc(handleGetWebSubHubs)
+	api.RateLimitGetRatelimitExemptionsHandler = rate_limit.GetRatelimitExemptionsHandlerFunc(
+		handleGetRateLimitExemptions)
+	api.RateLimitPostRatelimitExemptionsHandler = rate_limit.PostRatelimitExemptionsHandlerFunc(
+		handlePostRateLimitExemption)
+	api.RateLimitDeleteRatelimitExemptionsHandler = rate_limit.DeleteRatelimitExemptionsHandlerFunc(
+		handleDeleteRateLimitExemption)
+	api.EventInjectionPostEventsInjectHandler = event_injection.PostEventsInjectHandlerFunc(handlePostInjectEvents)
+	api.BotDetectionGetBotdetectionEventsHandler = bot_detection.GetBotdetectionEventsHandlerFunc(
+		handleGetBotDetectionEvents)
+	api.ResourceStoreGetStoresHandler = resource_store.GetStoresHandlerFunc(handleGetResourceStores)
+	api.ResourceStoreGetStoresLookupHandler = resource_store.GetStoresLookupHandlerFunc(handleGetResourceStoreLookup)
+}
+
+// handleGetAuditChanges serves the signed change report for the time range given by the from and to query
+// parameters. The time range defaults to the last 24 hours.
+func handleGetAuditChanges(params audit_trail.GetAuditChangesParams, principal *models.Principal) middleware.Responder {
+	if !mgwConfig.Adapter.Audit.Enabled {
+		return audit_trail.NewGetAuditChangesNotFound().WithPayload(errorPayload(http.StatusNotFound,
+			"Auditing is not enabled in the adapter"))
+	}
+	to := time.Now().UTC()
+	if params.To != nil {
+		to = time.Time(*params.To)
+	}
+	from := to.Add(-24 * time.Hour)
+	if params.From != nil {
+		from = time.Time(*params.From)
+	}
+	if to.Before(from) {
+		return audit_trail.NewGetAuditChangesBadRequest().WithPayload(errorPayload(http.StatusBadRequest,
+			"The query parameter from should not be after to"))
+	}
+
+	report, err := audit.GenerateReport(from, to)
+	if err != nil {
+		logger.LoggerAPI.ErrorC(logging.ErrorDetails{
+			Message:   fmt.Sprintf("Error while generating the change report. %v", err),
+			Severity:  logging.MAJOR,
+			ErrorCode: 1229,
+		})
+		return audit_trail.NewGetAuditChangesInternalServerError().WithPayload(errorPayload(
+			http.StatusInternalServerError, "Error while generating the change report"))
+	}
+	logger.LoggerAPI.Infof("Change report from %v to %v is generated for the user: %s", from, to, principal.Username)
+	return audit_trail.NewGetAuditChangesOK().WithPayload(report)
+}
+
+// handleGetAuditEvents serves the control plane events processed by the adapter, filtered by the resourceId (ex: the
+// API UUID), type, from and to query parameters. All the recorded events are served if not filtered.
+func handleGetAuditEvents(params audit_trail.GetAuditEventsParams, principal *models.Principal) middleware.Responder {
+	if !mgwConfig.Adapter.Audit.Events.Enabled {
+		return audit_trail.NewGetAuditEventsNotFound().WithPayload(errorPayload(http.StatusNotFound,
+			"Recording the events is not enabled in the adapter"))
+	}
+	var filter audit.EventFilter
+	if params.ResourceID != nil {
+		filter.ResourceID = *params.ResourceID
+	}
+	if params.Type != nil {
+		filter.Type = *params.Type
+	}
+	if params.From != nil {
+		filter.From = time.Time(*params.From)
+	}
+	if params.To != nil {
+		filter.To = time.Time(*params.To)
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && filter.To.Before(filter.From) {
+		return audit_trail.NewGetAuditEventsBadRequest().WithPayload(errorPayload(http.StatusBadRequest,
+			"The query parameter from should not be after to"))
+	}
+	return audit_trail.NewGetAuditEventsOK().WithPayload(toPayloads(audit.GetEvents(filter)))
+}
+
+// handleGetState serves the configuration state of each gateway environment.
+func handleGetState(params gateway.GetStateParams, principal *models.Principal) middleware.Responder {
+	return gateway.NewGetStateOK().WithPayload(toPayloads(xds.GetGatewayStates()))
+}
+
+// handleGetConfigDump serves the configuration generated for each gateway environment and the state of the
+// stores, which is linted by the adapterctl lint command.
+func handleGetConfigDump(params gateway.GetConfigParams, principal *models.Principal) middleware.Responder {
+	return gateway.NewGetConfigOK().WithPayload(xds.GetConfigDump())
+}
+
+// handlePostResync triggers pulling all the APIs from the control plane. The APIs are pulled in a job, hence the
+// request is accepted without waiting for the APIs to be applied.
+func handlePostResync(params job.PostResyncParams, principal *models.Principal) middleware.Responder {
+	if !mgwConfig.ControlPlane.Enabled {
+		return job.NewPostResyncBadRequest().WithPayload(errorPayload(http.StatusBadRequest,
+			"Control plane is not enabled in the adapter"))
+	}
+	submitted, err := jobs.Submit(jobs.TypeResync, principal.Username, func(reporter *jobs.Reporter) (interface{}, error) {
+		reporter.Logf("Resyncing the APIs of the environments %v from the control plane",
+			mgwConfig.ControlPlane.EnvironmentLabels)
+		if err := synchronizer.ResyncAPIsFromControlPlane(); err != nil {
+			logger.LoggerAPI.ErrorC(logging.ErrorDetails{
+				Message:   fmt.Sprintf("Error while resyncing the APIs from control plane. %v", err),
+				Severity:  logging.MAJOR,
+				ErrorCode: 1230,
+			})
+			return nil, err
+		}
+		reporter.Logf("APIs are resynced from the control plane")
+		return nil, nil
+	})
+	if activeJobErr, ok := err.(*jobs.ActiveJobError); ok {
+		return job.NewPostResyncConflict().WithLocation(jobLocation(activeJobErr.ActiveJob.ID)).WithPayload(
+			errorPayload(http.StatusConflict, activeJobErr.Error()))
+	}
+	if err != nil {
+		return job.NewPostResyncInternalServerError().WithPayload(jobSubmissionErrorPayload(err))
+	}
+	return job.NewPostResyncAccepted().WithLocation(jobLocation(submitted.ID)).WithPayload(submitted)
+}
+
+// handlePostRebuild rebuilds the deployed APIs from the audit journal in a job. The APIs which would be rebuilt
+// are the result of the job, without altering the deployed APIs, if the query parameter dryRun is true.
+func handlePostRebuild(params job.PostRebuildParams, principal *models.Principal) middleware.Responder {
+	if !mgwConfig.Adapter.Audit.Enabled || mgwConfig.Adapter.Audit.JournalFilePath == "" {
+		return job.NewPostRebuildBadRequest().WithPayload(errorPayload(http.StatusBadRequest,
+			"Audit journal file is not configured in the adapter"))
+	}
+	dryRun := params.DryRun != nil && *params.DryRun
+	submitted, err := jobs.Submit(jobs.TypeRebuild, principal.Username, func(reporter *jobs.Reporter) (interface{}, error) {
+		reporter.Logf("Rebuilding the APIs from the journal (dry run: %v)", dryRun)
+		result, err := apiServer.RebuildAPIsFromJournal(dryRun, func(rebuilt, total int) {
+			reporter.SetProgress(rebuilt * 100 / total)
+		})
+		if err != nil {
+			logger.LoggerAPI.ErrorC(logging.ErrorDetails{
+				Message:   fmt.Sprintf("Error while rebuilding the APIs from the journal. %v", err),
+				Severity:  logging.MAJOR,
+				ErrorCode: 1234,
+			})
+			return nil, err
+		}
+		reporter.Logf("Rebuilt %d APIs from the journal, while %d APIs failed", len(result.APIs),
+			len(result.FailedAPIs))
+		return result, nil
+	})
+	if activeJobErr, ok := err.(*jobs.ActiveJobError); ok {
+		return job.NewPostRebuildConflict().WithLocation(jobLocation(activeJobErr.ActiveJob.ID)).WithPayload(
+			errorPayload(http.StatusConflict, activeJobErr.Error()))
+	}
+	if err != nil {
+		return job.NewPostRebuildInternalServerError().WithPayload(jobSubmissionErrorPayload(err))
+	}
+	return job.NewPostRebuildAccepted().WithLocation(jobLocation(submitted.ID)).WithPayload(submitted)
+}
+
+// handlePostCompaction triggers a compaction of the in-memory stores and the audit journal in a job. The entries
+// removed and the memory reclaimed are the result of the job.
+func handlePostCompaction(params job.PostCompactionParams, principal *models.Principal) middleware.Responder {
+	submitted, err := compaction.Submit(principal.Username)
+	if activeJobErr, ok := err.(*jobs.ActiveJobError); ok {
+		return job.NewPostCompactionConflict().WithLocation(jobLocation(activeJobErr.ActiveJob.ID)).WithPayload(
+			errorPayload(http.StatusConflict, activeJobErr.Error()))
+	}
+	if err != nil {
+		return job.NewPostCompactionInternalServerError().WithPayload(jobSubmissionErrorPayload(err))
+	}
+	return job.NewPostCompactionAccepted().WithLocation(jobLocation(submitted.ID)).WithPayload(submitted)
+}
+
+// handleGetJobs lists the jobs.
+func handleGetJobs(params job.GetJobsParams, principal *models.Principal) middleware.Responder {
+	return job.NewGetJobsOK().WithPayload(toPayloads(jobs.List()))
+}
+
+// handleGetJob serves a job with its logs and result.
+func handleGetJob(params job.GetJobsJobIDParams, principal *models.Principal) middleware.Responder {
+	found, ok := jobs.Get(params.JobID)
+	if !ok {
+		return job.NewGetJobsJobIDNotFound().WithPayload(errorPayload(http.StatusNotFound, "Job is not found"))
+	}
+	return job.NewGetJobsJobIDOK().WithPayload(found)
+}
+
+// jobLocation returns the location of a job, which is served by the GetJobsJobID operation.
+func jobLocation(jobID string) string {
+	return (&job.GetJobsJobIDURL{JobID: jobID}).String()
+}
+
+func jobSubmissionErrorPayload(err error) *models.Error {
+	return errorPayload(http.StatusInternalServerError, "Error while submitting the job. "+err.Error())
+}
+
+// handlePostValidateAPI reports the features of the API project (multipart form field file), which are not
+// supported by the gateway. The project is not deployed.
+func handlePostValidateAPI(params api_individual.PostApisValidateParams,
+	principal *models.Principal) middleware.Responder {
+	defer params.File.Close()
+	payload, err := ioutil.ReadAll(params.File)
+	if err != nil {
+		return api_individual.NewPostApisValidateBadRequest().WithPayload(errorPayload(http.StatusBadRequest,
+			"Error while reading the API project. "+err.Error()))
+	}
+	report := apiServer.CheckAPIProjectCapabilities(payload)
+	logger.LoggerAPI.Infof("API project %s:%s is validated by the user: %s. Compatible: %v", report.APIName,
+		report.APIVersion, principal.Username, report.Compatible)
+	return api_individual.NewPostApisValidateOK().WithPayload(report)
+}
+
+// handleGetRateLimitExemptions lists the clients exempted from the rate limits of the router.
+func handleGetRateLimitExemptions(params rate_limit.GetRatelimitExemptionsParams,
+	principal *models.Principal) middleware.Responder {
+	exemptions := xds.GetRateLimitExemptions()
+	payload := make([]*models.RateLimitExemption, 0, len(exemptions))
+	for _, exemption := range exemptions {
+		payload = append(payload, rateLimitExemptionPayload(exemption))
+	}
+	return rate_limit.NewGetRatelimitExemptionsOK().WithPayload(payload)
+}
+
+// handlePostRateLimitExemption exempts a client from the rate limits of the router. An existing exemption is
+// responded with 200 OK, without being added again.
+func handlePostRateLimitExemption(params rate_limit.PostRatelimitExemptionsParams,
+	principal *models.Principal) middleware.Responder {
+	exemption, changed, err := xds.AddRateLimitExemption(xds.RateLimitExemption{Type: params.Exemption.Type,
+		Value: params.Exemption.Value})
+	if errors.Is(err, xds.ErrRateLimitExemptionsNotPersisted) {
+		return rate_limit.NewPostRatelimitExemptionsInternalServerError().WithPayload(errorPayload(
+			http.StatusInternalServerError, "Rate limit exemptions are not updated. "+err.Error()))
+	}
+	if err != nil {
+		return rate_limit.NewPostRatelimitExemptionsBadRequest().WithPayload(errorPayload(http.StatusBadRequest,
+			"Invalid rate limit exemption. "+err.Error()))
+	}
+	if !changed {
+		return rate_limit.NewPostRatelimitExemptionsOK().WithPayload(rateLimitExemptionPayload(exemption))
+	}
+	recordRateLimitExemptionChange(audit.ActionAddRateLimitExemption, exemption, principal)
+	return rate_limit.NewPostRatelimitExemptionsCreated().WithPayload(rateLimitExemptionPayload(exemption))
+}
+
+// handleDeleteRateLimitExemption removes the exemption of a client, given in the query parameters type and value,
+// from the rate limits of the router.
+func handleDeleteRateLimitExemption(params rate_limit.DeleteRatelimitExemptionsParams,
+	principal *models.Principal) middleware.Responder {
+	exemption, changed, err := xds.RemoveRateLimitExemption(xds.RateLimitExemption{Type: params.Type,
+		Value: params.Value})
+	if errors.Is(err, xds.ErrRateLimitExemptionsNotPersisted) {
+		return rate_limit.NewDeleteRatelimitExemptionsInternalServerError().WithPayload(errorPayload(
+			http.StatusInternalServerError, "Rate limit exemptions are not updated. "+err.Error()))
+	}
+	if err != nil {
+		return rate_limit.NewDeleteRatelimitExemptionsBadRequest().WithPayload(errorPayload(http.StatusBadRequest,
+			"Invalid rate limit exemption. "+err.Error()))
+	}
+	if !changed {
+		return rate_limit.NewDeleteRatelimitExemptionsNotFound().WithPayload(errorPayload(http.StatusNotFound,
+			"Rate limit exemption is not found"))
+	}
+	recordRateLimitExemptionChange(audit.ActionRemoveRateLimitExemption, exemption, principal)
+	return rate_limit.NewDeleteRatelimitExemptionsNoContent()
+}
+
+func rateLimitExemptionPayload(exemption xds.RateLimitExemption) *models.RateLimitExemption {
+	return &models.RateLimitExemption{Type: exemption.Type, Value: exemption.Value}
+}
+
+// handleGetStandby serves whether the site is in the standby mode.
+func handleGetStandby(params gateway.GetStandbyParams, principal *models.Principal) middleware.Responder {
+	return gateway.NewGetStandbyOK().WithPayload(xds.GetStandbyStatus())
+}
+
+// handlePostStandbyActivate activates the site, hence the API requests are routed to the backends.
+func handlePostStandbyActivate(params gateway.PostStandbyActivateParams,
+	principal *models.Principal) middleware.Responder {
+	changeStandbyMode(principal, false)
+	return gateway.NewPostStandbyActivateOK().WithPayload(xds.GetStandbyStatus())
+}
+
+// handlePostStandbyDeactivate switches the site back to the standby mode.
+func handlePostStandbyDeactivate(params gateway.PostStandbyDeactivateParams,
+	principal *models.Principal) middleware.Responder {
+	changeStandbyMode(principal, true)
+	return gateway.NewPostStandbyDeactivateOK().WithPayload(xds.GetStandbyStatus())
+}
+
+func changeStandbyMode(principal *models.Principal, standby bool) {
+	action := audit.ActionActivateSite
+	if standby {
+		action = audit.ActionStandbySite
+	}
+	if xds.SetStandby(standby, "Changed by the user: "+principal.Username) {
+		audit.RecordChange(audit.ChangeRecord{
+			Timestamp: time.Now().UTC(),
+			Actor:     principal.Username,
+			Action:    action,
+		})
+	}
+}
+
+// handleGetAPIProbes returns the results of the synthetic probes of the deployed APIs.
+func handleGetAPIProbes(params api_collection.GetApisProbesParams, principal *models.Principal) middleware.Responder {
+	return api_collection.NewGetApisProbesOK().WithPayload(toPayloads(xds.GetAPIProbeStatuses()))
+}
+
+// handleGetAdmissionRejections lists the API projects recently rejected by the admission checks, along with the
+// reasons for the rejection.
+func handleGetAdmissionRejections(params api_collection.GetApisAdmissionRejectionsParams,
+	principal *models.Principal) middleware.Responder {
+	return api_collection.NewGetApisAdmissionRejectionsOK().WithPayload(
+		toPayloads(apiServer.GetAdmissionRejections()))
+}
+
+// handlePostInjectEvents processes the synthetic control plane events in the request, in the given order, as if
+// those are received from the message broker. The events after an invalid event are not processed.
+func handlePostInjectEvents(params event_injection.PostEventsInjectParams,
+	principal *models.Principal) middleware.Responder {
+	if !mgwConfig.Adapter.EventInjection.Enabled {
+		return event_injection.NewPostEventsInjectNotFound().WithPayload(errorPayload(http.StatusNotFound,
+			"Event injection is not enabled in the adapter"))
+	}
+	for i, event := range params.Events {
+		if err := injectEvent(event); err != nil {
+			return event_injection.NewPostEventsInjectBadRequest().WithPayload(errorPayload(http.StatusBadRequest,
+				fmt.Sprintf("Invalid synthetic event at index %d. %v", i, err)))
+		}
+	}
+	logger.LoggerAPI.Debugf("%d synthetic events are injected by the user: %s", len(params.Events),
+		principal.Username)
+	return event_injection.NewPostEventsInjectAccepted().WithPayload(
+		&event_injection.PostEventsInjectAcceptedBody{Injected: int64(len(params.Events))})
+}
+
+func injectEvent(event *models.SyntheticEvent) error {
+	if event == nil {
+		return errors.New("event is not provided")
+	}
+	payload, err := json.Marshal(event.Event)
+	if err != nil {
+		return err
+	}
+	return messaging.InjectEvent(messaging.SyntheticEvent{Type: event.Type, Event: payload})
+}
+
+// handleGetAPIAdvisories lists the advisories of an API, which are sent to the consumers of the API in the
+// Warning response header.
+func handleGetAPIAdvisories(params api_individual.GetApisAdvisoriesParams,
+	principal *models.Principal) middleware.Responder {
+	return api_individual.NewGetApisAdvisoriesOK().WithPayload(toPayloads(xds.GetAPIAdvisories(
+		config.GetControlPlaneConnectedTenantDomain(), apiVhost(params.Vhost), params.APIName, params.Version)))
+}
+
+// handlePostAPIAdvisory adds an advisory to an API. The API is given in the request body along with the message.
+func handlePostAPIAdvisory(params api_individual.PostApisAdvisoriesParams,
+	principal *models.Principal) middleware.Responder {
+	request := params.Advisory
+	if request.APIName == "" || request.Version == "" {
+		return api_individual.NewPostApisAdvisoriesBadRequest().WithPayload(errorPayload(http.StatusBadRequest,
+			"API name and version are required"))
+	}
+	vhost := apiVhost(&request.Vhost)
+	apiSubject := vhost + ":" + request.APIName + ":" + request.Version
+	advisory, err := xds.AddAPIAdvisory(config.GetControlPlaneConnectedTenantDomain(), vhost, request.APIName,
+		request.Version, xds.APIAdvisory{Message: request.Message, Link: request.Link})
+	if err == xds.ErrAPINotFound {
+		return api_individual.NewPostApisAdvisoriesNotFound().WithPayload(errorPayload(http.StatusNotFound,
+			"API "+apiSubject+" is not found"))
+	}
+	if err != nil {
+		return api_individual.NewPostApisAdvisoriesBadRequest().WithPayload(errorPayload(http.StatusBadRequest,
+			"Invalid API advisory. "+err.Error()))
+	}
+	recordAPIAdvisoryChange(audit.ActionAddAPIAdvisory, apiSubject, advisory.ID, principal)
+	return api_individual.NewPostApisAdvisoriesCreated().WithPayload(advisory)
+}
+
+// handleDeleteAPIAdvisory removes the advisory given in the query parameter id from an API.
+func handleDeleteAPIAdvisory(params api_individual.DeleteApisAdvisoriesParams,
+	principal *models.Principal) middleware.Responder {
+	vhost := apiVhost(params.Vhost)
+	apiSubject := vhost + ":" + params.APIName + ":" + params.Version
+	if !xds.RemoveAPIAdvisory(config.GetControlPlaneConnectedTenantDomain(), vhost, params.APIName,
+		params.Version, params.ID) {
+		return api_individual.NewDeleteApisAdvisoriesNotFound().WithPayload(errorPayload(http.StatusNotFound,
+			"API advisory is not found"))
+	}
+	recordAPIAdvisoryChange(audit.ActionRemoveAPIAdvisory, apiSubject, params.ID, principal)
+	return api_individual.NewDeleteApisAdvisoriesNoContent()
+}
+
+// apiVhost returns the given vhost of an API, which defaults to the vhost of the default environment.
+func apiVhost(vhost *string) string {
+	if vhost != nil && *vhost != "" {
+		return *vhost
+	}
+	defaultVhost, _, _ := config.GetDefaultVhost(config.DefaultGatewayName)
+	return defaultVhost
+}
+
+// handlePostPromoteAPIRevision routes the complete traffic of an API, which is split between two revisions, to the
+// newly deployed revision.
+func handlePostPromoteAPIRevision(params api_individual.PostApisRevisionsPromoteParams,
+	principal *models.Principal) middleware.Responder {
+	revision, errPayload := changeAPIRevision(params.APIName, params.Version, params.Vhost, principal,
+		xds.PromoteAPIRevision)
+	if errPayload == nil {
+		return api_individual.NewPostApisRevisionsPromoteOK().WithPayload(revision)
+	}
+	switch *errPayload.Code {
+	case http.StatusNotFound:
+		return api_individual.NewPostApisRevisionsPromoteNotFound().WithPayload(errPayload)
+	case http.StatusConflict:
+		return api_individual.NewPostApisRevisionsPromoteConflict().WithPayload(errPayload)
+	default:
+		return api_individual.NewPostApisRevisionsPromoteInternalServerError().WithPayload(errPayload)
+	}
+}
+
+// handlePostRollbackAPIRevision re-publishes the previously deployed revision of an API, whose traffic is split
+// between two revisions.
+func handlePostRollbackAPIRevision(params api_individual.PostApisRevisionsRollbackParams,
+	principal *models.Principal) middleware.Responder {
+	revision, errPayload := changeAPIRevision(params.APIName, params.Version, params.Vhost, principal,
+		xds.RollbackAPIRevision)
+	if errPayload == nil {
+		return api_individual.NewPostApisRevisionsRollbackOK().WithPayload(revision)
+	}
+	switch *errPayload.Code {
+	case http.StatusNotFound:
+		return api_individual.NewPostApisRevisionsRollbackNotFound().WithPayload(errPayload)
+	case http.StatusConflict:
+		return api_individual.NewPostApisRevisionsRollbackConflict().WithPayload(errPayload)
+	default:
+		return api_individual.NewPostApisRevisionsRollbackInternalServerError().WithPayload(errPayload)
+	}
+}
+
+// changeAPIRevision changes the revision which receives the complete traffic of an API. The error payload is
+// returned instead of the revision, if the revision is not changed.
+func changeAPIRevision(apiName, version string, vhost *string, principal *models.Principal,
+	change func(organizationID, vhost, name, version, actor string) (string, error)) (*models.APIRevision,
+	*models.Error) {
+	revision := &models.APIRevision{APIName: apiName, Version: version, Vhost: apiVhost(vhost)}
+	apiSubject := revision.Vhost + ":" + revision.APIName + ":" + revision.Version
+	revisionID, err := change(config.GetControlPlaneConnectedTenantDomain(), revision.Vhost, revision.APIName,
+		revision.Version, principal.Username)
+	if err == xds.ErrAPINotFound {
+		return nil, errorPayload(http.StatusNotFound, "API "+apiSubject+" is not found")
+	}
+	if err == xds.ErrAPIRevisionsNotSplit {
+		return nil, errorPayload(http.StatusConflict, "Traffic of the API "+apiSubject+" is not split between revisions")
+	}
+	if err != nil {
+		logger.LoggerAPI.Errorf("Error while changing the revision of the API %s. %v", apiSubject, err)
+		return nil, errorPayload(http.StatusInternalServerError, "Error while changing the revision of the API. "+
+			err.Error())
+	}
+	logger.LoggerAPI.Infof("Revision %s of the API %s receives the complete traffic, as changed by the user: %s",
+		revisionID, apiSubject, principal.Username)
+	revision.RevisionID = revisionID
+	return revision, nil
+}
+
+func recordAPIAdvisoryChange(action, apiSubject, advisoryID string, principal *models.Principal) {
+	logger.LoggerAPI.Infof("Advisory %s of the API %s is changed (%s) by the user: %s", advisoryID, apiSubject,
+		action, principal.Username)
+	audit.RecordChange(audit.ChangeRecord{
+		Timestamp: time.Now().UTC(),
+		Actor:     principal.Username,
+		Action:    action,
+		Subject:   apiSubject + ":" + advisoryID,
+	})
+}
+
+func recordRateLimitExemptionChange(action string, exemption xds.RateLimitExemption, principal *models.Principal) {
+	logger.LoggerAPI.Infof("Rate limit exemption %s:%s is changed (%s) by the user: %s", exemption.Type,
+		exemption.Value, action, principal.Username)
+	audit.RecordChange(audit.ChangeRecord{
+		Timestamp: time.Now().UTC(),
+		Actor:     principal.Username,
+		Action:    action,
+		Subject:   exemption.Type + ":" + exemption.Value,
+	})
+	xds.UpdateRateLimits()
+}
+
+// handleGetBotDetectionEvents lists the latest bot detection events, the latest first.
+func handleGetBotDetectionEvents(params bot_detection.GetBotdetectionEventsParams,
+	principal *models.Principal) middleware.Responder {
+	if !mgwConfig.Adapter.BotDetection.Enabled {
+		return bot_detection.NewGetBotdetectionEventsNotFound().WithPayload(errorPayload(http.StatusNotFound,
+			"Bot detection is not enabled in the adapter"))
+	}
+	return bot_detection.NewGetBotdetectionEventsOK().WithPayload(toPayloads(botdetection.GetEvents()))
+}
+
+// handleGetResourceStores serves the usage of the bounded resource stores.
+func handleGetResourceStores(params resource_store.GetStoresParams, principal *models.Principal) middleware.Responder {
+	return resource_store.NewGetStoresOK().WithPayload(toPayloads(xds.GetResourceStoreStats()))
+}
+
+// handleGetWebSubHubs lists the hubs of the deployed WebSub APIs with the registrations of the subscribed
+// applications.
+func handleGetWebSubHubs(params api_collection.GetWebsubHubsParams, principal *models.Principal) middleware.Responder {
+	return api_collection.NewGetWebsubHubsOK().WithPayload(toPayloads(xds.GetWebSubHubs(
+		config.GetControlPlaneConnectedTenantDomain())))
+}
+
+// handleGetResourceStoreLookup serves an application, a subscription or an application key mapping by the
+// resource (applications, subscriptions or keymappings) and the key query parameters, including the entries
+// evicted from the memory.
+func handleGetResourceStoreLookup(params resource_store.GetStoresLookupParams,
+	principal *models.Principal) middleware.Responder {
+	message, err := xds.LookupResource(params.Resource, params.Key)
+	if err == xds.ErrResourceNotFound {
+		return resource_store.NewGetStoresLookupNotFound().WithPayload(errorPayload(http.StatusNotFound,
+			fmt.Sprintf("%s %s is not found", params.Resource, params.Key)))
+	}
+	if errors.Is(err, xds.ErrResourceNotSupported) {
+		return resource_store.NewGetStoresLookupBadRequest().WithPayload(errorPayload(http.StatusBadRequest,
+			err.Error()))
+	}
+	if err != nil {
+		logger.LoggerAPI.Errorf("Error while looking up %s %s from the control plane. %v", params.Resource,
+			params.Key, err)
+		return resource_store.NewGetStoresLookupBadGateway().WithPayload(errorPayload(http.StatusBadGateway,
+			"Error while looking up from the control plane"))
+	}
+	return resource_store.NewGetStoresLookupOK().WithPayload(message)
+}
+
+// toPayloads converts the entries to the payload of a response, which is an array of free-form objects.
+func toPayloads[T any](entries []T) []interface{} {
+	payloads := make([]interface{}, 0, len(entries))
+	for _, entry := range entries {
+		payloads = append(payloads, entry)
+	}
+	return payloads
+}
+
+func errorPayload(status int, message string) *models.Error {
+	code := int64(status)
+	return &models.Error{
+		Code:        &code,
+		Message:     &message,
+		Description: http.StatusText(status),
+	}
+}
@@ -0,0 +1,119 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package restserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-openapi/loads"
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-microgateway/adapter/config"
+	"github.com/wso2/product-microgateway/adapter/internal/api/models"
+	"github.com/wso2/product-microgateway/adapter/internal/api/restserver/operations"
+	"github.com/wso2/product-microgateway/adapter/internal/api/restserver/operations/api_individual"
+	"github.com/wso2/product-microgateway/adapter/internal/discovery/xds"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
+)
+
+func deployAPIRevision(t *testing.T, revisionID int, endpoint string) {
+	definition := `{"openapi": "3.0.0", "info": {"title": "RevisionSplitAPI", "version": "1.0.0"},
+		"x-wso2-basePath": "/revisions/1.0.0", "paths": {"/orders": {"get": {"responses": {"200": {}}}}}}`
+	apiProject := model.ProjectAPI{APIDefinition: []byte(definition), DeployedBy: "admin"}
+	apiProject.APIYaml.Type = "api"
+	apiProject.APIYaml.Data.ID = "revision-split-api"
+	apiProject.APIYaml.Data.Name = "RevisionSplitAPI"
+	apiProject.APIYaml.Data.Version = "1.0.0"
+	apiProject.APIYaml.Data.Context = "/revisions/1.0.0"
+	apiProject.APIYaml.Data.APIType = "HTTP"
+	apiProject.APIYaml.Data.RevisionID = revisionID
+	apiProject.APIYaml.Data.OrganizationID = config.GetControlPlaneConnectedTenantDomain()
+	apiProject.APIYaml.Data.EndpointConfig.EndpointType = "http"
+	apiProject.APIYaml.Data.EndpointConfig.ProductionEndpoints = []model.EndpointInfo{{Endpoint: endpoint}}
+	_, err := xds.UpdateAPI(config.DefaultGatewayVHost, apiProject, []string{config.DefaultGatewayName})
+	assert.Nil(t, err, "Error while deploying the revision %d", revisionID)
+}
+
+// newRestAPIHandler configures the REST API with an admin user, whose password is admin.
+func newRestAPIHandler(t *testing.T, conf *config.Config) http.Handler {
+	restConfig := *conf
+	restConfig.Adapter.Server.Users = []config.APICtlUser{{Username: "admin", Password: "admin"}}
+	mgwConfig = &restConfig
+	swaggerSpec, err := loads.Embedded(SwaggerJSON, FlatSwaggerJSON)
+	assert.Nil(t, err, "Error while loading the REST API specification")
+	return configureAPI(operations.NewRestapiAPI(swaggerSpec))
+}
+
+func changeRevision(handler http.Handler, method, url string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(method, url, nil)
+	request.SetBasicAuth("admin", "admin")
+	handler.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestChangeAPIRevision(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	splitConf := conf.Adapter.RevisionTrafficSplit
+	conf.Adapter.RevisionTrafficSplit.Enabled = true
+	conf.Adapter.RevisionTrafficSplit.PromoteAfterInSeconds = 0
+	previousConfig := mgwConfig
+	defer func() {
+		conf.Adapter.RevisionTrafficSplit = splitConf
+		mgwConfig = previousConfig
+		xds.DeleteAPIs(config.DefaultGatewayVHost, "RevisionSplitAPI", "1.0.0", nil,
+			config.GetControlPlaneConnectedTenantDomain(), "admin")
+	}()
+	handler := newRestAPIHandler(t, conf)
+	promoteURL := (&api_individual.PostApisRevisionsPromoteURL{APIName: "RevisionSplitAPI",
+		Version: "1.0.0"}).String()
+	rollbackURL := (&api_individual.PostApisRevisionsRollbackURL{APIName: "RevisionSplitAPI",
+		Version: "1.0.0"}).String()
+
+	recorder := changeRevision(handler, http.MethodGet, promoteURL)
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+	recorder = changeRevision(handler, http.MethodPost,
+		(&api_individual.PostApisRevisionsPromoteURL{APIName: "RevisionSplitAPI"}).String())
+	assert.Equal(t, http.StatusUnprocessableEntity, recorder.Code, "Version of the API should be required")
+	recorder = changeRevision(handler, http.MethodPost, rollbackURL)
+	assert.Equal(t, http.StatusNotFound, recorder.Code, "API is not deployed yet")
+
+	deployAPIRevision(t, 1, "http://revision1.example.com")
+	recorder = changeRevision(handler, http.MethodPost, promoteURL)
+	assert.Equal(t, http.StatusConflict, recorder.Code, "Traffic of a single revision is not split")
+
+	var revision models.APIRevision
+	deployAPIRevision(t, 2, "http://revision2.example.com")
+	recorder = changeRevision(handler, http.MethodPost, rollbackURL)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &revision))
+	assert.Equal(t, "1", revision.RevisionID, "Previous revision should be restored")
+	assert.Equal(t, config.DefaultGatewayVHost, revision.Vhost)
+	recorder = changeRevision(handler, http.MethodPost, promoteURL)
+	assert.Equal(t, http.StatusConflict, recorder.Code, "Traffic is not split after the rollback")
+
+	deployAPIRevision(t, 3, "http://revision3.example.com")
+	recorder = changeRevision(handler, http.MethodPost, promoteURL)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &revision))
+	assert.Equal(t, "3", revision.RevisionID, "New revision should be promoted")
+	recorder = changeRevision(handler, http.MethodPost, rollbackURL)
+	assert.Equal(t, http.StatusConflict, recorder.Code, "Traffic is not split after the promotion")
+}
@@ -19,14 +19,14 @@ package restserver
 
 import (
 	"crypto/tls"
+	goerrors "errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
-
-	// enable profiling endpoints
-	_ "net/http/pprof"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/go-openapi/errors"
 	"github.com/go-openapi/loads"
@@ -35,6 +35,7 @@ import (
 
 	"github.com/wso2/product-microgateway/adapter/pkg/health"
 	"github.com/wso2/product-microgateway/adapter/pkg/logging"
+	"github.com/wso2/product-microgateway/adapter/pkg/metrics"
 	"github.com/wso2/product-microgateway/adapter/pkg/tlsutils"
 
 	"github.com/wso2/product-microgateway/adapter/config"
@@ -52,6 +53,10 @@ import (
 
 var (
 	mgwConfig *config.Config
+	// restServer is the running REST API server, which is stopped when the adapter is stopped
+	restServer        *Server
+	restServerMutex   sync.Mutex
+	restServerStopped = make(chan struct{})
 )
 
 //go:generate swagger generate server --target ../../api --name Restapi --spec ../../../../resources/adminAPI.yaml --server-package restserver --principal models.Principal
@@ -101,6 +106,7 @@ func configureAPI(api *operations.RestapiAPI) http.Handler {
 		}
 		p := models.Principal{}
 		p.Token = token
+		p.Username = auth.GetTokenUsername(token)
 		return &p, nil
 	}
 
@@ -151,7 +157,7 @@ func configureAPI(api *operations.RestapiAPI) http.Handler {
 		if params.Environments != nil {
 			environments = strings.Split(*params.Environments, ":")
 		}
-		err := xds.DeleteAPIs(vhost, params.APIName, params.Version, environments, tenantDomain)
+		err := xds.DeleteAPIs(vhost, params.APIName, params.Version, environments, tenantDomain, principal.Username)
 		if err == nil {
 			return api_individual.NewDeleteApisOK()
 		}
@@ -185,8 +191,21 @@ func configureAPI(api *operations.RestapiAPI) http.Handler {
 		}
 
 		jsonByteArray, _ := ioutil.ReadAll(params.File)
-		_, err := apiServer.ApplyAPIProjectInStandaloneMode(jsonByteArray, params.Override)
+		deployStartTime := time.Now()
+		_, err := apiServer.ApplyAPIProjectInStandaloneMode(jsonByteArray, params.Override, principal.Username)
+		metrics.ObserveAPIDeployDuration(metrics.DeploySourceREST, time.Since(deployStartTime), err,
+			metrics.TraceIDFromRequest(params.HTTPRequest))
 		if err != nil {
+			var admissionErr *apiServer.AdmissionError
+			var capabilityErr *apiServer.CapabilityError
+			if goerrors.As(err, &admissionErr) || goerrors.As(err, &capabilityErr) {
+				errCode := int64(400)
+				errMsg := err.Error()
+				return api_individual.NewDeleteApisBadRequest().WithPayload(&models.Error{
+					Code:    &errCode,
+					Message: &errMsg,
+				})
+			}
 			if err.Error() == constants.AlreadyExists {
 				return api_individual.NewPostApisConflict()
 			} else if strings.HasPrefix(err.Error(), "An API exists with the same basepath") {
@@ -198,11 +217,14 @@ func configureAPI(api *operations.RestapiAPI) http.Handler {
 		return api_individual.NewPostApisOK()
 	})
 
+	// Handlers for the adapter admin operations
+	configureAdminHandlers(api)
+
 	api.PreServerShutdown = func() {}
 
 	api.ServerShutdown = func() {}
 
-	return setupGlobalMiddleware(api.Serve(setupMiddlewares))
+	return setupGlobalMiddleware(api.Serve(setupMiddlewares), api.Context())
 }
 
 // The TLS configuration before HTTPS server starts.
@@ -229,8 +251,8 @@ func setupMiddlewares(handler http.Handler) http.Handler {
 
 // The middleware configuration happens before anything, this middleware also applies to serving the swagger.json document.
 // So this is a good place to plug in a panic handling middleware, logging and metrics
-func setupGlobalMiddleware(handler http.Handler) http.Handler {
-	return handler
+func setupGlobalMiddleware(handler http.Handler, apiContext *middleware.Context) http.Handler {
+	return instrumentRequests(handler, apiContext)
 }
 
 // StartRestServer starts the listener which is used to fetch the requests sent from apictl.
@@ -261,6 +283,12 @@ func StartRestServer(config *config.Config) {
 		return
 	}
 	server.TLSPort = port
+	// requests in progress are given the drain timeout to complete, when the server is shutdown
+	server.GracefulTimeout = time.Duration(mgwConfig.Adapter.Shutdown.DrainTimeoutInSeconds) * time.Second
+	restServerMutex.Lock()
+	restServer = server
+	restServerMutex.Unlock()
+	defer close(restServerStopped)
 
 	// handle server interruption
 	go func() {
@@ -274,14 +302,24 @@ func StartRestServer(config *config.Config) {
 		}
 	}()
 
-	// expose new port for serving pprof based go profiling endpoints
-	go func() {
-		logger.LoggerAPI.Fatal(http.ListenAndServe("127.0.0.1:6060", nil))
-	}()
-
 	health.RestService.SetStatus(true)
 	if err := server.Serve(); err != nil {
 		logger.LoggerAPI.Fatal(err)
 		health.RestService.SetStatus(false)
 	}
 }
+
+// ShutdownRestServer stops the REST API server accepting new connections, and waits until the requests in
+// progress are completed or the drain timeout elapses.
+func ShutdownRestServer() {
+	restServerMutex.Lock()
+	server := restServer
+	restServerMutex.Unlock()
+	if server == nil {
+		return
+	}
+	logger.LoggerAPI.Info("Shutting down the REST API server ...")
+	server.Shutdown()
+	<-restServerStopped
+	logger.LoggerAPI.Info("REST API server is stopped")
+}
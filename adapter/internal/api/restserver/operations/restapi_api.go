@@ -37,7 +37,14 @@ import (
 	"github.com/wso2/product-microgateway/adapter/internal/api/models"
 	"github.com/wso2/product-microgateway/adapter/internal/api/restserver/operations/api_collection"
 	"github.com/wso2/product-microgateway/adapter/internal/api/restserver/operations/api_individual"
+	"github.com/wso2/product-microgateway/adapter/internal/api/restserver/operations/audit_trail"
 	"github.com/wso2/product-microgateway/adapter/internal/api/restserver/operations/authorization"
+	"github.com/wso2/product-microgateway/adapter/internal/api/restserver/operations/bot_detection"
+	"github.com/wso2/product-microgateway/adapter/internal/api/restserver/operations/event_injection"
+	"github.com/wso2/product-microgateway/adapter/internal/api/restserver/operations/gateway"
+	"github.com/wso2/product-microgateway/adapter/internal/api/restserver/operations/job"
+	"github.com/wso2/product-microgateway/adapter/internal/api/restserver/operations/rate_limit"
+	"github.com/wso2/product-microgateway/adapter/internal/api/restserver/operations/resource_store"
 )
 
 // NewRestapiAPI creates a new Restapi instance
@@ -66,15 +73,99 @@ func NewRestapiAPI(spec *loads.Document) *RestapiAPI {
 		APIIndividualDeleteApisHandler: api_individual.DeleteApisHandlerFunc(func(params api_individual.DeleteApisParams, principal *models.Principal) middleware.Responder {
 			return middleware.NotImplemented("operation api_individual.DeleteApis has not yet been implemented")
 		}),
+		APIIndividualDeleteApisAdvisoriesHandler: api_individual.DeleteApisAdvisoriesHandlerFunc(func(params api_individual.DeleteApisAdvisoriesParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation api_individual.DeleteApisAdvisories has not yet been implemented")
+		}),
+		RateLimitDeleteRatelimitExemptionsHandler: rate_limit.DeleteRatelimitExemptionsHandlerFunc(func(params rate_limit.DeleteRatelimitExemptionsParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation rate_limit.DeleteRatelimitExemptions has not yet been implemented")
+		}),
 		APICollectionGetApisHandler: api_collection.GetApisHandlerFunc(func(params api_collection.GetApisParams, principal *models.Principal) middleware.Responder {
 			return middleware.NotImplemented("operation api_collection.GetApis has not yet been implemented")
 		}),
+		APICollectionGetApisAdmissionRejectionsHandler: api_collection.GetApisAdmissionRejectionsHandlerFunc(func(params api_collection.GetApisAdmissionRejectionsParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation api_collection.GetApisAdmissionRejections has not yet been implemented")
+		}),
+		APIIndividualGetApisAdvisoriesHandler: api_individual.GetApisAdvisoriesHandlerFunc(func(params api_individual.GetApisAdvisoriesParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation api_individual.GetApisAdvisories has not yet been implemented")
+		}),
+		APICollectionGetApisProbesHandler: api_collection.GetApisProbesHandlerFunc(func(params api_collection.GetApisProbesParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation api_collection.GetApisProbes has not yet been implemented")
+		}),
+		AuditTrailGetAuditChangesHandler: audit_trail.GetAuditChangesHandlerFunc(func(params audit_trail.GetAuditChangesParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation audit_trail.GetAuditChanges has not yet been implemented")
+		}),
+		AuditTrailGetAuditEventsHandler: audit_trail.GetAuditEventsHandlerFunc(func(params audit_trail.GetAuditEventsParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation audit_trail.GetAuditEvents has not yet been implemented")
+		}),
+		BotDetectionGetBotdetectionEventsHandler: bot_detection.GetBotdetectionEventsHandlerFunc(func(params bot_detection.GetBotdetectionEventsParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation bot_detection.GetBotdetectionEvents has not yet been implemented")
+		}),
+		GatewayGetConfigHandler: gateway.GetConfigHandlerFunc(func(params gateway.GetConfigParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation gateway.GetConfig has not yet been implemented")
+		}),
+		JobGetJobsHandler: job.GetJobsHandlerFunc(func(params job.GetJobsParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation job.GetJobs has not yet been implemented")
+		}),
+		JobGetJobsJobIDHandler: job.GetJobsJobIDHandlerFunc(func(params job.GetJobsJobIDParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation job.GetJobsJobID has not yet been implemented")
+		}),
+		RateLimitGetRatelimitExemptionsHandler: rate_limit.GetRatelimitExemptionsHandlerFunc(func(params rate_limit.GetRatelimitExemptionsParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation rate_limit.GetRatelimitExemptions has not yet been implemented")
+		}),
+		GatewayGetStandbyHandler: gateway.GetStandbyHandlerFunc(func(params gateway.GetStandbyParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation gateway.GetStandby has not yet been implemented")
+		}),
+		GatewayGetStateHandler: gateway.GetStateHandlerFunc(func(params gateway.GetStateParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation gateway.GetState has not yet been implemented")
+		}),
+		ResourceStoreGetStoresHandler: resource_store.GetStoresHandlerFunc(func(params resource_store.GetStoresParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation resource_store.GetStores has not yet been implemented")
+		}),
+		ResourceStoreGetStoresLookupHandler: resource_store.GetStoresLookupHandlerFunc(func(params resource_store.GetStoresLookupParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation resource_store.GetStoresLookup has not yet been implemented")
+		}),
+		APICollectionGetWebsubHubsHandler: api_collection.GetWebsubHubsHandlerFunc(func(params api_collection.GetWebsubHubsParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation api_collection.GetWebsubHubs has not yet been implemented")
+		}),
 		APIIndividualPostApisHandler: api_individual.PostApisHandlerFunc(func(params api_individual.PostApisParams, principal *models.Principal) middleware.Responder {
 			return middleware.NotImplemented("operation api_individual.PostApis has not yet been implemented")
 		}),
+		APIIndividualPostApisAdvisoriesHandler: api_individual.PostApisAdvisoriesHandlerFunc(func(params api_individual.PostApisAdvisoriesParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation api_individual.PostApisAdvisories has not yet been implemented")
+		}),
+		APIIndividualPostApisRevisionsPromoteHandler: api_individual.PostApisRevisionsPromoteHandlerFunc(func(params api_individual.PostApisRevisionsPromoteParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation api_individual.PostApisRevisionsPromote has not yet been implemented")
+		}),
+		APIIndividualPostApisRevisionsRollbackHandler: api_individual.PostApisRevisionsRollbackHandlerFunc(func(params api_individual.PostApisRevisionsRollbackParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation api_individual.PostApisRevisionsRollback has not yet been implemented")
+		}),
+		APIIndividualPostApisValidateHandler: api_individual.PostApisValidateHandlerFunc(func(params api_individual.PostApisValidateParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation api_individual.PostApisValidate has not yet been implemented")
+		}),
+		JobPostCompactionHandler: job.PostCompactionHandlerFunc(func(params job.PostCompactionParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation job.PostCompaction has not yet been implemented")
+		}),
+		EventInjectionPostEventsInjectHandler: event_injection.PostEventsInjectHandlerFunc(func(params event_injection.PostEventsInjectParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation event_injection.PostEventsInject has not yet been implemented")
+		}),
 		AuthorizationPostOauth2TokenHandler: authorization.PostOauth2TokenHandlerFunc(func(params authorization.PostOauth2TokenParams) middleware.Responder {
 			return middleware.NotImplemented("operation authorization.PostOauth2Token has not yet been implemented")
 		}),
+		RateLimitPostRatelimitExemptionsHandler: rate_limit.PostRatelimitExemptionsHandlerFunc(func(params rate_limit.PostRatelimitExemptionsParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation rate_limit.PostRatelimitExemptions has not yet been implemented")
+		}),
+		JobPostRebuildHandler: job.PostRebuildHandlerFunc(func(params job.PostRebuildParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation job.PostRebuild has not yet been implemented")
+		}),
+		JobPostResyncHandler: job.PostResyncHandlerFunc(func(params job.PostResyncParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation job.PostResync has not yet been implemented")
+		}),
+		GatewayPostStandbyActivateHandler: gateway.PostStandbyActivateHandlerFunc(func(params gateway.PostStandbyActivateParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation gateway.PostStandbyActivate has not yet been implemented")
+		}),
+		GatewayPostStandbyDeactivateHandler: gateway.PostStandbyDeactivateHandlerFunc(func(params gateway.PostStandbyDeactivateParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation gateway.PostStandbyDeactivate has not yet been implemented")
+		}),
 
 		// Applies when the Authorization header is set with the Basic scheme
 		BasicAuthAuth: func(user string, pass string) (*models.Principal, error) {
@@ -138,12 +229,68 @@ type RestapiAPI struct {
 
 	// APIIndividualDeleteApisHandler sets the operation handler for the delete apis operation
 	APIIndividualDeleteApisHandler api_individual.DeleteApisHandler
+	// APIIndividualDeleteApisAdvisoriesHandler sets the operation handler for the delete apis advisories operation
+	APIIndividualDeleteApisAdvisoriesHandler api_individual.DeleteApisAdvisoriesHandler
+	// RateLimitDeleteRatelimitExemptionsHandler sets the operation handler for the delete ratelimit exemptions operation
+	RateLimitDeleteRatelimitExemptionsHandler rate_limit.DeleteRatelimitExemptionsHandler
 	// APICollectionGetApisHandler sets the operation handler for the get apis operation
 	APICollectionGetApisHandler api_collection.GetApisHandler
+	// APICollectionGetApisAdmissionRejectionsHandler sets the operation handler for the get apis admission rejections operation
+	APICollectionGetApisAdmissionRejectionsHandler api_collection.GetApisAdmissionRejectionsHandler
+	// APIIndividualGetApisAdvisoriesHandler sets the operation handler for the get apis advisories operation
+	APIIndividualGetApisAdvisoriesHandler api_individual.GetApisAdvisoriesHandler
+	// APICollectionGetApisProbesHandler sets the operation handler for the get apis probes operation
+	APICollectionGetApisProbesHandler api_collection.GetApisProbesHandler
+	// AuditTrailGetAuditChangesHandler sets the operation handler for the get audit changes operation
+	AuditTrailGetAuditChangesHandler audit_trail.GetAuditChangesHandler
+	// AuditTrailGetAuditEventsHandler sets the operation handler for the get audit events operation
+	AuditTrailGetAuditEventsHandler audit_trail.GetAuditEventsHandler
+	// BotDetectionGetBotdetectionEventsHandler sets the operation handler for the get botdetection events operation
+	BotDetectionGetBotdetectionEventsHandler bot_detection.GetBotdetectionEventsHandler
+	// GatewayGetConfigHandler sets the operation handler for the get config operation
+	GatewayGetConfigHandler gateway.GetConfigHandler
+	// JobGetJobsHandler sets the operation handler for the get jobs operation
+	JobGetJobsHandler job.GetJobsHandler
+	// JobGetJobsJobIDHandler sets the operation handler for the get jobs job ID operation
+	JobGetJobsJobIDHandler job.GetJobsJobIDHandler
+	// RateLimitGetRatelimitExemptionsHandler sets the operation handler for the get ratelimit exemptions operation
+	RateLimitGetRatelimitExemptionsHandler rate_limit.GetRatelimitExemptionsHandler
+	// GatewayGetStandbyHandler sets the operation handler for the get standby operation
+	GatewayGetStandbyHandler gateway.GetStandbyHandler
+	// GatewayGetStateHandler sets the operation handler for the get state operation
+	GatewayGetStateHandler gateway.GetStateHandler
+	// ResourceStoreGetStoresHandler sets the operation handler for the get stores operation
+	ResourceStoreGetStoresHandler resource_store.GetStoresHandler
+	// ResourceStoreGetStoresLookupHandler sets the operation handler for the get stores lookup operation
+	ResourceStoreGetStoresLookupHandler resource_store.GetStoresLookupHandler
+	// APICollectionGetWebsubHubsHandler sets the operation handler for the get websub hubs operation
+	APICollectionGetWebsubHubsHandler api_collection.GetWebsubHubsHandler
 	// APIIndividualPostApisHandler sets the operation handler for the post apis operation
 	APIIndividualPostApisHandler api_individual.PostApisHandler
+	// APIIndividualPostApisAdvisoriesHandler sets the operation handler for the post apis advisories operation
+	APIIndividualPostApisAdvisoriesHandler api_individual.PostApisAdvisoriesHandler
+	// APIIndividualPostApisRevisionsPromoteHandler sets the operation handler for the post apis revisions promote operation
+	APIIndividualPostApisRevisionsPromoteHandler api_individual.PostApisRevisionsPromoteHandler
+	// APIIndividualPostApisRevisionsRollbackHandler sets the operation handler for the post apis revisions rollback operation
+	APIIndividualPostApisRevisionsRollbackHandler api_individual.PostApisRevisionsRollbackHandler
+	// APIIndividualPostApisValidateHandler sets the operation handler for the post apis validate operation
+	APIIndividualPostApisValidateHandler api_individual.PostApisValidateHandler
+	// JobPostCompactionHandler sets the operation handler for the post compaction operation
+	JobPostCompactionHandler job.PostCompactionHandler
+	// EventInjectionPostEventsInjectHandler sets the operation handler for the post events inject operation
+	EventInjectionPostEventsInjectHandler event_injection.PostEventsInjectHandler
 	// AuthorizationPostOauth2TokenHandler sets the operation handler for the post oauth2 token operation
 	AuthorizationPostOauth2TokenHandler authorization.PostOauth2TokenHandler
+	// RateLimitPostRatelimitExemptionsHandler sets the operation handler for the post ratelimit exemptions operation
+	RateLimitPostRatelimitExemptionsHandler rate_limit.PostRatelimitExemptionsHandler
+	// JobPostRebuildHandler sets the operation handler for the post rebuild operation
+	JobPostRebuildHandler job.PostRebuildHandler
+	// JobPostResyncHandler sets the operation handler for the post resync operation
+	JobPostResyncHandler job.PostResyncHandler
+	// GatewayPostStandbyActivateHandler sets the operation handler for the post standby activate operation
+	GatewayPostStandbyActivateHandler gateway.PostStandbyActivateHandler
+	// GatewayPostStandbyDeactivateHandler sets the operation handler for the post standby deactivate operation
+	GatewayPostStandbyDeactivateHandler gateway.PostStandbyDeactivateHandler
 
 	// ServeError is called when an error is received, there is a default handler
 	// but you can set your own with this
@@ -234,15 +381,99 @@ func (o *RestapiAPI) Validate() error {
 	if o.APIIndividualDeleteApisHandler == nil {
 		unregistered = append(unregistered, "api_individual.DeleteApisHandler")
 	}
+	if o.APIIndividualDeleteApisAdvisoriesHandler == nil {
+		unregistered = append(unregistered, "api_individual.DeleteApisAdvisoriesHandler")
+	}
+	if o.RateLimitDeleteRatelimitExemptionsHandler == nil {
+		unregistered = append(unregistered, "rate_limit.DeleteRatelimitExemptionsHandler")
+	}
 	if o.APICollectionGetApisHandler == nil {
 		unregistered = append(unregistered, "api_collection.GetApisHandler")
 	}
+	if o.APICollectionGetApisAdmissionRejectionsHandler == nil {
+		unregistered = append(unregistered, "api_collection.GetApisAdmissionRejectionsHandler")
+	}
+	if o.APIIndividualGetApisAdvisoriesHandler == nil {
+		unregistered = append(unregistered, "api_individual.GetApisAdvisoriesHandler")
+	}
+	if o.APICollectionGetApisProbesHandler == nil {
+		unregistered = append(unregistered, "api_collection.GetApisProbesHandler")
+	}
+	if o.AuditTrailGetAuditChangesHandler == nil {
+		unregistered = append(unregistered, "audit_trail.GetAuditChangesHandler")
+	}
+	if o.AuditTrailGetAuditEventsHandler == nil {
+		unregistered = append(unregistered, "audit_trail.GetAuditEventsHandler")
+	}
+	if o.BotDetectionGetBotdetectionEventsHandler == nil {
+		unregistered = append(unregistered, "bot_detection.GetBotdetectionEventsHandler")
+	}
+	if o.GatewayGetConfigHandler == nil {
+		unregistered = append(unregistered, "gateway.GetConfigHandler")
+	}
+	if o.JobGetJobsHandler == nil {
+		unregistered = append(unregistered, "job.GetJobsHandler")
+	}
+	if o.JobGetJobsJobIDHandler == nil {
+		unregistered = append(unregistered, "job.GetJobsJobIDHandler")
+	}
+	if o.RateLimitGetRatelimitExemptionsHandler == nil {
+		unregistered = append(unregistered, "rate_limit.GetRatelimitExemptionsHandler")
+	}
+	if o.GatewayGetStandbyHandler == nil {
+		unregistered = append(unregistered, "gateway.GetStandbyHandler")
+	}
+	if o.GatewayGetStateHandler == nil {
+		unregistered = append(unregistered, "gateway.GetStateHandler")
+	}
+	if o.ResourceStoreGetStoresHandler == nil {
+		unregistered = append(unregistered, "resource_store.GetStoresHandler")
+	}
+	if o.ResourceStoreGetStoresLookupHandler == nil {
+		unregistered = append(unregistered, "resource_store.GetStoresLookupHandler")
+	}
+	if o.APICollectionGetWebsubHubsHandler == nil {
+		unregistered = append(unregistered, "api_collection.GetWebsubHubsHandler")
+	}
 	if o.APIIndividualPostApisHandler == nil {
 		unregistered = append(unregistered, "api_individual.PostApisHandler")
 	}
+	if o.APIIndividualPostApisAdvisoriesHandler == nil {
+		unregistered = append(unregistered, "api_individual.PostApisAdvisoriesHandler")
+	}
+	if o.APIIndividualPostApisRevisionsPromoteHandler == nil {
+		unregistered = append(unregistered, "api_individual.PostApisRevisionsPromoteHandler")
+	}
+	if o.APIIndividualPostApisRevisionsRollbackHandler == nil {
+		unregistered = append(unregistered, "api_individual.PostApisRevisionsRollbackHandler")
+	}
+	if o.APIIndividualPostApisValidateHandler == nil {
+		unregistered = append(unregistered, "api_individual.PostApisValidateHandler")
+	}
+	if o.JobPostCompactionHandler == nil {
+		unregistered = append(unregistered, "job.PostCompactionHandler")
+	}
+	if o.EventInjectionPostEventsInjectHandler == nil {
+		unregistered = append(unregistered, "event_injection.PostEventsInjectHandler")
+	}
 	if o.AuthorizationPostOauth2TokenHandler == nil {
 		unregistered = append(unregistered, "authorization.PostOauth2TokenHandler")
 	}
+	if o.RateLimitPostRatelimitExemptionsHandler == nil {
+		unregistered = append(unregistered, "rate_limit.PostRatelimitExemptionsHandler")
+	}
+	if o.JobPostRebuildHandler == nil {
+		unregistered = append(unregistered, "job.PostRebuildHandler")
+	}
+	if o.JobPostResyncHandler == nil {
+		unregistered = append(unregistered, "job.PostResyncHandler")
+	}
+	if o.GatewayPostStandbyActivateHandler == nil {
+		unregistered = append(unregistered, "gateway.PostStandbyActivateHandler")
+	}
+	if o.GatewayPostStandbyDeactivateHandler == nil {
+		unregistered = append(unregistered, "gateway.PostStandbyDeactivateHandler")
+	}
 
 	if len(unregistered) > 0 {
 		return fmt.Errorf("missing registration: %s", strings.Join(unregistered, ", "))
@@ -352,10 +583,78 @@ func (o *RestapiAPI) initHandlerCache() {
 		o.handlers["DELETE"] = make(map[string]http.Handler)
 	}
 	o.handlers["DELETE"]["/apis"] = api_individual.NewDeleteApis(o.context, o.APIIndividualDeleteApisHandler)
+	if o.handlers["DELETE"] == nil {
+		o.handlers["DELETE"] = make(map[string]http.Handler)
+	}
+	o.handlers["DELETE"]["/apis/advisories"] = api_individual.NewDeleteApisAdvisories(o.context, o.APIIndividualDeleteApisAdvisoriesHandler)
+	if o.handlers["DELETE"] == nil {
+		o.handlers["DELETE"] = make(map[string]http.Handler)
+	}
+	o.handlers["DELETE"]["/ratelimit/exemptions"] = rate_limit.NewDeleteRatelimitExemptions(o.context, o.RateLimitDeleteRatelimitExemptionsHandler)
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
 	o.handlers["GET"]["/apis"] = api_collection.NewGetApis(o.context, o.APICollectionGetApisHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/apis/admission/rejections"] = api_collection.NewGetApisAdmissionRejections(o.context, o.APICollectionGetApisAdmissionRejectionsHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/apis/advisories"] = api_individual.NewGetApisAdvisories(o.context, o.APIIndividualGetApisAdvisoriesHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/apis/probes"] = api_collection.NewGetApisProbes(o.context, o.APICollectionGetApisProbesHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/audit/changes"] = audit_trail.NewGetAuditChanges(o.context, o.AuditTrailGetAuditChangesHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/audit/events"] = audit_trail.NewGetAuditEvents(o.context, o.AuditTrailGetAuditEventsHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/botdetection/events"] = bot_detection.NewGetBotdetectionEvents(o.context, o.BotDetectionGetBotdetectionEventsHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/config"] = gateway.NewGetConfig(o.context, o.GatewayGetConfigHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/jobs"] = job.NewGetJobs(o.context, o.JobGetJobsHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/jobs/{jobId}"] = job.NewGetJobsJobID(o.context, o.JobGetJobsJobIDHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/ratelimit/exemptions"] = rate_limit.NewGetRatelimitExemptions(o.context, o.RateLimitGetRatelimitExemptionsHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/standby"] = gateway.NewGetStandby(o.context, o.GatewayGetStandbyHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/state"] = gateway.NewGetState(o.context, o.GatewayGetStateHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/stores"] = resource_store.NewGetStores(o.context, o.ResourceStoreGetStoresHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/stores/lookup"] = resource_store.NewGetStoresLookup(o.context, o.ResourceStoreGetStoresLookupHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/websub/hubs"] = api_collection.NewGetWebsubHubs(o.context, o.APICollectionGetWebsubHubsHandler)
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
@@ -363,7 +662,51 @@ func (o *RestapiAPI) initHandlerCache() {
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
+	o.handlers["POST"]["/apis/advisories"] = api_individual.NewPostApisAdvisories(o.context, o.APIIndividualPostApisAdvisoriesHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/apis/revisions/promote"] = api_individual.NewPostApisRevisionsPromote(o.context, o.APIIndividualPostApisRevisionsPromoteHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/apis/revisions/rollback"] = api_individual.NewPostApisRevisionsRollback(o.context, o.APIIndividualPostApisRevisionsRollbackHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/apis/validate"] = api_individual.NewPostApisValidate(o.context, o.APIIndividualPostApisValidateHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/compaction"] = job.NewPostCompaction(o.context, o.JobPostCompactionHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/events/inject"] = event_injection.NewPostEventsInject(o.context, o.EventInjectionPostEventsInjectHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
 	o.handlers["POST"]["/oauth2/token"] = authorization.NewPostOauth2Token(o.context, o.AuthorizationPostOauth2TokenHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/ratelimit/exemptions"] = rate_limit.NewPostRatelimitExemptions(o.context, o.RateLimitPostRatelimitExemptionsHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/rebuild"] = job.NewPostRebuild(o.context, o.JobPostRebuildHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/resync"] = job.NewPostResync(o.context, o.JobPostResyncHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/standby/activate"] = gateway.NewPostStandbyActivate(o.context, o.GatewayPostStandbyActivateHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/standby/deactivate"] = gateway.NewPostStandbyDeactivate(o.context, o.GatewayPostStandbyDeactivateHandler)
 }
 
 // Serve creates a http handler to serve the API over HTTP
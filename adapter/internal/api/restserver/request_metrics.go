@@ -0,0 +1,62 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package restserver
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-openapi/runtime/middleware"
+
+	"github.com/wso2/product-microgateway/adapter/pkg/metrics"
+)
+
+const unknownOperation string = "unknown"
+
+// statusRecorder captures the status code written to the response.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (recorder *statusRecorder) WriteHeader(status int) {
+	recorder.status = status
+	recorder.ResponseWriter.WriteHeader(status)
+}
+
+// instrumentRequests records the latency of the REST API requests, along with the trace ID of the request.
+func instrumentRequests(handler http.Handler, apiContext *middleware.Context) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		handler.ServeHTTP(recorder, r)
+		metrics.ObserveRESTRequestDuration(r.Method, requestOperation(apiContext, r), recorder.status, time.Since(start),
+			metrics.TraceIDFromRequest(r))
+	})
+}
+
+// requestOperation returns the path template of the REST API operation of the request (relative to the REST API
+// basepath). Unknown paths are not used as they are, as each would add a new series to the histogram.
+func requestOperation(apiContext *middleware.Context, r *http.Request) string {
+	route, found := apiContext.LookupRoute(r)
+	if !found {
+		return unknownOperation
+	}
+	return strings.TrimPrefix(route.PathPattern, route.BasePath)
+}
@@ -0,0 +1,346 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package audit
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/wso2/product-microgateway/adapter/config"
+)
+
+func TestDiffRoutes(t *testing.T) {
+	newRoute := func(name, path, cluster string) *routev3.Route {
+		return &routev3.Route{
+			Name: name,
+			Match: &routev3.RouteMatch{
+				PathSpecifier: &routev3.RouteMatch_Path{Path: path},
+			},
+			Action: &routev3.Route_Route{
+				Route: &routev3.RouteAction{
+					ClusterSpecifier: &routev3.RouteAction_Cluster{Cluster: cluster},
+				},
+			},
+		}
+	}
+	oldRoutes := []*routev3.Route{newRoute("/pets", "/pets", "c1"), newRoute("/orders", "/orders", "c1")}
+	newRoutes := []*routev3.Route{newRoute("/pets", "/pets", "c2"), newRoute("/users", "/users", "c1")}
+
+	changes := DiffRoutes(oldRoutes, newRoutes)
+	assert.Equal(t, 3, len(changes), "Route change count mismatch")
+	assert.Equal(t, RouteModified, changes[0].Change)
+	assert.Equal(t, "/pets", changes[0].Name)
+	assert.Equal(t, RouteAdded, changes[1].Change)
+	assert.Equal(t, "/users", changes[1].Name)
+	assert.Equal(t, RouteRemoved, changes[2].Change)
+	assert.Equal(t, "/orders", changes[2].Name)
+
+	assert.Empty(t, DiffRoutes(oldRoutes, oldRoutes), "Identical routes should not be reported")
+	assert.Equal(t, 2, len(DiffRoutes(oldRoutes, nil)), "All routes should be removed")
+}
+
+func TestRecordChangeAndGenerateReport(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	auditConf := conf.Adapter.Audit
+	keystore := conf.Adapter.Keystore
+	defer func() {
+		conf.Adapter.Audit = auditConf
+		conf.Adapter.Keystore = keystore
+		records = nil
+	}()
+	conf.Adapter.Audit.Enabled = true
+	conf.Adapter.Audit.MaxRecordsInMemory = 2
+	conf.Adapter.Audit.JournalFilePath = ""
+	conf.Adapter.Keystore.KeyPath = config.GetMgwHome() + "/security/mg.key"
+	conf.Adapter.Keystore.CertPath = config.GetMgwHome() + "/security/mg.pem"
+
+	start := time.Date(2022, 5, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		RecordChange(ChangeRecord{
+			Timestamp:     start.Add(time.Duration(i) * time.Hour),
+			Actor:         "admin",
+			Action:        ActionDeploy,
+			APIIdentifier: "localhost:api" + string(rune('1'+i)),
+		})
+	}
+	changes, err := GetChanges(start, start.Add(5*time.Hour))
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(changes), "Only the configured number of records should be kept in memory")
+	assert.Equal(t, "localhost:api2", changes[0].APIIdentifier)
+
+	// Journal file keeps all the records
+	conf.Adapter.Audit.JournalFilePath = filepath.Join(t.TempDir(), "journal.jsonl")
+	for i := 0; i < 3; i++ {
+		RecordChange(ChangeRecord{
+			Timestamp:     start.Add(time.Duration(i) * time.Hour),
+			Actor:         ActorControlPlane,
+			Action:        ActionUndeploy,
+			APIIdentifier: "localhost:api" + string(rune('1'+i)),
+		})
+	}
+	changes, err = GetChanges(start.Add(time.Hour), start.Add(5*time.Hour))
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(changes), "Changes within the time range should be returned from the journal file")
+
+	report, err := GenerateReport(start, start.Add(5*time.Hour))
+	assert.Nil(t, err, "Error while generating the report")
+	var content ReportContent
+	assert.Nil(t, json.Unmarshal(report.Content, &content))
+	assert.Equal(t, 3, len(content.Changes), "Report change count mismatch")
+	assert.Equal(t, "SHA256withRSA", report.SignatureAlgorithm)
+
+	block, _ := pem.Decode([]byte(report.Certificate))
+	assert.NotNil(t, block, "Certificate should be attached to the report")
+	cert, err := x509.ParseCertificate(block.Bytes)
+	assert.Nil(t, err)
+	signature, err := base64.StdEncoding.DecodeString(report.Signature)
+	assert.Nil(t, err)
+	// Serialized report should carry the signed content as it is
+	serializedReport, err := json.Marshal(report)
+	assert.Nil(t, err)
+	var parsedReport Report
+	assert.Nil(t, json.Unmarshal(serializedReport, &parsedReport))
+	digest := sha256.Sum256(parsedReport.Content)
+	assert.Nil(t, rsa.VerifyPKCS1v15(cert.PublicKey.(*rsa.PublicKey), crypto.SHA256, digest[:], signature),
+		"Report signature should be valid")
+
+	_, err = GenerateReport(start.Add(time.Hour), start)
+	assert.NotNil(t, err, "Invalid time range should not be accepted")
+}
+
+func TestCompactJournal(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	auditConf := conf.Adapter.Audit
+	defer func() {
+		conf.Adapter.Audit = auditConf
+		records = nil
+	}()
+	conf.Adapter.Audit.Enabled = true
+	conf.Adapter.Audit.JournalFilePath = filepath.Join(t.TempDir(), "journal.jsonl")
+	conf.Adapter.Audit.ArtifactEncryptionKey = base64.StdEncoding.EncodeToString([]byte("0123456789abcdef"))
+
+	start := time.Date(2022, 5, 1, 0, 0, 0, 0, time.UTC)
+	record := func(hour int, action, apiIdentifier string, environments ...string) ChangeRecord {
+		changeRecord := ChangeRecord{
+			Timestamp:      start.Add(time.Duration(hour) * time.Hour),
+			Actor:          "admin",
+			Action:         action,
+			OrganizationID: "carbon.super",
+			APIIdentifier:  apiIdentifier,
+			Environments:   environments,
+			VHost:          "localhost",
+		}
+		if action == ActionDeploy {
+			changeRecord.Artifact = &Artifact{Files: []ArtifactFile{{Name: "api.yaml", Content: []byte(apiIdentifier)}}}
+		}
+		return changeRecord
+	}
+	RecordChange(record(0, ActionDeploy, "localhost:api1", "Default"))
+	RecordChange(record(1, ActionDeploy, "localhost:api2", "Default", "Staging"))
+	RecordChange(record(2, ActionDeploy, "localhost:api3", "Default"))
+	RecordChange(record(3, ActionUndeploy, "localhost:api2", "Staging"))
+	RecordChange(record(4, ActionUndeploy, "localhost:api3"))
+	RecordChange(record(5, ActionDeploy, "localhost:api1", "Default"))
+
+	assert.Nil(t, records[0].Artifact, "Artifacts should not be kept in memory")
+	changes, err := GetChanges(start, start.Add(5*time.Hour))
+	assert.Nil(t, err)
+	assert.Nil(t, changes[0].Artifact, "Artifacts should not be included in the changes")
+
+	content, err := ioutil.ReadFile(conf.Adapter.Audit.JournalFilePath)
+	assert.Nil(t, err)
+	assert.NotContains(t, string(content), base64.StdEncoding.EncodeToString([]byte("localhost:api1")),
+		"Artifacts should be encrypted in the journal")
+
+	compacted, err := CompactJournal(false)
+	assert.Nil(t, err, "Error while compacting the journal")
+	deployments, journalRecords := compacted.Deployments, compacted.JournalRecords
+	assert.Equal(t, 6, journalRecords, "Journal record count mismatch")
+	assert.Equal(t, 2, len(deployments), "Undeployed APIs should not be rebuilt")
+	assert.Equal(t, "localhost:api2", deployments[0].APIIdentifier, "APIs should be rebuilt in the deployed order")
+	assert.Equal(t, []string{"Default"}, deployments[0].Environments, "Undeployed environments should be removed")
+	assert.Equal(t, "localhost:api1", deployments[1].APIIdentifier)
+	assert.Equal(t, start.Add(5*time.Hour), deployments[1].Timestamp, "Latest deployment should be rebuilt")
+	assert.Equal(t, []byte("localhost:api1"), deployments[1].Artifact.Files[0].Content)
+
+	// Only the records after the snapshot are read once the snapshot is persisted
+	_, err = CompactJournal(true)
+	assert.Nil(t, err, "Error while persisting the snapshot")
+	RecordChange(record(6, ActionUndeploy, "localhost:api1"))
+	compacted, err = CompactJournal(true)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, compacted.JournalRecords, "Records folded into the snapshot should not be read")
+	assert.Equal(t, 1, len(compacted.Deployments))
+	assert.Equal(t, "localhost:api2", compacted.Deployments[0].APIIdentifier)
+	assert.Equal(t, []byte("localhost:api2"), compacted.Deployments[0].Artifact.Files[0].Content,
+		"Artifacts should be decrypted from the snapshot")
+
+	// Snapshot is discarded if the journal is truncated
+	assert.Nil(t, ioutil.WriteFile(conf.Adapter.Audit.JournalFilePath, nil, 0600))
+	RecordChange(record(7, ActionDeploy, "localhost:api3", "Default"))
+	compacted, err = CompactJournal(false)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, compacted.JournalRecords, "Snapshot should be discarded")
+	assert.Equal(t, 1, len(compacted.Deployments))
+	assert.Equal(t, "localhost:api3", compacted.Deployments[0].APIIdentifier)
+
+	// Artifacts are not persisted without the encryption key
+	conf.Adapter.Audit.ArtifactEncryptionKey = ""
+	RecordChange(record(8, ActionDeploy, "localhost:api4", "Default"))
+	content, err = ioutil.ReadFile(conf.Adapter.Audit.JournalFilePath)
+	assert.Nil(t, err)
+	assert.NotContains(t, string(content), base64.StdEncoding.EncodeToString([]byte("localhost:api4")),
+		"Artifacts should not be persisted in plain text")
+	compacted, err = CompactJournal(false)
+	assert.Nil(t, err)
+	assert.Empty(t, compacted.Deployments, "APIs should not be rebuilt without the encryption key")
+
+	conf.Adapter.Audit.Enabled = false
+	_, err = CompactJournal(false)
+	assert.NotNil(t, err, "Journal should be required to compact")
+}
+
+func TestCompactSubscriptions(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	auditConf := conf.Adapter.Audit
+	defer func() {
+		conf.Adapter.Audit = auditConf
+		records = nil
+	}()
+	conf.Adapter.Audit.Enabled = true
+	conf.Adapter.Audit.JournalFilePath = filepath.Join(t.TempDir(), "journal.jsonl")
+
+	start := time.Date(2022, 5, 1, 0, 0, 0, 0, time.UTC)
+	record := func(hour int, action, dataType string) ChangeRecord {
+		return ChangeRecord{
+			Timestamp:    start.Add(time.Duration(hour) * time.Hour),
+			Actor:        ActorControlPlane,
+			Action:       action,
+			Subscription: &SubscriptionData{Type: dataType, Payload: []byte(`{"consumerKey":"key"}`)},
+		}
+	}
+	// Subscription data is not persisted without the encryption key
+	RecordChange(record(0, ActionLoadSubscriptionData, "subscriptions"))
+	_, err := os.Stat(conf.Adapter.Audit.JournalFilePath)
+	assert.True(t, os.IsNotExist(err), "Subscription data should not be persisted without the encryption key")
+
+	conf.Adapter.Audit.ArtifactEncryptionKey = base64.StdEncoding.EncodeToString([]byte("0123456789abcdef"))
+	RecordChange(record(1, ActionSubscriptionEvent, "SUBSCRIPTIONS_CREATE"))
+	RecordChange(record(2, ActionLoadSubscriptionData, "subscriptions"))
+	RecordChange(record(3, ActionLoadSubscriptionData, "applications"))
+	RecordChange(record(4, ActionSubscriptionEvent, "APPLICATION_CREATE"))
+	RecordChange(record(5, ActionLoadSubscriptionData, "subscriptions"))
+	RecordChange(record(6, ActionSubscriptionEvent, "SUBSCRIPTIONS_DELETE"))
+
+	assert.Empty(t, records, "Subscription data should not be kept in memory")
+	changes, err := GetChanges(start, start.Add(6*time.Hour))
+	assert.Nil(t, err)
+	assert.Empty(t, changes, "Subscription data should not be reported as changes")
+	content, err := ioutil.ReadFile(conf.Adapter.Audit.JournalFilePath)
+	assert.Nil(t, err)
+	assert.NotContains(t, string(content), base64.StdEncoding.EncodeToString([]byte(`{"consumerKey":"key"}`)),
+		"Subscription data should be encrypted in the journal")
+
+	compacted, err := CompactJournal(true)
+	assert.Nil(t, err)
+	var types []string
+	for _, subscription := range compacted.Subscriptions {
+		types = append(types, subscription.Subscription.Type)
+	}
+	assert.Equal(t, []string{"applications", "APPLICATION_CREATE", "subscriptions", "SUBSCRIPTIONS_DELETE"}, types,
+		"Data loaded and the events superseded by the data loaded later should be dropped")
+
+	compacted, err = CompactJournal(false)
+	assert.Nil(t, err)
+	assert.Equal(t, 4, len(compacted.Subscriptions), "Subscription data should be read from the snapshot")
+	assert.Equal(t, []byte(`{"consumerKey":"key"}`), compacted.Subscriptions[0].Subscription.Payload)
+
+	conf.Adapter.Audit.ArtifactEncryptionKey = base64.StdEncoding.EncodeToString([]byte("fedcba9876543210"))
+	compacted, err = CompactJournal(false)
+	assert.Nil(t, err)
+	assert.Empty(t, compacted.Subscriptions, "Subscription data should not be decrypted by another key")
+}
+
+func TestRecordEvent(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	auditConf := conf.Adapter.Audit
+	defer func() {
+		conf.Adapter.Audit = auditConf
+		events = eventRing{}
+	}()
+	conf.Adapter.Audit.Events.Enabled = true
+	conf.Adapter.Audit.Events.MaxEventsInMemory = 3
+	conf.Adapter.Audit.Events.FilePath = filepath.Join(t.TempDir(), "events.jsonl")
+
+	start := time.Date(2022, 5, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 4; i++ {
+		RecordEvent(EventRecord{
+			Timestamp:  start.Add(time.Duration(i) * time.Hour),
+			Source:     EventSourceNotification,
+			Type:       "DEPLOY_API_IN_GATEWAY",
+			ResourceID: "api" + string(rune('1'+i%2)),
+			Outcome:    EventProcessed,
+		})
+	}
+	RecordEvent(EventRecord{
+		Timestamp:  start.Add(5 * time.Hour),
+		Source:     EventSourceNotification,
+		Type:       "REMOVE_API_FROM_GATEWAY",
+		ResourceID: "api1",
+		Outcome:    EventProcessed,
+	})
+
+	all := GetEvents(EventFilter{})
+	assert.Equal(t, 3, len(all), "Only the configured number of events should be kept in memory")
+	assert.Equal(t, start.Add(2*time.Hour), all[0].Timestamp, "Oldest events should be overwritten")
+	assert.Equal(t, "REMOVE_API_FROM_GATEWAY", all[2].Type)
+
+	byAPI := GetEvents(EventFilter{ResourceID: "api1"})
+	assert.Equal(t, 2, len(byAPI))
+	byType := GetEvents(EventFilter{ResourceID: "api1", Type: "REMOVE_API_FROM_GATEWAY"})
+	assert.Equal(t, 1, len(byType))
+	assert.Equal(t, start.Add(5*time.Hour), byType[0].Timestamp)
+	byTime := GetEvents(EventFilter{From: start.Add(3 * time.Hour), To: start.Add(4 * time.Hour)})
+	assert.Equal(t, 1, len(byTime))
+	assert.Equal(t, "api2", byTime[0].ResourceID)
+
+	conf.Adapter.Audit.Events.MaxEventsInMemory = 2
+	RecordEvent(EventRecord{Type: "HEALTH_CHECK", Outcome: EventIgnored})
+	resized := GetEvents(EventFilter{})
+	assert.Equal(t, 2, len(resized), "Ring should be resized, keeping the latest events")
+	assert.Equal(t, "REMOVE_API_FROM_GATEWAY", resized[0].Type)
+	assert.Equal(t, "HEALTH_CHECK", resized[1].Type)
+
+	content, err := ioutil.ReadFile(conf.Adapter.Audit.Events.FilePath)
+	assert.Nil(t, err)
+	assert.Equal(t, 6, strings.Count(string(content), "\n"), "All the events should be appended to the file")
+}
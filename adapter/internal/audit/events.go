@@ -0,0 +1,172 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/wso2/product-microgateway/adapter/config"
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/pkg/logging"
+)
+
+// Sources of the control plane events
+const (
+	EventSourceNotification  string = "notification"
+	EventSourceCatchUp       string = "catch-up"
+	EventSourceGlobalAdapter string = "global-adapter"
+	EventSourceInjected      string = "injected"
+	EventSourceJournal       string = "journal"
+)
+
+// Outcomes of the control plane events
+const (
+	EventProcessed string = "PROCESSED"
+	EventIgnored   string = "IGNORED"
+	EventFailed    string = "FAILED"
+)
+
+// EventRecord represents a control plane event processed by the adapter.
+type EventRecord struct {
+	Timestamp      time.Time `json:"timestamp"`
+	Source         string    `json:"source"`
+	EventID        string    `json:"eventId,omitempty"`
+	Type           string    `json:"type"`
+	ResourceID     string    `json:"resourceId,omitempty"`
+	OrganizationID string    `json:"organizationId,omitempty"`
+	Outcome        string    `json:"outcome"`
+	// Reason explains the outcome (ex: the error of a failed event)
+	Reason string `json:"reason,omitempty"`
+}
+
+// EventFilter selects the event records. The empty fields are not considered, and the time range is inclusive.
+type EventFilter struct {
+	ResourceID string
+	Type       string
+	From       time.Time
+	To         time.Time
+}
+
+// eventRing keeps the latest events in a fixed size buffer, overwriting the oldest event once it is full.
+type eventRing struct {
+	events []EventRecord
+	next   int
+	full   bool
+}
+
+var (
+	eventMutex sync.RWMutex
+	events     eventRing
+)
+
+// RecordEvent adds the event to the history, if recording the events is enabled.
+func RecordEvent(record EventRecord) {
+	conf, _ := config.ReadConfigs()
+	eventConf := conf.Adapter.Audit.Events
+	if !eventConf.Enabled || eventConf.MaxEventsInMemory <= 0 {
+		return
+	}
+	if record.Timestamp.IsZero() {
+		record.Timestamp = time.Now().UTC()
+	}
+
+	eventMutex.Lock()
+	defer eventMutex.Unlock()
+	events.add(record, eventConf.MaxEventsInMemory)
+	if eventConf.FilePath != "" {
+		if err := appendEventToFile(eventConf.FilePath, record); err != nil {
+			logger.LoggerAudit.ErrorC(logging.ErrorDetails{
+				Message: fmt.Sprintf("Error while writing the %s event of %s to the events file. %v", record.Type,
+					record.ResourceID, err),
+				Severity:  logging.MINOR,
+				ErrorCode: 1901,
+			})
+		}
+	}
+	logger.LoggerAudit.Debugf("%s event of %s is recorded as %s.", record.Type, record.ResourceID, record.Outcome)
+}
+
+// GetEvents returns the recorded events matching the filter, in the order they are recorded.
+func GetEvents(filter EventFilter) []EventRecord {
+	eventMutex.RLock()
+	defer eventMutex.RUnlock()
+	matched := []EventRecord{}
+	for _, record := range events.list() {
+		if filter.matches(record) {
+			matched = append(matched, record)
+		}
+	}
+	return matched
+}
+
+func (filter EventFilter) matches(record EventRecord) bool {
+	if filter.ResourceID != "" && filter.ResourceID != record.ResourceID {
+		return false
+	}
+	if filter.Type != "" && filter.Type != record.Type {
+		return false
+	}
+	if !filter.From.IsZero() && record.Timestamp.Before(filter.From) {
+		return false
+	}
+	return filter.To.IsZero() || !record.Timestamp.After(filter.To)
+}
+
+// add adds an event to the ring. The ring is resized (keeping the latest events), if the size is changed.
+func (ring *eventRing) add(record EventRecord, size int) {
+	if len(ring.events) != size {
+		latest := ring.list()
+		if len(latest) > size-1 {
+			latest = latest[len(latest)-(size-1):]
+		}
+		ring.events = make([]EventRecord, size)
+		ring.next = copy(ring.events, latest)
+		ring.full = false
+	}
+	ring.events[ring.next] = record
+	ring.next = (ring.next + 1) % size
+	if ring.next == 0 {
+		ring.full = true
+	}
+}
+
+// list returns the events of the ring, the oldest first.
+func (ring *eventRing) list() []EventRecord {
+	if !ring.full {
+		return append([]EventRecord{}, ring.events[:ring.next]...)
+	}
+	return append(append([]EventRecord{}, ring.events[ring.next:]...), ring.events[:ring.next]...)
+}
+
+func appendEventToFile(filePath string, record EventRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	file, err := os.OpenFile(filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	_, err = file.Write(append(line, '\n'))
+	return err
+}
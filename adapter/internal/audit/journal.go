@@ -0,0 +1,207 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/wso2/product-microgateway/adapter/config"
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/pkg/logging"
+)
+
+var (
+	journalMutex sync.RWMutex
+	// records contains the latest change records, in the order they are recorded
+	records []ChangeRecord
+)
+
+// RecordChange adds the change record to the journal, if auditing is enabled.
+func RecordChange(record ChangeRecord) {
+	conf, _ := config.ReadConfigs()
+	if !conf.Adapter.Audit.Enabled {
+		return
+	}
+	if record.Timestamp.IsZero() {
+		record.Timestamp = time.Now().UTC()
+	}
+
+	replayOnly := isReplayOnly(record.Action)
+	journalMutex.Lock()
+	defer journalMutex.Unlock()
+	if !replayOnly {
+		records = append(records, withoutArtifact(record))
+		if maxRecords := conf.Adapter.Audit.MaxRecordsInMemory; maxRecords > 0 && len(records) > maxRecords {
+			records = records[len(records)-maxRecords:]
+		}
+	}
+	if conf.Adapter.Audit.JournalFilePath != "" {
+		key, err := getArtifactEncryptionKey(conf)
+		if err != nil {
+			logger.LoggerAudit.ErrorC(logging.ErrorDetails{
+				Message:   fmt.Sprintf("Error while reading the artifact encryption key of the journal. %v", err),
+				Severity:  logging.MAJOR,
+				ErrorCode: 1902,
+			})
+		}
+		if replayOnly && key == nil {
+			// the subscription data is recorded only to rebuild the subscriptions
+			return
+		}
+		if record, err = sealRecord(record, key); err == nil {
+			err = appendToJournalFile(conf.Adapter.Audit.JournalFilePath, record)
+		}
+		if err != nil {
+			logger.LoggerAudit.ErrorC(logging.ErrorDetails{
+				Message: fmt.Sprintf("Error while writing the change record of %s to the journal file. %v",
+					record.APIIdentifier, err),
+				Severity:  logging.MAJOR,
+				ErrorCode: 1900,
+			})
+		}
+	}
+	logger.LoggerAudit.Debugf("%s of %s by %s is recorded with %d route changes.", record.Action,
+		record.APIIdentifier, record.Actor, len(record.RouteChanges))
+}
+
+// GetChanges returns the changes recorded within the given time range (both inclusive), sorted by the time.
+// The journal file is read, if configured, as the in memory records are limited.
+func GetChanges(from, to time.Time) ([]ChangeRecord, error) {
+	conf, _ := config.ReadConfigs()
+	journalMutex.RLock()
+	defer journalMutex.RUnlock()
+
+	source := records
+	if conf.Adapter.Audit.JournalFilePath != "" {
+		var err error
+		if source, err = readJournalFile(conf.Adapter.Audit.JournalFilePath); err != nil {
+			return nil, err
+		}
+	}
+	changes := []ChangeRecord{}
+	for _, record := range source {
+		if isReplayOnly(record.Action) {
+			continue
+		}
+		if !record.Timestamp.Before(from) && !record.Timestamp.After(to) {
+			changes = append(changes, withoutArtifact(record))
+		}
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].Timestamp.Before(changes[j].Timestamp)
+	})
+	return changes, nil
+}
+
+// DiffRoutes returns the routes added, removed and modified, when the old routes are replaced with the
+// new routes. A route is identified by its match.
+func DiffRoutes(oldRoutes, newRoutes []*routev3.Route) []RouteChange {
+	oldRouteMap := make(map[string]*routev3.Route, len(oldRoutes))
+	var oldRouteKeys []string
+	for _, route := range oldRoutes {
+		key := getRouteMatchString(route)
+		oldRouteMap[key] = route
+		oldRouteKeys = append(oldRouteKeys, key)
+	}
+	var changes []RouteChange
+	newRouteKeys := make(map[string]struct{}, len(newRoutes))
+	for _, route := range newRoutes {
+		key := getRouteMatchString(route)
+		newRouteKeys[key] = struct{}{}
+		if oldRoute, found := oldRouteMap[key]; !found {
+			changes = append(changes, RouteChange{Change: RouteAdded, Name: route.GetName(), Match: key})
+		} else if !proto.Equal(oldRoute, route) {
+			changes = append(changes, RouteChange{Change: RouteModified, Name: route.GetName(), Match: key})
+		}
+	}
+	for _, key := range oldRouteKeys {
+		if _, found := newRouteKeys[key]; !found {
+			changes = append(changes, RouteChange{Change: RouteRemoved, Name: oldRouteMap[key].GetName(), Match: key})
+		}
+	}
+	return changes
+}
+
+// withoutArtifact returns the record without the deployed artifact and the subscription data, which are required
+// only to rebuild the APIs and the subscriptions.
+func withoutArtifact(record ChangeRecord) ChangeRecord {
+	record.Artifact, record.Subscription, record.SealedContent = nil, nil, nil
+	return record
+}
+
+// isReplayOnly returns whether the records of the action are recorded only to rebuild the subscriptions, which are
+// neither kept in memory nor reported as changes.
+func isReplayOnly(action string) bool {
+	return action == ActionLoadSubscriptionData || action == ActionSubscriptionEvent
+}
+
+func getRouteMatchString(route *routev3.Route) string {
+	match, err := protojson.Marshal(route.GetMatch())
+	if err != nil {
+		return route.GetName()
+	}
+	return string(match)
+}
+
+func appendToJournalFile(journalFilePath string, record ChangeRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	file, err := os.OpenFile(journalFilePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	_, err = file.Write(append(line, '\n'))
+	return err
+}
+
+func readJournalFile(journalFilePath string) ([]ChangeRecord, error) {
+	file, err := os.Open(journalFilePath)
+	if os.IsNotExist(err) {
+		return []ChangeRecord{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var journal []ChangeRecord
+	scanner := bufio.NewScanner(file)
+	// route changes of an API with many resources may exceed the default line limit
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var record ChangeRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			logger.LoggerAudit.Warnf("Skipping a malformed change record in the journal file. %v", err)
+			continue
+		}
+		journal = append(journal, record)
+	}
+	return journal, scanner.Err()
+}
@@ -0,0 +1,279 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package audit
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/wso2/product-microgateway/adapter/config"
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+)
+
+const snapshotFileSuffix string = ".snapshot"
+
+// journalSnapshot is the compacted state of the journal. The journal records up to the offset are folded
+// into the snapshot, hence only the records after the offset are read when rebuilding.
+type journalSnapshot struct {
+	CreatedAt time.Time `json:"createdAt"`
+	// JournalOffset is the number of journal records folded into the snapshot
+	JournalOffset int `json:"journalOffset"`
+	// Deployments contains the latest deploy record of each API, which is not undeployed afterwards
+	Deployments []ChangeRecord `json:"deployments"`
+	// Subscriptions contains the latest subscription data loaded of each resource, and the subscription events
+	// processed afterwards
+	Subscriptions []ChangeRecord `json:"subscriptions,omitempty"`
+}
+
+// CompactedJournal is the journal folded into the records, which are replayed to rebuild the APIs and the
+// subscriptions.
+type CompactedJournal struct {
+	// Deployments contains the latest deployment of each API, in the order they were deployed
+	Deployments []ChangeRecord
+	// Subscriptions contains the subscription data loaded and the subscription events, in the order they were
+	// recorded
+	Subscriptions []ChangeRecord
+	// JournalRecords is the number of journal records read after the latest snapshot
+	JournalRecords int
+}
+
+// CompactJournal folds the journal into the latest deployment of each API, in the order they were deployed, and the
+// subscription data required to rebuild the subscriptions. The number of journal records read after the latest
+// snapshot is returned as well. The compacted journal is persisted as a snapshot if persistSnapshot is true, hence
+// the subsequent compactions read only the records appended after the snapshot.
+func CompactJournal(persistSnapshot bool) (*CompactedJournal, error) {
+	conf, _ := config.ReadConfigs()
+	journalFilePath := conf.Adapter.Audit.JournalFilePath
+	if !conf.Adapter.Audit.Enabled || journalFilePath == "" {
+		return nil, errors.New("journal file is not configured")
+	}
+	key, err := getArtifactEncryptionKey(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	journalMutex.Lock()
+	defer journalMutex.Unlock()
+	journal, err := readJournalFile(journalFilePath)
+	if err != nil {
+		return nil, err
+	}
+	snapshot, err := readSnapshotFile(journalFilePath + snapshotFileSuffix)
+	if err != nil {
+		return nil, err
+	}
+	if snapshot.JournalOffset > len(journal) {
+		// the journal is replaced or truncated after the snapshot is taken
+		logger.LoggerAudit.Warnf("The journal has %d records, which is less than the %d records of the snapshot. "+
+			"Hence the snapshot is discarded.", len(journal), snapshot.JournalOffset)
+		snapshot = &journalSnapshot{}
+	}
+	tail := unsealRecords(journal[snapshot.JournalOffset:], key)
+	compacted := &CompactedJournal{
+		Deployments:    compactJournal(unsealRecords(snapshot.Deployments, key), tail),
+		Subscriptions:  compactSubscriptions(unsealRecords(snapshot.Subscriptions, key), tail),
+		JournalRecords: len(tail),
+	}
+	if persistSnapshot {
+		err = writeSnapshotFile(journalFilePath+snapshotFileSuffix, &journalSnapshot{
+			CreatedAt:     time.Now().UTC(),
+			JournalOffset: len(journal),
+			Deployments:   sealRecords(compacted.Deployments, key),
+			Subscriptions: sealRecords(compacted.Subscriptions, key),
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return compacted, nil
+}
+
+// NewRebuiltAPI returns the API rebuilt from the deploy record.
+func NewRebuiltAPI(record ChangeRecord) RebuiltAPI {
+	return RebuiltAPI{
+		OrganizationID: record.OrganizationID,
+		APIIdentifier:  record.APIIdentifier,
+		APIName:        record.APIName,
+		APIVersion:     record.APIVersion,
+		RevisionID:     record.RevisionID,
+		Environments:   record.Environments,
+		DeployedAt:     record.Timestamp,
+		DeployedBy:     record.Actor,
+	}
+}
+
+// compactJournal folds the records into the deployments, and returns the latest deploy record of each API
+// which is not undeployed afterwards, in the order they were deployed.
+func compactJournal(deployments []ChangeRecord, records []ChangeRecord) []ChangeRecord {
+	compacted := append([]ChangeRecord{}, deployments...)
+	indexOf := func(record ChangeRecord) int {
+		for i, deployment := range compacted {
+			if deployment.OrganizationID == record.OrganizationID && deployment.APIIdentifier == record.APIIdentifier {
+				return i
+			}
+		}
+		return -1
+	}
+	remove := func(i int) {
+		compacted = append(compacted[:i], compacted[i+1:]...)
+	}
+
+	for _, record := range records {
+		i := indexOf(record)
+		switch record.Action {
+		case ActionDeploy:
+			if i >= 0 {
+				remove(i)
+			}
+			if record.Artifact == nil {
+				// recorded before the artifacts were persisted in the journal, or without the encryption key
+				logger.LoggerAudit.Warnf("Deploy record of %s at %v does not contain the artifact. Hence the API "+
+					"cannot be rebuilt.", record.APIIdentifier, record.Timestamp)
+				continue
+			}
+			compacted = append(compacted, record)
+		case ActionUndeploy:
+			if i < 0 {
+				continue
+			}
+			// an API is undeployed from all the environments, if the environments are not given
+			var environments []string
+			if len(record.Environments) > 0 {
+				for _, env := range compacted[i].Environments {
+					if !contains(record.Environments, env) {
+						environments = append(environments, env)
+					}
+				}
+			}
+			if len(environments) == 0 {
+				remove(i)
+				continue
+			}
+			compacted[i].Environments = environments
+		}
+	}
+	return compacted
+}
+
+// compactSubscriptions folds the records into the subscription records, and returns the latest subscription data
+// loaded of each resource, and the subscription events recorded after the earliest of those, in the order they were
+// recorded. The events recorded before are already reflected in the data loaded afterwards.
+func compactSubscriptions(subscriptions []ChangeRecord, records []ChangeRecord) []ChangeRecord {
+	var compacted []ChangeRecord
+	for _, record := range append(append([]ChangeRecord{}, subscriptions...), records...) {
+		if record.Subscription == nil || !isReplayOnly(record.Action) {
+			continue
+		}
+		if record.Action == ActionLoadSubscriptionData {
+			// the data loaded replaces the data of the resource loaded earlier
+			for i := 0; i < len(compacted); i++ {
+				if compacted[i].Action == ActionLoadSubscriptionData &&
+					compacted[i].Subscription.Type == record.Subscription.Type {
+					compacted = append(compacted[:i], compacted[i+1:]...)
+					i--
+				}
+			}
+		}
+		compacted = append(compacted, record)
+	}
+	// events before the earliest data loaded are dropped, if the data is loaded at least once
+	for i, record := range compacted {
+		if record.Action == ActionLoadSubscriptionData {
+			return compacted[i:]
+		}
+	}
+	return compacted
+}
+
+// unsealRecords decrypts the artifacts and the subscription data of the records. The records, which cannot be
+// decrypted, are returned without those, hence the APIs and the subscriptions of those are not rebuilt.
+func unsealRecords(records []ChangeRecord, key []byte) []ChangeRecord {
+	unsealed := make([]ChangeRecord, 0, len(records))
+	for _, record := range records {
+		unsealedRecord, err := unsealRecord(record, key)
+		if err != nil {
+			logger.LoggerAudit.Warnf("Content of the %s record of %s at %v cannot be decrypted. %v", record.Action,
+				record.APIIdentifier, record.Timestamp, err)
+			unsealedRecord = withoutArtifact(record)
+		}
+		unsealed = append(unsealed, unsealedRecord)
+	}
+	return unsealed
+}
+
+// sealRecords encrypts the artifacts and the subscription data of the records, to be written to the snapshot.
+func sealRecords(records []ChangeRecord, key []byte) []ChangeRecord {
+	sealed := make([]ChangeRecord, 0, len(records))
+	for _, record := range records {
+		sealedRecord, err := sealRecord(record, key)
+		if err != nil {
+			logger.LoggerAudit.Warnf("Content of the %s record of %s at %v cannot be encrypted. %v", record.Action,
+				record.APIIdentifier, record.Timestamp, err)
+		}
+		sealed = append(sealed, sealedRecord)
+	}
+	return sealed
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func readSnapshotFile(snapshotFilePath string) (*journalSnapshot, error) {
+	content, err := ioutil.ReadFile(snapshotFilePath)
+	if os.IsNotExist(err) {
+		return &journalSnapshot{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var snapshot journalSnapshot
+	if err := json.Unmarshal(content, &snapshot); err != nil {
+		return nil, err
+	}
+	return &snapshot, nil
+}
+
+// writeSnapshotFile replaces the snapshot atomically, hence a failure does not corrupt the previous snapshot.
+func writeSnapshotFile(snapshotFilePath string, snapshot *journalSnapshot) error {
+	content, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	tempFile, err := ioutil.TempFile(filepath.Dir(snapshotFilePath), filepath.Base(snapshotFilePath)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tempFile.Name())
+	if _, err = tempFile.Write(content); err != nil {
+		tempFile.Close()
+		return err
+	}
+	if err = tempFile.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tempFile.Name(), snapshotFilePath)
+}
@@ -0,0 +1,85 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package audit
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"time"
+
+	"github.com/wso2/product-microgateway/adapter/config"
+	"github.com/wso2/product-microgateway/adapter/pkg/tlsutils"
+)
+
+// GenerateReport generates the change report for the given time range. The report is signed with the
+// private key of the adapter keystore and the certificate of the keystore is attached to the report.
+func GenerateReport(from, to time.Time) (*Report, error) {
+	if to.Before(from) {
+		return nil, errors.New("the end of the time range should not be before the start")
+	}
+	changes, err := GetChanges(from, to)
+	if err != nil {
+		return nil, err
+	}
+	content, err := json.Marshal(ReportContent{
+		From:        from,
+		To:          to,
+		GeneratedAt: time.Now().UTC(),
+		Changes:     changes,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	conf, _ := config.ReadConfigs()
+	keyPair, err := tlsutils.GetServerCertificate(conf.Adapter.Keystore.CertPath, conf.Adapter.Keystore.KeyPath)
+	if err != nil {
+		return nil, err
+	}
+	signer, ok := keyPair.PrivateKey.(crypto.Signer)
+	if !ok || len(keyPair.Certificate) == 0 {
+		return nil, errors.New("unsupported adapter keystore to sign the report")
+	}
+	var signatureAlgorithm string
+	switch signer.(type) {
+	case *rsa.PrivateKey:
+		signatureAlgorithm = "SHA256withRSA"
+	case *ecdsa.PrivateKey:
+		signatureAlgorithm = "SHA256withECDSA"
+	default:
+		return nil, errors.New("unsupported private key type to sign the report")
+	}
+	digest := sha256.Sum256(content)
+	signature, err := signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+	if err != nil {
+		return nil, err
+	}
+	return &Report{
+		Content:            content,
+		SignatureAlgorithm: signatureAlgorithm,
+		Signature:          base64.StdEncoding.EncodeToString(signature),
+		Certificate:        string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: keyPair.Certificate[0]})),
+	}, nil
+}
@@ -0,0 +1,125 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package audit
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/wso2/product-microgateway/adapter/config"
+)
+
+// sealedContent is the content of a change record encrypted in the journal file, as the API projects and the
+// subscription data contain credentials (ex: endpoint credentials and consumer keys).
+type sealedContent struct {
+	Artifact     *Artifact         `json:"artifact,omitempty"`
+	Subscription *SubscriptionData `json:"subscription,omitempty"`
+}
+
+// getArtifactEncryptionKey returns the AES key of the config, by which the artifacts and the subscription data are
+// encrypted in the journal file. The key is nil if not configured.
+func getArtifactEncryptionKey(conf *config.Config) ([]byte, error) {
+	if conf.Adapter.Audit.ArtifactEncryptionKey == "" {
+		return nil, nil
+	}
+	key, err := base64.StdEncoding.DecodeString(conf.Adapter.Audit.ArtifactEncryptionKey)
+	if err != nil {
+		return nil, fmt.Errorf("artifact encryption key is not base64 encoded. %v", err)
+	}
+	switch len(key) {
+	case 16, 24, 32:
+		return key, nil
+	}
+	return nil, fmt.Errorf("artifact encryption key should be 16, 24 or 32 bytes, but it is %d bytes", len(key))
+}
+
+// sealRecord encrypts the artifact and the subscription data of the record into the sealed content by AES-GCM.
+// Those are removed from the record without being encrypted, if the key is nil.
+func sealRecord(record ChangeRecord, key []byte) (ChangeRecord, error) {
+	if record.Artifact == nil && record.Subscription == nil {
+		return record, nil
+	}
+	content := sealedContent{Artifact: record.Artifact, Subscription: record.Subscription}
+	record.Artifact, record.Subscription, record.SealedContent = nil, nil, nil
+	if key == nil {
+		return record, nil
+	}
+	plaintext, err := json.Marshal(content)
+	if err != nil {
+		return record, err
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return record, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return record, err
+	}
+	record.SealedContent = gcm.Seal(nonce, nonce, plaintext, getAdditionalData(record))
+	return record, nil
+}
+
+// unsealRecord decrypts the sealed content of the record into the artifact and the subscription data.
+func unsealRecord(record ChangeRecord, key []byte) (ChangeRecord, error) {
+	if len(record.SealedContent) == 0 {
+		return record, nil
+	}
+	if key == nil {
+		return record, errors.New("artifact encryption key is not configured")
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return record, err
+	}
+	if len(record.SealedContent) < gcm.NonceSize() {
+		return record, errors.New("sealed content is truncated")
+	}
+	nonce, ciphertext := record.SealedContent[:gcm.NonceSize()], record.SealedContent[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, getAdditionalData(record))
+	if err != nil {
+		return record, err
+	}
+	var content sealedContent
+	if err := json.Unmarshal(plaintext, &content); err != nil {
+		return record, err
+	}
+	record.Artifact, record.Subscription, record.SealedContent = content.Artifact, content.Subscription, nil
+	return record, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// getAdditionalData binds the sealed content to the record, hence the content cannot be moved to another record.
+func getAdditionalData(record ChangeRecord) []byte {
+	return []byte(record.Action + "\x00" + record.OrganizationID + "\x00" + record.APIIdentifier + "\x00" +
+		record.Timestamp.UTC().Format(time.RFC3339Nano))
+}
@@ -0,0 +1,156 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+// Package audit records the API configuration changes applied to the gateway and generates signed
+// change reports, which can be used as change management evidence.
+package audit
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/wso2/product-microgateway/adapter/pkg/synchronizer"
+)
+
+// Actors of the changes, other than the users of the adapter REST API
+const (
+	ActorControlPlane       string = "control-plane"
+	ActorArtifactsDirectory string = "artifacts-directory"
+	ActorAdapter            string = "adapter"
+	ActorKubernetesOperator string = "kubernetes-operator"
+)
+
+// Actions recorded in the change records
+const (
+	ActionDeploy                   string = "DEPLOY"
+	ActionUndeploy                 string = "UNDEPLOY"
+	ActionAddRateLimitExemption    string = "ADD_RATE_LIMIT_EXEMPTION"
+	ActionRemoveRateLimitExemption string = "REMOVE_RATE_LIMIT_EXEMPTION"
+	ActionActivateSite             string = "ACTIVATE_SITE"
+	ActionStandbySite              string = "STANDBY_SITE"
+	ActionAddAPIAdvisory           string = "ADD_API_ADVISORY"
+	ActionRemoveAPIAdvisory        string = "REMOVE_API_ADVISORY"
+	// ActionLoadSubscriptionData and ActionSubscriptionEvent are persisted only in the journal file, to rebuild the
+	// subscriptions from the journal
+	ActionLoadSubscriptionData string = "LOAD_SUBSCRIPTION_DATA"
+	ActionSubscriptionEvent    string = "SUBSCRIPTION_EVENT"
+)
+
+// Route changes
+const (
+	RouteAdded    string = "ADDED"
+	RouteRemoved  string = "REMOVED"
+	RouteModified string = "MODIFIED"
+)
+
+// ChangeRecord represents a configuration change of an API, or of the gateway if the APIIdentifier is empty.
+type ChangeRecord struct {
+	Timestamp      time.Time     `json:"timestamp"`
+	Actor          string        `json:"actor"`
+	Action         string        `json:"action"`
+	OrganizationID string        `json:"organizationId"`
+	APIIdentifier  string        `json:"apiIdentifier"`
+	APIName        string        `json:"apiName,omitempty"`
+	APIVersion     string        `json:"apiVersion,omitempty"`
+	RevisionID     int           `json:"revisionId,omitempty"`
+	Environments   []string      `json:"environments,omitempty"`
+	VHost          string        `json:"vhost,omitempty"`
+	RouteChanges   []RouteChange `json:"routeChanges,omitempty"`
+	// Subject is the gateway configuration changed by a change, which is not specific to an API
+	// (ex: sourceCIDR:10.0.0.0/8 of a rate limit exemption)
+	Subject string `json:"subject,omitempty"`
+	// Artifact of a deployment is persisted only in the journal file, to rebuild the APIs from the journal
+	Artifact *Artifact `json:"artifact,omitempty"`
+	// Subscription is the subscription data loaded from the control plane, or the subscription event processed
+	Subscription *SubscriptionData `json:"subscription,omitempty"`
+	// SealedContent is the artifact and the subscription data encrypted by the artifact encryption key, which are
+	// not written to the journal file in plain text
+	SealedContent []byte `json:"sealedContent,omitempty"`
+}
+
+// Artifact is the API project deployed by a change, along with the properties applied to the project
+// at the deployment.
+type Artifact struct {
+	Files            []ArtifactFile                      `json:"files"`
+	APIEnvProps      map[string]synchronizer.APIEnvProps `json:"apiEnvProps,omitempty"`
+	IsDefaultVersion bool                                `json:"isDefaultVersion,omitempty"`
+}
+
+// SubscriptionData is the payload of a subscription data resource loaded from the control plane (ex: subscriptions),
+// or of a subscription event, which is replayed to rebuild the subscriptions.
+type SubscriptionData struct {
+	// Type is the resource loaded or the event type
+	Type    string `json:"type"`
+	Payload []byte `json:"payload"`
+}
+
+// ArtifactFile is a file of the API project.
+type ArtifactFile struct {
+	Name    string `json:"name"`
+	Content []byte `json:"content"`
+}
+
+// RouteChange represents an envoy route added, removed or modified by a change.
+type RouteChange struct {
+	Change string `json:"change"`
+	Name   string `json:"name"`
+	Match  string `json:"match"`
+}
+
+// Report is a signed change report. The signature is calculated over the exact bytes of the Content,
+// hence the content should be verified before it is parsed.
+type Report struct {
+	Content            json.RawMessage `json:"report"`
+	SignatureAlgorithm string          `json:"signatureAlgorithm"`
+	Signature          string          `json:"signature"`
+	Certificate        string          `json:"certificate"`
+}
+
+// ReportContent is the content of a change report.
+type ReportContent struct {
+	From        time.Time      `json:"from"`
+	To          time.Time      `json:"to"`
+	GeneratedAt time.Time      `json:"generatedAt"`
+	Changes     []ChangeRecord `json:"changes"`
+}
+
+// RebuildResult represents the outcome of rebuilding the APIs from the journal.
+type RebuildResult struct {
+	DryRun bool `json:"dryRun"`
+	// JournalRecords is the number of records read from the journal, after the latest snapshot
+	JournalRecords int `json:"journalRecords"`
+	// APIs rebuilt from the compacted journal. These are the APIs, which would be rebuilt in a dry run.
+	APIs       []RebuiltAPI `json:"apis"`
+	FailedAPIs []RebuiltAPI `json:"failedApis,omitempty"`
+	// SubscriptionRecords is the number of the subscription data and the subscription events replayed, to rebuild
+	// the subscriptions
+	SubscriptionRecords int    `json:"subscriptionRecords"`
+	SubscriptionError   string `json:"subscriptionError,omitempty"`
+}
+
+// RebuiltAPI identifies an API rebuilt from the journal and the deployment it is rebuilt from.
+type RebuiltAPI struct {
+	OrganizationID string    `json:"organizationId"`
+	APIIdentifier  string    `json:"apiIdentifier"`
+	APIName        string    `json:"apiName,omitempty"`
+	APIVersion     string    `json:"apiVersion,omitempty"`
+	RevisionID     int       `json:"revisionId,omitempty"`
+	Environments   []string  `json:"environments,omitempty"`
+	DeployedAt     time.Time `json:"deployedAt"`
+	DeployedBy     string    `json:"deployedBy"`
+	Error          string    `json:"error,omitempty"`
+}
@@ -180,6 +180,20 @@ func ValidateToken(accessToken string, resourceScopes []string, conf *config.Con
 	return true, nil
 }
 
+// GetTokenUsername returns the username of an access token, which is already validated
+func GetTokenUsername(accessToken string) string {
+	token, err := jwt.ParseString(accessToken)
+	if err != nil {
+		return ""
+	}
+	if tokenUser, found := token.Get(usernameConst); found {
+		if username, ok := tokenUser.(string); ok {
+			return username
+		}
+	}
+	return ""
+}
+
 func getPrivateKey() (*rsa.PrivateKey, error) {
 	if storedPrivateKey != nil {
 		return storedPrivateKey, nil
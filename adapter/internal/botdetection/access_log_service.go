@@ -0,0 +1,106 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package botdetection
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	accesslogdatav3 "github.com/envoyproxy/go-control-plane/envoy/data/accesslog/v3"
+	accesslogv3 "github.com/envoyproxy/go-control-plane/envoy/service/accesslog/v3"
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"google.golang.org/grpc"
+)
+
+// accessLogService receives the access logs of the failed requests streamed by the router.
+type accessLogService struct{}
+
+// RegisterAccessLogService registers the access log service receiving the failures from the router, if bot
+// detection is enabled.
+func RegisterAccessLogService(grpcServer *grpc.Server) {
+	if failureTracker == nil {
+		return
+	}
+	accesslogv3.RegisterAccessLogServiceServer(grpcServer, &accessLogService{})
+}
+
+// StreamAccessLogs records the failures of the access log entries of the stream.
+func (service *accessLogService) StreamAccessLogs(stream accesslogv3.AccessLogService_StreamAccessLogsServer) error {
+	for {
+		message, err := stream.Recv()
+		if err == io.EOF {
+			return stream.SendAndClose(&accesslogv3.StreamAccessLogsResponse{})
+		}
+		if err != nil {
+			logger.LoggerBotDetection.Debugf("Access log stream is closed. %v", err)
+			return err
+		}
+		for _, entry := range message.GetHttpLogs().GetLogEntry() {
+			recordEntry(failureTracker, entry)
+		}
+	}
+}
+
+// recordEntry records the failure of the access log entry, if the request is failed due to authentication or
+// throttling.
+func recordEntry(t *tracker, entry *accesslogdatav3.HTTPAccessLogEntry) {
+	failureType := getFailureType(entry.GetResponse().GetResponseCode().GetValue())
+	if t == nil || failureType == "" {
+		return
+	}
+	at := time.Now()
+	if startTime := entry.GetCommonProperties().GetStartTime(); startTime != nil {
+		at = startTime.AsTime()
+	}
+	t.record(getClientIP(entry), failureType, getPath(entry), at)
+}
+
+func getFailureType(responseCode uint32) string {
+	switch responseCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return AuthFailure
+	case http.StatusTooManyRequests:
+		return ThrottleOut
+	}
+	return ""
+}
+
+// getClientIP returns the downstream remote address, which the router resolves from the x-forwarded-for header
+// only if the configured hops are trusted. Hence the header is not read as is.
+func getClientIP(entry *accesslogdatav3.HTTPAccessLogEntry) string {
+	commonProperties := entry.GetCommonProperties()
+	address := commonProperties.GetDownstreamRemoteAddress().GetSocketAddress().GetAddress()
+	if address == "" {
+		address = commonProperties.GetDownstreamDirectRemoteAddress().GetSocketAddress().GetAddress()
+	}
+	return address
+}
+
+// getPath returns the path invoked by the client (before rewriting), without the query.
+func getPath(entry *accesslogdatav3.HTTPAccessLogEntry) string {
+	path := entry.GetRequest().GetOriginalPath()
+	if path == "" {
+		path = entry.GetRequest().GetPath()
+	}
+	if i := strings.Index(path, "?"); i >= 0 {
+		path = path[:i]
+	}
+	return path
+}
@@ -0,0 +1,270 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+// Package botdetection tracks the authentication failures and the throttled out requests of the clients, which are
+// reported by the router as access logs, and emits bot detection events for the clients exceeding the configured
+// thresholds. The events are published to the control plane broker and listed in the admin API, helping the
+// operators to identify the sources of credential stuffing.
+package botdetection
+
+import (
+	"container/list"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/wso2/product-microgateway/adapter/config"
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/pkg/logging"
+	"github.com/wso2/product-microgateway/adapter/pkg/messaging"
+)
+
+// Types of the failures tracked
+const (
+	AuthFailure string = "AUTH_FAILURE"
+	ThrottleOut string = "THROTTLE_OUT"
+)
+
+// maxPathsPerClient bounds the distinct paths recorded for a client
+const maxPathsPerClient = 10
+
+// Event represents a client exceeding the threshold of a failure type within the window.
+type Event struct {
+	ClientIP string `json:"clientIP"`
+	Type     string `json:"type"`
+	// Count is the failures within the window, which is at most the threshold as only the latest failures are kept
+	Count           int      `json:"count"`
+	Threshold       int      `json:"threshold"`
+	WindowInSeconds int      `json:"windowInSeconds"`
+	Paths           []string `json:"paths,omitempty"`
+	// FirstFailure is the earliest of the failures counted
+	FirstFailure time.Time `json:"firstFailure"`
+	DetectedAt   time.Time `json:"detectedAt"`
+}
+
+// client is the failures of a client within the window.
+type client struct {
+	ip string
+	// failures are the timestamps of the latest failures within the window in the order of the time, by the failure
+	// type. At most the threshold of the failure type are kept.
+	failures map[string][]time.Time
+	// emittedAt is the time the last event is emitted, by the failure type
+	emittedAt map[string]time.Time
+	paths     []string
+}
+
+type tracker struct {
+	window     time.Duration
+	thresholds map[string]int
+	cooldown   time.Duration
+	maxClients int
+	maxEvents  int
+	publish    func(event Event)
+
+	mutex sync.Mutex
+	// clients are the elements of the recency list, by the client IP
+	clients map[string]*list.Element
+	// recency is the list of the clients, the most recently seen first
+	recency *list.List
+	events  []Event
+}
+
+var failureTracker *tracker
+
+// Start starts tracking the failures, if bot detection is enabled. The failures are received via the access log
+// service registered by RegisterAccessLogService.
+func Start(conf *config.Config) {
+	botDetection := conf.Adapter.BotDetection
+	if !botDetection.Enabled {
+		return
+	}
+	topic := botDetection.Topic
+	failureTracker = newTracker(time.Duration(botDetection.WindowInSeconds)*time.Second,
+		botDetection.AuthFailureThreshold, botDetection.ThrottleOutThreshold,
+		time.Duration(botDetection.CooldownInSeconds)*time.Second, botDetection.MaxTrackedClients,
+		botDetection.MaxEvents, func(event Event) {
+			if topic != "" {
+				go publishEvent(topic, event)
+			}
+		})
+	logger.LoggerBotDetection.Infof("Bot detection is enabled with a window of %v", failureTracker.window)
+}
+
+// GetEvents returns the latest bot detection events, the latest first.
+func GetEvents() []Event {
+	if failureTracker == nil {
+		return []Event{}
+	}
+	return failureTracker.getEvents()
+}
+
+func newTracker(window time.Duration, authFailureThreshold, throttleOutThreshold int, cooldown time.Duration,
+	maxClients, maxEvents int, publish func(event Event)) *tracker {
+	if window <= 0 {
+		window = time.Minute
+	}
+	return &tracker{
+		window: window,
+		thresholds: map[string]int{
+			AuthFailure: authFailureThreshold,
+			ThrottleOut: throttleOutThreshold,
+		},
+		cooldown:   cooldown,
+		maxClients: maxClients,
+		maxEvents:  maxEvents,
+		publish:    publish,
+		clients:    make(map[string]*list.Element),
+		recency:    list.New(),
+	}
+}
+
+// record records a failure of the client, and returns the event emitted if the client exceeds the threshold of
+// the failure type. Nil is returned if the threshold is not exceeded, or an event is emitted within the cooldown.
+func (t *tracker) record(clientIP, failureType, path string, at time.Time) *Event {
+	threshold := t.thresholds[failureType]
+	if threshold <= 0 || clientIP == "" {
+		return nil
+	}
+	t.mutex.Lock()
+	c := t.getClient(clientIP)
+	failures, counted := addFailure(c.failures[failureType], at, t.window, threshold)
+	c.failures[failureType] = failures
+	c.addPath(path)
+	if !counted || len(failures) < threshold {
+		t.mutex.Unlock()
+		return nil
+	}
+	if emittedAt, emitted := c.emittedAt[failureType]; emitted && at.Sub(emittedAt) < t.cooldown {
+		t.mutex.Unlock()
+		return nil
+	}
+	c.emittedAt[failureType] = at
+	event := Event{
+		ClientIP:        clientIP,
+		Type:            failureType,
+		Count:           len(failures),
+		Threshold:       threshold,
+		WindowInSeconds: int(t.window / time.Second),
+		Paths:           append([]string(nil), c.paths...),
+		FirstFailure:    failures[0],
+		DetectedAt:      at,
+	}
+	t.events = append(t.events, event)
+	if t.maxEvents > 0 && len(t.events) > t.maxEvents {
+		t.events = t.events[len(t.events)-t.maxEvents:]
+	}
+	t.mutex.Unlock()
+
+	logger.LoggerBotDetection.Warnf("Possible bot detected: %d %s failures of the client %s within %v",
+		event.Count, failureType, clientIP, t.window)
+	if t.publish != nil {
+		t.publish(event)
+	}
+	return &event
+}
+
+// getClient returns the tracked client, adding it if it is not tracked. The least recently seen client is evicted
+// if the number of clients exceeds the maximum.
+func (t *tracker) getClient(clientIP string) *client {
+	if element, tracked := t.clients[clientIP]; tracked {
+		t.recency.MoveToFront(element)
+		return element.Value.(*client)
+	}
+	c := &client{
+		ip:        clientIP,
+		failures:  make(map[string][]time.Time),
+		emittedAt: make(map[string]time.Time),
+	}
+	t.clients[clientIP] = t.recency.PushFront(c)
+	for t.maxClients > 0 && t.recency.Len() > t.maxClients {
+		oldest := t.recency.Back()
+		t.recency.Remove(oldest)
+		delete(t.clients, oldest.Value.(*client).ip)
+	}
+	return c
+}
+
+func (t *tracker) getEvents() []Event {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	events := make([]Event, 0, len(t.events))
+	for i := len(t.events) - 1; i >= 0; i-- {
+		events = append(events, t.events[i])
+	}
+	return events
+}
+
+func (c *client) addPath(path string) {
+	if path == "" || len(c.paths) >= maxPathsPerClient {
+		return
+	}
+	for _, recordedPath := range c.paths {
+		if recordedPath == path {
+			return
+		}
+	}
+	c.paths = append(c.paths, path)
+}
+
+// addFailure inserts the failure in the order of the time, as the access logs of several router streams may be
+// received out of order, and returns the latest failures (at most the limit) within the window ending at the latest
+// failure. False is returned if the failure is before the window, hence it is not counted.
+func addFailure(failures []time.Time, at time.Time, window time.Duration, limit int) ([]time.Time, bool) {
+	latest := at
+	if len(failures) > 0 && failures[len(failures)-1].After(latest) {
+		latest = failures[len(failures)-1]
+	}
+	failures = pruneFailures(failures, latest.Add(-window))
+	if !at.After(latest.Add(-window)) {
+		return failures, false
+	}
+	i := sort.Search(len(failures), func(i int) bool { return failures[i].After(at) })
+	failures = append(failures, time.Time{})
+	copy(failures[i+1:], failures[i:])
+	failures[i] = at
+	if len(failures) > limit {
+		// the oldest failures are dropped, hence the memory is bounded by the threshold
+		failures = append(failures[:0], failures[len(failures)-limit:]...)
+	}
+	return failures, true
+}
+
+// pruneFailures removes the failures before the start of the window. The failures are in the order of the time.
+func pruneFailures(failures []time.Time, windowStart time.Time) []time.Time {
+	i := 0
+	for i < len(failures) && !failures[i].After(windowStart) {
+		i++
+	}
+	return append(failures[:0], failures[i:]...)
+}
+
+func publishEvent(topic string, event Event) {
+	body, err := json.Marshal(event)
+	if err == nil {
+		err = messaging.PublishEvent(topic, body)
+	}
+	if err != nil {
+		logger.LoggerBotDetection.ErrorC(logging.ErrorDetails{
+			Message: fmt.Sprintf("Error while publishing the bot detection event of the client %s to the topic %q. %v",
+				event.ClientIP, topic, err),
+			Severity:  logging.MINOR,
+			ErrorCode: 2900,
+		})
+	}
+}
@@ -0,0 +1,155 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package botdetection
+
+import (
+	"testing"
+	"time"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	accesslogdatav3 "github.com/envoyproxy/go-control-plane/envoy/data/accesslog/v3"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestRecord(t *testing.T) {
+	var published []Event
+	tracker := newTracker(time.Minute, 3, 0, 5*time.Minute, 10, 10, func(event Event) {
+		published = append(published, event)
+	})
+	start := time.Unix(1700000000, 0)
+
+	assert.Nil(t, tracker.record("10.0.0.1", AuthFailure, "/pets", start))
+	assert.Nil(t, tracker.record("10.0.0.1", AuthFailure, "/pets", start.Add(10*time.Second)))
+	// the failures of another client are not counted
+	assert.Nil(t, tracker.record("10.0.0.2", AuthFailure, "/pets", start.Add(20*time.Second)))
+	// the threshold of throttling is disabled
+	assert.Nil(t, tracker.record("10.0.0.1", ThrottleOut, "/pets", start.Add(20*time.Second)))
+
+	event := tracker.record("10.0.0.1", AuthFailure, "/orders", start.Add(30*time.Second))
+	if assert.NotNil(t, event) {
+		assert.Equal(t, "10.0.0.1", event.ClientIP)
+		assert.Equal(t, AuthFailure, event.Type)
+		assert.Equal(t, 3, event.Count)
+		assert.Equal(t, 3, event.Threshold)
+		assert.Equal(t, 60, event.WindowInSeconds)
+		assert.Equal(t, []string{"/pets", "/orders"}, event.Paths)
+		assert.Equal(t, start, event.FirstFailure)
+	}
+	assert.Len(t, published, 1)
+
+	// an event is not emitted again within the cooldown
+	assert.Nil(t, tracker.record("10.0.0.1", AuthFailure, "/pets", start.Add(40*time.Second)))
+
+	// the failures before the window are not counted
+	assert.Nil(t, tracker.record("10.0.0.2", AuthFailure, "/pets", start.Add(90*time.Second)))
+	assert.Nil(t, tracker.record("10.0.0.2", AuthFailure, "/pets", start.Add(100*time.Second)))
+	assert.NotNil(t, tracker.record("10.0.0.2", AuthFailure, "/pets", start.Add(110*time.Second)))
+
+	events := tracker.getEvents()
+	if assert.Len(t, events, 2) {
+		assert.Equal(t, "10.0.0.2", events[0].ClientIP)
+		assert.Equal(t, "10.0.0.1", events[1].ClientIP)
+	}
+}
+
+func TestRecordKeepsLatestFailures(t *testing.T) {
+	tracker := newTracker(time.Minute, 3, 0, time.Hour, 10, 10, nil)
+	start := time.Unix(1700000000, 0)
+
+	for i := 0; i < 1000; i++ {
+		tracker.record("10.0.0.1", AuthFailure, "/pets", start.Add(time.Duration(i)*time.Millisecond))
+	}
+	failures := tracker.clients["10.0.0.1"].Value.(*client).failures[AuthFailure]
+	assert.Len(t, failures, 3, "Failures beyond the threshold are kept")
+	assert.Equal(t, start.Add(997*time.Millisecond), failures[0])
+
+	// the failures received out of order are counted in the order of the time
+	assert.Nil(t, tracker.record("10.0.0.2", AuthFailure, "/pets", start.Add(30*time.Second)))
+	assert.Nil(t, tracker.record("10.0.0.2", AuthFailure, "/pets", start.Add(10*time.Second)))
+	// the failure before the window of the latest failure is not counted
+	assert.Nil(t, tracker.record("10.0.0.2", AuthFailure, "/pets", start.Add(-40*time.Second)))
+	event := tracker.record("10.0.0.2", AuthFailure, "/pets", start.Add(20*time.Second))
+	if assert.NotNil(t, event) {
+		assert.Equal(t, 3, event.Count)
+		assert.Equal(t, start.Add(10*time.Second), event.FirstFailure)
+	}
+	assert.Equal(t, []time.Time{start.Add(10 * time.Second), start.Add(20 * time.Second), start.Add(30 * time.Second)},
+		tracker.clients["10.0.0.2"].Value.(*client).failures[AuthFailure])
+}
+
+func TestRecordEvictsClients(t *testing.T) {
+	tracker := newTracker(time.Minute, 2, 0, time.Minute, 2, 1, nil)
+	start := time.Unix(1700000000, 0)
+
+	tracker.record("10.0.0.1", AuthFailure, "", start)
+	tracker.record("10.0.0.2", AuthFailure, "", start)
+	tracker.record("10.0.0.3", AuthFailure, "", start)
+	assert.Len(t, tracker.clients, 2)
+	// the failure of the evicted client is not counted
+	assert.Nil(t, tracker.record("10.0.0.1", AuthFailure, "", start))
+
+	assert.NotNil(t, tracker.record("10.0.0.3", AuthFailure, "", start))
+	assert.NotNil(t, tracker.record("10.0.0.1", AuthFailure, "", start))
+	// only the latest events are kept
+	events := tracker.getEvents()
+	if assert.Len(t, events, 1) {
+		assert.Equal(t, "10.0.0.1", events[0].ClientIP)
+	}
+}
+
+func TestRecordEntry(t *testing.T) {
+	tracker := newTracker(time.Minute, 2, 1, time.Minute, 10, 10, nil)
+	newEntry := func(responseCode uint32, remoteAddress string) *accesslogdatav3.HTTPAccessLogEntry {
+		return &accesslogdatav3.HTTPAccessLogEntry{
+			CommonProperties: &accesslogdatav3.AccessLogCommon{
+				StartTime: timestamppb.New(time.Unix(1700000000, 0)),
+				DownstreamRemoteAddress: &corev3.Address{
+					Address: &corev3.Address_SocketAddress{
+						SocketAddress: &corev3.SocketAddress{Address: remoteAddress},
+					},
+				},
+			},
+			Request: &accesslogdatav3.HTTPRequestProperties{
+				Path:         "/v1/pets?limit=10",
+				OriginalPath: "/pets/v1/pets?limit=10",
+				ForwardedFor: "192.168.1.1",
+			},
+			Response: &accesslogdatav3.HTTPResponseProperties{
+				ResponseCode: wrapperspb.UInt32(responseCode),
+			},
+		}
+	}
+
+	recordEntry(tracker, newEntry(200, "10.0.0.1"))
+	recordEntry(tracker, newEntry(500, "10.0.0.1"))
+	recordEntry(tracker, newEntry(429, "10.0.0.1"))
+	recordEntry(tracker, newEntry(401, "10.0.0.2"))
+	recordEntry(tracker, newEntry(403, "10.0.0.2"))
+
+	events := tracker.getEvents()
+	if assert.Len(t, events, 2) {
+		assert.Equal(t, ThrottleOut, events[1].Type)
+		assert.Equal(t, "10.0.0.1", events[1].ClientIP)
+		assert.Equal(t, []string{"/pets/v1/pets"}, events[1].Paths)
+		assert.Equal(t, AuthFailure, events[0].Type)
+		assert.Equal(t, "10.0.0.2", events[0].ClientIP)
+		assert.Equal(t, 2, events[0].Count)
+	}
+}
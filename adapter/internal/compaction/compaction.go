@@ -0,0 +1,123 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+// Package compaction removes the stale entries accumulated in the in-memory stores and the audit journal of
+// long-running adapters.
+package compaction
+
+import (
+	"runtime"
+	"runtime/debug"
+	"time"
+
+	"github.com/wso2/product-microgateway/adapter/config"
+	"github.com/wso2/product-microgateway/adapter/internal/audit"
+	"github.com/wso2/product-microgateway/adapter/internal/discovery/xds"
+	"github.com/wso2/product-microgateway/adapter/internal/eventorder"
+	"github.com/wso2/product-microgateway/adapter/internal/jobs"
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/pkg/metrics"
+)
+
+// Result represents the entries removed and the memory reclaimed by a compaction.
+type Result struct {
+	xds.StoreCompaction
+	// ExpiredEventTimestamps are the timestamps of the processed events, which are older than the retention
+	ExpiredEventTimestamps int `json:"expiredEventTimestamps"`
+	// CompactedJournalRecords are the audit journal records folded into the snapshot of the journal
+	CompactedJournalRecords int `json:"compactedJournalRecords"`
+	// ReclaimedHeapBytes is the decrease of the allocated heap memory after the garbage collection
+	ReclaimedHeapBytes int64 `json:"reclaimedHeapBytes"`
+	// HeapAllocBytes is the allocated heap memory after the compaction
+	HeapAllocBytes uint64    `json:"heapAllocBytes"`
+	StartedAt      time.Time `json:"startedAt"`
+	Duration       string    `json:"duration"`
+}
+
+// Submit queues a compaction job, which results in the compaction Result.
+func Submit(createdBy string) (jobs.Job, error) {
+	return jobs.Submit(jobs.TypeCompaction, createdBy, func(reporter *jobs.Reporter) (interface{}, error) {
+		return compact(reporter)
+	})
+}
+
+// StartPeriodicCompaction submits a compaction job in the configured interval. A compaction is skipped if the
+// previous compaction (or a compaction triggered via the REST API) is not completed.
+func StartPeriodicCompaction() {
+	conf, _ := config.ReadConfigs()
+	if !conf.Adapter.Compaction.Enabled || conf.Adapter.Compaction.IntervalInMinutes <= 0 {
+		return
+	}
+	for range time.Tick(time.Duration(conf.Adapter.Compaction.IntervalInMinutes) * time.Minute) {
+		if _, err := Submit(audit.ActorAdapter); err != nil {
+			logger.LoggerCompaction.Warnf("Periodic compaction is skipped. %v", err)
+		}
+	}
+}
+
+func compact(reporter *jobs.Reporter) (*Result, error) {
+	conf, _ := config.ReadConfigs()
+	startedAt := time.Now()
+	var memStats runtime.MemStats
+	runtime.ReadMemStats(&memStats)
+	heapAllocBefore := memStats.HeapAlloc
+	result := &Result{StartedAt: startedAt.UTC()}
+
+	result.StoreCompaction = xds.CompactEnforcerStores(startedAt)
+	reporter.Logf("Removed %d expired revoked tokens, %d duplicate key mappings and %d superseded resource lists",
+		result.ExpiredRevokedTokens, result.DuplicateKeyMappings, result.SupersededResourceLists)
+	reporter.SetProgress(40)
+
+	retention := time.Duration(conf.Adapter.Compaction.EventTimestampRetentionInHours) * time.Hour
+	expired, err := eventorder.Compact(startedAt.Add(-retention))
+	if err != nil {
+		return nil, err
+	}
+	result.ExpiredEventTimestamps = expired
+	reporter.Logf("Removed %d event timestamps older than %v", result.ExpiredEventTimestamps, retention)
+	reporter.SetProgress(60)
+
+	if conf.Adapter.Audit.Enabled && conf.Adapter.Audit.JournalFilePath != "" {
+		compacted, err := audit.CompactJournal(true)
+		if err != nil {
+			return nil, err
+		}
+		result.CompactedJournalRecords = compacted.JournalRecords
+		reporter.Logf("Folded %d audit journal records into the snapshot", compacted.JournalRecords)
+	}
+	reporter.SetProgress(80)
+
+	// runs the garbage collection and returns the freed memory to the OS
+	debug.FreeOSMemory()
+	runtime.ReadMemStats(&memStats)
+	result.ReclaimedHeapBytes = int64(heapAllocBefore) - int64(memStats.HeapAlloc)
+	result.HeapAllocBytes = memStats.HeapAlloc
+	duration := time.Since(startedAt)
+	result.Duration = duration.String()
+	reporter.Logf("Reclaimed %d bytes of heap memory", result.ReclaimedHeapBytes)
+
+	metrics.ObserveCompaction(map[string]int{
+		"revokedTokens":   result.ExpiredRevokedTokens,
+		"keyMappings":     result.DuplicateKeyMappings,
+		"resourceLists":   result.SupersededResourceLists,
+		"eventTimestamps": result.ExpiredEventTimestamps,
+		"auditJournal":    result.CompactedJournalRecords,
+	}, result.ReclaimedHeapBytes, startedAt, duration)
+	logger.LoggerCompaction.Infof("Compaction is completed in %v. Reclaimed %d bytes of heap memory.", duration,
+		result.ReclaimedHeapBytes)
+	return result, nil
+}
@@ -0,0 +1,101 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+// Package configdump holds the model of the configuration generated by the adapter for the gateway
+// environments, along with the state of the in-memory stores, which is served by the admin endpoint /config.
+package configdump
+
+import "time"
+
+// ConfigDump is the configuration generated by the adapter and the state of its stores.
+type ConfigDump struct {
+	GeneratedAt  time.Time     `json:"generatedAt"`
+	Environments []Environment `json:"environments"`
+	// Certificates of the adapter and the router listeners
+	Certificates []Certificate `json:"certificates"`
+	Store        StoreState    `json:"store"`
+}
+
+// Environment is the configuration applied to the routers of a gateway environment.
+type Environment struct {
+	Label      string `json:"label"`
+	XdsVersion string `json:"xdsVersion,omitempty"`
+	APIs       []API  `json:"apis"`
+}
+
+// API is a deployed API, with the clusters generated for its endpoints.
+type API struct {
+	// ID is the identifier of the API in the adapter (vhost:API_UUID)
+	ID              string `json:"id"`
+	Name            string `json:"name"`
+	Version         string `json:"version"`
+	Vhost           string `json:"vhost"`
+	BasePath        string `json:"basePath"`
+	OrganizationID  string `json:"organizationId"`
+	APIType         string `json:"apiType"`
+	Tier            string `json:"tier,omitempty"`
+	DisableSecurity bool   `json:"disableSecurity"`
+	// HealthStatus is the status reported by the synthetic probes, which is empty if the API is not probed
+	HealthStatus       string        `json:"healthStatus,omitempty"`
+	Resources          []Resource    `json:"resources"`
+	Clusters           []Cluster     `json:"clusters"`
+	ClientCertificates []Certificate `json:"clientCertificates,omitempty"`
+}
+
+// Resource is a path of an API, routed by the router.
+type Resource struct {
+	Path       string      `json:"path"`
+	Operations []Operation `json:"operations"`
+}
+
+// Operation is an HTTP method of a resource.
+type Operation struct {
+	Method          string `json:"method"`
+	Tier            string `json:"tier,omitempty"`
+	DisableSecurity bool   `json:"disableSecurity"`
+	// PolicyCount is the number of request, response and fault policies of the operation
+	PolicyCount int `json:"policyCount"`
+}
+
+// Cluster is an upstream cluster of the router, with the endpoints (host:port) and the certificates used to
+// connect to them.
+type Cluster struct {
+	Name         string        `json:"name"`
+	Endpoints    []string      `json:"endpoints"`
+	Certificates []Certificate `json:"certificates,omitempty"`
+}
+
+// Certificate is an X.509 certificate used by the gateway.
+type Certificate struct {
+	Subject  string    `json:"subject"`
+	Issuer   string    `json:"issuer"`
+	Source   string    `json:"source"`
+	NotAfter time.Time `json:"notAfter"`
+}
+
+// StoreState is the number of entries in the stores of the subscription data, sent to the enforcers.
+type StoreState struct {
+	APIs                   int `json:"apis"`
+	Applications           int `json:"applications"`
+	Subscriptions          int `json:"subscriptions"`
+	ApplicationKeyMappings int `json:"applicationKeyMappings"`
+	ApplicationPolicies    int `json:"applicationPolicies"`
+	SubscriptionPolicies   int `json:"subscriptionPolicies"`
+	Scopes                 int `json:"scopes"`
+	KeyManagers            int `json:"keyManagers"`
+	RevokedTokens          int `json:"revokedTokens"`
+}
@@ -0,0 +1,154 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package xds
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	"github.com/google/uuid"
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/envoyconf"
+)
+
+// APIAdvisory is a message to the consumers of an API (ex: an upcoming breaking change), which is sent in the
+// Warning header of the responses and served in the /_advisories path of the API.
+type APIAdvisory struct {
+	ID        string    `json:"id"`
+	Message   string    `json:"message"`
+	Link      string    `json:"link,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// ErrAPINotFound is returned when the API of an advisory or a revision change is not deployed.
+var ErrAPINotFound = errors.New("API is not found")
+
+const maxAdvisoryLength int = 256
+
+var (
+	// organization -> API identifier -> advisories
+	apiAdvisories    = make(map[string]map[string][]APIAdvisory)
+	apiAdvisoryMutex sync.RWMutex
+)
+
+// GetAPIAdvisories returns the advisories of an API.
+func GetAPIAdvisories(organizationID, vhost, name, version string) []APIAdvisory {
+	apiAdvisoryMutex.RLock()
+	defer apiAdvisoryMutex.RUnlock()
+	return append([]APIAdvisory{}, apiAdvisories[organizationID][getAPIIdentifier(vhost, name, version)]...)
+}
+
+// AddAPIAdvisory adds an advisory to a deployed API, and updates the routes of the API.
+func AddAPIAdvisory(organizationID, vhost, name, version string, advisory APIAdvisory) (APIAdvisory, error) {
+	advisory.Message = strings.TrimSpace(advisory.Message)
+	if advisory.Message == "" {
+		return advisory, errors.New("message of the advisory is empty")
+	}
+	if len(advisory.Message) > maxAdvisoryLength {
+		return advisory, fmt.Errorf("message of the advisory exceeds %d characters", maxAdvisoryLength)
+	}
+	apiIdentifier := getAPIIdentifier(vhost, name, version)
+	mutexForInternalMapUpdate.Lock()
+	_, found := orgIDAPIMgwSwaggerMap[organizationID][apiIdentifier]
+	mutexForInternalMapUpdate.Unlock()
+	if !found {
+		return advisory, ErrAPINotFound
+	}
+	advisory.ID = uuid.New().String()
+	advisory.CreatedAt = time.Now().UTC()
+	apiAdvisoryMutex.Lock()
+	if _, ok := apiAdvisories[organizationID]; !ok {
+		apiAdvisories[organizationID] = make(map[string][]APIAdvisory)
+	}
+	apiAdvisories[organizationID][apiIdentifier] = append(apiAdvisories[organizationID][apiIdentifier], advisory)
+	apiAdvisoryMutex.Unlock()
+	invalidateAPIFragment(organizationID, apiIdentifier)
+	logger.LoggerXds.Infof("Advisory %s is added to the API %s of organization %s", advisory.ID, apiIdentifier,
+		organizationID)
+	UpdateXdsCacheForLabels(nil)
+	return advisory, nil
+}
+
+// RemoveAPIAdvisory removes an advisory of an API, and returns whether it is removed.
+func RemoveAPIAdvisory(organizationID, vhost, name, version, advisoryID string) bool {
+	apiIdentifier := getAPIIdentifier(vhost, name, version)
+	apiAdvisoryMutex.Lock()
+	advisories := apiAdvisories[organizationID][apiIdentifier]
+	removed := false
+	for i, advisory := range advisories {
+		if advisory.ID == advisoryID {
+			apiAdvisories[organizationID][apiIdentifier] = append(advisories[:i:i], advisories[i+1:]...)
+			removed = true
+			break
+		}
+	}
+	apiAdvisoryMutex.Unlock()
+	if removed {
+		invalidateAPIFragment(organizationID, apiIdentifier)
+		logger.LoggerXds.Infof("Advisory %s is removed from the API %s of organization %s", advisoryID,
+			apiIdentifier, organizationID)
+		UpdateXdsCacheForLabels(nil)
+	}
+	return removed
+}
+
+// deleteAPIAdvisories removes the advisories of an undeployed API.
+func deleteAPIAdvisories(organizationID, apiIdentifier string) {
+	apiAdvisoryMutex.Lock()
+	defer apiAdvisoryMutex.Unlock()
+	delete(apiAdvisories[organizationID], apiIdentifier)
+}
+
+// pruneAPIAdvisories removes the advisories of the APIs, which are not deployed after the APIs are rebuilt from
+// the audit journal. Should be called while holding the mutexForInternalMapUpdate lock.
+func pruneAPIAdvisories() {
+	apiAdvisoryMutex.Lock()
+	defer apiAdvisoryMutex.Unlock()
+	for organizationID, advisories := range apiAdvisories {
+		for apiIdentifier := range advisories {
+			if _, found := orgIDAPIMgwSwaggerMap[organizationID][apiIdentifier]; !found {
+				logger.LoggerXds.Infof("Advisories of the API %s of organization %s are removed, as the API is "+
+					"not rebuilt", apiIdentifier, organizationID)
+				delete(advisories, apiIdentifier)
+			}
+		}
+	}
+}
+
+// getAdvisedRoutes returns the routes of an API including the advisories of the API, if any. The route serving
+// the advisories is the first, as the routes of the API may match the path of the advisories.
+func getAdvisedRoutes(organizationID, apiIdentifier, basePath string, routes []*routev3.Route) []*routev3.Route {
+	apiAdvisoryMutex.RLock()
+	advisories := apiAdvisories[organizationID][apiIdentifier]
+	apiAdvisoryMutex.RUnlock()
+	if len(advisories) == 0 {
+		return routes
+	}
+	messages := make([]string, 0, len(advisories))
+	for _, advisory := range advisories {
+		messages = append(messages, advisory.Message)
+	}
+	advisoryList, _ := json.Marshal(advisories)
+	return append([]*routev3.Route{envoyconf.CreateAdvisoriesRoute(basePath, advisoryList)},
+		envoyconf.AddAdvisoryHeaders(routes, messages)...)
+}
@@ -0,0 +1,204 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package xds
+
+import (
+	"sync"
+	"time"
+
+	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	"github.com/wso2/product-microgateway/adapter/config"
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/envoyconf"
+)
+
+// drainingAPI is an API removed from the gateway environments (labels), of which the routes are kept until the
+// drain period elapses.
+type drainingAPI struct {
+	uuid         string
+	revisionUUID string
+	labels       []string
+	undeployedAt time.Time
+	removedAt    time.Time
+	timer        *time.Timer
+}
+
+var (
+	// organization -> API identifier -> draining API
+	drainingAPIs     = make(map[string]map[string]*drainingAPI)
+	mutexForAPIDrain sync.RWMutex
+)
+
+// UndeployAPIWithAPIMEvent undeploys the API with the given UUID from the given gw environments, and removes the API
+// from the API lists of the enforcers. If the drain is enabled, the routes of the API are kept for the drain period
+// and respond with the Deprecation and the Sunset headers, hence the requests in progress are not dropped.
+func UndeployAPIWithAPIMEvent(uuid, organizationID string, environments []string, revisionUUID string) {
+	conf, _ := config.ReadConfigs()
+	drain := conf.Adapter.UndeployDrain
+	if !drain.Enabled || drain.PeriodInSeconds <= 0 {
+		DeleteAPIWithAPIMEvent(uuid, organizationID, environments, revisionUUID)
+		deleteAPIFromEnforcerAPILists(uuid, organizationID, environments)
+		return
+	}
+	drainAPI(uuid, organizationID, environments, revisionUUID, time.Duration(drain.PeriodInSeconds)*time.Second)
+}
+
+// drainAPI marks the API with the given UUID as draining in the given environments, updates its routes and
+// schedules the undeployment of the API once the drain period elapses.
+func drainAPI(uuid, organizationID string, environments []string, revisionUUID string, period time.Duration) {
+	mutexForInternalMapUpdate.Lock()
+	defer mutexForInternalMapUpdate.Unlock()
+	undeployedAt := time.Now().UTC()
+	drainedLabels := make([]string, 0, len(environments))
+	var drainedAPIs []string
+	mutexForAPIDrain.Lock()
+	for gw, vhost := range apiUUIDToGatewayToVhosts[uuid] {
+		if !arrayContains(environments, gw) {
+			continue
+		}
+		apiIdentifier := GenerateIdentifierForAPIWithUUID(vhost, uuid)
+		if _, ok := drainingAPIs[organizationID]; !ok {
+			drainingAPIs[organizationID] = make(map[string]*drainingAPI)
+		}
+		api, ok := drainingAPIs[organizationID][apiIdentifier]
+		if !ok {
+			api = &drainingAPI{
+				uuid:         uuid,
+				revisionUUID: revisionUUID,
+				undeployedAt: undeployedAt,
+				removedAt:    undeployedAt.Add(period),
+			}
+			drainingAPIs[organizationID][apiIdentifier] = api
+			api.timer = time.AfterFunc(period, func() {
+				completeAPIDrain(organizationID, apiIdentifier, api)
+			})
+		}
+		if !arrayContains(api.labels, gw) {
+			api.labels = append(api.labels, gw)
+		}
+		drainedLabels = append(drainedLabels, gw)
+		drainedAPIs = append(drainedAPIs, apiIdentifier)
+	}
+	mutexForAPIDrain.Unlock()
+	for _, apiIdentifier := range drainedAPIs {
+		invalidateAPIFragment(organizationID, apiIdentifier)
+	}
+	if len(drainedLabels) == 0 {
+		// the API is not deployed in the environments, hence there is nothing to drain
+		deleteAPIWithAPIMEvent(uuid, organizationID, environments, revisionUUID)
+		deleteAPIFromEnforcerAPILists(uuid, organizationID, environments)
+		return
+	}
+	logger.LoggerXds.Infof("Draining the API %s of organization %s in the environments %v for %v", uuid,
+		organizationID, drainedLabels, period)
+	updateXdsCacheOnAPIAdd(nil, drainedLabels)
+}
+
+// completeAPIDrain undeploys a draining API from the environments still being drained, unless the drain is
+// cancelled in all of those (ie: the API is redeployed).
+func completeAPIDrain(organizationID, apiIdentifier string, api *drainingAPI) {
+	mutexForInternalMapUpdate.Lock()
+	defer mutexForInternalMapUpdate.Unlock()
+	mutexForAPIDrain.Lock()
+	if drainingAPIs[organizationID][apiIdentifier] != api {
+		mutexForAPIDrain.Unlock()
+		return
+	}
+	delete(drainingAPIs[organizationID], apiIdentifier)
+	labels := api.labels
+	mutexForAPIDrain.Unlock()
+	if len(labels) == 0 {
+		return
+	}
+	logger.LoggerXds.Infof("Drain period of the API %s of organization %s is elapsed, hence undeploying from the "+
+		"environments %v", api.uuid, organizationID, labels)
+	deleteAPIWithAPIMEvent(api.uuid, organizationID, labels, api.revisionUUID)
+	deleteAPIFromEnforcerAPILists(api.uuid, organizationID, labels)
+}
+
+// cancelAPIDrain stops draining an API in the given environments, as the API is redeployed to those. The
+// undeployment is cancelled, if the API is not drained in any other environment.
+// Should be called while holding the mutexForInternalMapUpdate lock.
+func cancelAPIDrain(organizationID, apiIdentifier string, environments []string) {
+	mutexForAPIDrain.Lock()
+	defer mutexForAPIDrain.Unlock()
+	api, ok := drainingAPIs[organizationID][apiIdentifier]
+	if !ok {
+		return
+	}
+	labels := make([]string, 0, len(api.labels))
+	for _, label := range api.labels {
+		if !arrayContains(environments, label) {
+			labels = append(labels, label)
+		}
+	}
+	api.labels = labels
+	if len(labels) == 0 {
+		api.timer.Stop()
+		delete(drainingAPIs[organizationID], apiIdentifier)
+		logger.LoggerXds.Infof("Drain of the API %s of organization %s is cancelled, as the API is redeployed",
+			apiIdentifier, organizationID)
+	}
+}
+
+// deleteAPIDrain stops draining a deleted API.
+func deleteAPIDrain(organizationID, apiIdentifier string) {
+	mutexForAPIDrain.Lock()
+	defer mutexForAPIDrain.Unlock()
+	if api, ok := drainingAPIs[organizationID][apiIdentifier]; ok {
+		api.timer.Stop()
+		delete(drainingAPIs[organizationID], apiIdentifier)
+	}
+}
+
+// resetAPIDrains stops draining all the APIs, as the APIs are rebuilt from the audit journal.
+// Should be called while holding the mutexForInternalMapUpdate lock.
+func resetAPIDrains() {
+	mutexForAPIDrain.Lock()
+	defer mutexForAPIDrain.Unlock()
+	for _, apis := range drainingAPIs {
+		for _, api := range apis {
+			api.timer.Stop()
+		}
+	}
+	drainingAPIs = make(map[string]map[string]*drainingAPI)
+}
+
+// getDrainedRoutes returns the routes of an API for the given label, including the Deprecation and the Sunset
+// headers if the API is drained in the label.
+func getDrainedRoutes(organizationID, apiIdentifier, label string, routes []*routev3.Route) []*routev3.Route {
+	mutexForAPIDrain.RLock()
+	api, ok := drainingAPIs[organizationID][apiIdentifier]
+	if !ok || !arrayContains(api.labels, label) {
+		mutexForAPIDrain.RUnlock()
+		return routes
+	}
+	undeployedAt, removedAt := api.undeployedAt, api.removedAt
+	mutexForAPIDrain.RUnlock()
+	return envoyconf.AddDrainHeaders(routes, undeployedAt, removedAt)
+}
+
+// deleteAPIFromEnforcerAPILists removes the API with the given UUID from the API lists of the given environments,
+// and updates the enforcers.
+func deleteAPIFromEnforcerAPILists(uuid, organizationID string, environments []string) {
+	for _, env := range environments {
+		if xdsAPIList := DeleteAPIAndReturnList(uuid, organizationID, env); xdsAPIList != nil {
+			UpdateEnforcerAPIList(env, xdsAPIList)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package xds
+
+import (
+	"testing"
+	"time"
+
+	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-microgateway/adapter/config"
+)
+
+func TestAPIDrain(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	defaultBatching := conf.Adapter.XdsBatching
+	defaultPushLabels := pushLabels
+	defer func() {
+		conf.Adapter.XdsBatching = defaultBatching
+		pushLabels = defaultPushLabels
+		delete(apiUUIDToGatewayToVhosts, "drain-api")
+	}()
+	// the routes are not pushed to the routers
+	conf.Adapter.XdsBatching.Enabled = true
+	conf.Adapter.XdsBatching.WindowInMilliseconds = 10
+	pushLabels = func(labels []string) {}
+
+	apiIdentifier := GenerateIdentifierForAPIWithUUID("localhost", "drain-api")
+	mutexForInternalMapUpdate.Lock()
+	apiUUIDToGatewayToVhosts["drain-api"] = map[string]string{"Default": "localhost", "Sandbox": "localhost"}
+	mutexForInternalMapUpdate.Unlock()
+	routes := []*routev3.Route{{Name: "/drain/1.0.0/pets"}}
+
+	drainAPI("drain-api", "carbon.super", []string{"Default", "Sandbox"}, "", time.Hour)
+	drainedRoutes := getDrainedRoutes("carbon.super", apiIdentifier, "Default", routes)
+	assert.Len(t, drainedRoutes[0].ResponseHeadersToAdd, 2, "Deprecation and Sunset headers are not added")
+	assert.Equal(t, "Deprecation", drainedRoutes[0].ResponseHeadersToAdd[0].Header.Key)
+	assert.Equal(t, "Sunset", drainedRoutes[0].ResponseHeadersToAdd[1].Header.Key)
+	assert.Empty(t, routes[0].ResponseHeadersToAdd, "Routes of the API are modified")
+
+	cancelAPIDrain("carbon.super", apiIdentifier, []string{"Default"})
+	assert.Equal(t, routes, getDrainedRoutes("carbon.super", apiIdentifier, "Default", routes),
+		"Routes of a redeployed environment are drained")
+	assert.Len(t, getDrainedRoutes("carbon.super", apiIdentifier, "Sandbox", routes)[0].ResponseHeadersToAdd, 2)
+	cancelAPIDrain("carbon.super", apiIdentifier, []string{"Sandbox"})
+	mutexForAPIDrain.RLock()
+	_, draining := drainingAPIs["carbon.super"][apiIdentifier]
+	mutexForAPIDrain.RUnlock()
+	assert.False(t, draining, "Drain is not cancelled once the API is redeployed to all the environments")
+
+	drainAPI("drain-api", "carbon.super", []string{"Default"}, "", 50*time.Millisecond)
+	assert.Eventually(t, func() bool {
+		mutexForAPIDrain.RLock()
+		defer mutexForAPIDrain.RUnlock()
+		_, draining := drainingAPIs["carbon.super"][apiIdentifier]
+		return !draining
+	}, 2*time.Second, 20*time.Millisecond, "API is not undeployed once the drain period elapses")
+}
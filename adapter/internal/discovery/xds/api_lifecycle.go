@@ -0,0 +1,97 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package xds
+
+import (
+	"strings"
+
+	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	oasParser "github.com/wso2/product-microgateway/adapter/internal/oasparser"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/constants"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/envoyconf"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
+)
+
+// UpdateAPILifecycleStatus applies the lifecycle status changed from the control plane to the API with the given
+// UUID, and updates the routes and the enforcer API in the labels the API is deployed to. The status is replaced
+// by the status of the API project, when the API is deployed again. Returns whether the API is deployed.
+func UpdateAPILifecycleStatus(apiUUID, status string) bool {
+	status = strings.ToUpper(status)
+	mutexForInternalMapUpdate.Lock()
+	defer mutexForInternalMapUpdate.Unlock()
+
+	found := false
+	var labels []string
+	for organizationID, mgwSwaggers := range orgIDAPIMgwSwaggerMap {
+		for apiIdentifier, mgwSwagger := range mgwSwaggers {
+			if mgwSwagger.GetID() != apiUUID {
+				continue
+			}
+			found = true
+			if mgwSwagger.LifecycleStatus == status {
+				continue
+			}
+			logger.LoggerXds.Infof("Lifecycle status of the API %s of organization %s is changed from %q to %q",
+				apiIdentifier, organizationID, mgwSwagger.LifecycleStatus, status)
+			mgwSwagger.LifecycleStatus = status
+			mgwSwaggers[apiIdentifier] = mgwSwagger
+			invalidateAPIFragment(organizationID, apiIdentifier)
+			if vhost, err := ExtractVhostFromAPIIdentifier(apiIdentifier); err == nil {
+				if _, ok := orgIDOpenAPIEnforcerApisMap[organizationID]; ok {
+					orgIDOpenAPIEnforcerApisMap[organizationID][apiIdentifier] = oasParser.GetEnforcerAPI(mgwSwagger, vhost)
+				}
+			}
+			for _, label := range orgIDOpenAPIEnvoyMap[organizationID][apiIdentifier] {
+				if !arrayContains(labels, label) {
+					labels = append(labels, label)
+				}
+			}
+		}
+	}
+	if len(labels) > 0 {
+		updateXdsCacheOnAPIAdd(nil, labels)
+	}
+	return found
+}
+
+// IsAPIDeprecated returns whether the API with the given UUID is deployed, and is deprecated from the control plane.
+func IsAPIDeprecated(apiUUID string) bool {
+	mutexForInternalMapUpdate.Lock()
+	defer mutexForInternalMapUpdate.Unlock()
+	for _, mgwSwaggers := range orgIDAPIMgwSwaggerMap {
+		for _, mgwSwagger := range mgwSwaggers {
+			if mgwSwagger.GetID() == apiUUID && mgwSwagger.LifecycleStatus == constants.DeprecatedLifecycleStatus {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// getLifecycleRoutes returns the routes of an API by its lifecycle status. The requests to a blocked API are
+// responded with 503, while the responses of a deprecated API include a warning.
+func getLifecycleRoutes(mgwSwagger *model.MgwSwagger, routes []*routev3.Route) []*routev3.Route {
+	switch strings.ToUpper(mgwSwagger.LifecycleStatus) {
+	case constants.BlockedLifecycleStatus:
+		return envoyconf.CreateBlockedRoutes(routes)
+	case constants.DeprecatedLifecycleStatus:
+		return envoyconf.AddDeprecatedHeaders(routes, mgwSwagger.GetDeprecationConfig() != nil)
+	}
+	return routes
+}
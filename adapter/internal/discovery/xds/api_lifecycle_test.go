@@ -0,0 +1,60 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package xds
+
+import (
+	"testing"
+
+	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
+)
+
+func TestAPILifecycleStatus(t *testing.T) {
+	routes := []*routev3.Route{{Name: "/pets/1.0.0/pets", Action: &routev3.Route_Route{Route: &routev3.RouteAction{}}}}
+	var swagger model.MgwSwagger
+	swagger.SetID("lifecycle-api-uuid")
+	swagger.LifecycleStatus = "PUBLISHED"
+	assert.Equal(t, routes, getLifecycleRoutes(&swagger, routes))
+
+	organizationID := "lifecycle-org"
+	apiIdentifier := GenerateIdentifierForAPIWithUUID("localhost", swagger.GetID())
+	mutexForInternalMapUpdate.Lock()
+	orgIDAPIMgwSwaggerMap[organizationID] = map[string]model.MgwSwagger{apiIdentifier: swagger}
+	mutexForInternalMapUpdate.Unlock()
+	defer func() {
+		mutexForInternalMapUpdate.Lock()
+		delete(orgIDAPIMgwSwaggerMap, organizationID)
+		mutexForInternalMapUpdate.Unlock()
+	}()
+	assert.False(t, UpdateAPILifecycleStatus("unknown-api-uuid", "BLOCKED"))
+	assert.False(t, IsAPIDeprecated(swagger.GetID()))
+
+	assert.True(t, UpdateAPILifecycleStatus(swagger.GetID(), "Blocked"))
+	swagger = orgIDAPIMgwSwaggerMap[organizationID][apiIdentifier]
+	assert.Equal(t, "BLOCKED", swagger.LifecycleStatus)
+	blockedRoutes := getLifecycleRoutes(&swagger, routes)
+	assert.Equal(t, uint32(503), blockedRoutes[0].GetDirectResponse().GetStatus())
+
+	assert.True(t, UpdateAPILifecycleStatus(swagger.GetID(), "DEPRECATED"))
+	assert.True(t, IsAPIDeprecated(swagger.GetID()))
+	swagger = orgIDAPIMgwSwaggerMap[organizationID][apiIdentifier]
+	deprecatedRoutes := getLifecycleRoutes(&swagger, routes)
+	assert.NotNil(t, deprecatedRoutes[0].GetRoute(), "Requests to a deprecated API should be routed")
+	assert.Len(t, deprecatedRoutes[0].ResponseHeadersToAdd, 2)
+}
@@ -0,0 +1,100 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package xds
+
+import (
+	"sync"
+	"time"
+
+	"github.com/wso2/product-microgateway/adapter/config"
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/pkg/metrics"
+)
+
+// xdsBatch is the labels of which the APIs are changed since the first change of the batch, but are not pushed to
+// the routers and the enforcers yet.
+type xdsBatch struct {
+	labels    []string
+	changes   int
+	startedAt time.Time
+}
+
+var (
+	pendingXdsBatch  *xdsBatch
+	mutexForXdsBatch sync.Mutex
+)
+
+// pushLabels generates the resources of the labels, and updates the router and the enforcer caches.
+var pushLabels = func(labels []string) {
+	for _, label := range labels {
+		listeners, clusters, routes, endpoints, apis := GenerateEnvoyResoucesForLabel(label)
+		UpdateEnforcerApis(label, apis, "")
+		UpdateXdsCacheWithLock(label, endpoints, clusters, routes, listeners)
+	}
+}
+
+// queueXdsUpdate adds the labels to the pending batch if the xDS updates are batched, and returns whether they
+// are queued. The batch is pushed once the window elapses since its first change, hence a burst of events (ie:
+// a tenant migration) results in a single update per window, instead of an update per event. The deployments,
+// the undeployments and the deletions of the APIs are batched, as all of those update the caches via
+// updateXdsCacheOnAPIAdd. The changes of the endpoints discovered via Consul are not batched.
+// Should be called while holding the mutexForInternalMapUpdate lock.
+func queueXdsUpdate(labels []string) bool {
+	conf, _ := config.ReadConfigs()
+	batching := conf.Adapter.XdsBatching
+	if !batching.Enabled || batching.WindowInMilliseconds <= 0 {
+		return false
+	}
+	mutexForXdsBatch.Lock()
+	defer mutexForXdsBatch.Unlock()
+	if pendingXdsBatch == nil {
+		pendingXdsBatch = &xdsBatch{startedAt: time.Now()}
+		time.AfterFunc(time.Duration(batching.WindowInMilliseconds)*time.Millisecond, flushXdsBatch)
+	}
+	pendingXdsBatch.changes++
+	for _, label := range labels {
+		if !arrayContains(pendingXdsBatch.labels, label) {
+			pendingXdsBatch.labels = append(pendingXdsBatch.labels, label)
+		}
+	}
+	return true
+}
+
+// flushXdsBatch pushes the labels of the pending batch. The internal maps are locked, hence the batch is not
+// changed while its labels are pushed.
+func flushXdsBatch() {
+	mutexForInternalMapUpdate.Lock()
+	defer mutexForInternalMapUpdate.Unlock()
+	mutexForXdsBatch.Lock()
+	batch := pendingXdsBatch
+	pendingXdsBatch = nil
+	mutexForXdsBatch.Unlock()
+	if batch == nil {
+		return
+	}
+	pushLabels(batch.labels)
+	metrics.ObserveXdsPush(batch.changes, time.Since(batch.startedAt))
+	logger.LoggerXds.Infof("Pushed %d changes of the APIs to the labels %v in a single update", batch.changes,
+		batch.labels)
+}
+
+// FlushXdsBatch pushes the changes of the pending batch without waiting for the window to elapse, hence the changes
+// are sent to the routers and the enforcers before the adapter is stopped.
+func FlushXdsBatch() {
+	flushXdsBatch()
+}
@@ -0,0 +1,83 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package xds
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-microgateway/adapter/config"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
+)
+
+func TestXdsBatching(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	defaultBatching := conf.Adapter.XdsBatching
+	defaultPushLabels := pushLabels
+	defer func() {
+		conf.Adapter.XdsBatching = defaultBatching
+		pushLabels = defaultPushLabels
+	}()
+	var pushes [][]string
+	var mutex sync.Mutex
+	pushLabels = func(labels []string) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		pushes = append(pushes, labels)
+	}
+	getPushes := func() [][]string {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return append([][]string{}, pushes...)
+	}
+
+	conf.Adapter.XdsBatching.Enabled = false
+	assert.False(t, queueXdsUpdate([]string{"Default"}), "Update is queued although batching is disabled")
+
+	conf.Adapter.XdsBatching.Enabled = true
+	conf.Adapter.XdsBatching.WindowInMilliseconds = 100
+	assert.True(t, queueXdsUpdate([]string{"Default"}))
+	assert.True(t, queueXdsUpdate([]string{"Default", "Sandbox"}))
+	assert.True(t, queueXdsUpdate([]string{"Sandbox"}))
+	assert.Empty(t, getPushes(), "Batch is pushed before the window elapses")
+	assert.Eventually(t, func() bool { return len(getPushes()) > 0 }, 2*time.Second, 20*time.Millisecond)
+	time.Sleep(200 * time.Millisecond)
+	assert.Equal(t, [][]string{{"Default", "Sandbox"}}, getPushes(), "Changes are not coalesced into a single push")
+
+	conf.Adapter.XdsBatching.WindowInMilliseconds = 60000
+	assert.True(t, queueXdsUpdate([]string{"Sandbox"}))
+	FlushXdsBatch()
+	assert.Equal(t, [][]string{{"Default", "Sandbox"}, {"Sandbox"}}, getPushes(), "Pending batch is not flushed")
+	FlushXdsBatch()
+	assert.Len(t, getPushes(), 2, "Flushed batch is pushed again")
+
+	organizationID := "batching-org"
+	apiIdentifier := GenerateIdentifierForAPIWithUUID("localhost", "batched-api")
+	mutexForInternalMapUpdate.Lock()
+	orgIDAPIMgwSwaggerMap[organizationID] = map[string]model.MgwSwagger{apiIdentifier: {}}
+	orgIDOpenAPIEnvoyMap[organizationID] = map[string][]string{apiIdentifier: {"Default", "Sandbox"}}
+	assert.Nil(t, deleteAPI(apiIdentifier, []string{"Sandbox"}, organizationID, "admin"))
+	assert.Nil(t, deleteAPI(apiIdentifier, nil, organizationID, "admin"))
+	mutexForInternalMapUpdate.Unlock()
+	assert.Len(t, getPushes(), 2, "Undeployment is pushed before the window elapses")
+	FlushXdsBatch()
+	assert.Equal(t, []string{"Sandbox", "Default"}, getPushes()[2], "Undeployments are not coalesced into a single push")
+	assert.NotContains(t, orgIDAPIMgwSwaggerMap[organizationID], apiIdentifier)
+}
@@ -0,0 +1,457 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package xds
+
+import (
+	"container/list"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/wso2/product-microgateway/adapter/config"
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/pkg/discovery/api/wso2/discovery/subscription"
+	"github.com/wso2/product-microgateway/adapter/pkg/eventhub/types"
+	"github.com/wso2/product-microgateway/adapter/pkg/logging"
+	"google.golang.org/protobuf/proto"
+)
+
+// ErrResourceNotFound is returned when a resource is found neither in the memory, the spill directory nor the
+// control plane.
+var ErrResourceNotFound = errors.New("resource is not found")
+
+// ErrResourceNotSupported is returned when the resources of a type are not kept in a bounded store.
+var ErrResourceNotSupported = errors.New("resource is not supported")
+
+// ResourceStoreStats represents the usage of a bounded resource store.
+type ResourceStoreStats struct {
+	Resource string `json:"resource"`
+	// MaxEntries is the limit of the entries in memory, which is 0 if unbounded
+	MaxEntries int    `json:"maxEntries"`
+	InMemory   int    `json:"inMemory"`
+	Spilled    int    `json:"spilled"`
+	Evictions  uint64 `json:"evictions"`
+	// Misses are the lookups of the entries not in memory
+	Misses uint64 `json:"misses"`
+}
+
+// boundedStore tracks the recency of the entries of a resource store, and evicts the least recently used entries
+// beyond the limit to the spill directory. The entries themselves are kept in the maps of the resources (ex:
+// ApplicationMap), which are guarded by the mutexes of those maps. The evicted entries are only evicted from the
+// maps of the adapter, as the lists of the resources sent to the enforcers are read along with the spilled entries.
+type boundedStore struct {
+	resource   string
+	newMessage func() proto.Message
+	maxEntries int
+	// spillDirectory is empty if the evicted entries are not written to disk
+	spillDirectory string
+	// recency contains the keys, the most recently used first
+	recency   *list.List
+	elements  map[string]*list.Element
+	spilled   map[string]struct{}
+	evictions uint64
+	misses    uint64
+	mutex     sync.Mutex
+}
+
+var (
+	applicationStore  = newBoundedStore(sharedApplications, func() proto.Message { return &subscription.Application{} })
+	subscriptionStore = newBoundedStore(sharedSubscriptions,
+		func() proto.Message { return &subscription.Subscription{} })
+	keyMappingStore = newBoundedStore(sharedKeyMappings,
+		func() proto.Message { return &subscription.ApplicationKeyMapping{} })
+	boundedStores = []*boundedStore{applicationStore, subscriptionStore, keyMappingStore}
+
+	// controlPlaneLookup reads a resource of the internal data REST API of the control plane by the query
+	// parameters, which is nil until the control plane client is initialized
+	controlPlaneLookup      func(endpoint string, queryParams map[string]string) ([]byte, error)
+	controlPlaneLookupMutex sync.RWMutex
+)
+
+func newBoundedStore(resource string, newMessage func() proto.Message) *boundedStore {
+	return &boundedStore{
+		resource:   resource,
+		newMessage: newMessage,
+		recency:    list.New(),
+		elements:   make(map[string]*list.Element),
+		spilled:    make(map[string]struct{}),
+	}
+}
+
+// InitResourceStores applies the limits of the resource stores. The spill directory is cleared, as the entries
+// are loaded from the control plane at startup.
+func InitResourceStores(conf *config.Config) {
+	storeConf := conf.Adapter.ResourceStores
+	limits := map[*boundedStore]int{
+		applicationStore:  storeConf.MaxApplications,
+		subscriptionStore: storeConf.MaxSubscriptions,
+		keyMappingStore:   storeConf.MaxKeyMappings,
+	}
+	for store, maxEntries := range limits {
+		if maxEntries > 0 && storeConf.SpillDirectory == "" {
+			// the evicted entries can not be sent to the enforcers without reading those back from the disk
+			logger.LoggerXds.Warnf("The %s are not bounded, as the spill directory of the evicted entries is not "+
+				"configured", store.resource)
+			maxEntries = 0
+		}
+		spillDirectory := ""
+		if maxEntries > 0 {
+			spillDirectory = filepath.Join(storeConf.SpillDirectory, store.resource)
+			if err := resetSpillDirectory(spillDirectory); err != nil {
+				logger.LoggerXds.ErrorC(logging.ErrorDetails{
+					Message: fmt.Sprintf("Error while preparing the spill directory of the %s, hence the %s are "+
+						"not bounded. %v", store.resource, store.resource, err),
+					Severity:  logging.MAJOR,
+					ErrorCode: 1427,
+				})
+				maxEntries = 0
+			}
+		}
+		store.mutex.Lock()
+		store.maxEntries = maxEntries
+		store.spillDirectory = spillDirectory
+		store.mutex.Unlock()
+		if maxEntries > 0 {
+			logger.LoggerXds.Infof("Up to %d %s are kept in memory", maxEntries, store.resource)
+		}
+	}
+}
+
+// SetControlPlaneLookup sets the function reading the resources from the control plane, which are looked up when
+// the applications and key mappings are not found in the unbounded stores (ex: not received by the adapter yet).
+func SetControlPlaneLookup(lookup func(endpoint string, queryParams map[string]string) ([]byte, error)) {
+	controlPlaneLookupMutex.Lock()
+	defer controlPlaneLookupMutex.Unlock()
+	controlPlaneLookup = lookup
+}
+
+// GetResourceStoreStats returns the usage of the bounded resource stores.
+func GetResourceStoreStats() []ResourceStoreStats {
+	stats := make([]ResourceStoreStats, 0, len(boundedStores))
+	for _, store := range boundedStores {
+		store.mutex.Lock()
+		stats = append(stats, ResourceStoreStats{
+			Resource:   store.resource,
+			MaxEntries: store.maxEntries,
+			InMemory:   store.recency.Len(),
+			Spilled:    len(store.spilled),
+			Evictions:  store.evictions,
+			Misses:     store.misses,
+		})
+		store.mutex.Unlock()
+	}
+	return stats
+}
+
+// LookupResource looks up an application, a subscription or an application key mapping by the key of its store
+// (application UUID, subscription ID or consumerKey:keyManager). The entries evicted from the memory are read from
+// the spill directory, while the entries not found in the unbounded stores are read from the control plane.
+func LookupResource(resource, key string) (proto.Message, error) {
+	message, store, err := lookupLocalResource(resource, key)
+	if message != nil || err != nil {
+		return message, err
+	}
+	if store.isSpilling() {
+		return nil, ErrResourceNotFound
+	}
+	return lookupControlPlaneResource(resource, key)
+}
+
+// lookupLocalResource looks up a resource in the memory and in the spill directory of its store. The message is nil,
+// if the resource is not found.
+func lookupLocalResource(resource, key string) (proto.Message, *boundedStore, error) {
+	var message proto.Message
+	var store *boundedStore
+	switch resource {
+	case sharedApplications:
+		store = applicationStore
+		subscriptionDataMutex.Lock()
+		if application, found := ApplicationMap[key]; found {
+			message = application
+		}
+		subscriptionDataMutex.Unlock()
+	case sharedSubscriptions:
+		store = subscriptionStore
+		if subscriptionID, err := strconv.ParseInt(key, 10, 32); err == nil {
+			subscriptionDataMutex.Lock()
+			if sub, found := SubscriptionMap[int32(subscriptionID)]; found {
+				message = sub
+			}
+			subscriptionDataMutex.Unlock()
+		}
+	case sharedKeyMappings:
+		store = keyMappingStore
+		keyMappingMutex.Lock()
+		if keyMapping, found := ApplicationKeyMappingMap[key]; found {
+			message = keyMapping
+		}
+		keyMappingMutex.Unlock()
+	default:
+		return nil, nil, fmt.Errorf("%w: %s", ErrResourceNotSupported, resource)
+	}
+	if message != nil {
+		store.markUsed(key)
+		return message, store, nil
+	}
+	store.mutex.Lock()
+	store.misses++
+	store.mutex.Unlock()
+	return store.readSpilled(key), store, nil
+}
+
+// touch marks the entry of the key as the most recently used, and returns the keys of the entries evicted as the
+// limit is exceeded. The spilled entry of the key is removed, as the entry is in memory.
+func (store *boundedStore) touch(key string) []string {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	if store.maxEntries <= 0 {
+		return nil
+	}
+	if element, found := store.elements[key]; found {
+		store.recency.MoveToFront(element)
+	} else {
+		store.elements[key] = store.recency.PushFront(key)
+	}
+	store.removeSpilled(key)
+	var evicted []string
+	for store.recency.Len() > store.maxEntries {
+		oldest := store.recency.Back()
+		evictedKey := store.recency.Remove(oldest).(string)
+		delete(store.elements, evictedKey)
+		evicted = append(evicted, evictedKey)
+		store.evictions++
+	}
+	return evicted
+}
+
+// markUsed marks the entry of the key, which is in memory, as the most recently used.
+func (store *boundedStore) markUsed(key string) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	if element, found := store.elements[key]; found {
+		store.recency.MoveToFront(element)
+	}
+}
+
+// spill writes an evicted entry to the spill directory, and returns whether the entry is written. The entry is kept
+// in memory otherwise, as it is still sent to the enforcers.
+func (store *boundedStore) spill(key string, message proto.Message) bool {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	if store.spillDirectory == "" || message == nil {
+		return false
+	}
+	value, err := proto.Marshal(message)
+	if err == nil {
+		err = ioutil.WriteFile(store.spillFile(key), value, 0600)
+	}
+	if err != nil {
+		logger.LoggerXds.ErrorC(logging.ErrorDetails{
+			Message:   fmt.Sprintf("Error while writing the evicted entry %s of the %s to disk. %v", key, store.resource, err),
+			Severity:  logging.MINOR,
+			ErrorCode: 1428,
+		})
+		return false
+	}
+	store.spilled[key] = struct{}{}
+	return true
+}
+
+// remove removes the entry of the key from the memory and the spill directory, once the resource is deleted.
+func (store *boundedStore) remove(key string) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	if element, found := store.elements[key]; found {
+		store.recency.Remove(element)
+		delete(store.elements, key)
+	}
+	store.removeSpilled(key)
+}
+
+// reset removes all the entries, once all the resources of the store are replaced.
+func (store *boundedStore) reset() {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	store.recency.Init()
+	store.elements = make(map[string]*list.Element)
+	for key := range store.spilled {
+		store.removeSpilled(key)
+	}
+}
+
+// readSpilled reads an evicted entry from the spill directory, which is nil if not found.
+func (store *boundedStore) readSpilled(key string) proto.Message {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	if _, found := store.spilled[key]; !found {
+		return nil
+	}
+	value, err := ioutil.ReadFile(store.spillFile(key))
+	if err != nil {
+		logger.LoggerXds.Warnf("Error while reading the evicted entry %s of the %s from disk. %v", key,
+			store.resource, err)
+		return nil
+	}
+	message := store.newMessage()
+	if err = proto.Unmarshal(value, message); err != nil {
+		logger.LoggerXds.Warnf("Error while decoding the evicted entry %s of the %s. %v", key, store.resource, err)
+		return nil
+	}
+	return message
+}
+
+// readAllSpilled reads all the evicted entries from the spill directory, by the key.
+func (store *boundedStore) readAllSpilled() map[string]proto.Message {
+	store.mutex.Lock()
+	keys := make([]string, 0, len(store.spilled))
+	for key := range store.spilled {
+		keys = append(keys, key)
+	}
+	store.mutex.Unlock()
+	messages := make(map[string]proto.Message, len(keys))
+	for _, key := range keys {
+		if message := store.readSpilled(key); message != nil {
+			messages[key] = message
+		}
+	}
+	return messages
+}
+
+func (store *boundedStore) isSpilling() bool {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	return store.spillDirectory != ""
+}
+
+// removeSpilled removes the spilled entry of the key, which should be called with the mutex of the store.
+func (store *boundedStore) removeSpilled(key string) {
+	if _, found := store.spilled[key]; !found {
+		return
+	}
+	delete(store.spilled, key)
+	if err := os.Remove(store.spillFile(key)); err != nil && !os.IsNotExist(err) {
+		logger.LoggerXds.Warnf("Error while removing the evicted entry %s of the %s from disk. %v", key,
+			store.resource, err)
+	}
+}
+
+// spillFile returns the file of an entry, named by the hex encoded key as the keys contain the characters not
+// allowed in the file names.
+func (store *boundedStore) spillFile(key string) string {
+	return filepath.Join(store.spillDirectory, hex.EncodeToString([]byte(key)))
+}
+
+func resetSpillDirectory(directory string) error {
+	if err := os.RemoveAll(directory); err != nil {
+		return err
+	}
+	return os.MkdirAll(directory, 0700)
+}
+
+// lookupControlPlaneResource reads an application or a key mapping from the control plane.
+func lookupControlPlaneResource(resource, key string) (proto.Message, error) {
+	controlPlaneLookupMutex.RLock()
+	lookup := controlPlaneLookup
+	controlPlaneLookupMutex.RUnlock()
+	if lookup == nil {
+		return nil, ErrResourceNotFound
+	}
+	switch resource {
+	case sharedApplications:
+		payload, err := lookup("applications", map[string]string{"uuid": key})
+		if err != nil {
+			return nil, err
+		}
+		var applications types.ApplicationList
+		if err = json.Unmarshal(payload, &applications); err != nil {
+			return nil, err
+		}
+		for _, application := range applications.List {
+			if application.UUID == key {
+				return marshalApplication(&application), nil
+			}
+		}
+	case sharedKeyMappings:
+		separator := strings.LastIndex(key, ":")
+		if separator < 0 {
+			return nil, ErrResourceNotFound
+		}
+		payload, err := lookup("application-key-mappings",
+			map[string]string{"consumerKey": key[:separator], "keymanager": key[separator+1:]})
+		if err != nil {
+			return nil, err
+		}
+		var keyMappings types.ApplicationKeyMappingList
+		if err = json.Unmarshal(payload, &keyMappings); err != nil {
+			return nil, err
+		}
+		for _, keyMapping := range keyMappings.List {
+			if GetApplicationKeyMappingReference(&keyMapping) == key {
+				return marshalKeyMapping(&keyMapping), nil
+			}
+		}
+	}
+	return nil, ErrResourceNotFound
+}
+
+// admitApplication adds an application to the ApplicationMap, evicting the least recently used applications beyond
+// the limit. Should be called with the subscriptionDataMutex.
+func admitApplication(applicationMap map[string]*subscription.Application, key string,
+	application *subscription.Application) {
+	applicationMap[key] = application
+	for _, evictedKey := range applicationStore.touch(key) {
+		if applicationStore.spill(evictedKey, applicationMap[evictedKey]) {
+			delete(applicationMap, evictedKey)
+		}
+	}
+}
+
+// admitSubscription adds a subscription to the SubscriptionMap, evicting the least recently used subscriptions
+// beyond the limit. Should be called with the subscriptionDataMutex.
+func admitSubscription(subscriptionMap map[int32]*subscription.Subscription, subscriptionID int32,
+	sub *subscription.Subscription) {
+	subscriptionMap[subscriptionID] = sub
+	for _, evictedKey := range subscriptionStore.touch(strconv.Itoa(int(subscriptionID))) {
+		evictedID, err := strconv.ParseInt(evictedKey, 10, 32)
+		if err != nil {
+			continue
+		}
+		if subscriptionStore.spill(evictedKey, subscriptionMap[int32(evictedID)]) {
+			delete(subscriptionMap, int32(evictedID))
+		}
+	}
+}
+
+// admitKeyMapping adds a key mapping to the ApplicationKeyMappingMap, evicting the least recently used key mappings
+// beyond the limit. Should be called with the keyMappingMutex.
+func admitKeyMapping(keyMappingMap map[string]*subscription.ApplicationKeyMapping, key string,
+	keyMapping *subscription.ApplicationKeyMapping) {
+	keyMappingMap[key] = keyMapping
+	for _, evictedKey := range keyMappingStore.touch(key) {
+		if keyMappingStore.spill(evictedKey, keyMappingMap[evictedKey]) {
+			delete(keyMappingMap, evictedKey)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package xds
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-microgateway/adapter/config"
+	"github.com/wso2/product-microgateway/adapter/pkg/discovery/api/wso2/discovery/subscription"
+	"github.com/wso2/product-microgateway/adapter/pkg/eventhub/types"
+)
+
+func TestBoundedResourceStores(t *testing.T) {
+	applicationMap, subscriptionMap := ApplicationMap, SubscriptionMap
+	conf := &config.Config{}
+	conf.Adapter.ResourceStores.MaxApplications = 2
+	conf.Adapter.ResourceStores.MaxSubscriptions = 1
+	conf.Adapter.ResourceStores.SpillDirectory = t.TempDir()
+	InitResourceStores(conf)
+	defer func() {
+		InitResourceStores(&config.Config{})
+		applicationStore.reset()
+		subscriptionStore.reset()
+		ApplicationMap, SubscriptionMap = applicationMap, subscriptionMap
+	}()
+
+	applicationList := MarshalMultipleApplications(&types.ApplicationList{List: []types.Application{
+		{UUID: "app1", Name: "App1"},
+		{UUID: "app2", Name: "App2"},
+	}})
+	assert.Len(t, applicationList.List, 2)
+	// app1 is used later than app2, hence app2 is evicted once app3 is added
+	_, err := LookupResource(sharedApplications, "app1")
+	assert.Nil(t, err)
+	applicationList = MarshalApplicationEventAndReturnList(&types.Application{UUID: "app3", Name: "App3"}, CreateEvent)
+	assert.Len(t, applicationList.List, 3, "Evicted applications should be sent to the enforcer")
+	assert.NotContains(t, ApplicationMap, "app2", "Applications beyond the limit should not be kept in memory")
+
+	evicted, err := LookupResource(sharedApplications, "app2")
+	if assert.Nil(t, err, "Evicted applications should be read from the spill directory") {
+		assert.Equal(t, "App2", evicted.(*subscription.Application).Name)
+	}
+	MarshalApplicationEventAndReturnList(&types.Application{UUID: "app2"}, DeleteEvent)
+	_, err = LookupResource(sharedApplications, "app2")
+	assert.Equal(t, ErrResourceNotFound, err)
+
+	MarshalMultipleSubscriptions(&types.SubscriptionList{List: []types.Subscription{
+		{SubscriptionID: 1, APIUUID: "api1", ApplicationUUID: "app1"},
+		{SubscriptionID: 2, APIUUID: "api2", ApplicationUUID: "app1"},
+	}})
+	assert.Len(t, SubscriptionMap, 1)
+	assert.Len(t, marshalSubscriptionMapToList(SubscriptionMap).List, 2,
+		"Evicted subscriptions should be sent to the enforcer")
+	for _, stats := range GetResourceStoreStats() {
+		if stats.Resource == sharedSubscriptions {
+			assert.Equal(t, 1, stats.InMemory)
+			assert.Equal(t, 1, stats.Spilled)
+		}
+	}
+	for _, key := range []string{"1", "2"} {
+		sub, err := LookupResource(sharedSubscriptions, key)
+		if assert.Nil(t, err) {
+			assert.Equal(t, key, sub.(*subscription.Subscription).SubscriptionId)
+		}
+	}
+	_, err = LookupResource("scopes", "scope1")
+	assert.True(t, errors.Is(err, ErrResourceNotSupported))
+}
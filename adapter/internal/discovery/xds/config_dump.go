@@ -0,0 +1,245 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package xds
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	clusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	tlsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	envoy_resource "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"github.com/wso2/product-microgateway/adapter/config"
+	"github.com/wso2/product-microgateway/adapter/internal/configdump"
+	"github.com/wso2/product-microgateway/adapter/pkg/discovery/api/wso2/discovery/api"
+	"github.com/wso2/product-microgateway/adapter/pkg/discovery/api/wso2/discovery/keymgt"
+	"github.com/wso2/product-microgateway/adapter/pkg/discovery/api/wso2/discovery/subscription"
+)
+
+// Sources of the certificates in the config dump
+const (
+	certificateSourceAdapter  string = "adapter"
+	certificateSourceRouter   string = "router"
+	certificateSourceUpstream string = "upstream"
+	certificateSourceClient   string = "mutualSSL"
+)
+
+// GetConfigDump returns the configuration generated for the gateway environments and the state of the stores.
+func GetConfigDump() configdump.ConfigDump {
+	conf, _ := config.ReadConfigs()
+	dump := configdump.ConfigDump{
+		GeneratedAt:  time.Now().UTC(),
+		Environments: getEnvironmentDumps(),
+		Store:        getStoreState(),
+	}
+	for path, source := range map[string]string{conf.Adapter.Keystore.CertPath: certificateSourceAdapter,
+		conf.Envoy.KeyStore.CertPath: certificateSourceRouter} {
+		// the certificates of the router are not available if the router and the adapter are not colocated
+		if content, err := os.ReadFile(path); err == nil {
+			dump.Certificates = append(dump.Certificates, parseCertificates(content, source)...)
+		}
+	}
+	return dump
+}
+
+func getEnvironmentDumps() []configdump.Environment {
+	mutexForInternalMapUpdate.Lock()
+	defer mutexForInternalMapUpdate.Unlock()
+
+	environmentsMap := make(map[string]*configdump.Environment)
+	getEnvironment := func(label string) *configdump.Environment {
+		if _, found := environmentsMap[label]; !found {
+			environmentsMap[label] = &configdump.Environment{Label: label, APIs: []configdump.API{}}
+		}
+		return environmentsMap[label]
+	}
+	for organizationID, apiEnvsMap := range orgIDOpenAPIEnvoyMap {
+		for apiIdentifier, labels := range apiEnvsMap {
+			apiDump := getAPIDump(organizationID, apiIdentifier)
+			for _, label := range labels {
+				environment := getEnvironment(label)
+				environment.APIs = append(environment.APIs, apiDump)
+			}
+		}
+	}
+	for _, label := range cache.GetStatusKeys() {
+		getEnvironment(label)
+	}
+
+	environments := make([]configdump.Environment, 0, len(environmentsMap))
+	for label, environment := range environmentsMap {
+		if snapshot, err := cache.GetSnapshot(label); err == nil {
+			environment.XdsVersion = snapshot.GetVersion(envoy_resource.RouteType)
+		}
+		sort.Slice(environment.APIs, func(i, j int) bool {
+			return environment.APIs[i].ID < environment.APIs[j].ID
+		})
+		environments = append(environments, *environment)
+	}
+	sort.Slice(environments, func(i, j int) bool {
+		return environments[i].Label < environments[j].Label
+	})
+	return environments
+}
+
+func getAPIDump(organizationID, apiIdentifier string) configdump.API {
+	apiDump := configdump.API{
+		ID:             apiIdentifier,
+		OrganizationID: organizationID,
+		HealthStatus:   getAPIHealthStatus(apiIdentifier),
+		Resources:      []configdump.Resource{},
+		Clusters:       []configdump.Cluster{},
+	}
+	if enforcerAPI, ok := orgIDOpenAPIEnforcerApisMap[organizationID][apiIdentifier].(*api.Api); ok {
+		apiDump.Name = enforcerAPI.GetTitle()
+		apiDump.Version = enforcerAPI.GetVersion()
+		apiDump.Vhost = enforcerAPI.GetVhost()
+		apiDump.BasePath = enforcerAPI.GetBasePath()
+		apiDump.APIType = enforcerAPI.GetApiType()
+		apiDump.Tier = enforcerAPI.GetTier()
+		apiDump.DisableSecurity = enforcerAPI.GetDisableSecurity()
+		for _, resource := range enforcerAPI.GetResources() {
+			resourceDump := configdump.Resource{Path: resource.GetPath(), Operations: []configdump.Operation{}}
+			for _, operation := range resource.GetMethods() {
+				policies := operation.GetPolicies()
+				resourceDump.Operations = append(resourceDump.Operations, configdump.Operation{
+					Method:          operation.GetMethod(),
+					Tier:            operation.GetTier(),
+					DisableSecurity: operation.GetDisableSecurity(),
+					PolicyCount:     len(policies.GetRequest()) + len(policies.GetResponse()) + len(policies.GetFault()),
+				})
+			}
+			apiDump.Resources = append(apiDump.Resources, resourceDump)
+		}
+		for _, certificate := range enforcerAPI.GetClientCertificates() {
+			apiDump.ClientCertificates = append(apiDump.ClientCertificates,
+				parseCertificates(certificate.GetContent(), certificateSourceClient)...)
+		}
+	}
+	for _, cluster := range orgIDOpenAPIClustersMap[organizationID][apiIdentifier] {
+		apiDump.Clusters = append(apiDump.Clusters, getClusterDump(cluster))
+	}
+	return apiDump
+}
+
+func getClusterDump(cluster *clusterv3.Cluster) configdump.Cluster {
+	clusterDump := configdump.Cluster{Name: cluster.GetName(), Endpoints: []string{}}
+	for _, localityEndpoints := range cluster.GetLoadAssignment().GetEndpoints() {
+		for _, lbEndpoint := range localityEndpoints.GetLbEndpoints() {
+			socketAddress := lbEndpoint.GetEndpoint().GetAddress().GetSocketAddress()
+			if socketAddress.GetAddress() == "" {
+				continue
+			}
+			clusterDump.Endpoints = append(clusterDump.Endpoints,
+				socketAddress.GetAddress()+":"+strconv.FormatUint(uint64(socketAddress.GetPortValue()), 10))
+		}
+	}
+	for _, transportSocketMatch := range cluster.GetTransportSocketMatches() {
+		var tlsContext tlsv3.UpstreamTlsContext
+		typedConfig := transportSocketMatch.GetTransportSocket().GetTypedConfig()
+		if typedConfig == nil || typedConfig.UnmarshalTo(&tlsContext) != nil {
+			continue
+		}
+		commonTLSContext := tlsContext.GetCommonTlsContext()
+		clusterDump.Certificates = append(clusterDump.Certificates, parseCertificates(
+			commonTLSContext.GetValidationContext().GetTrustedCa().GetInlineBytes(), certificateSourceUpstream)...)
+		for _, tlsCertificate := range commonTLSContext.GetTlsCertificates() {
+			clusterDump.Certificates = append(clusterDump.Certificates, parseCertificates(
+				tlsCertificate.GetCertificateChain().GetInlineBytes(), certificateSourceClient)...)
+		}
+	}
+	return clusterDump
+}
+
+// parseCertificates parses the PEM encoded certificates, or a DER encoded certificate. The content which cannot be
+// parsed is ignored.
+func parseCertificates(content []byte, source string) []configdump.Certificate {
+	var certificates []configdump.Certificate
+	appendCertificate := func(der []byte) {
+		if certificate, err := x509.ParseCertificate(der); err == nil {
+			certificates = append(certificates, configdump.Certificate{
+				Subject:  certificate.Subject.String(),
+				Issuer:   certificate.Issuer.String(),
+				Source:   source,
+				NotAfter: certificate.NotAfter.UTC(),
+			})
+		}
+	}
+	rest := content
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type == "CERTIFICATE" {
+			appendCertificate(block.Bytes)
+		}
+	}
+	if len(certificates) == 0 && len(content) > 0 {
+		appendCertificate(content)
+	}
+	return certificates
+}
+
+// getStoreState returns the number of entries in the latest lists of the subscription data.
+func getStoreState() configdump.StoreState {
+	mutexForEnforcerResourceUpdate.Lock()
+	defer mutexForEnforcerResourceUpdate.Unlock()
+	var state configdump.StoreState
+	latestResource := func(resourceMap map[string][]types.Resource) types.Resource {
+		if resources := resourceMap[commonEnforcerLabel]; len(resources) > 0 {
+			return resources[len(resources)-1]
+		}
+		return nil
+	}
+	if apiList, ok := latestResource(enforcerAPIListMap).(*subscription.APIList); ok {
+		state.APIs = len(apiList.GetList())
+	}
+	if applicationList, ok := latestResource(enforcerApplicationMap).(*subscription.ApplicationList); ok {
+		state.Applications = len(applicationList.GetList())
+	}
+	if subscriptionList, ok := latestResource(enforcerSubscriptionMap).(*subscription.SubscriptionList); ok {
+		state.Subscriptions = len(subscriptionList.GetList())
+	}
+	if keyMappingList, ok := latestResource(
+		enforcerApplicationKeyMappingMap).(*subscription.ApplicationKeyMappingList); ok {
+		state.ApplicationKeyMappings = len(keyMappingList.GetList())
+	}
+	if policyList, ok := latestResource(enforcerApplicationPolicyMap).(*subscription.ApplicationPolicyList); ok {
+		state.ApplicationPolicies = len(policyList.GetList())
+	}
+	if policyList, ok := latestResource(enforcerSubscriptionPolicyMap).(*subscription.SubscriptionPolicyList); ok {
+		state.SubscriptionPolicies = len(policyList.GetList())
+	}
+	if scopeList, ok := latestResource(enforcerScopeMap).(*subscription.ScopeList); ok {
+		state.Scopes = len(scopeList.GetList())
+	}
+	for _, resource := range enforcerRevokedTokensMap[commonEnforcerLabel] {
+		if _, ok := resource.(*keymgt.RevokedToken); ok {
+			state.RevokedTokens++
+		}
+	}
+	state.KeyManagers = len(KeyManagerList)
+	return state
+}
@@ -0,0 +1,98 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package xds
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	clusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	endpointv3 "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	tlsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func TestGetClusterDump(t *testing.T) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+	notAfter := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "petstore"},
+		NotBefore:    notAfter.AddDate(-1, 0, 0),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &privateKey.PublicKey, privateKey)
+	assert.Nil(t, err)
+	tlsContext, err := anypb.New(&tlsv3.UpstreamTlsContext{
+		CommonTlsContext: &tlsv3.CommonTlsContext{
+			ValidationContextType: &tlsv3.CommonTlsContext_ValidationContext{
+				ValidationContext: &tlsv3.CertificateValidationContext{
+					TrustedCa: &corev3.DataSource{Specifier: &corev3.DataSource_InlineBytes{
+						InlineBytes: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+					}},
+				},
+			},
+		},
+	})
+	assert.Nil(t, err)
+	cluster := &clusterv3.Cluster{
+		Name: "clusterProd_localhost_petstore1.0.0",
+		LoadAssignment: &endpointv3.ClusterLoadAssignment{
+			Endpoints: []*endpointv3.LocalityLbEndpoints{{
+				LbEndpoints: []*endpointv3.LbEndpoint{{
+					HostIdentifier: &endpointv3.LbEndpoint_Endpoint{Endpoint: &endpointv3.Endpoint{
+						Address: &corev3.Address{Address: &corev3.Address_SocketAddress{
+							SocketAddress: &corev3.SocketAddress{
+								Address:       "petstore.swagger.io",
+								PortSpecifier: &corev3.SocketAddress_PortValue{PortValue: 443},
+							},
+						}},
+					}},
+				}},
+			}},
+		},
+		TransportSocketMatches: []*clusterv3.Cluster_TransportSocketMatch{{
+			Name: "ts0",
+			TransportSocket: &corev3.TransportSocket{
+				Name:       "envoy.transport_sockets.tls",
+				ConfigType: &corev3.TransportSocket_TypedConfig{TypedConfig: tlsContext},
+			},
+		}},
+	}
+
+	clusterDump := getClusterDump(cluster)
+	assert.Equal(t, cluster.Name, clusterDump.Name)
+	assert.Equal(t, []string{"petstore.swagger.io:443"}, clusterDump.Endpoints)
+	if assert.Len(t, clusterDump.Certificates, 1) {
+		assert.Equal(t, "CN=petstore", clusterDump.Certificates[0].Subject)
+		assert.Equal(t, certificateSourceUpstream, clusterDump.Certificates[0].Source)
+		assert.Equal(t, notAfter, clusterDump.Certificates[0].NotAfter)
+	}
+	assert.Len(t, parseCertificates(der, certificateSourceClient), 1, "DER encoded certificates should be parsed")
+	assert.Empty(t, parseCertificates([]byte("invalid"), certificateSourceClient))
+}
@@ -106,6 +106,9 @@ func updateCertsForServiceMesh(organizationID string) {
 		}
 	}
 
+	for apiKey := range orgIDOpenAPIClustersMap[organizationID] {
+		invalidateAPIFragment(organizationID, apiKey)
+	}
 	//send the update to Router
 	for apiKey := range orgIDOpenAPIClustersMap[organizationID] {
 		updateXDSClusterCache(apiKey, organizationID)
@@ -116,6 +119,13 @@ func getServiceDiscoveryData(query svcdiscovery.Query, clusterName string, apiKe
 	doneChan := make(chan bool)
 	svcdiscovery.ClusterConsulDoneChanMap[clusterName] = doneChan
 	resultChan := svcdiscovery.ConsulClientInstance.Poll(query, doneChan)
+	watchServiceDiscoveryResults(resultChan, clusterName, apiKey, organizationID)
+}
+
+// watchServiceDiscoveryResults updates the cluster with the instances of the service, as they are discovered from the
+// service registry
+func watchServiceDiscoveryResults(resultChan <-chan []svcdiscovery.Upstream, clusterName string, apiKey string,
+	organizationID string) {
 	for {
 		select {
 		case queryResultsList, ok := <-resultChan:
@@ -125,7 +135,7 @@ func getServiceDiscoveryData(query svcdiscovery.Query, clusterName string, apiKe
 			}
 			//stop the process when API is deleted
 			if _, clusterExists := orgIDOpenAPIClustersMap[organizationID][apiKey]; !clusterExists {
-				logger.LoggerXds.Debugln("Consul service discovery stopped for cluster ", clusterName, " in API ",
+				logger.LoggerXds.Debugln("Service discovery stopped for cluster ", clusterName, " in API ",
 					apiKey, " upon API removal")
 				stopConsulDiscoveryFor(clusterName)
 				return
@@ -138,7 +148,7 @@ func getServiceDiscoveryData(query svcdiscovery.Query, clusterName string, apiKe
 					updateCluster(apiKey, clusterName, organizationID, queryResultsList)
 				}
 			} else {
-				logger.LoggerXds.Debugln("updating cluster from the consul service registry, removed the default host")
+				logger.LoggerXds.Debugln("updating cluster from the service registry, removed the default host")
 				svcdiscovery.SetClusterConsulResultMap(clusterName, queryResultsList)
 				updateCluster(apiKey, clusterName, organizationID, queryResultsList)
 			}
@@ -190,12 +200,14 @@ func updateCluster(apiKey string, clusterName string, organizationID string, que
 }
 
 func updateXDSClusterCache(apiKey string, organizationID string) {
+	// the clusters of the API are updated in place
+	invalidateAPIFragment(organizationID, apiKey)
 	for key, envoyLabelList := range orgIDOpenAPIEnvoyMap[organizationID] {
 		if key == apiKey {
 			for _, label := range envoyLabelList {
 				listeners, clusters, routes, endpoints, _ := GenerateEnvoyResoucesForLabel(label)
 				UpdateXdsCacheWithLock(label, endpoints, clusters, routes, listeners)
-				logger.LoggerXds.Info("Updated XDS cache by consul service discovery for API: ", apiKey)
+				logger.LoggerXds.Info("Updated XDS cache by service discovery for API: ", apiKey)
 			}
 		}
 	}
@@ -204,7 +216,9 @@ func updateXDSClusterCache(apiKey string, organizationID string) {
 func stopConsulDiscoveryFor(clusterName string) {
 	if doneChan, available := svcdiscovery.ClusterConsulDoneChanMap[clusterName]; available {
 		close(doneChan)
+		delete(svcdiscovery.ClusterConsulDoneChanMap, clusterName)
 	}
 	delete(svcdiscovery.ClusterConsulResultMap, clusterName)
 	delete(svcdiscovery.ClusterConsulKeyMap, clusterName)
+	delete(svcdiscovery.ClusterEurekaKeyMap, clusterName)
 }
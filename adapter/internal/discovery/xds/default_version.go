@@ -0,0 +1,70 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package xds
+
+import (
+	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/envoyconf"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
+)
+
+// getDefaultVersionRoutes returns the routes which forward the requests without the version in the path to the
+// API, if the API is the default version.
+func getDefaultVersionRoutes(mgwSwagger model.MgwSwagger, routes []*routev3.Route) []*routev3.Route {
+	if !mgwSwagger.IsDefaultVersion {
+		return nil
+	}
+	return envoyconf.CreateDefaultVersionRoutes(mgwSwagger.GetXWso2Basepath(), mgwSwagger.GetVersion(), routes)
+}
+
+// demoteDefaultVersions unmarks the other versions of the API in the vhost, which were the default version, once
+// a version of the API becomes the default version. The labels of the demoted versions are returned, hence their
+// caches can be updated. mutexForInternalMapUpdate should be acquired by the caller.
+func demoteDefaultVersions(organizationID, vHost, apiIdentifier, apiName string) []string {
+	var labels []string
+	for identifier, mgwSwagger := range orgIDAPIMgwSwaggerMap[organizationID] {
+		if identifier == apiIdentifier || !mgwSwagger.IsDefaultVersion || mgwSwagger.GetTitle() != apiName {
+			continue
+		}
+		if vhost, err := ExtractVhostFromAPIIdentifier(identifier); err != nil || vhost != vHost {
+			continue
+		}
+		mgwSwagger.IsDefaultVersion = false
+		orgIDAPIMgwSwaggerMap[organizationID][identifier] = mgwSwagger
+		invalidateAPIFragment(organizationID, identifier)
+		logger.LoggerXds.Infof("API %s:%s of Organization %s is no longer the default version in the vhost %s",
+			apiName, mgwSwagger.GetVersion(), organizationID, vHost)
+		for _, label := range orgIDOpenAPIEnvoyMap[organizationID][identifier] {
+			unmarkDefaultVersionInAPIList(label, mgwSwagger.GetID())
+			if !arrayContains(labels, label) {
+				labels = append(labels, label)
+			}
+		}
+	}
+	return labels
+}
+
+// unmarkDefaultVersionInAPIList updates the API metadata of the label, so that the API is not deployed as the
+// default version again when it is redeployed.
+func unmarkDefaultVersionInAPIList(label, apiUUID string) {
+	if api, ok := APIListMap[label][apiUUID]; ok && api.IsDefaultVersion {
+		api.IsDefaultVersion = false
+		UpdateEnforcerAPIList(label, marshalAPIListMapToList(APIListMap[label]))
+	}
+}
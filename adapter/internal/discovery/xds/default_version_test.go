@@ -0,0 +1,75 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package xds
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
+	"github.com/wso2/product-microgateway/adapter/pkg/discovery/api/wso2/discovery/subscription"
+)
+
+func TestDemoteDefaultVersions(t *testing.T) {
+	mgwSwaggerMap, envoyMap, apiListMap := orgIDAPIMgwSwaggerMap, orgIDOpenAPIEnvoyMap, APIListMap
+	defer func() {
+		orgIDAPIMgwSwaggerMap, orgIDOpenAPIEnvoyMap, APIListMap = mgwSwaggerMap, envoyMap, apiListMap
+	}()
+
+	newMgwSwagger := func(id, name, version string, isDefaultVersion bool) model.MgwSwagger {
+		var mgwSwagger model.MgwSwagger
+		mgwSwagger.SetID(id)
+		mgwSwagger.SetName(name)
+		mgwSwagger.SetVersion(version)
+		mgwSwagger.IsDefaultVersion = isDefaultVersion
+		return mgwSwagger
+	}
+	orgIDAPIMgwSwaggerMap = map[string]map[string]model.MgwSwagger{
+		"org1": {
+			"localhost:pets-v1":      newMgwSwagger("pets-v1", "PetStore", "v1", true),
+			"localhost:pets-v2":      newMgwSwagger("pets-v2", "PetStore", "v2", true),
+			"foo.com:pets-v1":        newMgwSwagger("pets-v1", "PetStore", "v1", true),
+			"localhost:pizza-v1":     newMgwSwagger("pizza-v1", "Pizza", "v1", true),
+			"localhost:pets-v3-beta": newMgwSwagger("pets-v3-beta", "PetStore", "v3-beta", false),
+		},
+	}
+	orgIDOpenAPIEnvoyMap = map[string]map[string][]string{
+		"org1": {
+			"localhost:pets-v1":  {"Default", "us-region"},
+			"localhost:pets-v2":  {"Default"},
+			"foo.com:pets-v1":    {"Default"},
+			"localhost:pizza-v1": {"Default"},
+		},
+	}
+	APIListMap = map[string]map[string]*subscription.APIs{
+		"us-region": {"pets-v1": {Uuid: "pets-v1", IsDefaultVersion: true}},
+	}
+
+	labels := demoteDefaultVersions("org1", "localhost", "localhost:pets-v2", "PetStore")
+	assert.ElementsMatch(t, []string{"Default", "us-region"}, labels)
+	assert.False(t, orgIDAPIMgwSwaggerMap["org1"]["localhost:pets-v1"].IsDefaultVersion)
+	assert.True(t, orgIDAPIMgwSwaggerMap["org1"]["localhost:pets-v2"].IsDefaultVersion)
+	assert.True(t, orgIDAPIMgwSwaggerMap["org1"]["foo.com:pets-v1"].IsDefaultVersion,
+		"Default version of the API in other vhosts should not be changed")
+	assert.True(t, orgIDAPIMgwSwaggerMap["org1"]["localhost:pizza-v1"].IsDefaultVersion,
+		"Default version of other APIs should not be changed")
+	assert.False(t, APIListMap["us-region"]["pets-v1"].IsDefaultVersion,
+		"API metadata of the demoted version should be updated")
+
+	assert.Empty(t, demoteDefaultVersions("org1", "localhost", "localhost:pets-v2", "PetStore"))
+}
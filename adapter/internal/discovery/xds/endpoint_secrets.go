@@ -0,0 +1,106 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package xds
+
+import (
+	"fmt"
+
+	"github.com/wso2/product-microgateway/adapter/config"
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	oasParser "github.com/wso2/product-microgateway/adapter/internal/oasparser"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
+	"github.com/wso2/product-microgateway/adapter/pkg/secrets"
+)
+
+// resolveEndpointSecrets resolves the $secret{} references of the endpoint credentials of the API, so that an API
+// referring a secret which can not be resolved, or is not within the secret paths allowed for the API artifacts, is
+// rejected at the deployment. The secrets are cached by the secret
+// manager, and resolved again from the cache when the enforcer API is generated.
+func resolveEndpointSecrets(mgwSwagger *model.MgwSwagger) error {
+	conf, _ := config.ReadConfigs()
+	for _, value := range getEndpointCredentials(mgwSwagger) {
+		if _, err := secrets.ResolveWithin(value, conf.Adapter.Secrets.ArtifactSecretPaths); err != nil {
+			return fmt.Errorf("error resolving the endpoint credentials of the API %s:%s. %v",
+				mgwSwagger.GetTitle(), mgwSwagger.GetVersion(), err)
+		}
+	}
+	return nil
+}
+
+// RefreshEndpointSecrets generates the enforcer APIs referring the rotated secrets again, and updates the caches of
+// the labels those APIs are deployed to.
+func RefreshEndpointSecrets(references []string) {
+	rotated := make(map[string]struct{}, len(references))
+	for _, reference := range references {
+		rotated[reference] = struct{}{}
+	}
+	mutexForInternalMapUpdate.Lock()
+	defer mutexForInternalMapUpdate.Unlock()
+
+	var labels []string
+	for organizationID, mgwSwaggers := range orgIDAPIMgwSwaggerMap {
+		for apiIdentifier, mgwSwagger := range mgwSwaggers {
+			if !refersSecrets(&mgwSwagger, rotated) {
+				continue
+			}
+			vhost, err := ExtractVhostFromAPIIdentifier(apiIdentifier)
+			if err != nil {
+				continue
+			}
+			if _, found := orgIDOpenAPIEnforcerApisMap[organizationID]; !found {
+				continue
+			}
+			logger.LoggerXds.Infof("Applying the rotated secrets to the endpoint credentials of the API %s", apiIdentifier)
+			orgIDOpenAPIEnforcerApisMap[organizationID][apiIdentifier] = oasParser.GetEnforcerAPI(mgwSwagger, vhost)
+			for _, label := range orgIDOpenAPIEnvoyMap[organizationID][apiIdentifier] {
+				if !arrayContains(labels, label) {
+					labels = append(labels, label)
+				}
+			}
+		}
+	}
+	if len(labels) > 0 {
+		updateXdsCacheOnAPIAdd(nil, labels)
+	}
+}
+
+func refersSecrets(mgwSwagger *model.MgwSwagger, references map[string]struct{}) bool {
+	for _, value := range getEndpointCredentials(mgwSwagger) {
+		for _, reference := range secrets.GetReferences(value) {
+			if _, found := references[reference]; found {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// getEndpointCredentials returns the endpoint credentials of the API, which are sent to the enforcer.
+func getEndpointCredentials(mgwSwagger *model.MgwSwagger) []string {
+	var credentials []string
+	for _, endpoints := range []*model.EndpointCluster{mgwSwagger.GetProdEndpoints(), mgwSwagger.GetSandEndpoints()} {
+		if endpoints == nil {
+			continue
+		}
+		credentials = append(credentials, endpoints.SecurityConfig.Username, endpoints.SecurityConfig.Password)
+		for _, value := range endpoints.SecurityConfig.CustomParameters {
+			credentials = append(credentials, value)
+		}
+	}
+	return credentials
+}
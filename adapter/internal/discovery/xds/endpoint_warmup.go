@@ -0,0 +1,158 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package xds
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/wso2/product-microgateway/adapter/config"
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
+	"github.com/wso2/product-microgateway/adapter/pkg/logging"
+)
+
+const (
+	warmupProbeInterval = 500 * time.Millisecond
+	warmupDialTimeout   = 2 * time.Second
+)
+
+// dialEndpoint connects to an endpoint, which resolves the host name as well.
+var dialEndpoint = func(address string, timeout time.Duration) error {
+	conn, err := net.DialTimeout("tcp", address, timeout)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+// warmUpEndpoints primes the endpoints added by an update of an API, before the routes are switched to them. It
+// waits until the new endpoints are resolved and accept connections, as the router returns 503 responses for the
+// requests routed to an unreachable cluster. Nothing is done if the API is not deployed yet, as there are no
+// routes to switch.
+//
+// An error is returned if the update should be rejected, ie: the endpoints are not reachable within the timeout
+// and the update is configured to fail on the timeout.
+func warmUpEndpoints(organizationID, apiIdentifier string, mgwSwagger *model.MgwSwagger) error {
+	conf, _ := config.ReadConfigs()
+	warmup := conf.Envoy.Upstream.Warmup
+	if !warmup.Enabled {
+		return nil
+	}
+	mutexForInternalMapUpdate.Lock()
+	existingSwagger, exists := orgIDAPIMgwSwaggerMap[organizationID][apiIdentifier]
+	mutexForInternalMapUpdate.Unlock()
+	if !exists {
+		return nil
+	}
+	newAddresses := getNewEndpointAddresses(&existingSwagger, mgwSwagger)
+	if len(newAddresses) == 0 {
+		return nil
+	}
+	logger.LoggerXds.Infof("Warming up the new endpoints %v of the API %s:%s", newAddresses,
+		mgwSwagger.GetTitle(), mgwSwagger.GetVersion())
+	unreachable := probeEndpoints(newAddresses, time.Duration(warmup.TimeoutInSeconds)*time.Second)
+	if len(unreachable) == 0 {
+		return nil
+	}
+	errorMsg := fmt.Sprintf("Endpoints %v of the API %s:%s are not reachable within %d seconds",
+		unreachable, mgwSwagger.GetTitle(), mgwSwagger.GetVersion(), warmup.TimeoutInSeconds)
+	if warmup.FailOnTimeout {
+		logger.LoggerXds.ErrorC(logging.ErrorDetails{
+			Message:   errorMsg + ". The update is rejected and the existing routes are retained.",
+			Severity:  logging.MAJOR,
+			ErrorCode: 1420,
+		})
+		return errors.New(errorMsg)
+	}
+	logger.LoggerXds.ErrorC(logging.ErrorDetails{
+		Message:   errorMsg + ". The update is deployed regardless.",
+		Severity:  logging.MINOR,
+		ErrorCode: 1420,
+	})
+	return nil
+}
+
+// getNewEndpointAddresses returns the addresses (host:port) of the endpoints of the updated API, which are not
+// endpoints of the deployed API. The endpoints resolved by the service discovery are skipped.
+func getNewEndpointAddresses(existingSwagger, updatedSwagger *model.MgwSwagger) []string {
+	existingAddresses := getEndpointAddresses(existingSwagger)
+	var newAddresses []string
+	for address := range getEndpointAddresses(updatedSwagger) {
+		if _, found := existingAddresses[address]; !found {
+			newAddresses = append(newAddresses, address)
+		}
+	}
+	sort.Strings(newAddresses)
+	return newAddresses
+}
+
+func getEndpointAddresses(mgwSwagger *model.MgwSwagger) map[string]struct{} {
+	addresses := make(map[string]struct{})
+	addClusterAddresses := func(cluster *model.EndpointCluster) {
+		if cluster == nil {
+			return
+		}
+		for _, endpoint := range cluster.Endpoints {
+			if endpoint.ServiceDiscoveryString != "" || endpoint.Host == "" {
+				continue
+			}
+			addresses[net.JoinHostPort(endpoint.Host, strconv.FormatUint(uint64(endpoint.Port), 10))] = void
+		}
+	}
+	addClusterAddresses(mgwSwagger.GetProdEndpoints())
+	addClusterAddresses(mgwSwagger.GetSandEndpoints())
+	for _, resource := range mgwSwagger.GetResources() {
+		addClusterAddresses(resource.GetProdEndpoints())
+		addClusterAddresses(resource.GetSandEndpoints())
+	}
+	return addresses
+}
+
+// probeEndpoints connects to the endpoints until all of them are reachable or the timeout elapses, and returns
+// the endpoints which are not reachable.
+func probeEndpoints(addresses []string, timeout time.Duration) []string {
+	deadline := time.Now().Add(timeout)
+	pending := addresses
+	for {
+		var unreachable []string
+		for _, address := range pending {
+			dialTimeout := warmupDialTimeout
+			if remaining := time.Until(deadline); remaining < dialTimeout {
+				dialTimeout = remaining
+			}
+			if dialTimeout <= 0 {
+				unreachable = append(unreachable, address)
+				continue
+			}
+			if err := dialEndpoint(address, dialTimeout); err != nil {
+				logger.LoggerXds.Debugf("Endpoint %s is not reachable yet. %v", address, err)
+				unreachable = append(unreachable, address)
+			}
+		}
+		pending = unreachable
+		if len(pending) == 0 || time.Until(deadline) <= warmupProbeInterval {
+			return pending
+		}
+		time.Sleep(warmupProbeInterval)
+	}
+}
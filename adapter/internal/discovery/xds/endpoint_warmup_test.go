@@ -0,0 +1,57 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package xds
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
+)
+
+func TestEndpointWarmup(t *testing.T) {
+	var existingSwagger, updatedSwagger model.MgwSwagger
+	existingSwagger.SetProductionEndpoints([]model.Endpoint{{Host: "backend-v1", Port: 8080}})
+	existingSwagger.SetSandboxEndpoints([]model.Endpoint{{Host: "sandbox", Port: 8080}})
+	updatedSwagger.SetProductionEndpoints([]model.Endpoint{{Host: "backend-v2", Port: 8080},
+		{Host: "backend-v2", Port: 8081}})
+	updatedSwagger.SetSandboxEndpoints([]model.Endpoint{{Host: "sandbox", Port: 8080}})
+	assert.Equal(t, []string{"backend-v2:8080", "backend-v2:8081"},
+		getNewEndpointAddresses(&existingSwagger, &updatedSwagger), "New endpoints mismatch")
+	assert.Empty(t, getNewEndpointAddresses(&updatedSwagger, &updatedSwagger), "Unchanged endpoints are primed")
+
+	defaultDialEndpoint := dialEndpoint
+	defer func() { dialEndpoint = defaultDialEndpoint }()
+	attempts := 0
+	dialEndpoint = func(address string, timeout time.Duration) error {
+		if address == "backend-v2:8081" {
+			return errors.New("connection refused")
+		}
+		// the first endpoint becomes reachable at the second attempt
+		attempts++
+		if attempts < 2 {
+			return errors.New("no such host")
+		}
+		return nil
+	}
+	unreachable := probeEndpoints([]string{"backend-v2:8080", "backend-v2:8081"}, 1200*time.Millisecond)
+	assert.Equal(t, []string{"backend-v2:8081"}, unreachable, "Unreachable endpoints mismatch")
+	assert.Equal(t, 2, attempts, "Reachable endpoint is probed again")
+}
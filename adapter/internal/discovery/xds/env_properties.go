@@ -0,0 +1,106 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package xds
+
+import (
+	"sync"
+
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/pkg/synchronizer"
+)
+
+var (
+	// API UUID -> gateway label -> environment specific properties of the API, set with the deploy events of the
+	// control plane
+	envPropsOverrides     = make(map[string]map[string]synchronizer.APIEnvProps)
+	envPropsOverrideMutex sync.RWMutex
+)
+
+// SetEnvPropsOverride sets the environment specific properties of an API received with a deploy event, keyed by
+// the gateway label, which override the properties of the API artifact when the API is deployed. The overrides are
+// removed if empty.
+func SetEnvPropsOverride(apiUUID string, envProps map[string]synchronizer.APIEnvProps) {
+	envPropsOverrideMutex.Lock()
+	defer envPropsOverrideMutex.Unlock()
+	if len(envProps) == 0 {
+		delete(envPropsOverrides, apiUUID)
+		return
+	}
+	envPropsOverrides[apiUUID] = envProps
+}
+
+// resetEnvPropsOverrides removes the environment specific properties received with the deploy events, as the APIs
+// are rebuilt from the audit journal, which records the properties applied to each API.
+func resetEnvPropsOverrides() {
+	envPropsOverrideMutex.Lock()
+	defer envPropsOverrideMutex.Unlock()
+	envPropsOverrides = make(map[string]map[string]synchronizer.APIEnvProps)
+}
+
+// getEffectiveEnvProps returns the environment specific properties of the API artifact, overridden by the
+// properties received with the deploy event of the API, keyed by the gateway label.
+func getEffectiveEnvProps(apiUUID string,
+	artifactEnvProps map[string]synchronizer.APIEnvProps) map[string]synchronizer.APIEnvProps {
+	envPropsOverrideMutex.RLock()
+	overrides := envPropsOverrides[apiUUID]
+	envPropsOverrideMutex.RUnlock()
+	if len(overrides) == 0 {
+		return artifactEnvProps
+	}
+	envProps := make(map[string]synchronizer.APIEnvProps, len(artifactEnvProps)+len(overrides))
+	for env, props := range artifactEnvProps {
+		envProps[env] = props
+	}
+	for env, props := range overrides {
+		envProps[env] = props
+	}
+	return envProps
+}
+
+// getAPIEnvProps returns the environment specific properties of an API to be applied in the environments of a vhost.
+// The properties received with the deploy event of the API take precedence over the properties of the API artifact.
+// As the API is generated once for all the environments of the vhost, the properties of the first environment
+// having them are applied.
+func getAPIEnvProps(apiUUID string, artifactEnvProps map[string]synchronizer.APIEnvProps,
+	environments []string) synchronizer.APIEnvProps {
+	envPropsOverrideMutex.RLock()
+	overrides := envPropsOverrides[apiUUID]
+	envPropsOverrideMutex.RUnlock()
+
+	var (
+		selected    synchronizer.APIEnvProps
+		selectedEnv string
+	)
+	for _, env := range environments {
+		envProps, found := overrides[env]
+		if !found {
+			envProps, found = artifactEnvProps[env]
+		}
+		if !found {
+			continue
+		}
+		if selectedEnv == "" {
+			selected, selectedEnv = envProps, env
+		} else if envProps.APIConfigs != selected.APIConfigs {
+			logger.LoggerXds.Warnf("Environment specific properties of the environment %s are not applied for the "+
+				"API %s, as they differ from the properties of the environment %s of the same vhost", env, apiUUID,
+				selectedEnv)
+		}
+	}
+	return selected
+}
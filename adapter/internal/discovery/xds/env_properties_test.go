@@ -0,0 +1,61 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package xds
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-microgateway/adapter/pkg/synchronizer"
+)
+
+func TestGetAPIEnvProps(t *testing.T) {
+	envProps := func(productionEndpoint string) synchronizer.APIEnvProps {
+		return synchronizer.APIEnvProps{APIConfigs: synchronizer.APIConfigs{ProductionEndpoint: productionEndpoint}}
+	}
+	artifactEnvProps := map[string]synchronizer.APIEnvProps{
+		"staging":    envProps("http://staging-backend"),
+		"production": envProps("http://production-backend"),
+	}
+
+	assert.Equal(t, envProps("http://production-backend"),
+		getAPIEnvProps("env-props-api", artifactEnvProps, []string{"production"}))
+	assert.Equal(t, envProps("http://staging-backend"),
+		getAPIEnvProps("env-props-api", artifactEnvProps, []string{"Default", "staging"}),
+		"Properties of the environment having them should be applied")
+	assert.Equal(t, synchronizer.APIEnvProps{}, getAPIEnvProps("env-props-api", artifactEnvProps, []string{"Default"}))
+
+	SetEnvPropsOverride("env-props-api", map[string]synchronizer.APIEnvProps{
+		"production": envProps("http://production-backend-v2"),
+	})
+	assert.Equal(t, envProps("http://production-backend-v2"),
+		getAPIEnvProps("env-props-api", artifactEnvProps, []string{"production"}),
+		"Properties of the deploy event should override the properties of the artifact")
+	assert.Equal(t, envProps("http://staging-backend"),
+		getAPIEnvProps("env-props-api", artifactEnvProps, []string{"staging"}))
+	assert.Equal(t, map[string]synchronizer.APIEnvProps{
+		"staging":    envProps("http://staging-backend"),
+		"production": envProps("http://production-backend-v2"),
+	}, getEffectiveEnvProps("env-props-api", artifactEnvProps), "Recorded properties should include the overrides")
+	assert.Equal(t, envProps("http://production-backend"), artifactEnvProps["production"],
+		"Properties of the artifact are modified")
+
+	SetEnvPropsOverride("env-props-api", nil)
+	assert.Equal(t, envProps("http://production-backend"),
+		getAPIEnvProps("env-props-api", artifactEnvProps, []string{"production"}))
+}
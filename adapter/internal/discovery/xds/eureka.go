@@ -0,0 +1,46 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package xds
+
+import (
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/internal/svcdiscovery"
+)
+
+// startEurekaServiceDiscovery starts polling the eureka server for the instances of the applications referred by the
+// clusters of the given API. The polling of a redeployed API is restarted, as its clusters are initialized with the
+// default host again.
+func startEurekaServiceDiscovery(organizationID string, apiKey string) {
+	for _, cluster := range orgIDOpenAPIClustersMap[organizationID][apiKey] {
+		appName, ok := svcdiscovery.ClusterEurekaKeyMap[cluster.Name]
+		if !ok {
+			continue
+		}
+		svcdiscovery.InitEureka()
+		if doneChan, available := svcdiscovery.ClusterConsulDoneChanMap[cluster.Name]; available {
+			close(doneChan)
+		}
+		svcdiscovery.SetClusterConsulResultMap(cluster.Name, nil)
+
+		logger.LoggerXds.Debugln("eureka application of the cluster ", cluster.Name, ": ", appName)
+		doneChan := make(chan bool)
+		svcdiscovery.ClusterConsulDoneChanMap[cluster.Name] = doneChan
+		resultChan := svcdiscovery.EurekaClientInstance.Poll(appName, doneChan)
+		go watchServiceDiscoveryResults(resultChan, cluster.Name, apiKey, organizationID)
+	}
+}
@@ -0,0 +1,129 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package xds
+
+import (
+	"sync"
+
+	clusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	envoy_cachev3 "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+	"github.com/wso2/product-microgateway/adapter/config"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/envoyconf"
+)
+
+// apiFragment is the router resources of an API for a label. The fragments of the unchanged APIs are reused when
+// the snapshot of a label is composed, hence only the changed APIs are processed and hashed again.
+type apiFragment struct {
+	apiUUID              string
+	vhost                string
+	routes               []*routev3.Route
+	defaultVersionRoutes []*routev3.Route
+	clusters             []*clusterv3.Cluster
+	endpoints            []*corev3.Address
+	// grpcTranscoder transcodes the requests of the API to gRPC, which is attached to the vhost of the API
+	grpcTranscoder *envoyconf.GRPCTranscoder
+}
+
+var (
+	// organizationID -> API identifier -> label -> fragment
+	apiFragments = make(map[string]map[string]map[string]*apiFragment)
+	// fragmentResourceHashes holds the hashes of the clusters of the cached fragments
+	fragmentResourceHashes = make(map[types.Resource]string)
+	apiFragmentsMutex      sync.Mutex
+)
+
+// getAPIFragment returns the fragment of the API for the label, which is composed from the routes and the clusters
+// of the API if it is not cached. False is returned if the API is not deployed.
+func getAPIFragment(organizationID, apiIdentifier, label, vhost string) (*apiFragment, bool, error) {
+	apiFragmentsMutex.Lock()
+	defer apiFragmentsMutex.Unlock()
+	if fragment, found := apiFragments[organizationID][apiIdentifier][label]; found {
+		return fragment, true, nil
+	}
+	mgwSwagger, found := orgIDAPIMgwSwaggerMap[organizationID][apiIdentifier]
+	if !found {
+		return nil, false, nil
+	}
+	routes := getAdvisedRoutes(organizationID, apiIdentifier, mgwSwagger.GetXWso2Basepath(),
+		orgIDOpenAPIRoutesMap[organizationID][apiIdentifier])
+	routes = getDrainedRoutes(organizationID, apiIdentifier, label, routes)
+	routes = getIPRestrictedRoutes(organizationID, &mgwSwagger, routes)
+	routes = getLifecycleRoutes(&mgwSwagger, routes)
+	fragment := &apiFragment{
+		apiUUID:              mgwSwagger.GetID(),
+		vhost:                vhost,
+		routes:               routes,
+		defaultVersionRoutes: getDefaultVersionRoutes(mgwSwagger, routes),
+		clusters:             orgIDOpenAPIClustersMap[organizationID][apiIdentifier],
+		endpoints:            orgIDOpenAPIEndpointsMap[organizationID][apiIdentifier],
+	}
+	if conf, _ := config.ReadConfigs(); conf.Envoy.Filters.GRPCTranscoding.Enabled {
+		grpcTranscoder, err := envoyconf.CreateGRPCTranscoder(mgwSwagger)
+		if err != nil {
+			return nil, false, err
+		}
+		fragment.grpcTranscoder = grpcTranscoder
+	}
+	for _, cluster := range fragment.clusters {
+		marshalledCluster, err := envoy_cachev3.MarshalResource(cluster)
+		if err != nil {
+			return nil, false, err
+		}
+		fragmentResourceHashes[cluster] = envoy_cachev3.HashResource(marshalledCluster)
+	}
+	if _, found := apiFragments[organizationID]; !found {
+		apiFragments[organizationID] = make(map[string]map[string]*apiFragment)
+	}
+	if _, found := apiFragments[organizationID][apiIdentifier]; !found {
+		apiFragments[organizationID][apiIdentifier] = make(map[string]*apiFragment)
+	}
+	apiFragments[organizationID][apiIdentifier][label] = fragment
+	return fragment, true, nil
+}
+
+// getFragmentResourceHash returns the hash of a resource of a cached fragment.
+func getFragmentResourceHash(resource types.Resource) (string, bool) {
+	apiFragmentsMutex.Lock()
+	defer apiFragmentsMutex.Unlock()
+	hash, found := fragmentResourceHashes[resource]
+	return hash, found
+}
+
+// invalidateAPIFragment removes the cached fragments of the API, once the API or the state its routes are derived
+// from is changed. Should not be called while holding the locks of the state read by getAPIFragment.
+func invalidateAPIFragment(organizationID, apiIdentifier string) {
+	apiFragmentsMutex.Lock()
+	defer apiFragmentsMutex.Unlock()
+	for _, fragment := range apiFragments[organizationID][apiIdentifier] {
+		for _, cluster := range fragment.clusters {
+			delete(fragmentResourceHashes, cluster)
+		}
+	}
+	delete(apiFragments[organizationID], apiIdentifier)
+}
+
+// resetAPIFragments removes the cached fragments of all the APIs, once the state shared by the APIs is changed.
+func resetAPIFragments() {
+	apiFragmentsMutex.Lock()
+	defer apiFragmentsMutex.Unlock()
+	apiFragments = make(map[string]map[string]map[string]*apiFragment)
+	fragmentResourceHashes = make(map[types.Resource]string)
+}
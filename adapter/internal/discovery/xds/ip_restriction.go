@@ -0,0 +1,92 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package xds
+
+import (
+	"sync"
+
+	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	"github.com/wso2/product-microgateway/adapter/config"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/envoyconf"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
+	"github.com/wso2/product-microgateway/adapter/pkg/discovery/api/wso2/discovery/throttle"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	// blockedIPs holds the IP blocking conditions of the control plane, which are enforced by the router as well
+	blockedIPs      []*throttle.IPCondition
+	blockedIPsMutex sync.RWMutex
+)
+
+// updateBlockedIPs updates the IP blocking conditions enforced by the router, and returns whether the conditions
+// are changed.
+func updateBlockedIPs(conditions []*throttle.IPCondition) bool {
+	blockedIPsMutex.Lock()
+	defer blockedIPsMutex.Unlock()
+	if len(conditions) == len(blockedIPs) {
+		changed := false
+		for i, condition := range conditions {
+			if !proto.Equal(condition, blockedIPs[i]) {
+				changed = true
+				break
+			}
+		}
+		if !changed {
+			return false
+		}
+	}
+	blockedIPs = conditions
+	return true
+}
+
+// getIPRestrictedRoutes returns the routes of an API denying the requests from the sources restricted by the
+// API definition or the adapter config, and the sources blocked by the IP blocking conditions of the organization.
+func getIPRestrictedRoutes(organizationID string, swagger *model.MgwSwagger,
+	routes []*routev3.Route) []*routev3.Route {
+	var organizationBlockedIPs []*throttle.IPCondition
+	blockedIPsMutex.RLock()
+	for _, condition := range blockedIPs {
+		if condition.TenantDomain == organizationID {
+			organizationBlockedIPs = append(organizationBlockedIPs, condition)
+		}
+	}
+	blockedIPsMutex.RUnlock()
+	return envoyconf.AddIPRestriction(routes, getIPRestriction(swagger), organizationBlockedIPs)
+}
+
+// getIPRestriction returns the IP restriction of the API definition, merged with the IP restrictions configured
+// for the basepath of the API.
+func getIPRestriction(swagger *model.MgwSwagger) *model.IPRestriction {
+	conf, _ := config.ReadConfigs()
+	restriction := swagger.GetIPRestriction()
+	for _, configured := range conf.Envoy.IPRestrictions {
+		if configured.Context != swagger.GetXWso2Basepath() {
+			continue
+		}
+		merged := &model.IPRestriction{}
+		if restriction != nil {
+			merged.Allow = append(merged.Allow, restriction.Allow...)
+			merged.Deny = append(merged.Deny, restriction.Deny...)
+		}
+		merged.Allow = append(merged.Allow, configured.Allow...)
+		merged.Deny = append(merged.Deny, configured.Deny...)
+		restriction = merged
+	}
+	return restriction
+}
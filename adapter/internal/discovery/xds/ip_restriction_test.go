@@ -0,0 +1,45 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package xds
+
+import (
+	"testing"
+
+	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
+	"github.com/wso2/product-microgateway/adapter/pkg/discovery/api/wso2/discovery/throttle"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestGetIPRestrictedRoutes(t *testing.T) {
+	defer updateBlockedIPs(nil)
+	routes := []*routev3.Route{{Name: "/pets/1.0.0/pets"}}
+	var swagger model.MgwSwagger
+	assert.Equal(t, routes, getIPRestrictedRoutes("carbon.super", &swagger, routes),
+		"Routes of an API without restrictions should not be changed")
+
+	blockedIPs := []*throttle.IPCondition{{Id: 1, Type: "IP", FixedIp: "10.1.2.3", TenantDomain: "carbon.super"}}
+	assert.True(t, updateBlockedIPs(blockedIPs))
+	assert.False(t, updateBlockedIPs([]*throttle.IPCondition{proto.Clone(blockedIPs[0]).(*throttle.IPCondition)}),
+		"Unchanged IP blocking conditions should not update the routes")
+	restrictedRoutes := getIPRestrictedRoutes("carbon.super", &swagger, routes)
+	assert.Contains(t, restrictedRoutes[0].TypedPerFilterConfig, "envoy.filters.http.rbac.ip_restriction")
+	assert.Equal(t, routes, getIPRestrictedRoutes("org1", &swagger, routes),
+		"IP blocking conditions of other organizations should not be applied")
+}
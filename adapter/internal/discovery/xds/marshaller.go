@@ -4,13 +4,17 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"strings"
+	"sync"
 
 	"github.com/wso2/product-microgateway/adapter/config"
 	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/internal/tenant"
 	"github.com/wso2/product-microgateway/adapter/pkg/discovery/api/wso2/discovery/config/enforcer"
 	"github.com/wso2/product-microgateway/adapter/pkg/discovery/api/wso2/discovery/keymgt"
 	"github.com/wso2/product-microgateway/adapter/pkg/discovery/api/wso2/discovery/subscription"
 	"github.com/wso2/product-microgateway/adapter/pkg/eventhub/types"
+	"google.golang.org/protobuf/proto"
 )
 
 var (
@@ -22,10 +26,14 @@ var (
 	ApplicationMap map[string]*subscription.Application
 	// ApplicationKeyMappingMap contains the application key mappings recieved from API Manager Control Plane
 	ApplicationKeyMappingMap map[string]*subscription.ApplicationKeyMapping
+	// keyMappingMutex guards the ApplicationKeyMappingMap, which is compacted in the background
+	keyMappingMutex sync.Mutex
 	// ApplicationPolicyMap contains the application policies recieved from API Manager Control Plane
 	ApplicationPolicyMap map[int32]*subscription.ApplicationPolicy
 	// SubscriptionPolicyMap contains the subscription policies recieved from API Manager Control Plane
 	SubscriptionPolicyMap map[int32]*subscription.SubscriptionPolicy
+	// ScopeMap contains the scopes and their role bindings recieved from API Manager Control Plane
+	ScopeMap map[string]*subscription.Scope
 )
 
 // EventType is a enum to distinguish Create, Update and Delete Events
@@ -40,7 +48,11 @@ const (
 	DeleteEvent
 )
 
-const blockedStatus string = "BLOCKED"
+const (
+	blockedStatus string = "BLOCKED"
+	// apiKeyIssuerName is the name of the token service, which validates the API keys in the enforcer.
+	apiKeyIssuerName string = "APIM APIkey"
+)
 
 // MarshalConfig will marshal a Config struct - read from the config toml - to
 // enfocer's CDS resource representation.
@@ -48,7 +60,11 @@ func MarshalConfig(config *config.Config) *enforcer.Config {
 	issuers := []*enforcer.Issuer{}
 	urlGroups := []*enforcer.TMURLGroup{}
 
+	apiKeyConfig := config.Enforcer.Security.APIKey
 	for _, issuer := range config.Enforcer.Security.TokenService {
+		if apiKeyConfig.Enabled && issuer.Name == apiKeyIssuerName {
+			continue
+		}
 		claimMaps := []*enforcer.ClaimMapping{}
 		for _, claimMap := range issuer.ClaimMapping {
 			claim := &enforcer.ClaimMapping{
@@ -69,6 +85,16 @@ func MarshalConfig(config *config.Config) *enforcer.Config {
 		}
 		issuers = append(issuers, jwtConfig)
 	}
+	if apiKeyConfig.Enabled {
+		issuers = append(issuers, &enforcer.Issuer{
+			Name:                 apiKeyIssuerName,
+			Issuer:               apiKeyConfig.Issuer,
+			CertificateAlias:     apiKeyConfig.CertificateAlias,
+			CertificateFilePath:  apiKeyConfig.CertificateFilePath,
+			ValidateSubscription: apiKeyConfig.ValidateSubscription,
+			ClaimMapping:         []*enforcer.ClaimMapping{},
+		})
+	}
 
 	jwtUsers := []*enforcer.JWTUser{}
 	for _, user := range config.Enforcer.JwtIssuer.JwtUser {
@@ -130,7 +156,7 @@ func MarshalConfig(config *config.Config) *enforcer.Config {
 	analytics := &enforcer.Analytics{
 		Enabled:          config.Analytics.Enabled,
 		Type:             config.Analytics.Type,
-		ConfigProperties: config.Analytics.Enforcer.ConfigProperties,
+		ConfigProperties: getAnalyticsConfigProperties(config),
 		Service: &enforcer.Service{
 			Port:           config.Analytics.Enforcer.LogReceiver.Port,
 			MaxHeaderLimit: config.Analytics.Enforcer.LogReceiver.MaxHeaderLimit,
@@ -221,6 +247,7 @@ func MarshalConfig(config *config.Config) *enforcer.Config {
 			EnableJwtClaimConditions:           config.Enforcer.Throttling.EnableJwtClaimConditions,
 			JmsConnectionInitialContextFactory: config.Enforcer.Throttling.JmsConnectionInitialContextFactory,
 			JmsConnectionProviderUrl:           config.Enforcer.Throttling.JmsConnectionProviderURL,
+			EnableConcurrencyLimits:            config.Enforcer.Throttling.ConcurrencyLimits.Enabled,
 			Publisher: &enforcer.BinaryPublisher{
 				Username: config.Enforcer.Throttling.Publisher.Username,
 				Password: config.Enforcer.Throttling.Publisher.Password,
@@ -262,29 +289,62 @@ func MarshalConfig(config *config.Config) *enforcer.Config {
 }
 
 // marshalSubscriptionMapToList converts the data into SubscriptionList proto type
+// getAnalyticsConfigProperties returns the properties of the analytics publisher, including the publish interval
+// when it is configured.
+func getAnalyticsConfigProperties(conf *config.Config) map[string]string {
+	properties := make(map[string]string, len(conf.Analytics.Enforcer.ConfigProperties)+1)
+	for key, value := range conf.Analytics.Enforcer.ConfigProperties {
+		properties[key] = value
+	}
+	if conf.Analytics.Enforcer.PublishIntervalInSeconds > 0 {
+		properties[config.AnalyticsPublishIntervalProperty] =
+			strconv.FormatUint(uint64(conf.Analytics.Enforcer.PublishIntervalInSeconds), 10)
+	}
+	return properties
+}
+
 func marshalSubscriptionMapToList(subscriptionMap map[int32]*subscription.Subscription) *subscription.SubscriptionList {
 	subscriptions := []*subscription.Subscription{}
 	for _, sub := range subscriptionMap {
 		subscriptions = append(subscriptions, sub)
 	}
+	for _, sub := range subscriptionStore.readAllSpilled() {
+		subscriptions = append(subscriptions, sub.(*subscription.Subscription))
+	}
 
 	return &subscription.SubscriptionList{
 		List: subscriptions,
 	}
 }
 
-// marshalApplicationMapToList converts the data into ApplicationList proto type
+// marshalApplicationMapToList converts the data into ApplicationList proto type, along with the applications evicted
+// to the spill directory
 func marshalApplicationMapToList(appMap map[string]*subscription.Application) *subscription.ApplicationList {
 	applications := []*subscription.Application{}
 	for _, app := range appMap {
 		applications = append(applications, app)
 	}
+	for _, app := range applicationStore.readAllSpilled() {
+		applications = append(applications, app.(*subscription.Application))
+	}
 
 	return &subscription.ApplicationList{
 		List: applications,
 	}
 }
 
+// marshalScopeMapToList converts the data into ScopeList proto type
+func marshalScopeMapToList(scopeMap map[string]*subscription.Scope) *subscription.ScopeList {
+	scopes := []*subscription.Scope{}
+	for _, scope := range scopeMap {
+		scopes = append(scopes, scope)
+	}
+
+	return &subscription.ScopeList{
+		List: scopes,
+	}
+}
+
 // marshalAPIListMapToList converts the data into APIList proto type
 func marshalAPIListMapToList(apiMap map[string]*subscription.APIs) *subscription.APIList {
 	apis := []*subscription.APIs{}
@@ -322,7 +382,8 @@ func marshalSubscriptionPolicyMapToList(subPolicyMap map[int32]*subscription.Sub
 	}
 }
 
-// marshalKeyMappingMapToList converts the data into ApplicationKeyMappingList proto type
+// marshalKeyMappingMapToList converts the data into ApplicationKeyMappingList proto type, along with the key mappings
+// evicted to the spill directory
 func marshalKeyMappingMapToList(keyMappingMap map[string]*subscription.ApplicationKeyMapping) *subscription.ApplicationKeyMappingList {
 	applicationKeyMappings := []*subscription.ApplicationKeyMapping{}
 
@@ -330,6 +391,9 @@ func marshalKeyMappingMapToList(keyMappingMap map[string]*subscription.Applicati
 		// TODO: (VirajSalaka) tenant domain check missing
 		applicationKeyMappings = append(applicationKeyMappings, keyMapping)
 	}
+	for _, keyMapping := range keyMappingStore.readAllSpilled() {
+		applicationKeyMappings = append(applicationKeyMappings, keyMapping.(*subscription.ApplicationKeyMapping))
+	}
 
 	return &subscription.ApplicationKeyMappingList{
 		List: applicationKeyMappings,
@@ -358,44 +422,98 @@ func MarshalKeyManager(keyManager *types.KeyManager) *keymgt.KeyManagerConfig {
 // multiple applications are pulled at once. And then it returns the ApplicationList.
 func MarshalMultipleApplications(appList *types.ApplicationList) *subscription.ApplicationList {
 	resourceMap := make(map[string]*subscription.Application)
+	sharedResourceMap := make(map[string]proto.Message)
+	applicationStore.reset()
 	for _, application := range appList.List {
 		applicationSub := marshalApplication(&application)
-		resourceMap[application.UUID] = applicationSub
+		admitApplication(resourceMap, application.UUID, applicationSub)
+		sharedResourceMap[application.UUID] = applicationSub
 	}
+	subscriptionDataMutex.Lock()
 	ApplicationMap = resourceMap
-	return marshalApplicationMapToList(ApplicationMap)
+	applicationList := marshalApplicationMapToList(ApplicationMap)
+	subscriptionDataMutex.Unlock()
+	replaceSharedResources(sharedApplications, sharedResourceMap)
+	return applicationList
 }
 
 // MarshalApplicationEventAndReturnList handles the Application Event corresponding to the event received
 // from message broker. And then it returns the ApplicationList.
 func MarshalApplicationEventAndReturnList(application *types.Application,
 	eventType EventType) *subscription.ApplicationList {
+	var applicationSub *subscription.Application
+	subscriptionDataMutex.Lock()
 	if eventType == DeleteEvent {
 		delete(ApplicationMap, application.UUID)
+		applicationStore.remove(application.UUID)
 		logger.LoggerXds.Infof("Application %s is deleted.", application.UUID)
 	} else {
-		applicationSub := marshalApplication(application)
-		ApplicationMap[application.UUID] = applicationSub
+		applicationSub = marshalApplication(application)
+		admitApplication(ApplicationMap, application.UUID, applicationSub)
 		if eventType == CreateEvent {
 			logger.LoggerXds.Infof("Application %s is added.", application.UUID)
 		} else {
 			logger.LoggerXds.Infof("Application %s is updated.", application.UUID)
 		}
 	}
-	return marshalApplicationMapToList(ApplicationMap)
+	applicationList := marshalApplicationMapToList(ApplicationMap)
+	subscriptionDataMutex.Unlock()
+	putSharedResource(sharedApplications, application.UUID, applicationSub)
+	return applicationList
+}
+
+// MarshalMultipleScopes is used to update the scopeList during the startup where
+// multiple scopes are pulled at once. And then it returns the ScopeList.
+func MarshalMultipleScopes(scopeList *types.ScopeList) *subscription.ScopeList {
+	resourceMap := make(map[string]*subscription.Scope)
+	for _, scope := range scopeList.List {
+		scopeSub := marshalScope(&scope)
+		resourceMap[getScopeReference(scopeSub)] = scopeSub
+	}
+	ScopeMap = resourceMap
+	return marshalScopeMapToList(ScopeMap)
+}
+
+// MarshalScopeEventAndReturnList handles the Scope Event corresponding to the event received
+// from message broker. And then it returns the ScopeList.
+func MarshalScopeEventAndReturnList(scope *types.Scope, eventType EventType) *subscription.ScopeList {
+	if ScopeMap == nil {
+		ScopeMap = make(map[string]*subscription.Scope)
+	}
+	scopeSub := marshalScope(scope)
+	scopeReference := getScopeReference(scopeSub)
+	if eventType == DeleteEvent {
+		delete(ScopeMap, scopeReference)
+		logger.LoggerXds.Infof("Scope %s is deleted.", scopeReference)
+	} else {
+		ScopeMap[scopeReference] = scopeSub
+		if eventType == CreateEvent {
+			logger.LoggerXds.Infof("Scope %s is added.", scopeReference)
+		} else {
+			logger.LoggerXds.Infof("Scope %s is updated.", scopeReference)
+		}
+	}
+	return marshalScopeMapToList(ScopeMap)
 }
 
 // MarshalMultipleApplicationKeyMappings is used to update the application key mappings during the startup where
 // multiple key mappings are pulled at once. And then it returns the ApplicationKeyMappingList.
 func MarshalMultipleApplicationKeyMappings(keymappingList *types.ApplicationKeyMappingList) *subscription.ApplicationKeyMappingList {
 	resourceMap := make(map[string]*subscription.ApplicationKeyMapping)
+	sharedResourceMap := make(map[string]proto.Message)
+	keyMappingStore.reset()
 	for _, keyMapping := range keymappingList.List {
 		applicationKeyMappingReference := GetApplicationKeyMappingReference(&keyMapping)
 		keyMappingSub := marshalKeyMapping(&keyMapping)
-		resourceMap[applicationKeyMappingReference] = keyMappingSub
+		admitKeyMapping(resourceMap, applicationKeyMappingReference, keyMappingSub)
+		sharedResourceMap[applicationKeyMappingReference] = keyMappingSub
 	}
+	keyMappingMutex.Lock()
 	ApplicationKeyMappingMap = resourceMap
-	return marshalKeyMappingMapToList(ApplicationKeyMappingMap)
+	keyMappingList := marshalKeyMappingMapToList(ApplicationKeyMappingMap)
+	keyMappingMutex.Unlock()
+	replaceSharedResources(sharedKeyMappings, sharedResourceMap)
+	return keyMappingList
 }
 
 // MarshalApplicationKeyMappingEventAndReturnList handles the Application Key Mapping Event corresponding to the event received
@@ -403,46 +521,68 @@ func MarshalMultipleApplicationKeyMappings(keymappingList *types.ApplicationKeyM
 func MarshalApplicationKeyMappingEventAndReturnList(keyMapping *types.ApplicationKeyMapping,
 	eventType EventType) *subscription.ApplicationKeyMappingList {
 	applicationKeyMappingReference := GetApplicationKeyMappingReference(keyMapping)
+	var keyMappingSub *subscription.ApplicationKeyMapping
+	keyMappingMutex.Lock()
 	if eventType == DeleteEvent {
 		delete(ApplicationKeyMappingMap, applicationKeyMappingReference)
+		keyMappingStore.remove(applicationKeyMappingReference)
 		logger.LoggerXds.Infof("Application Key Mapping for the applicationKeyMappingReference %s is removed.",
 			applicationKeyMappingReference)
 	} else {
-		keyMappingSub := marshalKeyMapping(keyMapping)
-		ApplicationKeyMappingMap[applicationKeyMappingReference] = keyMappingSub
+		keyMappingSub = marshalKeyMapping(keyMapping)
+		admitKeyMapping(ApplicationKeyMappingMap, applicationKeyMappingReference, keyMappingSub)
 		logger.LoggerXds.Infof("Application Key Mapping for the applicationKeyMappingReference %s is added.",
 			applicationKeyMappingReference)
 	}
-	return marshalKeyMappingMapToList(ApplicationKeyMappingMap)
+	keyMappingList := marshalKeyMappingMapToList(ApplicationKeyMappingMap)
+	keyMappingMutex.Unlock()
+	putSharedResource(sharedKeyMappings, applicationKeyMappingReference, keyMappingSub)
+	return keyMappingList
 }
 
 // MarshalMultipleSubscriptions is used to update the subscriptions during the startup where
 // multiple subscriptions are pulled at once. And then it returns the SubscriptionList.
 func MarshalMultipleSubscriptions(subscriptionsList *types.SubscriptionList) *subscription.SubscriptionList {
 	resourceMap := make(map[int32]*subscription.Subscription)
+	sharedResourceMap := make(map[string]proto.Message)
+	subscriptionStore.reset()
 	for _, sb := range subscriptionsList.List {
-		resourceMap[sb.SubscriptionID] = marshalSubscription(&sb)
+		subscriptionSub := marshalSubscription(&sb)
+		admitSubscription(resourceMap, sb.SubscriptionID, subscriptionSub)
+		sharedResourceMap[strconv.Itoa(int(sb.SubscriptionID))] = subscriptionSub
 	}
+	subscriptionDataMutex.Lock()
 	SubscriptionMap = resourceMap
-	return marshalSubscriptionMapToList(SubscriptionMap)
+	subscriptionList := marshalSubscriptionMapToList(SubscriptionMap)
+	subscriptionDataMutex.Unlock()
+	replaceSharedResources(sharedSubscriptions, sharedResourceMap)
+	resetWebSubRegistrations(subscriptionsList.List)
+	return subscriptionList
 }
 
 // MarshalSubscriptionEventAndReturnList handles the Subscription Event corresponding to the event received
 // from message broker. And then it returns the SubscriptionList.
 func MarshalSubscriptionEventAndReturnList(sub *types.Subscription, eventType EventType) *subscription.SubscriptionList {
+	var subscriptionSub *subscription.Subscription
+	subscriptionDataMutex.Lock()
 	if eventType == DeleteEvent {
 		delete(SubscriptionMap, sub.SubscriptionID)
+		subscriptionStore.remove(strconv.Itoa(int(sub.SubscriptionID)))
 		logger.LoggerXds.Infof("Subscription for %s:%s is deleted.", sub.APIUUID, sub.ApplicationUUID)
 	} else {
-		subscriptionSub := marshalSubscription(sub)
-		SubscriptionMap[sub.SubscriptionID] = subscriptionSub
+		subscriptionSub = marshalSubscription(sub)
+		admitSubscription(SubscriptionMap, sub.SubscriptionID, subscriptionSub)
 		if eventType == UpdateEvent {
 			logger.LoggerXds.Infof("Subscription for %s:%s is updated.", sub.APIUUID, sub.ApplicationUUID)
 		} else {
 			logger.LoggerXds.Infof("Subscription for %s:%s is added.", sub.APIUUID, sub.ApplicationUUID)
 		}
 	}
-	return marshalSubscriptionMapToList(SubscriptionMap)
+	subscriptionList := marshalSubscriptionMapToList(SubscriptionMap)
+	subscriptionDataMutex.Unlock()
+	putSharedResource(sharedSubscriptions, strconv.Itoa(int(sub.SubscriptionID)), subscriptionSub)
+	updateWebSubRegistration(sub, eventType)
+	return subscriptionList
 }
 
 // MarshalMultipleApplicationPolicies is used to update the applicationPolicies during the startup where
@@ -455,6 +595,7 @@ func MarshalMultipleApplicationPolicies(policies *types.ApplicationPolicyList) *
 		logger.LoggerXds.Infof("appPolicy Entry is added : %v", appPolicy)
 	}
 	ApplicationPolicyMap = resourceMap
+	setApplicationPolicyLimits(policies.List)
 	return marshalApplicationPolicyMapToList(ApplicationPolicyMap)
 }
 
@@ -473,6 +614,7 @@ func MarshalApplicationPolicyEventAndReturnList(policy *types.ApplicationPolicy,
 			logger.LoggerInternalMsg.Infof("Application Policy: %s is added.", appPolicy.Name)
 		}
 	}
+	updatePolicyLimit(applicationPolicyLimits, policy.Name, policy.DefaultLimit, eventType)
 	return marshalApplicationPolicyMapToList(ApplicationPolicyMap)
 }
 
@@ -484,6 +626,7 @@ func MarshalMultipleSubscriptionPolicies(policies *types.SubscriptionPolicyList)
 		resourceMap[policy.ID] = marshalSubscriptionPolicy(&policy)
 	}
 	SubscriptionPolicyMap = resourceMap
+	setSubscriptionPolicyLimits(policies.List)
 	return marshalSubscriptionPolicyMapToList(SubscriptionPolicyMap)
 }
 
@@ -502,6 +645,7 @@ func MarshalSubscriptionPolicyEventAndReturnList(policy *types.SubscriptionPolic
 			logger.LoggerInternalMsg.Infof("Subscription Policy: %s is added.", subPolicy.Name)
 		}
 	}
+	updatePolicyLimit(subscriptionPolicyLimits, policy.Name, policy.DefaultLimit, eventType)
 	return marshalSubscriptionPolicyMapToList(SubscriptionPolicyMap)
 }
 
@@ -585,6 +729,7 @@ func marshalSubscription(subscriptionInternal *types.Subscription) *subscription
 	if sub.TenantDomain == "" {
 		sub.TenantDomain = config.GetControlPlaneConnectedTenantDomain()
 	}
+	sub.TenantId = tenant.ResolveTenantID(sub.TenantDomain, sub.TenantId)
 	return sub
 }
 
@@ -605,11 +750,12 @@ func marshalApplication(appInternal *types.Application) *subscription.Applicatio
 	if app.TenantDomain == "" {
 		app.TenantDomain = config.GetControlPlaneConnectedTenantDomain()
 	}
+	app.TenantId = tenant.ResolveTenantID(app.TenantDomain, app.TenantId)
 	return app
 }
 
 func marshalKeyMapping(keyMappingInternal *types.ApplicationKeyMapping) *subscription.ApplicationKeyMapping {
-	return &subscription.ApplicationKeyMapping{
+	keyMapping := &subscription.ApplicationKeyMapping{
 		ConsumerKey:     keyMappingInternal.ConsumerKey,
 		KeyType:         keyMappingInternal.KeyType,
 		KeyManager:      keyMappingInternal.KeyManager,
@@ -619,6 +765,11 @@ func marshalKeyMapping(keyMappingInternal *types.ApplicationKeyMapping) *subscri
 		TenantDomain:    keyMappingInternal.TenantDomain,
 		Timestamp:       keyMappingInternal.TimeStamp,
 	}
+	if keyMapping.TenantDomain == "" {
+		keyMapping.TenantDomain = config.GetControlPlaneConnectedTenantDomain()
+	}
+	keyMapping.TenantId = tenant.ResolveTenantID(keyMapping.TenantDomain, keyMapping.TenantId)
+	return keyMapping
 }
 
 func marshalAPIMetadata(api *types.API) *subscription.APIs {
@@ -638,15 +789,16 @@ func marshalAPIMetadata(api *types.API) *subscription.APIs {
 
 func marshalApplicationPolicy(policy *types.ApplicationPolicy) *subscription.ApplicationPolicy {
 	return &subscription.ApplicationPolicy{
-		Id:        policy.ID,
-		TenantId:  policy.TenantID,
+		Id: policy.ID,
+		// application policies do not have the tenant domain, and belong to the tenant of the control plane user
+		TenantId:  tenant.ResolveTenantID(config.GetControlPlaneConnectedTenantDomain(), policy.TenantID),
 		Name:      policy.Name,
 		QuotaType: policy.QuotaType,
 	}
 }
 
 func marshalSubscriptionPolicy(policy *types.SubscriptionPolicy) *subscription.SubscriptionPolicy {
-	return &subscription.SubscriptionPolicy{
+	subscriptionPolicy := &subscription.SubscriptionPolicy{
 		Id:                   policy.ID,
 		Name:                 policy.Name,
 		QuotaType:            policy.QuotaType,
@@ -659,6 +811,39 @@ func marshalSubscriptionPolicy(policy *types.SubscriptionPolicy) *subscription.S
 		TenantDomain:         policy.TenantDomain,
 		Timestamp:            policy.TimeStamp,
 	}
+	if subscriptionPolicy.TenantDomain == "" {
+		subscriptionPolicy.TenantDomain = config.GetControlPlaneConnectedTenantDomain()
+	}
+	subscriptionPolicy.TenantId = tenant.ResolveTenantID(subscriptionPolicy.TenantDomain, subscriptionPolicy.TenantId)
+	return subscriptionPolicy
+}
+
+func marshalScope(scopeInternal *types.Scope) *subscription.Scope {
+	roles := []string{}
+	for _, role := range strings.Split(scopeInternal.Roles, ",") {
+		if role = strings.TrimSpace(role); role != "" {
+			roles = append(roles, role)
+		}
+	}
+	scope := &subscription.Scope{
+		Name:         scopeInternal.Name,
+		DisplayName:  scopeInternal.DisplayName,
+		Description:  scopeInternal.Description,
+		Roles:        roles,
+		TenantId:     scopeInternal.TenantID,
+		TenantDomain: scopeInternal.TenantDomain,
+	}
+	if scope.TenantDomain == "" {
+		scope.TenantDomain = config.GetControlPlaneConnectedTenantDomain()
+	}
+	scope.TenantId = tenant.ResolveTenantID(scope.TenantDomain, scope.TenantId)
+	return scope
+}
+
+// getScopeReference returns unique reference for each scope, as the scope names are unique within a tenant.
+// It is the combination of tenantDomain:name
+func getScopeReference(scope *subscription.Scope) string {
+	return scope.TenantDomain + ":" + scope.Name
 }
 
 // GetApplicationKeyMappingReference returns unique reference for each key Mapping event.
@@ -0,0 +1,55 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package xds
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-microgateway/adapter/config"
+	"github.com/wso2/product-microgateway/adapter/pkg/discovery/api/wso2/discovery/config/enforcer"
+)
+
+func TestMarshalAPIKeyConfig(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	testConf := *conf
+	getAPIKeyIssuers := func() []*enforcer.Issuer {
+		var apiKeyIssuers []*enforcer.Issuer
+		for _, issuer := range MarshalConfig(&testConf).Security.TokenService {
+			if issuer.Name == apiKeyIssuerName {
+				apiKeyIssuers = append(apiKeyIssuers, issuer)
+			}
+		}
+		return apiKeyIssuers
+	}
+
+	testConf.Enforcer.Security.APIKey.Enabled = false
+	tokenServiceCount := len(testConf.Enforcer.Security.TokenService)
+	assert.Len(t, MarshalConfig(&testConf).Security.TokenService, tokenServiceCount,
+		"Token services are not retained")
+
+	testConf.Enforcer.Security.APIKey.Enabled = true
+	testConf.Enforcer.Security.APIKey.Issuer = "https://apim:9443/publisher"
+	testConf.Enforcer.Security.APIKey.CertificateAlias = "apikey_cert"
+	testConf.Enforcer.Security.APIKey.ValidateSubscription = false
+	apiKeyIssuers := getAPIKeyIssuers()
+	assert.Len(t, apiKeyIssuers, 1, "API key token service is not replaced")
+	assert.Equal(t, "https://apim:9443/publisher", apiKeyIssuers[0].Issuer)
+	assert.Equal(t, "apikey_cert", apiKeyIssuers[0].CertificateAlias)
+	assert.False(t, apiKeyIssuers[0].ValidateSubscription)
+}
@@ -0,0 +1,587 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package xds
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wso2/product-microgateway/adapter/config"
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/envoyconf"
+	"github.com/wso2/product-microgateway/adapter/pkg/discovery/api/wso2/discovery/subscription"
+	"github.com/wso2/product-microgateway/adapter/pkg/eventhub/types"
+	"github.com/wso2/product-microgateway/adapter/pkg/logging"
+)
+
+const requestCountQuotaType string = "requestCount"
+
+// The maps are updated in place, hence those can be passed to the update functions.
+var (
+	// policy name -> request count limit
+	applicationPolicyLimits  = make(map[string]*types.RequestCountLimit)
+	subscriptionPolicyLimits = make(map[string]*types.RequestCountLimit)
+	policyLimitsMutex        sync.RWMutex
+)
+
+// UpdateRateLimits updates the router with the request count limits of the application and subscription
+// policies, if the local rate limits are enabled, and the config of the rate limit service, if the global rate
+// limits are enabled.
+func UpdateRateLimits() {
+	conf, _ := config.ReadConfigs()
+	if conf.Envoy.GlobalRateLimit.Enabled {
+		updateRateLimitServiceConfig(conf.Envoy.GlobalRateLimit.Domain, conf.Envoy.GlobalRateLimit.ConfigFilePath)
+	}
+	if conf.Envoy.LocalRateLimit.Enabled || conf.Envoy.GlobalRateLimit.Enabled {
+		// the source exemptions are applied to the routes of both
+		UpdateXdsCacheForLabels(nil)
+	}
+}
+
+// UpdateRateLimitsOfAPIs updates the router with the request count limits of the policies, once the limits of the
+// given APIs are changed (ex: a subscription is added). Only the labels the APIs are deployed to are updated for the
+// local rate limits, while only the config of the rate limit service is updated for the global rate limits.
+func UpdateRateLimitsOfAPIs(apiUUIDs []string) {
+	conf, _ := config.ReadConfigs()
+	if conf.Envoy.GlobalRateLimit.Enabled {
+		updateRateLimitServiceConfig(conf.Envoy.GlobalRateLimit.Domain, conf.Envoy.GlobalRateLimit.ConfigFilePath)
+	}
+	if !conf.Envoy.LocalRateLimit.Enabled || len(apiUUIDs) == 0 {
+		return
+	}
+	mutexForInternalMapUpdate.Lock()
+	defer mutexForInternalMapUpdate.Unlock()
+	var labels []string
+	for organizationID, mgwSwaggers := range orgIDAPIMgwSwaggerMap {
+		for apiIdentifier, mgwSwagger := range mgwSwaggers {
+			if !arrayContains(apiUUIDs, mgwSwagger.GetID()) {
+				continue
+			}
+			for _, label := range orgIDOpenAPIEnvoyMap[organizationID][apiIdentifier] {
+				if !arrayContains(labels, label) {
+					labels = append(labels, label)
+				}
+			}
+		}
+	}
+	if len(labels) > 0 {
+		updateXdsCacheOnAPIAdd(nil, labels)
+	}
+}
+
+// GetAPIsSubscribedByApplication returns the UUIDs of the APIs the application is subscribed to.
+func GetAPIsSubscribedByApplication(applicationUUID string) []string {
+	return getSubscribedAPIs(func(sub *subscription.Subscription) bool {
+		return sub.AppUUID == applicationUUID
+	})
+}
+
+// GetAPIsOfApplicationPolicy returns the UUIDs of the APIs subscribed by the applications of the policy.
+func GetAPIsOfApplicationPolicy(policyName string) []string {
+	subscriptionDataMutex.Lock()
+	applications := make(map[string]bool)
+	for uuid, application := range ApplicationMap {
+		if application.Policy == policyName {
+			applications[uuid] = true
+		}
+	}
+	subscriptionDataMutex.Unlock()
+	return getSubscribedAPIs(func(sub *subscription.Subscription) bool {
+		return applications[sub.AppUUID]
+	})
+}
+
+// GetAPIsOfSubscriptionPolicy returns the UUIDs of the APIs subscribed with the policy.
+func GetAPIsOfSubscriptionPolicy(policyName string) []string {
+	return getSubscribedAPIs(func(sub *subscription.Subscription) bool {
+		return sub.PolicyId == policyName
+	})
+}
+
+func getSubscribedAPIs(isIncluded func(sub *subscription.Subscription) bool) []string {
+	subscriptionDataMutex.Lock()
+	defer subscriptionDataMutex.Unlock()
+	var apiUUIDs []string
+	for _, sub := range SubscriptionMap {
+		if isIncluded(sub) && !arrayContains(apiUUIDs, sub.ApiUUID) {
+			apiUUIDs = append(apiUUIDs, sub.ApiUUID)
+		}
+	}
+	return apiUUIDs
+}
+
+// updateRateLimitServiceConfig writes the config of the rate limit service to the file. The file is replaced
+// atomically, hence the rate limit service does not load a partially written config.
+func updateRateLimitServiceConfig(domain, configFilePath string) {
+	if configFilePath == "" {
+		logger.LoggerXds.Warn("Config file path of the rate limit service is not configured. Hence the global " +
+			"rate limits are not updated.")
+		return
+	}
+	serviceConfig, err := envoyconf.GenerateRateLimitServiceConfig(domain,
+		getGlobalRateLimitDescriptors(GetRateLimitExemptions()))
+	if err == nil {
+		err = writeFileAtomically(configFilePath, serviceConfig)
+	}
+	if err != nil {
+		logger.LoggerXds.ErrorC(logging.ErrorDetails{
+			Message:   fmt.Sprintf("Error while updating the config of the rate limit service. %v", err),
+			Severity:  logging.MAJOR,
+			ErrorCode: 1419,
+		})
+		return
+	}
+	logger.LoggerXds.Debugf("Config of the rate limit service is updated at %s", configFilePath)
+}
+
+func writeFileAtomically(filePath string, content []byte) error {
+	tempFile, err := ioutil.TempFile(filepath.Dir(filePath), "."+filepath.Base(filePath)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tempFile.Name())
+	if _, err = tempFile.Write(content); err != nil {
+		tempFile.Close()
+		return err
+	}
+	if err = tempFile.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tempFile.Name(), filePath)
+}
+
+// setApplicationPolicyLimits replaces the request count limits of the application policies.
+func setApplicationPolicyLimits(policies []types.ApplicationPolicy) {
+	defaultLimits := make(map[string]*types.ThrottleLimit)
+	for _, policy := range policies {
+		defaultLimits[policy.Name] = policy.DefaultLimit
+	}
+	resetPolicyLimits(applicationPolicyLimits, defaultLimits)
+}
+
+// setSubscriptionPolicyLimits replaces the request count limits of the subscription policies.
+func setSubscriptionPolicyLimits(policies []types.SubscriptionPolicy) {
+	defaultLimits := make(map[string]*types.ThrottleLimit)
+	for _, policy := range policies {
+		defaultLimits[policy.Name] = policy.DefaultLimit
+	}
+	resetPolicyLimits(subscriptionPolicyLimits, defaultLimits)
+}
+
+func resetPolicyLimits(limits map[string]*types.RequestCountLimit, defaultLimits map[string]*types.ThrottleLimit) {
+	policyLimitsMutex.Lock()
+	defer policyLimitsMutex.Unlock()
+	for policyName := range limits {
+		delete(limits, policyName)
+	}
+	for policyName, defaultLimit := range defaultLimits {
+		if limit := getRequestCountLimit(defaultLimit); limit != nil {
+			limits[policyName] = limit
+		}
+	}
+}
+
+// updatePolicyLimit updates the request count limit of a policy for a policy event. The previous limit is
+// retained if the event does not contain the limit of the policy.
+func updatePolicyLimit(limits map[string]*types.RequestCountLimit, policyName string,
+	defaultLimit *types.ThrottleLimit, eventType EventType) {
+	policyLimitsMutex.Lock()
+	defer policyLimitsMutex.Unlock()
+	if eventType == DeleteEvent {
+		delete(limits, policyName)
+		return
+	}
+	if defaultLimit == nil {
+		logger.LoggerXds.Debugf("Request count limit of the policy %s is not updated, as the event does not "+
+			"contain the limit.", policyName)
+		return
+	}
+	if limit := getRequestCountLimit(defaultLimit); limit != nil {
+		limits[policyName] = limit
+	} else {
+		// the quota type is changed from the request count
+		delete(limits, policyName)
+	}
+}
+
+// getLocalRateLimitDescriptors returns the request count limits of the applications subscribed to the APIs of
+// each vhost. vhostToAPIsMap maps the vhost to the UUIDs of the APIs deployed in the vhost. The exempted
+// applications are not limited.
+func getLocalRateLimitDescriptors(vhostToAPIsMap map[string][]string,
+	exemptions []RateLimitExemption) map[string][]envoyconf.RateLimitDescriptor {
+	exemptedApplications := getExemptedApplications(exemptions)
+	policyLimitsMutex.RLock()
+	defer policyLimitsMutex.RUnlock()
+
+	vhostToDescriptorsMap := make(map[string][]envoyconf.RateLimitDescriptor)
+	for vhost, apiUUIDs := range vhostToAPIsMap {
+		vhostToDescriptorsMap[vhost] = getRateLimitDescriptors(func(apiUUID string) bool {
+			return arrayContains(apiUUIDs, apiUUID)
+		}, exemptedApplications)
+	}
+	return vhostToDescriptorsMap
+}
+
+// getGlobalRateLimitDescriptors returns the request count limits of the applications subscribed to any API. The
+// exempted applications are not limited.
+func getGlobalRateLimitDescriptors(exemptions []RateLimitExemption) []envoyconf.RateLimitDescriptor {
+	exemptedApplications := getExemptedApplications(exemptions)
+	policyLimitsMutex.RLock()
+	defer policyLimitsMutex.RUnlock()
+	return getRateLimitDescriptors(func(string) bool { return true }, exemptedApplications)
+}
+
+// getRateLimitDescriptors returns the request count limits of the applications subscribed to the included APIs.
+// The policy limits should be locked by the caller.
+func getRateLimitDescriptors(isAPIIncluded func(apiUUID string) bool,
+	exemptedApplications map[string]bool) []envoyconf.RateLimitDescriptor {
+	var descriptors []envoyconf.RateLimitDescriptor
+	limitedApplications := make(map[string]bool)
+	subscriptionDataMutex.Lock()
+	defer subscriptionDataMutex.Unlock()
+	for _, sub := range SubscriptionMap {
+		if !isAPIIncluded(sub.ApiUUID) || exemptedApplications[sub.AppUUID] {
+			continue
+		}
+		if limit, ok := subscriptionPolicyLimits[sub.PolicyId]; ok {
+			descriptors = append(descriptors, newRateLimitDescriptor(sub.AppUUID, sub.ApiUUID, limit))
+		}
+		if limitedApplications[sub.AppUUID] {
+			continue
+		}
+		limitedApplications[sub.AppUUID] = true
+		if application, ok := ApplicationMap[sub.AppUUID]; ok {
+			if limit, ok := applicationPolicyLimits[application.Policy]; ok {
+				descriptors = append(descriptors, newRateLimitDescriptor(sub.AppUUID, "", limit))
+			}
+		}
+	}
+	return descriptors
+}
+
+func newRateLimitDescriptor(applicationUUID, apiUUID string,
+	limit *types.RequestCountLimit) envoyconf.RateLimitDescriptor {
+	return envoyconf.RateLimitDescriptor{
+		ApplicationUUID: applicationUUID,
+		APIUUID:         apiUUID,
+		RequestCount:    uint32(limit.RequestCount),
+		FillInterval:    getTimeUnitDuration(limit.TimeUnit) * time.Duration(limit.UnitTime),
+	}
+}
+
+// getRequestCountLimit returns the request count limit of the policy, or nil if the policy is not a valid request
+// count policy. The limits of the other quota types (i.e. bandwidth) are enforced only by the enforcer.
+func getRequestCountLimit(defaultLimit *types.ThrottleLimit) *types.RequestCountLimit {
+	if defaultLimit == nil || !strings.EqualFold(defaultLimit.QuotaType, requestCountQuotaType) ||
+		defaultLimit.RequestCount == nil {
+		return nil
+	}
+	limit := defaultLimit.RequestCount
+	if limit.RequestCount <= 0 || limit.UnitTime <= 0 || getTimeUnitDuration(limit.TimeUnit) == 0 {
+		logger.LoggerXds.Warnf("Invalid request count limit %d per %d %s is ignored.", limit.RequestCount,
+			limit.UnitTime, limit.TimeUnit)
+		return nil
+	}
+	return limit
+}
+
+// getTimeUnitDuration returns the duration of the time unit of a policy, or 0 if the time unit is unknown.
+func getTimeUnitDuration(timeUnit string) time.Duration {
+	switch strings.TrimSuffix(strings.ToLower(timeUnit), "s") {
+	case "sec", "second":
+		return time.Second
+	case "min", "minute":
+		return time.Minute
+	case "hour":
+		return time.Hour
+	case "day":
+		return 24 * time.Hour
+	case "week":
+		return 7 * 24 * time.Hour
+	case "month":
+		return 30 * 24 * time.Hour
+	case "year":
+		return 365 * 24 * time.Hour
+	}
+	return 0
+}
+
+// Types of the rate limit exemptions
+const (
+	// ExemptionTypeConsumerKey exempts the application of the consumer key
+	ExemptionTypeConsumerKey string = "consumerKey"
+	// ExemptionTypeApplicationID exempts the application of the UUID
+	ExemptionTypeApplicationID string = "applicationId"
+	// ExemptionTypeApplicationAttribute exempts the applications having the custom attribute (name=value)
+	ExemptionTypeApplicationAttribute string = "applicationAttribute"
+	// ExemptionTypeSourceCIDR exempts the requests from the source addresses within the CIDR
+	ExemptionTypeSourceCIDR string = "sourceCIDR"
+)
+
+// RateLimitExemption represents a trusted client, which is not rate limited by the router.
+type RateLimitExemption struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+var (
+	rateLimitExemptions     []RateLimitExemption
+	rateLimitExemptionsOnce sync.Once
+	rateLimitExemptionMutex sync.RWMutex
+)
+
+// ErrRateLimitExemptionsNotPersisted is returned if the exemptions updated via the admin API are not persisted,
+// hence the exemptions are not updated.
+var ErrRateLimitExemptionsNotPersisted = errors.New("rate limit exemptions are not persisted")
+
+// loadRateLimitExemptions loads the exemptions at the first access of the exemptions. The exemptions persisted by
+// the admin API are loaded if any, or the exemptions of the config otherwise.
+func loadRateLimitExemptions() {
+	rateLimitExemptionsOnce.Do(func() {
+		conf, _ := config.ReadConfigs()
+		var loadedExemptions []RateLimitExemption
+		persisted, err := readPersistedRateLimitExemptions(conf.Envoy.LocalRateLimit.ExemptionsFilePath)
+		if err != nil {
+			logger.LoggerXds.ErrorC(logging.ErrorDetails{
+				Message: fmt.Sprintf("Error while reading the persisted rate limit exemptions, hence the exemptions "+
+					"of the config are applied. %v", err),
+				Severity:  logging.MAJOR,
+				ErrorCode: 1430,
+			})
+		}
+		if persisted != nil {
+			loadedExemptions = persisted
+		} else {
+			for _, configExemption := range conf.Envoy.LocalRateLimit.Exemptions {
+				loadedExemptions = append(loadedExemptions,
+					RateLimitExemption{Type: configExemption.Type, Value: configExemption.Value})
+			}
+		}
+		for _, exemption := range loadedExemptions {
+			if err := normalizeRateLimitExemption(&exemption); err != nil {
+				logger.LoggerXds.Warnf("Rate limit exemption %s:%s is ignored. %v", exemption.Type,
+					exemption.Value, err)
+				continue
+			}
+			if !containsRateLimitExemption(rateLimitExemptions, exemption) {
+				rateLimitExemptions = append(rateLimitExemptions, exemption)
+			}
+		}
+	})
+}
+
+// readPersistedRateLimitExemptions reads the exemptions persisted in the file. Nil is returned if the file path is
+// not configured or the exemptions are not persisted yet.
+func readPersistedRateLimitExemptions(filePath string) ([]RateLimitExemption, error) {
+	if filePath == "" {
+		return nil, nil
+	}
+	content, err := ioutil.ReadFile(filePath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	exemptions := []RateLimitExemption{}
+	if err = json.Unmarshal(content, &exemptions); err != nil {
+		return nil, err
+	}
+	return exemptions, nil
+}
+
+// persistRateLimitExemptions writes the exemptions to the file, if the file path is configured.
+func persistRateLimitExemptions(exemptions []RateLimitExemption) error {
+	conf, _ := config.ReadConfigs()
+	filePath := conf.Envoy.LocalRateLimit.ExemptionsFilePath
+	if filePath == "" {
+		return nil
+	}
+	if exemptions == nil {
+		exemptions = []RateLimitExemption{}
+	}
+	content, err := json.Marshal(exemptions)
+	if err == nil {
+		err = writeFileAtomically(filePath, content)
+	}
+	if err != nil {
+		logger.LoggerXds.ErrorC(logging.ErrorDetails{
+			Message:   fmt.Sprintf("Error while persisting the rate limit exemptions at %s. %v", filePath, err),
+			Severity:  logging.MAJOR,
+			ErrorCode: 1431,
+		})
+		return ErrRateLimitExemptionsNotPersisted
+	}
+	return nil
+}
+
+// GetRateLimitExemptions returns the clients, which are not rate limited by the router.
+func GetRateLimitExemptions() []RateLimitExemption {
+	loadRateLimitExemptions()
+	rateLimitExemptionMutex.RLock()
+	defer rateLimitExemptionMutex.RUnlock()
+	return append([]RateLimitExemption{}, rateLimitExemptions...)
+}
+
+// AddRateLimitExemption adds the exemption and returns it in the normalized form, along with whether it is
+// added. The exemption is not added if it already exists.
+func AddRateLimitExemption(exemption RateLimitExemption) (RateLimitExemption, bool, error) {
+	if err := normalizeRateLimitExemption(&exemption); err != nil {
+		return exemption, false, err
+	}
+	loadRateLimitExemptions()
+	rateLimitExemptionMutex.Lock()
+	defer rateLimitExemptionMutex.Unlock()
+	if containsRateLimitExemption(rateLimitExemptions, exemption) {
+		return exemption, false, nil
+	}
+	updatedExemptions := append(append([]RateLimitExemption{}, rateLimitExemptions...), exemption)
+	if err := persistRateLimitExemptions(updatedExemptions); err != nil {
+		return exemption, false, err
+	}
+	rateLimitExemptions = updatedExemptions
+	return exemption, true, nil
+}
+
+// RemoveRateLimitExemption removes the exemption and returns it in the normalized form, along with whether it
+// is removed.
+func RemoveRateLimitExemption(exemption RateLimitExemption) (RateLimitExemption, bool, error) {
+	if err := normalizeRateLimitExemption(&exemption); err != nil {
+		return exemption, false, err
+	}
+	loadRateLimitExemptions()
+	rateLimitExemptionMutex.Lock()
+	defer rateLimitExemptionMutex.Unlock()
+	for i, existing := range rateLimitExemptions {
+		if existing == exemption {
+			updatedExemptions := append(append([]RateLimitExemption{}, rateLimitExemptions[:i]...),
+				rateLimitExemptions[i+1:]...)
+			if err := persistRateLimitExemptions(updatedExemptions); err != nil {
+				return exemption, false, err
+			}
+			rateLimitExemptions = updatedExemptions
+			return exemption, true, nil
+		}
+	}
+	return exemption, false, nil
+}
+
+// normalizeRateLimitExemption validates the exemption. An IP address given as the source CIDR is converted to
+// the CIDR of the single address.
+func normalizeRateLimitExemption(exemption *RateLimitExemption) error {
+	exemption.Value = strings.TrimSpace(exemption.Value)
+	if exemption.Value == "" {
+		return errors.New("value of the exemption is empty")
+	}
+	switch exemption.Type {
+	case ExemptionTypeConsumerKey, ExemptionTypeApplicationID:
+		return nil
+	case ExemptionTypeApplicationAttribute:
+		name, value, found := strings.Cut(exemption.Value, "=")
+		if !found || strings.TrimSpace(name) == "" {
+			return errors.New("value of the exemption should be the name and the value of the attribute (name=value)")
+		}
+		exemption.Value = strings.TrimSpace(name) + "=" + strings.TrimSpace(value)
+		return nil
+	case ExemptionTypeSourceCIDR:
+		if ip := net.ParseIP(exemption.Value); ip != nil {
+			if ip.To4() != nil {
+				exemption.Value = ip.String() + "/32"
+			} else {
+				exemption.Value = ip.String() + "/128"
+			}
+			return nil
+		}
+		_, ipNet, err := net.ParseCIDR(exemption.Value)
+		if err != nil {
+			return fmt.Errorf("invalid source CIDR. %v", err)
+		}
+		exemption.Value = ipNet.String()
+		return nil
+	}
+	return fmt.Errorf("unknown exemption type %q. Supported types are %s, %s, %s and %s", exemption.Type,
+		ExemptionTypeConsumerKey, ExemptionTypeApplicationID, ExemptionTypeApplicationAttribute,
+		ExemptionTypeSourceCIDR)
+}
+
+func containsRateLimitExemption(exemptions []RateLimitExemption, exemption RateLimitExemption) bool {
+	for _, existing := range exemptions {
+		if existing == exemption {
+			return true
+		}
+	}
+	return false
+}
+
+// getExemptedApplications returns the UUIDs of the applications exempted by the application ID, by a consumer key
+// or by a custom attribute of the application.
+func getExemptedApplications(exemptions []RateLimitExemption) map[string]bool {
+	exemptedApplications := make(map[string]bool)
+	exemptedConsumerKeys := make(map[string]bool)
+	exemptedAttributes := make(map[string]bool)
+	for _, exemption := range exemptions {
+		switch exemption.Type {
+		case ExemptionTypeApplicationID:
+			exemptedApplications[exemption.Value] = true
+		case ExemptionTypeConsumerKey:
+			exemptedConsumerKeys[exemption.Value] = true
+		case ExemptionTypeApplicationAttribute:
+			exemptedAttributes[exemption.Value] = true
+		}
+	}
+	if len(exemptedAttributes) > 0 {
+		subscriptionDataMutex.Lock()
+		for applicationUUID, application := range ApplicationMap {
+			for name, value := range application.Attributes {
+				if exemptedAttributes[name+"="+value] {
+					exemptedApplications[applicationUUID] = true
+				}
+			}
+		}
+		subscriptionDataMutex.Unlock()
+	}
+	if len(exemptedConsumerKeys) > 0 {
+		keyMappingMutex.Lock()
+		for _, keyMapping := range ApplicationKeyMappingMap {
+			if exemptedConsumerKeys[keyMapping.ConsumerKey] {
+				exemptedApplications[keyMapping.ApplicationUUID] = true
+			}
+		}
+		keyMappingMutex.Unlock()
+	}
+	return exemptedApplications
+}
+
+// getExemptedSourceCIDRs returns the source CIDRs of the exemptions.
+func getExemptedSourceCIDRs(exemptions []RateLimitExemption) []string {
+	var cidrs []string
+	for _, exemption := range exemptions {
+		if exemption.Type == ExemptionTypeSourceCIDR {
+			cidrs = append(cidrs, exemption.Value)
+		}
+	}
+	return cidrs
+}
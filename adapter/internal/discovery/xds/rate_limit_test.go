@@ -0,0 +1,185 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package xds
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-microgateway/adapter/config"
+	"github.com/wso2/product-microgateway/adapter/pkg/discovery/api/wso2/discovery/subscription"
+	"github.com/wso2/product-microgateway/adapter/pkg/eventhub/types"
+)
+
+func TestGetLocalRateLimitDescriptors(t *testing.T) {
+	subscriptionMap, applicationMap := SubscriptionMap, ApplicationMap
+	defer func() {
+		SubscriptionMap, ApplicationMap = subscriptionMap, applicationMap
+		setApplicationPolicyLimits(nil)
+		setSubscriptionPolicyLimits(nil)
+	}()
+
+	SubscriptionMap = map[int32]*subscription.Subscription{
+		1: {AppUUID: "app1", ApiUUID: "api1", PolicyId: "Gold"},
+		2: {AppUUID: "app1", ApiUUID: "api2", PolicyId: "Unlimited"},
+		3: {AppUUID: "app2", ApiUUID: "api3", PolicyId: "Gold"},
+	}
+	ApplicationMap = map[string]*subscription.Application{
+		"app1": {Uuid: "app1", Policy: "10PerMin"},
+		"app2": {Uuid: "app2", Policy: "10PerMin", Attributes: map[string]string{"tier": "internal"}},
+	}
+	setApplicationPolicyLimits([]types.ApplicationPolicy{
+		{Name: "10PerMin", DefaultLimit: &types.ThrottleLimit{QuotaType: "requestCount",
+			RequestCount: &types.RequestCountLimit{TimeUnit: "min", UnitTime: 1, RequestCount: 10}}},
+	})
+	setSubscriptionPolicyLimits([]types.SubscriptionPolicy{
+		{Name: "Gold", DefaultLimit: &types.ThrottleLimit{QuotaType: "requestCount",
+			RequestCount: &types.RequestCountLimit{TimeUnit: "hours", UnitTime: 2, RequestCount: 5000}}},
+		{Name: "Unlimited", DefaultLimit: &types.ThrottleLimit{QuotaType: "requestCount",
+			RequestCount: &types.RequestCountLimit{TimeUnit: "min", UnitTime: 1, RequestCount: 0}}},
+		{Name: "Bandwidth", DefaultLimit: &types.ThrottleLimit{QuotaType: "bandwidthVolume"}},
+	})
+
+	vhostToDescriptorsMap := getLocalRateLimitDescriptors(map[string][]string{"foo.com": {"api1", "api2"}}, nil)
+	descriptors := vhostToDescriptorsMap["foo.com"]
+	// The subscription of app2 is not in the vhost and the limit of the Unlimited policy is ignored
+	assert.Equal(t, 2, len(descriptors), "Descriptor count mismatch")
+	for _, descriptor := range descriptors {
+		assert.Equal(t, "app1", descriptor.ApplicationUUID, "Application UUID mismatch")
+		if descriptor.APIUUID == "" {
+			assert.Equal(t, uint32(10), descriptor.RequestCount, "Application policy request count mismatch")
+			assert.Equal(t, time.Minute, descriptor.FillInterval, "Application policy fill interval mismatch")
+		} else {
+			assert.Equal(t, "api1", descriptor.APIUUID, "API UUID mismatch")
+			assert.Equal(t, uint32(5000), descriptor.RequestCount, "Subscription policy request count mismatch")
+			assert.Equal(t, 2*time.Hour, descriptor.FillInterval, "Subscription policy fill interval mismatch")
+		}
+	}
+
+	// the global rate limits include the subscriptions to all the APIs
+	assert.Equal(t, 4, len(getGlobalRateLimitDescriptors(nil)), "Global descriptor count mismatch")
+	configFilePath := filepath.Join(t.TempDir(), "config.yaml")
+	updateRateLimitServiceConfig("Default", configFilePath)
+	serviceConfig, err := ioutil.ReadFile(configFilePath)
+	assert.Nil(t, err, "Error while reading the config of the rate limit service")
+	assert.Contains(t, string(serviceConfig), "domain: Default", "Rate limit service config mismatch")
+
+	// app1 is exempted by its consumer key and app2 by its UUID
+	keyMappingMap := ApplicationKeyMappingMap
+	defer func() { ApplicationKeyMappingMap = keyMappingMap }()
+	ApplicationKeyMappingMap = map[string]*subscription.ApplicationKeyMapping{
+		"key1:PRODUCTION:Resident Key Manager": {ApplicationUUID: "app1", ConsumerKey: "key1"},
+	}
+	vhostToDescriptorsMap = getLocalRateLimitDescriptors(map[string][]string{"foo.com": {"api1", "api3"}},
+		[]RateLimitExemption{{Type: ExemptionTypeConsumerKey, Value: "key1"}})
+	assert.Equal(t, 2, len(vhostToDescriptorsMap["foo.com"]), "Descriptor count mismatch with the exemption")
+	for _, descriptor := range vhostToDescriptorsMap["foo.com"] {
+		assert.Equal(t, "app2", descriptor.ApplicationUUID, "Exempted application is rate limited")
+	}
+	vhostToDescriptorsMap = getLocalRateLimitDescriptors(map[string][]string{"foo.com": {"api1", "api3"}},
+		[]RateLimitExemption{{Type: ExemptionTypeConsumerKey, Value: "key1"},
+			{Type: ExemptionTypeApplicationID, Value: "app2"}})
+	assert.Empty(t, vhostToDescriptorsMap["foo.com"], "Exempted applications are rate limited")
+	vhostToDescriptorsMap = getLocalRateLimitDescriptors(map[string][]string{"foo.com": {"api1", "api3"}},
+		[]RateLimitExemption{{Type: ExemptionTypeApplicationAttribute, Value: "tier=internal"}})
+	for _, descriptor := range vhostToDescriptorsMap["foo.com"] {
+		assert.Equal(t, "app1", descriptor.ApplicationUUID, "Application exempted by the attribute is rate limited")
+	}
+
+	// the limit is retained if the update event does not contain the limit
+	updatePolicyLimit(subscriptionPolicyLimits, "Gold", nil, UpdateEvent)
+	assert.NotNil(t, subscriptionPolicyLimits["Gold"], "Limit is removed for the event without the limit")
+	updatePolicyLimit(subscriptionPolicyLimits, "Gold", nil, DeleteEvent)
+	assert.Nil(t, subscriptionPolicyLimits["Gold"], "Limit is not removed for the delete event")
+}
+
+func TestRateLimitExemptions(t *testing.T) {
+	loadRateLimitExemptions()
+	exemptions := rateLimitExemptions
+	defer func() { rateLimitExemptions = exemptions }()
+	rateLimitExemptions = nil
+
+	added, ok, err := AddRateLimitExemption(RateLimitExemption{Type: ExemptionTypeSourceCIDR, Value: "10.1.2.3"})
+	assert.Nil(t, err, "Error while adding the exemption of an IP address")
+	assert.True(t, ok, "Exemption is not added")
+	assert.Equal(t, "10.1.2.3/32", added.Value, "IP address is not converted to a CIDR")
+	_, ok, err = AddRateLimitExemption(RateLimitExemption{Type: ExemptionTypeSourceCIDR, Value: "10.1.2.3/32"})
+	assert.Nil(t, err, "Error while adding an existing exemption")
+	assert.False(t, ok, "Existing exemption is added again")
+	added, _, err = AddRateLimitExemption(RateLimitExemption{Type: ExemptionTypeSourceCIDR, Value: "fd00::1/8"})
+	assert.Nil(t, err, "Error while adding the exemption of an IPv6 CIDR")
+	assert.Equal(t, "fd00::/8", added.Value, "CIDR is not normalized")
+	_, _, err = AddRateLimitExemption(RateLimitExemption{Type: ExemptionTypeSourceCIDR, Value: "10.1.2.3/33"})
+	assert.NotNil(t, err, "Invalid CIDR is added")
+	_, _, err = AddRateLimitExemption(RateLimitExemption{Type: "ip", Value: "10.1.2.3"})
+	assert.NotNil(t, err, "Exemption of an unknown type is added")
+	_, _, err = AddRateLimitExemption(RateLimitExemption{Type: ExemptionTypeApplicationID, Value: " "})
+	assert.NotNil(t, err, "Exemption without a value is added")
+	added, _, err = AddRateLimitExemption(RateLimitExemption{Type: ExemptionTypeApplicationAttribute,
+		Value: "tier = internal"})
+	assert.Nil(t, err, "Error while adding the exemption of an application attribute")
+	assert.Equal(t, "tier=internal", added.Value, "Application attribute is not normalized")
+	_, _, err = AddRateLimitExemption(RateLimitExemption{Type: ExemptionTypeApplicationAttribute, Value: "tier"})
+	assert.NotNil(t, err, "Exemption of an application attribute without a value is added")
+	assert.Equal(t, []string{"10.1.2.3/32", "fd00::/8"}, getExemptedSourceCIDRs(GetRateLimitExemptions()),
+		"Exempted source CIDRs mismatch")
+
+	_, ok, err = RemoveRateLimitExemption(RateLimitExemption{Type: ExemptionTypeSourceCIDR, Value: "10.1.2.3"})
+	assert.Nil(t, err, "Error while removing the exemption")
+	assert.True(t, ok, "Exemption is not removed")
+	_, ok, _ = RemoveRateLimitExemption(RateLimitExemption{Type: ExemptionTypeSourceCIDR, Value: "10.1.2.3"})
+	assert.False(t, ok, "Removed exemption is removed again")
+	assert.Equal(t, 2, len(GetRateLimitExemptions()), "Exemption count mismatch")
+}
+
+func TestPersistedRateLimitExemptions(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	defaultFilePath := conf.Envoy.LocalRateLimit.ExemptionsFilePath
+	loadRateLimitExemptions()
+	exemptions := rateLimitExemptions
+	defer func() {
+		conf.Envoy.LocalRateLimit.ExemptionsFilePath = defaultFilePath
+		rateLimitExemptions = exemptions
+	}()
+	rateLimitExemptions = nil
+	conf.Envoy.LocalRateLimit.ExemptionsFilePath = filepath.Join(t.TempDir(), "exemptions.json")
+
+	persisted, err := readPersistedRateLimitExemptions(conf.Envoy.LocalRateLimit.ExemptionsFilePath)
+	assert.Nil(t, err, "Error while reading the exemptions, which are not persisted yet")
+	assert.Nil(t, persisted, "Exemptions are read before persisted")
+
+	_, _, err = AddRateLimitExemption(RateLimitExemption{Type: ExemptionTypeConsumerKey, Value: "key1"})
+	assert.Nil(t, err, "Error while adding the exemption")
+	_, _, err = AddRateLimitExemption(RateLimitExemption{Type: ExemptionTypeSourceCIDR, Value: "10.1.2.3"})
+	assert.Nil(t, err, "Error while adding the exemption")
+	_, _, err = RemoveRateLimitExemption(RateLimitExemption{Type: ExemptionTypeConsumerKey, Value: "key1"})
+	assert.Nil(t, err, "Error while removing the exemption")
+	persisted, err = readPersistedRateLimitExemptions(conf.Envoy.LocalRateLimit.ExemptionsFilePath)
+	assert.Nil(t, err, "Error while reading the persisted exemptions")
+	assert.Equal(t, []RateLimitExemption{{Type: ExemptionTypeSourceCIDR, Value: "10.1.2.3/32"}}, persisted,
+		"Persisted exemptions mismatch")
+
+	conf.Envoy.LocalRateLimit.ExemptionsFilePath = filepath.Join(t.TempDir(), "missing", "exemptions.json")
+	_, ok, err := AddRateLimitExemption(RateLimitExemption{Type: ExemptionTypeConsumerKey, Value: "key2"})
+	assert.ErrorIs(t, err, ErrRateLimitExemptionsNotPersisted, "Exemption is added without being persisted")
+	assert.False(t, ok, "Exemption is added without being persisted")
+	assert.Equal(t, 1, len(GetRateLimitExemptions()), "Exemption count mismatch")
+}
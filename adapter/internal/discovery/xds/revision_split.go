@@ -0,0 +1,210 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package xds
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/wso2/product-microgateway/adapter/config"
+	"github.com/wso2/product-microgateway/adapter/internal/audit"
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
+	"github.com/wso2/product-microgateway/adapter/pkg/logging"
+)
+
+// ErrAPIRevisionsNotSplit is returned when the traffic of an API is not split between two revisions, hence there
+// is no revision to be promoted or rolled back to.
+var ErrAPIRevisionsNotSplit = errors.New("traffic of the API is not split between revisions")
+
+// apiRevision is a snapshot of a deployed revision of an API. The snapshot is used to re-publish the revision
+// when it is promoted or rolled back.
+type apiRevision struct {
+	revisionID    string
+	vHost         string
+	environments  []string
+	apiProject    model.ProjectAPI
+	prodEndpoints *model.EndpointCluster
+	sandEndpoints *model.EndpointCluster
+}
+
+// apiRevisionState holds the revisions of an API which receive the traffic.
+type apiRevisionState struct {
+	current *apiRevision
+	// previous is set only while the traffic is split between the previous and the current revision.
+	previous       *apiRevision
+	promotionTimer *time.Timer
+}
+
+func (state *apiRevisionState) stopPromotionTimer() {
+	if state.promotionTimer != nil {
+		state.promotionTimer.Stop()
+		state.promotionTimer = nil
+	}
+}
+
+// newAPIRevision creates the snapshot of the API revision. The api project is copied as its certificate
+// maps are altered during the deployment.
+func newAPIRevision(vHost string, environments []string, apiProject model.ProjectAPI) *apiRevision {
+	revision := &apiRevision{
+		vHost:        vHost,
+		environments: environments,
+		apiProject:   copyProjectAPI(apiProject),
+	}
+	// APIs deployed via apictl do not have revisions
+	if apiProject.APIYaml.Data.RevisionID > 0 {
+		revision.revisionID = strconv.Itoa(apiProject.APIYaml.Data.RevisionID)
+	}
+	return revision
+}
+
+// updateAPIRevisionState records the revision being deployed and splits the traffic with the previously
+// deployed revision of the same API, if the revision traffic split is enabled.
+// Should be called while holding the mutexForInternalMapUpdate lock.
+func updateAPIRevisionState(organizationID, apiIdentifier string, mgwSwagger *model.MgwSwagger,
+	revision *apiRevision, isRevisionSplitAllowed bool) {
+	revision.prodEndpoints = mgwSwagger.GetProdEndpoints()
+	revision.sandEndpoints = mgwSwagger.GetSandEndpoints()
+
+	if _, ok := orgIDAPIRevisionStateMap[organizationID]; !ok {
+		orgIDAPIRevisionStateMap[organizationID] = make(map[string]*apiRevisionState)
+	}
+	state, found := orgIDAPIRevisionStateMap[organizationID][apiIdentifier]
+	if !found {
+		state = &apiRevisionState{}
+		orgIDAPIRevisionStateMap[organizationID][apiIdentifier] = state
+	}
+	state.stopPromotionTimer()
+	previous := state.current
+	isRollback := state.previous != nil && state.previous.revisionID == revision.revisionID
+	state.current = revision
+	state.previous = nil
+
+	conf, _ := config.ReadConfigs()
+	splitConf := conf.Adapter.RevisionTrafficSplit
+	if !isRevisionSplitAllowed || !splitConf.Enabled || isRollback || previous == nil ||
+		previous.revisionID == "" || revision.revisionID == "" || previous.revisionID == revision.revisionID {
+		return
+	}
+
+	err := mgwSwagger.SetRevisionTrafficSplit(previous.prodEndpoints, previous.sandEndpoints,
+		model.RevisionTrafficSplit{
+			NewRevisionID:      revision.revisionID,
+			PreviousRevisionID: previous.revisionID,
+			NewRevisionWeight:  splitConf.NewRevisionWeight,
+			TesterHeader:       splitConf.TesterHeader,
+		})
+	if err != nil {
+		logger.LoggerXds.ErrorC(logging.ErrorDetails{
+			Message: fmt.Sprintf("Unable to split the traffic between the revisions %s and %s of the API %s. "+
+				"Hence the new revision receives the complete traffic. %v", previous.revisionID, revision.revisionID,
+				apiIdentifier, err),
+			Severity:  logging.MINOR,
+			ErrorCode: 1417,
+		})
+		return
+	}
+	state.previous = previous
+	logger.LoggerXds.Infof("Traffic of the API %s is split between the revisions %s (%d%%) and %s.", apiIdentifier,
+		revision.revisionID, splitConf.NewRevisionWeight, previous.revisionID)
+
+	if splitConf.PromoteAfterInSeconds > 0 {
+		state.promotionTimer = time.AfterFunc(time.Duration(splitConf.PromoteAfterInSeconds)*time.Second, func() {
+			if _, err := promoteAPIRevision(organizationID, apiIdentifier, audit.ActorAdapter); err != nil {
+				logger.LoggerXds.Errorf("Error while promoting the revision %s of the API %s. %v",
+					revision.revisionID, apiIdentifier, err)
+			}
+		})
+	}
+}
+
+// PromoteAPIRevision routes the complete traffic of the API to the newly deployed revision, ending the
+// traffic split between the revisions, and returns the promoted revision. The actor is recorded as the deployer
+// of the promoted revision.
+func PromoteAPIRevision(organizationID, vhost, name, version, actor string) (string, error) {
+	return promoteAPIRevision(organizationID, getAPIIdentifier(vhost, name, version), actor)
+}
+
+// RollbackAPIRevision re-publishes the snapshot of the previously deployed revision of the API, which
+// routes the complete traffic of the API to the previous revision, and returns the restored revision. The actor
+// is recorded as the deployer of the restored revision.
+func RollbackAPIRevision(organizationID, vhost, name, version, actor string) (string, error) {
+	return rollbackAPIRevision(organizationID, getAPIIdentifier(vhost, name, version), actor)
+}
+
+func promoteAPIRevision(organizationID, apiIdentifier, actor string) (string, error) {
+	current, _, err := getSplitAPIRevisions(organizationID, apiIdentifier)
+	if err != nil {
+		return "", err
+	}
+	logger.LoggerXds.Infof("Promoting the revision %s of the API %s.", current.revisionID, apiIdentifier)
+	apiProject := copyProjectAPI(current.apiProject)
+	apiProject.DeployedBy = actor
+	_, err = updateAPI(current.vHost, apiProject, current.environments, false, false)
+	return current.revisionID, err
+}
+
+func rollbackAPIRevision(organizationID, apiIdentifier, actor string) (string, error) {
+	current, previous, err := getSplitAPIRevisions(organizationID, apiIdentifier)
+	if err != nil {
+		return "", err
+	}
+	logger.LoggerXds.Infof("Rolling back the API %s from the revision %s to %s.", apiIdentifier,
+		current.revisionID, previous.revisionID)
+	apiProject := copyProjectAPI(previous.apiProject)
+	apiProject.DeployedBy = actor
+	_, err = updateAPI(previous.vHost, apiProject, previous.environments, false, false)
+	return previous.revisionID, err
+}
+
+func getSplitAPIRevisions(organizationID, apiIdentifier string) (current *apiRevision, previous *apiRevision,
+	err error) {
+	mutexForInternalMapUpdate.Lock()
+	defer mutexForInternalMapUpdate.Unlock()
+	state, found := orgIDAPIRevisionStateMap[organizationID][apiIdentifier]
+	if !found {
+		return nil, nil, ErrAPINotFound
+	}
+	if state.previous == nil {
+		return nil, nil, ErrAPIRevisionsNotSplit
+	}
+	state.stopPromotionTimer()
+	return state.current, state.previous, nil
+}
+
+func deleteAPIRevisionState(organizationID, apiIdentifier string) {
+	if state, found := orgIDAPIRevisionStateMap[organizationID][apiIdentifier]; found {
+		state.stopPromotionTimer()
+		delete(orgIDAPIRevisionStateMap[organizationID], apiIdentifier)
+	}
+}
+
+func copyProjectAPI(apiProject model.ProjectAPI) model.ProjectAPI {
+	projectCopy := apiProject
+	projectCopy.UpstreamCerts = make(map[string][]byte, len(apiProject.UpstreamCerts))
+	for name, cert := range apiProject.UpstreamCerts {
+		projectCopy.UpstreamCerts[name] = cert
+	}
+	projectCopy.DownstreamCerts = make(map[string][]byte, len(apiProject.DownstreamCerts))
+	for name, cert := range apiProject.DownstreamCerts {
+		projectCopy.DownstreamCerts[name] = cert
+	}
+	return projectCopy
+}
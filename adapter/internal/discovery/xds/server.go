@@ -20,10 +20,12 @@ package xds
 import (
 	"context"
 	"crypto/sha1"
+	"crypto/tls"
 	"encoding/hex"
 	"errors"
 	"fmt"
 	"math/rand"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -38,12 +40,14 @@ import (
 	envoy_resource "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
 	"github.com/wso2/product-microgateway/adapter/config"
 	apiModel "github.com/wso2/product-microgateway/adapter/internal/api/models"
+	"github.com/wso2/product-microgateway/adapter/internal/audit"
 	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
 	"github.com/wso2/product-microgateway/adapter/internal/notifier"
 	oasParser "github.com/wso2/product-microgateway/adapter/internal/oasparser"
 	"github.com/wso2/product-microgateway/adapter/internal/oasparser/constants"
 	"github.com/wso2/product-microgateway/adapter/internal/oasparser/envoyconf"
 	"github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
+	"github.com/wso2/product-microgateway/adapter/internal/profiling"
 	"github.com/wso2/product-microgateway/adapter/internal/svcdiscovery"
 	"github.com/wso2/product-microgateway/adapter/pkg/discovery/api/wso2/discovery/subscription"
 	"github.com/wso2/product-microgateway/adapter/pkg/discovery/api/wso2/discovery/throttle"
@@ -51,7 +55,7 @@ import (
 	wso2_resource "github.com/wso2/product-microgateway/adapter/pkg/discovery/protocol/resource/v3"
 	eventhubTypes "github.com/wso2/product-microgateway/adapter/pkg/eventhub/types"
 	"github.com/wso2/product-microgateway/adapter/pkg/logging"
-	"github.com/wso2/product-microgateway/adapter/pkg/synchronizer"
+	"github.com/wso2/product-microgateway/adapter/pkg/metrics"
 )
 
 var (
@@ -73,6 +77,7 @@ var (
 	enforcerKeyManagerCache            wso2_cache.SnapshotCache
 	enforcerRevokedTokensCache         wso2_cache.SnapshotCache
 	enforcerThrottleDataCache          wso2_cache.SnapshotCache
+	enforcerScopeCache                 wso2_cache.SnapshotCache
 
 	// Vhosts entry maps, these maps updated with delta changes (when an API added, only added its entry only)
 	// These maps are managed separately for API-CTL and APIM, since when deploying an project from API-CTL there is no API uuid
@@ -86,6 +91,7 @@ var (
 	orgIDOpenAPIEndpointsMap    map[string]map[string][]*corev3.Address    // organizationID -> Vhost:API_UUID -> Envoy Endpoints map
 	orgIDOpenAPIEnforcerApisMap map[string]map[string]types.Resource       // organizationID -> Vhost:API_UUID -> API Resource map
 	orgIDvHostBasepathMap       map[string]map[string]string               // organizationID -> Vhost:basepath -> Vhost:API_UUID
+	orgIDAPIRevisionStateMap    map[string]map[string]*apiRevisionState    // organizationID -> Vhost:API_UUID -> API revision state
 
 	reverseAPINameVersionMap map[string]string
 
@@ -106,11 +112,18 @@ var (
 	enforcerSubscriptionPolicyMap    map[string][]types.Resource
 	enforcerApplicationKeyMappingMap map[string][]types.Resource
 	enforcerRevokedTokensMap         map[string][]types.Resource
+	enforcerScopeMap                 map[string][]types.Resource
 	enforcerThrottleData             *throttle.ThrottleData
+	// mutexForEnforcerResourceUpdate guards the enforcer resource maps of the subscription data, which are
+	// updated by the event listeners and compacted in the background
+	mutexForEnforcerResourceUpdate sync.Mutex
 
 	// KeyManagerList to store data
 	KeyManagerList = make([]eventhubTypes.KeyManager, 0)
 	isReady        = false
+	// tenantKeyManagerList holds the resident key managers of the tenants, with the JWKS cached by the adapter
+	tenantKeyManagerList  []eventhubTypes.KeyManager
+	tenantKeyManagerMutex sync.Mutex
 )
 
 var void struct{}
@@ -147,6 +160,7 @@ func init() {
 	enforcerKeyManagerCache = wso2_cache.NewSnapshotCache(false, IDHash{}, nil)
 	enforcerRevokedTokensCache = wso2_cache.NewSnapshotCache(false, IDHash{}, nil)
 	enforcerThrottleDataCache = wso2_cache.NewSnapshotCache(false, IDHash{}, nil)
+	enforcerScopeCache = wso2_cache.NewSnapshotCache(false, IDHash{}, nil)
 
 	apiUUIDToGatewayToVhosts = make(map[string]map[string]string)
 	apiToVhostsMap = make(map[string]map[string]struct{})
@@ -164,6 +178,7 @@ func init() {
 	orgIDOpenAPIEndpointsMap = make(map[string]map[string][]*corev3.Address)   // organizationID -> Vhost:API_UUID -> Envoy Endpoints map
 	orgIDOpenAPIEnforcerApisMap = make(map[string]map[string]types.Resource)   // organizationID -> Vhost:API_UUID -> API Resource map
 	orgIDvHostBasepathMap = make(map[string]map[string]string)
+	orgIDAPIRevisionStateMap = make(map[string]map[string]*apiRevisionState)
 
 	reverseAPINameVersionMap = make(map[string]string)
 
@@ -176,6 +191,7 @@ func init() {
 	enforcerSubscriptionPolicyMap = make(map[string][]types.Resource)
 	enforcerApplicationKeyMappingMap = make(map[string][]types.Resource)
 	enforcerRevokedTokensMap = make(map[string][]types.Resource)
+	enforcerScopeMap = make(map[string][]types.Resource)
 	enforcerThrottleData = &throttle.ThrottleData{}
 	rand.Seed(time.Now().UnixNano())
 	// go watchEnforcerResponse()
@@ -221,6 +237,11 @@ func GetEnforcerApplicationKeyMappingCache() wso2_cache.SnapshotCache {
 	return enforcerApplicationKeyMappingCache
 }
 
+// GetEnforcerScopeCache returns xds server cache.
+func GetEnforcerScopeCache() wso2_cache.SnapshotCache {
+	return enforcerScopeCache
+}
+
 // GetEnforcerKeyManagerCache returns xds server cache.
 func GetEnforcerKeyManagerCache() wso2_cache.SnapshotCache {
 	return enforcerKeyManagerCache
@@ -249,10 +270,21 @@ func DeployReadinessAPI(envs []string) {
 
 // UpdateAPI updates the Xds Cache when OpenAPI Json content is provided
 func UpdateAPI(vHost string, apiProject model.ProjectAPI, environments []string) (*notifier.DeployedAPIRevision, error) {
+	return updateAPI(vHost, apiProject, environments, true, false)
+}
+
+// updateAPI updates the Xds Cache. The traffic is split with the already deployed revision of the API only if
+// isRevisionSplitAllowed is true (ie: not allowed when an API revision is promoted or rolled back).
+// If isReplayed is true (ie: the API is rebuilt from the audit journal), only the internal maps are updated,
+// and the change is neither pushed to the caches nor recorded in the journal. The caller of a replayed API should
+// hold mutexForInternalMapUpdate.
+func updateAPI(vHost string, apiProject model.ProjectAPI, environments []string,
+	isRevisionSplitAllowed bool, isReplayed bool) (*notifier.DeployedAPIRevision, error) {
 	var mgwSwagger model.MgwSwagger
 	var deployedRevision *notifier.DeployedAPIRevision
 	var err error
 	var newLabels []string
+	apiProject.SetSOAPAPIType()
 	apiYaml := apiProject.APIYaml.Data
 
 	// handle panic
@@ -265,13 +297,9 @@ func UpdateAPI(vHost string, apiProject model.ProjectAPI, environments []string)
 	if len(environments) == 0 {
 		environments = []string{config.DefaultGatewayName}
 	}
+	revision := newAPIRevision(vHost, environments, apiProject)
 
-	var apiEnvProps synchronizer.APIEnvProps
-
-	// TODO(amali) under the assumption vhost has one environment at the moment
-	if apiEnvPropsV, found := apiProject.APIEnvProps[environments[0]]; found {
-		apiEnvProps = apiEnvPropsV
-	}
+	apiEnvProps := getAPIEnvProps(apiYaml.ID, apiProject.APIEnvProps, environments)
 
 	err = apiProject.APIYaml.ValidateAPIType()
 	if err != nil {
@@ -284,7 +312,7 @@ func UpdateAPI(vHost string, apiProject model.ProjectAPI, environments []string)
 		return nil, err
 	}
 
-	err = mgwSwagger.GetMgwSwagger(apiProject.APIDefinition)
+	err = mgwSwagger.PopulateFromAPIDefinitions(&apiProject)
 	if err != nil {
 		logger.LoggerXds.Error("Error while populating swagger from api definition. ", err)
 		return nil, err
@@ -294,9 +322,11 @@ func UpdateAPI(vHost string, apiProject model.ProjectAPI, environments []string)
 	mgwSwagger.SetID(apiYaml.ID)
 	mgwSwagger.SetName(apiYaml.Name)
 	mgwSwagger.SetVersion(apiYaml.Version)
+	applySubscriptionValidationOverride(&mgwSwagger)
 
-	if apiYaml.APIType == constants.HTTP || apiYaml.APIType == constants.GRAPHQL || apiYaml.APIType == constants.SOAP {
-		// avoid the following for AsyncAPI types
+	if apiYaml.APIType == constants.HTTP || apiYaml.APIType == constants.GRAPHQL || apiYaml.APIType == constants.SOAP ||
+		apiYaml.APIType == constants.WEBSUB {
+		// avoid the following for the streaming AsyncAPI types, the hub of a WebSub API is secured as a REST API
 		// the following will be used for APIM specific security config.
 		// it will enable folowing securities globally for the API, overriding swagger securities.
 		isYamlAPIKey := false
@@ -339,10 +369,26 @@ func UpdateAPI(vHost string, apiProject model.ProjectAPI, environments []string)
 		}
 	}
 
+	if apiYaml.APIType == constants.HTTP || apiYaml.APIType == constants.GRAPHQL || apiYaml.APIType == constants.SOAP {
+		err = mgwSwagger.SetInterceptors(apiProject.Interceptors)
+		if err != nil {
+			logger.LoggerOasparser.ErrorC(logging.ErrorDetails{
+				Message: fmt.Sprintf("Error while populating the interceptors for the API %s:%s of Organization %s. %s",
+					apiYaml.Name, apiYaml.Version, apiYaml.OrganizationID, err),
+				Severity:  logging.MINOR,
+				ErrorCode: 1423,
+			})
+			return nil, err
+		}
+	}
+
 	if apiYaml.APIType == constants.GRAPHQL {
 		mgwSwagger.GraphQLComplexities = apiProject.GraphQLComplexities
 	}
 	mgwSwagger.SetXWso2AuthHeader(apiYaml.AuthorizationHeader)
+	mgwSwagger.SetAPIDocs(apiProject.APIDocs)
+	mgwSwagger.SetGRPCProtoDescriptor(apiProject.ProtoDescriptor)
+	mgwSwagger.SetUpstreamClientCerts(getUpstreamClientCerts(apiProject))
 	mgwSwagger.SetEnvLabelProperties(apiEnvProps)
 	mgwSwagger.OrganizationID = apiYaml.OrganizationID
 	organizationID := apiYaml.OrganizationID
@@ -373,6 +419,14 @@ func UpdateAPI(vHost string, apiProject model.ProjectAPI, environments []string)
 		})
 		return nil, validationErr
 	}
+	if err = resolveEndpointSecrets(&mgwSwagger); err != nil {
+		logger.LoggerXds.ErrorC(logging.ErrorDetails{
+			Message:   fmt.Sprintf("Endpoint credentials are not resolved for Organization %s. %v", organizationID, err),
+			Severity:  logging.MAJOR,
+			ErrorCode: 1429,
+		})
+		return nil, err
+	}
 
 	// create client map for API
 	var clientCerts []model.Certificate
@@ -397,6 +451,7 @@ func UpdateAPI(vHost string, apiProject model.ProjectAPI, environments []string)
 	}
 
 	mgwSwagger.SetClientCerts(clientCerts)
+	validateMutualSSL(&mgwSwagger)
 
 	// -------- Finished updating mgwSwagger struct
 
@@ -410,8 +465,21 @@ func UpdateAPI(vHost string, apiProject model.ProjectAPI, environments []string)
 	reverseAPINameVersionMap[GenerateIdentifierForAPIWithoutVhost(apiYaml.Name, apiYaml.Version)] = uniqueIdentifier
 	apiIdentifier := GenerateIdentifierForAPIWithUUID(vHost, uniqueIdentifier)
 
-	mutexForInternalMapUpdate.Lock()
-	defer mutexForInternalMapUpdate.Unlock()
+	if !isReplayed {
+		if err = warmUpEndpoints(organizationID, apiIdentifier, &mgwSwagger); err != nil {
+			return nil, err
+		}
+	}
+
+	if !isReplayed {
+		// the internal maps are locked by RebuildAPIs throughout the rebuild
+		mutexForInternalMapUpdate.Lock()
+		defer mutexForInternalMapUpdate.Unlock()
+	}
+
+	// the API is redeployed to the environments, hence those are not drained anymore
+	cancelAPIDrain(organizationID, apiIdentifier, environments)
+	invalidateAPIFragment(organizationID, apiIdentifier)
 
 	// -------- Begin updating maps
 
@@ -420,6 +488,8 @@ func UpdateAPI(vHost string, apiProject model.ProjectAPI, environments []string)
 		return nil, err
 	}
 
+	updateAPIRevisionState(organizationID, apiIdentifier, &mgwSwagger, revision, isRevisionSplitAllowed)
+
 	// Get the map from organizationID map.
 	if _, ok := orgIDAPIMgwSwaggerMap[organizationID]; ok {
 		orgIDAPIMgwSwaggerMap[organizationID][apiIdentifier] = mgwSwagger
@@ -473,6 +543,17 @@ func UpdateAPI(vHost string, apiProject model.ProjectAPI, environments []string)
 		certMap["default"] = append(certMap["default"], newLineByteArray...)
 	}
 	interceptCertMap["default"] = apiProject.InterceptorCerts
+	for url, certFile := range apiProject.Interceptors.GetInterceptorCertificates() {
+		if cert, found := apiProject.InterceptorCertMap[certFile]; found {
+			interceptCertMap[url] = cert
+		} else {
+			logger.LoggerXds.ErrorC(logging.ErrorDetails{
+				Message:   fmt.Sprintf("Certificate file %v not found for the interceptor service %v", certFile, url),
+				Severity:  logging.MAJOR,
+				ErrorCode: 1406,
+			})
+		}
+	}
 
 	routes, clusters, endpoints, err := oasParser.GetRoutesClustersEndpoints(mgwSwagger, certMap,
 		interceptCertMap, vHost, organizationID)
@@ -481,6 +562,8 @@ func UpdateAPI(vHost string, apiProject model.ProjectAPI, environments []string)
 			mgwSwagger.GetTitle(), mgwSwagger.GetVersion(), organizationID, err.Error())
 	}
 
+	invalidateAPIFragment(organizationID, apiIdentifier)
+	oldRoutes := orgIDOpenAPIRoutesMap[organizationID][apiIdentifier]
 	if _, ok := orgIDOpenAPIRoutesMap[organizationID]; ok {
 		orgIDOpenAPIRoutesMap[organizationID][apiIdentifier] = routes
 	} else {
@@ -513,6 +596,19 @@ func UpdateAPI(vHost string, apiProject model.ProjectAPI, environments []string)
 		orgIDOpenAPIEnforcerApisMap[organizationID] = enforcerAPIMap
 	}
 
+	if mgwSwagger.IsDefaultVersion {
+		// the caches of the labels of the previous default version are updated to remove its default version routes
+		for _, label := range demoteDefaultVersions(organizationID, vHost, apiIdentifier, mgwSwagger.GetTitle()) {
+			if !arrayContains(oldLabels, label) {
+				oldLabels = append(append([]string{}, oldLabels...), label)
+			}
+		}
+	}
+
+	if isReplayed {
+		return nil, nil
+	}
+
 	// TODO: (VirajSalaka) Fault tolerance mechanism implementation
 	revisionStatus := updateXdsCacheOnAPIAdd(oldLabels, newLabels)
 	if revisionStatus {
@@ -520,12 +616,149 @@ func UpdateAPI(vHost string, apiProject model.ProjectAPI, environments []string)
 		deployedRevision = notifier.UpdateDeployedRevisions(apiYaml.ID, apiYaml.RevisionID, environments,
 			vHost)
 	}
+	audit.RecordChange(audit.ChangeRecord{
+		Actor:          apiProject.DeployedBy,
+		Action:         audit.ActionDeploy,
+		OrganizationID: organizationID,
+		APIIdentifier:  apiIdentifier,
+		APIName:        apiYaml.Name,
+		APIVersion:     apiYaml.Version,
+		RevisionID:     apiYaml.RevisionID,
+		Environments:   environments,
+		VHost:          vHost,
+		RouteChanges:   audit.DiffRoutes(oldRoutes, routes),
+		Artifact:       getAuditArtifact(apiProject),
+	})
 	if svcdiscovery.IsServiceDiscoveryEnabled {
 		startConsulServiceDiscovery(organizationID) //consul service discovery starting point
 	}
+	if svcdiscovery.IsEurekaEnabled {
+		startEurekaServiceDiscovery(organizationID, apiIdentifier)
+	}
 	return deployedRevision, nil
 }
 
+// getAuditArtifact returns the files and the properties of the API project, which are required to rebuild the
+// API from the audit journal. The environment specific properties received with the deploy event are recorded
+// along with the properties of the project, as those are not retained once the APIs are rebuilt.
+func getAuditArtifact(apiProject model.ProjectAPI) *audit.Artifact {
+	artifact := &audit.Artifact{
+		Files:            make([]audit.ArtifactFile, 0, len(apiProject.Files)),
+		APIEnvProps:      getEffectiveEnvProps(apiProject.APIYaml.Data.ID, apiProject.APIEnvProps),
+		IsDefaultVersion: apiProject.APIYaml.Data.IsDefaultVersion,
+	}
+	for _, file := range apiProject.Files {
+		artifact.Files = append(artifact.Files, audit.ArtifactFile{Name: file.Name, Content: file.Content})
+	}
+	return artifact
+}
+
+// RebuildAPIs replaces the APIs in the internal maps with the APIs rebuilt from the audit journal by the replay
+// function, which passes each API to replayAPI. The caches of the labels the APIs were and are deployed to are
+// updated once all the APIs are replayed. The internal maps are locked throughout the rebuild, hence the APIs
+// deployed meanwhile are applied on top of the rebuilt APIs.
+func RebuildAPIs(replay func(replayAPI func(vHost string, apiProject model.ProjectAPI, environments []string) error)) {
+	mutexForInternalMapUpdate.Lock()
+	defer mutexForInternalMapUpdate.Unlock()
+
+	labels := clearAPIs()
+	replay(func(vHost string, apiProject model.ProjectAPI, environments []string) error {
+		_, err := updateAPI(vHost, apiProject, environments, true, true)
+		return err
+	})
+	pruneAPIAdvisories()
+	updateXdsCacheOnAPIAdd(nil, appendDeployedLabels(labels))
+}
+
+// clearAPIs removes all the APIs from the internal maps, to rebuild the APIs from the audit journal.
+// The caches are not updated, hence the routers keep serving the existing configuration until the APIs
+// are rebuilt. Returns the labels the APIs were deployed to. The caller should hold mutexForInternalMapUpdate.
+func clearAPIs() []string {
+	labels := appendDeployedLabels(nil)
+	for _, apiRevisionStates := range orgIDAPIRevisionStateMap {
+		for _, state := range apiRevisionStates {
+			state.stopPromotionTimer()
+		}
+	}
+	// the journal records the deployed APIs only, hence the undeployed APIs are not drained once rebuilt
+	resetAPIDrains()
+	resetEnvPropsOverrides()
+
+	apiUUIDToGatewayToVhosts = make(map[string]map[string]string)
+	apiToVhostsMap = make(map[string]map[string]struct{})
+	orgIDAPIMgwSwaggerMap = make(map[string]map[string]model.MgwSwagger)
+	orgIDOpenAPIEnvoyMap = make(map[string]map[string][]string)
+	orgIDOpenAPIRoutesMap = make(map[string]map[string][]*routev3.Route)
+	orgIDOpenAPIClustersMap = make(map[string]map[string][]*clusterv3.Cluster)
+	orgIDOpenAPIEndpointsMap = make(map[string]map[string][]*corev3.Address)
+	orgIDOpenAPIEnforcerApisMap = make(map[string]map[string]types.Resource)
+	resetAPIFragments()
+	orgIDvHostBasepathMap = make(map[string]map[string]string)
+	orgIDAPIRevisionStateMap = make(map[string]map[string]*apiRevisionState)
+	reverseAPINameVersionMap = make(map[string]string)
+	return labels
+}
+
+// UpdateXdsCacheForLabels updates the router and enforcer caches of the given labels, and the labels the
+// APIs are deployed to, with the APIs in the internal maps.
+func UpdateXdsCacheForLabels(labels []string) {
+	mutexForInternalMapUpdate.Lock()
+	defer mutexForInternalMapUpdate.Unlock()
+	updateXdsCacheOnAPIAdd(nil, appendDeployedLabels(labels))
+}
+
+// appendDeployedLabels appends the labels the APIs are deployed to, which are not in the given labels.
+// The caller should hold mutexForInternalMapUpdate.
+func appendDeployedLabels(labels []string) []string {
+	for _, apiEnvsMap := range orgIDOpenAPIEnvoyMap {
+		for _, apiLabels := range apiEnvsMap {
+			for _, label := range apiLabels {
+				if !arrayContains(labels, label) {
+					labels = append(labels, label)
+				}
+			}
+		}
+	}
+	return labels
+}
+
+// getUpstreamClientCerts resolves the client certs presented to the endpoints of the API, from the cert and key
+// files of the API project. Invalid certs are skipped, hence the envoy keystore cert is presented instead.
+func getUpstreamClientCerts(apiProject model.ProjectAPI) map[string]model.UpstreamClientCert {
+	if len(apiProject.EndpointClientCerts) == 0 {
+		return nil
+	}
+	clientCerts := make(map[string]model.UpstreamClientCert)
+	for _, endpointClientCert := range apiProject.EndpointClientCerts {
+		endpoint := endpointClientCert.Endpoint
+		if endpoint == "" {
+			endpoint = "default"
+		}
+		cert, certFound := apiProject.UpstreamClientCerts[endpointClientCert.Certificate]
+		key, keyFound := apiProject.UpstreamClientCerts[endpointClientCert.Key]
+		if !certFound || !keyFound {
+			logger.LoggerXds.ErrorC(logging.ErrorDetails{
+				Message: fmt.Sprintf("Client certificate %v or key %v not found for the endpoint %v",
+					endpointClientCert.Certificate, endpointClientCert.Key, endpoint),
+				Severity:  logging.MAJOR,
+				ErrorCode: 1418,
+			})
+			continue
+		}
+		if _, err := tls.X509KeyPair(cert, key); err != nil {
+			logger.LoggerXds.ErrorC(logging.ErrorDetails{
+				Message: fmt.Sprintf("Invalid client certificate %v or key %v for the endpoint %v. %v",
+					endpointClientCert.Certificate, endpointClientCert.Key, endpoint, err),
+				Severity:  logging.MAJOR,
+				ErrorCode: 1418,
+			})
+			continue
+		}
+		clientCerts[endpoint] = model.UpstreamClientCert{Certificate: cert, Key: key}
+	}
+	return clientCerts
+}
+
 // GetAllEnvironments returns all the environments merging new environments with already deployed environments
 // of the given vhost of the API
 func GetAllEnvironments(apiUUID, vhost string, newEnvironments []string) []string {
@@ -554,6 +787,31 @@ func GetDeployedEnvironments(apiUUID string) []string {
 	return envs
 }
 
+// GetControlPlaneAPIEnvironments returns the gateway environments of the APIs deployed from the control plane,
+// by the organization and the UUID of the API.
+func GetControlPlaneAPIEnvironments() map[string]map[string][]string {
+	mutexForInternalMapUpdate.Lock()
+	defer mutexForInternalMapUpdate.Unlock()
+	apiEnvironments := make(map[string]map[string][]string)
+	for organizationID, swaggers := range orgIDAPIMgwSwaggerMap {
+		for _, mgwSwagger := range swaggers {
+			envToVhost, found := apiUUIDToGatewayToVhosts[mgwSwagger.GetID()]
+			if !found || len(envToVhost) == 0 {
+				continue
+			}
+			if _, ok := apiEnvironments[organizationID]; !ok {
+				apiEnvironments[organizationID] = make(map[string][]string)
+			}
+			envs := make([]string, 0, len(envToVhost))
+			for env := range envToVhost {
+				envs = append(envs, env)
+			}
+			apiEnvironments[organizationID][mgwSwagger.GetID()] = envs
+		}
+	}
+	return apiEnvironments
+}
+
 // GetVhostOfAPI returns the vhost of API deployed in the given gateway environment
 func GetVhostOfAPI(apiUUID, environment string) (vhost string, exists bool) {
 	if envToVhost, ok := apiUUIDToGatewayToVhosts[apiUUID]; ok {
@@ -563,6 +821,32 @@ func GetVhostOfAPI(apiUUID, environment string) (vhost string, exists bool) {
 	return "", false
 }
 
+// CheckAPICollision returns an error if another API is deployed in the vhost with the same basepath, or with the
+// same name and version. apiUUID is empty for the APIs deployed using apictl.
+func CheckAPICollision(organizationID, vHost, apiUUID, name, version, basepath string) error {
+	uniqueIdentifier := apiUUID
+	if uniqueIdentifier == "" {
+		uniqueIdentifier = GenerateHashedAPINameVersionIDWithoutVhost(name, version)
+	}
+	apiIdentifier := GenerateIdentifierForAPIWithUUID(vHost, uniqueIdentifier)
+
+	mutexForInternalMapUpdate.Lock()
+	defer mutexForInternalMapUpdate.Unlock()
+	if existingAPIIdentifier, ok := orgIDvHostBasepathMap[organizationID][vHost+":"+basepath]; ok &&
+		existingAPIIdentifier != apiIdentifier {
+		return fmt.Errorf("the basepath %s is already used by the API %s", basepath, existingAPIIdentifier)
+	}
+	for existingAPIIdentifier, mgwSwagger := range orgIDAPIMgwSwaggerMap[organizationID] {
+		if existingAPIIdentifier == apiIdentifier || mgwSwagger.GetTitle() != name || mgwSwagger.GetVersion() != version {
+			continue
+		}
+		if existingVhost, err := ExtractVhostFromAPIIdentifier(existingAPIIdentifier); err == nil && existingVhost == vHost {
+			return fmt.Errorf("the API %s:%s is already deployed as %s", name, version, existingAPIIdentifier)
+		}
+	}
+	return nil
+}
+
 func addBasepathToMap(mgwSwagger model.MgwSwagger, organizationID, vHost, apiIdentifier string) error {
 	newBasepath := mgwSwagger.GetXWso2Basepath()
 
@@ -598,8 +882,9 @@ func addBasepathToMap(mgwSwagger model.MgwSwagger, organizationID, vHost, apiIde
 	return nil
 }
 
-// DeleteAPIs deletes an API, its resources and updates the caches of given environments
-func DeleteAPIs(vhost, apiName, version string, environments []string, organizationID string) error {
+// DeleteAPIs deletes an API, its resources and updates the caches of given environments. actor is the user or
+// the component deleting the API.
+func DeleteAPIs(vhost, apiName, version string, environments []string, organizationID string, actor string) error {
 	apiNameVersionID := GenerateIdentifierForAPIWithoutVhost(apiName, version)
 	apiNameVersionHashedID := reverseAPINameVersionMap[apiNameVersionID]
 
@@ -619,7 +904,7 @@ func DeleteAPIs(vhost, apiName, version string, environments []string, organizat
 		for vh := range vhosts {
 			apiIdentifier := GenerateIdentifierForAPIWithUUID(vh, apiNameVersionHashedID)
 			// Updating cache one API by one API, if one API failed to update cache continue with others.
-			if err := deleteAPI(apiIdentifier, environments, organizationID); err != nil {
+			if err := deleteAPI(apiIdentifier, environments, organizationID, actor); err != nil {
 				// Update apiToVhostsMap with already deleted vhosts in the loop
 				logger.LoggerXds.ErrorC(logging.ErrorDetails{
 					Message:   fmt.Sprintf("Error deleting API: %v of organization: %v", apiIdentifier, organizationID),
@@ -651,7 +936,7 @@ func DeleteAPIs(vhost, apiName, version string, environments []string, organizat
 	}
 
 	apiIdentifier := GenerateIdentifierForAPIWithUUID(vhost, apiNameVersionHashedID)
-	if err := deleteAPI(apiIdentifier, environments, organizationID); err != nil {
+	if err := deleteAPI(apiIdentifier, environments, organizationID, actor); err != nil {
 		return err
 	}
 
@@ -687,7 +972,7 @@ func DeleteAPIsWithUUID(vhost, uuid string, environments []string, organizationI
 		for vh := range vhosts {
 			apiIdentifier := GenerateIdentifierForAPIWithUUID(vh, uuid)
 			// Updating cache one API by one API, if one API failed to update cache continue with others.
-			if err := deleteAPI(apiIdentifier, environments, organizationID); err != nil {
+			if err := deleteAPI(apiIdentifier, environments, organizationID, audit.ActorControlPlane); err != nil {
 				// Update apiToVhostsMap with already deleted vhosts in the loop
 				logger.LoggerXds.ErrorC(logging.ErrorDetails{
 					Message:   fmt.Sprintf("Error deleting API: %v of organization: %v", apiIdentifier, organizationID),
@@ -712,7 +997,7 @@ func DeleteAPIsWithUUID(vhost, uuid string, environments []string, organizationI
 	}
 
 	apiIdentifier := GenerateIdentifierForAPIWithUUID(vhost, uuid)
-	if err := deleteAPI(apiIdentifier, environments, organizationID); err != nil {
+	if err := deleteAPI(apiIdentifier, environments, organizationID, audit.ActorControlPlane); err != nil {
 		return err
 	}
 
@@ -731,10 +1016,15 @@ func DeleteAPIsWithUUID(vhost, uuid string, environments []string, organizationI
 
 // DeleteAPIWithAPIMEvent deletes API with the given UUID from the given gw environments
 func DeleteAPIWithAPIMEvent(uuid, organizationID string, environments []string, revisionUUID string) {
-	apiIdentifiers := make(map[string]struct{})
 	mutexForInternalMapUpdate.Lock()
 	defer mutexForInternalMapUpdate.Unlock()
+	deleteAPIWithAPIMEvent(uuid, organizationID, environments, revisionUUID)
+}
 
+// deleteAPIWithAPIMEvent deletes API with the given UUID from the given gw environments.
+// Should be called while holding the mutexForInternalMapUpdate lock.
+func deleteAPIWithAPIMEvent(uuid, organizationID string, environments []string, revisionUUID string) {
+	apiIdentifiers := make(map[string]struct{})
 	for gw, vhost := range apiUUIDToGatewayToVhosts[uuid] {
 		// delete from only specified environments
 		if arrayContains(environments, gw) {
@@ -743,7 +1033,7 @@ func DeleteAPIWithAPIMEvent(uuid, organizationID string, environments []string,
 		}
 	}
 	for apiIdentifier := range apiIdentifiers {
-		if err := deleteAPI(apiIdentifier, environments, organizationID); err != nil {
+		if err := deleteAPI(apiIdentifier, environments, organizationID, audit.ActorControlPlane); err != nil {
 			logger.LoggerXds.ErrorC(logging.ErrorDetails{
 				Message:   fmt.Sprintf("Error undeploying API %v of Organization %v from environments %v", apiIdentifier, organizationID, environments),
 				Severity:  logging.MAJOR,
@@ -763,12 +1053,26 @@ func DeleteAPIWithAPIMEvent(uuid, organizationID string, environments []string,
 }
 
 // deleteAPI deletes an API, its resources and updates the caches of given environments
-func deleteAPI(apiIdentifier string, environments []string, organizationID string) error {
-	_, exists := orgIDAPIMgwSwaggerMap[organizationID][apiIdentifier]
+func deleteAPI(apiIdentifier string, environments []string, organizationID string, actor string) error {
+	mgwSwagger, exists := orgIDAPIMgwSwaggerMap[organizationID][apiIdentifier]
 	if !exists {
 		logger.LoggerXds.Infof("Unable to delete API: %v from Organization: %v. API Does not exist.", apiIdentifier, organizationID)
 		return errors.New(constants.NotFound)
 	}
+	// routes are removed only if the API is deleted from all the environments
+	oldRoutes := orgIDOpenAPIRoutesMap[organizationID][apiIdentifier]
+	defer func() {
+		audit.RecordChange(audit.ChangeRecord{
+			Actor:          actor,
+			Action:         audit.ActionUndeploy,
+			OrganizationID: organizationID,
+			APIIdentifier:  apiIdentifier,
+			APIName:        mgwSwagger.GetTitle(),
+			APIVersion:     mgwSwagger.GetVersion(),
+			Environments:   environments,
+			RouteChanges:   audit.DiffRoutes(oldRoutes, orgIDOpenAPIRoutesMap[organizationID][apiIdentifier]),
+		})
+	}()
 
 	existingLabels := orgIDOpenAPIEnvoyMap[organizationID][apiIdentifier]
 	toBeDelEnvs, toBeKeptEnvs := getEnvironmentsToBeDeleted(existingLabels, environments)
@@ -797,6 +1101,7 @@ func deleteAPI(apiIdentifier string, environments []string, organizationID strin
 }
 
 func cleanMapResources(apiIdentifier string, organizationID string, toBeDelEnvs []string) {
+	invalidateAPIFragment(organizationID, apiIdentifier)
 	delete(orgIDOpenAPIRoutesMap[organizationID], apiIdentifier)
 	delete(orgIDOpenAPIClustersMap[organizationID], apiIdentifier)
 	delete(orgIDOpenAPIEndpointsMap[organizationID], apiIdentifier)
@@ -810,6 +1115,9 @@ func cleanMapResources(apiIdentifier string, organizationID string, toBeDelEnvs
 	deleteBasepathForVHost(organizationID, apiIdentifier)
 	delete(orgIDOpenAPIEnvoyMap[organizationID], apiIdentifier)  //delete labels
 	delete(orgIDAPIMgwSwaggerMap[organizationID], apiIdentifier) //delete mgwSwagger
+	deleteAPIRevisionState(organizationID, apiIdentifier)
+	deleteAPIAdvisories(organizationID, apiIdentifier)
+	deleteAPIDrain(organizationID, apiIdentifier)
 	//TODO: (SuKSW) clean any remaining in label wise maps, if this is the last API of that label
 	logger.LoggerXds.Infof("Deleted API %v of organization %v", apiIdentifier, organizationID)
 }
@@ -854,6 +1162,18 @@ func mergeResourceArrays(resourceArrays [][]types.Resource) []types.Resource {
 // Old labels refers to the previously assigned labels
 // New labels refers to the the updated labels
 func updateXdsCacheOnAPIAdd(oldLabels []string, newLabels []string) bool {
+	labels := append([]string{}, newLabels...)
+	for _, oldLabel := range oldLabels {
+		if !arrayContains(labels, oldLabel) {
+			labels = append(labels, oldLabel)
+		}
+	}
+	if queueXdsUpdate(labels) {
+		// the revision is considered deployed, as the batch is pushed once the batching window elapses
+		return true
+	}
+	startedAt := time.Now()
+	defer func() { metrics.ObserveXdsPush(1, time.Since(startedAt)) }()
 	revisionStatus := false
 	// TODO: (VirajSalaka) check possible optimizations, Since the number of labels are low by design it should not be an issue
 	for _, newLabel := range newLabels {
@@ -883,14 +1203,32 @@ func updateXdsCacheOnAPIAdd(oldLabels []string, newLabels []string) bool {
 // This method will list out all APIs mapped to the label. and generate envoy resources for all of these APIs.
 func GenerateEnvoyResoucesForLabel(label string) ([]types.Resource, []types.Resource, []types.Resource,
 	[]types.Resource, []types.Resource) {
+	startedAt := time.Now()
+	defer func() { profiling.ObserveSnapshotGeneration(time.Since(startedAt)) }()
 	var clusterArray []*clusterv3.Cluster
 	var vhostToRouteArrayMap = make(map[string][]*routev3.Route)
 	var endpointArray []*corev3.Address
 	var apis []types.Resource
-
-	for organizationID, entityMap := range orgIDOpenAPIEnvoyMap {
-		for apiKey, labels := range entityMap {
-			if arrayContains(labels, label) {
+	// vhost -> UUIDs of the APIs
+	var vhostToAPIsMap = make(map[string][]string)
+	var vhostToGRPCTranscodersMap = make(map[string][]*envoyconf.GRPCTranscoder)
+
+	// The APIs are iterated in a stable order, so that the resources generated for unchanged APIs are identical
+	// to the last snapshot.
+	organizationIDs := make([]string, 0, len(orgIDOpenAPIEnvoyMap))
+	for organizationID := range orgIDOpenAPIEnvoyMap {
+		organizationIDs = append(organizationIDs, organizationID)
+	}
+	sort.Strings(organizationIDs)
+	for _, organizationID := range organizationIDs {
+		entityMap := orgIDOpenAPIEnvoyMap[organizationID]
+		apiKeys := make([]string, 0, len(entityMap))
+		for apiKey := range entityMap {
+			apiKeys = append(apiKeys, apiKey)
+		}
+		sort.Strings(apiKeys)
+		for _, apiKey := range apiKeys {
+			if labels := entityMap[apiKey]; arrayContains(labels, label) {
 				vhost, err := ExtractVhostFromAPIIdentifier(apiKey)
 				if err != nil {
 					logger.LoggerXds.ErrorC(logging.ErrorDetails{
@@ -900,24 +1238,36 @@ func GenerateEnvoyResoucesForLabel(label string) ([]types.Resource, []types.Reso
 					})
 					continue
 				}
-				isDefaultVersion := false
-				if enforcerAPISwagger, ok := orgIDAPIMgwSwaggerMap[organizationID][apiKey]; ok {
-					isDefaultVersion = enforcerAPISwagger.IsDefaultVersion
-				} else {
+				// The fragment of the API is composed only if the API or its state is changed since the last
+				// snapshot
+				fragment, found, err := getAPIFragment(organizationID, apiKey, label, vhost)
+				if err != nil {
+					logger.LoggerXds.ErrorC(logging.ErrorDetails{
+						Message: fmt.Sprintf("Error while composing the router resources of the API %s of "+
+							"Organization %s. %v", apiKey, organizationID, err),
+						Severity:  logging.MAJOR,
+						ErrorCode: 1436,
+					})
+					continue
+				}
+				if !found {
 					// If the mgwSwagger is not found, proceed with other APIs. (Unreachable condition at this point)
 					// If that happens, there is no purpose in processing clusters too.
 					continue
 				}
-				// If it is a default versioned API, the routes are added to the end of the existing array.
-				// Otherwise the routes would be added to the front.
-				// /fooContext/2.0.0/* resource path should be matched prior to the /fooContext/* .
-				if isDefaultVersion {
-					vhostToRouteArrayMap[vhost] = append(vhostToRouteArrayMap[vhost], orgIDOpenAPIRoutesMap[organizationID][apiKey]...)
-				} else {
-					vhostToRouteArrayMap[vhost] = append(orgIDOpenAPIRoutesMap[organizationID][apiKey], vhostToRouteArrayMap[vhost]...)
+				vhostToAPIsMap[vhost] = append(vhostToAPIsMap[vhost], fragment.apiUUID)
+				if fragment.grpcTranscoder != nil {
+					vhostToGRPCTranscodersMap[vhost] = append(vhostToGRPCTranscodersMap[vhost], fragment.grpcTranscoder)
 				}
-				clusterArray = append(clusterArray, orgIDOpenAPIClustersMap[organizationID][apiKey]...)
-				endpointArray = append(endpointArray, orgIDOpenAPIEndpointsMap[organizationID][apiKey]...)
+				// The routes of the API are added to the front of the existing array, while the default version
+				// routes are added to the end. The routes of the fragment are copied, as the fragment is reused.
+				// /fooContext/2.0.0/* resource path should be matched prior to the /fooContext/* .
+				apiRoutes := append(make([]*routev3.Route, 0, len(fragment.routes)+len(vhostToRouteArrayMap[vhost])),
+					fragment.routes...)
+				vhostToRouteArrayMap[vhost] = append(apiRoutes, vhostToRouteArrayMap[vhost]...)
+				vhostToRouteArrayMap[vhost] = append(vhostToRouteArrayMap[vhost], fragment.defaultVersionRoutes...)
+				clusterArray = append(clusterArray, fragment.clusters...)
+				endpointArray = append(endpointArray, fragment.endpoints...)
 				enfocerAPI, ok := orgIDOpenAPIEnforcerApisMap[organizationID][apiKey]
 				if ok {
 					apis = append(apis, enfocerAPI)
@@ -927,6 +1277,9 @@ func GenerateEnvoyResoucesForLabel(label string) ([]types.Resource, []types.Reso
 		}
 	}
 
+	// The API requests are not routed to the backends while the site is in the standby mode.
+	vhostToRouteArrayMap = getStandbyRoutes(vhostToRouteArrayMap)
+
 	// If the token endpoint is enabled, the token endpoint also needs to be added.
 	conf, errReadConfig := config.ReadConfigs()
 	if errReadConfig != nil {
@@ -965,6 +1318,20 @@ func GenerateEnvoyResoucesForLabel(label string) ([]types.Resource, []types.Reso
 		// If the routesConfig exists, the listener exists too
 		oasParser.UpdateRoutesConfig(routesConfig, vhostToRouteArrayMap)
 	}
+	customDomains := config.GetCustomDomains(label)
+	envoyconf.SetCustomDomains(routesConfig.GetVirtualHosts(), customDomains)
+	if conf.Envoy.Filters.GRPCTranscoding.Enabled {
+		envoyconf.SetGRPCTranscoders(routesConfig.GetVirtualHosts(), vhostToGRPCTranscodersMap)
+	}
+	if conf.Envoy.LocalRateLimit.Enabled || conf.Envoy.GlobalRateLimit.Enabled {
+		exemptions := GetRateLimitExemptions()
+		if conf.Envoy.LocalRateLimit.Enabled {
+			envoyconf.SetLocalRateLimitDescriptors(routesConfig.GetVirtualHosts(),
+				getLocalRateLimitDescriptors(vhostToAPIsMap, exemptions))
+		}
+		envoyconf.SetRateLimitSourceExemptions(routesConfig.GetVirtualHosts(), getExemptedSourceCIDRs(exemptions))
+	}
+	oasParser.UpdateListenersForVhosts(listenerArray, vhostToRouteArrayMap, customDomains)
 	clusterArray = append(clusterArray, envoyClusterConfigMap[label]...)
 	endpointArray = append(endpointArray, envoyEndpointConfigMap[label]...)
 	endpoints, clusters, listeners, routeConfigs := oasParser.GetCacheResources(endpointArray, clusterArray, listenerArray, routesConfig)
@@ -980,10 +1347,7 @@ func GenerateGlobalClusters(label string) {
 
 // use UpdateXdsCacheWithLock to avoid race conditions
 func updateXdsCache(label string, endpoints []types.Resource, clusters []types.Resource, routes []types.Resource, listeners []types.Resource) bool {
-	version := rand.Intn(maxRandomInt)
-	// TODO: (VirajSalaka) kept same version for all the resources as we are using simple cache implementation.
-	// Will be updated once decide to move to incremental XDS
-	snap, errNewSnap := envoy_cachev3.NewSnapshot(fmt.Sprint(version), map[envoy_resource.Type][]types.Resource{
+	snap, state, diffs, errNewSnap := newRouterSnapshot(label, map[envoy_resource.Type][]types.Resource{
 		envoy_resource.EndpointType: endpoints,
 		envoy_resource.ClusterType:  clusters,
 		envoy_resource.ListenerType: listeners,
@@ -997,6 +1361,11 @@ func updateXdsCache(label string, endpoints []types.Resource, clusters []types.R
 		})
 		return false
 	}
+	if snap == nil {
+		logger.LoggerXds.Debugf("Router resources of the label: %s are unchanged, hence the version: %d is kept",
+			label, state.version)
+		return true
+	}
 	snap.Consistent()
 	//TODO: (VirajSalaka) check
 	errSetSnap := cache.SetSnapshot(context.Background(), label, snap)
@@ -1008,7 +1377,9 @@ func updateXdsCache(label string, endpoints []types.Resource, clusters []types.R
 		})
 		return false
 	}
-	logger.LoggerXds.Infof("New Router cache updated for the label: " + label + " version: " + fmt.Sprint(version))
+	routerSnapshotStates[label] = state
+	logger.LoggerXds.Infof("New Router cache updated for the label: %s version: %d (%s)", label, state.version,
+		describeResourceDiffs(diffs))
 	return true
 }
 
@@ -1068,6 +1439,8 @@ func UpdateEnforcerApis(label string, apis []types.Resource, version string) {
 
 // UpdateEnforcerSubscriptions sets new update to the enforcer's Subscriptions
 func UpdateEnforcerSubscriptions(subscriptions *subscription.SubscriptionList) {
+	mutexForEnforcerResourceUpdate.Lock()
+	defer mutexForEnforcerResourceUpdate.Unlock()
 	//TODO: (Dinusha) check this hardcoded value
 	logger.LoggerXds.Debug("Updating Enforcer Subscription Cache")
 	label := commonEnforcerLabel
@@ -1095,6 +1468,8 @@ func UpdateEnforcerSubscriptions(subscriptions *subscription.SubscriptionList) {
 
 // UpdateEnforcerApplications sets new update to the enforcer's Applications
 func UpdateEnforcerApplications(applications *subscription.ApplicationList) {
+	mutexForEnforcerResourceUpdate.Lock()
+	defer mutexForEnforcerResourceUpdate.Unlock()
 	logger.LoggerXds.Debug("Updating Enforcer Application Cache")
 	label := commonEnforcerLabel
 	applicationList := enforcerApplicationMap[label]
@@ -1120,6 +1495,8 @@ func UpdateEnforcerApplications(applications *subscription.ApplicationList) {
 
 // UpdateEnforcerAPIList sets new update to the enforcer's Apis
 func UpdateEnforcerAPIList(label string, apis *subscription.APIList) {
+	mutexForEnforcerResourceUpdate.Lock()
+	defer mutexForEnforcerResourceUpdate.Unlock()
 	logger.LoggerXds.Debug("Updating Enforcer API Cache")
 	apiList := enforcerAPIListMap[label]
 	apiList = append(apiList, apis)
@@ -1144,6 +1521,8 @@ func UpdateEnforcerAPIList(label string, apis *subscription.APIList) {
 
 // UpdateEnforcerApplicationPolicies sets new update to the enforcer's Application Policies
 func UpdateEnforcerApplicationPolicies(applicationPolicies *subscription.ApplicationPolicyList) {
+	mutexForEnforcerResourceUpdate.Lock()
+	defer mutexForEnforcerResourceUpdate.Unlock()
 	logger.LoggerXds.Debug("Updating Enforcer Application Policy Cache")
 	label := commonEnforcerLabel
 	applicationPolicyList := enforcerApplicationPolicyMap[label]
@@ -1169,6 +1548,8 @@ func UpdateEnforcerApplicationPolicies(applicationPolicies *subscription.Applica
 
 // UpdateEnforcerSubscriptionPolicies sets new update to the enforcer's Subscription Policies
 func UpdateEnforcerSubscriptionPolicies(subscriptionPolicies *subscription.SubscriptionPolicyList) {
+	mutexForEnforcerResourceUpdate.Lock()
+	defer mutexForEnforcerResourceUpdate.Unlock()
 	logger.LoggerXds.Debug("Updating Enforcer Subscription Policy Cache")
 	label := commonEnforcerLabel
 	subscriptionPolicyList := enforcerSubscriptionPolicyMap[label]
@@ -1194,6 +1575,8 @@ func UpdateEnforcerSubscriptionPolicies(subscriptionPolicies *subscription.Subsc
 
 // UpdateEnforcerApplicationKeyMappings sets new update to the enforcer's Application Key Mappings
 func UpdateEnforcerApplicationKeyMappings(applicationKeyMappings *subscription.ApplicationKeyMappingList) {
+	mutexForEnforcerResourceUpdate.Lock()
+	defer mutexForEnforcerResourceUpdate.Unlock()
 	logger.LoggerXds.Debug("Updating Application Key Mapping Cache")
 	label := commonEnforcerLabel
 	applicationKeyMappingList := enforcerApplicationKeyMappingMap[label]
@@ -1217,6 +1600,33 @@ func UpdateEnforcerApplicationKeyMappings(applicationKeyMappings *subscription.A
 	logger.LoggerXds.Infof("New Application Key Mapping cache update for the label: " + label + " version: " + fmt.Sprint(version))
 }
 
+// UpdateEnforcerScopes sets new update to the enforcer's Scopes
+func UpdateEnforcerScopes(scopes *subscription.ScopeList) {
+	mutexForEnforcerResourceUpdate.Lock()
+	defer mutexForEnforcerResourceUpdate.Unlock()
+	logger.LoggerXds.Debug("Updating Enforcer Scope Cache")
+	label := commonEnforcerLabel
+	scopeList := enforcerScopeMap[label]
+	scopeList = append(scopeList, scopes)
+
+	version := rand.Intn(maxRandomInt)
+	snap, _ := wso2_cache.NewSnapshot(fmt.Sprint(version), map[wso2_resource.Type][]types.Resource{
+		wso2_resource.ScopeListType: scopeList,
+	})
+	snap.Consistent()
+
+	errSetSnap := enforcerScopeCache.SetSnapshot(context.Background(), label, snap)
+	if errSetSnap != nil {
+		logger.LoggerXds.ErrorC(logging.ErrorDetails{
+			Message:   fmt.Sprintf("Error while setting the snapshot : %v", errSetSnap.Error()),
+			Severity:  logging.MAJOR,
+			ErrorCode: 1414,
+		})
+	}
+	enforcerScopeMap[label] = scopeList
+	logger.LoggerXds.Infof("New Scope cache update for the label: " + label + " version: " + fmt.Sprint(version))
+}
+
 // UpdateXdsCacheWithLock uses mutex and lock to avoid different go routines updating XDS at the same time
 func UpdateXdsCacheWithLock(label string, endpoints []types.Resource, clusters []types.Resource, routes []types.Resource,
 	listeners []types.Resource) bool {
@@ -1251,6 +1661,8 @@ func ListApis(apiType string, organizationID string, limit *int64) *apiModel.API
 				vhost = vh
 			}
 			apiMetaListItem.Vhost = vhost
+			apiMetaListItem.Status = getAPIHealthStatus(apiIdentifier)
+			apiMetaListItem.LifecycleStatus = mgwSwagger.LifecycleStatus
 			apisArray = append(apisArray, &apiMetaListItem)
 			i++
 		}
@@ -1278,6 +1690,16 @@ func GenerateIdentifierForAPI(vhost, name, version string) string {
 	return fmt.Sprint(vhost, apiKeyFieldSeparator, name, apiKeyFieldSeparator, version)
 }
 
+// getAPIIdentifier returns the identifier of the API deployed in the vhost, given the name and the version of the API
+func getAPIIdentifier(vhost, name, version string) string {
+	uuid, found := reverseAPINameVersionMap[GenerateIdentifierForAPIWithoutVhost(name, version)]
+	if !found {
+		// If API is imported from apictl the hash of the name and the version is the unique ID
+		uuid = GenerateHashedAPINameVersionIDWithoutVhost(name, version)
+	}
+	return GenerateIdentifierForAPIWithUUID(vhost, uuid)
+}
+
 // GenerateIdentifierForAPIWithUUID generates an identifier unique to the API
 func GenerateIdentifierForAPIWithUUID(vhost, uuid string) string {
 	return fmt.Sprint(vhost, apiKeyFieldSeparator, uuid)
@@ -1312,6 +1734,16 @@ func ExtractVhostFromAPIIdentifier(id string) (string, error) {
 // GenerateAndUpdateKeyManagerList converts the data into KeyManager proto type
 func GenerateAndUpdateKeyManagerList() {
 	var keyManagerConfigList = make([]types.Resource, 0)
+	// the key managers of the control plane are added later, hence they override the tenant key managers of the
+	// same issuer in the enforcer
+	tenantKeyManagerMutex.Lock()
+	for _, keyManager := range tenantKeyManagerList {
+		kmConfig := MarshalKeyManager(&keyManager)
+		if kmConfig != nil {
+			keyManagerConfigList = append(keyManagerConfigList, kmConfig)
+		}
+	}
+	tenantKeyManagerMutex.Unlock()
 	for _, keyManager := range KeyManagerList {
 		kmConfig := MarshalKeyManager(&keyManager)
 		if kmConfig != nil {
@@ -1321,6 +1753,15 @@ func GenerateAndUpdateKeyManagerList() {
 	UpdateEnforcerKeyManagers(keyManagerConfigList)
 }
 
+// SetTenantKeyManagers replaces the resident key managers of the tenants and updates the key managers of the
+// enforcer.
+func SetTenantKeyManagers(keyManagers []eventhubTypes.KeyManager) {
+	tenantKeyManagerMutex.Lock()
+	tenantKeyManagerList = keyManagers
+	tenantKeyManagerMutex.Unlock()
+	GenerateAndUpdateKeyManagerList()
+}
+
 // UpdateEnforcerKeyManagers Sets new update to the enforcer's configuration
 func UpdateEnforcerKeyManagers(keyManagerConfigList []types.Resource) {
 	logger.LoggerXds.Debug("Updating Key Manager Cache")
@@ -1347,6 +1788,8 @@ func UpdateEnforcerKeyManagers(keyManagerConfigList []types.Resource) {
 // UpdateEnforcerRevokedTokens method update the revoked tokens
 // in the enforcer
 func UpdateEnforcerRevokedTokens(revokedTokens []types.Resource) {
+	mutexForEnforcerResourceUpdate.Lock()
+	defer mutexForEnforcerResourceUpdate.Unlock()
 	logger.LoggerXds.Debug("Updating enforcer cache for revoked tokens")
 	label := commonEnforcerLabel
 	tokens := enforcerRevokedTokensMap[label]
@@ -1415,4 +1858,9 @@ func UpdateEnforcerThrottleData(throttleData *throttle.ThrottleData) {
 	}
 	enforcerThrottleData = t
 	logger.LoggerXds.Infof("New Throttle Data cache update for the label: " + label + " version: " + fmt.Sprint(version))
+	// The routes are updated as the router enforces the IP blocking conditions as well
+	if throttleData.IpBlockingConditions != nil && updateBlockedIPs(ipConditions) {
+		resetAPIFragments()
+		UpdateXdsCacheForLabels(nil)
+	}
 }
@@ -20,6 +20,12 @@ import (
 	"reflect"
 	"sort"
 	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-microgateway/adapter/config"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
+	"github.com/wso2/product-microgateway/adapter/pkg/synchronizer"
 )
 
 func TestGetVhostOfAPI(t *testing.T) {
@@ -176,3 +182,47 @@ func setupInternalMemoryMapsWithTestSamples() {
 		},
 	}
 }
+
+func TestRebuildAPIsResetsAPIState(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	defaultBatching := conf.Adapter.XdsBatching
+	defaultPushLabels := pushLabels
+	defer func() {
+		conf.Adapter.XdsBatching = defaultBatching
+		pushLabels = defaultPushLabels
+		RebuildAPIs(func(replayAPI func(string, model.ProjectAPI, []string) error) {})
+	}()
+	// the routes are not pushed to the routers
+	conf.Adapter.XdsBatching.Enabled = true
+	conf.Adapter.XdsBatching.WindowInMilliseconds = 10
+	pushLabels = func(labels []string) {}
+
+	rebuiltAPI := GenerateIdentifierForAPIWithUUID("localhost", "rebuilt-api")
+	removedAPI := GenerateIdentifierForAPIWithUUID("localhost", "removed-api")
+	mutexForInternalMapUpdate.Lock()
+	apiUUIDToGatewayToVhosts["removed-api"] = map[string]string{"Default": "localhost"}
+	mutexForInternalMapUpdate.Unlock()
+	drainAPI("removed-api", "carbon.super", []string{"Default"}, "", time.Hour)
+	apiAdvisoryMutex.Lock()
+	apiAdvisories["carbon.super"] = map[string][]APIAdvisory{
+		rebuiltAPI: {{ID: "rebuilt-advisory", Message: "Migrate to v2"}},
+		removedAPI: {{ID: "removed-advisory", Message: "Migrate to v2"}},
+	}
+	apiAdvisoryMutex.Unlock()
+	SetEnvPropsOverride("removed-api", map[string]synchronizer.APIEnvProps{"Default": {}})
+
+	RebuildAPIs(func(replayAPI func(string, model.ProjectAPI, []string) error) {
+		orgIDAPIMgwSwaggerMap["carbon.super"] = map[string]model.MgwSwagger{rebuiltAPI: {}}
+	})
+
+	mutexForAPIDrain.RLock()
+	assert.Empty(t, drainingAPIs, "Drain of an API is not reset with the rebuild")
+	mutexForAPIDrain.RUnlock()
+	apiAdvisoryMutex.RLock()
+	assert.Contains(t, apiAdvisories["carbon.super"], rebuiltAPI, "Advisories of a rebuilt API are removed")
+	assert.NotContains(t, apiAdvisories["carbon.super"], removedAPI, "Advisories of a removed API are retained")
+	apiAdvisoryMutex.RUnlock()
+	envPropsOverrideMutex.RLock()
+	assert.Empty(t, envPropsOverrides, "Properties of the deploy events are not reset with the rebuild")
+	envPropsOverrideMutex.RUnlock()
+}
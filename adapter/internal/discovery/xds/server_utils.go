@@ -17,7 +17,10 @@
 package xds
 
 import (
+	"github.com/wso2/product-microgateway/adapter/config"
 	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/constants"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
 )
 
 // getEnvironmentsToBeDeleted returns an slice of environments APIs to be u-deployed from
@@ -81,3 +84,22 @@ func updateVhostInternalMaps(uuid, name, version, vHost string, gwEnvs []string)
 	}
 	apiUUIDToGatewayToVhosts[uuid] = envToVhostMap
 }
+
+// validateMutualSSL warns if the mutual SSL of an API can not be enforced as expected, ie: the API artifact does
+// not include client certificates or the router does not request the client certificates.
+func validateMutualSSL(mgwSwagger *model.MgwSwagger) {
+	mutualSSL := mgwSwagger.GetXWSO2MutualSSL()
+	if mutualSSL != constants.Mandatory && mutualSSL != constants.Optional {
+		return
+	}
+	if len(mgwSwagger.GetClientCerts()) == 0 {
+		logger.LoggerXds.Warnf("Mutual SSL is %s for the API %s:%s, but no client certificates are included in "+
+			"the API. The requests are not authenticated by the client certificates.", mutualSSL,
+			mgwSwagger.GetTitle(), mgwSwagger.GetVersion())
+	}
+	conf, _ := config.ReadConfigs()
+	if !conf.Envoy.Downstream.TLS.MTLSAPIsEnabled && conf.Enforcer.Security.MutualSSL.EnableClientValidation {
+		logger.LoggerXds.Warnf("Mutual SSL is %s for the API %s:%s, but the router does not request the client "+
+			"certificates as mTLSAPIsEnabled is false.", mutualSSL, mgwSwagger.GetTitle(), mgwSwagger.GetVersion())
+	}
+}
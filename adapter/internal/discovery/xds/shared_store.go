@@ -0,0 +1,556 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+package xds
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/wso2/product-microgateway/adapter/config"
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/pkg/discovery/api/wso2/discovery/subscription"
+	"github.com/wso2/product-microgateway/adapter/pkg/logging"
+	"github.com/wso2/product-microgateway/adapter/pkg/tlsutils"
+	"google.golang.org/protobuf/proto"
+)
+
+// Types of the resources in the shared store. The resources of a type are stored in a hash of the key prefix and
+// the type, where the fields are the keys of the resources and the values are the protobuf encoded resources. The
+// versions of the resources are stored in a hash of the same name with the versions suffix, where the version of a
+// removed resource is kept as a tombstone.
+const (
+	sharedApplications   string = "applications"
+	sharedSubscriptions  string = "subscriptions"
+	sharedKeyMappings    string = "keymappings"
+	invalidationsChannel string = "invalidations"
+	sharedVersionsSuffix string = ":versions"
+)
+
+// sharedStoreInvalidation is published to the invalidations channel, once the resources are changed by an adapter.
+type sharedStoreInvalidation struct {
+	// Origin is the adapter which changed the resources
+	Origin   string `json:"origin"`
+	Resource string `json:"resource"`
+	// Key of the changed resource, or empty if the resources of the type are replaced
+	Key string `json:"key,omitempty"`
+}
+
+// sharedResource represents how a type of resources is applied to the local store.
+type sharedResource struct {
+	newMessage func() proto.Message
+	// update applies the resources to the local store and updates the enforcers. A nil resource is removed. All the
+	// resources of the local store are replaced, if replaceAll is true.
+	update func(resources map[string]proto.Message, replaceAll bool)
+}
+
+var (
+	// sharedStore is nil, if the shared store is disabled or not connected
+	sharedStore          *redis.Client
+	sharedStoreLock      sync.RWMutex
+	sharedStoreKeyPrefix string
+	// sharedStoreOrigin identifies the invalidations published by this adapter
+	sharedStoreOrigin = uuid.New().String()
+	// subscriptionDataMutex guards the ApplicationMap and the SubscriptionMap, which are updated by the other
+	// adapters via the shared store
+	subscriptionDataMutex sync.Mutex
+	// sharedVersions are the versions of the resources of the local store by the type, which are the times (in
+	// nanoseconds) the resources are changed. The version of a resource removed is kept until it is written to the
+	// shared store. The versions are compared with the versions of the shared store, hence a change is not
+	// overwritten by an earlier change of another adapter (ex: the changes made while the shared store is not
+	// reachable are written to the shared store once reconnected).
+	sharedVersions      = make(map[string]map[string]int64)
+	sharedVersionsMutex sync.Mutex
+
+	sharedResources = map[string]sharedResource{
+		sharedApplications: {
+			newMessage: func() proto.Message { return &subscription.Application{} },
+			update:     updateSharedApplications,
+		},
+		sharedSubscriptions: {
+			newMessage: func() proto.Message { return &subscription.Subscription{} },
+			update:     updateSharedSubscriptions,
+		},
+		sharedKeyMappings: {
+			newMessage: func() proto.Message { return &subscription.ApplicationKeyMapping{} },
+			update:     updateSharedKeyMappings,
+		},
+	}
+)
+
+// StartSharedStore connects to the Redis server, if the shared store is enabled. The resources are merged with the
+// shared store by the versions, and reloaded as the other adapters publish the changes. The connection is retried
+// once lost, and the resources are merged again, as the changes published while disconnected are missed.
+func StartSharedStore() {
+	conf, _ := config.ReadConfigs()
+	storeConf := conf.Adapter.RedisStore
+	if !storeConf.Enabled {
+		return
+	}
+	timeout := time.Duration(storeConf.TimeoutInSeconds) * time.Second
+	options := &redis.Options{
+		Addr:         storeConf.Address,
+		Username:     storeConf.Username,
+		Password:     storeConf.Password,
+		DB:           storeConf.Database,
+		DialTimeout:  timeout,
+		ReadTimeout:  timeout,
+		WriteTimeout: timeout,
+	}
+	if storeConf.TLS {
+		options.TLSConfig = &tls.Config{RootCAs: tlsutils.GetTrustedCertPool(conf.Adapter.Truststore.Location)}
+	}
+	sharedStoreKeyPrefix = storeConf.KeyPrefix
+	for {
+		err := runSharedStore(options)
+		setSharedStore(nil)
+		logger.LoggerXds.ErrorC(logging.ErrorDetails{
+			Message: fmt.Sprintf("Connection to the shared store %s is lost. Retrying in %d seconds. %v",
+				storeConf.Address, storeConf.ReconnectIntervalInSeconds, err),
+			Severity:  logging.MAJOR,
+			ErrorCode: 1424,
+		})
+		time.Sleep(time.Duration(storeConf.ReconnectIntervalInSeconds) * time.Second)
+	}
+}
+
+// runSharedStore applies the invalidations published by the other adapters, until the connection is lost. The
+// connections of the client are discarded on any error (ex: a timeout), hence the reply of a command is never read
+// as the reply of a subsequent command.
+func runSharedStore(options *redis.Options) error {
+	ctx := context.Background()
+	client := redis.NewClient(options)
+	defer client.Close()
+	if err := client.Ping(ctx).Err(); err != nil {
+		return err
+	}
+	subscriber := client.Subscribe(ctx, sharedStoreKeyPrefix+invalidationsChannel)
+	defer subscriber.Close()
+	// the subscription is confirmed before the resources are merged, hence the changes made meanwhile are received
+	if _, err := subscriber.Receive(ctx); err != nil {
+		return err
+	}
+	setSharedStore(client)
+	logger.LoggerXds.Infof("Connected to the shared store %s", options.Addr)
+	for resource := range sharedResources {
+		syncSharedResources(client, resource)
+	}
+	for {
+		message, err := subscriber.ReceiveMessage(ctx)
+		if err != nil {
+			return err
+		}
+		var invalidation sharedStoreInvalidation
+		if err = json.Unmarshal([]byte(message.Payload), &invalidation); err != nil {
+			logger.LoggerXds.Warnf("Invalid message in the shared store invalidations channel: %v", err)
+			continue
+		}
+		if invalidation.Origin == sharedStoreOrigin {
+			continue
+		}
+		logger.LoggerXds.Debugf("Shared store invalidation of %s %q is received from %s", invalidation.Resource,
+			invalidation.Key, invalidation.Origin)
+		if invalidation.Key == "" {
+			syncSharedResources(client, invalidation.Resource)
+		} else {
+			loadSharedResource(client, invalidation.Resource, invalidation.Key)
+		}
+	}
+}
+
+func setSharedStore(client *redis.Client) {
+	sharedStoreLock.Lock()
+	defer sharedStoreLock.Unlock()
+	sharedStore = client
+}
+
+func getSharedStore() *redis.Client {
+	sharedStoreLock.RLock()
+	defer sharedStoreLock.RUnlock()
+	return sharedStore
+}
+
+// syncSharedResources merges the resources of the type in the local store and in the shared store. The later
+// version of a resource is kept in both stores, hence the local changes made while the shared store is not reachable
+// are written to the shared store, rather than being overwritten by the earlier changes of the shared store.
+func syncSharedResources(client *redis.Client, resource string) {
+	shared, found := sharedResources[resource]
+	if !found {
+		return
+	}
+	remoteResources, remoteVersions, err := readSharedResources(client, resource)
+	if err != nil {
+		logSharedStoreReadError(resource, err)
+		return
+	}
+	localVersions := getSharedVersions(resource)
+	keys := make(map[string]struct{}, len(remoteVersions)+len(localVersions))
+	for key := range remoteResources {
+		keys[key] = struct{}{}
+	}
+	for key := range remoteVersions {
+		keys[key] = struct{}{}
+	}
+	for key := range localVersions {
+		keys[key] = struct{}{}
+	}
+
+	loaded := make(map[string]proto.Message)
+	loadedVersions := make(map[string]int64)
+	written := make(map[string]proto.Message)
+	for key := range keys {
+		localVersion, remoteVersion := localVersions[key], remoteVersions[key]
+		remoteResource, remoteFound := remoteResources[key]
+		switch {
+		case localVersion > remoteVersion:
+			// nil, if the resource is removed from the local store
+			written[key], _, _ = lookupLocalResource(resource, key)
+		case remoteVersion > localVersion || remoteFound:
+			// nil, if the resource is removed from the shared store
+			loaded[key] = remoteResource
+			if remoteFound {
+				loadedVersions[key] = remoteVersion
+			}
+		}
+	}
+	if len(loaded) > 0 {
+		shared.update(loaded, false)
+		setSharedVersions(resource, loadedVersions)
+		logger.LoggerXds.Infof("%d %s are loaded from the shared store", len(loaded), resource)
+	}
+	if len(written) > 0 {
+		versions := make(map[string]int64, len(written))
+		for key := range written {
+			versions[key] = localVersions[key]
+		}
+		if err := writeSharedResources(client, resource, written, versions, false); err != nil {
+			logSharedStoreWriteError(resource, err)
+			return
+		}
+		publishSharedStoreInvalidation(client, resource, "")
+		logger.LoggerXds.Infof("%d %s changed locally are written to the shared store", len(written), resource)
+	}
+}
+
+// readSharedResources reads the resources of the type and the versions of those from the shared store.
+func readSharedResources(client *redis.Client, resource string) (map[string]proto.Message, map[string]int64, error) {
+	ctx := context.Background()
+	pipeline := client.Pipeline()
+	valuesCmd := pipeline.HGetAll(ctx, sharedStoreKeyPrefix+resource)
+	versionsCmd := pipeline.HGetAll(ctx, sharedStoreKeyPrefix+resource+sharedVersionsSuffix)
+	if _, err := pipeline.Exec(ctx); err != nil {
+		return nil, nil, err
+	}
+	shared := sharedResources[resource]
+	resources := make(map[string]proto.Message, len(valuesCmd.Val()))
+	for key, value := range valuesCmd.Val() {
+		message := shared.newMessage()
+		if err := proto.Unmarshal([]byte(value), message); err != nil {
+			logSharedStoreReadError(resource+" "+key, err)
+			continue
+		}
+		resources[key] = message
+	}
+	versions := make(map[string]int64, len(versionsCmd.Val()))
+	for key, value := range versionsCmd.Val() {
+		if version, err := strconv.ParseInt(value, 10, 64); err == nil {
+			versions[key] = version
+		}
+	}
+	return resources, versions, nil
+}
+
+// loadSharedResource applies a resource of the shared store to the local store, unless the resource is changed
+// later in the local store. The resource is removed from the local store, if it does not exist in the shared store.
+func loadSharedResource(client *redis.Client, resource, key string) {
+	shared, found := sharedResources[resource]
+	if !found {
+		return
+	}
+	ctx := context.Background()
+	pipeline := client.Pipeline()
+	valueCmd := pipeline.HGet(ctx, sharedStoreKeyPrefix+resource, key)
+	versionCmd := pipeline.HGet(ctx, sharedStoreKeyPrefix+resource+sharedVersionsSuffix, key)
+	if _, err := pipeline.Exec(ctx); err != nil && err != redis.Nil {
+		logSharedStoreReadError(resource+" "+key, err)
+		return
+	}
+	remoteVersion, _ := strconv.ParseInt(versionCmd.Val(), 10, 64)
+	if localVersion := getSharedVersions(resource)[key]; localVersion > remoteVersion {
+		logger.LoggerXds.Debugf("%s %q of the shared store is ignored, as it is changed later locally", resource, key)
+		return
+	}
+	var message proto.Message
+	if valueCmd.Err() == nil {
+		message = shared.newMessage()
+		if err := proto.Unmarshal([]byte(valueCmd.Val()), message); err != nil {
+			logSharedStoreReadError(resource+" "+key, err)
+			return
+		}
+	}
+	shared.update(map[string]proto.Message{key: message}, false)
+	if message != nil {
+		setSharedVersions(resource, map[string]int64{key: remoteVersion})
+	}
+}
+
+// putSharedResource writes a resource changed by this adapter to the shared store, and publishes the change. A nil
+// resource is removed from the shared store. The change is versioned even if the shared store is not reachable,
+// hence it is written once reconnected.
+func putSharedResource(resource, key string, message proto.Message) {
+	if !isSharedStoreEnabled() {
+		return
+	}
+	// a nil resource pointer is not a nil message
+	if message != nil && !message.ProtoReflect().IsValid() {
+		message = nil
+	}
+	versions := map[string]int64{key: time.Now().UnixNano()}
+	setSharedVersions(resource, versions)
+	client := getSharedStore()
+	if client == nil {
+		return
+	}
+	if err := writeSharedResources(client, resource, map[string]proto.Message{key: message}, versions,
+		false); err != nil {
+		logSharedStoreWriteError(resource, err)
+		return
+	}
+	publishSharedStoreInvalidation(client, resource, key)
+}
+
+// replaceSharedResources replaces all the resources of the type in the shared store atomically, and publishes the
+// change. The resources removed are versioned as well, hence those are not loaded again from the shared store.
+func replaceSharedResources(resource string, resources map[string]proto.Message) {
+	if !isSharedStoreEnabled() {
+		return
+	}
+	version := time.Now().UnixNano()
+	versions := make(map[string]int64, len(resources))
+	for key := range getSharedVersions(resource) {
+		versions[key] = version
+	}
+	for key := range resources {
+		versions[key] = version
+	}
+	setSharedVersions(resource, versions)
+	client := getSharedStore()
+	if client == nil {
+		return
+	}
+	if err := writeSharedResources(client, resource, resources, versions, true); err != nil {
+		logSharedStoreWriteError(resource, err)
+		return
+	}
+	publishSharedStoreInvalidation(client, resource, "")
+}
+
+// writeSharedResources writes the resources and the versions of those to the shared store atomically. The resources
+// without a value are removed, while the versions of those are kept. All the resources of the type are replaced, if
+// replaceAll is true. The versions of the resources removed are discarded from the local store once written.
+func writeSharedResources(client *redis.Client, resource string, resources map[string]proto.Message,
+	versions map[string]int64, replaceAll bool) error {
+	ctx := context.Background()
+	valuesKey := sharedStoreKeyPrefix + resource
+	versionsKey := valuesKey + sharedVersionsSuffix
+	if replaceAll {
+		// the resources of the shared store, which are not replaced, are removed as well
+		keys, err := client.HKeys(ctx, valuesKey).Result()
+		if err != nil {
+			return err
+		}
+		version := time.Now().UnixNano()
+		for _, key := range keys {
+			if _, found := versions[key]; !found {
+				versions[key] = version
+			}
+		}
+	}
+	values := make(map[string]interface{}, len(resources))
+	var removed []string
+	for key, message := range resources {
+		if message == nil || !message.ProtoReflect().IsValid() {
+			removed = append(removed, key)
+			continue
+		}
+		value, err := proto.Marshal(message)
+		if err != nil {
+			return err
+		}
+		values[key] = value
+	}
+	versionValues := make(map[string]interface{}, len(versions))
+	for key, version := range versions {
+		versionValues[key] = strconv.FormatInt(version, 10)
+	}
+	_, err := client.TxPipelined(ctx, func(pipeline redis.Pipeliner) error {
+		if replaceAll {
+			// the tombstones of the resources removed earlier are discarded as well
+			pipeline.Del(ctx, valuesKey, versionsKey)
+		} else if len(removed) > 0 {
+			pipeline.HDel(ctx, valuesKey, removed...)
+		}
+		if len(values) > 0 {
+			pipeline.HSet(ctx, valuesKey, values)
+		}
+		if len(versionValues) > 0 {
+			pipeline.HSet(ctx, versionsKey, versionValues)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	removeSharedTombstones(resource, versions)
+	return nil
+}
+
+func publishSharedStoreInvalidation(client *redis.Client, resource, key string) {
+	message, _ := json.Marshal(sharedStoreInvalidation{Origin: sharedStoreOrigin, Resource: resource, Key: key})
+	if err := client.Publish(context.Background(), sharedStoreKeyPrefix+invalidationsChannel,
+		message).Err(); err != nil {
+		logSharedStoreWriteError(resource, err)
+	}
+}
+
+func isSharedStoreEnabled() bool {
+	conf, _ := config.ReadConfigs()
+	return conf.Adapter.RedisStore.Enabled
+}
+
+// getSharedVersions returns a copy of the versions of the resources of the type in the local store.
+func getSharedVersions(resource string) map[string]int64 {
+	sharedVersionsMutex.Lock()
+	defer sharedVersionsMutex.Unlock()
+	versions := make(map[string]int64, len(sharedVersions[resource]))
+	for key, version := range sharedVersions[resource] {
+		versions[key] = version
+	}
+	return versions
+}
+
+func setSharedVersions(resource string, versions map[string]int64) {
+	sharedVersionsMutex.Lock()
+	defer sharedVersionsMutex.Unlock()
+	if sharedVersions[resource] == nil {
+		sharedVersions[resource] = make(map[string]int64, len(versions))
+	}
+	for key, version := range versions {
+		sharedVersions[resource][key] = version
+	}
+}
+
+// removeSharedTombstones discards the versions of the resources removed from the local store, once those are
+// written to the shared store, unless the resources are changed again meanwhile.
+func removeSharedTombstones(resource string, written map[string]int64) {
+	var removed []string
+	for key := range written {
+		if message, _, _ := lookupLocalResource(resource, key); message == nil {
+			removed = append(removed, key)
+		}
+	}
+	sharedVersionsMutex.Lock()
+	defer sharedVersionsMutex.Unlock()
+	for _, key := range removed {
+		if sharedVersions[resource][key] == written[key] {
+			delete(sharedVersions[resource], key)
+		}
+	}
+}
+
+func updateSharedApplications(resources map[string]proto.Message, replaceAll bool) {
+	subscriptionDataMutex.Lock()
+	if replaceAll || ApplicationMap == nil {
+		ApplicationMap = make(map[string]*subscription.Application, len(resources))
+		applicationStore.reset()
+	}
+	for key, message := range resources {
+		if message == nil {
+			delete(ApplicationMap, key)
+			applicationStore.remove(key)
+		} else {
+			admitApplication(ApplicationMap, key, message.(*subscription.Application))
+		}
+	}
+	applicationList := marshalApplicationMapToList(ApplicationMap)
+	subscriptionDataMutex.Unlock()
+	UpdateEnforcerApplications(applicationList)
+}
+
+func updateSharedSubscriptions(resources map[string]proto.Message, replaceAll bool) {
+	subscriptionDataMutex.Lock()
+	if replaceAll || SubscriptionMap == nil {
+		SubscriptionMap = make(map[int32]*subscription.Subscription, len(resources))
+		subscriptionStore.reset()
+	}
+	for key, message := range resources {
+		subscriptionID, err := strconv.ParseInt(key, 10, 32)
+		if err != nil {
+			continue
+		}
+		if message == nil {
+			delete(SubscriptionMap, int32(subscriptionID))
+			subscriptionStore.remove(key)
+		} else {
+			admitSubscription(SubscriptionMap, int32(subscriptionID), message.(*subscription.Subscription))
+		}
+	}
+	subscriptionList := marshalSubscriptionMapToList(SubscriptionMap)
+	subscriptionDataMutex.Unlock()
+	UpdateEnforcerSubscriptions(subscriptionList)
+}
+
+func updateSharedKeyMappings(resources map[string]proto.Message, replaceAll bool) {
+	keyMappingMutex.Lock()
+	if replaceAll || ApplicationKeyMappingMap == nil {
+		ApplicationKeyMappingMap = make(map[string]*subscription.ApplicationKeyMapping, len(resources))
+		keyMappingStore.reset()
+	}
+	for key, message := range resources {
+		if message == nil {
+			delete(ApplicationKeyMappingMap, key)
+			keyMappingStore.remove(key)
+		} else {
+			admitKeyMapping(ApplicationKeyMappingMap, key, message.(*subscription.ApplicationKeyMapping))
+		}
+	}
+	keyMappingList := marshalKeyMappingMapToList(ApplicationKeyMappingMap)
+	keyMappingMutex.Unlock()
+	UpdateEnforcerApplicationKeyMappings(keyMappingList)
+}
+
+func logSharedStoreReadError(resource string, err error) {
+	logger.LoggerXds.ErrorC(logging.ErrorDetails{
+		Message:   fmt.Sprintf("Error while reading %s from the shared store. %v", resource, err),
+		Severity:  logging.MAJOR,
+		ErrorCode: 1425,
+	})
+}
+
+func logSharedStoreWriteError(resource string, err error) {
+	logger.LoggerXds.ErrorC(logging.ErrorDetails{
+		Message:   fmt.Sprintf("Error while writing %s to the shared store. %v", resource, err),
+		Severity:  logging.MAJOR,
+		ErrorCode: 1426,
+	})
+}
@@ -0,0 +1,126 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package xds
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-microgateway/adapter/config"
+	"github.com/wso2/product-microgateway/adapter/pkg/discovery/api/wso2/discovery/subscription"
+	"github.com/wso2/product-microgateway/adapter/pkg/eventhub/types"
+	"google.golang.org/protobuf/proto"
+)
+
+// newSharedStore returns a client of an in-memory Redis server, which is used as the shared store.
+func newSharedStore(t *testing.T) (*miniredis.Miniredis, *redis.Client) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return server, client
+}
+
+func TestSharedStore(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	applicationMap, subscriptionMap := ApplicationMap, SubscriptionMap
+	server, client := newSharedStore(t)
+	sharedStoreKeyPrefix = "test:"
+	conf.Adapter.RedisStore.Enabled = true
+	setSharedStore(client)
+	defer func() {
+		conf.Adapter.RedisStore.Enabled = false
+		setSharedStore(nil)
+		ApplicationMap, SubscriptionMap = applicationMap, subscriptionMap
+		sharedVersions = make(map[string]map[string]int64)
+	}()
+	subscriber := server.NewSubscriber()
+	defer subscriber.Close()
+	subscriber.Subscribe("test:" + invalidationsChannel)
+	// the messages of the subscriber are buffered, as the publishing is blocked until those are received
+	invalidations := make(chan string, 16)
+	go func() {
+		for message := range subscriber.Messages() {
+			invalidations <- message.Message
+		}
+	}()
+	nextInvalidation := func() sharedStoreInvalidation {
+		var invalidation sharedStoreInvalidation
+		select {
+		case message := <-invalidations:
+			json.Unmarshal([]byte(message), &invalidation)
+		case <-time.After(time.Second):
+		}
+		return invalidation
+	}
+
+	// the changes of this adapter are written to the shared store
+	ApplicationMap = make(map[string]*subscription.Application)
+	MarshalApplicationEventAndReturnList(&types.Application{UUID: "app1", Name: "App1"}, CreateEvent)
+	assert.True(t, server.Exists("test:applications"))
+	assert.NotEmpty(t, server.HGet("test:applications", "app1"))
+	assert.NotEmpty(t, server.HGet("test:applications:versions", "app1"), "Changes should be versioned")
+	assert.Equal(t, sharedStoreInvalidation{Origin: sharedStoreOrigin, Resource: sharedApplications, Key: "app1"},
+		nextInvalidation())
+	MarshalMultipleSubscriptions(&types.SubscriptionList{List: []types.Subscription{
+		{SubscriptionID: 1, APIUUID: "api1", ApplicationUUID: "app1"},
+		{SubscriptionID: 2, APIUUID: "api2", ApplicationUUID: "app1"},
+	}})
+	subscriptionKeys, _ := server.HKeys("test:subscriptions")
+	assert.Len(t, subscriptionKeys, 2)
+	assert.Equal(t, "", nextInvalidation().Key, "Replacing the subscriptions should invalidate all of them")
+
+	// the changes of the other adapters are read from the shared store
+	later := strconv.FormatInt(time.Now().Add(time.Minute).UnixNano(), 10)
+	value, _ := proto.Marshal(&subscription.Application{Uuid: "app2", Name: "App2"})
+	server.HSet("test:applications", "app2", string(value))
+	server.HSet("test:applications:versions", "app2", later, "app1", later)
+	server.HDel("test:applications", "app1")
+	loadSharedResource(client, sharedApplications, "app2")
+	loadSharedResource(client, sharedApplications, "app1")
+	assert.Equal(t, 1, len(ApplicationMap))
+	assert.Equal(t, "App2", ApplicationMap["app2"].Name)
+
+	SubscriptionMap = make(map[int32]*subscription.Subscription)
+	sharedVersions[sharedSubscriptions] = nil
+	syncSharedResources(client, sharedSubscriptions)
+	assert.Equal(t, 2, len(SubscriptionMap), "Subscriptions should be loaded from the shared store")
+	assert.Equal(t, "api2", SubscriptionMap[2].ApiUUID)
+
+	// the local changes made while the shared store is not reachable are not overwritten once reconnected
+	setSharedStore(nil)
+	MarshalApplicationEventAndReturnList(&types.Application{UUID: "app2", Name: "App2 (updated)"}, UpdateEvent)
+	MarshalApplicationEventAndReturnList(&types.Application{UUID: "app3", Name: "App3"}, CreateEvent)
+	setSharedStore(client)
+	server.HSet("test:applications:versions", "app2", "1")
+	syncSharedResources(client, sharedApplications)
+	assert.Equal(t, "App2 (updated)", ApplicationMap["app2"].Name, "Later local changes should be kept")
+	applicationKeys, _ := server.HKeys("test:applications")
+	assert.Len(t, applicationKeys, 2, "Local changes should be written to the shared store")
+
+	// an earlier change of another adapter is ignored
+	value, _ = proto.Marshal(&subscription.Application{Uuid: "app3", Name: "App3 (stale)"})
+	server.HSet("test:applications", "app3", string(value))
+	server.HSet("test:applications:versions", "app3", "1")
+	loadSharedResource(client, sharedApplications, "app3")
+	assert.Equal(t, "App3", ApplicationMap["app3"].Name, "Earlier changes of the shared store should be ignored")
+}
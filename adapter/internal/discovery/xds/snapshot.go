@@ -0,0 +1,156 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package xds
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	envoy_cachev3 "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+	envoy_resource "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+)
+
+// routerSnapshotState is the state of the last router snapshot set for a label. The version of a resource type is
+// bumped only when a resource of that type is added, modified or removed. Hence the routers subscribed with the state
+// of the world protocol receive only the changed resource types, while the routers subscribed with the incremental
+// (delta) protocol receive only the changed resources.
+type routerSnapshotState struct {
+	version        uint64
+	typeVersions   map[envoy_resource.Type]string
+	resourceHashes map[envoy_resource.Type]map[string]string
+}
+
+// resourceDiff summarizes the changes of a resource type compared to the last snapshot of a label.
+type resourceDiff struct {
+	added    int
+	modified int
+	removed  int
+}
+
+var (
+	// routerSnapshotStates holds the snapshot state of each label. Guarded by mutexForXdsUpdate.
+	routerSnapshotStates = make(map[string]*routerSnapshotState)
+	// routerSnapshotEpoch distinguishes the snapshot versions of this adapter instance from the versions served by a
+	// previous instance, which the routers may still hold after the adapter restarts.
+	routerSnapshotEpoch = time.Now().Unix()
+	routerResourceTypes = []envoy_resource.Type{envoy_resource.EndpointType, envoy_resource.ClusterType,
+		envoy_resource.RouteType, envoy_resource.ListenerType}
+)
+
+func (diff resourceDiff) changed() bool {
+	return diff.added > 0 || diff.modified > 0 || diff.removed > 0
+}
+
+// newRouterSnapshot composes the router snapshot of the label by comparing the given resources with the last snapshot
+// of the label. A nil snapshot is returned when none of the resources have changed. The returned state should be
+// stored with the snapshot, once the snapshot is set to the cache.
+func newRouterSnapshot(label string, resources map[envoy_resource.Type][]types.Resource) (*envoy_cachev3.Snapshot,
+	*routerSnapshotState, map[envoy_resource.Type]resourceDiff, error) {
+	previous := routerSnapshotStates[label]
+	changed := previous == nil
+	diffs := make(map[envoy_resource.Type]resourceDiff, len(routerResourceTypes))
+	hashes := make(map[envoy_resource.Type]map[string]string, len(routerResourceTypes))
+	for _, typeURL := range routerResourceTypes {
+		typeHashes, err := hashResources(resources[typeURL])
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		var previousHashes map[string]string
+		if previous != nil {
+			previousHashes = previous.resourceHashes[typeURL]
+		}
+		hashes[typeURL] = typeHashes
+		diffs[typeURL] = diffResourceHashes(previousHashes, typeHashes)
+		changed = changed || diffs[typeURL].changed()
+	}
+	if !changed {
+		return nil, previous, diffs, nil
+	}
+
+	state := &routerSnapshotState{
+		typeVersions:   make(map[envoy_resource.Type]string, len(routerResourceTypes)),
+		resourceHashes: hashes,
+	}
+	if previous != nil {
+		state.version = previous.version
+	}
+	state.version++
+	snapshot := &envoy_cachev3.Snapshot{VersionMap: make(map[string]map[string]string, len(routerResourceTypes))}
+	for _, typeURL := range routerResourceTypes {
+		typeVersion := fmt.Sprintf("%d.%d", routerSnapshotEpoch, state.version)
+		if previous != nil && !diffs[typeURL].changed() {
+			typeVersion = previous.typeVersions[typeURL]
+		}
+		state.typeVersions[typeURL] = typeVersion
+		snapshot.Resources[envoy_cachev3.GetResponseType(typeURL)] = envoy_cachev3.NewResources(typeVersion,
+			resources[typeURL])
+		// The hashes are reused as the resource versions of the incremental protocol, instead of letting the cache
+		// marshal all the resources again.
+		snapshot.VersionMap[typeURL] = hashes[typeURL]
+	}
+	return snapshot, state, diffs, nil
+}
+
+// hashResources returns the hash of each resource by the resource name. The hashes of the resources of the cached
+// API fragments are reused.
+func hashResources(resources []types.Resource) (map[string]string, error) {
+	hashes := make(map[string]string, len(resources))
+	for _, resource := range resources {
+		if hash, found := getFragmentResourceHash(resource); found {
+			hashes[envoy_cachev3.GetResourceName(resource)] = hash
+			continue
+		}
+		marshalledResource, err := envoy_cachev3.MarshalResource(resource)
+		if err != nil {
+			return nil, err
+		}
+		hashes[envoy_cachev3.GetResourceName(resource)] = envoy_cachev3.HashResource(marshalledResource)
+	}
+	return hashes, nil
+}
+
+func diffResourceHashes(previous, current map[string]string) resourceDiff {
+	var diff resourceDiff
+	for name, hash := range current {
+		if previousHash, found := previous[name]; !found {
+			diff.added++
+		} else if previousHash != hash {
+			diff.modified++
+		}
+	}
+	for name := range previous {
+		if _, found := current[name]; !found {
+			diff.removed++
+		}
+	}
+	return diff
+}
+
+// describeResourceDiffs returns a summary of the changed resource types, to be logged.
+func describeResourceDiffs(diffs map[envoy_resource.Type]resourceDiff) string {
+	var changes []string
+	for _, typeURL := range routerResourceTypes {
+		if diff := diffs[typeURL]; diff.changed() {
+			changes = append(changes, fmt.Sprintf("%s: %d added, %d modified, %d removed",
+				typeURL[strings.LastIndex(typeURL, ".")+1:], diff.added, diff.modified, diff.removed))
+		}
+	}
+	return strings.Join(changes, "; ")
+}
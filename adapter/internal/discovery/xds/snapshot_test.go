@@ -0,0 +1,111 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package xds
+
+import (
+	"testing"
+
+	clusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	listenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	envoy_resource "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestNewRouterSnapshot(t *testing.T) {
+	label := "snapshotTestLabel"
+	defer delete(routerSnapshotStates, label)
+	clusterFoo := &clusterv3.Cluster{Name: "clusterFoo"}
+	clusterBar := &clusterv3.Cluster{Name: "clusterBar"}
+	resources := map[envoy_resource.Type][]types.Resource{
+		envoy_resource.ClusterType:  {clusterFoo, clusterBar},
+		envoy_resource.RouteType:    {&routev3.RouteConfiguration{Name: "default"}},
+		envoy_resource.ListenerType: {&listenerv3.Listener{Name: "httpListener"}},
+	}
+
+	snapshot, state, diffs, err := newRouterSnapshot(label, resources)
+	assert.Nil(t, err)
+	assert.NotNil(t, snapshot)
+	assert.Equal(t, uint64(1), state.version)
+	assert.Equal(t, resourceDiff{added: 2}, diffs[envoy_resource.ClusterType])
+	assert.Len(t, snapshot.GetResources(envoy_resource.ClusterType), 2)
+	assert.Len(t, snapshot.GetVersionMap(envoy_resource.ClusterType), 2)
+	firstListenerVersion := snapshot.GetVersion(envoy_resource.ListenerType)
+	routerSnapshotStates[label] = state
+
+	snapshot, state, _, err = newRouterSnapshot(label, resources)
+	assert.Nil(t, err)
+	assert.Nil(t, snapshot, "Snapshot should not be created when the resources are unchanged.")
+	assert.Equal(t, uint64(1), state.version)
+
+	resources[envoy_resource.ClusterType] = []types.Resource{
+		&clusterv3.Cluster{Name: "clusterFoo", ConnectTimeout: durationpb.New(5)},
+		&clusterv3.Cluster{Name: "clusterBaz"},
+	}
+	snapshot, state, diffs, err = newRouterSnapshot(label, resources)
+	assert.Nil(t, err)
+	assert.NotNil(t, snapshot)
+	assert.Equal(t, uint64(2), state.version)
+	assert.Equal(t, resourceDiff{added: 1, modified: 1, removed: 1}, diffs[envoy_resource.ClusterType])
+	assert.False(t, diffs[envoy_resource.ListenerType].changed())
+	assert.NotEqual(t, firstListenerVersion, snapshot.GetVersion(envoy_resource.ClusterType),
+		"Version of the changed resource type should be bumped.")
+	assert.Equal(t, firstListenerVersion, snapshot.GetVersion(envoy_resource.ListenerType),
+		"Version of the unchanged resource type should be kept.")
+	assert.Equal(t, "Cluster: 1 added, 1 modified, 1 removed", describeResourceDiffs(diffs))
+}
+
+func TestAPIFragments(t *testing.T) {
+	organizationID, apiIdentifier, label := "fragment-org", "foo.com:fragment-api", "Default"
+	var mgwSwagger model.MgwSwagger
+	mgwSwagger.SetID("fragment-api")
+	cluster := &clusterv3.Cluster{Name: "clusterFragment"}
+	orgIDAPIMgwSwaggerMap[organizationID] = map[string]model.MgwSwagger{apiIdentifier: mgwSwagger}
+	orgIDOpenAPIRoutesMap[organizationID] = map[string][]*routev3.Route{apiIdentifier: {{Name: "routeFragment"}}}
+	orgIDOpenAPIClustersMap[organizationID] = map[string][]*clusterv3.Cluster{apiIdentifier: {cluster}}
+	defer func() {
+		invalidateAPIFragment(organizationID, apiIdentifier)
+		delete(orgIDAPIMgwSwaggerMap, organizationID)
+		delete(orgIDOpenAPIRoutesMap, organizationID)
+		delete(orgIDOpenAPIClustersMap, organizationID)
+	}()
+
+	fragment, found, err := getAPIFragment(organizationID, apiIdentifier, label, "foo.com")
+	assert.Nil(t, err)
+	assert.True(t, found)
+	assert.Equal(t, "fragment-api", fragment.apiUUID)
+	assert.Len(t, fragment.routes, 1)
+	_, hashed := getFragmentResourceHash(cluster)
+	assert.True(t, hashed, "Clusters of the fragment should be hashed")
+
+	cachedFragment, _, _ := getAPIFragment(organizationID, apiIdentifier, label, "foo.com")
+	assert.Same(t, fragment, cachedFragment, "Fragment of the unchanged API should be reused")
+
+	invalidateAPIFragment(organizationID, apiIdentifier)
+	_, hashed = getFragmentResourceHash(cluster)
+	assert.False(t, hashed, "Hashes of the invalidated fragment should be removed")
+	updatedFragment, _, _ := getAPIFragment(organizationID, apiIdentifier, label, "foo.com")
+	assert.NotSame(t, fragment, updatedFragment, "Fragment of the changed API should be composed again")
+
+	_, found, err = getAPIFragment(organizationID, "foo.com:undeployed-api", label, "foo.com")
+	assert.Nil(t, err)
+	assert.False(t, found)
+}
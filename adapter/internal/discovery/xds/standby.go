@@ -0,0 +1,238 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package xds
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	"github.com/wso2/product-microgateway/adapter/config"
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/envoyconf"
+	"github.com/wso2/product-microgateway/adapter/pkg/logging"
+)
+
+// StandbyStatus represents whether the site serves the API requests.
+type StandbyStatus struct {
+	Standby     bool      `json:"standby"`
+	Reason      string    `json:"reason,omitempty"`
+	ChangedAt   time.Time `json:"changedAt,omitempty"`
+	RedirectURL string    `json:"redirectURL,omitempty"`
+}
+
+var (
+	standbyStatus      StandbyStatus
+	standbyStatusOnce  sync.Once
+	standbyStatusMutex sync.RWMutex
+)
+
+// loadStandbyStatus loads the initial status from the config, at the first access of the status. The status
+// persisted by the previous runs of the adapter is restored, hence an activated site is not switched back to the
+// standby mode on a restart.
+func loadStandbyStatus() {
+	standbyStatusOnce.Do(func() {
+		conf, _ := config.ReadConfigs()
+		standbyStatus.Standby = conf.Adapter.Standby.Enabled
+		standbyStatus.RedirectURL = conf.Adapter.Standby.RedirectURL
+		if !standbyStatus.Standby {
+			return
+		}
+		standbyStatus.Reason = "Started in the standby mode"
+		standbyStatus.ChangedAt = time.Now().UTC()
+		persistedStatus, err := readStandbyStatus(conf.Adapter.Standby.StatusFilePath)
+		if err != nil {
+			logger.LoggerXds.ErrorC(logging.ErrorDetails{
+				Message: fmt.Sprintf("Error while reading the persisted standby status, hence the site is started "+
+					"in the standby mode. %v", err),
+				Severity:  logging.MAJOR,
+				ErrorCode: 1434,
+			})
+		} else if persistedStatus != nil {
+			standbyStatus.Standby = persistedStatus.Standby
+			standbyStatus.Reason = persistedStatus.Reason
+			standbyStatus.ChangedAt = persistedStatus.ChangedAt
+		}
+	})
+}
+
+// GetStandbyStatus returns whether the site is in the standby mode.
+func GetStandbyStatus() StandbyStatus {
+	loadStandbyStatus()
+	standbyStatusMutex.RLock()
+	defer standbyStatusMutex.RUnlock()
+	return standbyStatus
+}
+
+// SetStandby switches the site to the standby mode or activates it, and returns whether the mode is changed.
+// The routes of the APIs are updated in the router if the mode is changed.
+func SetStandby(standby bool, reason string) bool {
+	loadStandbyStatus()
+	standbyStatusMutex.Lock()
+	if standbyStatus.Standby == standby {
+		standbyStatusMutex.Unlock()
+		return false
+	}
+	standbyStatus.Standby = standby
+	standbyStatus.Reason = reason
+	standbyStatus.ChangedAt = time.Now().UTC()
+	conf, _ := config.ReadConfigs()
+	if err := persistStandbyStatus(conf.Adapter.Standby.StatusFilePath, standbyStatus); err != nil {
+		logger.LoggerXds.ErrorC(logging.ErrorDetails{
+			Message: fmt.Sprintf("Error while persisting the standby status, hence the status is not retained "+
+				"on a restart. %v", err),
+			Severity:  logging.MAJOR,
+			ErrorCode: 1435,
+		})
+	}
+	standbyStatusMutex.Unlock()
+	if standby {
+		logger.LoggerXds.Infof("Site is switched to the standby mode. %s", reason)
+	} else {
+		logger.LoggerXds.Infof("Site is activated. %s", reason)
+	}
+	UpdateXdsCacheForLabels(nil)
+	return true
+}
+
+// readStandbyStatus reads the persisted status. Nil is returned if the status is not persisted.
+func readStandbyStatus(filePath string) (*StandbyStatus, error) {
+	if filePath == "" {
+		return nil, nil
+	}
+	content, err := ioutil.ReadFile(filePath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	status := &StandbyStatus{}
+	if err = json.Unmarshal(content, status); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// persistStandbyStatus writes the status to the file, replacing the previous status. The status is not persisted
+// if the file is not configured.
+func persistStandbyStatus(filePath string, status StandbyStatus) error {
+	if filePath == "" {
+		return nil
+	}
+	content, err := json.Marshal(status)
+	if err != nil {
+		return err
+	}
+	tempFile, err := ioutil.TempFile(filepath.Dir(filePath), "."+filepath.Base(filePath)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tempFile.Name())
+	if _, err = tempFile.Write(content); err != nil {
+		tempFile.Close()
+		return err
+	}
+	if err = tempFile.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tempFile.Name(), filePath)
+}
+
+// getStandbyRoutes replaces the routes of the APIs with the routes which do not route the requests to the
+// backends, if the site is in the standby mode.
+func getStandbyRoutes(vhostToRouteArrayMap map[string][]*routev3.Route) map[string][]*routev3.Route {
+	status := GetStandbyStatus()
+	if !status.Standby {
+		return vhostToRouteArrayMap
+	}
+	var redirectURL *url.URL
+	if status.RedirectURL != "" {
+		var err error
+		if redirectURL, err = url.Parse(status.RedirectURL); err != nil || redirectURL.Host == "" {
+			logger.LoggerXds.ErrorC(logging.ErrorDetails{
+				Message: fmt.Sprintf("Invalid redirect URL %q of the standby mode, hence the API requests are "+
+					"responded with 503. %v", status.RedirectURL, err),
+				Severity:  logging.MINOR,
+				ErrorCode: 1421,
+			})
+			redirectURL = nil
+		}
+	}
+	standbyRouteArrayMap := make(map[string][]*routev3.Route, len(vhostToRouteArrayMap))
+	for vhost, routes := range vhostToRouteArrayMap {
+		standbyRouteArrayMap[vhost] = envoyconf.CreateStandbyRoutes(routes, redirectURL)
+	}
+	return standbyRouteArrayMap
+}
+
+// StartPrimaryHealthCheck checks the health of the primary site periodically, while the site is in the standby
+// mode. The site is activated when the consecutive failed health checks reach the threshold. The site is not
+// switched back to the standby mode automatically, when the primary site is healthy again.
+func StartPrimaryHealthCheck() {
+	conf, _ := config.ReadConfigs()
+	healthCheck := conf.Adapter.Standby.PrimaryHealthCheck
+	if !conf.Adapter.Standby.Enabled || !healthCheck.Enabled {
+		return
+	}
+	if healthCheck.URL == "" {
+		logger.LoggerXds.ErrorC(logging.ErrorDetails{
+			Message:   "Health endpoint of the primary site is not configured, hence the site is not activated automatically.",
+			Severity:  logging.MAJOR,
+			ErrorCode: 1422,
+		})
+		return
+	}
+	client := &http.Client{Timeout: time.Duration(healthCheck.TimeoutInSeconds) * time.Second}
+	failures := 0
+	for range time.Tick(time.Duration(healthCheck.IntervalInSeconds) * time.Second) {
+		if !GetStandbyStatus().Standby {
+			failures = 0
+			continue
+		}
+		if err := checkPrimaryHealth(client, healthCheck.URL); err != nil {
+			failures++
+			logger.LoggerXds.Warnf("Health check of the primary site failed (%d/%d). %v", failures,
+				healthCheck.FailureThreshold, err)
+		} else {
+			failures = 0
+		}
+		if failures >= healthCheck.FailureThreshold {
+			SetStandby(false, fmt.Sprintf("Primary site failed %d consecutive health checks", failures))
+			failures = 0
+		}
+	}
+}
+
+func checkPrimaryHealth(client *http.Client, healthURL string) error {
+	resp, err := client.Get(healthURL)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("health endpoint responded with %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package xds
+
+import (
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-microgateway/adapter/config"
+)
+
+func TestPersistedStandbyStatus(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	defaultStandby := conf.Adapter.Standby
+	loadStandbyStatus()
+	status := standbyStatus
+	defer func() {
+		conf.Adapter.Standby = defaultStandby
+		standbyStatus = status
+	}()
+	conf.Adapter.Standby.Enabled = true
+	conf.Adapter.Standby.StatusFilePath = filepath.Join(t.TempDir(), "standby.json")
+	standbyStatus = StandbyStatus{Standby: true}
+
+	assert.True(t, SetStandby(false, "Activated by the operator"), "Site is not activated")
+	persisted, err := readStandbyStatus(conf.Adapter.Standby.StatusFilePath)
+	assert.Nil(t, err, "Error while reading the persisted standby status")
+	assert.False(t, persisted.Standby, "Persisted standby status mismatch")
+	assert.Equal(t, "Activated by the operator", persisted.Reason, "Persisted reason mismatch")
+
+	// restart
+	standbyStatusOnce = sync.Once{}
+	standbyStatus = StandbyStatus{}
+	assert.False(t, GetStandbyStatus().Standby, "Activated site is switched to the standby mode on a restart")
+}
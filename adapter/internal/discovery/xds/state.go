@@ -0,0 +1,76 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package xds
+
+import (
+	"sort"
+
+	envoy_resource "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+)
+
+// GatewayState represents the configuration applied to the routers of a gateway environment.
+type GatewayState struct {
+	Label string `json:"label"`
+	// XdsVersion is the version of the latest xDS snapshot of the environment.
+	XdsVersion   string `json:"xdsVersion,omitempty"`
+	APICount     int    `json:"apiCount"`
+	RouteCount   int    `json:"routeCount"`
+	ClusterCount int    `json:"clusterCount"`
+}
+
+// GetGatewayStates returns the configuration state of each gateway environment, sorted by the label.
+func GetGatewayStates() []GatewayState {
+	mutexForInternalMapUpdate.Lock()
+	defer mutexForInternalMapUpdate.Unlock()
+
+	statesMap := make(map[string]*GatewayState)
+	getState := func(label string) *GatewayState {
+		if _, found := statesMap[label]; !found {
+			statesMap[label] = &GatewayState{Label: label}
+		}
+		return statesMap[label]
+	}
+	for organizationID, apiEnvsMap := range orgIDOpenAPIEnvoyMap {
+		for apiIdentifier, labels := range apiEnvsMap {
+			for _, label := range labels {
+				state := getState(label)
+				state.APICount++
+				state.RouteCount += len(orgIDOpenAPIRoutesMap[organizationID][apiIdentifier])
+				state.ClusterCount += len(orgIDOpenAPIClustersMap[organizationID][apiIdentifier])
+			}
+		}
+	}
+	// Routers connected to the environments without APIs are also included
+	for _, label := range cache.GetStatusKeys() {
+		getState(label)
+	}
+	for label, state := range statesMap {
+		if snapshot, err := cache.GetSnapshot(label); err == nil {
+			state.XdsVersion = snapshot.GetVersion(envoy_resource.RouteType)
+		}
+	}
+
+	states := make([]GatewayState, 0, len(statesMap))
+	for _, state := range statesMap {
+		states = append(states, *state)
+	}
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].Label < states[j].Label
+	})
+	return states
+}
@@ -0,0 +1,117 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package xds
+
+import (
+	"time"
+
+	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	"github.com/wso2/product-microgateway/adapter/pkg/discovery/api/wso2/discovery/keymgt"
+	"github.com/wso2/product-microgateway/adapter/pkg/discovery/api/wso2/discovery/subscription"
+)
+
+// StoreCompaction represents the number of entries removed from the in-memory stores by a compaction.
+type StoreCompaction struct {
+	// ExpiredRevokedTokens are the revoked tokens, which are expired
+	ExpiredRevokedTokens int `json:"expiredRevokedTokens"`
+	// DuplicateKeyMappings are the key mappings replaced by a later key mapping of the same application, key type
+	// and key manager
+	DuplicateKeyMappings int `json:"duplicateKeyMappings"`
+	// SupersededResourceLists are the subscription data lists replaced by a later list of the same type
+	SupersededResourceLists int `json:"supersededResourceLists"`
+}
+
+// CompactEnforcerStores removes the stale entries from the stores of the subscription data and the revoked
+// tokens. The enforcers are updated only if duplicate key mappings are removed.
+func CompactEnforcerStores(now time.Time) StoreCompaction {
+	var compaction StoreCompaction
+	keyMappingList := compactApplicationKeyMappings(&compaction)
+
+	mutexForEnforcerResourceUpdate.Lock()
+	compaction.ExpiredRevokedTokens = removeExpiredRevokedTokens(enforcerRevokedTokensMap, now)
+	// each list contains all the entries of its type, hence only the latest list is required
+	for _, resourceMap := range []map[string][]types.Resource{enforcerSubscriptionMap, enforcerApplicationMap,
+		enforcerAPIListMap, enforcerApplicationPolicyMap, enforcerSubscriptionPolicyMap,
+		enforcerApplicationKeyMappingMap, enforcerScopeMap} {
+		compaction.SupersededResourceLists += removeSupersededResourceLists(resourceMap)
+	}
+	mutexForEnforcerResourceUpdate.Unlock()
+
+	if keyMappingList != nil {
+		UpdateEnforcerApplicationKeyMappings(keyMappingList)
+	}
+	return compaction
+}
+
+// compactApplicationKeyMappings merges the key mappings of the same application, key type and key manager into
+// the latest one, as a consumer key replaces the previous one. The key mapping list is returned if any key
+// mapping is removed.
+func compactApplicationKeyMappings(compaction *StoreCompaction) *subscription.ApplicationKeyMappingList {
+	keyMappingMutex.Lock()
+	defer keyMappingMutex.Unlock()
+	// application:keyType:keyManager -> reference of the latest key mapping
+	latestKeyMappings := make(map[string]string, len(ApplicationKeyMappingMap))
+	for reference, keyMapping := range ApplicationKeyMappingMap {
+		key := keyMapping.ApplicationUUID + ":" + keyMapping.KeyType + ":" + keyMapping.KeyManager
+		latestReference, found := latestKeyMappings[key]
+		if !found {
+			latestKeyMappings[key] = reference
+			continue
+		}
+		if ApplicationKeyMappingMap[latestReference].Timestamp < keyMapping.Timestamp {
+			latestKeyMappings[key] = reference
+			reference = latestReference
+		}
+		delete(ApplicationKeyMappingMap, reference)
+		keyMappingStore.remove(reference)
+		compaction.DuplicateKeyMappings++
+	}
+	if compaction.DuplicateKeyMappings == 0 {
+		return nil
+	}
+	return marshalKeyMappingMapToList(ApplicationKeyMappingMap)
+}
+
+func removeExpiredRevokedTokens(revokedTokensMap map[string][]types.Resource, now time.Time) int {
+	removed := 0
+	for label, tokens := range revokedTokensMap {
+		validTokens := make([]types.Resource, 0, len(tokens))
+		for _, resource := range tokens {
+			// expiry time of the revoked tokens is in milliseconds
+			if token, ok := resource.(*keymgt.RevokedToken); ok && token.Expirytime > 0 &&
+				token.Expirytime < now.UnixMilli() {
+				removed++
+				continue
+			}
+			validTokens = append(validTokens, resource)
+		}
+		revokedTokensMap[label] = validTokens
+	}
+	return removed
+}
+
+func removeSupersededResourceLists(resourceMap map[string][]types.Resource) int {
+	removed := 0
+	for label, resources := range resourceMap {
+		if len(resources) > 1 {
+			removed += len(resources) - 1
+			resourceMap[label] = []types.Resource{resources[len(resources)-1]}
+		}
+	}
+	return removed
+}
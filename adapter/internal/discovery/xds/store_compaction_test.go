@@ -0,0 +1,65 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package xds
+
+import (
+	"testing"
+	"time"
+
+	envoy_types "github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-microgateway/adapter/pkg/discovery/api/wso2/discovery/keymgt"
+	"github.com/wso2/product-microgateway/adapter/pkg/discovery/api/wso2/discovery/subscription"
+)
+
+func TestCompactEnforcerStores(t *testing.T) {
+	keyMappingMap := ApplicationKeyMappingMap
+	defer func() { ApplicationKeyMappingMap = keyMappingMap }()
+	ApplicationKeyMappingMap = map[string]*subscription.ApplicationKeyMapping{
+		"key1:Resident Key Manager": {ApplicationUUID: "app1", ConsumerKey: "key1", KeyType: "PRODUCTION",
+			KeyManager: "Resident Key Manager", Timestamp: 100},
+		"key2:Resident Key Manager": {ApplicationUUID: "app1", ConsumerKey: "key2", KeyType: "PRODUCTION",
+			KeyManager: "Resident Key Manager", Timestamp: 200},
+		"key3:Resident Key Manager": {ApplicationUUID: "app1", ConsumerKey: "key3", KeyType: "SANDBOX",
+			KeyManager: "Resident Key Manager", Timestamp: 50},
+	}
+	var compaction StoreCompaction
+	keyMappingList := compactApplicationKeyMappings(&compaction)
+	assert.Equal(t, 1, compaction.DuplicateKeyMappings, "Duplicate key mapping count mismatch")
+	assert.Equal(t, 2, len(keyMappingList.GetList()), "Key mapping count mismatch")
+	assert.NotContains(t, ApplicationKeyMappingMap, "key1:Resident Key Manager", "Replaced key mapping is retained")
+	assert.Nil(t, compactApplicationKeyMappings(&StoreCompaction{}), "Key mappings are updated without duplicates")
+
+	now := time.Now()
+	revokedTokensMap := map[string][]envoy_types.Resource{
+		commonEnforcerLabel: {
+			&keymgt.RevokedToken{Jti: "expired", Expirytime: now.Add(-time.Minute).UnixMilli()},
+			&keymgt.RevokedToken{Jti: "valid", Expirytime: now.Add(time.Minute).UnixMilli()},
+		},
+	}
+	assert.Equal(t, 1, removeExpiredRevokedTokens(revokedTokensMap, now), "Expired token count mismatch")
+	assert.Equal(t, "valid", revokedTokensMap[commonEnforcerLabel][0].(*keymgt.RevokedToken).GetJti(),
+		"Valid revoked token is removed")
+
+	latestList := &subscription.SubscriptionList{}
+	resourceMap := map[string][]envoy_types.Resource{
+		commonEnforcerLabel: {&subscription.SubscriptionList{}, &subscription.SubscriptionList{}, latestList},
+	}
+	assert.Equal(t, 2, removeSupersededResourceLists(resourceMap), "Superseded list count mismatch")
+	assert.Equal(t, []envoy_types.Resource{latestList}, resourceMap[commonEnforcerLabel], "Latest list is not retained")
+}
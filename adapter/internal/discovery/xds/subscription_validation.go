@@ -0,0 +1,126 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package xds
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+
+	"github.com/wso2/product-microgateway/adapter/config"
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
+	"github.com/wso2/product-microgateway/adapter/pkg/logging"
+)
+
+var (
+	// API UUID -> subscription validation of the API, set with the deploy events of the control plane
+	subscriptionValidationOverrides     = make(map[string]bool)
+	subscriptionValidationOverridesOnce sync.Once
+	subscriptionValidationOverrideMutex sync.RWMutex
+)
+
+// loadSubscriptionValidationOverrides loads the overrides persisted by the previous run of the adapter, at the first
+// access of the overrides.
+func loadSubscriptionValidationOverrides() {
+	subscriptionValidationOverridesOnce.Do(func() {
+		conf, _ := config.ReadConfigs()
+		filePath := conf.ControlPlane.SubscriptionValidationOverridesFilePath
+		if filePath == "" {
+			return
+		}
+		content, err := ioutil.ReadFile(filePath)
+		if os.IsNotExist(err) {
+			return
+		}
+		overrides := make(map[string]bool)
+		if err == nil {
+			err = json.Unmarshal(content, &overrides)
+		}
+		if err != nil {
+			logger.LoggerXds.ErrorC(logging.ErrorDetails{
+				Message: fmt.Sprintf("Error while reading the persisted subscription validation of the APIs, "+
+					"hence the subscription validation of the API artifacts is applied. %v", err),
+				Severity:  logging.MAJOR,
+				ErrorCode: 1432,
+			})
+			return
+		}
+		subscriptionValidationOverrideMutex.Lock()
+		subscriptionValidationOverrides = overrides
+		subscriptionValidationOverrideMutex.Unlock()
+	})
+}
+
+// persistSubscriptionValidationOverrides writes the overrides to the file, if the file path is configured. Should
+// be called with the subscriptionValidationOverrideMutex.
+func persistSubscriptionValidationOverrides() {
+	conf, _ := config.ReadConfigs()
+	filePath := conf.ControlPlane.SubscriptionValidationOverridesFilePath
+	if filePath == "" {
+		return
+	}
+	content, err := json.Marshal(subscriptionValidationOverrides)
+	if err == nil {
+		err = writeFileAtomically(filePath, content)
+	}
+	if err != nil {
+		logger.LoggerXds.ErrorC(logging.ErrorDetails{
+			Message: fmt.Sprintf("Error while persisting the subscription validation of the APIs at %s, hence the "+
+				"subscription validation of the API artifacts is applied after a restart. %v", filePath, err),
+			Severity:  logging.MAJOR,
+			ErrorCode: 1433,
+		})
+	}
+}
+
+// SetSubscriptionValidationOverride sets the subscription validation of an API received with a deploy event,
+// which overrides the subscription validation of the API artifact when the API is deployed. The override is
+// removed if nil. The overrides are persisted, hence applied to the APIs fetched at the next startup.
+func SetSubscriptionValidationOverride(apiUUID string, enabled *bool) {
+	loadSubscriptionValidationOverrides()
+	subscriptionValidationOverrideMutex.Lock()
+	defer subscriptionValidationOverrideMutex.Unlock()
+	existing, found := subscriptionValidationOverrides[apiUUID]
+	if enabled == nil {
+		if !found {
+			return
+		}
+		delete(subscriptionValidationOverrides, apiUUID)
+	} else {
+		if found && existing == *enabled {
+			return
+		}
+		subscriptionValidationOverrides[apiUUID] = *enabled
+	}
+	persistSubscriptionValidationOverrides()
+}
+
+// applySubscriptionValidationOverride overrides the subscription validation of the API with the one received
+// with the deploy event of the API, if any.
+func applySubscriptionValidationOverride(mgwSwagger *model.MgwSwagger) {
+	loadSubscriptionValidationOverrides()
+	subscriptionValidationOverrideMutex.RLock()
+	enabled, found := subscriptionValidationOverrides[mgwSwagger.GetID()]
+	subscriptionValidationOverrideMutex.RUnlock()
+	if found {
+		mgwSwagger.SetSubscriptionValidation(&enabled)
+	}
+}
@@ -0,0 +1,55 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package xds
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-microgateway/adapter/config"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
+)
+
+func TestPersistedSubscriptionValidationOverrides(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	defaultFilePath := conf.ControlPlane.SubscriptionValidationOverridesFilePath
+	loadSubscriptionValidationOverrides()
+	overrides := subscriptionValidationOverrides
+	defer func() {
+		conf.ControlPlane.SubscriptionValidationOverridesFilePath = defaultFilePath
+		subscriptionValidationOverrides = overrides
+	}()
+	subscriptionValidationOverrides = make(map[string]bool)
+	filePath := filepath.Join(t.TempDir(), "subscription-validation.json")
+	conf.ControlPlane.SubscriptionValidationOverridesFilePath = filePath
+
+	disabled, enabled := false, true
+	SetSubscriptionValidationOverride("api-1", &disabled)
+	SetSubscriptionValidationOverride("api-2", &enabled)
+	SetSubscriptionValidationOverride("api-2", nil)
+	content, err := ioutil.ReadFile(filePath)
+	assert.Nil(t, err, "Error while reading the persisted subscription validation overrides")
+	assert.JSONEq(t, `{"api-1": false}`, string(content), "Persisted subscription validation overrides mismatch")
+
+	var mgwSwagger model.MgwSwagger
+	mgwSwagger.SetID("api-1")
+	applySubscriptionValidationOverride(&mgwSwagger)
+	assert.False(t, *mgwSwagger.GetSubscriptionValidation(), "Subscription validation override is not applied")
+}
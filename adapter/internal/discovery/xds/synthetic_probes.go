@@ -0,0 +1,204 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package xds
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wso2/product-microgateway/adapter/config"
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/pkg/metrics"
+	"github.com/wso2/product-microgateway/adapter/pkg/tlsutils"
+)
+
+// Health statuses of the APIs, reported by the synthetic probes
+const (
+	APIStatusHealthy  string = "HEALTHY"
+	APIStatusDegraded string = "DEGRADED"
+)
+
+// APIProbeStatus represents the results of the synthetic probes of an API.
+type APIProbeStatus struct {
+	APIName             string    `json:"apiName"`
+	Version             string    `json:"version"`
+	Vhost               string    `json:"vhost"`
+	Status              string    `json:"status"`
+	ConsecutiveFailures int       `json:"consecutiveFailures"`
+	LastProbedAt        time.Time `json:"lastProbedAt"`
+	LastLatencyMillis   int64     `json:"lastLatencyMillis"`
+	LastStatusCode      int       `json:"lastStatusCode,omitempty"`
+	LastError           string    `json:"lastError,omitempty"`
+}
+
+var (
+	// API identifier -> probe status
+	apiProbeStatuses   = make(map[string]*APIProbeStatus)
+	apiProbeStatusLock sync.RWMutex
+)
+
+// GetAPIProbeStatuses returns the results of the synthetic probes of the deployed APIs.
+func GetAPIProbeStatuses() []APIProbeStatus {
+	apiProbeStatusLock.RLock()
+	defer apiProbeStatusLock.RUnlock()
+	statuses := make([]APIProbeStatus, 0, len(apiProbeStatuses))
+	for _, status := range apiProbeStatuses {
+		statuses = append(statuses, *status)
+	}
+	return statuses
+}
+
+// getAPIHealthStatus returns the health status of an API reported by the synthetic probes, or an empty string
+// if the API is not probed.
+func getAPIHealthStatus(apiIdentifier string) string {
+	apiProbeStatusLock.RLock()
+	defer apiProbeStatusLock.RUnlock()
+	if status, found := apiProbeStatuses[apiIdentifier]; found {
+		return status.Status
+	}
+	return ""
+}
+
+// StartSyntheticProbes invokes the configured health resources of the APIs through the router periodically, if
+// the synthetic monitoring is enabled. An API is marked degraded when the consecutive failed probes reach the
+// threshold, and healthy again at the next successful probe.
+func StartSyntheticProbes() {
+	conf, _ := config.ReadConfigs()
+	monitoring := conf.Adapter.SyntheticMonitoring
+	if !monitoring.Enabled || len(monitoring.Probes) == 0 {
+		return
+	}
+	client := &http.Client{
+		Timeout: time.Duration(monitoring.TimeoutInSeconds) * time.Second,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{RootCAs: tlsutils.GetTrustedCertPool(conf.Adapter.Truststore.Location)},
+		},
+		// the response of the health resource is checked, not the response of a redirected location
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	routerURL := strings.TrimSuffix(monitoring.RouterURL, "/")
+	logger.LoggerXds.Infof("Synthetic monitoring of %d APIs is started", len(monitoring.Probes))
+	for range time.Tick(time.Duration(monitoring.IntervalInSeconds) * time.Second) {
+		var wg sync.WaitGroup
+		for _, probe := range monitoring.Probes {
+			wg.Add(1)
+			go func(probe config.APIProbe) {
+				defer wg.Done()
+				runAPIProbe(client, routerURL, probe, monitoring.FailureThreshold)
+			}(probe)
+		}
+		wg.Wait()
+	}
+}
+
+func runAPIProbe(client *http.Client, routerURL string, probe config.APIProbe, failureThreshold int) {
+	vhost := probe.Vhost
+	if vhost == "" {
+		vhost, _, _ = config.GetDefaultVhost(config.DefaultGatewayName)
+	}
+	apiIdentifier := getAPIIdentifier(vhost, probe.APIName, probe.APIVersion)
+	mutexForInternalMapUpdate.Lock()
+	mgwSwagger, deployed := orgIDAPIMgwSwaggerMap[config.GetControlPlaneConnectedTenantDomain()][apiIdentifier]
+	mutexForInternalMapUpdate.Unlock()
+	apiLabel := probe.APIName + apiKeyFieldSeparator + probe.APIVersion
+	if !deployed {
+		// the results of an undeployed API are discarded
+		apiProbeStatusLock.Lock()
+		if _, found := apiProbeStatuses[apiIdentifier]; found {
+			delete(apiProbeStatuses, apiIdentifier)
+			metrics.DeleteAPIProbe(apiLabel, vhost)
+		}
+		apiProbeStatusLock.Unlock()
+		return
+	}
+
+	method := probe.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+	probeURL := routerURL + strings.TrimSuffix(mgwSwagger.GetXWso2Basepath(), "/") + "/" +
+		strings.TrimPrefix(probe.Resource, "/")
+	statusCode, latency, err := invokeAPIProbe(client, method, probeURL, vhost, probe.Headers)
+	if err == nil && !isExpectedProbeStatus(statusCode, probe.ExpectedStatusCode) {
+		err = fmt.Errorf("unexpected status code %d", statusCode)
+	}
+	metrics.ObserveAPIProbe(apiLabel, vhost, latency, err == nil)
+
+	apiProbeStatusLock.Lock()
+	defer apiProbeStatusLock.Unlock()
+	status, found := apiProbeStatuses[apiIdentifier]
+	if !found {
+		status = &APIProbeStatus{APIName: probe.APIName, Version: probe.APIVersion, Vhost: vhost,
+			Status: APIStatusHealthy}
+		apiProbeStatuses[apiIdentifier] = status
+	}
+	status.LastProbedAt = time.Now().UTC()
+	status.LastLatencyMillis = latency.Milliseconds()
+	status.LastStatusCode = statusCode
+	status.LastError = ""
+	if err == nil {
+		if status.Status == APIStatusDegraded {
+			logger.LoggerXds.Infof("API %s is healthy, as the synthetic probe succeeded", apiIdentifier)
+		}
+		status.ConsecutiveFailures = 0
+		status.Status = APIStatusHealthy
+		return
+	}
+	status.LastError = err.Error()
+	status.ConsecutiveFailures++
+	logger.LoggerXds.Debugf("Synthetic probe of the API %s failed (%d/%d). %v", apiIdentifier,
+		status.ConsecutiveFailures, failureThreshold, err)
+	if status.ConsecutiveFailures >= failureThreshold && status.Status != APIStatusDegraded {
+		status.Status = APIStatusDegraded
+		logger.LoggerXds.Warnf("API %s is degraded, as %d consecutive synthetic probes failed. %v", apiIdentifier,
+			status.ConsecutiveFailures, err)
+	}
+}
+
+func invokeAPIProbe(client *http.Client, method, probeURL, vhost string, headers map[string]string) (int,
+	time.Duration, error) {
+	req, err := http.NewRequest(method, probeURL, nil)
+	if err != nil {
+		return 0, 0, err
+	}
+	req.Host = vhost
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	start := time.Now()
+	resp, err := client.Do(req)
+	latency := time.Since(start)
+	if err != nil {
+		return 0, latency, err
+	}
+	resp.Body.Close()
+	return resp.StatusCode, latency, nil
+}
+
+func isExpectedProbeStatus(statusCode, expectedStatusCode int) bool {
+	if expectedStatusCode == 0 {
+		return statusCode >= 200 && statusCode < 300
+	}
+	return statusCode == expectedStatusCode
+}
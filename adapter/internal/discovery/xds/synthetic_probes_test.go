@@ -0,0 +1,71 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package xds
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-microgateway/adapter/config"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
+)
+
+func TestRunAPIProbe(t *testing.T) {
+	healthy := true
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/health", r.URL.Path)
+		assert.Equal(t, "probe.example.com", r.Host, "API is not invoked with the vhost")
+		if !healthy {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer backend.Close()
+
+	probe := config.APIProbe{APIName: "ProbedAPI", APIVersion: "1.0.0", Vhost: "probe.example.com", Resource: "health"}
+	apiIdentifier := getAPIIdentifier(probe.Vhost, probe.APIName, probe.APIVersion)
+	organizationID := config.GetControlPlaneConnectedTenantDomain()
+	runAPIProbe(backend.Client(), backend.URL, probe, 2)
+	assert.Empty(t, getAPIHealthStatus(apiIdentifier), "API which is not deployed is probed")
+
+	mutexForInternalMapUpdate.Lock()
+	if _, ok := orgIDAPIMgwSwaggerMap[organizationID]; !ok {
+		orgIDAPIMgwSwaggerMap[organizationID] = make(map[string]model.MgwSwagger)
+	}
+	orgIDAPIMgwSwaggerMap[organizationID][apiIdentifier] = model.MgwSwagger{}
+	mutexForInternalMapUpdate.Unlock()
+	defer func() {
+		mutexForInternalMapUpdate.Lock()
+		delete(orgIDAPIMgwSwaggerMap[organizationID], apiIdentifier)
+		mutexForInternalMapUpdate.Unlock()
+	}()
+
+	runAPIProbe(backend.Client(), backend.URL, probe, 2)
+	assert.Equal(t, APIStatusHealthy, getAPIHealthStatus(apiIdentifier))
+
+	healthy = false
+	runAPIProbe(backend.Client(), backend.URL, probe, 2)
+	assert.Equal(t, APIStatusHealthy, getAPIHealthStatus(apiIdentifier), "API is degraded before the threshold")
+	runAPIProbe(backend.Client(), backend.URL, probe, 2)
+	assert.Equal(t, APIStatusDegraded, getAPIHealthStatus(apiIdentifier))
+
+	healthy = true
+	runAPIProbe(backend.Client(), backend.URL, probe, 2)
+	assert.Equal(t, APIStatusHealthy, getAPIHealthStatus(apiIdentifier), "API is not healthy after a successful probe")
+}
@@ -0,0 +1,140 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package xds
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/constants"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
+	"github.com/wso2/product-microgateway/adapter/pkg/eventhub/types"
+)
+
+// WebSubRegistration is a subscription of an application to a WebSub API, which allows the application to
+// register its callbacks with the hub of the API.
+type WebSubRegistration struct {
+	SubscriptionUUID string `json:"subscriptionUUID"`
+	ApplicationUUID  string `json:"applicationUUID"`
+	State            string `json:"state"`
+}
+
+// WebSubHub is the hub of a deployed WebSub API.
+type WebSubHub struct {
+	APIUUID            string               `json:"apiUUID"`
+	Name               string               `json:"name"`
+	Version            string               `json:"version"`
+	Vhost              string               `json:"vhost"`
+	HubPath            string               `json:"hubPath"`
+	EventsReceiverPath string               `json:"eventsReceiverPath"`
+	Topics             []string             `json:"topics"`
+	Registrations      []WebSubRegistration `json:"registrations"`
+}
+
+var (
+	// API UUID -> subscription ID -> registration
+	webSubRegistrations     = make(map[string]map[int32]WebSubRegistration)
+	webSubRegistrationMutex sync.RWMutex
+)
+
+// resetWebSubRegistrations replaces the registrations with the subscriptions pulled from the control plane.
+func resetWebSubRegistrations(subscriptions []types.Subscription) {
+	registrations := make(map[string]map[int32]WebSubRegistration)
+	for _, sub := range subscriptions {
+		addWebSubRegistration(registrations, &sub)
+	}
+	webSubRegistrationMutex.Lock()
+	webSubRegistrations = registrations
+	webSubRegistrationMutex.Unlock()
+}
+
+// updateWebSubRegistration applies a subscription event received from the control plane to the registrations.
+// The registrations are kept for all the APIs as the subscriptions could be received before the API is deployed.
+func updateWebSubRegistration(sub *types.Subscription, eventType EventType) {
+	webSubRegistrationMutex.Lock()
+	defer webSubRegistrationMutex.Unlock()
+	if eventType == DeleteEvent {
+		if registrations, found := webSubRegistrations[sub.APIUUID]; found {
+			delete(registrations, sub.SubscriptionID)
+			if len(registrations) == 0 {
+				delete(webSubRegistrations, sub.APIUUID)
+			}
+		}
+		return
+	}
+	addWebSubRegistration(webSubRegistrations, sub)
+}
+
+func addWebSubRegistration(registrations map[string]map[int32]WebSubRegistration, sub *types.Subscription) {
+	if sub.APIUUID == "" {
+		return
+	}
+	if _, found := registrations[sub.APIUUID]; !found {
+		registrations[sub.APIUUID] = make(map[int32]WebSubRegistration)
+	}
+	registrations[sub.APIUUID][sub.SubscriptionID] = WebSubRegistration{
+		SubscriptionUUID: sub.SubscriptionUUID,
+		ApplicationUUID:  sub.ApplicationUUID,
+		State:            sub.SubscriptionState,
+	}
+}
+
+// GetWebSubHubs returns the hubs of the WebSub APIs deployed for the organization, with the registrations of
+// the subscribed applications.
+func GetWebSubHubs(organizationID string) []WebSubHub {
+	mutexForInternalMapUpdate.Lock()
+	hubs := make([]WebSubHub, 0)
+	for apiIdentifier, mgwSwagger := range orgIDAPIMgwSwaggerMap[organizationID] {
+		if mgwSwagger.GetAPIType() != constants.WEBSUB {
+			continue
+		}
+		hubs = append(hubs, newWebSubHub(apiIdentifier, mgwSwagger))
+	}
+	mutexForInternalMapUpdate.Unlock()
+
+	webSubRegistrationMutex.RLock()
+	for i := range hubs {
+		for _, registration := range webSubRegistrations[hubs[i].APIUUID] {
+			hubs[i].Registrations = append(hubs[i].Registrations, registration)
+		}
+		sort.Slice(hubs[i].Registrations, func(a, b int) bool {
+			return hubs[i].Registrations[a].SubscriptionUUID < hubs[i].Registrations[b].SubscriptionUUID
+		})
+	}
+	webSubRegistrationMutex.RUnlock()
+
+	sort.Slice(hubs, func(i, j int) bool {
+		return hubs[i].HubPath < hubs[j].HubPath
+	})
+	return hubs
+}
+
+func newWebSubHub(apiIdentifier string, mgwSwagger model.MgwSwagger) WebSubHub {
+	vhost, _ := ExtractVhostFromAPIIdentifier(apiIdentifier)
+	basePath := mgwSwagger.GetXWso2Basepath()
+	return WebSubHub{
+		APIUUID:            mgwSwagger.GetID(),
+		Name:               mgwSwagger.GetTitle(),
+		Version:            mgwSwagger.GetVersion(),
+		Vhost:              vhost,
+		HubPath:            basePath + constants.WebSubHubResource,
+		EventsReceiverPath: basePath + constants.WebSubEventsReceiverResource,
+		Topics:             append([]string{}, mgwSwagger.GetWebSubTopics()...),
+		Registrations:      []WebSubRegistration{},
+	}
+}
@@ -0,0 +1,63 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package xds
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
+	"github.com/wso2/product-microgateway/adapter/pkg/eventhub/types"
+)
+
+func TestWebSubHubs(t *testing.T) {
+	registrations := webSubRegistrations
+	defer func() {
+		webSubRegistrations = registrations
+	}()
+	var apiYaml model.APIYaml
+	apiYaml.Data.ID, apiYaml.Data.Name, apiYaml.Data.Version = "websub-api", "Orders", "1.0.0"
+	apiYaml.Data.Context, apiYaml.Data.APIType = "/orders", "WEBSUB"
+	webSubAPI := model.MgwSwagger{}
+	assert.Nil(t, webSubAPI.PopulateFromAPIYaml(apiYaml))
+	if _, ok := orgIDAPIMgwSwaggerMap["websub-org"]; !ok {
+		orgIDAPIMgwSwaggerMap["websub-org"] = make(map[string]model.MgwSwagger)
+	}
+	orgIDAPIMgwSwaggerMap["websub-org"]["foo.com:websub-api"] = webSubAPI
+	orgIDAPIMgwSwaggerMap["websub-org"]["foo.com:rest-api"] = model.MgwSwagger{}
+	defer delete(orgIDAPIMgwSwaggerMap, "websub-org")
+
+	resetWebSubRegistrations([]types.Subscription{
+		{SubscriptionID: 1, SubscriptionUUID: "sub1", APIUUID: "websub-api", ApplicationUUID: "app1",
+			SubscriptionState: "ACTIVE"},
+		{SubscriptionID: 2, SubscriptionUUID: "sub2", APIUUID: "other-api", ApplicationUUID: "app1"},
+	})
+	updateWebSubRegistration(&types.Subscription{SubscriptionID: 3, SubscriptionUUID: "sub3", APIUUID: "websub-api",
+		ApplicationUUID: "app2", SubscriptionState: "BLOCKED"}, CreateEvent)
+	updateWebSubRegistration(&types.Subscription{SubscriptionID: 1, APIUUID: "websub-api"}, DeleteEvent)
+
+	hubs := GetWebSubHubs("websub-org")
+	if assert.Len(t, hubs, 1, "Only the WebSub APIs should be listed") {
+		assert.Equal(t, "foo.com", hubs[0].Vhost)
+		assert.Equal(t, "/orders/1.0.0/", hubs[0].HubPath)
+		assert.Equal(t, "/orders/1.0.0/webhooks_events_receiver_resource", hubs[0].EventsReceiverPath)
+		assert.Equal(t, []WebSubRegistration{{SubscriptionUUID: "sub3", ApplicationUUID: "app2", State: "BLOCKED"}},
+			hubs[0].Registrations)
+	}
+	assert.Empty(t, GetWebSubHubs("other-org"))
+}
@@ -0,0 +1,101 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+// Package eventfilter decides whether the control plane events are applied, only logged or dropped, by the filter
+// rules of the configuration. Hence the adapters of multiple business units can share a broker, consuming only the
+// events of their own APIs.
+package eventfilter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/wso2/product-microgateway/adapter/config"
+)
+
+// Actions applied to the events matching a rule
+const (
+	ActionApply = "apply"
+	ActionLog   = "log"
+	ActionDrop  = "drop"
+)
+
+// rule is a compiled filter rule.
+type rule struct {
+	name       string
+	expression Expression
+	action     string
+}
+
+// Filter is the filter rules of the configuration, in the order those are evaluated.
+type Filter struct {
+	rules []rule
+}
+
+// NewFilter compiles the filter rules. An error is returned if the expression or the action of any rule is invalid.
+func NewFilter(rules []config.EventFilterRule) (*Filter, error) {
+	filter := &Filter{rules: make([]rule, 0, len(rules))}
+	for i, configuredRule := range rules {
+		name := configuredRule.Name
+		if name == "" {
+			name = fmt.Sprintf("rule-%d", i+1)
+		}
+		action := strings.ToLower(strings.TrimSpace(configuredRule.Action))
+		if action != ActionApply && action != ActionLog && action != ActionDrop {
+			return nil, fmt.Errorf("action %q of the event filter rule %s is not one of apply, log or drop",
+				configuredRule.Action, name)
+		}
+		expression, err := Compile(configuredRule.Expression)
+		if err != nil {
+			return nil, fmt.Errorf("invalid expression of the event filter rule %s. %v", name, err)
+		}
+		filter.rules = append(filter.rules, rule{name: name, expression: expression, action: action})
+	}
+	return filter, nil
+}
+
+// NewDropAllFilter returns a filter dropping all the events by the given rule name. Used where the rules of the
+// configuration are invalid, as the events meant to be dropped would be applied otherwise.
+func NewDropAllFilter(ruleName string) *Filter {
+	return &Filter{rules: []rule{{name: ruleName, expression: matchAll{}, action: ActionDrop}}}
+}
+
+// HasRules returns whether the filter has any rule, hence whether the attributes of the events are required.
+func (filter *Filter) HasRules() bool {
+	return filter != nil && len(filter.rules) > 0
+}
+
+// matchAll is the expression matching any event.
+type matchAll struct{}
+
+func (matchAll) Evaluate(map[string]string) bool {
+	return true
+}
+
+// Evaluate returns the action of the first rule matching the attributes of an event, and the name of the rule.
+// The events not matching any rule are applied, where the name of the rule is empty.
+func (filter *Filter) Evaluate(attributes map[string]string) (action string, ruleName string) {
+	if filter == nil {
+		return ActionApply, ""
+	}
+	for _, rule := range filter.rules {
+		if rule.expression.Evaluate(attributes) {
+			return rule.action, rule.name
+		}
+	}
+	return ActionApply, ""
+}
@@ -0,0 +1,97 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package eventfilter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-microgateway/adapter/config"
+)
+
+func TestCompileExpression(t *testing.T) {
+	attributes := map[string]string{
+		"tenantDomain": "bu1.com",
+		"apiProvider":  "bu1-admin",
+		"apiContext":   "/bu1/orders/1.0.0",
+		"eventType":    "DEPLOY_API_IN_GATEWAY",
+	}
+	tests := []struct {
+		expression string
+		expected   bool
+	}{
+		{`tenantDomain == "bu1.com"`, true},
+		{`tenantDomain != 'bu1.com'`, false},
+		{`apiProvider in ["bu2-admin", "bu1-admin"]`, true},
+		{`apiContext startsWith "/bu1/" and eventType endsWith "_GATEWAY"`, true},
+		{`apiContext contains "/payments/" or apiName == ""`, true},
+		{`apiContext matches "^/bu[0-9]+/orders/"`, true},
+		{`not (tenantDomain == "bu1.com" or apiProvider == "bu2-admin")`, false},
+		{`NOT tenantDomain == "bu2.com" AND apiContext STARTSWITH "/bu1/"`, true},
+		{`tenantDomain == "bu2.com" or apiProvider == "bu1-admin" and apiContext startsWith "/bu2/"`, false},
+		{`apiContext == "/bu1/\"quoted\""`, false},
+	}
+	for _, test := range tests {
+		expression, err := Compile(test.expression)
+		if assert.Nil(t, err, test.expression) {
+			assert.Equal(t, test.expected, expression.Evaluate(attributes), test.expression)
+		}
+	}
+
+	for _, invalid := range []string{``, `tenantDomain`, `tenantDomain = "bu1.com"`, `tenantDomain == bu1`,
+		`tenantDomain == "bu1.com`, `(tenantDomain == "bu1.com"`, `tenantDomain in "bu1.com"`,
+		`tenantDomain in ["bu1.com",]`, `apiContext matches "("`, `tenantDomain == "bu1.com" apiName == "orders"`,
+		`tenantDomain like "bu1%"`} {
+		_, err := Compile(invalid)
+		assert.NotNil(t, err, invalid)
+	}
+}
+
+func TestEvaluateFilter(t *testing.T) {
+	filter, err := NewFilter([]config.EventFilterRule{
+		{Name: "bu1-internal", Expression: `apiContext startsWith "/bu1/internal/"`, Action: "LOG"},
+		{Expression: `tenantDomain != "bu1.com"`, Action: "drop"},
+		{Name: "bu1", Expression: `tenantDomain == "bu1.com"`, Action: "apply"},
+	})
+	assert.Nil(t, err)
+	action, rule := filter.Evaluate(map[string]string{"tenantDomain": "bu1.com", "apiContext": "/bu1/internal/hr"})
+	assert.Equal(t, ActionLog, action)
+	assert.Equal(t, "bu1-internal", rule)
+	action, rule = filter.Evaluate(map[string]string{"tenantDomain": "bu2.com"})
+	assert.Equal(t, ActionDrop, action)
+	assert.Equal(t, "rule-2", rule, "Rule without a name should be named by its position")
+	action, rule = filter.Evaluate(map[string]string{"tenantDomain": "bu1.com", "apiContext": "/bu1/orders"})
+	assert.Equal(t, ActionApply, action)
+	assert.Equal(t, "bu1", rule)
+
+	var noFilter *Filter
+	action, rule = noFilter.Evaluate(map[string]string{"tenantDomain": "bu2.com"})
+	assert.Equal(t, ActionApply, action)
+	assert.Empty(t, rule)
+
+	_, err = NewFilter([]config.EventFilterRule{{Name: "invalid", Expression: `tenantDomain == "bu1.com"`,
+		Action: "ignore"}})
+	assert.NotNil(t, err)
+	_, err = NewFilter([]config.EventFilterRule{{Name: "invalid", Expression: `tenantDomain ==`, Action: "drop"}})
+	assert.NotNil(t, err)
+	assert.False(t, noFilter.HasRules())
+
+	action, rule = NewDropAllFilter("invalid-rules").Evaluate(map[string]string{"tenantDomain": "bu1.com"})
+	assert.Equal(t, ActionDrop, action, "Events should be dropped if the rules are invalid")
+	assert.Equal(t, "invalid-rules", rule)
+}
@@ -0,0 +1,325 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package eventfilter
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Operators comparing an attribute of an event with the values
+const (
+	opEquals     = "=="
+	opNotEquals  = "!="
+	opIn         = "in"
+	opStartsWith = "startswith"
+	opEndsWith   = "endswith"
+	opContains   = "contains"
+	opMatches    = "matches"
+)
+
+// Expression is a compiled filter expression, which is evaluated against the attributes of an event. An attribute
+// not present in the event is considered empty.
+type Expression interface {
+	Evaluate(attributes map[string]string) bool
+}
+
+type andExpression struct {
+	left, right Expression
+}
+
+func (e *andExpression) Evaluate(attributes map[string]string) bool {
+	return e.left.Evaluate(attributes) && e.right.Evaluate(attributes)
+}
+
+type orExpression struct {
+	left, right Expression
+}
+
+func (e *orExpression) Evaluate(attributes map[string]string) bool {
+	return e.left.Evaluate(attributes) || e.right.Evaluate(attributes)
+}
+
+type notExpression struct {
+	operand Expression
+}
+
+func (e *notExpression) Evaluate(attributes map[string]string) bool {
+	return !e.operand.Evaluate(attributes)
+}
+
+type comparison struct {
+	attribute string
+	operator  string
+	values    []string
+	pattern   *regexp.Regexp
+}
+
+func (e *comparison) Evaluate(attributes map[string]string) bool {
+	value := attributes[e.attribute]
+	switch e.operator {
+	case opEquals:
+		return value == e.values[0]
+	case opNotEquals:
+		return value != e.values[0]
+	case opIn:
+		for _, candidate := range e.values {
+			if value == candidate {
+				return true
+			}
+		}
+		return false
+	case opStartsWith:
+		return strings.HasPrefix(value, e.values[0])
+	case opEndsWith:
+		return strings.HasSuffix(value, e.values[0])
+	case opContains:
+		return strings.Contains(value, e.values[0])
+	case opMatches:
+		return e.pattern.MatchString(value)
+	}
+	return false
+}
+
+type tokenType int
+
+const (
+	tokenEOF tokenType = iota
+	tokenIdentifier
+	tokenString
+	tokenSymbol
+)
+
+type token struct {
+	kind     tokenType
+	value    string
+	position int
+}
+
+// Compile parses a filter expression. The expressions compare the attributes with the operators ==, !=, in,
+// startsWith, endsWith, contains and matches (regular expression), which are combined with and, or, not and
+// parentheses. ex: tenantDomain == "bu1.com" and not apiContext in ["/bu1/internal", "/bu1/admin"]
+func Compile(expression string) (Expression, error) {
+	tokens, err := tokenize(expression)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	compiled, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if next := p.peek(); next.kind != tokenEOF {
+		return nil, fmt.Errorf("unexpected %q at %d", next.value, next.position)
+	}
+	return compiled, nil
+}
+
+func tokenize(expression string) ([]token, error) {
+	var tokens []token
+	runes := []rune(expression)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(' || r == ')' || r == '[' || r == ']' || r == ',':
+			tokens = append(tokens, token{tokenSymbol, string(r), i})
+			i++
+		case (r == '=' || r == '!') && i+1 < len(runes) && runes[i+1] == '=':
+			tokens = append(tokens, token{tokenSymbol, string(runes[i : i+2]), i})
+			i += 2
+		case r == '"' || r == '\'':
+			start := i
+			var value strings.Builder
+			for i++; i < len(runes) && runes[i] != r; i++ {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+				}
+				value.WriteRune(runes[i])
+			}
+			if i >= len(runes) {
+				return nil, fmt.Errorf("string at %d is not terminated", start)
+			}
+			tokens = append(tokens, token{tokenString, value.String(), start})
+			i++
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_' ||
+				runes[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, token{tokenIdentifier, string(runes[start:i]), start})
+		default:
+			return nil, fmt.Errorf("unexpected %q at %d", r, i)
+		}
+	}
+	return append(tokens, token{tokenEOF, "end of the expression", len(runes)}), nil
+}
+
+type parser struct {
+	tokens []token
+	next   int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.next]
+}
+
+func (p *parser) consume() token {
+	t := p.tokens[p.next]
+	if t.kind != tokenEOF {
+		p.next++
+	}
+	return t
+}
+
+// isKeyword returns whether the next token is the keyword, which is case insensitive.
+func (p *parser) isKeyword(keyword string) bool {
+	next := p.peek()
+	return next.kind == tokenIdentifier && strings.EqualFold(next.value, keyword)
+}
+
+func (p *parser) isSymbol(symbol string) bool {
+	next := p.peek()
+	return next.kind == tokenSymbol && next.value == symbol
+}
+
+func (p *parser) expectSymbol(symbol string) error {
+	if !p.isSymbol(symbol) {
+		next := p.peek()
+		return fmt.Errorf("expected %q at %d, found %q", symbol, next.position, next.value)
+	}
+	p.consume()
+	return nil
+}
+
+func (p *parser) parseOr() (Expression, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("or") {
+		p.consume()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &orExpression{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (Expression, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("and") {
+		p.consume()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &andExpression{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (Expression, error) {
+	if p.isKeyword("not") {
+		p.consume()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &notExpression{operand}, nil
+	}
+	if p.isSymbol("(") {
+		p.consume()
+		expression, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err = p.expectSymbol(")"); err != nil {
+			return nil, err
+		}
+		return expression, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (Expression, error) {
+	attribute := p.consume()
+	if attribute.kind != tokenIdentifier {
+		return nil, fmt.Errorf("expected an attribute at %d, found %q", attribute.position, attribute.value)
+	}
+	operator := p.consume()
+	compiled := &comparison{attribute: attribute.value, operator: strings.ToLower(operator.value)}
+	switch {
+	case operator.kind == tokenSymbol && (operator.value == opEquals || operator.value == opNotEquals):
+	case operator.kind == tokenIdentifier && compiled.operator == opIn:
+		values, err := p.parseList()
+		if err != nil {
+			return nil, err
+		}
+		compiled.values = values
+		return compiled, nil
+	case operator.kind == tokenIdentifier && (compiled.operator == opStartsWith ||
+		compiled.operator == opEndsWith || compiled.operator == opContains || compiled.operator == opMatches):
+	default:
+		return nil, fmt.Errorf("expected an operator at %d, found %q", operator.position, operator.value)
+	}
+	value := p.consume()
+	if value.kind != tokenString {
+		return nil, fmt.Errorf("expected a string at %d, found %q", value.position, value.value)
+	}
+	compiled.values = []string{value.value}
+	if compiled.operator == opMatches {
+		pattern, err := regexp.Compile(value.value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regular expression at %d. %v", value.position, err)
+		}
+		compiled.pattern = pattern
+	}
+	return compiled, nil
+}
+
+func (p *parser) parseList() ([]string, error) {
+	if err := p.expectSymbol("["); err != nil {
+		return nil, err
+	}
+	var values []string
+	for {
+		value := p.consume()
+		if value.kind != tokenString {
+			return nil, fmt.Errorf("expected a string at %d, found %q", value.position, value.value)
+		}
+		values = append(values, value.value)
+		if !p.isSymbol(",") {
+			break
+		}
+		p.consume()
+	}
+	if err := p.expectSymbol("]"); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
@@ -30,8 +30,10 @@ import (
 
 	"github.com/sirupsen/logrus"
 	"github.com/wso2/product-microgateway/adapter/config"
+	"github.com/wso2/product-microgateway/adapter/internal/audit"
 	"github.com/wso2/product-microgateway/adapter/internal/common"
 	"github.com/wso2/product-microgateway/adapter/internal/discovery/xds"
+	"github.com/wso2/product-microgateway/adapter/internal/eventorder"
 	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
 	pkgAuth "github.com/wso2/product-microgateway/adapter/pkg/auth"
 	"github.com/wso2/product-microgateway/adapter/pkg/eventhub/types"
@@ -65,27 +67,38 @@ var (
 	appPolicyList     *types.ApplicationPolicyList
 	subPolicyList     *types.SubscriptionPolicyList
 	apiList           *types.APIList
+	scopeList         *types.ScopeList
 
 	resources = []resource{
 		{
 			endpoint:     "subscriptions",
 			responseType: subList,
+			resourceType: eventorder.ResourceSubscription,
 		},
 		{
 			endpoint:     "applications",
 			responseType: appList,
+			resourceType: eventorder.ResourceApplication,
 		},
 		{
 			endpoint:     "application-key-mappings",
 			responseType: appKeyMappingList,
+			resourceType: eventorder.ResourceApplicationKeyMapping,
 		},
 		{
 			endpoint:     "application-policies",
 			responseType: appPolicyList,
+			resourceType: eventorder.ResourcePolicy,
 		},
 		{
 			endpoint:     "subscription-policies",
 			responseType: subPolicyList,
+			resourceType: eventorder.ResourcePolicy,
+		},
+		{
+			endpoint:     "scopes",
+			responseType: scopeList,
+			resourceType: eventorder.ResourceScope,
 		},
 	}
 	// APIListChannel is used to add apis
@@ -106,6 +119,8 @@ type response struct {
 type resource struct {
 	endpoint     string
 	responseType interface{}
+	// resourceType is the type of the resources, of which the events are ordered with the pulled resources
+	resourceType string
 }
 
 func init() {
@@ -114,18 +129,27 @@ func init() {
 
 // LoadSubscriptionData loads subscription data from control-plane
 func LoadSubscriptionData(configFile *config.Config, initialAPIUUIDListMap map[string]int) {
+	if !config.IsEventCategoryEnabled(configFile, config.EventCategorySubscription) {
+		logger.LoggerSync.Info("Subscription data is not loaded, as the subscription events are not enabled")
+		return
+	}
 	conf = configFile
 	accessToken = pkgAuth.GetBasicAuth(configFile.ControlPlane.Username, configFile.ControlPlane.Password)
+	xds.SetControlPlaneLookup(lookupControlPlane)
 
 	var responseChannel = make(chan response)
 	for _, url := range resources {
+		// the events received while pulling are applied again over the pulled resources
+		pull := eventorder.BeginPull(url.resourceType)
 		go InvokeService(url.endpoint, url.responseType, nil, responseChannel, 0)
 		for {
 			data := <-responseChannel
 			logger.LoggerSync.Debug("Receiving subscription data for an environment")
 			if data.Payload != nil {
 				logger.LoggerSync.Info("Payload data with subscription information recieved")
-				retrieveSubscriptionDataFromChannel(data)
+				pull.Apply(func() {
+					retrieveSubscriptionDataFromChannel(data)
+				})
 				break
 			} else if data.ErrorCode >= 400 && data.ErrorCode < 500 {
 				logger.LoggerSync.ErrorC(logging.ErrorDetails{
@@ -279,6 +303,18 @@ func InvokeService(endpoint string, responseType interface{}, queryParamMap map[
 	}
 }
 
+// lookupControlPlane reads a resource of the internal data REST API by the query parameters, which looks up the
+// entries evicted from the bounded resource stores.
+func lookupControlPlane(endpoint string, queryParams map[string]string) ([]byte, error) {
+	responseChannel := make(chan response)
+	go InvokeService(endpoint, nil, queryParams, responseChannel, 0)
+	data := <-responseChannel
+	if data.Error != nil {
+		return nil, data.Error
+	}
+	return data.Payload, nil
+}
+
 func retrieveAPIListFromChannel(c chan response, initialAPIUUIDListMap map[string]int) {
 	for response := range c {
 		retrieveAPIList(response, initialAPIUUIDListMap)
@@ -317,36 +353,67 @@ func retrieveAPIList(response response, initialAPIUUIDListMap map[string]int) {
 }
 
 func retrieveSubscriptionDataFromChannel(response response) {
-	responseType := reflect.TypeOf(response.Type).Elem()
-	newResponse := reflect.New(responseType).Interface()
-	err := json.Unmarshal(response.Payload, &newResponse)
-
-	if err != nil {
+	if err := applySubscriptionData(response.Type, response.Payload); err != nil {
 		logger.LoggerSubscription.Errorf("Error occurred while unmarshalling the response received for: "+response.Endpoint, err)
-	} else {
-		switch t := newResponse.(type) {
-		case *types.SubscriptionList:
-			logger.LoggerSubscription.Debug("Received Subscription information.")
-			subList = newResponse.(*types.SubscriptionList)
-			xds.UpdateEnforcerSubscriptions(xds.MarshalMultipleSubscriptions(subList))
-		case *types.ApplicationList:
-			logger.LoggerSubscription.Debug("Received Application information.")
-			appList = newResponse.(*types.ApplicationList)
-			xds.UpdateEnforcerApplications(xds.MarshalMultipleApplications(appList))
-		case *types.ApplicationPolicyList:
-			logger.LoggerSubscription.Debug("Received Application Policy information.")
-			appPolicyList = newResponse.(*types.ApplicationPolicyList)
-			xds.UpdateEnforcerApplicationPolicies(xds.MarshalMultipleApplicationPolicies(appPolicyList))
-		case *types.SubscriptionPolicyList:
-			logger.LoggerSubscription.Debug("Received Subscription Policy information.")
-			subPolicyList = newResponse.(*types.SubscriptionPolicyList)
-			xds.UpdateEnforcerSubscriptionPolicies(xds.MarshalMultipleSubscriptionPolicies(subPolicyList))
-		case *types.ApplicationKeyMappingList:
-			logger.LoggerSubscription.Debug("Received Application Key Mapping information.")
-			appKeyMappingList = newResponse.(*types.ApplicationKeyMappingList)
-			xds.UpdateEnforcerApplicationKeyMappings(xds.MarshalMultipleApplicationKeyMappings(appKeyMappingList))
-		default:
-			logger.LoggerSubscription.Debugf("Unknown type %T", t)
+		return
+	}
+	// the data loaded is recorded in the journal, to rebuild the subscriptions from the journal
+	audit.RecordChange(audit.ChangeRecord{
+		Actor:        audit.ActorControlPlane,
+		Action:       audit.ActionLoadSubscriptionData,
+		Subject:      response.Endpoint,
+		Subscription: &audit.SubscriptionData{Type: response.Endpoint, Payload: response.Payload},
+	})
+}
+
+// ReplaySubscriptionData applies the subscription data of a resource loaded from the control plane (ex: subscriptions)
+// as recorded in the audit journal, to rebuild the subscriptions from the journal.
+func ReplaySubscriptionData(endpoint string, payload []byte) error {
+	for _, resource := range resources {
+		if resource.endpoint == endpoint {
+			return applySubscriptionData(resource.responseType, payload)
 		}
 	}
+	return fmt.Errorf("unknown subscription data resource %s", endpoint)
+}
+
+// applySubscriptionData unmarshals the payload to the response type and replaces the subscription data of the type.
+func applySubscriptionData(responseType interface{}, payload []byte) error {
+	newResponse := reflect.New(reflect.TypeOf(responseType).Elem()).Interface()
+	if err := json.Unmarshal(payload, &newResponse); err != nil {
+		return err
+	}
+	switch t := newResponse.(type) {
+	case *types.SubscriptionList:
+		logger.LoggerSubscription.Debug("Received Subscription information.")
+		subList = newResponse.(*types.SubscriptionList)
+		xds.UpdateEnforcerSubscriptions(xds.MarshalMultipleSubscriptions(subList))
+		xds.UpdateRateLimits()
+	case *types.ApplicationList:
+		logger.LoggerSubscription.Debug("Received Application information.")
+		appList = newResponse.(*types.ApplicationList)
+		xds.UpdateEnforcerApplications(xds.MarshalMultipleApplications(appList))
+		xds.UpdateRateLimits()
+	case *types.ApplicationPolicyList:
+		logger.LoggerSubscription.Debug("Received Application Policy information.")
+		appPolicyList = newResponse.(*types.ApplicationPolicyList)
+		xds.UpdateEnforcerApplicationPolicies(xds.MarshalMultipleApplicationPolicies(appPolicyList))
+		xds.UpdateRateLimits()
+	case *types.SubscriptionPolicyList:
+		logger.LoggerSubscription.Debug("Received Subscription Policy information.")
+		subPolicyList = newResponse.(*types.SubscriptionPolicyList)
+		xds.UpdateEnforcerSubscriptionPolicies(xds.MarshalMultipleSubscriptionPolicies(subPolicyList))
+		xds.UpdateRateLimits()
+	case *types.ApplicationKeyMappingList:
+		logger.LoggerSubscription.Debug("Received Application Key Mapping information.")
+		appKeyMappingList = newResponse.(*types.ApplicationKeyMappingList)
+		xds.UpdateEnforcerApplicationKeyMappings(xds.MarshalMultipleApplicationKeyMappings(appKeyMappingList))
+	case *types.ScopeList:
+		logger.LoggerSubscription.Debug("Received Scope information.")
+		scopeList = newResponse.(*types.ScopeList)
+		xds.UpdateEnforcerScopes(xds.MarshalMultipleScopes(scopeList))
+	default:
+		logger.LoggerSubscription.Debugf("Unknown type %T", t)
+	}
+	return nil
 }
@@ -0,0 +1,269 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+// Package eventorder applies the changes of the control plane resources in the order of the timestamps of the
+// events (last write wins), among the handlers of the events and the resources pulled from the control plane, as
+// the events are not guaranteed to be received in order.
+package eventorder
+
+import (
+	"hash/fnv"
+	"sort"
+	"sync"
+
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+)
+
+// Resource types of which the events are ordered. The events of the different resource types are not ordered
+// against each other.
+const (
+	// ResourceAPI is an API in an environment, of the deploy and remove events
+	ResourceAPI = "api"
+	// ResourceAPILifeCycle is the lifecycle status of an API
+	ResourceAPILifeCycle = "apiLifeCycle"
+	// ResourceAPIMetadata is the metadata of an API (ex: the default version)
+	ResourceAPIMetadata           = "apiMetadata"
+	ResourceApplication           = "application"
+	ResourceApplicationKeyMapping = "applicationKeyMapping"
+	ResourceSubscription          = "subscription"
+	ResourcePolicy                = "policy"
+	ResourceScope                 = "scope"
+	ResourceKeyManager            = "keyManager"
+)
+
+// resourceLockStripes is the number of the locks, among which the resources are distributed
+const resourceLockStripes = 64
+
+// eventTimeStamp is the timestamp of the last event processed for a resource. The timestamp of a delete event is
+// kept as a tombstone, so that the create and update events delayed beyond the delete do not add the resource again.
+type eventTimeStamp struct {
+	timeStamp int64
+	deleted   bool
+}
+
+var (
+	// resource type -> key of the resource -> timestamp of the last event processed
+	timeStamps      = make(map[string]map[string]eventTimeStamp)
+	timeStampsMutex sync.Mutex
+	// the events of a resource are applied one at a time, while the pulled resources of a type are applied
+	// exclusive of the events of the type
+	resourceLocks         [resourceLockStripes]sync.Mutex
+	resourceTypeLocks     = make(map[string]*sync.RWMutex)
+	resourceTypeLockMutex sync.Mutex
+	// resource type -> pulls in progress, which replay the events applied while the resources are pulled
+	pulls      = make(map[string][]*Pull)
+	pullsMutex sync.Mutex
+)
+
+// Pull is a pull of all the resources of a type from the control plane (ex: at the startup). The events applied
+// while the resources are pulled are applied again once the pulled resources are applied, as the pulled resources
+// may not contain the changes of those events.
+type Pull struct {
+	resourceTypes []string
+	events        []func()
+}
+
+// Apply applies an event of a resource by apply, unless a later event of the resource is already processed (in
+// which case true is returned). The check and the apply are done under the lock of the resource, hence a later
+// event of the resource is not applied in between by another handler.
+func Apply(resourceType, key string, timeStamp int64, isDelete bool, apply func()) bool {
+	return len(ApplyToParts(resourceType, key, []string{""}, timeStamp, isDelete, func([]string) {
+		apply()
+	})) == 0
+}
+
+// ApplyToParts applies an event to the parts of a resource (ex: the environments of an API), of which a later
+// event is not already processed, and returns those parts. The event is not applied if no part remains.
+func ApplyToParts(resourceType, resourceID string, parts []string, timeStamp int64, isDelete bool,
+	apply func(parts []string)) []string {
+	unlock := lockResource(resourceType, resourceID)
+	defer unlock()
+	var applicableParts []string
+	timeStampsMutex.Lock()
+	for _, part := range parts {
+		if !isLaterEvent(resourceType, getKey(resourceID, part), timeStamp, isDelete) {
+			applicableParts = append(applicableParts, part)
+		}
+	}
+	timeStampsMutex.Unlock()
+	if len(applicableParts) == 0 {
+		return nil
+	}
+	apply(applicableParts)
+	recordForPulls(resourceType, func() {
+		apply(applicableParts)
+	})
+	return applicableParts
+}
+
+// ApplyPulled applies a resource pulled from the control plane to the parts of the resource (ex: the environments
+// of an API), of which an event later than the pull is not processed, and returns those parts. The timestamps of
+// the events are not updated, as the pulled resource is not an event.
+func ApplyPulled(resourceType, resourceID string, parts []string, pulledAt int64, apply func(parts []string)) []string {
+	unlock := lockResource(resourceType, resourceID)
+	defer unlock()
+	var applicableParts []string
+	timeStampsMutex.Lock()
+	for _, part := range parts {
+		if processed, found := getTimeStamp(resourceType, getKey(resourceID, part), pulledAt); !found ||
+			processed.timeStamp <= pulledAt {
+			applicableParts = append(applicableParts, part)
+		}
+	}
+	timeStampsMutex.Unlock()
+	if len(applicableParts) == 0 {
+		logger.LoggerEventOrder.Debugf("Pulled %s %s is not applied, as a later event is already processed",
+			resourceType, resourceID)
+		return nil
+	}
+	apply(applicableParts)
+	return applicableParts
+}
+
+// BeginPull begins a pull of all the resources of the types. The events of the types applied from now on are
+// applied again, once the pulled resources are applied by Pull.Apply.
+func BeginPull(resourceTypes ...string) *Pull {
+	pull := &Pull{resourceTypes: resourceTypes}
+	pullsMutex.Lock()
+	defer pullsMutex.Unlock()
+	for _, resourceType := range resourceTypes {
+		pulls[resourceType] = append(pulls[resourceType], pull)
+	}
+	return pull
+}
+
+// Apply applies the pulled resources by apply, and then the events applied since the pull began, while the events
+// of the resource types are not applied. The pull is ended.
+func (pull *Pull) Apply(apply func()) {
+	resourceTypes := append([]string(nil), pull.resourceTypes...)
+	// the locks are acquired in the same order by all the pulls
+	sort.Strings(resourceTypes)
+	for _, resourceType := range resourceTypes {
+		getResourceTypeLock(resourceType).Lock()
+	}
+	defer func() {
+		for _, resourceType := range resourceTypes {
+			getResourceTypeLock(resourceType).Unlock()
+		}
+	}()
+	events := pull.end()
+	apply()
+	if len(events) > 0 {
+		logger.LoggerEventOrder.Infof("Applying %d events of %v received while the resources are pulled",
+			len(events), pull.resourceTypes)
+	}
+	for _, event := range events {
+		event()
+	}
+}
+
+// end removes the pull from the pulls in progress, and returns the events applied since the pull began.
+func (pull *Pull) end() []func() {
+	pullsMutex.Lock()
+	defer pullsMutex.Unlock()
+	for _, resourceType := range pull.resourceTypes {
+		remaining := pulls[resourceType][:0]
+		for _, inProgress := range pulls[resourceType] {
+			if inProgress != pull {
+				remaining = append(remaining, inProgress)
+			}
+		}
+		pulls[resourceType] = remaining
+	}
+	return pull.events
+}
+
+// Reset removes the timestamps of the events processed for the resource types, hence the events are not discarded
+// as stale when those are applied again (ex: replayed from the audit journal).
+func Reset(resourceTypes ...string) {
+	timeStampsMutex.Lock()
+	defer timeStampsMutex.Unlock()
+	for _, resourceType := range resourceTypes {
+		delete(timeStamps, resourceType)
+	}
+}
+
+// recordForPulls records an applied event for the pulls of the resource type in progress. Should be called with
+// the lock of the resource type.
+func recordForPulls(resourceType string, event func()) {
+	pullsMutex.Lock()
+	defer pullsMutex.Unlock()
+	for _, pull := range pulls[resourceType] {
+		pull.events = append(pull.events, event)
+	}
+}
+
+// isLaterEvent returns whether a later event of the resource is already processed, in which case the current event
+// should be discarded (last write wins). Otherwise the timestamp of the current event is recorded. A delete event
+// wins over the other events of the same timestamp, hence the resource is not added again by a create or an update
+// event of the same timestamp, which is received after the delete event. Should be called with the timeStampsMutex.
+func isLaterEvent(resourceType, key string, currentTimeStamp int64, isDelete bool) bool {
+	if processed, found := getTimeStamp(resourceType, key, currentTimeStamp); found {
+		if processed.timeStamp > currentTimeStamp ||
+			(processed.deleted && !isDelete && processed.timeStamp == currentTimeStamp) {
+			logger.LoggerEventOrder.Debugf("Event of the %s %s with the timestamp %d is discarded as an event with "+
+				"the timestamp %d is already processed (deleted: %t)", resourceType, key, currentTimeStamp,
+				processed.timeStamp, processed.deleted)
+			return true
+		}
+	}
+	if _, found := timeStamps[resourceType]; !found {
+		timeStamps[resourceType] = make(map[string]eventTimeStamp)
+	}
+	timeStamps[resourceType][key] = eventTimeStamp{timeStamp: currentTimeStamp, deleted: isDelete}
+	return false
+}
+
+// getTimeStamp returns the timestamp of the last event processed for the resource, to be compared with the given
+// timestamp. The tombstones persisted by the compactions are looked up, if the timestamp is not in memory. Should be
+// called with the timeStampsMutex.
+func getTimeStamp(resourceType, key string, timeStamp int64) (eventTimeStamp, bool) {
+	if processed, found := timeStamps[resourceType][key]; found {
+		return processed, true
+	}
+	return getPersistedTombstone(resourceType, key, timeStamp)
+}
+
+// lockResource locks the resource for an event, and returns the function unlocking the resource.
+func lockResource(resourceType, resourceID string) func() {
+	resourceTypeLock := getResourceTypeLock(resourceType)
+	resourceTypeLock.RLock()
+	hash := fnv.New32a()
+	hash.Write([]byte(resourceID))
+	resourceLock := &resourceLocks[hash.Sum32()%resourceLockStripes]
+	resourceLock.Lock()
+	return func() {
+		resourceLock.Unlock()
+		resourceTypeLock.RUnlock()
+	}
+}
+
+func getResourceTypeLock(resourceType string) *sync.RWMutex {
+	resourceTypeLockMutex.Lock()
+	defer resourceTypeLockMutex.Unlock()
+	if _, found := resourceTypeLocks[resourceType]; !found {
+		resourceTypeLocks[resourceType] = &sync.RWMutex{}
+	}
+	return resourceTypeLocks[resourceType]
+}
+
+func getKey(resourceID, part string) string {
+	if part == "" {
+		return resourceID
+	}
+	return resourceID + ":" + part
+}
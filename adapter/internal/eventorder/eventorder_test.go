@@ -0,0 +1,146 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package eventorder
+
+import (
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-microgateway/adapter/config"
+)
+
+func TestApply(t *testing.T) {
+	defer Reset(ResourceApplication)
+	applied := 0
+	apply := func() {
+		applied++
+	}
+	assert.False(t, Apply(ResourceApplication, "app", 5, false, apply))
+	assert.False(t, Apply(ResourceApplication, "app", 5, false, apply), "Events of the same timestamp should be applied")
+	assert.False(t, Apply(ResourceApplication, "app", 5, true, apply), "Delete event should win over the same timestamp")
+	assert.True(t, Apply(ResourceApplication, "app", 5, false, apply),
+		"Create event of the same timestamp as the delete should be discarded")
+	assert.True(t, Apply(ResourceApplication, "app", 4, true, apply))
+	assert.Equal(t, 3, applied)
+	assert.Equal(t, eventTimeStamp{timeStamp: 5, deleted: true}, timeStamps[ResourceApplication]["app"])
+
+	Reset(ResourceApplication)
+	assert.False(t, Apply(ResourceApplication, "app", 4, false, apply), "Reset should not discard the older events")
+}
+
+func TestApplyToParts(t *testing.T) {
+	defer Reset(ResourceAPI)
+	var applied []string
+	apply := func(envs []string) {
+		applied = envs
+	}
+	assert.Equal(t, []string{"dev"}, ApplyToParts(ResourceAPI, "api", []string{"dev"}, 10, true, apply))
+	assert.Equal(t, []string{"prod"}, ApplyToParts(ResourceAPI, "api", []string{"dev", "prod"}, 5, false, apply),
+		"Deploy event older than the remove should not be applied to the environment removed from")
+	assert.Equal(t, []string{"prod"}, applied)
+	applied = nil
+	assert.Empty(t, ApplyToParts(ResourceAPI, "api", []string{"dev", "prod"}, 4, true, apply))
+	assert.Nil(t, applied, "Event should not be applied if a later event is processed for all the parts")
+}
+
+func TestApplyPulled(t *testing.T) {
+	defer Reset(ResourceAPI)
+	var applied []string
+	apply := func(envs []string) {
+		applied = envs
+	}
+	assert.False(t, Apply(ResourceAPI, "api:dev", 20, true, func() {}))
+	assert.Equal(t, []string{"prod"}, ApplyPulled(ResourceAPI, "api", []string{"dev", "prod"}, 10, apply),
+		"Pulled API should not be deployed to the environment removed from after the pull")
+	assert.Equal(t, []string{"prod"}, applied)
+	assert.Equal(t, []string{"dev", "prod"}, ApplyPulled(ResourceAPI, "api", []string{"dev", "prod"}, 20, apply))
+	_, found := timeStamps[ResourceAPI]["api:prod"]
+	assert.False(t, found, "Pulled resource should not update the timestamps of the events")
+}
+
+func TestPull(t *testing.T) {
+	defer Reset(ResourceSubscription, ResourceScope)
+	var applied []string
+	var mutex sync.Mutex
+	record := func(value string) func() {
+		return func() {
+			mutex.Lock()
+			defer mutex.Unlock()
+			applied = append(applied, value)
+		}
+	}
+	pull := BeginPull(ResourceSubscription)
+	assert.False(t, Apply(ResourceSubscription, "sub", 10, false, record("event")))
+	assert.False(t, Apply(ResourceScope, "scope", 10, false, record("other")))
+
+	// the events are not applied while the pulled resources are applied
+	pulledApplied := make(chan struct{})
+	eventApplied := make(chan struct{})
+	go func() {
+		<-pulledApplied
+		Apply(ResourceSubscription, "sub", 20, false, record("later"))
+		close(eventApplied)
+	}()
+	pull.Apply(func() {
+		record("pulled")()
+		close(pulledApplied)
+		time.Sleep(50 * time.Millisecond)
+	})
+	<-eventApplied
+	assert.Equal(t, []string{"event", "other", "pulled", "event", "later"}, applied,
+		"Events received while pulling should be applied again over the pulled resources")
+	assert.Empty(t, pulls[ResourceSubscription])
+}
+
+func TestCompact(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	defaultTombstonesFilePath := conf.Adapter.Compaction.TombstonesFilePath
+	defer func() {
+		conf.Adapter.Compaction.TombstonesFilePath = defaultTombstonesFilePath
+		compactedBefore = 0
+		Reset(ResourceScope)
+	}()
+	conf.Adapter.Compaction.TombstonesFilePath = ""
+	loadCompactedBefore()
+
+	assert.False(t, Apply(ResourceScope, "deleted", 10, true, func() {}))
+	assert.False(t, Apply(ResourceScope, "updated", 10, false, func() {}))
+	assert.False(t, Apply(ResourceScope, "recent", 30, false, func() {}))
+	removed, err := Compact(time.UnixMilli(20))
+	assert.Nil(t, err)
+	assert.Equal(t, 1, removed, "Tombstones should be retained if those are not persisted")
+	assert.Contains(t, timeStamps[ResourceScope], "deleted")
+
+	conf.Adapter.Compaction.TombstonesFilePath = filepath.Join(t.TempDir(), "tombstones.json")
+	removed, err = Compact(time.UnixMilli(20))
+	assert.Nil(t, err)
+	assert.Equal(t, 1, removed)
+	assert.NotContains(t, timeStamps[ResourceScope], "deleted")
+	assert.Contains(t, timeStamps[ResourceScope], "recent")
+	assert.True(t, Apply(ResourceScope, "deleted", 5, false, func() {}),
+		"Event delayed beyond the persisted tombstone should be discarded")
+	assert.False(t, Apply(ResourceScope, "deleted", 25, false, func() {}))
+
+	tombstones, err := readTombstones(conf.Adapter.Compaction.TombstonesFilePath)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(20), tombstones.CompactedBefore)
+	assert.Equal(t, map[string]map[string]int64{ResourceScope: {"deleted": 10}}, tombstones.Tombstones)
+}
@@ -0,0 +1,192 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package eventorder
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/wso2/product-microgateway/adapter/config"
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/pkg/logging"
+)
+
+// persistedTombstones are the tombstones of the deleted resources removed from the memory by the compactions.
+type persistedTombstones struct {
+	// CompactedBefore is the timestamp (in milliseconds), before which the tombstones are removed from the memory
+	CompactedBefore int64 `json:"compactedBefore"`
+	// Tombstones are the timestamps of the delete events, by the resource type and the key of the resource
+	Tombstones map[string]map[string]int64 `json:"tombstones"`
+}
+
+var (
+	// compactedBefore is the timestamp, before which the tombstones are looked up in the persisted tombstones
+	compactedBefore     int64
+	compactedBeforeOnce sync.Once
+)
+
+// Compact removes the timestamps of the processed events, which are older than the given time, and returns the
+// number of timestamps removed. The tombstones of the deleted resources are persisted before those are removed,
+// hence the events delayed beyond the retention do not add a deleted resource again. The tombstones are retained
+// in the memory if the file of the tombstones is not configured.
+func Compact(before time.Time) (int, error) {
+	conf, _ := config.ReadConfigs()
+	filePath := conf.Adapter.Compaction.TombstonesFilePath
+	loadCompactedBefore()
+	timeStampsMutex.Lock()
+	defer timeStampsMutex.Unlock()
+	compactedTombstones := make(map[string]map[string]int64)
+	for resourceType, resourceTimeStamps := range timeStamps {
+		for key, processed := range resourceTimeStamps {
+			if processed.timeStamp >= before.UnixMilli() || (processed.deleted && filePath == "") {
+				continue
+			}
+			if processed.deleted {
+				if _, found := compactedTombstones[resourceType]; !found {
+					compactedTombstones[resourceType] = make(map[string]int64)
+				}
+				compactedTombstones[resourceType][key] = processed.timeStamp
+			}
+		}
+	}
+	if filePath != "" {
+		if err := persistTombstones(filePath, compactedTombstones, before.UnixMilli()); err != nil {
+			return 0, err
+		}
+		if before.UnixMilli() > compactedBefore {
+			compactedBefore = before.UnixMilli()
+		}
+	}
+	removed := 0
+	for _, resourceTimeStamps := range timeStamps {
+		for key, processed := range resourceTimeStamps {
+			if processed.timeStamp >= before.UnixMilli() || (processed.deleted && filePath == "") {
+				continue
+			}
+			delete(resourceTimeStamps, key)
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+// getPersistedTombstone looks up the tombstone of a resource removed from the memory by a compaction, to be compared
+// with the given timestamp. The file is read only for the timestamps before the compaction (ex: the events delayed
+// beyond the retention), as the compacted tombstones are not later than the other timestamps.
+func getPersistedTombstone(resourceType, key string, timeStamp int64) (eventTimeStamp, bool) {
+	loadCompactedBefore()
+	if timeStamp >= compactedBefore {
+		return eventTimeStamp{}, false
+	}
+	conf, _ := config.ReadConfigs()
+	tombstones, err := readTombstones(conf.Adapter.Compaction.TombstonesFilePath)
+	if err != nil {
+		logTombstonesReadError(err)
+		return eventTimeStamp{}, false
+	}
+	if deletedAt, found := tombstones.Tombstones[resourceType][key]; found {
+		return eventTimeStamp{timeStamp: deletedAt, deleted: true}, true
+	}
+	return eventTimeStamp{}, false
+}
+
+// loadCompactedBefore reads the time of the last compaction of the tombstones persisted by the previous runs of the
+// adapter, at the first access of the timestamps.
+func loadCompactedBefore() {
+	compactedBeforeOnce.Do(func() {
+		conf, _ := config.ReadConfigs()
+		tombstones, err := readTombstones(conf.Adapter.Compaction.TombstonesFilePath)
+		if err != nil {
+			logTombstonesReadError(err)
+			return
+		}
+		compactedBefore = tombstones.CompactedBefore
+	})
+}
+
+// persistTombstones merges the compacted tombstones into the file of the tombstones.
+func persistTombstones(filePath string, compactedTombstones map[string]map[string]int64, before int64) error {
+	tombstones, err := readTombstones(filePath)
+	if err != nil {
+		return err
+	}
+	for resourceType, resourceTombstones := range compactedTombstones {
+		if _, found := tombstones.Tombstones[resourceType]; !found {
+			tombstones.Tombstones[resourceType] = make(map[string]int64)
+		}
+		for key, timeStamp := range resourceTombstones {
+			if timeStamp > tombstones.Tombstones[resourceType][key] {
+				tombstones.Tombstones[resourceType][key] = timeStamp
+			}
+		}
+	}
+	if before > tombstones.CompactedBefore {
+		tombstones.CompactedBefore = before
+	}
+	content, err := json.Marshal(tombstones)
+	if err != nil {
+		return err
+	}
+	tempFile, err := ioutil.TempFile(filepath.Dir(filePath), "."+filepath.Base(filePath)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tempFile.Name())
+	if _, err = tempFile.Write(content); err != nil {
+		tempFile.Close()
+		return err
+	}
+	if err = tempFile.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tempFile.Name(), filePath)
+}
+
+func readTombstones(filePath string) (*persistedTombstones, error) {
+	tombstones := &persistedTombstones{Tombstones: make(map[string]map[string]int64)}
+	if filePath == "" {
+		return tombstones, nil
+	}
+	content, err := ioutil.ReadFile(filePath)
+	if os.IsNotExist(err) {
+		return tombstones, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(content, tombstones); err != nil {
+		return nil, err
+	}
+	if tombstones.Tombstones == nil {
+		tombstones.Tombstones = make(map[string]map[string]int64)
+	}
+	return tombstones, nil
+}
+
+func logTombstonesReadError(err error) {
+	logger.LoggerEventOrder.ErrorC(logging.ErrorDetails{
+		Message: fmt.Sprintf("Error while reading the persisted tombstones of the deleted resources, hence the "+
+			"events delayed beyond the retention are not discarded. %v", err),
+		Severity:  logging.MAJOR,
+		ErrorCode: 3200,
+	})
+}
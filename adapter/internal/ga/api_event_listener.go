@@ -18,9 +18,13 @@
 package ga
 
 import (
+	"time"
+
 	"github.com/wso2/product-microgateway/adapter/config"
+	"github.com/wso2/product-microgateway/adapter/internal/audit"
 	"github.com/wso2/product-microgateway/adapter/internal/discovery/xds"
 	eh "github.com/wso2/product-microgateway/adapter/internal/eventhub"
+	"github.com/wso2/product-microgateway/adapter/internal/eventorder"
 	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
 	"github.com/wso2/product-microgateway/adapter/internal/synchronizer"
 	"github.com/wso2/product-microgateway/adapter/pkg/eventhub/types"
@@ -35,29 +39,47 @@ func handleAPIEventsFromGA(channel chan APIEvent) {
 		if len(configuredEnvs) == 0 {
 			configuredEnvs = append(configuredEnvs, config.DefaultGatewayName)
 		}
+		eventType := "DEPLOY_API_IN_GATEWAY"
 		if !event.IsDeployEvent {
-			xds.DeleteAPIWithAPIMEvent(event.APIUUID, event.OrganizationUUID, configuredEnvs, event.RevisionUUID)
-			for _, env := range configuredEnvs {
-				xds.DeleteAPIAndReturnList(event.APIUUID, event.OrganizationUUID, env)
-			}
-			continue
+			eventType = "REMOVE_API_FROM_GATEWAY"
 		}
+		audit.RecordEvent(audit.EventRecord{
+			Source:         audit.EventSourceGlobalAdapter,
+			Type:           eventType,
+			ResourceID:     event.APIUUID,
+			OrganizationID: event.OrganizationUUID,
+			Outcome:        audit.EventProcessed,
+		})
+		// the events from GA do not carry a timestamp, hence those are ordered by the time received
+		receivedAt := time.Now().UnixMilli()
+		eventorder.ApplyToParts(eventorder.ResourceAPI, event.APIUUID, configuredEnvs, receivedAt,
+			!event.IsDeployEvent, func(envs []string) {
+				applyAPIEventFromGA(event, envs, receivedAt)
+			})
+	}
+}
 
-		go synchronizer.FetchAPIsFromControlPlane(event.APIUUID, configuredEnvs)
+// applyAPIEventFromGA deploys or undeploys the API of an event from GA in the environments.
+func applyAPIEventFromGA(event APIEvent, envs []string, receivedAt int64) {
+	if !event.IsDeployEvent {
+		xds.UndeployAPIWithAPIMEvent(event.APIUUID, event.OrganizationUUID, envs, event.RevisionUUID)
+		return
+	}
+
+	go synchronizer.FetchAPIsFromControlPlane(event.APIUUID, envs, receivedAt)
 
-		for _, env := range configuredEnvs {
-			if xds.CheckIfAPIMetadataIsAlreadyAvailable(event.APIUUID, env) {
-				logger.LoggerGA.Debugf("APIList for API UUID: %s is not updated as it already "+
-					"exists", event.APIUUID)
-				continue
-			}
-			queryParamMap := make(map[string]string, 2)
-			queryParamMap[eh.GatewayLabelParam] = env
-			queryParamMap[eh.APIUUIDParam] = event.APIUUID
-			logger.LoggerGA.Infof("Invoking the apis service endpoint")
-			var apiList *types.APIList
-			go eh.InvokeService(eh.ApisEndpoint, apiList, queryParamMap,
-				eh.APIListChannel, 0)
+	for _, env := range envs {
+		if xds.CheckIfAPIMetadataIsAlreadyAvailable(event.APIUUID, env) {
+			logger.LoggerGA.Debugf("APIList for API UUID: %s is not updated as it already "+
+				"exists", event.APIUUID)
+			continue
 		}
+		queryParamMap := make(map[string]string, 2)
+		queryParamMap[eh.GatewayLabelParam] = env
+		queryParamMap[eh.APIUUIDParam] = event.APIUUID
+		logger.LoggerGA.Infof("Invoking the apis service endpoint")
+		var apiList *types.APIList
+		go eh.InvokeService(eh.ApisEndpoint, apiList, queryParamMap,
+			eh.APIListChannel, 0)
 	}
 }
@@ -0,0 +1,222 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package jobs
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wso2/product-microgateway/adapter/config"
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/pkg/logging"
+)
+
+// Func is the operation run by a job. The returned value is the result of the job.
+type Func func(reporter *Reporter) (interface{}, error)
+
+// ActiveJobError is returned when a job is submitted while a job of the same type is queued or running.
+type ActiveJobError struct {
+	ActiveJob Job
+}
+
+func (e *ActiveJobError) Error() string {
+	return fmt.Sprintf("%s job %s is already %s", e.ActiveJob.Type, e.ActiveJob.ID, e.ActiveJob.Status)
+}
+
+var (
+	jobs      = make(map[string]*Job)
+	jobIDs    []string // in the order of submission
+	jobsMutex sync.RWMutex
+
+	// slots limit the jobs running concurrently
+	slots     chan struct{}
+	slotsOnce sync.Once
+)
+
+// Submit queues the operation as a job and returns the job immediately. Only one job of a type is queued or run
+// at a time, hence an ActiveJobError is returned if there is an active job of the type.
+func Submit(jobType, createdBy string, run Func) (Job, error) {
+	conf, _ := config.ReadConfigs()
+	slotsOnce.Do(func() {
+		maxConcurrentJobs := conf.Adapter.Jobs.MaxConcurrentJobs
+		if maxConcurrentJobs <= 0 {
+			maxConcurrentJobs = 1
+		}
+		slots = make(chan struct{}, maxConcurrentJobs)
+	})
+
+	jobsMutex.Lock()
+	for _, job := range jobs {
+		if job.Type == jobType && !job.IsCompleted() {
+			activeJob := job.copy()
+			jobsMutex.Unlock()
+			return Job{}, &ActiveJobError{ActiveJob: activeJob}
+		}
+	}
+	job := &Job{
+		ID:        uuid.New().String(),
+		Type:      jobType,
+		Status:    StatusQueued,
+		CreatedBy: createdBy,
+		CreatedAt: time.Now().UTC(),
+		Logs:      []LogEntry{},
+	}
+	jobs[job.ID] = job
+	jobIDs = append(jobIDs, job.ID)
+	evictCompletedJobs(conf.Adapter.Jobs.MaxRetainedJobs)
+	submitted := job.copy()
+	jobsMutex.Unlock()
+
+	logger.LoggerJobs.Infof("%s job %s is submitted by the user: %s", jobType, job.ID, createdBy)
+	go runJob(job.ID, run, conf.Adapter.Jobs.MaxLogsPerJob)
+	return submitted, nil
+}
+
+// Get returns the job of the ID.
+func Get(jobID string) (Job, bool) {
+	jobsMutex.RLock()
+	defer jobsMutex.RUnlock()
+	job, found := jobs[jobID]
+	if !found {
+		return Job{}, false
+	}
+	return job.copy(), true
+}
+
+// List returns the retained jobs, from the latest. The logs and results are not included.
+func List() []Job {
+	jobsMutex.RLock()
+	defer jobsMutex.RUnlock()
+	list := make([]Job, 0, len(jobIDs))
+	for i := len(jobIDs) - 1; i >= 0; i-- {
+		job := jobs[jobIDs[i]].copy()
+		job.Logs = nil
+		job.Result = nil
+		list = append(list, job)
+	}
+	return list
+}
+
+func runJob(jobID string, run Func, maxLogs int) {
+	slots <- struct{}{}
+	defer func() { <-slots }()
+
+	reporter := &Reporter{jobID: jobID, maxLogs: maxLogs}
+	reporter.update(func(job *Job) {
+		startedAt := time.Now().UTC()
+		job.Status = StatusRunning
+		job.StartedAt = &startedAt
+	})
+	result, err := runSafely(run, reporter)
+	reporter.update(func(job *Job) {
+		completedAt := time.Now().UTC()
+		job.CompletedAt = &completedAt
+		if err != nil {
+			job.Status = StatusFailed
+			job.Error = err.Error()
+			return
+		}
+		job.Status = StatusSucceeded
+		job.Progress = 100
+		job.Result = result
+	})
+	if err != nil {
+		logger.LoggerJobs.ErrorC(logging.ErrorDetails{
+			Message:   fmt.Sprintf("Job %s is failed. %v", jobID, err),
+			Severity:  logging.MAJOR,
+			ErrorCode: 2300,
+		})
+		return
+	}
+	logger.LoggerJobs.Infof("Job %s is succeeded", jobID)
+}
+
+// runSafely runs the operation, and returns an error if the operation panics.
+func runSafely(run Func, reporter *Reporter) (result interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("recovered from panic. %v", r)
+		}
+	}()
+	return run(reporter)
+}
+
+// evictCompletedJobs removes the oldest completed jobs, exceeding the maximum number of retained jobs. The jobs
+// should be locked by the caller.
+func evictCompletedJobs(maxRetainedJobs int) {
+	excess := len(jobIDs) - maxRetainedJobs
+	if maxRetainedJobs <= 0 || excess <= 0 {
+		return
+	}
+	retainedIDs := make([]string, 0, len(jobIDs))
+	for _, jobID := range jobIDs {
+		if excess > 0 && jobs[jobID].IsCompleted() {
+			delete(jobs, jobID)
+			excess--
+			continue
+		}
+		retainedIDs = append(retainedIDs, jobID)
+	}
+	jobIDs = retainedIDs
+}
+
+func (job *Job) copy() Job {
+	copied := *job
+	copied.Logs = append([]LogEntry{}, job.Logs...)
+	return copied
+}
+
+// Reporter reports the progress and the logs of a running job.
+type Reporter struct {
+	jobID   string
+	maxLogs int
+}
+
+// Logf adds a log message to the job. The oldest messages are discarded once the maximum number of messages
+// is reached.
+func (r *Reporter) Logf(format string, args ...interface{}) {
+	entry := LogEntry{Timestamp: time.Now().UTC(), Message: fmt.Sprintf(format, args...)}
+	r.update(func(job *Job) {
+		job.Logs = append(job.Logs, entry)
+		if r.maxLogs > 0 && len(job.Logs) > r.maxLogs {
+			job.Logs = job.Logs[len(job.Logs)-r.maxLogs:]
+		}
+	})
+}
+
+// SetProgress sets the percentage of the job completed.
+func (r *Reporter) SetProgress(progress int) {
+	if progress < 0 {
+		progress = 0
+	} else if progress > 100 {
+		progress = 100
+	}
+	r.update(func(job *Job) {
+		job.Progress = progress
+	})
+}
+
+func (r *Reporter) update(updateJob func(job *Job)) {
+	jobsMutex.Lock()
+	defer jobsMutex.Unlock()
+	if job, found := jobs[r.jobID]; found {
+		updateJob(job)
+	}
+}
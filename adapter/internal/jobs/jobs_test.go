@@ -0,0 +1,94 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package jobs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func waitForJob(t *testing.T, jobID string) Job {
+	for i := 0; i < 100; i++ {
+		if job, _ := Get(jobID); job.IsCompleted() {
+			return job
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("Job %s is not completed", jobID)
+	return Job{}
+}
+
+func TestSubmitJob(t *testing.T) {
+	release := make(chan struct{})
+	job, err := Submit(TypeRebuild, "admin", func(reporter *Reporter) (interface{}, error) {
+		reporter.Logf("rebuilding %d APIs", 2)
+		reporter.SetProgress(50)
+		<-release
+		return "rebuilt", nil
+	})
+	assert.Nil(t, err, "Error while submitting the job")
+	assert.Equal(t, "admin", job.CreatedBy, "Job creator mismatch")
+
+	_, err = Submit(TypeRebuild, "admin", func(*Reporter) (interface{}, error) { return nil, nil })
+	activeJobErr, ok := err.(*ActiveJobError)
+	assert.True(t, ok, "Job should not be submitted while a job of the same type is active")
+	assert.Equal(t, job.ID, activeJobErr.ActiveJob.ID, "Active job mismatch")
+
+	close(release)
+	completed := waitForJob(t, job.ID)
+	assert.Equal(t, StatusSucceeded, completed.Status, "Job status mismatch")
+	assert.Equal(t, 100, completed.Progress, "Progress of a succeeded job mismatch")
+	assert.Equal(t, "rebuilt", completed.Result, "Job result mismatch")
+	assert.Equal(t, "rebuilding 2 APIs", completed.Logs[0].Message, "Job log mismatch")
+	assert.NotNil(t, completed.CompletedAt, "Completion time is not set")
+
+	failed, err := Submit(TypeRebuild, "admin", func(*Reporter) (interface{}, error) {
+		panic("unexpected")
+	})
+	assert.Nil(t, err, "Job should be submitted once the previous job is completed")
+	completed = waitForJob(t, failed.ID)
+	assert.Equal(t, StatusFailed, completed.Status, "Panicked job should be failed")
+	assert.Contains(t, completed.Error, "unexpected", "Job error mismatch")
+
+	list := List()
+	assert.Equal(t, failed.ID, list[0].ID, "Jobs should be listed from the latest")
+	assert.Nil(t, list[0].Logs, "Logs should not be listed")
+}
+
+func TestEvictCompletedJobs(t *testing.T) {
+	jobsMutex.Lock()
+	defer jobsMutex.Unlock()
+	savedJobs, savedIDs := jobs, jobIDs
+	defer func() { jobs, jobIDs = savedJobs, savedIDs }()
+
+	jobs = map[string]*Job{
+		"1": {ID: "1", Status: StatusSucceeded},
+		"2": {ID: "2", Status: StatusRunning},
+		"3": {ID: "3", Status: StatusFailed},
+		"4": {ID: "4", Status: StatusQueued},
+	}
+	jobIDs = []string{"1", "2", "3", "4"}
+	evictCompletedJobs(2)
+	assert.Equal(t, []string{"2", "4"}, jobIDs, "Oldest completed jobs should be evicted")
+	assert.Equal(t, 2, len(jobs), "Evicted jobs should be removed")
+
+	evictCompletedJobs(1)
+	assert.Equal(t, []string{"2", "4"}, jobIDs, "Active jobs should not be evicted")
+}
@@ -0,0 +1,68 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+// Package jobs runs the long-running admin operations asynchronously, and keeps their progress, logs and
+// results to be queried by the job ID.
+package jobs
+
+import (
+	"time"
+)
+
+// Types of the jobs
+const (
+	TypeResync     string = "RESYNC"
+	TypeRebuild    string = "REBUILD"
+	TypeCompaction string = "COMPACTION"
+)
+
+// Statuses of the jobs
+const (
+	// StatusQueued is the status of a job waiting for a free slot, as the concurrent jobs are limited
+	StatusQueued    string = "QUEUED"
+	StatusRunning   string = "RUNNING"
+	StatusSucceeded string = "SUCCEEDED"
+	StatusFailed    string = "FAILED"
+)
+
+// Job represents an admin operation run asynchronously.
+type Job struct {
+	ID          string     `json:"id"`
+	Type        string     `json:"type"`
+	Status      string     `json:"status"`
+	CreatedBy   string     `json:"createdBy"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	StartedAt   *time.Time `json:"startedAt,omitempty"`
+	CompletedAt *time.Time `json:"completedAt,omitempty"`
+	// Progress is the percentage of the job completed
+	Progress int        `json:"progress"`
+	Logs     []LogEntry `json:"logs"`
+	// Result of a succeeded job, which is specific to the job type
+	Result interface{} `json:"result,omitempty"`
+	Error  string      `json:"error,omitempty"`
+}
+
+// LogEntry is a log message of a job.
+type LogEntry struct {
+	Timestamp time.Time `json:"timestamp"`
+	Message   string    `json:"message"`
+}
+
+// IsCompleted returns true if the job is succeeded or failed.
+func (job *Job) IsCompleted() bool {
+	return job.Status == StatusSucceeded || job.Status == StatusFailed
+}
@@ -0,0 +1,288 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+// Package jwks fetches and caches the JWKS and the issuer of the resident key manager of the configured tenants,
+// and distributes them to the enforcers as key managers. Hence the enforcers validate the tokens of the tenants
+// without fetching the JWKS for the first request.
+package jwks
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wso2/product-microgateway/adapter/config"
+	"github.com/wso2/product-microgateway/adapter/internal/discovery/xds"
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	eventhubTypes "github.com/wso2/product-microgateway/adapter/pkg/eventhub/types"
+	"github.com/wso2/product-microgateway/adapter/pkg/logging"
+	"github.com/wso2/product-microgateway/adapter/pkg/tlsutils"
+)
+
+const (
+	tenantDomainPlaceholder string = "{tenantDomain}"
+	tenantPathPlaceholder   string = "{tenantPath}"
+	superTenantDomain       string = "carbon.super"
+
+	residentKeyManagerName string = "Resident Key Manager"
+	residentKeyManagerType string = "default"
+
+	// configurations of the key manager read by the enforcer
+	issuerConfig          string = "issuer"
+	jwksEndpointConfig    string = "jwks_endpoint"
+	selfValidateJWTConfig string = "self_validate_jwt"
+	jwksConfig            string = "jwks"
+)
+
+// tenantKeys is the JWKS of a tenant, which is empty if the JWKS is not fetched yet.
+type tenantKeys struct {
+	issuer    string
+	jwksURL   string
+	jwks      string
+	fetchedAt time.Time
+}
+
+type cache struct {
+	issuerTemplate      string
+	jwksURLTemplate     string
+	refreshInterval     time.Duration
+	retryInterval       time.Duration
+	skipSSLVerification bool
+	publish             func(keyManagers []eventhubTypes.KeyManager)
+	// trustedTenants is the set of tenant domains, for which the resident key manager is trusted
+	trustedTenants map[string]bool
+
+	mutex   sync.Mutex
+	tenants map[string]*tenantKeys
+	// fetching is the set of tenant domains of which the JWKS is being fetched
+	fetching map[string]bool
+}
+
+var tenantCache *cache
+
+// Start caches the JWKS of the configured tenants, and refreshes the cached JWKS once they are older than the
+// refresh interval.
+func Start() {
+	conf, _ := config.ReadConfigs()
+	tenantJWKS := conf.ControlPlane.TenantJWKS
+	if !tenantJWKS.Enabled {
+		return
+	}
+	if len(tenantJWKS.Tenants) == 0 {
+		logger.LoggerJWKS.Warn("JWKS of the tenants are not cached, as no tenant is configured")
+		return
+	}
+	tenantCache = newCache(tenantJWKS.IssuerTemplate, tenantJWKS.JWKSURLTemplate,
+		time.Duration(tenantJWKS.RefreshIntervalInSeconds)*time.Second, conf.ControlPlane.RetryInterval*time.Second,
+		conf.ControlPlane.SkipSSLVerification, tenantJWKS.Tenants, xds.SetTenantKeyManagers)
+	for _, tenantDomain := range tenantJWKS.Tenants {
+		tenantCache.refreshTenant(tenantDomain, false)
+	}
+	go func() {
+		for now := range time.Tick(tenantCache.retryInterval) {
+			tenantCache.refreshExpired(now)
+		}
+	}()
+	logger.LoggerJWKS.Infof("Caching the JWKS of the tenants, which are refreshed in every %v",
+		tenantCache.refreshInterval)
+}
+
+// AddTenant fetches and caches the JWKS of the tenant, if it is not cached already. The JWKS is not cached if the
+// resident key manager is not trusted for the tenant.
+func AddTenant(tenantDomain string) {
+	if tenantCache != nil && tenantDomain != "" {
+		tenantCache.refreshTenant(tenantDomain, false)
+	}
+}
+
+// InvalidateTenant fetches the JWKS of the tenant again, as the key managers of the tenant are changed.
+func InvalidateTenant(tenantDomain string) {
+	if tenantCache != nil && tenantDomain != "" {
+		tenantCache.refreshTenant(tenantDomain, true)
+	}
+}
+
+func newCache(issuerTemplate, jwksURLTemplate string, refreshInterval, retryInterval time.Duration,
+	skipSSLVerification bool, trustedTenants []string, publish func(keyManagers []eventhubTypes.KeyManager)) *cache {
+	if retryInterval <= 0 {
+		retryInterval = time.Second
+	}
+	if refreshInterval < retryInterval {
+		refreshInterval = retryInterval
+	}
+	trustedTenantSet := make(map[string]bool, len(trustedTenants))
+	for _, tenantDomain := range trustedTenants {
+		trustedTenantSet[tenantDomain] = true
+	}
+	return &cache{
+		issuerTemplate:      issuerTemplate,
+		jwksURLTemplate:     jwksURLTemplate,
+		refreshInterval:     refreshInterval,
+		retryInterval:       retryInterval,
+		skipSSLVerification: skipSSLVerification,
+		publish:             publish,
+		trustedTenants:      trustedTenantSet,
+		tenants:             make(map[string]*tenantKeys),
+		fetching:            make(map[string]bool),
+	}
+}
+
+// refreshTenant fetches the JWKS of the tenant in the background. The JWKS is not fetched if the resident key
+// manager is not trusted for the tenant, if it is being fetched, or if it is cached and the refresh is not forced.
+func (c *cache) refreshTenant(tenantDomain string, force bool) {
+	if !c.trustedTenants[tenantDomain] {
+		logger.LoggerJWKS.Debugf("JWKS of the tenant domain %q is not cached, as the tenant is not configured",
+			tenantDomain)
+		return
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if _, cached := c.tenants[tenantDomain]; (cached && !force) || c.fetching[tenantDomain] {
+		return
+	}
+	c.fetching[tenantDomain] = true
+	go c.refresh(tenantDomain)
+}
+
+// refreshExpired fetches the JWKS older than the refresh interval, and retries the JWKS which are failed to fetch.
+func (c *cache) refreshExpired(now time.Time) {
+	c.mutex.Lock()
+	var expiredTenants []string
+	for tenantDomain, keys := range c.tenants {
+		ttl := c.refreshInterval
+		if keys.jwks == "" {
+			ttl = c.retryInterval
+		}
+		if now.Sub(keys.fetchedAt) >= ttl && !c.fetching[tenantDomain] {
+			c.fetching[tenantDomain] = true
+			expiredTenants = append(expiredTenants, tenantDomain)
+		}
+	}
+	c.mutex.Unlock()
+	for _, tenantDomain := range expiredTenants {
+		c.refresh(tenantDomain)
+	}
+}
+
+// refresh fetches the JWKS of the tenant, and distributes the key managers to the enforcers if the JWKS is
+// changed. The previous JWKS is kept if the JWKS cannot be fetched.
+func (c *cache) refresh(tenantDomain string) {
+	keys := &tenantKeys{
+		issuer:  resolveTemplate(c.issuerTemplate, tenantDomain),
+		jwksURL: resolveTemplate(c.jwksURLTemplate, tenantDomain),
+	}
+	jwks, err := c.fetchJWKS(keys.jwksURL)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	delete(c.fetching, tenantDomain)
+	keys.fetchedAt = time.Now()
+	previousJWKS := ""
+	if previousKeys, cached := c.tenants[tenantDomain]; cached {
+		previousJWKS = previousKeys.jwks
+	}
+	if err != nil {
+		logger.LoggerJWKS.ErrorC(logging.ErrorDetails{
+			Message: fmt.Sprintf("Error while fetching the JWKS of the tenant domain %q from %s. %v",
+				tenantDomain, keys.jwksURL, err),
+			Severity:  logging.MINOR,
+			ErrorCode: 2700,
+		})
+		jwks = previousJWKS
+	}
+	keys.jwks = jwks
+	c.tenants[tenantDomain] = keys
+	if jwks == previousJWKS {
+		return
+	}
+	logger.LoggerJWKS.Infof("JWKS of the tenant domain %q is updated", tenantDomain)
+	c.publish(c.keyManagers())
+}
+
+func (c *cache) fetchJWKS(jwksURL string) (string, error) {
+	req, err := http.NewRequest(http.MethodGet, jwksURL, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := tlsutils.InvokeControlPlane(req, c.skipSSLVerification)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("JWKS endpoint responded with %d", resp.StatusCode)
+	}
+	var keySet struct {
+		Keys []json.RawMessage `json:"keys"`
+	}
+	if err := json.Unmarshal(body, &keySet); err != nil {
+		return "", err
+	}
+	if len(keySet.Keys) == 0 {
+		return "", errors.New("JWKS does not contain any key")
+	}
+	return string(body), nil
+}
+
+// keyManagers returns the resident key managers of the tenants of which the JWKS is fetched, sorted by the tenant
+// domain.
+func (c *cache) keyManagers() []eventhubTypes.KeyManager {
+	tenantDomains := make([]string, 0, len(c.tenants))
+	for tenantDomain, keys := range c.tenants {
+		if keys.jwks != "" {
+			tenantDomains = append(tenantDomains, tenantDomain)
+		}
+	}
+	sort.Strings(tenantDomains)
+	keyManagers := make([]eventhubTypes.KeyManager, 0, len(tenantDomains))
+	for _, tenantDomain := range tenantDomains {
+		keys := c.tenants[tenantDomain]
+		keyManagers = append(keyManagers, eventhubTypes.KeyManager{
+			Name:         residentKeyManagerName,
+			Type:         residentKeyManagerType,
+			Enabled:      true,
+			TenantDomain: tenantDomain,
+			Configuration: map[string]interface{}{
+				issuerConfig:          keys.issuer,
+				jwksEndpointConfig:    keys.jwksURL,
+				selfValidateJWTConfig: true,
+				jwksConfig:            keys.jwks,
+			},
+		})
+	}
+	return keyManagers
+}
+
+// resolveTemplate resolves the issuer or the JWKS URL template for the tenant.
+func resolveTemplate(template, tenantDomain string) string {
+	tenantPath := ""
+	if tenantDomain != superTenantDomain {
+		tenantPath = "/t/" + tenantDomain
+	}
+	return strings.NewReplacer(tenantPathPlaceholder, tenantPath, tenantDomainPlaceholder, tenantDomain).
+		Replace(template)
+}
@@ -0,0 +1,127 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package jwks
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	eventhubTypes "github.com/wso2/product-microgateway/adapter/pkg/eventhub/types"
+)
+
+func TestResolveTemplate(t *testing.T) {
+	assert.Equal(t, "https://apim:9443/oauth2/jwks",
+		resolveTemplate("https://apim:9443{tenantPath}/oauth2/jwks", "carbon.super"))
+	assert.Equal(t, "https://apim:9443/t/wso2.com/oauth2/jwks",
+		resolveTemplate("https://apim:9443{tenantPath}/oauth2/jwks", "wso2.com"))
+	assert.Equal(t, "https://wso2.com.idp/token", resolveTemplate("https://{tenantDomain}.idp/token", "wso2.com"))
+}
+
+func TestRefresh(t *testing.T) {
+	var jwks atomic.Value
+	jwks.Store(`{"keys":[{"kid":"1"}]}`)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/t/wso2.com/oauth2/jwks" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(jwks.Load().(string)))
+	}))
+	defer server.Close()
+
+	var published [][]eventhubTypes.KeyManager
+	c := newCache("https://apim{tenantPath}/oauth2/token", server.URL+"{tenantPath}/oauth2/jwks", time.Hour,
+		time.Second, true, []string{"wso2.com", "foo.com"}, func(keyManagers []eventhubTypes.KeyManager) {
+			published = append(published, keyManagers)
+		})
+
+	c.refresh("wso2.com")
+	assert.Len(t, published, 1)
+	assert.Len(t, published[0], 1)
+	keyManager := published[0][0]
+	assert.Equal(t, "wso2.com", keyManager.TenantDomain)
+	assert.Equal(t, "https://apim/t/wso2.com/oauth2/token", keyManager.Configuration[issuerConfig])
+	assert.Equal(t, `{"keys":[{"kid":"1"}]}`, keyManager.Configuration[jwksConfig])
+	assert.Equal(t, true, keyManager.Configuration[selfValidateJWTConfig])
+
+	// the enforcers are not updated if the JWKS is not changed
+	c.refresh("wso2.com")
+	assert.Len(t, published, 1)
+
+	// the JWKS of the tenant, which cannot be fetched, is not distributed
+	c.refresh("foo.com")
+	assert.Len(t, published, 1)
+	assert.Equal(t, "", c.tenants["foo.com"].jwks)
+
+	jwks.Store(`{"keys":[{"kid":"2"}]}`)
+	c.refresh("wso2.com")
+	assert.Len(t, published, 2)
+	assert.Equal(t, `{"keys":[{"kid":"2"}]}`, published[1][0].Configuration[jwksConfig])
+
+	// the previous JWKS is kept if the JWKS cannot be fetched
+	jwks.Store(`{"keys":[]}`)
+	c.refresh("wso2.com")
+	assert.Len(t, published, 2)
+	assert.Equal(t, `{"keys":[{"kid":"2"}]}`, c.tenants["wso2.com"].jwks)
+}
+
+func TestRefreshExpired(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Write([]byte(`{"keys":[{"kid":"1"}]}`))
+	}))
+	defer server.Close()
+
+	c := newCache("https://apim{tenantPath}/oauth2/token", server.URL+"{tenantPath}/oauth2/jwks", time.Hour,
+		time.Second, true, []string{"carbon.super"}, func(keyManagers []eventhubTypes.KeyManager) {})
+	c.refresh("carbon.super")
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+
+	c.refreshExpired(time.Now().Add(time.Minute))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests), "JWKS is fetched before it is expired")
+
+	c.refreshExpired(time.Now().Add(time.Hour))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+}
+
+func TestRefreshTenant(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Write([]byte(`{"keys":[{"kid":"1"}]}`))
+	}))
+	defer server.Close()
+
+	c := newCache("https://apim{tenantPath}/oauth2/token", server.URL+"{tenantPath}/oauth2/jwks", time.Hour,
+		time.Second, true, []string{"carbon.super"}, func(keyManagers []eventhubTypes.KeyManager) {})
+	c.refreshTenant("wso2.com", false)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&requests),
+		"Resident key manager should not be trusted for the tenants which are not configured")
+	assert.NotContains(t, c.tenants, "wso2.com")
+	assert.NotContains(t, c.fetching, "wso2.com")
+
+	c.refreshTenant("carbon.super", false)
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt32(&requests) == 1
+	}, time.Second, 10*time.Millisecond)
+}
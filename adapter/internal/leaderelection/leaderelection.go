@@ -0,0 +1,188 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+// Package leaderelection elects the leader among the adapters connected to the control plane, with a Kubernetes
+// lease. The lease is held by the leader as long as it is renewed within the lease duration.
+package leaderelection
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/wso2/product-microgateway/adapter/config"
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/pkg/logging"
+)
+
+// elector acquires and renews the lease on behalf of an adapter.
+type elector struct {
+	client        *leaseClient
+	identity      string
+	leaseDuration time.Duration
+	retryPeriod   time.Duration
+	// observedRecord is the holder and the renew time of the lease last read, and observedAt is the time it is
+	// changed. The expiry of the lease of another adapter is decided by the local clock, hence the clock skew
+	// between the adapters does not matter.
+	observedRecord string
+	observedAt     time.Time
+	// lastRenewal is the time the lease is last acquired or renewed by this adapter
+	lastRenewal time.Time
+	leading     bool
+	// released is set once the lease is given up, hence the adapter does not acquire the lease again
+	released bool
+	mutex    sync.Mutex
+}
+
+var currentElector *elector
+
+// Start elects the leader in the background. onStartedLeading is called once the lease is acquired, and
+// onStoppedLeading once the lease is not renewed before it may be acquired by another adapter.
+func Start(onStartedLeading, onStoppedLeading func()) error {
+	conf, _ := config.ReadConfigs()
+	haConf := conf.Adapter.HA
+	identity := haConf.Identity
+	if identity == "" {
+		var err error
+		if identity, err = os.Hostname(); err != nil {
+			return fmt.Errorf("error resolving the identity of the adapter. %v", err)
+		}
+	}
+	client, err := newLeaseClient(haConf.APIServerURL, haConf.Namespace, haConf.LeaseName, haConf.TokenFile,
+		haConf.CACertFile)
+	if err != nil {
+		return err
+	}
+	currentElector = &elector{
+		client:        client,
+		identity:      identity,
+		leaseDuration: time.Duration(haConf.LeaseDurationInSeconds) * time.Second,
+		retryPeriod:   time.Duration(haConf.RetryPeriodInSeconds) * time.Second,
+	}
+	logger.LoggerLeaderElection.Infof("Joining the election of the lease %s/%s as %s", haConf.Namespace,
+		haConf.LeaseName, identity)
+	go currentElector.run(onStartedLeading, onStoppedLeading)
+	return nil
+}
+
+// Release gives up the lease if the adapter is the leader (ie: when the adapter is stopped), hence a follower
+// acquires the lease without waiting for the lease duration. The adapter leaves the election, hence the lease is
+// not acquired again.
+func Release() {
+	if currentElector == nil {
+		return
+	}
+	currentElector.mutex.Lock()
+	defer currentElector.mutex.Unlock()
+	currentElector.released = true
+	if !currentElector.leading {
+		return
+	}
+	current, err := currentElector.client.get()
+	if err == nil && current.Spec.HolderIdentity == currentElector.identity {
+		spec := current.Spec
+		spec.HolderIdentity = ""
+		_, err = currentElector.client.update(current, spec)
+	}
+	if err != nil {
+		logger.LoggerLeaderElection.Warnf("Error while releasing the lease. %v", err)
+		return
+	}
+	currentElector.leading = false
+	logger.LoggerLeaderElection.Info("Lease is released")
+}
+
+func (e *elector) run(onStartedLeading, onStoppedLeading func()) {
+	// the leader stops leading a retry period before the followers consider the lease expired
+	renewDeadline := e.leaseDuration - e.retryPeriod
+	for {
+		e.mutex.Lock()
+		if e.released {
+			e.mutex.Unlock()
+			return
+		}
+		now := time.Now()
+		acquired, err := e.tryAcquireOrRenew(now)
+		if err != nil {
+			logger.LoggerLeaderElection.ErrorC(logging.ErrorDetails{
+				Message:   fmt.Sprintf("Error while acquiring or renewing the lease. %v", err),
+				Severity:  logging.MAJOR,
+				ErrorCode: 2800,
+			})
+		}
+		if acquired {
+			e.lastRenewal = now
+			if !e.leading {
+				e.leading = true
+				logger.LoggerLeaderElection.Infof("%s is elected as the leader", e.identity)
+				go onStartedLeading()
+			}
+		} else if e.leading && (err == nil || now.Sub(e.lastRenewal) > renewDeadline) {
+			e.leading = false
+			e.mutex.Unlock()
+			logger.LoggerLeaderElection.Infof("%s is no longer the leader", e.identity)
+			onStoppedLeading()
+			return
+		}
+		e.mutex.Unlock()
+		time.Sleep(e.retryPeriod)
+	}
+}
+
+// tryAcquireOrRenew renews the lease if it is held by the adapter, or acquires it if it is not held or expired.
+// Returns whether the adapter holds the lease.
+func (e *elector) tryAcquireOrRenew(now time.Time) (bool, error) {
+	spec := leaseSpec{
+		HolderIdentity:       e.identity,
+		LeaseDurationSeconds: int(e.leaseDuration.Seconds()),
+		RenewTime:            now.UTC().Format(microTimeFormat),
+	}
+	current, err := e.client.get()
+	if err == errLeaseNotFound {
+		spec.AcquireTime = spec.RenewTime
+		if _, err = e.client.create(spec); err == errLeaseConflict {
+			return false, nil
+		}
+		return err == nil, err
+	} else if err != nil {
+		return false, err
+	}
+
+	if record := current.Spec.HolderIdentity + "@" + current.Spec.RenewTime; record != e.observedRecord {
+		e.observedRecord = record
+		e.observedAt = now
+	}
+	if current.Spec.HolderIdentity == e.identity {
+		spec.AcquireTime = current.Spec.AcquireTime
+		spec.LeaseTransitions = current.Spec.LeaseTransitions
+	} else {
+		leaseDuration := time.Duration(current.Spec.LeaseDurationSeconds) * time.Second
+		if leaseDuration <= 0 {
+			leaseDuration = e.leaseDuration
+		}
+		if current.Spec.HolderIdentity != "" && now.Before(e.observedAt.Add(leaseDuration)) {
+			return false, nil
+		}
+		spec.AcquireTime = spec.RenewTime
+		spec.LeaseTransitions = current.Spec.LeaseTransitions + 1
+	}
+	if _, err = e.client.update(current, spec); err == errLeaseConflict {
+		return false, nil
+	}
+	return err == nil, err
+}
@@ -0,0 +1,135 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package leaderelection
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newFakeAPIServer serves a lease, rejecting the updates of a stale resource version as the API server does.
+func newFakeAPIServer() *httptest.Server {
+	var stored *lease
+	var mutex sync.Mutex
+	version := 0
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		var received lease
+		if r.Method != http.MethodGet {
+			json.NewDecoder(r.Body).Decode(&received)
+		}
+		switch {
+		case r.Method == http.MethodGet && stored == nil:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		case r.Method == http.MethodPost && stored != nil,
+			r.Method == http.MethodPut && received.Metadata.ResourceVersion != stored.Metadata.ResourceVersion:
+			w.WriteHeader(http.StatusConflict)
+			return
+		case r.Method != http.MethodGet:
+			version++
+			received.Metadata.ResourceVersion = strconv.Itoa(version)
+			stored = &received
+		}
+		json.NewEncoder(w).Encode(stored)
+	}))
+}
+
+func newTestElector(server *httptest.Server, identity string) *elector {
+	return &elector{
+		client: &leaseClient{
+			leaseURL:   server.URL + "/apis/coordination.k8s.io/v1/namespaces/default/leases",
+			name:       "adapter",
+			namespace:  "default",
+			httpClient: server.Client(),
+		},
+		identity:      identity,
+		leaseDuration: 15 * time.Second,
+		retryPeriod:   2 * time.Second,
+	}
+}
+
+func TestTryAcquireOrRenew(t *testing.T) {
+	server := newFakeAPIServer()
+	defer server.Close()
+	adapter1 := newTestElector(server, "adapter-1")
+	adapter2 := newTestElector(server, "adapter-2")
+	now := time.Now()
+
+	acquired, err := adapter1.tryAcquireOrRenew(now)
+	assert.Nil(t, err)
+	assert.True(t, acquired, "Lease is not acquired when it does not exist")
+	acquired, err = adapter2.tryAcquireOrRenew(now)
+	assert.Nil(t, err)
+	assert.False(t, acquired, "Lease held by another adapter is acquired")
+
+	acquired, _ = adapter1.tryAcquireOrRenew(now.Add(10 * time.Second))
+	assert.True(t, acquired, "Lease is not renewed by the leader")
+	// the renewal is observed by the follower, hence the lease is not expired
+	acquired, _ = adapter2.tryAcquireOrRenew(now.Add(12 * time.Second))
+	assert.False(t, acquired, "Renewed lease is acquired")
+
+	// the leader stops renewing the lease
+	acquired, _ = adapter2.tryAcquireOrRenew(now.Add(28 * time.Second))
+	assert.True(t, acquired, "Expired lease is not acquired")
+	current, _ := adapter2.client.get()
+	assert.Equal(t, "adapter-2", current.Spec.HolderIdentity)
+	assert.Equal(t, 1, current.Spec.LeaseTransitions)
+	acquired, _ = adapter1.tryAcquireOrRenew(now.Add(29 * time.Second))
+	assert.False(t, acquired, "Former leader renews the lease acquired by another adapter")
+}
+
+func TestRelease(t *testing.T) {
+	server := newFakeAPIServer()
+	defer server.Close()
+	currentElector = newTestElector(server, "adapter-1")
+	defer func() { currentElector = nil }()
+	adapter2 := newTestElector(server, "adapter-2")
+	now := time.Now()
+
+	acquired, _ := currentElector.tryAcquireOrRenew(now)
+	assert.True(t, acquired)
+	currentElector.leading = true
+	acquired, _ = adapter2.tryAcquireOrRenew(now)
+	assert.False(t, acquired)
+
+	Release()
+	assert.False(t, currentElector.leading)
+	acquired, _ = adapter2.tryAcquireOrRenew(now.Add(time.Second))
+	assert.True(t, acquired, "Released lease is not acquired before the lease duration")
+
+	// the released adapter leaves the election, hence does not acquire the lease again
+	returned := make(chan struct{})
+	go func() {
+		currentElector.run(func() { t.Error("Lease is acquired again once released") }, func() {})
+		close(returned)
+	}()
+	select {
+	case <-returned:
+	case <-time.After(time.Second):
+		t.Error("Released adapter does not leave the election")
+	}
+}
@@ -0,0 +1,180 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package leaderelection
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	requestTimeout = 10 * time.Second
+	// microTimeFormat is the format of the times of the lease (metav1.MicroTime)
+	microTimeFormat = "2006-01-02T15:04:05.000000Z07:00"
+)
+
+var (
+	// errLeaseNotFound is returned when the lease is not created yet
+	errLeaseNotFound = errors.New("lease is not found")
+	// errLeaseConflict is returned when the lease is changed by another adapter since it is read
+	errLeaseConflict = errors.New("lease is changed by another adapter")
+)
+
+// lease is a coordination.k8s.io/v1 Lease.
+type lease struct {
+	APIVersion string        `json:"apiVersion"`
+	Kind       string        `json:"kind"`
+	Metadata   leaseMetadata `json:"metadata"`
+	Spec       leaseSpec     `json:"spec"`
+}
+
+type leaseMetadata struct {
+	Name            string `json:"name"`
+	Namespace       string `json:"namespace,omitempty"`
+	ResourceVersion string `json:"resourceVersion,omitempty"`
+}
+
+type leaseSpec struct {
+	HolderIdentity       string `json:"holderIdentity,omitempty"`
+	LeaseDurationSeconds int    `json:"leaseDurationSeconds,omitempty"`
+	AcquireTime          string `json:"acquireTime,omitempty"`
+	RenewTime            string `json:"renewTime,omitempty"`
+	LeaseTransitions     int    `json:"leaseTransitions"`
+}
+
+// status is the error response of the Kubernetes API server.
+type status struct {
+	Message string `json:"message"`
+	Code    int    `json:"code"`
+}
+
+// leaseClient reads and writes the lease through the Kubernetes API server.
+type leaseClient struct {
+	leaseURL  string
+	name      string
+	namespace string
+	// tokenFile is read per request, as the projected service account tokens are rotated
+	tokenFile  string
+	httpClient *http.Client
+}
+
+func newLeaseClient(apiServerURL, namespace, name, tokenFile, caCertFile string) (*leaseClient, error) {
+	caCert, err := ioutil.ReadFile(caCertFile)
+	if err != nil {
+		return nil, fmt.Errorf("error reading the CA certificate of the API server. %v", err)
+	}
+	certPool := x509.NewCertPool()
+	if !certPool.AppendCertsFromPEM(caCert) {
+		return nil, fmt.Errorf("invalid CA certificate of the API server %s", caCertFile)
+	}
+	return &leaseClient{
+		leaseURL: strings.TrimSuffix(apiServerURL, "/") + "/apis/coordination.k8s.io/v1/namespaces/" +
+			url.PathEscape(namespace) + "/leases",
+		name:      name,
+		namespace: namespace,
+		tokenFile: tokenFile,
+		httpClient: &http.Client{
+			Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: certPool}},
+			Timeout:   requestTimeout,
+		},
+	}, nil
+}
+
+// get returns the lease, or errLeaseNotFound if it is not created yet.
+func (client *leaseClient) get() (*lease, error) {
+	return client.do(http.MethodGet, client.leaseURL+"/"+url.PathEscape(client.name), nil)
+}
+
+// create creates the lease, or returns errLeaseConflict if it is created by another adapter.
+func (client *leaseClient) create(spec leaseSpec) (*lease, error) {
+	return client.do(http.MethodPost, client.leaseURL, &lease{
+		APIVersion: "coordination.k8s.io/v1",
+		Kind:       "Lease",
+		Metadata:   leaseMetadata{Name: client.name, Namespace: client.namespace},
+		Spec:       spec,
+	})
+}
+
+// update replaces the spec of the lease, or returns errLeaseConflict if the lease is changed since it is read.
+func (client *leaseClient) update(current *lease, spec leaseSpec) (*lease, error) {
+	updated := *current
+	updated.Spec = spec
+	return client.do(http.MethodPut, client.leaseURL+"/"+url.PathEscape(client.name), &updated)
+}
+
+func (client *leaseClient) do(method, requestURL string, body *lease) (*lease, error) {
+	var reqBody io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reqBody = bytes.NewReader(payload)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, method, requestURL, reqBody)
+	if err != nil {
+		return nil, err
+	}
+	if client.tokenFile != "" {
+		token, err := ioutil.ReadFile(client.tokenFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading the service account token. %v", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := client.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusCreated:
+		var result lease
+		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+			return nil, fmt.Errorf("error decoding the lease. %v", err)
+		}
+		return &result, nil
+	case http.StatusNotFound:
+		return nil, errLeaseNotFound
+	case http.StatusConflict:
+		return nil, errLeaseConflict
+	}
+	var errStatus status
+	respBody, _ := ioutil.ReadAll(resp.Body)
+	if json.Unmarshal(respBody, &errStatus) == nil && errStatus.Message != "" {
+		return nil, fmt.Errorf("%s (%d)", errStatus.Message, resp.StatusCode)
+	}
+	return nil, fmt.Errorf("unexpected response from the Kubernetes API server (%d)", resp.StatusCode)
+}
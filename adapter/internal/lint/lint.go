@@ -0,0 +1,262 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+// Package lint checks the configuration generated by the adapter against a rule set, producing a scored report
+// for the pre-production gate checks.
+package lint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/wso2/product-microgateway/adapter/internal/configdump"
+)
+
+// Severities of the findings
+const (
+	SeverityError   string = "ERROR"
+	SeverityWarning string = "WARNING"
+)
+
+// Rules of the rule set
+const (
+	RuleUnreachableCluster   string = "unreachable-cluster"
+	RuleRouteWithoutSecurity string = "route-without-security"
+	RuleAPIWithoutPolicies   string = "api-without-policies"
+	RuleExpiringCertificate  string = "expiring-certificate"
+)
+
+// penalties deducted from the score per finding of the severity
+var penalties = map[string]int{
+	SeverityError:   10,
+	SeverityWarning: 3,
+}
+
+const (
+	maxScore      int    = 100
+	unlimitedTier string = "Unlimited"
+	degradedAPI   string = "DEGRADED"
+)
+
+// Options configures the rules.
+type Options struct {
+	// CertificateExpiryThreshold is the period before the expiry of a certificate, from which it is reported
+	CertificateExpiryThreshold time.Duration
+	// Now is the time the certificates are checked at
+	Now time.Time
+}
+
+// Finding is a violation of a rule.
+type Finding struct {
+	Rule        string `json:"rule"`
+	Severity    string `json:"severity"`
+	Environment string `json:"environment,omitempty"`
+	// Subject is the API, route, cluster or certificate violating the rule
+	Subject string `json:"subject"`
+	Message string `json:"message"`
+}
+
+// Report is the result of linting a configuration. The score is reduced from 100 for each finding according to
+// its severity, down to 0.
+type Report struct {
+	Score    int                   `json:"score"`
+	Errors   int                   `json:"errors"`
+	Warnings int                   `json:"warnings"`
+	Findings []Finding             `json:"findings"`
+	Store    configdump.StoreState `json:"store"`
+}
+
+// Lint checks the configuration against the rule set.
+func Lint(dump configdump.ConfigDump, options Options) Report {
+	if options.Now.IsZero() {
+		options.Now = time.Now()
+	}
+	report := Report{Findings: []Finding{}, Store: dump.Store}
+	for _, environment := range dump.Environments {
+		for _, api := range environment.APIs {
+			subject := getAPISubject(api)
+			report.Findings = append(report.Findings, checkClusters(environment.Label, subject, api)...)
+			report.Findings = append(report.Findings, checkSecurity(environment.Label, subject, api)...)
+			report.Findings = append(report.Findings, checkPolicies(environment.Label, subject, api)...)
+			certificates := api.ClientCertificates
+			for _, cluster := range api.Clusters {
+				certificates = append(certificates, cluster.Certificates...)
+			}
+			report.Findings = append(report.Findings,
+				checkCertificates(environment.Label, subject, certificates, options)...)
+		}
+	}
+	report.Findings = append(report.Findings, checkCertificates("", "", dump.Certificates, options)...)
+	report.Findings = deduplicate(report.Findings)
+
+	report.Score = maxScore
+	for _, finding := range report.Findings {
+		switch finding.Severity {
+		case SeverityError:
+			report.Errors++
+		case SeverityWarning:
+			report.Warnings++
+		}
+		report.Score -= penalties[finding.Severity]
+	}
+	if report.Score < 0 {
+		report.Score = 0
+	}
+	return report
+}
+
+// checkClusters reports the clusters without endpoints, and the APIs whose endpoints are failing the synthetic
+// probes.
+func checkClusters(environment, subject string, api configdump.API) []Finding {
+	var findings []Finding
+	for _, cluster := range api.Clusters {
+		if len(cluster.Endpoints) == 0 {
+			findings = append(findings, Finding{
+				Rule:        RuleUnreachableCluster,
+				Severity:    SeverityError,
+				Environment: environment,
+				Subject:     cluster.Name,
+				Message:     fmt.Sprintf("Cluster of the API %s has no endpoints", subject),
+			})
+		}
+	}
+	if api.HealthStatus == degradedAPI {
+		findings = append(findings, Finding{
+			Rule:        RuleUnreachableCluster,
+			Severity:    SeverityWarning,
+			Environment: environment,
+			Subject:     subject,
+			Message:     "Endpoints of the API are failing the synthetic probes",
+		})
+	}
+	return findings
+}
+
+// checkSecurity reports the APIs and the operations which are routed without authentication.
+func checkSecurity(environment, subject string, api configdump.API) []Finding {
+	if api.DisableSecurity {
+		return []Finding{{
+			Rule:        RuleRouteWithoutSecurity,
+			Severity:    SeverityError,
+			Environment: environment,
+			Subject:     subject,
+			Message:     "Security is disabled for all the resources of the API",
+		}}
+	}
+	var findings []Finding
+	for _, resource := range api.Resources {
+		for _, operation := range resource.Operations {
+			if operation.DisableSecurity {
+				findings = append(findings, Finding{
+					Rule:        RuleRouteWithoutSecurity,
+					Severity:    SeverityWarning,
+					Environment: environment,
+					Subject:     fmt.Sprintf("%s %s%s", operation.Method, api.BasePath, resource.Path),
+					Message:     fmt.Sprintf("Security is disabled for the resource of the API %s", subject),
+				})
+			}
+		}
+	}
+	return findings
+}
+
+// checkPolicies reports the APIs without a rate limiting tier or an operation policy.
+func checkPolicies(environment, subject string, api configdump.API) []Finding {
+	if isLimitingTier(api.Tier) {
+		return nil
+	}
+	for _, resource := range api.Resources {
+		for _, operation := range resource.Operations {
+			if isLimitingTier(operation.Tier) || operation.PolicyCount > 0 {
+				return nil
+			}
+		}
+	}
+	return []Finding{{
+		Rule:        RuleAPIWithoutPolicies,
+		Severity:    SeverityWarning,
+		Environment: environment,
+		Subject:     subject,
+		Message:     "API has neither a rate limiting tier nor an operation policy",
+	}}
+}
+
+// checkCertificates reports the certificates which are expired, or expiring within the threshold.
+func checkCertificates(environment, subject string, certificates []configdump.Certificate,
+	options Options) []Finding {
+	var findings []Finding
+	for _, certificate := range certificates {
+		finding := Finding{
+			Rule:        RuleExpiringCertificate,
+			Environment: environment,
+			Subject:     certificate.Subject,
+		}
+		owner := certificate.Source
+		if subject != "" {
+			owner = fmt.Sprintf("%s (%s)", subject, certificate.Source)
+		}
+		if certificate.NotAfter.Before(options.Now) {
+			finding.Severity = SeverityError
+			finding.Message = fmt.Sprintf("Certificate of %s expired at %s", owner,
+				certificate.NotAfter.Format(time.RFC3339))
+		} else if certificate.NotAfter.Before(options.Now.Add(options.CertificateExpiryThreshold)) {
+			finding.Severity = SeverityWarning
+			finding.Message = fmt.Sprintf("Certificate of %s expires at %s", owner,
+				certificate.NotAfter.Format(time.RFC3339))
+		} else {
+			continue
+		}
+		findings = append(findings, finding)
+	}
+	return findings
+}
+
+func isLimitingTier(tier string) bool {
+	return tier != "" && !strings.EqualFold(tier, unlimitedTier)
+}
+
+func getAPISubject(api configdump.API) string {
+	if api.Name == "" {
+		return api.ID
+	}
+	return fmt.Sprintf("%s:%s (%s)", api.Name, api.Version, api.Vhost)
+}
+
+// deduplicate removes the repeated findings (ex: a certificate shared by the clusters of an API) and sorts the
+// findings by the severity, environment and subject.
+func deduplicate(findings []Finding) []Finding {
+	seen := make(map[Finding]struct{}, len(findings))
+	unique := make([]Finding, 0, len(findings))
+	for _, finding := range findings {
+		if _, found := seen[finding]; !found {
+			seen[finding] = struct{}{}
+			unique = append(unique, finding)
+		}
+	}
+	sort.SliceStable(unique, func(i, j int) bool {
+		if unique[i].Severity != unique[j].Severity {
+			return unique[i].Severity == SeverityError
+		}
+		if unique[i].Environment != unique[j].Environment {
+			return unique[i].Environment < unique[j].Environment
+		}
+		return unique[i].Subject < unique[j].Subject
+	})
+	return unique
+}
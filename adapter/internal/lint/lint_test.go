@@ -0,0 +1,120 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package lint
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-microgateway/adapter/internal/configdump"
+)
+
+func TestLint(t *testing.T) {
+	now := time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)
+	dump := configdump.ConfigDump{
+		Environments: []configdump.Environment{{
+			Label: "Default",
+			APIs: []configdump.API{{
+				ID:      "localhost:petstore",
+				Name:    "PetStore",
+				Version: "1.0.0",
+				Vhost:   "localhost",
+				Tier:    "Gold",
+				Resources: []configdump.Resource{{
+					Path: "/pets",
+					Operations: []configdump.Operation{
+						{Method: "GET", Tier: "Unlimited"},
+						{Method: "POST", DisableSecurity: true},
+					},
+				}},
+				Clusters: []configdump.Cluster{
+					{Name: "clusterProd", Endpoints: []string{"petstore:443"}, Certificates: []configdump.Certificate{
+						{Subject: "CN=petstore", Source: "upstream", NotAfter: now.Add(10 * 24 * time.Hour)},
+					}},
+					{Name: "clusterSand", Endpoints: []string{}},
+				},
+			}, {
+				ID:              "localhost:orders",
+				Name:            "Orders",
+				Version:         "v1",
+				Vhost:           "localhost",
+				DisableSecurity: true,
+				HealthStatus:    "DEGRADED",
+				Resources: []configdump.Resource{{
+					Path:       "/orders",
+					Operations: []configdump.Operation{{Method: "GET", Tier: "Unlimited"}},
+				}},
+			}, {
+				ID:      "localhost:billing",
+				Name:    "Billing",
+				Version: "v1",
+				Vhost:   "localhost",
+				Resources: []configdump.Resource{{
+					Path:       "/invoices",
+					Operations: []configdump.Operation{{Method: "GET", PolicyCount: 1}},
+				}},
+			}},
+		}},
+		Certificates: []configdump.Certificate{
+			{Subject: "CN=adapter", Source: "adapter", NotAfter: now.Add(-time.Hour)},
+			{Subject: "CN=router", Source: "router", NotAfter: now.Add(365 * 24 * time.Hour)},
+		},
+	}
+
+	report := Lint(dump, Options{CertificateExpiryThreshold: 30 * 24 * time.Hour, Now: now})
+	findings := make(map[string][]Finding)
+	for _, finding := range report.Findings {
+		findings[finding.Rule] = append(findings[finding.Rule], finding)
+	}
+
+	if assert.Len(t, findings[RuleUnreachableCluster], 2) {
+		assert.Equal(t, SeverityError, findings[RuleUnreachableCluster][0].Severity)
+		assert.Equal(t, "clusterSand", findings[RuleUnreachableCluster][0].Subject)
+		assert.Equal(t, "Orders:v1 (localhost)", findings[RuleUnreachableCluster][1].Subject,
+			"APIs failing the synthetic probes should be reported")
+	}
+	if assert.Len(t, findings[RuleRouteWithoutSecurity], 2) {
+		assert.Equal(t, SeverityError, findings[RuleRouteWithoutSecurity][0].Severity)
+		assert.Equal(t, "Orders:v1 (localhost)", findings[RuleRouteWithoutSecurity][0].Subject)
+		assert.Equal(t, "POST /pets", findings[RuleRouteWithoutSecurity][1].Subject)
+	}
+	if assert.Len(t, findings[RuleAPIWithoutPolicies], 1, "Only the API without a tier or a policy should be reported") {
+		assert.Equal(t, "Orders:v1 (localhost)", findings[RuleAPIWithoutPolicies][0].Subject)
+	}
+	if assert.Len(t, findings[RuleExpiringCertificate], 2) {
+		assert.Equal(t, SeverityError, findings[RuleExpiringCertificate][0].Severity)
+		assert.Equal(t, "CN=adapter", findings[RuleExpiringCertificate][0].Subject)
+		assert.Equal(t, SeverityWarning, findings[RuleExpiringCertificate][1].Severity)
+		assert.Equal(t, "CN=petstore", findings[RuleExpiringCertificate][1].Subject)
+	}
+	assert.Equal(t, 3, report.Errors)
+	assert.Equal(t, 4, report.Warnings)
+	assert.Equal(t, 100-3*10-4*3, report.Score)
+}
+
+func TestLintScoreLowerBound(t *testing.T) {
+	apis := make([]configdump.API, 20)
+	for i := range apis {
+		apis[i] = configdump.API{ID: string(rune('a' + i)), DisableSecurity: true, Tier: "Gold"}
+	}
+	report := Lint(configdump.ConfigDump{Environments: []configdump.Environment{{Label: "Default", APIs: apis}}},
+		Options{})
+	assert.Equal(t, 20, report.Errors)
+	assert.Equal(t, 0, report.Score)
+}
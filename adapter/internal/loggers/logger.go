@@ -47,6 +47,17 @@ const (
 	pkgGA                   = "github.com/wso2/product-microgateway/adapter/internal/ga"
 	pkgNotifier             = "github.com/wso2/product-microgateway/adapter/internal/notifier"
 	pkgSourceWatcher        = "github.com/wso2/product-microgateway/adapter/internal/sourcewatcher"
+	pkgAudit                = "github.com/wso2/product-microgateway/adapter/internal/audit"
+	pkgJobs                 = "github.com/wso2/product-microgateway/adapter/internal/jobs"
+	pkgCompaction           = "github.com/wso2/product-microgateway/adapter/internal/compaction"
+	pkgTenant               = "github.com/wso2/product-microgateway/adapter/internal/tenant"
+	pkgOperator             = "github.com/wso2/product-microgateway/adapter/internal/operator"
+	pkgAnalytics            = "github.com/wso2/product-microgateway/adapter/internal/analytics"
+	pkgJWKS                 = "github.com/wso2/product-microgateway/adapter/internal/jwks"
+	pkgLeaderElection       = "github.com/wso2/product-microgateway/adapter/internal/leaderelection"
+	pkgBotDetection         = "github.com/wso2/product-microgateway/adapter/internal/botdetection"
+	pkgQuotaSync            = "github.com/wso2/product-microgateway/adapter/internal/quotasync"
+	pkgEventOrder           = "github.com/wso2/product-microgateway/adapter/internal/eventorder"
 )
 
 // logger package references
@@ -66,6 +77,17 @@ var (
 	LoggerGA                   logging.Log
 	LoggerNotifier             logging.Log
 	LoggerSourceWatcher        logging.Log
+	LoggerAudit                logging.Log
+	LoggerJobs                 logging.Log
+	LoggerCompaction           logging.Log
+	LoggerTenant               logging.Log
+	LoggerOperator             logging.Log
+	LoggerAnalytics            logging.Log
+	LoggerJWKS                 logging.Log
+	LoggerLeaderElection       logging.Log
+	LoggerBotDetection         logging.Log
+	LoggerQuotaSync            logging.Log
+	LoggerEventOrder           logging.Log
 )
 
 func init() {
@@ -90,5 +112,16 @@ func UpdateLoggers() {
 	LoggerGA = logging.InitPackageLogger(pkgGA)
 	LoggerNotifier = logging.InitPackageLogger(pkgNotifier)
 	LoggerSourceWatcher = logging.InitPackageLogger(pkgSourceWatcher)
+	LoggerAudit = logging.InitPackageLogger(pkgAudit)
+	LoggerJobs = logging.InitPackageLogger(pkgJobs)
+	LoggerCompaction = logging.InitPackageLogger(pkgCompaction)
+	LoggerTenant = logging.InitPackageLogger(pkgTenant)
+	LoggerOperator = logging.InitPackageLogger(pkgOperator)
+	LoggerAnalytics = logging.InitPackageLogger(pkgAnalytics)
+	LoggerJWKS = logging.InitPackageLogger(pkgJWKS)
+	LoggerLeaderElection = logging.InitPackageLogger(pkgLeaderElection)
+	LoggerBotDetection = logging.InitPackageLogger(pkgBotDetection)
+	LoggerQuotaSync = logging.InitPackageLogger(pkgQuotaSync)
+	LoggerEventOrder = logging.InitPackageLogger(pkgEventOrder)
 	logrus.Info("Updated loggers")
 }
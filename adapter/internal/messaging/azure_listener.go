@@ -19,34 +19,74 @@
 package messaging
 
 import (
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/wso2/product-microgateway/adapter/config"
 	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
-	"github.com/wso2/product-microgateway/adapter/pkg/health"
 	msg "github.com/wso2/product-microgateway/adapter/pkg/messaging"
 )
 
 const (
-	componentName                = "adapter"
-	subscriptionIdleTimeDuration = "P0Y0M3DT0H0M0S"
+	componentName = "adapter"
+	// sharedAccessKey is a property of the connection strings of Azure Service Bus
+	sharedAccessKey = "SharedAccessKey"
 )
 
 // InitiateAndProcessEvents to pass event consumption
 func InitiateAndProcessEvents(config *config.Config) {
-	var err error
-	var reconnectRetryCount = config.ControlPlane.BrokerConnectionParameters.ReconnectRetryCount
-	var reconnectInterval = config.ControlPlane.BrokerConnectionParameters.ReconnectInterval
-	connectionString := config.ControlPlane.BrokerConnectionParameters.EventListeningEndpoints[0]
-	subscriptionMetaDataList, err := msg.InitiateBrokerConnectionAndValidate(connectionString, componentName,
-		reconnectRetryCount, reconnectInterval*time.Millisecond, subscriptionIdleTimeDuration)
-	health.SetControlPlaneBrokerStatus(err == nil)
-	if err == nil {
+	codec, err := newPayloadCodec(config)
+	if err != nil {
+		logPayloadCodecError(err)
+		return
+	}
+	if consumeEvents(newAzureServiceBusEventSource(config), codec, selectTopics(config, map[string]func(<-chan msg.Delivery){
+		msg.TopicNotification:      handleNotification,
+		msg.TopicTokenRevocation:   handleTokenRevocation,
+		msg.TopicOrganizationPurge: handleOrganizationPurge,
+	})) {
 		logger.LoggerMgw.Info("Service bus meta data successfully initialized.")
-		msg.InitiateConsumers(connectionString, subscriptionMetaDataList, reconnectInterval*time.Millisecond)
-		go handleAzureNotification()
-		go handleAzureTokenRevocation()
-		go handleAzureOrganizationPurge()
 	}
+}
+
+// newAzureServiceBusEventSource creates the event source of the Azure Service Bus of the event listening endpoint,
+// which is either a connection string or a namespace authenticated with the managed identity.
+func newAzureServiceBusEventSource(config *config.Config) msg.EventSource {
+	brokerParams := config.ControlPlane.BrokerConnectionParameters
+	asbConfig := brokerParams.AzureServiceBus
+	options := msg.AzureServiceBusOptions{
+		ManagedIdentityClientID: asbConfig.ManagedIdentityClientID,
+		Topics: map[string]string{
+			msg.TopicNotification:      asbConfig.NotificationTopic,
+			msg.TopicTokenRevocation:   asbConfig.TokenRevocationTopic,
+			msg.TopicOrganizationPurge: asbConfig.OrganizationPurgeTopic,
+		},
+		SubscriptionName:     asbConfig.SubscriptionName,
+		SubscriptionIdleTime: asbConfig.SubscriptionIdleTime,
+		ComponentName:        componentName,
+		ReconnectInterval:    brokerParams.ReconnectInterval * time.Millisecond,
+		ReconnectRetryCount:  brokerParams.ReconnectRetryCount,
+	}
+	// the subscriptions are not created for the topics of which events are not consumed
+	for topic := range options.Topics {
+		if !isTopicSelected(config, topic) {
+			delete(options.Topics, topic)
+		}
+	}
+	endpoint := brokerParams.EventListeningEndpoints[0]
+	if strings.Contains(endpoint, sharedAccessKey) {
+		options.ConnectionString = endpoint
+	} else {
+		options.Namespace = getAzureServiceBusNamespace(endpoint)
+	}
+	return msg.NewAzureServiceBusEventSource(options)
+}
 
+// getAzureServiceBusNamespace returns the fully qualified namespace of an endpoint (ex: sb://<namespace>/).
+func getAzureServiceBusNamespace(endpoint string) string {
+	if namespaceURL, err := url.Parse(endpoint); err == nil && namespaceURL.Host != "" {
+		return namespaceURL.Host
+	}
+	return strings.TrimSuffix(endpoint, "/")
 }
@@ -0,0 +1,71 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package messaging
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wso2/product-microgateway/adapter/config"
+	"github.com/wso2/product-microgateway/adapter/internal/audit"
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	msg "github.com/wso2/product-microgateway/adapter/pkg/messaging"
+)
+
+// SyntheticEvent is a control plane event injected without the message broker. The event is the payload
+// published to the message broker for the event type (ex: an APIEvent for DEPLOY_API_IN_GATEWAY), as a json object.
+type SyntheticEvent struct {
+	Type  string          `json:"type"`
+	Event json.RawMessage `json:"event"`
+}
+
+// InjectEvent processes a synthetic event by the handlers of the notification events. The type, the event ID and
+// the timestamp of the payload are set if not provided, hence a payload can be injected repeatedly without being
+// discarded as an older event.
+func InjectEvent(event SyntheticEvent) error {
+	if event.Type == "" {
+		return errors.New("type of the event is empty")
+	}
+	var payload map[string]interface{}
+	if err := json.Unmarshal(event.Event, &payload); err != nil || payload == nil {
+		return errors.New("event should be a json object")
+	}
+	if _, found := payload["type"]; !found {
+		payload["type"] = event.Type
+	}
+	if _, found := payload["eventId"]; !found {
+		payload["eventId"] = uuid.New().String()
+	}
+	if _, found := payload["timeStamp"]; !found {
+		payload["timeStamp"] = time.Now().UnixMilli()
+	}
+	encodedEvent, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	var notification msg.EventNotification
+	notification.Event.PayloadData.EventType = event.Type
+	notification.Event.PayloadData.Timstamp = float64(time.Now().UnixMilli())
+	notification.Event.PayloadData.Event = base64.StdEncoding.EncodeToString(encodedEvent)
+	logger.LoggerInternalMsg.Debugf("Synthetic event %s is injected", event.Type)
+	conf, _ := config.ReadConfigs()
+	return processNotificationEvent(conf, &notification, audit.EventSourceInjected)
+}
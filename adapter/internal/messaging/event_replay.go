@@ -0,0 +1,215 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package messaging
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"runtime"
+	"time"
+
+	"github.com/wso2/product-microgateway/adapter/config"
+	"github.com/wso2/product-microgateway/adapter/internal/audit"
+	"github.com/wso2/product-microgateway/adapter/internal/discovery/xds"
+	eh "github.com/wso2/product-microgateway/adapter/internal/eventhub"
+	"github.com/wso2/product-microgateway/adapter/internal/eventorder"
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/internal/profiling"
+	"github.com/wso2/product-microgateway/adapter/pkg/eventhub/types"
+	msg "github.com/wso2/product-microgateway/adapter/pkg/messaging"
+)
+
+// ReplayOptions are the options of replaying a captured event stream.
+type ReplayOptions struct {
+	// Count is the number of the events replayed. The events are replayed repeatedly, in the order captured, until
+	// the count is reached. Defaults to the number of the events captured.
+	Count int
+	// Rate is the events replayed per second. The events are replayed without a delay if 0.
+	Rate float64
+	// Labels are the gateway environments, whose snapshots are generated after each event. The events updating the
+	// APIs generate the snapshots of the labels of the APIs regardless.
+	Labels []string
+}
+
+// ReplayReport is the throughput of the event handlers, and the allocations and the snapshot generations caused by
+// the events replayed.
+type ReplayReport struct {
+	Events          int              `json:"events"`
+	Failures        int              `json:"failures"`
+	Outcomes        map[string]int64 `json:"outcomes"`
+	Elapsed         time.Duration    `json:"elapsed"`
+	EventsPerSecond float64          `json:"eventsPerSecond"`
+	// Mallocs and AllocatedBytes are the heap objects and the bytes allocated while replaying the events
+	Mallocs        uint64 `json:"mallocs"`
+	AllocatedBytes uint64 `json:"allocatedBytes"`
+	// Snapshots are the snapshot generations, which took SnapshotLatency on average. MaxSnapshotLatency is the
+	// maximum since the process is started, as reported by the profiling stats.
+	Snapshots          int64         `json:"snapshots"`
+	SnapshotLatency    time.Duration `json:"snapshotLatency"`
+	MaxSnapshotLatency time.Duration `json:"maxSnapshotLatency"`
+}
+
+// String formats the report to be printed.
+func (report ReplayReport) String() string {
+	perEvent := func(value uint64) uint64 {
+		if report.Events == 0 {
+			return 0
+		}
+		return value / uint64(report.Events)
+	}
+	return fmt.Sprintf("Replayed %d events (%d failed) in %v: %.1f events/s\n"+
+		"Outcomes: %v\n"+
+		"Allocations: %d objects, %d bytes (%d objects, %d bytes per event, %.1f MB/s)\n"+
+		"Snapshots: %d generated, %v average, %v max\n",
+		report.Events, report.Failures, report.Elapsed.Round(time.Millisecond), report.EventsPerSecond,
+		report.Outcomes,
+		report.Mallocs, report.AllocatedBytes, perEvent(report.Mallocs), perEvent(report.AllocatedBytes),
+		float64(report.AllocatedBytes)/(1<<20)/report.Elapsed.Seconds(),
+		report.Snapshots, report.SnapshotLatency, report.MaxSnapshotLatency)
+}
+
+// ReadCapturedEvents reads an event stream captured as a json array of the synthetic events, or as json lines of
+// those (one event per line), as injected with the adapterctl inject command.
+func ReadCapturedEvents(reader io.Reader) ([]SyntheticEvent, error) {
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	var events []SyntheticEvent
+	if trimmed := bytes.TrimSpace(content); len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &events); err != nil {
+			return nil, err
+		}
+		return events, nil
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 64*1024), len(content)+1)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var event SyntheticEvent
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			return nil, fmt.Errorf("invalid event at line %d. %v", line, err)
+		}
+		events = append(events, event)
+	}
+	return events, scanner.Err()
+}
+
+// LoadEmptyDatastore loads the datastore of the subscriptions as pulled from a control plane without any
+// applications, subscriptions, key mappings, scopes or policies, hence the captured events can be replayed by a
+// process, which is not connected to a control plane.
+func LoadEmptyDatastore() {
+	xds.UpdateEnforcerApplications(xds.MarshalMultipleApplications(&types.ApplicationList{}))
+	xds.UpdateEnforcerSubscriptions(xds.MarshalMultipleSubscriptions(&types.SubscriptionList{}))
+	xds.UpdateEnforcerApplicationKeyMappings(xds.MarshalMultipleApplicationKeyMappings(
+		&types.ApplicationKeyMappingList{}))
+	xds.UpdateEnforcerApplicationPolicies(xds.MarshalMultipleApplicationPolicies(&types.ApplicationPolicyList{}))
+	xds.UpdateEnforcerSubscriptionPolicies(xds.MarshalMultipleSubscriptionPolicies(&types.SubscriptionPolicyList{}))
+	xds.UpdateEnforcerScopes(xds.MarshalMultipleScopes(&types.ScopeList{}))
+}
+
+// ReplayEvents processes the captured events by the handlers of the notification events, at the rate of the
+// options, and reports the throughput, the allocations and the snapshot generation latency. The events are not
+// replayed concurrently, as the events received from the message broker are not processed concurrently either.
+func ReplayEvents(events []SyntheticEvent, options ReplayOptions) ReplayReport {
+	count := options.Count
+	if count <= 0 {
+		count = len(events)
+	}
+	report := ReplayReport{Events: count}
+	if len(events) == 0 {
+		report.Events = 0
+		return report
+	}
+	var ticker *time.Ticker
+	if options.Rate > 0 {
+		ticker = time.NewTicker(time.Duration(float64(time.Second) / options.Rate))
+		defer ticker.Stop()
+	}
+
+	var memStatsBefore, memStatsAfter runtime.MemStats
+	runtime.ReadMemStats(&memStatsBefore)
+	statsBefore := profiling.GetStats()
+	startedAt := time.Now()
+	for i := 0; i < count; i++ {
+		if ticker != nil && i > 0 {
+			<-ticker.C
+		}
+		if err := InjectEvent(events[i%len(events)]); err != nil {
+			report.Failures++
+		}
+		if len(options.Labels) > 0 {
+			xds.UpdateXdsCacheForLabels(options.Labels)
+		}
+	}
+	report.Elapsed = time.Since(startedAt)
+	stats := profiling.GetStats().Sub(statsBefore)
+	runtime.ReadMemStats(&memStatsAfter)
+
+	report.EventsPerSecond = float64(count) / report.Elapsed.Seconds()
+	report.Outcomes = stats.Events
+	report.Mallocs = memStatsAfter.Mallocs - memStatsBefore.Mallocs
+	report.AllocatedBytes = memStatsAfter.TotalAlloc - memStatsBefore.TotalAlloc
+	report.Snapshots = stats.Snapshots
+	if stats.Snapshots > 0 {
+		report.SnapshotLatency = time.Duration(stats.SnapshotNanos / stats.Snapshots)
+		report.MaxSnapshotLatency = time.Duration(stats.MaxSnapshotNanos)
+	}
+	return report
+}
+
+// RebuildSubscriptions replaces the subscription data with the data loaded from the control plane and the
+// subscription events processed, as recorded in the audit journal, in the order those were recorded.
+func RebuildSubscriptions(records []audit.ChangeRecord) error {
+	conf, _ := config.ReadConfigs()
+	LoadEmptyDatastore()
+	eventorder.Reset(eventorder.ResourceSubscription, eventorder.ResourceApplicationKeyMapping,
+		eventorder.ResourceApplication, eventorder.ResourcePolicy)
+	failed := 0
+	for _, record := range records {
+		if record.Subscription == nil {
+			continue
+		}
+		var err error
+		switch record.Action {
+		case audit.ActionLoadSubscriptionData:
+			err = eh.ReplaySubscriptionData(record.Subscription.Type, record.Subscription.Payload)
+		case audit.ActionSubscriptionEvent:
+			var notification msg.EventNotification
+			if err = json.Unmarshal(record.Subscription.Payload, &notification); err == nil {
+				err = processNotificationEvent(conf, &notification, audit.EventSourceJournal)
+			}
+		}
+		if err != nil {
+			logger.LoggerInternalMsg.Warnf("%s record of %s at %v is not replayed. %v", record.Action,
+				record.Subscription.Type, record.Timestamp, err)
+			failed++
+		}
+	}
+	logger.LoggerInternalMsg.Infof("Rebuilt the subscriptions from %d journal records, while %d records failed.",
+		len(records)-failed, failed)
+	if failed > 0 {
+		return fmt.Errorf("%d of %d subscription records are not replayed", failed, len(records))
+	}
+	return nil
+}
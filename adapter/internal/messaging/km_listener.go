@@ -23,8 +23,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/wso2/product-microgateway/adapter/internal/discovery/xds"
+	"github.com/wso2/product-microgateway/adapter/internal/eventorder"
+	"github.com/wso2/product-microgateway/adapter/internal/jwks"
 	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
 	eventhubTypes "github.com/wso2/product-microgateway/adapter/pkg/eventhub/types"
 	"github.com/wso2/product-microgateway/adapter/pkg/logging"
@@ -41,32 +44,22 @@ const (
 )
 
 // handleKMEvent
-func handleKMConfiguration() {
-	var (
-		indexOfKeymanager int
-		isFound           bool
-	)
-	for d := range msg.KeyManagerChannel {
+func handleKMConfiguration(deliveries <-chan msg.Delivery) {
+	for d := range deliveries {
 		var notification msg.EventKeyManagerNotification
 		// var keyManagerConfig resourceTypes.KeymanagerConfig
 		var kmConfigMap map[string]interface{}
-		unmarshalErr := json.Unmarshal([]byte(string(d.Body)), &notification)
+		unmarshalErr := json.Unmarshal(d.Body(), &notification)
 		if unmarshalErr != nil {
 			logger.LoggerInternalMsg.ErrorC(logging.ErrorDetails{
 				Message:   fmt.Sprintf("Error occurred while unmarshalling key manager event data %v", unmarshalErr.Error()),
 				Severity:  logging.CRITICAL,
 				ErrorCode: 2000,
 			})
-			return
+			d.Nack()
+			continue
 		}
 		logger.LoggerInternalMsg.Infof("Event %s is received", notification.Event.PayloadData.EventType)
-		for i := range xds.KeyManagerList {
-			if strings.EqualFold(notification.Event.PayloadData.Name, xds.KeyManagerList[i].Name) {
-				isFound = true
-				indexOfKeymanager = i
-				break
-			}
-		}
 
 		var decodedByte, err = base64.StdEncoding.DecodeString(notification.Event.PayloadData.Value)
 
@@ -83,19 +76,13 @@ func handleKMConfiguration() {
 				Severity:  logging.CRITICAL,
 				ErrorCode: 2002,
 			})
-			return
+			d.Nack()
+			continue
 		}
 
 		if strings.EqualFold(keyManagerConfigEvent, notification.Event.PayloadData.EventType) {
-			if isFound && strings.EqualFold(actionDelete, notification.Event.PayloadData.Action) {
-				logger.LoggerInternalMsg.Infof("Found KM %s to be deleted index %d", notification.Event.PayloadData.Name,
-					indexOfKeymanager)
-				if isFound {
-					xds.KeyManagerList[indexOfKeymanager] = xds.KeyManagerList[len(xds.KeyManagerList)-1]
-					xds.KeyManagerList = xds.KeyManagerList[:len(xds.KeyManagerList)-1]
-				}
-				xds.GenerateAndUpdateKeyManagerList()
-			} else if decodedByte != nil {
+			isDelete := strings.EqualFold(actionDelete, notification.Event.PayloadData.Action)
+			if !isDelete && decodedByte != nil {
 				logger.LoggerInternalMsg.Infof("decoded stream %s", string(decodedByte))
 				kmConfigMapErr := json.Unmarshal([]byte(string(decodedByte)), &kmConfigMap)
 				if kmConfigMapErr != nil {
@@ -104,26 +91,59 @@ func handleKMConfiguration() {
 						Severity:  logging.CRITICAL,
 						ErrorCode: 2003,
 					})
-					return
-				}
-
-				if strings.EqualFold(actionAdd, notification.Event.PayloadData.Action) ||
-					strings.EqualFold(actionUpdate, notification.Event.PayloadData.Action) {
-					keyManager := eventhubTypes.KeyManager{Name: notification.Event.PayloadData.Name,
-						Type: notification.Event.PayloadData.Type, Enabled: notification.Event.PayloadData.Enabled,
-						TenantDomain: notification.Event.PayloadData.TenantDomain, Configuration: kmConfigMap}
-					logger.LoggerInternalMsg.Infof("data %v", keyManager.Configuration)
-
-					if isFound {
-						xds.KeyManagerList[indexOfKeymanager] = keyManager
-					} else {
-						xds.KeyManagerList = append(xds.KeyManagerList, keyManager)
-					}
-					xds.GenerateAndUpdateKeyManagerList()
+					d.Nack()
+					continue
 				}
 			}
+			// the key manager events do not carry a timestamp, hence those are ordered by the time received
+			eventorder.Apply(eventorder.ResourceKeyManager, notification.Event.PayloadData.TenantDomain+":"+
+				notification.Event.PayloadData.Name, time.Now().UnixMilli(), isDelete, func() {
+				applyKMConfiguration(notification, kmConfigMap, isDelete)
+			})
+			// the keys of the tenant may be changed along with its key managers
+			jwks.InvalidateTenant(notification.Event.PayloadData.TenantDomain)
 		}
-		d.Ack(false)
+		d.Ack()
 	}
 	logger.LoggerInternalMsg.Info("handle: deliveries channel closed")
 }
+
+// applyKMConfiguration adds, updates or deletes the key manager of an event in the key managers.
+func applyKMConfiguration(notification msg.EventKeyManagerNotification, kmConfigMap map[string]interface{},
+	isDelete bool) {
+	indexOfKeymanager := -1
+	for i := range xds.KeyManagerList {
+		if strings.EqualFold(notification.Event.PayloadData.Name, xds.KeyManagerList[i].Name) {
+			indexOfKeymanager = i
+			break
+		}
+	}
+	isFound := indexOfKeymanager >= 0
+	if isDelete {
+		if isFound {
+			logger.LoggerInternalMsg.Infof("Found KM %s to be deleted index %d", notification.Event.PayloadData.Name,
+				indexOfKeymanager)
+			xds.KeyManagerList[indexOfKeymanager] = xds.KeyManagerList[len(xds.KeyManagerList)-1]
+			xds.KeyManagerList = xds.KeyManagerList[:len(xds.KeyManagerList)-1]
+			xds.GenerateAndUpdateKeyManagerList()
+		}
+		return
+	}
+	if kmConfigMap == nil {
+		return
+	}
+	if strings.EqualFold(actionAdd, notification.Event.PayloadData.Action) ||
+		strings.EqualFold(actionUpdate, notification.Event.PayloadData.Action) {
+		keyManager := eventhubTypes.KeyManager{Name: notification.Event.PayloadData.Name,
+			Type: notification.Event.PayloadData.Type, Enabled: notification.Event.PayloadData.Enabled,
+			TenantDomain: notification.Event.PayloadData.TenantDomain, Configuration: kmConfigMap}
+		logger.LoggerInternalMsg.Infof("data %v", keyManager.Configuration)
+
+		if isFound {
+			xds.KeyManagerList[indexOfKeymanager] = keyManager
+		} else {
+			xds.KeyManagerList = append(xds.KeyManagerList, keyManager)
+		}
+		xds.GenerateAndUpdateKeyManagerList()
+	}
+}
@@ -19,19 +19,320 @@
 package messaging
 
 import (
-	"github.com/wso2/product-microgateway/adapter/pkg/health"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/wso2/product-microgateway/adapter/config"
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/pkg/health"
+	"github.com/wso2/product-microgateway/adapter/pkg/logging"
 	msg "github.com/wso2/product-microgateway/adapter/pkg/messaging"
+	"github.com/wso2/product-microgateway/adapter/pkg/tlsutils"
+)
+
+const natsProtocol = "nats"
+
+var (
+	// topicEventCategories are the categories of the events consumed from the topics
+	topicEventCategories = map[string][]string{
+		msg.TopicNotification:      {config.EventCategoryAPI, config.EventCategorySubscription},
+		msg.TopicKeyManager:        {config.EventCategoryKeyManager},
+		msg.TopicThrottleData:      {config.EventCategoryThrottleData},
+		msg.TopicTokenRevocation:   {config.EventCategoryTokenRevocation},
+		msg.TopicOrganizationPurge: {config.EventCategoryOrganizationPurge},
+	}
+	// activeEventSource is the event source the events are consumed from
+	activeEventSource      msg.EventSource
+	activeEventSourceMutex sync.Mutex
 )
 
 // ProcessEvents to pass event consumption
 func ProcessEvents(config *config.Config) {
-	err := msg.InitiateJMSConnection(config.ControlPlane.BrokerConnectionParameters.EventListeningEndpoints)
+	// the event filter rules are validated before the events are consumed
+	getEventFilter(config)
+	codec, err := newPayloadCodec(config)
+	if err != nil {
+		logPayloadCodecError(err)
+		return
+	}
+	connected := consumeEvents(newEventSource(config), codec, selectTopics(config, map[string]func(<-chan msg.Delivery){
+		msg.TopicNotification:    handleNotification,
+		msg.TopicKeyManager:      handleKMConfiguration,
+		msg.TopicThrottleData:    handleThrottleData,
+		msg.TopicTokenRevocation: handleTokenRevocation,
+	}))
+	if connected && config.ControlPlane.BrokerConnectionParameters.CatchUpOnReconnect {
+		go handleNotificationReconnect(config)
+	}
+}
+
+// consumeEvents connects to the broker, and processes the events of each topic with the handler of the topic, once
+// the payloads are decoded with the codec. Returns whether the connection to the broker is established.
+func consumeEvents(eventSource msg.EventSource, codec msg.Codec, handlers map[string]func(<-chan msg.Delivery)) bool {
+	err := eventSource.Connect()
 	health.SetControlPlaneBrokerStatus(err == nil)
+	if err != nil {
+		logger.LoggerInternalMsg.ErrorC(logging.ErrorDetails{
+			Message:   fmt.Sprintf("Error occurred while connecting to the message broker. %v", err),
+			Severity:  logging.BLOCKER,
+			ErrorCode: 2010,
+		})
+		return false
+	}
+	activeEventSourceMutex.Lock()
+	activeEventSource = eventSource
+	activeEventSourceMutex.Unlock()
+	for topic, handler := range handlers {
+		deliveries, err := eventSource.Consume(topic)
+		if err != nil {
+			logger.LoggerInternalMsg.ErrorC(logging.ErrorDetails{
+				Message:   fmt.Sprintf("Error occurred while consuming the events of the topic %s. %v", topic, err),
+				Severity:  logging.BLOCKER,
+				ErrorCode: 2011,
+			})
+			continue
+		}
+		// the deliveries are tracked, hence the events in progress are processed when the adapter is stopped
+		decodedDeliveries := consumedDeliveries.track(decodeDeliveries(topic, deliveries, codec))
+		if topic != msg.TopicNotification {
+			go handler(decodedDeliveries)
+			continue
+		}
+		for _, partition := range partitionDeliveries(decodedDeliveries, getConsumersPerTopic(eventSource),
+			getNotificationEntity) {
+			go handler(partition)
+		}
+	}
+	return true
+}
+
+// getConsumersPerTopic returns the number of the handlers processing the events of a topic in parallel.
+func getConsumersPerTopic(eventSource msg.EventSource) int {
+	if concurrentEventSource, ok := eventSource.(msg.ConcurrentEventSource); ok &&
+		concurrentEventSource.ConsumersPerTopic() > 1 {
+		return concurrentEventSource.ConsumersPerTopic()
+	}
+	return 1
+}
+
+// partitionDeliveries distributes the deliveries among the given number of partitions by the entity of each event,
+// hence the events of an entity are processed by the handler of the same partition, in the order received.
+func partitionDeliveries(deliveries <-chan msg.Delivery, partitions int,
+	getEntity func(msg.Delivery) string) []<-chan msg.Delivery {
+	if partitions <= 1 {
+		return []<-chan msg.Delivery{deliveries}
+	}
+	partitionedDeliveries := make([]chan msg.Delivery, partitions)
+	result := make([]<-chan msg.Delivery, partitions)
+	for i := range partitionedDeliveries {
+		partitionedDeliveries[i] = make(chan msg.Delivery)
+		result[i] = partitionedDeliveries[i]
+	}
+	go func() {
+		for d := range deliveries {
+			hash := fnv.New32a()
+			hash.Write([]byte(getEntity(d)))
+			partitionedDeliveries[hash.Sum32()%uint32(partitions)] <- d
+		}
+		for _, partition := range partitionedDeliveries {
+			close(partition)
+		}
+	}()
+	return result
+}
+
+// getNotificationEntity returns the ID of the resource of a notification event (ex: the UUID of an API or an
+// application). The events which are not parsed are assigned to the same partition, as those are only nacked.
+func getNotificationEntity(d msg.Delivery) string {
+	var notification msg.EventNotification
+	if err := json.Unmarshal(d.Body(), &notification); err != nil {
+		return ""
+	}
+	decodedEvent, err := base64.StdEncoding.DecodeString(notification.Event.PayloadData.Event)
+	if err != nil {
+		return ""
+	}
+	var event eventResource
+	if err := json.Unmarshal(decodedEvent, &event); err != nil {
+		return ""
+	}
+	return event.getID()
+}
+
+// selectTopics returns the handlers of the topics, which carry the events of the enabled event categories. The
+// other topics are not bound to reduce the load on the broker.
+func selectTopics(conf *config.Config, handlers map[string]func(<-chan msg.Delivery)) map[string]func(<-chan msg.Delivery) {
+	selectedHandlers := make(map[string]func(<-chan msg.Delivery), len(handlers))
+	for topic, handler := range handlers {
+		if !isTopicSelected(conf, topic) {
+			logger.LoggerInternalMsg.Infof("Events of the topic %s are not consumed, as none of its event "+
+				"categories are enabled", topic)
+			continue
+		}
+		selectedHandlers[topic] = handler
+	}
+	return selectedHandlers
+}
+
+// isTopicSelected returns whether any of the event categories of the topic is enabled.
+func isTopicSelected(conf *config.Config, topic string) bool {
+	for _, category := range topicEventCategories[topic] {
+		if config.IsEventCategoryEnabled(conf, category) {
+			return true
+		}
+	}
+	return false
+}
+
+// ReconnectEventSource reconnects the consumers of the events to the event listening endpoints of the
+// configuration, once those are changed at runtime.
+func ReconnectEventSource(config *config.Config) {
+	activeEventSourceMutex.Lock()
+	defer activeEventSourceMutex.Unlock()
+	eventSource, reconnectable := activeEventSource.(msg.ReconnectableEventSource)
+	if !reconnectable {
+		logger.LoggerInternalMsg.Info("Event listening endpoints are updated, while the events are not consumed " +
+			"from a message broker which can be reconnected")
+		return
+	}
+	endpoints := config.ControlPlane.BrokerConnectionParameters.EventListeningEndpoints
+	logger.LoggerInternalMsg.Infof("Reconnecting to the %d updated event listening endpoints", len(endpoints))
+	if err := eventSource.Reconnect(endpoints); err != nil {
+		logger.LoggerInternalMsg.ErrorC(logging.ErrorDetails{
+			Message:   fmt.Sprintf("Error occurred while reconnecting to the updated event listening endpoints. %v", err),
+			Severity:  logging.MAJOR,
+			ErrorCode: 2014,
+		})
+	}
+}
+
+// decodeDeliveries decodes the payloads of the deliveries with the codec. The deliveries which cannot be decoded
+// are rejected.
+func decodeDeliveries(topic string, deliveries <-chan msg.Delivery, codec msg.Codec) <-chan msg.Delivery {
+	decodedDeliveries := make(chan msg.Delivery)
+	go func() {
+		for d := range deliveries {
+			body, err := codec.Decode(topic, d.Body())
+			if err != nil {
+				logger.LoggerInternalMsg.ErrorC(logging.ErrorDetails{
+					Message:   fmt.Sprintf("Error occurred while decoding the payload of an event of the topic %s. %v", topic, err),
+					Severity:  logging.MAJOR,
+					ErrorCode: 2013,
+				})
+				d.Nack()
+				continue
+			}
+			decodedDeliveries <- &decodedDelivery{Delivery: d, body: body}
+		}
+		close(decodedDeliveries)
+	}()
+	return decodedDeliveries
+}
+
+// decodedDelivery is a delivery with the payload decoded by the codec of the event source.
+type decodedDelivery struct {
+	msg.Delivery
+	body []byte
+}
+
+func (delivery *decodedDelivery) Body() []byte {
+	return delivery.body
+}
+
+// newPayloadCodec creates the codec of the payload encoding of the event source.
+func newPayloadCodec(config *config.Config) (msg.Codec, error) {
+	codecConfig := config.ControlPlane.BrokerConnectionParameters.PayloadCodec
+	switch strings.ToLower(codecConfig.Type) {
+	case "", msg.CodecJSON:
+		return msg.NewJSONCodec(), nil
+	case msg.CodecProtobuf:
+		return msg.NewProtobufCodec(codecConfig.Protobuf.DescriptorSetFile, codecConfig.Protobuf.MessageTypes)
+	case msg.CodecAvro:
+		transport := &http.Transport{
+			TLSClientConfig: &tls.Config{RootCAs: tlsutils.GetTrustedCertPool(config.Adapter.Truststore.Location)},
+		}
+		return msg.NewAvroCodec(msg.AvroCodecOptions{
+			SchemaRegistryURL: codecConfig.Avro.SchemaRegistryURL,
+			Username:          codecConfig.Avro.Username,
+			Password:          codecConfig.Avro.Password,
+			HTTPClient: &http.Client{
+				Transport: transport,
+				Timeout:   time.Duration(codecConfig.Avro.TimeoutInSeconds) * time.Second,
+			},
+		}), nil
+	default:
+		return nil, fmt.Errorf("payload codec %q is not supported", codecConfig.Type)
+	}
+}
+
+// getAMQPOptions returns the options of the RabbitMQ consumers. The queues are named per topic with the queue name
+// prefix, unless the names of the queues are given. The durable queues should be named explicitly, as the queues
+// named by the broker are not reused by the adapter once reconnected, hence the queues are not durable otherwise.
+func getAMQPOptions(config *config.Config) msg.AMQPOptions {
+	amqpConfig := config.ControlPlane.BrokerConnectionParameters.AMQP
+	queueNames := make(map[string]string)
+	durableQueues := amqpConfig.DurableQueues
+	for _, topic := range []string{msg.TopicNotification, msg.TopicKeyManager, msg.TopicTokenRevocation,
+		msg.TopicThrottleData} {
+		if queueName, found := amqpConfig.QueueNames[topic]; found && queueName != "" {
+			queueNames[topic] = queueName
+		} else if amqpConfig.QueueNamePrefix != "" {
+			queueNames[topic] = amqpConfig.QueueNamePrefix + topic
+		} else if durableQueues {
+			logger.LoggerInternalMsg.ErrorC(logging.ErrorDetails{
+				Message: fmt.Sprintf("The queue of the topic %s is not named by the queue name prefix or the queue "+
+					"names. Hence the queues are not durable.", topic),
+				Severity:  logging.MAJOR,
+				ErrorCode: 2022,
+			})
+			durableQueues = false
+		}
+	}
+	return msg.AMQPOptions{
+		PrefetchCount:      amqpConfig.PrefetchCount,
+		ConsumersPerQueue:  amqpConfig.ConsumersPerQueue,
+		DurableQueues:      durableQueues,
+		DurableQueueExpiry: time.Duration(amqpConfig.DurableQueueExpiry) * time.Second,
+		ExclusiveQueues:    amqpConfig.ExclusiveQueues,
+		QueueNames:         queueNames,
+		MaxRedeliveries:    config.ControlPlane.BrokerConnectionParameters.MaxRedeliveries,
+	}
+}
+
+func logPayloadCodecError(err error) {
+	logger.LoggerInternalMsg.ErrorC(logging.ErrorDetails{
+		Message:   fmt.Sprintf("Error occurred while creating the codec of the event payloads. %v", err),
+		Severity:  logging.BLOCKER,
+		ErrorCode: 2012,
+	})
+}
 
-	go handleNotification()
-	go handleKMConfiguration()
-	go handleThrottleData()
-	go handleTokenRevocation()
+// newEventSource creates the event source of the broker, which is a NATS JetStream server or a RabbitMQ broker.
+func newEventSource(config *config.Config) msg.EventSource {
+	brokerParams := config.ControlPlane.BrokerConnectionParameters
+	if !strings.HasPrefix(brokerParams.EventListeningEndpoints[0], natsProtocol) {
+		return msg.NewAMQPEventSource(brokerParams.EventListeningEndpoints, getAMQPOptions(config))
+	}
+	durableName := brokerParams.JetStream.DurableName
+	if durableName == "" {
+		durableName, _ = os.Hostname()
+	}
+	return msg.NewJetStreamEventSource(brokerParams.EventListeningEndpoints, msg.JetStreamOptions{
+		Stream:            brokerParams.JetStream.Stream,
+		SubjectPrefix:     brokerParams.JetStream.SubjectPrefix,
+		DurableName:       durableName,
+		AckWait:           time.Duration(brokerParams.JetStream.AckWaitInSeconds) * time.Second,
+		MaxRedeliveries:   brokerParams.MaxRedeliveries,
+		InactiveThreshold: time.Duration(brokerParams.JetStream.InactiveThresholdInSeconds) * time.Second,
+		ReconnectWait:     brokerParams.ReconnectInterval * time.Millisecond,
+		MaxReconnects:     brokerParams.ReconnectRetryCount,
+	})
 }
@@ -18,10 +18,29 @@
 package messaging
 
 import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
 	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-microgateway/adapter/config"
+	"github.com/wso2/product-microgateway/adapter/internal/audit"
+	"github.com/wso2/product-microgateway/adapter/internal/discovery/xds"
 	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/internal/synchronizer"
+	"github.com/wso2/product-microgateway/adapter/pkg/discovery/api/wso2/discovery/subscription"
 	msg "github.com/wso2/product-microgateway/adapter/pkg/messaging"
-	"testing"
 )
 
 func TestNotificationChannelSubscriptionAndEventFormat(t *testing.T) {
@@ -34,19 +53,11 @@ func TestNotificationChannelSubscriptionAndEventFormat(t *testing.T) {
 		"A0LTIzZGQtNGI5Zi04YzM5LWExMTAzZDA2ZDA1OCIsInRpbWVTdGFtcCI6MTYyODQ5MDkwODE0NywidHlwZSI" +
 		"6IkFQSV9DUkVBVEUiLCJ0ZW5hbnRJZCI6LTEyMzQsInRlbmFudERvbWFpbiI6ImNhcmJvbi5zdXBlciJ9\"}}}"
 
-	var parsedSuccessfully bool
 	var notification msg.EventNotification
-	go func() {
-		msg.AzureNotificationChannel <- []byte(sampleTestEvent)
-	}()
-	outputData := <-msg.AzureNotificationChannel
-	error := parseNotificationJSONEvent(outputData, &notification)
-	if error != nil {
-		logger.LoggerMgw.Info("Error occurred", error)
-	} else {
-		parsedSuccessfully = true
-	}
-	assert.Equal(t, true, parsedSuccessfully)
+	assert.Nil(t, parseNotificationJSONEvent([]byte(sampleTestEvent), &notification))
+	assert.Equal(t, "API_CREATE", notification.Event.PayloadData.EventType)
+	assert.NotEmpty(t, notification.Event.PayloadData.Event)
+	assert.NotNil(t, parseNotificationJSONEvent([]byte("not a notification event"), &notification))
 }
 
 func TestTokenRevocationChannelSubscriptionAndEventFormat(t *testing.T) {
@@ -55,17 +66,569 @@ func TestTokenRevocationChannelSubscriptionAndEventFormat(t *testing.T) {
 	sampleTestEvent := "{\"event\":{\"payloadData\":{\"eventId\":\"444d2f9b-57d8-4245-bef2-3f8d824741c3\"," +
 		"\"revokedToken\":\"fc8ee897-b3d9-3bb6-a9ca-f4aeb036e5c0\",\"ttl\":\"5000\",\"expiryTime\":1628175421481," +
 		"\"type\":\"Default\",\"tenantId\":-1234}}}"
-	var parsedSuccessfully bool
 	var notification msg.EventTokenRevocationNotification
+	assert.Nil(t, parseRevokedTokenJSONEvent([]byte(sampleTestEvent), &notification))
+	assert.Equal(t, "fc8ee897-b3d9-3bb6-a9ca-f4aeb036e5c0", notification.Event.PayloadData.RevokedToken)
+	assert.Equal(t, int64(1628175421481), notification.Event.PayloadData.ExpiryTime)
+	assert.Equal(t, -1234, notification.Event.PayloadData.TenantID)
+	assert.NotNil(t, parseRevokedTokenJSONEvent([]byte("not a revoked token event"), &notification))
+}
+
+func TestProcessThrottleDataBlockingConditions(t *testing.T) {
+	blockingEvent := func(id int, condition, value, state string) []byte {
+		payload, _ := json.Marshal(map[string]interface{}{
+			"event": map[string]interface{}{
+				"payloadData": map[string]interface{}{
+					"id":                id,
+					"blockingCondition": condition,
+					"conditionValue":    value,
+					"state":             state,
+					"tenantDomain":      "carbon.super",
+				},
+			},
+		})
+		return payload
+	}
+
+	// redelivered events do not duplicate the condition
+	for i := 0; i < 2; i++ {
+		throttleData, err := processThrottleData(blockingEvent(1, "API", "/pizzashack/1.0.0", "true"))
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"/pizzashack/1.0.0"}, throttleData.BlockingConditions)
+	}
+	pushed, _ := processThrottleData(blockingEvent(2, "USER", "admin", "true"))
+	assert.Equal(t, []string{"/pizzashack/1.0.0", "admin"}, pushed.BlockingConditions)
+	throttleData, err := processThrottleData(blockingEvent(1, "API", "/pizzashack/1.0.0", "false"))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"admin"}, throttleData.BlockingConditions)
+	// conditions already pushed to the enforcer are not modified
+	assert.Equal(t, []string{"/pizzashack/1.0.0", "admin"}, pushed.BlockingConditions)
+
+	// an IP condition with the same ID is replaced
+	_, err = processThrottleData(blockingEvent(3, "IPRANGE",
+		`{"startingIp":"10.0.0.1","endingIp":"10.0.0.10","invert":false}`, "true"))
+	assert.Nil(t, err)
+	throttleData, err = processThrottleData(blockingEvent(3, "IPRANGE",
+		`{"startingIp":"10.0.0.1","endingIp":"10.0.0.20","invert":false}`, "true"))
+	assert.Nil(t, err)
+	assert.Len(t, throttleData.IpBlockingConditions, 1)
+	assert.Equal(t, "10.0.0.20", throttleData.IpBlockingConditions[0].EndingIp)
+	assert.Equal(t, "carbon.super", throttleData.IpBlockingConditions[0].TenantDomain)
+	throttleData, err = processThrottleData(blockingEvent(3, "IPRANGE", `{}`, "false"))
+	assert.Nil(t, err)
+	assert.Empty(t, throttleData.IpBlockingConditions)
+
+	// subscription blocking is evaluated with the subscriptions, hence the event is ignored
+	throttleData, err = processThrottleData(blockingEvent(4, "SUBSCRIPTION", "1:/pizzashack/1.0.0:app", "true"))
+	assert.Nil(t, err)
+	assert.Nil(t, throttleData)
+
+	_, err = processThrottleData(blockingEvent(5, "IP", "not-a-json", "true"))
+	assert.NotNil(t, err)
+	_, err = processThrottleData([]byte("not-a-json"))
+	assert.NotNil(t, err)
+	synchronizer.RemoveBlockingCondition("admin")
+}
+
+func TestHandleScopeEvents(t *testing.T) {
+	scopeEvent := func(eventType, roles string, timeStamp int64) []byte {
+		payload, _ := json.Marshal(map[string]interface{}{
+			"name":         "read:orders",
+			"displayName":  "Read Orders",
+			"roles":        roles,
+			"type":         eventType,
+			"timeStamp":    timeStamp,
+			"tenantDomain": "carbon.super",
+		})
+		return payload
+	}
+
+	handleScopeEvents(scopeEvent(scopeCreate, "admin, manager,", 1))
+	scope := xds.ScopeMap["carbon.super:read:orders"]
+	if assert.NotNil(t, scope) {
+		assert.Equal(t, "Read Orders", scope.DisplayName)
+		assert.Equal(t, []string{"admin", "manager"}, scope.Roles)
+	}
+
+	handleScopeEvents(scopeEvent(scopeUpdate, "admin", 3))
+	assert.Equal(t, []string{"admin"}, xds.ScopeMap["carbon.super:read:orders"].Roles)
+	// an update older than the processed event is discarded
+	handleScopeEvents(scopeEvent(scopeUpdate, "manager", 2))
+	assert.Equal(t, []string{"admin"}, xds.ScopeMap["carbon.super:read:orders"].Roles)
+
+	handleScopeEvents(scopeEvent(scopeDelete, "", 4))
+	assert.NotContains(t, xds.ScopeMap, "carbon.super:read:orders")
+}
+
+func TestOutOfOrderEvents(t *testing.T) {
+	scopeEvent := func(eventType string, timeStamp int64) []byte {
+		payload, _ := json.Marshal(map[string]interface{}{
+			"name":         "write:orders",
+			"roles":        "admin",
+			"type":         eventType,
+			"timeStamp":    timeStamp,
+			"tenantDomain": "carbon.super",
+		})
+		return payload
+	}
+
+	// the delete event is received before the create event, after a reconnection
+	assert.False(t, handleScopeEvents(scopeEvent(scopeDelete, 20)))
+	assert.True(t, handleScopeEvents(scopeEvent(scopeCreate, 10)), "Create event older than the delete should be discarded")
+	assert.NotContains(t, xds.ScopeMap, "carbon.super:write:orders")
+	assert.True(t, handleScopeEvents(scopeEvent(scopeCreate, 20)),
+		"Create event of the same timestamp as the delete should be discarded")
+	assert.NotContains(t, xds.ScopeMap, "carbon.super:write:orders")
+
+	assert.False(t, handleScopeEvents(scopeEvent(scopeCreate, 30)), "Create event later than the delete should be applied")
+	assert.Contains(t, xds.ScopeMap, "carbon.super:write:orders")
+}
+
+func TestHandleApplicationEventAttributes(t *testing.T) {
+	applicationEvent := func(attributes interface{}) []byte {
+		payload, _ := json.Marshal(map[string]interface{}{
+			"uuid":            "b2ba1c4d-8c52-4a0b-9c1c-3b7a4d2e9f10",
+			"applicationId":   7,
+			"applicationName": "Orders",
+			"attributes":      attributes,
+			"type":            applicationCreate,
+			"timeStamp":       time.Now().UnixMilli(),
+			"tenantDomain":    "carbon.super",
+		})
+		return payload
+	}
+	if xds.ApplicationMap == nil {
+		xds.ApplicationMap = make(map[string]*subscription.Application)
+	}
+
+	handleApplicationEvents(applicationEvent(map[string]interface{}{"region": "eu", "retries": 3}), applicationCreate)
+	application := xds.ApplicationMap["b2ba1c4d-8c52-4a0b-9c1c-3b7a4d2e9f10"]
+	if assert.NotNil(t, application) {
+		assert.Equal(t, map[string]string{"region": "eu", "retries": "3"}, application.Attributes)
+	}
+	assert.Equal(t, map[string]string{"tier": "gold"}, parseApplicationAttributes(`{"tier":"gold"}`),
+		"Attributes encoded as a string should be parsed")
+	assert.Nil(t, parseApplicationAttributes("not-json"))
+	assert.Nil(t, parseApplicationAttributes(nil))
+}
+
+func TestRecordNotificationEvents(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	eventConf := conf.Adapter.Audit.Events
+	defer func() { conf.Adapter.Audit.Events = eventConf }()
+	conf.Adapter.Audit.Events.Enabled = true
+	conf.Adapter.Audit.Events.FilePath = ""
+
+	notification := func(eventType string, event string) *msg.EventNotification {
+		var notification msg.EventNotification
+		notification.Event.PayloadData.EventType = eventType
+		notification.Event.PayloadData.Event = event
+		return &notification
+	}
+	payload, _ := json.Marshal(map[string]interface{}{
+		"eventId":          "c0a8e5e4-event",
+		"subscriptionUUID": "5d1a-subscription",
+		"applicationUUID":  "8f2b-application",
+		"apiUUID":          "3c4d-api",
+		"tenantDomain":     "carbon.super",
+	})
+	var resource eventResource
+	assert.Nil(t, json.Unmarshal(payload, &resource))
+	assert.Equal(t, "5d1a-subscription", resource.getID(), "Subscription should be the resource of the event")
+
+	healthCheck, _ := json.Marshal(map[string]interface{}{"eventId": "health-event", "tenantDomain": "carbon.super"})
+	assert.Nil(t, processNotificationEvent(conf, notification("HEALTH_CHECK",
+		base64.StdEncoding.EncodeToString(healthCheck)), audit.EventSourceNotification))
+	assert.NotNil(t, processNotificationEvent(conf, notification("CORRUPTED_EVENT", "not base64!"),
+		audit.EventSourceNotification))
+
+	ignored := audit.GetEvents(audit.EventFilter{Type: "HEALTH_CHECK"})
+	if assert.NotEmpty(t, ignored) {
+		assert.Equal(t, audit.EventIgnored, ignored[len(ignored)-1].Outcome)
+		assert.Equal(t, "health-event", ignored[len(ignored)-1].EventID)
+	}
+	failed := audit.GetEvents(audit.EventFilter{Type: "CORRUPTED_EVENT"})
+	if assert.NotEmpty(t, failed) {
+		assert.Equal(t, audit.EventFailed, failed[len(failed)-1].Outcome)
+	}
+}
+
+func TestInjectEvent(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	eventConf := conf.Adapter.Audit.Events
+	defer func() { conf.Adapter.Audit.Events = eventConf }()
+	conf.Adapter.Audit.Events.Enabled = true
+	conf.Adapter.Audit.Events.FilePath = ""
+
+	scope := json.RawMessage(`{"name": "write:orders", "roles": "admin", "tenantDomain": "carbon.super"}`)
+	assert.Nil(t, InjectEvent(SyntheticEvent{Type: scopeCreate, Event: scope}))
+	assert.Contains(t, xds.ScopeMap, "carbon.super:write:orders", "Injected event is not processed")
+	// the timestamp is generated, hence the same payload is not discarded as an older event
+	assert.Nil(t, InjectEvent(SyntheticEvent{Type: scopeDelete, Event: scope}))
+	assert.NotContains(t, xds.ScopeMap, "carbon.super:write:orders", "Injected event is discarded")
+
+	injected := audit.GetEvents(audit.EventFilter{ResourceID: "write:orders"})
+	if assert.Equal(t, 2, len(injected)) {
+		assert.Equal(t, audit.EventSourceInjected, injected[0].Source)
+		assert.NotEmpty(t, injected[0].EventID, "Event ID is not generated")
+	}
+
+	assert.NotNil(t, InjectEvent(SyntheticEvent{Event: scope}), "Event without a type is injected")
+	assert.NotNil(t, InjectEvent(SyntheticEvent{Type: scopeCreate, Event: json.RawMessage(`[]`)}),
+		"Event, which is not a json object, is injected")
+}
+
+func TestWebhookHandler(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	handler := newWebhookHandler(conf, "webhook-secret", 1024, time.Minute)
+	sign := func(timestamp string, body []byte) string {
+		mac := hmac.New(sha256.New, []byte("webhook-secret"))
+		mac.Write([]byte(timestamp + "."))
+		mac.Write(body)
+		return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	}
+	event := []byte(`{"event":{"payloadData":{"eventType":"HEALTH_CHECK","timeStamp":1628490908147,"event":"e30="}}}`)
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	signature := sign(timestamp, event)
+
+	sendEvent := func(path string, body []byte, timestamp, signature string) int {
+		req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(body))
+		req.Header.Set("X-WSO2-Signature", signature)
+		req.Header.Set("X-WSO2-Timestamp", timestamp)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+	assert.Equal(t, http.StatusAccepted, sendEvent("/events/notification", event, timestamp, signature))
+	assert.Equal(t, http.StatusConflict, sendEvent("/events/notification", event, timestamp, signature),
+		"Replayed event is accepted")
+	assert.Equal(t, http.StatusConflict, sendEvent("/events/notification", event, timestamp,
+		"sha256="+strings.ToUpper(strings.TrimPrefix(signature, "sha256="))),
+		"Replayed event with the signature in a different case is accepted")
+	assert.Equal(t, http.StatusUnauthorized, sendEvent("/events/notification", event, "1", signature),
+		"Event with a timestamp not covered by the signature is accepted")
+	assert.Equal(t, http.StatusUnauthorized, sendEvent("/events/notification", event, timestamp, "sha256=abcd"),
+		"Event with an invalid signature is accepted")
+	assert.Equal(t, http.StatusUnauthorized, sendEvent("/events/notification", event, timestamp, ""),
+		"Event without a signature is accepted")
+	expiredTimestamp := strconv.FormatInt(time.Now().Add(-2*time.Minute).Unix(), 10)
+	assert.Equal(t, http.StatusUnauthorized, sendEvent("/events/notification", event, expiredTimestamp,
+		sign(expiredTimestamp, event)), "Expired event is accepted")
+	assert.Equal(t, http.StatusRequestEntityTooLarge, sendEvent("/events/notification", make([]byte, 2048),
+		timestamp, signature))
+
+	invalidEvent := []byte("not-a-json")
+	assert.Equal(t, http.StatusBadRequest, sendEvent("/events/notification", invalidEvent, timestamp,
+		sign(timestamp, invalidEvent)))
+}
+
+type fakeDelivery struct {
+	body   []byte
+	acked  bool
+	nacked bool
+}
+
+func (d *fakeDelivery) Body() []byte { return d.body }
+
+func (d *fakeDelivery) Ack() error {
+	d.acked = true
+	return nil
+}
+
+func (d *fakeDelivery) Nack() error {
+	d.nacked = true
+	return nil
+}
+
+func TestHandleThrottleDataAcknowledgesDeliveries(t *testing.T) {
+	invalidEvent := &fakeDelivery{body: []byte("not a throttle data event")}
+	deliveries := make(chan msg.Delivery, 1)
+	deliveries <- invalidEvent
+	close(deliveries)
+	handleThrottleData(deliveries)
+	assert.True(t, invalidEvent.acked, "Invalid throttle data event is not acknowledged")
+	assert.False(t, invalidEvent.nacked)
+}
+
+func TestGetAzureServiceBusNamespace(t *testing.T) {
+	assert.Equal(t, "cc-events.servicebus.windows.net",
+		getAzureServiceBusNamespace("sb://cc-events.servicebus.windows.net/"))
+	assert.Equal(t, "cc-events.servicebus.windows.net", getAzureServiceBusNamespace("cc-events.servicebus.windows.net"))
+}
+
+type fakeEventSource struct {
+	endpoints []string
+}
+
+func (source *fakeEventSource) Connect() error {
+	return nil
+}
+
+func (source *fakeEventSource) Consume(topic string) (<-chan msg.Delivery, error) {
+	return make(chan msg.Delivery), nil
+}
+
+func (source *fakeEventSource) Close() error {
+	return nil
+}
+
+func (source *fakeEventSource) Reconnect(endpoints []string) error {
+	source.endpoints = endpoints
+	return nil
+}
+
+func TestReconnectEventSource(t *testing.T) {
+	eventSource := &fakeEventSource{endpoints: []string{"amqp://apim:5672"}}
+	activeEventSource = eventSource
+	defer func() { activeEventSource = nil }()
+
+	conf := &config.Config{}
+	conf.ControlPlane.BrokerConnectionParameters.EventListeningEndpoints = []string{"amqp://apim-2:5672"}
+	ReconnectEventSource(conf)
+	assert.Equal(t, []string{"amqp://apim-2:5672"}, eventSource.endpoints)
+}
+
+func TestGetAMQPOptions(t *testing.T) {
+	conf := &config.Config{}
+	amqpConf := &conf.ControlPlane.BrokerConnectionParameters.AMQP
+	amqpConf.PrefetchCount = 50
+	amqpConf.ConsumersPerQueue = 4
+	options := getAMQPOptions(conf)
+	assert.Equal(t, 50, options.PrefetchCount)
+	assert.Equal(t, 4, options.ConsumersPerQueue)
+	assert.Empty(t, options.QueueNames, "Queues should be named by the broker by default")
+
+	amqpConf.DurableQueues = true
+	amqpConf.DurableQueueExpiry = 3600
+	amqpConf.QueueNames = map[string]string{msg.TopicKeyManager: "km-events"}
+	options = getAMQPOptions(conf)
+	assert.False(t, options.DurableQueues, "Queues should not be durable unless named explicitly")
+
+	amqpConf.QueueNamePrefix = "adapter-1-"
+	options = getAMQPOptions(conf)
+	assert.True(t, options.DurableQueues)
+	assert.Equal(t, time.Hour, options.DurableQueueExpiry)
+	assert.Equal(t, "km-events", options.QueueNames[msg.TopicKeyManager])
+	assert.Equal(t, "adapter-1-throttleData", options.QueueNames[msg.TopicThrottleData])
+	assert.Equal(t, 4, getConsumersPerTopic(msg.NewAMQPEventSource(nil, options)))
+	assert.Equal(t, 1, getConsumersPerTopic(&fakeEventSource{}))
+}
+
+func TestPartitionDeliveries(t *testing.T) {
+	newDelivery := func(uuid string) *fakeDelivery {
+		event, _ := json.Marshal(map[string]string{"uuid": uuid})
+		notification, _ := json.Marshal(map[string]interface{}{"event": map[string]interface{}{
+			"payloadData": map[string]string{"eventType": "APPLICATION_UPDATE",
+				"event": base64.StdEncoding.EncodeToString(event)}}})
+		return &fakeDelivery{body: notification}
+	}
+	assert.Equal(t, "app1", getNotificationEntity(newDelivery("app1")))
+	deliveries := make(chan msg.Delivery)
+	go func() {
+		for i := 0; i < 12; i++ {
+			deliveries <- newDelivery("app" + strconv.Itoa(i%3))
+		}
+		close(deliveries)
+	}()
+	partitions := partitionDeliveries(deliveries, 4, getNotificationEntity)
+	assert.Len(t, partitions, 4)
+	received := make([][]string, len(partitions))
+	var wg sync.WaitGroup
+	for i, partition := range partitions {
+		wg.Add(1)
+		go func(i int, partition <-chan msg.Delivery) {
+			defer wg.Done()
+			for d := range partition {
+				received[i] = append(received[i], getNotificationEntity(d))
+			}
+		}(i, partition)
+	}
+	wg.Wait()
+	entities := make(map[string]int)
+	for i, partitionEntities := range received {
+		for _, entity := range partitionEntities {
+			if partition, found := entities[entity]; found {
+				assert.Equal(t, partition, i, "Events of an entity should be processed by the same handler")
+			}
+			entities[entity] = i
+		}
+	}
+	assert.Len(t, entities, 3)
+}
+
+func TestSelectEventCategories(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	eventCategories := conf.ControlPlane.BrokerConnectionParameters.EventCategories
+	auditConf := conf.Adapter.Audit.Events
+	defer func() {
+		conf.ControlPlane.BrokerConnectionParameters.EventCategories = eventCategories
+		conf.Adapter.Audit.Events = auditConf
+	}()
+	conf.Adapter.Audit.Events.Enabled = true
+	conf.Adapter.Audit.Events.FilePath = ""
+
+	handler := func(<-chan msg.Delivery) {}
+	handlers := map[string]func(<-chan msg.Delivery){
+		msg.TopicNotification:    handler,
+		msg.TopicKeyManager:      handler,
+		msg.TopicThrottleData:    handler,
+		msg.TopicTokenRevocation: handler,
+	}
+	conf.ControlPlane.BrokerConnectionParameters.EventCategories = nil
+	assert.Len(t, selectTopics(conf, handlers), 4, "All the topics should be consumed if no category is configured")
+
+	conf.ControlPlane.BrokerConnectionParameters.EventCategories = []string{"API"}
+	selected := selectTopics(conf, handlers)
+	assert.Len(t, selected, 1)
+	assert.Contains(t, selected, msg.TopicNotification)
+
+	assert.Equal(t, config.EventCategoryAPI, getNotificationEventCategory("API_CREATE"))
+	assert.Equal(t, config.EventCategorySubscription, getNotificationEventCategory("SUBSCRIPTIONS_CREATE"))
+	assert.Equal(t, config.EventCategorySubscription, getNotificationEventCategory("APPLICATION_UPDATE"))
+	assert.Empty(t, getNotificationEventCategory("HEALTH_CHECK"))
+
+	payload, _ := json.Marshal(map[string]interface{}{"eventId": "disabled-category-event"})
+	var notification msg.EventNotification
+	notification.Event.PayloadData.EventType = "SUBSCRIPTIONS_DELETE"
+	notification.Event.PayloadData.Event = base64.StdEncoding.EncodeToString(payload)
+	assert.Nil(t, processNotificationEvent(conf, &notification, audit.EventSourceNotification))
+	ignored := audit.GetEvents(audit.EventFilter{Type: "SUBSCRIPTIONS_DELETE"})
+	if assert.NotEmpty(t, ignored) {
+		assert.Equal(t, audit.EventIgnored, ignored[len(ignored)-1].Outcome,
+			"Events of the disabled categories should be ignored")
+	}
+}
+
+func TestFilterNotificationEvents(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	eventConf, eventFilters := conf.Adapter.Audit.Events, conf.ControlPlane.EventFilters
+	defer func() {
+		conf.Adapter.Audit.Events, conf.ControlPlane.EventFilters = eventConf, eventFilters
+		compileEventFilter(conf)
+	}()
+	conf.Adapter.Audit.Events.Enabled = true
+	conf.Adapter.Audit.Events.FilePath = ""
+	conf.ControlPlane.EventFilters = []config.EventFilterRule{
+		{Name: "bu2", Expression: `tenantDomain == "bu2.com" and category == "api"`, Action: "drop"},
+		{Name: "audit-only", Expression: `eventType == "SCOPE_CREATE" and name startsWith "audit:"`, Action: "log"},
+	}
+	getEventFilter(conf)
+	compileEventFilter(conf)
+
+	attributes := getEventAttributes([]byte(`{"tenantDomain": "bu2.com", "apiId": 12, "isDefault": true,
+		"gatewayLabels": ["default"]}`), scopeCreate, config.EventCategoryAPI)
+	assert.Equal(t, map[string]string{"tenantDomain": "bu2.com", "apiId": "12", "isDefault": "true",
+		"eventType": scopeCreate, "category": config.EventCategoryAPI}, attributes)
+
+	for _, scope := range []string{
+		`{"name": "write:bu2", "roles": "admin", "tenantDomain": "bu2.com"}`,
+		`{"name": "audit:orders", "roles": "admin", "tenantDomain": "carbon.super"}`,
+		`{"name": "read:orders", "roles": "admin", "tenantDomain": "carbon.super"}`,
+	} {
+		assert.Nil(t, InjectEvent(SyntheticEvent{Type: scopeCreate, Event: json.RawMessage(scope)}))
+	}
+	assert.NotContains(t, xds.ScopeMap, "bu2.com:write:bu2", "Dropped event is applied")
+	assert.NotContains(t, xds.ScopeMap, "carbon.super:audit:orders", "Logged event is applied")
+	assert.Contains(t, xds.ScopeMap, "carbon.super:read:orders", "Event not matching any rule is not applied")
+	dropped := audit.GetEvents(audit.EventFilter{ResourceID: "write:bu2"})
+	if assert.NotEmpty(t, dropped) {
+		assert.Equal(t, audit.EventIgnored, dropped[len(dropped)-1].Outcome)
+		assert.Equal(t, "Dropped by the event filter rule bu2", dropped[len(dropped)-1].Reason)
+	}
+}
+
+func readCapturedEvents(tb testing.TB) []SyntheticEvent {
+	file, err := os.Open(config.GetMgwHome() + "/../adapter/test-resources/events/captured_events.jsonl")
+	if err != nil {
+		tb.Fatal(err)
+	}
+	defer file.Close()
+	events, err := ReadCapturedEvents(file)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return events
+}
+
+func TestReplayEvents(t *testing.T) {
+	events := readCapturedEvents(t)
+	assert.Len(t, events, 8)
+	assert.Equal(t, applicationCreate, events[0].Type)
+	array, err := ReadCapturedEvents(bytes.NewReader([]byte(`[{"type": "SCOPE_CREATE", "event": {"name": "a"}}]`)))
+	assert.Nil(t, err)
+	assert.Len(t, array, 1, "Events captured as a json array are not read")
+	_, err = ReadCapturedEvents(bytes.NewReader([]byte("{\"type\": \"SCOPE_CREATE\"}\nnot-json\n")))
+	assert.EqualError(t, err, "invalid event at line 2. invalid character 'o' in literal null (expecting 'u')")
+
+	LoadEmptyDatastore()
+	report := ReplayEvents(events, ReplayOptions{Count: 16, Labels: []string{"Default"}})
+	assert.Equal(t, 16, report.Events)
+	assert.Zero(t, report.Failures)
+	var outcomes int64
+	for _, count := range report.Outcomes {
+		outcomes += count
+	}
+	assert.Equal(t, int64(16), outcomes, "Outcomes of the events replayed are not reported")
+	assert.GreaterOrEqual(t, report.Snapshots, int64(16), "Snapshots generated after each event are not reported")
+	assert.NotZero(t, report.AllocatedBytes)
+	assert.Empty(t, xds.ApplicationMap, "Application is not deleted by the last event replayed")
+}
+
+// BenchmarkReplayEvents replays the captured events against the handlers of the notification events. Run with
+// -benchmem to compare the allocations of the datastore and the xDS layers, along with the snapshot latency.
+func BenchmarkReplayEvents(b *testing.B) {
+	events := readCapturedEvents(b)
+	LoadEmptyDatastore()
+	b.ReportAllocs()
+	b.ResetTimer()
+	report := ReplayEvents(events, ReplayOptions{Count: b.N, Labels: []string{"Default"}})
+	b.StopTimer()
+	b.ReportMetric(report.EventsPerSecond, "events/s")
+	if report.Snapshots > 0 {
+		b.ReportMetric(float64(report.SnapshotLatency.Nanoseconds()), "ns/snapshot")
+	}
+}
+
+func TestDeliveryTrackerDrainsDeliveriesInProgress(t *testing.T) {
+	tracker := newDeliveryTracker()
+	deliveries := make(chan msg.Delivery, 3)
+	inProgress := &fakeDelivery{body: []byte("in progress")}
+	deliveries <- inProgress
+	trackedDeliveries := tracker.track(deliveries)
+
+	received := <-trackedDeliveries
+	handlerReturned := make(chan struct{})
+	go func() {
+		for range trackedDeliveries {
+			t.Error("Delivery is handed to the handler after the tracker is stopped")
+		}
+		close(handlerReturned)
+	}()
 	go func() {
-		msg.AzureRevokedTokenChannel <- []byte(sampleTestEvent)
+		time.Sleep(50 * time.Millisecond)
+		received.Ack()
 	}()
-	outputData := <-msg.AzureRevokedTokenChannel
-	error := parseRevokedTokenJSONEvent(outputData, &notification)
-	if error != nil {
-		logger.LoggerMgw.Info("Error occurred", error)
-	} else {
-		parsedSuccessfully = true
-	}
-	assert.Equal(t, true, parsedSuccessfully)
+	assert.Equal(t, 0, tracker.stop(time.Now().Add(2*time.Second)), "Delivery in progress is not drained")
+	assert.True(t, inProgress.acked, "Delivery in progress is not acknowledged")
+	// only the first completion of a delivery reaches the broker
+	received.Nack()
+	assert.False(t, inProgress.nacked, "Delivery acknowledged is rejected as well")
+
+	pending := &fakeDelivery{body: []byte("pending")}
+	deliveries <- pending
+	select {
+	case <-handlerReturned:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Tracked deliveries are not closed once the tracker is stopped")
+	}
+	assert.False(t, pending.acked || pending.nacked, "Pending delivery should be left for the broker to redeliver")
+
+	tracker = newDeliveryTracker()
+	deliveries = make(chan msg.Delivery, 2)
+	deliveries <- &fakeDelivery{body: []byte("not acknowledged")}
+	deliveries <- &fakeDelivery{body: []byte("not handed to the handler")}
+	trackedDeliveries = tracker.track(deliveries)
+	<-trackedDeliveries
+	assert.Equal(t, 1, tracker.stop(time.Now().Add(50*time.Millisecond)),
+		"Delivery not completed by the deadline is not reported")
 }
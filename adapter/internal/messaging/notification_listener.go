@@ -19,20 +19,34 @@
 package messaging
 
 import (
+	"bytes"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"reflect"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/wso2/product-microgateway/adapter/config"
+	"github.com/wso2/product-microgateway/adapter/internal/analytics"
+	"github.com/wso2/product-microgateway/adapter/internal/audit"
 	"github.com/wso2/product-microgateway/adapter/internal/discovery/xds"
+	"github.com/wso2/product-microgateway/adapter/internal/eventfilter"
 	eh "github.com/wso2/product-microgateway/adapter/internal/eventhub"
+	"github.com/wso2/product-microgateway/adapter/internal/eventorder"
+	"github.com/wso2/product-microgateway/adapter/internal/jwks"
 	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/internal/profiling"
 	"github.com/wso2/product-microgateway/adapter/internal/synchronizer"
+	stringutils "github.com/wso2/product-microgateway/adapter/internal/utils"
 	"github.com/wso2/product-microgateway/adapter/pkg/discovery/api/wso2/discovery/subscription"
 	"github.com/wso2/product-microgateway/adapter/pkg/eventhub/types"
 	"github.com/wso2/product-microgateway/adapter/pkg/logging"
 	msg "github.com/wso2/product-microgateway/adapter/pkg/messaging"
+	"github.com/wso2/product-microgateway/adapter/pkg/metrics"
+	apisync "github.com/wso2/product-microgateway/adapter/pkg/synchronizer"
 )
 
 // constant variables
@@ -40,7 +54,7 @@ const (
 	apiEventType                = "API"
 	applicationEventType        = "APPLICATION"
 	subscriptionEventType       = "SUBSCRIPTION"
-	scopeEvenType               = "SCOPE"
+	scopeEventType              = "SCOPE"
 	policyEventType             = "POLICY"
 	removeAPIFromGateway        = "REMOVE_API_FROM_GATEWAY"
 	deployAPIToGateway          = "DEPLOY_API_IN_GATEWAY"
@@ -56,58 +70,92 @@ const (
 	policyCreate                = "POLICY_CREATE"
 	policyUpdate                = "POLICY_UPDATE"
 	policyDelete                = "POLICY_DELETE"
+	scopeCreate                 = "SCOPE_CREATE"
+	scopeUpdate                 = "SCOPE_UPDATE"
+	scopeDelete                 = "SCOPE_DELETE"
 	blockedStatus               = "BLOCKED"
+	retiredStatus               = "RETIRED"
 	apiUpdate                   = "API_UPDATE"
+	analyticsConfigUpdate       = "ANALYTICS_CONFIG_UPDATE"
 )
 
 // var variables
 var (
-	ScopeList = make([]types.Scope, 0)
-	// timestamps needs to be maintained as it is not guranteed to receive them in order,
-	// hence older events should be discarded
-	apiListTimeStampMap               = make(map[string]int64, 0)
-	subsriptionsListTimeStampMap      = make(map[string]int64, 0)
-	applicationKeyMappingTimeStampMap = make(map[string]int64, 0)
-	applicationListTimeStampMap       = make(map[string]int64, 0)
+	// timestamp (in milliseconds) of the last notification event processed
+	lastEventTimestamp int64
+	// eventFilter is the *eventfilter.Filter compiled from the event filter rules of the config
+	eventFilter     atomic.Value
+	eventFilterOnce sync.Once
 )
 
+// staleEventReason is the reason recorded for the events discarded in favour of a later event of the resource
+const staleEventReason = "A later event of the resource is already processed"
+
+// invalidEventFilterRuleName is the rule name of the events dropped, as the event filter rules are invalid
+const invalidEventFilterRuleName = "invalid-event-filter-rules"
+
+// sensitiveEventFields are masked when the events are logged
+var sensitiveEventFields = []string{"consumerKey", "consumerSecret", "clientSecret", "password", "secret", "token"}
+
+// eventResource contains the fields identifying the resource of a notification event, among the event types.
+type eventResource struct {
+	msg.Event
+	UUID             string `json:"uuid"`
+	ApplicationUUID  string `json:"applicationUUID"`
+	SubscriptionUUID string `json:"subscriptionUUID"`
+	PolicyName       string `json:"policyName"`
+	Name             string `json:"name"`
+}
+
 // handleNotification to process
-func handleNotification() {
+func handleNotification(deliveries <-chan msg.Delivery) {
 	conf, _ := config.ReadConfigs()
-	for d := range msg.NotificationChannel {
+	for d := range deliveries {
 		var notification msg.EventNotification
-		notificationErr := parseNotificationJSONEvent([]byte(string(d.Body)), &notification)
+		notificationErr := parseNotificationJSONEvent(d.Body(), &notification)
 		if notificationErr != nil {
+			d.Nack()
 			continue
 		}
 		logger.LoggerInternalMsg.Infof("Event %s is received", notification.Event.PayloadData.EventType)
-		err := processNotificationEvent(conf, &notification)
+		err := processNotificationEvent(conf, &notification, audit.EventSourceNotification)
 		if err != nil {
+			d.Nack()
 			continue
 		}
-		d.Ack(false)
+		d.Ack()
 	}
 	logger.LoggerInternalMsg.Infof("handle: deliveries channel closed")
 }
 
-func handleAzureNotification() {
-	conf, _ := config.ReadConfigs()
-	for d := range msg.AzureNotificationChannel {
-		var notification msg.EventNotification
-		error := parseNotificationJSONEvent(d, &notification)
-		if error != nil {
-			continue
+// handleNotificationReconnect catches up with the control plane when the connection to the broker is restored, as
+// the notification events published while the broker was unreachable are not received.
+func handleNotificationReconnect(conf *config.Config) {
+	for range msg.NotificationReconnectChannel {
+		missedSince := "the adapter startup"
+		if timestamp := atomic.LoadInt64(&lastEventTimestamp); timestamp > 0 {
+			missedSince = time.UnixMilli(timestamp).UTC().Format(time.RFC3339)
 		}
-		logger.LoggerInternalMsg.Infof("Event %s is received", notification.Event.PayloadData.EventType)
-		err := processNotificationEvent(conf, &notification)
-		if err != nil {
-			continue
+		logger.LoggerInternalMsg.Infof("Catching up with the control plane for the events missed since %s", missedSince)
+		if !conf.GlobalAdapter.Enabled && config.IsEventCategoryEnabled(conf, config.EventCategoryAPI) {
+			if err := synchronizer.CatchUpWithControlPlane(); err != nil {
+				logger.LoggerInternalMsg.ErrorC(logging.ErrorDetails{
+					Message:   fmt.Sprintf("Error occurred while catching up the APIs with the control plane. %v", err),
+					Severity:  logging.MAJOR,
+					ErrorCode: 2009,
+				})
+			}
 		}
+		eh.LoadSubscriptionData(conf, nil)
 	}
 }
 
-func processNotificationEvent(conf *config.Config, notification *msg.EventNotification) error {
+// processNotificationEvent processes an event by the handler of the event type. The source of the event is recorded
+// in the event history.
+func processNotificationEvent(conf *config.Config, notification *msg.EventNotification, source string) error {
+	startedAt := time.Now()
 	var eventType string
+	eventType = notification.Event.PayloadData.EventType
 	var decodedByte, err = base64.StdEncoding.DecodeString(notification.Event.PayloadData.Event)
 	if err != nil {
 		if _, ok := err.(base64.CorruptInputError); ok {
@@ -115,25 +163,154 @@ func processNotificationEvent(conf *config.Config, notification *msg.EventNotifi
 		}
 		logger.LoggerInternalMsg.Errorf("Error occurred while decoding the notification event %v. "+
 			"Hence dropping the event", err)
+		audit.RecordEvent(audit.EventRecord{
+			Source:  source,
+			Type:    eventType,
+			Outcome: audit.EventFailed,
+			Reason:  fmt.Sprintf("Error occurred while decoding the event. %v", err),
+		})
+		profiling.ObserveEvent(audit.EventFailed, time.Since(startedAt))
 		return err
 	}
 	logger.LoggerInternalMsg.Debugf("\n\n[%s]", decodedByte)
-	eventType = notification.Event.PayloadData.EventType
-	if strings.Contains(eventType, apiLifeCycleChange) {
-		handleLifeCycleEvents(decodedByte)
+	atomic.StoreInt64(&lastEventTimestamp, int64(notification.Event.PayloadData.Timstamp))
+	var event eventResource
+	if err := json.Unmarshal(decodedByte, &event); err == nil {
+		// the JWKS of the tenants are cached as they are discovered
+		jwks.AddTenant(event.TenantDomain)
+	}
+	outcome, reason, stale := audit.EventProcessed, "", false
+	category := getNotificationEventCategory(eventType)
+	action, ruleName := eventfilter.ActionApply, ""
+	if filter := getEventFilter(conf); filter.HasRules() {
+		action, ruleName = filter.Evaluate(getEventAttributes(decodedByte, eventType, category))
+	}
+	if category != "" && !config.IsEventCategoryEnabled(conf, category) {
+		outcome, reason = audit.EventIgnored, fmt.Sprintf("Events of the category %s are not enabled", category)
+	} else if action == eventfilter.ActionDrop {
+		logger.LoggerInternalMsg.Debugf("Event %s is dropped by the event filter rule %s", eventType, ruleName)
+		outcome, reason = audit.EventIgnored, fmt.Sprintf("Dropped by the event filter rule %s", ruleName)
+	} else if action == eventfilter.ActionLog {
+		logger.LoggerInternalMsg.Infof("Event %s is matched by the event filter rule %s, hence only logged. %s",
+			eventType, ruleName, stringutils.MaskJSONFields(decodedByte, sensitiveEventFields))
+		outcome, reason = audit.EventIgnored, fmt.Sprintf("Logged only by the event filter rule %s", ruleName)
+	} else if strings.Contains(eventType, analyticsConfigUpdate) {
+		handleAnalyticsConfigEvents(decodedByte)
+	} else if strings.Contains(eventType, apiLifeCycleChange) {
+		stale = handleLifeCycleEvents(decodedByte)
 	} else if strings.Contains(eventType, apiEventType) && !conf.GlobalAdapter.Enabled {
-		handleAPIEvents(decodedByte, eventType)
+		stale = handleAPIEvents(decodedByte, eventType)
 	} else if strings.Contains(eventType, applicationEventType) {
-		handleApplicationEvents(decodedByte, eventType)
+		stale = handleApplicationEvents(decodedByte, eventType)
 	} else if strings.Contains(eventType, subscriptionEventType) {
-		handleSubscriptionEvents(decodedByte, eventType)
+		stale = handleSubscriptionEvents(decodedByte, eventType)
 	} else if strings.Contains(eventType, policyEventType) {
-		handlePolicyEvents(decodedByte, eventType)
+		stale = handlePolicyEvents(decodedByte, eventType)
+	} else if strings.Contains(eventType, scopeEventType) {
+		stale = handleScopeEvents(decodedByte)
+	} else if strings.Contains(eventType, apiEventType) {
+		outcome, reason = audit.EventIgnored, "API events are received from the global adapter"
+	} else {
+		// other events will ignore including HEALTH_CHECK event
+		outcome = audit.EventIgnored
+	}
+	if stale {
+		outcome, reason = audit.EventIgnored, staleEventReason
+	}
+	if outcome == audit.EventProcessed && category == config.EventCategorySubscription &&
+		source != audit.EventSourceJournal {
+		// the subscription events are recorded in the journal, to rebuild the subscriptions from the journal
+		recordSubscriptionEvent(notification, eventType, event)
 	}
-	// other events will ignore including HEALTH_CHECK event
+	audit.RecordEvent(audit.EventRecord{
+		Source:         source,
+		EventID:        event.EventID,
+		Type:           eventType,
+		ResourceID:     event.getID(),
+		OrganizationID: event.TenantDomain,
+		Outcome:        outcome,
+		Reason:         reason,
+	})
+	profiling.ObserveEvent(outcome, time.Since(startedAt))
 	return nil
 }
 
+// recordSubscriptionEvent records the subscription event processed in the audit journal.
+func recordSubscriptionEvent(notification *msg.EventNotification, eventType string, event eventResource) {
+	payload, err := json.Marshal(notification)
+	if err != nil {
+		logger.LoggerInternalMsg.Warnf("Event %s of %s is not recorded in the journal. %v", eventType, event.getID(), err)
+		return
+	}
+	audit.RecordChange(audit.ChangeRecord{
+		Actor:          audit.ActorControlPlane,
+		Action:         audit.ActionSubscriptionEvent,
+		OrganizationID: event.TenantDomain,
+		Subject:        event.getID(),
+		Subscription:   &audit.SubscriptionData{Type: eventType, Payload: payload},
+	})
+}
+
+// getEventFilter returns the filter compiled from the event filter rules of the config, which is compiled once
+// before the events are consumed. The adapter exits if any rule is invalid. The filter drops all the events if it
+// does not, as the events meant to be dropped would be applied otherwise.
+func getEventFilter(conf *config.Config) *eventfilter.Filter {
+	eventFilterOnce.Do(func() {
+		compileEventFilter(conf)
+	})
+	return eventFilter.Load().(*eventfilter.Filter)
+}
+
+// compileEventFilter compiles the event filter rules of the config, replacing the filter the events are evaluated by.
+func compileEventFilter(conf *config.Config) {
+	filter, err := eventfilter.NewFilter(conf.ControlPlane.EventFilters)
+	if err != nil {
+		filter = eventfilter.NewDropAllFilter(invalidEventFilterRuleName)
+		eventFilter.Store(filter)
+		logger.LoggerInternalMsg.ErrorC(logging.ErrorDetails{
+			Message:   fmt.Sprintf("Error compiling the event filter rules, hence all the events are dropped. %v", err),
+			Severity:  logging.BLOCKER,
+			ErrorCode: 2017,
+		})
+		return
+	}
+	eventFilter.Store(filter)
+}
+
+// getEventAttributes returns the attributes of an event matched by the event filter rules, which are the scalar
+// fields of the event payload, the event type and the category of the event.
+func getEventAttributes(data []byte, eventType, category string) map[string]string {
+	var payload map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	attributes := make(map[string]string)
+	if err := decoder.Decode(&payload); err == nil {
+		for key, value := range payload {
+			switch value.(type) {
+			case string, bool, json.Number:
+				attributes[key] = fmt.Sprint(value)
+			}
+		}
+	}
+	attributes["eventType"] = eventType
+	attributes["category"] = category
+	return attributes
+}
+
+// getNotificationEventCategory returns the event category of a notification event type, which is empty for the
+// events not belonging to a category (ex: HEALTH_CHECK).
+func getNotificationEventCategory(eventType string) string {
+	switch {
+	case strings.Contains(eventType, analyticsConfigUpdate), strings.Contains(eventType, apiLifeCycleChange),
+		strings.Contains(eventType, apiEventType), strings.Contains(eventType, scopeEventType):
+		return config.EventCategoryAPI
+	case strings.Contains(eventType, applicationEventType), strings.Contains(eventType, subscriptionEventType),
+		strings.Contains(eventType, policyEventType):
+		return config.EventCategorySubscription
+	}
+	return ""
+}
+
 // handleDefaultVersionUpdate will redeploy default versioned API.
 // API runtime artifact doesn't get updated in CP side when default version is updated
 // (isDefaultVersion prop in apiYaml is not updated). API deployment or should happen
@@ -153,25 +330,25 @@ func handleDefaultVersionUpdate(event msg.APIEvent) {
 		eh.UpdateAPIMetadataFromCP(query)
 	}
 
-	synchronizer.FetchAPIsFromControlPlane(event.UUID, deployedEnvs)
+	synchronizer.FetchAPIsFromControlPlane(event.UUID, deployedEnvs, event.TimeStamp)
 }
 
-// handleAPIEvents to process api related data
-func handleAPIEvents(data []byte, eventType string) {
+// handleAPIEvents to process api related data, and returns whether the event is discarded as a later event of the
+// API is already processed
+func handleAPIEvents(data []byte, eventType string) bool {
 	var (
 		apiEvent              msg.APIEvent
-		currentTimeStamp      int64 = apiEvent.Event.TimeStamp
 		isDefaultVersionEvent bool
 	)
 
-	apiEventErr := json.Unmarshal([]byte(string(data)), &apiEvent)
+	apiEventErr := decodeEvent(eventType, data, &apiEvent)
 	if apiEventErr != nil {
 		logger.LoggerInternalMsg.ErrorC(logging.ErrorDetails{
 			Message:   fmt.Sprintf("Error occurred while unmarshalling API event data %v", apiEventErr),
 			Severity:  logging.MAJOR,
 			ErrorCode: 2004,
 		})
-		return
+		return false
 	}
 
 	if !belongsToTenant(apiEvent.TenantDomain) {
@@ -185,35 +362,48 @@ func handleAPIEvents(data []byte, eventType string) {
 		}
 		logger.LoggerInternalMsg.Debugf("API event for the API %s:%s is dropped due to having non related tenantDomain : %s",
 			apiName, apiVersion, apiEvent.TenantDomain)
-		return
+		return false
 	}
 
 	isDefaultVersionEvent = isDefaultVersionUpdate(apiEvent)
 
 	if isDefaultVersionEvent {
-		handleDefaultVersionUpdate(apiEvent)
-		return
+		return eventorder.Apply(eventorder.ResourceAPIMetadata, apiEvent.UUID, apiEvent.TimeStamp, false, func() {
+			handleDefaultVersionUpdate(apiEvent)
+		})
 	}
 
+	// the API is neither deployed again by a deploy event received after it is removed, nor removed by a remove
+	// event received after it is deployed again
+	isRemoveEvent := strings.EqualFold(removeAPIFromGateway, apiEvent.Event.Type)
+	if len(apiEvent.GatewayLabels) == 0 {
+		return eventorder.Apply(eventorder.ResourceAPI, apiEvent.UUID, apiEvent.TimeStamp, isRemoveEvent, func() {
+			applyAPIEvent(apiEvent, nil, isRemoveEvent)
+		})
+	}
+	envs := eventorder.ApplyToParts(eventorder.ResourceAPI, apiEvent.UUID, apiEvent.GatewayLabels, apiEvent.TimeStamp,
+		isRemoveEvent, func(envs []string) {
+			applyAPIEvent(apiEvent, envs, isRemoveEvent)
+		})
+	return len(envs) == 0
+}
+
+// applyAPIEvent deploys or removes the API of an event in the environments.
+func applyAPIEvent(apiEvent msg.APIEvent, envs []string, isRemoveEvent bool) {
 	// Per each revision, synchronization should happen.
 	if strings.EqualFold(deployAPIToGateway, apiEvent.Event.Type) {
-		go synchronizer.FetchAPIsFromControlPlane(apiEvent.UUID, apiEvent.GatewayLabels)
+		xds.SetSubscriptionValidationOverride(apiEvent.UUID, apiEvent.SubscriptionValidation)
+		xds.SetEnvPropsOverride(apiEvent.UUID, getAPIEnvPropsOfEvent(apiEvent))
+		fetchAPIsFromControlPlane(apiEvent.UUID, envs, apiEvent.TimeStamp)
 	}
 
-	for _, env := range apiEvent.GatewayLabels {
-		if isLaterEvent(apiListTimeStampMap, apiEvent.UUID+":"+env, currentTimeStamp) {
-			break
-		}
+	for _, env := range envs {
 		// removeFromGateway event with multiple labels could only appear when the API is subjected
 		// to delete. Hence we could simply delete after checking against just one iteration.
-		if strings.EqualFold(removeAPIFromGateway, apiEvent.Event.Type) {
-			xds.DeleteAPIWithAPIMEvent(apiEvent.UUID, apiEvent.TenantDomain, apiEvent.GatewayLabels, "")
-			for _, env := range apiEvent.GatewayLabels {
-				xdsAPIList := xds.DeleteAPIAndReturnList(apiEvent.UUID, apiEvent.TenantDomain, env)
-				if xdsAPIList != nil {
-					xds.UpdateEnforcerAPIList(env, xdsAPIList)
-				}
-			}
+		if isRemoveEvent {
+			xds.SetSubscriptionValidationOverride(apiEvent.UUID, nil)
+			xds.SetEnvPropsOverride(apiEvent.UUID, nil)
+			xds.UndeployAPIWithAPIMEvent(apiEvent.UUID, apiEvent.TenantDomain, envs, "")
 			break
 		}
 		if strings.EqualFold(deployAPIToGateway, apiEvent.Event.Type) {
@@ -241,24 +431,43 @@ func handleAPIEvents(data []byte, eventType string) {
 	}
 }
 
-func handleLifeCycleEvents(data []byte) {
+// handleLifeCycleEvents to process the API lifecycle state changes, and returns whether the event is discarded as a
+// later lifecycle state change of the API is already processed
+func handleLifeCycleEvents(data []byte) bool {
 	var apiEvent msg.APIEvent
-	apiLCEventErr := json.Unmarshal([]byte(string(data)), &apiEvent)
+	apiLCEventErr := decodeEvent(apiLifeCycleChange, data, &apiEvent)
 	if apiLCEventErr != nil {
 		logger.LoggerInternalMsg.Errorf("Error occurred while unmarshalling Lifecycle event data %v", apiLCEventErr)
-		return
+		return false
 	}
 	if !belongsToTenant(apiEvent.TenantDomain) {
 		logger.LoggerInternalMsg.Debugf("API Lifecycle event for the API %s:%s is dropped due to having non related tenantDomain : %s",
 			apiEvent.APIName, apiEvent.APIVersion, apiEvent.TenantDomain)
-		return
+		return false
 	}
+	return eventorder.Apply(eventorder.ResourceAPILifeCycle, apiEvent.UUID, apiEvent.TimeStamp, false, func() {
+		applyLifeCycleEvent(apiEvent)
+	})
+}
+
+// applyLifeCycleEvent undeploys the retired API of a lifecycle event, or updates the lifecycle status of the API.
+func applyLifeCycleEvent(apiEvent msg.APIEvent) {
 	conf, _ := config.ReadConfigs()
 	configuredEnvs := conf.ControlPlane.EnvironmentLabels
 	logger.LoggerInternalMsg.Debugf("%s : %s API life cycle state change event triggered", apiEvent.APIName, apiEvent.APIVersion)
 	if len(configuredEnvs) == 0 {
 		configuredEnvs = append(configuredEnvs, config.DefaultGatewayName)
 	}
+	// the retired APIs are undeployed, while the routes of the other APIs are updated by the lifecycle status
+	if strings.EqualFold(apiEvent.APIStatus, retiredStatus) {
+		if deployedEnvs := xds.GetDeployedEnvironments(apiEvent.UUID); len(deployedEnvs) > 0 {
+			logger.LoggerInternalMsg.Infof("Undeploying the API %s:%s as it is retired", apiEvent.APIName,
+				apiEvent.APIVersion)
+			xds.UndeployAPIWithAPIMEvent(apiEvent.UUID, apiEvent.TenantDomain, deployedEnvs, "")
+		}
+		return
+	}
+	xds.UpdateAPILifecycleStatus(apiEvent.UUID, apiEvent.APIStatus)
 	for _, configuredEnv := range configuredEnvs {
 		xdsAPIList := xds.MarshalAPIForLifeCycleChangeEventAndReturnList(apiEvent.UUID, apiEvent.APIStatus, configuredEnv)
 		if xdsAPIList != nil {
@@ -267,93 +476,96 @@ func handleLifeCycleEvents(data []byte) {
 	}
 }
 
-// handleApplicationEvents to process application related events
-func handleApplicationEvents(data []byte, eventType string) {
+// handleApplicationEvents to process application related events, and returns whether the event is discarded as a
+// later event of the application or the key mapping is already processed
+func handleApplicationEvents(data []byte, eventType string) bool {
 	if strings.EqualFold(applicationRegistration, eventType) ||
 		strings.EqualFold(removeApplicationKeyMapping, eventType) {
 		var applicationRegistrationEvent msg.ApplicationRegistrationEvent
-		appRegEventErr := json.Unmarshal([]byte(string(data)), &applicationRegistrationEvent)
+		appRegEventErr := decodeEvent(eventType, data, &applicationRegistrationEvent)
 		if appRegEventErr != nil {
 			logger.LoggerInternalMsg.Errorf("Error occurred while unmarshalling Application Registration event data %v", appRegEventErr)
-			return
+			return false
 		}
 
 		if !belongsToTenant(applicationRegistrationEvent.TenantDomain) {
 			logger.LoggerInternalMsg.Debugf("Application Registration event for the Consumer Key : %s is dropped due to having non related tenantDomain : %s",
 				applicationRegistrationEvent.ConsumerKey, applicationRegistrationEvent.TenantDomain)
-			return
+			return false
 		}
 
 		applicationKeyMapping := types.ApplicationKeyMapping{ApplicationID: applicationRegistrationEvent.ApplicationID,
 			ConsumerKey: applicationRegistrationEvent.ConsumerKey, KeyType: applicationRegistrationEvent.KeyType,
-			KeyManager: applicationRegistrationEvent.KeyManager, TenantID: -1, TenantDomain: applicationRegistrationEvent.TenantDomain,
+			KeyManager: applicationRegistrationEvent.KeyManager, TenantID: applicationRegistrationEvent.TenantID, TenantDomain: applicationRegistrationEvent.TenantDomain,
 			TimeStamp: applicationRegistrationEvent.TimeStamp, ApplicationUUID: applicationRegistrationEvent.ApplicationUUID}
 
 		applicationKeyMappingReference := xds.GetApplicationKeyMappingReference(&applicationKeyMapping)
 
-		if isLaterEvent(applicationKeyMappingTimeStampMap, fmt.Sprint(applicationKeyMappingReference),
-			applicationRegistrationEvent.TimeStamp) {
-			return
-		}
-
-		var appKeyMappingList *subscription.ApplicationKeyMappingList
-		if strings.EqualFold(removeApplicationKeyMapping, eventType) {
-			appKeyMappingList = xds.MarshalApplicationKeyMappingEventAndReturnList(&applicationKeyMapping, xds.DeleteEvent)
-		} else {
-			appKeyMappingList = xds.MarshalApplicationKeyMappingEventAndReturnList(&applicationKeyMapping, xds.CreateEvent)
-		}
-		xds.UpdateEnforcerApplicationKeyMappings(appKeyMappingList)
+		isRemoveEvent := strings.EqualFold(removeApplicationKeyMapping, eventType)
+		return eventorder.Apply(eventorder.ResourceApplicationKeyMapping, fmt.Sprint(applicationKeyMappingReference),
+			applicationRegistrationEvent.TimeStamp, isRemoveEvent, func() {
+				var appKeyMappingList *subscription.ApplicationKeyMappingList
+				if isRemoveEvent {
+					appKeyMappingList = xds.MarshalApplicationKeyMappingEventAndReturnList(&applicationKeyMapping, xds.DeleteEvent)
+				} else {
+					appKeyMappingList = xds.MarshalApplicationKeyMappingEventAndReturnList(&applicationKeyMapping, xds.CreateEvent)
+				}
+				xds.UpdateEnforcerApplicationKeyMappings(appKeyMappingList)
+			})
 	} else {
 		var applicationEvent msg.ApplicationEvent
-		appEventErr := json.Unmarshal([]byte(string(data)), &applicationEvent)
+		appEventErr := decodeEvent(eventType, data, &applicationEvent)
 		if appEventErr != nil {
 			logger.LoggerInternalMsg.Errorf("Error occurred while unmarshalling Application event data %v", appEventErr)
-			return
+			return false
 		}
 
 		if !belongsToTenant(applicationEvent.TenantDomain) {
 			logger.LoggerInternalMsg.Debugf("Application event for the Application : %s (with uuid %s) is dropped due to having non related tenantDomain : %s",
 				applicationEvent.ApplicationName, applicationEvent.UUID, applicationEvent.TenantDomain)
-			return
+			return false
 		}
 
 		app := types.Application{UUID: applicationEvent.UUID, ID: applicationEvent.ApplicationID,
 			Name: applicationEvent.ApplicationName, SubName: applicationEvent.Subscriber,
-			Policy: applicationEvent.ApplicationPolicy, TokenType: applicationEvent.TokenType, Attributes: nil,
-			TenantID: -1, TenantDomain: applicationEvent.TenantDomain, TimeStamp: applicationEvent.TimeStamp}
-
-		if isLaterEvent(applicationListTimeStampMap, fmt.Sprint(applicationEvent.ApplicationID), applicationEvent.TimeStamp) {
-			return
-		}
+			Policy: applicationEvent.ApplicationPolicy, TokenType: applicationEvent.TokenType, Attributes: parseApplicationAttributes(applicationEvent.Attributes),
+			TenantID: applicationEvent.TenantID, TenantDomain: applicationEvent.TenantDomain, TimeStamp: applicationEvent.TimeStamp}
 
-		var appList *subscription.ApplicationList
+		var eventType xds.EventType
 		if applicationEvent.Event.Type == applicationCreate {
-			appList = xds.MarshalApplicationEventAndReturnList(&app, xds.CreateEvent)
+			eventType = xds.CreateEvent
 		} else if applicationEvent.Event.Type == applicationUpdate {
-			appList = xds.MarshalApplicationEventAndReturnList(&app, xds.UpdateEvent)
+			eventType = xds.UpdateEvent
 		} else if applicationEvent.Event.Type == applicationDelete {
-			appList = xds.MarshalApplicationEventAndReturnList(&app, xds.DeleteEvent)
+			eventType = xds.DeleteEvent
 		} else {
 			logger.LoggerInternalMsg.Warnf("Application Event Type is not recognized for the Event under "+
 				"Application UUID %s", app.UUID)
-			return
+			return false
 		}
-		xds.UpdateEnforcerApplications(appList)
+		return eventorder.Apply(eventorder.ResourceApplication, fmt.Sprint(applicationEvent.ApplicationID),
+			applicationEvent.TimeStamp, eventType == xds.DeleteEvent, func() {
+				appList := xds.MarshalApplicationEventAndReturnList(&app, eventType)
+				subscribedAPIs := xds.GetAPIsSubscribedByApplication(app.UUID)
+				xds.UpdateEnforcerApplications(appList)
+				xds.UpdateRateLimitsOfAPIs(subscribedAPIs)
+			})
 	}
 }
 
-// handleSubscriptionRelatedEvents to process subscription related events
-func handleSubscriptionEvents(data []byte, eventType string) {
+// handleSubscriptionRelatedEvents to process subscription related events, and returns whether the event is discarded
+// as a later event of the subscription is already processed
+func handleSubscriptionEvents(data []byte, eventType string) bool {
 	var subscriptionEvent msg.SubscriptionEvent
-	subEventErr := json.Unmarshal([]byte(string(data)), &subscriptionEvent)
+	subEventErr := decodeEvent(eventType, data, &subscriptionEvent)
 	if subEventErr != nil {
 		logger.LoggerInternalMsg.Errorf("Error occurred while unmarshalling Subscription event data %v", subEventErr)
-		return
+		return false
 	}
 	if !belongsToTenant(subscriptionEvent.TenantDomain) {
 		logger.LoggerInternalMsg.Debugf("Subscription event for the Application : %s and API %s is dropped due to having non related tenantDomain : %s",
 			subscriptionEvent.ApplicationUUID, subscriptionEvent.APIUUID, subscriptionEvent.TenantDomain)
-		return
+		return false
 	}
 
 	sub := types.Subscription{SubscriptionID: subscriptionEvent.SubscriptionID, SubscriptionUUID: subscriptionEvent.SubscriptionUUID,
@@ -361,32 +573,40 @@ func handleSubscriptionEvents(data []byte, eventType string) {
 		APIID: subscriptionEvent.APIID, AppID: subscriptionEvent.ApplicationID, SubscriptionState: subscriptionEvent.SubscriptionState,
 		TenantID: subscriptionEvent.TenantID, TenantDomain: subscriptionEvent.TenantDomain, TimeStamp: subscriptionEvent.TimeStamp}
 
-	if isLaterEvent(subsriptionsListTimeStampMap, fmt.Sprint(subscriptionEvent.SubscriptionID), subscriptionEvent.TimeStamp) {
-		return
+	// the deprecated APIs are not subscribed anymore, while the existing subscriptions are retained
+	if subscriptionEvent.Event.Type == subscriptionCreate && xds.IsAPIDeprecated(sub.APIUUID) {
+		logger.LoggerInternalMsg.Warnf("Subscription %s of the Application %s is dropped, as the API %s is deprecated",
+			sub.SubscriptionUUID, sub.ApplicationUUID, sub.APIUUID)
+		return false
 	}
-	var subList *subscription.SubscriptionList
+	var subEventType xds.EventType
 	if subscriptionEvent.Event.Type == subscriptionCreate {
-		subList = xds.MarshalSubscriptionEventAndReturnList(&sub, xds.CreateEvent)
+		subEventType = xds.CreateEvent
 	} else if subscriptionEvent.Event.Type == subscriptionUpdate {
-		subList = xds.MarshalSubscriptionEventAndReturnList(&sub, xds.UpdateEvent)
+		subEventType = xds.UpdateEvent
 	} else if subscriptionEvent.Event.Type == subscriptionDelete {
-		subList = xds.MarshalSubscriptionEventAndReturnList(&sub, xds.DeleteEvent)
+		subEventType = xds.DeleteEvent
 	} else {
 		logger.LoggerInternalMsg.Warnf("Subscription Event Type is not recognized for the Event under "+
 			"Application UUID %s and API UUID %s", sub.ApplicationUUID, sub.APIUUID)
-		return
+		return false
 	}
-	// EventTypes: SUBSCRIPTIONS_CREATE, SUBSCRIPTIONS_UPDATE, SUBSCRIPTIONS_DELETE
-	xds.UpdateEnforcerSubscriptions(subList)
+	return eventorder.Apply(eventorder.ResourceSubscription, fmt.Sprint(subscriptionEvent.SubscriptionID),
+		subscriptionEvent.TimeStamp, subEventType == xds.DeleteEvent, func() {
+			// EventTypes: SUBSCRIPTIONS_CREATE, SUBSCRIPTIONS_UPDATE, SUBSCRIPTIONS_DELETE
+			xds.UpdateEnforcerSubscriptions(xds.MarshalSubscriptionEventAndReturnList(&sub, subEventType))
+			xds.UpdateRateLimitsOfAPIs([]string{sub.APIUUID})
+		})
 }
 
-// handlePolicyRelatedEvents to process policy related events
-func handlePolicyEvents(data []byte, eventType string) {
+// handlePolicyRelatedEvents to process policy related events, and returns whether the event is discarded as a later
+// event of the policy is already processed
+func handlePolicyEvents(data []byte, eventType string) bool {
 	var policyEvent msg.PolicyInfo
-	policyEventErr := json.Unmarshal([]byte(string(data)), &policyEvent)
+	policyEventErr := decodeEvent(eventType, data, &policyEvent)
 	if policyEventErr != nil {
 		logger.LoggerInternalMsg.Errorf("Error occurred while unmarshalling Throttling Policy event data %v", policyEventErr)
-		return
+		return false
 	}
 	// TODO: Handle policy events
 	if strings.EqualFold(eventType, policyCreate) {
@@ -397,74 +617,229 @@ func handlePolicyEvents(data []byte, eventType string) {
 		logger.LoggerInternalMsg.Infof("Policy: %s for policy type: %s", policyEvent.PolicyName, policyEvent.PolicyType)
 	}
 
+	policyKey := fmt.Sprintf("%s:%s:%d", policyEvent.TenantDomain, policyEvent.PolicyType, policyEvent.PolicyID)
 	if strings.EqualFold(applicationEventType, policyEvent.PolicyType) {
 		applicationPolicy := types.ApplicationPolicy{ID: policyEvent.PolicyID, TenantID: policyEvent.Event.TenantID,
-			Name: policyEvent.PolicyName, QuotaType: policyEvent.QuotaType}
-		var applicationPolicyList *subscription.ApplicationPolicyList
+			Name: policyEvent.PolicyName, QuotaType: policyEvent.QuotaType, DefaultLimit: policyEvent.DefaultLimit}
+		var changeType xds.EventType
 		if policyEvent.Event.Type == policyCreate {
-			applicationPolicyList = xds.MarshalApplicationPolicyEventAndReturnList(&applicationPolicy, xds.CreateEvent)
+			changeType = xds.CreateEvent
 		} else if policyEvent.Event.Type == policyUpdate {
-			applicationPolicyList = xds.MarshalApplicationPolicyEventAndReturnList(&applicationPolicy, xds.UpdateEvent)
+			changeType = xds.UpdateEvent
 		} else if policyEvent.Event.Type == policyDelete {
-			applicationPolicyList = xds.MarshalApplicationPolicyEventAndReturnList(&applicationPolicy, xds.DeleteEvent)
+			changeType = xds.DeleteEvent
 		} else {
 			logger.LoggerInternalMsg.Warnf("ApplicationPolicy Event Type is not recognized for the Event under "+
 				" policy name %s", policyEvent.PolicyName)
-			return
+			return false
 		}
-		xds.UpdateEnforcerApplicationPolicies(applicationPolicyList)
-
+		return eventorder.Apply(eventorder.ResourcePolicy, policyKey, policyEvent.TimeStamp,
+			changeType == xds.DeleteEvent, func() {
+				xds.UpdateEnforcerApplicationPolicies(
+					xds.MarshalApplicationPolicyEventAndReturnList(&applicationPolicy, changeType))
+				xds.UpdateRateLimitsOfAPIs(xds.GetAPIsOfApplicationPolicy(applicationPolicy.Name))
+			})
 	} else if strings.EqualFold(subscriptionEventType, policyEvent.PolicyType) {
 		var subscriptionPolicyEvent msg.SubscriptionPolicyEvent
-		subPolicyErr := json.Unmarshal([]byte(string(data)), &subscriptionPolicyEvent)
+		subPolicyErr := decodeEvent(eventType, data, &subscriptionPolicyEvent)
 		if subPolicyErr != nil {
 			logger.LoggerInternalMsg.Errorf("Error occurred while unmarshalling Subscription Policy event data %v", subPolicyErr)
-			return
+			return false
 		}
 
-		subscriptionPolicy := types.SubscriptionPolicy{ID: subscriptionPolicyEvent.PolicyID, TenantID: -1,
+		subscriptionPolicy := types.SubscriptionPolicy{ID: subscriptionPolicyEvent.PolicyID, TenantID: subscriptionPolicyEvent.TenantID,
 			Name: subscriptionPolicyEvent.PolicyName, QuotaType: subscriptionPolicyEvent.QuotaType,
 			GraphQLMaxComplexity: subscriptionPolicyEvent.GraphQLMaxComplexity,
 			GraphQLMaxDepth:      subscriptionPolicyEvent.GraphQLMaxDepth, RateLimitCount: subscriptionPolicyEvent.RateLimitCount,
 			RateLimitTimeUnit: subscriptionPolicyEvent.RateLimitTimeUnit, StopOnQuotaReach: subscriptionPolicyEvent.StopOnQuotaReach,
-			TenantDomain: subscriptionPolicyEvent.TenantDomain, TimeStamp: subscriptionPolicyEvent.TimeStamp}
+			TenantDomain: subscriptionPolicyEvent.TenantDomain, TimeStamp: subscriptionPolicyEvent.TimeStamp,
+			DefaultLimit: subscriptionPolicyEvent.DefaultLimit}
 
-		var subscriptionPolicyList *subscription.SubscriptionPolicyList
+		var changeType xds.EventType
 		if subscriptionPolicyEvent.Event.Type == policyCreate {
-			subscriptionPolicyList = xds.MarshalSubscriptionPolicyEventAndReturnList(&subscriptionPolicy, xds.CreateEvent)
+			changeType = xds.CreateEvent
 		} else if subscriptionPolicyEvent.Event.Type == policyUpdate {
-			subscriptionPolicyList = xds.MarshalSubscriptionPolicyEventAndReturnList(&subscriptionPolicy, xds.UpdateEvent)
+			changeType = xds.UpdateEvent
 		} else if subscriptionPolicyEvent.Event.Type == policyDelete {
-			subscriptionPolicyList = xds.MarshalSubscriptionPolicyEventAndReturnList(&subscriptionPolicy, xds.DeleteEvent)
+			changeType = xds.DeleteEvent
 		} else {
 			logger.LoggerInternalMsg.Warnf("SubscriptionPolicy Event Type is not recognized for the Event under "+
 				" policy name %s", policyEvent.PolicyName)
-			return
+			return false
 		}
-		xds.UpdateEnforcerSubscriptionPolicies(subscriptionPolicyList)
+		return eventorder.Apply(eventorder.ResourcePolicy, policyKey, policyEvent.TimeStamp,
+			changeType == xds.DeleteEvent, func() {
+				xds.UpdateEnforcerSubscriptionPolicies(
+					xds.MarshalSubscriptionPolicyEventAndReturnList(&subscriptionPolicy, changeType))
+				xds.UpdateRateLimitsOfAPIs(xds.GetAPIsOfSubscriptionPolicy(subscriptionPolicy.Name))
+			})
 	}
+	return false
 }
 
-func isLaterEvent(timeStampMap map[string]int64, mapKey string, currentTimeStamp int64) bool {
-	if timeStamp, ok := timeStampMap[mapKey]; ok {
-		if timeStamp > currentTimeStamp {
-			return true
+// handleScopeEvents to process scope related events, and returns whether the event is discarded as a later event of
+// the scope is already processed
+func handleScopeEvents(data []byte) bool {
+	var scopeEvent msg.ScopeEvent
+	scopeEventErr := decodeEvent(scopeEventType, data, &scopeEvent)
+	if scopeEventErr != nil {
+		logger.LoggerInternalMsg.Errorf("Error occurred while unmarshalling Scope event data %v", scopeEventErr)
+		return false
+	}
+	if !belongsToTenant(scopeEvent.TenantDomain) {
+		logger.LoggerInternalMsg.Debugf("Scope event for the Scope : %s is dropped due to having non related tenantDomain : %s",
+			scopeEvent.Name, scopeEvent.TenantDomain)
+		return false
+	}
+
+	scope := types.Scope{Name: scopeEvent.Name, DisplayName: scopeEvent.DisplayName,
+		Description: scopeEvent.Description, Roles: scopeEvent.Roles, TenantID: scopeEvent.TenantID,
+		TenantDomain: scopeEvent.TenantDomain}
+
+	var scopeEventType xds.EventType
+	if scopeEvent.Event.Type == scopeCreate {
+		scopeEventType = xds.CreateEvent
+	} else if scopeEvent.Event.Type == scopeUpdate {
+		scopeEventType = xds.UpdateEvent
+	} else if scopeEvent.Event.Type == scopeDelete {
+		scopeEventType = xds.DeleteEvent
+	} else {
+		logger.LoggerInternalMsg.Warnf("Scope Event Type is not recognized for the Event under Scope %s", scope.Name)
+		return false
+	}
+	return eventorder.Apply(eventorder.ResourceScope, scopeEvent.TenantDomain+":"+scopeEvent.Name, scopeEvent.TimeStamp,
+		scopeEventType == xds.DeleteEvent, func() {
+			// EventTypes: SCOPE_CREATE, SCOPE_UPDATE, SCOPE_DELETE
+			xds.UpdateEnforcerScopes(xds.MarshalScopeEventAndReturnList(&scope, scopeEventType))
+		})
+}
+
+// getID returns the ID of the resource of an event. The fields identifying the resource differ by the event type.
+func (resource eventResource) getID() string {
+	for _, id := range []string{resource.SubscriptionUUID, resource.UUID, resource.ApplicationUUID,
+		resource.PolicyName, resource.Name} {
+		if id != "" {
+			return id
 		}
 	}
-	timeStampMap[mapKey] = currentTimeStamp
-	return false
+	return ""
+}
+
+// parseApplicationAttributes returns the custom attributes of an application event. The attributes are sent as a
+// JSON object, or as a string containing the JSON object. The values other than strings are converted to their
+// JSON representation.
+func parseApplicationAttributes(attributes interface{}) map[string]string {
+	if encoded, ok := attributes.(string); ok {
+		if err := json.Unmarshal([]byte(encoded), &attributes); err != nil {
+			logger.LoggerInternalMsg.Warnf("Application attributes %q are ignored, as those are not a JSON object",
+				encoded)
+			return nil
+		}
+	}
+	attributeMap, ok := attributes.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	parsedAttributes := make(map[string]string, len(attributeMap))
+	for name, value := range attributeMap {
+		switch typedValue := value.(type) {
+		case string:
+			parsedAttributes[name] = typedValue
+		case nil:
+			parsedAttributes[name] = ""
+		default:
+			encodedValue, _ := json.Marshal(typedValue)
+			parsedAttributes[name] = string(encodedValue)
+		}
+	}
+	return parsedAttributes
+}
+
+// getAPIEnvPropsOfEvent returns the environment specific properties of the API deployed with an event, keyed by the
+// gateway label.
+func getAPIEnvPropsOfEvent(event msg.APIEvent) map[string]apisync.APIEnvProps {
+	if len(event.EnvProperties) == 0 {
+		return nil
+	}
+	envProps := make(map[string]apisync.APIEnvProps, len(event.EnvProperties))
+	for envLabel, endpoints := range event.EnvProperties {
+		envProps[envLabel] = apisync.APIEnvProps{APIConfigs: apisync.APIConfigs{
+			ProductionEndpoint: endpoints.ProductionEndpoint,
+			SandBoxEndpoint:    endpoints.SandboxEndpoint,
+		}}
+	}
+	return envProps
 }
 
 func isDefaultVersionUpdate(event msg.APIEvent) bool {
 	return strings.EqualFold(apiUpdate, event.Event.Type) && strings.EqualFold("DEFAULT_VERSION", event.Action)
 }
 
+// handleAnalyticsConfigEvents applies the analytics configuration changes of the control plane to the enforcer
+func handleAnalyticsConfigEvents(data []byte) {
+	var analyticsEvent msg.AnalyticsConfigEvent
+	if err := decodeEvent(analyticsConfigUpdate, data, &analyticsEvent); err != nil {
+		logger.LoggerInternalMsg.ErrorC(logging.ErrorDetails{
+			Message:   fmt.Sprintf("Error occurred while unmarshalling analytics configuration event data %v", err),
+			Severity:  logging.MAJOR,
+			ErrorCode: 2015,
+		})
+		return
+	}
+	if !belongsToTenant(analyticsEvent.TenantDomain) {
+		logger.LoggerInternalMsg.Debugf("Analytics configuration event is dropped due to having non related "+
+			"tenantDomain : %s", analyticsEvent.TenantDomain)
+		return
+	}
+	if err := analytics.HandleConfigEvent(&analyticsEvent); err != nil {
+		logger.LoggerInternalMsg.ErrorC(logging.ErrorDetails{
+			Message:   fmt.Sprintf("Analytics configuration event %s is rejected. %v", analyticsEvent.EventID, err),
+			Severity:  logging.MAJOR,
+			ErrorCode: 2016,
+		})
+	}
+}
+
 func belongsToTenant(tenantDomain string) bool {
 	// TODO : enable this once the events are fixed in apim
 	// return config.GetControlPlaneConnectedTenantDomain() == tenantDomain
 	return true
 }
 
+// decodeEvent decodes an event of any schema version of the control plane to the target. The incompatibilities
+// with the schema known by the adapter are recorded in the metrics, hence the changes of the event schemas of the
+// control plane are noticed even when the events are decoded.
+func decodeEvent(eventType string, data []byte, target interface{}) error {
+	report, err := msg.DecodeEvent(data, target)
+	// the event type of the payload is not bounded, hence the events are counted by the decoded struct
+	metrics.ObserveEventDecode(reflect.TypeOf(target).Elem().Name(), getEventSchemaVersionLabel(report),
+		report.Incompatibilities)
+	if err != nil {
+		return err
+	}
+	if fields := report.Incompatibilities[msg.IncompatibilityUnknownField]; len(fields) > 0 {
+		logger.LoggerInternalMsg.Debugf("Unknown fields %v of the %s event (schema version %s) are ignored",
+			fields, eventType, report.SchemaVersion)
+	}
+	if _, found := report.Incompatibilities[msg.IncompatibilityUnsupportedVersion]; found {
+		logger.LoggerInternalMsg.Warnf("Schema version %s of the %s event is newer than the supported version %s. "+
+			"The known fields of the event are processed", report.SchemaVersion, eventType, msg.EventSchemaCurrent)
+	}
+	return nil
+}
+
+// getEventSchemaVersionLabel returns the schema version of the decoded event for the metrics, which is one of the
+// supported versions or unsupported.
+func getEventSchemaVersionLabel(report msg.EventDecodeReport) string {
+	if _, found := report.Incompatibilities[msg.IncompatibilityUnsupportedVersion]; found {
+		return "unsupported"
+	}
+	if report.SchemaVersion == msg.EventSchemaLegacy {
+		return msg.EventSchemaLegacy
+	}
+	return msg.EventSchemaCurrent
+}
+
 func parseNotificationJSONEvent(data []byte, notification *msg.EventNotification) error {
 	unmarshalErr := json.Unmarshal(data, &notification)
 	if unmarshalErr != nil {
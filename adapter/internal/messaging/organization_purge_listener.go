@@ -27,15 +27,16 @@ import (
 	msg "github.com/wso2/product-microgateway/adapter/pkg/messaging"
 )
 
-func handleAzureOrganizationPurge() {
-	for d := range msg.AzureOrganizationPurgeChannel {
-		logger.LoggerInternalMsg.Info("message received for OrganizationPurgeChannel = " + string(d))
+func handleOrganizationPurge(deliveries <-chan msg.Delivery) {
+	for d := range deliveries {
+		logger.LoggerInternalMsg.Info("message received for OrganizationPurgeChannel = " + string(d.Body()))
 		var event msg.EventOrganizationPurge
-		error := parseOrganizationPurgeJSONEvent(d, &event)
+		error := parseOrganizationPurgeJSONEvent(d.Body(), &event)
 
 		if error != nil {
 			logger.LoggerInternalMsg.Errorf("Error while processing "+
 				"the organization purge event %v. Hence dropping the event", error)
+			d.Nack()
 			continue
 		}
 
@@ -51,7 +52,7 @@ func handleAzureOrganizationPurge() {
 		synchronizer.ClearKeyManagerData()
 		// Pull Key Manager Data from APIM
 		synchronizer.FetchKeyManagersOnStartUp(conf)
-
+		d.Ack()
 	}
 }
 
@@ -29,36 +29,23 @@ import (
 	msg "github.com/wso2/product-microgateway/adapter/pkg/messaging"
 )
 
-func handleTokenRevocation() {
-	for d := range msg.RevokedTokenChannel {
+func handleTokenRevocation(deliveries <-chan msg.Delivery) {
+	for d := range deliveries {
 		var notification msg.EventTokenRevocationNotification
-		unmarshalErr := parseRevokedTokenJSONEvent([]byte(string(d.Body)), &notification)
+		unmarshalErr := parseRevokedTokenJSONEvent(d.Body(), &notification)
 		if unmarshalErr != nil {
+			d.Nack()
 			continue
 		}
 		logger.LoggerInternalMsg.Infof("Event %s is received", notification.Event.PayloadData.Type)
 		logger.LoggerInternalMsg.Debugf("RevokedToken: %s, Token Type: %s", stringutils.MaskToken(notification.Event.PayloadData.RevokedToken),
 			notification.Event.PayloadData.Type)
 		processTokenRevocationEvent(&notification)
-		d.Ack(false)
+		d.Ack()
 	}
 	logger.LoggerInternalMsg.Infof("handle: deliveries channel closed")
 }
 
-func handleAzureTokenRevocation() {
-	for d := range msg.AzureRevokedTokenChannel {
-		var notification msg.EventTokenRevocationNotification
-		error := parseRevokedTokenJSONEvent(d, &notification)
-		if error != nil {
-			continue
-		}
-		logger.LoggerInternalMsg.Infof("Event %s is received", notification.Event.PayloadData.Type)
-		logger.LoggerInternalMsg.Debugf("RevokedToken: %s, Token Type: %s", stringutils.MaskToken(notification.Event.PayloadData.RevokedToken),
-			notification.Event.PayloadData.Type)
-		processTokenRevocationEvent(&notification)
-	}
-}
-
 func processTokenRevocationEvent(notification *msg.EventTokenRevocationNotification) {
 	var revokedTokens []types.Resource
 	token := &keymgt.RevokedToken{}
@@ -0,0 +1,241 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package messaging
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/internal/synchronizer"
+	"github.com/wso2/product-microgateway/adapter/pkg/logging"
+	msg "github.com/wso2/product-microgateway/adapter/pkg/messaging"
+)
+
+// deliveryTracker counts the deliveries handed to the handlers, which are not acknowledged or rejected yet. Once
+// stopped, the deliveries are no longer handed to the handlers, and are left unacknowledged to be redelivered by
+// the broker (to another adapter) once the connection is closed.
+type deliveryTracker struct {
+	mutex    sync.Mutex
+	inFlight int
+	stopped  bool
+	stopping chan struct{}
+	drained  chan struct{}
+}
+
+// trackedDelivery is a delivery handed to a handler, which is complete once acknowledged or rejected.
+type trackedDelivery struct {
+	msg.Delivery
+	complete sync.Once
+	tracker  *deliveryTracker
+}
+
+var (
+	// consumedDeliveries tracks the deliveries of all the topics consumed from the active event source
+	consumedDeliveries = newDeliveryTracker()
+	// webhookServer is the server receiving the events, if the events are received from the webhook
+	webhookServer      *http.Server
+	webhookServerMutex sync.Mutex
+	// pendingDeploys tracks the APIs fetched from the control plane for the deploy events already acknowledged
+	pendingDeploys sync.WaitGroup
+)
+
+func newDeliveryTracker() *deliveryTracker {
+	return &deliveryTracker{stopping: make(chan struct{}), drained: make(chan struct{})}
+}
+
+// track hands the deliveries to the handlers until the tracker is stopped. The returned channel is closed once
+// the tracker is stopped, hence the handlers return once the deliveries in progress are processed.
+func (tracker *deliveryTracker) track(deliveries <-chan msg.Delivery) <-chan msg.Delivery {
+	trackedDeliveries := make(chan msg.Delivery)
+	go func() {
+		defer close(trackedDeliveries)
+		for {
+			select {
+			case <-tracker.stopping:
+				return
+			case d, ok := <-deliveries:
+				if !ok || !tracker.begin() {
+					return
+				}
+				select {
+				case trackedDeliveries <- &trackedDelivery{Delivery: d, tracker: tracker}:
+				case <-tracker.stopping:
+					tracker.end()
+					return
+				}
+			}
+		}
+	}()
+	return trackedDeliveries
+}
+
+// begin counts a delivery handed to a handler, and returns false if the tracker is stopped.
+func (tracker *deliveryTracker) begin() bool {
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+	if tracker.stopped {
+		return false
+	}
+	tracker.inFlight++
+	return true
+}
+
+// end completes a delivery, and signals the drain once the last delivery is completed after the tracker is stopped.
+func (tracker *deliveryTracker) end() {
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+	tracker.inFlight--
+	if tracker.stopped && tracker.inFlight == 0 {
+		close(tracker.drained)
+	}
+}
+
+// stop stops handing the deliveries to the handlers, and waits for the deliveries in progress until the deadline.
+// Returns the number of the deliveries, which are not completed by the deadline.
+func (tracker *deliveryTracker) stop(deadline time.Time) int {
+	tracker.mutex.Lock()
+	if !tracker.stopped {
+		tracker.stopped = true
+		close(tracker.stopping)
+		if tracker.inFlight == 0 {
+			close(tracker.drained)
+		}
+	}
+	tracker.mutex.Unlock()
+
+	select {
+	case <-tracker.drained:
+		return 0
+	case <-time.After(time.Until(deadline)):
+		tracker.mutex.Lock()
+		defer tracker.mutex.Unlock()
+		return tracker.inFlight
+	}
+}
+
+// Ack acknowledges the delivery. Only the first completion of the delivery reaches the broker, as the broker closes
+// the channel on an unknown delivery tag.
+func (delivery *trackedDelivery) Ack() error {
+	var err error
+	delivery.complete.Do(func() {
+		defer delivery.tracker.end()
+		err = delivery.Delivery.Ack()
+	})
+	return err
+}
+
+// Nack rejects the delivery, unless the delivery is already completed.
+func (delivery *trackedDelivery) Nack() error {
+	var err error
+	delivery.complete.Do(func() {
+		defer delivery.tracker.end()
+		err = delivery.Delivery.Nack()
+	})
+	return err
+}
+
+// fetchAPIsFromControlPlane fetches and deploys the APIs of a deploy event, which is acknowledged before the fetch
+// completes. The fetch is waited for by the drain, hence the pending changes are flushed once the API is deployed.
+func fetchAPIsFromControlPlane(apiUUID string, envs []string, eventTimeStamp int64) {
+	pendingDeploys.Add(1)
+	go func() {
+		defer pendingDeploys.Done()
+		synchronizer.FetchAPIsFromControlPlane(apiUUID, envs, eventTimeStamp)
+	}()
+}
+
+// waitForPendingDeploys waits until the deadline for the deploys of the APIs in progress, and returns whether the
+// deploys are completed by the deadline.
+func waitForPendingDeploys(deadline time.Time) bool {
+	completed := make(chan struct{})
+	go func() {
+		pendingDeploys.Wait()
+		close(completed)
+	}()
+	select {
+	case <-completed:
+		return true
+	case <-time.After(time.Until(deadline)):
+		logger.LoggerInternalMsg.ErrorC(logging.ErrorDetails{
+			Message:   "Deploys of the APIs in progress are not completed by the deadline",
+			Severity:  logging.MINOR,
+			ErrorCode: 2021,
+		})
+		return false
+	}
+}
+
+func setWebhookServer(server *http.Server) {
+	webhookServerMutex.Lock()
+	defer webhookServerMutex.Unlock()
+	webhookServer = server
+}
+
+// StopConsumingEvents stops receiving the events of the control plane, and waits until the deadline for the events
+// in progress to be processed and acknowledged (or rejected). The connection to the broker is closed afterwards,
+// hence the events delivered but not handed to the handlers are redelivered by the broker. Returns whether the
+// events in progress are processed by the deadline.
+func StopConsumingEvents(deadline time.Time) bool {
+	webhookServerMutex.Lock()
+	server := webhookServer
+	webhookServerMutex.Unlock()
+	if server != nil {
+		// the webhook stops accepting the events, and responds the events in progress once processed
+		ctx, cancel := context.WithDeadline(context.Background(), deadline)
+		defer cancel()
+		if err := server.Shutdown(ctx); err != nil {
+			logger.LoggerInternalMsg.ErrorC(logging.ErrorDetails{
+				Message:   fmt.Sprintf("Events in progress of the webhook are not processed by the deadline. %v", err),
+				Severity:  logging.MINOR,
+				ErrorCode: 2018,
+			})
+			return false
+		}
+		logger.LoggerInternalMsg.Info("Webhook stopped receiving the events of the control plane")
+		return waitForPendingDeploys(deadline)
+	}
+
+	remaining := consumedDeliveries.stop(deadline)
+	if remaining > 0 {
+		logger.LoggerInternalMsg.ErrorC(logging.ErrorDetails{
+			Message: fmt.Sprintf("%d events in progress are not processed by the deadline, hence the events are "+
+				"left to be redelivered by the broker", remaining),
+			Severity:  logging.MINOR,
+			ErrorCode: 2019,
+		})
+	} else {
+		logger.LoggerInternalMsg.Info("Events in progress are processed, and the consumption of the events is stopped")
+	}
+	activeEventSourceMutex.Lock()
+	eventSource := activeEventSource
+	activeEventSourceMutex.Unlock()
+	if eventSource != nil {
+		if err := eventSource.Close(); err != nil {
+			logger.LoggerInternalMsg.ErrorC(logging.ErrorDetails{
+				Message:   fmt.Sprintf("Error occurred while closing the connection to the message broker. %v", err),
+				Severity:  logging.MINOR,
+				ErrorCode: 2020,
+			})
+		}
+	}
+	return waitForPendingDeploys(deadline) && remaining == 0
+}
@@ -20,92 +20,103 @@ package messaging
 
 import (
 	"encoding/json"
+	"fmt"
 
 	"github.com/wso2/product-microgateway/adapter/internal/discovery/xds"
 	"github.com/wso2/product-microgateway/adapter/internal/synchronizer"
 	"github.com/wso2/product-microgateway/adapter/pkg/discovery/api/wso2/discovery/throttle"
 
 	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/pkg/logging"
 	msg "github.com/wso2/product-microgateway/adapter/pkg/messaging"
 )
 
 const (
 	blockIPRange        = "IPRANGE"
 	blockIP             = "IP"
+	blockSubscription   = "SUBSCRIPTION"
 	blockStateTrue      = "true"
 	templateStateAdd    = "add"
 	templateStateRemove = "remove"
 )
 
 // handleThrottleData handles Key template and Blocking condition in throttle data event.
-func handleThrottleData() {
-	for d := range msg.ThrottleDataChannel {
-		var data msg.EventThrottleData
-		var throttleData *throttle.ThrottleData
-		e := json.Unmarshal([]byte(string(d.Body)), &data)
-		if e != nil {
-			logger.LoggerInternalMsg.Errorf("Couldn't parse throttle data message. %v", e)
-			return
+func handleThrottleData(deliveries <-chan msg.Delivery) {
+	for d := range deliveries {
+		logger.LoggerInternalMsg.Debugf("Throttle Data: %s", string(d.Body()))
+		throttleData, err := processThrottleData(d.Body())
+		if err != nil {
+			// the event is acknowledged as it cannot be processed even if it is redelivered
+			logger.LoggerInternalMsg.ErrorC(logging.ErrorDetails{
+				Message:   fmt.Sprintf("Error occurred while processing throttle data event. %v", err.Error()),
+				Severity:  logging.MAJOR,
+				ErrorCode: 2005,
+			})
+		} else if throttleData != nil {
+			xds.UpdateEnforcerThrottleData(throttleData)
 		}
-		logger.LoggerInternalMsg.Debugf("Throttle Data: %s", string(d.Body))
+		d.Ack()
+	}
+	logger.LoggerInternalMsg.Infof("handle: deliveries channel closed")
+}
 
-		payload := data.Event.PayloadData
-		if payload.BlockingCondition != "" {
-			// control plane sends a blocking throttle data event for subscription blocking.
-			// this is not required and causes issues in evaluating subscription blocking.
-			if payload.BlockingCondition == "SUBSCRIPTION" {
-				return
-			}
-			isIPCondition := payload.BlockingCondition == blockIP || payload.BlockingCondition == blockIPRange
+// processThrottleData applies the key template or blocking condition in the throttle data event to the
+// synchronizer, and returns the throttle data to be pushed to the enforcer. Nil is returned if the event
+// does not change the throttle data of the enforcer.
+func processThrottleData(body []byte) (*throttle.ThrottleData, error) {
+	var data msg.EventThrottleData
+	if err := json.Unmarshal(body, &data); err != nil {
+		return nil, fmt.Errorf("couldn't parse throttle data message. %v", err)
+	}
 
-			if isIPCondition {
-				var ipCondition synchronizer.IPCondition
-				ipError := json.Unmarshal([]byte(payload.ConditionValue), &ipCondition)
-				if ipError != nil {
-					logger.LoggerInternalMsg.Errorf("Couldn't parse condition value as IPCondition. %v", ipError)
-					return
-				}
-				ip := &throttle.IPCondition{
-					TenantDomain: payload.TenantDomain,
-					Id:           payload.ID,
-					Type:         payload.BlockingCondition,
-					FixedIp:      ipCondition.FixedIP,
-					StartingIp:   ipCondition.StartingIP,
-					EndingIp:     ipCondition.EndingIP,
-					Invert:       ipCondition.Invert,
-				}
-				if payload.State == blockStateTrue {
-					synchronizer.AddBlockingIPCondition(ip)
-				} else {
-					synchronizer.RemoveBlockingIPCondition(ip)
-				}
-			} else {
-				if payload.State == blockStateTrue {
-					synchronizer.AddBlockingCondition(payload.ConditionValue)
-				} else {
-					synchronizer.RemoveBlockingCondition(payload.ConditionValue)
-				}
-			}
-			throttleData = &throttle.ThrottleData{
-				BlockingConditions:   synchronizer.GetBlockingConditions(),
-				IpBlockingConditions: synchronizer.GetBlockingIPConditions(),
+	payload := data.Event.PayloadData
+	if payload.BlockingCondition != "" {
+		// control plane sends a blocking throttle data event for subscription blocking.
+		// this is not required and causes issues in evaluating subscription blocking.
+		if payload.BlockingCondition == blockSubscription {
+			return nil, nil
+		}
+		isIPCondition := payload.BlockingCondition == blockIP || payload.BlockingCondition == blockIPRange
+
+		if isIPCondition {
+			var ipCondition synchronizer.IPCondition
+			if err := json.Unmarshal([]byte(payload.ConditionValue), &ipCondition); err != nil {
+				return nil, fmt.Errorf("couldn't parse condition value as IPCondition. %v", err)
 			}
-		} else if data.Event.PayloadData.KeyTemplateValue != "" {
-			if payload.KeyTemplateState == templateStateAdd {
-				synchronizer.AddKeyTemplate(payload.KeyTemplateValue)
-			} else if payload.KeyTemplateState == templateStateRemove {
-				synchronizer.RemoveKeyTemplate(payload.KeyTemplateValue)
+			ip := &throttle.IPCondition{
+				TenantDomain: payload.TenantDomain,
+				Id:           payload.ID,
+				Type:         payload.BlockingCondition,
+				FixedIp:      ipCondition.FixedIP,
+				StartingIp:   ipCondition.StartingIP,
+				EndingIp:     ipCondition.EndingIP,
+				Invert:       ipCondition.Invert,
 			}
-
-			throttleData = &throttle.ThrottleData{
-				KeyTemplates: synchronizer.GetKeyTemplates(),
+			if payload.State == blockStateTrue {
+				synchronizer.AddBlockingIPCondition(ip)
+			} else {
+				synchronizer.RemoveBlockingIPCondition(ip)
 			}
 		} else {
-			d.Ack(false)
-			continue
+			if payload.State == blockStateTrue {
+				synchronizer.AddBlockingCondition(payload.ConditionValue)
+			} else {
+				synchronizer.RemoveBlockingCondition(payload.ConditionValue)
+			}
+		}
+		return &throttle.ThrottleData{
+			BlockingConditions:   synchronizer.GetBlockingConditions(),
+			IpBlockingConditions: synchronizer.GetBlockingIPConditions(),
+		}, nil
+	} else if payload.KeyTemplateValue != "" {
+		if payload.KeyTemplateState == templateStateAdd {
+			synchronizer.AddKeyTemplate(payload.KeyTemplateValue)
+		} else if payload.KeyTemplateState == templateStateRemove {
+			synchronizer.RemoveKeyTemplate(payload.KeyTemplateValue)
 		}
-		xds.UpdateEnforcerThrottleData(throttleData)
-		d.Ack(false)
+		return &throttle.ThrottleData{
+			KeyTemplates: synchronizer.GetKeyTemplates(),
+		}, nil
 	}
-	logger.LoggerInternalMsg.Infof("handle: deliveries channel closed")
+	return nil, nil
 }
@@ -0,0 +1,222 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package messaging
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wso2/product-microgateway/adapter/config"
+	"github.com/wso2/product-microgateway/adapter/internal/audit"
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/pkg/health"
+	"github.com/wso2/product-microgateway/adapter/pkg/logging"
+	msg "github.com/wso2/product-microgateway/adapter/pkg/messaging"
+	"github.com/wso2/product-microgateway/adapter/pkg/tlsutils"
+)
+
+const (
+	webhookSignatureHeader string = "X-WSO2-Signature"
+	webhookTimestampHeader string = "X-WSO2-Timestamp"
+	webhookSignaturePrefix string = "sha256="
+	notificationEventPath  string = "/events/notification"
+	revokedTokenEventPath  string = "/events/tokenrevocation"
+)
+
+// StartWebhookReceiver receives the events of the control plane over HTTPS, where the message broker cannot be
+// reached. The events have the same payloads as the events published to the message broker, and are processed
+// by the same handlers. The events are authenticated with the shared secret, the client certificate, or both.
+// The events are processed one at a time, in the order they are received.
+func StartWebhookReceiver(conf *config.Config) {
+	// the event filter rules are validated before the events are received
+	getEventFilter(conf)
+	webhookConf := conf.ControlPlane.Webhook
+	if webhookConf.Secret == "" && !webhookConf.MutualTLS {
+		logger.LoggerInternalMsg.ErrorC(logging.ErrorDetails{
+			Message:   "Neither a secret nor mutual TLS is configured for the webhook, hence the events are not received.",
+			Severity:  logging.BLOCKER,
+			ErrorCode: 2006,
+		})
+		health.SetControlPlaneBrokerStatus(false)
+		return
+	}
+	publicKeyLocation, privateKeyLocation, truststoreLocation := tlsutils.GetKeyLocations()
+	cert, err := tlsutils.GetServerCertificate(publicKeyLocation, privateKeyLocation)
+	if err != nil {
+		logger.LoggerInternalMsg.ErrorC(logging.ErrorDetails{
+			Message:   fmt.Sprintf("Error while loading the certificate of the webhook. %v", err),
+			Severity:  logging.BLOCKER,
+			ErrorCode: 2007,
+		})
+		health.SetControlPlaneBrokerStatus(false)
+		return
+	}
+	tlsConfig := &tls.Config{Certificates: []tls.Certificate{cert}}
+	if webhookConf.MutualTLS {
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+		tlsConfig.ClientCAs = tlsutils.GetTrustedCertPool(truststoreLocation)
+	}
+	server := &http.Server{
+		Addr: fmt.Sprintf("%s:%d", webhookConf.Host, webhookConf.Port),
+		Handler: newWebhookHandler(conf, webhookConf.Secret, webhookConf.MaxEventSizeInBytes,
+			time.Duration(webhookConf.MaxEventAgeInSeconds)*time.Second),
+		TLSConfig: tlsConfig,
+	}
+	listener, err := tls.Listen("tcp", server.Addr, tlsConfig)
+	if err != nil {
+		logger.LoggerInternalMsg.ErrorC(logging.ErrorDetails{
+			Message:   fmt.Sprintf("Error while listening for the events of the control plane on %s. %v", server.Addr, err),
+			Severity:  logging.BLOCKER,
+			ErrorCode: 2023,
+		})
+		health.SetControlPlaneBrokerStatus(false)
+		return
+	}
+	setWebhookServer(server)
+	// events are considered as received from the control plane, once the webhook is listening
+	health.SetControlPlaneBrokerStatus(true)
+	logger.LoggerInternalMsg.Infof("Webhook is listening for the events of the control plane on %s", server.Addr)
+	// the server is closed by StopConsumingEvents when the adapter is stopped
+	if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
+		logger.LoggerInternalMsg.ErrorC(logging.ErrorDetails{
+			Message:   fmt.Sprintf("Webhook stopped receiving the events of the control plane. %v", err),
+			Severity:  logging.BLOCKER,
+			ErrorCode: 2008,
+		})
+	}
+}
+
+// newWebhookHandler returns the handler of the events. The signature and the timestamp of an event are verified if
+// the secret is not empty, and a signed event is accepted once within the maximum age of the events.
+func newWebhookHandler(conf *config.Config, secret string, maxEventSize int64, maxEventAge time.Duration) http.Handler {
+	mux := http.NewServeMux()
+	// the events are processed one at a time, hence an event is processed after the events received earlier
+	var processMutex sync.Mutex
+	// MAC of the signature (lower case hex) -> time the event expires, of the events processed within the maximum age
+	processedSignatures := make(map[string]time.Time)
+	handleEvent := func(path string, process func(body []byte) error) {
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+			body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxEventSize))
+			if err != nil {
+				http.Error(w, "Event exceeds the maximum size", http.StatusRequestEntityTooLarge)
+				return
+			}
+			signature, timestamp := r.Header.Get(webhookSignatureHeader), r.Header.Get(webhookTimestampHeader)
+			eventMAC, validSignature := isValidWebhookSignature(timestamp, body, signature, secret)
+			if secret != "" && !validSignature {
+				logger.LoggerInternalMsg.Warnf("Event received to the webhook from %s is dropped, as the "+
+					"signature is invalid", r.RemoteAddr)
+				http.Error(w, "Invalid signature", http.StatusUnauthorized)
+				return
+			}
+			if secret != "" && !isRecentWebhookEvent(timestamp, maxEventAge) {
+				logger.LoggerInternalMsg.Warnf("Event received to the webhook from %s is dropped, as the "+
+					"timestamp %s is not within %v", r.RemoteAddr, timestamp, maxEventAge)
+				http.Error(w, "Expired event", http.StatusUnauthorized)
+				return
+			}
+			processMutex.Lock()
+			defer processMutex.Unlock()
+			if secret != "" {
+				now := time.Now()
+				for processedMAC, expiry := range processedSignatures {
+					if now.After(expiry) {
+						delete(processedSignatures, processedMAC)
+					}
+				}
+				// the MAC is compared instead of the signature header, as the hex encoded signature is case
+				// insensitive
+				if _, found := processedSignatures[eventMAC]; found {
+					logger.LoggerInternalMsg.Warnf("Event received to the webhook from %s is dropped, as it is "+
+						"already received", r.RemoteAddr)
+					http.Error(w, "Replayed event", http.StatusConflict)
+					return
+				}
+			}
+			if err := process(body); err != nil {
+				http.Error(w, "Invalid event", http.StatusBadRequest)
+				return
+			}
+			if secret != "" {
+				processedSignatures[eventMAC] = time.Now().Add(2 * maxEventAge)
+			}
+			w.WriteHeader(http.StatusAccepted)
+		})
+	}
+	handleEvent(notificationEventPath, func(body []byte) error {
+		var notification msg.EventNotification
+		if err := parseNotificationJSONEvent(body, &notification); err != nil {
+			return err
+		}
+		logger.LoggerInternalMsg.Infof("Event %s is received from the webhook", notification.Event.PayloadData.EventType)
+		return processNotificationEvent(conf, &notification, audit.EventSourceNotification)
+	})
+	handleEvent(revokedTokenEventPath, func(body []byte) error {
+		var notification msg.EventTokenRevocationNotification
+		if err := parseRevokedTokenJSONEvent(body, &notification); err != nil {
+			return err
+		}
+		logger.LoggerInternalMsg.Infof("Event %s is received from the webhook", notification.Event.PayloadData.Type)
+		processTokenRevocationEvent(&notification)
+		return nil
+	})
+	return mux
+}
+
+// isValidWebhookSignature checks the signature, which is the hex encoded HMAC-SHA256 of the timestamp and the body
+// joined by a dot, and returns the MAC of the signature in lower case hex, which identifies the event.
+func isValidWebhookSignature(timestamp string, body []byte, signature, secret string) (string, bool) {
+	if !strings.HasPrefix(signature, webhookSignaturePrefix) {
+		return "", false
+	}
+	receivedMAC, err := hex.DecodeString(strings.TrimPrefix(signature, webhookSignaturePrefix))
+	if err != nil {
+		return "", false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	if !hmac.Equal(receivedMAC, mac.Sum(nil)) {
+		return "", false
+	}
+	return hex.EncodeToString(receivedMAC), true
+}
+
+// isRecentWebhookEvent checks whether the timestamp (epoch seconds) of an event is within the maximum age of the
+// events, in either direction to tolerate the clock skew of the sender.
+func isRecentWebhookEvent(timestamp string, maxEventAge time.Duration) bool {
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return false
+	}
+	age := time.Since(time.Unix(seconds, 0))
+	return age <= maxEventAge && age >= -maxEventAge
+}
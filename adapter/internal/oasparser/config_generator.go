@@ -34,6 +34,8 @@ import (
 	"github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
 	mgw "github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
 	"github.com/wso2/product-microgateway/adapter/pkg/discovery/api/wso2/discovery/api"
+	"github.com/wso2/product-microgateway/adapter/pkg/logging"
+	"github.com/wso2/product-microgateway/adapter/pkg/secrets"
 )
 
 // GetRoutesClustersEndpoints generates the routes, clusters and endpoints (envoy)
@@ -68,6 +70,16 @@ func GetGlobalClusters() ([]*clusterv3.Cluster, []*corev3.Address) {
 		}
 	}
 
+	if conf.Envoy.GlobalRateLimit.Enabled {
+		logger.LoggerOasparser.Debugln("Creating global cluster - Rate limit service")
+		if c, e, err := envoyconf.CreateRateLimitCluster(conf); err == nil {
+			clusters = append(clusters, c)
+			endpoints = append(endpoints, e...)
+		} else {
+			logger.LoggerOasparser.Error("Failed to initialize the cluster of the rate limit service. ", err)
+		}
+	}
+
 	logger.LoggerOasparser.Debug("Creating global cluster - Aws Lambda")
 	if c, e, err := envoyconf.CreateAwsLambdaCluster(conf); err == nil {
 		clusters = append(clusters, c)
@@ -118,6 +130,19 @@ func GetCacheResources(endpoints []*corev3.Address, clusters []*clusterv3.Cluste
 	return listenerRes, clusterRes, routeConfigRes, endpointRes
 }
 
+// UpdateListenersForVhosts updates the listeners according to the vhosts which have routes. (ie: SNI based
+// filter chains are added to the secured listener for the vhosts and their custom domains having certificates
+// in the SNI certificate pool)
+func UpdateListenersForVhosts(listeners []*listenerv3.Listener, vhostToRouteArrayMap map[string][]*routev3.Route,
+	customDomains map[string][]string) {
+	vhosts := make([]string, 0, len(vhostToRouteArrayMap))
+	for vhost := range vhostToRouteArrayMap {
+		vhosts = append(vhosts, vhost)
+		vhosts = append(vhosts, customDomains[vhost]...)
+	}
+	envoy.UpdateSNIFilterChains(listeners, vhosts)
+}
+
 // UpdateRoutesConfig updates the existing routes configuration with the provided map of vhost to array of routes.
 // All the already existing routes (within the routeConfiguration) will be removed.
 func UpdateRoutesConfig(routeConfig *routev3.RouteConfiguration, vhostToRouteArrayMap map[string][]*routev3.Route) {
@@ -176,26 +201,15 @@ func GetEnforcerAPI(mgwSwagger model.MgwSwagger, vhost string) *api.Api {
 		}
 		resources = append(resources, resource)
 	}
+	resources = append(resources, getAPIDocsResources(mgwSwagger)...)
 
 	endpointSecurityDetails := &api.EndpointSecurity{}
 
 	if mgwSwagger.GetProdEndpoints() != nil {
-		endpointSecurityDetails.ProductionSecurityInfo = &api.SecurityInfo{
-			Username:         mgwSwagger.GetProdEndpoints().SecurityConfig.Username,
-			Password:         mgwSwagger.GetProdEndpoints().SecurityConfig.Password,
-			SecurityType:     mgwSwagger.GetProdEndpoints().SecurityConfig.Type,
-			Enabled:          mgwSwagger.GetProdEndpoints().SecurityConfig.Enabled,
-			CustomParameters: mgwSwagger.GetProdEndpoints().SecurityConfig.CustomParameters,
-		}
+		endpointSecurityDetails.ProductionSecurityInfo = getSecurityInfo(mgwSwagger.GetProdEndpoints().SecurityConfig)
 	}
 	if mgwSwagger.GetSandEndpoints() != nil {
-		endpointSecurityDetails.SandBoxSecurityInfo = &api.SecurityInfo{
-			Username:         mgwSwagger.GetSandEndpoints().SecurityConfig.Username,
-			Password:         mgwSwagger.GetSandEndpoints().SecurityConfig.Password,
-			SecurityType:     mgwSwagger.GetSandEndpoints().SecurityConfig.Type,
-			Enabled:          mgwSwagger.GetSandEndpoints().SecurityConfig.Enabled,
-			CustomParameters: mgwSwagger.GetSandEndpoints().SecurityConfig.CustomParameters,
-		}
+		endpointSecurityDetails.SandBoxSecurityInfo = getSecurityInfo(mgwSwagger.GetSandEndpoints().SecurityConfig)
 	}
 
 	for _, cert := range mgwSwagger.GetClientCerts() {
@@ -207,6 +221,16 @@ func GetEnforcerAPI(mgwSwagger model.MgwSwagger, vhost string) *api.Api {
 		clientCertificates = append(clientCertificates, certificate)
 	}
 
+	var maxConcurrentRequestsPerApplication uint32
+	if concurrencyLimits := mgwSwagger.GetConcurrencyLimits(); concurrencyLimits != nil {
+		maxConcurrentRequestsPerApplication = concurrencyLimits.MaxConcurrentRequestsPerApplication
+	}
+
+	backendJWT := mgwSwagger.GetBackendJWT()
+	if backendJWT == nil {
+		backendJWT = &model.BackendJWT{}
+	}
+
 	return &api.Api{
 		Id:                    mgwSwagger.GetID(),
 		Title:                 mgwSwagger.GetTitle(),
@@ -233,7 +257,39 @@ func GetEnforcerAPI(mgwSwagger model.MgwSwagger, vhost string) *api.Api {
 		GraphQLSchema:         mgwSwagger.GraphQLSchema,
 		GraphqlComplexityInfo: mgwSwagger.GraphQLComplexities.Data.List,
 		EndpointType:          mgwSwagger.GetEndpointType(),
+		AllowedIssuers:        mgwSwagger.GetAllowedIssuers(),
+		AllowedAudiences:      mgwSwagger.GetAllowedAudiences(),
+
+		MaxConcurrentRequestsPerApplication: maxConcurrentRequestsPerApplication,
+		BackendJwtEnabled:                   backendJWT.Enabled,
+		BackendJwtHeader:                    backendJWT.Header,
+		BackendJwtSigningAlgorithm:          backendJWT.SigningAlgorithm,
+		BackendJwtEnableUserClaims:          backendJWT.EnableUserClaims,
+		BackendJwtApplicationAttributes:     backendJWT.ApplicationAttributes,
+	}
+}
+
+// getAPIDocsResources returns the resources of the API docs paths, which are authenticated by the enforcer
+// if the API docs are protected.
+func getAPIDocsResources(mgwSwagger model.MgwSwagger) []*api.Resource {
+	conf, _ := config.ReadConfigs()
+	if !conf.Envoy.APIDocs.Enabled || !conf.Envoy.APIDocs.Protected || len(mgwSwagger.GetAPIDocs()) == 0 {
+		return nil
 	}
+	resources := make([]*api.Resource, 0, 2)
+	for _, path := range []string{constants.APIDocsPath, constants.APIDocPathTemplate} {
+		resources = append(resources, &api.Resource{
+			Id:   path,
+			Path: path,
+			Methods: []*api.Operation{
+				{
+					Method:   "GET",
+					Policies: &api.OperationPolicies{},
+				},
+			},
+		})
+	}
+	return resources
 }
 
 // GetEnforcerAPIOperation builds the operation object expected by the proto definition
@@ -289,6 +345,11 @@ func castPoliciesToEnforcerPolicies(policies []model.Policy) []*api.Policy {
 						parameterMap[paramK] = paramV
 					} else if paramV, parsed := params[paramK].(bool); parsed {
 						parameterMap[paramK] = strconv.FormatBool(paramV)
+					} else if paramV, parsed := params[paramK].(int); parsed {
+						// ex: maxOpenConnections of the OPA policy
+						parameterMap[paramK] = strconv.Itoa(paramV)
+					} else if paramV, parsed := params[paramK].(float64); parsed {
+						parameterMap[paramK] = strconv.FormatFloat(paramV, 'f', -1, 64)
 					}
 				}
 
@@ -345,3 +406,37 @@ func generateRPCEndpointCluster(inputEndpointCluster *mgw.EndpointCluster) *api.
 	}
 	return endpoints
 }
+
+// getSecurityInfo converts the endpoint security of an API to the enforcer API resource. The $secret{} references of
+// the credentials are resolved here, hence the API artifacts keep the references and the rotated secrets are applied
+// once the enforcer API is generated again.
+func getSecurityInfo(securityConfig model.EndpointSecurity) *api.SecurityInfo {
+	securityInfo := &api.SecurityInfo{
+		Username:     resolveSecret(securityConfig.Username),
+		Password:     resolveSecret(securityConfig.Password),
+		SecurityType: securityConfig.Type,
+		Enabled:      securityConfig.Enabled,
+	}
+	if securityConfig.CustomParameters != nil {
+		securityInfo.CustomParameters = make(map[string]string, len(securityConfig.CustomParameters))
+		for key, value := range securityConfig.CustomParameters {
+			securityInfo.CustomParameters[key] = resolveSecret(value)
+		}
+	}
+	return securityInfo
+}
+
+// resolveSecret resolves the $secret{} references of a credential, which are within the secret paths allowed for the
+// API artifacts.
+func resolveSecret(value string) string {
+	conf, _ := config.ReadConfigs()
+	resolved, err := secrets.ResolveWithin(value, conf.Adapter.Secrets.ArtifactSecretPaths)
+	if err != nil {
+		logger.LoggerOasparser.ErrorC(logging.ErrorDetails{
+			Message:   fmt.Sprintf("Error resolving the secrets of the endpoint security. %v", err),
+			Severity:  logging.MAJOR,
+			ErrorCode: 2266,
+		})
+	}
+	return resolved
+}
@@ -23,6 +23,8 @@ const (
 	Type                  string = "type"
 	LoadBalance           string = "load_balance"
 	FailOver              string = "failover"
+	RoundRobin            string = "round_robin"
+	LeastRequest          string = "least_request"
 	AdvanceEndpointConfig string = "advanceEndpointConfig"
 	SecurityConfig        string = "securityConfig"
 )
@@ -48,6 +50,58 @@ const (
 	XScopes                           string = "x-scopes"
 	XWso2PassRequestPayloadToEnforcer string = "x-wso2-pass-request-payload-to-enforcer"
 	XUriMapping                       string = "x-uri-mapping"
+	XWso2Streaming                    string = "x-wso2-streaming"
+	XWso2DisableGlobalPolicies        string = "x-wso2-disable-global-policies"
+	XWso2Deprecation                  string = "x-wso2-deprecation"
+	XWso2AllowedIssuers               string = "x-wso2-allowed-issuers"
+	XWso2AllowedAudiences             string = "x-wso2-allowed-audiences"
+	XWso2MutualSSL                    string = "x-wso2-mutual-ssl"
+	XWso2RateLimitHeaders             string = "x-wso2-rate-limit-headers"
+	XWso2ConcurrencyLimits            string = "x-wso2-concurrency-limits"
+	XWso2PayloadLimits                string = "x-wso2-payload-limits"
+	XWso2ResponseCompression          string = "x-wso2-response-compression"
+	XWso2TrafficMirror                string = "x-wso2-traffic-mirror"
+	XWso2BackendJWT                   string = "x-wso2-backend-jwt"
+	XWso2Timeouts                     string = "x-wso2-timeouts"
+	XWso2RetryBudget                  string = "x-wso2-retry-budget"
+	XWso2IPRestriction                string = "x-wso2-ip-restriction"
+	XWso2SubscriptionValidation       string = "x-wso2-subscription-validation"
+	XWso2GRPCTranscoding              string = "x-wso2-grpc-transcoding"
+	XWso2GRPCMethod                   string = "x-wso2-grpc-method"
+	XWso2ResponseCache                string = "x-wso2-response-cache"
+)
+
+// formats of the rate limit headers
+const (
+	RateLimitHeadersFormat  string = "rateLimit"
+	XRateLimitHeadersFormat string = "xRateLimit"
+	// RateLimitHeadersDisabled represents an API which opts out of the rate limit headers
+	RateLimitHeadersDisabled string = "none"
+)
+
+// API docs and advisories paths, relative to the API basepath
+const (
+	APIDocsPath        string = "/_docs"
+	APIDocPathTemplate string = "/_docs/{docName}"
+	APIAdvisoriesPath  string = "/_advisories"
+)
+
+// WebSub hub resources, relative to the API basepath
+const (
+	// WebSubHubResource accepts the subscribe and unsubscribe requests of the subscribers
+	WebSubHubResource string = "/"
+	// WebSubEventsReceiverResource accepts the events published to the topics of the API
+	WebSubEventsReceiverResource string = "/webhooks_events_receiver_resource"
+	WebSubHubTopicQueryParam     string = "hub.topic"
+	WebSubTopicQueryParam        string = "topic"
+)
+
+// SOAP passthrough resources and versions
+const (
+	// SOAPPassthroughResource accepts the SOAP requests of any path, relative to the API basepath
+	SOAPPassthroughResource string = "/*"
+	SOAP11Version           string = "1.1"
+	SOAP12Version           string = "1.2"
 )
 
 // cluster name prefixes
@@ -55,6 +109,7 @@ const (
 	SandClustersConfigNamePrefix    string = "clusterSand"
 	ProdClustersConfigNamePrefix    string = "clusterProd"
 	XWso2EPClustersConfigNamePrefix string = "xwso2cluster"
+	MirrorClustersConfigNamePrefix  string = "clusterMirror"
 )
 
 // sub-property values and keys relevant for x-wso2-application security extension
@@ -94,9 +149,14 @@ const (
 const (
 	ActionHeaderAdd          string = "SET_HEADER"
 	ActionHeaderRemove       string = "REMOVE_HEADER"
+	ActionHeaderAppend       string = "ADD_HEADER"
+	ActionHeaderRename       string = "RENAME_HEADER"
 	ActionRewriteMethod      string = "REWRITE_RESOURCE_METHOD"
 	ActionInterceptorService string = "CALL_INTERCEPTOR_SERVICE"
 	ActionRewritePath        string = "REWRITE_RESOURCE_PATH"
+	ActionRewriteURL         string = "REWRITE_RESOURCE_URL"
+	ActionOverrideMethod     string = "OVERRIDE_RESOURCE_METHOD"
+	ActionOPA                string = "OPA"
 
 	RewritePathResourcePath    string = "resourcePath"
 	InterceptorServiceURL      string = "interceptorServiceURL"
@@ -104,8 +164,11 @@ const (
 	IncludeQueryParams         string = "includeQueryParams"
 	HeaderName                 string = "headerName"
 	HeaderValue                string = "headerValue"
+	NewHeaderName              string = "newHeaderName"
 	CurrentMethod              string = "currentMethod"
 	UpdatedMethod              string = "updatedMethod"
+	RewriteURLPattern          string = "pattern"
+	RewriteURLSubstitution     string = "substitution"
 )
 
 // Constants that occur as values in api.yaml
@@ -115,12 +178,21 @@ const (
 	WS                    string = "WS"
 	GRAPHQL               string = "GRAPHQL"
 	WEBHOOK               string = "WEBHOOK"
+	WEBSUB                string = "WEBSUB"
 	SSE                   string = "SSE"
 	Prototyped            string = "prototyped"
 	MockedOASEndpointType string = "MOCKED_OAS"
 	AwsLambda             string = "awslambda"
 	TemplateEndpointType  string = "TEMPLATE"
 	InlineEndpointType    string = "INLINE"
+	// DeprecatedLifecycleStatus is the lifecycle status of the API versions deprecated from the control plane
+	DeprecatedLifecycleStatus string = "DEPRECATED"
+	// BlockedLifecycleStatus is the lifecycle status of the APIs blocked from the control plane, which are
+	// responded with 503 by the router
+	BlockedLifecycleStatus string = "BLOCKED"
+	// RetiredLifecycleStatus is the lifecycle status of the APIs retired from the control plane, which are
+	// undeployed from the gateway
+	RetiredLifecycleStatus string = "RETIRED"
 )
 
 // Constants used for version identification of API definitions
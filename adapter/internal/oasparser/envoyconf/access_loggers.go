@@ -93,7 +93,9 @@ func getFileAccessLogConfigs() *config_access_logv3.AccessLog {
 
 // getAccessLogConfigs provides grpc access log configurations for envoy
 func getGRPCAccessLogConfigs(conf *config.Config) *config_access_logv3.AccessLog {
-	grpcAccessLogsEnabled := conf.Analytics.Enabled || conf.Enforcer.Metrics.Enabled
+	// The enforcer releases the in-flight requests counted for the concurrency limits upon the access log entries.
+	grpcAccessLogsEnabled := conf.Analytics.Enabled || conf.Enforcer.Metrics.Enabled ||
+		conf.Enforcer.Throttling.ConcurrencyLimits.Enabled
 	if !grpcAccessLogsEnabled {
 		logger.LoggerOasparser.Debug("gRPC access logs are not enabled as analytics is disabled.")
 		return nil
@@ -151,6 +153,66 @@ func getGRPCAccessLogConfigs(conf *config.Config) *config_access_logv3.AccessLog
 	return &accessLog
 }
 
+// getBotDetectionAccessLogConfigs provides the grpc access log configurations streaming the authentication failures
+// and the throttled out requests to the adapter, which tracks these for bot detection.
+func getBotDetectionAccessLogConfigs(conf *config.Config) *config_access_logv3.AccessLog {
+	if !conf.Adapter.BotDetection.Enabled {
+		return nil
+	}
+	accessLogConf := &grpc_accesslogv3.HttpGrpcAccessLogConfig{
+		CommonConfig: &grpc_accesslogv3.CommonGrpcAccessLogConfig{
+			TransportApiVersion: corev3.ApiVersion_V3,
+			LogName:             botDetectionAccessLogLogName,
+			GrpcService: &corev3.GrpcService{
+				TargetSpecifier: &corev3.GrpcService_EnvoyGrpc_{
+					EnvoyGrpc: &corev3.GrpcService_EnvoyGrpc{
+						ClusterName: adapterClusterName,
+					},
+				},
+			},
+		},
+	}
+	accessLogTypedConf, err := anypb.New(accessLogConf)
+	if err != nil {
+		logger.LoggerOasparser.ErrorC(logging.ErrorDetails{
+			Message:   fmt.Sprintf("Error marshalling bot detection access log configs. %v", err.Error()),
+			Severity:  logging.CRITICAL,
+			ErrorCode: 2260,
+		})
+		return nil
+	}
+
+	var statusCodeFilters []*config_access_logv3.AccessLogFilter
+	for _, statusCode := range []uint32{401, 403, 429} {
+		statusCodeFilters = append(statusCodeFilters, &config_access_logv3.AccessLogFilter{
+			FilterSpecifier: &config_access_logv3.AccessLogFilter_StatusCodeFilter{
+				StatusCodeFilter: &config_access_logv3.StatusCodeFilter{
+					Comparison: &config_access_logv3.ComparisonFilter{
+						Op: config_access_logv3.ComparisonFilter_EQ,
+						Value: &corev3.RuntimeUInt32{
+							DefaultValue: statusCode,
+							RuntimeKey:   fmt.Sprintf("bot_detection.status_code_%d", statusCode),
+						},
+					},
+				},
+			},
+		})
+	}
+	return &config_access_logv3.AccessLog{
+		Name: grpcAccessLogName,
+		Filter: &config_access_logv3.AccessLogFilter{
+			FilterSpecifier: &config_access_logv3.AccessLogFilter_OrFilter{
+				OrFilter: &config_access_logv3.OrFilter{
+					Filters: statusCodeFilters,
+				},
+			},
+		},
+		ConfigType: &config_access_logv3.AccessLog_TypedConfig{
+			TypedConfig: accessLogTypedConf,
+		},
+	}
+}
+
 // getAccessLogs provides access logs for envoy
 func getAccessLogs() []*config_access_logv3.AccessLog {
 	conf, _ := config.ReadConfigs()
@@ -163,5 +225,8 @@ func getAccessLogs() []*config_access_logv3.AccessLog {
 	if grpcAccessLog != nil {
 		accessLoggers = append(accessLoggers, getGRPCAccessLogConfigs(conf))
 	}
+	if botDetectionAccessLog := getBotDetectionAccessLogConfigs(conf); botDetectionAccessLog != nil {
+		accessLoggers = append(accessLoggers, botDetectionAccessLog)
+	}
 	return accessLoggers
 }
@@ -0,0 +1,111 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package envoyconf
+
+import (
+	"strconv"
+	"strings"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	extAuthService "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ext_authz/v3"
+	envoy_type_matcherv3 "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/constants"
+)
+
+const (
+	warningHeader string = "Warning"
+	// miscellaneous persistent warning (RFC 7234)
+	advisoryWarnCode string = "299"
+)
+
+// AddAdvisoryHeaders returns copies of the routes of an API, which add a Warning header per each advisory
+// message to the responses. The routes of the API are not modified, as those are reused when the advisories
+// are changed.
+func AddAdvisoryHeaders(routes []*routev3.Route, messages []string) []*routev3.Route {
+	if len(messages) == 0 {
+		return routes
+	}
+	headers := make([]*corev3.HeaderValueOption, 0, len(messages))
+	for _, message := range messages {
+		headers = append(headers, generateHeaderValueOption(warningHeader,
+			advisoryWarnCode+" - "+strconv.Quote(message), corev3.HeaderValueOption_APPEND_IF_EXISTS_OR_ADD))
+	}
+	advisedRoutes := make([]*routev3.Route, 0, len(routes))
+	for _, route := range routes {
+		advisedRoute := proto.Clone(route).(*routev3.Route)
+		advisedRoute.ResponseHeadersToAdd = append(advisedRoute.ResponseHeadersToAdd, headers...)
+		advisedRoutes = append(advisedRoutes, advisedRoute)
+	}
+	return advisedRoutes
+}
+
+// CreateAdvisoriesRoute creates the route serving the advisories of an API (as json) in the well-known path
+// relative to the basepath of the API. The advisories are public, hence the requests are not authenticated.
+func CreateAdvisoriesRoute(basePath string, advisories []byte) *routev3.Route {
+	path := strings.TrimSuffix(basePath, "/") + constants.APIAdvisoriesPath
+	perFilterConfig := extAuthService.ExtAuthzPerRoute{
+		Override: &extAuthService.ExtAuthzPerRoute_Disabled{
+			Disabled: true,
+		},
+	}
+	return &routev3.Route{
+		Name: path,
+		Match: &routev3.RouteMatch{
+			PathSpecifier: &routev3.RouteMatch_Path{
+				Path: path,
+			},
+			Headers: []*routev3.HeaderMatcher{
+				{
+					Name: ":method",
+					HeaderMatchSpecifier: &routev3.HeaderMatcher_StringMatch{
+						StringMatch: &envoy_type_matcherv3.StringMatcher{
+							MatchPattern: &envoy_type_matcherv3.StringMatcher_Exact{
+								Exact: "GET",
+							},
+						},
+					},
+				},
+			},
+		},
+		Action: &routev3.Route_DirectResponse{
+			DirectResponse: &routev3.DirectResponseAction{
+				Status: 200,
+				Body: &corev3.DataSource{
+					Specifier: &corev3.DataSource_InlineBytes{
+						InlineBytes: advisories,
+					},
+				},
+			},
+		},
+		ResponseHeadersToAdd: []*corev3.HeaderValueOption{
+			generateHeaderValueOption("content-type", "application/json",
+				corev3.HeaderValueOption_OVERWRITE_IF_EXISTS_OR_ADD),
+		},
+		Decorator: &routev3.Decorator{
+			Operation: path,
+		},
+		TypedPerFilterConfig: map[string]*anypb.Any{
+			wellknown.HTTPExternalAuthorization: marshalFilterConfig(&perFilterConfig),
+		},
+	}
+}
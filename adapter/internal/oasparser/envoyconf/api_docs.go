@@ -0,0 +1,176 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package envoyconf
+
+import (
+	"encoding/json"
+	"mime"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	extAuthService "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ext_authz/v3"
+	envoy_type_matcherv3 "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/golang/protobuf/ptypes/any"
+
+	"github.com/wso2/product-microgateway/adapter/config"
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/constants"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
+)
+
+// content types of the common doc formats, which are not known to the mime package
+var docContentTypes = map[string]string{
+	".md":   "text/markdown",
+	".yaml": "application/yaml",
+	".yml":  "application/yaml",
+	".json": "application/json",
+	".txt":  "text/plain",
+}
+
+// createAPIDocsRoutes creates a route per each doc of the API and a route to list the docs. The docs are
+// served by the router itself as direct responses. If the docs are protected, the requests are authenticated
+// by the enforcer as the requests to the API resources.
+func createAPIDocsRoutes(mgwSwagger *model.MgwSwagger, vHost string) []*routev3.Route {
+	conf, _ := config.ReadConfigs()
+	basePath := strings.TrimSuffix(mgwSwagger.GetXWso2Basepath(), "/")
+	if !conf.Envoy.APIDocs.Enabled || len(mgwSwagger.GetAPIDocs()) == 0 || basePath == "" {
+		return nil
+	}
+
+	docNames := make([]string, 0, len(mgwSwagger.GetAPIDocs()))
+	for docName, content := range mgwSwagger.GetAPIDocs() {
+		if uint32(len(content)) > conf.Envoy.APIDocs.MaxSizeInBytes {
+			logger.LoggerOasparser.Warnf("The doc %s of the API %s:%s is not served as it exceeds the max size %d bytes.",
+				docName, mgwSwagger.GetTitle(), mgwSwagger.GetVersion(), conf.Envoy.APIDocs.MaxSizeInBytes)
+			continue
+		}
+		docNames = append(docNames, docName)
+	}
+	sort.Strings(docNames)
+	docList, _ := json.Marshal(docNames)
+
+	routes := []*routev3.Route{
+		createAPIDocRoute(mgwSwagger, vHost, basePath+constants.APIDocsPath, constants.APIDocsPath,
+			"application/json", docList),
+	}
+	for _, docName := range docNames {
+		routes = append(routes, createAPIDocRoute(mgwSwagger, vHost,
+			basePath+constants.APIDocsPath+"/"+escapeDocPath(docName), constants.APIDocPathTemplate,
+			getDocContentType(docName), mgwSwagger.GetAPIDocs()[docName]))
+	}
+	return routes
+}
+
+// escapeDocPath escapes each segment of the doc path (<doc name>/<doc file name>), as the exact path of the route
+// is matched with the encoded path of the request (ie: doc names with spaces).
+func escapeDocPath(docPath string) string {
+	segments := strings.Split(docPath, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+func createAPIDocRoute(mgwSwagger *model.MgwSwagger, vHost, path, resourcePath, contentType string,
+	content []byte) *routev3.Route {
+	conf, _ := config.ReadConfigs()
+	var perFilterConfig extAuthService.ExtAuthzPerRoute
+	if conf.Envoy.APIDocs.Protected {
+		perFilterConfig = extAuthService.ExtAuthzPerRoute{
+			Override: &extAuthService.ExtAuthzPerRoute_CheckSettings{
+				CheckSettings: &extAuthService.CheckSettings{
+					ContextExtensions: map[string]string{
+						pathContextExtension:       resourcePath,
+						vHostContextExtension:      vHost,
+						basePathContextExtension:   mgwSwagger.GetXWso2Basepath(),
+						methodContextExtension:     "GET",
+						apiVersionContextExtension: mgwSwagger.GetVersion(),
+						apiNameContextExtension:    mgwSwagger.GetTitle(),
+					},
+				},
+			},
+		}
+	} else {
+		perFilterConfig = extAuthService.ExtAuthzPerRoute{
+			Override: &extAuthService.ExtAuthzPerRoute_Disabled{
+				Disabled: true,
+			},
+		}
+	}
+
+	return &routev3.Route{
+		Name: path,
+		Match: &routev3.RouteMatch{
+			PathSpecifier: &routev3.RouteMatch_Path{
+				Path: path,
+			},
+			Headers: []*routev3.HeaderMatcher{
+				{
+					Name: ":method",
+					HeaderMatchSpecifier: &routev3.HeaderMatcher_StringMatch{
+						StringMatch: &envoy_type_matcherv3.StringMatcher{
+							MatchPattern: &envoy_type_matcherv3.StringMatcher_Exact{
+								Exact: "GET",
+							},
+						},
+					},
+				},
+			},
+		},
+		Action: &routev3.Route_DirectResponse{
+			DirectResponse: &routev3.DirectResponseAction{
+				Status: 200,
+				Body: &corev3.DataSource{
+					Specifier: &corev3.DataSource_InlineBytes{
+						InlineBytes: content,
+					},
+				},
+			},
+		},
+		ResponseHeadersToAdd: []*corev3.HeaderValueOption{
+			{
+				Header: &corev3.HeaderValue{
+					Key:   "content-type",
+					Value: contentType,
+				},
+			},
+		},
+		Decorator: &routev3.Decorator{
+			Operation: path,
+		},
+		TypedPerFilterConfig: map[string]*any.Any{
+			wellknown.HTTPExternalAuthorization: marshalFilterConfig(&perFilterConfig),
+		},
+	}
+}
+
+func getDocContentType(docName string) string {
+	extension := strings.ToLower(filepath.Ext(docName))
+	if contentType, found := docContentTypes[extension]; found {
+		return contentType
+	}
+	if contentType := mime.TypeByExtension(extension); contentType != "" {
+		return contentType
+	}
+	return "application/octet-stream"
+}
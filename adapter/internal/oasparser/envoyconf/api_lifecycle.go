@@ -0,0 +1,93 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package envoyconf
+
+import (
+	"strconv"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	extAuthService "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ext_authz/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
+)
+
+const (
+	blockedResponseBody string = `{"code":"503","message":"Service Unavailable",` +
+		`"description":"The API is blocked"}`
+	deprecatedWarning string = "The API is deprecated"
+)
+
+// CreateBlockedRoutes returns the routes of a blocked API, which match the same requests as the given routes and
+// respond with 503. The requests are not authenticated by the enforcer.
+func CreateBlockedRoutes(routes []*routev3.Route) []*routev3.Route {
+	extAuthzDisabled := marshalFilterConfig(&extAuthService.ExtAuthzPerRoute{
+		Override: &extAuthService.ExtAuthzPerRoute_Disabled{
+			Disabled: true,
+		},
+	})
+	blockedRoutes := make([]*routev3.Route, 0, len(routes))
+	for _, route := range routes {
+		blockedRoutes = append(blockedRoutes, &routev3.Route{
+			Name:      route.GetName(),
+			Match:     route.GetMatch(),
+			Decorator: route.GetDecorator(),
+			TypedPerFilterConfig: map[string]*anypb.Any{
+				wellknown.HTTPExternalAuthorization: extAuthzDisabled,
+			},
+			Action: &routev3.Route_DirectResponse{
+				DirectResponse: &routev3.DirectResponseAction{
+					Status: 503,
+					Body: &corev3.DataSource{
+						Specifier: &corev3.DataSource_InlineString{
+							InlineString: blockedResponseBody,
+						},
+					},
+				},
+			},
+			ResponseHeadersToAdd: []*corev3.HeaderValueOption{
+				generateHeaderValueOption("Content-Type", "application/json",
+					corev3.HeaderValueOption_OVERWRITE_IF_EXISTS_OR_ADD),
+			},
+		})
+	}
+	return blockedRoutes
+}
+
+// AddDeprecatedHeaders returns copies of the routes of an API deprecated from the control plane, which add a
+// Warning header to the responses. The Deprecation header is added as well, unless the routes already advertise
+// the deprecation of the API version.
+func AddDeprecatedHeaders(routes []*routev3.Route, deprecationAdvertised bool) []*routev3.Route {
+	headers := []*corev3.HeaderValueOption{
+		generateHeaderValueOption(warningHeader, advisoryWarnCode+" - "+strconv.Quote(deprecatedWarning),
+			corev3.HeaderValueOption_APPEND_IF_EXISTS_OR_ADD),
+	}
+	if !deprecationAdvertised {
+		headers = append(headers, getDeprecationHeaders(&model.DeprecationConfig{})...)
+	}
+	deprecatedRoutes := make([]*routev3.Route, 0, len(routes))
+	for _, route := range routes {
+		deprecatedRoute := proto.Clone(route).(*routev3.Route)
+		deprecatedRoute.ResponseHeadersToAdd = append(deprecatedRoute.ResponseHeadersToAdd, headers...)
+		deprecatedRoutes = append(deprecatedRoutes, deprecatedRoute)
+	}
+	return deprecatedRoutes
+}
@@ -0,0 +1,108 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package envoyconf
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
+)
+
+const (
+	deprecationHeader string = "Deprecation"
+	sunsetHeader      string = "Sunset"
+	linkHeader        string = "Link"
+)
+
+var statPrefixInvalidChars = regexp.MustCompile(`[^a-zA-Z0-9_-]`)
+
+// getDeprecationHeaders returns the response headers advertising the deprecation of an API version.
+// The Deprecation header (RFC 9745) is the time of the deprecation, or true if only the lifecycle status is
+// known. The Sunset header (RFC 8594) is an HTTP date. The links are appended to the Link header of the
+// backend response, if any.
+func getDeprecationHeaders(deprecation *model.DeprecationConfig) []*corev3.HeaderValueOption {
+	if deprecation == nil {
+		return nil
+	}
+	deprecationValue := "true"
+	if !deprecation.DeprecatedAt.IsZero() {
+		deprecationValue = fmt.Sprintf("@%d", deprecation.DeprecatedAt.Unix())
+	}
+	headers := []*corev3.HeaderValueOption{
+		generateHeaderValueOption(deprecationHeader, deprecationValue, corev3.HeaderValueOption_OVERWRITE_IF_EXISTS_OR_ADD),
+	}
+	if !deprecation.SunsetAt.IsZero() {
+		headers = append(headers, generateHeaderValueOption(sunsetHeader,
+			deprecation.SunsetAt.UTC().Format(http.TimeFormat), corev3.HeaderValueOption_OVERWRITE_IF_EXISTS_OR_ADD))
+	}
+	var links []string
+	if deprecation.Link != "" {
+		links = append(links, fmt.Sprintf("<%s>; rel=\"deprecation\"", deprecation.Link))
+	}
+	if deprecation.SuccessorLink != "" {
+		links = append(links, fmt.Sprintf("<%s>; rel=\"successor-version\"", deprecation.SuccessorLink))
+	}
+	if len(links) > 0 {
+		headers = append(headers, generateHeaderValueOption(linkHeader, strings.Join(links, ", "),
+			corev3.HeaderValueOption_APPEND_IF_EXISTS_OR_ADD))
+	}
+	return headers
+}
+
+// AddDrainHeaders returns copies of the routes of an undeployed API, which add the Deprecation header (the time of
+// the undeployment) and the Sunset header (the time the routes are removed) to the responses. The headers override
+// the deprecation headers of the API version, if any.
+func AddDrainHeaders(routes []*routev3.Route, undeployedAt, removedAt time.Time) []*routev3.Route {
+	headers := getDeprecationHeaders(&model.DeprecationConfig{
+		DeprecatedAt: undeployedAt,
+		SunsetAt:     removedAt,
+	})
+	drainedRoutes := make([]*routev3.Route, 0, len(routes))
+	for _, route := range routes {
+		drainedRoute := proto.Clone(route).(*routev3.Route)
+		drainedRoute.ResponseHeadersToAdd = append(drainedRoute.ResponseHeadersToAdd, headers...)
+		drainedRoutes = append(drainedRoutes, drainedRoute)
+	}
+	return drainedRoutes
+}
+
+// getAPIVersionStatPrefix returns the stat prefix of the routes of an API version. The routes of a version share
+// the prefix, hence envoy aggregates the statistics of those routes. The vhost separates the statistics of the
+// API versions with the same name deployed in different vhosts.
+func getAPIVersionStatPrefix(vHost, title, version string) string {
+	return statPrefixInvalidChars.ReplaceAllString(vHost+"_"+title+"_"+version, "_")
+}
+
+func generateHeaderValueOption(name, value string,
+	action corev3.HeaderValueOption_HeaderAppendAction) *corev3.HeaderValueOption {
+	return &corev3.HeaderValueOption{
+		Header: &corev3.HeaderValue{
+			Key:   name,
+			Value: value,
+		},
+		AppendAction: action,
+	}
+}
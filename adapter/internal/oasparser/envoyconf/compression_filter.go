@@ -23,11 +23,14 @@ import (
 	"strings"
 
 	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	brotli_compressor "github.com/envoyproxy/go-control-plane/envoy/extensions/compression/brotli/compressor/v3"
 	gzip_compressor "github.com/envoyproxy/go-control-plane/envoy/extensions/compression/gzip/compressor/v3"
 	compressor3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/compressor/v3"
 	hcmv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
 	"github.com/wso2/product-microgateway/adapter/config"
 	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
 	"github.com/wso2/product-microgateway/adapter/pkg/logging"
 	"google.golang.org/protobuf/reflect/protoreflect"
 	"google.golang.org/protobuf/types/known/anypb"
@@ -97,7 +100,6 @@ func getCompressorFilter() (*hcmv3.HttpFilter, error) {
 	configRead, _ := config.ReadConfigs()
 	var responseDirectionContentTypes []string
 	var requestDirectionContentTypes []string
-	var libraryConfig protoreflect.ProtoMessage
 
 	for _, val := range configRead.Envoy.Filters.Compression.ResponseDirection.ContentType {
 		responseDirectionContentTypes = append(responseDirectionContentTypes, val)
@@ -106,13 +108,9 @@ func getCompressorFilter() (*hcmv3.HttpFilter, error) {
 		requestDirectionContentTypes = append(requestDirectionContentTypes, val)
 	}
 
-	compressionLibrary := strings.ToLower(configRead.Envoy.Filters.Compression.Library)
-	if compressionLibrary == "gzip" {
-		libraryConfig = getGzipConfigurations(*configRead)
-	}
-	marshalldConfig, err := anypb.New(libraryConfig)
+	compressorLibrary, err := getCompressorLibrary(*configRead, configRead.Envoy.Filters.Compression.Library, 0)
 	if err != nil {
-		return nil, errors.New("Error occurred while marshalling compression library configurations. " + err.Error())
+		return nil, err
 	}
 	conf := &compressor3.Compressor{
 		ResponseDirectionConfig: &compressor3.Compressor_ResponseDirectionConfig{
@@ -144,23 +142,205 @@ func getCompressorFilter() (*hcmv3.HttpFilter, error) {
 				ContentType: requestDirectionContentTypes,
 			},
 		},
-		CompressorLibrary: &corev3.TypedExtensionConfig{
-			Name:        "text_optimized",
-			TypedConfig: marshalldConfig,
-		},
+		CompressorLibrary: compressorLibrary,
 	}
-	compressorConfig, err := anypb.New(conf)
+	compressorFilter, err := marshalCompressorFilter(compressorFilterName, conf)
+	if err != nil {
+		return nil, err
+	}
+	logger.LoggerAPI.Debugf("compression filter configured successfully")
+	return compressorFilter, nil
+}
+
+// getCompressionProfileFilters returns a compressor filter per compression profile. The response compression of a
+// profile filter is disabled, and enabled only for the routes of the APIs selecting the profile.
+func getCompressionProfileFilters() []*hcmv3.HttpFilter {
+	configRead, _ := config.ReadConfigs()
+	var filters []*hcmv3.HttpFilter
+	profileNames := make(map[string]struct{}, len(configRead.Envoy.Filters.Compression.Profiles))
+	for _, profile := range configRead.Envoy.Filters.Compression.Profiles {
+		var filter *hcmv3.HttpFilter
+		var compressorLibrary *corev3.TypedExtensionConfig
+		_, duplicated := profileNames[profile.Name]
+		library := profile.Library
+		if library == "" {
+			library = configRead.Envoy.Filters.Compression.Library
+		}
+		err := errors.New("the name of the profile is empty")
+		if duplicated {
+			err = errors.New("the profile is duplicated")
+		} else if strings.TrimSpace(profile.Name) != "" {
+			compressorLibrary, err = getCompressorLibrary(*configRead, library, profile.CompressionLevel)
+		}
+		if err == nil {
+			filter, err = marshalCompressorFilter(getCompressionProfileFilterName(profile.Name), &compressor3.Compressor{
+				ResponseDirectionConfig: &compressor3.Compressor_ResponseDirectionConfig{
+					CommonConfig: &compressor3.Compressor_CommonDirectionConfig{
+						MinContentLength: wrapperspb.UInt32(uint32(profile.MinimumContentLength)),
+						ContentType:      profile.ContentType,
+						Enabled: &corev3.RuntimeFeatureFlag{
+							DefaultValue: wrapperspb.Bool(false),
+							RuntimeKey:   "response_compressor_enabled_" + profile.Name,
+						},
+					},
+					DisableOnEtagHeader: configRead.Envoy.Filters.Compression.ResponseDirection.EnableForEtagHeader,
+				},
+				CompressorLibrary: compressorLibrary,
+			})
+		}
+		if err != nil {
+			logger.LoggerXds.ErrorC(logging.ErrorDetails{
+				Message:   fmt.Sprintf("Error while creating the filter of the compression profile %q: %v", profile.Name, err),
+				Severity:  logging.MINOR,
+				ErrorCode: 2248,
+			})
+			continue
+		}
+		profileNames[profile.Name] = struct{}{}
+		filters = append(filters, filter)
+	}
+	return filters
+}
+
+// getCompressionProfileFilterName returns the name of the compressor filter of a compression profile
+func getCompressionProfileFilterName(profileName string) string {
+	return compressorFilterName + "." + profileName
+}
+
+// hasCompressionProfile returns true if a compression profile of the given name is configured
+func hasCompressionProfile(configRead *config.Config, profileName string) bool {
+	for _, profile := range configRead.Envoy.Filters.Compression.Profiles {
+		if profile.Name == profileName {
+			return true
+		}
+	}
+	return false
+}
+
+// getCompressorLibrary returns the configuration of the given compression library (gzip or brotli). The compression
+// level overrides the level of the library properties, unless it is 0.
+func getCompressorLibrary(configRead config.Config, library string, compressionLevel uint32) (
+	*corev3.TypedExtensionConfig, error) {
+	var libraryConfig protoreflect.ProtoMessage
+	switch strings.ToLower(library) {
+	case compressionLibraryGzip:
+		gzipConf := getGzipConfigurations(configRead)
+		if compressionLevel > 9 {
+			return nil, fmt.Errorf("gzip compression level %d is not within 1 to 9", compressionLevel)
+		}
+		if compressionLevel != 0 {
+			gzipConf.CompressionLevel = getGzipCompressionLevel(compressionLevel)
+		}
+		libraryConfig = gzipConf
+	case compressionLibraryBrotli:
+		brotliConf := getBrotliConfigurations(configRead)
+		if compressionLevel > 11 {
+			return nil, fmt.Errorf("brotli compression level %d is not within 0 to 11", compressionLevel)
+		}
+		if compressionLevel != 0 {
+			brotliConf.Quality = wrapperspb.UInt32(compressionLevel)
+		}
+		libraryConfig = brotliConf
+	default:
+		return nil, fmt.Errorf("compression library %q is not supported", library)
+	}
+	marshalledConfig, err := anypb.New(libraryConfig)
+	if err != nil {
+		return nil, errors.New("Error occurred while marshalling compression library configurations. " + err.Error())
+	}
+	return &corev3.TypedExtensionConfig{
+		Name:        "text_optimized",
+		TypedConfig: marshalledConfig,
+	}, nil
+}
+
+// getBrotliConfigurations returns the brotli configuration of the library properties. The brotli defaults are
+// applied for the properties not within the brotli ranges.
+func getBrotliConfigurations(config config.Config) *brotli_compressor.Brotli {
+	brotliConf := &brotli_compressor.Brotli{
+		Quality:    wrapperspb.UInt32(3),
+		WindowBits: wrapperspb.UInt32(18),
+		ChunkSize:  wrapperspb.UInt32(4096),
+	}
+	properties := config.Envoy.Filters.Compression.LibraryProperties
+	if quality, err := getUInt32Value(properties["compressionLevel"]); err == nil && quality <= 11 {
+		brotliConf.Quality = wrapperspb.UInt32(quality)
+	}
+	if windowBits, err := getUInt32Value(properties["windowBits"]); err == nil && windowBits >= 10 && windowBits <= 24 {
+		brotliConf.WindowBits = wrapperspb.UInt32(windowBits)
+	}
+	if chunkSize, err := getUInt32Value(properties["chunkSize"]); err == nil && chunkSize >= 4096 && chunkSize <= 65536 {
+		brotliConf.ChunkSize = wrapperspb.UInt32(chunkSize)
+	}
+	logger.LoggerAPI.Debug("brotli configuration values parsed successfully.")
+	return brotliConf
+}
+
+func marshalCompressorFilter(name string, compressorConfig *compressor3.Compressor) (*hcmv3.HttpFilter, error) {
+	marshalledConfig, err := anypb.New(compressorConfig)
 	if err != nil {
 		return nil, errors.New("Error occurred while marshalling compression filter configurations. " + err.Error())
 	}
-	compressorFilter := hcmv3.HttpFilter{
-		Name: compressorFilterName,
+	return &hcmv3.HttpFilter{
+		Name: name,
 		ConfigType: &hcmv3.HttpFilter_TypedConfig{
-			TypedConfig: compressorConfig,
+			TypedConfig: marshalledConfig,
 		},
+	}, nil
+}
+
+// applyResponseCompression applies the response compression of an API to the given routes. The compressor filter of
+// the router is disabled for the routes of the APIs selecting a compression profile, as the responses are compressed
+// by the filter of the profile.
+func applyResponseCompression(routes []*routev3.Route, compression *model.ResponseCompression) {
+	if compression == nil {
+		return
+	}
+	configRead, _ := config.ReadConfigs()
+	var err error
+	if !configRead.Envoy.Filters.Compression.Enabled {
+		err = errors.New("the compression filter of the router is disabled")
+	} else if compression.Profile != "" && !hasCompressionProfile(configRead, compression.Profile) {
+		err = fmt.Errorf("the compression profile %q is not configured", compression.Profile)
+	}
+	if err != nil {
+		logger.LoggerOasparser.ErrorC(logging.ErrorDetails{
+			Message:   fmt.Sprintf("Response compression of the API is not applied, as %v", err),
+			Severity:  logging.MINOR,
+			ErrorCode: 2249,
+		})
+		return
+	}
+
+	disabled, _ := anypb.New(&compressor3.CompressorPerRoute{
+		Override: &compressor3.CompressorPerRoute_Disabled{Disabled: true},
+	})
+	// the response compression of a filter is enabled for a route when the overrides are present
+	enabled, _ := anypb.New(&compressor3.CompressorPerRoute{
+		Override: &compressor3.CompressorPerRoute_Overrides{
+			Overrides: &compressor3.CompressorOverrides{
+				ResponseDirectionConfig: &compressor3.ResponseDirectionOverrides{},
+			},
+		},
+	})
+	perFilterConfigs := make(map[string]*anypb.Any, 2)
+	switch {
+	case !compression.Enabled:
+		perFilterConfigs[compressorFilterName] = disabled
+	case compression.Profile == "":
+		perFilterConfigs[compressorFilterName] = enabled
+	default:
+		perFilterConfigs[compressorFilterName] = disabled
+		perFilterConfigs[getCompressionProfileFilterName(compression.Profile)] = enabled
+	}
+	for _, route := range routes {
+		if route.TypedPerFilterConfig == nil {
+			route.TypedPerFilterConfig = make(map[string]*anypb.Any)
+		}
+		for filterName, perFilterConfig := range perFilterConfigs {
+			route.TypedPerFilterConfig[filterName] = perFilterConfig
+		}
 	}
-	logger.LoggerAPI.Debugf("compression filter configured successfully")
-	return &compressorFilter, nil
 }
 
 func getUInt32Value(s interface{}) (uint32, error) {
@@ -23,11 +23,17 @@ const (
 	tracingClusterName      string = "wso2_cc_trace"
 	extAuthzHTTPClusterName string = "ext_authz_http_cluster"
 	awslambdaClusterName    string = "wso2_lambda_egress_gateway"
+	rateLimitClusterName    string = "wso2_rate_limit_service"
+	// adapterClusterName is the cluster of the adapter in the bootstrap configuration of the router
+	adapterClusterName           string = "xds_cluster"
+	botDetectionAccessLogLogName string = "mgw_bot_detection_logs"
 )
 
 const (
 	extAuthzFilterName         string = "envoy.filters.http.ext_authz"
 	luaFilterName              string = "envoy.filters.http.lua"
+	headerTransformFilterName  string = "envoy.filters.http.lua.header_transformation"
+	rateLimitHeadersFilterName string = "envoy.filters.http.lua.rate_limit_headers"
 	awsLambdaFilterName        string = "envoy.filters.http.aws_lambda"
 	transportSocketName        string = "envoy.transport_sockets.tls"
 	fileAccessLogName          string = "envoy.access_loggers.file"
@@ -45,9 +51,20 @@ const (
 	mgwWebSocketWASMFilterRoot string = "mgw_WASM_websocket_root"
 	mgwWebSocketWASM           string = "/home/wso2/wasm/websocket/mgw-websocket.wasm"
 	compressorFilterName       string = "envoy.filters.http.compressor"
+	cacheFilterName            string = "envoy.filters.http.cache"
 	localRatelimitFilterName   string = "envoy.filters.http.local_ratelimit"
 )
 
+// applicationAttributeMetadataPrefix prefixes the names of the application attributes in the dynamic metadata set by
+// the enforcer
+const applicationAttributeMetadataPrefix string = "application.attributes."
+
+// compression libraries of the compressor filters
+const (
+	compressionLibraryGzip   string = "gzip"
+	compressionLibraryBrotli string = "brotli"
+)
+
 const (
 	localRateLimitStatPrefix        string = "http_local_rate_limiter"
 	jwksRateLimitStatPrefix         string = "jwks_rate_limit"
@@ -64,6 +81,21 @@ const (
 	defaultListenerSecretConfigName string = "DefaultListenerSecret"
 )
 
+// SNI certificate pool related constants
+const (
+	sniFilterChainNamePrefix string = "sni_"
+	sniCertFileName          string = "tls.crt"
+	sniKeyFileName           string = "tls.key"
+	sniMetadataFilterName    string = "wso2.sni"
+	sniRevisionMetadataKey   string = "revision"
+)
+
+// Revision traffic split related constants
+const (
+	lbMetadataFilterName string = "envoy.lb"
+	revisionMetadataKey  string = "revision"
+)
+
 // cluster prefixes
 const (
 	xWso2EPClustersConfigNamePrefix     string = "xwso2cluster"
@@ -83,7 +115,15 @@ const (
 	apiNameContextExtension         string = "name"
 	prodClusterNameContextExtension string = "prodClusterName"
 	sandClusterNameContextExtension string = "sandClusterName"
-	retryPolicyRetriableStatusCodes string = "retriable-status-codes"
+	// subscriptionValidationContextExtension is set only if the API overrides the subscription validation of the
+	// token issuer, with the value true or false.
+	subscriptionValidationContextExtension string = "subscriptionValidation"
+	// The signature of the events is set only for the events receiver of a WebSub API, which is verified by the
+	// enforcer with the request body.
+	webSubSecretContextExtension           string = "webSubSecret"
+	webSubSigningAlgorithmContextExtension string = "webSubSigningAlgorithm"
+	webSubSignatureHeaderContextExtension  string = "webSubSignatureHeader"
+	retryPolicyRetriableStatusCodes        string = "retriable-status-codes"
 )
 
 const (
@@ -104,6 +144,7 @@ const (
 	OperationLevelInterceptor string = "operation"
 )
 const (
+	httpURLType      string = "http"
 	httpsURLType     string = "https"
 	wssURLType       string = "wss"
 	httpMethodHeader string = ":method"
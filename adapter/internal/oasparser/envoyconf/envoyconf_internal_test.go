@@ -20,15 +20,26 @@ package envoyconf
 import (
 	"fmt"
 	"io/ioutil"
+	"net"
+	"net/url"
 	"regexp"
 	"strings"
 	"testing"
 	"time"
 
+	clusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
 	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	rbac_config_v3 "github.com/envoyproxy/go-control-plane/envoy/config/rbac/v3"
 	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	brotliv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/compression/brotli/compressor/v3"
+	gzipv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/compression/gzip/compressor/v3"
+	bufferv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/buffer/v3"
+	compressorv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/compressor/v3"
 	cors_filter_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/cors/v3"
 	extAuthService "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ext_authz/v3"
+	grpc_json_transcoderv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/grpc_json_transcoder/v3"
+	local_rate_limitv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/local_ratelimit/v3"
+	rbacv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/rbac/v3"
 	tlsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
 	envoy_type_matcherv3 "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
@@ -36,7 +47,14 @@ import (
 	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/stretchr/testify/assert"
 	"github.com/wso2/product-microgateway/adapter/config"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/constants"
 	"github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
+	"github.com/wso2/product-microgateway/adapter/pkg/discovery/api/wso2/discovery/throttle"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
@@ -113,7 +131,7 @@ func TestCreateRoute(t *testing.T) {
 	}
 
 	generatedRouteArrayWithXWso2BasePath, err := createRoutes(generateRouteCreateParamsForUnitTests(title, apiType, vHost, xWso2BasePath, version,
-		endpoint.Basepath, &resourceWithGet, clusterName, "", nil, false))
+		endpoint.Basepath, &resourceWithGet, clusterName, "", nil))
 	assert.Nil(t, err, "Error while creating routes WithXWso2BasePath")
 	generatedRouteWithXWso2BasePath := generatedRouteArrayWithXWso2BasePath[0]
 	assert.NotNil(t, generatedRouteWithXWso2BasePath, "Route should not be null.")
@@ -124,7 +142,7 @@ func TestCreateRoute(t *testing.T) {
 		"Assigned HTTP Method Regex is incorrect when single method is available.")
 
 	generatedRouteArrayWithoutXWso2BasePath, err := createRoutes(generateRouteCreateParamsForUnitTests(title, apiType, vHost, "", version,
-		endpoint.Basepath, &resourceWithGetPost, clusterName, "", nil, false))
+		endpoint.Basepath, &resourceWithGetPost, clusterName, "", nil))
 	assert.Nil(t, err, "Error while creating routes WithoutXWso2BasePath")
 	generatedRouteWithoutXWso2BasePath := generatedRouteArrayWithoutXWso2BasePath[0]
 	assert.NotNil(t, generatedRouteWithoutXWso2BasePath, "Route should not be null")
@@ -133,12 +151,139 @@ func TestCreateRoute(t *testing.T) {
 		"Assigned HTTP Method Regex is incorrect when multiple methods are available.")
 
 	context := fmt.Sprintf("%s/%s", xWso2BasePath, version)
-	generatedRouteWithDefaultVersion, err := createRoutes(generateRouteCreateParamsForUnitTests(title, apiType, vHost, context, version,
-		endpoint.Basepath, &resourceWithGetPost, clusterName, "", nil, true))
-	assert.Nil(t, err, "Error while creating routes WithDefaultVersion")
-	assert.NotNil(t, generatedRouteWithDefaultVersion, "Route should not be null")
-	assert.True(t, strings.HasPrefix(generatedRouteWithDefaultVersion[0].GetMatch().GetSafeRegex().Regex, fmt.Sprintf("^(?:%s|%s)", context, xWso2BasePath)),
+	generatedRouteWithVersion, err := createRoutes(generateRouteCreateParamsForUnitTests(title, apiType, vHost, context, version,
+		endpoint.Basepath, &resourceWithGetPost, clusterName, "", nil))
+	assert.Nil(t, err, "Error while creating routes WithVersion")
+	generatedRouteWithDefaultVersion := CreateDefaultVersionRoutes(context, version, generatedRouteWithVersion)
+	assert.Equal(t, len(generatedRouteWithVersion), len(generatedRouteWithDefaultVersion),
+		"A default version route should be created per versioned route")
+	assert.Equal(t, "^"+xWso2BasePath+"/resourcePath[/]{0,1}", generatedRouteWithDefaultVersion[0].GetMatch().GetSafeRegex().Regex,
 		"Default version basepath is not generated correctly")
+	assert.Equal(t, "^"+xWso2BasePath+"/resourcePath[/]{0,1}",
+		generatedRouteWithDefaultVersion[0].GetRoute().GetRegexRewrite().GetPattern().Regex,
+		"Default version path rewrite is not generated correctly")
+	assert.Equal(t, vHost+":^"+xWso2BasePath+"/resourcePath[/]{0,1}", generatedRouteWithDefaultVersion[0].GetDecorator().Operation)
+	assert.True(t, strings.HasPrefix(generatedRouteWithVersion[0].GetMatch().GetSafeRegex().Regex, "^"+context+"/"),
+		"Versioned route should not be changed")
+	assert.Empty(t, CreateDefaultVersionRoutes(xWso2BasePath, version, generatedRouteWithVersion),
+		"Default version routes should not be created when the basepath does not end with the version")
+}
+
+func TestCreateRouteForStreamingAPI(t *testing.T) {
+	resourceWithGet := model.CreateMinimalDummyResourceForTests("/events", []*model.Operation{model.NewOperation("GET", nil, nil)},
+		"resource_operation_id", []model.Endpoint{}, []model.Endpoint{})
+	params := generateRouteCreateParamsForUnitTests("WSO2", "SSE", "localhost", "/events", "1.0", "/basepath",
+		&resourceWithGet, "resource_operation_id", "", nil)
+	params.passRequestPayloadToEnforcer = true
+
+	params.streamingConfig = &model.StreamingConfig{Enabled: true}
+	routes, err := createRoutes(params)
+	assert.Nil(t, err, "Error while creating routes for streaming API")
+	routeAction := routes[0].GetRoute()
+	assert.Equal(t, int64(0), routeAction.GetTimeout().GetSeconds(), "Route timeout should be disabled for streaming routes.")
+	assert.Equal(t, int64(3600), routeAction.GetIdleTimeout().GetSeconds(), "Default streaming idle timeout is not applied.")
+	extAuthzPerRoute := &extAuthService.ExtAuthzPerRoute{}
+	err = routes[0].GetTypedPerFilterConfig()[wellknown.HTTPExternalAuthorization].UnmarshalTo(extAuthzPerRoute)
+	assert.Nil(t, err, "Error while parsing ext authz per route config")
+	assert.True(t, extAuthzPerRoute.GetCheckSettings().DisableRequestBodyBuffering,
+		"Request body buffering should be disabled for streaming routes.")
+
+	params.streamingConfig = &model.StreamingConfig{Enabled: true, IdleTimeoutInSeconds: 120}
+	routes, err = createRoutes(params)
+	assert.Nil(t, err, "Error while creating routes for streaming API")
+	assert.Equal(t, int64(120), routes[0].GetRoute().GetIdleTimeout().GetSeconds(), "API level idle timeout is not applied.")
+
+	params.streamingConfig = &model.StreamingConfig{Enabled: false, IdleTimeoutInSeconds: 120}
+	routes, err = createRoutes(params)
+	assert.Nil(t, err, "Error while creating routes")
+	assert.Equal(t, int64(60), routes[0].GetRoute().GetTimeout().GetSeconds(), "Route timeout should be applied.")
+	assert.Equal(t, int64(300), routes[0].GetRoute().GetIdleTimeout().GetSeconds(), "Route idle timeout should be applied.")
+}
+
+func TestCreateRouteWithPayloadLimits(t *testing.T) {
+	resourceWithGet := model.CreateMinimalDummyResourceForTests("/orders", []*model.Operation{model.NewOperation("POST", nil, nil)},
+		"resource_operation_id", []model.Endpoint{}, []model.Endpoint{})
+	params := generateRouteCreateParamsForUnitTests("WSO2", "HTTP", "localhost", "/orders", "1.0", "/basepath",
+		&resourceWithGet, "resource_operation_id", "", nil)
+	params.passRequestPayloadToEnforcer = true
+
+	routes, err := createRoutes(params)
+	assert.Nil(t, err, "Error while creating routes")
+	assert.Nil(t, routes[0].GetPerRequestBufferLimitBytes(), "Buffer limit should not be set without payload limits")
+	_, found := routes[0].GetTypedPerFilterConfig()[wellknown.Buffer]
+	assert.False(t, found, "Buffer filter should not be enabled without payload limits")
+
+	params.payloadLimits = &model.PayloadLimits{MaxRequestBodySizeInBytes: 2048, MaxBufferSizeInBytes: 4096}
+	routes, err = createRoutes(params)
+	assert.Nil(t, err, "Error while creating routes")
+	assert.Equal(t, uint32(4096), routes[0].GetPerRequestBufferLimitBytes().GetValue(), "Buffer limit mismatch")
+	bufferPerRoute := &bufferv3.BufferPerRoute{}
+	err = routes[0].GetTypedPerFilterConfig()[wellknown.Buffer].UnmarshalTo(bufferPerRoute)
+	assert.Nil(t, err, "Error while parsing buffer per route config")
+	assert.Equal(t, uint32(2048), bufferPerRoute.GetBuffer().GetMaxRequestBytes().GetValue(),
+		"Request body size limit mismatch")
+
+	params.payloadLimits.Passthrough = true
+	routes, err = createRoutes(params)
+	assert.Nil(t, err, "Error while creating routes")
+	_, found = routes[0].GetTypedPerFilterConfig()[wellknown.Buffer]
+	assert.False(t, found, "Buffer filter should not be enabled for passthrough routes")
+	extAuthzPerRoute := &extAuthService.ExtAuthzPerRoute{}
+	err = routes[0].GetTypedPerFilterConfig()[wellknown.HTTPExternalAuthorization].UnmarshalTo(extAuthzPerRoute)
+	assert.Nil(t, err, "Error while parsing ext authz per route config")
+	assert.True(t, extAuthzPerRoute.GetCheckSettings().DisableRequestBodyBuffering,
+		"Request body buffering should be disabled for passthrough routes.")
+}
+
+func TestCreateVirtualHostsDisablesBufferFilter(t *testing.T) {
+	vHosts := CreateVirtualHosts(map[string][]*routev3.Route{"foo.com": nil})
+	bufferPerRoute := &bufferv3.BufferPerRoute{}
+	err := vHosts[0].GetTypedPerFilterConfig()[wellknown.Buffer].UnmarshalTo(bufferPerRoute)
+	assert.Nil(t, err, "Error while parsing buffer per route config")
+	assert.True(t, bufferPerRoute.GetDisabled(), "Buffer filter should be disabled for the vhost")
+}
+
+func TestGenerateRouteActionRetryPolicy(t *testing.T) {
+	action := generateRouteAction("HTTP", nil, nil, "", nil)
+	assert.Nil(t, action.Route.GetRetryPolicy(), "Retry policy should not be added without retry configs")
+
+	prodRouteConfig := &model.EndpointConfig{
+		RetryConfig: &model.RetryConfig{Count: 3, StatusCodes: []uint32{503}},
+	}
+	sandRouteConfig := &model.EndpointConfig{
+		RetryConfig: &model.RetryConfig{Count: 2, StatusCodes: []uint32{503}, PerTryTimeoutInMillis: 500},
+	}
+	action = generateRouteAction("HTTP", prodRouteConfig, sandRouteConfig, "", nil)
+	assert.Nil(t, action.Route.GetRetryPolicy().GetPerTryTimeout(),
+		"Per try timeout of the sandbox endpoints should not be applied to the production requests")
+
+	prodRouteConfig.RetryConfig.PerTryTimeoutInMillis = 1000
+	action = generateRouteAction("HTTP", prodRouteConfig, sandRouteConfig, "", nil)
+	assert.Equal(t, int64(1000), action.Route.GetRetryPolicy().GetPerTryTimeout().AsDuration().Milliseconds(),
+		"Per try timeout of the production endpoints should be applied")
+}
+
+func TestAddRevisionTesterRoutes(t *testing.T) {
+	resourceWithGet := model.CreateMinimalDummyResourceForTests("/resourcePath", []*model.Operation{model.NewOperation("GET", nil, nil)},
+		"resource_operation_id", []model.Endpoint{}, []model.Endpoint{})
+	routes, err := createRoutes(generateRouteCreateParamsForUnitTests("WSO2", "HTTP", "localhost", "/basepath", "1.0.0", "/basepath",
+		&resourceWithGet, "prodCluster", "", nil))
+	assert.Nil(t, err, "Error while creating routes")
+
+	assert.Equal(t, routes, addRevisionTesterRoutes(nil, routes), "Routes should not be altered without a revision split")
+
+	split := &model.RevisionTrafficSplit{NewRevisionID: "2", PreviousRevisionID: "1", NewRevisionWeight: 20,
+		TesterHeader: "x-wso2-revision"}
+	routesWithTesterRoutes := addRevisionTesterRoutes(split, routes)
+	assert.Equal(t, 2*len(routes), len(routesWithTesterRoutes), "Tester route should be added for each route")
+	testerRoute := routesWithTesterRoutes[0]
+	testerHeader := testerRoute.GetMatch().GetHeaders()[len(testerRoute.GetMatch().GetHeaders())-1]
+	assert.Equal(t, "x-wso2-revision", testerHeader.GetName(), "Tester header mismatch")
+	assert.Equal(t, "2", testerHeader.GetStringMatch().GetExact(), "Tester header value mismatch")
+	assert.Equal(t, "2", testerRoute.GetRoute().GetMetadataMatch().GetFilterMetadata()[lbMetadataFilterName].
+		GetFields()[revisionMetadataKey].GetStringValue(), "Tester route should be routed to the new revision")
+	assert.Equal(t, routes[0], routesWithTesterRoutes[1], "Original route should follow the tester route")
+	assert.Nil(t, routes[0].GetRoute().GetMetadataMatch(), "Original route should not be altered")
 }
 
 func TestCreateRouteClusterSpecifier(t *testing.T) {
@@ -160,7 +305,7 @@ func TestCreateRouteClusterSpecifier(t *testing.T) {
 		"resource_operation_id", []model.Endpoint{}, []model.Endpoint{})
 
 	routeWithProdEp, err := createRoutes(generateRouteCreateParamsForUnitTests(title, apiType, vHost, xWso2BasePath, version, endpointBasePath,
-		&resourceWithGet, prodClusterName, "", nil, false))
+		&resourceWithGet, prodClusterName, "", nil))
 	assert.Nil(t, err, "Error while creating routeWithProdEp")
 	assert.NotNil(t, routeWithProdEp[0], "Route should not be null")
 	assert.NotNil(t, routeWithProdEp[0].GetRoute().GetClusterHeader(), "Route Cluster Header should not be null.")
@@ -168,7 +313,7 @@ func TestCreateRouteClusterSpecifier(t *testing.T) {
 	assert.Equal(t, clusterHeaderName, routeWithProdEp[0].GetRoute().GetClusterHeader(), "Route Cluster Name mismatch.")
 
 	routeWithSandEp, err := createRoutes(generateRouteCreateParamsForUnitTests(title, apiType, vHost, xWso2BasePath, version, endpointBasePath,
-		&resourceWithGet, "", sandClusterName, nil, false))
+		&resourceWithGet, "", sandClusterName, nil))
 	assert.Nil(t, err, "Error while creating routeWithSandEp")
 	assert.NotNil(t, routeWithSandEp[0], "Route should not be null")
 	assert.NotNil(t, routeWithSandEp[0].GetRoute().GetClusterHeader(), "Route Cluster Header should not be null.")
@@ -176,7 +321,7 @@ func TestCreateRouteClusterSpecifier(t *testing.T) {
 	assert.Equal(t, clusterHeaderName, routeWithSandEp[0].GetRoute().GetClusterHeader(), "Route Cluster Name mismatch.")
 
 	routeWithProdSandEp, err := createRoutes(generateRouteCreateParamsForUnitTests(title, apiType, vHost, xWso2BasePath, version, endpointBasePath,
-		&resourceWithGet, prodClusterName, sandClusterName, nil, false))
+		&resourceWithGet, prodClusterName, sandClusterName, nil))
 	assert.Nil(t, err, "Error while creating routeWithProdSandEp")
 	assert.NotNil(t, routeWithProdSandEp[0], "Route should not be null")
 	assert.NotNil(t, routeWithProdSandEp[0].GetRoute().GetClusterHeader(), "Route Cluster Header should not be null.")
@@ -202,7 +347,7 @@ func TestCreateRouteExtAuthzContext(t *testing.T) {
 		"resource_operation_id", []model.Endpoint{}, []model.Endpoint{})
 
 	routeWithProdEp, err := createRoutes(generateRouteCreateParamsForUnitTests(title, apiType, vHost, xWso2BasePath, version,
-		endpointBasePath, &resourceWithGet, prodClusterName, sandClusterName, nil, false))
+		endpointBasePath, &resourceWithGet, prodClusterName, sandClusterName, nil))
 	assert.Nil(t, err, "Error while creating routeWithProdEp")
 	assert.NotNil(t, routeWithProdEp[0], "Route should not be null")
 	assert.NotNil(t, routeWithProdEp[0].GetTypedPerFilterConfig(), "TypedPerFilter config should not be null")
@@ -226,6 +371,49 @@ func TestCreateRouteExtAuthzContext(t *testing.T) {
 		"Production Cluster mismatch in route ext authz context.")
 	assert.Equal(t, sandClusterName, contextExtensionMap[sandClusterNameContextExtension],
 		"Sandbox Cluster mismatch in route ext authz context.")
+	assert.NotContains(t, contextExtensionMap, subscriptionValidationContextExtension,
+		"Subscription validation should not be in route ext authz context, if not overridden by the API.")
+
+	subscriptionValidation := false
+	params := generateRouteCreateParamsForUnitTests(title, apiType, vHost, xWso2BasePath, version,
+		endpointBasePath, &resourceWithGet, prodClusterName, sandClusterName, nil)
+	params.subscriptionValidation = &subscriptionValidation
+	routeWithoutSubscriptionValidation, err := createRoutes(params)
+	assert.Nil(t, err, "Error while creating routeWithoutSubscriptionValidation")
+	err = routeWithoutSubscriptionValidation[0].TypedPerFilterConfig[wellknown.HTTPExternalAuthorization].
+		UnmarshalTo(extAuthPerRouteConfig)
+	assert.Nilf(t, err, "Error while parsing ExtAuthzPerRouteConfig %v", extAuthPerRouteConfig)
+	assert.Equal(t, "false", extAuthPerRouteConfig.GetCheckSettings().ContextExtensions[subscriptionValidationContextExtension],
+		"Subscription validation mismatch in route ext authz context.")
+}
+
+func TestCreateRoutesForWebSubEventsReceiver(t *testing.T) {
+	signature := &model.WebSubSignature{Secret: "hub-secret", SigningAlgorithm: "SHA256",
+		SignatureHeader: "x-hub-signature"}
+	for _, resourcePath := range []string{constants.WebSubEventsReceiverResource, constants.WebSubHubResource} {
+		resource := model.CreateMinimalDummyResourceForTests(resourcePath,
+			[]*model.Operation{model.NewOperation("POST", nil, nil)}, "", []model.Endpoint{}, []model.Endpoint{})
+		params := generateRouteCreateParamsForUnitTests("Orders", constants.WEBSUB, "localhost", "/orders/1.0.0",
+			"1.0.0", "/hub", &resource, "prodCluster", "sandCluster", nil)
+		params.webSubSignature = signature
+		routes, err := createRoutes(params)
+		assert.Nil(t, err, "Error while creating the routes of the WebSub API")
+		extAuthPerRouteConfig := &extAuthService.ExtAuthzPerRoute{}
+		err = routes[0].TypedPerFilterConfig[wellknown.HTTPExternalAuthorization].UnmarshalTo(extAuthPerRouteConfig)
+		assert.Nil(t, err, "Error while parsing ExtAuthzPerRouteConfig")
+		checkSettings := extAuthPerRouteConfig.GetCheckSettings()
+
+		if resourcePath == constants.WebSubEventsReceiverResource {
+			assert.Equal(t, "hub-secret", checkSettings.GetContextExtensions()[webSubSecretContextExtension])
+			assert.Equal(t, "SHA256", checkSettings.GetContextExtensions()[webSubSigningAlgorithmContextExtension])
+			assert.Equal(t, "x-hub-signature", checkSettings.GetContextExtensions()[webSubSignatureHeaderContextExtension])
+			assert.False(t, checkSettings.GetDisableRequestBodyBuffering(),
+				"Body of the events should be passed to the enforcer to verify the signature")
+		} else {
+			assert.NotContains(t, checkSettings.GetContextExtensions(), webSubSecretContextExtension,
+				"Secret should be passed only for the events receiver")
+		}
+	}
 }
 
 func TestGenerateTLSCert(t *testing.T) {
@@ -545,9 +733,9 @@ func TestCreateUpstreamTLSContext(t *testing.T) {
 	}}
 
 	tlsCert := generateTLSCert(defaultMgwKeyPath, defaultMgwCertPath)
-	upstreamTLSContextWithCerts := createUpstreamTLSContext(certByteArr, hostNameAddress, false)
-	upstreamTLSContextWithoutCerts := createUpstreamTLSContext(nil, hostNameAddress, false)
-	upstreamTLSContextWithIP := createUpstreamTLSContext(certByteArr, hostNameAddressWithIP, false)
+	upstreamTLSContextWithCerts := createUpstreamTLSContext(certByteArr, nil, hostNameAddress, false)
+	upstreamTLSContextWithoutCerts := createUpstreamTLSContext(nil, nil, hostNameAddress, false)
+	upstreamTLSContextWithIP := createUpstreamTLSContext(certByteArr, nil, hostNameAddressWithIP, false)
 
 	assert.NotEmpty(t, upstreamTLSContextWithCerts, "Upstream TLS Context should not be null when certs provided")
 	assert.NotEmpty(t, upstreamTLSContextWithCerts.CommonTlsContext, "CommonTLSContext should not be "+
@@ -583,6 +771,25 @@ func TestCreateUpstreamTLSContext(t *testing.T) {
 		"Upstream SAN type mismatch.")
 }
 
+func TestCreateUpstreamTLSContextWithClientCert(t *testing.T) {
+	address := &corev3.Address{Address: &corev3.Address_SocketAddress{
+		SocketAddress: &corev3.SocketAddress{
+			Address:  "abc.com",
+			Protocol: corev3.SocketAddress_TCP,
+			PortSpecifier: &corev3.SocketAddress_PortValue{
+				PortValue: uint32(2384),
+			},
+		},
+	}}
+	clientCert := &model.UpstreamClientCert{Certificate: []byte("client-cert"), Key: []byte("client-key")}
+	upstreamTLSContext := createUpstreamTLSContext(nil, clientCert, address, false)
+
+	assert.Len(t, upstreamTLSContext.CommonTlsContext.TlsCertificates, 1, "Only the client cert should be presented")
+	tlsCert := upstreamTLSContext.CommonTlsContext.TlsCertificates[0]
+	assert.Equal(t, []byte("client-cert"), tlsCert.GetCertificateChain().GetInlineBytes(), "Client cert mismatch")
+	assert.Equal(t, []byte("client-key"), tlsCert.GetPrivateKey().GetInlineBytes(), "Client key mismatch")
+}
+
 func TestGetCorsPolicy(t *testing.T) {
 
 	corsConfigModel1 := &model.CorsConfig{
@@ -642,7 +849,7 @@ func TestGetCorsPolicy(t *testing.T) {
 
 	// Route without CORS configuration
 	routeWithoutCors, err := createRoutes(generateRouteCreateParamsForUnitTests("test", "HTTP", "localhost", "/test", "1.0.0", "/test",
-		&resourceWithGet, "test-cluster", "", nil, false))
+		&resourceWithGet, "test-cluster", "", nil))
 	assert.Nil(t, err, "Error while creating routeWithoutCors")
 
 	corsConfig1 := &cors_filter_v3.CorsPolicy{}
@@ -657,7 +864,7 @@ func TestGetCorsPolicy(t *testing.T) {
 
 	// Route with CORS configuration
 	routeWithCors, err := createRoutes(generateRouteCreateParamsForUnitTests("test", "HTTP", "localhost", "/test", "1.0.0", "/test",
-		&resourceWithGet, "test-cluster", "", corsConfigModel3, false))
+		&resourceWithGet, "test-cluster", "", corsConfigModel3))
 	assert.Nil(t, err, "Error while creating routeWithCors")
 
 	corsConfig2 := &cors_filter_v3.CorsPolicy{}
@@ -671,9 +878,72 @@ func TestGetCorsPolicy(t *testing.T) {
 	assert.Empty(t, corsConfig2.GetAllowCredentials(), "Cors AllowCredentials should be empty.")
 }
 
+func TestSetLocalRateLimitDescriptors(t *testing.T) {
+	vHosts := CreateVirtualHosts(map[string][]*routev3.Route{"foo.com": nil, "bar.com": nil})
+	SetLocalRateLimitDescriptors(vHosts, map[string][]RateLimitDescriptor{
+		"foo.com": {
+			{ApplicationUUID: "app1", RequestCount: 10, FillInterval: time.Minute},
+			{ApplicationUUID: "app1", APIUUID: "api1", RequestCount: 5, FillInterval: time.Hour},
+		},
+	})
+	SetRateLimitSourceExemptions(vHosts, []string{"10.0.0.0/8", "invalid"})
+
+	for _, vHost := range vHosts {
+		rbacConfig, found := vHost.GetTypedPerFilterConfig()[wellknown.HTTPRoleBasedAccessControl]
+		assert.True(t, found, "Source exemptions are not added to the vhost")
+		rbacPerRoute := &rbacv3.RBACPerRoute{}
+		err := rbacConfig.UnmarshalTo(rbacPerRoute)
+		assert.Nil(t, err, "Error while parsing the RBAC config")
+		assert.Empty(t, rbacPerRoute.GetRbac().GetRules().GetPolicies(), "Requests are denied by the RBAC config")
+		principals := rbacPerRoute.GetRbac().GetShadowRules().GetPolicies()[rateLimitExemptionsPolicyName].GetPrincipals()
+		assert.Equal(t, 1, len(principals), "Invalid CIDR is not ignored")
+		assert.Equal(t, "10.0.0.0", principals[0].GetDirectRemoteIp().GetAddressPrefix(), "Exempted CIDR mismatch")
+
+		filterConfig, found := vHost.GetTypedPerFilterConfig()[policyRateLimitFilterName]
+		if vHost.GetName() == "bar.com" {
+			assert.False(t, found, "Local rate limit is added to the vhost without descriptors")
+			continue
+		}
+		assert.True(t, found, "Local rate limit is not added to the vhost")
+		localRateLimit := &local_rate_limitv3.LocalRateLimit{}
+		err = filterConfig.UnmarshalTo(localRateLimit)
+		assert.Nil(t, err, "Error while parsing the local rate limit")
+		assert.NotNil(t, localRateLimit.GetTokenBucket(), "Token bucket is mandatory for the vhosts")
+		assert.Equal(t, 2, len(localRateLimit.GetDescriptors()), "Descriptor count mismatch")
+
+		appDescriptor := localRateLimit.GetDescriptors()[0]
+		assert.Equal(t, 2, len(appDescriptor.GetEntries()), "Application descriptor entries mismatch")
+		assert.Equal(t, "app1", appDescriptor.GetEntries()[0].GetValue(), "Application UUID mismatch")
+		assert.Equal(t, uint32(10), appDescriptor.GetTokenBucket().GetMaxTokens(), "Request count mismatch")
+		assert.Equal(t, time.Minute, appDescriptor.GetTokenBucket().GetFillInterval().AsDuration(),
+			"Fill interval mismatch")
+
+		subDescriptor := localRateLimit.GetDescriptors()[1]
+		assert.Equal(t, 3, len(subDescriptor.GetEntries()), "Subscription descriptor entries mismatch")
+		assert.Equal(t, "api1", subDescriptor.GetEntries()[1].GetValue(), "API UUID mismatch")
+		assert.Equal(t, rbacShadowDenied, subDescriptor.GetEntries()[2].GetValue(),
+			"Exempted sources are rate limited")
+
+	}
+
+	// the descriptors generated for the routes should match the descriptors of the vhost
+	rateLimits := getRateLimitActions("api1")
+	assert.Equal(t, 2, len(rateLimits), "Rate limit count mismatch")
+	assert.Equal(t, applicationDescriptorKey, rateLimits[0].GetActions()[0].GetMetadata().GetDescriptorKey(),
+		"Application descriptor key mismatch")
+	// the application is the one authenticated by the enforcer, rather than the one claimed by the client
+	assert.Equal(t, extAuthzFilterName, rateLimits[0].GetActions()[0].GetMetadata().GetMetadataKey().GetKey(),
+		"Application metadata namespace mismatch")
+	assert.Equal(t, applicationUUIDMetadataKey,
+		rateLimits[0].GetActions()[0].GetMetadata().GetMetadataKey().GetPath()[0].GetKey(),
+		"Application metadata key mismatch")
+	assert.Equal(t, "api1", rateLimits[1].GetActions()[1].GetGenericKey().GetDescriptorValue(),
+		"API UUID mismatch")
+}
+
 func generateRouteCreateParamsForUnitTests(title string, apiType string, vhost string, xWso2Basepath string, version string, endpointBasepath string,
 	resource *model.Resource, prodClusterName string, sandClusterName string,
-	corsConfig *model.CorsConfig, isDefaultVersion bool) *routeCreateParams {
+	corsConfig *model.CorsConfig) *routeCreateParams {
 	return &routeCreateParams{
 		title:            title,
 		apiType:          apiType,
@@ -685,6 +955,724 @@ func generateRouteCreateParamsForUnitTests(title string, apiType string, vhost s
 		sandClusterName:  sandClusterName,
 		endpointBasePath: endpointBasepath,
 		corsPolicy:       corsConfig,
-		isDefaultVersion: isDefaultVersion,
 	}
 }
+
+func TestGlobalPolicyHeaders(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	policies := conf.Envoy.GlobalPolicies
+	defer func() { conf.Envoy.GlobalPolicies = policies }()
+	conf.Envoy.GlobalPolicies.Request = []config.GlobalPolicy{
+		{Name: "removeDebug", Action: "REMOVE_HEADER", Parameters: map[string]string{"headerName": "x-debug"}},
+		{Name: "invalid", Action: "SET_HEADER", Parameters: map[string]string{"headerName": "x-foo"}},
+	}
+	conf.Envoy.GlobalPolicies.Response = []config.GlobalPolicy{
+		{Name: "hsts", Action: "SET_HEADER", Parameters: map[string]string{"headerName": "Strict-Transport-Security",
+			"headerValue": "max-age=31536000"}},
+		{Name: "waf", Action: "CALL_INTERCEPTOR_SERVICE"},
+	}
+	assert.Equal(t, 2, len(loadGlobalPolicies()), "Invalid global policies are not ignored")
+
+	route := &routev3.Route{
+		ResponseHeadersToAdd: []*corev3.HeaderValueOption{{Header: &corev3.HeaderValue{Key: "x-foo", Value: "bar"}}},
+	}
+	headers := getGlobalPolicyHeaders(func(policyName string) bool { return policyName == "removeDebug" })
+	headers.applyTo(route)
+	assert.Empty(t, route.GetRequestHeadersToRemove(), "Disabled global policy is applied")
+	assert.Equal(t, 2, len(route.GetResponseHeadersToAdd()), "Response headers to add mismatch")
+	assert.Equal(t, "Strict-Transport-Security", route.GetResponseHeadersToAdd()[0].GetHeader().GetKey(),
+		"Global policy should precede the operation policies")
+
+	route = &routev3.Route{}
+	getGlobalPolicyHeaders(func(string) bool { return false }).applyTo(route)
+	assert.Equal(t, []string{"x-debug"}, route.GetRequestHeadersToRemove(), "Request headers to remove mismatch")
+
+	conf.Envoy.GlobalPolicies.Request = nil
+	route = &routev3.Route{}
+	getGlobalPolicyHeaders(func(string) bool { return false }).applyTo(route)
+	assert.Empty(t, route.GetRequestHeadersToRemove(), "Changed global policies of the config are not applied")
+}
+
+func TestGenerateRateLimitServiceConfig(t *testing.T) {
+	serviceConfig, err := GenerateRateLimitServiceConfig("Default", []RateLimitDescriptor{
+		{ApplicationUUID: "app1", APIUUID: "api1", RequestCount: 5000, FillInterval: 2 * time.Hour},
+		{ApplicationUUID: "app1", RequestCount: 10, FillInterval: time.Minute},
+		{ApplicationUUID: "app2", RequestCount: 10, FillInterval: 7 * 24 * time.Hour},
+	})
+	assert.Nil(t, err, "Error while generating the rate limit service config")
+	expected := `domain: Default
+descriptors:
+- key: application
+  value: app1
+  descriptors:
+  - key: source_exemption
+    value: denied
+    rate_limit:
+      unit: minute
+      requests_per_unit: 10
+  - key: api
+    value: api1
+    descriptors:
+    - key: source_exemption
+      value: denied
+      rate_limit:
+        unit: hour
+        requests_per_unit: 2500
+- key: application
+  value: app2
+  descriptors:
+  - key: source_exemption
+    value: denied
+    rate_limit:
+      unit: day
+      requests_per_unit: 1
+`
+	assert.Equal(t, expected, string(serviceConfig), "Rate limit service config mismatch")
+}
+
+func TestGetDeprecationHeaders(t *testing.T) {
+	assert.Nil(t, getDeprecationHeaders(nil), "Headers are added to a version which is not deprecated")
+
+	headers := getDeprecationHeaders(&model.DeprecationConfig{})
+	assert.Equal(t, 1, len(headers), "Deprecation headers mismatch")
+	assert.Equal(t, "Deprecation", headers[0].GetHeader().GetKey())
+	assert.Equal(t, "true", headers[0].GetHeader().GetValue())
+
+	headers = getDeprecationHeaders(&model.DeprecationConfig{
+		DeprecatedAt:  time.Date(2023, time.June, 30, 23, 59, 59, 0, time.UTC),
+		SunsetAt:      time.Date(2023, time.December, 31, 0, 0, 0, 0, time.UTC),
+		Link:          "https://example.com/deprecation",
+		SuccessorLink: "/pizzashack/2.0.0",
+	})
+	assert.Equal(t, 3, len(headers), "Deprecation headers mismatch")
+	assert.Equal(t, "@1688169599", headers[0].GetHeader().GetValue(), "Deprecation header mismatch")
+	assert.Equal(t, "Sunset", headers[1].GetHeader().GetKey())
+	assert.Equal(t, "Sun, 31 Dec 2023 00:00:00 GMT", headers[1].GetHeader().GetValue(), "Sunset header mismatch")
+	assert.Equal(t, "Link", headers[2].GetHeader().GetKey())
+	assert.Equal(t, `<https://example.com/deprecation>; rel="deprecation", </pizzashack/2.0.0>; rel="successor-version"`,
+		headers[2].GetHeader().GetValue(), "Link header mismatch")
+	assert.Equal(t, corev3.HeaderValueOption_APPEND_IF_EXISTS_OR_ADD, headers[2].GetAppendAction(),
+		"Link header of the backend should be retained")
+
+	assert.Equal(t, "localhost_Pizza_Shack_API_1_0_0", getAPIVersionStatPrefix("localhost", "Pizza Shack.API", "1.0.0"))
+	assert.NotEqual(t, getAPIVersionStatPrefix("localhost", "PizzaShackAPI", "1.0.0"),
+		getAPIVersionStatPrefix("us.wso2.com", "PizzaShackAPI", "1.0.0"),
+		"Statistics of the API versions deployed in different vhosts should not be aggregated")
+}
+
+func TestHeaderTransformationPolicies(t *testing.T) {
+	headerToAppend, err := generateHeaderToAppendRouteConfig(map[string]interface{}{
+		"headerName": "Via", "headerValue": "choreo-connect"})
+	assert.Nil(t, err)
+	assert.Equal(t, "Via", headerToAppend.GetHeader().GetKey())
+	assert.Equal(t, corev3.HeaderValueOption_APPEND_IF_EXISTS_OR_ADD, headerToAppend.GetAppendAction(),
+		"Existing values of the header should be retained")
+	headerToAdd, err := generateHeaderToAddRouteConfig(map[string]interface{}{
+		"headerName": "x-region", "headerValue": "${application.attributes.region}/${application.attributes.zone}"})
+	assert.Nil(t, err)
+	assert.Equal(t, "%DYNAMIC_METADATA(envoy.filters.http.ext_authz:application.attributes.region)%/"+
+		"%DYNAMIC_METADATA(envoy.filters.http.ext_authz:application.attributes.zone)%",
+		headerToAdd.GetHeader().GetValue(), "Application attributes are not resolved from the metadata")
+
+	_, err = generateHeaderRename(map[string]interface{}{"headerName": "x-user"})
+	assert.NotNil(t, err, "Rename without the new header name is accepted")
+	_, err = generateHeaderRename(map[string]interface{}{"headerName": "x-user", "newHeaderName": "x-end\")user"})
+	assert.NotNil(t, err, "Invalid header name is accepted")
+
+	rename, err := generateHeaderRename(map[string]interface{}{"headerName": "X-User", "newHeaderName": "X-End-User"})
+	assert.Nil(t, err)
+	script := generateHeaderRenameScript([]*headerRename{rename}, nil)
+	assert.Contains(t, script, `local value = headers:get("x-user")`)
+	assert.Contains(t, script, `headers:remove("x-user")`)
+	assert.Contains(t, script, `headers:replace("x-end-user", value)`)
+	assert.Contains(t, script, "function envoy_on_response(response_handle)\nend\n",
+		"Response flow should not be modified")
+
+	filterConfigs := map[string]*anypb.Any{wellknown.Lua: {}}
+	renamedConfigs, err := withHeaderRenames(filterConfigs, nil, []*headerRename{rename})
+	assert.Nil(t, err)
+	assert.Contains(t, renamedConfigs, headerTransformFilterName)
+	assert.Contains(t, renamedConfigs, wellknown.Lua)
+	assert.NotContains(t, filterConfigs, headerTransformFilterName, "Shared filter configs are modified")
+	unchangedConfigs, _ := withHeaderRenames(filterConfigs, nil, nil)
+	assert.Equal(t, filterConfigs, unchangedConfigs)
+}
+
+func TestCreateStandbyRoutes(t *testing.T) {
+	route := &routev3.Route{
+		Name: "/pets",
+		Match: &routev3.RouteMatch{
+			PathSpecifier: &routev3.RouteMatch_SafeRegex{
+				SafeRegex: &envoy_type_matcherv3.RegexMatcher{Regex: "^/pets((?:/.*)*)"},
+			},
+		},
+		Action: &routev3.Route_Route{Route: &routev3.RouteAction{}},
+	}
+
+	standbyRoutes := CreateStandbyRoutes([]*routev3.Route{route}, nil)
+	assert.Len(t, standbyRoutes, 1)
+	assert.Equal(t, route.Match, standbyRoutes[0].Match, "Standby route matches a different path")
+	assert.Equal(t, uint32(503), standbyRoutes[0].GetDirectResponse().GetStatus())
+	assert.Contains(t, standbyRoutes[0].TypedPerFilterConfig, wellknown.HTTPExternalAuthorization,
+		"Enforcer is not disabled for the standby route")
+	assert.NotNil(t, route.GetRoute(), "Route of the API is modified")
+
+	redirectURL, _ := url.Parse("https://primary.example.com:9095")
+	standbyRoutes = CreateStandbyRoutes([]*routev3.Route{route}, redirectURL)
+	redirect := standbyRoutes[0].GetRedirect()
+	assert.NotNil(t, redirect, "Standby route does not redirect to the primary site")
+	assert.Equal(t, "https", redirect.GetSchemeRedirect())
+	assert.Equal(t, "primary.example.com", redirect.GetHostRedirect())
+	assert.Equal(t, uint32(9095), redirect.GetPortRedirect())
+	assert.Equal(t, routev3.RedirectAction_TEMPORARY_REDIRECT, redirect.GetResponseCode())
+}
+
+func TestAddAdvisoryHeaders(t *testing.T) {
+	route := &routev3.Route{
+		Name:   "/pets",
+		Action: &routev3.Route_Route{Route: &routev3.RouteAction{}},
+	}
+
+	advisedRoutes := AddAdvisoryHeaders([]*routev3.Route{route}, []string{"Version 1.0.0 is retired on \"2023-01-01\""})
+	assert.Len(t, advisedRoutes, 1)
+	assert.Len(t, advisedRoutes[0].ResponseHeadersToAdd, 1)
+	assert.Equal(t, "Warning", advisedRoutes[0].ResponseHeadersToAdd[0].Header.Key)
+	assert.Equal(t, `299 - "Version 1.0.0 is retired on \"2023-01-01\""`,
+		advisedRoutes[0].ResponseHeadersToAdd[0].Header.Value)
+	assert.Empty(t, route.ResponseHeadersToAdd, "Route of the API is modified")
+
+	advisoriesRoute := CreateAdvisoriesRoute("/petstore/v1/", []byte("[]"))
+	assert.Equal(t, "/petstore/v1/_advisories", advisoriesRoute.GetMatch().GetPath())
+	assert.Equal(t, uint32(200), advisoriesRoute.GetDirectResponse().GetStatus())
+	assert.Contains(t, advisoriesRoute.TypedPerFilterConfig, wellknown.HTTPExternalAuthorization,
+		"Enforcer is not disabled for the advisories route")
+}
+
+func TestLifecycleRoutes(t *testing.T) {
+	route := &routev3.Route{
+		Name:   "/pets",
+		Action: &routev3.Route_Route{Route: &routev3.RouteAction{}},
+	}
+
+	blockedRoutes := CreateBlockedRoutes([]*routev3.Route{route})
+	assert.Len(t, blockedRoutes, 1)
+	assert.Equal(t, uint32(503), blockedRoutes[0].GetDirectResponse().GetStatus())
+	assert.Contains(t, blockedRoutes[0].TypedPerFilterConfig, wellknown.HTTPExternalAuthorization,
+		"Enforcer is not disabled for the blocked route")
+	assert.NotNil(t, route.GetRoute(), "Route of the API is modified")
+
+	deprecatedRoutes := AddDeprecatedHeaders([]*routev3.Route{route}, false)
+	assert.Len(t, deprecatedRoutes[0].ResponseHeadersToAdd, 2)
+	assert.Equal(t, "Warning", deprecatedRoutes[0].ResponseHeadersToAdd[0].Header.Key)
+	assert.Equal(t, `299 - "The API is deprecated"`, deprecatedRoutes[0].ResponseHeadersToAdd[0].Header.Value)
+	assert.Equal(t, "Deprecation", deprecatedRoutes[0].ResponseHeadersToAdd[1].Header.Key)
+	assert.Equal(t, "true", deprecatedRoutes[0].ResponseHeadersToAdd[1].Header.Value)
+	deprecatedRoutes = AddDeprecatedHeaders([]*routev3.Route{route}, true)
+	assert.Len(t, deprecatedRoutes[0].ResponseHeadersToAdd, 1,
+		"Deprecation header should not be added again if the deprecation is advertised")
+	assert.Empty(t, route.ResponseHeadersToAdd, "Route of the API is modified")
+}
+
+func TestGenerateRateLimitHeadersScript(t *testing.T) {
+	script := generateRateLimitHeadersScript(constants.RateLimitHeadersFormat)
+	assert.Contains(t, script, `headers:replace("ratelimit-remaining", value)`)
+	assert.Contains(t, script, `if string.sub(limit, 1, 10) == "4294967295" then`,
+		"Headers of the unlimited token bucket should be removed")
+
+	script = generateRateLimitHeadersScript(constants.XRateLimitHeadersFormat)
+	assert.NotContains(t, script, "headers:replace", "X-RateLimit-* headers should not be renamed")
+
+	script = generateRateLimitHeadersScript(constants.RateLimitHeadersDisabled)
+	assert.Contains(t, script, "  do\n    headers:remove(\"x-ratelimit-limit\")")
+	assert.NotContains(t, script, "headers:replace")
+}
+
+func TestApplyConcurrencyLimits(t *testing.T) {
+	cluster := &clusterv3.Cluster{}
+	applyConcurrencyLimits(cluster, nil)
+	assert.Nil(t, cluster.CircuitBreakers)
+
+	applyConcurrencyLimits(cluster, &model.ConcurrencyLimits{MaxConcurrentRequests: 50})
+	assert.Equal(t, uint32(50), cluster.CircuitBreakers.Thresholds[0].MaxRequests.GetValue())
+
+	// the lower limit of the endpoint configuration is retained
+	cluster.CircuitBreakers.Thresholds[0].MaxRequests = wrapperspb.UInt32(20)
+	cluster.CircuitBreakers.Thresholds[0].MaxConnections = wrapperspb.UInt32(10)
+	applyConcurrencyLimits(cluster, &model.ConcurrencyLimits{MaxConcurrentRequests: 50})
+	assert.Equal(t, uint32(20), cluster.CircuitBreakers.Thresholds[0].MaxRequests.GetValue())
+	assert.Equal(t, uint32(10), cluster.CircuitBreakers.Thresholds[0].MaxConnections.GetValue())
+}
+
+func TestCreateRouteWithTimeouts(t *testing.T) {
+	resourceWithGet := model.CreateMinimalDummyResourceForTests("/orders", []*model.Operation{model.NewOperation("GET", nil, nil)},
+		"resource_operation_id", []model.Endpoint{}, []model.Endpoint{})
+	params := generateRouteCreateParamsForUnitTests("WSO2", "HTTP", "localhost", "/orders", "1.0", "/basepath",
+		&resourceWithGet, "resource_operation_id", "", nil)
+
+	conf, _ := config.ReadConfigs()
+	routes, err := createRoutes(params)
+	assert.Nil(t, err, "Error while creating routes")
+	assert.Equal(t, time.Duration(conf.Envoy.Upstream.Timeouts.RouteTimeoutInSeconds)*time.Second,
+		routes[0].GetRoute().GetTimeout().AsDuration(), "Route timeout of the router should be applied by default")
+
+	params.timeouts = &model.Timeouts{RouteTimeoutInMillis: 1500}
+	routes, err = createRoutes(params)
+	assert.Nil(t, err, "Error while creating routes")
+	assert.Equal(t, 1500*time.Millisecond, routes[0].GetRoute().GetTimeout().AsDuration(), "Route timeout mismatch")
+
+	params.streamingConfig = &model.StreamingConfig{Enabled: true}
+	routes, err = createRoutes(params)
+	assert.Nil(t, err, "Error while creating routes")
+	assert.Equal(t, time.Duration(0), routes[0].GetRoute().GetTimeout().AsDuration(),
+		"Route timeout should remain disabled for streaming routes")
+}
+
+func TestApplyClusterTimeoutsAndRetryBudget(t *testing.T) {
+	cluster := &clusterv3.Cluster{ConnectTimeout: durationpb.New(20 * time.Second)}
+	applyClusterTimeouts(cluster, nil)
+	applyRetryBudget(cluster, nil)
+	assert.Equal(t, 20*time.Second, cluster.ConnectTimeout.AsDuration())
+	assert.Nil(t, cluster.CircuitBreakers)
+
+	applyClusterTimeouts(cluster, &model.Timeouts{ConnectTimeoutInMillis: 250})
+	assert.Equal(t, 250*time.Millisecond, cluster.ConnectTimeout.AsDuration())
+
+	// the retry budget is added to the thresholds of the endpoint configuration
+	cluster.CircuitBreakers = &clusterv3.CircuitBreakers{
+		Thresholds: []*clusterv3.CircuitBreakers_Thresholds{{MaxConnections: wrapperspb.UInt32(10)}},
+	}
+	applyRetryBudget(cluster, &model.RetryBudget{BudgetPercent: 20, MinRetryConcurrency: 3})
+	thresholds := cluster.CircuitBreakers.Thresholds[0]
+	assert.Equal(t, uint32(10), thresholds.MaxConnections.GetValue())
+	assert.Equal(t, float64(20), thresholds.RetryBudget.GetBudgetPercent().GetValue())
+	assert.Equal(t, uint32(3), thresholds.RetryBudget.GetMinRetryConcurrency().GetValue())
+}
+
+func TestIPRangeToCIDRs(t *testing.T) {
+	tests := []struct {
+		start, end string
+		expected   []string
+	}{
+		{"10.0.0.1", "10.0.0.1", []string{"10.0.0.1/32"}},
+		{"10.0.0.0", "10.0.0.255", []string{"10.0.0.0/24"}},
+		{"10.0.0.5", "10.0.0.17", []string{"10.0.0.5/32", "10.0.0.6/31", "10.0.0.8/29", "10.0.0.16/31"}},
+		{"0.0.0.0", "255.255.255.255", []string{"0.0.0.0/0"}},
+		{"2001:db8::", "2001:db8::ffff", []string{"2001:db8::/112"}},
+	}
+	for _, test := range tests {
+		ipNets, err := ipRangeToCIDRs(net.ParseIP(test.start), net.ParseIP(test.end))
+		assert.Nil(t, err, "Error while converting %s - %s", test.start, test.end)
+		cidrs := make([]string, 0, len(ipNets))
+		for _, ipNet := range ipNets {
+			cidrs = append(cidrs, ipNet.String())
+		}
+		assert.Equal(t, test.expected, cidrs, "CIDRs mismatch for %s - %s", test.start, test.end)
+	}
+
+	for _, invalid := range [][]string{{"10.0.0.2", "10.0.0.1"}, {"10.0.0.1", "2001:db8::"}, {"10.0.0.1", "x"}} {
+		_, err := ipRangeToCIDRs(net.ParseIP(invalid[0]), net.ParseIP(invalid[1]))
+		assert.NotNil(t, err, "Range %v should be invalid", invalid)
+	}
+}
+
+func TestAddIPRestriction(t *testing.T) {
+	routes := []*routev3.Route{{Name: "/pets/1.0.0/pets"}}
+	assert.Equal(t, routes, AddIPRestriction(routes, nil, nil), "Routes should not be changed without restrictions")
+
+	restriction := &model.IPRestriction{Allow: []string{"10.0.0.0/8", "invalid"}, Deny: []string{"10.1.2.3"}}
+	blockedIPs := []*throttle.IPCondition{
+		{Id: 1, Type: "IPRANGE", StartingIp: "192.168.0.0", EndingIp: "192.168.0.255"},
+		{Id: 2, Type: "IP", FixedIp: "172.16.0.1", Invert: true},
+		{Id: 3, Type: "IP", FixedIp: "not-an-ip"},
+	}
+	restrictedRoutes := AddIPRestriction(routes, restriction, blockedIPs)
+	assert.Empty(t, routes[0].TypedPerFilterConfig, "Routes of the API are modified")
+	rbacPerRoute := &rbacv3.RBACPerRoute{}
+	err := restrictedRoutes[0].TypedPerFilterConfig[ipRestrictionFilterName].UnmarshalTo(rbacPerRoute)
+	assert.Nil(t, err, "Error while parsing the RBAC per route config")
+	policy := rbacPerRoute.GetRbac().GetRules().GetPolicies()[ipRestrictionPolicyName]
+	assert.Equal(t, rbac_config_v3.RBAC_ALLOW, rbacPerRoute.GetRbac().GetRules().GetAction())
+
+	// allowed CIDRs AND NOT (denied CIDRs OR blocked IPs)
+	ids := policy.GetPrincipals()[0].GetAndIds().GetIds()
+	assert.Len(t, ids, 2)
+	assert.Equal(t, "10.0.0.0", ids[0].GetDirectRemoteIp().GetAddressPrefix())
+	denied := ids[1].GetNotId().GetOrIds().GetIds()
+	assert.Len(t, denied, 3)
+	assert.Equal(t, "10.1.2.3", denied[0].GetDirectRemoteIp().GetAddressPrefix())
+	assert.Equal(t, uint32(32), denied[0].GetDirectRemoteIp().GetPrefixLen().GetValue())
+	assert.Equal(t, "192.168.0.0", denied[1].GetDirectRemoteIp().GetAddressPrefix())
+	assert.Equal(t, uint32(24), denied[1].GetDirectRemoteIp().GetPrefixLen().GetValue())
+	assert.Equal(t, "172.16.0.1", denied[2].GetNotId().GetDirectRemoteIp().GetAddressPrefix(),
+		"All the sources except the IP should be denied for an inverted condition")
+
+	// none of the sources are allowed, if all the allowed CIDRs are invalid
+	restrictedRoutes = AddIPRestriction(routes, &model.IPRestriction{Allow: []string{"invalid"}}, nil)
+	err = restrictedRoutes[0].TypedPerFilterConfig[ipRestrictionFilterName].UnmarshalTo(rbacPerRoute)
+	assert.Nil(t, err, "Error while parsing the RBAC per route config")
+	principal := rbacPerRoute.GetRbac().GetRules().GetPolicies()[ipRestrictionPolicyName].GetPrincipals()[0]
+	assert.True(t, principal.GetNotId().GetAny())
+}
+
+func TestCreateRouteWithResponseCompression(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	existing := conf.Envoy.Filters.Compression
+	defer func() { conf.Envoy.Filters.Compression = existing }()
+	conf.Envoy.Filters.Compression.Enabled = true
+	conf.Envoy.Filters.Compression.Profiles = []config.CompressionProfile{{Name: "brotli-json", Library: "brotli"}}
+
+	resourceWithGet := model.CreateMinimalDummyResourceForTests("/orders", []*model.Operation{model.NewOperation("GET", nil, nil)},
+		"resource_operation_id", []model.Endpoint{}, []model.Endpoint{})
+	params := generateRouteCreateParamsForUnitTests("WSO2", "HTTP", "localhost", "/orders", "1.0", "/basepath",
+		&resourceWithGet, "resource_operation_id", "", nil)
+	getCompressorPerRoute := func(route *routev3.Route, filterName string) *compressorv3.CompressorPerRoute {
+		perFilterConfig, found := route.GetTypedPerFilterConfig()[filterName]
+		if !found {
+			return nil
+		}
+		compressorPerRoute := &compressorv3.CompressorPerRoute{}
+		assert.Nil(t, perFilterConfig.UnmarshalTo(compressorPerRoute), "Error while parsing compressor per route config")
+		return compressorPerRoute
+	}
+
+	routes, err := createRoutes(params)
+	assert.Nil(t, err, "Error while creating routes")
+	assert.Nil(t, getCompressorPerRoute(routes[0], compressorFilterName),
+		"Compression of the router should be applied without the response compression of the API")
+
+	params.responseCompression = &model.ResponseCompression{Enabled: false}
+	routes, err = createRoutes(params)
+	assert.Nil(t, err, "Error while creating routes")
+	assert.True(t, getCompressorPerRoute(routes[0], compressorFilterName).GetDisabled(),
+		"Compressor filter should be disabled for the route")
+
+	params.responseCompression = &model.ResponseCompression{Enabled: true}
+	routes, err = createRoutes(params)
+	assert.Nil(t, err, "Error while creating routes")
+	assert.NotNil(t, getCompressorPerRoute(routes[0], compressorFilterName).GetOverrides().GetResponseDirectionConfig(),
+		"Response compression should be enabled for the route")
+
+	params.responseCompression = &model.ResponseCompression{Enabled: true, Profile: "brotli-json"}
+	routes, err = createRoutes(params)
+	assert.Nil(t, err, "Error while creating routes")
+	assert.True(t, getCompressorPerRoute(routes[0], compressorFilterName).GetDisabled(),
+		"Compressor filter of the router should be disabled for the routes with a compression profile")
+	assert.NotNil(t, getCompressorPerRoute(routes[0], compressorFilterName+".brotli-json").GetOverrides(),
+		"Compressor filter of the profile should be enabled for the route")
+
+	params.responseCompression = &model.ResponseCompression{Enabled: true, Profile: "unknown"}
+	routes, err = createRoutes(params)
+	assert.Nil(t, err, "Error while creating routes")
+	assert.Nil(t, getCompressorPerRoute(routes[0], compressorFilterName),
+		"Response compression with an unknown profile should not be applied")
+}
+
+func TestCreateRouteWithResponseCache(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	existing := conf.Envoy.Filters.ResponseCache
+	defer func() { conf.Envoy.Filters.ResponseCache = existing }()
+	conf.Envoy.Filters.ResponseCache.Enabled = true
+	conf.Envoy.Filters.ResponseCache.AllowedVaryHeaders = []string{"Accept-Language", "x-no-cache"}
+
+	unsecured := map[string]interface{}{constants.XWso2DisableSecurity: true}
+	resourceWithGetPost := model.CreateMinimalDummyResourceForTests("/orders",
+		[]*model.Operation{model.NewOperation("GET", nil, unsecured), model.NewOperation("POST", nil, nil)},
+		"resource_operation_id", []model.Endpoint{}, []model.Endpoint{})
+	params := generateRouteCreateParamsForUnitTests("WSO2", "HTTP", "localhost", "/orders", "1.0", "/basepath",
+		&resourceWithGetPost, "resource_operation_id", "", nil)
+	getResponseHeaders := func(route *routev3.Route) map[string]string {
+		headers := make(map[string]string)
+		for _, header := range route.GetResponseHeadersToAdd() {
+			headers[header.GetHeader().GetKey()] = header.GetHeader().GetValue()
+		}
+		return headers
+	}
+
+	routes, err := createRoutes(params)
+	assert.Nil(t, err, "Error while creating routes")
+	assert.NotContains(t, getResponseHeaders(routes[0]), cacheControlHeader,
+		"Cache-Control of the backend should be applied without the response cache of the resource")
+
+	params.responseCache = &model.ResponseCache{Enabled: true, TTLInSeconds: 300,
+		KeyHeaders: []string{"accept-language"}, BypassHeaders: []string{"x-no-cache"}}
+	routes, err = createRoutes(params)
+	assert.Nil(t, err, "Error while creating routes")
+	if assert.Len(t, routes, 2, "A route bypassing the cache should be added") {
+		bypassHeaders := getResponseHeaders(routes[0])
+		assert.Equal(t, noStoreCacheControl, bypassHeaders[cacheControlHeader])
+		assert.Equal(t, "accept-language, x-no-cache", bypassHeaders[varyHeader])
+		assert.Contains(t, routes[0].GetMatch().GetHeaders(), &routev3.HeaderMatcher{Name: "x-no-cache",
+			HeaderMatchSpecifier: &routev3.HeaderMatcher_PresentMatch{PresentMatch: true}})
+		cachedHeaders := getResponseHeaders(routes[1])
+		assert.Equal(t, "max-age=300", cachedHeaders[cacheControlHeader])
+		assert.Equal(t, "accept-language, x-no-cache", cachedHeaders[varyHeader])
+		for _, header := range routes[1].GetResponseHeadersToAdd() {
+			if header.GetHeader().GetKey() == cacheControlHeader {
+				assert.Equal(t, corev3.HeaderValueOption_ADD_IF_ABSENT, header.GetAppendAction(),
+					"Cache-Control of the backend should not be overwritten")
+			}
+		}
+	}
+
+	securedResource := model.CreateMinimalDummyResourceForTests("/orders",
+		[]*model.Operation{model.NewOperation("GET", nil, nil)}, "resource_operation_id", []model.Endpoint{},
+		[]model.Endpoint{})
+	securedParams := generateRouteCreateParamsForUnitTests("WSO2", "HTTP", "localhost", "/orders", "1.0",
+		"/basepath", &securedResource, "resource_operation_id", "", nil)
+	securedParams.responseCache = &model.ResponseCache{Enabled: true, TTLInSeconds: 300}
+	routes, err = createRoutes(securedParams)
+	assert.Nil(t, err, "Error while creating routes")
+	if assert.Len(t, routes, 1) {
+		assert.NotContains(t, getResponseHeaders(routes[0]), cacheControlHeader,
+			"Responses of a secured resource should not be cached")
+	}
+	securedParams.disableSecurity = true
+	routes, err = createRoutes(securedParams)
+	assert.Nil(t, err, "Error while creating routes")
+	assert.Equal(t, "max-age=300", getResponseHeaders(routes[0])[cacheControlHeader],
+		"Responses of a resource of an API without security should be cached")
+
+	params.responseCache = &model.ResponseCache{Enabled: false}
+	routes, err = createRoutes(params)
+	assert.Nil(t, err, "Error while creating routes")
+	assert.Equal(t, noStoreCacheControl, getResponseHeaders(routes[0])[cacheControlHeader],
+		"Responses of the resource should not be cached")
+
+	params.responseCache = &model.ResponseCache{Enabled: true, TTLInSeconds: 300, KeyHeaders: []string{"cookie"}}
+	routes, err = createRoutes(params)
+	assert.Nil(t, err, "Error while creating routes")
+	assert.NotContains(t, getResponseHeaders(routes[0]), cacheControlHeader,
+		"Response cache varying by a header not allowed by the router should not be applied")
+
+	routes = []*routev3.Route{{Match: &routev3.RouteMatch{Headers: generateHTTPMethodMatcher("POST|PUT", false, "")}}}
+	assert.Equal(t, routes, applyResponseCache(routes, &model.ResponseCache{Enabled: true, TTLInSeconds: 300}, false))
+	assert.Empty(t, routes[0].GetResponseHeadersToAdd(), "Responses of the POST requests should not be cached")
+}
+
+func TestGetCompressionProfileFilters(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	existing := conf.Envoy.Filters.Compression
+	defer func() { conf.Envoy.Filters.Compression = existing }()
+	conf.Envoy.Filters.Compression.Profiles = []config.CompressionProfile{
+		{Name: "brotli-json", Library: "brotli", CompressionLevel: 5, MinimumContentLength: 1024,
+			ContentType: []string{"application/json"}},
+		{Name: "brotli-json", Library: "gzip"},
+		{Name: "zstd", Library: "zstd"},
+		{Name: "gzip-max", CompressionLevel: 10},
+		{Name: "gzip-fast", CompressionLevel: 1},
+	}
+
+	filters := getCompressionProfileFilters()
+	if assert.Len(t, filters, 2, "Filters should be created only for the valid profiles") {
+		assert.Equal(t, compressorFilterName+".brotli-json", filters[0].Name)
+		compressor := &compressorv3.Compressor{}
+		assert.Nil(t, filters[0].GetTypedConfig().UnmarshalTo(compressor), "Error while parsing compressor config")
+		responseConfig := compressor.GetResponseDirectionConfig().GetCommonConfig()
+		assert.False(t, responseConfig.GetEnabled().GetDefaultValue().GetValue(),
+			"Response compression of a profile should be disabled by default")
+		assert.Equal(t, uint32(1024), responseConfig.GetMinContentLength().GetValue())
+		assert.Equal(t, []string{"application/json"}, responseConfig.GetContentType())
+		brotli := &brotliv3.Brotli{}
+		assert.Nil(t, compressor.GetCompressorLibrary().GetTypedConfig().UnmarshalTo(brotli),
+			"Brotli library should be configured")
+		assert.Equal(t, uint32(5), brotli.GetQuality().GetValue())
+
+		assert.Equal(t, compressorFilterName+".gzip-fast", filters[1].Name)
+		assert.Nil(t, filters[1].GetTypedConfig().UnmarshalTo(compressor), "Error while parsing compressor config")
+		gzip := &gzipv3.Gzip{}
+		assert.Nil(t, compressor.GetCompressorLibrary().GetTypedConfig().UnmarshalTo(gzip),
+			"Gzip library of the router should be configured")
+		assert.Equal(t, gzipv3.Gzip_COMPRESSION_LEVEL_1, gzip.GetCompressionLevel())
+	}
+}
+
+func TestApplyWebSubTopicMatchers(t *testing.T) {
+	topics := []string{"orders", "orders.created"}
+	hubRoutes := []*routev3.Route{{Match: generateRouteMatch("^/hub/1.0.0/?$")}}
+	applyWebSubTopicMatchers(hubRoutes, constants.WebSubHubResource, topics)
+	queryParams := hubRoutes[0].GetMatch().GetQueryParameters()
+	if assert.Len(t, queryParams, 1, "Hub route should match the declared topics") {
+		assert.Equal(t, constants.WebSubHubTopicQueryParam, queryParams[0].GetName())
+		assert.Equal(t, `^(orders|orders\.created)$`, queryParams[0].GetStringMatch().GetSafeRegex().GetRegex())
+	}
+
+	receiverRoutes := []*routev3.Route{{Match: generateRouteMatch("^/hub/1.0.0/webhooks_events_receiver_resource")}}
+	applyWebSubTopicMatchers(receiverRoutes, constants.WebSubEventsReceiverResource, topics)
+	assert.Equal(t, constants.WebSubTopicQueryParam, receiverRoutes[0].GetMatch().GetQueryParameters()[0].GetName())
+
+	otherRoutes := []*routev3.Route{{Match: generateRouteMatch("^/hub/1.0.0/other")}}
+	applyWebSubTopicMatchers(otherRoutes, "/other", topics)
+	assert.Empty(t, otherRoutes[0].GetMatch().GetQueryParameters(), "Only the hub resources should be restricted")
+}
+
+func TestGenerateRewriteURLRouteConfig(t *testing.T) {
+	rewrite, err := generateRewriteURLRouteConfig("/shop/1.0.0", "/api/", map[string]interface{}{
+		constants.RewriteURLPattern:      "^/users/([^/]+)/orders/([0-9]+)$",
+		constants.RewriteURLSubstitution: `/orders/\2/owner/\1`,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, `^/shop/1\.0\.0/users/([^/]+)/orders/([0-9]+)$`, rewrite.GetPattern().GetRegex(),
+		"Basepath should be quoted in the pattern")
+	assert.Equal(t, `/api/orders/\2/owner/\1`, rewrite.GetSubstitution())
+
+	// the quoted basepath is replaced in the default version routes
+	route := &routev3.Route{
+		Match:  generateRouteMatch("^/shop/1.0.0/users/([^/]+)/orders/([0-9]+)"),
+		Action: &routev3.Route_Route{Route: &routev3.RouteAction{RegexRewrite: rewrite}},
+	}
+	defaultRoutes := CreateDefaultVersionRoutes("/shop/1.0.0", "1.0.0", []*routev3.Route{route})
+	if assert.Len(t, defaultRoutes, 1) {
+		assert.Equal(t, "^/shop/users/([^/]+)/orders/([0-9]+)$",
+			defaultRoutes[0].GetRoute().GetRegexRewrite().GetPattern().GetRegex())
+	}
+
+	_, err = generateRewriteURLRouteConfig("/shop/1.0.0", "", map[string]interface{}{
+		constants.RewriteURLPattern:      "/users/([^/]+)",
+		constants.RewriteURLSubstitution: `/users/\2`,
+	})
+	assert.NotNil(t, err, "Substitution referring to an unknown capture group is accepted")
+	_, err = generateRewriteURLRouteConfig("/shop/1.0.0", "", map[string]interface{}{
+		constants.RewriteURLPattern:      "/users/(?=admin)",
+		constants.RewriteURLSubstitution: "/admins",
+	})
+	assert.NotNil(t, err, "Pattern not supported by RE2 is accepted")
+	_, err = generateRewriteURLRouteConfig("/shop/1.0.0", "", map[string]interface{}{
+		constants.RewriteURLSubstitution: "/admins",
+	})
+	assert.NotNil(t, err, "Rewrite without a pattern is accepted")
+}
+
+func TestMethodOverride(t *testing.T) {
+	method, err := getOverrideMethod(map[string]interface{}{constants.UpdatedMethod: "put"})
+	assert.Nil(t, err)
+	assert.Equal(t, "PUT", method)
+	_, err = getOverrideMethod(map[string]interface{}{constants.UpdatedMethod: "CONNECT"})
+	assert.NotNil(t, err, "Unsupported method is accepted")
+
+	script := generateHeaderTransformationScript(nil, nil, method)
+	assert.Contains(t, script, "function envoy_on_request(request_handle)\n  local headers = request_handle:headers()\n"+
+		"  headers:replace(\":method\", \"PUT\")\nend\n")
+	assert.Contains(t, script, "function envoy_on_response(response_handle)\nend\n")
+
+	filterConfigs := map[string]*anypb.Any{wellknown.Lua: {}}
+	overriddenConfigs, err := withHeaderTransformations(filterConfigs, nil, nil, method)
+	assert.Nil(t, err)
+	assert.Contains(t, overriddenConfigs, headerTransformFilterName)
+}
+
+func TestSetGRPCTranscoders(t *testing.T) {
+	descriptorSet := &descriptorpb.FileDescriptorSet{
+		File: []*descriptorpb.FileDescriptorProto{{
+			Name:    proto.String("bookstore.proto"),
+			Package: proto.String("bookstore"),
+			Service: []*descriptorpb.ServiceDescriptorProto{{
+				Name: proto.String("Bookstore"),
+				Method: []*descriptorpb.MethodDescriptorProto{
+					{Name: proto.String("GetShelf"), InputType: proto.String(".bookstore.GetShelfRequest"),
+						OutputType: proto.String(".bookstore.Shelf")},
+				},
+			}},
+		}},
+	}
+	protoDescriptor, err := proto.Marshal(descriptorSet)
+	assert.Nil(t, err)
+	transcoding := &model.GRPCTranscoding{Services: []string{"bookstore.Bookstore"}, ProtoDescriptor: protoDescriptor}
+	operations := []*model.Operation{
+		model.NewOperation("GET", nil, map[string]interface{}{constants.XWso2GRPCMethod: "bookstore.Bookstore/GetShelf"}),
+		model.NewOperation("DELETE", nil, nil),
+	}
+	resource := model.CreateMinimalDummyResourceForTests("/shelves/{shelf}", operations, "", nil, nil)
+
+	// the operations of the default version are mapped with the unversioned path as well
+	transcoder, err := createGRPCTranscoder(transcoding, []*model.Resource{&resource}, []string{"/shop/1.0.0", "/shop"})
+	assert.Nil(t, err)
+	otherTranscoder, err := createGRPCTranscoder(transcoding, []*model.Resource{&resource}, []string{"/store/1.0.0"})
+	assert.Nil(t, err)
+
+	virtualHosts := []*routev3.VirtualHost{{Name: "localhost"}, {Name: "us.wso2.com"}}
+	SetGRPCTranscoders(virtualHosts, map[string][]*GRPCTranscoder{"localhost": {transcoder, otherTranscoder}})
+	assert.NotContains(t, virtualHosts[1].GetTypedPerFilterConfig(), wellknown.GRPCJSONTranscoder,
+		"Requests of a vhost without gRPC APIs are transcoded")
+	var transcoderConfig grpc_json_transcoderv3.GrpcJsonTranscoder
+	err = virtualHosts[0].TypedPerFilterConfig[wellknown.GRPCJSONTranscoder].UnmarshalTo(&transcoderConfig)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"bookstore.Bookstore"}, transcoderConfig.GetServices())
+	assert.True(t, transcoderConfig.GetMatchIncomingRequestRoute())
+
+	// the proto file shared by the APIs is included once, with the bindings of both the APIs
+	var transcodedSet descriptorpb.FileDescriptorSet
+	assert.Nil(t, proto.Unmarshal(transcoderConfig.GetProtoDescriptorBin(), &transcodedSet))
+	if !assert.Len(t, transcodedSet.GetFile(), 1) {
+		return
+	}
+	method := transcodedSet.GetFile()[0].GetService()[0].GetMethod()[0]
+	rule, _ := proto.GetExtension(method.GetOptions(), annotations.E_Http).(*annotations.HttpRule)
+	assert.Equal(t, "/shop/1.0.0/shelves/{shelf}", rule.GetGet(), "Operation is not mapped to the gRPC method")
+	if assert.Len(t, rule.GetAdditionalBindings(), 2) {
+		assert.Equal(t, "/shop/shelves/{shelf}", rule.GetAdditionalBindings()[0].GetGet(),
+			"Operation of the default version is not mapped with the unversioned path")
+		assert.Equal(t, "/store/1.0.0/shelves/{shelf}", rule.GetAdditionalBindings()[1].GetGet())
+	}
+	// the descriptor sets of the transcoders are not modified, as those are reused by the later snapshots
+	rule, _ = proto.GetExtension(transcoder.descriptorSet.GetFile()[0].GetService()[0].GetMethod()[0].GetOptions(),
+		annotations.E_Http).(*annotations.HttpRule)
+	assert.Len(t, rule.GetAdditionalBindings(), 1)
+
+	// the bindings of the proto files are kept, and the same binding is not added twice
+	addHTTPRule(method, "POST", "/shop/1.0.0/shelves")
+	addHTTPRule(method, "POST", "/shop/1.0.0/shelves")
+	rule, _ = proto.GetExtension(method.GetOptions(), annotations.E_Http).(*annotations.HttpRule)
+	assert.Equal(t, "/shop/1.0.0/shelves/{shelf}", rule.GetGet())
+	if assert.Len(t, rule.GetAdditionalBindings(), 3) {
+		assert.Equal(t, "/shop/1.0.0/shelves", rule.GetAdditionalBindings()[2].GetPost())
+		assert.Equal(t, "*", rule.GetAdditionalBindings()[2].GetBody())
+	}
+
+	operations = []*model.Operation{
+		model.NewOperation("GET", nil, map[string]interface{}{constants.XWso2GRPCMethod: "bookstore.Bookstore/ListShelves"}),
+	}
+	resource = model.CreateMinimalDummyResourceForTests("/shelves", operations, "", nil, nil)
+	_, err = createGRPCTranscoder(transcoding, []*model.Resource{&resource}, []string{"/shop/1.0.0"})
+	assert.NotNil(t, err, "Operation mapped to an unknown gRPC method is accepted")
+	_, err = createGRPCTranscoder(&model.GRPCTranscoding{Services: []string{"bookstore.Library"},
+		ProtoDescriptor: protoDescriptor}, nil, []string{"/shop/1.0.0"})
+	assert.NotNil(t, err, "Unknown gRPC service is accepted")
+	_, err = createGRPCTranscoder(&model.GRPCTranscoding{Services: []string{"bookstore.Bookstore"}}, nil,
+		[]string{"/shop/1.0.0"})
+	assert.NotNil(t, err, "Transcoding without a proto descriptor set is accepted")
+}
+
+func TestApplySOAPPassthrough(t *testing.T) {
+	match := generateRouteMatch("^/phoneverify/1.0.0((?:/.*)*)")
+	match.Headers = generateHTTPMethodMatcher(includeOptionsMethod("POST"), false, "")
+	route := &routev3.Route{
+		Name:                   "^/phoneverify/1.0.0((?:/.*)*)",
+		Match:                  match,
+		Action:                 &routev3.Route_Route{Route: &routev3.RouteAction{}},
+		RequestHeadersToRemove: []string{"x-debug", "soapaction"},
+	}
+	routes := applySOAPPassthrough([]*routev3.Route{route}, []string{constants.SOAP12Version})
+	if !assert.Len(t, routes, 2, "Unsupported media type route is not created") {
+		return
+	}
+	assert.Equal(t, route, routes[1], "Passthrough route should follow the unsupported media type route")
+	assert.Equal(t, []string{"x-debug"}, route.GetRequestHeadersToRemove(), "SOAPAction header is removed")
+	assert.Equal(t, "^POST|OPTIONS$", route.GetMatch().GetHeaders()[0].GetStringMatch().GetSafeRegex().GetRegex(),
+		"Method matcher of the passthrough route is changed")
+
+	rejectRoute := routes[0]
+	headers := rejectRoute.GetMatch().GetHeaders()
+	if assert.Len(t, headers, 2) {
+		assert.Equal(t, "^POST$", headers[0].GetStringMatch().GetSafeRegex().GetRegex())
+		assert.Equal(t, contentTypeHeaderName, headers[1].GetName())
+		assert.Equal(t, `^(application/soap\+xml)(;.*)?$`, headers[1].GetStringMatch().GetSafeRegex().GetRegex())
+		assert.True(t, headers[1].GetInvertMatch(), "Content type matcher should match the other content types")
+	}
+	assert.Equal(t, uint32(415), rejectRoute.GetDirectResponse().GetStatus())
+	assert.Contains(t, rejectRoute.GetDirectResponse().GetBody().GetInlineString(), "Unsupported Media Type")
+	assert.Equal(t, contentTypeHeaderSoap, rejectRoute.GetResponseHeadersToAdd()[0].GetHeader().GetValue())
+	assert.Contains(t, rejectRoute.GetTypedPerFilterConfig(), wellknown.HTTPExternalAuthorization,
+		"Requests of the unsupported media types should not be authenticated")
+
+	assert.Equal(t, []string{contentTypeHeaderXML, contentTypeHeaderSoap}, getSOAPContentTypes(nil))
+}
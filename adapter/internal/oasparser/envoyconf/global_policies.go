@@ -0,0 +1,130 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package envoyconf
+
+import (
+	"fmt"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+
+	"github.com/wso2/product-microgateway/adapter/config"
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/constants"
+	"github.com/wso2/product-microgateway/adapter/pkg/logging"
+)
+
+// globalPolicy is a global policy of the config, translated to the route config.
+type globalPolicy struct {
+	name           string
+	isRequestFlow  bool
+	headerToAdd    *corev3.HeaderValueOption
+	headerToRemove string
+}
+
+// globalPolicyHeaders contains the headers of the global policies applied to the routes of an API.
+type globalPolicyHeaders struct {
+	requestHeadersToAdd     []*corev3.HeaderValueOption
+	requestHeadersToRemove  []string
+	responseHeadersToAdd    []*corev3.HeaderValueOption
+	responseHeadersToRemove []string
+}
+
+// loadGlobalPolicies translates the global policies of the config, hence the routes generated after a change of
+// the config apply the changed policies. The invalid policies are logged and ignored.
+func loadGlobalPolicies() []globalPolicy {
+	conf, _ := config.ReadConfigs()
+	return append(translateGlobalPolicies(conf.Envoy.GlobalPolicies.Request, true),
+		translateGlobalPolicies(conf.Envoy.GlobalPolicies.Response, false)...)
+}
+
+func translateGlobalPolicies(configPolicies []config.GlobalPolicy, isRequestFlow bool) []globalPolicy {
+	var policies []globalPolicy
+	for _, configPolicy := range configPolicies {
+		params := make(map[string]interface{}, len(configPolicy.Parameters))
+		for key, value := range configPolicy.Parameters {
+			params[key] = value
+		}
+		policy := globalPolicy{name: configPolicy.Name, isRequestFlow: isRequestFlow}
+		var err error
+		switch configPolicy.Action {
+		case constants.ActionHeaderAdd:
+			policy.headerToAdd, err = generateHeaderToAddRouteConfig(params)
+		case constants.ActionHeaderRemove:
+			policy.headerToRemove, err = generateHeaderToRemoveString(params)
+		default:
+			// the logging and the WAF (interceptor service) policies are not supported as global policies
+			err = fmt.Errorf("action %q is not supported for the global policies", configPolicy.Action)
+		}
+		if err != nil {
+			logger.LoggerOasparser.ErrorC(logging.ErrorDetails{
+				Message:   fmt.Sprintf("Global policy %q is ignored. %v", configPolicy.Name, err),
+				Severity:  logging.MAJOR,
+				ErrorCode: 2242,
+			})
+			continue
+		}
+		policies = append(policies, policy)
+	}
+	return policies
+}
+
+// getGlobalPolicyHeaders returns the headers of the global policies, which are not disabled for the API.
+func getGlobalPolicyHeaders(isPolicyDisabled func(policyName string) bool) globalPolicyHeaders {
+	var headers globalPolicyHeaders
+	for _, policy := range loadGlobalPolicies() {
+		if isPolicyDisabled(policy.name) {
+			continue
+		}
+		if policy.isRequestFlow {
+			if policy.headerToAdd != nil {
+				headers.requestHeadersToAdd = append(headers.requestHeadersToAdd, policy.headerToAdd)
+			} else {
+				headers.requestHeadersToRemove = append(headers.requestHeadersToRemove, policy.headerToRemove)
+			}
+		} else {
+			if policy.headerToAdd != nil {
+				headers.responseHeadersToAdd = append(headers.responseHeadersToAdd, policy.headerToAdd)
+			} else {
+				headers.responseHeadersToRemove = append(headers.responseHeadersToRemove, policy.headerToRemove)
+			}
+		}
+	}
+	return headers
+}
+
+// applyTo adds the headers of the global policies to the route. The global policies precede the operation
+// policies of the route, hence an operation policy overrides a global policy setting the same header.
+func (headers globalPolicyHeaders) applyTo(route *routev3.Route) {
+	if len(headers.requestHeadersToAdd) > 0 {
+		route.RequestHeadersToAdd = append(append([]*corev3.HeaderValueOption{}, headers.requestHeadersToAdd...),
+			route.RequestHeadersToAdd...)
+	}
+	if len(headers.requestHeadersToRemove) > 0 {
+		route.RequestHeadersToRemove = append(append([]string{}, headers.requestHeadersToRemove...),
+			route.RequestHeadersToRemove...)
+	}
+	if len(headers.responseHeadersToAdd) > 0 {
+		route.ResponseHeadersToAdd = append(append([]*corev3.HeaderValueOption{}, headers.responseHeadersToAdd...),
+			route.ResponseHeadersToAdd...)
+	}
+	if len(headers.responseHeadersToRemove) > 0 {
+		route.ResponseHeadersToRemove = append(append([]string{}, headers.responseHeadersToRemove...),
+			route.ResponseHeadersToRemove...)
+	}
+}
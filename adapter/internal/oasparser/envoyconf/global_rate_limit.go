@@ -0,0 +1,109 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package envoyconf
+
+import (
+	"sort"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// rateLimitServiceConfig is the config of the envoy rate limit service. The descriptors match the descriptors
+// generated by the rate limit actions of the routes.
+type rateLimitServiceConfig struct {
+	Domain      string                        `yaml:"domain"`
+	Descriptors []*rateLimitServiceDescriptor `yaml:"descriptors"`
+}
+
+type rateLimitServiceDescriptor struct {
+	Key         string                        `yaml:"key"`
+	Value       string                        `yaml:"value,omitempty"`
+	RateLimit   *rateLimitServiceLimit        `yaml:"rate_limit,omitempty"`
+	Descriptors []*rateLimitServiceDescriptor `yaml:"descriptors,omitempty"`
+}
+
+type rateLimitServiceLimit struct {
+	Unit            string `yaml:"unit"`
+	RequestsPerUnit uint32 `yaml:"requests_per_unit"`
+}
+
+// units of the rate limit service, from the largest
+var rateLimitServiceUnits = []struct {
+	name     string
+	duration time.Duration
+}{
+	{"day", 24 * time.Hour},
+	{"hour", time.Hour},
+	{"minute", time.Minute},
+	{"second", time.Second},
+}
+
+// GenerateRateLimitServiceConfig returns the config (YAML) of the envoy rate limit service for the request count
+// limits.
+func GenerateRateLimitServiceConfig(domain string, descriptors []RateLimitDescriptor) ([]byte, error) {
+	sorted := append([]RateLimitDescriptor{}, descriptors...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].ApplicationUUID != sorted[j].ApplicationUUID {
+			return sorted[i].ApplicationUUID < sorted[j].ApplicationUUID
+		}
+		return sorted[i].APIUUID < sorted[j].APIUUID
+	})
+
+	serviceConfig := &rateLimitServiceConfig{Domain: domain, Descriptors: []*rateLimitServiceDescriptor{}}
+	applicationDescriptors := make(map[string]*rateLimitServiceDescriptor)
+	for _, descriptor := range sorted {
+		applicationDescriptor, found := applicationDescriptors[descriptor.ApplicationUUID]
+		if !found {
+			applicationDescriptor = &rateLimitServiceDescriptor{
+				Key:   applicationDescriptorKey,
+				Value: descriptor.ApplicationUUID,
+			}
+			applicationDescriptors[descriptor.ApplicationUUID] = applicationDescriptor
+			serviceConfig.Descriptors = append(serviceConfig.Descriptors, applicationDescriptor)
+		}
+		parent := applicationDescriptor
+		if descriptor.APIUUID != "" {
+			parent = &rateLimitServiceDescriptor{Key: apiDescriptorKey, Value: descriptor.APIUUID}
+			applicationDescriptor.Descriptors = append(applicationDescriptor.Descriptors, parent)
+		}
+		// the requests from the exempted sources do not match the descriptor
+		parent.Descriptors = append(parent.Descriptors, &rateLimitServiceDescriptor{
+			Key:       sourceExemptionDescriptorKey,
+			Value:     rbacShadowDenied,
+			RateLimit: getRateLimitServiceLimit(descriptor.RequestCount, descriptor.FillInterval),
+		})
+	}
+	return yaml.Marshal(serviceConfig)
+}
+
+// getRateLimitServiceLimit converts the limit to the largest unit of the rate limit service, which divides the
+// fill interval. The requests per unit are rounded down, as the service does not support multiple units.
+func getRateLimitServiceLimit(requestCount uint32, fillInterval time.Duration) *rateLimitServiceLimit {
+	for _, unit := range rateLimitServiceUnits {
+		if fillInterval < unit.duration || fillInterval%unit.duration != 0 {
+			continue
+		}
+		requestsPerUnit := requestCount / uint32(fillInterval/unit.duration)
+		if requestsPerUnit == 0 {
+			requestsPerUnit = 1
+		}
+		return &rateLimitServiceLimit{Unit: unit.name, RequestsPerUnit: requestsPerUnit}
+	}
+	return &rateLimitServiceLimit{Unit: "second", RequestsPerUnit: requestCount}
+}
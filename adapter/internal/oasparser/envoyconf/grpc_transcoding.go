@@ -0,0 +1,324 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package envoyconf
+
+import (
+	"fmt"
+	"strings"
+
+	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	grpc_json_transcoderv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/grpc_json_transcoder/v3"
+	grpc_webv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/grpc_web/v3"
+	hcmv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/wso2/product-microgateway/adapter/config"
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
+	"github.com/wso2/product-microgateway/adapter/pkg/logging"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// getGRPCTranscoderFilter returns the gRPC-JSON transcoder filter without any services, which does not transcode
+// the requests. The services are transcoded per virtual host, with the proto descriptor sets of its APIs.
+func getGRPCTranscoderFilter() *hcmv3.HttpFilter {
+	transcoderConfig, err := anypb.New(&grpc_json_transcoderv3.GrpcJsonTranscoder{
+		DescriptorSet: &grpc_json_transcoderv3.GrpcJsonTranscoder_ProtoDescriptorBin{
+			ProtoDescriptorBin: []byte{},
+		},
+	})
+	if err != nil {
+		logger.LoggerOasparser.Error("Error while generating the gRPC-JSON transcoder filter.", err)
+	}
+	return &hcmv3.HttpFilter{
+		Name: wellknown.GRPCJSONTranscoder,
+		ConfigType: &hcmv3.HttpFilter_TypedConfig{
+			TypedConfig: transcoderConfig,
+		},
+	}
+}
+
+// getGRPCWebFilter returns the gRPC-Web filter, which translates the gRPC-Web requests to gRPC.
+func getGRPCWebFilter() *hcmv3.HttpFilter {
+	grpcWebConfig, err := anypb.New(&grpc_webv3.GrpcWeb{})
+	if err != nil {
+		logger.LoggerOasparser.Error("Error while generating the gRPC-Web filter.", err)
+	}
+	return &hcmv3.HttpFilter{
+		Name: wellknown.GRPCWeb,
+		ConfigType: &hcmv3.HttpFilter_TypedConfig{
+			TypedConfig: grpcWebConfig,
+		},
+	}
+}
+
+// GRPCTranscoder is the transcoding of the requests of an API to its gRPC services. The operations of the API are
+// bound to the gRPC methods in the proto descriptor set, once the API is deployed.
+type GRPCTranscoder struct {
+	descriptorSet *descriptorpb.FileDescriptorSet
+	services      []string
+}
+
+// CreateGRPCTranscoder creates the transcoder of the API, of which the operations with the x-wso2-grpc-method
+// extension are mapped to the gRPC methods with the path templates of the resources, in addition to the HTTP
+// bindings annotated in the proto files. The operations of the default version are mapped with the unversioned
+// paths as well. Nil is returned if the requests of the API are not transcoded.
+func CreateGRPCTranscoder(mgwSwagger model.MgwSwagger) (*GRPCTranscoder, error) {
+	transcoding := mgwSwagger.GetGRPCTranscoding()
+	if transcoding == nil {
+		return nil, nil
+	}
+	basePath := strings.TrimSuffix(mgwSwagger.GetXWso2Basepath(), "/")
+	basePaths := []string{basePath}
+	if mgwSwagger.IsDefaultVersion {
+		if context := getDefaultVersionBasepath(basePath, mgwSwagger.GetVersion()); context != basePath {
+			basePaths = append(basePaths, context)
+		}
+	}
+	return createGRPCTranscoder(transcoding, mgwSwagger.GetResources(), basePaths)
+}
+
+// createGRPCTranscoder binds the operations of the resources to the gRPC methods, with the path templates of the
+// resources under each of the base paths.
+func createGRPCTranscoder(transcoding *model.GRPCTranscoding, resources []*model.Resource,
+	basePaths []string) (*GRPCTranscoder, error) {
+	if len(transcoding.ProtoDescriptor) == 0 {
+		return nil, fmt.Errorf("proto descriptor set of the services is not available in the API project")
+	}
+	var descriptorSet descriptorpb.FileDescriptorSet
+	if err := proto.Unmarshal(transcoding.ProtoDescriptor, &descriptorSet); err != nil {
+		return nil, fmt.Errorf("invalid proto descriptor set. %v", err)
+	}
+	services := append([]string{}, transcoding.Services...)
+	transcodedServices := make(map[string]struct{}, len(services))
+	for _, service := range services {
+		if findGRPCService(&descriptorSet, service) == nil {
+			return nil, fmt.Errorf("service %s is not found in the proto descriptor set", service)
+		}
+		transcodedServices[service] = struct{}{}
+	}
+
+	for _, resource := range resources {
+		for _, operation := range resource.GetMethod() {
+			service, methodName, err := model.ResolveGRPCMethod(operation.GetVendorExtensions())
+			if err != nil {
+				return nil, fmt.Errorf("invalid gRPC method of the operation %s %s. %v", operation.GetMethod(),
+					resource.GetPath(), err)
+			}
+			if service == "" {
+				continue
+			}
+			method := findGRPCMethod(findGRPCService(&descriptorSet, service), methodName)
+			if method == nil {
+				return nil, fmt.Errorf("gRPC method %s/%s of the operation %s %s is not found in the proto "+
+					"descriptor set", service, methodName, operation.GetMethod(), resource.GetPath())
+			}
+			for _, basePath := range basePaths {
+				addHTTPRule(method, operation.GetMethod(), toHTTPRuleTemplate(basePath+resource.GetPath()))
+			}
+			if _, found := transcodedServices[service]; !found {
+				services = append(services, service)
+				transcodedServices[service] = struct{}{}
+			}
+		}
+	}
+	return &GRPCTranscoder{descriptorSet: &descriptorSet, services: services}, nil
+}
+
+// SetGRPCTranscoders transcodes the requests of the APIs of the virtual hosts to gRPC. The descriptor sets of the
+// APIs of a virtual host are merged and attached to the virtual host, rather than to each route of the APIs.
+func SetGRPCTranscoders(virtualHosts []*routev3.VirtualHost, vhostToTranscodersMap map[string][]*GRPCTranscoder) {
+	for _, virtualHost := range virtualHosts {
+		transcoders := vhostToTranscodersMap[virtualHost.GetName()]
+		if len(transcoders) == 0 {
+			continue
+		}
+		transcoderConfig, err := generateGRPCTranscoderConfig(transcoders)
+		if err != nil {
+			logger.LoggerOasparser.ErrorC(logging.ErrorDetails{
+				Message: fmt.Sprintf("Error while transcoding the requests of the vhost %s to gRPC. %v",
+					virtualHost.GetName(), err),
+				Severity:  logging.MAJOR,
+				ErrorCode: 2268,
+			})
+			continue
+		}
+		if virtualHost.TypedPerFilterConfig == nil {
+			virtualHost.TypedPerFilterConfig = make(map[string]*anypb.Any)
+		}
+		virtualHost.TypedPerFilterConfig[wellknown.GRPCJSONTranscoder] = transcoderConfig
+	}
+}
+
+// generateGRPCTranscoderConfig generates the transcoder config of the merged descriptor sets of the transcoders. The
+// proto files shared by the APIs are included once, with the HTTP rules of the methods of all the APIs.
+func generateGRPCTranscoderConfig(transcoders []*GRPCTranscoder) (*anypb.Any, error) {
+	descriptorSet := &descriptorpb.FileDescriptorSet{}
+	files := make(map[string]*descriptorpb.FileDescriptorProto)
+	var services []string
+	transcodedServices := make(map[string]struct{})
+	for _, transcoder := range transcoders {
+		for _, file := range transcoder.descriptorSet.GetFile() {
+			if mergedFile, found := files[file.GetName()]; found {
+				mergeHTTPRules(mergedFile, file)
+				continue
+			}
+			// the descriptor set of the transcoder is reused by the later snapshots, hence is not modified
+			mergedFile := proto.Clone(file).(*descriptorpb.FileDescriptorProto)
+			files[file.GetName()] = mergedFile
+			descriptorSet.File = append(descriptorSet.File, mergedFile)
+		}
+		for _, service := range transcoder.services {
+			if _, found := transcodedServices[service]; !found {
+				services = append(services, service)
+				transcodedServices[service] = struct{}{}
+			}
+		}
+	}
+	marshalOptions := proto.MarshalOptions{Deterministic: true}
+	protoDescriptor, err := marshalOptions.Marshal(descriptorSet)
+	if err != nil {
+		return nil, err
+	}
+	conf, _ := config.ReadConfigs()
+	return anypb.New(&grpc_json_transcoderv3.GrpcJsonTranscoder{
+		DescriptorSet: &grpc_json_transcoderv3.GrpcJsonTranscoder_ProtoDescriptorBin{
+			ProtoDescriptorBin: protoDescriptor,
+		},
+		Services: services,
+		PrintOptions: &grpc_json_transcoderv3.GrpcJsonTranscoder_PrintOptions{
+			AlwaysPrintPrimitiveFields: conf.Envoy.Filters.GRPCTranscoding.AlwaysPrintPrimitiveFields,
+			PreserveProtoFieldNames:    conf.Envoy.Filters.GRPCTranscoding.PreserveProtoFieldNames,
+		},
+		// the routes of the APIs match the REST requests, rather than the gRPC methods
+		MatchIncomingRequestRoute: true,
+	})
+}
+
+// mergeHTTPRules adds the HTTP rules of the methods of the file to the same methods of the merged file.
+func mergeHTTPRules(mergedFile, file *descriptorpb.FileDescriptorProto) {
+	for _, service := range file.GetService() {
+		var mergedService *descriptorpb.ServiceDescriptorProto
+		for _, candidate := range mergedFile.GetService() {
+			if candidate.GetName() == service.GetName() {
+				mergedService = candidate
+			}
+		}
+		for _, method := range service.GetMethod() {
+			rule, ok := proto.GetExtension(method.GetOptions(), annotations.E_Http).(*annotations.HttpRule)
+			mergedMethod := findGRPCMethod(mergedService, method.GetName())
+			if !ok || rule == nil || rule.Pattern == nil || mergedMethod == nil {
+				continue
+			}
+			for _, binding := range append([]*annotations.HttpRule{rule}, rule.GetAdditionalBindings()...) {
+				binding = proto.Clone(binding).(*annotations.HttpRule)
+				binding.AdditionalBindings = nil
+				addHTTPBinding(mergedMethod, binding)
+			}
+		}
+	}
+}
+
+// toHTTPRuleTemplate converts a path template of OpenAPI to a path template of the HTTP rules.
+func toHTTPRuleTemplate(pathTemplate string) string {
+	pathTemplate = strings.Split(pathTemplate, "?")[0]
+	if strings.HasSuffix(pathTemplate, "/*") {
+		pathTemplate += "*"
+	}
+	return pathTemplate
+}
+
+// addHTTPRule binds the HTTP method and the path template to the gRPC method. The binding is added to the
+// additional bindings, if the method already has a HTTP rule.
+func addHTTPRule(method *descriptorpb.MethodDescriptorProto, httpMethod, pathTemplate string) {
+	rule := &annotations.HttpRule{}
+	switch strings.ToUpper(httpMethod) {
+	case "GET":
+		rule.Pattern = &annotations.HttpRule_Get{Get: pathTemplate}
+	case "POST":
+		rule.Pattern = &annotations.HttpRule_Post{Post: pathTemplate}
+		rule.Body = "*"
+	case "PUT":
+		rule.Pattern = &annotations.HttpRule_Put{Put: pathTemplate}
+		rule.Body = "*"
+	case "PATCH":
+		rule.Pattern = &annotations.HttpRule_Patch{Patch: pathTemplate}
+		rule.Body = "*"
+	case "DELETE":
+		rule.Pattern = &annotations.HttpRule_Delete{Delete: pathTemplate}
+	default:
+		rule.Pattern = &annotations.HttpRule_Custom{
+			Custom: &annotations.CustomHttpPattern{Kind: strings.ToUpper(httpMethod), Path: pathTemplate},
+		}
+	}
+	addHTTPBinding(method, rule)
+}
+
+// addHTTPBinding adds the HTTP rule to the method, unless the method is already bound with the same rule.
+func addHTTPBinding(method *descriptorpb.MethodDescriptorProto, rule *annotations.HttpRule) {
+	if method.Options == nil {
+		method.Options = &descriptorpb.MethodOptions{}
+	}
+	if existing, ok := proto.GetExtension(method.Options, annotations.E_Http).(*annotations.HttpRule); ok &&
+		existing != nil && existing.Pattern != nil {
+		for _, binding := range append([]*annotations.HttpRule{existing}, existing.AdditionalBindings...) {
+			if isSameHTTPBinding(binding, rule) {
+				return
+			}
+		}
+		existing.AdditionalBindings = append(existing.AdditionalBindings, rule)
+		rule = existing
+	}
+	proto.SetExtension(method.Options, annotations.E_Http, rule)
+}
+
+// isSameHTTPBinding checks whether the rules bind the same request, ignoring their additional bindings.
+func isSameHTTPBinding(rule, other *annotations.HttpRule) bool {
+	return rule.GetBody() == other.GetBody() && rule.GetResponseBody() == other.GetResponseBody() &&
+		proto.Equal(&annotations.HttpRule{Pattern: rule.Pattern}, &annotations.HttpRule{Pattern: other.Pattern})
+}
+
+// findGRPCService finds the service by the fully qualified name (ex: bookstore.Bookstore) in the descriptor set.
+func findGRPCService(descriptorSet *descriptorpb.FileDescriptorSet,
+	serviceName string) *descriptorpb.ServiceDescriptorProto {
+	for _, file := range descriptorSet.GetFile() {
+		for _, service := range file.GetService() {
+			fullName := service.GetName()
+			if file.GetPackage() != "" {
+				fullName = file.GetPackage() + "." + fullName
+			}
+			if fullName == serviceName {
+				return service
+			}
+		}
+	}
+	return nil
+}
+
+// findGRPCMethod finds the method of the service by the name.
+func findGRPCMethod(service *descriptorpb.ServiceDescriptorProto,
+	methodName string) *descriptorpb.MethodDescriptorProto {
+	for _, method := range service.GetMethod() {
+		if method.GetName() == methodName {
+			return method
+		}
+	}
+	return nil
+}
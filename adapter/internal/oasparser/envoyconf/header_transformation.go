@@ -0,0 +1,209 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package envoyconf
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	luav3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/lua/v3"
+	hcmv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/constants"
+)
+
+// headerNameRegex matches the HTTP header names (RFC 7230 tokens)
+var headerNameRegex = regexp.MustCompile("^[!#$%&'*+.^_`|~0-9A-Za-z-]+$")
+
+// applicationAttributeRegex matches the references to the custom attributes of the application of the request in
+// the header values (ie: ${application.attributes.region})
+var applicationAttributeRegex = regexp.MustCompile(`\$\{application\.attributes\.([^{}:]+)\}`)
+
+// headerRename renames a header, keeping its value.
+type headerRename struct {
+	from string
+	to   string
+}
+
+// getHeaderTransformationFilter returns the Lua filter which renames the headers of the routes with rename
+// policies. The filter does nothing by default, the script of a route is set as a per route config.
+func getHeaderTransformationFilter() *hcmv3.HttpFilter {
+	luaConfig := &luav3.Lua{
+		DefaultSourceCode: &corev3.DataSource{
+			Specifier: &corev3.DataSource_InlineString{
+				InlineString: "function envoy_on_request(request_handle)" +
+					"\nend" +
+					"\nfunction envoy_on_response(response_handle)" +
+					"\nend",
+			},
+		},
+	}
+	ext, err := anypb.New(luaConfig)
+	if err != nil {
+		logger.LoggerOasparser.Error(err)
+	}
+	return &hcmv3.HttpFilter{
+		Name: headerTransformFilterName,
+		ConfigType: &hcmv3.HttpFilter_TypedConfig{
+			TypedConfig: ext,
+		},
+	}
+}
+
+// resolveApplicationAttributes replaces the references to the application attributes in a header value with the
+// dynamic metadata set by the enforcer, which contains the attributes of the authenticated application. A reference
+// to an attribute the application does not have resolves to an empty value.
+func resolveApplicationAttributes(headerValue string) string {
+	return applicationAttributeRegex.ReplaceAllStringFunc(headerValue, func(reference string) string {
+		name := applicationAttributeRegex.FindStringSubmatch(reference)[1]
+		return fmt.Sprintf("%%DYNAMIC_METADATA(%s:%s%s)%%", extAuthzFilterName, applicationAttributeMetadataPrefix,
+			name)
+	})
+}
+
+// generateHeaderToAppendRouteConfig returns the header of an ADD_HEADER policy. Unlike SET_HEADER, the value
+// is appended to the existing values of the header.
+func generateHeaderToAppendRouteConfig(policyParams interface{}) (*corev3.HeaderValueOption, error) {
+	headerToAppend, err := generateHeaderToAddRouteConfig(policyParams)
+	if err != nil {
+		return nil, err
+	}
+	headerToAppend.AppendAction = corev3.HeaderValueOption_APPEND_IF_EXISTS_OR_ADD
+	return headerToAppend, nil
+}
+
+// generateHeaderRename returns the header rename of a RENAME_HEADER policy.
+func generateHeaderRename(policyParams interface{}) (*headerRename, error) {
+	params, ok := policyParams.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("error while processing policy parameter map. Map: %v", policyParams)
+	}
+	from, ok := params[constants.HeaderName].(string)
+	if !ok || strings.TrimSpace(from) == "" {
+		return nil, errors.New("policy parameter map must include headerName")
+	}
+	to, ok := params[constants.NewHeaderName].(string)
+	if !ok || strings.TrimSpace(to) == "" {
+		return nil, errors.New("policy parameter map must include newHeaderName")
+	}
+	for _, name := range []string{from, to} {
+		if !headerNameRegex.MatchString(name) {
+			return nil, fmt.Errorf("invalid header name %q", name)
+		}
+	}
+	return &headerRename{from: from, to: to}, nil
+}
+
+// generateHeaderRenameScript returns the Lua script renaming the headers of a route. A header is renamed only if
+// it is present in the request or the response, replacing any header with the new name.
+func generateHeaderRenameScript(requestRenames, responseRenames []*headerRename) string {
+	return generateHeaderTransformationScript(requestRenames, responseRenames, "")
+}
+
+// generateHeaderTransformationScript returns the Lua script renaming the headers of a route, which overrides the
+// method of the requests sent to the backend as well if the methodOverride is not empty.
+func generateHeaderTransformationScript(requestRenames, responseRenames []*headerRename,
+	methodOverride string) string {
+	var script strings.Builder
+	writeFunction := func(function, handle string, renames []*headerRename, method string) {
+		script.WriteString(fmt.Sprintf("function %s(%s)\n", function, handle))
+		if len(renames) > 0 || method != "" {
+			script.WriteString(fmt.Sprintf("  local headers = %s:headers()\n", handle))
+		}
+		for _, rename := range renames {
+			from := strconv.Quote(strings.ToLower(rename.from))
+			to := strconv.Quote(strings.ToLower(rename.to))
+			script.WriteString(fmt.Sprintf("  do\n    local value = headers:get(%s)\n", from))
+			script.WriteString("    if value ~= nil then\n")
+			script.WriteString(fmt.Sprintf("      headers:remove(%s)\n", from))
+			script.WriteString(fmt.Sprintf("      headers:replace(%s, value)\n", to))
+			script.WriteString("    end\n  end\n")
+		}
+		if method != "" {
+			script.WriteString(fmt.Sprintf("  headers:replace(\":method\", %s)\n", strconv.Quote(method)))
+		}
+		script.WriteString("end\n")
+	}
+	writeFunction("envoy_on_request", "request_handle", requestRenames, methodOverride)
+	writeFunction("envoy_on_response", "response_handle", responseRenames, "")
+	return script.String()
+}
+
+// withHeaderRenames returns a copy of the per route filter configs, including the script renaming the headers.
+// The filter configs are returned as they are, if there are no renames.
+func withHeaderRenames(perRouteFilterConfigs map[string]*anypb.Any, requestRenames,
+	responseRenames []*headerRename) (map[string]*anypb.Any, error) {
+	return withHeaderTransformations(perRouteFilterConfigs, requestRenames, responseRenames, "")
+}
+
+// withHeaderTransformations returns a copy of the per route filter configs, including the script renaming the
+// headers and overriding the method of the requests. The filter configs are returned as they are, if there are
+// no transformations.
+func withHeaderTransformations(perRouteFilterConfigs map[string]*anypb.Any, requestRenames,
+	responseRenames []*headerRename, methodOverride string) (map[string]*anypb.Any, error) {
+	if len(requestRenames) == 0 && len(responseRenames) == 0 && methodOverride == "" {
+		return perRouteFilterConfigs, nil
+	}
+	luaPerRoute, err := anypb.New(&luav3.LuaPerRoute{
+		Override: &luav3.LuaPerRoute_SourceCode{
+			SourceCode: &corev3.DataSource{
+				Specifier: &corev3.DataSource_InlineString{
+					InlineString: generateHeaderTransformationScript(requestRenames, responseRenames, methodOverride),
+				},
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	configs := make(map[string]*anypb.Any, len(perRouteFilterConfigs)+1)
+	for name, filterConfig := range perRouteFilterConfigs {
+		configs[name] = filterConfig
+	}
+	configs[headerTransformFilterName] = luaPerRoute
+	return configs, nil
+}
+
+// supportedOverrideMethods are the methods, the requests could be sent to the backend with
+var supportedOverrideMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"}
+
+// getOverrideMethod returns the method of an OVERRIDE_RESOURCE_METHOD policy. Unlike REWRITE_RESOURCE_METHOD, the
+// method is overridden by the router, hence the request is authorized by the enforcer with the original method.
+func getOverrideMethod(policyParams interface{}) (string, error) {
+	params, ok := policyParams.(map[string]interface{})
+	if !ok {
+		return "", fmt.Errorf("error while processing policy parameter map. Map: %v", policyParams)
+	}
+	method, ok := params[constants.UpdatedMethod].(string)
+	if !ok || strings.TrimSpace(method) == "" {
+		return "", errors.New("policy parameter map must include updatedMethod")
+	}
+	method = strings.ToUpper(strings.TrimSpace(method))
+	for _, supportedMethod := range supportedOverrideMethods {
+		if method == supportedMethod {
+			return method, nil
+		}
+	}
+	return "", fmt.Errorf("method %q is not supported", method)
+}
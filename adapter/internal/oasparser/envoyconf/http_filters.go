@@ -30,6 +30,8 @@ import (
 	ext_authv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ext_authz/v3"
 	local_ratelimit_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/local_ratelimit/v3"
 	luav3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/lua/v3"
+	ratelimitv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ratelimit/v3"
+	rbacv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/rbac/v3"
 	routerv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/router/v3"
 	wasm_filter_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/wasm/v3"
 	hcmv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
@@ -38,7 +40,7 @@ import (
 	"github.com/golang/protobuf/ptypes/wrappers"
 	"google.golang.org/protobuf/types/known/anypb"
 
-	//rls "github.com/envoyproxy/go-control-plane/envoy/config/ratelimit/v3"
+	rls "github.com/envoyproxy/go-control-plane/envoy/config/ratelimit/v3"
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
 	"github.com/wso2/product-microgateway/adapter/config"
@@ -54,21 +56,62 @@ func getHTTPFilters() []*hcmv3.HttpFilter {
 	extAauth := getExtAuthzHTTPFilter()
 	router := getRouterHTTPFilter()
 	lua := getLuaFilter()
+	headerTransformation := getHeaderTransformationFilter()
 	awsLambda := getAwsLambdaFilter()
 	cors := getCorsHTTPFilter()
 	localRateLimit := getHTTPLocalRateLimitFilter()
+	buffer := getBufferFilter()
 
 	httpFilters := []*hcmv3.HttpFilter{
 		cors,
 		localRateLimit,
+		buffer,
 		extAauth,
 		lua,
+		headerTransformation,
 		awsLambda,
 		router,
 	}
 
 	conf, _ := config.ReadConfigs()
 
+	// The limits of the application and subscription policies are applied after the authentication, as the
+	// application of the request is set by the enforcer.
+	var policyRateLimits []*hcmv3.HttpFilter
+	if conf.Envoy.LocalRateLimit.Enabled {
+		policyRateLimits = append(policyRateLimits, getPolicyLocalRateLimitFilter())
+	}
+	if conf.Envoy.GlobalRateLimit.Enabled {
+		policyRateLimits = append(policyRateLimits, getGlobalRateLimitFilter())
+	}
+	httpFilters = insertHTTPFiltersAfter(httpFilters, extAuthzFilterName, policyRateLimits...)
+	if conf.Envoy.LocalRateLimit.Enabled || conf.Envoy.GlobalRateLimit.Enabled {
+		// The source exemptions of the rate limits are evaluated by the RBAC filter, hence it should be
+		// placed before the rate limit filters.
+		httpFilters = append([]*hcmv3.HttpFilter{cors, getRBACFilter()}, httpFilters[1:]...)
+	}
+	if isRateLimitHeadersEnabled(conf) {
+		// The rate limit headers are formatted before the rate limit filters, as the filters are applied to the
+		// responses in the reverse order.
+		httpFilters = append([]*hcmv3.HttpFilter{cors, getRateLimitHeadersFilter()}, httpFilters[1:]...)
+	}
+	// The requests from the restricted source IPs are denied prior to the rate limits and the authentication.
+	httpFilters = append([]*hcmv3.HttpFilter{cors, getIPRestrictionFilter()}, httpFilters[1:]...)
+
+	if conf.Envoy.Filters.ResponseCache.Enabled {
+		// The responses are served from the cache after the authentication, and are cached after they are
+		// transcoded and compressed. The Cache-Control of the responses is set before those reach the cache.
+		httpFilters = append(httpFilters[:len(httpFilters)-1], getResponseCacheFilter(),
+			getResponseCacheControlFilter(), router)
+	}
+	if conf.Envoy.Filters.GRPCTranscoding.Enabled {
+		// The requests are transcoded after the authentication, as the enforcer validates the REST requests.
+		httpFilters = append(httpFilters[:len(httpFilters)-1], getGRPCTranscoderFilter(), router)
+	}
+	if conf.Envoy.Filters.GRPCTranscoding.GRPCWebEnabled {
+		httpFilters = append(httpFilters[:len(httpFilters)-1], getGRPCWebFilter(), router)
+	}
+
 	if conf.Envoy.Filters.Compression.Enabled {
 		compressionFilter, err := getCompressorFilter()
 		if err != nil {
@@ -81,6 +124,7 @@ func getHTTPFilters() []*hcmv3.HttpFilter {
 		}
 		httpFilters = httpFilters[:len(httpFilters)-1]
 		httpFilters = append(httpFilters, compressionFilter)
+		httpFilters = append(httpFilters, getCompressionProfileFilters()...)
 		httpFilters = append(httpFilters, router)
 	}
 	return httpFilters
@@ -243,6 +287,26 @@ func getAwsLambdaFilter() *hcmv3.HttpFilter {
 	return &awsLambdaFilter
 }
 
+// insertHTTPFiltersAfter inserts the filters after the filter of the given name, or before the router if the
+// filter is not found.
+func insertHTTPFiltersAfter(httpFilters []*hcmv3.HttpFilter, name string,
+	filters ...*hcmv3.HttpFilter) []*hcmv3.HttpFilter {
+	if len(filters) == 0 {
+		return httpFilters
+	}
+	index := len(httpFilters) - 1
+	for i, httpFilter := range httpFilters {
+		if httpFilter.GetName() == name {
+			index = i + 1
+			break
+		}
+	}
+	inserted := make([]*hcmv3.HttpFilter, 0, len(httpFilters)+len(filters))
+	inserted = append(inserted, httpFilters[:index]...)
+	inserted = append(inserted, filters...)
+	return append(inserted, httpFilters[index:]...)
+}
+
 // getHTTPLocalRateLimitFilter returns the local rate limit filter which is used for JWKS endpoint specifically.
 func getHTTPLocalRateLimitFilter() *hcmv3.HttpFilter {
 	localRateLimitConfig := &local_ratelimit_v3.LocalRateLimit{
@@ -261,6 +325,52 @@ func getHTTPLocalRateLimitFilter() *hcmv3.HttpFilter {
 	return localRateLimitFilter
 }
 
+// getGlobalRateLimitFilter returns the rate limit filter, which enforces the limits using the rate limit service.
+func getGlobalRateLimitFilter() *hcmv3.HttpFilter {
+	conf, _ := config.ReadConfigs()
+	timeout := time.Duration(conf.Envoy.GlobalRateLimit.RequestTimeoutInMillis) * time.Millisecond
+	rateLimitConfig := &ratelimitv3.RateLimit{
+		Domain:          conf.Envoy.GlobalRateLimit.Domain,
+		FailureModeDeny: conf.Envoy.GlobalRateLimit.FailureModeDeny,
+		Timeout:         ptypes.DurationProto(timeout),
+		RateLimitService: &rls.RateLimitServiceConfig{
+			GrpcService: &corev3.GrpcService{
+				TargetSpecifier: &corev3.GrpcService_EnvoyGrpc_{
+					EnvoyGrpc: &corev3.GrpcService_EnvoyGrpc{
+						ClusterName: rateLimitClusterName,
+					},
+				},
+			},
+			TransportApiVersion: corev3.ApiVersion_V3,
+		},
+		EnableXRatelimitHeaders: getXRateLimitHeadersVersion(conf),
+	}
+	marshalledRateLimitConfig, err := anypb.New(rateLimitConfig)
+	if err != nil {
+		logger.LoggerOasparser.Error("Error while generating the global rate limit filter.", err)
+	}
+	return &hcmv3.HttpFilter{
+		Name: wellknown.HTTPRateLimit,
+		ConfigType: &hcmv3.HttpFilter_TypedConfig{
+			TypedConfig: marshalledRateLimitConfig,
+		},
+	}
+}
+
+// getRBACFilter returns the RBAC filter without any rules. The rules are applied per virtual host.
+func getRBACFilter() *hcmv3.HttpFilter {
+	marshalledRBACConfig, err := ptypes.MarshalAny(&rbacv3.RBAC{})
+	if err != nil {
+		logger.LoggerOasparser.Error("Error while generating the RBAC filter.", err)
+	}
+	return &hcmv3.HttpFilter{
+		Name: wellknown.HTTPRoleBasedAccessControl,
+		ConfigType: &hcmv3.HttpFilter_TypedConfig{
+			TypedConfig: marshalledRBACConfig,
+		},
+	}
+}
+
 func getMgwWebSocketWASMFilter() *hcmv3.HttpFilter {
 	config := &wrappers.StringValue{
 		Value: `{
@@ -24,6 +24,7 @@ import (
 // routeCreateParams is the DTO used to provide information to the envoy route create function
 type routeCreateParams struct {
 	organizationID               string
+	apiUUID                      string
 	title                        string
 	version                      string
 	apiType                      string
@@ -39,9 +40,23 @@ type routeCreateParams struct {
 	requestInterceptor           map[string]model.InterceptEndpoint
 	responseInterceptor          map[string]model.InterceptEndpoint
 	corsPolicy                   *model.CorsConfig
+	streamingConfig              *model.StreamingConfig
+	payloadLimits                *model.PayloadLimits
+	timeouts                     *model.Timeouts
+	responseCompression          *model.ResponseCompression
+	responseCache                *model.ResponseCache
+	disableSecurity              bool
+	trafficMirror                *model.TrafficMirror
+	mirrorClusterName            string
 	passRequestPayloadToEnforcer bool
-	isDefaultVersion             bool
 	isSandbox                    bool
 	endpointType                 string
 	amznResourceName             string
+	globalPolicyHeaders          globalPolicyHeaders
+	deprecation                  *model.DeprecationConfig
+	rateLimitHeadersFormat       string
+	webSubTopics                 []string
+	webSubSignature              *model.WebSubSignature
+	soapVersions                 []string
+	subscriptionValidation       *bool
 }
@@ -0,0 +1,287 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package envoyconf
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	rbac_config_v3 "github.com/envoyproxy/go-control-plane/envoy/config/rbac/v3"
+	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	rbacv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/rbac/v3"
+	hcmv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	"github.com/golang/protobuf/proto"
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
+	"github.com/wso2/product-microgateway/adapter/pkg/discovery/api/wso2/discovery/throttle"
+	"github.com/wso2/product-microgateway/adapter/pkg/logging"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// The source IP restrictions of the APIs are enforced by a separate RBAC filter, as the per route config of the
+// RBAC filter evaluating the rate limit exemptions is applied to the virtual hosts.
+const (
+	ipRestrictionFilterName string = "envoy.filters.http.rbac.ip_restriction"
+	ipRestrictionPolicyName string = "ip_restriction"
+)
+
+// types of the IP blocking conditions of the control plane
+const (
+	blockedIPType      string = "IP"
+	blockedIPRangeType string = "IPRANGE"
+)
+
+// getIPRestrictionFilter returns the RBAC filter enforcing the source IP restrictions. The filter allows all the
+// requests, while the rules are applied per route.
+func getIPRestrictionFilter() *hcmv3.HttpFilter {
+	marshalledRBACConfig, err := anypb.New(&rbacv3.RBAC{})
+	if err != nil {
+		logger.LoggerOasparser.Error("Error while generating the IP restriction filter.", err)
+	}
+	return &hcmv3.HttpFilter{
+		Name: ipRestrictionFilterName,
+		ConfigType: &hcmv3.HttpFilter_TypedConfig{
+			TypedConfig: marshalledRBACConfig,
+		},
+	}
+}
+
+// AddIPRestriction returns copies of the given routes of an API, which deny the requests from the sources not
+// allowed by the IP restriction of the API or blocked by the given IP blocking conditions. The routes are returned
+// as they are if the API is not restricted.
+func AddIPRestriction(routes []*routev3.Route, restriction *model.IPRestriction,
+	blockedIPs []*throttle.IPCondition) []*routev3.Route {
+	rbacPerRoute := generateIPRestrictionRBAC(restriction, blockedIPs)
+	if rbacPerRoute == nil {
+		return routes
+	}
+	restrictedRoutes := make([]*routev3.Route, 0, len(routes))
+	for _, route := range routes {
+		restrictedRoute := proto.Clone(route).(*routev3.Route)
+		if restrictedRoute.TypedPerFilterConfig == nil {
+			restrictedRoute.TypedPerFilterConfig = make(map[string]*anypb.Any)
+		}
+		restrictedRoute.TypedPerFilterConfig[ipRestrictionFilterName] = rbacPerRoute
+		restrictedRoutes = append(restrictedRoutes, restrictedRoute)
+	}
+	return restrictedRoutes
+}
+
+// generateIPRestrictionRBAC returns the RBAC config, which allows the requests from the allowed CIDRs (or any
+// source if there are none) unless the source is denied. The source is the direct peer of the connection, as the
+// x-forwarded-for header can be set by the clients. Nil is returned if the requests are not restricted.
+func generateIPRestrictionRBAC(restriction *model.IPRestriction, blockedIPs []*throttle.IPCondition) *anypb.Any {
+	var allowed, denied []*rbac_config_v3.Principal
+	restrictedToAllowed := restriction != nil && len(restriction.Allow) > 0
+	if restriction != nil {
+		allowed = getCIDRPrincipals(restriction.Allow)
+		denied = getCIDRPrincipals(restriction.Deny)
+	}
+	for _, condition := range blockedIPs {
+		principal, err := getBlockedIPPrincipal(condition)
+		if err != nil {
+			logger.LoggerOasparser.ErrorC(logging.ErrorDetails{
+				Message:   fmt.Sprintf("IP blocking condition %d is not enforced by the router. %v", condition.Id, err),
+				Severity:  logging.MINOR,
+				ErrorCode: 2259,
+			})
+			continue
+		}
+		denied = append(denied, principal)
+	}
+	if !restrictedToAllowed && len(denied) == 0 {
+		return nil
+	}
+
+	var principal *rbac_config_v3.Principal
+	if restrictedToAllowed {
+		// none of the sources are allowed if all the allowed CIDRs are invalid
+		principal = &rbac_config_v3.Principal{
+			Identifier: &rbac_config_v3.Principal_NotId{
+				NotId: &rbac_config_v3.Principal{Identifier: &rbac_config_v3.Principal_Any{Any: true}},
+			},
+		}
+		if len(allowed) > 0 {
+			principal = orPrincipals(allowed)
+		}
+	}
+	if len(denied) > 0 {
+		notDenied := &rbac_config_v3.Principal{
+			Identifier: &rbac_config_v3.Principal_NotId{NotId: orPrincipals(denied)},
+		}
+		if principal == nil {
+			principal = notDenied
+		} else {
+			principal = &rbac_config_v3.Principal{
+				Identifier: &rbac_config_v3.Principal_AndIds{
+					AndIds: &rbac_config_v3.Principal_Set{Ids: []*rbac_config_v3.Principal{principal, notDenied}},
+				},
+			}
+		}
+	}
+
+	rbacPerRoute := &rbacv3.RBACPerRoute{
+		Rbac: &rbacv3.RBAC{
+			Rules: &rbac_config_v3.RBAC{
+				Action: rbac_config_v3.RBAC_ALLOW,
+				Policies: map[string]*rbac_config_v3.Policy{
+					ipRestrictionPolicyName: {
+						Permissions: []*rbac_config_v3.Permission{
+							{Rule: &rbac_config_v3.Permission_Any{Any: true}},
+						},
+						Principals: []*rbac_config_v3.Principal{principal},
+					},
+				},
+			},
+		},
+	}
+	marshalledRBAC, err := anypb.New(rbacPerRoute)
+	if err != nil {
+		logger.LoggerOasparser.Error("Error while generating the RBAC config of the IP restriction.", err)
+		return nil
+	}
+	return marshalledRBAC
+}
+
+// getCIDRPrincipals returns the principals matching the source IPs of the given CIDRs (or IPs). The invalid
+// CIDRs are ignored.
+func getCIDRPrincipals(cidrs []string) []*rbac_config_v3.Principal {
+	principals := make([]*rbac_config_v3.Principal, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		if ip := net.ParseIP(cidr); ip != nil {
+			cidr = getSingleAddressCIDR(ip)
+		}
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			logger.LoggerOasparser.ErrorC(logging.ErrorDetails{
+				Message:   fmt.Sprintf("Invalid CIDR %q of the IP restriction is ignored. %v", cidr, err),
+				Severity:  logging.MINOR,
+				ErrorCode: 2258,
+			})
+			continue
+		}
+		principals = append(principals, getDirectRemoteIPPrincipal(ipNet))
+	}
+	return principals
+}
+
+// getBlockedIPPrincipal returns the principal matching the source IPs blocked by the given IP blocking condition.
+func getBlockedIPPrincipal(condition *throttle.IPCondition) (*rbac_config_v3.Principal, error) {
+	var principals []*rbac_config_v3.Principal
+	switch condition.Type {
+	case blockedIPType:
+		ip := net.ParseIP(condition.FixedIp)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid IP %q", condition.FixedIp)
+		}
+		_, ipNet, _ := net.ParseCIDR(getSingleAddressCIDR(ip))
+		principals = append(principals, getDirectRemoteIPPrincipal(ipNet))
+	case blockedIPRangeType:
+		ipNets, err := ipRangeToCIDRs(net.ParseIP(condition.StartingIp), net.ParseIP(condition.EndingIp))
+		if err != nil {
+			return nil, fmt.Errorf("invalid IP range %q - %q. %v", condition.StartingIp, condition.EndingIp, err)
+		}
+		for _, ipNet := range ipNets {
+			principals = append(principals, getDirectRemoteIPPrincipal(ipNet))
+		}
+	default:
+		return nil, fmt.Errorf("unknown condition type %q", condition.Type)
+	}
+	principal := orPrincipals(principals)
+	if condition.Invert {
+		// all the sources except the given IPs are blocked
+		principal = &rbac_config_v3.Principal{
+			Identifier: &rbac_config_v3.Principal_NotId{NotId: principal},
+		}
+	}
+	return principal, nil
+}
+
+// ipRangeToCIDRs returns the minimal list of CIDRs covering the IPs from start to end (inclusive).
+func ipRangeToCIDRs(start, end net.IP) ([]*net.IPNet, error) {
+	if start == nil || end == nil {
+		return nil, errors.New("invalid IP")
+	}
+	if start.To4() != nil && end.To4() != nil {
+		start, end = start.To4(), end.To4()
+	} else if start.To4() != nil || end.To4() != nil {
+		return nil, errors.New("IP versions of the range are different")
+	}
+	bits := len(start) * 8
+	first := new(big.Int).SetBytes(start)
+	last := new(big.Int).SetBytes(end)
+	if first.Cmp(last) > 0 {
+		return nil, errors.New("starting IP is greater than the ending IP")
+	}
+	var cidrs []*net.IPNet
+	one := big.NewInt(1)
+	for first.Cmp(last) <= 0 {
+		// the largest block aligned to the first IP, which does not exceed the last IP
+		size := int(first.TrailingZeroBits())
+		if first.Sign() == 0 {
+			size = bits
+		}
+		for ; size > 0; size-- {
+			blockLast := new(big.Int).Add(first, new(big.Int).Sub(new(big.Int).Lsh(one, uint(size)), one))
+			if blockLast.Cmp(last) <= 0 {
+				break
+			}
+		}
+		ip := make(net.IP, len(start))
+		first.FillBytes(ip)
+		cidrs = append(cidrs, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits-size, bits)})
+		first.Add(first, new(big.Int).Lsh(one, uint(size)))
+	}
+	return cidrs, nil
+}
+
+// getSingleAddressCIDR returns the CIDR of the given IP only.
+func getSingleAddressCIDR(ip net.IP) string {
+	if ip.To4() != nil {
+		return ip.String() + "/32"
+	}
+	return ip.String() + "/128"
+}
+
+// getDirectRemoteIPPrincipal returns the principal matching the IP of the direct peer of the connection.
+func getDirectRemoteIPPrincipal(ipNet *net.IPNet) *rbac_config_v3.Principal {
+	prefixLen, _ := ipNet.Mask.Size()
+	return &rbac_config_v3.Principal{
+		Identifier: &rbac_config_v3.Principal_DirectRemoteIp{
+			DirectRemoteIp: &corev3.CidrRange{
+				AddressPrefix: ipNet.IP.String(),
+				PrefixLen:     wrapperspb.UInt32(uint32(prefixLen)),
+			},
+		},
+	}
+}
+
+func orPrincipals(principals []*rbac_config_v3.Principal) *rbac_config_v3.Principal {
+	if len(principals) == 1 {
+		return principals[0]
+	}
+	return &rbac_config_v3.Principal{
+		Identifier: &rbac_config_v3.Principal_OrIds{
+			OrIds: &rbac_config_v3.Principal_Set{Ids: principals},
+		},
+	}
+}
@@ -20,7 +20,9 @@ package envoyconf
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
@@ -34,6 +36,7 @@ import (
 	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/wso2/product-microgateway/adapter/config"
 	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/pkg/logging"
 	"google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/durationpb"
 )
@@ -48,6 +51,10 @@ func CreateRoutesConfigForRds(vHosts []*routev3.VirtualHost) *routev3.RouteConfi
 		VirtualHosts:           vHosts,
 		RequestHeadersToRemove: []string{clusterHeaderName},
 	}
+	conf, _ := config.ReadConfigs()
+	if conf.Envoy.APIDocs.Enabled {
+		routeConfiguration.MaxDirectResponseBodySizeBytes = &wrappers.UInt32Value{Value: conf.Envoy.APIDocs.MaxSizeInBytes}
+	}
 	return &routeConfiguration
 }
 
@@ -122,6 +129,7 @@ func createListeners(conf *config.Config) []*listenerv3.Listener {
 	if len(accessLogs) > 0 {
 		manager.AccessLog = accessLogs
 	}
+	setForwardClientCertDetails(manager, conf.Envoy.Downstream.TLS.ForwardClientCertDetails)
 
 	if conf.Tracing.Enabled {
 		if conf.Tracing.Type == TracerTypeOtlp {
@@ -160,8 +168,6 @@ func createListeners(conf *config.Config) []*listenerv3.Listener {
 	filters = append(filters, &connectionManagerFilterP)
 
 	if conf.Envoy.SecuredListenerPort > 0 {
-		var tlsFilter *tlsv3.DownstreamTlsContext
-
 		listenerHostAddress := defaultListenerHostAddress
 		if len(conf.Envoy.SecuredListenerHost) > 0 {
 			listenerHostAddress = conf.Envoy.SecuredListenerHost
@@ -188,50 +194,9 @@ func createListeners(conf *config.Config) []*listenerv3.Listener {
 		}
 
 		tlsCert := generateTLSCert(conf.Envoy.KeyStore.KeyPath, conf.Envoy.KeyStore.CertPath)
-		//TODO: (VirajSalaka) Make it configurable via SDS
-		if conf.Envoy.Downstream.TLS.MTLSAPIsEnabled {
-			tlsFilter = &tlsv3.DownstreamTlsContext{
-				// This is false since the authentication will be done at the enforcer
-				RequireClientCertificate: &wrappers.BoolValue{
-					Value: false,
-				},
-				CommonTlsContext: &tlsv3.CommonTlsContext{
-					//TlsCertificateSdsSecretConfigs
-					TlsCertificates: []*tlsv3.TlsCertificate{tlsCert},
-					//For the purpose of including peer certificate into the request context
-					ValidationContextType: &tlsv3.CommonTlsContext_ValidationContext{
-						ValidationContext: &tlsv3.CertificateValidationContext{
-							TrustedCa: &corev3.DataSource{
-								Specifier: &corev3.DataSource_Filename{
-									Filename: conf.Envoy.Downstream.TLS.TrustedCertPath,
-								},
-							},
-						},
-					},
-				},
-			}
-		} else {
-			tlsFilter = &tlsv3.DownstreamTlsContext{
-				CommonTlsContext: &tlsv3.CommonTlsContext{
-					//TlsCertificateSdsSecretConfigs
-					TlsCertificates: []*tlsv3.TlsCertificate{tlsCert},
-				},
-			}
-		}
-
-		marshalledTLSFilter, err := anypb.New(tlsFilter)
-		if err != nil {
-			logger.LoggerOasparser.Fatal("Error while Marshalling the downstream TLS Context for the configuration.")
-		}
-
-		transportSocket := &corev3.TransportSocket{
-			Name: transportSocketName,
-			ConfigType: &corev3.TransportSocket_TypedConfig{
-				TypedConfig: marshalledTLSFilter,
-			},
-		}
+		transportSocket := generateDownstreamTransportSocket(conf, tlsCert)
 
-		// At the moment, the listener as only one filter chain
+		// The default filter chain is the first one. SNI based filter chains are added later, once the vhosts are known.
 		securedListener.FilterChains[0].TransportSocket = transportSocket
 		listeners = append(listeners, &securedListener)
 		logger.LoggerOasparser.Infof("Secured Listener is added. %s : %d", listenerHostAddress, conf.Envoy.SecuredListenerPort)
@@ -277,22 +242,153 @@ func createListeners(conf *config.Config) []*listenerv3.Listener {
 	return listeners
 }
 
+// generateDownstreamTransportSocket creates the TLS transport socket of the secured listener which serves the
+// provided certificate.
+func generateDownstreamTransportSocket(conf *config.Config, tlsCert *tlsv3.TlsCertificate) *corev3.TransportSocket {
+	var tlsFilter *tlsv3.DownstreamTlsContext
+	//TODO: (VirajSalaka) Make it configurable via SDS
+	if conf.Envoy.Downstream.TLS.MTLSAPIsEnabled {
+		tlsFilter = &tlsv3.DownstreamTlsContext{
+			// This is false since the authentication will be done at the enforcer
+			RequireClientCertificate: &wrappers.BoolValue{
+				Value: false,
+			},
+			CommonTlsContext: &tlsv3.CommonTlsContext{
+				//TlsCertificateSdsSecretConfigs
+				TlsCertificates: []*tlsv3.TlsCertificate{tlsCert},
+				//For the purpose of including peer certificate into the request context
+				ValidationContextType: &tlsv3.CommonTlsContext_ValidationContext{
+					ValidationContext: &tlsv3.CertificateValidationContext{
+						TrustedCa: &corev3.DataSource{
+							Specifier: &corev3.DataSource_Filename{
+								Filename: conf.Envoy.Downstream.TLS.TrustedCertPath,
+							},
+						},
+						TrustChainVerification: getTrustChainVerification(conf),
+					},
+				},
+			},
+		}
+	} else {
+		tlsFilter = &tlsv3.DownstreamTlsContext{
+			CommonTlsContext: &tlsv3.CommonTlsContext{
+				//TlsCertificateSdsSecretConfigs
+				TlsCertificates: []*tlsv3.TlsCertificate{tlsCert},
+			},
+		}
+	}
+
+	marshalledTLSFilter, err := anypb.New(tlsFilter)
+	if err != nil {
+		logger.LoggerOasparser.Fatal("Error while Marshalling the downstream TLS Context for the configuration.")
+	}
+
+	return &corev3.TransportSocket{
+		Name: transportSocketName,
+		ConfigType: &corev3.TransportSocket_TypedConfig{
+			TypedConfig: marshalledTLSFilter,
+		},
+	}
+}
+
+// getTrustChainVerification returns whether the client certificates which are not signed by the trusted
+// certificates are accepted. Those are authenticated by the enforcer using the client certificates of the API.
+func getTrustChainVerification(conf *config.Config) tlsv3.CertificateValidationContext_TrustChainVerification {
+	if conf.Envoy.Downstream.TLS.TrustAPIClientCerts {
+		return tlsv3.CertificateValidationContext_ACCEPT_UNTRUSTED
+	}
+	return tlsv3.CertificateValidationContext_VERIFY_TRUST_CHAIN
+}
+
+// setForwardClientCertDetails sets how the x-forwarded-client-cert header is sent to the backends. The
+// certificate and the subject of the client connection are set, when the header is set by the router.
+func setForwardClientCertDetails(manager *hcmv3.HttpConnectionManager, forwardClientCertDetails string) {
+	details, found := hcmv3.HttpConnectionManager_ForwardClientCertDetails_value[strings.ToUpper(
+		forwardClientCertDetails)]
+	if !found {
+		if forwardClientCertDetails != "" {
+			logger.LoggerOasparser.Warnf("Invalid value %q for forwardClientCertDetails, hence the "+
+				"x-forwarded-client-cert header is removed from the requests.", forwardClientCertDetails)
+		}
+		return
+	}
+	manager.ForwardClientCertDetails = hcmv3.HttpConnectionManager_ForwardClientCertDetails(details)
+	switch manager.ForwardClientCertDetails {
+	case hcmv3.HttpConnectionManager_SANITIZE_SET, hcmv3.HttpConnectionManager_APPEND_FORWARD:
+		manager.SetCurrentClientCertDetails = &hcmv3.HttpConnectionManager_SetCurrentClientCertDetails{
+			Subject: &wrappers.BoolValue{Value: true},
+			Cert:    true,
+			Uri:     true,
+		}
+	}
+}
+
 // CreateVirtualHosts creates VirtualHost configurations for envoy which serves
 // request from the vHost domain. The routes array will be included as the routes
 // for the created virtual host.
 func CreateVirtualHosts(vhostToRouteArrayMap map[string][]*routev3.Route) []*routev3.VirtualHost {
+	vhosts := make([]string, 0, len(vhostToRouteArrayMap))
+	for vhost := range vhostToRouteArrayMap {
+		vhosts = append(vhosts, vhost)
+	}
+	// sorted to generate the same route configuration for the same set of routes
+	sort.Strings(vhosts)
 	virtualHosts := make([]*routev3.VirtualHost, 0, len(vhostToRouteArrayMap))
-	for vhost, routes := range vhostToRouteArrayMap {
+	for _, vhost := range vhosts {
+		routes := vhostToRouteArrayMap[vhost]
 		virtualHost := &routev3.VirtualHost{
 			Name:    vhost,
 			Domains: []string{vhost, fmt.Sprint(vhost, ":*")},
 			Routes:  routes,
+			// The buffer filter is enabled only for the routes with a request body size limit
+			TypedPerFilterConfig: map[string]*anypb.Any{
+				wellknown.Buffer: getDisabledBufferFilterConfig(),
+			},
 		}
 		virtualHosts = append(virtualHosts, virtualHost)
 	}
 	return virtualHosts
 }
 
+// SetCustomDomains adds the custom domains of the vhosts to the respective virtual hosts. A domain already served
+// by another virtual host is skipped, as the router rejects a route configuration with duplicate domains.
+func SetCustomDomains(virtualHosts []*routev3.VirtualHost, customDomains map[string][]string) {
+	servedDomains := make(map[string]string)
+	for _, virtualHost := range virtualHosts {
+		for _, domain := range virtualHost.Domains {
+			servedDomains[domain] = virtualHost.Name
+		}
+	}
+	for _, virtualHost := range virtualHosts {
+		for _, customDomain := range customDomains[virtualHost.Name] {
+			customDomain = strings.ToLower(strings.TrimSpace(customDomain))
+			if customDomain == "" {
+				continue
+			}
+			domains := []string{customDomain}
+			// a domain can have a single wildcard, hence any port is not matched for wildcard domains
+			if !strings.HasPrefix(customDomain, "*") {
+				domains = append(domains, fmt.Sprint(customDomain, ":*"))
+			}
+			for _, domain := range domains {
+				if existingVhost, found := servedDomains[domain]; found {
+					if existingVhost != virtualHost.Name {
+						logger.LoggerOasparser.ErrorC(logging.ErrorDetails{
+							Message: fmt.Sprintf("Custom domain %s of the vhost %s is ignored, as it is already "+
+								"served by the vhost %s", domain, virtualHost.Name, existingVhost),
+							Severity:  logging.MINOR,
+							ErrorCode: 2253,
+						})
+					}
+					continue
+				}
+				servedDomains[domain] = virtualHost.Name
+				virtualHost.Domains = append(virtualHost.Domains, domain)
+			}
+		}
+	}
+}
+
 // TODO: (VirajSalaka) Still the following method is not utilized as Sds is not implement. Keeping the Implementation for future reference
 func generateDefaultSdsSecretFromConfigfile(privateKeyPath string, pulicKeyPath string) (*tlsv3.Secret, error) {
 	var secret tlsv3.Secret
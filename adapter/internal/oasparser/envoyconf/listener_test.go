@@ -18,11 +18,17 @@
 package envoyconf
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
+	listenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
 	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
 	envoy_config_trace_v3 "github.com/envoyproxy/go-control-plane/envoy/config/trace/v3"
 	cors_filter_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/cors/v3"
+	hcmv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	tlsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
 	"github.com/stretchr/testify/assert"
 	"github.com/wso2/product-microgateway/adapter/config"
@@ -60,6 +66,35 @@ func TestCreateListenerWithRds(t *testing.T) {
 		"Transport Socket should be null for non-secured listener")
 }
 
+func TestGetTrustChainVerification(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	defaultTrustAPIClientCerts := conf.Envoy.Downstream.TLS.TrustAPIClientCerts
+	defer func() {
+		conf.Envoy.Downstream.TLS.TrustAPIClientCerts = defaultTrustAPIClientCerts
+	}()
+	assert.Equal(t, tlsv3.CertificateValidationContext_VERIFY_TRUST_CHAIN, getTrustChainVerification(conf),
+		"Untrusted client certificates should be rejected by default")
+
+	conf.Envoy.Downstream.TLS.TrustAPIClientCerts = true
+	assert.Equal(t, tlsv3.CertificateValidationContext_ACCEPT_UNTRUSTED, getTrustChainVerification(conf))
+}
+
+func TestSetForwardClientCertDetails(t *testing.T) {
+	manager := &hcmv3.HttpConnectionManager{}
+	setForwardClientCertDetails(manager, "sanitize_set")
+	assert.Equal(t, hcmv3.HttpConnectionManager_SANITIZE_SET, manager.ForwardClientCertDetails)
+	assert.True(t, manager.GetSetCurrentClientCertDetails().GetCert(), "Client certificate is not forwarded")
+
+	manager = &hcmv3.HttpConnectionManager{}
+	setForwardClientCertDetails(manager, "forward_only")
+	assert.Equal(t, hcmv3.HttpConnectionManager_FORWARD_ONLY, manager.ForwardClientCertDetails)
+	assert.Nil(t, manager.GetSetCurrentClientCertDetails(), "Client certificate details are set when forwarding")
+
+	manager = &hcmv3.HttpConnectionManager{}
+	setForwardClientCertDetails(manager, "invalid")
+	assert.Equal(t, hcmv3.HttpConnectionManager_SANITIZE, manager.ForwardClientCertDetails)
+}
+
 func TestCreateVirtualHost(t *testing.T) {
 	// TODO: (Vajira) Add more test scenarios
 
@@ -86,6 +121,24 @@ func TestCreateVirtualHost(t *testing.T) {
 	}
 }
 
+func TestSetCustomDomains(t *testing.T) {
+	vhostToRouteArrayMap := map[string][]*routev3.Route{
+		"mg.wso2.com":  testCreateRoutesForUnitTests(t),
+		"api.wso2.com": testCreateRoutesForUnitTests(t),
+	}
+	vHosts := CreateVirtualHosts(vhostToRouteArrayMap)
+	SetCustomDomains(vHosts, map[string][]string{
+		"mg.wso2.com":  {"API.example.com", "*.apis.example.com", "api.wso2.com"},
+		"unknown.host": {"unknown.example.com"},
+	})
+
+	assert.Equal(t, "api.wso2.com", vHosts[0].Name, "Virtual hosts should be sorted by the vhost.")
+	assert.Equal(t, []string{"api.wso2.com", "api.wso2.com:*"}, vHosts[0].Domains)
+	assert.Equal(t, []string{"mg.wso2.com", "mg.wso2.com:*", "api.example.com", "api.example.com:*",
+		"*.apis.example.com"}, vHosts[1].Domains, "Custom domain served by another vhost should be ignored.")
+	assert.Nil(t, CreateRoutesConfigForRds(vHosts).Validate())
+}
+
 func TestCreateRoutesConfigForRds(t *testing.T) {
 	// TODO: (Vajira) Add more test scenarios
 	vhostToRouteArrayMap := map[string][]*routev3.Route{
@@ -202,16 +255,16 @@ func testCreateRoutesForUnitTests(t *testing.T) []*routev3.Route {
 		"resource_operation_id", []model.Endpoint{}, []model.Endpoint{})
 
 	route1, err := createRoutes(generateRouteCreateParamsForUnitTests("test", "HTTP", "localhost", "/test", "1.0.0", "/test",
-		&resourceWithGet, "test-cluster", "", corsConfigModel3, false))
+		&resourceWithGet, "test-cluster", "", corsConfigModel3))
 	assert.Nil(t, err, "Error while creating routes for resourceWithGet")
 	route2, err := createRoutes(generateRouteCreateParamsForUnitTests("test", "HTTP", "localhost", "/test", "1.0.0", "/test",
-		&resourceWithPost, "test-cluster", "", corsConfigModel3, false))
+		&resourceWithPost, "test-cluster", "", corsConfigModel3))
 	assert.Nil(t, err, "Error while creating routes for resourceWithPost")
 	route3, err := createRoutes(generateRouteCreateParamsForUnitTests("test", "HTTP", "localhost", "/test", "1.0.0", "/test",
-		&resourceWithPut, "test-cluster", "", corsConfigModel3, false))
+		&resourceWithPut, "test-cluster", "", corsConfigModel3))
 	assert.Nil(t, err, "Error while creating routes for resourceWithPut")
 	route4, err := createRoutes(generateRouteCreateParamsForUnitTests("test", "HTTP", "localhost", "/test", "1.0.0", "/test",
-		&resourceWithMultipleOperations, "test-cluster", "", corsConfigModel3, false))
+		&resourceWithMultipleOperations, "test-cluster", "", corsConfigModel3))
 	assert.Nil(t, err, "Error while creating routes for resourceWithMultipleOperations")
 
 	routes := []*routev3.Route{route1[0], route2[0], route3[0], route4[0]}
@@ -228,3 +281,61 @@ func testCreateRoutesForUnitTests(t *testing.T) []*routev3.Route {
 
 	return routes
 }
+
+func TestUpdateSNIFilterChains(t *testing.T) {
+	poolDir := t.TempDir()
+	for _, domain := range []string{"foo.com", "*.bar.com", "incomplete.com"} {
+		assert.Nil(t, os.Mkdir(filepath.Join(poolDir, domain), 0755))
+		assert.Nil(t, ioutil.WriteFile(filepath.Join(poolDir, domain, "tls.crt"), []byte("cert"), 0644))
+		if domain != "incomplete.com" {
+			assert.Nil(t, ioutil.WriteFile(filepath.Join(poolDir, domain, "tls.key"), []byte("key"), 0644))
+		}
+	}
+
+	conf, _ := config.ReadConfigs()
+	conf.Envoy.Downstream.TLS.SNI.Enabled = true
+	conf.Envoy.Downstream.TLS.SNI.CertPoolDirectory = poolDir
+	defer func() {
+		conf.Envoy.Downstream.TLS.SNI.Enabled = false
+	}()
+
+	listeners := CreateListenersWithRds()
+	UpdateSNIFilterChains(listeners, []string{"foo.com", "api.bar.com", "incomplete.com", "localhost"})
+
+	securedListener := listeners[0]
+	assert.Nil(t, securedListener.Validate(), "Listener validation failed")
+	assert.Equal(t, 3, len(securedListener.FilterChains), "Default and two SNI filter chains are expected.")
+	assert.Nil(t, securedListener.FilterChains[0].FilterChainMatch, "Default filter chain should not have a match.")
+	assert.Equal(t, []string{"*.bar.com"}, securedListener.FilterChains[1].FilterChainMatch.ServerNames)
+	assert.Equal(t, []string{"foo.com"}, securedListener.FilterChains[2].FilterChainMatch.ServerNames)
+	assert.NotNil(t, securedListener.FilterChains[2].GetTransportSocket(), "SNI filter chain should have a transport socket.")
+
+	// Vhosts and custom domains are matched with the pool regardless of the case.
+	UpdateSNIFilterChains(listeners, []string{"Foo.com", " API.Bar.com"})
+	assert.Equal(t, 3, len(securedListener.FilterChains), "Mixed case vhosts should match the SNI certificates.")
+	assert.Equal(t, []string{"*.bar.com"}, securedListener.FilterChains[1].FilterChainMatch.ServerNames)
+	assert.Equal(t, []string{"foo.com"}, securedListener.FilterChains[2].FilterChainMatch.ServerNames)
+
+	// Filter chains of undeployed vhosts are removed with the next update.
+	UpdateSNIFilterChains(listeners, []string{"localhost"})
+	assert.Equal(t, 1, len(securedListener.FilterChains), "Only the default filter chain is expected.")
+	assert.Equal(t, 1, len(listeners[1].FilterChains), "Non secured listener should not be updated.")
+
+	// The pool is read again only when it is reloaded.
+	UpdateSNIFilterChains(listeners, []string{"foo.com"})
+	getRevision := func(filterChain *listenerv3.FilterChain) string {
+		return filterChain.GetMetadata().GetFilterMetadata()[sniMetadataFilterName].GetFields()[sniRevisionMetadataKey].
+			GetStringValue()
+	}
+	revision := getRevision(securedListener.FilterChains[1])
+	assert.NotEmpty(t, revision)
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(poolDir, "foo.com", "tls.crt"), []byte("rotated cert"), 0644))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(poolDir, "incomplete.com", "tls.key"), []byte("key"), 0644))
+	UpdateSNIFilterChains(listeners, []string{"foo.com", "incomplete.com"})
+	assert.Equal(t, 2, len(securedListener.FilterChains), "Pool should not be read for each update.")
+	ReloadSNICertPool()
+	UpdateSNIFilterChains(listeners, []string{"foo.com", "incomplete.com"})
+	assert.Equal(t, 3, len(securedListener.FilterChains), "Certificate added to the pool should be applied.")
+	assert.NotEqual(t, revision, getRevision(securedListener.FilterChains[1]),
+		"Filter chain of a rotated certificate should be changed.")
+}
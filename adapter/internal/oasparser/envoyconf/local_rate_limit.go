@@ -0,0 +1,284 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package envoyconf
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"time"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	rbac_config_v3 "github.com/envoyproxy/go-control-plane/envoy/config/rbac/v3"
+	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	ratelimitv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/common/ratelimit/v3"
+	local_rate_limitv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/local_ratelimit/v3"
+	rbacv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/rbac/v3"
+	hcmv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	metadatav3 "github.com/envoyproxy/go-control-plane/envoy/type/metadata/v3"
+	typev3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/any"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/wso2/product-microgateway/adapter/config"
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/pkg/logging"
+)
+
+const (
+	applicationDescriptorKey  string = "application"
+	apiDescriptorKey          string = "api"
+	policyRateLimitStatPrefix string = "policy_rate_limit"
+	// policyRateLimitFilterName is the name of the local rate limit filter of the policies, which is placed after
+	// the ext_authz filter unlike the local rate limit filter of the JWKS endpoint
+	policyRateLimitFilterName        string = "envoy.filters.http.local_ratelimit.policies"
+	policyRateLimitEnabledRuntimeKey string = "policy_ratelimit_enabled"
+	// policyRateLimitFillInterval is the fill interval of the token bucket applied to the requests which do not
+	// match any descriptor. The fill intervals of the descriptors should be multiples of this.
+	policyRateLimitFillInterval time.Duration = time.Second
+	// applicationUUIDMetadataKey is the key of the UUID of the authenticated application, in the dynamic metadata
+	// of the ext_authz filter set by the enforcer
+	applicationUUIDMetadataKey string = "x-wso2-application-uuid"
+)
+
+// The requests from the exempted sources are identified by the shadow rules of the RBAC filter, which only
+// records the result in the dynamic metadata. The result is added to the descriptors of the requests, hence the
+// requests from the exempted sources do not match the descriptors of the limits.
+const (
+	sourceExemptionDescriptorKey string = "source_exemption"
+	rbacShadowEngineResultKey    string = "shadow_engine_result"
+	// rbacShadowDenied is the result of the shadow rules for the sources which are not exempted
+	rbacShadowDenied              string = "denied"
+	rateLimitExemptionsPolicyName string = "rate_limit_exemptions"
+)
+
+// RateLimitDescriptor represents the request count limit of an application policy or a subscription policy.
+// The limit of a subscription policy is applied to the requests of the application to the given API, whereas the
+// limit of an application policy is applied to all the requests of the application.
+type RateLimitDescriptor struct {
+	ApplicationUUID string
+	// APIUUID is empty for the application policies
+	APIUUID      string
+	RequestCount uint32
+	FillInterval time.Duration
+}
+
+// getRateLimitActions returns the rate limit actions of an API route, which generate the descriptors of the
+// application and the subscription of the request. The application is read from the dynamic metadata set by the
+// enforcer once the request is authenticated, hence no descriptors are generated for the requests without an
+// authenticated application.
+func getRateLimitActions(apiUUID string) []*routev3.RateLimit {
+	applicationAction := &routev3.RateLimit_Action{
+		ActionSpecifier: &routev3.RateLimit_Action_Metadata{
+			Metadata: &routev3.RateLimit_Action_MetaData{
+				DescriptorKey: applicationDescriptorKey,
+				MetadataKey: &metadatav3.MetadataKey{
+					Key: extAuthzFilterName,
+					Path: []*metadatav3.MetadataKey_PathSegment{
+						{Segment: &metadatav3.MetadataKey_PathSegment_Key{Key: applicationUUIDMetadataKey}},
+					},
+				},
+				Source: routev3.RateLimit_Action_MetaData_DYNAMIC,
+			},
+		},
+	}
+	apiAction := &routev3.RateLimit_Action{
+		ActionSpecifier: &routev3.RateLimit_Action_GenericKey_{
+			GenericKey: &routev3.RateLimit_Action_GenericKey{
+				DescriptorKey:   apiDescriptorKey,
+				DescriptorValue: apiUUID,
+			},
+		},
+	}
+	sourceExemptionAction := &routev3.RateLimit_Action{
+		ActionSpecifier: &routev3.RateLimit_Action_Metadata{
+			Metadata: &routev3.RateLimit_Action_MetaData{
+				DescriptorKey: sourceExemptionDescriptorKey,
+				MetadataKey: &metadatav3.MetadataKey{
+					Key: wellknown.HTTPRoleBasedAccessControl,
+					Path: []*metadatav3.MetadataKey_PathSegment{
+						{Segment: &metadatav3.MetadataKey_PathSegment_Key{Key: rbacShadowEngineResultKey}},
+					},
+				},
+				// the shadow rules are not evaluated if there are no source exemptions
+				DefaultValue: rbacShadowDenied,
+				Source:       routev3.RateLimit_Action_MetaData_DYNAMIC,
+			},
+		},
+	}
+	return []*routev3.RateLimit{
+		{Actions: []*routev3.RateLimit_Action{applicationAction, sourceExemptionAction}},
+		{Actions: []*routev3.RateLimit_Action{applicationAction, apiAction, sourceExemptionAction}},
+	}
+}
+
+// getPolicyLocalRateLimitFilter returns the local rate limit filter of the application and subscription policies.
+// The limits are applied per virtual host.
+func getPolicyLocalRateLimitFilter() *hcmv3.HttpFilter {
+	marshalledRateLimitConfig, err := anypb.New(&local_rate_limitv3.LocalRateLimit{
+		StatPrefix: policyRateLimitStatPrefix,
+	})
+	if err != nil {
+		logger.LoggerOasparser.Error("Error while generating the local rate limit filter of the policies.", err)
+	}
+	return &hcmv3.HttpFilter{
+		Name: policyRateLimitFilterName,
+		ConfigType: &hcmv3.HttpFilter_TypedConfig{
+			TypedConfig: marshalledRateLimitConfig,
+		},
+	}
+}
+
+// SetLocalRateLimitDescriptors applies the request count limits to the virtual hosts. The limits are shared by
+// all the routes of a virtual host, and the requests which do not match any limit are not rate limited.
+func SetLocalRateLimitDescriptors(virtualHosts []*routev3.VirtualHost,
+	vhostToDescriptorsMap map[string][]RateLimitDescriptor) {
+	for _, virtualHost := range virtualHosts {
+		descriptors := vhostToDescriptorsMap[virtualHost.GetName()]
+		if len(descriptors) == 0 {
+			continue
+		}
+		if virtualHost.TypedPerFilterConfig == nil {
+			virtualHost.TypedPerFilterConfig = make(map[string]*any.Any)
+		}
+		virtualHost.TypedPerFilterConfig[policyRateLimitFilterName] = marshalLocalRateLimit(
+			generateLocalRateLimit(descriptors))
+	}
+}
+
+// SetRateLimitSourceExemptions exempts the requests from the source CIDRs from the local and global rate limits
+// of the virtual hosts.
+func SetRateLimitSourceExemptions(virtualHosts []*routev3.VirtualHost, exemptedCIDRs []string) {
+	if len(exemptedCIDRs) == 0 {
+		return
+	}
+	rbacPerRoute := generateSourceExemptionRBAC(exemptedCIDRs)
+	if rbacPerRoute == nil {
+		return
+	}
+	for _, virtualHost := range virtualHosts {
+		if virtualHost.TypedPerFilterConfig == nil {
+			virtualHost.TypedPerFilterConfig = make(map[string]*any.Any)
+		}
+		virtualHost.TypedPerFilterConfig[wellknown.HTTPRoleBasedAccessControl] = rbacPerRoute
+	}
+}
+
+// generateSourceExemptionRBAC returns the RBAC config, which records whether the request is from an exempted
+// source CIDR without denying any request. The source is the direct peer of the connection, as the
+// x-forwarded-for header can be set by the clients.
+func generateSourceExemptionRBAC(exemptedCIDRs []string) *any.Any {
+	var principals []*rbac_config_v3.Principal
+	for _, cidr := range exemptedCIDRs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			logger.LoggerOasparser.ErrorC(logging.ErrorDetails{
+				Message:   fmt.Sprintf("Invalid source CIDR %q of the rate limit exemptions is ignored. %v", cidr, err),
+				Severity:  logging.MINOR,
+				ErrorCode: 2241,
+			})
+			continue
+		}
+		principals = append(principals, getDirectRemoteIPPrincipal(ipNet))
+	}
+	if len(principals) == 0 {
+		return nil
+	}
+	rbacPerRoute := &rbacv3.RBACPerRoute{
+		Rbac: &rbacv3.RBAC{
+			ShadowRules: &rbac_config_v3.RBAC{
+				Action: rbac_config_v3.RBAC_ALLOW,
+				Policies: map[string]*rbac_config_v3.Policy{
+					rateLimitExemptionsPolicyName: {
+						Permissions: []*rbac_config_v3.Permission{
+							{Rule: &rbac_config_v3.Permission_Any{Any: true}},
+						},
+						Principals: principals,
+					},
+				},
+			},
+		},
+	}
+	marshalledRBAC, err := anypb.New(rbacPerRoute)
+	if err != nil {
+		logger.LoggerOasparser.Error("Error while generating the RBAC config of the rate limit exemptions.", err)
+		return nil
+	}
+	return marshalledRBAC
+}
+
+func generateLocalRateLimit(descriptors []RateLimitDescriptor) *local_rate_limitv3.LocalRateLimit {
+	enabled := &corev3.RuntimeFractionalPercent{
+		DefaultValue: &typev3.FractionalPercent{
+			Numerator:   100,
+			Denominator: typev3.FractionalPercent_HUNDRED,
+		},
+		RuntimeKey: policyRateLimitEnabledRuntimeKey,
+	}
+	localRateLimit := &local_rate_limitv3.LocalRateLimit{
+		StatPrefix: policyRateLimitStatPrefix,
+		// The token bucket is mandatory for a virtual host. Hence a bucket, which is never exhausted, is applied to
+		// the requests without a matching descriptor.
+		TokenBucket: &typev3.TokenBucket{
+			MaxTokens:     math.MaxUint32,
+			TokensPerFill: wrapperspb.UInt32(math.MaxUint32),
+			FillInterval:  durationpb.New(policyRateLimitFillInterval),
+		},
+		FilterEnabled:  enabled,
+		FilterEnforced: enabled,
+	}
+	// the headers of the global rate limits are added instead, if enabled
+	if conf, _ := config.ReadConfigs(); isRateLimitHeadersEnabled(conf) && !conf.Envoy.GlobalRateLimit.Enabled {
+		localRateLimit.EnableXRatelimitHeaders = ratelimitv3.XRateLimitHeadersRFCVersion_DRAFT_VERSION_03
+	}
+	for _, descriptor := range descriptors {
+		// the entries should be in the order of the rate limit actions
+		entries := []*ratelimitv3.RateLimitDescriptor_Entry{
+			{Key: applicationDescriptorKey, Value: descriptor.ApplicationUUID},
+		}
+		if descriptor.APIUUID != "" {
+			entries = append(entries, &ratelimitv3.RateLimitDescriptor_Entry{
+				Key: apiDescriptorKey, Value: descriptor.APIUUID})
+		}
+		entries = append(entries, &ratelimitv3.RateLimitDescriptor_Entry{
+			Key: sourceExemptionDescriptorKey, Value: rbacShadowDenied})
+		localRateLimit.Descriptors = append(localRateLimit.Descriptors, &ratelimitv3.LocalRateLimitDescriptor{
+			Entries: entries,
+			TokenBucket: &typev3.TokenBucket{
+				MaxTokens:     descriptor.RequestCount,
+				TokensPerFill: wrapperspb.UInt32(descriptor.RequestCount),
+				FillInterval:  durationpb.New(descriptor.FillInterval),
+			},
+		})
+	}
+	return localRateLimit
+}
+
+func marshalLocalRateLimit(localRateLimit *local_rate_limitv3.LocalRateLimit) *any.Any {
+	buffer := proto.NewBuffer(nil)
+	buffer.SetDeterministic(true)
+	_ = buffer.Marshal(localRateLimit)
+	return &any.Any{
+		TypeUrl: localRateLimitPerRouteName,
+		Value:   buffer.Bytes(),
+	}
+}
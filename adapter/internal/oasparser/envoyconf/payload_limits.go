@@ -0,0 +1,86 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package envoyconf
+
+import (
+	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	bufferv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/buffer/v3"
+	hcmv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// defaultBufferFilterMaxRequestBytes is the limit of the buffer filter, which is never applied as the filter is
+// disabled for the virtual hosts and enabled only for the routes with a request body size limit.
+const defaultBufferFilterMaxRequestBytes uint32 = 1024 * 1024
+
+// getBufferFilter returns the buffer filter, which enforces the request body size limits of the routes
+func getBufferFilter() *hcmv3.HttpFilter {
+	bufferConfig, err := anypb.New(&bufferv3.Buffer{
+		MaxRequestBytes: wrapperspb.UInt32(defaultBufferFilterMaxRequestBytes),
+	})
+	if err != nil {
+		logger.LoggerOasparser.Error("Error while generating the buffer filter.", err)
+	}
+	return &hcmv3.HttpFilter{
+		Name: wellknown.Buffer,
+		ConfigType: &hcmv3.HttpFilter_TypedConfig{
+			TypedConfig: bufferConfig,
+		},
+	}
+}
+
+// getDisabledBufferFilterConfig returns the per route configuration disabling the buffer filter
+func getDisabledBufferFilterConfig() *anypb.Any {
+	bufferPerRoute, _ := anypb.New(&bufferv3.BufferPerRoute{
+		Override: &bufferv3.BufferPerRoute_Disabled{Disabled: true},
+	})
+	return bufferPerRoute
+}
+
+// applyPayloadLimits applies the payload limits to the given routes. The request bodies are buffered to enforce
+// the request body size limit, unless the request bodies are passed through.
+func applyPayloadLimits(routes []*routev3.Route, payloadLimits *model.PayloadLimits) {
+	if payloadLimits == nil {
+		return
+	}
+	var bufferPerRoute *anypb.Any
+	if payloadLimits.MaxRequestBodySizeInBytes > 0 && !payloadLimits.Passthrough {
+		bufferPerRoute, _ = anypb.New(&bufferv3.BufferPerRoute{
+			Override: &bufferv3.BufferPerRoute_Buffer{
+				Buffer: &bufferv3.Buffer{
+					MaxRequestBytes: wrapperspb.UInt32(payloadLimits.MaxRequestBodySizeInBytes),
+				},
+			},
+		})
+	}
+	for _, route := range routes {
+		if payloadLimits.MaxBufferSizeInBytes > 0 {
+			route.PerRequestBufferLimitBytes = wrapperspb.UInt32(payloadLimits.MaxBufferSizeInBytes)
+		}
+		if bufferPerRoute != nil {
+			if route.TypedPerFilterConfig == nil {
+				route.TypedPerFilterConfig = make(map[string]*anypb.Any)
+			}
+			route.TypedPerFilterConfig[wellknown.Buffer] = bufferPerRoute
+		}
+	}
+}
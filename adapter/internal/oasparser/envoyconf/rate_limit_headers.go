@@ -0,0 +1,145 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package envoyconf
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	luav3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/lua/v3"
+	ratelimitfilterv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ratelimit/v3"
+	hcmv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	"github.com/golang/protobuf/ptypes/any"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/wso2/product-microgateway/adapter/config"
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/constants"
+)
+
+// The rate limit filters add the X-RateLimit-* headers (draft 03 of the IETF RateLimit header fields). The headers
+// are renamed or removed as per the format by a Lua filter, which is placed before the rate limit filters, hence the
+// headers of the responses rate limited by those filters are processed as well.
+var rateLimitHeaderNames = []string{"limit", "remaining", "reset"}
+
+const xRateLimitHeaderPrefix string = "x-ratelimit-"
+
+// isRateLimitHeadersEnabled returns true if the rate limit headers are enabled and the requests are rate limited.
+func isRateLimitHeadersEnabled(conf *config.Config) bool {
+	return conf.Envoy.RateLimitHeaders.Enabled && (conf.Envoy.LocalRateLimit.Enabled ||
+		conf.Envoy.GlobalRateLimit.Enabled)
+}
+
+// getRateLimitHeadersFilter returns the Lua filter, which formats the rate limit headers of the responses as per the
+// configured format. The format of an API is set as a per route config.
+func getRateLimitHeadersFilter() *hcmv3.HttpFilter {
+	conf, _ := config.ReadConfigs()
+	luaConfig := &luav3.Lua{
+		DefaultSourceCode: &corev3.DataSource{
+			Specifier: &corev3.DataSource_InlineString{
+				InlineString: generateRateLimitHeadersScript(conf.Envoy.RateLimitHeaders.Format),
+			},
+		},
+	}
+	ext, err := anypb.New(luaConfig)
+	if err != nil {
+		logger.LoggerOasparser.Error(err)
+	}
+	return &hcmv3.HttpFilter{
+		Name: rateLimitHeadersFilterName,
+		ConfigType: &hcmv3.HttpFilter_TypedConfig{
+			TypedConfig: ext,
+		},
+	}
+}
+
+// generateRateLimitHeadersScript returns the Lua script, which renames the X-RateLimit-* headers to RateLimit-*
+// headers for the rateLimit format, or removes those if the headers are disabled. The headers of the requests, which
+// do not match a limit, are removed as those show the unlimited token bucket of the local rate limits.
+func generateRateLimitHeadersScript(format string) string {
+	var script strings.Builder
+	script.WriteString("function envoy_on_request(request_handle)\nend\n")
+	script.WriteString("function envoy_on_response(response_handle)\n")
+	script.WriteString("  local headers = response_handle:headers()\n")
+	script.WriteString(fmt.Sprintf("  local limit = headers:get(%q)\n", xRateLimitHeaderPrefix+"limit"))
+	script.WriteString("  if limit == nil then\n    return\n  end\n")
+	unlimited := strconv.FormatUint(math.MaxUint32, 10)
+	if format == constants.RateLimitHeadersDisabled {
+		script.WriteString("  do\n")
+	} else {
+		script.WriteString(fmt.Sprintf("  if string.sub(limit, 1, %d) == %q then\n", len(unlimited), unlimited))
+	}
+	for _, name := range rateLimitHeaderNames {
+		script.WriteString(fmt.Sprintf("    headers:remove(%q)\n", xRateLimitHeaderPrefix+name))
+	}
+	script.WriteString("    return\n  end\n")
+	if format == constants.RateLimitHeadersFormat {
+		for _, name := range rateLimitHeaderNames {
+			script.WriteString(fmt.Sprintf("  do\n    local value = headers:get(%q)\n", xRateLimitHeaderPrefix+name))
+			script.WriteString("    if value ~= nil then\n")
+			script.WriteString(fmt.Sprintf("      headers:remove(%q)\n", xRateLimitHeaderPrefix+name))
+			script.WriteString(fmt.Sprintf("      headers:replace(%q, value)\n", "ratelimit-"+name))
+			script.WriteString("    end\n  end\n")
+		}
+	}
+	script.WriteString("end\n")
+	return script.String()
+}
+
+// setRateLimitHeadersFormat sets the format of the rate limit headers of an API to its routes, if it is not the
+// format configured for the router.
+func setRateLimitHeadersFormat(routes []*routev3.Route, format string) {
+	conf, _ := config.ReadConfigs()
+	if !isRateLimitHeadersEnabled(conf) || format == "" || format == conf.Envoy.RateLimitHeaders.Format {
+		return
+	}
+	luaPerRoute, err := anypb.New(&luav3.LuaPerRoute{
+		Override: &luav3.LuaPerRoute_SourceCode{
+			SourceCode: &corev3.DataSource{
+				Specifier: &corev3.DataSource_InlineString{
+					InlineString: generateRateLimitHeadersScript(format),
+				},
+			},
+		},
+	})
+	if err != nil {
+		logger.LoggerOasparser.Error("Error while generating the rate limit headers config of the routes. ", err)
+		return
+	}
+	for _, route := range routes {
+		// the per route configs may be shared by the routes of an API, hence copied
+		configs := make(map[string]*any.Any, len(route.TypedPerFilterConfig)+1)
+		for name, filterConfig := range route.TypedPerFilterConfig {
+			configs[name] = filterConfig
+		}
+		configs[rateLimitHeadersFilterName] = luaPerRoute
+		route.TypedPerFilterConfig = configs
+	}
+}
+
+// getXRateLimitHeadersVersion returns the version of the rate limit headers added by the global rate limit filter.
+func getXRateLimitHeadersVersion(conf *config.Config) ratelimitfilterv3.RateLimit_XRateLimitHeadersRFCVersion {
+	if isRateLimitHeadersEnabled(conf) {
+		return ratelimitfilterv3.RateLimit_DRAFT_VERSION_03
+	}
+	return ratelimitfilterv3.RateLimit_OFF
+}
@@ -0,0 +1,235 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package envoyconf
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	cachev3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/cache/v3"
+	luav3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/lua/v3"
+	hcmv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	simple_http_cachev3 "github.com/envoyproxy/go-control-plane/envoy/extensions/http/cache/simple_http_cache/v3"
+	envoy_type_matcherv3 "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	"github.com/wso2/product-microgateway/adapter/config"
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
+	"github.com/wso2/product-microgateway/adapter/pkg/logging"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+const (
+	cacheControlHeader string = "cache-control"
+	varyHeader         string = "vary"
+	// noStoreCacheControl prevents the router (and the clients) from caching a response
+	noStoreCacheControl string = "no-store"
+	// privateCacheControl prevents the router from caching a response, while the client may still cache it
+	privateCacheControl string = "private"
+	setCookieHeader     string = "set-cookie"
+	// responseCacheControlFilterName is the name of the filter setting the Cache-Control of the responses to be cached
+	responseCacheControlFilterName string = "envoy.filters.http.lua.response_cache_control"
+)
+
+// getResponseCacheFilter returns the cache filter, which caches the responses in memory. The responses are cached
+// as allowed by their Cache-Control header, which is set by the routes of the cached resources.
+func getResponseCacheFilter() *hcmv3.HttpFilter {
+	conf, _ := config.ReadConfigs()
+	cacheConfig := &cachev3.CacheConfig{}
+	cacheConfig.TypedConfig, _ = anypb.New(&simple_http_cachev3.SimpleHttpCacheConfig{})
+	for _, header := range conf.Envoy.Filters.ResponseCache.AllowedVaryHeaders {
+		cacheConfig.AllowedVaryHeaders = append(cacheConfig.AllowedVaryHeaders, &envoy_type_matcherv3.StringMatcher{
+			MatchPattern: &envoy_type_matcherv3.StringMatcher_Exact{Exact: strings.ToLower(header)},
+			IgnoreCase:   true,
+		})
+	}
+	typedConfig, err := anypb.New(cacheConfig)
+	if err != nil {
+		logger.LoggerOasparser.Error("Error while generating the response cache filter.", err)
+	}
+	return &hcmv3.HttpFilter{
+		Name: cacheFilterName,
+		ConfigType: &hcmv3.HttpFilter_TypedConfig{
+			TypedConfig: typedConfig,
+		},
+	}
+}
+
+// getResponseCacheControlFilter returns the filter, which marks the responses setting cookies as private before
+// those reach the cache filter, hence the cookies of a client are never served to the other clients from the cache.
+func getResponseCacheControlFilter() *hcmv3.HttpFilter {
+	luaConfig := &luav3.Lua{
+		DefaultSourceCode: &corev3.DataSource{
+			Specifier: &corev3.DataSource_InlineString{
+				InlineString: generateResponseCacheControlScript(),
+			},
+		},
+	}
+	ext, err := anypb.New(luaConfig)
+	if err != nil {
+		logger.LoggerOasparser.Error(err)
+	}
+	return &hcmv3.HttpFilter{
+		Name: responseCacheControlFilterName,
+		ConfigType: &hcmv3.HttpFilter_TypedConfig{
+			TypedConfig: ext,
+		},
+	}
+}
+
+// generateResponseCacheControlScript returns the Lua script, which prepends the private directive to the
+// Cache-Control header of the responses setting cookies.
+func generateResponseCacheControlScript() string {
+	var script strings.Builder
+	script.WriteString("function envoy_on_request(request_handle)\nend\n")
+	script.WriteString("function envoy_on_response(response_handle)\n")
+	script.WriteString("  local headers = response_handle:headers()\n")
+	script.WriteString(fmt.Sprintf("  if headers:get(%q) == nil then\n    return\n  end\n", setCookieHeader))
+	script.WriteString(fmt.Sprintf("  local cacheControl = headers:get(%q)\n", cacheControlHeader))
+	script.WriteString("  if cacheControl == nil or cacheControl == \"\" then\n")
+	script.WriteString(fmt.Sprintf("    headers:replace(%q, %q)\n", cacheControlHeader, privateCacheControl))
+	script.WriteString("  else\n")
+	script.WriteString(fmt.Sprintf("    headers:replace(%q, %q .. cacheControl)\n", cacheControlHeader,
+		privateCacheControl+", "))
+	script.WriteString("  end\nend\n")
+	return script.String()
+}
+
+// applyResponseCache applies the response cache of a resource to the routes of the GET requests, and returns the
+// routes. The responses are cached for the TTL of the cache, unless the backend sets the Cache-Control header of
+// the responses (ie: private or no-store), varying by the key headers. The responses of a secured resource are not
+// cached, as the cached responses are not keyed by the consumer authenticated by the enforcer.
+// A copy of a route is added prior to the route for each bypass header, which matches the requests with the header
+// and prevents caching their responses. As the cached responses vary by the bypass headers as well, the requests
+// with a bypass header are never served from the cache.
+func applyResponseCache(routes []*routev3.Route, cache *model.ResponseCache, secured bool) []*routev3.Route {
+	if cache == nil {
+		return routes
+	}
+	conf, _ := config.ReadConfigs()
+	if !conf.Envoy.Filters.ResponseCache.Enabled {
+		logger.LoggerOasparser.Warnf("Response cache of the resource is not applied, as the response cache of the " +
+			"router is disabled.")
+		return routes
+	}
+	if secured && cache.Enabled {
+		logger.LoggerOasparser.ErrorC(logging.ErrorDetails{
+			Message: "Response cache of the resource is not applied, as the responses of a secured resource can " +
+				"not be shared among the consumers.",
+			Severity:  logging.MINOR,
+			ErrorCode: 2267,
+		})
+		return routes
+	}
+	varyHeaders := append(append([]string{}, cache.KeyHeaders...), cache.BypassHeaders...)
+	if header := findDisallowedVaryHeader(varyHeaders, conf.Envoy.Filters.ResponseCache.AllowedVaryHeaders); header != "" {
+		logger.LoggerOasparser.ErrorC(logging.ErrorDetails{
+			Message: fmt.Sprintf("Response cache of the resource is not applied, as the header %q is not allowed to "+
+				"vary the cached responses by the router.", header),
+			Severity:  logging.MINOR,
+			ErrorCode: 2265,
+		})
+		return routes
+	}
+
+	cachedRoutes := make([]*routev3.Route, 0, len(routes))
+	for _, route := range routes {
+		if !routeMatchesMethod(route, http.MethodGet) {
+			cachedRoutes = append(cachedRoutes, route)
+			continue
+		}
+		if !cache.Enabled {
+			route.ResponseHeadersToAdd = append(route.ResponseHeadersToAdd, generateHeaderValueOption(
+				cacheControlHeader, noStoreCacheControl, corev3.HeaderValueOption_OVERWRITE_IF_EXISTS_OR_ADD))
+			cachedRoutes = append(cachedRoutes, route)
+			continue
+		}
+		var varyHeaderOptions []*corev3.HeaderValueOption
+		if len(varyHeaders) > 0 {
+			varyHeaderOptions = append(varyHeaderOptions, generateHeaderValueOption(varyHeader,
+				strings.Join(varyHeaders, ", "), corev3.HeaderValueOption_APPEND_IF_EXISTS_OR_ADD))
+		}
+		for _, bypassHeader := range cache.BypassHeaders {
+			bypassRoute := proto.Clone(route).(*routev3.Route)
+			bypassRoute.Match.Headers = append(bypassRoute.Match.Headers, &routev3.HeaderMatcher{
+				Name:                 bypassHeader,
+				HeaderMatchSpecifier: &routev3.HeaderMatcher_PresentMatch{PresentMatch: true},
+			})
+			bypassRoute.ResponseHeadersToAdd = append(bypassRoute.ResponseHeadersToAdd, varyHeaderOptions...)
+			bypassRoute.ResponseHeadersToAdd = append(bypassRoute.ResponseHeadersToAdd, generateHeaderValueOption(
+				cacheControlHeader, noStoreCacheControl, corev3.HeaderValueOption_OVERWRITE_IF_EXISTS_OR_ADD))
+			cachedRoutes = append(cachedRoutes, bypassRoute)
+		}
+		route.ResponseHeadersToAdd = append(route.ResponseHeadersToAdd, varyHeaderOptions...)
+		route.ResponseHeadersToAdd = append(route.ResponseHeadersToAdd, generateHeaderValueOption(cacheControlHeader,
+			fmt.Sprintf("max-age=%d", cache.TTLInSeconds), corev3.HeaderValueOption_ADD_IF_ABSENT))
+		cachedRoutes = append(cachedRoutes, route)
+	}
+	return cachedRoutes
+}
+
+// findDisallowedVaryHeader returns the first header, which is not allowed to vary the cached responses by.
+func findDisallowedVaryHeader(headers, allowedHeaders []string) string {
+	for _, header := range headers {
+		allowed := false
+		for _, allowedHeader := range allowedHeaders {
+			if strings.EqualFold(header, allowedHeader) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return header
+		}
+	}
+	return ""
+}
+
+// routeMatchesMethod returns whether a route matches the requests of the HTTP method. The routes not matching the
+// methods match the requests of any method.
+func routeMatchesMethod(route *routev3.Route, method string) bool {
+	for _, header := range route.GetMatch().GetHeaders() {
+		if header.GetName() != httpMethodHeader {
+			continue
+		}
+		methodRegex := header.GetStringMatch().GetSafeRegex().GetRegex()
+		if methodRegex == "" {
+			continue
+		}
+		matched, err := regexp.MatchString(methodRegex, method)
+		return err == nil && matched
+	}
+	return true
+}
+
+// isSecuredResource returns whether the GET requests of a resource are authenticated by the enforcer.
+func isSecuredResource(resource *model.Resource, apiDisableSecurity bool) bool {
+	if apiDisableSecurity || resource == nil {
+		return false
+	}
+	for _, operation := range resource.GetMethod() {
+		if strings.EqualFold(operation.GetMethod(), http.MethodGet) && !operation.GetDisableSecurity() {
+			return true
+		}
+	}
+	return false
+}
@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -40,6 +41,9 @@ import (
 	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
+// substitutionGroupRegex matches the references to the capture groups in a substitution (ex: \1)
+var substitutionGroupRegex = regexp.MustCompile(`\\(\d+)`)
+
 func generateRouteConfig(routeName string, match *routev3.RouteMatch, action *routev3.Route_Route,
 	metadata *corev3.Metadata, decorator *routev3.Decorator, typedPerFilterConfig map[string]*anypb.Any,
 	requestHeadersToAdd []*corev3.HeaderValueOption, requestHeadersToRemove []string,
@@ -75,7 +79,8 @@ func generateRouteMatch(routeRegex string) *routev3.RouteMatch {
 	return match
 }
 
-func generateRouteAction(apiType string, prodRouteConfig, sandRouteConfig *model.EndpointConfig, endpointType string) (action *routev3.Route_Route) {
+func generateRouteAction(apiType string, prodRouteConfig, sandRouteConfig *model.EndpointConfig, endpointType string,
+	streamingConfig *model.StreamingConfig) (action *routev3.Route_Route) {
 
 	config, _ := config.ReadConfigs()
 
@@ -88,6 +93,17 @@ func generateRouteAction(apiType string, prodRouteConfig, sandRouteConfig *model
 		},
 	}
 
+	if streamingConfig != nil && streamingConfig.Enabled {
+		// Long lived streams should not be terminated by the route timeout. Hence the route timeout is disabled
+		// and the idle timeout (which overrides the stream idle timeout of the connection manager) is applied.
+		idleTimeout := config.Envoy.Upstream.Timeouts.StreamingRouteIdleTimeoutInSeconds
+		if streamingConfig.IdleTimeoutInSeconds > 0 {
+			idleTimeout = streamingConfig.IdleTimeoutInSeconds
+		}
+		action.Route.Timeout = durationpb.New(0)
+		action.Route.IdleTimeout = durationpb.New(time.Duration(idleTimeout) * time.Second)
+	}
+
 	if endpointType == constants.AwsLambda {
 		action.Route.ClusterSpecifier = &routev3.RouteAction_Cluster{
 			Cluster: awslambdaClusterName,
@@ -123,12 +139,33 @@ func generateRouteAction(apiType string, prodRouteConfig, sandRouteConfig *model
 				},
 			},
 		}
+		// Per try timeout cannot be updated via headers. As the route is common to the production and sandbox
+		// endpoints, only the per try timeout of the production endpoints is applied.
+		if perTryTimeout := getPerTryTimeoutInMillis(prodRouteConfig, sandRouteConfig); perTryTimeout > 0 {
+			commonRetryPolicy.PerTryTimeout = durationpb.New(time.Duration(perTryTimeout) * time.Millisecond)
+		}
 		action.Route.RetryPolicy = commonRetryPolicy
 	}
 
 	return action
 }
 
+// getPerTryTimeoutInMillis returns the per try timeout of the production endpoints. The per try timeout of the
+// sandbox endpoints is ignored, as it would otherwise be applied to the production requests too.
+func getPerTryTimeoutInMillis(prodRouteConfig, sandRouteConfig *model.EndpointConfig) uint32 {
+	var perTryTimeout uint32
+	if prodRouteConfig != nil && prodRouteConfig.RetryConfig != nil {
+		perTryTimeout = prodRouteConfig.RetryConfig.PerTryTimeoutInMillis
+	}
+	if sandRouteConfig != nil && sandRouteConfig.RetryConfig != nil &&
+		sandRouteConfig.RetryConfig.PerTryTimeoutInMillis > 0 &&
+		sandRouteConfig.RetryConfig.PerTryTimeoutInMillis != perTryTimeout {
+		logger.LoggerOasparser.Warnf("Per try timeout %d ms of the sandbox endpoints is ignored, as only the per try "+
+			"timeout of the production endpoints is supported", sandRouteConfig.RetryConfig.PerTryTimeoutInMillis)
+	}
+	return perTryTimeout
+}
+
 func generateHTTPMethodMatcher(methodRegex string, isSandbox bool, sandClusterName string) []*routev3.HeaderMatcher {
 	headerMatcher := generateHeaderMatcher(httpMethodHeader, methodRegex)
 	headerMatcherArray := []*routev3.HeaderMatcher{headerMatcher}
@@ -195,7 +232,7 @@ func generateHeaderToAddRouteConfig(policyParams interface{}) (*corev3.HeaderVal
 	headerToAdd := corev3.HeaderValueOption{
 		Header: &corev3.HeaderValue{
 			Key:   headerName,
-			Value: headerValue,
+			Value: resolveApplicationAttributes(headerValue),
 		},
 		AppendAction: *corev3.HeaderValueOption_OVERWRITE_IF_EXISTS_OR_ADD.Enum(),
 	}
@@ -238,6 +275,50 @@ func generateRewritePathRouteConfig(routePath, resourcePath, endpointBasepath st
 	return rewriteRegex, nil
 }
 
+// generateRewriteURLRouteConfig returns Router config for REWRITE_RESOURCE_URL. The pattern is matched against the
+// request path relative to the API basepath, and the capture groups of the pattern could be referenced in the
+// substitution (ex: pattern /users/([^/]+)/orders and substitution /orders/\1). The part of the path which
+// is not matched by the pattern is kept as it is.
+func generateRewriteURLRouteConfig(basePath, endpointBasepath string,
+	policyParams interface{}) (*envoy_type_matcherv3.RegexMatchAndSubstitute, error) {
+
+	params, ok := policyParams.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("error while processing policy parameter map. Map: %v", policyParams)
+	}
+	pattern, ok := params[constants.RewriteURLPattern].(string)
+	if !ok || strings.TrimSpace(pattern) == "" {
+		return nil, errors.New("policy parameter map must include pattern")
+	}
+	substitution, ok := params[constants.RewriteURLSubstitution].(string)
+	if !ok {
+		return nil, errors.New("policy parameter map must include substitution")
+	}
+	pattern = strings.TrimPrefix(strings.TrimSpace(pattern), "^")
+	if !strings.HasPrefix(pattern, "/") {
+		return nil, fmt.Errorf("pattern %q should start with /", pattern)
+	}
+	compiledPattern, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern %q. %v", pattern, err)
+	}
+	for _, reference := range substitutionGroupRegex.FindAllStringSubmatch(substitution, -1) {
+		if group, _ := strconv.Atoi(reference[1]); group > compiledPattern.NumSubexp() {
+			return nil, fmt.Errorf("substitution %q refers to the capture group %d, while the pattern %q has %d",
+				substitution, group, pattern, compiledPattern.NumSubexp())
+		}
+	}
+	if !strings.HasPrefix(substitution, "/") {
+		substitution = "/" + substitution
+	}
+	return &envoy_type_matcherv3.RegexMatchAndSubstitute{
+		Pattern: &envoy_type_matcherv3.RegexMatcher{
+			Regex: "^" + regexp.QuoteMeta(basePath) + pattern,
+		},
+		Substitution: strings.TrimSuffix(endpointBasepath, "/") + substitution,
+	}, nil
+}
+
 func generateFilterConfigToSkipEnforcer() map[string]*anypb.Any {
 	perFilterConfig := extAuthService.ExtAuthzPerRoute{
 		Override: &extAuthService.ExtAuthzPerRoute_Disabled{
@@ -92,6 +92,29 @@ func CreateRoutesWithClusters(mgwSwagger model.MgwSwagger, upstreamCerts map[str
 
 	conf, _ := config.ReadConfigs()
 	timeout := conf.Envoy.ClusterTimeoutInSeconds
+	upstreamClientCerts := mgwSwagger.GetUpstreamClientCerts()
+	concurrencyLimits := mgwSwagger.GetConcurrencyLimits()
+	timeouts := mgwSwagger.GetTimeouts()
+	retryBudget := mgwSwagger.GetRetryBudget()
+
+	// The operations are validated against the gRPC services, while the requests are transcoded per virtual host
+	if mgwSwagger.GetGRPCTranscoding() != nil {
+		if !conf.Envoy.Filters.GRPCTranscoding.Enabled {
+			logger.LoggerOasparser.Warnf("Requests of the API %s:%s are not transcoded to gRPC as the gRPC-JSON "+
+				"transcoding is disabled in the router.", apiTitle, apiVersion)
+		} else if _, err := CreateGRPCTranscoder(mgwSwagger); err != nil {
+			logger.LoggerOasparser.ErrorC(logging.ErrorDetails{
+				Message: fmt.Sprintf("Error while transcoding the requests of the API %s:%s to gRPC. %v",
+					apiTitle, apiVersion, err),
+				Severity:  logging.MAJOR,
+				ErrorCode: 2263,
+			})
+			return nil, nil, nil, err
+		}
+	}
+
+	// Docs routes are added first, as the API resources may contain path templates matching the docs paths
+	routes = append(routes, createAPIDocsRoutes(&mgwSwagger, vHost)...)
 
 	// The any upstream endpoint's basepath.
 	apiLevelBasePathProd := ""
@@ -115,7 +138,7 @@ func CreateRoutesWithClusters(mgwSwagger model.MgwSwagger, upstreamCerts map[str
 			apiVersion, "")
 		if !strings.Contains(apiLevelProdEndpoints.EndpointPrefix, xWso2EPClustersConfigNamePrefix) {
 			cluster, address, err := processEndpoints(apiLevelClusterNameProd, apiLevelProdEndpoints,
-				upstreamCerts, timeout, apiLevelBasePathProd)
+				upstreamCerts, upstreamClientCerts, timeout, apiLevelBasePathProd)
 			if err != nil {
 				apiLevelClusterNameProd = ""
 				logger.LoggerOasparser.ErrorC(logging.ErrorDetails{
@@ -124,6 +147,9 @@ func CreateRoutesWithClusters(mgwSwagger model.MgwSwagger, upstreamCerts map[str
 					ErrorCode: 2202,
 				})
 			} else {
+				applyConcurrencyLimits(cluster, concurrencyLimits)
+				applyClusterTimeouts(cluster, timeouts)
+				applyRetryBudget(cluster, retryBudget)
 				clusters = append(clusters, cluster)
 				endpoints = append(endpoints, address...)
 			}
@@ -152,7 +178,7 @@ func CreateRoutesWithClusters(mgwSwagger model.MgwSwagger, upstreamCerts map[str
 				apiTitle, apiVersion, "")
 			if !strings.Contains(apiLevelSandEndpoints.EndpointPrefix, xWso2EPClustersConfigNamePrefix) {
 				cluster, address, err := processEndpoints(apiLevelClusterNameSand, apiLevelSandEndpoints,
-					upstreamCerts, timeout, selectedBasePathSand)
+					upstreamCerts, upstreamClientCerts, timeout, selectedBasePathSand)
 				if err != nil {
 					apiLevelClusterNameSand = ""
 					logger.LoggerOasparser.ErrorC(logging.ErrorDetails{
@@ -161,6 +187,9 @@ func CreateRoutesWithClusters(mgwSwagger model.MgwSwagger, upstreamCerts map[str
 						ErrorCode: 2203,
 					})
 				} else {
+					applyConcurrencyLimits(cluster, concurrencyLimits)
+					applyClusterTimeouts(cluster, timeouts)
+					applyRetryBudget(cluster, retryBudget)
 					clusters = append(clusters, cluster)
 					endpoints = append(endpoints, address...)
 				}
@@ -179,17 +208,41 @@ func CreateRoutesWithClusters(mgwSwagger model.MgwSwagger, upstreamCerts map[str
 			}
 			epClusterName := getClusterName(endpointCluster.EndpointPrefix, organizationID, vHost, apiTitle,
 				apiVersion, "")
-			cluster, addresses, err := processEndpoints(epClusterName, endpointCluster, upstreamCerts, timeout, apiLevelBasePathProd)
+			cluster, addresses, err := processEndpoints(epClusterName, endpointCluster, upstreamCerts, upstreamClientCerts, timeout,
+				apiLevelBasePathProd)
 			if err != nil {
 				logger.LoggerOasparser.Errorf("Error while adding x-wso2-endpoints cluster %v for %s. %v ", epName, apiTitle, err.Error())
 			} else {
 				strictBasePath = true
+				applyConcurrencyLimits(cluster, concurrencyLimits)
+				applyClusterTimeouts(cluster, timeouts)
+				applyRetryBudget(cluster, retryBudget)
 				clusters = append(clusters, cluster)
 				endpoints = append(endpoints, addresses...)
 			}
 		}
 	}
 
+	// Create the cluster of the shadow endpoint, if the requests are mirrored
+	if mirror := mgwSwagger.GetTrafficMirror(); mirror != nil {
+		mirrorClusterName := getClusterName(mirror.Endpoint.EndpointPrefix, organizationID, vHost, apiTitle,
+			apiVersion, "")
+		cluster, addresses, err := processEndpoints(mirrorClusterName, mirror.Endpoint, upstreamCerts,
+			upstreamClientCerts, timeout, strings.TrimSuffix(mirror.Endpoint.Endpoints[0].Basepath, "/"))
+		if err != nil {
+			mgwSwagger.SetTrafficMirror(nil)
+			logger.LoggerOasparser.ErrorC(logging.ErrorDetails{
+				Message: fmt.Sprintf("Error while adding the shadow endpoint of %s, hence the requests are not mirrored. %v",
+					apiTitle, err.Error()),
+				Severity:  logging.MINOR,
+				ErrorCode: 2252,
+			})
+		} else {
+			clusters = append(clusters, cluster)
+			endpoints = append(endpoints, addresses...)
+		}
+	}
+
 	// Create API level interceptor clusters if required
 	clustersI, endpointsI, apiRequestInterceptor, apiResponseInterceptor := createInterceptorAPIClusters(mgwSwagger,
 		interceptorCerts, vHost, organizationID)
@@ -213,9 +266,18 @@ func CreateRoutesWithClusters(mgwSwagger model.MgwSwagger, upstreamCerts map[str
 				})
 				return nil, nil, nil, fmt.Errorf("error while creating routes for Websocket API. %v", err)
 			}
+			if apiLevelBasePathSand != "" {
+				routesS, err := createAPILevelSandboxRoutes(&mgwSwagger, resource, vHost, apiLevelBasePathSand,
+					apiLevelClusterNameProd, apiLevelClusterNameSand, organizationID)
+				if err != nil {
+					return nil, nil, nil, fmt.Errorf("error while creating sandbox routes for Websocket API. %v", err)
+				}
+				// Sandbox route should be appended before to prod route to have the expected header based sandbox routing.
+				routes = append(routes, routesS...)
+			}
 			routes = append(routes, routesP...)
 		}
-		return routes, clusters, endpoints, nil
+		return addRevisionTesterRoutes(mgwSwagger.GetRevisionTrafficSplit(), routes), clusters, endpoints, nil
 
 	}
 
@@ -263,8 +325,17 @@ func CreateRoutesWithClusters(mgwSwagger model.MgwSwagger, upstreamCerts map[str
 			})
 			return nil, nil, nil, fmt.Errorf("error while creating routes for GraphQL API : %s version : %s. %v", apiTitle, apiVersion, err)
 		}
+		if apiLevelBasePathSand != "" {
+			routesS, err := createAPILevelSandboxRoutes(&mgwSwagger, nil, vHost, apiLevelBasePathSand,
+				apiLevelClusterNameProd, apiLevelClusterNameSand, organizationID)
+			if err != nil {
+				return nil, nil, nil, fmt.Errorf("error while creating sandbox routes for GraphQL API : %s version : %s. %v",
+					apiTitle, apiVersion, err)
+			}
+			routes = append(routes, routesS...)
+		}
 		routes = append(routes, routesP...)
-		return routes, clusters, endpoints, nil
+		return addRevisionTesterRoutes(mgwSwagger.GetRevisionTrafficSplit(), routes), clusters, endpoints, nil
 	}
 
 	for _, resource := range mgwSwagger.GetResources() {
@@ -274,6 +345,16 @@ func CreateRoutesWithClusters(mgwSwagger model.MgwSwagger, upstreamCerts map[str
 		resourceBasePathSand := ""
 		isResourceBasePathSandAvailable := false
 		resourcePath := resource.GetPath()
+		// Resource level timeouts and retry budget override the API level configurations for the resource
+		// level endpoints.
+		resourceTimeouts := timeouts
+		if resolved := model.ResolveTimeouts(resource.GetVendorExtensions(), timeouts); resolved != nil {
+			resourceTimeouts = resolved
+		}
+		resourceRetryBudget := retryBudget
+		if resolved := model.ResolveRetryBudget(resource.GetVendorExtensions(), retryBudget); resolved != nil {
+			resourceRetryBudget = resolved
+		}
 		if strictBasePath || ((resource.GetProdEndpoints() == nil || len(resource.GetProdEndpoints().Endpoints) < 1) &&
 			(resource.GetSandEndpoints() == nil || len(resource.GetSandEndpoints().Endpoints) < 1)) {
 			resourceBasePath = apiLevelBasePathProd
@@ -296,7 +377,8 @@ func CreateRoutesWithClusters(mgwSwagger model.MgwSwagger, upstreamCerts map[str
 			if !strings.Contains(endpointProd.EndpointPrefix, xWso2EPClustersConfigNamePrefix) {
 				clusterNameProd = getClusterName(endpointProd.EndpointPrefix, organizationID, vHost,
 					mgwSwagger.GetTitle(), apiVersion, resource.GetID())
-				clusterProd, addressProd, err := processEndpoints(clusterNameProd, endpointProd, upstreamCerts, timeout, resourceBasePath)
+				clusterProd, addressProd, err := processEndpoints(clusterNameProd, endpointProd, upstreamCerts, upstreamClientCerts,
+					timeout, resourceBasePath)
 				if err != nil {
 					clusterNameProd = apiLevelClusterNameProd
 					// reverting resource base path setting as production cluster creation has failed
@@ -304,6 +386,9 @@ func CreateRoutesWithClusters(mgwSwagger model.MgwSwagger, upstreamCerts map[str
 					logger.LoggerOasparser.Errorf("Error while adding resource level production endpoints for %s:%v-%v. %v",
 						apiTitle, apiVersion, resourcePath, err.Error())
 				} else {
+					applyConcurrencyLimits(clusterProd, concurrencyLimits)
+					applyClusterTimeouts(clusterProd, resourceTimeouts)
+					applyRetryBudget(clusterProd, resourceRetryBudget)
 					clusters = append(clusters, clusterProd)
 					endpoints = append(endpoints, addressProd...)
 				}
@@ -329,7 +414,8 @@ func CreateRoutesWithClusters(mgwSwagger model.MgwSwagger, upstreamCerts map[str
 			if isSandboxClusterRequired(resource.GetProdEndpoints(), resource.GetSandEndpoints()) {
 				clusterNameSand = getClusterName(endpointSand.EndpointPrefix, organizationID, vHost, apiTitle,
 					apiVersion, resource.GetID())
-				clusterSand, addressSand, err := processEndpoints(clusterNameSand, endpointSand, upstreamCerts, timeout, resourceBasePathSand)
+				clusterSand, addressSand, err := processEndpoints(clusterNameSand, endpointSand, upstreamCerts, upstreamClientCerts,
+					timeout, resourceBasePathSand)
 				if err != nil {
 					clusterNameSand = apiLevelClusterNameSand
 					// reverting resource base path setting as sandbox cluster creation has failed
@@ -337,6 +423,9 @@ func CreateRoutesWithClusters(mgwSwagger model.MgwSwagger, upstreamCerts map[str
 					logger.LoggerOasparser.Errorf("Error while adding resource level sandbox endpoints for %s:%v-%v. %v",
 						apiTitle, apiVersion, resourcePath, err.Error())
 				} else {
+					applyConcurrencyLimits(clusterSand, concurrencyLimits)
+					applyClusterTimeouts(clusterSand, resourceTimeouts)
+					applyRetryBudget(clusterSand, resourceRetryBudget)
 					clusters = append(clusters, clusterSand)
 					endpoints = append(endpoints, addressSand...)
 					isResourceBasePathSandAvailable = true
@@ -419,7 +508,66 @@ func CreateRoutesWithClusters(mgwSwagger model.MgwSwagger, upstreamCerts map[str
 		routes = append(routes, routeP...)
 	}
 
-	return routes, clusters, endpoints, nil
+	return addRevisionTesterRoutes(mgwSwagger.GetRevisionTrafficSplit(), routes), clusters, endpoints, nil
+}
+
+// createAPILevelSandboxRoutes creates the routes for the requests having the sandbox cluster header, when the
+// sandbox endpoint basepath is different from the production endpoint basepath. The cluster header is set by the
+// enforcer based on the key type of the validated token, hence such requests are rewritten with the sandbox
+// endpoint basepath. Used for the API types without resource level endpoints (i.e. Websocket and GraphQL).
+func createAPILevelSandboxRoutes(mgwSwagger *model.MgwSwagger, resource *model.Resource, vHost, basePathSand,
+	clusterNameProd, clusterNameSand, organizationID string) ([]*routev3.Route, error) {
+	logger.LoggerOasparser.Debugf("Creating sandbox route for : %v:%v - %v", mgwSwagger.GetTitle(),
+		mgwSwagger.GetVersion(), basePathSand)
+	routes, err := createRoutes(genRouteCreateParams(mgwSwagger, resource, vHost, basePathSand, clusterNameProd,
+		clusterNameSand, nil, nil, organizationID, true))
+	if err != nil {
+		logger.LoggerXds.ErrorC(logging.ErrorDetails{
+			Message: fmt.Sprintf("Error while creating sandbox cluster routes for %s API %s %s. Error: %s",
+				mgwSwagger.GetAPIType(), mgwSwagger.GetTitle(), mgwSwagger.GetVersion(), err.Error()),
+			Severity:  logging.MAJOR,
+			ErrorCode: 2240,
+		})
+		return nil, err
+	}
+	return routes, nil
+}
+
+// addRevisionTesterRoutes adds a route per each route, which matches the requests having the tester header
+// with the new revision ID. Such requests are routed only to the endpoints of the new revision. The tester routes
+// are added before the original routes, as envoy picks the first matching route.
+func addRevisionTesterRoutes(split *model.RevisionTrafficSplit, routes []*routev3.Route) []*routev3.Route {
+	if split == nil || split.TesterHeader == "" {
+		return routes
+	}
+	routesWithTesterRoutes := make([]*routev3.Route, 0, 2*len(routes))
+	for _, route := range routes {
+		if route.GetRoute() != nil {
+			testerRoute := proto.Clone(route).(*routev3.Route)
+			testerRoute.Match.Headers = append(testerRoute.Match.Headers, &routev3.HeaderMatcher{
+				Name: split.TesterHeader,
+				HeaderMatchSpecifier: &routev3.HeaderMatcher_StringMatch{
+					StringMatch: &envoy_type_matcherv3.StringMatcher{
+						MatchPattern: &envoy_type_matcherv3.StringMatcher_Exact{
+							Exact: split.NewRevisionID,
+						},
+					},
+				},
+			})
+			testerRoute.GetRoute().MetadataMatch = &corev3.Metadata{
+				FilterMetadata: map[string]*structpb.Struct{
+					lbMetadataFilterName: {
+						Fields: map[string]*structpb.Value{
+							revisionMetadataKey: structpb.NewStringValue(split.NewRevisionID),
+						},
+					},
+				},
+			}
+			routesWithTesterRoutes = append(routesWithTesterRoutes, testerRoute)
+		}
+		routesWithTesterRoutes = append(routesWithTesterRoutes, route)
+	}
+	return routesWithTesterRoutes
 }
 
 func getClusterName(epPrefix string, organizationID string, vHost string, swaggerTitle string, swaggerVersion string,
@@ -435,7 +583,7 @@ func getClusterName(epPrefix string, organizationID string, vHost string, swagge
 // CreateLuaCluster creates lua cluster configuration.
 func CreateLuaCluster(interceptorCerts map[string][]byte, endpoint model.InterceptEndpoint) (*clusterv3.Cluster, []*corev3.Address, error) {
 	logger.LoggerOasparser.Debug("creating a lua cluster ", endpoint.ClusterName)
-	return processEndpoints(endpoint.ClusterName, &endpoint.EndpointCluster, interceptorCerts, endpoint.ClusterTimeout, endpoint.EndpointCluster.Endpoints[0].Basepath)
+	return processEndpoints(endpoint.ClusterName, &endpoint.EndpointCluster, interceptorCerts, nil, endpoint.ClusterTimeout, endpoint.EndpointCluster.Endpoints[0].Basepath)
 }
 
 // CreateAwsLambdaCluster creates AWS Lambda cluster configuration.
@@ -449,7 +597,7 @@ func CreateAwsLambdaCluster(conf *config.Config) (*clusterv3.Cluster, []*corev3.
 		}},
 	}
 
-	cluster, address, err := processEndpoints(awslambdaClusterName, epCluster, nil, epTimeout, "")
+	cluster, address, err := processEndpoints(awslambdaClusterName, epCluster, nil, nil, epTimeout, "")
 	cluster.Metadata = &corev3.Metadata{
 		FilterMetadata: map[string]*structpb.Struct{
 			"com.amazonaws.lambda": {
@@ -463,6 +611,19 @@ func CreateAwsLambdaCluster(conf *config.Config) (*clusterv3.Cluster, []*corev3.
 	return cluster, address, err
 }
 
+// CreateRateLimitCluster creates the cluster of the gRPC server of the rate limit service.
+func CreateRateLimitCluster(conf *config.Config) (*clusterv3.Cluster, []*corev3.Address, error) {
+	epCluster := &model.EndpointCluster{
+		Endpoints: []model.Endpoint{{
+			Host:    conf.Envoy.GlobalRateLimit.Host,
+			URLType: httpURLType,
+			Port:    conf.Envoy.GlobalRateLimit.Port,
+		}},
+		HTTP2BackendEnabled: true,
+	}
+	return processEndpoints(rateLimitClusterName, epCluster, nil, nil, conf.Envoy.ClusterTimeoutInSeconds, "")
+}
+
 // CreateTracingCluster creates a cluster definition for router's tracing server.
 func CreateTracingCluster(conf *config.Config) (*clusterv3.Cluster, []*corev3.Address, error) {
 	var epHost string
@@ -499,14 +660,15 @@ func CreateTracingCluster(conf *config.Config) (*clusterv3.Cluster, []*corev3.Ad
 		epCluster.HTTP2BackendEnabled = true
 	}
 
-	return processEndpoints(tracingClusterName, epCluster, nil, epTimeout, epPath)
+	return processEndpoints(tracingClusterName, epCluster, nil, nil, epTimeout, epPath)
 }
 
 // processEndpoints creates cluster configuration. AddressConfiguration, cluster name and
 // urlType (http or https) is required to be provided.
 // timeout cluster timeout
 func processEndpoints(clusterName string, clusterDetails *model.EndpointCluster, upstreamCerts map[string][]byte,
-	timeout time.Duration, basePath string) (*clusterv3.Cluster, []*corev3.Address, error) {
+	upstreamClientCerts map[string]model.UpstreamClientCert, timeout time.Duration,
+	basePath string) (*clusterv3.Cluster, []*corev3.Address, error) {
 	// tls configs
 	var transportSocketMatches []*clusterv3.Cluster_TransportSocketMatch
 	// create loadbalanced/failover endpoints
@@ -515,6 +677,8 @@ func processEndpoints(clusterName string, clusterDetails *model.EndpointCluster,
 	priority := 0
 	// epType {loadbalance, failover}
 	epType := clusterDetails.EndpointType
+	// endpoints belong to two revisions of the API
+	isRevisionSplit := false
 
 	addresses := []*corev3.Address{}
 
@@ -550,7 +714,14 @@ func processEndpoints(clusterName string, clusterDetails *model.EndpointCluster,
 				epCert = defaultCerts
 			}
 
-			upstreamtlsContext := createUpstreamTLSContext(epCert, address, clusterDetails.HTTP2BackendEnabled)
+			var clientCert *model.UpstreamClientCert
+			if cert, found := upstreamClientCerts[ep.RawURL]; found {
+				clientCert = &cert
+			} else if defaultCert, found := upstreamClientCerts["default"]; found {
+				clientCert = &defaultCert
+			}
+
+			upstreamtlsContext := createUpstreamTLSContext(epCert, clientCert, address, clusterDetails.HTTP2BackendEnabled)
 			marshalledTLSContext, err := anypb.New(upstreamtlsContext)
 			if err != nil {
 				return nil, nil, errors.New("internal Error while marshalling the upstream TLS Context")
@@ -580,6 +751,22 @@ func processEndpoints(clusterName string, clusterDetails *model.EndpointCluster,
 				},
 			}
 		}
+		if ep.LbWeight > 0 {
+			localityLbEndpoints.LbEndpoints[0].LoadBalancingWeight = wrapperspb.UInt32(ep.LbWeight)
+		}
+		if ep.Revision != "" {
+			if localityLbEndpoints.LbEndpoints[0].Metadata == nil {
+				localityLbEndpoints.LbEndpoints[0].Metadata = &corev3.Metadata{
+					FilterMetadata: map[string]*structpb.Struct{},
+				}
+			}
+			localityLbEndpoints.LbEndpoints[0].Metadata.FilterMetadata[lbMetadataFilterName] = &structpb.Struct{
+				Fields: map[string]*structpb.Value{
+					revisionMetadataKey: structpb.NewStringValue(ep.Revision),
+				},
+			}
+			isRevisionSplit = true
+		}
 		lbEPs = append(lbEPs, localityLbEndpoints)
 
 		// set priority for next endpoint
@@ -644,8 +831,30 @@ func processEndpoints(clusterName string, clusterDetails *model.EndpointCluster,
 		},
 	}
 
-	if len(clusterDetails.Endpoints) > 1 {
-		cluster.HealthChecks = createHealthCheck()
+	if clusterDetails.Config != nil && clusterDetails.Config.LbAlgorithm == constants.LeastRequest {
+		cluster.LbPolicy = clusterv3.Cluster_LEAST_REQUEST
+	}
+
+	if clusterDetails.Config != nil && clusterDetails.Config.HealthCheck != nil {
+		cluster.HealthChecks = createHealthCheck(clusterDetails.Config.HealthCheck)
+	} else if len(clusterDetails.Endpoints) > 1 {
+		cluster.HealthChecks = createHealthCheck(nil)
+	}
+
+	if clusterDetails.Config != nil && clusterDetails.Config.OutlierDetection != nil {
+		cluster.OutlierDetection = createOutlierDetection(clusterDetails.Config.OutlierDetection)
+	}
+
+	if isRevisionSplit {
+		// Requests without a revision metadata match are load balanced among all the endpoints as per the weights.
+		cluster.LbSubsetConfig = &clusterv3.Cluster_LbSubsetConfig{
+			FallbackPolicy: clusterv3.Cluster_LbSubsetConfig_ANY_ENDPOINT,
+			SubsetSelectors: []*clusterv3.Cluster_LbSubsetConfig_LbSubsetSelector{
+				{
+					Keys: []string{revisionMetadataKey},
+				},
+			},
+		}
 	}
 
 	if clusterDetails.Config != nil && clusterDetails.Config.CircuitBreakers != nil {
@@ -676,7 +885,11 @@ func processEndpoints(clusterName string, clusterDetails *model.EndpointCluster,
 	// service discovery itself will be handling loadbancing etc.
 	// Therefore mutiple endpoint support is not needed, hence consider only.
 	serviceDiscoveryString := clusterDetails.Endpoints[0].ServiceDiscoveryString
-	if serviceDiscoveryString != "" {
+	if serviceDiscoveryString != "" && clusterDetails.Endpoints[0].ServiceDiscoveryRegistry == svcdiscovery.RegistryEureka {
+		svcdiscovery.ClusterEurekaKeyMap[clusterName] = serviceDiscoveryString
+		logger.LoggerOasparser.Debugln("Eureka cluster added for the endpoints: ", clusterName, " ",
+			serviceDiscoveryString)
+	} else if serviceDiscoveryString != "" {
 		//add the api level cluster name to the ClusterConsulKeyMap
 		svcdiscovery.ClusterConsulKeyMap[clusterName] = serviceDiscoveryString
 		logger.LoggerOasparser.Debugln("Consul cluster added for x-wso2-endpoints: ", clusterName, " ",
@@ -686,21 +899,92 @@ func processEndpoints(clusterName string, clusterDetails *model.EndpointCluster,
 	return &cluster, addresses, nil
 }
 
-func createHealthCheck() []*corev3.HealthCheck {
+// createHealthCheck creates the health check of the cluster. The values not provided in the API level health
+// check are taken from the upstream health check configuration.
+func createHealthCheck(apiHealthCheck *model.HealthCheck) []*corev3.HealthCheck {
 	conf, _ := config.ReadConfigs()
-	return []*corev3.HealthCheck{
-		{
-			Timeout:            durationpb.New(time.Duration(conf.Envoy.Upstream.Health.Timeout) * time.Second),
-			Interval:           durationpb.New(time.Duration(conf.Envoy.Upstream.Health.Interval) * time.Second),
-			UnhealthyThreshold: wrapperspb.UInt32(uint32(conf.Envoy.Upstream.Health.UnhealthyThreshold)),
-			HealthyThreshold:   wrapperspb.UInt32(uint32(conf.Envoy.Upstream.Health.HealthyThreshold)),
-			// we only support tcp default healthcheck
-			HealthChecker: &corev3.HealthCheck_TcpHealthCheck_{},
-		},
+	timeout := conf.Envoy.Upstream.Health.Timeout
+	interval := conf.Envoy.Upstream.Health.Interval
+	unhealthyThreshold := conf.Envoy.Upstream.Health.UnhealthyThreshold
+	healthyThreshold := conf.Envoy.Upstream.Health.HealthyThreshold
+	path := ""
+	if apiHealthCheck != nil {
+		if apiHealthCheck.TimeoutInSeconds > 0 {
+			timeout = apiHealthCheck.TimeoutInSeconds
+		}
+		if apiHealthCheck.IntervalInSeconds > 0 {
+			interval = apiHealthCheck.IntervalInSeconds
+		}
+		if apiHealthCheck.UnhealthyThreshold > 0 {
+			unhealthyThreshold = apiHealthCheck.UnhealthyThreshold
+		}
+		if apiHealthCheck.HealthyThreshold > 0 {
+			healthyThreshold = apiHealthCheck.HealthyThreshold
+		}
+		path = apiHealthCheck.Path
+	}
+	healthCheck := &corev3.HealthCheck{
+		Timeout:            durationpb.New(time.Duration(timeout) * time.Second),
+		Interval:           durationpb.New(time.Duration(interval) * time.Second),
+		UnhealthyThreshold: wrapperspb.UInt32(uint32(unhealthyThreshold)),
+		HealthyThreshold:   wrapperspb.UInt32(uint32(healthyThreshold)),
+		// tcp health check is done unless a path is provided for the http health check
+		HealthChecker: &corev3.HealthCheck_TcpHealthCheck_{},
+	}
+	if path != "" {
+		healthCheck.HealthChecker = &corev3.HealthCheck_HttpHealthCheck_{
+			HttpHealthCheck: &corev3.HealthCheck_HttpHealthCheck{
+				Path: path,
+			},
+		}
 	}
+	return []*corev3.HealthCheck{healthCheck}
 }
 
-func createUpstreamTLSContext(upstreamCerts []byte, address *corev3.Address, hTTP2BackendEnabled bool) *tlsv3.UpstreamTlsContext {
+// createOutlierDetection creates the outlier detection of the cluster. Envoy defaults are used for the values
+// not provided.
+func createOutlierDetection(apiOutlierDetection *model.OutlierDetection) *clusterv3.OutlierDetection {
+	outlierDetection := &clusterv3.OutlierDetection{}
+	if apiOutlierDetection.Consecutive5xx > 0 {
+		outlierDetection.Consecutive_5Xx = wrapperspb.UInt32(uint32(apiOutlierDetection.Consecutive5xx))
+	}
+	if apiOutlierDetection.IntervalInSeconds > 0 {
+		outlierDetection.Interval = durationpb.New(time.Duration(apiOutlierDetection.IntervalInSeconds) * time.Second)
+	}
+	if apiOutlierDetection.BaseEjectionTimeInSeconds > 0 {
+		outlierDetection.BaseEjectionTime = durationpb.New(
+			time.Duration(apiOutlierDetection.BaseEjectionTimeInSeconds) * time.Second)
+	}
+	if apiOutlierDetection.MaxEjectionPercent > 0 {
+		outlierDetection.MaxEjectionPercent = wrapperspb.UInt32(uint32(apiOutlierDetection.MaxEjectionPercent))
+	}
+	return outlierDetection
+}
+
+// applyConcurrencyLimits limits the in-flight requests of an upstream cluster of the API with the circuit breaker
+// of the cluster. The lower limit is applied if the endpoint configuration also limits the requests. The router
+// responds with 503 once the limit is reached.
+func applyConcurrencyLimits(cluster *clusterv3.Cluster, limits *model.ConcurrencyLimits) {
+	if limits == nil || limits.MaxConcurrentRequests == 0 {
+		return
+	}
+	if cluster.CircuitBreakers == nil || len(cluster.CircuitBreakers.Thresholds) == 0 {
+		cluster.CircuitBreakers = &clusterv3.CircuitBreakers{
+			Thresholds: []*clusterv3.CircuitBreakers_Thresholds{
+				{},
+			},
+		}
+	}
+	thresholds := cluster.CircuitBreakers.Thresholds[0]
+	if thresholds.MaxRequests == nil || thresholds.MaxRequests.GetValue() > limits.MaxConcurrentRequests {
+		thresholds.MaxRequests = wrapperspb.UInt32(limits.MaxConcurrentRequests)
+	}
+}
+
+// createUpstreamTLSContext creates the TLS context for an endpoint. The client cert is presented to the endpoint
+// if provided, otherwise the envoy keystore cert is presented.
+func createUpstreamTLSContext(upstreamCerts []byte, clientCert *model.UpstreamClientCert, address *corev3.Address,
+	hTTP2BackendEnabled bool) *tlsv3.UpstreamTlsContext {
 	conf, errReadConfig := config.ReadConfigs()
 	//TODO: (VirajSalaka) Error Handling
 	if errReadConfig != nil {
@@ -708,6 +992,20 @@ func createUpstreamTLSContext(upstreamCerts []byte, address *corev3.Address, hTT
 		return nil
 	}
 	tlsCert := generateTLSCert(conf.Envoy.KeyStore.KeyPath, conf.Envoy.KeyStore.CertPath)
+	if clientCert != nil {
+		tlsCert = &tlsv3.TlsCertificate{
+			PrivateKey: &corev3.DataSource{
+				Specifier: &corev3.DataSource_InlineBytes{
+					InlineBytes: clientCert.Key,
+				},
+			},
+			CertificateChain: &corev3.DataSource{
+				Specifier: &corev3.DataSource_InlineBytes{
+					InlineBytes: clientCert.Certificate,
+				},
+			},
+		}
+	}
 	// Convert the cipher string to a string array
 	ciphersArray := strings.Split(conf.Envoy.Upstream.TLS.Ciphers, ",")
 	for i := range ciphersArray {
@@ -811,8 +1109,11 @@ func createRoutes(params *routeCreateParams) (routes []*routev3.Route, err error
 	endpointBasepath := params.endpointBasePath
 	requestInterceptor := params.requestInterceptor
 	responseInterceptor := params.responseInterceptor
-	isDefaultVersion := params.isDefaultVersion
 	endpointType := params.endpointType
+	streamingConfig := params.streamingConfig
+	isStreaming := streamingConfig != nil && streamingConfig.Enabled
+	// The request bodies of streaming routes and the routes which pass the request bodies through are not buffered.
+	isPassthrough := isStreaming || (params.payloadLimits != nil && params.payloadLimits.Passthrough)
 	amznResourceName := ""
 
 	if resource != nil {
@@ -828,9 +1129,6 @@ func createRoutes(params *routeCreateParams) (routes []*routev3.Route, err error
 	)
 
 	basePath := strings.TrimSuffix(xWso2Basepath, "/")
-	if isDefaultVersion {
-		basePath = getDefaultVersionBasepath(basePath, version)
-	}
 
 	resourcePath := ""
 	var resourceMethods []string
@@ -869,13 +1167,27 @@ func createRoutes(params *routeCreateParams) (routes []*routev3.Route, err error
 	// to validate the key type component in the token.
 	contextExtensions[prodClusterNameContextExtension] = prodClusterName
 	contextExtensions[sandClusterNameContextExtension] = sandClusterName
+	if params.subscriptionValidation != nil {
+		contextExtensions[subscriptionValidationContextExtension] = strconv.FormatBool(*params.subscriptionValidation)
+	}
+	passRequestPayloadToEnforcer := params.passRequestPayloadToEnforcer
+	if apiType == constants.WEBSUB && resourcePath == constants.WebSubEventsReceiverResource &&
+		params.webSubSignature != nil {
+		contextExtensions[webSubSecretContextExtension] = params.webSubSignature.Secret
+		contextExtensions[webSubSigningAlgorithmContextExtension] = params.webSubSignature.SigningAlgorithm
+		contextExtensions[webSubSignatureHeaderContextExtension] = params.webSubSignature.SignatureHeader
+		// the signature is computed over the body of the event, hence the events are rejected by the enforcer if
+		// the body is not buffered (i.e. streamed)
+		passRequestPayloadToEnforcer = true
+	}
 
 	extAuthPerFilterConfig := extAuthService.ExtAuthzPerRoute{
 		Override: &extAuthService.ExtAuthzPerRoute_CheckSettings{
 			CheckSettings: &extAuthService.CheckSettings{
 				ContextExtensions: contextExtensions,
 				// negation is performing to match the envoy config name (disable_request_body_buffering)
-				DisableRequestBodyBuffering: !params.passRequestPayloadToEnforcer,
+				// Request body is never buffered for streaming and passthrough routes.
+				DisableRequestBodyBuffering: !passRequestPayloadToEnforcer || isPassthrough,
 			},
 		},
 	}
@@ -893,7 +1205,8 @@ func createRoutes(params *routeCreateParams) (routes []*routev3.Route, err error
 
 		logConf := config.ReadLogConfigs()
 
-		if logConf.WireLogs.Enable {
+		// Wire logs buffer the complete body, hence disabled for streaming and passthrough routes.
+		if logConf.WireLogs.Enable && !isPassthrough {
 
 			templateString := `
 local utils = require 'home.wso2.interceptor.lib.utils'
@@ -1006,10 +1319,15 @@ end`
 			var requestHeadersToRemove []string
 			var responseHeadersToAdd []*corev3.HeaderValueOption
 			var responseHeadersToRemove []string
+			var requestHeaderRenames []*headerRename
+			var responseHeaderRenames []*headerRename
 			var pathRewriteConfig *envoy_type_matcherv3.RegexMatchAndSubstitute
 
 			hasMethodRewritePolicy := false
+			hasPathRewritePolicy := false
+			hasURLRewritePolicy := false
 			var newMethod string
+			var methodOverride string
 
 			// Policies - for request flow
 			for _, requestPolicy := range operation.GetPolicies().Request {
@@ -1036,6 +1354,26 @@ end`
 					}
 					requestHeadersToRemove = append(requestHeadersToRemove, requestHeaderToRemove)
 
+				case constants.ActionHeaderAppend:
+					logger.LoggerOasparser.Debugf("Adding %s policy to request flow for %s %s",
+						constants.ActionHeaderAppend, resourcePath, operation.GetMethod())
+					requestHeaderToAppend, err := generateHeaderToAppendRouteConfig(requestPolicy.Parameters)
+					if err != nil {
+						return nil, fmt.Errorf("error adding request policy %s to operation %s of resource %s."+
+							" %v", requestPolicy.Action, operation.GetMethod(), resourcePath, err)
+					}
+					requestHeadersToAdd = append(requestHeadersToAdd, requestHeaderToAppend)
+
+				case constants.ActionHeaderRename:
+					logger.LoggerOasparser.Debugf("Adding %s policy to request flow for %s %s",
+						constants.ActionHeaderRename, resourcePath, operation.GetMethod())
+					requestHeaderRename, err := generateHeaderRename(requestPolicy.Parameters)
+					if err != nil {
+						return nil, fmt.Errorf("error adding request policy %s to operation %s of resource %s."+
+							" %v", requestPolicy.Action, operation.GetMethod(), resourcePath, err)
+					}
+					requestHeaderRenames = append(requestHeaderRenames, requestHeaderRename)
+
 				case constants.ActionRewritePath:
 					logger.LoggerOasparser.Debug("Adding %s policy to request flow for %s %s",
 						constants.ActionRewritePath, resourcePath, operation.GetMethod())
@@ -1052,6 +1390,34 @@ end`
 						return nil, errors.New(errMsg)
 					}
 					pathRewriteConfig = regexRewrite
+					hasPathRewritePolicy = true
+
+				case constants.ActionRewriteURL:
+					logger.LoggerOasparser.Debugf("Adding %s policy to request flow for %s %s",
+						constants.ActionRewriteURL, resourcePath, operation.GetMethod())
+					regexRewrite, err := generateRewriteURLRouteConfig(basePath, endpointBasepath,
+						requestPolicy.Parameters)
+					if err != nil {
+						errMsg := fmt.Sprintf("error adding request policy %s to operation %s of resource %s. %v",
+							constants.ActionRewriteURL, operation.GetMethod(), resourcePath, err)
+						logger.LoggerOasparser.ErrorC(logging.ErrorDetails{
+							Message:   errMsg,
+							Severity:  logging.MINOR,
+							ErrorCode: 2269,
+						})
+						return nil, errors.New(errMsg)
+					}
+					pathRewriteConfig = regexRewrite
+					hasURLRewritePolicy = true
+
+				case constants.ActionOverrideMethod:
+					logger.LoggerOasparser.Debugf("Adding %s policy to request flow for %s %s",
+						constants.ActionOverrideMethod, resourcePath, operation.GetMethod())
+					methodOverride, err = getOverrideMethod(requestPolicy.Parameters)
+					if err != nil {
+						return nil, fmt.Errorf("error adding request policy %s to operation %s of resource %s. %v",
+							constants.ActionOverrideMethod, operation.GetMethod(), resourcePath, err)
+					}
 
 				case constants.ActionRewriteMethod:
 					logger.LoggerOasparser.Debug("Adding %s policy to request flow for %s %s",
@@ -1094,9 +1460,46 @@ end`
 							" %v", responsePolicy.Action, operation.GetMethod(), resourcePath, err)
 					}
 					responseHeadersToRemove = append(responseHeadersToRemove, responseHeaderToRemove)
+
+				case constants.ActionHeaderAppend:
+					logger.LoggerOasparser.Debugf("Adding %s policy to response flow for %s %s",
+						constants.ActionHeaderAppend, resourcePath, operation.GetMethod())
+					responseHeaderToAppend, err := generateHeaderToAppendRouteConfig(responsePolicy.Parameters)
+					if err != nil {
+						return nil, fmt.Errorf("error adding response policy %s to operation %s of resource %s."+
+							" %v", responsePolicy.Action, operation.GetMethod(), resourcePath, err)
+					}
+					responseHeadersToAdd = append(responseHeadersToAdd, responseHeaderToAppend)
+
+				case constants.ActionHeaderRename:
+					logger.LoggerOasparser.Debugf("Adding %s policy to response flow for %s %s",
+						constants.ActionHeaderRename, resourcePath, operation.GetMethod())
+					responseHeaderRename, err := generateHeaderRename(responsePolicy.Parameters)
+					if err != nil {
+						return nil, fmt.Errorf("error adding response policy %s to operation %s of resource %s."+
+							" %v", responsePolicy.Action, operation.GetMethod(), resourcePath, err)
+					}
+					responseHeaderRenames = append(responseHeaderRenames, responseHeaderRename)
 				}
 			}
 
+			if hasPathRewritePolicy && hasURLRewritePolicy {
+				return nil, fmt.Errorf("request policies %s and %s can not be applied together to operation %s of "+
+					"resource %s", constants.ActionRewritePath, constants.ActionRewriteURL, operation.GetMethod(),
+					resourcePath)
+			}
+			if hasMethodRewritePolicy && methodOverride != "" {
+				return nil, fmt.Errorf("request policies %s and %s can not be applied together to operation %s of "+
+					"resource %s", constants.ActionRewriteMethod, constants.ActionOverrideMethod, operation.GetMethod(),
+					resourcePath)
+			}
+			operationFilterConfigs, err := withHeaderTransformations(perRouteFilterConfigs, requestHeaderRenames,
+				responseHeaderRenames, methodOverride)
+			if err != nil {
+				return nil, fmt.Errorf("error adding header rename policies to operation %s of resource %s. %v",
+					operation.GetMethod(), resourcePath, err)
+			}
+
 			// TODO: (suksw) preserve header key case?
 			if hasMethodRewritePolicy {
 				logger.LoggerOasparser.Debug("Creating two routes to support method rewrite for %s %s. New method: %s",
@@ -1115,8 +1518,8 @@ end`
 				metadataValue := operation.GetMethod() + "_to_" + newMethod
 				match2.DynamicMetadata = generateMetadataMatcherForInternalRoutes(metadataValue)
 
-				action1 := generateRouteAction(apiType, prodRouteConfig, sandRouteConfig, endpointType)
-				action2 := generateRouteAction(apiType, prodRouteConfig, sandRouteConfig, endpointType)
+				action1 := generateRouteAction(apiType, prodRouteConfig, sandRouteConfig, endpointType, streamingConfig)
+				action2 := generateRouteAction(apiType, prodRouteConfig, sandRouteConfig, endpointType, streamingConfig)
 
 				// Create route1 for current method.
 				// Do not add policies to route config. Send via enforcer
@@ -1130,7 +1533,12 @@ end`
 				} else {
 					action2.Route.RegexRewrite = generateRegexMatchAndSubstitute(routePath, endpointBasepath, resourcePath)
 				}
-				configToSkipEnforcer := generateFilterConfigToSkipEnforcer()
+				configToSkipEnforcer, err := withHeaderRenames(generateFilterConfigToSkipEnforcer(),
+					requestHeaderRenames, responseHeaderRenames)
+				if err != nil {
+					return nil, fmt.Errorf("error adding header rename policies to operation %s of resource %s. %v",
+						operation.GetMethod(), resourcePath, err)
+				}
 				route2 := generateRouteConfig(xWso2Basepath+"-"+metadataValue, match2, action2, nil, decorator,
 					configToSkipEnforcer, requestHeadersToAdd, requestHeadersToRemove, responseHeadersToAdd,
 					responseHeadersToRemove)
@@ -1140,7 +1548,7 @@ end`
 			} else {
 				logger.LoggerOasparser.Debug("Creating routes for resource with policies", resourcePath, operation.GetMethod())
 				// create route for current method. Add policies to route config. Send via enforcer
-				action := generateRouteAction(apiType, prodRouteConfig, sandRouteConfig, endpointType)
+				action := generateRouteAction(apiType, prodRouteConfig, sandRouteConfig, endpointType, streamingConfig)
 				match := generateRouteMatch(routePath)
 				match.Headers = generateHTTPMethodMatcher(includeOptionsMethod(operation.GetMethod()), params.isSandbox,
 					sandClusterName)
@@ -1150,7 +1558,7 @@ end`
 				} else {
 					action.Route.RegexRewrite = generateRegexMatchAndSubstitute(routePath, endpointBasepath, resourcePath)
 				}
-				route := generateRouteConfig(xWso2Basepath, match, action, nil, decorator, perRouteFilterConfigs,
+				route := generateRouteConfig(xWso2Basepath, match, action, nil, decorator, operationFilterConfigs,
 					requestHeadersToAdd, requestHeadersToRemove, responseHeadersToAdd, responseHeadersToRemove)
 				routes = append(routes, route)
 			}
@@ -1162,14 +1570,41 @@ end`
 		methodRegex := strings.Join(resourceMethods, "|")
 		match := generateRouteMatch(routePath)
 		match.Headers = generateHTTPMethodMatcher(includeOptionsMethod(methodRegex), params.isSandbox, sandClusterName)
-		action := generateRouteAction(apiType, prodRouteConfig, sandRouteConfig, endpointType)
+		action := generateRouteAction(apiType, prodRouteConfig, sandRouteConfig, endpointType, streamingConfig)
 		action.Route.RegexRewrite = generateRegexMatchAndSubstitute(routePath, endpointBasepath, resourcePath)
 
 		route := generateRouteConfig(xWso2Basepath, match, action, nil, decorator, perRouteFilterConfigs,
 			nil, nil, nil, nil) // general headers to add and remove are included in this methods
 		routes = append(routes, route)
 	}
-	return routes, nil
+	if apiType == constants.WEBSUB {
+		applyWebSubTopicMatchers(routes, resourcePath, params.webSubTopics)
+	}
+	applyPayloadLimits(routes, params.payloadLimits)
+	applyRouteTimeouts(routes, params.timeouts)
+	applyResponseCompression(routes, params.responseCompression)
+	applyTrafficMirror(routes, params.mirrorClusterName, params.trafficMirror)
+	deprecationHeaders := getDeprecationHeaders(params.deprecation)
+	for _, route := range routes {
+		params.globalPolicyHeaders.applyTo(route)
+		route.ResponseHeadersToAdd = append(route.ResponseHeadersToAdd, deprecationHeaders...)
+		if conf.Envoy.APIVersionStats.Enabled {
+			route.StatPrefix = getAPIVersionStatPrefix(vHost, title, version)
+		}
+	}
+	if conf.Envoy.LocalRateLimit.Enabled || conf.Envoy.GlobalRateLimit.Enabled {
+		for _, route := range routes {
+			if routeAction := route.GetRoute(); routeAction != nil {
+				routeAction.RateLimits = getRateLimitActions(params.apiUUID)
+			}
+		}
+		setRateLimitHeadersFormat(routes, params.rateLimitHeadersFormat)
+	}
+	if apiType == constants.SOAP {
+		routes = applySOAPPassthrough(routes, params.soapVersions)
+	}
+	// the routes bypassing the cache are copies of the routes, hence the cache is applied at last
+	return applyResponseCache(routes, params.responseCache, isSecuredResource(resource, params.disableSecurity)), nil
 }
 
 func getInlineLuaScript(requestInterceptor map[string]model.InterceptEndpoint, responseInterceptor map[string]model.InterceptEndpoint,
@@ -1590,6 +2025,7 @@ func genRouteCreateParams(swagger *model.MgwSwagger, resource *model.Resource, v
 	responseInterceptor map[string]model.InterceptEndpoint, organizationID string, isSandbox bool) *routeCreateParams {
 	params := &routeCreateParams{
 		organizationID:               organizationID,
+		apiUUID:                      swagger.GetID(),
 		title:                        swagger.GetTitle(),
 		apiType:                      swagger.GetAPIType(),
 		version:                      swagger.GetVersion(),
@@ -1600,13 +2036,55 @@ func genRouteCreateParams(swagger *model.MgwSwagger, resource *model.Resource, v
 		sandClusterName:              sandClusterName,
 		endpointBasePath:             endpointBasePath,
 		corsPolicy:                   swagger.GetCorsConfig(),
+		streamingConfig:              swagger.GetStreamingConfig(),
+		payloadLimits:                swagger.GetPayloadLimits(),
+		timeouts:                     swagger.GetTimeouts(),
+		responseCompression:          swagger.GetResponseCompression(),
 		resource:                     resource,
 		requestInterceptor:           requestInterceptor,
 		responseInterceptor:          responseInterceptor,
 		passRequestPayloadToEnforcer: swagger.GetXWso2RequestBodyPass(),
-		isDefaultVersion:             swagger.IsDefaultVersion,
 		isSandbox:                    isSandbox,
 		endpointType:                 swagger.GetEndpointType(),
+		globalPolicyHeaders:          getGlobalPolicyHeaders(swagger.IsGlobalPolicyDisabled),
+		deprecation:                  swagger.GetDeprecationConfig(),
+		rateLimitHeadersFormat:       swagger.GetRateLimitHeadersFormat(),
+		webSubTopics:                 swagger.GetWebSubTopics(),
+		webSubSignature:              swagger.GetWebSubSignature(),
+		soapVersions:                 swagger.GetSoapVersions(),
+		subscriptionValidation:       swagger.GetSubscriptionValidation(),
+		responseCache:                swagger.GetResponseCache(),
+		disableSecurity:              swagger.GetDisableSecurity(),
+	}
+
+	// Resource level streaming configuration overrides the API level configuration.
+	if resource != nil {
+		if resourceStreamingConfig := model.ResolveStreamingConfig(resource.GetVendorExtensions()); resourceStreamingConfig != nil {
+			params.streamingConfig = resourceStreamingConfig
+		}
+		// Resource level payload limits override the API level limits.
+		if resourcePayloadLimits := model.ResolvePayloadLimits(resource.GetVendorExtensions(), params.payloadLimits); resourcePayloadLimits != nil {
+			params.payloadLimits = resourcePayloadLimits
+		}
+		// Resource level timeouts override the API level timeouts.
+		if resourceTimeouts := model.ResolveTimeouts(resource.GetVendorExtensions(), params.timeouts); resourceTimeouts != nil {
+			params.timeouts = resourceTimeouts
+		}
+		// Resource level response compression overrides the API level compression.
+		if resourceCompression := model.ResolveResponseCompression(resource.GetVendorExtensions(), params.responseCompression); resourceCompression != nil {
+			params.responseCompression = resourceCompression
+		}
+		// Resource level response cache overrides the API level cache.
+		if resourceCache := model.ResolveResponseCache(resource.GetVendorExtensions(), params.responseCache); resourceCache != nil {
+			params.responseCache = resourceCache
+		}
+	}
+
+	// The requests are mirrored only from the production routes. Websocket upgrade requests are not mirrored.
+	if mirror := swagger.GetTrafficMirror(); mirror != nil && !isSandbox && swagger.GetAPIType() != constants.WS {
+		params.trafficMirror = mirror
+		params.mirrorClusterName = getClusterName(mirror.Endpoint.EndpointPrefix, organizationID, vHost,
+			swagger.GetTitle(), swagger.GetVersion(), "")
 	}
 
 	if swagger.GetProdEndpoints() != nil {
@@ -1645,14 +2123,48 @@ func getMaxStreamDuration(apiType string) *routev3.RouteAction_MaxStreamDuration
 	return maxStreamDuration
 }
 
-func getDefaultVersionBasepath(basePath string, version string) string {
-	// Following is used to replace only the version when basepath = /foo/v2 and version = v2 and context => /foo/v2/v2
-	indexOfVersionString := strings.LastIndex(basePath, "/"+version)
-	context := strings.Replace(basePath, "/"+version, "", indexOfVersionString)
+// CreateDefaultVersionRoutes creates the routes of the default version of the API, which match the API context
+// without the version segment. The routes are copies of the versioned routes of the API, hence the requests are
+// forwarded to the default version in the same way.
+func CreateDefaultVersionRoutes(xWso2Basepath, version string, routes []*routev3.Route) []*routev3.Route {
+	basePath := strings.TrimSuffix(xWso2Basepath, "/")
+	context := getDefaultVersionBasepath(basePath, version)
+	if context == basePath {
+		logger.LoggerOasparser.Debugf("Default version routes are not created as the basepath %s does not end with "+
+			"the version %s", basePath, version)
+		return nil
+	}
+	versionedPrefix := "^" + basePath
+	defaultRoutes := make([]*routev3.Route, 0, len(routes))
+	for _, route := range routes {
+		if !strings.HasPrefix(route.GetMatch().GetSafeRegex().GetRegex(), versionedPrefix) {
+			continue
+		}
+		defaultRoute := proto.Clone(route).(*routev3.Route)
+		pathRegex := defaultRoute.GetMatch().GetSafeRegex()
+		pathRegex.Regex = "^" + context + strings.TrimPrefix(pathRegex.Regex, versionedPrefix)
+		if rewritePattern := defaultRoute.GetRoute().GetRegexRewrite().GetPattern(); rewritePattern != nil {
+			// the basepath is quoted in the patterns of the REWRITE_RESOURCE_URL policies
+			quotedPrefix := "^" + regexp.QuoteMeta(basePath)
+			if strings.HasPrefix(rewritePattern.Regex, versionedPrefix) {
+				rewritePattern.Regex = "^" + context + strings.TrimPrefix(rewritePattern.Regex, versionedPrefix)
+			} else if strings.HasPrefix(rewritePattern.Regex, quotedPrefix) {
+				rewritePattern.Regex = "^" + regexp.QuoteMeta(context) +
+					strings.TrimPrefix(rewritePattern.Regex, quotedPrefix)
+			}
+		}
+		if decorator := defaultRoute.GetDecorator(); decorator != nil {
+			decorator.Operation = strings.Replace(decorator.Operation, ":"+versionedPrefix, ":^"+context, 1)
+		}
+		defaultRoutes = append(defaultRoutes, defaultRoute)
+	}
+	return defaultRoutes
+}
 
-	// Having ?: in the regex below, avoids this regex acting as a capturing group. Without this the basepath
-	// would again be added in the locations of path variables when sending the request to backend.
-	return fmt.Sprintf("(?:%s|%s)", basePath, context)
+// getDefaultVersionBasepath returns the basepath without the version segment at the end.
+// ex: /foo/v2 for the basepath /foo/v2/v2 and the version v2
+func getDefaultVersionBasepath(basePath string, version string) string {
+	return strings.TrimSuffix(basePath, "/"+version)
 }
 
 func isSandboxClusterRequired(productionEndpoint *model.EndpointCluster, sandboxEndpoint *model.EndpointCluster) bool {
@@ -1661,11 +2173,13 @@ func isSandboxClusterRequired(productionEndpoint *model.EndpointCluster, sandbox
 	}
 	if sandboxEndpoint != nil && len(sandboxEndpoint.Endpoints) > 0 {
 		// For general host and port based endpoint, check whether host or port are different.
-		// For Consul endpoints, check whether the service discovery strings are different.
+		// For Consul and Eureka endpoints, check whether the service discovery strings are different.
 		if (productionEndpoint.Endpoints[0].Host != sandboxEndpoint.Endpoints[0].Host) ||
 			(productionEndpoint.Endpoints[0].Port != sandboxEndpoint.Endpoints[0].Port) ||
 			(productionEndpoint.Endpoints[0].ServiceDiscoveryString !=
-				sandboxEndpoint.Endpoints[0].ServiceDiscoveryString) {
+				sandboxEndpoint.Endpoints[0].ServiceDiscoveryString) ||
+			(productionEndpoint.Endpoints[0].ServiceDiscoveryRegistry !=
+				sandboxEndpoint.Endpoints[0].ServiceDiscoveryRegistry) {
 			return true
 		}
 	}
@@ -22,6 +22,8 @@ import (
 	"strings"
 	"testing"
 
+	clusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 
@@ -79,6 +81,39 @@ func TestCreateRouteswithClustersGraphQLProdSand(t *testing.T) {
 	testCreateRouteWithClustersGraphQL(t, apiYamlFilePath)
 }
 
+func TestCreateRoutesWithClustersGraphQLSandBasePath(t *testing.T) {
+	apiYamlFilePath := config.GetMgwHome() + "/../adapter/test-resources/envoycodegen/graphql_api.yaml"
+	apiYamlByteArr, err := ioutil.ReadFile(apiYamlFilePath)
+	assert.Nil(t, err, "Error while reading the api.yaml file : %v"+apiYamlFilePath)
+	apiYaml, err := model.NewAPIYaml(apiYamlByteArr)
+	assert.Nil(t, err, "Error occurred while processing api.yaml")
+	apiYaml.Data.EndpointConfig.SandBoxEndpoints[0].Endpoint = "http://www.graphqltestsand.com/sandbox"
+
+	var mgwSwagger model.MgwSwagger
+	err = mgwSwagger.PopulateFromAPIYaml(apiYaml)
+	assert.Nil(t, err, "Error while populating api.yaml file : %v")
+	err = mgwSwagger.SetInfoGraphQLAPI(apiYaml)
+	assert.Nil(t, err, "Error while populating GraphQL attributes from api.yaml : %v")
+
+	routes, clusters, _, _ := envoy.CreateRoutesWithClusters(mgwSwagger, nil, nil, "localhost", "carbon.super")
+	assert.Equal(t, 2, len(clusters), "Number of clusters created incorrect")
+	assert.Equal(t, 2, len(routes), "Number of routes incorrect")
+
+	// sandbox route is matched only if the enforcer sets the sandbox cluster header
+	sandRoute := routes[0]
+	sandHeaders := sandRoute.GetMatch().GetHeaders()
+	assert.Equal(t, "x-wso2-cluster-header", sandHeaders[len(sandHeaders)-1].GetName(), "Cluster header matcher is not added")
+	assert.Equal(t, "carbon.super_clusterSand_localhost_GraphQLAPI1.0.0",
+		sandHeaders[len(sandHeaders)-1].GetStringMatch().GetExact(), "Sandbox cluster name mismatch")
+	assert.Equal(t, "/sandbox", sandRoute.GetRoute().GetRegexRewrite().GetSubstitution(),
+		"Sandbox route is not rewritten with the sandbox basepath")
+
+	prodRoute := routes[1]
+	for _, header := range prodRoute.GetMatch().GetHeaders() {
+		assert.NotEqual(t, "x-wso2-cluster-header", header.GetName(), "Cluster header matcher is added to the production route")
+	}
+}
+
 func TestCreateRoutesWithClustersAwsLambda(t *testing.T) {
 	apiYamlFilePath := config.GetMgwHome() + "/../adapter/test-resources/envoycodegen/awslambda_api.yaml"
 	testCreateRoutesWithClustersAwsLambda(t, apiYamlFilePath)
@@ -697,3 +732,162 @@ func testCreateRoutesWithClustersAPIClusters(t *testing.T) {
 
 	assert.Equal(t, 2, len(routes), "Number of routes created is incorrect")
 }
+
+func TestCreateRoutesWithClustersForRevisionSplit(t *testing.T) {
+	apiYamlFilePath := config.GetMgwHome() + "/../adapter/test-resources/envoycodegen/ws_api_loadbalance.yaml"
+	apiYamlByteArr, err := ioutil.ReadFile(apiYamlFilePath)
+	assert.Nil(t, err, "Error while reading the api.yaml file : %v", apiYamlFilePath)
+	apiYaml, err := model.NewAPIYaml(apiYamlByteArr)
+	assert.Nil(t, err, "Error occurred while processing api.yaml")
+	var mgwSwagger model.MgwSwagger
+	err = mgwSwagger.PopulateFromAPIYaml(apiYaml)
+	assert.Nil(t, err, "Error while populating the MgwSwagger object for web socket APIs")
+
+	prevEndpoints := &model.EndpointCluster{
+		EndpointType: "load_balance",
+		Endpoints: []model.Endpoint{
+			{Host: "prev.websocket.org", Port: 80, URLType: "ws", RawURL: "ws://prev.websocket.org:80"},
+		},
+	}
+	err = mgwSwagger.SetRevisionTrafficSplit(prevEndpoints, nil, model.RevisionTrafficSplit{
+		NewRevisionID:      "2",
+		PreviousRevisionID: "1",
+		NewRevisionWeight:  20,
+		TesterHeader:       "x-wso2-revision",
+	})
+	assert.Nil(t, err, "Error while splitting the traffic between revisions")
+
+	_, clusters, _, err := envoy.CreateRoutesWithClusters(mgwSwagger, nil, nil, "localhost", "carbon.super")
+	assert.Nil(t, err, "Error while creating routes and clusters")
+	assert.Equal(t, 1, len(clusters), "Number of clusters created incorrect")
+	lbEndpoints := clusters[0].GetLoadAssignment().GetEndpoints()
+	assert.Equal(t, 3, len(lbEndpoints), "Endpoints of both the revisions should be added to the cluster")
+	// The new revision has two endpoints, hence the weights of each endpoint are 20*1 and 80*2.
+	expectedWeights := []uint32{20, 20, 160}
+	expectedRevisions := []string{"2", "2", "1"}
+	for i, lbEndpoint := range lbEndpoints {
+		assert.Equal(t, expectedWeights[i], lbEndpoint.GetLbEndpoints()[0].GetLoadBalancingWeight().GetValue(),
+			"Load balancing weight mismatch")
+		assert.Equal(t, expectedRevisions[i], lbEndpoint.GetLbEndpoints()[0].GetMetadata().GetFilterMetadata()["envoy.lb"].
+			GetFields()["revision"].GetStringValue(), "Revision metadata mismatch")
+	}
+	assert.NotNil(t, clusters[0].GetLbSubsetConfig(), "Subset load balancing should be configured")
+}
+
+func TestCreateRoutesWithClustersForAPIDocs(t *testing.T) {
+	apiYamlFilePath := config.GetMgwHome() + "/../adapter/test-resources/envoycodegen/ws_api_loadbalance.yaml"
+	apiYamlByteArr, err := ioutil.ReadFile(apiYamlFilePath)
+	assert.Nil(t, err, "Error while reading the api.yaml file : %v", apiYamlFilePath)
+	apiYaml, err := model.NewAPIYaml(apiYamlByteArr)
+	assert.Nil(t, err, "Error occurred while processing api.yaml")
+	var mgwSwagger model.MgwSwagger
+	err = mgwSwagger.PopulateFromAPIYaml(apiYaml)
+	assert.Nil(t, err, "Error while populating the MgwSwagger object for web socket APIs")
+	mgwSwagger.SetAPIDocs(map[string][]byte{
+		"Getting Started/guide.md": []byte("# Guide"),
+		"Postman/collection.json":  []byte("{}"),
+		"Reference/large.pdf":      make([]byte, 2048),
+		"Reference/guide.md":       []byte("# Reference"),
+	})
+
+	conf, _ := config.ReadConfigs()
+	apiDocsConf := conf.Envoy.APIDocs
+	defer func() {
+		conf.Envoy.APIDocs = apiDocsConf
+	}()
+
+	routes, _, _, err := envoy.CreateRoutesWithClusters(mgwSwagger, nil, nil, "localhost", "carbon.super")
+	assert.Nil(t, err, "Error while creating routes and clusters")
+	assert.Empty(t, routes, "Docs routes should not be created when the API docs are disabled")
+
+	conf.Envoy.APIDocs.Enabled = true
+	conf.Envoy.APIDocs.MaxSizeInBytes = 1024
+	routes, _, _, err = envoy.CreateRoutesWithClusters(mgwSwagger, nil, nil, "localhost", "carbon.super")
+	assert.Nil(t, err, "Error while creating routes and clusters")
+	assert.Equal(t, 4, len(routes), "Docs exceeding the max size should not be served")
+
+	basePath := mgwSwagger.GetXWso2Basepath()
+	assert.Equal(t, basePath+"/_docs", routes[0].GetMatch().GetPath(), "Docs list route path mismatch")
+	assert.Equal(t, `["Getting Started/guide.md","Postman/collection.json","Reference/guide.md"]`,
+		string(routes[0].GetDirectResponse().GetBody().GetInlineBytes()), "Docs list mismatch")
+	assert.Equal(t, basePath+"/_docs/Getting%20Started/guide.md", routes[1].GetMatch().GetPath(),
+		"Doc route path mismatch")
+	assert.Equal(t, "# Guide", string(routes[1].GetDirectResponse().GetBody().GetInlineBytes()), "Doc content mismatch")
+	assert.Equal(t, "text/markdown", routes[1].GetResponseHeadersToAdd()[0].GetHeader().GetValue(),
+		"Doc content type mismatch")
+	assert.Equal(t, "application/json", routes[2].GetResponseHeadersToAdd()[0].GetHeader().GetValue(),
+		"Doc content type mismatch")
+	assert.Equal(t, basePath+"/_docs/Reference/guide.md", routes[3].GetMatch().GetPath(),
+		"Docs with the same file name should be served separately")
+	assert.Equal(t, "# Reference", string(routes[3].GetDirectResponse().GetBody().GetInlineBytes()),
+		"Doc content mismatch")
+}
+
+func TestCreateRoutesWithClustersForLbConfig(t *testing.T) {
+	openapiFilePath := config.GetMgwHome() + "/../adapter/test-resources/envoycodegen/openapi_with_lb_config.yaml"
+	openapiByteArr, err := ioutil.ReadFile(openapiFilePath)
+	assert.Nil(t, err, "Error while reading the openapi file : "+openapiFilePath)
+	mgwSwagger := model.MgwSwagger{}
+	err = mgwSwagger.GetMgwSwagger(openapiByteArr)
+	assert.Nil(t, err, "Error should not be present when openAPI definition is converted to a MgwSwagger object")
+	assert.Nil(t, mgwSwagger.Validate(), "Load balancing configuration should be valid")
+	_, clusters, _, err := envoy.CreateRoutesWithClusters(mgwSwagger, nil, nil, "localhost", "carbon.super")
+	assert.Nil(t, err, "Error while creating routes and clusters")
+	assert.Equal(t, 2, len(clusters), "Number of clusters created is incorrect.")
+
+	conf, _ := config.ReadConfigs()
+	apiLevelCluster := clusters[0]
+	assert.Equal(t, clusterv3.Cluster_LEAST_REQUEST, apiLevelCluster.GetLbPolicy(), "Load balancing policy mismatch")
+	healthCheck := apiLevelCluster.GetHealthChecks()[0]
+	assert.Equal(t, "/health", healthCheck.GetHttpHealthCheck().GetPath(), "Health check path mismatch")
+	assert.Equal(t, int64(5), healthCheck.GetInterval().GetSeconds(), "Health check interval mismatch")
+	assert.Equal(t, uint32(3), healthCheck.GetUnhealthyThreshold().GetValue(), "Unhealthy threshold mismatch")
+	assert.Equal(t, uint32(conf.Envoy.Upstream.Health.HealthyThreshold), healthCheck.GetHealthyThreshold().GetValue(),
+		"Healthy threshold should be taken from the upstream health check configuration")
+	outlierDetection := apiLevelCluster.GetOutlierDetection()
+	assert.Equal(t, uint32(3), outlierDetection.GetConsecutive_5Xx().GetValue(), "Consecutive 5xx mismatch")
+	assert.Equal(t, int64(30), outlierDetection.GetBaseEjectionTime().GetSeconds(), "Base ejection time mismatch")
+	assert.Equal(t, uint32(50), outlierDetection.GetMaxEjectionPercent().GetValue(), "Max ejection percent mismatch")
+
+	resourceLevelCluster := clusters[1]
+	assert.Equal(t, clusterv3.Cluster_ROUND_ROBIN, resourceLevelCluster.GetLbPolicy(), "Load balancing policy mismatch")
+	assert.IsType(t, &corev3.HealthCheck_TcpHealthCheck_{}, resourceLevelCluster.GetHealthChecks()[0].GetHealthChecker(),
+		"TCP health check should be used by default")
+	assert.Equal(t, uint32(1), resourceLevelCluster.GetLoadAssignment().GetEndpoints()[1].GetPriority(),
+		"Failover endpoint priority mismatch")
+	assert.Equal(t, uint32(1), resourceLevelCluster.GetOutlierDetection().GetConsecutive_5Xx().GetValue(),
+		"Consecutive 5xx mismatch")
+	assert.Nil(t, resourceLevelCluster.GetOutlierDetection().GetMaxEjectionPercent(),
+		"Envoy default should be used for the max ejection percent")
+}
+
+func TestCreateRoutesWithClustersForTrafficMirror(t *testing.T) {
+	openapiFilePath := config.GetMgwHome() + "/../adapter/test-resources/envoycodegen/openapi.yaml"
+	openapiByteArr, err := ioutil.ReadFile(openapiFilePath)
+	assert.Nil(t, err, "Error while reading the openapi file : "+openapiFilePath)
+	mgwSwagger := model.MgwSwagger{}
+	err = mgwSwagger.GetMgwSwagger(openapiByteArr)
+	assert.Nil(t, err, "Error should not be present when openAPI definition is converted to a MgwSwagger object")
+	mirror, err := model.ResolveTrafficMirror(map[string]interface{}{
+		"x-wso2-traffic-mirror": map[string]interface{}{"url": "http://shadow-backend:8080", "percentage": 12.5},
+	})
+	assert.Nil(t, err, "Error while resolving the traffic mirror")
+	mgwSwagger.SetTrafficMirror(mirror)
+
+	routes, clusters, _, err := envoy.CreateRoutesWithClusters(mgwSwagger, nil, nil, "localhost", "carbon.super")
+	assert.Nil(t, err, "Error while creating routes and clusters")
+	assert.Equal(t, 3, len(clusters), "Cluster of the shadow endpoint should be created")
+	mirrorClusterName := "carbon.super_clusterMirror_localhost_SwaggerPetstore1.0.0"
+	assert.Equal(t, mirrorClusterName, clusters[1].GetName(), "Mirror cluster name mismatch")
+	assert.Equal(t, "shadow-backend", clusters[1].GetLoadAssignment().GetEndpoints()[0].GetLbEndpoints()[0].
+		GetEndpoint().GetAddress().GetSocketAddress().GetAddress(), "Mirror cluster host mismatch")
+
+	for _, route := range routes {
+		mirrorPolicies := route.GetRoute().GetRequestMirrorPolicies()
+		if assert.Equal(t, 1, len(mirrorPolicies), "Requests of the route should be mirrored") {
+			assert.Equal(t, mirrorClusterName, mirrorPolicies[0].GetCluster(), "Mirror cluster mismatch")
+			assert.Equal(t, uint32(125000), mirrorPolicies[0].GetRuntimeFraction().GetDefaultValue().GetNumerator(),
+				"Mirrored percentage mismatch")
+		}
+	}
+}
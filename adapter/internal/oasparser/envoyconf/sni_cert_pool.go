@@ -0,0 +1,185 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package envoyconf
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	listenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	tlsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	"github.com/wso2/product-microgateway/adapter/config"
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// sniCertificate is a certificate of the SNI certificate pool.
+type sniCertificate struct {
+	tlsCert *tlsv3.TlsCertificate
+	// revision is the hash of the certificate and the private key files. The filter chain changes when the files
+	// are rotated, hence envoy reads the rotated files.
+	revision string
+}
+
+var (
+	// sniCertPool is the certificates of the pool directory, by the domain
+	sniCertPool          map[string]*sniCertificate
+	sniCertPoolDirectory string
+	sniCertPoolMutex     sync.RWMutex
+)
+
+// UpdateSNIFilterChains adds a filter chain per vhost to the secured listener, if a certificate is available
+// for the vhost within the SNI certificate pool. The filter chain is selected by envoy based on the
+// server name indication sent by the client. Connections without a matching SNI are served by the default
+// filter chain, which uses the router keystore.
+//
+// The pool is read once, and read again by ReloadSNICertPool when the pool directory is changed.
+func UpdateSNIFilterChains(listeners []*listenerv3.Listener, vhosts []string) {
+	conf, _ := config.ReadConfigs()
+	if !conf.Envoy.Downstream.TLS.SNI.Enabled {
+		return
+	}
+	certPool := getSNICertPool(conf.Envoy.Downstream.TLS.SNI.CertPoolDirectory)
+	for _, listener := range listeners {
+		if listener.Name != defaultHTTPSListenerName || len(listener.FilterChains) == 0 {
+			continue
+		}
+		defaultFilterChain := listener.FilterChains[0]
+		filterChains := []*listenerv3.FilterChain{defaultFilterChain}
+		for _, domain := range getSNIDomainsForVhosts(certPool, vhosts) {
+			filterChains = append(filterChains, &listenerv3.FilterChain{
+				Name: sniFilterChainNamePrefix + domain,
+				FilterChainMatch: &listenerv3.FilterChainMatch{
+					ServerNames: []string{domain},
+				},
+				Filters:         defaultFilterChain.Filters,
+				TransportSocket: generateDownstreamTransportSocket(conf, certPool[domain].tlsCert),
+				Metadata: &corev3.Metadata{
+					FilterMetadata: map[string]*structpb.Struct{
+						sniMetadataFilterName: {
+							Fields: map[string]*structpb.Value{
+								sniRevisionMetadataKey: structpb.NewStringValue(certPool[domain].revision),
+							},
+						},
+					},
+				},
+			})
+		}
+		listener.FilterChains = filterChains
+		logger.LoggerOasparser.Debugf("%d SNI based filter chains are added to the listener %s",
+			len(filterChains)-1, listener.Name)
+	}
+}
+
+// ReloadSNICertPool reads the SNI certificate pool directory again, hence the certificates added, removed or
+// rotated are applied to the listeners generated afterwards.
+func ReloadSNICertPool() {
+	conf, _ := config.ReadConfigs()
+	loadSNICertPool(conf.Envoy.Downstream.TLS.SNI.CertPoolDirectory)
+}
+
+// getSNICertPool returns the certificates of the pool directory, which are read at the first access of the
+// directory.
+func getSNICertPool(poolDirectory string) map[string]*sniCertificate {
+	sniCertPoolMutex.RLock()
+	certPool, loaded := sniCertPool, sniCertPool != nil && sniCertPoolDirectory == poolDirectory
+	sniCertPoolMutex.RUnlock()
+	if loaded {
+		return certPool
+	}
+	return loadSNICertPool(poolDirectory)
+}
+
+// getSNIDomainsForVhosts returns the sorted set of pool domains matching the provided vhosts.
+// An exact match is preferred over a wildcard (*.foo.com) match. The vhosts are matched case insensitively, as the
+// domains of the pool are in lower case.
+func getSNIDomainsForVhosts(certPool map[string]*sniCertificate, vhosts []string) []string {
+	domainSet := make(map[string]struct{})
+	for _, vhost := range vhosts {
+		vhost = strings.ToLower(strings.TrimSpace(vhost))
+		if _, found := certPool[vhost]; found {
+			domainSet[vhost] = struct{}{}
+			continue
+		}
+		if index := strings.Index(vhost, "."); index > 0 {
+			wildcardDomain := "*" + vhost[index:]
+			if _, found := certPool[wildcardDomain]; found {
+				domainSet[wildcardDomain] = struct{}{}
+			}
+		}
+	}
+	domains := make([]string, 0, len(domainSet))
+	for domain := range domainSet {
+		domains = append(domains, domain)
+	}
+	sort.Strings(domains)
+	return domains
+}
+
+// loadSNICertPool reads the certificate pool directory, and caches the certificates. Each sub directory is named
+// after the domain and should contain the tls.crt and tls.key files (the layout of a mounted kubernetes TLS secret).
+func loadSNICertPool(poolDirectory string) map[string]*sniCertificate {
+	certPool := make(map[string]*sniCertificate)
+	defer func() {
+		sniCertPoolMutex.Lock()
+		sniCertPool, sniCertPoolDirectory = certPool, poolDirectory
+		sniCertPoolMutex.Unlock()
+	}()
+	entries, err := ioutil.ReadDir(poolDirectory)
+	if err != nil {
+		logger.LoggerOasparser.Errorf("Error while reading the SNI certificate pool directory %s. %v", poolDirectory, err)
+		return certPool
+	}
+	for _, entry := range entries {
+		// Mounted kubernetes secrets contain hidden directories (ie: ..data) and symbolic links.
+		if strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		domainDirectory := filepath.Join(poolDirectory, entry.Name())
+		if info, err := os.Stat(domainDirectory); err != nil || !info.IsDir() {
+			continue
+		}
+		certPath := filepath.Join(domainDirectory, sniCertFileName)
+		keyPath := filepath.Join(domainDirectory, sniKeyFileName)
+		cert, err := ioutil.ReadFile(certPath)
+		if err != nil {
+			logger.LoggerOasparser.Warnf("Certificate is not found for the domain %s in SNI certificate pool. %v",
+				entry.Name(), err)
+			continue
+		}
+		key, err := ioutil.ReadFile(keyPath)
+		if err != nil {
+			logger.LoggerOasparser.Warnf("Private key is not found for the domain %s in SNI certificate pool. %v",
+				entry.Name(), err)
+			continue
+		}
+		revision := sha256.Sum256(append(cert, key...))
+		certPool[strings.ToLower(entry.Name())] = &sniCertificate{
+			tlsCert:  generateTLSCert(keyPath, certPath),
+			revision: hex.EncodeToString(revision[:]),
+		}
+	}
+	return certPool
+}
@@ -0,0 +1,146 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package envoyconf
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	extAuthService "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ext_authz/v3"
+	envoy_type_matcherv3 "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/constants"
+	"github.com/wso2/product-microgateway/adapter/pkg/soaputils"
+)
+
+const (
+	soapUnsupportedMediaTypeMessage     string = "Unsupported Media Type"
+	soapUnsupportedMediaTypeDescription string = "The content type of the request is not supported by the SOAP service"
+)
+
+// getSOAPContentTypes returns the content types of the SOAP versions, which are text/xml for SOAP 1.1 and
+// application/soap+xml for SOAP 1.2. Both are returned if the SOAP versions are not known.
+func getSOAPContentTypes(soapVersions []string) []string {
+	var contentTypes []string
+	for _, version := range soapVersions {
+		switch version {
+		case constants.SOAP11Version:
+			contentTypes = append(contentTypes, contentTypeHeaderXML)
+		case constants.SOAP12Version:
+			contentTypes = append(contentTypes, contentTypeHeaderSoap)
+		}
+	}
+	if len(contentTypes) == 0 {
+		return []string{contentTypeHeaderXML, contentTypeHeaderSoap}
+	}
+	return contentTypes
+}
+
+// applySOAPPassthrough returns the routes of a SOAP API, which pass the SOAP requests through to the backend. The
+// SOAPAction header is not removed from the requests by the global policies, as the SOAP 1.1 services dispatch the
+// requests by that. The POST requests of a content type other than the content types of the SOAP versions of the
+// WSDL are responded with a 415 SOAP fault, by a route preceding each route. The requests without a content type
+// are passed through, and left for the SOAP service to reject.
+func applySOAPPassthrough(routes []*routev3.Route, soapVersions []string) []*routev3.Route {
+	contentTypes := getSOAPContentTypes(soapVersions)
+	quotedContentTypes := make([]string, 0, len(contentTypes))
+	for _, contentType := range contentTypes {
+		quotedContentTypes = append(quotedContentTypes, regexp.QuoteMeta(contentType))
+	}
+	contentTypeMatcher := &routev3.HeaderMatcher{
+		Name: contentTypeHeaderName,
+		HeaderMatchSpecifier: &routev3.HeaderMatcher_StringMatch{
+			StringMatch: &envoy_type_matcherv3.StringMatcher{
+				MatchPattern: &envoy_type_matcherv3.StringMatcher_SafeRegex{
+					SafeRegex: &envoy_type_matcherv3.RegexMatcher{
+						Regex: "^(" + strings.Join(quotedContentTypes, "|") + ")(;.*)?$",
+					},
+				},
+				IgnoreCase: true,
+			},
+		},
+		InvertMatch: true,
+	}
+
+	// the fault of the SOAP 1.1 version is preferred, as the requests of an unsupported content type can not be
+	// attributed to a SOAP version
+	faultVersion, faultContentType := soap11ProtocolVersion, contentTypeHeaderXML
+	if contentTypes[0] == contentTypeHeaderSoap {
+		faultVersion, faultContentType = soap12ProtocolVersion, contentTypeHeaderSoap
+	}
+	fault, _ := soaputils.GenerateSoapFaultMessage(faultVersion, soapUnsupportedMediaTypeMessage,
+		soapUnsupportedMediaTypeDescription, strconv.Itoa(http.StatusUnsupportedMediaType))
+	extAuthzDisabled := marshalFilterConfig(&extAuthService.ExtAuthzPerRoute{
+		Override: &extAuthService.ExtAuthzPerRoute_Disabled{
+			Disabled: true,
+		},
+	})
+
+	soapRoutes := make([]*routev3.Route, 0, 2*len(routes))
+	for _, route := range routes {
+		route.RequestHeadersToRemove = removeHeaderName(route.RequestHeadersToRemove, soapActionHeaderName)
+		match := proto.Clone(route.GetMatch()).(*routev3.RouteMatch)
+		for _, header := range match.Headers {
+			if header.GetName() == httpMethodHeader {
+				header.HeaderMatchSpecifier = generateHeaderMatcher(httpMethodHeader, http.MethodPost).HeaderMatchSpecifier
+			}
+		}
+		match.Headers = append(match.Headers, contentTypeMatcher)
+		soapRoutes = append(soapRoutes, &routev3.Route{
+			Name:      route.GetName(),
+			Match:     match,
+			Decorator: route.GetDecorator(),
+			TypedPerFilterConfig: map[string]*anypb.Any{
+				wellknown.HTTPExternalAuthorization: extAuthzDisabled,
+			},
+			Action: &routev3.Route_DirectResponse{
+				DirectResponse: &routev3.DirectResponseAction{
+					Status: http.StatusUnsupportedMediaType,
+					Body: &corev3.DataSource{
+						Specifier: &corev3.DataSource_InlineString{
+							InlineString: fault,
+						},
+					},
+				},
+			},
+			ResponseHeadersToAdd: []*corev3.HeaderValueOption{
+				generateHeaderValueOption("Content-Type", faultContentType,
+					corev3.HeaderValueOption_OVERWRITE_IF_EXISTS_OR_ADD),
+			},
+		}, route)
+	}
+	return soapRoutes
+}
+
+// removeHeaderName returns the header names without the given header name, compared case insensitively.
+func removeHeaderName(headerNames []string, headerName string) []string {
+	var remaining []string
+	for _, name := range headerNames {
+		if !strings.EqualFold(name, headerName) {
+			remaining = append(remaining, name)
+		}
+	}
+	return remaining
+}
@@ -0,0 +1,91 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package envoyconf
+
+import (
+	"net/url"
+	"strconv"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	extAuthService "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ext_authz/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+const standbyResponseBody string = `{"code":"503","message":"Service Unavailable",` +
+	`"description":"The gateway is in standby mode"}`
+
+// CreateStandbyRoutes returns the routes of a site in the standby mode, which match the same requests as the
+// given routes. The requests are redirected to the primary site if the redirect URL is given (keeping the path
+// and the query), or responded with 503 otherwise. The requests are not authenticated by the enforcer.
+func CreateStandbyRoutes(routes []*routev3.Route, redirectURL *url.URL) []*routev3.Route {
+	extAuthzDisabled := marshalFilterConfig(&extAuthService.ExtAuthzPerRoute{
+		Override: &extAuthService.ExtAuthzPerRoute_Disabled{
+			Disabled: true,
+		},
+	})
+	standbyRoutes := make([]*routev3.Route, 0, len(routes))
+	for _, route := range routes {
+		standbyRoute := &routev3.Route{
+			Name:      route.GetName(),
+			Match:     route.GetMatch(),
+			Decorator: route.GetDecorator(),
+			TypedPerFilterConfig: map[string]*anypb.Any{
+				wellknown.HTTPExternalAuthorization: extAuthzDisabled,
+			},
+		}
+		if redirectURL != nil {
+			standbyRoute.Action = &routev3.Route_Redirect{
+				Redirect: generateStandbyRedirect(redirectURL),
+			}
+		} else {
+			standbyRoute.Action = &routev3.Route_DirectResponse{
+				DirectResponse: &routev3.DirectResponseAction{
+					Status: 503,
+					Body: &corev3.DataSource{
+						Specifier: &corev3.DataSource_InlineString{
+							InlineString: standbyResponseBody,
+						},
+					},
+				},
+			}
+			standbyRoute.ResponseHeadersToAdd = []*corev3.HeaderValueOption{
+				generateHeaderValueOption("Content-Type", "application/json",
+					corev3.HeaderValueOption_OVERWRITE_IF_EXISTS_OR_ADD),
+			}
+		}
+		standbyRoutes = append(standbyRoutes, standbyRoute)
+	}
+	return standbyRoutes
+}
+
+// generateStandbyRedirect redirects to the primary site with 307, hence the method and the body are retained.
+func generateStandbyRedirect(redirectURL *url.URL) *routev3.RedirectAction {
+	redirect := &routev3.RedirectAction{
+		SchemeRewriteSpecifier: &routev3.RedirectAction_SchemeRedirect{
+			SchemeRedirect: redirectURL.Scheme,
+		},
+		HostRedirect: redirectURL.Hostname(),
+		ResponseCode: routev3.RedirectAction_TEMPORARY_REDIRECT,
+	}
+	if port, err := strconv.ParseUint(redirectURL.Port(), 10, 32); err == nil {
+		redirect.PortRedirect = uint32(port)
+	}
+	return redirect
+}
@@ -0,0 +1,48 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package envoyconf
+
+import (
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	typev3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
+)
+
+// applyTrafficMirror mirrors the configured percentage of the requests of the given routes to the mirror cluster.
+// The requests are mirrored in a fire and forget manner, hence the responses of the mirror cluster are ignored.
+func applyTrafficMirror(routes []*routev3.Route, mirrorClusterName string, mirror *model.TrafficMirror) {
+	if mirror == nil || mirrorClusterName == "" {
+		return
+	}
+	mirrorPolicy := &routev3.RouteAction_RequestMirrorPolicy{
+		Cluster: mirrorClusterName,
+		RuntimeFraction: &corev3.RuntimeFractionalPercent{
+			DefaultValue: &typev3.FractionalPercent{
+				// the percentage is converted to parts per million to support fractions of a percent
+				Numerator:   uint32(mirror.Percentage * 10000),
+				Denominator: typev3.FractionalPercent_MILLION,
+			},
+		},
+	}
+	for _, route := range routes {
+		if routeAction := route.GetRoute(); routeAction != nil {
+			routeAction.RequestMirrorPolicies = append(routeAction.RequestMirrorPolicies, mirrorPolicy)
+		}
+	}
+}
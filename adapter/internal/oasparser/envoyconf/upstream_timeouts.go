@@ -0,0 +1,75 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package envoyconf
+
+import (
+	"time"
+
+	clusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	typev3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// applyRouteTimeouts applies the route timeout to the given routes, which replaces the route timeout configured
+// for the router. The streaming routes, of which the route timeout is disabled, are not updated.
+func applyRouteTimeouts(routes []*routev3.Route, timeouts *model.Timeouts) {
+	if timeouts == nil || timeouts.RouteTimeoutInMillis == 0 {
+		return
+	}
+	for _, route := range routes {
+		action := route.GetRoute()
+		if action == nil || (action.Timeout != nil && action.Timeout.AsDuration() == 0) {
+			continue
+		}
+		action.Timeout = durationpb.New(time.Duration(timeouts.RouteTimeoutInMillis) * time.Millisecond)
+	}
+}
+
+// applyClusterTimeouts applies the connect timeout to an upstream cluster of the API, which replaces the cluster
+// timeout configured for the router.
+func applyClusterTimeouts(cluster *clusterv3.Cluster, timeouts *model.Timeouts) {
+	if timeouts == nil || timeouts.ConnectTimeoutInMillis == 0 {
+		return
+	}
+	cluster.ConnectTimeout = durationpb.New(time.Duration(timeouts.ConnectTimeoutInMillis) * time.Millisecond)
+}
+
+// applyRetryBudget limits the concurrent retries to an upstream cluster of the API with the circuit breaker of the
+// cluster. The retry budget overrides the maximum retries of the endpoint configuration, if any.
+func applyRetryBudget(cluster *clusterv3.Cluster, budget *model.RetryBudget) {
+	if budget == nil {
+		return
+	}
+	if cluster.CircuitBreakers == nil || len(cluster.CircuitBreakers.Thresholds) == 0 {
+		cluster.CircuitBreakers = &clusterv3.CircuitBreakers{
+			Thresholds: []*clusterv3.CircuitBreakers_Thresholds{
+				{},
+			},
+		}
+	}
+	retryBudget := &clusterv3.CircuitBreakers_Thresholds_RetryBudget{
+		BudgetPercent: &typev3.Percent{Value: budget.BudgetPercent},
+	}
+	if budget.MinRetryConcurrency > 0 {
+		retryBudget.MinRetryConcurrency = wrapperspb.UInt32(budget.MinRetryConcurrency)
+	}
+	cluster.CircuitBreakers.Thresholds[0].RetryBudget = retryBudget
+}
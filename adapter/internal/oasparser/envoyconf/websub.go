@@ -0,0 +1,65 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package envoyconf
+
+import (
+	"regexp"
+	"strings"
+
+	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	envoy_type_matcherv3 "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/constants"
+)
+
+// applyWebSubTopicMatchers restricts the routes of the hub resources of a WebSub API to the topics declared
+// in the API definition, hence the requests for unknown topics are not routed to the hub.
+func applyWebSubTopicMatchers(routes []*routev3.Route, resourcePath string, topics []string) {
+	var queryParam string
+	switch resourcePath {
+	case constants.WebSubHubResource:
+		queryParam = constants.WebSubHubTopicQueryParam
+	case constants.WebSubEventsReceiverResource:
+		queryParam = constants.WebSubTopicQueryParam
+	default:
+		return
+	}
+	if len(topics) == 0 {
+		return
+	}
+	quotedTopics := make([]string, 0, len(topics))
+	for _, topic := range topics {
+		quotedTopics = append(quotedTopics, regexp.QuoteMeta(topic))
+	}
+	topicMatcher := &routev3.QueryParameterMatcher{
+		Name: queryParam,
+		QueryParameterMatchSpecifier: &routev3.QueryParameterMatcher_StringMatch{
+			StringMatch: &envoy_type_matcherv3.StringMatcher{
+				MatchPattern: &envoy_type_matcherv3.StringMatcher_SafeRegex{
+					SafeRegex: &envoy_type_matcherv3.RegexMatcher{
+						Regex: "^(" + strings.Join(quotedTopics, "|") + ")$",
+					},
+				},
+			},
+		},
+	}
+	for _, route := range routes {
+		if match := route.GetMatch(); match != nil {
+			match.QueryParameters = append(match.QueryParameters, topicMatcher)
+		}
+	}
+}
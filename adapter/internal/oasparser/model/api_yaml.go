@@ -29,6 +29,23 @@ import (
 	"github.com/wso2/product-microgateway/adapter/internal/oasparser/utills"
 )
 
+// CorsConfiguration represents the API level CORS configuration of the api.yaml
+type CorsConfiguration struct {
+	CorsConfigurationEnabled      bool     `json:"corsConfigurationEnabled,omitempty"`
+	AccessControlAllowOrigins     []string `json:"accessControlAllowOrigins,omitempty"`
+	AccessControlAllowCredentials bool     `json:"accessControlAllowCredentials,omitempty"`
+	AccessControlAllowHeaders     []string `json:"accessControlAllowHeaders,omitempty"`
+	AccessControlAllowMethods     []string `json:"accessControlAllowMethods,omitempty"`
+}
+
+// WebSubSubscriptionConfiguration represents the signature of the events of a WebSub API in the api.yaml
+type WebSubSubscriptionConfiguration struct {
+	Enable           bool   `json:"enable,omitempty"`
+	Secret           string `json:"secret,omitempty"`
+	SigningAlgorithm string `json:"signingAlgorithm,omitempty"`
+	SignatureHeader  string `json:"signatureHeader,omitempty"`
+}
+
 // APIYaml contains everything necessary to extract api.json/api.yaml file
 // To support both api.json and api.yaml we convert yaml to json and then use json.Unmarshal()
 // Therefore, the params are defined to support json.Unmarshal()
@@ -36,27 +53,22 @@ type APIYaml struct {
 	Type    string `yaml:"type" json:"type"`
 	Version string `yaml:"version" json:"version"`
 	Data    struct {
-		ID                         string   `json:"Id,omitempty"`
-		Name                       string   `json:"name,omitempty"`
-		Context                    string   `json:"context,omitempty"`
-		Version                    string   `json:"version,omitempty"`
-		RevisionID                 int      `json:"revisionId,omitempty"`
-		APIType                    string   `json:"type,omitempty"`
-		LifeCycleStatus            string   `json:"lifeCycleStatus,omitempty"`
-		EndpointImplementationType string   `json:"endpointImplementationType,omitempty"`
-		AuthorizationHeader        string   `json:"authorizationHeader,omitempty"`
-		SecurityScheme             []string `json:"securityScheme,omitempty"`
-		OrganizationID             string   `json:"organizationId,omitempty"`
-		APIThrottlingPolicy        string   `json:"apiThrottlingPolicy,omitempty"`
-		IsDefaultVersion           bool     `json:"isDefaultVersion,omitempty"`
-		CorsConfiguration          struct {
-			CorsConfigurationEnabled      bool     `json:"corsConfigurationEnabled,omitempty"`
-			AccessControlAllowOrigins     []string `json:"accessControlAllowOrigins,omitempty"`
-			AccessControlAllowCredentials bool     `json:"accessControlAllowCredentials,omitempty"`
-			AccessControlAllowHeaders     []string `json:"accessControlAllowHeaders,omitempty"`
-			AccessControlAllowMethods     []string `json:"accessControlAllowMethods,omitempty"`
-		} `json:"corsConfiguration,omitempty"`
-		EndpointConfig struct {
+		ID                         string            `json:"Id,omitempty"`
+		Name                       string            `json:"name,omitempty"`
+		Context                    string            `json:"context,omitempty"`
+		Version                    string            `json:"version,omitempty"`
+		RevisionID                 int               `json:"revisionId,omitempty"`
+		APIType                    string            `json:"type,omitempty"`
+		LifeCycleStatus            string            `json:"lifeCycleStatus,omitempty"`
+		EndpointImplementationType string            `json:"endpointImplementationType,omitempty"`
+		AuthorizationHeader        string            `json:"authorizationHeader,omitempty"`
+		SecurityScheme             []string          `json:"securityScheme,omitempty"`
+		OrganizationID             string            `json:"organizationId,omitempty"`
+		APIThrottlingPolicy        string            `json:"apiThrottlingPolicy,omitempty"`
+		IsDefaultVersion           bool              `json:"isDefaultVersion,omitempty"`
+		SubscriptionValidation     *bool             `json:"subscriptionValidation,omitempty"`
+		CorsConfiguration          CorsConfiguration `json:"corsConfiguration,omitempty"`
+		EndpointConfig             struct {
 			EndpointType                 string              `json:"endpoint_type,omitempty"`
 			AccessMethod                 string              `json:"access_method,omitempty"`
 			AmazonRegion                 string              `json:"amznRegion"`
@@ -72,10 +84,22 @@ type APIYaml struct {
 			SandboxFailoverEndpoints     []EndpointInfo `json:"sandbox_failovers,omitempty"`
 			ImplementationStatus         string         `json:"implementation_status,omitempty"`
 		} `json:"endpointConfig,omitempty"`
-		Operations []OperationYaml `json:"Operations,omitempty"`
+		Operations        []OperationYaml   `json:"Operations,omitempty"`
+		APIPolicies       OperationPolicies `json:"apiPolicies,omitempty"`
+		MediationPolicies []MediationPolicy `json:"mediationPolicies,omitempty"`
+
+		// WebSubSubscriptionConfig is the signature of the events of a WebSub API
+		WebSubSubscriptionConfig WebSubSubscriptionConfiguration `json:"websubSubscriptionConfiguration,omitempty"`
 	} `json:"data"`
 }
 
+// MediationPolicy holds the attributes of the mediation sequences of APIM gateway, attached to the API
+type MediationPolicy struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+	Type string `json:"type,omitempty"`
+}
+
 // APIEndpointSecurity represents the structure of endpoint_security param in api.yaml
 type APIEndpointSecurity struct {
 	Production EndpointSecurity `json:"production,omitempty"`
@@ -271,7 +295,8 @@ func (apiYaml APIYaml) ValidateAPIType() (err error) {
 		// If no api.yaml file is included in the zip folder, return with error.
 		err = errors.New("could not find api.yaml or api.json")
 		return err
-	} else if apiType != constants.HTTP && apiType != constants.WS && apiType != constants.SOAP && apiType != constants.GRAPHQL {
+	} else if apiType != constants.HTTP && apiType != constants.WS && apiType != constants.SOAP && apiType != constants.GRAPHQL &&
+		apiType != constants.SSE && apiType != constants.WEBSUB {
 		errMsg := "The given API type is currently not supported in Choreo Connect. API type: " + apiType
 		err = errors.New(errMsg)
 		return err
@@ -19,12 +19,31 @@ package model
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/wso2/product-microgateway/adapter/internal/loggers"
 	"github.com/wso2/product-microgateway/adapter/internal/oasparser/constants"
 )
 
+// default signature of the events of the WebSub APIs, as of the control plane
+const (
+	defaultWebSubSigningAlgorithm string = "SHA1"
+	defaultWebSubSignatureHeader  string = "x-hub-signature"
+)
+
+// webSubSigningAlgorithms are the HMAC algorithms the events of the WebSub APIs can be signed with
+var webSubSigningAlgorithms = []string{"SHA1", "SHA256", "SHA384", "SHA512"}
+
+// WebSubSignature is the HMAC signature of the events published to the hub of a WebSub API, which is verified by
+// the enforcer as the events receiver is not secured with tokens.
+type WebSubSignature struct {
+	Secret           string
+	SigningAlgorithm string
+	SignatureHeader  string
+}
+
 // AsyncAPI is the struct for the AsyncAPI 2.0.0 definition
 type AsyncAPI struct {
 	SpecVersion string `json:"asyncapi,omitempty"`
@@ -86,10 +105,24 @@ func (swagger *MgwSwagger) SetInfoAsyncAPI(asyncAPI AsyncAPI) error {
 	swagger.vendorExtensions = asyncAPI.VendorExtensions
 	swagger.disableSecurity = ResolveDisableSecurity(asyncAPI.VendorExtensions)
 	swagger.securityScheme = asyncAPI.getSecuritySchemes()
+
+	// The channels of a WebSub API are the topics of the hub. The hub endpoint is taken from the
+	// endpointConfig of the api.yaml, hence the servers of the definition are not considered.
+	if swagger.apiType == constants.WEBSUB {
+		swagger.webSubTopics = asyncAPI.getTopics()
+		swagger.resources = getWebSubHubResources()
+		return nil
+	}
 	swagger.resources = asyncAPI.getResources()
 
+	// Server-Sent Events APIs are served over HTTP, hence the servers are not websocket endpoints.
+	getEndpoint := getWebSocketEndpoint
+	if swagger.apiType == constants.SSE {
+		getEndpoint = getHTTPEndpoint
+	}
+
 	if asyncAPI.Servers.Production.URL != "" {
-		endpoint, err := getWebSocketEndpoint(asyncAPI.Servers.Production.URL)
+		endpoint, err := getEndpoint(asyncAPI.Servers.Production.URL)
 		if err == nil {
 			productionEndpoints := append([]Endpoint{}, *endpoint)
 			swagger.productionEndpoints = generateEndpointCluster(constants.ProdClustersConfigNamePrefix,
@@ -99,7 +132,7 @@ func (swagger *MgwSwagger) SetInfoAsyncAPI(asyncAPI AsyncAPI) error {
 		}
 	}
 	if asyncAPI.Servers.Sandbox.URL != "" {
-		endpoint, err := getWebSocketEndpoint(asyncAPI.Servers.Sandbox.URL)
+		endpoint, err := getEndpoint(asyncAPI.Servers.Sandbox.URL)
 		if err == nil {
 			sandboxEndpoints := append([]Endpoint{}, *endpoint)
 			swagger.sandboxEndpoints = generateEndpointCluster(constants.SandClustersConfigNamePrefix,
@@ -163,6 +196,60 @@ func (asyncAPI AsyncAPI) getResources() []*Resource {
 	return SortResources(resources)
 }
 
+// setWebSubSignature sets the signature of the events of a WebSub API, with the defaults of the control plane for
+// the algorithm and the header.
+func (swagger *MgwSwagger) setWebSubSignature(subscriptionConfig WebSubSubscriptionConfiguration) {
+	signature := &WebSubSignature{
+		Secret:           subscriptionConfig.Secret,
+		SigningAlgorithm: strings.ToUpper(strings.TrimSpace(subscriptionConfig.SigningAlgorithm)),
+		SignatureHeader:  strings.ToLower(strings.TrimSpace(subscriptionConfig.SignatureHeader)),
+	}
+	if signature.SigningAlgorithm == "" {
+		signature.SigningAlgorithm = defaultWebSubSigningAlgorithm
+	}
+	if signature.SignatureHeader == "" {
+		signature.SignatureHeader = defaultWebSubSignatureHeader
+	}
+	swagger.webSubSignature = signature
+}
+
+// validateWebSubSignature confirms that the events of a WebSub API are signed, as the events receiver accepts the
+// events without tokens.
+func (swagger *MgwSwagger) validateWebSubSignature() error {
+	signature := swagger.webSubSignature
+	if signature == nil || signature.Secret == "" {
+		return errors.New("the secret of the hub (websubSubscriptionConfiguration) is required for a WebSub API, " +
+			"as the events are verified with the signature")
+	}
+	if !arrayContains(webSubSigningAlgorithms, signature.SigningAlgorithm) {
+		return fmt.Errorf("signing algorithm %q of the WebSub API is not one of %s", signature.SigningAlgorithm,
+			strings.Join(webSubSigningAlgorithms, ", "))
+	}
+	return nil
+}
+
+func (asyncAPI AsyncAPI) getTopics() []string {
+	topics := make([]string, 0, len(asyncAPI.Channels))
+	for channel := range asyncAPI.Channels {
+		topics = append(topics, channel)
+	}
+	sort.Strings(topics)
+	return topics
+}
+
+// getWebSubHubResources returns the resources of the hub of a WebSub API. The subscribers register their
+// callbacks via the hub resource while the publishers deliver the events to the events receiver resource.
+// The events receiver is not secured with tokens as the enforcer verifies the signature of the events instead.
+func getWebSubHubResources() []*Resource {
+	hubOperation := NewOperation("POST", nil, map[string]interface{}{})
+	hubResource := unmarshalSwaggerResources(constants.WebSubHubResource, []*Operation{hubOperation},
+		map[string]interface{}{})
+	receiverOperation := NewOperation("POST", nil, map[string]interface{}{constants.XWso2DisableSecurity: true})
+	receiverResource := unmarshalSwaggerResources(constants.WebSubEventsReceiverResource, []*Operation{receiverOperation},
+		map[string]interface{}{})
+	return SortResources([]*Resource{&hubResource, &receiverResource})
+}
+
 func populatePoliciesFromVendorExtensions(operation *Operation, vendorExtensions map[string]interface{}) {
 	var newResourcePath string
 	policyParameters := make(map[string]interface{})
@@ -23,6 +23,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/wso2/product-microgateway/adapter/config"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/constants"
 	"github.com/wso2/product-microgateway/adapter/internal/oasparser/utills"
 )
 
@@ -113,3 +114,48 @@ func TestSetInfoAsyncAPI(t *testing.T) {
 	assert.Equal(t, len(dataItem.expected.resources[0].methods), 1,
 		"AsyncAPI MgwSwagger resource has more that one method")
 }
+
+func TestSetInfoAsyncAPIForWebSub(t *testing.T) {
+	asyncapiFilePath := config.GetMgwHome() + "/../adapter/test-resources/envoycodegen/asyncapi_websocket.yaml"
+	asyncapiByteArr, err := ioutil.ReadFile(asyncapiFilePath)
+	assert.Nil(t, err, "Error while reading file : %v"+asyncapiFilePath)
+	apiJsn, conversionErr := utills.ToJSON(asyncapiByteArr)
+	assert.Nil(t, conversionErr, "YAML to JSON conversion error : %v"+asyncapiFilePath)
+
+	var asyncapi AsyncAPI
+	err = json.Unmarshal(apiJsn, &asyncapi)
+	assert.Nil(t, err, "Error occurred while parsing the AsyncAPI definition")
+	mgwSwagger := MgwSwagger{apiType: constants.WEBSUB}
+	err = mgwSwagger.SetInfoAsyncAPI(asyncapi)
+	assert.Nil(t, err, "Error while populating the MgwSwagger object for WebSub APIs")
+
+	assert.Equal(t, []string{"/notifications", "/rooms/{roomID}"}, mgwSwagger.GetWebSubTopics(),
+		"WebSub topics should be the channels of the definition")
+	assert.Nil(t, mgwSwagger.productionEndpoints, "WebSub hub endpoint should not be taken from the servers")
+	assert.Len(t, mgwSwagger.resources, 2, "WebSub API should have the hub and the events receiver resources")
+	assert.Equal(t, constants.WebSubHubResource, mgwSwagger.resources[0].path)
+	assert.Equal(t, "POST", mgwSwagger.resources[0].methods[0].method)
+	assert.False(t, mgwSwagger.resources[0].methods[0].disableSecurity, "Hub resource should be secured")
+	assert.Equal(t, constants.WebSubEventsReceiverResource, mgwSwagger.resources[1].path)
+	assert.True(t, mgwSwagger.resources[1].methods[0].disableSecurity,
+		"Events receiver resource should not be secured with tokens")
+}
+
+func TestWebSubSignature(t *testing.T) {
+	var apiYaml APIYaml
+	apiYaml.Data.Name, apiYaml.Data.Version = "Orders", "1.0.0"
+	apiYaml.Data.Context, apiYaml.Data.APIType = "/orders", constants.WEBSUB
+	mgwSwagger := MgwSwagger{}
+	assert.Nil(t, mgwSwagger.PopulateFromAPIYaml(apiYaml))
+	assert.NotNil(t, mgwSwagger.validateWebSubSignature(), "WebSub API is accepted without the secret of the hub")
+
+	apiYaml.Data.WebSubSubscriptionConfig = WebSubSubscriptionConfiguration{Enable: true, Secret: "hub-secret"}
+	assert.Nil(t, mgwSwagger.PopulateFromAPIYaml(apiYaml))
+	assert.Nil(t, mgwSwagger.validateWebSubSignature(), "WebSub API with the secret of the hub is rejected")
+	assert.Equal(t, &WebSubSignature{Secret: "hub-secret", SigningAlgorithm: "SHA1",
+		SignatureHeader: "x-hub-signature"}, mgwSwagger.GetWebSubSignature(), "Default signature mismatch")
+
+	apiYaml.Data.WebSubSubscriptionConfig.SigningAlgorithm = "md5"
+	assert.Nil(t, mgwSwagger.PopulateFromAPIYaml(apiYaml))
+	assert.NotNil(t, mgwSwagger.validateWebSubSignature(), "Unsupported signing algorithm is accepted")
+}
@@ -22,14 +22,20 @@ package model
 
 import (
 	"errors"
+	"fmt"
+	"net"
 	"net/url"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
+	parser "github.com/mitchellh/mapstructure"
+	"github.com/wso2/product-microgateway/adapter/config"
 	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
 	"github.com/wso2/product-microgateway/adapter/internal/oasparser/constants"
+	"github.com/wso2/product-microgateway/adapter/pkg/logging"
 )
 
 func arrayContains(a []string, x string) bool {
@@ -65,6 +71,118 @@ func getXWso2Basepath(vendorExtensions map[string]interface{}) string {
 	return xWso2basepath
 }
 
+// getXWso2DisabledGlobalPolicies extracts the value of x-wso2-disable-global-policies extension. The first
+// value is true if all the global policies are disabled, and the second value contains the names of the
+// disabled policies otherwise.
+func getXWso2DisabledGlobalPolicies(vendorExtensions map[string]interface{}) (bool, []string) {
+	var disabledPolicies []string
+	if y, found := vendorExtensions[constants.XWso2DisableGlobalPolicies]; found {
+		switch val := y.(type) {
+		case bool:
+			return val, nil
+		case []interface{}:
+			for _, policy := range val {
+				if policyName, ok := policy.(string); ok {
+					disabledPolicies = append(disabledPolicies, policyName)
+				}
+			}
+		}
+	}
+	return false, disabledPolicies
+}
+
+// getXWso2RateLimitHeaders extracts the value of x-wso2-rate-limit-headers extension, which is either a boolean or
+// the format of the headers. An empty string is returned if the API uses the format configured for the router.
+func getXWso2RateLimitHeaders(vendorExtensions map[string]interface{}) string {
+	y, found := vendorExtensions[constants.XWso2RateLimitHeaders]
+	if !found {
+		return ""
+	}
+	switch val := y.(type) {
+	case bool:
+		if !val {
+			return constants.RateLimitHeadersDisabled
+		}
+		return ""
+	case string:
+		if val == constants.RateLimitHeadersFormat || val == constants.XRateLimitHeadersFormat {
+			return val
+		}
+	}
+	logger.LoggerOasparser.ErrorC(logging.ErrorDetails{
+		Message: fmt.Sprintf("Invalid value %v of the extension %s, hence the rate limit headers configured for the "+
+			"router are added.", y, constants.XWso2RateLimitHeaders),
+		Severity:  logging.MINOR,
+		ErrorCode: 2245,
+	})
+	return ""
+}
+
+// validateSecurityScheme logs the security schemes which can not be enforced by the gateway. API key security
+// schemes are supported only if the key is sent in a header or a query parameter. The requests to the resources
+// secured only by an unsupported scheme are rejected, hence the scheme is retained.
+func validateSecurityScheme(scheme SecurityScheme) {
+	if scheme.Type != constants.APIKeyTypeInOAS {
+		return
+	}
+	if scheme.Name != "" && (strings.EqualFold(scheme.In, constants.APIKeyInHeaderOAS) ||
+		strings.EqualFold(scheme.In, constants.APIKeyInQueryOAS)) {
+		return
+	}
+	logger.LoggerOasparser.ErrorC(logging.ErrorDetails{
+		Message: fmt.Sprintf("API key security scheme %s is not supported, as the key should be given with a "+
+			"name in a header or a query parameter. name: %q, in: %q", scheme.DefinitionName, scheme.Name, scheme.In),
+		Severity:  logging.MINOR,
+		ErrorCode: 2244,
+	})
+}
+
+// getXWso2MutualSSL extracts the value of x-wso2-mutual-ssl extension, which is either mandatory or optional.
+// The extension is used when the mutual SSL is not enabled in the api.yaml. If the property is not available or
+// invalid, not_defined is returned.
+func getXWso2MutualSSL(vendorExtensions map[string]interface{}) string {
+	x, found := vendorExtensions[constants.XWso2MutualSSL]
+	if !found {
+		return constants.NotDefined
+	}
+	if val, ok := x.(string); ok {
+		switch strings.ToLower(val) {
+		case constants.Mandatory:
+			return constants.Mandatory
+		case constants.Optional:
+			return constants.Optional
+		}
+	}
+	logger.LoggerOasparser.Errorf("Error while parsing %v. Expected %v or %v.", constants.XWso2MutualSSL,
+		constants.Mandatory, constants.Optional)
+	return constants.NotDefined
+}
+
+// getXWso2StringList extracts the value of an extension which is either a string or a list of strings.
+// If the property is not available, nil is returned.
+func getXWso2StringList(vendorExtensions map[string]interface{}, extensionName string) []string {
+	x, found := vendorExtensions[extensionName]
+	if !found {
+		return nil
+	}
+	switch val := x.(type) {
+	case string:
+		return []string{val}
+	case []interface{}:
+		values := make([]string, 0, len(val))
+		for _, item := range val {
+			if value, ok := item.(string); ok {
+				values = append(values, value)
+			} else {
+				logger.LoggerOasparser.Errorf("Error while parsing %v. Expected a list of strings.", extensionName)
+			}
+		}
+		return values
+	}
+	logger.LoggerOasparser.Errorf("Error while parsing %v. Expected a string or a list of strings.", extensionName)
+	return nil
+}
+
 // getXWso2HTTP2BackendEnabled extracts the value of XWso2HTTP2BackendEnabled extension.
 // if the property is not available, false is returned.
 func getXWso2HTTP2BackendEnabled(vendorExtensions map[string]interface{}) bool {
@@ -95,6 +213,452 @@ func ResolveThrottlingTier(vendorExtensions map[string]interface{}) string {
 	return xTier
 }
 
+// ResolveStreamingConfig extracts the value of x-wso2-streaming extension. The extension can be provided
+// either as a boolean or as an object with the enabled and idleTimeoutInSeconds properties.
+// If the property is not available, nil is returned.
+func ResolveStreamingConfig(vendorExtensions map[string]interface{}) *StreamingConfig {
+	if x, found := vendorExtensions[constants.XWso2Streaming]; found {
+		if val, ok := x.(bool); ok {
+			return &StreamingConfig{Enabled: val}
+		}
+		if val, ok := x.(map[string]interface{}); ok {
+			streamingConfig := &StreamingConfig{Enabled: true}
+			if err := parser.Decode(val, streamingConfig); err != nil {
+				logger.LoggerOasparser.Errorf("Error while parsing %v: %v", constants.XWso2Streaming, err.Error())
+				return nil
+			}
+			return streamingConfig
+		}
+		logger.LoggerOasparser.Errorf("Error while parsing %v. Expected a boolean or an object.", constants.XWso2Streaming)
+	}
+	return nil
+}
+
+// ResolveConcurrencyLimits extracts the value of x-wso2-concurrency-limits extension, which is an object with the
+// maxConcurrentRequests and maxConcurrentRequestsPerApplication properties. If the property is not available or
+// invalid, nil is returned.
+func ResolveConcurrencyLimits(vendorExtensions map[string]interface{}) *ConcurrencyLimits {
+	x, found := vendorExtensions[constants.XWso2ConcurrencyLimits]
+	if !found {
+		return nil
+	}
+	val, ok := x.(map[string]interface{})
+	var limits ConcurrencyLimits
+	var err error
+	if !ok {
+		err = errors.New("expected an object")
+	} else {
+		err = parser.Decode(val, &limits)
+	}
+	if err != nil {
+		logger.LoggerOasparser.ErrorC(logging.ErrorDetails{
+			Message: fmt.Sprintf("Error while parsing %v, hence the default concurrency limits are applied. %v",
+				constants.XWso2ConcurrencyLimits, err),
+			Severity:  logging.MINOR,
+			ErrorCode: 2246,
+		})
+		return nil
+	}
+	return &limits
+}
+
+// ResolvePayloadLimits extracts the value of x-wso2-payload-limits extension, which is an object with the
+// maxRequestBodySizeInBytes, maxBufferSizeInBytes and passthrough properties. The properties not provided are
+// inherited from the given limits. If the property is not available or invalid, nil is returned.
+func ResolvePayloadLimits(vendorExtensions map[string]interface{}, inherited *PayloadLimits) *PayloadLimits {
+	x, found := vendorExtensions[constants.XWso2PayloadLimits]
+	if !found {
+		return nil
+	}
+	val, ok := x.(map[string]interface{})
+	var limits PayloadLimits
+	if inherited != nil {
+		limits = *inherited
+	}
+	var err error
+	if !ok {
+		err = errors.New("expected an object")
+	} else {
+		err = parser.Decode(val, &limits)
+	}
+	if err != nil {
+		logger.LoggerOasparser.ErrorC(logging.ErrorDetails{
+			Message: fmt.Sprintf("Error while parsing %v, hence the inherited payload limits are applied. %v",
+				constants.XWso2PayloadLimits, err),
+			Severity:  logging.MINOR,
+			ErrorCode: 2247,
+		})
+		return nil
+	}
+	return &limits
+}
+
+// ResolveTimeouts extracts the value of x-wso2-timeouts extension, which is an object with the
+// connectTimeoutInMillis and routeTimeoutInMillis properties. The properties not provided are inherited from the
+// given timeouts. If the property is not available or invalid, nil is returned.
+func ResolveTimeouts(vendorExtensions map[string]interface{}, inherited *Timeouts) *Timeouts {
+	x, found := vendorExtensions[constants.XWso2Timeouts]
+	if !found {
+		return nil
+	}
+	val, ok := x.(map[string]interface{})
+	var timeouts Timeouts
+	if inherited != nil {
+		timeouts = *inherited
+	}
+	var err error
+	if !ok {
+		err = errors.New("expected an object")
+	} else {
+		err = parser.Decode(val, &timeouts)
+	}
+	if err != nil {
+		logger.LoggerOasparser.ErrorC(logging.ErrorDetails{
+			Message: fmt.Sprintf("Error while parsing %v, hence the inherited timeouts are applied. %v",
+				constants.XWso2Timeouts, err),
+			Severity:  logging.MINOR,
+			ErrorCode: 2255,
+		})
+		return nil
+	}
+	conf, _ := config.ReadConfigs()
+	maxTimeoutInMillis := conf.Envoy.Upstream.Timeouts.MaxRouteTimeoutInSeconds * 1000
+	if timeouts.RouteTimeoutInMillis > maxTimeoutInMillis {
+		logger.LoggerOasparser.Warnf("Route timeout %v of %v exceeds the maximum route timeout. Reconfiguring the "+
+			"route timeout as %v", timeouts.RouteTimeoutInMillis, constants.XWso2Timeouts, maxTimeoutInMillis)
+		timeouts.RouteTimeoutInMillis = maxTimeoutInMillis
+	}
+	return &timeouts
+}
+
+// ResolveRetryBudget extracts the value of x-wso2-retry-budget extension, which is an object with the budgetPercent
+// and minRetryConcurrency properties. The properties not provided are inherited from the given budget. If the
+// property is not available or invalid, nil is returned.
+func ResolveRetryBudget(vendorExtensions map[string]interface{}, inherited *RetryBudget) *RetryBudget {
+	x, found := vendorExtensions[constants.XWso2RetryBudget]
+	if !found {
+		return nil
+	}
+	val, ok := x.(map[string]interface{})
+	var budget RetryBudget
+	if inherited != nil {
+		budget = *inherited
+	}
+	var err error
+	if !ok {
+		err = errors.New("expected an object")
+	} else if err = parser.Decode(val, &budget); err == nil &&
+		(budget.BudgetPercent < 0 || budget.BudgetPercent > 100) {
+		err = fmt.Errorf("budgetPercent %v is not within the range 0 - 100", budget.BudgetPercent)
+	}
+	if err != nil {
+		logger.LoggerOasparser.ErrorC(logging.ErrorDetails{
+			Message: fmt.Sprintf("Error while parsing %v, hence the inherited retry budget is applied. %v",
+				constants.XWso2RetryBudget, err),
+			Severity:  logging.MINOR,
+			ErrorCode: 2256,
+		})
+		return nil
+	}
+	return &budget
+}
+
+// ResolveIPRestriction extracts the value of x-wso2-ip-restriction extension, which is an object with the allow and
+// deny lists of CIDRs. The IPs in the lists are converted to CIDRs. Nil is returned if the property is not available
+// or both lists are empty.
+func ResolveIPRestriction(vendorExtensions map[string]interface{}) (*IPRestriction, error) {
+	x, found := vendorExtensions[constants.XWso2IPRestriction]
+	if !found {
+		return nil, nil
+	}
+	val, ok := x.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("expected an object")
+	}
+	var restriction IPRestriction
+	if err := parser.Decode(val, &restriction); err != nil {
+		return nil, err
+	}
+	var err error
+	if restriction.Allow, err = toCIDRs(restriction.Allow); err != nil {
+		return nil, err
+	}
+	if restriction.Deny, err = toCIDRs(restriction.Deny); err != nil {
+		return nil, err
+	}
+	if len(restriction.Allow) == 0 && len(restriction.Deny) == 0 {
+		return nil, nil
+	}
+	return &restriction, nil
+}
+
+// toCIDRs validates the given CIDRs, converting the IPs to CIDRs of a single address.
+func toCIDRs(values []string) ([]string, error) {
+	cidrs := make([]string, 0, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if ip := net.ParseIP(value); ip != nil {
+			if ip.To4() != nil {
+				value += "/32"
+			} else {
+				value += "/128"
+			}
+		}
+		_, ipNet, err := net.ParseCIDR(value)
+		if err != nil {
+			return nil, fmt.Errorf("%q is neither an IP nor a CIDR", value)
+		}
+		cidrs = append(cidrs, ipNet.String())
+	}
+	return cidrs, nil
+}
+
+// ResolveBackendJWT extracts the value of x-wso2-backend-jwt extension, which is an object with the enabled, header,
+// signingAlgorithm, enableUserClaims and applicationAttributes properties. The properties not provided are
+// inherited from the given backend JWT. If the property is not available or invalid, nil is returned.
+func ResolveBackendJWT(vendorExtensions map[string]interface{}, inherited *BackendJWT) *BackendJWT {
+	x, found := vendorExtensions[constants.XWso2BackendJWT]
+	if !found {
+		return nil
+	}
+	val, ok := x.(map[string]interface{})
+	var backendJWT BackendJWT
+	if inherited != nil {
+		backendJWT = *inherited
+	}
+	var err error
+	if !ok {
+		err = errors.New("expected an object")
+	} else {
+		err = parser.Decode(val, &backendJWT)
+	}
+	if err == nil && backendJWT.Enabled && backendJWT.Header == "" {
+		err = errors.New("header of the JWT is not provided")
+	}
+	if err != nil {
+		logger.LoggerOasparser.ErrorC(logging.ErrorDetails{
+			Message: fmt.Sprintf("Error while parsing %v, hence the inherited backend JWT settings are applied. %v",
+				constants.XWso2BackendJWT, err),
+			Severity:  logging.MINOR,
+			ErrorCode: 2254,
+		})
+		return nil
+	}
+	return &backendJWT
+}
+
+// ResolveResponseCompression extracts the value of x-wso2-response-compression extension, which is an object with
+// the enabled and profile properties. The properties not provided are inherited from the given compression, and the
+// compression is enabled if not inherited. If the property is not available or invalid, nil is returned.
+func ResolveResponseCompression(vendorExtensions map[string]interface{},
+	inherited *ResponseCompression) *ResponseCompression {
+	x, found := vendorExtensions[constants.XWso2ResponseCompression]
+	if !found {
+		return nil
+	}
+	val, ok := x.(map[string]interface{})
+	compression := ResponseCompression{Enabled: true}
+	if inherited != nil {
+		compression = *inherited
+	}
+	var err error
+	if !ok {
+		err = errors.New("expected an object")
+	} else {
+		err = parser.Decode(val, &compression)
+	}
+	if err != nil {
+		logger.LoggerOasparser.ErrorC(logging.ErrorDetails{
+			Message: fmt.Sprintf("Error while parsing %v, hence the inherited response compression is applied. %v",
+				constants.XWso2ResponseCompression, err),
+			Severity:  logging.MINOR,
+			ErrorCode: 2250,
+		})
+		return nil
+	}
+	return &compression
+}
+
+// ResolveResponseCache extracts the value of x-wso2-response-cache extension, which is an object with the enabled,
+// ttlInSeconds, keyHeaders and bypassHeaders properties. The properties not provided are inherited from the given
+// cache, and the caching is enabled if not inherited. If the property is not available or invalid, nil is returned.
+func ResolveResponseCache(vendorExtensions map[string]interface{}, inherited *ResponseCache) *ResponseCache {
+	x, found := vendorExtensions[constants.XWso2ResponseCache]
+	if !found {
+		return nil
+	}
+	val, ok := x.(map[string]interface{})
+	cache := ResponseCache{Enabled: true}
+	if inherited != nil {
+		cache = *inherited
+	}
+	var err error
+	if !ok {
+		err = errors.New("expected an object")
+	} else {
+		err = parser.Decode(val, &cache)
+	}
+	if err != nil {
+		logger.LoggerOasparser.ErrorC(logging.ErrorDetails{
+			Message: fmt.Sprintf("Error while parsing %v, hence the inherited response cache is applied. %v",
+				constants.XWso2ResponseCache, err),
+			Severity:  logging.MINOR,
+			ErrorCode: 2264,
+		})
+		return nil
+	}
+	if cache.TTLInSeconds == 0 {
+		conf, _ := config.ReadConfigs()
+		cache.TTLInSeconds = conf.Envoy.Filters.ResponseCache.DefaultTTLInSeconds
+	}
+	return &cache
+}
+
+// ResolveTrafficMirror extracts the value of x-wso2-traffic-mirror extension, which is an object with the url of the
+// shadow endpoint and the percentage of the requests mirrored (100 by default). Nil is returned if the property is
+// not available.
+func ResolveTrafficMirror(vendorExtensions map[string]interface{}) (*TrafficMirror, error) {
+	x, found := vendorExtensions[constants.XWso2TrafficMirror]
+	if !found {
+		return nil, nil
+	}
+	val, ok := x.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("expected an object")
+	}
+	mirrorConfig := struct {
+		URL        string  `mapstructure:"url"`
+		Percentage float64 `mapstructure:"percentage"`
+	}{Percentage: 100}
+	if err := parser.Decode(val, &mirrorConfig); err != nil {
+		return nil, err
+	}
+	if mirrorConfig.Percentage <= 0 || mirrorConfig.Percentage > 100 {
+		return nil, fmt.Errorf("percentage %v is not within (0, 100]", mirrorConfig.Percentage)
+	}
+	if mirrorConfig.URL == "" {
+		return nil, errors.New("url of the shadow endpoint is not provided")
+	}
+	endpoint, err := getHTTPEndpoint(mirrorConfig.URL)
+	if err != nil {
+		return nil, err
+	}
+	return &TrafficMirror{
+		Endpoint: &EndpointCluster{
+			EndpointPrefix: constants.MirrorClustersConfigNamePrefix,
+			Endpoints:      []Endpoint{*endpoint},
+		},
+		Percentage: mirrorConfig.Percentage,
+	}, nil
+}
+
+// ResolveGRPCTranscoding extracts the value of x-wso2-grpc-transcoding extension, which is an object with the
+// services transcoded. Nil is returned if the property is not available.
+func ResolveGRPCTranscoding(vendorExtensions map[string]interface{}) (*GRPCTranscoding, error) {
+	x, found := vendorExtensions[constants.XWso2GRPCTranscoding]
+	if !found {
+		return nil, nil
+	}
+	val, ok := x.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("expected an object")
+	}
+	var transcoding GRPCTranscoding
+	if err := parser.Decode(val, &transcoding); err != nil {
+		return nil, err
+	}
+	if len(transcoding.Services) == 0 {
+		return nil, errors.New("services are not provided")
+	}
+	return &transcoding, nil
+}
+
+// ResolveGRPCMethod extracts the value of x-wso2-grpc-method extension of an operation, which is the gRPC method
+// the operation is mapped to, in the form <package>.<service>/<method>. The service and the method are returned.
+// Empty strings are returned if the property is not available.
+func ResolveGRPCMethod(vendorExtensions map[string]interface{}) (string, string, error) {
+	x, found := vendorExtensions[constants.XWso2GRPCMethod]
+	if !found {
+		return "", "", nil
+	}
+	val, ok := x.(string)
+	if !ok {
+		return "", "", errors.New("expected a string")
+	}
+	sepIndex := strings.LastIndex(val, "/")
+	if sepIndex <= 0 || sepIndex == len(val)-1 {
+		return "", "", fmt.Errorf("%q is not in the form <package>.<service>/<method>", val)
+	}
+	return strings.TrimPrefix(val[:sepIndex], "/"), val[sepIndex+1:], nil
+}
+
+// ResolveDeprecationConfig extracts the value of x-wso2-deprecation extension. The extension can be provided
+// either as a boolean or as an object with the deprecatedAt, sunsetAt (RFC3339 timestamps or dates),
+// link and successorLink properties. If the property is not available or invalid, nil is returned.
+func ResolveDeprecationConfig(vendorExtensions map[string]interface{}) *DeprecationConfig {
+	x, found := vendorExtensions[constants.XWso2Deprecation]
+	if !found {
+		return nil
+	}
+	if val, ok := x.(bool); ok {
+		if val {
+			return &DeprecationConfig{}
+		}
+		return nil
+	}
+	val, ok := x.(map[string]interface{})
+	if !ok {
+		logDeprecationError(errors.New("expected a boolean or an object"))
+		return nil
+	}
+	var extension struct {
+		DeprecatedAt  string `mapstructure:"deprecatedAt"`
+		SunsetAt      string `mapstructure:"sunsetAt"`
+		Link          string `mapstructure:"link"`
+		SuccessorLink string `mapstructure:"successorLink"`
+	}
+	if err := parser.Decode(val, &extension); err != nil {
+		logDeprecationError(err)
+		return nil
+	}
+	deprecationConfig := &DeprecationConfig{Link: extension.Link, SuccessorLink: extension.SuccessorLink}
+	var err error
+	if deprecationConfig.DeprecatedAt, err = parseDeprecationTime(extension.DeprecatedAt); err != nil {
+		logDeprecationError(fmt.Errorf("invalid deprecatedAt. %v", err))
+		return nil
+	}
+	if deprecationConfig.SunsetAt, err = parseDeprecationTime(extension.SunsetAt); err != nil {
+		logDeprecationError(fmt.Errorf("invalid sunsetAt. %v", err))
+		return nil
+	}
+	if !deprecationConfig.DeprecatedAt.IsZero() && !deprecationConfig.SunsetAt.IsZero() &&
+		deprecationConfig.SunsetAt.Before(deprecationConfig.DeprecatedAt) {
+		logDeprecationError(errors.New("sunsetAt is before deprecatedAt"))
+		return nil
+	}
+	return deprecationConfig
+}
+
+// parseDeprecationTime parses a RFC3339 timestamp or a date (in UTC). Zero time is returned for an empty value.
+func parseDeprecationTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.UTC(), nil
+	}
+	return time.Parse("2006-01-02", value)
+}
+
+func logDeprecationError(err error) {
+	logger.LoggerOasparser.ErrorC(logging.ErrorDetails{
+		Message: fmt.Sprintf("Error while parsing %v, hence the API is not advertised as deprecated. %v",
+			constants.XWso2Deprecation, err),
+		Severity:  logging.MINOR,
+		ErrorCode: 2243,
+	})
+}
+
 // ResolveAmznResourceName extracts the value of x-amzn-resource-name extension.
 // If the property is not availble, an empty string is returned.
 func ResolveAmznResourceName(vendorExtensions map[string]interface{}) string {
@@ -94,14 +94,7 @@ func (swagger *MgwSwagger) SetInfoGraphQLAPI(apiYaml APIYaml) error {
 	}
 	swagger.resources = resources
 
-	var corsConfig *CorsConfig = generateGlobalCors()
-	if apiYaml.Data.CorsConfiguration.CorsConfigurationEnabled == true {
-		corsConfig.AccessControlAllowOrigins = apiYaml.Data.CorsConfiguration.AccessControlAllowOrigins
-		corsConfig.AccessControlAllowCredentials = apiYaml.Data.CorsConfiguration.AccessControlAllowCredentials
-		corsConfig.AccessControlAllowHeaders = apiYaml.Data.CorsConfiguration.AccessControlAllowHeaders
-		corsConfig.AccessControlAllowMethods = apiYaml.Data.CorsConfiguration.AccessControlAllowMethods
-	}
-	swagger.xWso2Cors = corsConfig
+	swagger.xWso2Cors = generateAPIYamlCors(apiYaml.Data.CorsConfiguration)
 
 	// enables request body passing feature for GraphQL APIs
 	swagger.xWso2RequestBodyPass = true
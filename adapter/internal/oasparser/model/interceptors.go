@@ -0,0 +1,136 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package model
+
+import (
+	"fmt"
+
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/constants"
+)
+
+// InterceptorsYaml represents the content of the interceptors.yaml file of an API project, which declares the
+// interceptor services of the API and its resources without changing the API definition.
+type InterceptorsYaml struct {
+	Type    string `yaml:"type" json:"type"`
+	Version string `yaml:"version" json:"version"`
+	Data    struct {
+		// Request and Response are the interceptor services of all the resources of the API
+		Request   *InterceptorService   `json:"request"`
+		Response  *InterceptorService   `json:"response"`
+		Resources []ResourceInterceptor `json:"resources"`
+	} `json:"data"`
+}
+
+// ResourceInterceptor represents the interceptor services of a resource, which override the interceptor services
+// of the API.
+type ResourceInterceptor struct {
+	// Target is the path of the resource in the API definition
+	Target   string              `json:"target"`
+	Request  *InterceptorService `json:"request"`
+	Response *InterceptorService `json:"response"`
+}
+
+// InterceptorService represents an interceptor service, which has the same properties as the
+// x-wso2-request-interceptor and x-wso2-response-interceptor extensions.
+type InterceptorService struct {
+	ServiceURL string `json:"serviceURL"`
+	// Includes are the parts of the request and the response sent to the interceptor service (ie: request_headers,
+	// request_body, invocation_context)
+	Includes       []string `json:"includes"`
+	ClusterTimeout int      `json:"clusterTimeout"`
+	RequestTimeout int      `json:"requestTimeout"`
+	// Certificate is the file in the Endpoint-certificates/interceptors directory, which is trusted when
+	// connecting to the service. All the interceptor certificates of the project are trusted if it is not given.
+	Certificate string `json:"certificate"`
+}
+
+// SetInterceptors sets the interceptor services declared in the interceptors.yaml file of the API project as the
+// interceptor extensions of the API and its resources. The declared interceptor services override the
+// extensions of the API definition.
+func (swagger *MgwSwagger) SetInterceptors(interceptors InterceptorsYaml) error {
+	if swagger.vendorExtensions == nil {
+		swagger.vendorExtensions = make(map[string]interface{})
+	}
+	setInterceptorExtension(swagger.vendorExtensions, constants.XWso2RequestInterceptor, interceptors.Data.Request)
+	setInterceptorExtension(swagger.vendorExtensions, constants.XWso2ResponseInterceptor, interceptors.Data.Response)
+	for _, resourceInterceptor := range interceptors.Data.Resources {
+		var matchedResource *Resource
+		for _, resource := range swagger.resources {
+			if resource.path == resourceInterceptor.Target {
+				matchedResource = resource
+				break
+			}
+		}
+		if matchedResource == nil {
+			return fmt.Errorf("resource %q of the interceptors is not found in the API definition",
+				resourceInterceptor.Target)
+		}
+		if matchedResource.vendorExtensions == nil {
+			matchedResource.vendorExtensions = make(map[string]interface{})
+		}
+		setInterceptorExtension(matchedResource.vendorExtensions, constants.XWso2RequestInterceptor,
+			resourceInterceptor.Request)
+		setInterceptorExtension(matchedResource.vendorExtensions, constants.XWso2ResponseInterceptor,
+			resourceInterceptor.Response)
+	}
+	return nil
+}
+
+// GetInterceptorCertificates returns the certificate files of the interceptor services, by the service URL.
+func (interceptors InterceptorsYaml) GetInterceptorCertificates() map[string]string {
+	certificates := make(map[string]string)
+	services := []*InterceptorService{interceptors.Data.Request, interceptors.Data.Response}
+	for _, resourceInterceptor := range interceptors.Data.Resources {
+		services = append(services, resourceInterceptor.Request, resourceInterceptor.Response)
+	}
+	for _, service := range services {
+		if service != nil && service.Certificate != "" {
+			certificates[service.ServiceURL] = service.Certificate
+		}
+	}
+	return certificates
+}
+
+// setInterceptorExtension sets the interceptor service in the format of the interceptor extensions, which are
+// read by GetInterceptor.
+func setInterceptorExtension(vendorExtensions map[string]interface{}, extensionName string,
+	service *InterceptorService) {
+	if service == nil {
+		return
+	}
+	if _, found := vendorExtensions[extensionName]; found {
+		logger.LoggerOasparser.Infof("%s extension of the API definition is overridden by the interceptors of the "+
+			"API project", extensionName)
+	}
+	extension := map[string]interface{}{constants.ServiceURL: service.ServiceURL}
+	if len(service.Includes) > 0 {
+		includes := make([]interface{}, 0, len(service.Includes))
+		for _, include := range service.Includes {
+			includes = append(includes, include)
+		}
+		extension[constants.Includes] = includes
+	}
+	if service.ClusterTimeout > 0 {
+		extension[constants.ClusterTimeout] = service.ClusterTimeout
+	}
+	if service.RequestTimeout > 0 {
+		extension[constants.RequestTimeout] = service.RequestTimeout
+	}
+	vendorExtensions[extensionName] = extension
+}
@@ -56,9 +56,33 @@ type MgwSwagger struct {
 	sandboxEndpoints           *EndpointCluster
 	xWso2Endpoints             map[string]*EndpointCluster
 	resources                  []*Resource
+	webSubTopics               []string
+	webSubSignature            *WebSubSignature
+	soapVersions               []string
 	xWso2Basepath              string
 	xWso2HTTP2BackendEnabled   bool
 	xWso2Cors                  *CorsConfig
+	xWso2Streaming             *StreamingConfig
+	xWso2Deprecation           *DeprecationConfig
+	allowedIssuers             []string
+	allowedAudiences           []string
+	backendJWT                 *BackendJWT
+	subscriptionValidation     *bool
+	grpcTranscoding            *GRPCTranscoding
+	disableGlobalPolicies      bool
+	disabledGlobalPolicies     []string
+	rateLimitHeadersFormat     string
+	concurrencyLimits          *ConcurrencyLimits
+	payloadLimits              *PayloadLimits
+	timeouts                   *Timeouts
+	retryBudget                *RetryBudget
+	responseCompression        *ResponseCompression
+	responseCache              *ResponseCache
+	trafficMirror              *TrafficMirror
+	ipRestriction              *IPRestriction
+	revisionTrafficSplit       *RevisionTrafficSplit
+	apiDocs                    map[string][]byte
+	upstreamClientCerts        map[string]UpstreamClientCert
 	securityScheme             []SecurityScheme
 	security                   []map[string][]string
 	xWso2ThrottlingTier        string
@@ -109,7 +133,14 @@ type Endpoint struct {
 	Port uint32
 	//ServiceDiscoveryQuery consul query for service discovery
 	ServiceDiscoveryString string
-	RawURL                 string
+	// ServiceDiscoveryRegistry is the service registry of the ServiceDiscoveryString (consul or eureka)
+	ServiceDiscoveryRegistry string
+	RawURL                   string
+	// Revision of the API, which the endpoint belongs to. This is only populated when the traffic is split
+	// between two revisions of the API.
+	Revision string
+	// LbWeight is the load balancing weight of the endpoint. 0 means the weight is not set.
+	LbWeight uint32
 }
 
 // EndpointConfig holds the configs such as timeout, retry, etc. for the EndpointCluster
@@ -117,12 +148,19 @@ type EndpointConfig struct {
 	RetryConfig     *RetryConfig     `mapstructure:"retryConfig"`
 	TimeoutInMillis uint32           `mapstructure:"timeoutInMillis"`
 	CircuitBreakers *CircuitBreakers `mapstructure:"circuitBreakers"`
+	// LbAlgorithm enum {round_robin, least_request}. Applicable only for the load balanced endpoints.
+	LbAlgorithm      string            `mapstructure:"lbAlgorithm"`
+	HealthCheck      *HealthCheck      `mapstructure:"healthCheck"`
+	OutlierDetection *OutlierDetection `mapstructure:"outlierDetection"`
 }
 
 // RetryConfig holds the parameters for retries done by cc to the EndpointCluster
 type RetryConfig struct {
 	Count       int32    `mapstructure:"count"`
 	StatusCodes []uint32 `mapstructure:"statusCodes"`
+	// PerTryTimeoutInMillis is the timeout of each attempt, including the first request. 0 means the route
+	// timeout applies to all the attempts together. Only the per try timeout of the production endpoints is applied.
+	PerTryTimeoutInMillis uint32 `mapstructure:"perTryTimeoutInMillis"`
 }
 
 // CircuitBreakers holds the parameters for retries done by cc to the EndpointCluster
@@ -134,6 +172,33 @@ type CircuitBreakers struct {
 	MaxConnectionPools int32 `mapstructure:"maxConnectionPools"`
 }
 
+// UpstreamClientCert holds the certificate chain and the private key (PEM encoded) presented to the
+// endpoints for mutual TLS.
+type UpstreamClientCert struct {
+	Certificate []byte
+	Key         []byte
+}
+
+// HealthCheck holds the parameters for the active health checks done by cc to the endpoints of the
+// EndpointCluster. The values not provided are taken from the upstream health check configuration.
+type HealthCheck struct {
+	// Path to send the HTTP health check requests. If not provided, a TCP health check is done.
+	Path               string `mapstructure:"path"`
+	TimeoutInSeconds   int32  `mapstructure:"timeoutInSeconds"`
+	IntervalInSeconds  int32  `mapstructure:"intervalInSeconds"`
+	UnhealthyThreshold int32  `mapstructure:"unhealthyThreshold"`
+	HealthyThreshold   int32  `mapstructure:"healthyThreshold"`
+}
+
+// OutlierDetection holds the parameters for ejecting the endpoints of the EndpointCluster, which
+// continuously fail to serve the requests.
+type OutlierDetection struct {
+	Consecutive5xx            int32 `mapstructure:"consecutive5xx"`
+	IntervalInSeconds         int32 `mapstructure:"intervalInSeconds"`
+	BaseEjectionTimeInSeconds int32 `mapstructure:"baseEjectionTimeInSeconds"`
+	MaxEjectionPercent        int32 `mapstructure:"maxEjectionPercent"`
+}
+
 // SecurityScheme represents the structure of an security scheme.
 type SecurityScheme struct {
 	DefinitionName string // Arbitrary name used to define the security scheme. ex: default, myApikey
@@ -152,6 +217,132 @@ type CorsConfig struct {
 	AccessControlExposeHeaders    []string `mapstructure:"accessControlExposeHeaders"`
 }
 
+// StreamingConfig represents the configuration of long lived streaming APIs (ie: Server-Sent Events).
+// Route timeouts are disabled for such APIs and the idle timeout is applied instead.
+type StreamingConfig struct {
+	Enabled              bool   `mapstructure:"enabled"`
+	IdleTimeoutInSeconds uint32 `mapstructure:"idleTimeoutInSeconds"`
+}
+
+// DeprecationConfig represents the deprecation of an API version, which is advertised to the consumers with
+// the Deprecation, Sunset and Link response headers.
+type DeprecationConfig struct {
+	// DeprecatedAt is the time the version is deprecated. Zero if it is only known that the version is deprecated.
+	DeprecatedAt time.Time
+	// SunsetAt is the time the version is expected to become unresponsive. Zero if it is not planned.
+	SunsetAt time.Time
+	// Link is the URL of the documentation about the deprecation
+	Link string
+	// SuccessorLink is the URL of the version which replaces the deprecated version
+	SuccessorLink string
+}
+
+// ConcurrencyLimits represents the limits of the in-flight requests of an API. The API level limit is enforced
+// by the router for each upstream cluster of the API, while the application level limit is enforced by the enforcer.
+// 0 means unlimited.
+type ConcurrencyLimits struct {
+	MaxConcurrentRequests               uint32 `mapstructure:"maxConcurrentRequests"`
+	MaxConcurrentRequestsPerApplication uint32 `mapstructure:"maxConcurrentRequestsPerApplication"`
+}
+
+// PayloadLimits represents the limits of the request and response payloads of an API or a resource.
+type PayloadLimits struct {
+	// MaxRequestBodySizeInBytes rejects the requests with larger bodies. The request bodies are buffered by the router
+	// to enforce the limit. 0 means unlimited.
+	MaxRequestBodySizeInBytes uint32 `mapstructure:"maxRequestBodySizeInBytes"`
+	// MaxBufferSizeInBytes is the maximum size of the request and response bodies buffered by the router for a
+	// request. 0 means the router default.
+	MaxBufferSizeInBytes uint32 `mapstructure:"maxBufferSizeInBytes"`
+	// Passthrough streams the request bodies to the backend without buffering them. The request bodies are
+	// neither passed to the enforcer nor wire logged, and the MaxRequestBodySizeInBytes is not enforced.
+	Passthrough bool `mapstructure:"passthrough"`
+}
+
+// Timeouts represents the timeouts of the requests to the upstream endpoints of an API or a resource. 0 means the
+// timeout configured for the router.
+type Timeouts struct {
+	// ConnectTimeoutInMillis is the timeout of establishing a connection with an upstream endpoint. It is applied
+	// to the clusters of the API or the resource.
+	ConnectTimeoutInMillis uint32 `mapstructure:"connectTimeoutInMillis"`
+	// RouteTimeoutInMillis is the timeout of receiving the complete response of a request, including the retries.
+	// The timeout of the endpoint configuration takes precedence, if provided.
+	RouteTimeoutInMillis uint32 `mapstructure:"routeTimeoutInMillis"`
+}
+
+// RetryBudget limits the concurrent retries to the upstream endpoints of an API or a resource, as a percentage of
+// the active requests. The budget is enforced by the router for each upstream cluster of the API.
+type RetryBudget struct {
+	// BudgetPercent is the percentage of the active requests which can be retried concurrently
+	BudgetPercent float64 `mapstructure:"budgetPercent"`
+	// MinRetryConcurrency is the number of concurrent retries allowed regardless of the active requests
+	MinRetryConcurrency uint32 `mapstructure:"minRetryConcurrency"`
+}
+
+// ResponseCompression represents the compression of the responses of an API or a resource.
+type ResponseCompression struct {
+	// Enabled compresses the responses, even if the response compression of the router is disabled.
+	// The responses are not compressed if false.
+	Enabled bool `mapstructure:"enabled"`
+	// Profile is the name of the compression profile (router.filters.compression.profiles) applied.
+	// The compression of the router is applied if empty.
+	Profile string `mapstructure:"profile"`
+}
+
+// ResponseCache represents the caching of the responses of an API or a resource by the router. Only the responses
+// of the GET requests are cached.
+type ResponseCache struct {
+	// Enabled caches the responses. The responses are not cached if false, even if the backend allows caching.
+	Enabled bool `mapstructure:"enabled"`
+	// TTLInSeconds is the time a response is served from the cache. The default TTL of the router is applied if 0.
+	TTLInSeconds uint32 `mapstructure:"ttlInSeconds"`
+	// KeyHeaders are the request headers the cached responses vary by, in addition to the path and the query.
+	KeyHeaders []string `mapstructure:"keyHeaders"`
+	// BypassHeaders are the request headers, the presence of which bypasses the cache.
+	BypassHeaders []string `mapstructure:"bypassHeaders"`
+}
+
+// TrafficMirror represents the mirroring of the requests of an API to a shadow endpoint (ex: a new version of the
+// backend). The responses of the shadow endpoint are ignored.
+type TrafficMirror struct {
+	// Endpoint receiving the mirrored requests. The requests are mirrored with the path sent to the production
+	// endpoint, hence the basepath of the shadow endpoint is not applied.
+	Endpoint *EndpointCluster
+	// Percentage of the requests mirrored (0 to 100]
+	Percentage float64
+}
+
+// IPRestriction restricts the source IPs of the requests to an API. The requests from the other sources are
+// denied by the router with 403.
+type IPRestriction struct {
+	// Allow is the list of CIDRs (or IPs) allowed to invoke the API. All the sources are allowed if empty.
+	Allow []string `mapstructure:"allow"`
+	// Deny is the list of CIDRs (or IPs) denied, which takes precedence over the allowed CIDRs.
+	Deny []string `mapstructure:"deny"`
+}
+
+// GRPCTranscoding represents the transcoding of the REST requests of an API to the gRPC services of the backend.
+type GRPCTranscoding struct {
+	// Services are the fully qualified names of the transcoded gRPC services (ex: bookstore.Bookstore).
+	Services []string `mapstructure:"services"`
+	// ProtoDescriptor is the proto descriptor set of the services, bundled in the API project.
+	ProtoDescriptor []byte `mapstructure:"-"`
+}
+
+// BackendJWT represents the generation of the JWT sent to the backend of an API, describing the consumer.
+type BackendJWT struct {
+	// Enabled sends the JWT to the backend.
+	Enabled bool `mapstructure:"enabled"`
+	// Header is the name of the header to which the JWT is attached.
+	Header string `mapstructure:"header"`
+	// SigningAlgorithm is the algorithm used to sign the JWT (ex: SHA256withRSA).
+	SigningAlgorithm string `mapstructure:"signingAlgorithm"`
+	// EnableUserClaims includes the claims of the user in the JWT.
+	EnableUserClaims bool `mapstructure:"enableUserClaims"`
+	// ApplicationAttributes are the attributes of the application included as claims in the JWT. All the
+	// attributes are included if empty.
+	ApplicationAttributes []string `mapstructure:"applicationAttributes"`
+}
+
 // InterceptEndpoint contains the parameters of endpoint security
 type InterceptEndpoint struct {
 	Enable          bool
@@ -180,6 +371,65 @@ func (swagger *MgwSwagger) GetCorsConfig() *CorsConfig {
 	return swagger.xWso2Cors
 }
 
+// GetStreamingConfig returns the streaming configuration of the API. Returns nil if the API is not
+// a streaming API.
+func (swagger *MgwSwagger) GetStreamingConfig() *StreamingConfig {
+	return swagger.xWso2Streaming
+}
+
+// GetDeprecationConfig returns the deprecation of the API version. Nil if the version is not deprecated.
+func (swagger *MgwSwagger) GetDeprecationConfig() *DeprecationConfig {
+	return swagger.xWso2Deprecation
+}
+
+// GetAllowedIssuers returns the token issuers accepted by the API. Any issuer configured in the enforcer
+// is accepted if empty.
+func (swagger *MgwSwagger) GetAllowedIssuers() []string {
+	return swagger.allowedIssuers
+}
+
+// GetAllowedAudiences returns the token audiences accepted by the API. The audience is not validated if empty.
+func (swagger *MgwSwagger) GetAllowedAudiences() []string {
+	return swagger.allowedAudiences
+}
+
+// GetBackendJWT returns the generation of the JWT sent to the backend of the API.
+func (swagger *MgwSwagger) GetBackendJWT() *BackendJWT {
+	return swagger.backendJWT
+}
+
+// GetSubscriptionValidation returns whether the subscriptions of the API are validated. The subscription
+// validation of the token issuer is applied if nil.
+func (swagger *MgwSwagger) GetSubscriptionValidation() *bool {
+	return swagger.subscriptionValidation
+}
+
+// SetSubscriptionValidation sets whether the subscriptions of the API are validated, overriding the
+// subscription validation of the API definition.
+func (swagger *MgwSwagger) SetSubscriptionValidation(enabled *bool) {
+	swagger.subscriptionValidation = enabled
+}
+
+// GetResponseCache returns the caching of the responses of the API. Nil if the responses are cached only as allowed
+// by the backend.
+func (swagger *MgwSwagger) GetResponseCache() *ResponseCache {
+	return swagger.responseCache
+}
+
+// GetGRPCTranscoding returns the transcoding of the REST requests of the API to gRPC, which is nil if the
+// requests are not transcoded.
+func (swagger *MgwSwagger) GetGRPCTranscoding() *GRPCTranscoding {
+	return swagger.grpcTranscoding
+}
+
+// SetGRPCProtoDescriptor sets the proto descriptor set bundled in the API project, which is used to transcode the
+// requests of the API.
+func (swagger *MgwSwagger) SetGRPCProtoDescriptor(protoDescriptor []byte) {
+	if swagger.grpcTranscoding != nil {
+		swagger.grpcTranscoding.ProtoDescriptor = protoDescriptor
+	}
+}
+
 // GetAPIType returns the openapi version
 func (swagger *MgwSwagger) GetAPIType() string {
 	return swagger.apiType
@@ -205,6 +455,66 @@ func (swagger *MgwSwagger) GetXWso2HTTP2BackendEnabled() bool {
 	return swagger.xWso2HTTP2BackendEnabled
 }
 
+// IsGlobalPolicyDisabled returns true if the API opts out of the global policy via the vendor extension.
+func (swagger *MgwSwagger) IsGlobalPolicyDisabled(policyName string) bool {
+	if swagger.disableGlobalPolicies {
+		return true
+	}
+	for _, disabledPolicy := range swagger.disabledGlobalPolicies {
+		if disabledPolicy == policyName {
+			return true
+		}
+	}
+	return false
+}
+
+// GetRateLimitHeadersFormat returns the format of the rate limit headers set via the vendor extension, which is
+// empty if the API uses the format configured for the router.
+func (swagger *MgwSwagger) GetRateLimitHeadersFormat() string {
+	return swagger.rateLimitHeadersFormat
+}
+
+// GetConcurrencyLimits returns the limits of the in-flight requests of the API. Nil if the API is not limited.
+func (swagger *MgwSwagger) GetConcurrencyLimits() *ConcurrencyLimits {
+	return swagger.concurrencyLimits
+}
+
+// GetPayloadLimits returns the payload limits of the API. Nil if the payloads of the API are not limited.
+func (swagger *MgwSwagger) GetPayloadLimits() *PayloadLimits {
+	return swagger.payloadLimits
+}
+
+// GetTimeouts returns the upstream timeouts of the API. Nil if the timeouts configured for the router are applied.
+func (swagger *MgwSwagger) GetTimeouts() *Timeouts {
+	return swagger.timeouts
+}
+
+// GetRetryBudget returns the retry budget of the API. Nil if the retries are not limited by a budget.
+func (swagger *MgwSwagger) GetRetryBudget() *RetryBudget {
+	return swagger.retryBudget
+}
+
+// GetResponseCompression returns the response compression of the API. Nil if the compression of the router
+// is applied.
+func (swagger *MgwSwagger) GetResponseCompression() *ResponseCompression {
+	return swagger.responseCompression
+}
+
+// GetTrafficMirror returns the traffic mirror of the API. Nil if the requests are not mirrored.
+func (swagger *MgwSwagger) GetTrafficMirror() *TrafficMirror {
+	return swagger.trafficMirror
+}
+
+// GetIPRestriction returns the source IP restriction of the API. Nil if the API is not restricted.
+func (swagger *MgwSwagger) GetIPRestriction() *IPRestriction {
+	return swagger.ipRestriction
+}
+
+// SetTrafficMirror sets the traffic mirror of the API.
+func (swagger *MgwSwagger) SetTrafficMirror(trafficMirror *TrafficMirror) {
+	swagger.trafficMirror = trafficMirror
+}
+
 // GetVendorExtensions returns the map of vendor extensions which are defined
 // at openAPI's root level.
 func (swagger *MgwSwagger) GetVendorExtensions() map[string]interface{} {
@@ -231,6 +541,16 @@ func (swagger *MgwSwagger) GetResources() []*Resource {
 	return swagger.resources
 }
 
+// GetWebSubTopics returns the topics of a WebSub API, which are the channels of its AsyncAPI definition
+func (swagger *MgwSwagger) GetWebSubTopics() []string {
+	return swagger.webSubTopics
+}
+
+// GetWebSubSignature returns the signature of the events published to the hub of a WebSub API
+func (swagger *MgwSwagger) GetWebSubSignature() *WebSubSignature {
+	return swagger.webSubSignature
+}
+
 // GetDescription returns the description of the openapi
 func (swagger *MgwSwagger) GetDescription() string {
 	return swagger.description
@@ -286,11 +606,10 @@ func (swagger *MgwSwagger) SetSecurityScheme(securityScheme []SecurityScheme) {
 // defined for the same API and would have the structure given below,
 //
 // security:
-//	- PetstoreAuth:
-// 		- 'write:pets'
-//		- 'read:pets'
-//	- ApiKeyAuth: []
-//
+//   - PetstoreAuth:
+//   - 'write:pets'
+//   - 'read:pets'
+//   - ApiKeyAuth: []
 func (swagger *MgwSwagger) SetSecurity(security []map[string][]string) {
 	swagger.security = security
 }
@@ -300,6 +619,27 @@ func (swagger *MgwSwagger) SetVersion(version string) {
 	swagger.version = version
 }
 
+// SetAPIDocs sets the docs included in the API project (<doc name>/<doc file name> -> content)
+func (swagger *MgwSwagger) SetAPIDocs(apiDocs map[string][]byte) {
+	swagger.apiDocs = apiDocs
+}
+
+// GetAPIDocs returns the docs of the API (<doc name>/<doc file name> -> content)
+func (swagger *MgwSwagger) GetAPIDocs() map[string][]byte {
+	return swagger.apiDocs
+}
+
+// SetUpstreamClientCerts sets the client certs presented to the endpoints of the API (endpoint url -> cert).
+// The cert mapped to the key "default" is presented to the endpoints without a specific cert.
+func (swagger *MgwSwagger) SetUpstreamClientCerts(clientCerts map[string]UpstreamClientCert) {
+	swagger.upstreamClientCerts = clientCerts
+}
+
+// GetUpstreamClientCerts returns the client certs presented to the endpoints of the API (endpoint url -> cert)
+func (swagger *MgwSwagger) GetUpstreamClientCerts() map[string]UpstreamClientCert {
+	return swagger.upstreamClientCerts
+}
+
 // SetXWso2AuthHeader sets the authHeader of the API
 func (swagger *MgwSwagger) SetXWso2AuthHeader(authHeader string) {
 	if swagger.xWso2AuthHeader == "" {
@@ -347,8 +687,15 @@ func (swagger *MgwSwagger) GetEndpointType() string {
 	return swagger.EndpointType
 }
 
-// SetOperationPolicies this will merge operation level policies provided in api yaml
+// SetOperationPolicies this will merge operation level policies provided in api yaml.
+// API level policies (ex: an OPA policy authorizing the requests to any resource of the API) are applied to all the
+// operations. Those are applied before the operation level policies in the request flow, and after the operation
+// level policies in the response and fault flows.
 func (swagger *MgwSwagger) SetOperationPolicies(apiProject ProjectAPI) (err error) {
+	apiPolicies, err := apiProject.Policies.GetFormattedOperationalPolicies(apiProject.APIYaml.Data.APIPolicies, swagger)
+	if err != nil {
+		return err
+	}
 	for _, resource := range swagger.resources {
 		path := strings.TrimSuffix(resource.path, "/")
 		for _, operation := range resource.methods {
@@ -359,17 +706,32 @@ func (swagger *MgwSwagger) SetOperationPolicies(apiProject ProjectAPI) (err erro
 					if err != nil {
 						return err
 					}
-					if operation.policies.Request != nil || operation.policies.Response != nil || operation.policies.Fault != nil {
-						resource.hasPolicies = true
-					}
 					break
 				}
 			}
+			operation.policies = mergeAPIPolicies(apiPolicies, operation.policies)
+			if operation.policies.Request != nil || operation.policies.Response != nil || operation.policies.Fault != nil {
+				resource.hasPolicies = true
+			}
 		}
 	}
 	return nil
 }
 
+// mergeAPIPolicies returns the policies of an operation including the API level policies
+func mergeAPIPolicies(apiPolicies, operationPolicies OperationPolicies) OperationPolicies {
+	if len(apiPolicies.Request) > 0 {
+		operationPolicies.Request = append(append(PolicyList{}, apiPolicies.Request...), operationPolicies.Request...)
+	}
+	if len(apiPolicies.Response) > 0 {
+		operationPolicies.Response = append(operationPolicies.Response, apiPolicies.Response...)
+	}
+	if len(apiPolicies.Fault) > 0 {
+		operationPolicies.Fault = append(operationPolicies.Fault, apiPolicies.Fault...)
+	}
+	return operationPolicies
+}
+
 // SanitizeAPISecurity this will validate api level and operation level swagger security
 // if apiyaml security is provided swagger security will be removed accordingly
 func (swagger *MgwSwagger) SanitizeAPISecurity(isYamlAPIKey bool, isYamlOauth bool, isYamlMutualssl bool, isYamlMutualsslMandatory bool, isYamlOauthBasicAuthAPIKeyMandatory bool) {
@@ -457,7 +819,7 @@ func (swagger *MgwSwagger) SanitizeAPISecurity(isYamlAPIKey bool, isYamlOauth bo
 	} else if isYamlMutualssl && !isYamlMutualsslMandatory {
 		mutualSSL = constants.Optional
 	} else {
-		mutualSSL = constants.NotDefined
+		mutualSSL = getXWso2MutualSSL(swagger.vendorExtensions)
 	}
 
 	if isYamlOauthBasicAuthAPIKeyMandatory {
@@ -509,6 +871,22 @@ func (swagger *MgwSwagger) SetXWso2Extensions() error {
 	swagger.setDisableSecurity()
 	swagger.setXWso2AuthHeader()
 	swagger.setXWso2HTTP2BackendEnabled()
+	swagger.setXWso2Streaming()
+	swagger.setXWso2DisabledGlobalPolicies()
+	swagger.setXWso2RateLimitHeaders()
+	swagger.setXWso2ConcurrencyLimits()
+	swagger.setXWso2PayloadLimits()
+	swagger.setXWso2Timeouts()
+	swagger.setXWso2RetryBudget()
+	swagger.setXWso2ResponseCompression()
+	swagger.setXWso2TrafficMirror()
+	swagger.setXWso2Deprecation()
+	swagger.setXWso2TokenValidation()
+	swagger.setXWso2BackendJWT()
+	swagger.setXWso2IPRestriction()
+	swagger.setXWso2SubscriptionValidation()
+	swagger.setXWso2GRPCTranscoding()
+	swagger.setXWso2ResponseCache()
 
 	// Error nil for successful execution
 	return nil
@@ -768,6 +1146,13 @@ func (swagger *MgwSwagger) Validate() error {
 		logger.LoggerOasparser.Errorf("Error while parsing the API %s:%s - %v", swagger.title, swagger.version, err)
 		return err
 	}
+	if swagger.apiType == constants.WEBSUB {
+		if err = swagger.validateWebSubSignature(); err != nil {
+			logger.LoggerOasparser.Errorf("Error while parsing the WebSub API %s:%s - %v", swagger.title,
+				swagger.version, err)
+			return err
+		}
+	}
 	return nil
 }
 
@@ -825,6 +1210,34 @@ func (retryConfig *RetryConfig) validateRetryConfig() {
 		validStatusCodes = append(validStatusCodes, conf.Envoy.Upstream.Retry.StatusCodes...)
 	}
 	retryConfig.StatusCodes = validStatusCodes
+	maxTimeoutInMillis := conf.Envoy.Upstream.Timeouts.MaxRouteTimeoutInSeconds * 1000
+	if retryConfig.PerTryTimeoutInMillis > maxTimeoutInMillis {
+		logger.LoggerOasparser.Errorf("Per try timeout for the API retry config must not exceed %v milliseconds. "+
+			"Reconfiguring per try timeout as %v", maxTimeoutInMillis, maxTimeoutInMillis)
+		retryConfig.PerTryTimeoutInMillis = maxTimeoutInMillis
+	}
+}
+
+func (healthCheck *HealthCheck) validateHealthCheck() error {
+	if healthCheck.TimeoutInSeconds < 0 || healthCheck.IntervalInSeconds < 0 ||
+		healthCheck.UnhealthyThreshold < 0 || healthCheck.HealthyThreshold < 0 {
+		return errors.New("health check values should not be negative")
+	}
+	if healthCheck.Path != "" && !strings.HasPrefix(healthCheck.Path, "/") {
+		return errors.New("health check path should start with /")
+	}
+	return nil
+}
+
+func (outlierDetection *OutlierDetection) validateOutlierDetection() error {
+	if outlierDetection.Consecutive5xx < 0 || outlierDetection.IntervalInSeconds < 0 ||
+		outlierDetection.BaseEjectionTimeInSeconds < 0 {
+		return errors.New("outlier detection values should not be negative")
+	}
+	if outlierDetection.MaxEjectionPercent < 0 || outlierDetection.MaxEjectionPercent > 100 {
+		return errors.New("outlier detection max ejection percent should be between 0 and 100")
+	}
+	return nil
 }
 
 func (endpointCluster *EndpointCluster) validateEndpointCluster(endpointName string) error {
@@ -850,19 +1263,38 @@ func (endpointCluster *EndpointCluster) validateEndpointCluster(endpointName str
 			if endpointCluster.Config.TimeoutInMillis > maxTimeoutInMillis {
 				endpointCluster.Config.TimeoutInMillis = maxTimeoutInMillis
 			}
+			// Validate load balancing algorithm
+			if endpointCluster.Config.LbAlgorithm != "" &&
+				endpointCluster.Config.LbAlgorithm != constants.RoundRobin &&
+				endpointCluster.Config.LbAlgorithm != constants.LeastRequest {
+				return errors.New("unsupported load balancing algorithm : " + endpointCluster.Config.LbAlgorithm)
+			}
+			// Validate health check
+			if endpointCluster.Config.HealthCheck != nil {
+				if err := endpointCluster.Config.HealthCheck.validateHealthCheck(); err != nil {
+					return err
+				}
+			}
+			// Validate outlier detection
+			if endpointCluster.Config.OutlierDetection != nil {
+				if err := endpointCluster.Config.OutlierDetection.validateOutlierDetection(); err != nil {
+					return err
+				}
+			}
 		}
 	}
 	return nil
 }
 
 // getEndpoints extracts and generate the EndpointCluster Object from any yaml map that has the following structure
-//   endpoint-name:
-// 		urls:
-// 			- <endpoint-URL-1>
-// 			- <endpoint-URL-2>
-//		type: <loadbalance or failover>
-//		advanceEndpointConfig:
-//			<the configs>
+//
+//	  endpoint-name:
+//			urls:
+//				- <endpoint-URL-1>
+//				- <endpoint-URL-2>
+//			type: <loadbalance or failover>
+//			advanceEndpointConfig:
+//				<the configs>
 func (swagger *MgwSwagger) getEndpoints(vendorExtensions map[string]interface{}, endpointName string) (*EndpointCluster, error) {
 
 	// TODO: (VirajSalaka) x-wso2-production-endpoint 's type does not represent http/https, instead it indicates loadbalance and failover
@@ -964,10 +1396,28 @@ func processEndpointUrls(urlsArray []interface{}) ([]Endpoint, error) {
 			endpoint, err := getHTTPEndpoint(defHost)
 			if err == nil {
 				endpoint.ServiceDiscoveryString = queryString
+				endpoint.ServiceDiscoveryRegistry = svcdiscovery.RegistryConsul
 				endpoints = append(endpoints, *endpoint)
 			} else {
 				return nil, err
 			}
+		} else if svcdiscovery.IsServiceURL(v.(string)) {
+			logger.LoggerOasparser.Debug("Service discovery endpoint found: ", v.(string))
+			serviceURL, err := svcdiscovery.ParseServiceURL(v.(string))
+			if err != nil {
+				return nil, err
+			}
+			if !svcdiscovery.IsRegistryEnabled(serviceURL.Registry) {
+				return nil, fmt.Errorf("service discovery with %s is not enabled for the endpoint %s",
+					serviceURL.Registry, v.(string))
+			}
+			endpoint, err := getHTTPEndpoint(serviceURL.URLType + "://" + serviceURL.ServiceName + serviceURL.Basepath)
+			if err != nil {
+				return nil, err
+			}
+			endpoint.ServiceDiscoveryString = serviceURL.Query
+			endpoint.ServiceDiscoveryRegistry = serviceURL.Registry
+			endpoints = append(endpoints, *endpoint)
 		} else {
 			endpoint, err := getHTTPEndpoint(v.(string))
 			if err == nil {
@@ -987,11 +1437,222 @@ func (swagger *MgwSwagger) setXWso2Basepath() {
 	}
 }
 
+func (swagger *MgwSwagger) setXWso2DisabledGlobalPolicies() {
+	swagger.disableGlobalPolicies, swagger.disabledGlobalPolicies = getXWso2DisabledGlobalPolicies(
+		swagger.vendorExtensions)
+}
+
+func (swagger *MgwSwagger) setXWso2RateLimitHeaders() {
+	swagger.rateLimitHeadersFormat = getXWso2RateLimitHeaders(swagger.vendorExtensions)
+}
+
+// setXWso2ConcurrencyLimits sets the limits of the in-flight requests of the API. The limits of the
+// x-wso2-concurrency-limits extension override the default limits configured for the enforcer.
+func (swagger *MgwSwagger) setXWso2ConcurrencyLimits() {
+	conf, _ := config.ReadConfigs()
+	if !conf.Enforcer.Throttling.ConcurrencyLimits.Enabled {
+		swagger.concurrencyLimits = nil
+		return
+	}
+	limits := ResolveConcurrencyLimits(swagger.vendorExtensions)
+	if limits == nil {
+		limits = &ConcurrencyLimits{
+			MaxConcurrentRequests:               conf.Enforcer.Throttling.ConcurrencyLimits.MaxConcurrentRequests,
+			MaxConcurrentRequestsPerApplication: conf.Enforcer.Throttling.ConcurrencyLimits.MaxConcurrentRequestsPerApplication,
+		}
+	}
+	if limits.MaxConcurrentRequests == 0 && limits.MaxConcurrentRequestsPerApplication == 0 {
+		limits = nil
+	}
+	swagger.concurrencyLimits = limits
+}
+
+// setXWso2PayloadLimits sets the payload limits of the API. The limits of the x-wso2-payload-limits extension
+// override the default limits configured for the router.
+func (swagger *MgwSwagger) setXWso2PayloadLimits() {
+	conf, _ := config.ReadConfigs()
+	defaultLimits := &PayloadLimits{
+		MaxRequestBodySizeInBytes: conf.Envoy.PayloadLimits.MaxRequestBodySizeInBytes,
+		MaxBufferSizeInBytes:      conf.Envoy.PayloadLimits.MaxBufferSizeInBytes,
+	}
+	limits := ResolvePayloadLimits(swagger.vendorExtensions, defaultLimits)
+	if limits == nil {
+		limits = defaultLimits
+	}
+	if *limits == (PayloadLimits{}) {
+		limits = nil
+	}
+	swagger.payloadLimits = limits
+}
+
+// setXWso2Timeouts sets the upstream timeouts of the API provided with the x-wso2-timeouts extension.
+func (swagger *MgwSwagger) setXWso2Timeouts() {
+	swagger.timeouts = ResolveTimeouts(swagger.vendorExtensions, nil)
+}
+
+// setXWso2RetryBudget sets the retry budget of the API provided with the x-wso2-retry-budget extension.
+func (swagger *MgwSwagger) setXWso2RetryBudget() {
+	swagger.retryBudget = ResolveRetryBudget(swagger.vendorExtensions, nil)
+}
+
+// setXWso2ResponseCompression sets the response compression of the API provided with the
+// x-wso2-response-compression extension.
+func (swagger *MgwSwagger) setXWso2ResponseCompression() {
+	swagger.responseCompression = ResolveResponseCompression(swagger.vendorExtensions, nil)
+}
+
+// setXWso2ResponseCache sets the caching of the responses of the API provided with the x-wso2-response-cache
+// extension.
+func (swagger *MgwSwagger) setXWso2ResponseCache() {
+	swagger.responseCache = ResolveResponseCache(swagger.vendorExtensions, nil)
+}
+
+// setXWso2TrafficMirror sets the traffic mirror of the API provided with the x-wso2-traffic-mirror extension.
+// The requests are not mirrored if the extension is invalid.
+func (swagger *MgwSwagger) setXWso2TrafficMirror() {
+	mirror, err := ResolveTrafficMirror(swagger.vendorExtensions)
+	if err != nil {
+		logger.LoggerOasparser.ErrorC(logging.ErrorDetails{
+			Message: fmt.Sprintf("Error while parsing %v of the API %s:%s, hence the requests are not mirrored. %v",
+				constants.XWso2TrafficMirror, swagger.title, swagger.version, err),
+			Severity:  logging.MINOR,
+			ErrorCode: 2251,
+		})
+	}
+	swagger.trafficMirror = mirror
+}
+
+// setXWso2IPRestriction sets the source IP restriction of the API provided with the x-wso2-ip-restriction
+// extension. All the requests are denied if the extension is invalid, rather than exposing the API to all sources.
+func (swagger *MgwSwagger) setXWso2IPRestriction() {
+	restriction, err := ResolveIPRestriction(swagger.vendorExtensions)
+	if err != nil {
+		logger.LoggerOasparser.ErrorC(logging.ErrorDetails{
+			Message: fmt.Sprintf("Error while parsing %v of the API %s:%s, hence all the requests are denied. %v",
+				constants.XWso2IPRestriction, swagger.title, swagger.version, err),
+			Severity:  logging.MAJOR,
+			ErrorCode: 2257,
+		})
+		restriction = &IPRestriction{Deny: []string{"0.0.0.0/0", "::/0"}}
+	}
+	swagger.ipRestriction = restriction
+}
+
+// setXWso2SubscriptionValidation sets the subscription validation of the API provided with the
+// x-wso2-subscription-validation extension, which overrides the subscription validation of the api.yaml.
+func (swagger *MgwSwagger) setXWso2SubscriptionValidation() {
+	x, found := swagger.vendorExtensions[constants.XWso2SubscriptionValidation]
+	if !found {
+		return
+	}
+	enabled, ok := x.(bool)
+	if !ok {
+		logger.LoggerOasparser.ErrorC(logging.ErrorDetails{
+			Message: fmt.Sprintf("Error while parsing %v of the API %s:%s, expected a boolean but found %v.",
+				constants.XWso2SubscriptionValidation, swagger.title, swagger.version, x),
+			Severity:  logging.MINOR,
+			ErrorCode: 2261,
+		})
+		return
+	}
+	swagger.subscriptionValidation = &enabled
+}
+
+// setXWso2GRPCTranscoding sets the transcoding of the REST requests of the API to gRPC provided with the
+// x-wso2-grpc-transcoding extension. The backends of the API are connected with HTTP/2 if the requests are transcoded.
+func (swagger *MgwSwagger) setXWso2GRPCTranscoding() {
+	transcoding, err := ResolveGRPCTranscoding(swagger.vendorExtensions)
+	if err != nil {
+		logger.LoggerOasparser.ErrorC(logging.ErrorDetails{
+			Message: fmt.Sprintf("Error while parsing %v of the API %s:%s, hence the requests are not transcoded. %v",
+				constants.XWso2GRPCTranscoding, swagger.title, swagger.version, err),
+			Severity:  logging.MINOR,
+			ErrorCode: 2262,
+		})
+	}
+	swagger.grpcTranscoding = transcoding
+	if transcoding != nil {
+		swagger.xWso2HTTP2BackendEnabled = true
+	}
+}
+
 func (swagger *MgwSwagger) setXWso2HTTP2BackendEnabled() {
 	extHTTP2BackendEnabled := getXWso2HTTP2BackendEnabled(swagger.vendorExtensions)
 	swagger.xWso2HTTP2BackendEnabled = extHTTP2BackendEnabled
 }
 
+func (swagger *MgwSwagger) setXWso2Streaming() {
+	swagger.xWso2Streaming = ResolveStreamingConfig(swagger.vendorExtensions)
+	// Server-Sent Events APIs are always considered as streaming APIs unless it is explicitly disabled.
+	if swagger.xWso2Streaming == nil && swagger.apiType == constants.SSE {
+		swagger.xWso2Streaming = &StreamingConfig{Enabled: true}
+	}
+}
+
+func (swagger *MgwSwagger) setXWso2Deprecation() {
+	swagger.xWso2Deprecation = ResolveDeprecationConfig(swagger.vendorExtensions)
+	// APIs deprecated from the control plane are advertised as deprecated, even without the dates
+	if swagger.xWso2Deprecation == nil && strings.EqualFold(swagger.LifecycleStatus, constants.DeprecatedLifecycleStatus) {
+		swagger.xWso2Deprecation = &DeprecationConfig{}
+	}
+}
+
+// setXWso2TokenValidation sets the token issuers and audiences accepted by the API. The x-wso2-allowed-issuers and
+// x-wso2-allowed-audiences extensions of the API definition override the adapter configuration of the API.
+func (swagger *MgwSwagger) setXWso2TokenValidation() {
+	swagger.allowedIssuers = getXWso2StringList(swagger.vendorExtensions, constants.XWso2AllowedIssuers)
+	swagger.allowedAudiences = getXWso2StringList(swagger.vendorExtensions, constants.XWso2AllowedAudiences)
+	conf, _ := config.ReadConfigs()
+	for _, tokenValidation := range conf.Adapter.APITokenValidation {
+		if tokenValidation.APIName != swagger.title ||
+			(tokenValidation.APIVersion != "" && tokenValidation.APIVersion != swagger.version) {
+			continue
+		}
+		if swagger.allowedIssuers == nil {
+			swagger.allowedIssuers = tokenValidation.Issuers
+		}
+		if swagger.allowedAudiences == nil {
+			swagger.allowedAudiences = tokenValidation.Audiences
+		}
+		break
+	}
+	for _, issuer := range swagger.allowedIssuers {
+		configured := false
+		for _, tokenService := range conf.Enforcer.Security.TokenService {
+			if tokenService.Issuer == issuer {
+				configured = true
+				break
+			}
+		}
+		if !configured {
+			logger.LoggerOasparser.Warnf("Token issuer %s allowed for the API %s:%s is not configured in the enforcer. "+
+				"The tokens of the issuer are rejected.", issuer, swagger.title, swagger.version)
+		}
+	}
+}
+
+// setXWso2BackendJWT sets the generation of the JWT sent to the backend of the API. The settings of the
+// x-wso2-backend-jwt extension override the JWT generator configured for the enforcer.
+func (swagger *MgwSwagger) setXWso2BackendJWT() {
+	conf, _ := config.ReadConfigs()
+	generator := conf.Enforcer.JwtGenerator
+	defaultJWT := &BackendJWT{
+		Enabled:               generator.Enabled,
+		Header:                generator.Header,
+		SigningAlgorithm:      generator.SigningAlgorithm,
+		EnableUserClaims:      generator.EnableUserClaims,
+		ApplicationAttributes: generator.ApplicationAttributes,
+	}
+	backendJWT := ResolveBackendJWT(swagger.vendorExtensions, defaultJWT)
+	if backendJWT == nil {
+		backendJWT = defaultJWT
+	}
+	swagger.backendJWT = backendJWT
+}
+
+// setXWso2Cors sets the CORS configuration of the API. The x-wso2-cors extension of the API definition
+// overrides the CORS configuration of the api.yaml, and the fields which are not given in the extension are
+// taken from the global CORS configuration. The global CORS configuration is applied if neither is enabled.
 func (swagger *MgwSwagger) setXWso2Cors() {
 	if cors, corsFound := swagger.vendorExtensions[constants.XWso2Cors]; corsFound {
 		logger.LoggerOasparser.Debugf("%v configuration is available", constants.XWso2Cors)
@@ -1003,20 +1664,21 @@ func (swagger *MgwSwagger) setXWso2Cors() {
 			err := parser.Decode(parsedCors, &corsConfig)
 			if err != nil {
 				logger.LoggerOasparser.Errorf("Error while parsing %v: "+err.Error(), constants.XWso2Cors)
-				return
-			}
-			if corsConfig.Enabled {
+			} else if corsConfig.Enabled {
+				inheritGlobalCors(corsConfig, parsedCors)
 				logger.LoggerOasparser.Debugf("API Level Cors Configuration is applied : %+v\n", corsConfig)
 				swagger.xWso2Cors = corsConfig
 				return
 			}
-			swagger.xWso2Cors = generateGlobalCors()
-			return
+		} else {
+			logger.LoggerOasparser.Errorf("Error while parsing %v .", constants.XWso2Cors)
 		}
-		logger.LoggerOasparser.Errorf("Error while parsing %v .", constants.XWso2Cors)
-	} else {
-		swagger.xWso2Cors = generateGlobalCors()
 	}
+	// CORS configuration of the api.yaml, if the API is deployed as an API project
+	if swagger.xWso2Cors != nil && swagger.xWso2Cors.Enabled {
+		return
+	}
+	swagger.xWso2Cors = generateGlobalCors()
 }
 
 func generateEndpointCluster(endpointPrefix string, endpoints []Endpoint, endpointType string) *EndpointCluster {
@@ -1031,6 +1693,52 @@ func generateEndpointCluster(endpointPrefix string, endpoints []Endpoint, endpoi
 	return nil
 }
 
+// inheritGlobalCors sets the fields of the CORS configuration, which are not given in the x-wso2-cors
+// extension, from the global CORS configuration.
+func inheritGlobalCors(corsConfig *CorsConfig, parsedCors map[string]interface{}) {
+	isGiven := func(field string) bool {
+		for key := range parsedCors {
+			// mapstructure matches the field names case insensitively
+			if strings.EqualFold(key, field) {
+				return true
+			}
+		}
+		return false
+	}
+	globalCors := generateGlobalCors()
+	if !isGiven("accessControlAllowCredentials") {
+		corsConfig.AccessControlAllowCredentials = globalCors.AccessControlAllowCredentials
+	}
+	if !isGiven("accessControlAllowHeaders") {
+		corsConfig.AccessControlAllowHeaders = globalCors.AccessControlAllowHeaders
+	}
+	if !isGiven("accessControlAllowMethods") {
+		corsConfig.AccessControlAllowMethods = globalCors.AccessControlAllowMethods
+	}
+	if !isGiven("accessControlAllowOrigins") {
+		corsConfig.AccessControlAllowOrigins = globalCors.AccessControlAllowOrigins
+	}
+	if !isGiven("accessControlExposeHeaders") {
+		corsConfig.AccessControlExposeHeaders = globalCors.AccessControlExposeHeaders
+	}
+}
+
+// generateAPIYamlCors returns the CORS configuration of the api.yaml, if it is enabled. Otherwise, the global
+// CORS configuration is returned. The api.yaml does not contain the exposed headers, hence those are taken from
+// the global CORS configuration.
+func generateAPIYamlCors(apiCors CorsConfiguration) *CorsConfig {
+	corsConfig := generateGlobalCors()
+	if apiCors.CorsConfigurationEnabled {
+		logger.LoggerOasparser.Debug("CORS policy is applied from the api.yaml.")
+		corsConfig.Enabled = true
+		corsConfig.AccessControlAllowOrigins = apiCors.AccessControlAllowOrigins
+		corsConfig.AccessControlAllowCredentials = apiCors.AccessControlAllowCredentials
+		corsConfig.AccessControlAllowHeaders = apiCors.AccessControlAllowHeaders
+		corsConfig.AccessControlAllowMethods = apiCors.AccessControlAllowMethods
+	}
+	return corsConfig
+}
+
 func generateGlobalCors() *CorsConfig {
 	conf, _ := config.ReadConfigs()
 	logger.LoggerOasparser.Debug("CORS policy is applied from global configuration.")
@@ -1044,7 +1752,7 @@ func generateGlobalCors() *CorsConfig {
 	}
 }
 
-//GetOperationInterceptors returns operation interceptors
+// GetOperationInterceptors returns operation interceptors
 func (swagger *MgwSwagger) GetOperationInterceptors(apiInterceptor InterceptEndpoint, resourceInterceptor InterceptEndpoint, operations []*Operation, isIn bool) map[string]InterceptEndpoint {
 	interceptorOperationMap := make(map[string]InterceptEndpoint)
 
@@ -1079,7 +1787,7 @@ func (swagger *MgwSwagger) GetOperationInterceptors(apiInterceptor InterceptEndp
 
 }
 
-//GetInterceptor returns interceptors
+// GetInterceptor returns interceptors
 func (swagger *MgwSwagger) GetInterceptor(vendorExtensions map[string]interface{}, extensionName string, level string) InterceptEndpoint {
 	var endpointCluster EndpointCluster
 	conf, _ := config.ReadConfigs()
@@ -1152,7 +1860,7 @@ func (swagger *MgwSwagger) GetInterceptor(vendorExtensions map[string]interface{
 	return InterceptEndpoint{}
 }
 
-//GenerateInterceptorIncludes generate includes
+// GenerateInterceptorIncludes generate includes
 func GenerateInterceptorIncludes(includes []string) *interceptor.RequestInclusions {
 	includesV := &interceptor.RequestInclusions{}
 	for _, include := range includes {
@@ -1235,7 +1943,7 @@ func (swagger *MgwSwagger) GetMgwSwagger(apiContent []byte) error {
 	return nil
 }
 
-//PopulateFromAPIYaml populates the mgwSwagger object for APIs using API.yaml
+// PopulateFromAPIYaml populates the mgwSwagger object for APIs using API.yaml
 // TODO - (VirajSalaka) read cors config and populate mgwSwagger feild
 func (swagger *MgwSwagger) PopulateFromAPIYaml(apiYaml APIYaml) error {
 
@@ -1250,6 +1958,11 @@ func (swagger *MgwSwagger) PopulateFromAPIYaml(apiYaml APIYaml) error {
 	swagger.xWso2Basepath = data.Context + "/" + swagger.version
 	swagger.LifecycleStatus = data.LifeCycleStatus
 	swagger.IsDefaultVersion = data.IsDefaultVersion
+	swagger.subscriptionValidation = data.SubscriptionValidation
+	swagger.xWso2Cors = generateAPIYamlCors(data.CorsConfiguration)
+	if swagger.apiType == constants.WEBSUB {
+		swagger.setWebSubSignature(data.WebSubSubscriptionConfig)
+	}
 
 	// Added with both HTTP and WS APIs. x-throttling-tier is not used with WS.
 	swagger.xWso2ThrottlingTier = data.APIThrottlingPolicy
@@ -18,10 +18,15 @@
 package model
 
 import (
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-microgateway/adapter/config"
 	"github.com/wso2/product-microgateway/adapter/internal/oasparser/constants"
+	"github.com/wso2/product-microgateway/adapter/internal/svcdiscovery"
 )
 
 func TestGetXWso2Endpoints(t *testing.T) {
@@ -354,3 +359,716 @@ func TestGetEndpointType(t *testing.T) {
 		assert.Equal(t, item.result, resultEndpointType, item.message)
 	}
 }
+
+func TestSetXWso2Streaming(t *testing.T) {
+	type setXWso2StreamingTestItem struct {
+		mgwSwagger MgwSwagger
+		result     *StreamingConfig
+		message    string
+	}
+	dataItems := []setXWso2StreamingTestItem{
+		{
+			mgwSwagger: MgwSwagger{apiType: constants.HTTP},
+			result:     nil,
+			message:    "streaming should not be enabled for HTTP APIs by default",
+		},
+		{
+			mgwSwagger: MgwSwagger{apiType: constants.SSE},
+			result:     &StreamingConfig{Enabled: true},
+			message:    "streaming should be enabled for SSE APIs by default",
+		},
+		{
+			mgwSwagger: MgwSwagger{apiType: constants.SSE, vendorExtensions: map[string]interface{}{
+				constants.XWso2Streaming: false,
+			}},
+			result:  &StreamingConfig{Enabled: false},
+			message: "streaming should be disabled when the extension is false",
+		},
+		{
+			mgwSwagger: MgwSwagger{apiType: constants.HTTP, vendorExtensions: map[string]interface{}{
+				constants.XWso2Streaming: map[string]interface{}{"idleTimeoutInSeconds": 600},
+			}},
+			result:  &StreamingConfig{Enabled: true, IdleTimeoutInSeconds: 600},
+			message: "streaming config object is not parsed properly",
+		},
+	}
+	for _, item := range dataItems {
+		item.mgwSwagger.setXWso2Streaming()
+		assert.Equal(t, item.result, item.mgwSwagger.GetStreamingConfig(), item.message)
+	}
+}
+
+func TestSetRevisionTrafficSplit(t *testing.T) {
+	split := RevisionTrafficSplit{NewRevisionID: "2", PreviousRevisionID: "1", NewRevisionWeight: 10}
+	newEndpoints := &EndpointCluster{Endpoints: []Endpoint{{Host: "new.com", Basepath: "/v2"}}}
+	prevEndpoints := &EndpointCluster{Endpoints: []Endpoint{{Host: "prev.com", Basepath: "/v1"}}}
+
+	mgwSwagger := MgwSwagger{productionEndpoints: newEndpoints}
+	err := mgwSwagger.SetRevisionTrafficSplit(prevEndpoints, nil, split)
+	assert.NotNil(t, err, "Endpoints with different basepaths should not be split")
+	assert.Nil(t, mgwSwagger.GetRevisionTrafficSplit(), "Revision split should not be set on failure")
+	assert.Equal(t, newEndpoints, mgwSwagger.GetProdEndpoints(), "Endpoints should not be changed on failure")
+
+	prevEndpoints.Endpoints[0].Basepath = "/v2/"
+	prevEndpoints.EndpointType = "failover"
+	err = mgwSwagger.SetRevisionTrafficSplit(prevEndpoints, nil, split)
+	assert.NotNil(t, err, "Failover endpoints should not be split")
+
+	prevEndpoints.EndpointType = "load_balance"
+	err = mgwSwagger.SetRevisionTrafficSplit(prevEndpoints, nil, split)
+	assert.Nil(t, err, "Error while splitting the traffic between revisions")
+	assert.Equal(t, &split, mgwSwagger.GetRevisionTrafficSplit(), "Revision split mismatch")
+	assert.Equal(t, []Endpoint{
+		{Host: "new.com", Basepath: "/v2", Revision: "2", LbWeight: 10},
+		{Host: "prev.com", Basepath: "/v2/", Revision: "1", LbWeight: 90},
+	}, mgwSwagger.GetProdEndpoints().Endpoints, "Merged endpoints mismatch")
+	assert.Equal(t, 1, len(newEndpoints.Endpoints), "Endpoints of the new revision should not be altered")
+	assert.Nil(t, mgwSwagger.GetSandEndpoints(), "Sandbox endpoints should not be added")
+}
+
+func TestSetXWso2DisabledGlobalPolicies(t *testing.T) {
+	mgwSwagger := MgwSwagger{vendorExtensions: map[string]interface{}{
+		constants.XWso2DisableGlobalPolicies: []interface{}{"hsts", "cors"},
+	}}
+	mgwSwagger.setXWso2DisabledGlobalPolicies()
+	assert.True(t, mgwSwagger.IsGlobalPolicyDisabled("hsts"), "Global policy should be disabled")
+	assert.False(t, mgwSwagger.IsGlobalPolicyDisabled("csp"), "Global policy should not be disabled")
+
+	mgwSwagger.vendorExtensions[constants.XWso2DisableGlobalPolicies] = true
+	mgwSwagger.setXWso2DisabledGlobalPolicies()
+	assert.True(t, mgwSwagger.IsGlobalPolicyDisabled("csp"), "All global policies should be disabled")
+
+	mgwSwagger.vendorExtensions = map[string]interface{}{}
+	mgwSwagger.setXWso2DisabledGlobalPolicies()
+	assert.False(t, mgwSwagger.IsGlobalPolicyDisabled("hsts"), "Global policies should be enabled by default")
+}
+
+func TestSetXWso2RateLimitHeaders(t *testing.T) {
+	mgwSwagger := MgwSwagger{vendorExtensions: map[string]interface{}{
+		constants.XWso2RateLimitHeaders: false,
+	}}
+	mgwSwagger.setXWso2RateLimitHeaders()
+	assert.Equal(t, constants.RateLimitHeadersDisabled, mgwSwagger.GetRateLimitHeadersFormat())
+
+	mgwSwagger.vendorExtensions[constants.XWso2RateLimitHeaders] = constants.XRateLimitHeadersFormat
+	mgwSwagger.setXWso2RateLimitHeaders()
+	assert.Equal(t, constants.XRateLimitHeadersFormat, mgwSwagger.GetRateLimitHeadersFormat())
+
+	for _, value := range []interface{}{true, "draft", 1} {
+		mgwSwagger.vendorExtensions[constants.XWso2RateLimitHeaders] = value
+		mgwSwagger.setXWso2RateLimitHeaders()
+		assert.Empty(t, mgwSwagger.GetRateLimitHeadersFormat(), "Router format should be used for %v", value)
+	}
+}
+
+func TestSetXWso2Cors(t *testing.T) {
+	globalCors := generateGlobalCors()
+	apiYamlCors := CorsConfiguration{
+		CorsConfigurationEnabled:  true,
+		AccessControlAllowOrigins: []string{"https://apim.example.com"},
+		AccessControlAllowHeaders: []string{"Authorization"},
+		AccessControlAllowMethods: []string{"GET"},
+	}
+
+	// global CORS configuration is applied, if the API does not have a CORS configuration
+	swagger := MgwSwagger{}
+	swagger.setXWso2Cors()
+	assert.Equal(t, globalCors, swagger.xWso2Cors)
+
+	// api.yaml CORS configuration is applied, if the API definition does not have a CORS configuration
+	swagger = MgwSwagger{xWso2Cors: generateAPIYamlCors(apiYamlCors)}
+	swagger.setXWso2Cors()
+	assert.True(t, swagger.xWso2Cors.Enabled)
+	assert.Equal(t, []string{"https://apim.example.com"}, swagger.xWso2Cors.AccessControlAllowOrigins)
+	assert.Equal(t, []string{"GET"}, swagger.xWso2Cors.AccessControlAllowMethods)
+	assert.Equal(t, globalCors.AccessControlExposeHeaders, swagger.xWso2Cors.AccessControlExposeHeaders)
+
+	// x-wso2-cors overrides the api.yaml, and the fields not given are taken from the global CORS configuration
+	swagger = MgwSwagger{
+		xWso2Cors: generateAPIYamlCors(apiYamlCors),
+		vendorExtensions: map[string]interface{}{
+			constants.XWso2Cors: map[string]interface{}{
+				"accessControlAllowOrigins":     []interface{}{"https://example.com"},
+				"accessControlAllowCredentials": true,
+			},
+		},
+	}
+	swagger.setXWso2Cors()
+	assert.Equal(t, &CorsConfig{
+		Enabled:                       true,
+		AccessControlAllowCredentials: true,
+		AccessControlAllowOrigins:     []string{"https://example.com"},
+		AccessControlAllowHeaders:     globalCors.AccessControlAllowHeaders,
+		AccessControlAllowMethods:     globalCors.AccessControlAllowMethods,
+		AccessControlExposeHeaders:    globalCors.AccessControlExposeHeaders,
+	}, swagger.xWso2Cors)
+
+	// disabled x-wso2-cors falls back to the api.yaml
+	swagger = MgwSwagger{
+		xWso2Cors: generateAPIYamlCors(apiYamlCors),
+		vendorExtensions: map[string]interface{}{
+			constants.XWso2Cors: map[string]interface{}{"corsConfigurationEnabled": false},
+		},
+	}
+	swagger.setXWso2Cors()
+	assert.Equal(t, []string{"https://apim.example.com"}, swagger.xWso2Cors.AccessControlAllowOrigins)
+
+	// api.yaml CORS configuration is not applied, if it is not enabled
+	assert.Equal(t, globalCors, generateAPIYamlCors(CorsConfiguration{AccessControlAllowOrigins: []string{"*.com"}}))
+}
+
+func TestSetXWso2Deprecation(t *testing.T) {
+	dataItems := []struct {
+		extension       interface{}
+		lifecycleStatus string
+		expected        *DeprecationConfig
+		message         string
+	}{
+		{nil, "PUBLISHED", nil, "when the API is not deprecated"},
+		{nil, "DEPRECATED", &DeprecationConfig{}, "when the API is deprecated from the control plane"},
+		{true, "PUBLISHED", &DeprecationConfig{}, "when the extension is a boolean"},
+		{
+			map[string]interface{}{
+				"deprecatedAt":  "2023-06-30T23:59:59+05:30",
+				"sunsetAt":      "2023-12-31",
+				"successorLink": "/pizzashack/2.0.0",
+			},
+			"PUBLISHED",
+			&DeprecationConfig{
+				DeprecatedAt:  time.Date(2023, time.June, 30, 18, 29, 59, 0, time.UTC),
+				SunsetAt:      time.Date(2023, time.December, 31, 0, 0, 0, 0, time.UTC),
+				SuccessorLink: "/pizzashack/2.0.0",
+			},
+			"when the dates are given",
+		},
+		{map[string]interface{}{"sunsetAt": "31/12/2023"}, "PUBLISHED", nil, "when the date is invalid"},
+		{map[string]interface{}{"deprecatedAt": "2024-01-01", "sunsetAt": "2023-12-31"}, "PUBLISHED", nil,
+			"when the sunset is before the deprecation"},
+	}
+	for _, item := range dataItems {
+		swagger := MgwSwagger{LifecycleStatus: item.lifecycleStatus, vendorExtensions: map[string]interface{}{}}
+		if item.extension != nil {
+			swagger.vendorExtensions[constants.XWso2Deprecation] = item.extension
+		}
+		swagger.setXWso2Deprecation()
+		assert.Equal(t, item.expected, swagger.GetDeprecationConfig(), item.message)
+	}
+}
+
+func TestSetXWso2TokenValidation(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	existing := conf.Adapter.APITokenValidation
+	defer func() { conf.Adapter.APITokenValidation = existing }()
+	conf.Adapter.APITokenValidation = []config.APITokenValidation{
+		{APIName: "PetStore", APIVersion: "1.0.0", Issuers: []string{"https://idp1"}, Audiences: []string{"pets"}},
+		{APIName: "PizzaShack", Issuers: []string{"https://idp2"}},
+	}
+
+	dataItems := []struct {
+		title             string
+		version           string
+		issuers           interface{}
+		audiences         interface{}
+		expectedIssuers   []string
+		expectedAudiences []string
+		message           string
+	}{
+		{"PetStore", "1.0.0", nil, nil, []string{"https://idp1"}, []string{"pets"},
+			"when the API is configured in the adapter"},
+		{"PetStore", "2.0.0", nil, nil, nil, nil, "when the version is not configured in the adapter"},
+		{"PizzaShack", "2.0.0", nil, nil, []string{"https://idp2"}, nil,
+			"when all the versions of the API are configured in the adapter"},
+		{"PetStore", "1.0.0", []interface{}{"https://idp3", "https://idp4"}, nil,
+			[]string{"https://idp3", "https://idp4"}, []string{"pets"}, "when the issuers are given in the extension"},
+		{"Books", "1.0.0", nil, "books", nil, []string{"books"}, "when the audience is given as a string"},
+	}
+	for _, item := range dataItems {
+		swagger := MgwSwagger{title: item.title, version: item.version, vendorExtensions: map[string]interface{}{}}
+		if item.issuers != nil {
+			swagger.vendorExtensions[constants.XWso2AllowedIssuers] = item.issuers
+		}
+		if item.audiences != nil {
+			swagger.vendorExtensions[constants.XWso2AllowedAudiences] = item.audiences
+		}
+		swagger.setXWso2TokenValidation()
+		assert.Equal(t, item.expectedIssuers, swagger.GetAllowedIssuers(), item.message)
+		assert.Equal(t, item.expectedAudiences, swagger.GetAllowedAudiences(), item.message)
+	}
+}
+
+func TestSetXWso2ConcurrencyLimits(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	existing := conf.Enforcer.Throttling.ConcurrencyLimits
+	defer func() { conf.Enforcer.Throttling.ConcurrencyLimits = existing }()
+	conf.Enforcer.Throttling.ConcurrencyLimits.MaxConcurrentRequests = 100
+
+	swagger := MgwSwagger{vendorExtensions: map[string]interface{}{
+		constants.XWso2ConcurrencyLimits: map[string]interface{}{"maxConcurrentRequestsPerApplication": 10},
+	}}
+	swagger.setXWso2ConcurrencyLimits()
+	assert.Nil(t, swagger.GetConcurrencyLimits(), "Limits should not be applied when disabled")
+
+	conf.Enforcer.Throttling.ConcurrencyLimits.Enabled = true
+	swagger.setXWso2ConcurrencyLimits()
+	assert.Equal(t, &ConcurrencyLimits{MaxConcurrentRequestsPerApplication: 10}, swagger.GetConcurrencyLimits())
+
+	// default limits are applied, if the extension is not given or invalid
+	for _, value := range []interface{}{nil, 10, map[string]interface{}{"maxConcurrentRequests": "many"}} {
+		swagger.vendorExtensions[constants.XWso2ConcurrencyLimits] = value
+		if value == nil {
+			delete(swagger.vendorExtensions, constants.XWso2ConcurrencyLimits)
+		}
+		swagger.setXWso2ConcurrencyLimits()
+		assert.Equal(t, &ConcurrencyLimits{MaxConcurrentRequests: 100}, swagger.GetConcurrencyLimits(),
+			"Default limits should be applied for %v", value)
+	}
+
+	conf.Enforcer.Throttling.ConcurrencyLimits.MaxConcurrentRequests = 0
+	swagger.setXWso2ConcurrencyLimits()
+	assert.Nil(t, swagger.GetConcurrencyLimits(), "Limits should not be applied when unlimited")
+}
+
+func TestSetXWso2PayloadLimits(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	existing := conf.Envoy.PayloadLimits
+	defer func() { conf.Envoy.PayloadLimits = existing }()
+
+	swagger := MgwSwagger{vendorExtensions: map[string]interface{}{}}
+	swagger.setXWso2PayloadLimits()
+	assert.Nil(t, swagger.GetPayloadLimits(), "Payloads should not be limited by default")
+
+	conf.Envoy.PayloadLimits.MaxRequestBodySizeInBytes = 1024
+	swagger.setXWso2PayloadLimits()
+	assert.Equal(t, &PayloadLimits{MaxRequestBodySizeInBytes: 1024}, swagger.GetPayloadLimits())
+
+	// the limits not given in the extension are inherited from the defaults
+	swagger.vendorExtensions[constants.XWso2PayloadLimits] = map[string]interface{}{"maxBufferSizeInBytes": 4096,
+		"passthrough": true}
+	swagger.setXWso2PayloadLimits()
+	assert.Equal(t, &PayloadLimits{MaxRequestBodySizeInBytes: 1024, MaxBufferSizeInBytes: 4096, Passthrough: true},
+		swagger.GetPayloadLimits())
+
+	// resource level limits override the API level limits
+	resourceLimits := ResolvePayloadLimits(map[string]interface{}{
+		constants.XWso2PayloadLimits: map[string]interface{}{"maxRequestBodySizeInBytes": 0, "passthrough": false},
+	}, swagger.GetPayloadLimits())
+	assert.Equal(t, &PayloadLimits{MaxBufferSizeInBytes: 4096}, resourceLimits)
+
+	swagger.vendorExtensions[constants.XWso2PayloadLimits] = map[string]interface{}{"maxBufferSizeInBytes": "large"}
+	swagger.setXWso2PayloadLimits()
+	assert.Equal(t, &PayloadLimits{MaxRequestBodySizeInBytes: 1024}, swagger.GetPayloadLimits(),
+		"Default limits should be applied for invalid extensions")
+}
+
+func TestSetXWso2Timeouts(t *testing.T) {
+	swagger := MgwSwagger{vendorExtensions: map[string]interface{}{}}
+	swagger.setXWso2Timeouts()
+	assert.Nil(t, swagger.GetTimeouts(), "Timeouts of the router should be applied by default")
+
+	swagger.vendorExtensions[constants.XWso2Timeouts] = map[string]interface{}{"connectTimeoutInMillis": 500,
+		"routeTimeoutInMillis": 10000}
+	swagger.setXWso2Timeouts()
+	assert.Equal(t, &Timeouts{ConnectTimeoutInMillis: 500, RouteTimeoutInMillis: 10000}, swagger.GetTimeouts())
+
+	// resource level timeouts override the API level timeouts
+	resourceTimeouts := ResolveTimeouts(map[string]interface{}{
+		constants.XWso2Timeouts: map[string]interface{}{"routeTimeoutInMillis": 2000},
+	}, swagger.GetTimeouts())
+	assert.Equal(t, &Timeouts{ConnectTimeoutInMillis: 500, RouteTimeoutInMillis: 2000}, resourceTimeouts)
+
+	// route timeouts larger than the maximum route timeout are reduced
+	conf, _ := config.ReadConfigs()
+	swagger.vendorExtensions[constants.XWso2Timeouts] = map[string]interface{}{
+		"routeTimeoutInMillis": conf.Envoy.Upstream.Timeouts.MaxRouteTimeoutInSeconds*1000 + 1}
+	swagger.setXWso2Timeouts()
+	assert.Equal(t, conf.Envoy.Upstream.Timeouts.MaxRouteTimeoutInSeconds*1000,
+		swagger.GetTimeouts().RouteTimeoutInMillis)
+
+	swagger.vendorExtensions[constants.XWso2Timeouts] = map[string]interface{}{"routeTimeoutInMillis": "long"}
+	swagger.setXWso2Timeouts()
+	assert.Nil(t, swagger.GetTimeouts(), "Invalid timeouts should not be applied")
+}
+
+func TestSetXWso2RetryBudget(t *testing.T) {
+	swagger := MgwSwagger{vendorExtensions: map[string]interface{}{}}
+	swagger.setXWso2RetryBudget()
+	assert.Nil(t, swagger.GetRetryBudget(), "Retries should not be limited by a budget by default")
+
+	swagger.vendorExtensions[constants.XWso2RetryBudget] = map[string]interface{}{"budgetPercent": 25,
+		"minRetryConcurrency": 5}
+	swagger.setXWso2RetryBudget()
+	assert.Equal(t, &RetryBudget{BudgetPercent: 25, MinRetryConcurrency: 5}, swagger.GetRetryBudget())
+
+	// resource level budget overrides the API level budget
+	resourceBudget := ResolveRetryBudget(map[string]interface{}{
+		constants.XWso2RetryBudget: map[string]interface{}{"budgetPercent": 12.5},
+	}, swagger.GetRetryBudget())
+	assert.Equal(t, &RetryBudget{BudgetPercent: 12.5, MinRetryConcurrency: 5}, resourceBudget)
+
+	for _, value := range []interface{}{true, map[string]interface{}{"budgetPercent": 120}} {
+		swagger.vendorExtensions[constants.XWso2RetryBudget] = value
+		swagger.setXWso2RetryBudget()
+		assert.Nil(t, swagger.GetRetryBudget(), "Invalid budget %v should not be applied", value)
+	}
+}
+
+func TestSetXWso2IPRestriction(t *testing.T) {
+	swagger := MgwSwagger{vendorExtensions: map[string]interface{}{}}
+	swagger.setXWso2IPRestriction()
+	assert.Nil(t, swagger.GetIPRestriction(), "API should not be restricted by default")
+
+	swagger.vendorExtensions[constants.XWso2IPRestriction] = map[string]interface{}{
+		"allow": []interface{}{"10.0.0.0/8", "192.168.1.10"},
+		"deny":  []interface{}{"10.1.2.3", "2001:db8::1/64"},
+	}
+	swagger.setXWso2IPRestriction()
+	assert.Equal(t, &IPRestriction{Allow: []string{"10.0.0.0/8", "192.168.1.10/32"},
+		Deny: []string{"10.1.2.3/32", "2001:db8::/64"}}, swagger.GetIPRestriction())
+
+	swagger.vendorExtensions[constants.XWso2IPRestriction] = map[string]interface{}{"allow": []interface{}{}}
+	swagger.setXWso2IPRestriction()
+	assert.Nil(t, swagger.GetIPRestriction(), "API should not be restricted without any CIDRs")
+
+	// all the requests are denied, if the extension is invalid
+	for _, value := range []interface{}{"10.0.0.0/8", map[string]interface{}{"allow": []interface{}{"10.0.0.0/33"}}} {
+		swagger.vendorExtensions[constants.XWso2IPRestriction] = value
+		swagger.setXWso2IPRestriction()
+		assert.Equal(t, &IPRestriction{Deny: []string{"0.0.0.0/0", "::/0"}}, swagger.GetIPRestriction(),
+			"All the requests should be denied for %v", value)
+	}
+}
+
+func TestSetXWso2SubscriptionValidation(t *testing.T) {
+	disabled := false
+	swagger := MgwSwagger{vendorExtensions: map[string]interface{}{}}
+	swagger.setXWso2SubscriptionValidation()
+	assert.Nil(t, swagger.GetSubscriptionValidation(), "Subscription validation of the issuer should be applied by default")
+
+	// subscription validation of the api.yaml is kept, if the extension is invalid
+	swagger.SetSubscriptionValidation(&disabled)
+	swagger.vendorExtensions[constants.XWso2SubscriptionValidation] = "yes"
+	swagger.setXWso2SubscriptionValidation()
+	assert.Equal(t, &disabled, swagger.GetSubscriptionValidation())
+
+	swagger.vendorExtensions[constants.XWso2SubscriptionValidation] = true
+	swagger.setXWso2SubscriptionValidation()
+	assert.NotNil(t, swagger.GetSubscriptionValidation())
+	assert.True(t, *swagger.GetSubscriptionValidation())
+}
+
+func TestSetXWso2GRPCTranscoding(t *testing.T) {
+	swagger := MgwSwagger{vendorExtensions: map[string]interface{}{}}
+	swagger.setXWso2GRPCTranscoding()
+	assert.Nil(t, swagger.GetGRPCTranscoding(), "Requests should not be transcoded by default")
+
+	swagger.vendorExtensions[constants.XWso2GRPCTranscoding] = map[string]interface{}{"services": []interface{}{}}
+	swagger.setXWso2GRPCTranscoding()
+	assert.Nil(t, swagger.GetGRPCTranscoding(), "Requests should not be transcoded without any services")
+
+	swagger.vendorExtensions[constants.XWso2GRPCTranscoding] = map[string]interface{}{
+		"services": []interface{}{"bookstore.Bookstore"},
+	}
+	swagger.setXWso2GRPCTranscoding()
+	swagger.SetGRPCProtoDescriptor([]byte{0x0a})
+	assert.Equal(t, &GRPCTranscoding{Services: []string{"bookstore.Bookstore"}, ProtoDescriptor: []byte{0x0a}},
+		swagger.GetGRPCTranscoding())
+	assert.True(t, swagger.GetXWso2HTTP2BackendEnabled(), "gRPC backends should be connected with HTTP/2")
+
+	service, method, err := ResolveGRPCMethod(map[string]interface{}{constants.XWso2GRPCMethod: "bookstore.Bookstore/GetShelf"})
+	assert.Nil(t, err)
+	assert.Equal(t, "bookstore.Bookstore", service)
+	assert.Equal(t, "GetShelf", method)
+	_, _, err = ResolveGRPCMethod(map[string]interface{}{constants.XWso2GRPCMethod: "GetShelf"})
+	assert.NotNil(t, err, "gRPC method without the service is accepted")
+}
+
+func TestSetXWso2ResponseCompression(t *testing.T) {
+	swagger := MgwSwagger{vendorExtensions: map[string]interface{}{}}
+	swagger.setXWso2ResponseCompression()
+	assert.Nil(t, swagger.GetResponseCompression(), "Compression of the router should be applied by default")
+
+	swagger.vendorExtensions[constants.XWso2ResponseCompression] = map[string]interface{}{"profile": "brotli-json"}
+	swagger.setXWso2ResponseCompression()
+	assert.Equal(t, &ResponseCompression{Enabled: true, Profile: "brotli-json"}, swagger.GetResponseCompression())
+
+	// resource level compression overrides the API level compression
+	resourceCompression := ResolveResponseCompression(map[string]interface{}{
+		constants.XWso2ResponseCompression: map[string]interface{}{"enabled": false},
+	}, swagger.GetResponseCompression())
+	assert.Equal(t, &ResponseCompression{Enabled: false, Profile: "brotli-json"}, resourceCompression)
+
+	swagger.vendorExtensions[constants.XWso2ResponseCompression] = true
+	swagger.setXWso2ResponseCompression()
+	assert.Nil(t, swagger.GetResponseCompression(), "Invalid extensions should be ignored")
+}
+
+func TestSetXWso2ResponseCache(t *testing.T) {
+	swagger := MgwSwagger{vendorExtensions: map[string]interface{}{}}
+	swagger.setXWso2ResponseCache()
+	assert.Nil(t, swagger.GetResponseCache(), "Responses should be cached only as allowed by the backend by default")
+
+	swagger.vendorExtensions[constants.XWso2ResponseCache] = map[string]interface{}{
+		"keyHeaders": []interface{}{"accept-language"},
+	}
+	swagger.setXWso2ResponseCache()
+	assert.Equal(t, &ResponseCache{Enabled: true, TTLInSeconds: 60, KeyHeaders: []string{"accept-language"}},
+		swagger.GetResponseCache(), "Default TTL of the router should be applied")
+
+	// resource level cache overrides the API level cache
+	resourceCache := ResolveResponseCache(map[string]interface{}{
+		constants.XWso2ResponseCache: map[string]interface{}{"ttlInSeconds": 300, "bypassHeaders": []interface{}{"x-no-cache"}},
+	}, swagger.GetResponseCache())
+	assert.Equal(t, &ResponseCache{Enabled: true, TTLInSeconds: 300, KeyHeaders: []string{"accept-language"},
+		BypassHeaders: []string{"x-no-cache"}}, resourceCache)
+
+	swagger.vendorExtensions[constants.XWso2ResponseCache] = map[string]interface{}{"ttlInSeconds": "5m"}
+	swagger.setXWso2ResponseCache()
+	assert.Nil(t, swagger.GetResponseCache(), "Invalid extensions should be ignored")
+}
+
+func TestSetXWso2TrafficMirror(t *testing.T) {
+	swagger := MgwSwagger{vendorExtensions: map[string]interface{}{}}
+	swagger.setXWso2TrafficMirror()
+	assert.Nil(t, swagger.GetTrafficMirror(), "Requests should not be mirrored by default")
+
+	swagger.vendorExtensions[constants.XWso2TrafficMirror] = map[string]interface{}{"url": "https://shadow:8443/v2"}
+	swagger.setXWso2TrafficMirror()
+	if assert.NotNil(t, swagger.GetTrafficMirror(), "Requests should be mirrored") {
+		assert.Equal(t, float64(100), swagger.GetTrafficMirror().Percentage, "All requests should be mirrored by default")
+		endpoint := swagger.GetTrafficMirror().Endpoint.Endpoints[0]
+		assert.Equal(t, "shadow", endpoint.Host)
+		assert.Equal(t, uint32(8443), endpoint.Port)
+		assert.Equal(t, "https", endpoint.URLType)
+	}
+
+	invalidMirrors := []interface{}{
+		"https://shadow:8443",
+		map[string]interface{}{"percentage": 10},
+		map[string]interface{}{"url": "https://shadow:8443", "percentage": 0},
+		map[string]interface{}{"url": "https://shadow:8443", "percentage": 120},
+		map[string]interface{}{"url": "https://shadow:8443", "percentage": "10%"},
+	}
+	for _, invalidMirror := range invalidMirrors {
+		swagger.vendorExtensions[constants.XWso2TrafficMirror] = invalidMirror
+		swagger.setXWso2TrafficMirror()
+		assert.Nil(t, swagger.GetTrafficMirror(), "Requests should not be mirrored for the invalid extension %v",
+			invalidMirror)
+	}
+}
+
+func TestSetXWso2BackendJWT(t *testing.T) {
+	conf, _ := config.ReadConfigs()
+	existing := conf.Enforcer.JwtGenerator
+	defer func() { conf.Enforcer.JwtGenerator = existing }()
+
+	conf.Enforcer.JwtGenerator.Enabled = false
+	conf.Enforcer.JwtGenerator.ApplicationAttributes = []string{"tenant"}
+	swagger := MgwSwagger{vendorExtensions: map[string]interface{}{}}
+	swagger.setXWso2BackendJWT()
+	assert.Equal(t, &BackendJWT{Enabled: false, Header: "X-JWT-Assertion", SigningAlgorithm: "SHA256withRSA",
+		ApplicationAttributes: []string{"tenant"}}, swagger.GetBackendJWT(), "Generator config should be applied by default")
+
+	// the settings not given in the extension are inherited from the generator config
+	swagger.vendorExtensions[constants.XWso2BackendJWT] = map[string]interface{}{"enabled": true,
+		"header": "X-Consumer-JWT", "applicationAttributes": []interface{}{"region", "tier"}}
+	swagger.setXWso2BackendJWT()
+	assert.Equal(t, &BackendJWT{Enabled: true, Header: "X-Consumer-JWT", SigningAlgorithm: "SHA256withRSA",
+		ApplicationAttributes: []string{"region", "tier"}}, swagger.GetBackendJWT())
+
+	invalidExtensions := []interface{}{
+		true,
+		map[string]interface{}{"enableUserClaims": "yes"},
+		map[string]interface{}{"enabled": true, "header": ""},
+	}
+	for _, value := range invalidExtensions {
+		swagger.vendorExtensions[constants.XWso2BackendJWT] = value
+		swagger.setXWso2BackendJWT()
+		assert.Equal(t, "X-JWT-Assertion", swagger.GetBackendJWT().Header,
+			"Generator config should be applied for %v", value)
+		assert.False(t, swagger.GetBackendJWT().Enabled, "Generator config should be applied for %v", value)
+	}
+}
+
+func TestSanitizeAPISecurityForMutualSSL(t *testing.T) {
+	dataItems := []struct {
+		extension         interface{}
+		yamlMutualSSL     bool
+		yamlMandatory     bool
+		expectedMutualSSL string
+		message           string
+	}{
+		{nil, true, true, constants.Mandatory, "when mandatory mutual SSL is enabled in the api.yaml"},
+		{nil, true, false, constants.Optional, "when optional mutual SSL is enabled in the api.yaml"},
+		{nil, false, false, constants.NotDefined, "when mutual SSL is not enabled"},
+		{"Mandatory", false, false, constants.Mandatory, "when mutual SSL is enabled in the extension"},
+		{"optional", true, true, constants.Mandatory, "when the api.yaml overrides the extension"},
+		{"required", false, false, constants.NotDefined, "when the extension is invalid"},
+	}
+	for _, item := range dataItems {
+		swagger := MgwSwagger{vendorExtensions: map[string]interface{}{}}
+		if item.extension != nil {
+			swagger.vendorExtensions[constants.XWso2MutualSSL] = item.extension
+		}
+		swagger.SanitizeAPISecurity(false, false, item.yamlMutualSSL, item.yamlMandatory, false)
+		assert.Equal(t, item.expectedMutualSSL, swagger.GetXWSO2MutualSSL(), item.message)
+	}
+}
+
+func TestProcessEndpointUrlsWithServiceURLs(t *testing.T) {
+	defer func(enabled bool) { svcdiscovery.IsEurekaEnabled = enabled }(svcdiscovery.IsEurekaEnabled)
+
+	svcdiscovery.IsEurekaEnabled = false
+	_, err := processEndpointUrls([]interface{}{"eureka://PAYMENTS/v1"})
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "service discovery with eureka is not enabled for the endpoint eureka://PAYMENTS/v1", err.Error())
+	}
+
+	svcdiscovery.IsEurekaEnabled = true
+	endpoints, err := processEndpointUrls([]interface{}{"eureka+https://PAYMENTS/v1"})
+	assert.Nil(t, err)
+	if assert.Len(t, endpoints, 1) {
+		assert.Equal(t, "PAYMENTS", endpoints[0].Host)
+		assert.Equal(t, uint32(443), endpoints[0].Port)
+		assert.Equal(t, "https", endpoints[0].URLType)
+		assert.Equal(t, "/v1", endpoints[0].Basepath)
+		assert.Equal(t, "PAYMENTS", endpoints[0].ServiceDiscoveryString)
+		assert.Equal(t, svcdiscovery.RegistryEureka, endpoints[0].ServiceDiscoveryRegistry)
+	}
+}
+
+func TestSetInterceptors(t *testing.T) {
+	swagger := MgwSwagger{
+		vendorExtensions: map[string]interface{}{
+			constants.XWso2RequestInterceptor: map[string]interface{}{constants.ServiceURL: "http://old:8080"},
+		},
+		resources: []*Resource{{path: "/pets"}, {path: "/pets/{id}"}},
+	}
+	var interceptors InterceptorsYaml
+	interceptors.Data.Request = &InterceptorService{ServiceURL: "https://interceptor:8443",
+		Includes: []string{"request_headers", "invocation_context"}, RequestTimeout: 10, Certificate: "interceptor.crt"}
+	interceptors.Data.Resources = []ResourceInterceptor{{Target: "/pets/{id}",
+		Response: &InterceptorService{ServiceURL: "http://response-interceptor:8080"}}}
+	assert.Nil(t, swagger.SetInterceptors(interceptors))
+
+	apiInterceptor := swagger.GetInterceptor(swagger.GetVendorExtensions(), constants.XWso2RequestInterceptor, "api")
+	assert.True(t, apiInterceptor.Enable)
+	assert.Equal(t, "interceptor", apiInterceptor.EndpointCluster.Endpoints[0].Host, "Definition extension is not overridden")
+	assert.Equal(t, time.Duration(10), apiInterceptor.RequestTimeout)
+	assert.True(t, apiInterceptor.Includes.RequestHeaders)
+	assert.True(t, apiInterceptor.Includes.InvocationContext)
+	assert.False(t, apiInterceptor.Includes.RequestBody)
+
+	assert.Nil(t, swagger.resources[0].vendorExtensions, "Interceptor is set to an undeclared resource")
+	resourceInterceptor := swagger.GetInterceptor(swagger.resources[1].vendorExtensions,
+		constants.XWso2ResponseInterceptor, "resource")
+	assert.True(t, resourceInterceptor.Enable)
+	assert.Equal(t, "response-interceptor", resourceInterceptor.EndpointCluster.Endpoints[0].Host)
+	assert.Equal(t, map[string]string{"https://interceptor:8443": "interceptor.crt"},
+		interceptors.GetInterceptorCertificates())
+
+	interceptors.Data.Resources = []ResourceInterceptor{{Target: "/stores"}}
+	assert.NotNil(t, swagger.SetInterceptors(interceptors), "Interceptor of an unknown resource is accepted")
+}
+
+func TestSetInfoWSDL(t *testing.T) {
+	wsdl := `<?xml version="1.0" encoding="UTF-8"?>
+<wsdl:definitions name="PhoneVerify" xmlns:wsdl="http://schemas.xmlsoap.org/wsdl/"
+    xmlns:soap="http://schemas.xmlsoap.org/wsdl/soap/" xmlns:soap12="http://schemas.xmlsoap.org/wsdl/soap12/"
+    xmlns:http="http://schemas.xmlsoap.org/wsdl/http/" xmlns:tns="http://ws.cdyne.com/PhoneVerify/query">
+  <wsdl:binding name="PhoneVerifySoap" type="tns:PhoneVerifySoap">
+    <soap:binding transport="http://schemas.xmlsoap.org/soap/http"/>
+    <wsdl:operation name="CheckPhoneNumber">
+      <soap:operation soapAction="http://ws.cdyne.com/PhoneVerify/query/CheckPhoneNumber" style="document"/>
+    </wsdl:operation>
+  </wsdl:binding>
+  <wsdl:binding name="PhoneVerifySoap12" type="tns:PhoneVerifySoap">
+    <soap12:binding transport="http://schemas.xmlsoap.org/soap/http"/>
+    <wsdl:operation name="CheckPhoneNumber">
+      <soap12:operation soapAction="http://ws.cdyne.com/PhoneVerify/query/CheckPhoneNumber" style="document"/>
+    </wsdl:operation>
+  </wsdl:binding>
+  <wsdl:binding name="PhoneVerifyHttpGet" type="tns:PhoneVerifyHttpGet">
+    <http:binding verb="GET"/>
+    <wsdl:operation name="CheckPhoneNumbers"/>
+  </wsdl:binding>
+  <wsdl:service name="PhoneVerify">
+    <wsdl:port name="PhoneVerifySoap12" binding="tns:PhoneVerifySoap12">
+      <soap12:address location="http://ws.cdyne.com/phoneverify/phoneverify12.asmx"/>
+    </wsdl:port>
+    <wsdl:port name="PhoneVerifySoap" binding="tns:PhoneVerifySoap">
+      <soap:address location="https://ws.cdyne.com/phoneverify/phoneverify.asmx"/>
+    </wsdl:port>
+  </wsdl:service>
+</wsdl:definitions>`
+
+	var mgwSwagger MgwSwagger
+	err := mgwSwagger.SetInfoWSDL([]byte(wsdl))
+	assert.Nil(t, err, "Error while populating the WSDL definition")
+	assert.Equal(t, []string{constants.SOAP11Version, constants.SOAP12Version}, mgwSwagger.GetSoapVersions())
+	if assert.Len(t, mgwSwagger.GetResources(), 1, "Passthrough resource is not created") {
+		resource := mgwSwagger.GetResources()[0]
+		assert.Equal(t, constants.SOAPPassthroughResource, resource.GetPath())
+		assert.Equal(t, "POST", resource.GetMethod()[0].GetMethod())
+	}
+	if assert.NotNil(t, mgwSwagger.GetProdEndpoints()) {
+		endpoint := mgwSwagger.GetProdEndpoints().Endpoints[0]
+		assert.Equal(t, "ws.cdyne.com", endpoint.Host)
+		assert.Equal(t, uint32(443), endpoint.Port, "SOAP 1.1 address should be preferred")
+		assert.Equal(t, "/phoneverify/phoneverify.asmx", endpoint.Basepath)
+	}
+
+	wsdl20 := `<description xmlns="http://www.w3.org/ns/wsdl"><service name="PhoneVerify"></service></description>`
+	assert.NotNil(t, mgwSwagger.SetInfoWSDL([]byte(wsdl20)), "WSDL 2.0 definition is accepted")
+	httpOnly := `<definitions xmlns="http://schemas.xmlsoap.org/wsdl/" xmlns:http="http://schemas.xmlsoap.org/wsdl/http/">
+  <binding name="PhoneVerifyHttpGet"><http:binding verb="GET"/></binding></definitions>`
+	assert.NotNil(t, mgwSwagger.SetInfoWSDL([]byte(httpOnly)), "WSDL definition without a SOAP binding is accepted")
+}
+
+func TestResolveWSDLImports(t *testing.T) {
+	files := map[string]string{
+		"service.wsdl": `<wsdl:definitions name="PhoneVerify" xmlns:wsdl="http://schemas.xmlsoap.org/wsdl/"
+    xmlns:soap="http://schemas.xmlsoap.org/wsdl/soap/" xmlns:tns="http://ws.cdyne.com/PhoneVerify/query">
+  <wsdl:import namespace="http://ws.cdyne.com/PhoneVerify/query" location="bindings/bindings.wsdl"/>
+  <wsdl:service name="PhoneVerify">
+    <wsdl:port name="PhoneVerifySoap" binding="tns:PhoneVerifySoap">
+      <soap:address location="https://ws.cdyne.com/phoneverify/phoneverify.asmx"/>
+    </wsdl:port>
+  </wsdl:service>
+</wsdl:definitions>`,
+		"bindings/bindings.wsdl": `<definitions xmlns="http://schemas.xmlsoap.org/wsdl/"
+    xmlns:soap12="http://schemas.xmlsoap.org/wsdl/soap12/">
+  <import location="../service.wsdl"/>
+  <import location="https://ws.cdyne.com/phoneverify/types.wsdl"/>
+  <binding name="PhoneVerifySoap"><soap12:binding transport="http://schemas.xmlsoap.org/soap/http"/></binding>
+</definitions>`,
+	}
+	readFile := func(fileName string) ([]byte, error) {
+		content, found := files[fileName]
+		if !found {
+			return nil, fmt.Errorf("file %s is not found", fileName)
+		}
+		return []byte(content), nil
+	}
+
+	// the service declaring definition is composed with the bindings of the imported definitions
+	content, err := ResolveWSDLImports("service.wsdl", readFile)
+	assert.Nil(t, err)
+	var mgwSwagger MgwSwagger
+	err = mgwSwagger.SetInfoWSDL(content)
+	assert.Nil(t, err, "Error while populating the WSDL definition composed with the imports")
+	assert.Equal(t, "PhoneVerify", mgwSwagger.GetTitle())
+	assert.Equal(t, []string{constants.SOAP12Version}, mgwSwagger.GetSoapVersions())
+	if assert.NotNil(t, mgwSwagger.GetProdEndpoints(), "Address of the SOAP service is not read") {
+		assert.Equal(t, "ws.cdyne.com", mgwSwagger.GetProdEndpoints().Endpoints[0].Host)
+	}
+
+	content, err = ResolveWSDLImports("bindings/bindings.wsdl", readFile)
+	assert.Nil(t, err)
+	wsdl, err := ParseWSDL(content)
+	assert.Nil(t, err)
+	assert.Len(t, wsdl.Services, 1, "Service of the definition importing the bindings is not resolved")
+
+	files["service.wsdl"] = strings.Replace(files["service.wsdl"], "bindings/bindings.wsdl", "bindings.wsdl", 1)
+	_, err = ResolveWSDLImports("service.wsdl", readFile)
+	assert.NotNil(t, err, "Import of a file not found in the archive is accepted")
+}
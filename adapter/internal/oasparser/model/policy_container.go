@@ -199,6 +199,62 @@ func (p PolicyContainerMap) getFormattedPolicyFromTemplated(policy Policy, flow
 	return policy, nil
 }
 
+// UnsupportedPolicy represents an operation policy which cannot be applied by the gateway
+type UnsupportedPolicy struct {
+	Flow   string
+	Policy string
+	Reason string
+}
+
+// GetUnsupportedPolicies returns the policies, which cannot be applied by the gateway. Unlike
+// GetFormattedOperationalPolicies, all the policies are checked and nothing is logged.
+func (p PolicyContainerMap) GetUnsupportedPolicies(policies OperationPolicies) []UnsupportedPolicy {
+	var unsupportedPolicies []UnsupportedPolicy
+	flows := map[PolicyFlow]PolicyList{
+		policyInFlow:    policies.Request,
+		policyOutFlow:   policies.Response,
+		policyFaultFlow: policies.Fault,
+	}
+	for _, flow := range []PolicyFlow{policyInFlow, policyOutFlow, policyFaultFlow} {
+		for _, policy := range flows[flow] {
+			if err := p.checkPolicySupport(policy, flow); err != nil {
+				unsupportedPolicies = append(unsupportedPolicies, UnsupportedPolicy{
+					Flow:   string(flow),
+					Policy: policy.GetFullName(),
+					Reason: err.Error(),
+				})
+			}
+		}
+	}
+	return unsupportedPolicies
+}
+
+func (p PolicyContainerMap) checkPolicySupport(policy Policy, flow PolicyFlow) error {
+	container, found := p[policy.GetFullName()]
+	if !found {
+		return errors.New("policy specification and definition not found in the API project")
+	}
+	if err := container.Specification.validatePolicy(policy, flow); err != nil {
+		return err
+	}
+	t, err := template.New("policy-def").Funcs(policyDefFuncMap).Parse(string(container.Definition.RawData))
+	if err != nil {
+		return fmt.Errorf("invalid policy definition: %v", err)
+	}
+	var out bytes.Buffer
+	if err = t.Execute(&out, policy.Parameters); err != nil {
+		return fmt.Errorf("invalid policy definition: %v", err)
+	}
+	def := PolicyDefinition{}
+	if err := yaml.Unmarshal(out.Bytes(), &def); err != nil {
+		return fmt.Errorf("invalid policy definition: %v", err)
+	}
+	policy.Parameters = def.Definition.Parameters
+	policy.Action = def.Definition.Action
+	container.Specification.fillDefaultsInPolicy(&policy)
+	return validatePolicyAction(&policy)
+}
+
 // validatePolicy validates the given policy against the spec
 func (spec *PolicySpecification) validatePolicy(policy Policy, flow PolicyFlow) error {
 	if spec.Data.Name != policy.PolicyName || spec.Data.Version != policy.PolicyVersion {
@@ -164,6 +164,87 @@ func TestAPIProjectGetFormattedPolicyFromTemplated(t *testing.T) {
 	assert.Equal(t, expFormattedP, actualFormattedP, "Converting operational policies to Choreo Connect format failed")
 }
 
+func TestGetUnsupportedPolicies(t *testing.T) {
+	spec := getSampleTestPolicySpec()
+	specInvalid := getSampleTestPolicySpec()
+	specInvalid.Data.Name = "fooAddRequestHeaderInvalid1"
+	policies := PolicyContainerMap{
+		"fooAddRequestHeader_v1": {
+			Specification: spec,
+			Definition:    PolicyDefinition{RawData: getSampleTestPolicyDef()},
+		},
+		"fooAddRequestHeaderInvalid1_v1": {
+			Specification: specInvalid,
+			Definition:    PolicyDefinition{RawData: getSampleInvalidTestPolicyDef1()},
+		},
+	}
+	params := map[string]interface{}{"fooName": "fooHeaderName", "fooValue": "fooHeaderValue"}
+	operationPolicies := OperationPolicies{
+		Request: PolicyList{
+			{PolicyName: "fooAddRequestHeader", PolicyVersion: "v1", Parameters: params},
+			{PolicyName: "fooAddRequestHeaderInvalid1", PolicyVersion: "v1", Parameters: params},
+			{PolicyName: "fooNotFound", PolicyVersion: "v1", Parameters: params},
+		},
+		Response: PolicyList{
+			{PolicyName: "fooAddRequestHeader", PolicyVersion: "v1", Parameters: params},
+		},
+	}
+
+	unsupportedPolicies := policies.GetUnsupportedPolicies(operationPolicies)
+	assert.Equal(t, 3, len(unsupportedPolicies), "Unsupported policy count mismatch")
+	assert.Equal(t, "fooAddRequestHeaderInvalid1_v1", unsupportedPolicies[0].Policy)
+	assert.Contains(t, unsupportedPolicies[0].Reason, "SET_HEADER_INVALID_ACTION", "Unsupported action should be reported")
+	assert.Equal(t, "fooNotFound_v1", unsupportedPolicies[1].Policy)
+	assert.Equal(t, "request", unsupportedPolicies[1].Flow)
+	assert.Equal(t, "fooAddRequestHeader_v1", unsupportedPolicies[2].Policy)
+	assert.Equal(t, "response", unsupportedPolicies[2].Flow, "Policy should not be applicable to the response flow")
+	assert.Equal(t, "fooHeaderName", params["fooName"], "Policy parameters should not be altered")
+}
+
+func TestSetOperationPoliciesWithAPIPolicies(t *testing.T) {
+	apiHeader := map[string]interface{}{"fooName": "apiHeader", "fooValue": "apiValue"}
+	operationHeader := map[string]interface{}{"fooName": "operationHeader", "fooValue": "operationValue"}
+	apiYaml := APIYaml{}
+	apiYaml.Data.APIPolicies = OperationPolicies{
+		Request: PolicyList{{PolicyName: "fooAddRequestHeader", PolicyVersion: "v1", Parameters: apiHeader}},
+	}
+	apiYaml.Data.Operations = []OperationYaml{
+		{
+			Target: "/pets",
+			Verb:   "POST",
+			OperationPolicies: OperationPolicies{
+				Request: PolicyList{{PolicyName: "fooAddRequestHeader", PolicyVersion: "v1", Parameters: operationHeader}},
+			},
+		},
+	}
+	proj := ProjectAPI{
+		APIYaml: apiYaml,
+		Policies: map[string]PolicyContainer{
+			"fooAddRequestHeader_v1": {
+				Specification: getSampleTestPolicySpec(),
+				Definition:    PolicyDefinition{RawData: getSampleTestPolicyDef()},
+			},
+		},
+	}
+	swagger := MgwSwagger{
+		resources: []*Resource{
+			{path: "/pets", methods: []*Operation{{method: "POST"}, {method: "GET"}}},
+		},
+	}
+
+	err := swagger.SetOperationPolicies(proj)
+	assert.Nil(t, err)
+	assert.True(t, swagger.resources[0].hasPolicies)
+	postPolicies := swagger.resources[0].methods[0].GetPolicies().Request
+	assert.Equal(t, 2, len(postPolicies), "API level policy should be applied along with the operation level policy")
+	assert.Equal(t, "apiHeader", postPolicies[0].Parameters.(map[string]interface{})["headerName"],
+		"API level policy should be applied first in the request flow")
+	assert.Equal(t, "operationHeader", postPolicies[1].Parameters.(map[string]interface{})["headerName"])
+	getPolicies := swagger.resources[0].methods[1].GetPolicies().Request
+	assert.Equal(t, 1, len(getPolicies), "API level policy should be applied to the operations without policies")
+	assert.Nil(t, swagger.resources[0].methods[1].GetPolicies().Response)
+}
+
 func getSampleTestPolicySpec() PolicySpecification {
 	spec := PolicySpecification{}
 	spec.Data.Name = "fooAddRequestHeader"
@@ -34,6 +34,14 @@ var supportedPoliciesMap = map[string]policyLayout{
 		RequiredParams:   []string{constants.HeaderName},
 		IsPassToEnforcer: false,
 	},
+	constants.ActionHeaderAppend: {
+		RequiredParams:   []string{constants.HeaderName, constants.HeaderValue},
+		IsPassToEnforcer: false,
+	},
+	constants.ActionHeaderRename: {
+		RequiredParams:   []string{constants.HeaderName, constants.NewHeaderName},
+		IsPassToEnforcer: false,
+	},
 	"ADD_QUERY": {
 		RequiredParams:   []string{"queryParamName", "queryParamValue"},
 		IsPassToEnforcer: true,
@@ -50,9 +58,17 @@ var supportedPoliciesMap = map[string]policyLayout{
 		RequiredParams:   []string{constants.RewritePathResourcePath, constants.IncludeQueryParams},
 		IsPassToEnforcer: true,
 	},
-	"OPA": {
+	constants.ActionRewriteURL: {
+		RequiredParams:   []string{constants.RewriteURLPattern, constants.RewriteURLSubstitution},
+		IsPassToEnforcer: false,
+	},
+	constants.ActionOverrideMethod: {
+		RequiredParams:   []string{constants.UpdatedMethod},
+		IsPassToEnforcer: false,
+	},
+	constants.ActionOPA: {
 		// Following parameters are not required (optional)
-		// "rule", token", "additionalProperties", "sendAccessToken", "maxOpenConnections", "maxPerRoute"
+		// "rule", "accessKey", "additionalProperties", "sendAccessToken", "maxOpenConnections", "maxPerRoute"
 		// "connectionTimeout", "requestGenerator"
 		RequiredParams:   []string{"serverURL", "policy"},
 		IsPassToEnforcer: true,
@@ -28,12 +28,27 @@ type ProjectAPI struct {
 	Deployments         []Deployment
 	APIDefinition       []byte
 	InterceptorCerts    []byte
+	InterceptorCertMap  map[string][]byte  // cert filename -> cert bytes, of the interceptor services
+	Interceptors        InterceptorsYaml   // interceptor services declared in interceptors.yaml
 	UpstreamCerts       map[string][]byte  // cert filename -> cert bytes
 	EndpointCerts       map[string]string  // url -> cert filename
 	Policies            PolicyContainerMap // read from policy dir, policyName -> {policy spec, policy definition}
 	DownstreamCerts     map[string][]byte  // cert filename -> cert bytes
 	ClientCerts         []CertificateDetails
 	GraphQLComplexities GraphQLComplexityYaml
+	ProtoDescriptor     []byte            // proto descriptor set of the gRPC services, which the requests are transcoded to
+	WSDL                []byte            // WSDL 1.1 definition of the SOAP service, which the requests are passed through to
+	DeployedBy          string            // user or the component deploying the project, recorded in the audit journal
+	APIDocs             map[string][]byte // <doc name>/<doc file name> -> doc content
+	UpstreamClientCerts map[string][]byte // cert or key filename -> content, of the client certs presented to the backends
+	EndpointClientCerts []EndpointClientCertificate
+	Files               []ProjectFile // files of the project in the order they are read, recorded in the audit journal
+}
+
+// ProjectFile represents a file of an API project
+type ProjectFile struct {
+	Name    string
+	Content []byte
 }
 
 // DeploymentEnvironments represents content of deployment_environments.yaml file
@@ -66,6 +81,22 @@ type EndpointCertificate struct {
 	Certificate string `json:"certificate"`
 }
 
+// EndpointClientCertificatesDetails represents content of endpoint_client_certificates.yaml file
+// of an API_CTL Project
+type EndpointClientCertificatesDetails struct {
+	Type    string                      `yaml:"type" json:"type"`
+	Version string                      `yaml:"version" json:"version"`
+	Data    []EndpointClientCertificate `json:"data"`
+}
+
+// EndpointClientCertificate represents the client certificate and the private key presented to an endpoint
+// for mutual TLS. If the endpoint is not provided, they are presented to all the endpoints of the API.
+type EndpointClientCertificate struct {
+	Endpoint    string `json:"endpoint"`
+	Certificate string `json:"certificate"`
+	Key         string `json:"key"`
+}
+
 // ClientCertificatesDetails represents content of client_certificates.yaml file
 // of an API_CTL Project
 type ClientCertificatesDetails struct {
@@ -0,0 +1,102 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package model
+
+import (
+	"errors"
+	"strings"
+)
+
+// RevisionTrafficSplit holds the details of the traffic split between a newly deployed revision of an API
+// and the revision which was deployed before.
+type RevisionTrafficSplit struct {
+	NewRevisionID      string
+	PreviousRevisionID string
+	// NewRevisionWeight is the percentage of the traffic routed to the new revision
+	NewRevisionWeight uint32
+	// TesterHeader is the request header used to pin a request to a revision
+	TesterHeader string
+}
+
+// GetRevisionTrafficSplit returns the revision traffic split of the API. Returns nil if the traffic is not split.
+func (swagger *MgwSwagger) GetRevisionTrafficSplit() *RevisionTrafficSplit {
+	return swagger.revisionTrafficSplit
+}
+
+// SetRevisionTrafficSplit merges the API level endpoints of the previous revision to the API level endpoints
+// of the new revision, weighted as per the provided split. The endpoints of each revision are labelled with
+// the revision ID, so that the testers can select the revision with the tester header.
+//
+// Resource level endpoints are not split. An error is returned, without modifying the swagger, if the
+// endpoints of the two revisions cannot be merged.
+func (swagger *MgwSwagger) SetRevisionTrafficSplit(prevProdEndpoints, prevSandEndpoints *EndpointCluster,
+	split RevisionTrafficSplit) error {
+	if split.NewRevisionWeight == 0 || split.NewRevisionWeight >= 100 {
+		return errors.New("new revision weight should be between 0 and 100 exclusively")
+	}
+	prodEndpoints, err := mergeRevisionEndpoints(swagger.productionEndpoints, prevProdEndpoints, split)
+	if err != nil {
+		return errors.New("production endpoints: " + err.Error())
+	}
+	sandEndpoints, err := mergeRevisionEndpoints(swagger.sandboxEndpoints, prevSandEndpoints, split)
+	if err != nil {
+		return errors.New("sandbox endpoints: " + err.Error())
+	}
+	swagger.productionEndpoints = prodEndpoints
+	swagger.sandboxEndpoints = sandEndpoints
+	swagger.revisionTrafficSplit = &split
+	return nil
+}
+
+// mergeRevisionEndpoints returns a new endpoint cluster containing the endpoints of both revisions.
+// The weights are assigned such that the new revision receives the configured percentage of the traffic,
+// irrespective of the endpoint count of each revision.
+func mergeRevisionEndpoints(newEndpoints, prevEndpoints *EndpointCluster,
+	split RevisionTrafficSplit) (*EndpointCluster, error) {
+	if newEndpoints == nil || len(newEndpoints.Endpoints) == 0 || prevEndpoints == nil ||
+		len(prevEndpoints.Endpoints) == 0 {
+		return newEndpoints, nil
+	}
+	if strings.HasPrefix(newEndpoints.EndpointType, "failover") ||
+		strings.HasPrefix(prevEndpoints.EndpointType, "failover") {
+		return nil, errors.New("failover endpoints cannot be split between revisions")
+	}
+	basePath := strings.TrimSuffix(newEndpoints.Endpoints[0].Basepath, "/")
+	for _, ep := range prevEndpoints.Endpoints {
+		if strings.TrimSuffix(ep.Basepath, "/") != basePath {
+			return nil, errors.New("endpoint basepath of the previous revision " + ep.Basepath +
+				" does not match with " + basePath)
+		}
+	}
+
+	newWeight := split.NewRevisionWeight * uint32(len(prevEndpoints.Endpoints))
+	prevWeight := (100 - split.NewRevisionWeight) * uint32(len(newEndpoints.Endpoints))
+	merged := *newEndpoints
+	merged.Endpoints = make([]Endpoint, 0, len(newEndpoints.Endpoints)+len(prevEndpoints.Endpoints))
+	for _, ep := range newEndpoints.Endpoints {
+		ep.Revision = split.NewRevisionID
+		ep.LbWeight = newWeight
+		merged.Endpoints = append(merged.Endpoints, ep)
+	}
+	for _, ep := range prevEndpoints.Endpoints {
+		ep.Revision = split.PreviousRevisionID
+		ep.LbWeight = prevWeight
+		merged.Endpoints = append(merged.Endpoints, ep)
+	}
+	return &merged, nil
+}
@@ -188,6 +188,7 @@ func setSecurityDefinitions(swagger2 spec.Swagger) []SecurityScheme {
 
 	for key, val := range swagger2.SecurityDefinitions {
 		scheme := SecurityScheme{DefinitionName: key, Type: val.Type, Name: val.Name, In: val.In}
+		validateSecurityScheme(scheme)
 		securitySchemes = append(securitySchemes, scheme)
 	}
 	logger.LoggerOasparser.Debugf("Security schemes in setSecurityDefinitions  %v:", securitySchemes)
@@ -0,0 +1,288 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package model
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/constants"
+)
+
+// xml namespaces of the WSDL 1.1 definitions and the SOAP bindings
+const (
+	wsdl11Namespace        = "http://schemas.xmlsoap.org/wsdl/"
+	wsdl20Namespace        = "http://www.w3.org/ns/wsdl"
+	soap11BindingNamespace = "http://schemas.xmlsoap.org/wsdl/soap/"
+	soap12BindingNamespace = "http://schemas.xmlsoap.org/wsdl/soap12/"
+)
+
+// WSDL is the struct for the WSDL 1.1 definition of a SOAP service. Only the SOAP bindings and the ports of the
+// services are read, as the messages are passed through to the backend as they are.
+type WSDL struct {
+	XMLName  xml.Name      `xml:"definitions"`
+	Name     string        `xml:"name,attr"`
+	Imports  []wsdlImport  `xml:"import"`
+	Bindings []wsdlBinding `xml:"binding"`
+	Services []wsdlService `xml:"service"`
+}
+
+// wsdlDefinitions is the WSDL definition composed of a definition and the definitions imported by that, which is
+// marshalled with the WSDL 1.1 namespace.
+type wsdlDefinitions struct {
+	XMLName  xml.Name      `xml:"http://schemas.xmlsoap.org/wsdl/ definitions"`
+	Name     string        `xml:"name,attr,omitempty"`
+	Bindings []wsdlBinding `xml:"binding"`
+	Services []wsdlService `xml:"service"`
+}
+
+type wsdlImport struct {
+	Namespace string `xml:"namespace,attr"`
+	Location  string `xml:"location,attr"`
+}
+
+type wsdlBinding struct {
+	Name        string          `xml:"name,attr"`
+	SOAPBinding []wsdlExtension `xml:"binding"`
+}
+
+type wsdlService struct {
+	Name  string `xml:"name,attr"`
+	Ports []struct {
+		Name        string          `xml:"name,attr"`
+		Binding     string          `xml:"binding,attr"`
+		SOAPAddress []wsdlExtension `xml:"address"`
+	} `xml:"port"`
+}
+
+// wsdlExtension is a SOAP extension element of a binding or a port (ie: soap:binding or soap12:address), where the
+// namespace identifies the SOAP version.
+type wsdlExtension struct {
+	XMLName  xml.Name
+	Location string `xml:"location,attr,omitempty"`
+}
+
+// getSOAPVersion returns the SOAP version of a binding extension, or an empty string if the extension is not a SOAP
+// extension (ie: an http:binding).
+func (extension wsdlExtension) getSOAPVersion() string {
+	switch extension.XMLName.Space {
+	case soap11BindingNamespace:
+		return constants.SOAP11Version
+	case soap12BindingNamespace:
+		return constants.SOAP12Version
+	}
+	return ""
+}
+
+// ParseWSDL parses a WSDL 1.1 definition. An error is returned if the definition is not a WSDL 1.1 definition or
+// does not declare any SOAP binding.
+func ParseWSDL(content []byte) (*WSDL, error) {
+	wsdl, err := parseWSDLDefinitions(content)
+	if err != nil {
+		return nil, err
+	}
+	if len(wsdl.getSOAPVersions()) == 0 {
+		return nil, errors.New("no SOAP 1.1 or SOAP 1.2 binding is found in the WSDL definition")
+	}
+	return wsdl, nil
+}
+
+// parseWSDLDefinitions parses a WSDL 1.1 definition, which may not declare any binding (ie: a definition importing
+// the bindings from another definition).
+func parseWSDLDefinitions(content []byte) (*WSDL, error) {
+	var wsdl WSDL
+	if err := xml.Unmarshal(content, &wsdl); err != nil {
+		var root struct {
+			XMLName xml.Name
+		}
+		if xml.Unmarshal(content, &root) == nil && root.XMLName.Space == wsdl20Namespace {
+			return nil, errors.New("WSDL 2.0 definitions are not supported, use a WSDL 1.1 definition")
+		}
+		return nil, fmt.Errorf("invalid WSDL definition. %v", err)
+	}
+	if wsdl.XMLName.Space != wsdl11Namespace {
+		return nil, fmt.Errorf("namespace %q of the definitions is not the WSDL 1.1 namespace", wsdl.XMLName.Space)
+	}
+	return &wsdl, nil
+}
+
+// ResolveWSDLImports returns the WSDL 1.1 definition of the file, composed with the bindings and the services of
+// the definitions imported by that (wsdl:import). The imported files are read by their paths, relative to the
+// importing file. The definitions imported by a URL are not resolved.
+func ResolveWSDLImports(fileName string, readFile func(fileName string) ([]byte, error)) ([]byte, error) {
+	content, err := readFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+	wsdl, err := parseWSDLDefinitions(content)
+	if err != nil {
+		return nil, err
+	}
+	if len(wsdl.Imports) == 0 {
+		return content, nil
+	}
+	definitions := wsdlDefinitions{Name: wsdl.Name, Bindings: wsdl.Bindings, Services: wsdl.Services}
+	resolved := map[string]bool{path.Clean(fileName): true}
+	type importingFile struct {
+		fileName string
+		imports  []wsdlImport
+	}
+	pending := []importingFile{{fileName, wsdl.Imports}}
+	for len(pending) > 0 {
+		importer, imports := pending[0].fileName, pending[0].imports
+		pending = pending[1:]
+		for _, definitionImport := range imports {
+			if definitionImport.Location == "" || strings.Contains(definitionImport.Location, "://") {
+				loggers.LoggerOasparser.Debugf("Import %q of the WSDL definition %s is not resolved",
+					definitionImport.Location, importer)
+				continue
+			}
+			importedFile := path.Join(path.Dir(importer), definitionImport.Location)
+			if resolved[importedFile] {
+				continue
+			}
+			resolved[importedFile] = true
+			importedContent, err := readFile(importedFile)
+			if err != nil {
+				return nil, fmt.Errorf("error while reading the WSDL definition %s imported by %s. %v",
+					importedFile, importer, err)
+			}
+			imported, err := parseWSDLDefinitions(importedContent)
+			if err != nil {
+				return nil, fmt.Errorf("invalid WSDL definition %s imported by %s. %v", importedFile, importer, err)
+			}
+			definitions.Bindings = append(definitions.Bindings, imported.Bindings...)
+			definitions.Services = append(definitions.Services, imported.Services...)
+			pending = append(pending, importingFile{importedFile, imported.Imports})
+		}
+	}
+	return xml.Marshal(definitions)
+}
+
+// getSOAPBindingVersions returns the SOAP version of each SOAP binding, by the name of the binding.
+func (wsdl *WSDL) getSOAPBindingVersions() map[string]string {
+	versions := make(map[string]string)
+	for _, binding := range wsdl.Bindings {
+		for _, extension := range binding.SOAPBinding {
+			if version := extension.getSOAPVersion(); version != "" {
+				versions[binding.Name] = version
+			}
+		}
+	}
+	return versions
+}
+
+// getSOAPVersions returns the SOAP versions of the bindings, in the ascending order.
+func (wsdl *WSDL) getSOAPVersions() []string {
+	var versions []string
+	for _, version := range wsdl.getSOAPBindingVersions() {
+		if !arrayContains(versions, version) {
+			versions = append(versions, version)
+		}
+	}
+	sort.Strings(versions)
+	return versions
+}
+
+// getServiceAddress returns the address of the first SOAP port of the services, preferring a SOAP 1.1 port as
+// the SOAP 1.1 requests are accepted by most of the SOAP 1.2 services as well.
+func (wsdl *WSDL) getServiceAddress() string {
+	address := ""
+	for _, service := range wsdl.Services {
+		for _, port := range service.Ports {
+			for _, extension := range port.SOAPAddress {
+				switch extension.getSOAPVersion() {
+				case constants.SOAP11Version:
+					return extension.Location
+				case constants.SOAP12Version:
+					if address == "" {
+						address = extension.Location
+					}
+				}
+			}
+		}
+	}
+	return address
+}
+
+// SetInfoWSDL populates the MgwSwagger object of a SOAP API with the SOAP bindings of the WSDL definition. If the
+// API is not accompanied by an OpenAPI definition, the requests of the API are passed through to the backend by
+// a POST resource matching any path. The address of the service is the endpoint, unless the api.yaml has the
+// endpoints of the API.
+func (swagger *MgwSwagger) SetInfoWSDL(content []byte) error {
+	wsdl, err := ParseWSDL(content)
+	if err != nil {
+		return err
+	}
+	swagger.soapVersions = wsdl.getSOAPVersions()
+	if swagger.title == "" {
+		swagger.title = wsdl.Name
+	}
+	if len(swagger.resources) == 0 {
+		operation := NewOperation("POST", nil, map[string]interface{}{})
+		resource := unmarshalSwaggerResources(constants.SOAPPassthroughResource, []*Operation{operation},
+			map[string]interface{}{})
+		swagger.resources = []*Resource{&resource}
+	}
+	if swagger.productionEndpoints == nil && swagger.EndpointImplementationType != constants.MockedOASEndpointType {
+		if address := wsdl.getServiceAddress(); address != "" {
+			endpoint, err := getHTTPEndpoint(address)
+			if err != nil {
+				return fmt.Errorf("invalid address %q of the SOAP service. %v", address, err)
+			}
+			swagger.productionEndpoints = generateEndpointCluster(constants.ProdClustersConfigNamePrefix,
+				[]Endpoint{*endpoint}, constants.LoadBalance)
+		}
+	}
+	return nil
+}
+
+// GetSoapVersions returns the SOAP versions (1.1 and/or 1.2) of the bindings of the WSDL definition of a SOAP API.
+// Empty if the API is not deployed with a WSDL definition, where the requests of any SOAP version are accepted.
+func (swagger *MgwSwagger) GetSoapVersions() []string {
+	return swagger.soapVersions
+}
+
+// SetSOAPAPIType marks an HTTP API project deployed with a WSDL definition but without an API definition as a SOAP
+// API, as the requests of such an API can only be passed through to the SOAP service.
+func (apiProject *ProjectAPI) SetSOAPAPIType() {
+	if apiProject.APIYaml.Data.APIType == constants.HTTP && len(apiProject.WSDL) > 0 &&
+		len(apiProject.APIDefinition) == 0 {
+		apiProject.APIYaml.Data.APIType = constants.SOAP
+	}
+}
+
+// PopulateFromAPIDefinitions populates the MgwSwagger object with the API definition of the API project and, for a
+// SOAP API, with the WSDL definition as well. The API definition is optional for a SOAP API with a WSDL definition.
+func (swagger *MgwSwagger) PopulateFromAPIDefinitions(apiProject *ProjectAPI) error {
+	isSOAPWithWSDL := swagger.apiType == constants.SOAP && len(apiProject.WSDL) > 0
+	if len(apiProject.APIDefinition) > 0 || !isSOAPWithWSDL {
+		if err := swagger.GetMgwSwagger(apiProject.APIDefinition); err != nil {
+			return err
+		}
+	}
+	if isSOAPWithWSDL {
+		return swagger.SetInfoWSDL(apiProject.WSDL)
+	}
+	return nil
+}
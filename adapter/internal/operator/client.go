@@ -0,0 +1,210 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package operator
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const requestTimeout = 30 * time.Second
+
+// errResourceVersionExpired is returned when the resource version the watch is started from is compacted, hence the
+// custom resources need to be listed again.
+var errResourceVersionExpired = errors.New("resource version is expired")
+
+// kubernetesClient lists and watches the API custom resources through the Kubernetes API server.
+type kubernetesClient struct {
+	apiServerURL string
+	// namespace of the custom resources, which is empty for all the namespaces
+	namespace string
+	// tokenFile is read per request, as the projected service account tokens are rotated
+	tokenFile  string
+	httpClient *http.Client
+}
+
+func newKubernetesClient(apiServerURL, namespace, tokenFile, caCertFile string) (*kubernetesClient, error) {
+	caCert, err := ioutil.ReadFile(caCertFile)
+	if err != nil {
+		return nil, fmt.Errorf("error reading the CA certificate of the API server. %v", err)
+	}
+	certPool := x509.NewCertPool()
+	if !certPool.AppendCertsFromPEM(caCert) {
+		return nil, fmt.Errorf("invalid CA certificate of the API server %s", caCertFile)
+	}
+	return &kubernetesClient{
+		apiServerURL: strings.TrimSuffix(apiServerURL, "/"),
+		namespace:    namespace,
+		tokenFile:    tokenFile,
+		httpClient: &http.Client{
+			Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: certPool}},
+		},
+	}, nil
+}
+
+// resourceURL returns the URL of the API custom resources of the namespace, or of all the namespaces if the
+// namespace is empty.
+func (client *kubernetesClient) resourceURL(namespace string) string {
+	resourceURL := client.apiServerURL + "/apis/" + apiGroup + "/" + apiVersion
+	if namespace != "" {
+		resourceURL += "/namespaces/" + url.PathEscape(namespace)
+	}
+	return resourceURL + "/" + apiResource
+}
+
+func (client *kubernetesClient) newRequest(ctx context.Context, method, requestURL string,
+	body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, requestURL, body)
+	if err != nil {
+		return nil, err
+	}
+	if client.tokenFile != "" {
+		token, err := ioutil.ReadFile(client.tokenFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading the service account token. %v", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	}
+	req.Header.Set("Accept", "application/json")
+	return req, nil
+}
+
+// list returns the API custom resources, along with the resource version the changes are watched from.
+func (client *kubernetesClient) list() (*apiList, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	req, err := client.newRequest(ctx, http.MethodGet, client.resourceURL(client.namespace), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, readErrorResponse(resp)
+	}
+	var apis apiList
+	if err = json.NewDecoder(resp.Body).Decode(&apis); err != nil {
+		return nil, fmt.Errorf("error decoding the API custom resources. %v", err)
+	}
+	return &apis, nil
+}
+
+// watch calls handle for the changes of the API custom resources since the resource version, until the timeout is
+// elapsed. Returns the resource version of the last change, to watch the subsequent changes from.
+func (client *kubernetesClient) watch(resourceVersion string, timeout time.Duration,
+	handle func(eventType string, api *API)) (string, error) {
+	query := url.Values{}
+	query.Set("watch", "true")
+	query.Set("resourceVersion", resourceVersion)
+	query.Set("allowWatchBookmarks", "true")
+	query.Set("timeoutSeconds", strconv.Itoa(int(timeout.Seconds())))
+	// the connection is closed by the API server once the timeout is elapsed
+	ctx, cancel := context.WithTimeout(context.Background(), timeout+requestTimeout)
+	defer cancel()
+	req, err := client.newRequest(ctx, http.MethodGet, client.resourceURL(client.namespace)+"?"+query.Encode(), nil)
+	if err != nil {
+		return resourceVersion, err
+	}
+	resp, err := client.httpClient.Do(req)
+	if err != nil {
+		return resourceVersion, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusGone {
+		return resourceVersion, errResourceVersionExpired
+	}
+	if resp.StatusCode != http.StatusOK {
+		return resourceVersion, readErrorResponse(resp)
+	}
+
+	decoder := json.NewDecoder(resp.Body)
+	for {
+		var event watchEvent
+		if err = decoder.Decode(&event); err != nil {
+			if err == io.EOF {
+				return resourceVersion, nil
+			}
+			return resourceVersion, fmt.Errorf("error decoding the watch event. %v", err)
+		}
+		if event.Type == eventError {
+			var errStatus status
+			json.Unmarshal(event.Object, &errStatus)
+			if errStatus.Code == http.StatusGone {
+				return resourceVersion, errResourceVersionExpired
+			}
+			return resourceVersion, fmt.Errorf("error watching the API custom resources. %s", errStatus.Message)
+		}
+		var api API
+		if err = json.Unmarshal(event.Object, &api); err != nil {
+			return resourceVersion, fmt.Errorf("error decoding the API custom resource. %v", err)
+		}
+		resourceVersion = api.Metadata.ResourceVersion
+		if event.Type != eventBookmark {
+			handle(event.Type, &api)
+		}
+	}
+}
+
+// updateStatus updates the status subresource of the API custom resource.
+func (client *kubernetesClient) updateStatus(api *API, apiStatus APIStatus) error {
+	patch, err := json.Marshal(map[string]interface{}{"status": apiStatus})
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	statusURL := client.resourceURL(api.Metadata.Namespace) + "/" + url.PathEscape(api.Metadata.Name) + "/status"
+	req, err := client.newRequest(ctx, http.MethodPatch, statusURL, bytes.NewReader(patch))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+	resp, err := client.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return readErrorResponse(resp)
+	}
+	return nil
+}
+
+func readErrorResponse(resp *http.Response) error {
+	var errStatus status
+	body, _ := ioutil.ReadAll(resp.Body)
+	if json.Unmarshal(body, &errStatus) == nil && errStatus.Message != "" {
+		return fmt.Errorf("%s (%d)", errStatus.Message, resp.StatusCode)
+	}
+	return fmt.Errorf("unexpected response from the Kubernetes API server (%d)", resp.StatusCode)
+}
@@ -0,0 +1,247 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+// Package operator deploys the APIs of the API custom resources of a Kubernetes cluster, and undeploys those once
+// the custom resources are deleted. It enables managing the APIs declaratively (GitOps) without the control plane.
+package operator
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wso2/product-microgateway/adapter/config"
+	"github.com/wso2/product-microgateway/adapter/internal/api"
+	"github.com/wso2/product-microgateway/adapter/internal/audit"
+	xds "github.com/wso2/product-microgateway/adapter/internal/discovery/xds"
+	"github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
+	"github.com/wso2/product-microgateway/adapter/pkg/logging"
+)
+
+const retryInterval = 5 * time.Second
+
+// deployedAPI is the latest deployment of an API custom resource.
+type deployedAPI struct {
+	generation int64
+	// failed is whether the deployment of the generation has failed, hence it is retried on the resync
+	failed bool
+	// project is the API project deployed successfully, which is nil if none is deployed
+	project *model.ProjectAPI
+}
+
+type operator struct {
+	client         *kubernetesClient
+	resyncInterval time.Duration
+	// deployedAPIs are the API custom resources deployed, by the namespace and the name
+	deployedAPIs map[string]*deployedAPI
+}
+
+// Start deploys the APIs of the API custom resources, and watches the custom resources for changes.
+func Start() error {
+	conf, _ := config.ReadConfigs()
+	operatorConf := conf.Adapter.Operator
+	client, err := newKubernetesClient(operatorConf.APIServerURL, operatorConf.Namespace, operatorConf.TokenFile,
+		operatorConf.CACertFile)
+	if err != nil {
+		return err
+	}
+	o := &operator{
+		client:         client,
+		resyncInterval: time.Duration(operatorConf.ResyncIntervalInSeconds) * time.Second,
+		deployedAPIs:   make(map[string]*deployedAPI),
+	}
+	loggers.LoggerOperator.Infof("Starting the operator for the API custom resources of the namespace %q",
+		operatorConf.Namespace)
+	// the APIs are deployed before the readiness probe is deployed
+	resourceVersion, err := o.reconcileAll()
+	if err != nil {
+		return err
+	}
+	go o.run(resourceVersion)
+	return nil
+}
+
+// run watches the custom resources from the resource version, and reconciles all the custom resources once the
+// resync interval is elapsed.
+func (o *operator) run(resourceVersion string) {
+	for {
+		resyncAt := time.Now().Add(o.resyncInterval)
+		for time.Now().Before(resyncAt) {
+			var err error
+			resourceVersion, err = o.client.watch(resourceVersion, time.Until(resyncAt), o.handleEvent)
+			if err == errResourceVersionExpired {
+				loggers.LoggerOperator.Info("Resource version of the watch is expired, hence listing the API custom resources")
+				break
+			}
+			if err != nil {
+				loggers.LoggerOperator.ErrorC(logging.ErrorDetails{
+					Message:   fmt.Sprintf("Error while watching the API custom resources. %v", err),
+					Severity:  logging.MAJOR,
+					ErrorCode: 2600,
+				})
+				time.Sleep(retryInterval)
+			}
+		}
+		var err error
+		for resourceVersion, err = o.reconcileAll(); err != nil; resourceVersion, err = o.reconcileAll() {
+			time.Sleep(retryInterval)
+		}
+	}
+}
+
+// reconcileAll deploys the API custom resources changed since deployed, and undeploys the APIs of the custom
+// resources deleted. Returns the resource version of the custom resources listed.
+func (o *operator) reconcileAll() (string, error) {
+	apis, err := o.client.list()
+	if err != nil {
+		loggers.LoggerOperator.ErrorC(logging.ErrorDetails{
+			Message:   fmt.Sprintf("Error while listing the API custom resources. %v", err),
+			Severity:  logging.MAJOR,
+			ErrorCode: 2601,
+		})
+		return "", err
+	}
+	keys := make(map[string]struct{}, len(apis.Items))
+	for i := range apis.Items {
+		o.reconcile(&apis.Items[i])
+		keys[apis.Items[i].key()] = struct{}{}
+	}
+	for key := range o.deployedAPIs {
+		if _, found := keys[key]; !found {
+			o.undeploy(key)
+		}
+	}
+	return apis.Metadata.ResourceVersion, nil
+}
+
+func (o *operator) handleEvent(eventType string, api *API) {
+	switch eventType {
+	case eventAdded, eventModified:
+		o.reconcile(api)
+	case eventDeleted:
+		o.undeploy(api.key())
+	}
+}
+
+// reconcile deploys the API custom resource, if the generation (spec) is changed since deployed. The deployments
+// of the previous generation which are not in the current generation are undeployed.
+func (o *operator) reconcile(apiResource *API) {
+	key := apiResource.key()
+	deployed, found := o.deployedAPIs[key]
+	if found && deployed.generation == apiResource.Metadata.Generation && !deployed.failed {
+		return
+	}
+	if !found {
+		deployed = &deployedAPI{}
+		o.deployedAPIs[key] = deployed
+	}
+	deployed.generation = apiResource.Metadata.Generation
+
+	// the API of the previous name and version is replaced, as the API is identified by the name and the version
+	if deployed.project != nil && (deployed.project.APIYaml.Data.Name != apiResource.Spec.Name ||
+		deployed.project.APIYaml.Data.Version != apiResource.Spec.Version) {
+		undeployRemovedDeployments(deployed.project, nil)
+		deployed.project = nil
+	}
+	project, err := deployAPI(apiResource)
+	if err != nil {
+		deployed.failed = true
+		loggers.LoggerOperator.ErrorC(logging.ErrorDetails{
+			Message:   fmt.Sprintf("Error while deploying the API custom resource %s. %v", key, err),
+			Severity:  logging.MAJOR,
+			ErrorCode: 2602,
+		})
+		o.updateStatus(apiResource, APIStatus{Phase: phaseFailed, Message: err.Error(),
+			ObservedGeneration: apiResource.Metadata.Generation})
+		return
+	}
+	if deployed.project != nil {
+		undeployRemovedDeployments(deployed.project, &project)
+	}
+	deployed.failed = false
+	deployed.project = &project
+	loggers.LoggerOperator.Infof("API custom resource %s (generation %d) is deployed as %s:%s", key,
+		apiResource.Metadata.Generation, project.APIYaml.Data.Name, project.APIYaml.Data.Version)
+	o.updateStatus(apiResource, APIStatus{Phase: phaseDeployed, ObservedGeneration: apiResource.Metadata.Generation})
+}
+
+// undeploy removes the API of the custom resource deleted.
+func (o *operator) undeploy(key string) {
+	deployed, found := o.deployedAPIs[key]
+	if !found {
+		return
+	}
+	delete(o.deployedAPIs, key)
+	if deployed.project != nil {
+		undeployRemovedDeployments(deployed.project, nil)
+		loggers.LoggerOperator.Infof("API custom resource %s is deleted, hence the API %s:%s is undeployed", key,
+			deployed.project.APIYaml.Data.Name, deployed.project.APIYaml.Data.Version)
+	}
+}
+
+func (o *operator) updateStatus(apiResource *API, apiStatus APIStatus) {
+	if err := o.client.updateStatus(apiResource, apiStatus); err != nil {
+		loggers.LoggerOperator.ErrorC(logging.ErrorDetails{
+			Message:   fmt.Sprintf("Error while updating the status of the API custom resource %s. %v", apiResource.key(), err),
+			Severity:  logging.MINOR,
+			ErrorCode: 2603,
+		})
+	}
+}
+
+// deployAPI deploys the API project generated from the API custom resource.
+func deployAPI(apiResource *API) (model.ProjectAPI, error) {
+	payload, err := newAPIProject(apiResource)
+	if err != nil {
+		return model.ProjectAPI{}, err
+	}
+	override := true
+	return api.ApplyAPIProjectInStandaloneMode(payload, &override, audit.ActorKubernetesOperator)
+}
+
+// undeployRemovedDeployments undeploys the deployments of the previous API project, which are not deployments of
+// the current API project of the same API. All the deployments are undeployed if the current API project is nil.
+func undeployRemovedDeployments(previous, current *model.ProjectAPI) {
+	previousAPI := previous.APIYaml.Data
+	vhostToEnvsMap := make(map[string][]string)
+	for _, deployment := range previous.Deployments {
+		if current != nil && containsDeployment(current.Deployments, deployment) {
+			continue
+		}
+		vhostToEnvsMap[deployment.DeploymentVhost] =
+			append(vhostToEnvsMap[deployment.DeploymentVhost], deployment.DeploymentEnvironment)
+	}
+	for vhost, environments := range vhostToEnvsMap {
+		if err := xds.DeleteAPIs(vhost, previousAPI.Name, previousAPI.Version, environments, previousAPI.OrganizationID,
+			audit.ActorKubernetesOperator); err != nil {
+			loggers.LoggerOperator.ErrorC(logging.ErrorDetails{
+				Message:   fmt.Sprintf("Error while undeploying the API %s:%s. %v", previousAPI.Name, previousAPI.Version, err),
+				Severity:  logging.MAJOR,
+				ErrorCode: 2604,
+			})
+		}
+	}
+}
+
+func containsDeployment(deployments []model.Deployment, deployment model.Deployment) bool {
+	for _, d := range deployments {
+		if d.DeploymentEnvironment == deployment.DeploymentEnvironment && d.DeploymentVhost == deployment.DeploymentVhost {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,222 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package operator
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
+)
+
+func newTestAPI() *API {
+	return &API{
+		Metadata: objectMeta{Name: "petstore", Namespace: "default", UID: "a3e1c2b4", Generation: 2},
+		Spec: APISpec{
+			Name:               "PetStore",
+			Version:            "1.0.0",
+			Context:            "/petstore",
+			Definition:         "openapi: 3.0.1\ninfo:\n  title: PetStore\n  version: 1.0.0\npaths: {}\n",
+			ProductionEndpoint: "http://petstore:8080",
+			Environments:       []APIEnvironment{{Name: "Default", Vhost: "petstore.wso2.com"}},
+		},
+	}
+}
+
+func readAPIProject(t *testing.T, payload []byte) map[string][]byte {
+	zipReader, err := zip.NewReader(bytes.NewReader(payload), int64(len(payload)))
+	assert.Nil(t, err)
+	files := make(map[string][]byte)
+	for _, file := range zipReader.File {
+		reader, err := file.Open()
+		assert.Nil(t, err)
+		content, err := ioutil.ReadAll(reader)
+		assert.Nil(t, err)
+		files[file.Name] = content
+	}
+	return files
+}
+
+func TestNewAPIProject(t *testing.T) {
+	payload, err := newAPIProject(newTestAPI())
+	assert.Nil(t, err)
+	files := readAPIProject(t, payload)
+	assert.Len(t, files, 3)
+	assert.Equal(t, newTestAPI().Spec.Definition, string(files["PetStore-1.0.0/Definitions/swagger.yaml"]))
+
+	apiYaml, err := model.NewAPIYaml(files["PetStore-1.0.0/api.yaml"])
+	assert.Nil(t, err)
+	assert.Equal(t, "a3e1c2b4", apiYaml.Data.ID)
+	assert.Equal(t, "PetStore", apiYaml.Data.Name)
+	assert.Equal(t, "1.0.0", apiYaml.Data.Version)
+	assert.Equal(t, "/petstore", apiYaml.Data.Context)
+	assert.Equal(t, "HTTP", apiYaml.Data.APIType)
+	if assert.Len(t, apiYaml.Data.EndpointConfig.ProductionEndpoints, 1) {
+		assert.Equal(t, "http://petstore:8080", apiYaml.Data.EndpointConfig.ProductionEndpoints[0].Endpoint)
+	}
+	assert.Empty(t, apiYaml.Data.EndpointConfig.SandBoxEndpoints)
+
+	var deployments model.DeploymentEnvironments
+	assert.Nil(t, json.Unmarshal(files["PetStore-1.0.0/deployment_environments.yaml"], &deployments))
+	assert.Equal(t, "deployment_environments", deployments.Type)
+}
+
+func TestNewAPIProjectOfGraphQLAPI(t *testing.T) {
+	apiResource := newTestAPI()
+	apiResource.Spec.Type = "graphql"
+	apiResource.Spec.Definition = "type Query { pets: [String] }"
+	apiResource.Spec.Environments = nil
+	payload, err := newAPIProject(apiResource)
+	assert.Nil(t, err)
+	files := readAPIProject(t, payload)
+	assert.Equal(t, apiResource.Spec.Definition, string(files["PetStore-1.0.0/Definitions/schema.graphql"]))
+	assert.Contains(t, string(files["PetStore-1.0.0/deployment_environments.yaml"]), "\"deploymentEnvironment\":\"Default\"")
+}
+
+func TestNewAPIProjectWithInvalidSpec(t *testing.T) {
+	tests := []struct {
+		name    string
+		update  func(spec *APISpec)
+		message string
+	}{
+		{
+			name:    "Without context",
+			update:  func(spec *APISpec) { spec.Context = "" },
+			message: "name, version and context of the API are required",
+		},
+		{
+			name:    "Without definition",
+			update:  func(spec *APISpec) { spec.Definition = " " },
+			message: "definition of the API is required",
+		},
+		{
+			name:    "Without endpoints",
+			update:  func(spec *APISpec) { spec.ProductionEndpoint = "" },
+			message: "production or sandbox endpoint of the API is required",
+		},
+		{
+			name:    "Unsupported type",
+			update:  func(spec *APISpec) { spec.Type = "SOAP" },
+			message: "API type \"SOAP\" is not supported",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			apiResource := newTestAPI()
+			test.update(&apiResource.Spec)
+			_, err := newAPIProject(apiResource)
+			if assert.NotNil(t, err) {
+				assert.Equal(t, test.message, err.Error())
+			}
+		})
+	}
+}
+
+func newTestClient(handler http.HandlerFunc) (*kubernetesClient, func()) {
+	server := httptest.NewServer(handler)
+	return &kubernetesClient{apiServerURL: server.URL, namespace: "default", httpClient: server.Client()}, server.Close
+}
+
+func TestKubernetesClientList(t *testing.T) {
+	client, closeServer := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/apis/choreo-connect.wso2.com/v1alpha1/namespaces/default/apis", r.URL.Path)
+		fmt.Fprint(w, `{"metadata":{"resourceVersion":"120"},"items":[{"metadata":{"name":"petstore",`+
+			`"namespace":"default","generation":1},"spec":{"name":"PetStore","version":"1.0.0"}}]}`)
+	})
+	defer closeServer()
+
+	apis, err := client.list()
+	assert.Nil(t, err)
+	assert.Equal(t, "120", apis.Metadata.ResourceVersion)
+	if assert.Len(t, apis.Items, 1) {
+		assert.Equal(t, "default/petstore", apis.Items[0].key())
+		assert.Equal(t, "PetStore", apis.Items[0].Spec.Name)
+	}
+}
+
+func TestKubernetesClientWatch(t *testing.T) {
+	client, closeServer := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "true", r.URL.Query().Get("watch"))
+		assert.Equal(t, "120", r.URL.Query().Get("resourceVersion"))
+		assert.Equal(t, "60", r.URL.Query().Get("timeoutSeconds"))
+		fmt.Fprintln(w, `{"type":"ADDED","object":{"metadata":{"name":"petstore","namespace":"default","resourceVersion":"121"}}}`)
+		fmt.Fprintln(w, `{"type":"BOOKMARK","object":{"metadata":{"resourceVersion":"125"}}}`)
+		fmt.Fprintln(w, `{"type":"DELETED","object":{"metadata":{"name":"petstore","namespace":"default","resourceVersion":"130"}}}`)
+	})
+	defer closeServer()
+
+	var events []string
+	resourceVersion, err := client.watch("120", time.Minute, func(eventType string, api *API) {
+		events = append(events, eventType+" "+api.key())
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "130", resourceVersion)
+	assert.Equal(t, []string{"ADDED default/petstore", "DELETED default/petstore"}, events)
+}
+
+func TestKubernetesClientWatchWithExpiredResourceVersion(t *testing.T) {
+	client, closeServer := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `{"type":"ERROR","object":{"kind":"Status","code":410,"message":"too old resource version"}}`)
+	})
+	defer closeServer()
+
+	resourceVersion, err := client.watch("120", time.Minute, func(eventType string, api *API) {
+		t.Errorf("Unexpected event %s", eventType)
+	})
+	assert.Equal(t, errResourceVersionExpired, err)
+	assert.Equal(t, "120", resourceVersion)
+}
+
+func TestKubernetesClientUpdateStatus(t *testing.T) {
+	client, closeServer := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPatch, r.Method)
+		assert.Equal(t, "/apis/choreo-connect.wso2.com/v1alpha1/namespaces/team-a/apis/petstore/status", r.URL.Path)
+		assert.Equal(t, "application/merge-patch+json", r.Header.Get("Content-Type"))
+		body, _ := ioutil.ReadAll(r.Body)
+		assert.JSONEq(t, `{"status":{"phase":"Failed","message":"invalid definition","observedGeneration":3}}`, string(body))
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"kind":"Status","code":404,"message":"apis.choreo-connect.wso2.com \"petstore\" not found"}`)
+	})
+	defer closeServer()
+
+	apiResource := &API{Metadata: objectMeta{Name: "petstore", Namespace: "team-a"}}
+	err := client.updateStatus(apiResource, APIStatus{Phase: phaseFailed, Message: "invalid definition",
+		ObservedGeneration: 3})
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "apis.choreo-connect.wso2.com \"petstore\" not found (404)", err.Error())
+	}
+}
+
+func TestContainsDeployment(t *testing.T) {
+	deployments := []model.Deployment{
+		{DeploymentEnvironment: "Default", DeploymentVhost: "localhost"},
+		{DeploymentEnvironment: "Internal", DeploymentVhost: "internal.wso2.com"},
+	}
+	assert.True(t, containsDeployment(deployments,
+		model.Deployment{DeploymentEnvironment: "Internal", DeploymentVhost: "internal.wso2.com"}))
+	assert.False(t, containsDeployment(deployments,
+		model.Deployment{DeploymentEnvironment: "Internal", DeploymentVhost: "localhost"}))
+}
@@ -0,0 +1,159 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package operator
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/wso2/product-microgateway/adapter/config"
+)
+
+// Files of the API project generated from an API custom resource
+const (
+	apiYAMLFile               string = "api.yaml"
+	deploymentsYAMLFile       string = "deployment_environments.yaml"
+	openAPIDefinitionFile     string = "Definitions/swagger.yaml"
+	asyncAPIDefinitionFile    string = "Definitions/asyncapi.yaml"
+	graphQLSchemaFile         string = "Definitions/schema.graphql"
+	apiProjectFormatVersion   string = "v4.1.0"
+	deploymentEnvironmentType string = "deployment_environments"
+)
+
+// API types supported by the API custom resource
+const (
+	apiTypeHTTP    string = "HTTP"
+	apiTypeWS      string = "WS"
+	apiTypeGraphQL string = "GRAPHQL"
+)
+
+// newAPIProject generates the API project (zip) of an API custom resource, which is deployed the same way as the
+// API projects deployed with the adapter REST API.
+func newAPIProject(api *API) ([]byte, error) {
+	spec := api.Spec
+	if spec.Name == "" || spec.Version == "" || spec.Context == "" {
+		return nil, errors.New("name, version and context of the API are required")
+	}
+	if strings.TrimSpace(spec.Definition) == "" {
+		return nil, errors.New("definition of the API is required")
+	}
+	if spec.ProductionEndpoint == "" && spec.SandboxEndpoint == "" {
+		return nil, errors.New("production or sandbox endpoint of the API is required")
+	}
+	apiType := strings.ToUpper(spec.Type)
+	var definitionFile string
+	switch apiType {
+	case "", apiTypeHTTP:
+		apiType = apiTypeHTTP
+		definitionFile = openAPIDefinitionFile
+	case apiTypeWS:
+		definitionFile = asyncAPIDefinitionFile
+	case apiTypeGraphQL:
+		definitionFile = graphQLSchemaFile
+	default:
+		return nil, fmt.Errorf("API type %q is not supported", spec.Type)
+	}
+
+	apiYAML, err := json.Marshal(newAPIYAML(api, apiType))
+	if err != nil {
+		return nil, err
+	}
+	deploymentsYAML, err := json.Marshal(newDeploymentEnvironments(spec.Environments))
+	if err != nil {
+		return nil, err
+	}
+
+	var buffer bytes.Buffer
+	zipWriter := zip.NewWriter(&buffer)
+	// the files are placed in the root directory of the project, as in the projects exported by apictl
+	projectDir := spec.Name + "-" + spec.Version + "/"
+	for fileName, content := range map[string][]byte{
+		apiYAMLFile:         apiYAML,
+		deploymentsYAMLFile: deploymentsYAML,
+		definitionFile:      []byte(spec.Definition),
+	} {
+		fileWriter, err := zipWriter.Create(projectDir + fileName)
+		if err != nil {
+			return nil, err
+		}
+		if _, err = fileWriter.Write(content); err != nil {
+			return nil, err
+		}
+	}
+	if err = zipWriter.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// newAPIYAML returns the content of the api.yaml of the API custom resource. JSON is written to the api.yaml, as it
+// is a subset of YAML.
+func newAPIYAML(api *API, apiType string) map[string]interface{} {
+	spec := api.Spec
+	endpointConfig := map[string]interface{}{
+		"endpoint_type": "http",
+	}
+	if spec.ProductionEndpoint != "" {
+		endpointConfig["production_endpoints"] = map[string]string{"url": spec.ProductionEndpoint}
+	}
+	if spec.SandboxEndpoint != "" {
+		endpointConfig["sandbox_endpoints"] = map[string]string{"url": spec.SandboxEndpoint}
+	}
+	data := map[string]interface{}{
+		"Id":              api.Metadata.UID,
+		"name":            spec.Name,
+		"version":         spec.Version,
+		"context":         spec.Context,
+		"type":            apiType,
+		"lifeCycleStatus": "PUBLISHED",
+		"endpointConfig":  endpointConfig,
+	}
+	if spec.OrganizationID != "" {
+		data["organizationId"] = spec.OrganizationID
+	}
+	return map[string]interface{}{
+		"type":    "api",
+		"version": apiProjectFormatVersion,
+		"data":    data,
+	}
+}
+
+// newDeploymentEnvironments returns the content of the deployment_environments.yaml of the environments of the API
+// custom resource. The API is deployed to the default environment if no environment is given.
+func newDeploymentEnvironments(environments []APIEnvironment) map[string]interface{} {
+	if len(environments) == 0 {
+		environments = []APIEnvironment{{Name: config.DefaultGatewayName}}
+	}
+	deployments := make([]map[string]interface{}, 0, len(environments))
+	for _, environment := range environments {
+		deployments = append(deployments, map[string]interface{}{
+			"deploymentEnvironment": environment.Name,
+			"deploymentVhost":       environment.Vhost,
+			"displayOnDevportal":    true,
+		})
+	}
+	return map[string]interface{}{
+		"type":    deploymentEnvironmentType,
+		"version": apiProjectFormatVersion,
+		"data":    deployments,
+	}
+}
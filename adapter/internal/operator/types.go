@@ -0,0 +1,113 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package operator
+
+import "encoding/json"
+
+// Group and version of the API custom resource definition
+const (
+	apiGroup    string = "choreo-connect.wso2.com"
+	apiVersion  string = "v1alpha1"
+	apiResource string = "apis"
+)
+
+// Phases of the API custom resources, set to the status
+const (
+	phaseDeployed string = "Deployed"
+	phaseFailed   string = "Failed"
+)
+
+// Types of the watch events
+const (
+	eventAdded    string = "ADDED"
+	eventModified string = "MODIFIED"
+	eventDeleted  string = "DELETED"
+	eventBookmark string = "BOOKMARK"
+	eventError    string = "ERROR"
+)
+
+// API is the custom resource describing an API deployed to the router.
+type API struct {
+	Metadata objectMeta `json:"metadata"`
+	Spec     APISpec    `json:"spec"`
+	Status   APIStatus  `json:"status,omitempty"`
+}
+
+// APISpec is the API deployed, which is converted to an API project.
+type APISpec struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Context string `json:"context"`
+	// Type is HTTP (default), WS or GRAPHQL
+	Type string `json:"type,omitempty"`
+	// Definition is the OpenAPI (HTTP), AsyncAPI (WS) or GraphQL SDL (GRAPHQL) definition of the API
+	Definition         string `json:"definition"`
+	ProductionEndpoint string `json:"productionEndpoint,omitempty"`
+	SandboxEndpoint    string `json:"sandboxEndpoint,omitempty"`
+	// Environments are the gateway environments the API is deployed to. The API is deployed to the default
+	// environment if empty.
+	Environments   []APIEnvironment `json:"environments,omitempty"`
+	OrganizationID string           `json:"organizationId,omitempty"`
+}
+
+// APIEnvironment is a gateway environment an API is deployed to. The default vhost of the environment is used if
+// the vhost is empty.
+type APIEnvironment struct {
+	Name  string `json:"name"`
+	Vhost string `json:"vhost,omitempty"`
+}
+
+// APIStatus is the result of the latest deployment of the API custom resource.
+type APIStatus struct {
+	Phase              string `json:"phase,omitempty"`
+	Message            string `json:"message,omitempty"`
+	ObservedGeneration int64  `json:"observedGeneration,omitempty"`
+}
+
+type objectMeta struct {
+	Name            string `json:"name"`
+	Namespace       string `json:"namespace,omitempty"`
+	UID             string `json:"uid,omitempty"`
+	ResourceVersion string `json:"resourceVersion,omitempty"`
+	Generation      int64  `json:"generation,omitempty"`
+}
+
+type listMeta struct {
+	ResourceVersion string `json:"resourceVersion,omitempty"`
+}
+
+type apiList struct {
+	Metadata listMeta `json:"metadata"`
+	Items    []API    `json:"items"`
+}
+
+type watchEvent struct {
+	Type   string          `json:"type"`
+	Object json.RawMessage `json:"object"`
+}
+
+// status is returned by the Kubernetes API server with the errors of the watch.
+type status struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// key returns the namespace and the name of the custom resource, which identify it.
+func (a *API) key() string {
+	return a.Metadata.Namespace + "/" + a.Metadata.Name
+}
@@ -0,0 +1,65 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+// Package profiling exposes the pprof and the expvar endpoints of the adapter, and keeps the stats of the event
+// processing and the snapshot generation, hence the performance regressions of the datastore and the xDS layers
+// can be measured.
+package profiling
+
+import (
+	"expvar"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"strconv"
+
+	"github.com/wso2/product-microgateway/adapter/config"
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/pkg/logging"
+)
+
+// NewHandler returns the handler of the pprof endpoints (/debug/pprof/) and the expvar endpoint (/debug/vars).
+func NewHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	mux.Handle("/debug/vars", expvar.Handler())
+	return mux
+}
+
+// Start serves the profiling endpoints on the configured address, if the profiling is enabled. The endpoints are
+// served on a dedicated server, hence those are not exposed on the ports of the other servers of the adapter.
+func Start(conf *config.Config) {
+	if !conf.Adapter.Profiling.Enabled {
+		return
+	}
+	address := net.JoinHostPort(conf.Adapter.Profiling.Host, strconv.Itoa(int(conf.Adapter.Profiling.Port)))
+	logger.LoggerMgw.Infof("Profiling endpoints are served on %s", address)
+	go func() {
+		if err := http.ListenAndServe(address, NewHandler()); err != nil {
+			logger.LoggerMgw.ErrorC(logging.ErrorDetails{
+				Message:   fmt.Sprintf("Error serving the profiling endpoints on %s. %v", address, err),
+				Severity:  logging.MAJOR,
+				ErrorCode: 3100,
+			})
+		}
+	}()
+}
@@ -0,0 +1,59 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package profiling
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPipelineStats(t *testing.T) {
+	before := GetStats()
+	ObserveEvent("PROCESSED", 2*time.Millisecond)
+	ObserveEvent("PROCESSED", time.Millisecond)
+	ObserveEvent("IGNORED", time.Millisecond)
+	ObserveSnapshotGeneration(5 * time.Millisecond)
+	ObserveSnapshotGeneration(time.Millisecond)
+
+	diff := GetStats().Sub(before)
+	assert.Equal(t, map[string]int64{"PROCESSED": 2, "IGNORED": 1}, diff.Events)
+	assert.Equal(t, int64(3), diff.EventCount())
+	assert.Equal(t, (4 * time.Millisecond).Nanoseconds(), diff.EventNanos)
+	assert.Equal(t, int64(2), diff.Snapshots)
+	assert.Equal(t, (6 * time.Millisecond).Nanoseconds(), diff.SnapshotNanos)
+	assert.GreaterOrEqual(t, diff.MaxSnapshotNanos, (5 * time.Millisecond).Nanoseconds())
+
+	recorder := httptest.NewRecorder()
+	NewHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/debug/vars", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	var vars struct {
+		EventPipeline Stats `json:"eventPipeline"`
+	}
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &vars))
+	assert.Equal(t, GetStats(), vars.EventPipeline, "Pipeline stats are not published to expvar")
+
+	recorder = httptest.NewRecorder()
+	NewHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/debug/pprof/", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "goroutine")
+}
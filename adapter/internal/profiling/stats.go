@@ -0,0 +1,104 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package profiling
+
+import (
+	"expvar"
+	"sync"
+	"time"
+)
+
+// Stats is the number of the events processed and the snapshots generated since the adapter is started, with the
+// time spent on those.
+type Stats struct {
+	Events           map[string]int64 `json:"events"`
+	EventNanos       int64            `json:"eventNanos"`
+	Snapshots        int64            `json:"snapshots"`
+	SnapshotNanos    int64            `json:"snapshotNanos"`
+	MaxSnapshotNanos int64            `json:"maxSnapshotNanos"`
+}
+
+// EventCount returns the number of the events processed, of any outcome.
+func (stats Stats) EventCount() int64 {
+	var count int64
+	for _, outcomeCount := range stats.Events {
+		count += outcomeCount
+	}
+	return count
+}
+
+// Sub returns the stats recorded since the given stats are read. The maximum snapshot generation latency is kept,
+// as it can not be derived for an interval.
+func (stats Stats) Sub(previous Stats) Stats {
+	diff := Stats{
+		Events:           make(map[string]int64, len(stats.Events)),
+		EventNanos:       stats.EventNanos - previous.EventNanos,
+		Snapshots:        stats.Snapshots - previous.Snapshots,
+		SnapshotNanos:    stats.SnapshotNanos - previous.SnapshotNanos,
+		MaxSnapshotNanos: stats.MaxSnapshotNanos,
+	}
+	for outcome, count := range stats.Events {
+		if count -= previous.Events[outcome]; count != 0 {
+			diff.Events[outcome] = count
+		}
+	}
+	return diff
+}
+
+var (
+	statsMutex sync.Mutex
+	stats      = Stats{Events: make(map[string]int64)}
+)
+
+func init() {
+	// published as "eventPipeline" of /debug/vars, next to the memory stats of the runtime
+	expvar.Publish("eventPipeline", expvar.Func(func() interface{} {
+		return GetStats()
+	}))
+}
+
+// ObserveEvent records a control plane event processed with the given outcome (ex: PROCESSED, IGNORED or FAILED).
+func ObserveEvent(outcome string, duration time.Duration) {
+	statsMutex.Lock()
+	defer statsMutex.Unlock()
+	stats.Events[outcome]++
+	stats.EventNanos += duration.Nanoseconds()
+}
+
+// ObserveSnapshotGeneration records the generation of the router resources of a label.
+func ObserveSnapshotGeneration(duration time.Duration) {
+	statsMutex.Lock()
+	defer statsMutex.Unlock()
+	stats.Snapshots++
+	stats.SnapshotNanos += duration.Nanoseconds()
+	if duration.Nanoseconds() > stats.MaxSnapshotNanos {
+		stats.MaxSnapshotNanos = duration.Nanoseconds()
+	}
+}
+
+// GetStats returns a copy of the stats recorded since the adapter is started.
+func GetStats() Stats {
+	statsMutex.Lock()
+	defer statsMutex.Unlock()
+	copied := stats
+	copied.Events = make(map[string]int64, len(stats.Events))
+	for outcome, count := range stats.Events {
+		copied.Events[outcome] = count
+	}
+	return copied
+}
@@ -0,0 +1,166 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package quotasync
+
+import (
+	"sort"
+	"sync"
+
+	throttle "github.com/wso2/product-microgateway/adapter/pkg/discovery/api/wso2/discovery/service/throttle"
+)
+
+// counter is the count of a throttle key within the current window, by the enforcer replica.
+type counter struct {
+	windowStart  int64
+	windowMillis int64
+	counts       map[string]int64
+	// changed is whether the counter is changed since it is last distributed
+	changed bool
+}
+
+func (c *counter) total() int64 {
+	var total int64
+	for _, count := range c.counts {
+		total += count
+	}
+	return total
+}
+
+// aggregator aggregates the counters reported by the enforcer replicas, by the gateway environment label.
+type aggregator struct {
+	maxCounters int
+	// counters are the counters by the throttle key, by the label
+	counters map[string]map[string]*counter
+	mutex    sync.Mutex
+}
+
+func newAggregator(maxCounters int) *aggregator {
+	return &aggregator{
+		maxCounters: maxCounters,
+		counters:    make(map[string]map[string]*counter),
+	}
+}
+
+// report applies the counters reported by a replica. A counter of a later window replaces the counter of the key,
+// while the counters of the earlier windows are ignored. The count of a replica only increases within a window,
+// hence the reports received out of order are ignored as well. Returns the number of counters dropped, as the
+// maximum number of counters is reached.
+func (a *aggregator) report(replicaID, label string, counters []*throttle.QuotaCounter) int {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	labelCounters, found := a.counters[label]
+	if !found {
+		labelCounters = make(map[string]*counter)
+		a.counters[label] = labelCounters
+	}
+	dropped := 0
+	for _, reported := range counters {
+		if reported.GetKey() == "" || reported.GetWindowMillis() <= 0 {
+			continue
+		}
+		current, found := labelCounters[reported.GetKey()]
+		if !found {
+			if a.maxCounters > 0 && len(labelCounters) >= a.maxCounters {
+				dropped++
+				continue
+			}
+			current = &counter{windowStart: reported.GetWindowStartMillis()}
+			labelCounters[reported.GetKey()] = current
+		}
+		if reported.GetWindowStartMillis() < current.windowStart {
+			continue
+		}
+		if reported.GetWindowStartMillis() > current.windowStart || current.counts == nil {
+			current.windowStart = reported.GetWindowStartMillis()
+			current.windowMillis = reported.GetWindowMillis()
+			current.counts = make(map[string]int64)
+		}
+		if reported.GetCount() > current.counts[replicaID] {
+			current.counts[replicaID] = reported.GetCount()
+			current.changed = true
+		}
+	}
+	return dropped
+}
+
+// removeEnded removes the counters of the windows ended, and the labels without any counter. Hence the labels of the
+// enforcers disconnected are released.
+func (a *aggregator) removeEnded(nowMillis int64) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	for label, labelCounters := range a.counters {
+		for key, current := range labelCounters {
+			if current.windowStart+current.windowMillis <= nowMillis {
+				delete(labelCounters, key)
+			}
+		}
+		if len(labelCounters) == 0 {
+			delete(a.counters, label)
+		}
+	}
+}
+
+// hasLabel returns whether the label has counters aggregated.
+func (a *aggregator) hasLabel(label string) bool {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	_, found := a.counters[label]
+	return found
+}
+
+// getLabels returns the labels with the counters aggregated.
+func (a *aggregator) getLabels() map[string]struct{} {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	labels := make(map[string]struct{}, len(a.counters))
+	for label := range a.counters {
+		labels[label] = struct{}{}
+	}
+	return labels
+}
+
+// collect returns the aggregated counters of the label, which are changed since those are last collected, or all
+// the counters of the label. Collecting all the counters does not reset the changes, as those are collected for an
+// enforcer joining. The counters of the windows ended are removed.
+func (a *aggregator) collect(label string, all bool, nowMillis int64) []*throttle.QuotaCounter {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	var aggregated []*throttle.QuotaCounter
+	for key, current := range a.counters[label] {
+		if current.windowStart+current.windowMillis <= nowMillis {
+			delete(a.counters[label], key)
+			continue
+		}
+		if !all {
+			if !current.changed {
+				continue
+			}
+			current.changed = false
+		}
+		aggregated = append(aggregated, &throttle.QuotaCounter{
+			Key:               key,
+			WindowStartMillis: current.windowStart,
+			WindowMillis:      current.windowMillis,
+			Count:             current.total(),
+		})
+	}
+	sort.Slice(aggregated, func(i, j int) bool {
+		return aggregated[i].Key < aggregated[j].Key
+	})
+	return aggregated
+}
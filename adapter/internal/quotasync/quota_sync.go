@@ -0,0 +1,248 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+// Package quotasync aggregates the throttle counters reported by the enforcer replicas of the gateways, and
+// distributes the aggregated counters back to the replicas of each gateway. Hence the quotas are enforced
+// approximately globally across the replicas, without a traffic manager.
+package quotasync
+
+import (
+	"io"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/wso2/product-microgateway/adapter/config"
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	throttle "github.com/wso2/product-microgateway/adapter/pkg/discovery/api/wso2/discovery/service/throttle"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// sendBufferSize bounds the aggregates pending to be sent to an enforcer. The aggregates are dropped for a slow
+// enforcer, as those are superseded by the next aggregates.
+const sendBufferSize = 16
+
+// identifierRegex restricts the labels and the replica IDs reported by the enforcers, as those are kept in memory
+// and logged.
+var identifierRegex = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// subscriber is an enforcer stream, receiving the aggregates of its label.
+type subscriber struct {
+	replicaID  string
+	label      string
+	aggregates chan *throttle.QuotaUsageAggregate
+}
+
+type quotaSyncService struct {
+	throttle.UnimplementedQuotaSyncServiceServer
+	aggregator *aggregator
+	maxLabels  int
+
+	mutex sync.RWMutex
+	// subscribers are the enforcer streams, by the label
+	subscribers map[string]map[*subscriber]struct{}
+}
+
+var service *quotaSyncService
+
+// Start starts aggregating the counters, if quota sync is enabled. The counters are received via the quota sync
+// service registered by RegisterQuotaSyncService.
+func Start(conf *config.Config) {
+	quotaSync := conf.Adapter.QuotaSync
+	if !quotaSync.Enabled {
+		return
+	}
+	interval := time.Duration(quotaSync.PublishIntervalInMillis) * time.Millisecond
+	if interval <= 0 {
+		interval = time.Second
+	}
+	service = newQuotaSyncService(quotaSync.MaxCounters, quotaSync.MaxLabels)
+	go func() {
+		for range time.Tick(interval) {
+			service.publish(nowMillis())
+		}
+	}()
+	logger.LoggerQuotaSync.Infof("Quota sync is enabled with a publish interval of %v", interval)
+}
+
+// RegisterQuotaSyncService registers the quota sync service, if quota sync is enabled. Otherwise the enforcers
+// receive the unimplemented status, and enforce the quotas per replica.
+func RegisterQuotaSyncService(grpcServer *grpc.Server) {
+	if service == nil {
+		return
+	}
+	throttle.RegisterQuotaSyncServiceServer(grpcServer, service)
+}
+
+func newQuotaSyncService(maxCounters, maxLabels int) *quotaSyncService {
+	return &quotaSyncService{
+		aggregator:  newAggregator(maxCounters),
+		maxLabels:   maxLabels,
+		subscribers: make(map[string]map[*subscriber]struct{}),
+	}
+}
+
+// StreamQuotaUsage aggregates the counters reported by the enforcer, and sends the counters aggregated across the
+// replicas of its label. The enforcer is subscribed to the aggregates at its first report, and the stream is rejected
+// if the label or the replica ID is invalid, or the maximum number of labels is reached.
+func (s *quotaSyncService) StreamQuotaUsage(stream throttle.QuotaSyncService_StreamQuotaUsageServer) error {
+	var sub *subscriber
+	done := make(chan struct{})
+	defer func() {
+		close(done)
+		if sub != nil {
+			s.unsubscribe(sub)
+		}
+	}()
+	for {
+		report, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			logger.LoggerQuotaSync.Debugf("Quota usage stream is closed. %v", err)
+			return err
+		}
+		if sub == nil {
+			if sub, err = s.subscribe(report.GetReplicaId(), report.GetLabel()); err != nil {
+				logger.LoggerQuotaSync.Warnf("Enforcer %q of the label %q is rejected for quota sync. %v",
+					report.GetReplicaId(), report.GetLabel(), err)
+				return err
+			}
+			go sub.send(stream, done)
+			// the enforcer joining is sent the counters of the current windows
+			sub.offer(&throttle.QuotaUsageAggregate{
+				Counters: s.aggregator.collect(sub.label, true, nowMillis()),
+				Replicas: s.getReplicas(sub.label),
+			})
+		}
+		if dropped := s.aggregator.report(sub.replicaID, sub.label, report.GetCounters()); dropped > 0 {
+			logger.LoggerQuotaSync.Warnf("%d counters reported by the enforcer %s of the label %s are not aggregated, "+
+				"as the maximum number of counters is reached", dropped, sub.replicaID, sub.label)
+		}
+	}
+}
+
+// publish sends the counters changed since the last publish to the subscribers of each label.
+func (s *quotaSyncService) publish(now int64) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	s.aggregator.removeEnded(now)
+	for label, subscribers := range s.subscribers {
+		counters := s.aggregator.collect(label, false, now)
+		if len(counters) == 0 {
+			continue
+		}
+		aggregate := &throttle.QuotaUsageAggregate{
+			Counters: counters,
+			Replicas: countReplicas(subscribers),
+		}
+		for sub := range subscribers {
+			sub.offer(aggregate)
+		}
+	}
+}
+
+func (s *quotaSyncService) subscribe(replicaID, label string) (*subscriber, error) {
+	if !identifierRegex.MatchString(label) || !identifierRegex.MatchString(replicaID) {
+		return nil, status.Errorf(codes.InvalidArgument, "label and replica ID should match %s",
+			identifierRegex.String())
+	}
+	sub := &subscriber{
+		replicaID:  replicaID,
+		label:      label,
+		aggregates: make(chan *throttle.QuotaUsageAggregate, sendBufferSize),
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, found := s.subscribers[label]; !found {
+		if s.maxLabels > 0 && s.countLabels() >= s.maxLabels && !s.aggregator.hasLabel(label) {
+			return nil, status.Errorf(codes.ResourceExhausted, "maximum number of %d labels is reached",
+				s.maxLabels)
+		}
+		s.subscribers[label] = make(map[*subscriber]struct{})
+	}
+	s.subscribers[label][sub] = struct{}{}
+	logger.LoggerQuotaSync.Infof("Enforcer %s of the label %s is connected for quota sync", replicaID, label)
+	return sub, nil
+}
+
+// countLabels returns the number of the labels with the enforcers connected or with the counters of the current
+// windows. Should be called with the mutex of the service.
+func (s *quotaSyncService) countLabels() int {
+	labels := s.aggregator.getLabels()
+	for label := range s.subscribers {
+		labels[label] = struct{}{}
+	}
+	return len(labels)
+}
+
+func (s *quotaSyncService) unsubscribe(sub *subscriber) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	delete(s.subscribers[sub.label], sub)
+	if len(s.subscribers[sub.label]) == 0 {
+		delete(s.subscribers, sub.label)
+	}
+	logger.LoggerQuotaSync.Infof("Enforcer %s of the label %s is disconnected from quota sync", sub.replicaID,
+		sub.label)
+}
+
+func (s *quotaSyncService) getReplicas(label string) int32 {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return countReplicas(s.subscribers[label])
+}
+
+// countReplicas returns the number of distinct replicas, as a replica reconnecting may have two streams for a while.
+func countReplicas(subscribers map[*subscriber]struct{}) int32 {
+	replicas := make(map[string]struct{}, len(subscribers))
+	for sub := range subscribers {
+		replicas[sub.replicaID] = struct{}{}
+	}
+	return int32(len(replicas))
+}
+
+// offer queues the aggregate to be sent, unless the buffer of the subscriber is full.
+func (sub *subscriber) offer(aggregate *throttle.QuotaUsageAggregate) {
+	select {
+	case sub.aggregates <- aggregate:
+	default:
+		logger.LoggerQuotaSync.Debugf("Aggregate is dropped for the enforcer %s, as it is not receiving",
+			sub.replicaID)
+	}
+}
+
+func (sub *subscriber) send(stream throttle.QuotaSyncService_StreamQuotaUsageServer, done <-chan struct{}) {
+	for {
+		select {
+		case <-done:
+			return
+		case aggregate := <-sub.aggregates:
+			if err := stream.Send(aggregate); err != nil {
+				logger.LoggerQuotaSync.Debugf("Error sending the aggregate to the enforcer %s. %v", sub.replicaID, err)
+				return
+			}
+		}
+	}
+}
+
+func nowMillis() int64 {
+	return time.Now().UnixNano() / int64(time.Millisecond)
+}
@@ -0,0 +1,146 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package quotasync
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	throttle "github.com/wso2/product-microgateway/adapter/pkg/discovery/api/wso2/discovery/service/throttle"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func newCounter(key string, windowStart, count int64) *throttle.QuotaCounter {
+	return &throttle.QuotaCounter{Key: key, WindowStartMillis: windowStart, WindowMillis: 60000, Count: count}
+}
+
+func TestAggregateCounters(t *testing.T) {
+	a := newAggregator(0)
+	a.report("enforcer-1", "default", []*throttle.QuotaCounter{newCounter("api1", 0, 5), newCounter("api2", 0, 1)})
+	a.report("enforcer-2", "default", []*throttle.QuotaCounter{newCounter("api1", 0, 3)})
+	a.report("enforcer-1", "internal", []*throttle.QuotaCounter{newCounter("api1", 0, 7)})
+
+	assert.Equal(t, []*throttle.QuotaCounter{newCounter("api1", 0, 8), newCounter("api2", 0, 1)},
+		a.collect("default", false, 1000))
+	assert.Empty(t, a.collect("default", false, 1000), "Counters not changed should not be collected again")
+
+	// the count of a replica only increases within a window
+	a.report("enforcer-2", "default", []*throttle.QuotaCounter{newCounter("api1", 0, 2)})
+	assert.Empty(t, a.collect("default", false, 1000))
+	a.report("enforcer-2", "default", []*throttle.QuotaCounter{newCounter("api1", 0, 6)})
+	assert.Equal(t, []*throttle.QuotaCounter{newCounter("api1", 0, 11)}, a.collect("default", false, 1000))
+
+	assert.Equal(t, []*throttle.QuotaCounter{newCounter("api1", 0, 7)}, a.collect("internal", true, 1000))
+	assert.Equal(t, []*throttle.QuotaCounter{newCounter("api1", 0, 7)}, a.collect("internal", false, 1000),
+		"Collecting all the counters should not reset the changes")
+}
+
+func TestAggregateCountersOfWindows(t *testing.T) {
+	a := newAggregator(0)
+	a.report("enforcer-1", "default", []*throttle.QuotaCounter{newCounter("api1", 0, 5)})
+	a.report("enforcer-2", "default", []*throttle.QuotaCounter{newCounter("api1", 60000, 2)})
+	// the report of the previous window is ignored
+	a.report("enforcer-1", "default", []*throttle.QuotaCounter{newCounter("api1", 0, 9)})
+	assert.Equal(t, []*throttle.QuotaCounter{newCounter("api1", 60000, 2)}, a.collect("default", false, 61000))
+
+	a.report("enforcer-1", "default", []*throttle.QuotaCounter{newCounter("api1", 60000, 4)})
+	assert.Equal(t, []*throttle.QuotaCounter{newCounter("api1", 60000, 6)}, a.collect("default", true, 61000))
+	assert.Empty(t, a.collect("default", true, 120000), "Counters of the windows ended should be removed")
+	assert.Empty(t, a.counters["default"])
+}
+
+func TestAggregateMaxCounters(t *testing.T) {
+	a := newAggregator(2)
+	dropped := a.report("enforcer-1", "default", []*throttle.QuotaCounter{newCounter("api1", 0, 1),
+		newCounter("api2", 0, 1), newCounter("api3", 0, 1), newCounter("", 0, 1)})
+	assert.Equal(t, 1, dropped)
+	assert.Equal(t, 0, a.report("enforcer-2", "default", []*throttle.QuotaCounter{newCounter("api2", 0, 1)}))
+	assert.Equal(t, 0, a.report("enforcer-2", "internal", []*throttle.QuotaCounter{newCounter("api3", 0, 1)}),
+		"Maximum number of counters should be applied per label")
+	assert.Equal(t, []*throttle.QuotaCounter{newCounter("api1", 0, 1), newCounter("api2", 0, 2)},
+		a.collect("default", false, 1000))
+
+	// the counters of the new keys are aggregated once the windows of the existing counters end
+	a.collect("default", false, 60000)
+	assert.Equal(t, 0, a.report("enforcer-1", "default", []*throttle.QuotaCounter{newCounter("api3", 60000, 1)}))
+}
+
+func TestPublishAggregates(t *testing.T) {
+	s := newQuotaSyncService(0, 0)
+	sub1, _ := s.subscribe("enforcer-1", "default")
+	sub2, _ := s.subscribe("enforcer-2", "default")
+	// a replica reconnecting
+	sub3, _ := s.subscribe("enforcer-2", "default")
+	other, _ := s.subscribe("enforcer-3", "internal")
+	s.aggregator.report("enforcer-1", "default", []*throttle.QuotaCounter{newCounter("api1", 0, 5)})
+	s.publish(1000)
+
+	for _, sub := range []*subscriber{sub1, sub2, sub3} {
+		assert.Len(t, sub.aggregates, 1)
+		aggregate := <-sub.aggregates
+		assert.Equal(t, int32(2), aggregate.Replicas)
+		assert.Equal(t, []*throttle.QuotaCounter{newCounter("api1", 0, 5)}, aggregate.Counters)
+	}
+	assert.Empty(t, other.aggregates)
+
+	s.publish(2000)
+	assert.Empty(t, sub1.aggregates, "Aggregates should not be published if the counters are not changed")
+
+	s.unsubscribe(sub1)
+	assert.Equal(t, int32(1), s.getReplicas("default"))
+	s.unsubscribe(sub2)
+	s.unsubscribe(sub3)
+	assert.NotContains(t, s.subscribers, "default")
+}
+
+func TestSubscribeLabels(t *testing.T) {
+	s := newQuotaSyncService(0, 2)
+	_, err := s.subscribe("enforcer-1", "")
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "Empty label should be rejected")
+	_, err = s.subscribe("enforcer 1", "default")
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "Invalid replica ID should be rejected")
+
+	sub1, err := s.subscribe("enforcer-1", "default")
+	assert.Nil(t, err)
+	_, err = s.subscribe("enforcer-2", "internal")
+	assert.Nil(t, err)
+	_, err = s.subscribe("enforcer-3", "external")
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err), "Labels beyond the maximum should be rejected")
+	_, err = s.subscribe("enforcer-3", "default")
+	assert.Nil(t, err, "Enforcers of an existing label should be accepted")
+
+	// the label is released once its enforcers are disconnected and the windows of its counters end
+	s.aggregator.report("enforcer-1", "default", []*throttle.QuotaCounter{newCounter("api1", 0, 1)})
+	s.unsubscribe(sub1)
+	s.unsubscribe(s.getSubscriber(t, "default"))
+	_, err = s.subscribe("enforcer-3", "external")
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err), "Label with the counters of the current windows "+
+		"should not be released")
+	s.publish(60000)
+	_, err = s.subscribe("enforcer-3", "external")
+	assert.Nil(t, err)
+}
+
+func (s *quotaSyncService) getSubscriber(t *testing.T, label string) *subscriber {
+	for sub := range s.subscribers[label] {
+		return sub
+	}
+	t.Fatalf("No subscriber of the label %s", label)
+	return nil
+}
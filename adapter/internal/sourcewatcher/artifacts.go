@@ -0,0 +1,199 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package sourcewatcher
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/wso2/product-microgateway/adapter/internal/api"
+	"github.com/wso2/product-microgateway/adapter/internal/audit"
+	xds "github.com/wso2/product-microgateway/adapter/internal/discovery/xds"
+	"github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
+	"github.com/wso2/product-microgateway/adapter/pkg/logging"
+)
+
+// deployedArtifact is an api artifact (zip file or directory) of the artifacts directory
+type deployedArtifact struct {
+	// hash of the content of the artifact, when it was last applied
+	hash string
+	// project is the API project deployed from the artifact, nil if the artifact has never been deployed successfully
+	project *model.ProjectAPI
+}
+
+var (
+	deployedArtifacts = make(map[string]*deployedArtifact)
+	artifactsLock     sync.Mutex
+)
+
+// syncArtifacts deploys the api artifacts of the given directory which are added or changed since the last sync, and
+// undeploys the APIs of the artifacts which are removed. An artifact which fails to deploy keeps the API deployed from
+// its previous content.
+func syncArtifacts(apisDirName string) error {
+	artifactsLock.Lock()
+	defer artifactsLock.Unlock()
+
+	files, err := ioutil.ReadDir(apisDirName)
+	if err != nil {
+		loggers.LoggerSourceWatcher.ErrorC(logging.ErrorDetails{
+			Message:   fmt.Sprintf("Error while reading API artifacts directory : %s", err.Error()),
+			Severity:  logging.MAJOR,
+			ErrorCode: 2509,
+		})
+		return err
+	}
+
+	currentArtifacts := make(map[string]bool)
+	for _, apiProjectFile := range files {
+		if strings.HasPrefix(apiProjectFile.Name(), ".") ||
+			(!apiProjectFile.IsDir() && !strings.HasSuffix(apiProjectFile.Name(), zipExt)) {
+			continue
+		}
+		currentArtifacts[apiProjectFile.Name()] = true
+
+		artifactHash, err := getArtifactHash(filepath.Join(apisDirName, apiProjectFile.Name()))
+		if err != nil {
+			loggers.LoggerSourceWatcher.ErrorC(logging.ErrorDetails{
+				Message:   fmt.Sprintf("Error while reading API artifact %s : %s", apiProjectFile.Name(), err.Error()),
+				Severity:  logging.MAJOR,
+				ErrorCode: 2512,
+			})
+			continue
+		}
+		artifact, found := deployedArtifacts[apiProjectFile.Name()]
+		if found && artifact.hash == artifactHash {
+			continue
+		}
+		if !found {
+			artifact = &deployedArtifact{}
+			deployedArtifacts[apiProjectFile.Name()] = artifact
+		}
+		artifact.hash = artifactHash
+
+		loggers.LoggerSourceWatcher.Infof("Deploying API artifact %s", apiProjectFile.Name())
+		apiProject, err := api.ProcessMountedAPIProject(apisDirName, apiProjectFile)
+		if err != nil {
+			continue
+		}
+		if artifact.project != nil {
+			undeployRemovedDeployments(artifact.project, &apiProject)
+		}
+		artifact.project = &apiProject
+	}
+
+	// Undeploy the APIs whose artifacts are not present in the directory
+	for artifactName, artifact := range deployedArtifacts {
+		if currentArtifacts[artifactName] {
+			continue
+		}
+		delete(deployedArtifacts, artifactName)
+		if artifact.project != nil {
+			loggers.LoggerSourceWatcher.Infof("Undeploying API of the removed API artifact %s", artifactName)
+			undeployAPIProject(artifact.project, artifact.project.Deployments)
+		}
+	}
+	return nil
+}
+
+// getArtifactHash returns the hash of the content of the given zip file, or of the files within the given directory
+func getArtifactHash(artifactPath string) (string, error) {
+	artifactHash := sha256.New()
+	err := filepath.Walk(artifactPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		relativePath, _ := filepath.Rel(artifactPath, path)
+		writeHashEntry(artifactHash, relativePath, content)
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(artifactHash.Sum(nil)), nil
+}
+
+func writeHashEntry(artifactHash hash.Hash, path string, content []byte) {
+	artifactHash.Write([]byte(filepath.ToSlash(path)))
+	artifactHash.Write([]byte{0})
+	artifactHash.Write(content)
+	artifactHash.Write([]byte{0})
+}
+
+// undeployRemovedDeployments undeploys the deployments of the previous API project which are not part of the
+// current API project of the same artifact. All the deployments are undeployed if the API name or version is changed.
+func undeployRemovedDeployments(previous, current *model.ProjectAPI) {
+	previousAPI := previous.APIYaml.Data
+	currentAPI := current.APIYaml.Data
+	if previousAPI.Name != currentAPI.Name || previousAPI.Version != currentAPI.Version ||
+		previousAPI.OrganizationID != currentAPI.OrganizationID {
+		undeployAPIProject(previous, previous.Deployments)
+		return
+	}
+	var removedDeployments []model.Deployment
+	for _, deployment := range previous.Deployments {
+		if !containsDeployment(current.Deployments, deployment) {
+			removedDeployments = append(removedDeployments, deployment)
+		}
+	}
+	undeployAPIProject(previous, removedDeployments)
+}
+
+// undeployAPIProject undeploys the API of the given API project from the given deployments
+func undeployAPIProject(apiProject *model.ProjectAPI, deployments []model.Deployment) {
+	apiYaml := apiProject.APIYaml.Data
+
+	vhostToEnvsMap := make(map[string][]string)
+	for _, environment := range deployments {
+		vhostToEnvsMap[environment.DeploymentVhost] =
+			append(vhostToEnvsMap[environment.DeploymentVhost], environment.DeploymentEnvironment)
+	}
+
+	for vhost, environments := range vhostToEnvsMap {
+		err := xds.DeleteAPIs(vhost, apiYaml.Name, apiYaml.Version, environments, apiYaml.OrganizationID,
+			audit.ActorArtifactsDirectory)
+		if err != nil {
+			loggers.LoggerSourceWatcher.ErrorC(logging.ErrorDetails{
+				Message:   fmt.Sprintf("Error while deleting API : %s", err.Error()),
+				Severity:  logging.MAJOR,
+				ErrorCode: 2510,
+			})
+		}
+	}
+}
+
+func containsDeployment(deployments []model.Deployment, deployment model.Deployment) bool {
+	for _, current := range deployments {
+		if current.DeploymentEnvironment == deployment.DeploymentEnvironment &&
+			current.DeploymentVhost == deployment.DeploymentVhost {
+			return true
+		}
+	}
+	return false
+}
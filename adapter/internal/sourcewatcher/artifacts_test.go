@@ -0,0 +1,102 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package sourcewatcher
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
+)
+
+func writeArtifactFile(t *testing.T, path string, content string) {
+	assert.Nil(t, os.MkdirAll(filepath.Dir(path), 0755))
+	assert.Nil(t, ioutil.WriteFile(path, []byte(content), 0644))
+}
+
+func TestGetArtifactHash(t *testing.T) {
+	apisDir := t.TempDir()
+	projectDir := filepath.Join(apisDir, "petstore")
+	writeArtifactFile(t, filepath.Join(projectDir, "api.yaml"), "name: PetStore")
+	writeArtifactFile(t, filepath.Join(projectDir, "Definitions", "swagger.yaml"), "openapi: 3.0.1")
+
+	initialHash, err := getArtifactHash(projectDir)
+	assert.Nil(t, err)
+	currentHash, _ := getArtifactHash(projectDir)
+	assert.Equal(t, initialHash, currentHash, "Hash of an unchanged artifact should not change")
+
+	writeArtifactFile(t, filepath.Join(projectDir, "Definitions", "swagger.yaml"), "openapi: 3.0.2")
+	currentHash, _ = getArtifactHash(projectDir)
+	assert.NotEqual(t, initialHash, currentHash, "Hash should change with the content of a file")
+
+	assert.Nil(t, os.Rename(filepath.Join(projectDir, "Definitions"), filepath.Join(projectDir, "Docs")))
+	renamedHash, _ := getArtifactHash(projectDir)
+	assert.NotEqual(t, currentHash, renamedHash, "Hash should change with the path of a file")
+
+	writeArtifactFile(t, filepath.Join(apisDir, "petstore.zip"), "zip content")
+	zipHash, err := getArtifactHash(filepath.Join(apisDir, "petstore.zip"))
+	assert.Nil(t, err)
+	assert.NotEmpty(t, zipHash)
+
+	_, err = getArtifactHash(filepath.Join(apisDir, "unknown.zip"))
+	assert.NotNil(t, err)
+}
+
+func TestSyncArtifacts(t *testing.T) {
+	defer func() { deployedArtifacts = make(map[string]*deployedArtifact) }()
+	apisDir := t.TempDir()
+	writeArtifactFile(t, filepath.Join(apisDir, "invalid.zip"), "not a zip file")
+	writeArtifactFile(t, filepath.Join(apisDir, "README.md"), "API artifacts")
+	writeArtifactFile(t, filepath.Join(apisDir, ".hidden.zip"), "not a zip file")
+
+	assert.Nil(t, syncArtifacts(apisDir))
+	assert.Len(t, deployedArtifacts, 1)
+	invalidArtifact := deployedArtifacts["invalid.zip"]
+	if assert.NotNil(t, invalidArtifact) {
+		assert.Nil(t, invalidArtifact.project, "An artifact failed to deploy should not have a deployed project")
+		assert.NotEmpty(t, invalidArtifact.hash)
+	}
+
+	// An artifact failed to deploy keeps the API deployed from its previous content
+	previousProject := &model.ProjectAPI{}
+	invalidArtifact.project = previousProject
+	invalidArtifact.hash = ""
+	assert.Nil(t, syncArtifacts(apisDir))
+	assert.Same(t, previousProject, deployedArtifacts["invalid.zip"].project)
+	assert.NotEmpty(t, deployedArtifacts["invalid.zip"].hash)
+
+	assert.Nil(t, os.Remove(filepath.Join(apisDir, "invalid.zip")))
+	assert.Nil(t, syncArtifacts(apisDir))
+	assert.Empty(t, deployedArtifacts)
+
+	assert.NotNil(t, syncArtifacts(filepath.Join(apisDir, "unknown")))
+}
+
+func TestContainsDeployment(t *testing.T) {
+	deployments := []model.Deployment{
+		{DeploymentEnvironment: "Default", DeploymentVhost: "localhost"},
+		{DeploymentEnvironment: "Internal", DeploymentVhost: "internal.wso2.com"},
+	}
+	assert.True(t, containsDeployment(deployments,
+		model.Deployment{DeploymentEnvironment: "Default", DeploymentVhost: "localhost"}))
+	assert.False(t, containsDeployment(deployments,
+		model.Deployment{DeploymentEnvironment: "Default", DeploymentVhost: "internal.wso2.com"}))
+}
@@ -0,0 +1,117 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package sourcewatcher
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/wso2/product-microgateway/adapter/config"
+	"github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/pkg/logging"
+)
+
+// StartArtifactsWatcher deploys the api artifacts of the artifacts directory at the startup and watches the directory
+// for changes. The APIs are deployed, redeployed and undeployed as the artifacts are added, changed and removed.
+func StartArtifactsWatcher() error {
+	conf, _ := config.ReadConfigs()
+	apisDirName := filepath.FromSlash(conf.Adapter.ArtifactsDirectory + "/" + apisArtifactDir)
+
+	loggers.LoggerSourceWatcher.Infof("Starting artifacts watcher for the directory %s", apisDirName)
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	// The directory is watched before the artifacts are deployed, so that no change is missed in between
+	if err = watchDirectory(watcher, apisDirName); err != nil {
+		watcher.Close()
+		return err
+	}
+	if err = syncArtifacts(apisDirName); err != nil {
+		watcher.Close()
+		return err
+	}
+
+	debounceInterval := time.Duration(conf.Adapter.ArtifactsWatcher.DebounceInMillis) * time.Millisecond
+	go watchArtifacts(watcher, apisDirName, debounceInterval)
+	return nil
+}
+
+// watchDirectory watches the given directory and its subdirectories, as the changes of the files inside the API
+// project directories are not notified for the parent directory.
+func watchDirectory(watcher *fsnotify.Watcher, dirName string) error {
+	return filepath.Walk(dirName, func(path string, info os.FileInfo, err error) error {
+		if err != nil || !info.IsDir() {
+			return err
+		}
+		return watcher.Add(path)
+	})
+}
+
+// watchArtifacts applies the changes of the api artifacts once the changes are settled for the debounce interval
+func watchArtifacts(watcher *fsnotify.Watcher, apisDirName string, debounceInterval time.Duration) {
+	var syncTimer *time.Timer
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			if event.Op == fsnotify.Chmod {
+				continue
+			}
+			loggers.LoggerSourceWatcher.Debugf("API artifacts are changed (%s)", event.String())
+			if event.Op&fsnotify.Create == fsnotify.Create {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					if err = watchDirectory(watcher, event.Name); err != nil {
+						loggers.LoggerSourceWatcher.ErrorC(logging.ErrorDetails{
+							Message:   fmt.Sprintf("Error while watching the directory %s : %s", event.Name, err.Error()),
+							Severity:  logging.MAJOR,
+							ErrorCode: 2513,
+						})
+					}
+				}
+			}
+			if syncTimer == nil {
+				syncTimer = time.AfterFunc(debounceInterval, func() {
+					if err := syncArtifacts(apisDirName); err != nil {
+						loggers.LoggerSourceWatcher.ErrorC(logging.ErrorDetails{
+							Message:   fmt.Sprintf("Error while processing artifact changes : %s", err.Error()),
+							Severity:  logging.MAJOR,
+							ErrorCode: 2506,
+						})
+					}
+				})
+			} else {
+				syncTimer.Reset(debounceInterval)
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			loggers.LoggerSourceWatcher.ErrorC(logging.ErrorDetails{
+				Message:   fmt.Sprintf("Error while watching the API artifacts : %s", err.Error()),
+				Severity:  logging.MAJOR,
+				ErrorCode: 2513,
+			})
+		}
+	}
+}
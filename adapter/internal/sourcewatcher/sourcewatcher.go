@@ -19,16 +19,11 @@ package sourcewatcher
 
 import (
 	"fmt"
-	"io/ioutil"
 	"path/filepath"
-	"strings"
 	"time"
 
 	"github.com/wso2/product-microgateway/adapter/config"
-	"github.com/wso2/product-microgateway/adapter/internal/api"
-	xds "github.com/wso2/product-microgateway/adapter/internal/discovery/xds"
 	"github.com/wso2/product-microgateway/adapter/internal/loggers"
-	"github.com/wso2/product-microgateway/adapter/internal/oasparser/model"
 	"github.com/wso2/product-microgateway/adapter/pkg/auth"
 	"github.com/wso2/product-microgateway/adapter/pkg/logging"
 
@@ -42,7 +37,8 @@ const (
 	branchHead      string = "refs/heads/"
 )
 
-var artifactsMap map[string]model.ProjectAPI
+// deployedCommitHash is the hash of the commit whose api artifacts are deployed
+var deployedCommitHash string
 
 // Start fetches the API artifacts at the startup and polls for changes from the remote repository
 func Start() error {
@@ -75,7 +71,7 @@ func Start() error {
 		return err
 	}
 
-	artifactsMap, err = api.ProcessMountedAPIProjects()
+	err = deployArtifactsOfHead(repository)
 	if err != nil {
 		loggers.LoggerSourceWatcher.ErrorC(logging.ErrorDetails{
 			Message:   fmt.Sprintf("Processing API artifacts failed : %s", err.Error()),
@@ -155,11 +151,12 @@ func pollChanges(repository *git.Repository) {
 	for {
 		loggers.LoggerSourceWatcher.Debugf("Polling changes from the remote repository %s", conf.Adapter.SourceControl.Repository.URL)
 		<-time.After(time.Duration(pollInterval) * time.Second)
-		go pullRepositoryIfUpdated(repository)
+		pullRepositoryIfUpdated(repository)
 	}
 }
 
-// pullRepositoryIfUpdated compares the hashes of the local and remote repositories and pulls if there are any changes
+// pullRepositoryIfUpdated compares the hashes of the local and remote repositories and pulls if there are any changes.
+// The api artifacts are applied if the head commit of the local repository is not deployed yet.
 func pullRepositoryIfUpdated(localRepository *git.Repository) {
 	remote, err := localRepository.Remote("origin")
 	if err != nil {
@@ -168,6 +165,7 @@ func pullRepositoryIfUpdated(localRepository *git.Repository) {
 			Severity:  logging.CRITICAL,
 			ErrorCode: 2503,
 		})
+		return
 	}
 
 	gitAuth, err := auth.GetGitAuth()
@@ -177,6 +175,7 @@ func pullRepositoryIfUpdated(localRepository *git.Repository) {
 			Severity:  logging.CRITICAL,
 			ErrorCode: 2501,
 		})
+		return
 	}
 
 	remoteList, err := remote.List(&git.ListOptions{
@@ -188,6 +187,7 @@ func pullRepositoryIfUpdated(localRepository *git.Repository) {
 			Severity:  logging.MAJOR,
 			ErrorCode: 2504,
 		})
+		return
 	}
 
 	ref, err := localRepository.Head()
@@ -197,6 +197,7 @@ func pullRepositoryIfUpdated(localRepository *git.Repository) {
 			Severity:  logging.MAJOR,
 			ErrorCode: 2505,
 		})
+		return
 	}
 
 	refName := ref.Name()
@@ -209,30 +210,41 @@ func pullRepositoryIfUpdated(localRepository *git.Repository) {
 			if err := pullChanges(localRepository); err != nil {
 				return
 			}
+			break
+		}
+	}
 
-			err := processArtifactChanges()
-			if err != nil {
-				loggers.LoggerSourceWatcher.ErrorC(logging.ErrorDetails{
-					Message:   fmt.Sprintf("Error while processing artifact changes : %s", err.Error()),
-					Severity:  logging.MAJOR,
-					ErrorCode: 2506,
-				})
-				return
-			}
+	err = deployArtifactsOfHead(localRepository)
+	if err != nil {
+		loggers.LoggerSourceWatcher.ErrorC(logging.ErrorDetails{
+			Message:   fmt.Sprintf("Error while processing artifact changes : %s", err.Error()),
+			Severity:  logging.MAJOR,
+			ErrorCode: 2506,
+		})
+	}
+}
 
-			//Redeploy changes
-			artifactsMap, err = api.ProcessMountedAPIProjects()
-			if err != nil {
-				loggers.LoggerSourceWatcher.ErrorC(logging.ErrorDetails{
-					Message:   fmt.Sprintf("Processing API artifacts failed : %s", err.Error()),
-					Severity:  logging.CRITICAL,
-					ErrorCode: 2500,
-				})
-				return
-			}
-		}
+// deployArtifactsOfHead applies the changes of the api artifacts of the head commit of the local repository, unless
+// the commit is already deployed. Only the artifacts changed since the previous deployed commit are redeployed.
+func deployArtifactsOfHead(localRepository *git.Repository) error {
+	conf, _ := config.ReadConfigs()
+
+	ref, err := localRepository.Head()
+	if err != nil {
+		return err
+	}
+	commitHash := ref.Hash().String()
+	if commitHash == deployedCommitHash {
+		return nil
 	}
 
+	apisDirName := filepath.FromSlash(conf.Adapter.SourceControl.ArtifactsDirectory + "/" + apisArtifactDir)
+	if err = syncArtifacts(apisDirName); err != nil {
+		return err
+	}
+	deployedCommitHash = commitHash
+	loggers.LoggerSourceWatcher.Infof("API artifacts of the commit %s are deployed", commitHash)
+	return nil
 }
 
 // pullChanges pulls changes from the given repository
@@ -277,61 +289,3 @@ func pullChanges(localRepository *git.Repository) error {
 	}
 	return err
 }
-
-// processArtifactChanges undeploy the APIs whose artifacts are not present in the repository
-func processArtifactChanges() (err error) {
-	conf, _ := config.ReadConfigs()
-
-	apisDirName := filepath.FromSlash(conf.Adapter.SourceControl.ArtifactsDirectory + "/" + apisArtifactDir)
-	files, err := ioutil.ReadDir(apisDirName)
-	if err != nil {
-		loggers.LoggerSourceWatcher.ErrorC(logging.ErrorDetails{
-			Message:   fmt.Sprintf("Error while reading API artifacts directory : %s", err.Error()),
-			Severity:  logging.MAJOR,
-			ErrorCode: 2509,
-		})
-	}
-
-	// Assign the API artifacts to a temporary map
-	removedArtifacts := artifactsMap
-
-	for _, apiProjectFile := range files {
-		if apiProjectFile.IsDir() {
-			// If the artifact is present in the artifacts directory, remove it from the temporary map
-			if _, ok := removedArtifacts[apiProjectFile.Name()]; ok {
-				delete(removedArtifacts, apiProjectFile.Name())
-			}
-			continue
-		} else if !strings.HasSuffix(apiProjectFile.Name(), zipExt) {
-			continue
-		}
-		// If the zip artifact is present in the artifacts directory, remove it from the temporary map
-		if _, ok := removedArtifacts[apiProjectFile.Name()]; ok {
-			delete(removedArtifacts, apiProjectFile.Name())
-		}
-	}
-
-	// Undeploy the APIs whose artifacts are not present in the repository
-	for _, apiProject := range removedArtifacts {
-		apiYaml := apiProject.APIYaml.Data
-
-		vhostToEnvsMap := make(map[string][]string)
-		for _, environment := range apiProject.Deployments {
-			vhostToEnvsMap[environment.DeploymentVhost] =
-				append(vhostToEnvsMap[environment.DeploymentVhost], environment.DeploymentEnvironment)
-		}
-
-		for vhost, environments := range vhostToEnvsMap {
-			err = xds.DeleteAPIs(vhost, apiYaml.Name, apiYaml.Version, environments, apiProject.APIYaml.Data.OrganizationID)
-
-			if err != nil {
-				loggers.LoggerSourceWatcher.ErrorC(logging.ErrorDetails{
-					Message:   fmt.Sprintf("Error while deleting API : %s", err.Error()),
-					Severity:  logging.MAJOR,
-					ErrorCode: 2510,
-				})
-			}
-		}
-	}
-	return nil
-}
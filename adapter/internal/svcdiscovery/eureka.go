@@ -0,0 +1,195 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package svcdiscovery
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wso2/product-microgateway/adapter/config"
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/pkg/tlsutils"
+)
+
+const (
+	eurekaAppsPath    = "/apps/"
+	eurekaStatusUp    = "UP"
+	eurekaEnabledAttr = "true"
+)
+
+var (
+	onceEurekaInit sync.Once
+	// EurekaClientInstance instance for eureka client
+	EurekaClientInstance EurekaClient
+)
+
+type eurekaPort struct {
+	Port    int    `json:"$"`
+	Enabled string `json:"@enabled"`
+}
+
+type eurekaInstance struct {
+	InstanceID string     `json:"instanceId"`
+	IPAddr     string     `json:"ipAddr"`
+	Status     string     `json:"status"`
+	Port       eurekaPort `json:"port"`
+	SecurePort eurekaPort `json:"securePort"`
+}
+
+// eurekaApplication is used to unmarshal the required components from the eureka server's response
+type eurekaApplication struct {
+	Application struct {
+		Instance []eurekaInstance `json:"instance"`
+	} `json:"application"`
+}
+
+// EurekaClient wraps the REST API of the eureka server
+type EurekaClient struct {
+	client       http.Client
+	url          string
+	username     string
+	password     string
+	pollInterval time.Duration
+}
+
+// NewEurekaClient constructor for EurekaClient
+func NewEurekaClient(client http.Client, url string, username string, password string,
+	pollInterval time.Duration) EurekaClient {
+	return EurekaClient{
+		client:       client,
+		url:          strings.TrimSuffix(url, "/"),
+		username:     username,
+		password:     password,
+		pollInterval: pollInterval,
+	}
+}
+
+// InitEureka initializes the EurekaClient
+// lazy loading
+func InitEureka() {
+	onceEurekaInit.Do(func() {
+		adapterConf, _ := config.ReadConfigs()
+		eurekaConf := adapterConf.Adapter.Eureka
+		pollInterval := time.Duration(eurekaConf.PollInterval) * time.Second
+		transport := newHTTPTransport()
+		if strings.HasPrefix(eurekaConf.URL, "https") {
+			tlsConfig := newTLSConfig(tlsutils.GetTrustedCertPool(adapterConf.Adapter.Truststore.Location),
+				[]tls.Certificate{}, false)
+			transport = newHTTPSTransport(&tlsConfig)
+		}
+		client := newHTTPClient(&transport, pollInterval)
+		EurekaClientInstance = NewEurekaClient(client, eurekaConf.URL, eurekaConf.Username, eurekaConf.Password,
+			pollInterval)
+	})
+}
+
+// getInstances gets the instances of the given application, which are up
+// an application is not found once all of its instances are deregistered, hence an empty list is returned
+func (c EurekaClient) getInstances(appName string) ([]Upstream, error) {
+	req, err := http.NewRequest(get, c.url+eurekaAppsPath+appName, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.username != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+	response, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode == http.StatusNotFound {
+		return []Upstream{}, nil
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d from the eureka server", response.StatusCode)
+	}
+
+	var application eurekaApplication
+	if err = json.Unmarshal(body, &application); err != nil {
+		return nil, err
+	}
+	out := []Upstream{}
+	for _, instance := range application.Application.Instance {
+		if instance.Status != eurekaStatusUp {
+			continue
+		}
+		port := instance.Port.Port
+		if instance.Port.Enabled != eurekaEnabledAttr && instance.SecurePort.Enabled == eurekaEnabledAttr {
+			port = instance.SecurePort.Port
+		}
+		out = append(out, Upstream{
+			Address:     instance.IPAddr,
+			ServicePort: port,
+			ID:          instance.InstanceID,
+		})
+	}
+	// keep the order stable, so that an unchanged set of instances is not pushed to the router again
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].ID < out[j].ID
+	})
+	return out, nil
+}
+
+// Poll periodically polls the eureka server for the instances of the given application
+// the instances are sent through the returned channel, including the empty list once all the instances are gone
+// closing the doneChan will stop polling
+func (c EurekaClient) Poll(appName string, doneChan <-chan bool) <-chan []Upstream {
+	resultChan := make(chan []Upstream)
+
+	// this routine will live until doneChan is closed
+	go func() {
+		ticker := time.NewTicker(c.pollInterval)
+		defer close(resultChan)
+		defer ticker.Stop()
+
+		for {
+			instances, err := c.getInstances(appName)
+			if err != nil {
+				logger.LoggerSvcDiscovery.Error("Eureka server unreachable for application ", appName, " ", err)
+			} else {
+				select {
+				case resultChan <- instances:
+				case <-doneChan:
+					logger.LoggerSvcDiscovery.Info("Eureka stopped polling for application :", appName)
+					return
+				}
+			}
+			select {
+			case <-doneChan:
+				logger.LoggerSvcDiscovery.Info("Eureka stopped polling for application :", appName)
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return resultChan
+}
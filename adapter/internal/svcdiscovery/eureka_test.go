@@ -0,0 +1,105 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package svcdiscovery
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const eurekaApplicationResponse = `{"application":{"name":"PAYMENTS","instance":[
+	{"instanceId":"payments-2","ipAddr":"10.0.0.12","status":"UP","port":{"$":8080,"@enabled":"true"},
+		"securePort":{"$":8443,"@enabled":"false"}},
+	{"instanceId":"payments-1","ipAddr":"10.0.0.11","status":"UP","port":{"$":8080,"@enabled":"false"},
+		"securePort":{"$":8443,"@enabled":"true"}},
+	{"instanceId":"payments-3","ipAddr":"10.0.0.13","status":"DOWN","port":{"$":8080,"@enabled":"true"}}]}}`
+
+func newTestEurekaClient(handler http.HandlerFunc) (EurekaClient, func()) {
+	server := httptest.NewServer(handler)
+	return NewEurekaClient(*server.Client(), server.URL+"/eureka/", "admin", "admin", 10*time.Millisecond),
+		server.Close
+}
+
+func TestEurekaGetInstances(t *testing.T) {
+	client, closeServer := newTestEurekaClient(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/eureka/apps/PAYMENTS", r.URL.Path)
+		assert.Equal(t, "application/json", r.Header.Get("Accept"))
+		username, password, _ := r.BasicAuth()
+		assert.Equal(t, "admin", username)
+		assert.Equal(t, "admin", password)
+		fmt.Fprint(w, eurekaApplicationResponse)
+	})
+	defer closeServer()
+
+	instances, err := client.getInstances("PAYMENTS")
+	assert.Nil(t, err)
+	assert.Equal(t, []Upstream{
+		{Address: "10.0.0.11", ServicePort: 8443, ID: "payments-1"},
+		{Address: "10.0.0.12", ServicePort: 8080, ID: "payments-2"},
+	}, instances)
+}
+
+func TestEurekaGetInstancesOfDeregisteredApplication(t *testing.T) {
+	client, closeServer := newTestEurekaClient(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	defer closeServer()
+
+	instances, err := client.getInstances("PAYMENTS")
+	assert.Nil(t, err)
+	assert.NotNil(t, instances)
+	assert.Empty(t, instances)
+}
+
+func TestEurekaGetInstancesWithServerError(t *testing.T) {
+	client, closeServer := newTestEurekaClient(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	defer closeServer()
+
+	_, err := client.getInstances("PAYMENTS")
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "unexpected status code 503 from the eureka server", err.Error())
+	}
+}
+
+func TestEurekaPoll(t *testing.T) {
+	requests := 0
+	client, closeServer := newTestEurekaClient(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests > 1 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, eurekaApplicationResponse)
+	})
+	defer closeServer()
+
+	doneChan := make(chan bool)
+	resultChan := client.Poll("PAYMENTS", doneChan)
+	assert.Len(t, <-resultChan, 2)
+	assert.Empty(t, <-resultChan, "Instances should be empty once the application is deregistered")
+	close(doneChan)
+	for range resultChan {
+	}
+}
@@ -19,6 +19,7 @@ package svcdiscovery
 
 import (
 	"errors"
+	"fmt"
 	"regexp"
 	"strings"
 )
@@ -26,8 +27,29 @@ import (
 const (
 	// consulBegin
 	consulBegin string = "consul"
+	// RegistryConsul is the Consul service registry
+	RegistryConsul string = "consul"
+	// RegistryEureka is the Eureka service registry
+	RegistryEureka string = "eureka"
+	schemeSeparator       = "://"
+	httpsSuffix           = "+https"
 )
 
+// ServiceURL is an endpoint URL referring a service of a service registry instead of a static host.
+// ex: consul://payments-service/v1, consul+https://[dc1].payments-service.[prod]/v1, eureka://PAYMENTS-SERVICE
+type ServiceURL struct {
+	// Registry is the service registry which the service is discovered from
+	Registry string
+	// Query is the consul query, or the application name of the service in eureka
+	Query string
+	// ServiceName is the name of the service, used as the host of the endpoint until the instances are discovered
+	ServiceName string
+	// URLType is the scheme used to invoke the service instances (http or https)
+	URLType string
+	// Basepath is added as a prefix to the resource paths when invoking the service instances
+	Basepath string
+}
+
 // DefaultHost host and port of the default host
 // Clusters are initialized with default host at the time of initialization of an api project
 type DefaultHost struct {
@@ -42,6 +64,67 @@ func IsDiscoveryServiceEndpoint(str string) bool {
 	return re.MatchString(str)
 }
 
+// IsServiceURL checks whether an endpoint string refers a service of a service registry (ex: eureka://payments)
+func IsServiceURL(str string) bool {
+	str = strings.TrimSpace(str)
+	for _, registry := range []string{RegistryConsul, RegistryEureka} {
+		if strings.HasPrefix(str, registry+schemeSeparator) || strings.HasPrefix(str, registry+httpsSuffix+schemeSeparator) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseServiceURL breaks an endpoint string referring a service of a service registry into its components
+func ParseServiceURL(str string) (*ServiceURL, error) {
+	str = strings.TrimSpace(str)
+	schemeEnd := strings.Index(str, schemeSeparator)
+	if schemeEnd < 0 {
+		return nil, fmt.Errorf("service registry is not provided in %q", str)
+	}
+	serviceURL := &ServiceURL{
+		Registry: str[:schemeEnd],
+		URLType:  "http",
+	}
+	if strings.HasSuffix(serviceURL.Registry, httpsSuffix) {
+		serviceURL.Registry = strings.TrimSuffix(serviceURL.Registry, httpsSuffix)
+		serviceURL.URLType = "https"
+	}
+	serviceURL.Query = str[schemeEnd+len(schemeSeparator):]
+	if basepathStart := strings.Index(serviceURL.Query, "/"); basepathStart >= 0 {
+		serviceURL.Basepath = serviceURL.Query[basepathStart:]
+		serviceURL.Query = serviceURL.Query[:basepathStart]
+	}
+	if serviceURL.Query == "" {
+		return nil, fmt.Errorf("service is not provided in %q", str)
+	}
+
+	switch serviceURL.Registry {
+	case RegistryConsul:
+		query, err := ParseQueryString(serviceURL.Query)
+		if err != nil {
+			return nil, err
+		}
+		serviceURL.ServiceName = query.ServiceName
+	case RegistryEureka:
+		serviceURL.ServiceName = serviceURL.Query
+	default:
+		return nil, fmt.Errorf("service registry %q is not supported", serviceURL.Registry)
+	}
+	return serviceURL, nil
+}
+
+// IsRegistryEnabled checks whether the service discovery with the given service registry is enabled
+func IsRegistryEnabled(registry string) bool {
+	switch registry {
+	case RegistryConsul:
+		return IsServiceDiscoveryEnabled
+	case RegistryEureka:
+		return IsEurekaEnabled
+	}
+	return false
+}
+
 //parse a list of datacenters or tags
 func parseList(str string) []string {
 	parsedString := strings.Split(str, ",")
@@ -228,3 +228,64 @@ func TestParseConsulSyntax(t *testing.T) {
 	}
 
 }
+
+func TestParseServiceURL(t *testing.T) {
+	dataItems := []struct {
+		input   string
+		output  *ServiceURL
+		err     string
+		message string
+	}{
+		{
+			input: " consul://payments-service ",
+			output: &ServiceURL{Registry: RegistryConsul, Query: "payments-service", ServiceName: "payments-service",
+				URLType: "http"},
+			message: "consul service name",
+		},
+		{
+			input: "consul+https://[dc1,dc2].payments-service.[prod]/v1/payments",
+			output: &ServiceURL{Registry: RegistryConsul, Query: "[dc1,dc2].payments-service.[prod]",
+				ServiceName: "payments-service", URLType: "https", Basepath: "/v1/payments"},
+			message: "consul query with basepath",
+		},
+		{
+			input: "eureka://PAYMENTS-SERVICE/v1",
+			output: &ServiceURL{Registry: RegistryEureka, Query: "PAYMENTS-SERVICE", ServiceName: "PAYMENTS-SERVICE",
+				URLType: "http", Basepath: "/v1"},
+			message: "eureka application",
+		},
+		{
+			input:   "eureka:///v1",
+			err:     "service is not provided in \"eureka:///v1\"",
+			message: "without service",
+		},
+		{
+			input:   "consul://dc1.payments-service",
+			err:     "bad consul query syntax",
+			message: "invalid consul query",
+		},
+		{
+			input:   "zookeeper://payments-service",
+			err:     "service registry \"zookeeper\" is not supported",
+			message: "unsupported registry",
+		},
+	}
+	for _, item := range dataItems {
+		serviceURL, err := ParseServiceURL(item.input)
+		if item.err != "" {
+			if assert.NotNil(t, err, item.message) {
+				assert.Equal(t, item.err, err.Error(), item.message)
+			}
+			continue
+		}
+		assert.Nil(t, err, item.message)
+		assert.Equal(t, item.output, serviceURL, item.message)
+	}
+}
+
+func TestIsServiceURL(t *testing.T) {
+	assert.True(t, IsServiceURL(" consul://payments-service"))
+	assert.True(t, IsServiceURL("eureka+https://PAYMENTS"))
+	assert.False(t, IsServiceURL("consul(payments-service, http://localhost:8080)"))
+	assert.False(t, IsServiceURL("http://eureka:8761"))
+}
@@ -36,6 +36,8 @@ import (
 var (
 	//IsServiceDiscoveryEnabled whether Consul service discovery should be enabled
 	IsServiceDiscoveryEnabled bool
+	//IsEurekaEnabled whether Eureka service discovery should be enabled
+	IsEurekaEnabled bool
 	onceConfigLoad            sync.Once
 	mutexForResultMap         sync.RWMutex
 	conf                      *config.Config
@@ -62,6 +64,9 @@ var (
 	ConsulClientInstance ConsulClient
 	//ClusterConsulKeyMap Cluster Name -> consul syntax key
 	ClusterConsulKeyMap map[string]string
+	//ClusterEurekaKeyMap Cluster Name -> eureka application name
+	//the results and the doneChans of the eureka clusters are kept in ClusterConsulResultMap and ClusterConsulDoneChanMap
+	ClusterEurekaKeyMap map[string]string
 	//ClusterConsulResultMap Cluster Name -> Upstream
 	//saves the last result with respected to a cluster
 	ClusterConsulResultMap map[string][]Upstream
@@ -74,12 +79,14 @@ var (
 
 func init() {
 	ClusterConsulKeyMap = make(map[string]string)
+	ClusterEurekaKeyMap = make(map[string]string)
 	ClusterConsulResultMap = make(map[string][]Upstream)
 	ClusterConsulDoneChanMap = make(map[string]chan bool)
 	ServiceConsulMeshMap = make(map[string]bool)
 	//Read config
 	conf, errConfLoad = config.ReadConfigs()
 	IsServiceDiscoveryEnabled = conf.Adapter.Consul.Enabled
+	IsEurekaEnabled = conf.Adapter.Eureka.Enabled
 	aclToken = strings.TrimSpace(conf.Adapter.Consul.ACLToken)
 	mgwServiceName = conf.Adapter.Consul.MgwServiceName
 	MeshEnabled = conf.Adapter.Consul.ServiceMeshEnabled
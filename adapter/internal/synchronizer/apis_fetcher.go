@@ -28,11 +28,14 @@ import (
 	"fmt"
 	"io/ioutil"
 	"strings"
+	"time"
 
 	"github.com/wso2/product-microgateway/adapter/config"
 	"github.com/wso2/product-microgateway/adapter/internal/common"
+	"github.com/wso2/product-microgateway/adapter/internal/eventorder"
 	"github.com/wso2/product-microgateway/adapter/internal/notifier"
 	"github.com/wso2/product-microgateway/adapter/pkg/health"
+	"github.com/wso2/product-microgateway/adapter/pkg/metrics"
 
 	apiServer "github.com/wso2/product-microgateway/adapter/internal/api"
 	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
@@ -56,7 +59,9 @@ func init() {
 // byte slice. This method ensures to update the enforcer and router using entries inside the
 // downloaded apis.zip one by one.
 // If the updating envoy or enforcer fails, this method returns an error, if not error would be nil.
-func PushAPIProjects(payload []byte, environments []string) error {
+// pulledAt is the time (in milliseconds) the APIs are pulled at, hence an API is not deployed to the environments
+// which a later deploy or remove event of the API is already processed for.
+func PushAPIProjects(payload []byte, environments []string, pulledAt int64) error {
 	var deploymentList []*notifier.DeployedAPIRevision
 	// Reading the root zip
 	zipReader, err := zip.NewReader(bytes.NewReader(payload), int64(len(payload)))
@@ -109,15 +114,28 @@ func PushAPIProjects(payload []byte, environments []string) error {
 		//Read the files inside each xxxx-api.zip
 		apiFileData, err := ioutil.ReadAll(f)
 		_ = f.Close() // Close the file here (without defer)
-		// Pass the byte slice for the XDS APIs to push it to the enforcer and router
-		// Updating cache one API by one API, if one API failed to update cache continue with others.
-		var deployedRevisionList []*notifier.DeployedAPIRevision
-		deployedRevisionList, err = apiServer.ApplyAPIProjectFromAPIM(apiFileData, vhostToEnvsMap, envProps)
+		apiUUID, err := apiServer.GetAPIIDOfProject(apiFileData)
 		if err != nil {
-			logger.LoggerSync.Errorf("Error occurred while applying project (API_ID:REVISION_ID).zip : %v, Error : %v", file.Name, err)
-		} else if deployedRevisionList != nil {
-			deploymentList = append(deploymentList, deployedRevisionList...)
+			logger.LoggerSync.Errorf("Error occurred while reading project (API_ID:REVISION_ID).zip : %v, Error : %v", file.Name, err)
+			continue
+		}
+		var envs []string
+		for _, environment := range deployment.Environments {
+			envs = append(envs, environment.Name)
 		}
+		// Pass the byte slice for the XDS APIs to push it to the enforcer and router
+		// Updating cache one API by one API, if one API failed to update cache continue with others.
+		eventorder.ApplyPulled(eventorder.ResourceAPI, apiUUID, envs, pulledAt, func(envs []string) {
+			deployStartTime := time.Now()
+			deployedRevisionList, err := apiServer.ApplyAPIProjectFromAPIM(apiFileData,
+				filterVhostEnvs(vhostToEnvsMap, envs), envProps)
+			metrics.ObserveAPIDeployDuration(metrics.DeploySourceControlPlane, time.Since(deployStartTime), err, "")
+			if err != nil {
+				logger.LoggerSync.Errorf("Error occurred while applying project (API_ID:REVISION_ID).zip : %v, Error : %v", file.Name, err)
+			} else if deployedRevisionList != nil {
+				deploymentList = append(deploymentList, deployedRevisionList...)
+			}
+		})
 	}
 	notifier.SendRevisionUpdateAck(deploymentList)
 	logger.LoggerSync.Infof("Successfully deployed %d API/s", len(deploymentList))
@@ -125,11 +143,25 @@ func PushAPIProjects(payload []byte, environments []string) error {
 	return nil
 }
 
+// filterVhostEnvs returns the vhosts of the given environments, with only those environments.
+func filterVhostEnvs(vhostToEnvsMap map[string][]string, envs []string) map[string][]string {
+	filtered := make(map[string][]string, len(vhostToEnvsMap))
+	for vhost, vhostEnvs := range vhostToEnvsMap {
+		for _, env := range vhostEnvs {
+			if containsEnv(envs, env) {
+				filtered[vhost] = append(filtered[vhost], env)
+			}
+		}
+	}
+	return filtered
+}
+
 // FetchAPIsFromControlPlane method pulls API data for a given APIs according to a
 // given API ID and a list of environments that API has been deployed to.
 // updatedAPIID is the corresponding ID of the API in the form of an UUID
 // updatedEnvs contains the list of environments the API deployed to.
-func FetchAPIsFromControlPlane(updatedAPIID string, updatedEnvs []string) {
+// eventTimeStamp is the timestamp (in milliseconds) of the event, which the API is fetched for.
+func FetchAPIsFromControlPlane(updatedAPIID string, updatedEnvs []string, eventTimeStamp int64) {
 	// Read configurations and derive the eventHub details
 	conf, errReadConfig := config.ReadConfigs()
 	if errReadConfig != nil {
@@ -173,7 +205,7 @@ func FetchAPIsFromControlPlane(updatedAPIID string, updatedEnvs []string) {
 		if data.Resp != nil {
 			// For successfull fetches, data.Resp would return a byte slice with API project(s)
 			logger.LoggerSync.Infof("Pushing data to router and enforcer for the API %q", updatedAPIID)
-			err := PushAPIProjects(data.Resp, finalEnvs)
+			err := PushAPIProjects(data.Resp, finalEnvs, eventTimeStamp)
 			if err != nil {
 				logger.LoggerSync.Errorf("Error occurred while pushing API data for the API %q: %v ", updatedAPIID, err)
 			}
@@ -189,3 +221,43 @@ func FetchAPIsFromControlPlane(updatedAPIID string, updatedEnvs []string) {
 	}
 
 }
+
+// ResyncAPIsFromControlPlane pulls all the APIs deployed in the configured environments from the control plane
+// and re-applies them to the router and enforcer. This can be used to recover from missed deployment events.
+func ResyncAPIsFromControlPlane() error {
+	conf, _ := config.ReadConfigs()
+	envs := conf.ControlPlane.EnvironmentLabels
+	logger.LoggerSync.Infof("Resyncing the APIs of the environments %v from control plane", envs)
+	pulledAt := time.Now().UnixMilli()
+	payload, err := fetchAPIsOfEnvironments(envs)
+	if err != nil || payload == nil {
+		return err
+	}
+	err = PushAPIProjects(payload, envs, pulledAt)
+	health.SetControlPlaneRestAPIStatus(err == nil)
+	return err
+}
+
+// fetchAPIsOfEnvironments pulls the runtime artifacts of all the APIs deployed in the given environments from the
+// control plane. The returned payload is nil if no APIs are deployed in the environments.
+func fetchAPIsOfEnvironments(envs []string) ([]byte, error) {
+	c := make(chan sync.SyncAPIResponse)
+	var queryParamMap map[string]string
+	queryParamMap = common.PopulateQueryParamForOrganizationID(queryParamMap)
+	go sync.FetchAPIs(nil, envs, c, sync.RuntimeArtifactEndpoint, true, nil, queryParamMap)
+	for {
+		data := <-c
+		if data.Resp != nil {
+			return data.Resp, nil
+		} else if data.ErrorCode == 204 {
+			logger.LoggerSync.Infof("No API Artifacts are available in the control plane for the envionments :%s",
+				strings.Join(envs, ", "))
+			return nil, nil
+		} else if data.ErrorCode >= 400 && data.ErrorCode < 500 {
+			return nil, fmt.Errorf("error occurred when retrieving APIs from control plane: %v", data.Err)
+		}
+		logger.LoggerSync.Errorf("Error occurred while fetching APIs from control plane: %v. Hence retrying..",
+			data.Err)
+		sync.RetryFetchingAPIs(c, data, sync.RuntimeArtifactEndpoint, true, queryParamMap)
+	}
+}
@@ -0,0 +1,172 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package synchronizer
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/wso2/product-microgateway/adapter/config"
+	apiServer "github.com/wso2/product-microgateway/adapter/internal/api"
+	"github.com/wso2/product-microgateway/adapter/internal/audit"
+	"github.com/wso2/product-microgateway/adapter/internal/discovery/xds"
+	"github.com/wso2/product-microgateway/adapter/internal/eventorder"
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	"github.com/wso2/product-microgateway/adapter/pkg/health"
+	sync "github.com/wso2/product-microgateway/adapter/pkg/synchronizer"
+)
+
+// CatchUpWithControlPlane applies the API deployment events missed while the broker was unreachable. The control
+// plane does not provide the changes since a given time, hence all the APIs deployed in the configured environments
+// are pulled and re-applied (the unchanged APIs are not pushed to the router and enforcer again), and the APIs
+// which are no longer deployed in those environments are undeployed.
+func CatchUpWithControlPlane() error {
+	conf, _ := config.ReadConfigs()
+	envs := conf.ControlPlane.EnvironmentLabels
+	pulledAt := time.Now().UnixMilli()
+	payload, err := fetchAPIsOfEnvironments(envs)
+	if err != nil {
+		return err
+	}
+	// API UUID -> environments
+	controlPlaneAPIs := make(map[string]map[string]struct{})
+	if payload != nil {
+		if controlPlaneAPIs, err = readAPIEnvironments(payload); err != nil {
+			return err
+		}
+		err = PushAPIProjects(payload, envs, pulledAt)
+		health.SetControlPlaneRestAPIStatus(err == nil)
+		if err != nil {
+			return err
+		}
+	}
+	removedAPIs := getRemovedAPIEnvironments(xds.GetControlPlaneAPIEnvironments(), controlPlaneAPIs, envs)
+	for organizationID, apis := range removedAPIs {
+		for apiUUID, apiEnvs := range apis {
+			// the API is not undeployed from the environments which it is deployed to again after the pull
+			eventorder.ApplyPulled(eventorder.ResourceAPI, apiUUID, apiEnvs, pulledAt, func(apiEnvs []string) {
+				undeployRemovedAPI(organizationID, apiUUID, apiEnvs)
+			})
+		}
+	}
+	return nil
+}
+
+// undeployRemovedAPI undeploys an API from the environments, which the API is removed from in the control plane.
+func undeployRemovedAPI(organizationID, apiUUID string, apiEnvs []string) {
+	logger.LoggerSync.Infof("API %s is no longer deployed in the environments %v of the control plane, "+
+		"hence undeploying", apiUUID, apiEnvs)
+	audit.RecordEvent(audit.EventRecord{
+		Source:         audit.EventSourceCatchUp,
+		Type:           "REMOVE_API_FROM_GATEWAY",
+		ResourceID:     apiUUID,
+		OrganizationID: organizationID,
+		Outcome:        audit.EventProcessed,
+		Reason:         fmt.Sprintf("API is no longer deployed in the environments %v of the control plane", apiEnvs),
+	})
+	xds.DeleteAPIWithAPIMEvent(apiUUID, organizationID, apiEnvs, "")
+	for _, env := range apiEnvs {
+		if xdsAPIList := xds.DeleteAPIAndReturnList(apiUUID, organizationID, env); xdsAPIList != nil {
+			xds.UpdateEnforcerAPIList(env, xdsAPIList)
+		}
+	}
+}
+
+// readAPIEnvironments returns the environments of the APIs in the runtime artifacts, by the UUID of the API.
+func readAPIEnvironments(payload []byte) (map[string]map[string]struct{}, error) {
+	zipReader, err := zip.NewReader(bytes.NewReader(payload), int64(len(payload)))
+	if err != nil {
+		return nil, err
+	}
+	deploymentDescriptor, _, err := sync.ReadRootFiles(zipReader)
+	if err != nil {
+		return nil, err
+	}
+	apiFiles := make(map[string]*zip.File)
+	for _, file := range zipReader.File {
+		if strings.HasSuffix(file.Name, zipExt) {
+			apiFiles[file.Name] = file
+		}
+	}
+	apiEnvironments := make(map[string]map[string]struct{})
+	for _, deployment := range deploymentDescriptor.Data.Deployments {
+		file := apiFiles[deployment.APIFile]
+		if file == nil {
+			return nil, fmt.Errorf("API file %q defined in deployment descriptor not found", deployment.APIFile)
+		}
+		f, err := file.Open()
+		if err != nil {
+			return nil, err
+		}
+		apiFileData, err := ioutil.ReadAll(f)
+		_ = f.Close()
+		if err != nil {
+			return nil, err
+		}
+		apiUUID, err := apiServer.GetAPIIDOfProject(apiFileData)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := apiEnvironments[apiUUID]; !ok {
+			apiEnvironments[apiUUID] = make(map[string]struct{})
+		}
+		for _, environment := range deployment.Environments {
+			apiEnvironments[apiUUID][environment.Name] = struct{}{}
+		}
+	}
+	return apiEnvironments, nil
+}
+
+// getRemovedAPIEnvironments returns the environments (among the given environments) which the deployed APIs are
+// removed from in the control plane, by the organization and the UUID of the API.
+func getRemovedAPIEnvironments(deployedAPIs map[string]map[string][]string,
+	controlPlaneAPIs map[string]map[string]struct{}, envs []string) map[string]map[string][]string {
+	removedAPIs := make(map[string]map[string][]string)
+	for organizationID, apis := range deployedAPIs {
+		for apiUUID, deployedEnvs := range apis {
+			var removedEnvs []string
+			for _, env := range deployedEnvs {
+				if _, found := controlPlaneAPIs[apiUUID][env]; found || !containsEnv(envs, env) {
+					continue
+				}
+				removedEnvs = append(removedEnvs, env)
+			}
+			if len(removedEnvs) == 0 {
+				continue
+			}
+			if _, ok := removedAPIs[organizationID]; !ok {
+				removedAPIs[organizationID] = make(map[string][]string)
+			}
+			removedAPIs[organizationID][apiUUID] = removedEnvs
+		}
+	}
+	return removedAPIs
+}
+
+func containsEnv(envs []string, env string) bool {
+	for _, e := range envs {
+		if e == env {
+			return true
+		}
+	}
+	return false
+}
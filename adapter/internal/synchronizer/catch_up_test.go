@@ -0,0 +1,66 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package synchronizer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetRemovedAPIEnvironments(t *testing.T) {
+	deployedAPIs := map[string]map[string][]string{
+		"carbon.super": {
+			"api-1": {"Default"},
+			"api-2": {"Default", "Production"},
+			"api-3": {"Default"},
+		},
+		"org-2": {
+			"api-4": {"Sandbox"},
+		},
+	}
+	controlPlaneAPIs := map[string]map[string]struct{}{
+		"api-1": {"Default": {}},
+		"api-2": {"Default": {}},
+	}
+
+	removedAPIs := getRemovedAPIEnvironments(deployedAPIs, controlPlaneAPIs, []string{"Default", "Production"})
+	assert.Equal(t, map[string]map[string][]string{
+		"carbon.super": {
+			"api-2": {"Production"},
+			"api-3": {"Default"},
+		},
+	}, removedAPIs, "APIs removed from the configured environments only should be undeployed")
+
+	removedAPIs = getRemovedAPIEnvironments(deployedAPIs, deployedAPIsToControlPlaneAPIs(deployedAPIs),
+		[]string{"Default", "Production"})
+	assert.Empty(t, removedAPIs, "No APIs should be undeployed when the control plane has the same APIs")
+}
+
+func deployedAPIsToControlPlaneAPIs(deployedAPIs map[string]map[string][]string) map[string]map[string]struct{} {
+	controlPlaneAPIs := make(map[string]map[string]struct{})
+	for _, apis := range deployedAPIs {
+		for apiUUID, envs := range apis {
+			controlPlaneAPIs[apiUUID] = make(map[string]struct{})
+			for _, env := range envs {
+				controlPlaneAPIs[apiUUID][env] = struct{}{}
+			}
+		}
+	}
+	return controlPlaneAPIs
+}
@@ -33,6 +33,7 @@ import (
 	"github.com/wso2/product-microgateway/adapter/config"
 	"github.com/wso2/product-microgateway/adapter/internal/common"
 	"github.com/wso2/product-microgateway/adapter/internal/discovery/xds"
+	"github.com/wso2/product-microgateway/adapter/internal/eventorder"
 	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
 	pkgAuth "github.com/wso2/product-microgateway/adapter/pkg/auth"
 	eventhubTypes "github.com/wso2/product-microgateway/adapter/pkg/eventhub/types"
@@ -99,16 +100,19 @@ func FetchKeyManagersOnStartUp(conf *config.Config) {
 
 	// Make the request
 	logger.LoggerSync.Debug("Sending the control plane request")
+	pull := eventorder.BeginPull(eventorder.ResourceKeyManager)
 	resp, err := tlsutils.InvokeControlPlane(req, skipSSL)
 	var errorMsg string
 	if err != nil {
 		errorMsg = "Error occurred while calling the REST API: " + keyManagersEndpoint
+		pull.Apply(func() {})
 		go retryFetchData(conf, errorMsg, err)
 		return
 	}
 	responseBytes, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
 		errorMsg = "Error occurred while reading the response received for: " + keyManagersEndpoint
+		pull.Apply(func() {})
 		go retryFetchData(conf, errorMsg, err)
 		return
 	}
@@ -118,14 +122,19 @@ func FetchKeyManagersOnStartUp(conf *config.Config) {
 		err := json.Unmarshal(responseBytes, &keyManagers)
 		if err != nil {
 			logger.LoggerInternalMsg.Errorf("Error occurred while unmarshelling Key Managers event data %v", err)
+			pull.Apply(func() {})
 			return
 		}
 
-		for _, kmConfig := range keyManagers {
-			xds.KeyManagerList = append(xds.KeyManagerList, kmConfig)
-		}
-		xds.GenerateAndUpdateKeyManagerList()
+		// the key managers changed by the events received while pulling are applied again over the pulled ones
+		pull.Apply(func() {
+			for _, kmConfig := range keyManagers {
+				addOrReplaceKeyManager(kmConfig)
+			}
+			xds.GenerateAndUpdateKeyManagerList()
+		})
 	} else {
+		pull.Apply(func() {})
 		errorMsg = "Failed to fetch data! " + keyManagersEndpoint + " responded with " +
 			strconv.Itoa(resp.StatusCode)
 		go retryFetchData(conf, errorMsg, err)
@@ -134,6 +143,18 @@ func FetchKeyManagersOnStartUp(conf *config.Config) {
 	return
 }
 
+// addOrReplaceKeyManager adds a pulled key manager to the key managers, replacing the key manager of the same name
+// (ex: added by an event received while pulling).
+func addOrReplaceKeyManager(keyManager eventhubTypes.KeyManager) {
+	for i := range xds.KeyManagerList {
+		if strings.EqualFold(keyManager.Name, xds.KeyManagerList[i].Name) {
+			xds.KeyManagerList[i] = keyManager
+			return
+		}
+	}
+	xds.KeyManagerList = append(xds.KeyManagerList, keyManager)
+}
+
 func retryFetchData(conf *config.Config, errorMessage string, err error) {
 	logger.LoggerSync.Debugf("Time Duration for retrying: %v",
 		conf.ControlPlane.RetryInterval*time.Second)
@@ -0,0 +1,148 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package synchronizer
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/wso2/product-microgateway/adapter/pkg/discovery/api/wso2/discovery/throttle"
+)
+
+var (
+	// throttleDataMutex guards the throttle data, as the events and the startup pull update them concurrently
+	throttleDataMutex sync.RWMutex
+	// blockingConditions holds the API, application, user and custom blocking conditions
+	blockingConditions []string
+	// blockingIPConditions holds the IP and IP range blocking conditions
+	blockingIPConditions []*throttle.IPCondition
+	// keyTemplates holds the key templates of the custom throttle policies
+	keyTemplates []string
+)
+
+// AddBlockingCondition adds a blocking condition. The condition is added only once, even if the event
+// is redelivered.
+func AddBlockingCondition(value string) {
+	throttleDataMutex.Lock()
+	defer throttleDataMutex.Unlock()
+	blockingConditions = add(blockingConditions, value)
+}
+
+// RemoveBlockingCondition removes a blocking condition.
+func RemoveBlockingCondition(value string) {
+	throttleDataMutex.Lock()
+	defer throttleDataMutex.Unlock()
+	blockingConditions = remove(blockingConditions, value)
+}
+
+// AddBlockingIPCondition adds an IP blocking condition. A condition with the same ID is replaced, as the
+// IP range of a condition can be updated.
+func AddBlockingIPCondition(ip *throttle.IPCondition) {
+	throttleDataMutex.Lock()
+	defer throttleDataMutex.Unlock()
+	conditions := make([]*throttle.IPCondition, 0, len(blockingIPConditions)+1)
+	for _, condition := range blockingIPConditions {
+		if condition.Id != ip.Id {
+			conditions = append(conditions, condition)
+		}
+	}
+	blockingIPConditions = append(conditions, ip)
+}
+
+// RemoveBlockingIPCondition removes the IP blocking condition with the same ID.
+func RemoveBlockingIPCondition(ip *throttle.IPCondition) {
+	throttleDataMutex.Lock()
+	defer throttleDataMutex.Unlock()
+	conditions := make([]*throttle.IPCondition, 0, len(blockingIPConditions))
+	for _, condition := range blockingIPConditions {
+		if condition.Id != ip.Id {
+			conditions = append(conditions, condition)
+		}
+	}
+	blockingIPConditions = conditions
+}
+
+// AddKeyTemplate adds a key template.
+func AddKeyTemplate(value string) {
+	throttleDataMutex.Lock()
+	defer throttleDataMutex.Unlock()
+	keyTemplates = add(keyTemplates, value)
+}
+
+// RemoveKeyTemplate removes a key template.
+func RemoveKeyTemplate(value string) {
+	throttleDataMutex.Lock()
+	defer throttleDataMutex.Unlock()
+	keyTemplates = remove(keyTemplates, value)
+}
+
+// GetBlockingConditions returns a copy of the blocking conditions.
+func GetBlockingConditions() []string {
+	throttleDataMutex.RLock()
+	defer throttleDataMutex.RUnlock()
+	return append([]string{}, blockingConditions...)
+}
+
+// GetBlockingIPConditions returns a copy of the IP blocking conditions.
+func GetBlockingIPConditions() []*throttle.IPCondition {
+	throttleDataMutex.RLock()
+	defer throttleDataMutex.RUnlock()
+	return append([]*throttle.IPCondition{}, blockingIPConditions...)
+}
+
+// GetKeyTemplates returns a copy of the key templates.
+func GetKeyTemplates() []string {
+	throttleDataMutex.RLock()
+	defer throttleDataMutex.RUnlock()
+	return append([]string{}, keyTemplates...)
+}
+
+func setBlockingConditions(conditions []string, ipConditions []*throttle.IPCondition) {
+	throttleDataMutex.Lock()
+	defer throttleDataMutex.Unlock()
+	blockingConditions = conditions
+	blockingIPConditions = ipConditions
+}
+
+func setKeyTemplates(templates []string) {
+	throttleDataMutex.Lock()
+	defer throttleDataMutex.Unlock()
+	keyTemplates = templates
+}
+
+// add returns a new slice with the value appended, if the value is not already there.
+func add(s []string, v string) []string {
+	for _, value := range s {
+		if strings.EqualFold(v, value) {
+			return s
+		}
+	}
+	return append(append(make([]string, 0, len(s)+1), s...), v)
+}
+
+// remove returns a new slice without the value. The slice is not modified in place, as it may be already
+// pushed to the enforcer.
+func remove(s []string, v string) []string {
+	values := make([]string, 0, len(s))
+	for _, value := range s {
+		if !strings.EqualFold(v, value) {
+			values = append(values, value)
+		}
+	}
+	return values
+}
@@ -0,0 +1,230 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+// Package tenant resolves the numeric IDs of the tenants, which are used by the policies of the enforcer.
+package tenant
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wso2/product-microgateway/adapter/config"
+	logger "github.com/wso2/product-microgateway/adapter/internal/loggers"
+	pkgAuth "github.com/wso2/product-microgateway/adapter/pkg/auth"
+	"github.com/wso2/product-microgateway/adapter/pkg/logging"
+	"github.com/wso2/product-microgateway/adapter/pkg/synchronizer"
+	"github.com/wso2/product-microgateway/adapter/pkg/tlsutils"
+	"gopkg.in/yaml.v2"
+)
+
+// UnresolvedTenantID is the tenant ID used if the tenant ID is not resolved.
+const UnresolvedTenantID int32 = -1
+
+const (
+	eventStrategy        string = "event"
+	controlPlaneStrategy string = "controlPlane"
+	fileStrategy         string = "file"
+	noneStrategy         string = "none"
+
+	tenantInfoEndpoint string = "api/am/admin/v3/tenant-info/"
+)
+
+// Resolver resolves the tenant ID of a tenant domain. The tenant ID in the event, if any, is given as the
+// event tenant ID (0 if the event does not contain the tenant ID).
+type Resolver interface {
+	ResolveTenantID(tenantDomain string, eventTenantID int32) (int32, error)
+}
+
+var (
+	resolver     Resolver
+	resolverOnce sync.Once
+	// tenant domain -> resolved tenant ID, the resolvers other than the event resolver are cached
+	resolvedTenantIDs = make(map[string]int32)
+	// tenant domain -> time of the failed resolution, hence the resolution is not retried for every event
+	failedResolutions = make(map[string]time.Time)
+	resolvedMutex     sync.Mutex
+)
+
+// ResolveTenantID resolves the tenant ID of a tenant domain with the configured strategy, and returns
+// UnresolvedTenantID if it cannot be resolved.
+func ResolveTenantID(tenantDomain string, eventTenantID int32) int32 {
+	resolverOnce.Do(initResolver)
+	if resolver == nil {
+		return UnresolvedTenantID
+	}
+	if _, isEventResolver := resolver.(eventResolver); isEventResolver {
+		if tenantID, err := resolver.ResolveTenantID(tenantDomain, eventTenantID); err == nil {
+			return tenantID
+		}
+		return UnresolvedTenantID
+	}
+
+	resolvedMutex.Lock()
+	defer resolvedMutex.Unlock()
+	if tenantID, found := resolvedTenantIDs[tenantDomain]; found {
+		return tenantID
+	}
+	conf, _ := config.ReadConfigs()
+	if failedAt, failed := failedResolutions[tenantDomain]; failed &&
+		time.Since(failedAt) < conf.ControlPlane.RetryInterval*time.Second {
+		return UnresolvedTenantID
+	}
+	tenantID, err := resolver.ResolveTenantID(tenantDomain, eventTenantID)
+	if err != nil {
+		failedResolutions[tenantDomain] = time.Now()
+		logger.LoggerTenant.ErrorC(logging.ErrorDetails{
+			Message:   fmt.Sprintf("Error while resolving the tenant ID of the tenant domain %q. %v", tenantDomain, err),
+			Severity:  logging.MINOR,
+			ErrorCode: 2401,
+		})
+		return UnresolvedTenantID
+	}
+	delete(failedResolutions, tenantDomain)
+	resolvedTenantIDs[tenantDomain] = tenantID
+	logger.LoggerTenant.Infof("Tenant ID of the tenant domain %q is resolved as %d", tenantDomain, tenantID)
+	return tenantID
+}
+
+func initResolver() {
+	conf, _ := config.ReadConfigs()
+	resolution := conf.ControlPlane.TenantResolution
+	switch resolution.Strategy {
+	case eventStrategy:
+		resolver = eventResolver{}
+	case controlPlaneStrategy:
+		resolver = &controlPlaneResolver{
+			serviceURL:          conf.ControlPlane.ServiceURL,
+			username:            conf.ControlPlane.Username,
+			password:            conf.ControlPlane.Password,
+			skipSSLVerification: conf.ControlPlane.SkipSSLVerification,
+		}
+	case fileStrategy:
+		fileResolver, err := newFileResolver(resolution.MappingFile)
+		if err != nil {
+			logger.LoggerTenant.ErrorC(logging.ErrorDetails{
+				Message: fmt.Sprintf("Error while reading the tenant mapping file %s, hence the tenant IDs are not "+
+					"resolved. %v", resolution.MappingFile, err),
+				Severity:  logging.MAJOR,
+				ErrorCode: 2400,
+			})
+			return
+		}
+		resolver = fileResolver
+	case noneStrategy, "":
+	default:
+		logger.LoggerTenant.ErrorC(logging.ErrorDetails{
+			Message: fmt.Sprintf("Invalid tenant resolution strategy %q, hence the tenant IDs are not resolved.",
+				resolution.Strategy),
+			Severity:  logging.MAJOR,
+			ErrorCode: 2400,
+		})
+	}
+}
+
+// eventResolver resolves the tenant ID from the tenant ID in the event.
+type eventResolver struct{}
+
+func (eventResolver) ResolveTenantID(tenantDomain string, eventTenantID int32) (int32, error) {
+	if eventTenantID == 0 {
+		return UnresolvedTenantID, errors.New("tenant ID is not found in the event")
+	}
+	return eventTenantID, nil
+}
+
+// controlPlaneResolver looks up the tenant ID via the tenant info resource of the admin REST API of the control
+// plane. The tenant info is available for the users of the tenant, hence only the tenant ID of the tenant of the
+// control plane user is resolved.
+type controlPlaneResolver struct {
+	serviceURL          string
+	username            string
+	password            string
+	skipSSLVerification bool
+}
+
+type tenantInfo struct {
+	Username     string `json:"username"`
+	TenantDomain string `json:"tenantDomain"`
+	TenantID     int32  `json:"tenantId"`
+}
+
+func (r *controlPlaneResolver) ResolveTenantID(tenantDomain string, eventTenantID int32) (int32, error) {
+	if tenantDomain != config.GetControlPlaneConnectedTenantDomain() {
+		return UnresolvedTenantID, errors.New("tenant domain is not the tenant domain of the control plane user")
+	}
+	tenantInfoURL := strings.TrimSuffix(r.serviceURL, "/") + "/" + tenantInfoEndpoint + url.PathEscape(r.username)
+	req, err := http.NewRequest(http.MethodGet, tenantInfoURL, nil)
+	if err != nil {
+		return UnresolvedTenantID, err
+	}
+	req.Header.Set(synchronizer.Authorization, "Basic "+pkgAuth.GetBasicAuth(r.username, r.password))
+	resp, err := tlsutils.InvokeControlPlane(req, r.skipSSLVerification)
+	if err != nil {
+		return UnresolvedTenantID, err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return UnresolvedTenantID, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return UnresolvedTenantID, fmt.Errorf("control plane responded with %d", resp.StatusCode)
+	}
+	var info tenantInfo
+	if err := json.Unmarshal(body, &info); err != nil {
+		return UnresolvedTenantID, err
+	}
+	if info.TenantDomain != "" && info.TenantDomain != tenantDomain {
+		return UnresolvedTenantID, fmt.Errorf("control plane responded with the tenant domain %q", info.TenantDomain)
+	}
+	return info.TenantID, nil
+}
+
+// fileResolver looks up the tenant ID in a static mapping of the tenant domains to the tenant IDs.
+type fileResolver struct {
+	tenantIDs map[string]int32
+}
+
+func newFileResolver(mappingFile string) (*fileResolver, error) {
+	content, err := ioutil.ReadFile(mappingFile)
+	if err != nil {
+		return nil, err
+	}
+	return parseTenantMapping(content)
+}
+
+func parseTenantMapping(content []byte) (*fileResolver, error) {
+	tenantIDs := make(map[string]int32)
+	if err := yaml.UnmarshalStrict(content, &tenantIDs); err != nil {
+		return nil, err
+	}
+	return &fileResolver{tenantIDs: tenantIDs}, nil
+}
+
+func (r *fileResolver) ResolveTenantID(tenantDomain string, eventTenantID int32) (int32, error) {
+	tenantID, found := r.tenantIDs[tenantDomain]
+	if !found {
+		return UnresolvedTenantID, errors.New("tenant domain is not found in the mapping file")
+	}
+	return tenantID, nil
+}
@@ -0,0 +1,54 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package tenant
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventResolver(t *testing.T) {
+	tenantID, err := eventResolver{}.ResolveTenantID("wso2.com", 3)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(3), tenantID)
+
+	tenantID, err = eventResolver{}.ResolveTenantID("wso2.com", 0)
+	assert.NotNil(t, err, "Tenant ID is resolved although the event does not contain it")
+	assert.Equal(t, UnresolvedTenantID, tenantID)
+}
+
+func TestFileResolver(t *testing.T) {
+	resolver, err := parseTenantMapping([]byte("carbon.super: -1234\nwso2.com: 1\n"))
+	assert.Nil(t, err)
+
+	tenantID, err := resolver.ResolveTenantID("carbon.super", 0)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(-1234), tenantID)
+
+	// the tenant ID in the event is not considered
+	tenantID, err = resolver.ResolveTenantID("wso2.com", 5)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(1), tenantID)
+
+	_, err = resolver.ResolveTenantID("foo.com", 0)
+	assert.NotNil(t, err)
+
+	_, err = parseTenantMapping([]byte("wso2.com: one\n"))
+	assert.NotNil(t, err, "Invalid tenant ID is accepted")
+}
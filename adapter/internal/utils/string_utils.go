@@ -18,6 +18,8 @@
 package stringutils
 
 import (
+	"bytes"
+	"encoding/json"
 	"strings"
 )
 
@@ -48,6 +50,49 @@ func MaskToken(token string) string {
 	return MaskString(token, 4, "*", false)
 }
 
+// MaskJSONFields masks the string values of the given fields (compared case insensitively) of a json document, at
+// any depth, with MaskToken. The document is returned as it is if it is not valid json.
+func MaskJSONFields(data []byte, fields []string) []byte {
+	var document interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&document); err != nil {
+		return data
+	}
+	masked, err := json.Marshal(maskFields(document, fields))
+	if err != nil {
+		return data
+	}
+	return masked
+}
+
+func maskFields(value interface{}, fields []string) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for key, fieldValue := range typed {
+			if str, isString := fieldValue.(string); isString && containsFold(fields, key) {
+				typed[key] = MaskToken(str)
+			} else {
+				typed[key] = maskFields(fieldValue, fields)
+			}
+		}
+	case []interface{}:
+		for i, item := range typed {
+			typed[i] = maskFields(item, fields)
+		}
+	}
+	return value
+}
+
+func containsFold(list []string, a string) bool {
+	for _, b := range list {
+		if strings.EqualFold(a, b) {
+			return true
+		}
+	}
+	return false
+}
+
 // StringInSlice checks whether a given string is included in the slice
 func StringInSlice(a string, list []string) bool {
 	for _, b := range list {
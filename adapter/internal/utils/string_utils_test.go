@@ -95,3 +95,11 @@ func TestMaskString(t *testing.T) {
 		assert.Equal(t, item.output, result, item.message, i)
 	}
 }
+
+func TestMaskJSONFields(t *testing.T) {
+	masked := MaskJSONFields([]byte(`{"consumerKey": "abcd1234efgh", "name": "app1", "applicationId": 10,
+		"keys": [{"ConsumerKey": "ijkl5678"}]}`), []string{"consumerKey"})
+	assert.JSONEq(t, `{"consumerKey": "**********efgh", "name": "app1", "applicationId": 10,
+		"keys": [{"ConsumerKey": "**********5678"}]}`, string(masked))
+	assert.Equal(t, "not-json", string(MaskJSONFields([]byte("not-json"), []string{"consumerKey"})))
+}
@@ -0,0 +1,343 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GetToken retrieves an access token for the configured username and password, and uses it for the
+// subsequent requests.
+func (c *Client) GetToken(ctx context.Context) (string, error) {
+	var tokenResp struct {
+		AccessToken string `json:"accessToken"`
+	}
+	err := c.do(ctx, request{
+		method:      http.MethodPost,
+		path:        "/oauth2/token",
+		contentType: "application/json",
+		noAuth:      true,
+		body: func() (io.Reader, error) {
+			payload, err := json.Marshal(map[string]string{"username": c.username, "password": c.password})
+			return bytes.NewReader(payload), err
+		},
+	}, &tokenResp)
+	if err != nil {
+		return "", err
+	}
+	c.SetToken(tokenResp.AccessToken)
+	return tokenResp.AccessToken, nil
+}
+
+// DeployAPI deploys the API project (zip archive) in the adapter. An existing API is updated only if
+// override is true.
+func (c *Client) DeployAPI(ctx context.Context, apiProject []byte, override bool) (*DeployResponse, error) {
+	body, contentType, err := createProjectForm(apiProject)
+	if err != nil {
+		return nil, err
+	}
+	var deployResp DeployResponse
+	err = c.do(ctx, request{
+		method:      http.MethodPost,
+		path:        "/apis",
+		query:       url.Values{"override": []string{strconv.FormatBool(override)}},
+		contentType: contentType,
+		body: func() (io.Reader, error) {
+			return bytes.NewReader(body), nil
+		},
+	}, &deployResp)
+	if err != nil {
+		return nil, err
+	}
+	return &deployResp, nil
+}
+
+// ValidateAPI reports the features of the API project (zip archive), which are not supported by the gateway.
+// The project is not deployed.
+func (c *Client) ValidateAPI(ctx context.Context, apiProject []byte) (*CapabilityReport, error) {
+	body, contentType, err := createProjectForm(apiProject)
+	if err != nil {
+		return nil, err
+	}
+	var report CapabilityReport
+	err = c.do(ctx, request{
+		method:      http.MethodPost,
+		path:        "/apis/validate",
+		contentType: contentType,
+		body: func() (io.Reader, error) {
+			return bytes.NewReader(body), nil
+		},
+	}, &report)
+	if err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// createProjectForm creates the multipart form with the API project, and returns the form with its content type.
+func createProjectForm(apiProject []byte) ([]byte, string, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("file", "api.zip")
+	if err != nil {
+		return nil, "", err
+	}
+	if _, err = part.Write(apiProject); err != nil {
+		return nil, "", err
+	}
+	if err = writer.Close(); err != nil {
+		return nil, "", err
+	}
+	return body.Bytes(), writer.FormDataContentType(), nil
+}
+
+// UndeployAPI undeploys the API from the adapter.
+func (c *Client) UndeployAPI(ctx context.Context, undeployReq UndeployRequest) (*DeployResponse, error) {
+	query := url.Values{
+		"apiName": []string{undeployReq.APIName},
+		"version": []string{undeployReq.Version},
+	}
+	if undeployReq.Vhost != "" {
+		query.Set("vhost", undeployReq.Vhost)
+	}
+	if len(undeployReq.Environments) > 0 {
+		query.Set("environments", strings.Join(undeployReq.Environments, ":"))
+	}
+	var deployResp DeployResponse
+	if err := c.do(ctx, request{method: http.MethodDelete, path: "/apis", query: query}, &deployResp); err != nil {
+		return nil, err
+	}
+	return &deployResp, nil
+}
+
+// PromoteAPIRevision routes the complete traffic of the API, which is split between two revisions, to the newly
+// deployed revision, and returns the promoted revision.
+func (c *Client) PromoteAPIRevision(ctx context.Context, revisionReq APIRevisionRequest) (*APIRevision, error) {
+	return c.changeAPIRevision(ctx, "/apis/revisions/promote", revisionReq)
+}
+
+// RollbackAPIRevision re-publishes the previously deployed revision of the API, whose traffic is split between
+// two revisions, and returns the restored revision.
+func (c *Client) RollbackAPIRevision(ctx context.Context, revisionReq APIRevisionRequest) (*APIRevision, error) {
+	return c.changeAPIRevision(ctx, "/apis/revisions/rollback", revisionReq)
+}
+
+func (c *Client) changeAPIRevision(ctx context.Context, path string, revisionReq APIRevisionRequest) (*APIRevision,
+	error) {
+	query := url.Values{
+		"apiName": []string{revisionReq.APIName},
+		"version": []string{revisionReq.Version},
+	}
+	if revisionReq.Vhost != "" {
+		query.Set("vhost", revisionReq.Vhost)
+	}
+	var revision APIRevision
+	if err := c.do(ctx, request{method: http.MethodPost, path: path, query: query}, &revision); err != nil {
+		return nil, err
+	}
+	return &revision, nil
+}
+
+// ListAPIs lists the APIs deployed in the adapter.
+func (c *Client) ListAPIs(ctx context.Context, opts ListAPIsOptions) (*APIList, error) {
+	query := url.Values{}
+	if opts.Query != "" {
+		query.Set("query", opts.Query)
+	}
+	if opts.Limit > 0 {
+		query.Set("limit", strconv.FormatInt(opts.Limit, 10))
+	}
+	var apiList APIList
+	if err := c.do(ctx, request{method: http.MethodGet, path: "/apis", query: query}, &apiList); err != nil {
+		return nil, err
+	}
+	return &apiList, nil
+}
+
+// GetState returns the configuration state of each gateway environment.
+func (c *Client) GetState(ctx context.Context) ([]GatewayState, error) {
+	var states []GatewayState
+	if err := c.do(ctx, request{method: http.MethodGet, path: "/state"}, &states); err != nil {
+		return nil, err
+	}
+	return states, nil
+}
+
+// Resync triggers pulling all the APIs from the control plane in a job, and returns the job without waiting
+// for the APIs to be applied.
+func (c *Client) Resync(ctx context.Context) (*Job, error) {
+	var job Job
+	if err := c.do(ctx, request{method: http.MethodPost, path: "/resync"}, &job); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// Rebuild triggers replacing the deployed APIs with the latest deployment of each API in the audit journal in
+// a job, and returns the job. The APIs which would be rebuilt are the result of the job (RebuildResult), without
+// altering the deployed APIs, if dryRun is true.
+func (c *Client) Rebuild(ctx context.Context, dryRun bool) (*Job, error) {
+	query := url.Values{"dryRun": []string{strconv.FormatBool(dryRun)}}
+	var job Job
+	if err := c.do(ctx, request{method: http.MethodPost, path: "/rebuild", query: query}, &job); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// GetJob returns the job along with its logs and result.
+func (c *Client) GetJob(ctx context.Context, jobID string) (*Job, error) {
+	var job Job
+	if err := c.do(ctx, request{method: http.MethodGet, path: "/jobs/" + url.PathEscape(jobID)}, &job); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// ListJobs returns the jobs retained in the adapter, from the latest. The logs and results are not included.
+func (c *Client) ListJobs(ctx context.Context) ([]Job, error) {
+	var jobList []Job
+	if err := c.do(ctx, request{method: http.MethodGet, path: "/jobs"}, &jobList); err != nil {
+		return nil, err
+	}
+	return jobList, nil
+}
+
+// WaitForJob polls the job until it is completed, and returns the completed job. The result of the job is
+// decoded to result, if result is not nil and the job is succeeded.
+func (c *Client) WaitForJob(ctx context.Context, jobID string, pollInterval time.Duration,
+	result interface{}) (*Job, error) {
+	for {
+		job, err := c.GetJob(ctx, jobID)
+		if err != nil {
+			return nil, err
+		}
+		if job.Status == JobStatusSucceeded || job.Status == JobStatusFailed {
+			if result != nil && job.Status == JobStatusSucceeded && len(job.Result) > 0 {
+				if err := json.Unmarshal(job.Result, result); err != nil {
+					return job, fmt.Errorf("error while parsing the job result. %v", err)
+				}
+			}
+			return job, nil
+		}
+		select {
+		case <-ctx.Done():
+			return job, ctx.Err()
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// GetChangeReport retrieves the signed report of the API configuration changes within the time range.
+func (c *Client) GetChangeReport(ctx context.Context, from, to time.Time) (*ChangeReport, error) {
+	query := url.Values{
+		"from": []string{from.UTC().Format(time.RFC3339)},
+		"to":   []string{to.UTC().Format(time.RFC3339)},
+	}
+	var report ChangeReport
+	if err := c.do(ctx, request{method: http.MethodGet, path: "/audit/changes", query: query}, &report); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// GetRateLimitExemptions returns the clients exempted from the rate limits of the router.
+func (c *Client) GetRateLimitExemptions(ctx context.Context) ([]RateLimitExemption, error) {
+	var exemptions []RateLimitExemption
+	if err := c.do(ctx, request{method: http.MethodGet, path: "/ratelimit/exemptions"}, &exemptions); err != nil {
+		return nil, err
+	}
+	return exemptions, nil
+}
+
+// AddRateLimitExemption exempts the client from the rate limits of the router, and returns the exemption
+// as added by the adapter.
+func (c *Client) AddRateLimitExemption(ctx context.Context, exemption RateLimitExemption) (*RateLimitExemption,
+	error) {
+	payload, err := json.Marshal(exemption)
+	if err != nil {
+		return nil, err
+	}
+	var added RateLimitExemption
+	err = c.do(ctx, request{
+		method:      http.MethodPost,
+		path:        "/ratelimit/exemptions",
+		contentType: "application/json",
+		body: func() (io.Reader, error) {
+			return bytes.NewReader(payload), nil
+		},
+	}, &added)
+	if err != nil {
+		return nil, err
+	}
+	return &added, nil
+}
+
+// RemoveRateLimitExemption removes the exemption of the client from the rate limits of the router.
+func (c *Client) RemoveRateLimitExemption(ctx context.Context, exemption RateLimitExemption) error {
+	query := url.Values{
+		"type":  []string{exemption.Type},
+		"value": []string{exemption.Value},
+	}
+	return c.do(ctx, request{method: http.MethodDelete, path: "/ratelimit/exemptions", query: query}, nil)
+}
+
+// GetConfigDump retrieves the configuration generated by the adapter for the routers and the enforcers of each
+// gateway environment, as a json document.
+func (c *Client) GetConfigDump(ctx context.Context) (json.RawMessage, error) {
+	var dump json.RawMessage
+	if err := c.do(ctx, request{method: http.MethodGet, path: "/config"}, &dump); err != nil {
+		return nil, err
+	}
+	return dump, nil
+}
+
+// InjectEvents processes the synthetic control plane events in the adapter, in the given order, as if those are
+// received from the message broker, and returns the number of events injected. The event injection should be
+// enabled in the adapter.
+func (c *Client) InjectEvents(ctx context.Context, events []SyntheticEvent) (int, error) {
+	payload, err := json.Marshal(events)
+	if err != nil {
+		return 0, err
+	}
+	var injectResp struct {
+		Injected int `json:"injected"`
+	}
+	err = c.do(ctx, request{
+		method:      http.MethodPost,
+		path:        "/events/inject",
+		contentType: "application/json",
+		body: func() (io.Reader, error) {
+			return bytes.NewReader(payload), nil
+		},
+	}, &injectResp)
+	if err != nil {
+		return 0, err
+	}
+	return injectResp.Injected, nil
+}
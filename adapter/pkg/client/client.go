@@ -0,0 +1,235 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+// Package client provides a Go client for the adapter REST API, which can be used to deploy, undeploy and
+// inspect the APIs of the gateway programmatically.
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	basePath             string        = "/api/mgw/adapter/0.1"
+	defaultTimeout       time.Duration = 30 * time.Second
+	defaultRetryInterval time.Duration = time.Second
+)
+
+// Client invokes the adapter REST API.
+type Client struct {
+	baseURL       string
+	username      string
+	password      string
+	token         string
+	maxRetries    int
+	retryInterval time.Duration
+	httpClient    *http.Client
+}
+
+// NewClient creates a client for the adapter REST API.
+func NewClient(conf Config) (*Client, error) {
+	if conf.BaseURL == "" {
+		return nil, errors.New("base URL of the adapter is not provided")
+	}
+	if _, err := url.ParseRequestURI(conf.BaseURL); err != nil {
+		return nil, fmt.Errorf("invalid base URL of the adapter. %v", err)
+	}
+	tlsConfig, err := createTLSConfig(conf.TLS)
+	if err != nil {
+		return nil, err
+	}
+	timeout := conf.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	retryInterval := conf.RetryInterval
+	if retryInterval <= 0 {
+		retryInterval = defaultRetryInterval
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	return &Client{
+		baseURL:       strings.TrimSuffix(conf.BaseURL, "/") + basePath,
+		username:      conf.Username,
+		password:      conf.Password,
+		token:         conf.Token,
+		maxRetries:    conf.MaxRetries,
+		retryInterval: retryInterval,
+		httpClient: &http.Client{
+			Transport: transport,
+			Timeout:   timeout,
+		},
+	}, nil
+}
+
+// SetToken sets the token used for bearer authentication of the subsequent requests.
+func (c *Client) SetToken(token string) {
+	c.token = token
+}
+
+func createTLSConfig(conf TLSConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: conf.InsecureSkipVerify,
+	}
+	if conf.CACertFile != "" {
+		caCert, err := ioutil.ReadFile(conf.CACertFile)
+		if err != nil {
+			return nil, fmt.Errorf("error while reading the CA certificate. %v", err)
+		}
+		caCertPool := x509.NewCertPool()
+		if !caCertPool.AppendCertsFromPEM(caCert) {
+			return nil, errors.New("no valid certificates are found in the CA certificate file")
+		}
+		tlsConfig.RootCAs = caCertPool
+	}
+	if conf.CertFile != "" || conf.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(conf.CertFile, conf.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("error while loading the client certificate. %v", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
+
+// request represents a request to the adapter. The body is created for each attempt, as the body of a
+// failed attempt may already be consumed.
+type request struct {
+	method      string
+	path        string
+	query       url.Values
+	contentType string
+	body        func() (io.Reader, error)
+	// noAuth skips adding the authorization header
+	noAuth bool
+}
+
+// do sends the request, retrying on failures, and decodes the JSON response to the result if provided.
+func (c *Client) do(ctx context.Context, req request, result interface{}) error {
+	interval := c.retryInterval
+	for attempt := 0; ; attempt++ {
+		resp, err := c.send(ctx, req)
+		if err == nil && !isRetriableStatus(req.method, resp.StatusCode) {
+			defer resp.Body.Close()
+			return decodeResponse(resp, result)
+		}
+		retriable := err != nil && isRetriableError(req.method, err)
+		if err == nil {
+			err = decodeResponse(resp, nil)
+			resp.Body.Close()
+			retriable = true
+		}
+		if !retriable || attempt >= c.maxRetries || ctx.Err() != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(interval):
+		}
+		interval *= 2
+	}
+}
+
+func (c *Client) send(ctx context.Context, req request) (*http.Response, error) {
+	var body io.Reader
+	if req.body != nil {
+		var err error
+		if body, err = req.body(); err != nil {
+			return nil, err
+		}
+	}
+	reqURL := c.baseURL + req.path
+	if len(req.query) > 0 {
+		reqURL += "?" + req.query.Encode()
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, req.method, reqURL, body)
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Accept", "application/json")
+	if req.contentType != "" {
+		httpReq.Header.Set("Content-Type", req.contentType)
+	}
+	if !req.noAuth {
+		if c.token != "" {
+			httpReq.Header.Set("Authorization", "Bearer "+c.token)
+		} else if c.username != "" {
+			httpReq.SetBasicAuth(c.username, c.password)
+		}
+	}
+	return c.httpClient.Do(httpReq)
+}
+
+// isRetriableStatus checks whether the request can be retried on the status. A request, which is not idempotent
+// (ie: deploying an API or submitting a job), is retried only if the adapter has not processed it.
+func isRetriableStatus(method string, statusCode int) bool {
+	if statusCode == http.StatusTooManyRequests || statusCode == http.StatusServiceUnavailable {
+		return true
+	}
+	return isIdempotent(method) &&
+		(statusCode == http.StatusBadGateway || statusCode == http.StatusGatewayTimeout)
+}
+
+// isRetriableError checks whether the request can be retried on the transport error. A request, which is not
+// idempotent, is retried only if the connection to the adapter is failed, hence the request is not sent.
+func isRetriableError(method string, err error) bool {
+	if isIdempotent(method) {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+func isIdempotent(method string) bool {
+	return method == http.MethodGet || method == http.MethodDelete
+}
+
+func decodeResponse(resp *http.Response, result interface{}) error {
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("error while reading the response. %v", err)
+	}
+	if resp.StatusCode >= 400 {
+		apiErr := &Error{StatusCode: resp.StatusCode}
+		if json.Unmarshal(respBody, apiErr) != nil || apiErr.Message == "" {
+			apiErr.Message = http.StatusText(resp.StatusCode)
+		}
+		return apiErr
+	}
+	if result == nil || len(bytes.TrimSpace(respBody)) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(respBody, result); err != nil {
+		return fmt.Errorf("error while parsing the response. %v", err)
+	}
+	return nil
+}
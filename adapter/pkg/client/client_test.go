@@ -0,0 +1,188 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeployAPIWithRetries(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		assert.Equal(t, basePath+"/apis", r.URL.Path, "Deploy path mismatch")
+		assert.Equal(t, "true", r.URL.Query().Get("override"), "Override query parameter mismatch")
+		username, password, ok := r.BasicAuth()
+		assert.True(t, ok && username == "admin" && password == "admin", "Basic auth header mismatch")
+		file, _, err := r.FormFile("file")
+		assert.Nil(t, err, "API project should be sent in each attempt")
+		content, _ := ioutil.ReadAll(file)
+		assert.Equal(t, "zip-content", string(content), "API project content mismatch")
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"action":"DEPLOYED","info":"API deployed successfully"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{BaseURL: server.URL, Username: "admin", Password: "admin", MaxRetries: 2,
+		RetryInterval: time.Millisecond})
+	assert.Nil(t, err, "Error while creating the client")
+	resp, err := client.DeployAPI(context.Background(), []byte("zip-content"), true)
+	assert.Nil(t, err, "API should be deployed after retrying")
+	assert.Equal(t, "DEPLOYED", resp.Action)
+	assert.Equal(t, 3, attempts, "Number of attempts mismatch")
+
+	attempts = 0
+	client.maxRetries = 1
+	_, err = client.DeployAPI(context.Background(), []byte("zip-content"), true)
+	assert.NotNil(t, err, "Error should be returned when the retries are exhausted")
+	assert.Equal(t, http.StatusServiceUnavailable, err.(*Error).StatusCode)
+}
+
+func TestDeployAPIWithoutRetriesOnGatewayTimeout(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusGatewayTimeout)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{BaseURL: server.URL, Token: "token", MaxRetries: 2,
+		RetryInterval: time.Millisecond})
+	assert.Nil(t, err, "Error while creating the client")
+	_, err = client.DeployAPI(context.Background(), []byte("zip-content"), false)
+	assert.NotNil(t, err, "Gateway timeout should be returned")
+	assert.Equal(t, http.StatusGatewayTimeout, err.(*Error).StatusCode)
+	assert.Equal(t, 1, attempts, "Deploy request, which may have been processed, should not be retried")
+
+	attempts = 0
+	_, err = client.ListJobs(context.Background())
+	assert.NotNil(t, err, "Gateway timeout should be returned")
+	assert.Equal(t, 3, attempts, "Idempotent request should be retried")
+}
+
+func TestUndeployAPIWithError(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		assert.Equal(t, http.MethodDelete, r.Method)
+		assert.Equal(t, "petstore", r.URL.Query().Get("apiName"))
+		assert.Equal(t, "1.0.0", r.URL.Query().Get("version"))
+		assert.Equal(t, "Default:Staging", r.URL.Query().Get("environments"))
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"), "Token should take precedence")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"code":404,"message":"Not Found","description":"API is not found"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{BaseURL: server.URL, Username: "admin", Password: "admin", Token: "token",
+		MaxRetries: 3, RetryInterval: time.Millisecond})
+	assert.Nil(t, err, "Error while creating the client")
+	_, err = client.UndeployAPI(context.Background(), UndeployRequest{APIName: "petstore", Version: "1.0.0",
+		Environments: []string{"Default", "Staging"}})
+	assert.Equal(t, 1, attempts, "Client errors should not be retried")
+	apiErr, ok := err.(*Error)
+	assert.True(t, ok, "Adapter error should be returned")
+	assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	assert.Equal(t, "API is not found", apiErr.Description)
+}
+
+func TestPromoteAPIRevision(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, basePath+"/apis/revisions/promote", r.URL.Path, "Promote path mismatch")
+		assert.Equal(t, "petstore", r.URL.Query().Get("apiName"))
+		assert.Equal(t, "1.0.0", r.URL.Query().Get("version"))
+		assert.Equal(t, "us.wso2.com", r.URL.Query().Get("vhost"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"apiName":"petstore","version":"1.0.0","vhost":"us.wso2.com","revisionId":"2"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{BaseURL: server.URL, Token: "token"})
+	assert.Nil(t, err, "Error while creating the client")
+	revision, err := client.PromoteAPIRevision(context.Background(), APIRevisionRequest{APIName: "petstore",
+		Version: "1.0.0", Vhost: "us.wso2.com"})
+	assert.Nil(t, err, "Revision should be promoted")
+	assert.Equal(t, "2", revision.RevisionID)
+}
+
+func TestInjectEvents(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, basePath+"/events/inject", r.URL.Path, "Inject path mismatch")
+		var events []SyntheticEvent
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&events), "Events should be sent as a json array")
+		assert.Equal(t, 1, len(events), "Number of events mismatch")
+		assert.Equal(t, "API_DELETE", events[0].Type, "Event type mismatch")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"injected":1}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{BaseURL: server.URL, Token: "token"})
+	assert.Nil(t, err, "Error while creating the client")
+	injected, err := client.InjectEvents(context.Background(), []SyntheticEvent{{Type: "API_DELETE",
+		Event: json.RawMessage(`{"apiId":1}`)}})
+	assert.Nil(t, err, "Events should be injected")
+	assert.Equal(t, 1, injected, "Number of injected events mismatch")
+}
+
+func TestNewClientWithInvalidConfig(t *testing.T) {
+	_, err := NewClient(Config{})
+	assert.NotNil(t, err, "Base URL should be required")
+	_, err = NewClient(Config{BaseURL: "https://localhost:9843", TLS: TLSConfig{CertFile: "not-found.pem"}})
+	assert.NotNil(t, err, "Client certificate should be loaded")
+}
+
+func TestWaitForJob(t *testing.T) {
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		assert.Equal(t, basePath+"/jobs/job1", r.URL.Path, "Job path mismatch")
+		w.Header().Set("Content-Type", "application/json")
+		if polls < 2 {
+			w.Write([]byte(`{"id":"job1","type":"REBUILD","status":"RUNNING","progress":50}`))
+			return
+		}
+		w.Write([]byte(`{"id":"job1","type":"REBUILD","status":"SUCCEEDED","progress":100,
+			"result":{"dryRun":true,"journalRecords":2,"apis":[{"apiIdentifier":"api1"}]}}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{BaseURL: server.URL, Token: "token"})
+	assert.Nil(t, err, "Error while creating the client")
+	var result RebuildResult
+	job, err := client.WaitForJob(context.Background(), "job1", time.Millisecond, &result)
+	assert.Nil(t, err, "Error while waiting for the job")
+	assert.Equal(t, JobStatusSucceeded, job.Status, "Job status mismatch")
+	assert.Equal(t, 2, polls, "Job should be polled until it is completed")
+	assert.Equal(t, 1, len(result.APIs), "Job result mismatch")
+}
@@ -0,0 +1,235 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Config holds the configurations of the adapter client.
+type Config struct {
+	// BaseURL of the adapter REST API. ie: https://adapter:9843
+	BaseURL string
+	// Username and Password are used for basic authentication, if the Token is not provided.
+	Username string
+	Password string
+	// Token is used for bearer authentication.
+	Token string
+	TLS   TLSConfig
+	// Timeout of a single request. Defaults to 30 seconds.
+	Timeout time.Duration
+	// MaxRetries is the number of times a failed request is retried. Requests are retried on connection
+	// failures and when the adapter is temporarily unavailable. The requests, which are not idempotent (POST),
+	// are retried only if the adapter has not received or rejected them (ie: 429 and 503 responses).
+	MaxRetries int
+	// RetryInterval is the initial interval between the retries, which is doubled after each retry.
+	// Defaults to 1 second.
+	RetryInterval time.Duration
+}
+
+// TLSConfig holds the TLS configurations of the adapter client.
+type TLSConfig struct {
+	// CACertFile is the CA certificate(s) to validate the adapter certificate. System CAs are used if not provided.
+	CACertFile string
+	// CertFile and KeyFile are the client certificate and key presented to the adapter (mutual TLS).
+	CertFile string
+	KeyFile  string
+	// InsecureSkipVerify skips the adapter certificate validation. Should not be used in production.
+	InsecureSkipVerify bool
+}
+
+// APIList represents the APIs deployed in the adapter.
+type APIList struct {
+	Count int64      `json:"count,omitempty"`
+	Total int64      `json:"total,omitempty"`
+	List  []*APIInfo `json:"list"`
+}
+
+// APIInfo represents an API deployed in the adapter.
+type APIInfo struct {
+	APIName     string   `json:"apiName,omitempty"`
+	Version     string   `json:"version,omitempty"`
+	APIType     string   `json:"apiType,omitempty"`
+	Context     string   `json:"context,omitempty"`
+	Vhost       string   `json:"vhost,omitempty"`
+	GatewayEnvs []string `json:"gateway-envs"`
+}
+
+// ListAPIsOptions holds the optional parameters to filter the APIs.
+type ListAPIsOptions struct {
+	// Query to filter the APIs. ie: "type:http" or "type:ws"
+	Query string
+	// Limit is the maximum number of APIs returned. 0 means no limit.
+	Limit int64
+}
+
+// UndeployRequest identifies the API to be undeployed.
+type UndeployRequest struct {
+	APIName string
+	Version string
+	// Vhost of the API. The API is undeployed from all the vhosts if not provided.
+	Vhost string
+	// Environments to undeploy the API from. The API is undeployed from all the environments if not provided.
+	Environments []string
+}
+
+// APIRevisionRequest identifies the API, whose traffic is split between two revisions, to be promoted or
+// rolled back.
+type APIRevisionRequest struct {
+	APIName string
+	Version string
+	// Vhost of the API. The vhost of the default environment is used if not provided.
+	Vhost string
+}
+
+// APIRevision is the revision of an API, which receives the complete traffic of the API after a promotion or
+// a rollback.
+type APIRevision struct {
+	APIName    string `json:"apiName"`
+	Version    string `json:"version"`
+	Vhost      string `json:"vhost"`
+	RevisionID string `json:"revisionId"`
+}
+
+// DeployResponse is the response of a deploy or undeploy request.
+type DeployResponse struct {
+	Action string `json:"action,omitempty"`
+	Info   string `json:"info,omitempty"`
+}
+
+// GatewayState represents the configuration applied to the routers of a gateway environment.
+type GatewayState struct {
+	Label        string `json:"label"`
+	XdsVersion   string `json:"xdsVersion,omitempty"`
+	APICount     int    `json:"apiCount"`
+	RouteCount   int    `json:"routeCount"`
+	ClusterCount int    `json:"clusterCount"`
+}
+
+// ChangeReport is a signed report of the API configuration changes. The signature is calculated over the
+// exact bytes of the Report.
+type ChangeReport struct {
+	Report             json.RawMessage `json:"report"`
+	SignatureAlgorithm string          `json:"signatureAlgorithm"`
+	Signature          string          `json:"signature"`
+	Certificate        string          `json:"certificate"`
+}
+
+// CapabilityReport lists the features of an API project, which are not supported by the gateway.
+type CapabilityReport struct {
+	APIName    string `json:"apiName,omitempty"`
+	APIVersion string `json:"apiVersion,omitempty"`
+	APIType    string `json:"apiType,omitempty"`
+	// Compatible is false if the project has at least one issue with the severity ERROR
+	Compatible bool              `json:"compatible"`
+	Issues     []CapabilityIssue `json:"issues"`
+}
+
+// CapabilityIssue represents a feature of an API project, which is not supported by the gateway. The severity
+// is ERROR if the project is not deployed or is deployed without the feature, and WARNING otherwise.
+type CapabilityIssue struct {
+	Feature  string `json:"feature"`
+	Value    string `json:"value,omitempty"`
+	Location string `json:"location,omitempty"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+// Statuses of the jobs
+const (
+	JobStatusQueued    string = "QUEUED"
+	JobStatusRunning   string = "RUNNING"
+	JobStatusSucceeded string = "SUCCEEDED"
+	JobStatusFailed    string = "FAILED"
+)
+
+// Job represents a long-running admin operation run asynchronously by the adapter.
+type Job struct {
+	ID          string     `json:"id"`
+	Type        string     `json:"type"`
+	Status      string     `json:"status"`
+	CreatedBy   string     `json:"createdBy"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	StartedAt   *time.Time `json:"startedAt,omitempty"`
+	CompletedAt *time.Time `json:"completedAt,omitempty"`
+	// Progress is the percentage of the job completed
+	Progress int           `json:"progress"`
+	Logs     []JobLogEntry `json:"logs,omitempty"`
+	// Result of a succeeded job, which is specific to the job type (i.e. RebuildResult for the rebuild jobs)
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  string          `json:"error,omitempty"`
+}
+
+// JobLogEntry is a log message of a job.
+type JobLogEntry struct {
+	Timestamp time.Time `json:"timestamp"`
+	Message   string    `json:"message"`
+}
+
+// RebuildResult represents the outcome of rebuilding the APIs from the audit journal.
+type RebuildResult struct {
+	DryRun bool `json:"dryRun"`
+	// JournalRecords is the number of records read from the journal, after the latest snapshot
+	JournalRecords int          `json:"journalRecords"`
+	APIs           []RebuiltAPI `json:"apis"`
+	FailedAPIs     []RebuiltAPI `json:"failedApis,omitempty"`
+}
+
+// RebuiltAPI identifies an API rebuilt from the journal and the deployment it is rebuilt from.
+type RebuiltAPI struct {
+	OrganizationID string    `json:"organizationId"`
+	APIIdentifier  string    `json:"apiIdentifier"`
+	APIName        string    `json:"apiName,omitempty"`
+	APIVersion     string    `json:"apiVersion,omitempty"`
+	RevisionID     int       `json:"revisionId,omitempty"`
+	Environments   []string  `json:"environments,omitempty"`
+	DeployedAt     time.Time `json:"deployedAt"`
+	DeployedBy     string    `json:"deployedBy"`
+	Error          string    `json:"error,omitempty"`
+}
+
+// RateLimitExemption represents a client, which is not rate limited by the router. The type is one of
+// consumerKey, applicationId, applicationAttribute or sourceCIDR.
+type RateLimitExemption struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// SyntheticEvent is a control plane event injected to the adapter. The event is the payload published to the
+// message broker for the event type (ex: an APIEvent for DEPLOY_API_IN_GATEWAY).
+type SyntheticEvent struct {
+	Type  string          `json:"type"`
+	Event json.RawMessage `json:"event"`
+}
+
+// Error is returned when the adapter responds with an error status.
+type Error struct {
+	StatusCode  int    `json:"-"`
+	Code        int64  `json:"code"`
+	Message     string `json:"message"`
+	Description string `json:"description,omitempty"`
+}
+
+func (e *Error) Error() string {
+	if e.Description != "" {
+		return fmt.Sprintf("adapter responded with status %d: %s. %s", e.StatusCode, e.Message, e.Description)
+	}
+	return fmt.Sprintf("adapter responded with status %d: %s", e.StatusCode, e.Message)
+}
@@ -67,6 +67,22 @@ type Api struct {
 	GraphQLSchema         string               `protobuf:"bytes,23,opt,name=graphQLSchema,proto3" json:"graphQLSchema,omitempty"`
 	GraphqlComplexityInfo []*GraphqlComplexity `protobuf:"bytes,24,rep,name=graphqlComplexityInfo,proto3" json:"graphqlComplexityInfo,omitempty"`
 	EndpointType          string               `protobuf:"bytes,25,opt,name=endpointType,proto3" json:"endpointType,omitempty"`
+	// Token issuers accepted by the API. Any issuer configured in the enforcer is accepted if empty.
+	AllowedIssuers []string `protobuf:"bytes,26,rep,name=allowedIssuers,proto3" json:"allowedIssuers,omitempty"`
+	// Audiences accepted by the API. The token should contain at least one of them, if not empty.
+	AllowedAudiences []string `protobuf:"bytes,27,rep,name=allowedAudiences,proto3" json:"allowedAudiences,omitempty"`
+	// Maximum number of in-flight requests allowed for an application. Unlimited if 0.
+	MaxConcurrentRequestsPerApplication uint32 `protobuf:"varint,28,opt,name=maxConcurrentRequestsPerApplication,proto3" json:"maxConcurrentRequestsPerApplication,omitempty"`
+	// Whether a JWT describing the consumer is sent to the backend.
+	BackendJwtEnabled bool `protobuf:"varint,29,opt,name=backendJwtEnabled,proto3" json:"backendJwtEnabled,omitempty"`
+	// Header of the backend JWT.
+	BackendJwtHeader string `protobuf:"bytes,30,opt,name=backendJwtHeader,proto3" json:"backendJwtHeader,omitempty"`
+	// Algorithm used to sign the backend JWT.
+	BackendJwtSigningAlgorithm string `protobuf:"bytes,31,opt,name=backendJwtSigningAlgorithm,proto3" json:"backendJwtSigningAlgorithm,omitempty"`
+	// Whether the claims of the user are included in the backend JWT.
+	BackendJwtEnableUserClaims bool `protobuf:"varint,32,opt,name=backendJwtEnableUserClaims,proto3" json:"backendJwtEnableUserClaims,omitempty"`
+	// Application attributes included as claims in the backend JWT. All the attributes are included if empty.
+	BackendJwtApplicationAttributes []string `protobuf:"bytes,33,rep,name=backendJwtApplicationAttributes,proto3" json:"backendJwtApplicationAttributes,omitempty"`
 }
 
 func (x *Api) Reset() {
@@ -276,6 +292,62 @@ func (x *Api) GetEndpointType() string {
 	return ""
 }
 
+func (x *Api) GetAllowedIssuers() []string {
+	if x != nil {
+		return x.AllowedIssuers
+	}
+	return nil
+}
+
+func (x *Api) GetAllowedAudiences() []string {
+	if x != nil {
+		return x.AllowedAudiences
+	}
+	return nil
+}
+
+func (x *Api) GetMaxConcurrentRequestsPerApplication() uint32 {
+	if x != nil {
+		return x.MaxConcurrentRequestsPerApplication
+	}
+	return 0
+}
+
+func (x *Api) GetBackendJwtEnabled() bool {
+	if x != nil {
+		return x.BackendJwtEnabled
+	}
+	return false
+}
+
+func (x *Api) GetBackendJwtHeader() string {
+	if x != nil {
+		return x.BackendJwtHeader
+	}
+	return ""
+}
+
+func (x *Api) GetBackendJwtSigningAlgorithm() string {
+	if x != nil {
+		return x.BackendJwtSigningAlgorithm
+	}
+	return ""
+}
+
+func (x *Api) GetBackendJwtEnableUserClaims() bool {
+	if x != nil {
+		return x.BackendJwtEnableUserClaims
+	}
+	return false
+}
+
+func (x *Api) GetBackendJwtApplicationAttributes() []string {
+	if x != nil {
+		return x.BackendJwtApplicationAttributes
+	}
+	return nil
+}
+
 var File_wso2_discovery_api_api_proto protoreflect.FileDescriptor
 
 var file_wso2_discovery_api_api_proto_rawDesc = []byte{
@@ -296,8 +368,8 @@ var file_wso2_discovery_api_api_proto_rawDesc = []byte{
 	0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x43, 0x65, 0x72, 0x74, 0x69,
 	0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x20, 0x77, 0x73,
 	0x6f, 0x32, 0x2f, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2f, 0x61, 0x70, 0x69,
-	0x2f, 0x67, 0x72, 0x61, 0x70, 0x68, 0x71, 0x6c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xa3,
-	0x09, 0x0a, 0x03, 0x41, 0x70, 0x69, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x2f, 0x67, 0x72, 0x61, 0x70, 0x68, 0x71, 0x6c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xed,
+	0x0c, 0x0a, 0x03, 0x41, 0x70, 0x69, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
 	0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18,
 	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x18, 0x0a, 0x07,
 	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76,
@@ -371,14 +443,43 @@ var file_wso2_discovery_api_api_proto_rawDesc = []byte{
 	0x71, 0x6c, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x78, 0x69, 0x74, 0x79, 0x49, 0x6e, 0x66, 0x6f,
 	0x12, 0x22, 0x0a, 0x0c, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65,
 	0x18, 0x19, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74,
-	0x54, 0x79, 0x70, 0x65, 0x42, 0x72, 0x0a, 0x25, 0x6f, 0x72, 0x67, 0x2e, 0x77, 0x73, 0x6f, 0x32,
-	0x2e, 0x63, 0x68, 0x6f, 0x72, 0x65, 0x6f, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x2e,
-	0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x61, 0x70, 0x69, 0x42, 0x08, 0x41,
-	0x70, 0x69, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x3d, 0x67, 0x69, 0x74, 0x68, 0x75,
-	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x65, 0x6e, 0x76, 0x6f, 0x79, 0x70, 0x72, 0x6f, 0x78, 0x79,
-	0x2f, 0x67, 0x6f, 0x2d, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x2d, 0x70, 0x6c, 0x61, 0x6e,
-	0x65, 0x2f, 0x77, 0x73, 0x6f, 0x32, 0x2f, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79,
-	0x2f, 0x61, 0x70, 0x69, 0x3b, 0x61, 0x70, 0x69, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x54, 0x79, 0x70, 0x65, 0x12, 0x26, 0x0a, 0x0e, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x49,
+	0x73, 0x73, 0x75, 0x65, 0x72, 0x73, 0x18, 0x1a, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x61, 0x6c,
+	0x6c, 0x6f, 0x77, 0x65, 0x64, 0x49, 0x73, 0x73, 0x75, 0x65, 0x72, 0x73, 0x12, 0x2a, 0x0a, 0x10,
+	0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x41, 0x75, 0x64, 0x69, 0x65, 0x6e, 0x63, 0x65, 0x73,
+	0x18, 0x1b, 0x20, 0x03, 0x28, 0x09, 0x52, 0x10, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x41,
+	0x75, 0x64, 0x69, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x50, 0x0a, 0x23, 0x6d, 0x61, 0x78, 0x43,
+	0x6f, 0x6e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x73, 0x50, 0x65, 0x72, 0x41, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x1c, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x23, 0x6d, 0x61, 0x78, 0x43, 0x6f, 0x6e, 0x63, 0x75, 0x72,
+	0x72, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x50, 0x65, 0x72, 0x41,
+	0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2c, 0x0a, 0x11, 0x62, 0x61,
+	0x63, 0x6b, 0x65, 0x6e, 0x64, 0x4a, 0x77, 0x74, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18,
+	0x1d, 0x20, 0x01, 0x28, 0x08, 0x52, 0x11, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x4a, 0x77,
+	0x74, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x12, 0x2a, 0x0a, 0x10, 0x62, 0x61, 0x63, 0x6b,
+	0x65, 0x6e, 0x64, 0x4a, 0x77, 0x74, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x1e, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x10, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x4a, 0x77, 0x74, 0x48, 0x65,
+	0x61, 0x64, 0x65, 0x72, 0x12, 0x3e, 0x0a, 0x1a, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x4a,
+	0x77, 0x74, 0x53, 0x69, 0x67, 0x6e, 0x69, 0x6e, 0x67, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74,
+	0x68, 0x6d, 0x18, 0x1f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x1a, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e,
+	0x64, 0x4a, 0x77, 0x74, 0x53, 0x69, 0x67, 0x6e, 0x69, 0x6e, 0x67, 0x41, 0x6c, 0x67, 0x6f, 0x72,
+	0x69, 0x74, 0x68, 0x6d, 0x12, 0x3e, 0x0a, 0x1a, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x4a,
+	0x77, 0x74, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6c, 0x61, 0x69,
+	0x6d, 0x73, 0x18, 0x20, 0x20, 0x01, 0x28, 0x08, 0x52, 0x1a, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e,
+	0x64, 0x4a, 0x77, 0x74, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6c,
+	0x61, 0x69, 0x6d, 0x73, 0x12, 0x48, 0x0a, 0x1f, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x4a,
+	0x77, 0x74, 0x41, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x41, 0x74, 0x74,
+	0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x18, 0x21, 0x20, 0x03, 0x28, 0x09, 0x52, 0x1f, 0x62,
+	0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x4a, 0x77, 0x74, 0x41, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x42, 0x72,
+	0x0a, 0x25, 0x6f, 0x72, 0x67, 0x2e, 0x77, 0x73, 0x6f, 0x32, 0x2e, 0x63, 0x68, 0x6f, 0x72, 0x65,
+	0x6f, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x2e, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76,
+	0x65, 0x72, 0x79, 0x2e, 0x61, 0x70, 0x69, 0x42, 0x08, 0x41, 0x70, 0x69, 0x50, 0x72, 0x6f, 0x74,
+	0x6f, 0x50, 0x01, 0x5a, 0x3d, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x65, 0x6e, 0x76, 0x6f, 0x79, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x2f, 0x67, 0x6f, 0x2d, 0x63, 0x6f,
+	0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x2d, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2f, 0x77, 0x73, 0x6f, 0x32,
+	0x2f, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2f, 0x61, 0x70, 0x69, 0x3b, 0x61,
+	0x70, 0x69, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
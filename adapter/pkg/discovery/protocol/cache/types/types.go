@@ -31,5 +31,6 @@ const (
 	RevokedTokens
 	ThrottleData
 	GAAPI
+	ScopeList
 	UnknownType // token to count the total number of supported types
 )
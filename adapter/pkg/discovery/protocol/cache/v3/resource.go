@@ -55,6 +55,8 @@ func GetResponseType(typeURL string) types.ResponseType {
 		return types.ThrottleData
 	case resource.GAAPIType:
 		return types.GAAPI
+	case resource.ScopeListType:
+		return types.ScopeList
 	}
 	return types.UnknownType
 }
@@ -81,6 +83,8 @@ func GetResourceName(res envoy_types.Resource) string {
 		return "ApplicationPolicyList"
 	case *subscription.SubscriptionPolicyList:
 		return "SubscriptionPolicyList"
+	case *subscription.ScopeList:
+		return "ScopeList"
 	case *keymgt.KeyManagerConfig:
 		return fmt.Sprint(v.Name)
 	case *throttle.ThrottleData:
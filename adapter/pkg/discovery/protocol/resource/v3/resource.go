@@ -29,6 +29,7 @@ const (
 	RevokedTokensType             = apiTypePrefix + "wso2.discovery.keymgt.RevokedToken"
 	ThrottleDataType              = apiTypePrefix + "wso2.discovery.throttle.ThrottleData"
 	GAAPIType                     = apiTypePrefix + "wso2.discovery.ga.Api"
+	ScopeListType                 = apiTypePrefix + "wso2.discovery.subscription.ScopeList"
 
 	// AnyType is used only by ADS
 	AnyType = ""
@@ -46,6 +46,7 @@ type Server interface {
 	subscription.ApplicationPolicyDiscoveryServiceServer
 	subscription.SubscriptionPolicyDiscoveryServiceServer
 	subscription.ApplicationKeyMappingDiscoveryServiceServer
+	subscription.ScopeDiscoveryServiceServer
 	keymgt.KMDiscoveryServiceServer
 	keymgt.RevokedTokenDiscoveryServiceServer
 	throttle.ThrottleDataDiscoveryServiceServer
@@ -76,6 +77,7 @@ type server struct {
 	subscription.UnimplementedApplicationPolicyDiscoveryServiceServer
 	subscription.UnimplementedSubscriptionPolicyDiscoveryServiceServer
 	subscription.UnimplementedApplicationKeyMappingDiscoveryServiceServer
+	subscription.UnimplementedScopeDiscoveryServiceServer
 	keymgt.UnimplementedKMDiscoveryServiceServer
 	keymgt.UnimplementedRevokedTokenDiscoveryServiceServer
 	throttle.UnimplementedThrottleDataDiscoveryServiceServer
@@ -121,6 +123,10 @@ func (s *server) StreamApplicationKeyMappings(stream subscription.ApplicationKey
 	return s.StreamHandler(stream, resource.ApplicationKeyMappingListType)
 }
 
+func (s *server) StreamScopes(stream subscription.ScopeDiscoveryService_StreamScopesServer) error {
+	return s.StreamHandler(stream, resource.ScopeListType)
+}
+
 func (s *server) StreamKeyManagers(stream keymgt.KMDiscoveryService_StreamKeyManagersServer) error {
 	return s.StreamHandler(stream, resource.KeyManagerType)
 }
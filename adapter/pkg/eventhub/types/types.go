@@ -97,10 +97,11 @@ type APIList struct {
 
 // ApplicationPolicy for struct ApplicationPolicy
 type ApplicationPolicy struct {
-	ID        int32  `json:"id"`
-	TenantID  int32  `json:"tenantId"`
-	Name      string `json:"name"`
-	QuotaType string `json:"quotaType"`
+	ID           int32          `json:"id"`
+	TenantID     int32          `json:"tenantId"`
+	Name         string         `json:"name"`
+	QuotaType    string         `json:"quotaType"`
+	DefaultLimit *ThrottleLimit `json:"defaultLimit,omitempty"`
 }
 
 // ApplicationPolicyList for struct list of ApplicationPolicy
@@ -110,17 +111,31 @@ type ApplicationPolicyList struct {
 
 // SubscriptionPolicy for struct list of SubscriptionPolicy
 type SubscriptionPolicy struct {
-	ID                   int32  `json:"id" json:"policyId"`
-	TenantID             int32  `json:"tenantId"`
-	Name                 string `json:"name"`
-	QuotaType            string `json:"quotaType"`
-	GraphQLMaxComplexity int32  `json:"graphQLMaxComplexity"`
-	GraphQLMaxDepth      int32  `json:"graphQLMaxDepth"`
-	RateLimitCount       int32  `json:"rateLimitCount"`
-	RateLimitTimeUnit    string `json:"rateLimitTimeUnit"`
-	StopOnQuotaReach     bool   `json:"stopOnQuotaReach"`
-	TenantDomain         string `json:"tenanDomain,omitempty"`
-	TimeStamp            int64  `json:"timeStamp,omitempty"`
+	ID                   int32          `json:"id" json:"policyId"`
+	TenantID             int32          `json:"tenantId"`
+	Name                 string         `json:"name"`
+	QuotaType            string         `json:"quotaType"`
+	GraphQLMaxComplexity int32          `json:"graphQLMaxComplexity"`
+	GraphQLMaxDepth      int32          `json:"graphQLMaxDepth"`
+	RateLimitCount       int32          `json:"rateLimitCount"`
+	RateLimitTimeUnit    string         `json:"rateLimitTimeUnit"`
+	StopOnQuotaReach     bool           `json:"stopOnQuotaReach"`
+	TenantDomain         string         `json:"tenanDomain,omitempty"`
+	TimeStamp            int64          `json:"timeStamp,omitempty"`
+	DefaultLimit         *ThrottleLimit `json:"defaultLimit,omitempty"`
+}
+
+// ThrottleLimit for struct default limit of a throttling policy
+type ThrottleLimit struct {
+	QuotaType    string             `json:"quotaType"`
+	RequestCount *RequestCountLimit `json:"requestCount,omitempty"`
+}
+
+// RequestCountLimit for struct request count limit of a throttling policy
+type RequestCountLimit struct {
+	TimeUnit     string `json:"timeUnit"`
+	UnitTime     int32  `json:"unitTime"`
+	RequestCount int32  `json:"requestCount"`
 }
 
 // SubscriptionPolicyList for struct list of SubscriptionPolicy
@@ -141,9 +156,12 @@ type APIPolicy struct {
 
 // Scope for struct Scope
 type Scope struct {
-	Name            string `json:"name"`
-	DisplayName     string `json:"displayName"`
-	ApplicationName string `json:"description"`
+	Name         string `json:"name"`
+	DisplayName  string `json:"displayName"`
+	Description  string `json:"description"`
+	Roles        string `json:"roles"`
+	TenantID     int32  `json:"tenanId,omitempty"`
+	TenantDomain string `json:"tenanDomain,omitempty"`
 }
 
 // ScopeList for struct list of Scope
@@ -36,6 +36,7 @@ var (
 // Service components to be set health status
 const (
 	RestService service = "adapter.internal.RestService"
+	XdsService  service = "adapter.internal.XdsService"
 )
 
 type service string
@@ -39,6 +39,7 @@ const (
 	pkgAdapter   = "github.com/wso2/product-microgateway/adapter/pkg/adapter"
 	pkgSync      = "github.com/wso2/product-microgateway/adapter/pkg/synchronizer"
 	pkgSoapUtils = "github.com/wso2/product-microgateway/adapter/pkg/soaputils"
+	pkgSecrets   = "github.com/wso2/product-microgateway/adapter/pkg/secrets"
 )
 
 // logger package references
@@ -50,6 +51,7 @@ var (
 	LoggerAdapter   logging.Log
 	LoggerSync      logging.Log
 	LoggerSoapUtils logging.Log
+	LoggerSecrets   logging.Log
 )
 
 func init() {
@@ -65,5 +67,6 @@ func UpdateLoggers() {
 	LoggerAdapter = logging.InitPackageLogger(pkgAdapter)
 	LoggerSync = logging.InitPackageLogger(pkgSync)
 	LoggerSoapUtils = logging.InitPackageLogger(pkgSoapUtils)
+	LoggerSecrets = logging.InitPackageLogger(pkgSecrets)
 	logrus.Info("Updated loggers")
 }
@@ -143,3 +143,43 @@ func TestInitGlobalLogger(t *testing.T) {
 		t.Error(e)
 	}
 }
+
+func TestValidateLogConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		message string
+	}{
+		{
+			name:    "Valid log configuration",
+			content: "logLevel = \"INFO\"\n[[pkg]]\nname = \"github.com/wso2/product-microgateway/adapter/internal/adapter\"\nlogLevel = \"DEBG\"\n",
+		},
+		{
+			name:    "Invalid TOML",
+			content: "logLevel = ",
+			message: "error parsing the log configuration",
+		},
+		{
+			name:    "Invalid log level",
+			content: "logLevel = \"DEBUG\"\n",
+			message: "invalid log level \"DEBUG\"",
+		},
+		{
+			name:    "Invalid package log level",
+			content: "logLevel = \"INFO\"\n[[pkg]]\nname = \"github.com/wso2/product-microgateway/adapter/internal/adapter\"\nlogLevel = \"TRACE\"\n",
+			message: "invalid log level \"TRACE\" of the package",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filePath := t.TempDir() + "/log_config.toml"
+			assert.Nil(t, os.WriteFile(filePath, []byte(test.content), 0644))
+			err := ValidateLogConfig(filePath)
+			if test.message == "" {
+				assert.Nil(t, err)
+			} else if assert.NotNil(t, err) {
+				assert.Contains(t, err.Error(), test.message)
+			}
+		})
+	}
+}
@@ -19,9 +19,12 @@
 package logging
 
 import (
+	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
 
+	toml "github.com/pelletier/go-toml"
 	"github.com/sirupsen/logrus"
 	"github.com/wso2/product-microgateway/adapter/pkg/config"
 )
@@ -112,3 +115,32 @@ func InitPackageLogger(pkgName string) Log {
 	logger.SetLevel(pkgLogLevel)
 	return logger
 }
+
+// ValidateLogConfig validates the log configuration file, before the loggers are updated with it.
+func ValidateLogConfig(filePath string) error {
+	content, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("error reading the log configuration. %v", err)
+	}
+	logConf := &config.LogConfig{}
+	if err = toml.Unmarshal(content, logConf); err != nil {
+		return fmt.Errorf("error parsing the log configuration. %v", err)
+	}
+	if err = validateLogLevel(logConf.LogLevel); err != nil {
+		return err
+	}
+	for _, pkg := range logConf.Pkg {
+		if err = validateLogLevel(pkg.LogLevel); err != nil {
+			return fmt.Errorf("%v of the package %s", err, pkg.Name)
+		}
+	}
+	return nil
+}
+
+func validateLogLevel(level string) error {
+	switch level {
+	case "", panicLevel, fatalLevel, errorLevel, warnLevel, infoLevel, debugLevel:
+		return nil
+	}
+	return fmt.Errorf("invalid log level %q", level)
+}
@@ -21,133 +21,169 @@ package messaging
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strconv"
 	"time"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
 	asb "github.com/Azure/azure-sdk-for-go/sdk/messaging/azservicebus"
 	"github.com/Azure/azure-sdk-for-go/sdk/messaging/azservicebus/admin"
 	"github.com/google/uuid"
 	logger "github.com/wso2/product-microgateway/adapter/pkg/loggers"
 )
 
-// TODO: (erandi) when refactoring, refactor organization purge flow as well
-var bindingKeys = []string{tokenRevocation, notification, stepQuotaThreshold, stepQuotaReset, organizationPurge}
+// TopicOrganizationPurge is the topic of the organization purge events, which is available in Azure Service Bus.
+const TopicOrganizationPurge string = organizationPurge
 
-// Subscription stores the meta data of a specific subscription
-type Subscription struct {
-	topicName        string
-	subscriptionName string
+// AzureServiceBusOptions are the options of the Azure Service Bus event source.
+type AzureServiceBusOptions struct {
+	// ConnectionString authenticates with a shared access key. The managed identity is used if it is empty.
+	ConnectionString string
+	// Namespace is the fully qualified namespace (<namespace>.servicebus.windows.net), used with the managed identity
+	Namespace string
+	// ManagedIdentityClientID is the client ID of a user assigned managed identity. The system assigned managed
+	// identity is used if it is empty.
+	ManagedIdentityClientID string
+	// Topics maps the topics consumed to the names of the topics in the namespace
+	Topics map[string]string
+	// SubscriptionName is the subscription of the topics. If it is empty, a subscription unique to the adapter is
+	// created per topic, which is deleted after the SubscriptionIdleTime (ISO 8601 duration).
+	SubscriptionName     string
+	SubscriptionIdleTime string
+	ComponentName        string
+	ReconnectInterval    time.Duration
+	ReconnectRetryCount  int
 }
 
-var (
-	// AzureRevokedTokenChannel stores the revoked token events
-	AzureRevokedTokenChannel chan []byte
-	// AzureNotificationChannel stores the notification events
-	AzureNotificationChannel chan []byte
-	// AzureStepQuotaThresholdChannel stores the step quota threshold events
-	AzureStepQuotaThresholdChannel chan []byte
-	// AzureStepQuotaResetChannel stores the step quota reset events
-	AzureStepQuotaResetChannel chan []byte
-	// AzureOrganizationPurgeChannel stores the Organization Purge events
-	AzureOrganizationPurgeChannel chan []byte
-)
+// azureEventSource consumes the events from the topics of an Azure Service Bus namespace. The events published
+// while the connection is lost are retained in the subscriptions.
+type azureEventSource struct {
+	options AzureServiceBusOptions
+	client  *asb.Client
+	// topic -> subscription
+	subscriptions map[string]string
+	receivers     []*asb.Receiver
+}
 
-func init() {
-	AzureRevokedTokenChannel = make(chan []byte)
-	AzureNotificationChannel = make(chan []byte)
-	AzureStepQuotaThresholdChannel = make(chan []byte)
-	AzureStepQuotaResetChannel = make(chan []byte)
-	AzureOrganizationPurgeChannel = make(chan []byte)
+// NewAzureServiceBusEventSource creates an EventSource for the topics of an Azure Service Bus namespace.
+func NewAzureServiceBusEventSource(options AzureServiceBusOptions) EventSource {
+	return &azureEventSource{options: options, subscriptions: make(map[string]string)}
 }
 
-// InitiateBrokerConnectionAndValidate to initiate connection and validate azure service bus constructs to
-// further process
-func InitiateBrokerConnectionAndValidate(connectionString string, componentName string, reconnectRetryCount int,
-	reconnectInterval time.Duration, subscriptionIdleTimeDuration string) ([]Subscription, error) {
-	subscriptionMetaDataList := make([]Subscription, 0)
-	subProps := &admin.SubscriptionProperties{
-		AutoDeleteOnIdle: &subscriptionIdleTimeDuration,
+func (source *azureEventSource) Connect() error {
+	var adminClient *admin.Client
+	var err error
+	if source.options.ConnectionString != "" {
+		// any error at this point is because the connection string is not up to the expected format,
+		// hence not retrying
+		if source.client, err = asb.NewClientFromConnectionString(source.options.ConnectionString, nil); err != nil {
+			return fmt.Errorf("error occurred while creating the ASB client from the connection string. %v", err)
+		}
+		if source.options.SubscriptionName == "" {
+			adminClient, err = admin.NewClientFromConnectionString(source.options.ConnectionString, nil)
+		}
+	} else {
+		var credential azcore.TokenCredential
+		if credential, err = newManagedIdentityCredential(source.options.ManagedIdentityClientID); err != nil {
+			return fmt.Errorf("error occurred while creating the managed identity credential. %v", err)
+		}
+		if source.client, err = asb.NewClient(source.options.Namespace, credential, nil); err != nil {
+			return fmt.Errorf("error occurred while creating the ASB client for the namespace %s. %v",
+				source.options.Namespace, err)
+		}
+		if source.options.SubscriptionName == "" {
+			adminClient, err = admin.NewClient(source.options.Namespace, credential, nil)
+		}
 	}
-	_, err := asb.NewClientFromConnectionString(connectionString, nil)
-
-	if err == nil {
-		logger.LoggerMsg.Debugf("ASB client initialized for connection url: %s", connectionString)
+	if err != nil {
+		return fmt.Errorf("error occurred while creating the ASB admin client. %v", err)
+	}
+	logger.LoggerMsg.Debug("ASB client initialized")
 
-		for j := 0; j < reconnectRetryCount || reconnectRetryCount == -1; j++ {
-			err = nil
-			subscriptionMetaDataList, err = retrieveSubscriptionMetadata(subscriptionMetaDataList,
-				connectionString, componentName, subProps)
-			if err != nil {
-				logError(reconnectRetryCount, reconnectInterval, err)
-				subscriptionMetaDataList = nil
-				time.Sleep(reconnectInterval)
-				continue
-			}
-			return subscriptionMetaDataList, err
+	for topic, topicName := range source.options.Topics {
+		if source.options.SubscriptionName != "" {
+			source.subscriptions[topic] = source.options.SubscriptionName
+			continue
 		}
+		subscriptionName, err := source.createSubscriptionWithRetries(adminClient, topicName)
 		if err != nil {
-			logger.LoggerMsg.Errorf("%v. Retry attempted %d times.", err, reconnectRetryCount)
-			return subscriptionMetaDataList, err
+			return err
 		}
-	} else {
-		// any error which comes to this point is because the connection url is not up to the expected format
-		// hence not retrying
-		logger.LoggerMsg.Errorf("Error occurred while trying to create ASB client using the connection url %s, err: %v",
-			connectionString, err)
+		source.subscriptions[topic] = subscriptionName
 	}
-	return subscriptionMetaDataList, err
+	return nil
 }
 
-// InitiateConsumers to pass event consumption
-func InitiateConsumers(connectionString string, subscriptionMetaDataList []Subscription, reconnectInterval time.Duration) {
-	for _, subscriptionMetaData := range subscriptionMetaDataList {
-		go func(subscriptionMetaData Subscription) {
-			startBrokerConsumer(connectionString, subscriptionMetaData, reconnectInterval)
-		}(subscriptionMetaData)
+func (source *azureEventSource) Consume(topic string) (<-chan Delivery, error) {
+	subscriptionName, found := source.subscriptions[topic]
+	if !found {
+		return nil, fmt.Errorf("topic %q is not configured", topic)
+	}
+	topicName := source.options.Topics[topic]
+	receiver, err := source.client.NewReceiverForSubscription(topicName, subscriptionName, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error occurred while creating the ASB receiver for the subscription %s of the "+
+			"topic %s. %v", subscriptionName, topicName, err)
 	}
+	source.receivers = append(source.receivers, receiver)
+	deliveries := make(chan Delivery)
+	go receiveMessages(receiver, topicName, subscriptionName, source.options.ReconnectInterval, deliveries)
+	return deliveries, nil
 }
 
-func retrieveSubscriptionMetadata(metaDataList []Subscription, connectionString string, componentName string,
-	opts *admin.SubscriptionProperties) ([]Subscription, error) {
-	parentContext := context.Background()
-	adminClient, clientErr := admin.NewClientFromConnectionString(connectionString, nil)
-	if clientErr != nil {
-		logger.LoggerMsg.Errorf("Error occurred while trying to create ASB admin client using the connection url %s", connectionString)
-		return nil, clientErr
+func (source *azureEventSource) Close() error {
+	if source.client == nil {
+		return nil
 	}
+	for _, receiver := range source.receivers {
+		receiver.Close(context.Background())
+	}
+	return source.client.Close(context.Background())
+}
 
-	for _, key := range bindingKeys {
-		var errorValue error
-		subscriptionMetaData := Subscription{
-			topicName:        key,
-			subscriptionName: "",
-		}
-		// we are creating a unique subscription for each adapter starts. Unused subscriptions will be deleted after
-		// idle for three days
-		uniqueID := uuid.New()
+func newManagedIdentityCredential(clientID string) (azcore.TokenCredential, error) {
+	options := &azidentity.ManagedIdentityCredentialOptions{}
+	if clientID != "" {
+		options.ID = azidentity.ClientID(clientID)
+	}
+	return azidentity.NewManagedIdentityCredential(options)
+}
 
-		// in ASB, subscription names can contain letters, numbers, periods (.), hyphens (-), and
-		// underscores (_), up to 50 characters. Subscription names are also case-insensitive.
-		var subscriptionName = componentName + "_" + uniqueID.String() + "_sub"
-		var subscriptionCreationError error
-		func() {
-			ctx, cancel := context.WithCancel(parentContext)
-			defer cancel()
-			_, subscriptionCreationError = adminClient.CreateSubscription(ctx, key, subscriptionName, &admin.CreateSubscriptionOptions{
-				Properties: opts,
-			})
-		}()
-		if subscriptionCreationError != nil {
-			errorValue = errors.New("Error occurred while trying to create subscription " + subscriptionName + " in ASB for topic name " +
-				key + "." + subscriptionCreationError.Error())
-			return metaDataList, errorValue
+func (source *azureEventSource) createSubscriptionWithRetries(adminClient *admin.Client,
+	topicName string) (string, error) {
+	var err error
+	for i := 0; i < source.options.ReconnectRetryCount || source.options.ReconnectRetryCount == -1; i++ {
+		var subscriptionName string
+		if subscriptionName, err = source.createSubscription(adminClient, topicName); err == nil {
+			return subscriptionName, nil
 		}
-		logger.LoggerMsg.Debugf("Subscription %s created.", subscriptionName)
-		subscriptionMetaData.subscriptionName = subscriptionName
-		subscriptionMetaData.topicName = key
-		metaDataList = append(metaDataList, subscriptionMetaData)
+		logError(source.options.ReconnectRetryCount, source.options.ReconnectInterval, err)
+		time.Sleep(source.options.ReconnectInterval)
+	}
+	logger.LoggerMsg.Errorf("%v. Retry attempted %d times.", err, source.options.ReconnectRetryCount)
+	return "", err
+}
+
+// createSubscription creates a unique subscription for each adapter start. Unused subscriptions are deleted after
+// being idle for the SubscriptionIdleTime.
+func (source *azureEventSource) createSubscription(adminClient *admin.Client, topicName string) (string, error) {
+	// in ASB, subscription names can contain letters, numbers, periods (.), hyphens (-), and
+	// underscores (_), up to 50 characters. Subscription names are also case-insensitive.
+	subscriptionName := source.options.ComponentName + "_" + uuid.New().String() + "_sub"
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_, err := adminClient.CreateSubscription(ctx, topicName, subscriptionName, &admin.CreateSubscriptionOptions{
+		Properties: &admin.SubscriptionProperties{
+			AutoDeleteOnIdle: &source.options.SubscriptionIdleTime,
+		},
+	})
+	if err != nil {
+		return "", errors.New("Error occurred while trying to create subscription " + subscriptionName +
+			" in ASB for topic name " + topicName + "." + err.Error())
 	}
-	return metaDataList, nil
+	logger.LoggerMsg.Debugf("Subscription %s created.", subscriptionName)
+	return subscriptionName, nil
 }
 
 func logError(reconnectRetryCount int, reconnectInterval time.Duration, errVal error) {
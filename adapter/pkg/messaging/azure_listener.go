@@ -20,73 +20,54 @@ package messaging
 
 import (
 	"context"
-	"strings"
 	"time"
 
 	asb "github.com/Azure/azure-sdk-for-go/sdk/messaging/azservicebus"
 	logger "github.com/wso2/product-microgateway/adapter/pkg/loggers"
 )
 
-func startBrokerConsumer(connectionString string, sub Subscription, reconnectInterval time.Duration) {
-	var topic = sub.topicName
-	var subName = sub.subscriptionName
+const deadLetterReason string = "Event cannot be processed by the adapter"
 
-	dataChannel := make(chan []byte)
-	if strings.EqualFold(topic, notification) {
-		dataChannel = AzureNotificationChannel
-	} else if strings.EqualFold(topic, tokenRevocation) {
-		dataChannel = AzureRevokedTokenChannel
-	} else if strings.EqualFold(topic, stepQuotaThreshold) {
-		dataChannel = AzureStepQuotaThresholdChannel
-	} else if strings.EqualFold(topic, stepQuotaReset) {
-		dataChannel = AzureStepQuotaResetChannel
-	} else if strings.EqualFold(topic, organizationPurge) {
-		dataChannel = AzureOrganizationPurgeChannel
-	}
-	parentContext := context.Background()
+type azureDelivery struct {
+	receiver *asb.Receiver
+	message  *asb.ReceivedMessage
+}
 
+// receiveMessages keeps receiving the messages of the subscription, and delivers those one by one. The next
+// messages are received once the delivered messages are consumed.
+func receiveMessages(receiver *asb.Receiver, topic, subName string, reconnectInterval time.Duration,
+	deliveries chan<- Delivery) {
+	logger.LoggerMsg.Infof("Starting the ASB consumer for subscription: %s, topic: %s", subName, topic)
+	defer logger.LoggerMsg.Errorf("ASB consumer has stopped for subscription: %q, topic: %q", subName, topic)
 	for {
-		// initializing the receiver client
-		subClient, err := asb.NewClientFromConnectionString(connectionString, nil)
+		logger.LoggerMsg.Debugf("Continue processing messages from ASB for subscription: %q, topic: %q", subName, topic)
+		messages, err := receiver.ReceiveMessages(context.Background(), 10, nil)
 		if err != nil {
-			logger.LoggerMsg.Errorf("Failed to create ASB client for %s , topic:  %s. error: %v.",
-				subName, topic, err)
+			logger.LoggerMsg.Errorf("Failed to receive messages from ASB. %v", err)
+			time.Sleep(reconnectInterval)
 			continue
 		}
-		receiver, err := subClient.NewReceiverForSubscription(topic, subName, nil)
-		if err != nil {
-			logger.LoggerMsg.Errorf("Failed to create ASB receiver for %s , topic:  %s. error: %v.",
-				subName, topic, err)
-			continue
+		for _, message := range messages {
+			logger.LoggerMsg.Debugf("Message %s from ASB is waiting to be processed.", message.MessageID)
+			deliveries <- &azureDelivery{receiver: receiver, message: message}
 		}
+		logger.LoggerMsg.Debugf("Received %d messages from ASB for subscription: %q, topic: %q", len(messages),
+			subName, topic)
+	}
+}
 
-		logger.LoggerMsg.Infof("Starting the ASB consumer for subscription: %s, topic: %s", subName, topic)
-		func() {
-			defer logger.LoggerMsg.Errorf("ASB consumer has stopped for subscription: %q, topic: %q", subName, topic)
-			ctx, cancel := context.WithCancel(parentContext)
-			defer cancel()
+func (delivery *azureDelivery) Body() []byte {
+	return delivery.message.Body
+}
 
-			// keep receiving messages from asb
-			for {
-				logger.LoggerMsg.Debugf("Continue processing messages from ASB for subscription: %q, topic: %q", subName, topic)
-				messages, err := receiver.ReceiveMessages(ctx, 10, nil)
-				if err != nil {
-					logger.LoggerMsg.Errorf("Failed to receive messages from ASB. %v", err)
-					time.Sleep(reconnectInterval)
-					continue
-				}
-				for _, message := range messages {
-					logger.LoggerMsg.Debugf("Message %s from ASB is waiting to be processed.", message.MessageID)
-					dataChannel <- message.Body
-					logger.LoggerMsg.Debugf("Message %s from ASB is processed", message.MessageID)
+func (delivery *azureDelivery) Ack() error {
+	logger.LoggerMsg.Debugf("Message %s from ASB is processed", delivery.message.MessageID)
+	return delivery.receiver.CompleteMessage(context.Background(), delivery.message, nil)
+}
 
-					err = receiver.CompleteMessage(ctx, message, &asb.CompleteMessageOptions{})
-					if err != nil {
-						logger.LoggerMsg.Warnf("Failed to complete the ASB message. %v", err)
-					}
-				}
-				logger.LoggerMsg.Debugf("Processed %d messages from ASB for subscription: %q, topic: %q", len(messages), subName, topic)
-			}
-		}()
-	}
+// Nack moves the message to the dead letter queue of the subscription, hence it is not redelivered.
+func (delivery *azureDelivery) Nack() error {
+	reason := deadLetterReason
+	return delivery.receiver.DeadLetterMessage(context.Background(), delivery.message,
+		&asb.DeadLetterOptions{Reason: &reason})
 }
@@ -0,0 +1,341 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package messaging
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/linkedin/goavro/v2"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// Encodings of the event payloads, decoded by a Codec
+const (
+	CodecJSON     string = "json"
+	CodecProtobuf string = "protobuf"
+	CodecAvro     string = "avro"
+)
+
+// avroMagicByte is the first byte of the payloads in the wire format of the schema registry, followed by the
+// 4 byte schema ID.
+const avroMagicByte byte = 0
+
+// Codec decodes the payload of an event to the JSON document processed by the event handlers. The bytes fields of
+// the compact encodings are base64 encoded in the JSON document, as the embedded events of the JSON payloads.
+type Codec interface {
+	// Decode decodes the payload of an event consumed from the topic
+	Decode(topic string, payload []byte) ([]byte, error)
+}
+
+// AvroCodecOptions configures the Avro codec.
+type AvroCodecOptions struct {
+	// SchemaRegistryURL is the URL of the schema registry, the writer schemas of the payloads are looked up from
+	SchemaRegistryURL string
+	Username          string
+	Password          string
+	HTTPClient        *http.Client
+}
+
+type jsonCodec struct{}
+
+type protobufCodec struct {
+	messages map[string]protoreflect.MessageDescriptor
+}
+
+type avroCodec struct {
+	options AvroCodecOptions
+	// schemas caches the schemas looked up from the schema registry, by the schema ID
+	schemas map[uint32]*avroSchema
+	mutex   sync.Mutex
+}
+
+type avroSchema struct {
+	codec *goavro.Codec
+	// definition is the parsed schema, used to resolve the union values decoded by the codec
+	definition interface{}
+}
+
+// NewJSONCodec creates a Codec for the JSON payloads, which are passed to the event handlers as they are.
+func NewJSONCodec() Codec {
+	return jsonCodec{}
+}
+
+// NewProtobufCodec creates a Codec for the protobuf payloads. The messages of the topics are looked up by the full
+// names in the file descriptor set (protoc --descriptor_set_out --include_imports). The names of the fields should
+// match the keys of the JSON payloads.
+func NewProtobufCodec(descriptorSetFile string, messageTypes map[string]string) (Codec, error) {
+	content, err := os.ReadFile(descriptorSetFile)
+	if err != nil {
+		return nil, fmt.Errorf("error reading the file descriptor set %s: %w", descriptorSetFile, err)
+	}
+	var descriptorSet descriptorpb.FileDescriptorSet
+	if err := proto.Unmarshal(content, &descriptorSet); err != nil {
+		return nil, fmt.Errorf("error parsing the file descriptor set %s: %w", descriptorSetFile, err)
+	}
+	files, err := protodesc.NewFiles(&descriptorSet)
+	if err != nil {
+		return nil, fmt.Errorf("error resolving the file descriptor set %s: %w", descriptorSetFile, err)
+	}
+	codec := &protobufCodec{messages: make(map[string]protoreflect.MessageDescriptor, len(messageTypes))}
+	for topic, messageType := range messageTypes {
+		descriptor, err := files.FindDescriptorByName(protoreflect.FullName(messageType))
+		if err != nil {
+			return nil, fmt.Errorf("message %s of the topic %s is not found: %w", messageType, topic, err)
+		}
+		message, ok := descriptor.(protoreflect.MessageDescriptor)
+		if !ok {
+			return nil, fmt.Errorf("%s of the topic %s is not a message", messageType, topic)
+		}
+		codec.messages[topic] = message
+	}
+	return codec, nil
+}
+
+// NewAvroCodec creates a Codec for the Avro payloads in the wire format of the schema registry
+// (magic byte, schema ID, Avro binary).
+func NewAvroCodec(options AvroCodecOptions) Codec {
+	if options.HTTPClient == nil {
+		options.HTTPClient = http.DefaultClient
+	}
+	options.SchemaRegistryURL = strings.TrimSuffix(options.SchemaRegistryURL, "/")
+	return &avroCodec{options: options, schemas: make(map[uint32]*avroSchema)}
+}
+
+func (jsonCodec) Decode(topic string, payload []byte) ([]byte, error) {
+	return payload, nil
+}
+
+func (codec *protobufCodec) Decode(topic string, payload []byte) ([]byte, error) {
+	descriptor, found := codec.messages[topic]
+	if !found {
+		return nil, fmt.Errorf("message type of the topic %s is not configured", topic)
+	}
+	message := dynamicpb.NewMessage(descriptor)
+	if err := proto.Unmarshal(payload, message); err != nil {
+		return nil, err
+	}
+	return json.Marshal(protoMessageToNative(message))
+}
+
+func protoMessageToNative(message protoreflect.Message) map[string]interface{} {
+	native := make(map[string]interface{})
+	message.Range(func(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		switch {
+		case field.IsList():
+			list := value.List()
+			items := make([]interface{}, list.Len())
+			for i := range items {
+				items[i] = protoValueToNative(field, list.Get(i))
+			}
+			native[string(field.Name())] = items
+		case field.IsMap():
+			entries := make(map[string]interface{})
+			value.Map().Range(func(key protoreflect.MapKey, value protoreflect.Value) bool {
+				entries[key.String()] = protoValueToNative(field.MapValue(), value)
+				return true
+			})
+			native[string(field.Name())] = entries
+		default:
+			native[string(field.Name())] = protoValueToNative(field, value)
+		}
+		return true
+	})
+	return native
+}
+
+func protoValueToNative(field protoreflect.FieldDescriptor, value protoreflect.Value) interface{} {
+	switch field.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return protoMessageToNative(value.Message())
+	case protoreflect.EnumKind:
+		if enumValue := field.Enum().Values().ByNumber(value.Enum()); enumValue != nil {
+			return string(enumValue.Name())
+		}
+		return int32(value.Enum())
+	default:
+		return value.Interface()
+	}
+}
+
+func (codec *avroCodec) Decode(topic string, payload []byte) ([]byte, error) {
+	if len(payload) < 5 || payload[0] != avroMagicByte {
+		return nil, fmt.Errorf("payload is not in the wire format of the schema registry")
+	}
+	schema, err := codec.getSchema(binary.BigEndian.Uint32(payload[1:5]))
+	if err != nil {
+		return nil, err
+	}
+	native, _, err := schema.codec.NativeFromBinary(payload[5:])
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(resolveAvroUnions(schema.definition, native, make(map[string]interface{})))
+}
+
+// getSchema returns the schema of the ID, looking it up from the schema registry if it is not cached. Schemas are
+// immutable in the registry, hence they are cached without an expiry.
+func (codec *avroCodec) getSchema(id uint32) (*avroSchema, error) {
+	codec.mutex.Lock()
+	defer codec.mutex.Unlock()
+	if schema, found := codec.schemas[id]; found {
+		return schema, nil
+	}
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/schemas/ids/%d", codec.options.SchemaRegistryURL, id),
+		nil)
+	if err != nil {
+		return nil, err
+	}
+	if codec.options.Username != "" {
+		req.SetBasicAuth(codec.options.Username, codec.options.Password)
+	}
+	resp, err := codec.options.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error looking up the schema %d: %w", id, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading the schema %d: %w", id, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error looking up the schema %d: schema registry responded with %d: %s", id,
+			resp.StatusCode, string(body))
+	}
+	var registryResponse struct {
+		Schema string `json:"schema"`
+	}
+	if err := json.Unmarshal(body, &registryResponse); err != nil {
+		return nil, fmt.Errorf("error parsing the schema %d: %w", id, err)
+	}
+	schema := &avroSchema{}
+	if schema.codec, err = goavro.NewCodec(registryResponse.Schema); err != nil {
+		return nil, fmt.Errorf("error parsing the schema %d: %w", id, err)
+	}
+	if err := json.Unmarshal([]byte(registryResponse.Schema), &schema.definition); err != nil {
+		return nil, fmt.Errorf("error parsing the schema %d: %w", id, err)
+	}
+	codec.schemas[id] = schema
+	return schema, nil
+}
+
+// resolveAvroUnions replaces the union values decoded by goavro (ex: {"string": "value"}) with the values of the
+// union, following the schema of the value. Named types are collected to the map as they are defined.
+func resolveAvroUnions(schema interface{}, value interface{}, namedTypes map[string]interface{}) interface{} {
+	switch schema := schema.(type) {
+	case string:
+		if definition, found := lookupAvroNamedType(schema, namedTypes); found {
+			return resolveAvroUnions(definition, value, namedTypes)
+		}
+		return value
+	case []interface{}:
+		union, ok := value.(map[string]interface{})
+		if !ok || len(union) != 1 {
+			return value
+		}
+		for typeName, unionValue := range union {
+			for _, branch := range schema {
+				if avroTypeName(branch) == typeName || strings.HasSuffix(typeName, "."+avroTypeName(branch)) {
+					return resolveAvroUnions(branch, unionValue, namedTypes)
+				}
+			}
+			return unionValue
+		}
+	case map[string]interface{}:
+		switch schema["type"] {
+		case "record", "error":
+			namedTypes[avroTypeName(schema)] = schema
+			record, ok := value.(map[string]interface{})
+			fields, _ := schema["fields"].([]interface{})
+			if !ok {
+				return value
+			}
+			for _, field := range fields {
+				if field, ok := field.(map[string]interface{}); ok {
+					name, _ := field["name"].(string)
+					if fieldValue, found := record[name]; found {
+						record[name] = resolveAvroUnions(field["type"], fieldValue, namedTypes)
+					}
+				}
+			}
+			return record
+		case "enum", "fixed":
+			namedTypes[avroTypeName(schema)] = schema
+			return value
+		case "array":
+			if items, ok := value.([]interface{}); ok {
+				for i := range items {
+					items[i] = resolveAvroUnions(schema["items"], items[i], namedTypes)
+				}
+			}
+			return value
+		case "map":
+			if entries, ok := value.(map[string]interface{}); ok {
+				for key := range entries {
+					entries[key] = resolveAvroUnions(schema["values"], entries[key], namedTypes)
+				}
+			}
+			return value
+		default:
+			// primitive types with attributes (ex: logical types)
+			return resolveAvroUnions(schema["type"], value, namedTypes)
+		}
+	}
+	return value
+}
+
+// avroTypeName returns the name of a type in a schema, which is the full name for the named types.
+func avroTypeName(schema interface{}) string {
+	switch schema := schema.(type) {
+	case string:
+		return schema
+	case map[string]interface{}:
+		name, _ := schema["name"].(string)
+		if name == "" {
+			typeName, _ := schema["type"].(string)
+			return typeName
+		}
+		if namespace, _ := schema["namespace"].(string); namespace != "" && !strings.Contains(name, ".") {
+			return namespace + "." + name
+		}
+		return name
+	}
+	return ""
+}
+
+func lookupAvroNamedType(name string, namedTypes map[string]interface{}) (interface{}, bool) {
+	if definition, found := namedTypes[name]; found {
+		return definition, true
+	}
+	for fullName, definition := range namedTypes {
+		if strings.HasSuffix(fullName, "."+name) {
+			return definition, true
+		}
+	}
+	return nil, false
+}
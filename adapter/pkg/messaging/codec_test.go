@@ -0,0 +1,167 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package messaging
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/linkedin/goavro/v2"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+const sampleEvent = `{"apiName":"MyAPI","uuid":"7808af84-6b9a-4c86-953a-4f40f157671f"}`
+
+func TestProtobufCodec(t *testing.T) {
+	descriptorSet := &descriptorpb.FileDescriptorSet{File: []*descriptorpb.FileDescriptorProto{{
+		Name:    proto.String("events.proto"),
+		Package: proto.String("controlplane.events"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("Notification"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				messageField("event", 1, ".controlplane.events.Notification.Event"),
+			},
+			NestedType: []*descriptorpb.DescriptorProto{{
+				Name: proto.String("Event"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					messageField("payloadData", 1, ".controlplane.events.Notification.PayloadData"),
+				},
+			}, {
+				Name: proto.String("PayloadData"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					scalarField("eventType", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING),
+					scalarField("timeStamp", 2, descriptorpb.FieldDescriptorProto_TYPE_DOUBLE),
+					scalarField("event", 3, descriptorpb.FieldDescriptorProto_TYPE_BYTES),
+				},
+			}},
+		}},
+	}}}
+	content, err := proto.Marshal(descriptorSet)
+	assert.Nil(t, err)
+	descriptorSetFile := filepath.Join(t.TempDir(), "events.desc")
+	assert.Nil(t, os.WriteFile(descriptorSetFile, content, 0600))
+
+	_, err = NewProtobufCodec(descriptorSetFile, map[string]string{TopicNotification: "controlplane.events.Unknown"})
+	assert.NotNil(t, err, "Codec should not be created for the messages not in the descriptor set")
+	codec, err := NewProtobufCodec(descriptorSetFile,
+		map[string]string{TopicNotification: "controlplane.events.Notification"})
+	assert.Nil(t, err)
+
+	files, err := protodesc.NewFiles(descriptorSet)
+	assert.Nil(t, err)
+	descriptor, _ := files.FindDescriptorByName("controlplane.events.Notification")
+	notificationMessage := dynamicpb.NewMessage(descriptor.(protoreflect.MessageDescriptor))
+	eventMessage := notificationMessage.Mutable(notificationMessage.Descriptor().Fields().ByName("event")).Message()
+	payloadData := eventMessage.Mutable(eventMessage.Descriptor().Fields().ByName("payloadData")).Message()
+	payloadFields := payloadData.Descriptor().Fields()
+	payloadData.Set(payloadFields.ByName("eventType"), protoreflect.ValueOfString("API_CREATE"))
+	payloadData.Set(payloadFields.ByName("timeStamp"), protoreflect.ValueOfFloat64(1628490908147))
+	payloadData.Set(payloadFields.ByName("event"), protoreflect.ValueOfBytes([]byte(sampleEvent)))
+	payload, err := proto.Marshal(notificationMessage)
+	assert.Nil(t, err)
+
+	decoded, err := codec.Decode(TopicNotification, payload)
+	assert.Nil(t, err)
+	var notification EventNotification
+	assert.Nil(t, json.Unmarshal(decoded, &notification))
+	assert.Equal(t, "API_CREATE", notification.Event.PayloadData.EventType)
+	assert.Equal(t, float64(1628490908147), notification.Event.PayloadData.Timstamp)
+	assert.Equal(t, base64.StdEncoding.EncodeToString([]byte(sampleEvent)), notification.Event.PayloadData.Event)
+
+	_, err = codec.Decode(TopicKeyManager, payload)
+	assert.NotNil(t, err, "Payloads of the topics without a message type should not be decoded")
+}
+
+func TestAvroCodec(t *testing.T) {
+	schema := `{"type":"record","name":"Notification","namespace":"controlplane.events","fields":[
+		{"name":"event","type":{"type":"record","name":"Event","fields":[
+			{"name":"payloadData","type":{"type":"record","name":"PayloadData","fields":[
+				{"name":"eventType","type":"string"},
+				{"name":"timeStamp","type":"double"},
+				{"name":"event","type":["null","bytes"]}]}}]}}]}`
+	lookups := 0
+	registry := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lookups++
+		if r.URL.Path != "/schemas/ids/7" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"schema": schema})
+	}))
+	defer registry.Close()
+
+	avroCodec, err := goavro.NewCodec(schema)
+	assert.Nil(t, err)
+	binaryPayload, err := avroCodec.BinaryFromNative(nil, map[string]interface{}{
+		"event": map[string]interface{}{
+			"payloadData": map[string]interface{}{
+				"eventType": "API_CREATE",
+				"timeStamp": float64(1628490908147),
+				"event":     goavro.Union("bytes", []byte(sampleEvent)),
+			},
+		},
+	})
+	assert.Nil(t, err)
+	payload := append([]byte{avroMagicByte, 0, 0, 0, 0}, binaryPayload...)
+	binary.BigEndian.PutUint32(payload[1:5], 7)
+
+	codec := NewAvroCodec(AvroCodecOptions{SchemaRegistryURL: registry.URL + "/"})
+	for i := 0; i < 2; i++ {
+		decoded, err := codec.Decode(TopicNotification, payload)
+		assert.Nil(t, err)
+		var notification EventNotification
+		assert.Nil(t, json.Unmarshal(decoded, &notification))
+		assert.Equal(t, "API_CREATE", notification.Event.PayloadData.EventType)
+		assert.Equal(t, base64.StdEncoding.EncodeToString([]byte(sampleEvent)), notification.Event.PayloadData.Event)
+	}
+	assert.Equal(t, 1, lookups, "Schema should be cached once looked up")
+
+	binary.BigEndian.PutUint32(payload[1:5], 8)
+	_, err = codec.Decode(TopicNotification, payload)
+	assert.NotNil(t, err, "Payloads of unknown schemas should not be decoded")
+	_, err = codec.Decode(TopicNotification, []byte(sampleEvent))
+	assert.NotNil(t, err, "Payloads not in the wire format should not be decoded")
+}
+
+func messageField(name string, number int32, typeName string) *descriptorpb.FieldDescriptorProto {
+	field := scalarField(name, number, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE)
+	field.TypeName = proto.String(typeName)
+	return field
+}
+
+func scalarField(name string, number int32, fieldType descriptorpb.FieldDescriptorProto_Type) *descriptorpb.FieldDescriptorProto {
+	return &descriptorpb.FieldDescriptorProto{
+		Name:     proto.String(name),
+		JsonName: proto.String(name),
+		Number:   proto.Int32(number),
+		Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+		Type:     fieldType.Enum(),
+	}
+}
@@ -39,6 +39,9 @@ var (
 	RevokedTokenChannel chan amqp.Delivery
 	// ThrottleDataChannel stores the throttling related events
 	ThrottleDataChannel chan amqp.Delivery
+	// NotificationReconnectChannel is signalled when the consumer of the notification events is reconnected,
+	// as the events published while the connection was lost are not received.
+	NotificationReconnectChannel chan struct{}
 )
 
 func init() {
@@ -46,17 +49,44 @@ func init() {
 	KeyManagerChannel = make(chan amqp.Delivery)
 	RevokedTokenChannel = make(chan amqp.Delivery)
 	ThrottleDataChannel = make(chan amqp.Delivery)
+	NotificationReconnectChannel = make(chan struct{}, 1)
 }
 
 // EventListeningEndpoints represents the list of endpoints
 var EventListeningEndpoints []string
 
+// setEventListeningEndpoints sets the endpoints of the broker, and the amqp URIs extracted from those.
+func setEventListeningEndpoints(endpoints []string) {
+	amqpURIsMutex.Lock()
+	defer amqpURIsMutex.Unlock()
+	EventListeningEndpoints = endpoints
+	amqpURIArray = retrieveAMQPURLList()
+}
+
+// getAMQPURIs returns the amqp URIs of the event listening endpoints.
+func getAMQPURIs() []amqpFailoverURL {
+	amqpURIsMutex.RLock()
+	defer amqpURIsMutex.RUnlock()
+	return amqpURIArray
+}
+
+func setRabbitConn(conn *amqp.Connection) {
+	rabbitConnMutex.Lock()
+	defer rabbitConnMutex.Unlock()
+	RabbitConn = conn
+}
+
+func getRabbitConn() *amqp.Connection {
+	rabbitConnMutex.RLock()
+	defer rabbitConnMutex.RUnlock()
+	return RabbitConn
+}
+
 // ConnectToRabbitMQ function tries to connect to the RabbitMQ server as long as it takes to establish a connection
 func connectToRabbitMQ() (*amqp.Connection, error) {
 	var err error = nil
 	var conn *amqp.Connection
-	amqpURIArray = retrieveAMQPURLList()
-	for index, url := range amqpURIArray {
+	for index, url := range getAMQPURIs() {
 		logger.LoggerMsg.Infof("Dialing URI [%d] %q", index, maskURL(url.URL)+"/")
 		conn, err = amqp.Dial(url.URL)
 		if err == nil {
@@ -94,8 +124,7 @@ func (c *Consumer) reconnect(key string) {
 
 	if shouldReconnect {
 		c.Conn.Close()
-		c, RabbitConn, err = connectionRetry(key)
-		if err != nil {
+		if _, _, err = connectionRetry(key); err != nil {
 			logger.LoggerMsg.Errorf("Cannot establish connection for topic %s", key)
 		}
 	} else {
@@ -109,6 +138,7 @@ func connectionRetry(key string) (*Consumer, *amqp.Connection, error) {
 	var err error = nil
 	var i int
 
+	amqpURIArray := getAMQPURIs()
 	for j := 0; j < len(amqpURIArray); j++ {
 		var maxAttempt int = amqpURIArray[j].retryCount
 		var retryInterval time.Duration = time.Duration(amqpURIArray[j].connectionDelay) * time.Second
@@ -125,16 +155,21 @@ func connectionRetry(key string) (*Consumer, *amqp.Connection, error) {
 
 		for i = 1; i <= maxAttempt; i++ {
 
-			RabbitConn, err = amqp.Dial(amqpURIArray[j].URL + "/")
+			var conn *amqp.Connection
+			conn, err = amqp.Dial(amqpURIArray[j].URL + "/")
 			if err == nil {
+				setRabbitConn(conn)
 				logger.LoggerMsg.Infof("Successfully connected to %s (URI %d) after %d attempts", maskURL(amqpURIArray[j].URL), j, i)
 				if key != "" && len(key) > 0 {
 					logger.LoggerMsg.Infof("Reconnected to topic %s", key)
+					if key == notification {
+						notifyNotificationReconnect()
+					}
 					// startup pull
 					c := startConsumer(key)
-					return c, RabbitConn, nil
+					return c, conn, nil
 				}
-				return nil, RabbitConn, nil
+				return nil, conn, nil
 			}
 
 			if key != "" && len(key) > 0 {
@@ -147,10 +182,12 @@ func connectionRetry(key string) (*Consumer, *amqp.Connection, error) {
 		}
 		// Retried all the uris in the amqp URI array. Start from begining.
 		if j == len(amqpURIArray)-1 {
+			// the endpoints may have been updated on a config reload
+			amqpURIArray = getAMQPURIs()
 			j = -1
 		}
 	}
-	return nil, RabbitConn, err
+	return nil, nil, err
 }
 
 // retrieveAMQPURLList function extract AMQPURLList from EventListening connection url
@@ -226,14 +263,20 @@ func handleEvent(c *Consumer, key string) error {
 		return fmt.Errorf("Exchange Declare: %s", err)
 	}
 
-	logger.LoggerMsg.Infof("declared Exchange, declaring Queue %q", key+"queue")
+	queueName := amqpOptions.QueueNames[key]
+	var queueArgs amqp.Table
+	if amqpOptions.DurableQueues && amqpOptions.DurableQueueExpiry > 0 {
+		// the durable queues of the adapters removed are deleted by the broker, once unused for the expiry
+		queueArgs = amqp.Table{"x-expires": amqpOptions.DurableQueueExpiry.Milliseconds()}
+	}
+	logger.LoggerMsg.Infof("declared Exchange, declaring Queue %q for %s events", queueName, key)
 	queue, err := c.Channel.QueueDeclare(
-		"",    // name of the queue
-		false, // durable
-		true,  // delete when usused
-		false, // exclusive
-		false, // noWait
-		nil,   // arguments
+		queueName,                   // name of the queue, named by the broker if empty
+		amqpOptions.DurableQueues,   // durable
+		!amqpOptions.DurableQueues,  // delete when usused
+		amqpOptions.ExclusiveQueues, // exclusive
+		false,                       // noWait
+		queueArgs,                   // arguments
 	)
 	if err != nil {
 		return fmt.Errorf("Error while declaring queue: %s", err)
@@ -251,6 +294,24 @@ func handleEvent(c *Consumer, key string) error {
 	); err != nil {
 		return fmt.Errorf("Queue Bind: %s", err)
 	}
+	if amqpOptions.PrefetchCount > 0 {
+		if err = c.Channel.Qos(amqpOptions.PrefetchCount, 0, false); err != nil {
+			return fmt.Errorf("Qos: %s", err)
+		}
+	}
+
+	var events chan amqp.Delivery
+	if strings.EqualFold(key, notification) {
+		events = NotificationChannel
+	} else if strings.EqualFold(key, keymanager) {
+		events = KeyManagerChannel
+	} else if strings.EqualFold(key, tokenRevocation) {
+		events = RevokedTokenChannel
+	} else if strings.EqualFold(key, throttleData) {
+		events = ThrottleDataChannel
+	}
+	// the events are consumed by a single consumer, hence are received in the published order. Those are
+	// processed in parallel by the handlers of the topic.
 	logger.LoggerMsg.Infof("Queue bound to Exchange, starting Consume (consumer tag %q) events", c.Tag)
 	deliveries, err := c.Channel.Consume(
 		queue.Name, // name
@@ -261,41 +322,23 @@ func handleEvent(c *Consumer, key string) error {
 		false,      // noWait
 		nil,        // arguments
 	)
-	if strings.EqualFold(key, notification) {
-		for event := range deliveries {
-			NotificationChannel <- event
-		}
-	} else if strings.EqualFold(key, keymanager) {
-		for event := range deliveries {
-			KeyManagerChannel <- event
-		}
-	} else if strings.EqualFold(key, tokenRevocation) {
-		for event := range deliveries {
-			RevokedTokenChannel <- event
-		}
-	} else if strings.EqualFold(key, throttleData) {
-		for event := range deliveries {
-			ThrottleDataChannel <- event
-		}
+	if err != nil {
+		return fmt.Errorf("Queue Consume: %s", err)
+	}
+	if events == nil {
+		return nil
+	}
+	for event := range deliveries {
+		events <- event
 	}
 	return nil
 }
 
-// InitiateJMSConnection to pass event consumption
-func InitiateJMSConnection(eventListeningEndpoints []string) error {
-	var err error
-	EventListeningEndpoints = eventListeningEndpoints
-	bindingKeys := []string{notification, keymanager, tokenRevocation, throttleData}
-	RabbitConn, err = connectToRabbitMQ()
-
-	if err == nil {
-		for i, key := range bindingKeys {
-			logger.LoggerMsg.Infof("Establishing consumer index %v for key %s ", i, key)
-			go func(key string) {
-				startConsumer(key)
-				select {}
-			}(key)
-		}
+// notifyNotificationReconnect signals the reconnection without blocking, as a pending signal covers the
+// reconnections until it is received.
+func notifyNotificationReconnect() {
+	select {
+	case NotificationReconnectChannel <- struct{}{}:
+	default:
 	}
-	return err
 }
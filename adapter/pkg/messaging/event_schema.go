@@ -0,0 +1,261 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package messaging
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Schema versions of the control plane events. The events of the legacy schema (APIM 3.x) name the fields
+// differently, which are mapped to the fields of the current schema before decoding.
+const (
+	EventSchemaLegacy  string = "1"
+	EventSchemaCurrent string = "2"
+)
+
+// Reasons of the incompatibilities found while decoding an event
+const (
+	// IncompatibilityUnknownField is a field of the event, which is not known by the adapter
+	IncompatibilityUnknownField string = "unknown_field"
+	// IncompatibilityCoercedField is a field of the event, which is converted to the type known by the adapter
+	IncompatibilityCoercedField string = "coerced_field"
+	// IncompatibilityTypeMismatch is a field of the event, which can not be converted, hence the event is rejected
+	IncompatibilityTypeMismatch string = "type_mismatch"
+	// IncompatibilityUnsupportedVersion is an event of a newer schema version than the adapter supports
+	IncompatibilityUnsupportedVersion string = "unsupported_version"
+)
+
+// eventSchemaVersionField is the field of the events, which declares the schema version explicitly
+const eventSchemaVersionField string = "schemaVersion"
+
+// EventFieldAlias maps a field of an event schema version to the field of the current schema.
+type EventFieldAlias struct {
+	Alias string
+	Field string
+}
+
+// eventFieldAliases are the fields renamed between the schema versions. An alias is applied only if the field
+// is known by the decoded struct, hence the same alias could be mapped to different fields of different events.
+var eventFieldAliases = []EventFieldAlias{
+	{Alias: "timestamp", Field: "timeStamp"},
+	{Alias: "name", Field: "apiName"},
+	{Alias: "version", Field: "apiVersion"},
+	{Alias: "context", Field: "apiContext"},
+	{Alias: "provider", Field: "apiProvider"},
+	{Alias: "status", Field: "apiStatus"},
+	{Alias: "name", Field: "applicationName"},
+	{Alias: "policy", Field: "applicationPolicy"},
+	{Alias: "applicationUUID", Field: "uuid"},
+	{Alias: "appId", Field: "applicationId"},
+	{Alias: "appUUID", Field: "applicationUUID"},
+	{Alias: "subscriptionStatus", Field: "subscriptionState"},
+	{Alias: "keyManagerName", Field: "keyManager"},
+	{Alias: "id", Field: "policyId"},
+	{Alias: "name", Field: "policyName"},
+}
+
+// EventDecodeReport describes how an event is decoded, including the incompatibilities with the schema known by
+// the adapter, by the field.
+type EventDecodeReport struct {
+	SchemaVersion     string
+	Incompatibilities map[string][]string
+}
+
+func (report *EventDecodeReport) addIncompatibility(reason, field string) {
+	if report.Incompatibilities == nil {
+		report.Incompatibilities = make(map[string][]string)
+	}
+	report.Incompatibilities[reason] = append(report.Incompatibilities[reason], field)
+}
+
+// DecodeEvent decodes a JSON event of any schema version to the target struct. The fields of the legacy schema are
+// mapped to the fields of the current schema, and the values of the fields are converted to the types of the
+// target where possible (ex: "12" to 12). The event is rejected if a field can not be converted, while the field
+// is reported.
+func DecodeEvent(data []byte, target interface{}) (EventDecodeReport, error) {
+	report := EventDecodeReport{SchemaVersion: EventSchemaCurrent}
+	targetType := reflect.TypeOf(target)
+	if targetType == nil || targetType.Kind() != reflect.Ptr || targetType.Elem().Kind() != reflect.Struct {
+		return report, errors.New("target of the event should be a pointer to a struct")
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return report, err
+	}
+	knownFields := make(map[string]reflect.Type)
+	collectEventFields(targetType.Elem(), knownFields)
+
+	declaredVersion, hasDeclaredVersion := fields[eventSchemaVersionField]
+	if _, known := knownFields[eventSchemaVersionField]; !known {
+		delete(fields, eventSchemaVersionField)
+	}
+	if hasDeclaredVersion {
+		report.SchemaVersion = strings.TrimSpace(fmt.Sprint(declaredVersion))
+		if compareSchemaVersions(report.SchemaVersion, EventSchemaCurrent) > 0 {
+			report.addIncompatibility(IncompatibilityUnsupportedVersion, eventSchemaVersionField)
+		}
+	}
+	if applyEventFieldAliases(fields, knownFields) && !hasDeclaredVersion {
+		report.SchemaVersion = EventSchemaLegacy
+	}
+
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fieldType, known := knownFields[name]
+		if !known {
+			report.addIncompatibility(IncompatibilityUnknownField, name)
+			continue
+		}
+		if value, coerced := coerceEventField(fields[name], fieldType); coerced {
+			fields[name] = value
+			report.addIncompatibility(IncompatibilityCoercedField, name)
+		}
+	}
+
+	normalized, err := json.Marshal(fields)
+	if err != nil {
+		return report, err
+	}
+	// the event is rejected if a field does not match the type, as the field could be a key of the event
+	// (ex: subscriptionId), which would be decoded as the zero value otherwise
+	err = json.Unmarshal(normalized, target)
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		field := strings.SplitN(typeErr.Field, ".", 2)[0]
+		report.addIncompatibility(IncompatibilityTypeMismatch, field)
+		return report, fmt.Errorf("type %s of the field %s does not match the type %s known by the adapter",
+			typeErr.Value, field, typeErr.Type)
+	}
+	return report, err
+}
+
+// collectEventFields collects the JSON fields of a struct, including the fields of the embedded structs.
+func collectEventFields(structType reflect.Type, fields map[string]reflect.Type) {
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if field.Anonymous && name == "" {
+			embeddedType := field.Type
+			if embeddedType.Kind() == reflect.Ptr {
+				embeddedType = embeddedType.Elem()
+			}
+			if embeddedType.Kind() == reflect.Struct {
+				collectEventFields(embeddedType, fields)
+				continue
+			}
+		}
+		if field.PkgPath != "" {
+			// unexported field
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = field.Type
+	}
+}
+
+// applyEventFieldAliases renames the fields of the other schema versions, which are known by the target, and
+// returns whether any of the fields is renamed.
+func applyEventFieldAliases(fields map[string]interface{}, knownFields map[string]reflect.Type) bool {
+	aliased := false
+	for _, alias := range eventFieldAliases {
+		value, found := fields[alias.Alias]
+		if !found {
+			continue
+		}
+		if _, known := knownFields[alias.Field]; !known {
+			continue
+		}
+		if _, exists := fields[alias.Field]; exists {
+			continue
+		}
+		fields[alias.Field] = value
+		// the alias is kept, if it is a field of the target as well (ex: the name of an API event)
+		if _, known := knownFields[alias.Alias]; !known {
+			delete(fields, alias.Alias)
+		}
+		aliased = true
+	}
+	return aliased
+}
+
+// coerceEventField converts a scalar value to the scalar type of the field, if they differ.
+func coerceEventField(value interface{}, fieldType reflect.Type) (interface{}, bool) {
+	for fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+	switch fieldType.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		if text, ok := value.(string); ok {
+			if number, err := strconv.ParseFloat(strings.TrimSpace(text), 64); err == nil {
+				return number, true
+			}
+		}
+	case reflect.String:
+		switch value := value.(type) {
+		case float64:
+			return strconv.FormatFloat(value, 'f', -1, 64), true
+		case bool:
+			return strconv.FormatBool(value), true
+		}
+	case reflect.Bool:
+		if text, ok := value.(string); ok {
+			if boolean, err := strconv.ParseBool(strings.TrimSpace(text)); err == nil {
+				return boolean, true
+			}
+		}
+	}
+	return value, false
+}
+
+// compareSchemaVersions compares two numeric schema versions (ex: 2 and 2.1). The versions which are not numeric
+// are considered newer than the numeric versions.
+func compareSchemaVersions(version, other string) int {
+	versionNumber, err := strconv.ParseFloat(version, 64)
+	if err != nil {
+		return 1
+	}
+	otherNumber, err := strconv.ParseFloat(other, 64)
+	if err != nil {
+		return -1
+	}
+	switch {
+	case versionNumber > otherNumber:
+		return 1
+	case versionNumber < otherNumber:
+		return -1
+	}
+	return 0
+}
@@ -0,0 +1,82 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package messaging
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeEvent(t *testing.T) {
+	// an event of the current schema is decoded as it is
+	var subscriptionEvent SubscriptionEvent
+	report, err := DecodeEvent([]byte(`{"subscriptionId":1,"applicationUUID":"app1","timeStamp":10,`+
+		`"type":"SUBSCRIPTIONS_CREATE"}`), &subscriptionEvent)
+	assert.Nil(t, err)
+	assert.Equal(t, EventSchemaCurrent, report.SchemaVersion)
+	assert.Empty(t, report.Incompatibilities)
+	assert.Equal(t, "app1", subscriptionEvent.ApplicationUUID)
+	assert.Equal(t, int64(10), subscriptionEvent.TimeStamp)
+
+	// the fields of the legacy schema are mapped, and the values are converted to the known types
+	subscriptionEvent = SubscriptionEvent{}
+	report, err = DecodeEvent([]byte(`{"subscriptionId":"2","appId":3,"appUUID":"app2","timestamp":20,`+
+		`"subscriptionStatus":"BLOCKED","tenantId":"-1234","throttlingTier":"Gold"}`), &subscriptionEvent)
+	assert.Nil(t, err)
+	assert.Equal(t, EventSchemaLegacy, report.SchemaVersion)
+	assert.Equal(t, int32(2), subscriptionEvent.SubscriptionID)
+	assert.Equal(t, int32(3), subscriptionEvent.ApplicationID)
+	assert.Equal(t, "app2", subscriptionEvent.ApplicationUUID)
+	assert.Equal(t, "BLOCKED", subscriptionEvent.SubscriptionState)
+	assert.Equal(t, int32(-1234), subscriptionEvent.TenantID)
+	assert.Equal(t, []string{"subscriptionId", "tenantId"}, report.Incompatibilities[IncompatibilityCoercedField])
+	assert.Equal(t, []string{"throttlingTier"}, report.Incompatibilities[IncompatibilityUnknownField])
+
+	// the aliases are mapped to the fields known by the decoded struct
+	var apiEvent APIEvent
+	_, err = DecodeEvent([]byte(`{"name":"PetStore","version":"1.0.0","uuid":"api1"}`), &apiEvent)
+	assert.Nil(t, err)
+	assert.Equal(t, "PetStore", apiEvent.APIName)
+	assert.Equal(t, "PetStore", apiEvent.Name, "Field of the legacy schema known by the struct should be kept")
+	assert.Equal(t, "1.0.0", apiEvent.APIVersion)
+	var applicationEvent ApplicationEvent
+	_, err = DecodeEvent([]byte(`{"name":"App","applicationUUID":"app1","policy":"Unlimited"}`), &applicationEvent)
+	assert.Nil(t, err)
+	assert.Equal(t, "App", applicationEvent.ApplicationName)
+	assert.Equal(t, "app1", applicationEvent.UUID)
+	assert.Equal(t, "Unlimited", applicationEvent.ApplicationPolicy)
+
+	// the events with the fields of mismatching types are rejected
+	var scopeEvent ScopeEvent
+	report, err = DecodeEvent([]byte(`{"schemaVersion":"3","name":"read","roles":["admin"],"tenantDomain":"foo"}`),
+		&scopeEvent)
+	assert.NotNil(t, err, "Event with a field of a mismatching type is accepted")
+	assert.Equal(t, "3", report.SchemaVersion)
+	assert.Equal(t, []string{"schemaVersion"}, report.Incompatibilities[IncompatibilityUnsupportedVersion])
+	assert.Equal(t, []string{"roles"}, report.Incompatibilities[IncompatibilityTypeMismatch])
+	subscriptionEvent = SubscriptionEvent{}
+	report, err = DecodeEvent([]byte(`{"subscriptionId":"sub1","applicationUUID":"app1"}`), &subscriptionEvent)
+	assert.NotNil(t, err, "Event with a key of a mismatching type is accepted")
+	assert.Equal(t, []string{"subscriptionId"}, report.Incompatibilities[IncompatibilityTypeMismatch])
+
+	_, err = DecodeEvent([]byte(`{"name":`), &scopeEvent)
+	assert.NotNil(t, err, "Malformed events should not be decoded")
+	_, err = DecodeEvent([]byte(`{}`), scopeEvent)
+	assert.NotNil(t, err, "Events should be decoded only to the pointers to the structs")
+}
@@ -0,0 +1,190 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package messaging
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/streadway/amqp"
+	logger "github.com/wso2/product-microgateway/adapter/pkg/loggers"
+)
+
+// Topics of the control plane events, consumed from an EventSource
+const (
+	TopicNotification    string = notification
+	TopicKeyManager      string = keymanager
+	TopicTokenRevocation string = tokenRevocation
+	TopicThrottleData    string = throttleData
+)
+
+// Delivery is an event consumed from an EventSource.
+type Delivery interface {
+	// Body returns the payload of the event
+	Body() []byte
+	// Ack acknowledges the event is processed
+	Ack() error
+	// Nack rejects the event as it cannot be processed. The event is redelivered, until the redelivery limit of the
+	// EventSource is reached.
+	Nack() error
+}
+
+// EventSource is a message broker delivering the control plane events to the adapter.
+type EventSource interface {
+	// Connect connects to the broker, retrying until the connection is established or the retries are exhausted
+	Connect() error
+	// Consume delivers the events of the topic to the returned channel
+	Consume(topic string) (<-chan Delivery, error)
+	// Close closes the connection to the broker
+	Close() error
+}
+
+// ReconnectableEventSource is an EventSource which reconnects to other endpoints of the same broker, while the
+// events are delivered to the channels returned when the topics are consumed.
+type ReconnectableEventSource interface {
+	EventSource
+	// Reconnect closes the connection to the broker, and connects to the given endpoints
+	Reconnect(endpoints []string) error
+}
+
+// AMQPOptions configures the queues and the consumers of the RabbitMQ EventSource.
+type AMQPOptions struct {
+	// PrefetchCount is the number of the unacknowledged events delivered to a consumer. Not limited if 0.
+	PrefetchCount int
+	// ConsumersPerQueue is the number of the handlers processing the events of a queue in parallel. The events
+	// are consumed from the queue by a single consumer, hence are received in the published order.
+	ConsumersPerQueue int
+	DurableQueues     bool
+	// DurableQueueExpiry is the time, after which a durable queue without consumers is deleted. Not expired if 0.
+	DurableQueueExpiry time.Duration
+	ExclusiveQueues    bool
+	// QueueNames maps the topics to the names of the queues. The broker names the queue of a topic if not mapped.
+	QueueNames map[string]string
+	// MaxRedeliveries is the number of times a rejected event is requeued. As the classic queues do not count the
+	// deliveries (x-delivery-count), the events of those are requeued once.
+	MaxRedeliveries int
+}
+
+// ConcurrentEventSource is an EventSource of which the events of a topic are processed by parallel handlers.
+type ConcurrentEventSource interface {
+	EventSource
+	// ConsumersPerTopic returns the number of the parallel handlers of a topic
+	ConsumersPerTopic() int
+}
+
+// amqpEventSource consumes the events from the topic exchange of a RabbitMQ broker. The events published while
+// the connection is lost are not received, hence NotificationReconnectChannel is signalled on reconnection.
+type amqpEventSource struct {
+	endpoints []string
+}
+
+type amqpDelivery struct {
+	message amqp.Delivery
+}
+
+// NewAMQPEventSource creates an EventSource for the RabbitMQ broker of the given endpoints (failover URLs).
+func NewAMQPEventSource(endpoints []string, options AMQPOptions) EventSource {
+	if options.ConsumersPerQueue < 1 {
+		options.ConsumersPerQueue = 1
+	}
+	amqpOptions = options
+	return &amqpEventSource{endpoints: endpoints}
+}
+
+func (source *amqpEventSource) Connect() error {
+	setEventListeningEndpoints(source.endpoints)
+	conn, err := connectToRabbitMQ()
+	setRabbitConn(conn)
+	return err
+}
+
+func (source *amqpEventSource) Consume(topic string) (<-chan Delivery, error) {
+	var deliveries chan amqp.Delivery
+	switch topic {
+	case notification:
+		deliveries = NotificationChannel
+	case keymanager:
+		deliveries = KeyManagerChannel
+	case tokenRevocation:
+		deliveries = RevokedTokenChannel
+	case throttleData:
+		deliveries = ThrottleDataChannel
+	default:
+		return nil, fmt.Errorf("topic %q is not supported", topic)
+	}
+	logger.LoggerMsg.Infof("Establishing consumer for key %s", topic)
+	go func() {
+		startConsumer(topic)
+		select {}
+	}()
+	events := make(chan Delivery)
+	go func() {
+		for message := range deliveries {
+			events <- &amqpDelivery{message: message}
+		}
+		close(events)
+	}()
+	return events, nil
+}
+
+func (source *amqpEventSource) ConsumersPerTopic() int {
+	return amqpOptions.ConsumersPerQueue
+}
+
+func (source *amqpEventSource) Close() error {
+	conn := getRabbitConn()
+	if conn == nil || conn.IsClosed() {
+		return nil
+	}
+	return conn.Close()
+}
+
+func (source *amqpEventSource) Reconnect(endpoints []string) error {
+	source.endpoints = endpoints
+	setEventListeningEndpoints(endpoints)
+	// the consumers reconnect to the endpoints, once the connections are closed
+	return closeConsumerConnections()
+}
+
+func (delivery *amqpDelivery) Body() []byte {
+	return delivery.message.Body
+}
+
+func (delivery *amqpDelivery) Ack() error {
+	return delivery.message.Ack(false)
+}
+
+// Nack requeues the event, unless it is requeued the maximum times.
+func (delivery *amqpDelivery) Nack() error {
+	return delivery.message.Reject(getAMQPRedeliveries(delivery.message) < amqpOptions.MaxRedeliveries)
+}
+
+// getAMQPRedeliveries returns the number of times the event is redelivered. The redelivered events of the queues
+// not counting the deliveries are considered to be redelivered the maximum times.
+func getAMQPRedeliveries(message amqp.Delivery) int {
+	switch count := message.Headers[amqpDeliveryCountHeader].(type) {
+	case int64:
+		return int(count)
+	case int32:
+		return int(count)
+	}
+	if message.Redelivered {
+		return amqpOptions.MaxRedeliveries
+	}
+	return 0
+}
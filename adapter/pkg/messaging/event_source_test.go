@@ -0,0 +1,37 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package messaging
+
+import (
+	"testing"
+
+	"github.com/streadway/amqp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAMQPRedeliveries(t *testing.T) {
+	defaultOptions := amqpOptions
+	defer func() { amqpOptions = defaultOptions }()
+	amqpOptions.MaxRedeliveries = 3
+
+	assert.Equal(t, 0, getAMQPRedeliveries(amqp.Delivery{}))
+	assert.Equal(t, 2, getAMQPRedeliveries(amqp.Delivery{Headers: amqp.Table{amqpDeliveryCountHeader: int64(2)}}),
+		"Deliveries counted by the quorum queues should be used")
+	assert.Equal(t, 3, getAMQPRedeliveries(amqp.Delivery{Redelivered: true}),
+		"Events redelivered by the classic queues should not be requeued again")
+}
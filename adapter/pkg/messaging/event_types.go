@@ -18,6 +18,8 @@
 // Package messaging holds the implementation for event listeners functions
 package messaging
 
+import "github.com/wso2/product-microgateway/adapter/pkg/eventhub/types"
+
 // EventNotification for struct event notifications
 type EventNotification struct {
 	Event struct {
@@ -113,6 +115,11 @@ type APIEvent struct {
 	APIProvider   string   `json:"apiProvider"`
 	APIStatus     string   `json:"apiStatus"`
 	APIType       string   `json:"apiType"`
+	// SubscriptionValidation of the API deployed with the event, which overrides the API artifact if set
+	SubscriptionValidation *bool `json:"subscriptionValidation,omitempty"`
+	// EnvProperties are the endpoints of the API deployed with the event, keyed by the gateway label, which
+	// override the endpoints of the API artifact in the respective environments
+	EnvProperties map[string]APIEnvEndpoints `json:"envProperties,omitempty"`
 	Event
 	// TODO: (VirajSalaka) Remove this when the event is fixed from APIM side
 	Version string `json:"version"`
@@ -121,6 +128,12 @@ type APIEvent struct {
 	Action  string `json:"action"`
 }
 
+// APIEnvEndpoints are the endpoints of an API specific to a gateway environment
+type APIEnvEndpoints struct {
+	ProductionEndpoint string `json:"productionEndpoint,omitempty"`
+	SandboxEndpoint    string `json:"sandboxEndpoint,omitempty"`
+}
+
 // ApplicationRegistrationEvent for struct application registration events
 type ApplicationRegistrationEvent struct {
 	ApplicationID   int32  `json:"applicationId"`
@@ -158,9 +171,10 @@ type SubscriptionEvent struct {
 
 // ScopeEvent for struct scope events
 type ScopeEvent struct {
-	Name            string `json:"name"`
-	DisplayName     string `json:"displayName"`
-	ApplicationName string `json:"description"`
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+	Description string `json:"description"`
+	Roles       string `json:"roles"`
 	Event
 }
 
@@ -170,6 +184,8 @@ type PolicyInfo struct {
 	PolicyName string `json:"policyName"`
 	QuotaType  string `json:"quotaType"`
 	PolicyType string `json:"policyType"`
+	// DefaultLimit is not included in the policy events by all the control plane versions
+	DefaultLimit *types.ThrottleLimit `json:"defaultLimit,omitempty"`
 	Event
 }
 
@@ -190,6 +206,15 @@ type SubscriptionPolicyEvent struct {
 	GraphQLMaxDepth      int32  `json:"graphQLMaxDepth"`
 }
 
+// AnalyticsConfigEvent for struct analytics configuration change events. The config properties are merged to the
+// configured properties, where a property with an empty value removes the property.
+type AnalyticsConfigEvent struct {
+	AnalyticsType            string            `json:"analyticsType"`
+	ConfigProperties         map[string]string `json:"configProperties"`
+	PublishIntervalInSeconds *uint32           `json:"publishIntervalInSeconds,omitempty"`
+	Event
+}
+
 // KeyManagerEvent for struct
 type KeyManagerEvent struct {
 	ServerURL                  string   `json:"ServerURL"`
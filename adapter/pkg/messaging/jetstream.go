@@ -0,0 +1,233 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package messaging
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	logger "github.com/wso2/product-microgateway/adapter/pkg/loggers"
+)
+
+const (
+	// jetStreamAPIStreamNames lists the names of the streams, filtered by a subject
+	jetStreamAPIStreamNames string        = "$JS.API.STREAM.NAMES"
+	jetStreamAPITimeout     time.Duration = 5 * time.Second
+)
+
+// JetStreamOptions configures the consumers of the NATS JetStream EventSource.
+type JetStreamOptions struct {
+	// Stream containing the control plane events. The stream is looked up by the subject if empty.
+	Stream string
+	// SubjectPrefix is prepended to the topic, to derive the subject of the events (ex: controlplane.notification)
+	SubjectPrefix string
+	// DurableName identifies the consumers of the adapter, which retain the events published while the adapter is
+	// disconnected. It should be unique per adapter, as the events are distributed among the adapters sharing it.
+	DurableName string
+	AckWait     time.Duration
+	// MaxRedeliveries is the number of times an event not acknowledged is redelivered
+	MaxRedeliveries int
+	// InactiveThreshold is the time, after which a durable consumer without subscriptions is deleted by the server.
+	// The consumers are not deleted if 0.
+	InactiveThreshold time.Duration
+	ReconnectWait     time.Duration
+	// MaxReconnects is the number of attempts to connect to the servers. The attempts are unlimited if negative.
+	MaxReconnects int
+}
+
+// jetStreamEventSource consumes the events from a NATS JetStream stream with durable consumers. The events
+// published while the connection is lost are delivered on reconnection, hence no catch up is required.
+type jetStreamEventSource struct {
+	// mutex guards the connection, as the source is reconnected at runtime
+	mutex     sync.Mutex
+	servers   []string
+	options   JetStreamOptions
+	conn      *nats.Conn
+	jetStream nats.JetStreamContext
+	// startedAt is the time the source is created. The consumers created by the adapter deliver the events
+	// published since then, as the state before is pulled from the control plane at the startup.
+	startedAt time.Time
+	// deliveries are the channels of the topics consumed
+	deliveries map[string]chan Delivery
+}
+
+type jetStreamDelivery struct {
+	message *nats.Msg
+}
+
+// NewJetStreamEventSource creates an EventSource for the NATS servers of the given URLs (nats://).
+func NewJetStreamEventSource(servers []string, options JetStreamOptions) EventSource {
+	return &jetStreamEventSource{servers: servers, options: options, startedAt: time.Now(),
+		deliveries: make(map[string]chan Delivery)}
+}
+
+func (source *jetStreamEventSource) Connect() error {
+	source.mutex.Lock()
+	defer source.mutex.Unlock()
+	return source.connect()
+}
+
+func (source *jetStreamEventSource) connect() error {
+	var err error
+	for attempt := 1; ; attempt++ {
+		source.conn, err = nats.Connect(strings.Join(source.servers, ","),
+			nats.Name(source.options.DurableName),
+			nats.ReconnectWait(source.options.ReconnectWait),
+			nats.MaxReconnects(source.options.MaxReconnects),
+			nats.DisconnectErrHandler(func(_ *nats.Conn, err error) {
+				logger.LoggerMsg.Errorf("Disconnected from the NATS server. %v", err)
+			}),
+			nats.ReconnectHandler(func(conn *nats.Conn) {
+				logger.LoggerMsg.Infof("Reconnected to the NATS server %s", conn.ConnectedUrlRedacted())
+			}))
+		if err == nil {
+			break
+		}
+		if source.options.MaxReconnects >= 0 && attempt > source.options.MaxReconnects {
+			return err
+		}
+		logger.LoggerMsg.Infof("Connection attempt %d to the NATS servers has failed. Retrying after %v. %v",
+			attempt, source.options.ReconnectWait, err)
+		time.Sleep(source.options.ReconnectWait)
+	}
+	logger.LoggerMsg.Infof("Successfully connected to the NATS server %s", source.conn.ConnectedUrlRedacted())
+	source.jetStream, err = source.conn.JetStream()
+	return err
+}
+
+func (source *jetStreamEventSource) Consume(topic string) (<-chan Delivery, error) {
+	source.mutex.Lock()
+	defer source.mutex.Unlock()
+	deliveries := make(chan Delivery)
+	if err := source.subscribe(topic, deliveries); err != nil {
+		return nil, err
+	}
+	source.deliveries[topic] = deliveries
+	return deliveries, nil
+}
+
+// subscribe delivers the events of the topic to the deliveries channel. An existing durable consumer delivers the
+// events from the last acknowledged event, hence the events published while the adapter is restarted are received.
+func (source *jetStreamEventSource) subscribe(topic string, deliveries chan<- Delivery) error {
+	subject := source.options.SubjectPrefix + topic
+	stream, err := source.getStream(subject)
+	if err != nil {
+		return err
+	}
+	durableName := getJetStreamDurableName(source.options.DurableName, topic)
+	subOpts := []nats.SubOpt{nats.ManualAck()}
+	if _, err = source.jetStream.ConsumerInfo(stream, durableName); err == nil {
+		subOpts = append(subOpts, nats.Bind(stream, durableName))
+	} else if errors.Is(err, nats.ErrConsumerNotFound) {
+		subOpts = append(subOpts,
+			nats.Durable(durableName),
+			nats.BindStream(stream),
+			// the adapter pulls the state before the startup from the control plane
+			nats.StartTime(source.startedAt),
+			nats.AckWait(source.options.AckWait),
+			nats.MaxDeliver(source.options.MaxRedeliveries+1),
+			nats.InactiveThreshold(source.options.InactiveThreshold))
+	} else {
+		return err
+	}
+	_, err = source.jetStream.Subscribe(subject, func(message *nats.Msg) {
+		deliveries <- &jetStreamDelivery{message: message}
+	}, subOpts...)
+	if err != nil {
+		return err
+	}
+	logger.LoggerMsg.Infof("Consuming the events of the subject %s", subject)
+	return nil
+}
+
+// getStream returns the configured stream, or looks up the stream of the subject.
+func (source *jetStreamEventSource) getStream(subject string) (string, error) {
+	if source.options.Stream != "" {
+		return source.options.Stream, nil
+	}
+	request, err := json.Marshal(map[string]string{"subject": subject})
+	if err != nil {
+		return "", err
+	}
+	response, err := source.conn.Request(jetStreamAPIStreamNames, request, jetStreamAPITimeout)
+	if err != nil {
+		return "", err
+	}
+	var streamNames struct {
+		Streams []string `json:"streams"`
+	}
+	if err = json.Unmarshal(response.Data, &streamNames); err != nil {
+		return "", err
+	}
+	if len(streamNames.Streams) != 1 {
+		return "", fmt.Errorf("no stream matches the subject %s", subject)
+	}
+	return streamNames.Streams[0], nil
+}
+
+func (source *jetStreamEventSource) Close() error {
+	source.mutex.Lock()
+	defer source.mutex.Unlock()
+	if source.conn == nil {
+		return nil
+	}
+	return source.conn.Drain()
+}
+
+func (source *jetStreamEventSource) Reconnect(servers []string) error {
+	source.mutex.Lock()
+	defer source.mutex.Unlock()
+	if source.conn != nil {
+		// the events not acknowledged are redelivered to the new connection, once the ack wait is elapsed
+		source.conn.Close()
+	}
+	source.servers = servers
+	if err := source.connect(); err != nil {
+		return err
+	}
+	for topic, deliveries := range source.deliveries {
+		if err := source.subscribe(topic, deliveries); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (delivery *jetStreamDelivery) Body() []byte {
+	return delivery.message.Data
+}
+
+func (delivery *jetStreamDelivery) Ack() error {
+	return delivery.message.Ack()
+}
+
+// Nack redelivers the event, unless it is delivered the maximum times of the consumer.
+func (delivery *jetStreamDelivery) Nack() error {
+	return delivery.message.Nak()
+}
+
+// getJetStreamDurableName returns the name of the durable consumer of the topic. The characters not allowed in
+// the durable names are replaced.
+func getJetStreamDurableName(durableName, topic string) string {
+	return strings.NewReplacer(".", "_", "*", "_", ">", "_", " ", "_").Replace(durableName + "-" + topic)
+}
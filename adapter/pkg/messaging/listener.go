@@ -20,6 +20,7 @@ package messaging
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/streadway/amqp"
 	logger "github.com/wso2/product-microgateway/adapter/pkg/loggers"
@@ -29,13 +30,23 @@ var (
 	amqpURI string
 	// RabbitConn represents the ampq connection
 	RabbitConn       *amqp.Connection
+	rabbitConnMutex  sync.RWMutex
 	rabbitCloseError chan *amqp.Error
 	// amqpURIArray represents an array of amqpFailoverURL objects
 	amqpURIArray = make([]amqpFailoverURL, 0)
+	// amqpURIsMutex guards the event listening endpoints and the amqp URIs, which are updated on a config reload
+	amqpURIsMutex sync.RWMutex
+	// consumerConnections are the connections of the consumers, by the topic
+	consumerConnections      = make(map[string]*amqp.Connection)
+	consumerConnectionsMutex sync.Mutex
+	// amqpOptions configures the queues and the consumers of the topics
+	amqpOptions = AMQPOptions{ConsumersPerQueue: 1}
 )
 
 const (
 	consumerTag string = "jms-consumer"
+	// amqpDeliveryCountHeader is the number of the previous deliveries of an event, set by the quorum queues
+	amqpDeliveryCountHeader string = "x-delivery-count"
 )
 
 const (
@@ -59,7 +70,10 @@ func startConsumer(key string) *Consumer {
 		Done:    make(chan error),
 	}
 
-	c.Conn = RabbitConn
+	c.Conn = getRabbitConn()
+	consumerConnectionsMutex.Lock()
+	consumerConnections[key] = c.Conn
+	consumerConnectionsMutex.Unlock()
 	go func() {
 		c.reconnect(key)
 	}()
@@ -98,6 +112,24 @@ func (c *Consumer) Shutdown() error {
 	return <-c.Done
 }
 
+// closeConsumerConnections closes the connections of the consumers, which are reconnected to the event listening
+// endpoints.
+func closeConsumerConnections() error {
+	consumerConnectionsMutex.Lock()
+	defer consumerConnectionsMutex.Unlock()
+	var err error
+	for key, conn := range consumerConnections {
+		if conn == nil || conn.IsClosed() {
+			continue
+		}
+		logger.LoggerMsg.Infof("Closing the connection of the consumer for %s", key)
+		if closeErr := conn.Close(); closeErr != nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
 // Consumer struct represents the structure of a consumer object
 type Consumer struct {
 	Conn    *amqp.Connection
@@ -0,0 +1,44 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package messaging
+
+import (
+	"errors"
+	"time"
+
+	"github.com/streadway/amqp"
+)
+
+// PublishEvent publishes the given JSON event to the topic of the control plane broker, using the AMQP connection
+// of the event listeners. A channel is opened for each event, as the events published by the adapter are rare.
+func PublishEvent(topic string, body []byte) error {
+	conn := getRabbitConn()
+	if conn == nil || conn.IsClosed() {
+		return errors.New("the connection to the control plane broker is not established")
+	}
+	channel, err := conn.Channel()
+	if err != nil {
+		return err
+	}
+	defer channel.Close()
+	return channel.Publish(exchange, topic, false, false, amqp.Publishing{
+		ContentType: "application/json",
+		Timestamp:   time.Now(),
+		Body:        body,
+	})
+}
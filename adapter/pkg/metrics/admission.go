@@ -0,0 +1,51 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	apiAdmissions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "adapter_api_admissions_total",
+		Help: "Number of API projects checked before the deployment, by the outcome of the admission checks.",
+	}, []string{"outcome"})
+
+	apiAdmissionViolations = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "adapter_api_admission_violations_total",
+		Help: "Number of violations found in the rejected API projects, by the admission check.",
+	}, []string{"check"})
+)
+
+func init() {
+	prometheusMetricRegistry.MustRegister(apiAdmissions, apiAdmissionViolations)
+}
+
+// ObserveAPIAdmission records the outcome of the admission checks of an API project. The violatedChecks are the
+// checks failed by the project, which is admitted if there are none.
+func ObserveAPIAdmission(violatedChecks []string) {
+	if len(violatedChecks) == 0 {
+		apiAdmissions.WithLabelValues("admitted").Inc()
+		return
+	}
+	apiAdmissions.WithLabelValues("rejected").Inc()
+	for _, check := range violatedChecks {
+		apiAdmissionViolations.WithLabelValues(check).Inc()
+	}
+}
@@ -0,0 +1,63 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	compactionRemovedEntries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "adapter_compaction_removed_entries_total",
+		Help: "Number of stale entries removed from the in-memory stores and the audit journal by the compactions.",
+	}, []string{"store"})
+
+	compactionReclaimedBytes = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "adapter_compaction_reclaimed_heap_bytes",
+		Help: "Heap memory reclaimed by the latest compaction.",
+	})
+
+	compactionDuration = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "adapter_compaction_duration_seconds",
+		Help: "Time taken by the latest compaction.",
+	})
+
+	compactionLastRun = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "adapter_compaction_last_run_timestamp_seconds",
+		Help: "Time of the latest compaction (unix time).",
+	})
+)
+
+func init() {
+	prometheusMetricRegistry.MustRegister(compactionRemovedEntries, compactionReclaimedBytes, compactionDuration,
+		compactionLastRun)
+}
+
+// ObserveCompaction records the outcome of a compaction. The removedEntries are the number of entries removed,
+// by the store.
+func ObserveCompaction(removedEntries map[string]int, reclaimedBytes int64, startedAt time.Time,
+	duration time.Duration) {
+	for store, removed := range removedEntries {
+		compactionRemovedEntries.WithLabelValues(store).Add(float64(removed))
+	}
+	compactionReclaimedBytes.Set(float64(reclaimedBytes))
+	compactionDuration.Set(duration.Seconds())
+	compactionLastRun.Set(float64(startedAt.Unix()))
+}
@@ -0,0 +1,48 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	eventDecodes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "adapter_control_plane_event_decodes_total",
+		Help: "Number of control plane events decoded, by the event type and the schema version of the event.",
+	}, []string{"event_type", "schema_version"})
+
+	eventSchemaIncompatibilities = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "adapter_control_plane_event_schema_incompatibilities_total",
+		Help: "Number of fields of the control plane events, which do not match the schema known by the adapter.",
+	}, []string{"event_type", "reason"})
+)
+
+func init() {
+	prometheusMetricRegistry.MustRegister(eventDecodes, eventSchemaIncompatibilities)
+}
+
+// ObserveEventDecode records a decoded control plane event, with the number of the fields incompatible with the
+// schema known by the adapter, by the reason. The labels should be of a fixed set of values, hence the event type
+// and the schema version should not be taken from the events as they are.
+func ObserveEventDecode(eventType, schemaVersion string, incompatibilities map[string][]string) {
+	eventDecodes.WithLabelValues(eventType, schemaVersion).Inc()
+	for reason, fields := range incompatibilities {
+		eventSchemaIncompatibilities.WithLabelValues(eventType, reason).Add(float64(len(fields)))
+	}
+}
@@ -0,0 +1,137 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Sources of the API deployments
+const (
+	DeploySourceREST         string = "rest"
+	DeploySourceControlPlane string = "controlplane"
+)
+
+const (
+	traceIDExemplarLabel string = "trace_id"
+	traceParentHeader    string = "traceparent"
+	b3TraceIDHeader      string = "X-B3-TraceId"
+	b3Header             string = "b3"
+)
+
+var (
+	// exemplarsEnabled is set when tracing is enabled, hence the trace IDs are attached to the latency histograms
+	exemplarsEnabled int32
+
+	latencyBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+	apiDeployDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "adapter_api_deploy_duration_seconds",
+		Help:    "Time taken to deploy an API project, until the configurations are pushed to the router and enforcer.",
+		Buckets: latencyBuckets,
+	}, []string{"source", "outcome"})
+
+	restRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "adapter_rest_request_duration_seconds",
+		Help:    "Time taken to serve a request of the adapter REST API.",
+		Buckets: latencyBuckets,
+	}, []string{"method", "operation", "status"})
+)
+
+func init() {
+	prometheusMetricRegistry.MustRegister(apiDeployDuration, restRequestDuration)
+}
+
+// EnableExemplars attaches the trace IDs of the requests as exemplars to the latency histograms, if enabled.
+func EnableExemplars(enabled bool) {
+	if enabled {
+		atomic.StoreInt32(&exemplarsEnabled, 1)
+	} else {
+		atomic.StoreInt32(&exemplarsEnabled, 0)
+	}
+}
+
+// ObserveAPIDeployDuration records the time taken to deploy an API project. The outcome is failure if
+// err is not nil.
+func ObserveAPIDeployDuration(source string, duration time.Duration, err error, traceID string) {
+	outcome := "success"
+	if err != nil {
+		outcome = "failure"
+	}
+	observe(apiDeployDuration.WithLabelValues(source, outcome), duration, traceID)
+}
+
+// ObserveRESTRequestDuration records the time taken to serve a request of the adapter REST API. The
+// operation is the path template of the request, which keeps the cardinality of the histogram bounded.
+func ObserveRESTRequestDuration(method, operation string, status int, duration time.Duration, traceID string) {
+	observe(restRequestDuration.WithLabelValues(method, operation, strconv.Itoa(status)), duration, traceID)
+}
+
+func observe(observer prometheus.Observer, duration time.Duration, traceID string) {
+	if traceID != "" && atomic.LoadInt32(&exemplarsEnabled) == 1 {
+		if exemplarObserver, ok := observer.(prometheus.ExemplarObserver); ok {
+			exemplarObserver.ObserveWithExemplar(duration.Seconds(), prometheus.Labels{traceIDExemplarLabel: traceID})
+			return
+		}
+	}
+	observer.Observe(duration.Seconds())
+}
+
+// TraceIDFromRequest returns the trace ID propagated with the request, using the W3C trace context or the
+// B3 (zipkin) headers. An empty string is returned if the request is not traced.
+func TraceIDFromRequest(r *http.Request) string {
+	if traceParent := r.Header.Get(traceParentHeader); traceParent != "" {
+		// version-traceid-parentid-flags
+		parts := strings.Split(traceParent, "-")
+		if len(parts) >= 4 && len(parts[1]) == 32 && isTraceID(parts[1]) {
+			return strings.ToLower(parts[1])
+		}
+	}
+	if traceID := r.Header.Get(b3TraceIDHeader); (len(traceID) == 16 || len(traceID) == 32) && isTraceID(traceID) {
+		return strings.ToLower(traceID)
+	}
+	if b3 := r.Header.Get(b3Header); b3 != "" {
+		// traceid-spanid-sampled-parentspanid
+		traceID := strings.Split(b3, "-")[0]
+		if (len(traceID) == 16 || len(traceID) == 32) && isTraceID(traceID) {
+			return strings.ToLower(traceID)
+		}
+	}
+	return ""
+}
+
+// isTraceID checks whether the value is hex encoded and not all zeros, which is an invalid trace ID.
+func isTraceID(value string) bool {
+	nonZero := false
+	for _, c := range value {
+		switch {
+		case c == '0':
+		case c >= '1' && c <= '9', c >= 'a' && c <= 'f', c >= 'A' && c <= 'F':
+			nonZero = true
+		default:
+			return false
+		}
+	}
+	return nonZero
+}
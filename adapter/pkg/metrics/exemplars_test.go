@@ -0,0 +1,77 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTraceIDFromRequest(t *testing.T) {
+	dataItems := []struct {
+		headers  map[string]string
+		expected string
+	}{
+		{map[string]string{"traceparent": "00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01"},
+			"4bf92f3577b34da6a3ce929d0e0e4736"},
+		{map[string]string{"traceparent": "00-00000000000000000000000000000000-00f067aa0ba902b7-01"}, ""},
+		{map[string]string{"traceparent": "00-not-a-trace-id"}, ""},
+		{map[string]string{"X-B3-TraceId": "80f198ee56343ba8"}, "80f198ee56343ba8"},
+		{map[string]string{"b3": "80f198ee56343ba864fe8b2a57d3eff7-e457b5a2e4d86bd1-1"},
+			"80f198ee56343ba864fe8b2a57d3eff7"},
+		{map[string]string{"b3": "0"}, ""},
+		{map[string]string{}, ""},
+	}
+	for _, item := range dataItems {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		for name, value := range item.headers {
+			r.Header.Set(name, value)
+		}
+		assert.Equal(t, item.expected, TraceIDFromRequest(r), "%v", item.headers)
+	}
+}
+
+func TestLatencyExemplars(t *testing.T) {
+	scrape := func() string {
+		r := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+		r.Header.Set("Accept", "application/openmetrics-text; version=0.0.1")
+		w := httptest.NewRecorder()
+		promhttp.HandlerFor(prometheusMetricRegistry, promhttp.HandlerOpts{EnableOpenMetrics: true}).ServeHTTP(w, r)
+		return w.Body.String()
+	}
+
+	EnableExemplars(false)
+	ObserveRESTRequestDuration(http.MethodGet, "/state", http.StatusOK, 20*time.Millisecond,
+		"4bf92f3577b34da6a3ce929d0e0e4736")
+	assert.False(t, strings.Contains(scrape(), "4bf92f3577b34da6a3ce929d0e0e4736"))
+
+	EnableExemplars(true)
+	defer EnableExemplars(false)
+	ObserveAPIDeployDuration(DeploySourceREST, 300*time.Millisecond, nil, "80f198ee56343ba864fe8b2a57d3eff7")
+	ObserveRESTRequestDuration(http.MethodGet, "/state", http.StatusOK, 20*time.Millisecond, "")
+	metrics := scrape()
+	assert.Contains(t, metrics, `adapter_api_deploy_duration_seconds_bucket{outcome="success",source="rest",le="0.5"} 1 `+
+		`# {trace_id="80f198ee56343ba864fe8b2a57d3eff7"} 0.3`)
+	assert.Contains(t, metrics, `adapter_rest_request_duration_seconds_count{method="GET",operation="/state",status="200"} 2`)
+}
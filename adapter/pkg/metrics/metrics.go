@@ -178,8 +178,13 @@ func StartPrometheusMetricsServer(port int32, collectionInterval int32) {
 
 	// Start the Prometheus metrics server
 	go func() {
-		http.Handle("/metrics", promhttp.HandlerFor(prometheusMetricRegistry, promhttp.HandlerOpts{}))
-		err := http.ListenAndServe(":"+strconv.Itoa(int(port)), nil)
+		// a dedicated mux, as the profiling endpoints registered on the default mux are not served on this port
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", promhttp.HandlerFor(prometheusMetricRegistry, promhttp.HandlerOpts{
+			// exemplars are exposed only in the OpenMetrics format
+			EnableOpenMetrics: true,
+		}))
+		err := http.ListenAndServe(":"+strconv.Itoa(int(port)), mux)
 		if err != nil {
 			logger.LoggerMgw.ErrorC(logging.ErrorDetails{
 				Message:   fmt.Sprintln("Prometheus metrics server error:", err),
@@ -0,0 +1,60 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	apiProbeDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "adapter_api_probe_duration_seconds",
+		Help:    "Time taken by a synthetic probe to invoke the health resource of an API through the router.",
+		Buckets: latencyBuckets,
+	}, []string{"api", "vhost", "outcome"})
+
+	apiProbeUp = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "adapter_api_probe_up",
+		Help: "Whether the latest synthetic probe of an API succeeded (1) or failed (0).",
+	}, []string{"api", "vhost"})
+)
+
+func init() {
+	prometheusMetricRegistry.MustRegister(apiProbeDuration, apiProbeUp)
+}
+
+// ObserveAPIProbe records the outcome and the latency of a synthetic probe of an API. The api is the name and
+// the version of the API (name:version).
+func ObserveAPIProbe(api, vhost string, duration time.Duration, success bool) {
+	outcome := "success"
+	up := 1.0
+	if !success {
+		outcome = "failure"
+		up = 0
+	}
+	apiProbeDuration.WithLabelValues(api, vhost, outcome).Observe(duration.Seconds())
+	apiProbeUp.WithLabelValues(api, vhost).Set(up)
+}
+
+// DeleteAPIProbe removes the metrics of an API, which is no longer probed.
+func DeleteAPIProbe(api, vhost string) {
+	apiProbeDuration.DeletePartialMatch(prometheus.Labels{"api": api, "vhost": vhost})
+	apiProbeUp.DeleteLabelValues(api, vhost)
+}
@@ -0,0 +1,49 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	xdsPushBatchSize = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "adapter_xds_push_batch_size",
+		Help:    "Number of API changes coalesced into a single update of the router and the enforcer resources.",
+		Buckets: []float64{1, 2, 5, 10, 25, 50, 100, 250, 500, 1000},
+	})
+
+	xdsPushLatency = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "adapter_xds_push_latency_seconds",
+		Help:    "Time from the first API change of a batch until the resources are pushed to the caches.",
+		Buckets: latencyBuckets,
+	})
+)
+
+func init() {
+	prometheusMetricRegistry.MustRegister(xdsPushBatchSize, xdsPushLatency)
+}
+
+// ObserveXdsPush records an update of the router and the enforcer resources, which coalesces the given number of
+// API changes.
+func ObserveXdsPush(changes int, latency time.Duration) {
+	xdsPushBatchSize.Observe(float64(changes))
+	xdsPushLatency.Observe(latency.Seconds())
+}
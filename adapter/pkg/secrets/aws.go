@@ -0,0 +1,193 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package secrets
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	awsService          string = "secretsmanager"
+	awsSigningAlgorithm string = "AWS4-HMAC-SHA256"
+	awsGetSecretTarget  string = "secretsmanager.GetSecretValue"
+	awsContentType      string = "application/x-amz-json-1.1"
+	awsDateFormat       string = "20060102T150405Z"
+	awsShortDateFormat  string = "20060102"
+)
+
+// AWSProvider fetches the secrets from AWS Secrets Manager. The path of a secret is its name or ARN. The key is
+// used for the secrets stored as JSON key value pairs.
+type AWSProvider struct {
+	region          string
+	endpoint        string
+	accessKeyID     string
+	secretAccessKey string
+	sessionToken    string
+	client          *http.Client
+	// now returns the time the requests are signed at
+	now func() time.Time
+}
+
+// NewAWSProvider creates an AWS Secrets Manager provider. The credentials of the environment (AWS_ACCESS_KEY_ID,
+// AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN) are used, if those are not given.
+func NewAWSProvider(region, endpoint, accessKeyID, secretAccessKey, sessionToken string) (*AWSProvider, error) {
+	if region == "" {
+		region = os.Getenv("AWS_REGION")
+	}
+	if region == "" {
+		return nil, errors.New("region of AWS Secrets Manager is not configured")
+	}
+	if accessKeyID == "" {
+		accessKeyID = os.Getenv("AWS_ACCESS_KEY_ID")
+		secretAccessKey = os.Getenv("AWS_SECRET_ACCESS_KEY")
+		sessionToken = os.Getenv("AWS_SESSION_TOKEN")
+	}
+	if accessKeyID == "" || secretAccessKey == "" {
+		return nil, errors.New("credentials of AWS Secrets Manager are not configured")
+	}
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://%s.%s.amazonaws.com", awsService, region)
+	}
+	if _, err := url.Parse(endpoint); err != nil {
+		return nil, fmt.Errorf("invalid endpoint of AWS Secrets Manager: %v", err)
+	}
+	client, err := newHTTPClient("")
+	if err != nil {
+		return nil, err
+	}
+	return &AWSProvider{
+		region:          region,
+		endpoint:        endpoint,
+		accessKeyID:     accessKeyID,
+		secretAccessKey: secretAccessKey,
+		sessionToken:    sessionToken,
+		client:          client,
+		now:             time.Now,
+	}, nil
+}
+
+// GetSecret returns the secret string of the secret, or the value of the key if the secret string is a JSON object.
+func (provider *AWSProvider) GetSecret(path, key string) (string, error) {
+	payload, err := json.Marshal(map[string]string{"SecretId": path})
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequest(http.MethodPost, provider.endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", awsContentType)
+	req.Header.Set("X-Amz-Target", awsGetSecretTarget)
+	provider.sign(req, payload)
+	resp, err := provider.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	body, err := readResponse(resp)
+	if err != nil {
+		return "", err
+	}
+
+	var secret struct {
+		SecretString string `json:"SecretString"`
+		SecretBinary string `json:"SecretBinary"`
+	}
+	if err := json.Unmarshal(body, &secret); err != nil {
+		return "", fmt.Errorf("error parsing the AWS Secrets Manager response: %v", err)
+	}
+	value := secret.SecretString
+	if value == "" && secret.SecretBinary != "" {
+		decoded, err := base64.StdEncoding.DecodeString(secret.SecretBinary)
+		if err != nil {
+			return "", fmt.Errorf("error decoding the binary secret: %v", err)
+		}
+		value = string(decoded)
+	}
+	if key == "" {
+		return value, nil
+	}
+	var values map[string]interface{}
+	if err := json.Unmarshal([]byte(value), &values); err != nil {
+		return "", fmt.Errorf("secret is not a JSON object, hence the key %q can not be selected", key)
+	}
+	return selectSecretKey(values, key)
+}
+
+// sign signs the request with the AWS signature version 4.
+func (provider *AWSProvider) sign(req *http.Request, payload []byte) {
+	now := provider.now().UTC()
+	amzDate := now.Format(awsDateFormat)
+	req.Header.Set("X-Amz-Date", amzDate)
+	if provider.sessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", provider.sessionToken)
+	}
+
+	signedHeaders := []string{"content-type", "host", "x-amz-date"}
+	if provider.sessionToken != "" {
+		signedHeaders = append(signedHeaders, "x-amz-security-token")
+	}
+	signedHeaders = append(signedHeaders, "x-amz-target")
+	var canonicalHeaders strings.Builder
+	for _, header := range signedHeaders {
+		value := req.Header.Get(header)
+		if header == "host" {
+			value = req.URL.Host
+		}
+		canonicalHeaders.WriteString(header + ":" + strings.TrimSpace(value) + "\n")
+	}
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	canonicalRequest := strings.Join([]string{req.Method, path, req.URL.Query().Encode(),
+		canonicalHeaders.String(), strings.Join(signedHeaders, ";"), hashHex(payload)}, "\n")
+
+	scope := strings.Join([]string{now.Format(awsShortDateFormat), provider.region, awsService, "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{awsSigningAlgorithm, amzDate, scope, hashHex([]byte(canonicalRequest))},
+		"\n")
+	signingKey := hmacSHA256([]byte("AWS4"+provider.secretAccessKey), now.Format(awsShortDateFormat))
+	for _, part := range []string{provider.region, awsService, "aws4_request"} {
+		signingKey = hmacSHA256(signingKey, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		awsSigningAlgorithm, provider.accessKeyID, scope, strings.Join(signedHeaders, ";"), signature))
+}
+
+func hashHex(content []byte) string {
+	hash := sha256.Sum256(content)
+	return hex.EncodeToString(hash[:])
+}
+
+func hmacSHA256(key []byte, content string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(content))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,123 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package secrets
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// Defaults of a pod, to access the API server with its service account
+const (
+	defaultKubernetesAPIServer  string = "https://kubernetes.default.svc"
+	serviceAccountDir           string = "/var/run/secrets/kubernetes.io/serviceaccount/"
+	defaultKubernetesTokenPath  string = serviceAccountDir + "token"
+	defaultKubernetesCACertPath string = serviceAccountDir + "ca.crt"
+	serviceAccountNamespacePath string = serviceAccountDir + "namespace"
+)
+
+// KubernetesProvider fetches the secrets from the Kubernetes API server. The path of a secret is its name, optionally
+// prefixed with its namespace (ex: mgw/backend-credentials).
+type KubernetesProvider struct {
+	apiServer string
+	namespace string
+	tokenPath string
+	client    *http.Client
+}
+
+// NewKubernetesProvider creates a Kubernetes provider. The API server, the namespace and the credentials of the
+// service account of the pod are used, if those are not given.
+func NewKubernetesProvider(apiServer, namespace, tokenPath, caCertPath string) (*KubernetesProvider, error) {
+	if apiServer == "" {
+		apiServer = defaultKubernetesAPIServer
+	}
+	if tokenPath == "" {
+		tokenPath = defaultKubernetesTokenPath
+	}
+	if _, err := os.Stat(defaultKubernetesCACertPath); caCertPath == "" && err == nil {
+		caCertPath = defaultKubernetesCACertPath
+	}
+	if namespace == "" {
+		if content, err := ioutil.ReadFile(serviceAccountNamespacePath); err == nil {
+			namespace = strings.TrimSpace(string(content))
+		}
+	}
+	client, err := newHTTPClient(caCertPath)
+	if err != nil {
+		return nil, err
+	}
+	return &KubernetesProvider{
+		apiServer: strings.TrimSuffix(apiServer, "/"),
+		namespace: namespace,
+		tokenPath: tokenPath,
+		client:    client,
+	}, nil
+}
+
+// GetSecret returns the value of the key of the secret at the path.
+func (provider *KubernetesProvider) GetSecret(path, key string) (string, error) {
+	namespace, name := provider.namespace, path
+	if parts := strings.SplitN(path, "/", 2); len(parts) == 2 {
+		namespace, name = parts[0], parts[1]
+	}
+	if namespace == "" {
+		return "", errors.New("namespace of the secret is not known")
+	}
+	// the service account token is rotated by the kubelet, hence it is read for each request
+	token, err := ioutil.ReadFile(provider.tokenPath)
+	if err != nil {
+		return "", fmt.Errorf("error reading the service account token: %v", err)
+	}
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/api/v1/namespaces/%s/secrets/%s", provider.apiServer,
+		url.PathEscape(namespace), url.PathEscape(name)), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	resp, err := provider.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	body, err := readResponse(resp)
+	if err != nil {
+		return "", err
+	}
+
+	var secret struct {
+		Data map[string]string `json:"data"`
+	}
+	if err := json.Unmarshal(body, &secret); err != nil {
+		return "", fmt.Errorf("error parsing the Kubernetes secret: %v", err)
+	}
+	values := make(map[string]interface{}, len(secret.Data))
+	for dataKey, encoded := range secret.Data {
+		decoded, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return "", fmt.Errorf("error decoding the key %q of the Kubernetes secret: %v", dataKey, err)
+		}
+		values[dataKey] = string(decoded)
+	}
+	return selectSecretKey(values, key)
+}
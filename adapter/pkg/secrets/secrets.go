@@ -0,0 +1,399 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+// Package secrets resolves the references to the secrets of the external secret stores (HashiCorp Vault,
+// Kubernetes Secrets and AWS Secrets Manager), from the adapter configuration and the API artifacts.
+//
+// A secret is referred as $secret{provider:path#key}, where the provider and the key are optional.
+// Ex: $secret{vault:secret/mgw/broker#password}, $secret{kubernetes:mgw/backend-credentials#username}
+package secrets
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	logger "github.com/wso2/product-microgateway/adapter/pkg/loggers"
+)
+
+// Names of the secret providers, used in the secret references
+const (
+	ProviderVault      string = "vault"
+	ProviderKubernetes string = "kubernetes"
+	ProviderAWS        string = "aws"
+	// providerKubernetesAlias is the short name of the kubernetes provider
+	providerKubernetesAlias string = "k8s"
+)
+
+const (
+	referencePrefix   string = "$secret{"
+	providerSeparator string = ":"
+	keySeparator      string = "#"
+	// httpTimeout is the timeout of the requests sent to the secret stores
+	httpTimeout = 10 * time.Second
+)
+
+var referencePattern = regexp.MustCompile(`\$secret\{([^}]+)\}`)
+
+var (
+	defaultManager *Manager
+	managerMutex   sync.RWMutex
+)
+
+// Provider fetches the secrets from a secret store.
+type Provider interface {
+	// GetSecret returns the value of the secret at the path. If a key is given, the value of the key is returned
+	// from a secret of multiple key value pairs.
+	GetSecret(path, key string) (string, error)
+}
+
+// Reference represents a secret referred in a configuration value.
+type Reference struct {
+	Provider string
+	Path     string
+	Key      string
+}
+
+// ParseReference parses the content of a secret reference (provider:path#key). The provider is left empty, if the
+// reference does not name a known provider, hence the paths having colons (ex: ARNs) are referred as they are.
+func ParseReference(reference string) (Reference, error) {
+	var parsed Reference
+	value := strings.TrimSpace(reference)
+	if index := strings.Index(value, providerSeparator); index > 0 {
+		if provider := normalizeProvider(value[:index]); provider != "" {
+			parsed.Provider = provider
+			value = value[index+1:]
+		}
+	}
+	if index := strings.LastIndex(value, keySeparator); index >= 0 {
+		parsed.Key = strings.TrimSpace(value[index+1:])
+		value = value[:index]
+	}
+	parsed.Path = strings.Trim(strings.TrimSpace(value), "/")
+	if parsed.Path == "" {
+		return parsed, fmt.Errorf("path of the secret reference %q is empty", reference)
+	}
+	return parsed, nil
+}
+
+func normalizeProvider(provider string) string {
+	switch strings.ToLower(strings.TrimSpace(provider)) {
+	case ProviderVault:
+		return ProviderVault
+	case ProviderKubernetes, providerKubernetesAlias:
+		return ProviderKubernetes
+	case ProviderAWS:
+		return ProviderAWS
+	}
+	return ""
+}
+
+// HasReference returns whether the value refers any secrets.
+func HasReference(value string) bool {
+	return strings.Contains(value, referencePrefix)
+}
+
+// GetReferences returns the secret references (the content within the braces) of the value.
+func GetReferences(value string) []string {
+	var references []string
+	for _, match := range referencePattern.FindAllStringSubmatch(value, -1) {
+		references = append(references, match[1])
+	}
+	return references
+}
+
+// Manager resolves the secret references with the registered providers. The resolved secrets are cached, and
+// fetched again when the manager is refreshed, to detect the secrets rotated in the secret stores.
+type Manager struct {
+	defaultProvider string
+	providers       map[string]Provider
+	// values are the values of the secrets resolved, by the reference
+	values    map[string]string
+	listeners []func(references []string)
+	mutex     sync.RWMutex
+}
+
+// NewManager creates a secret manager, which resolves the references without a provider with the default provider.
+func NewManager(defaultProvider string) *Manager {
+	return &Manager{
+		defaultProvider: normalizeProvider(defaultProvider),
+		providers:       make(map[string]Provider),
+		values:          make(map[string]string),
+	}
+}
+
+// RegisterProvider registers a provider by its name (vault, kubernetes or aws).
+func (manager *Manager) RegisterProvider(name string, provider Provider) {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	manager.providers[normalizeProvider(name)] = provider
+}
+
+// OnRotation registers a listener, which is notified with the references of the secrets rotated.
+func (manager *Manager) OnRotation(listener func(references []string)) {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	manager.listeners = append(manager.listeners, listener)
+}
+
+// Resolve replaces the secret references of the value with the values of the secrets. The secrets are fetched from
+// the secret stores only if those are not resolved before.
+func (manager *Manager) Resolve(value string) (string, error) {
+	if !HasReference(value) {
+		return value, nil
+	}
+	var resolveErr error
+	resolved := referencePattern.ReplaceAllStringFunc(value, func(match string) string {
+		reference := referencePattern.FindStringSubmatch(match)[1]
+		manager.mutex.RLock()
+		secret, found := manager.values[reference]
+		manager.mutex.RUnlock()
+		if found {
+			return secret
+		}
+		secret, err := manager.fetch(reference)
+		if err != nil {
+			if resolveErr == nil {
+				resolveErr = err
+			}
+			return match
+		}
+		manager.mutex.Lock()
+		manager.values[reference] = secret
+		manager.mutex.Unlock()
+		return secret
+	})
+	return resolved, resolveErr
+}
+
+// ResolveWithin replaces the secret references of the value, only if each secret is within the allowed paths
+// (provider:path). A secret is within a path if it is the secret of the path or a secret under the path, and the
+// provider is the provider of the path. An error is returned without fetching any secret otherwise.
+func (manager *Manager) ResolveWithin(value string, allowedPaths []string) (string, error) {
+	for _, reference := range GetReferences(value) {
+		parsed, err := ParseReference(reference)
+		if err != nil {
+			return value, err
+		}
+		if parsed.Provider == "" {
+			parsed.Provider = manager.defaultProvider
+		}
+		if !isWithinPaths(parsed, allowedPaths, manager.defaultProvider) {
+			return value, fmt.Errorf("secret %q is not within the paths allowed to be referred", reference)
+		}
+	}
+	return manager.Resolve(value)
+}
+
+// isWithinPaths returns whether the secret is the secret of an allowed path or a secret under an allowed path.
+func isWithinPaths(secret Reference, allowedPaths []string, defaultProvider string) bool {
+	for _, segment := range strings.Split(secret.Path, "/") {
+		if segment == ".." || segment == "." {
+			return false
+		}
+	}
+	for _, allowedPath := range allowedPaths {
+		allowed, err := ParseReference(allowedPath)
+		if err != nil {
+			continue
+		}
+		if allowed.Provider == "" {
+			allowed.Provider = defaultProvider
+		}
+		if allowed.Provider == secret.Provider &&
+			(secret.Path == allowed.Path || strings.HasPrefix(secret.Path, allowed.Path+"/")) {
+			return true
+		}
+	}
+	return false
+}
+
+func (manager *Manager) fetch(reference string) (string, error) {
+	parsed, err := ParseReference(reference)
+	if err != nil {
+		return "", err
+	}
+	if parsed.Provider == "" {
+		parsed.Provider = manager.defaultProvider
+	}
+	manager.mutex.RLock()
+	provider, found := manager.providers[parsed.Provider]
+	manager.mutex.RUnlock()
+	if !found {
+		return "", fmt.Errorf("secret provider %q of the secret %q is not enabled", parsed.Provider, reference)
+	}
+	secret, err := provider.GetSecret(parsed.Path, parsed.Key)
+	if err != nil {
+		return "", fmt.Errorf("error fetching the secret %q: %v", reference, err)
+	}
+	return secret, nil
+}
+
+// Refresh fetches the secrets resolved again, and notifies the listeners with the references of the secrets
+// rotated. The previous value is kept, if a secret can not be fetched. Returns the references of the secrets rotated.
+func (manager *Manager) Refresh() []string {
+	manager.mutex.RLock()
+	references := make([]string, 0, len(manager.values))
+	for reference := range manager.values {
+		references = append(references, reference)
+	}
+	manager.mutex.RUnlock()
+	sort.Strings(references)
+
+	var rotated []string
+	for _, reference := range references {
+		secret, err := manager.fetch(reference)
+		if err != nil {
+			logger.LoggerSecrets.Warnf("Secret %q is not refreshed. %v", reference, err)
+			continue
+		}
+		manager.mutex.Lock()
+		if manager.values[reference] != secret {
+			manager.values[reference] = secret
+			rotated = append(rotated, reference)
+		}
+		manager.mutex.Unlock()
+	}
+	if len(rotated) == 0 {
+		return nil
+	}
+	logger.LoggerSecrets.Infof("Secrets %s are rotated", strings.Join(rotated, ", "))
+	manager.mutex.RLock()
+	listeners := append([]func(references []string){}, manager.listeners...)
+	manager.mutex.RUnlock()
+	for _, listener := range listeners {
+		listener(rotated)
+	}
+	return rotated
+}
+
+// StartRefresh refreshes the secrets periodically, to apply the secrets rotated in the secret stores.
+func (manager *Manager) StartRefresh(interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	for range time.Tick(interval) {
+		manager.Refresh()
+	}
+}
+
+// SetManager sets the manager used to resolve the secret references of the adapter.
+func SetManager(manager *Manager) {
+	managerMutex.Lock()
+	defer managerMutex.Unlock()
+	defaultManager = manager
+}
+
+// GetManager returns the manager used to resolve the secret references of the adapter, or nil if no secret
+// store is configured.
+func GetManager() *Manager {
+	managerMutex.RLock()
+	defer managerMutex.RUnlock()
+	return defaultManager
+}
+
+// Resolve replaces the secret references of the value with the manager of the adapter.
+func Resolve(value string) (string, error) {
+	if !HasReference(value) {
+		return value, nil
+	}
+	manager := GetManager()
+	if manager == nil {
+		return value, errors.New("secret references can not be resolved as no secret store is configured")
+	}
+	return manager.Resolve(value)
+}
+
+// ResolveWithin replaces the secret references of the value with the manager of the adapter, only if each secret
+// is within the allowed paths.
+func ResolveWithin(value string, allowedPaths []string) (string, error) {
+	if !HasReference(value) {
+		return value, nil
+	}
+	manager := GetManager()
+	if manager == nil {
+		return value, errors.New("secret references can not be resolved as no secret store is configured")
+	}
+	return manager.ResolveWithin(value, allowedPaths)
+}
+
+// selectSecretKey returns the value of the key from a secret of multiple key value pairs. If the key is not given,
+// the value is returned only if the secret has a single key.
+func selectSecretKey(values map[string]interface{}, key string) (string, error) {
+	if key == "" {
+		if len(values) != 1 {
+			return "", fmt.Errorf("secret has %d keys, hence the key should be referred", len(values))
+		}
+		for _, value := range values {
+			key = fmt.Sprint(value)
+		}
+		return key, nil
+	}
+	value, found := values[key]
+	if !found {
+		return "", fmt.Errorf("key %q is not found in the secret", key)
+	}
+	if text, ok := value.(string); ok {
+		return text, nil
+	}
+	content, err := json.Marshal(value)
+	return string(content), err
+}
+
+// newHTTPClient creates the client of a secret store, trusting the certificates of the CA file if it is given, in
+// addition to the system certificates.
+func newHTTPClient(caCertPath string) (*http.Client, error) {
+	client := &http.Client{Timeout: httpTimeout}
+	if caCertPath == "" {
+		return client, nil
+	}
+	caCerts, err := ioutil.ReadFile(caCertPath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading the CA certificates %s: %v", caCertPath, err)
+	}
+	certPool, err := x509.SystemCertPool()
+	if err != nil || certPool == nil {
+		certPool = x509.NewCertPool()
+	}
+	if !certPool.AppendCertsFromPEM(caCerts) {
+		return nil, fmt.Errorf("no certificates are found in %s", caCertPath)
+	}
+	client.Transport = &http.Transport{TLSClientConfig: &tls.Config{RootCAs: certPool}}
+	return client, nil
+}
+
+// readResponse reads the body of a response of a secret store, failing if the secret is not returned.
+func readResponse(resp *http.Response) ([]byte, error) {
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("secret store responded with the status %d", resp.StatusCode)
+	}
+	return body, nil
+}
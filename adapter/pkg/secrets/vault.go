@@ -0,0 +1,123 @@
+/*
+ *  Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package secrets
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+const (
+	vaultTokenHeader     string = "X-Vault-Token"
+	vaultNamespaceHeader string = "X-Vault-Namespace"
+)
+
+// VaultProvider fetches the secrets from the KV secrets engine of HashiCorp Vault. The path of a secret starts with
+// the mount of the secrets engine (ex: secret/mgw/broker).
+type VaultProvider struct {
+	address   string
+	token     string
+	tokenPath string
+	namespace string
+	kvVersion int
+	client    *http.Client
+}
+
+// NewVaultProvider creates a Vault provider. If the token path is given, the token is read from the file for each
+// request, hence the tokens renewed by the Vault agent are used.
+func NewVaultProvider(address, token, tokenPath, namespace string, kvVersion int,
+	caCertPath string) (*VaultProvider, error) {
+	if address == "" {
+		return nil, errors.New("address of the Vault server is not configured")
+	}
+	if token == "" && tokenPath == "" {
+		return nil, errors.New("neither the token nor the token path of the Vault server is configured")
+	}
+	client, err := newHTTPClient(caCertPath)
+	if err != nil {
+		return nil, err
+	}
+	if kvVersion != 1 {
+		kvVersion = 2
+	}
+	return &VaultProvider{
+		address:   strings.TrimSuffix(address, "/"),
+		token:     token,
+		tokenPath: tokenPath,
+		namespace: namespace,
+		kvVersion: kvVersion,
+		client:    client,
+	}, nil
+}
+
+// GetSecret returns the value of the key of the secret at the path.
+func (provider *VaultProvider) GetSecret(path, key string) (string, error) {
+	token := provider.token
+	if provider.tokenPath != "" {
+		content, err := ioutil.ReadFile(provider.tokenPath)
+		if err != nil {
+			return "", fmt.Errorf("error reading the Vault token: %v", err)
+		}
+		token = strings.TrimSpace(string(content))
+	}
+	req, err := http.NewRequest(http.MethodGet, provider.address+"/v1/"+provider.getAPIPath(path), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set(vaultTokenHeader, token)
+	if provider.namespace != "" {
+		req.Header.Set(vaultNamespaceHeader, provider.namespace)
+	}
+	resp, err := provider.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	body, err := readResponse(resp)
+	if err != nil {
+		return "", err
+	}
+
+	var secret struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &secret); err != nil {
+		return "", fmt.Errorf("error parsing the Vault response: %v", err)
+	}
+	values := secret.Data
+	if provider.kvVersion == 2 {
+		// the secret of the version 2 engine is wrapped with its metadata
+		values, _ = secret.Data["data"].(map[string]interface{})
+	}
+	return selectSecretKey(values, key)
+}
+
+// getAPIPath returns the API path of a secret, which has the data prefix after the mount in the version 2 engine.
+func (provider *VaultProvider) getAPIPath(path string) string {
+	if provider.kvVersion != 2 {
+		return path
+	}
+	parts := strings.SplitN(path, "/", 2)
+	if len(parts) == 1 {
+		return parts[0] + "/data"
+	}
+	return parts[0] + "/data/" + parts[1]
+}
@@ -305,8 +305,8 @@ func parseEnvProps(data []byte) (map[string]map[string]APIEnvProps, error) {
 			for apiUUID, apiData := range apis {
 				apiProps := make(map[string]APIEnvProps)
 				if api, ok := apiData.(map[string]interface{}); ok {
-					var envProps APIEnvProps
 					for envLabel, envData := range api {
+						var envProps APIEnvProps
 						if err := parser.Decode(envData, &envProps); err != nil {
 							logger.LoggerSync.Error("Error parsing environment specific values: ", err)
 							return nil, err
@@ -66,8 +66,8 @@ type GatewayLabel struct {
 
 // APIConfigs represents env properties belongs to the API
 type APIConfigs struct {
-	ProductionEndpoint string `mapstructure:"productionEndpoint,omitempty"`
-	SandBoxEndpoint    string `mapstructure:"sandboxEndpoint,omitempty"`
+	ProductionEndpoint string `mapstructure:"productionEndpoint,omitempty" json:"productionEndpoint,omitempty"`
+	SandBoxEndpoint    string `mapstructure:"sandboxEndpoint,omitempty" json:"sandboxEndpoint,omitempty"`
 }
 
 // APIEnvProps represents env properties
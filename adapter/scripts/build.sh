@@ -76,3 +76,15 @@ if [ $? -ne 0 ]; then
   echo "FAILED: Build failure for GOARCH=amd64"
   exit 1
 fi 
+
+GOOS=linux GOARCH=amd64 CGO_ENABLED=0 go build -v -o target/adapterctl-linux-amd64 github.com/wso2/product-microgateway/adapter/cmd/adapterctl
+if [ $? -ne 0 ]; then 
+  echo "FAILED: Build failure of adapterctl for GOARCH=amd64"
+  exit 1
+fi 
+
+GOOS=linux GOARCH=amd64 CGO_ENABLED=0 go build -v -o target/eventbench-linux-amd64 github.com/wso2/product-microgateway/adapter/cmd/eventbench
+if [ $? -ne 0 ]; then 
+  echo "FAILED: Build failure of eventbench for GOARCH=amd64"
+  exit 1
+fi
@@ -51,6 +51,10 @@ public class APIConfig {
     private boolean applicationSecurity;
     private GraphQLSchemaDTO graphQLSchemaDTO;
     private String endpointType;
+    private List<String> allowedIssuers = new ArrayList<>();
+    private List<String> allowedAudiences = new ArrayList<>();
+    private int maxConcurrentRequestsPerApplication;
+    private BackendJWTConfig backendJWTConfig;
 
     /**
      * getApiType returns the API type. This could be one of the following.
@@ -256,6 +260,43 @@ public class APIConfig {
         return endpointType;
     }
 
+    /**
+     * Returns the token issuers accepted by the API. Any configured issuer is accepted if empty.
+     *
+     * @return allowed token issuers
+     */
+    public List<String> getAllowedIssuers() {
+        return allowedIssuers;
+    }
+
+    /**
+     * Returns the audiences accepted by the API. A token should contain at least one of them, if not empty.
+     *
+     * @return allowed token audiences
+     */
+    public List<String> getAllowedAudiences() {
+        return allowedAudiences;
+    }
+
+    /**
+     * Returns the maximum number of in-flight requests allowed for an application. Unlimited if 0.
+     *
+     * @return maximum concurrent requests per application
+     */
+    public int getMaxConcurrentRequestsPerApplication() {
+        return maxConcurrentRequestsPerApplication;
+    }
+
+    /**
+     * Returns the generation of the JWT sent to the backend of the API. Null if the JWT generator configuration
+     * of the enforcer is applied.
+     *
+     * @return backend JWT configuration
+     */
+    public BackendJWTConfig getBackendJWTConfig() {
+        return backendJWTConfig;
+    }
+
     /**
      * Implements builder pattern to build an API Config object.
      */
@@ -284,6 +325,10 @@ public class APIConfig {
         private boolean applicationSecurity;
         private GraphQLSchemaDTO graphQLSchemaDTO;
         private String endpointType;
+        private List<String> allowedIssuers = new ArrayList<>();
+        private List<String> allowedAudiences = new ArrayList<>();
+        private int maxConcurrentRequestsPerApplication;
+        private BackendJWTConfig backendJWTConfig;
 
         public Builder(String name) {
             this.name = name;
@@ -398,6 +443,26 @@ public class APIConfig {
             return this;
         }
 
+        public Builder allowedIssuers(List<String> allowedIssuers) {
+            this.allowedIssuers = allowedIssuers;
+            return this;
+        }
+
+        public Builder allowedAudiences(List<String> allowedAudiences) {
+            this.allowedAudiences = allowedAudiences;
+            return this;
+        }
+
+        public Builder maxConcurrentRequestsPerApplication(int maxConcurrentRequestsPerApplication) {
+            this.maxConcurrentRequestsPerApplication = maxConcurrentRequestsPerApplication;
+            return this;
+        }
+
+        public Builder backendJWTConfig(BackendJWTConfig backendJWTConfig) {
+            this.backendJWTConfig = backendJWTConfig;
+            return this;
+        }
+
         public APIConfig build() {
             APIConfig apiConfig = new APIConfig();
             apiConfig.name = this.name;
@@ -423,6 +488,10 @@ public class APIConfig {
             apiConfig.applicationSecurity = this.applicationSecurity;
             apiConfig.graphQLSchemaDTO = this.graphQLSchemaDTO;
             apiConfig.endpointType = this.endpointType;
+            apiConfig.allowedIssuers = this.allowedIssuers;
+            apiConfig.allowedAudiences = this.allowedAudiences;
+            apiConfig.maxConcurrentRequestsPerApplication = this.maxConcurrentRequestsPerApplication;
+            apiConfig.backendJWTConfig = this.backendJWTConfig;
             return apiConfig;
         }
     }
@@ -18,6 +18,7 @@
 package org.wso2.choreo.connect.enforcer.commons.model;
 
 import java.util.List;
+import java.util.Map;
 
 /**
  * AuthenticationContext contains the details populated after applying authentication filter.
@@ -49,6 +50,7 @@ public class AuthenticationContext {
     private String apiUUID;
     private String rawToken;
     private String tokenType;
+    private Map<String, String> applicationAttributes;
 
     public static final String UNKNOWN_VALUE = "__unknown__";
 
@@ -388,4 +390,17 @@ public class AuthenticationContext {
     public void setApplicationUUID(String applicationUUID) {
         this.applicationUUID = applicationUUID;
     }
+
+    /**
+     * Custom attributes of the application of the request.
+     *
+     * @return Application attributes, or null if the application is not resolved
+     */
+    public Map<String, String> getApplicationAttributes() {
+        return applicationAttributes;
+    }
+
+    public void setApplicationAttributes(Map<String, String> applicationAttributes) {
+        this.applicationAttributes = applicationAttributes;
+    }
 }
@@ -0,0 +1,84 @@
+/*
+ * Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package org.wso2.choreo.connect.enforcer.commons.model;
+
+import java.util.ArrayList;
+import java.util.List;
+
+/**
+ * The generation of the JWT sent to the backend of an API, describing the consumer.
+ */
+public class BackendJWTConfig {
+    private final boolean enabled;
+    private final String header;
+    private final String signingAlgorithm;
+    private final boolean enableUserClaims;
+    private final List<String> applicationAttributes;
+
+    /**
+     * @param enabled Whether the JWT is sent to the backend
+     * @param header Name of the header to which the JWT is attached
+     * @param signingAlgorithm Algorithm used to sign the JWT
+     * @param enableUserClaims Whether the claims of the user are included in the JWT
+     * @param applicationAttributes Application attributes included as claims. All are included if empty.
+     */
+    public BackendJWTConfig(boolean enabled, String header, String signingAlgorithm, boolean enableUserClaims,
+                            List<String> applicationAttributes) {
+        this.enabled = enabled;
+        this.header = header;
+        this.signingAlgorithm = signingAlgorithm;
+        this.enableUserClaims = enableUserClaims;
+        this.applicationAttributes = applicationAttributes != null ? applicationAttributes : new ArrayList<>();
+    }
+
+    /**
+     * @return Whether the JWT is sent to the backend
+     */
+    public boolean isEnabled() {
+        return enabled;
+    }
+
+    /**
+     * @return Name of the header to which the JWT is attached
+     */
+    public String getHeader() {
+        return header;
+    }
+
+    /**
+     * @return Algorithm used to sign the JWT
+     */
+    public String getSigningAlgorithm() {
+        return signingAlgorithm;
+    }
+
+    /**
+     * @return Whether the claims of the user are included in the JWT
+     */
+    public boolean isEnableUserClaims() {
+        return enableUserClaims;
+    }
+
+    /**
+     * @return Application attributes included as claims. All are included if empty.
+     */
+    public List<String> getApplicationAttributes() {
+        return applicationAttributes;
+    }
+}
@@ -39,6 +39,11 @@ private static final long serialVersionUID = 0L;
     graphQLSchema_ = "";
     graphqlComplexityInfo_ = java.util.Collections.emptyList();
     endpointType_ = "";
+    allowedIssuers_ = com.google.protobuf.LazyStringArrayList.EMPTY;
+    allowedAudiences_ = com.google.protobuf.LazyStringArrayList.EMPTY;
+    backendJwtHeader_ = "";
+    backendJwtSigningAlgorithm_ = "";
+    backendJwtApplicationAttributes_ = com.google.protobuf.LazyStringArrayList.EMPTY;
   }
 
   @java.lang.Override
@@ -255,6 +260,60 @@ private static final long serialVersionUID = 0L;
             endpointType_ = s;
             break;
           }
+          case 210: {
+            java.lang.String s = input.readStringRequireUtf8();
+            if (!((mutable_bitField0_ & 0x00000020) != 0)) {
+              allowedIssuers_ = new com.google.protobuf.LazyStringArrayList();
+              mutable_bitField0_ |= 0x00000020;
+            }
+            allowedIssuers_.add(s);
+            break;
+          }
+          case 218: {
+            java.lang.String s = input.readStringRequireUtf8();
+            if (!((mutable_bitField0_ & 0x00000040) != 0)) {
+              allowedAudiences_ = new com.google.protobuf.LazyStringArrayList();
+              mutable_bitField0_ |= 0x00000040;
+            }
+            allowedAudiences_.add(s);
+            break;
+          }
+          case 224: {
+
+            maxConcurrentRequestsPerApplication_ = input.readUInt32();
+            break;
+          }
+          case 232: {
+
+            backendJwtEnabled_ = input.readBool();
+            break;
+          }
+          case 242: {
+            java.lang.String s = input.readStringRequireUtf8();
+
+            backendJwtHeader_ = s;
+            break;
+          }
+          case 250: {
+            java.lang.String s = input.readStringRequireUtf8();
+
+            backendJwtSigningAlgorithm_ = s;
+            break;
+          }
+          case 256: {
+
+            backendJwtEnableUserClaims_ = input.readBool();
+            break;
+          }
+          case 266: {
+            java.lang.String s = input.readStringRequireUtf8();
+            if (!((mutable_bitField0_ & 0x00000080) != 0)) {
+              backendJwtApplicationAttributes_ = new com.google.protobuf.LazyStringArrayList();
+              mutable_bitField0_ |= 0x00000080;
+            }
+            backendJwtApplicationAttributes_.add(s);
+            break;
+          }
           default: {
             if (!parseUnknownField(
                 input, unknownFields, extensionRegistry, tag)) {
@@ -285,6 +344,15 @@ private static final long serialVersionUID = 0L;
       if (((mutable_bitField0_ & 0x00000010) != 0)) {
         graphqlComplexityInfo_ = java.util.Collections.unmodifiableList(graphqlComplexityInfo_);
       }
+      if (((mutable_bitField0_ & 0x00000020) != 0)) {
+        allowedIssuers_ = allowedIssuers_.getUnmodifiableView();
+      }
+      if (((mutable_bitField0_ & 0x00000040) != 0)) {
+        allowedAudiences_ = allowedAudiences_.getUnmodifiableView();
+      }
+      if (((mutable_bitField0_ & 0x00000080) != 0)) {
+        backendJwtApplicationAttributes_ = backendJwtApplicationAttributes_.getUnmodifiableView();
+      }
       this.unknownFields = unknownFields.build();
       makeExtensionsImmutable();
     }
@@ -1145,6 +1213,296 @@ private static final long serialVersionUID = 0L;
     }
   }
 
+  public static final int ALLOWEDISSUERS_FIELD_NUMBER = 26;
+  private com.google.protobuf.LazyStringList allowedIssuers_;
+  /**
+   * <pre>
+   * Token issuers accepted by the API. Any issuer configured in the enforcer is accepted if empty.
+   * </pre>
+   *
+   * <code>repeated string allowedIssuers = 26;</code>
+   * @return A list containing the allowedIssuers.
+   */
+  public com.google.protobuf.ProtocolStringList
+      getAllowedIssuersList() {
+    return allowedIssuers_;
+  }
+  /**
+   * <pre>
+   * Token issuers accepted by the API. Any issuer configured in the enforcer is accepted if empty.
+   * </pre>
+   *
+   * <code>repeated string allowedIssuers = 26;</code>
+   * @return The count of allowedIssuers.
+   */
+  public int getAllowedIssuersCount() {
+    return allowedIssuers_.size();
+  }
+  /**
+   * <pre>
+   * Token issuers accepted by the API. Any issuer configured in the enforcer is accepted if empty.
+   * </pre>
+   *
+   * <code>repeated string allowedIssuers = 26;</code>
+   * @param index The index of the element to return.
+   * @return The allowedIssuers at the given index.
+   */
+  public java.lang.String getAllowedIssuers(int index) {
+    return allowedIssuers_.get(index);
+  }
+  /**
+   * <pre>
+   * Token issuers accepted by the API. Any issuer configured in the enforcer is accepted if empty.
+   * </pre>
+   *
+   * <code>repeated string allowedIssuers = 26;</code>
+   * @param index The index of the value to return.
+   * @return The bytes of the allowedIssuers at the given index.
+   */
+  public com.google.protobuf.ByteString
+      getAllowedIssuersBytes(int index) {
+    return allowedIssuers_.getByteString(index);
+  }
+
+  public static final int ALLOWEDAUDIENCES_FIELD_NUMBER = 27;
+  private com.google.protobuf.LazyStringList allowedAudiences_;
+  /**
+   * <pre>
+   * Audiences accepted by the API. The token should contain at least one of them, if not empty.
+   * </pre>
+   *
+   * <code>repeated string allowedAudiences = 27;</code>
+   * @return A list containing the allowedAudiences.
+   */
+  public com.google.protobuf.ProtocolStringList
+      getAllowedAudiencesList() {
+    return allowedAudiences_;
+  }
+  /**
+   * <pre>
+   * Audiences accepted by the API. The token should contain at least one of them, if not empty.
+   * </pre>
+   *
+   * <code>repeated string allowedAudiences = 27;</code>
+   * @return The count of allowedAudiences.
+   */
+  public int getAllowedAudiencesCount() {
+    return allowedAudiences_.size();
+  }
+  /**
+   * <pre>
+   * Audiences accepted by the API. The token should contain at least one of them, if not empty.
+   * </pre>
+   *
+   * <code>repeated string allowedAudiences = 27;</code>
+   * @param index The index of the element to return.
+   * @return The allowedAudiences at the given index.
+   */
+  public java.lang.String getAllowedAudiences(int index) {
+    return allowedAudiences_.get(index);
+  }
+  /**
+   * <pre>
+   * Audiences accepted by the API. The token should contain at least one of them, if not empty.
+   * </pre>
+   *
+   * <code>repeated string allowedAudiences = 27;</code>
+   * @param index The index of the value to return.
+   * @return The bytes of the allowedAudiences at the given index.
+   */
+  public com.google.protobuf.ByteString
+      getAllowedAudiencesBytes(int index) {
+    return allowedAudiences_.getByteString(index);
+  }
+
+  public static final int MAXCONCURRENTREQUESTSPERAPPLICATION_FIELD_NUMBER = 28;
+  private int maxConcurrentRequestsPerApplication_;
+  /**
+   * <pre>
+   * Maximum number of in-flight requests allowed for an application. Unlimited if 0.
+   * </pre>
+   *
+   * <code>uint32 maxConcurrentRequestsPerApplication = 28;</code>
+   * @return The maxConcurrentRequestsPerApplication.
+   */
+  @java.lang.Override
+  public int getMaxConcurrentRequestsPerApplication() {
+    return maxConcurrentRequestsPerApplication_;
+  }
+
+  public static final int BACKENDJWTENABLED_FIELD_NUMBER = 29;
+  private boolean backendJwtEnabled_;
+  /**
+   * <pre>
+   * Whether a JWT describing the consumer is sent to the backend.
+   * </pre>
+   *
+   * <code>bool backendJwtEnabled = 29;</code>
+   * @return The backendJwtEnabled.
+   */
+  @java.lang.Override
+  public boolean getBackendJwtEnabled() {
+    return backendJwtEnabled_;
+  }
+
+  public static final int BACKENDJWTHEADER_FIELD_NUMBER = 30;
+  private volatile java.lang.Object backendJwtHeader_;
+  /**
+   * <pre>
+   * Header of the backend JWT.
+   * </pre>
+   *
+   * <code>string backendJwtHeader = 30;</code>
+   * @return The backendJwtHeader.
+   */
+  @java.lang.Override
+  public java.lang.String getBackendJwtHeader() {
+    java.lang.Object ref = backendJwtHeader_;
+    if (ref instanceof java.lang.String) {
+      return (java.lang.String) ref;
+    } else {
+      com.google.protobuf.ByteString bs = 
+          (com.google.protobuf.ByteString) ref;
+      java.lang.String s = bs.toStringUtf8();
+      backendJwtHeader_ = s;
+      return s;
+    }
+  }
+  /**
+   * <pre>
+   * Header of the backend JWT.
+   * </pre>
+   *
+   * <code>string backendJwtHeader = 30;</code>
+   * @return The bytes for backendJwtHeader.
+   */
+  @java.lang.Override
+  public com.google.protobuf.ByteString
+      getBackendJwtHeaderBytes() {
+    java.lang.Object ref = backendJwtHeader_;
+    if (ref instanceof java.lang.String) {
+      com.google.protobuf.ByteString b = 
+          com.google.protobuf.ByteString.copyFromUtf8(
+              (java.lang.String) ref);
+      backendJwtHeader_ = b;
+      return b;
+    } else {
+      return (com.google.protobuf.ByteString) ref;
+    }
+  }
+
+  public static final int BACKENDJWTSIGNINGALGORITHM_FIELD_NUMBER = 31;
+  private volatile java.lang.Object backendJwtSigningAlgorithm_;
+  /**
+   * <pre>
+   * Algorithm used to sign the backend JWT.
+   * </pre>
+   *
+   * <code>string backendJwtSigningAlgorithm = 31;</code>
+   * @return The backendJwtSigningAlgorithm.
+   */
+  @java.lang.Override
+  public java.lang.String getBackendJwtSigningAlgorithm() {
+    java.lang.Object ref = backendJwtSigningAlgorithm_;
+    if (ref instanceof java.lang.String) {
+      return (java.lang.String) ref;
+    } else {
+      com.google.protobuf.ByteString bs = 
+          (com.google.protobuf.ByteString) ref;
+      java.lang.String s = bs.toStringUtf8();
+      backendJwtSigningAlgorithm_ = s;
+      return s;
+    }
+  }
+  /**
+   * <pre>
+   * Algorithm used to sign the backend JWT.
+   * </pre>
+   *
+   * <code>string backendJwtSigningAlgorithm = 31;</code>
+   * @return The bytes for backendJwtSigningAlgorithm.
+   */
+  @java.lang.Override
+  public com.google.protobuf.ByteString
+      getBackendJwtSigningAlgorithmBytes() {
+    java.lang.Object ref = backendJwtSigningAlgorithm_;
+    if (ref instanceof java.lang.String) {
+      com.google.protobuf.ByteString b = 
+          com.google.protobuf.ByteString.copyFromUtf8(
+              (java.lang.String) ref);
+      backendJwtSigningAlgorithm_ = b;
+      return b;
+    } else {
+      return (com.google.protobuf.ByteString) ref;
+    }
+  }
+
+  public static final int BACKENDJWTENABLEUSERCLAIMS_FIELD_NUMBER = 32;
+  private boolean backendJwtEnableUserClaims_;
+  /**
+   * <pre>
+   * Whether the claims of the user are included in the backend JWT.
+   * </pre>
+   *
+   * <code>bool backendJwtEnableUserClaims = 32;</code>
+   * @return The backendJwtEnableUserClaims.
+   */
+  @java.lang.Override
+  public boolean getBackendJwtEnableUserClaims() {
+    return backendJwtEnableUserClaims_;
+  }
+
+  public static final int BACKENDJWTAPPLICATIONATTRIBUTES_FIELD_NUMBER = 33;
+  private com.google.protobuf.LazyStringList backendJwtApplicationAttributes_;
+  /**
+   * <pre>
+   * Application attributes included as claims in the backend JWT. All the attributes are included if empty.
+   * </pre>
+   *
+   * <code>repeated string backendJwtApplicationAttributes = 33;</code>
+   * @return A list containing the backendJwtApplicationAttributes.
+   */
+  public com.google.protobuf.ProtocolStringList
+      getBackendJwtApplicationAttributesList() {
+    return backendJwtApplicationAttributes_;
+  }
+  /**
+   * <pre>
+   * Application attributes included as claims in the backend JWT. All the attributes are included if empty.
+   * </pre>
+   *
+   * <code>repeated string backendJwtApplicationAttributes = 33;</code>
+   * @return The count of backendJwtApplicationAttributes.
+   */
+  public int getBackendJwtApplicationAttributesCount() {
+    return backendJwtApplicationAttributes_.size();
+  }
+  /**
+   * <pre>
+   * Application attributes included as claims in the backend JWT. All the attributes are included if empty.
+   * </pre>
+   *
+   * <code>repeated string backendJwtApplicationAttributes = 33;</code>
+   * @param index The index of the element to return.
+   * @return The backendJwtApplicationAttributes at the given index.
+   */
+  public java.lang.String getBackendJwtApplicationAttributes(int index) {
+    return backendJwtApplicationAttributes_.get(index);
+  }
+  /**
+   * <pre>
+   * Application attributes included as claims in the backend JWT. All the attributes are included if empty.
+   * </pre>
+   *
+   * <code>repeated string backendJwtApplicationAttributes = 33;</code>
+   * @param index The index of the value to return.
+   * @return The bytes of the backendJwtApplicationAttributes at the given index.
+   */
+  public com.google.protobuf.ByteString
+      getBackendJwtApplicationAttributesBytes(int index) {
+    return backendJwtApplicationAttributes_.getByteString(index);
+  }
+
   private byte memoizedIsInitialized = -1;
   @java.lang.Override
   public final boolean isInitialized() {
@@ -1234,6 +1592,30 @@ private static final long serialVersionUID = 0L;
     if (!getEndpointTypeBytes().isEmpty()) {
       com.google.protobuf.GeneratedMessageV3.writeString(output, 25, endpointType_);
     }
+    for (int i = 0; i < allowedIssuers_.size(); i++) {
+      com.google.protobuf.GeneratedMessageV3.writeString(output, 26, allowedIssuers_.getRaw(i));
+    }
+    for (int i = 0; i < allowedAudiences_.size(); i++) {
+      com.google.protobuf.GeneratedMessageV3.writeString(output, 27, allowedAudiences_.getRaw(i));
+    }
+    if (maxConcurrentRequestsPerApplication_ != 0) {
+      output.writeUInt32(28, maxConcurrentRequestsPerApplication_);
+    }
+    if (backendJwtEnabled_ != false) {
+      output.writeBool(29, backendJwtEnabled_);
+    }
+    if (!getBackendJwtHeaderBytes().isEmpty()) {
+      com.google.protobuf.GeneratedMessageV3.writeString(output, 30, backendJwtHeader_);
+    }
+    if (!getBackendJwtSigningAlgorithmBytes().isEmpty()) {
+      com.google.protobuf.GeneratedMessageV3.writeString(output, 31, backendJwtSigningAlgorithm_);
+    }
+    if (backendJwtEnableUserClaims_ != false) {
+      output.writeBool(32, backendJwtEnableUserClaims_);
+    }
+    for (int i = 0; i < backendJwtApplicationAttributes_.size(); i++) {
+      com.google.protobuf.GeneratedMessageV3.writeString(output, 33, backendJwtApplicationAttributes_.getRaw(i));
+    }
     unknownFields.writeTo(output);
   }
 
@@ -1329,6 +1711,48 @@ private static final long serialVersionUID = 0L;
     if (!getEndpointTypeBytes().isEmpty()) {
       size += com.google.protobuf.GeneratedMessageV3.computeStringSize(25, endpointType_);
     }
+    {
+      int dataSize = 0;
+      for (int i = 0; i < allowedIssuers_.size(); i++) {
+        dataSize += computeStringSizeNoTag(allowedIssuers_.getRaw(i));
+      }
+      size += dataSize;
+      size += 2 * getAllowedIssuersList().size();
+    }
+    {
+      int dataSize = 0;
+      for (int i = 0; i < allowedAudiences_.size(); i++) {
+        dataSize += computeStringSizeNoTag(allowedAudiences_.getRaw(i));
+      }
+      size += dataSize;
+      size += 2 * getAllowedAudiencesList().size();
+    }
+    if (maxConcurrentRequestsPerApplication_ != 0) {
+      size += com.google.protobuf.CodedOutputStream
+        .computeUInt32Size(28, maxConcurrentRequestsPerApplication_);
+    }
+    if (backendJwtEnabled_ != false) {
+      size += com.google.protobuf.CodedOutputStream
+        .computeBoolSize(29, backendJwtEnabled_);
+    }
+    if (!getBackendJwtHeaderBytes().isEmpty()) {
+      size += com.google.protobuf.GeneratedMessageV3.computeStringSize(30, backendJwtHeader_);
+    }
+    if (!getBackendJwtSigningAlgorithmBytes().isEmpty()) {
+      size += com.google.protobuf.GeneratedMessageV3.computeStringSize(31, backendJwtSigningAlgorithm_);
+    }
+    if (backendJwtEnableUserClaims_ != false) {
+      size += com.google.protobuf.CodedOutputStream
+        .computeBoolSize(32, backendJwtEnableUserClaims_);
+    }
+    {
+      int dataSize = 0;
+      for (int i = 0; i < backendJwtApplicationAttributes_.size(); i++) {
+        dataSize += computeStringSizeNoTag(backendJwtApplicationAttributes_.getRaw(i));
+      }
+      size += dataSize;
+      size += 2 * getBackendJwtApplicationAttributesList().size();
+    }
     size += unknownFields.getSerializedSize();
     memoizedSize = size;
     return size;
@@ -1403,6 +1827,22 @@ private static final long serialVersionUID = 0L;
         .equals(other.getGraphqlComplexityInfoList())) return false;
     if (!getEndpointType()
         .equals(other.getEndpointType())) return false;
+    if (!getAllowedIssuersList()
+        .equals(other.getAllowedIssuersList())) return false;
+    if (!getAllowedAudiencesList()
+        .equals(other.getAllowedAudiencesList())) return false;
+    if (getMaxConcurrentRequestsPerApplication()
+        != other.getMaxConcurrentRequestsPerApplication()) return false;
+    if (getBackendJwtEnabled()
+        != other.getBackendJwtEnabled()) return false;
+    if (!getBackendJwtHeader()
+        .equals(other.getBackendJwtHeader())) return false;
+    if (!getBackendJwtSigningAlgorithm()
+        .equals(other.getBackendJwtSigningAlgorithm())) return false;
+    if (getBackendJwtEnableUserClaims()
+        != other.getBackendJwtEnableUserClaims()) return false;
+    if (!getBackendJwtApplicationAttributesList()
+        .equals(other.getBackendJwtApplicationAttributesList())) return false;
     if (!unknownFields.equals(other.unknownFields)) return false;
     return true;
   }
@@ -1483,6 +1923,30 @@ private static final long serialVersionUID = 0L;
     }
     hash = (37 * hash) + ENDPOINTTYPE_FIELD_NUMBER;
     hash = (53 * hash) + getEndpointType().hashCode();
+    if (getAllowedIssuersCount() > 0) {
+      hash = (37 * hash) + ALLOWEDISSUERS_FIELD_NUMBER;
+      hash = (53 * hash) + getAllowedIssuersList().hashCode();
+    }
+    if (getAllowedAudiencesCount() > 0) {
+      hash = (37 * hash) + ALLOWEDAUDIENCES_FIELD_NUMBER;
+      hash = (53 * hash) + getAllowedAudiencesList().hashCode();
+    }
+    hash = (37 * hash) + MAXCONCURRENTREQUESTSPERAPPLICATION_FIELD_NUMBER;
+    hash = (53 * hash) + getMaxConcurrentRequestsPerApplication();
+    hash = (37 * hash) + BACKENDJWTENABLED_FIELD_NUMBER;
+    hash = (53 * hash) + com.google.protobuf.Internal.hashBoolean(
+        getBackendJwtEnabled());
+    hash = (37 * hash) + BACKENDJWTHEADER_FIELD_NUMBER;
+    hash = (53 * hash) + getBackendJwtHeader().hashCode();
+    hash = (37 * hash) + BACKENDJWTSIGNINGALGORITHM_FIELD_NUMBER;
+    hash = (53 * hash) + getBackendJwtSigningAlgorithm().hashCode();
+    hash = (37 * hash) + BACKENDJWTENABLEUSERCLAIMS_FIELD_NUMBER;
+    hash = (53 * hash) + com.google.protobuf.Internal.hashBoolean(
+        getBackendJwtEnableUserClaims());
+    if (getBackendJwtApplicationAttributesCount() > 0) {
+      hash = (37 * hash) + BACKENDJWTAPPLICATIONATTRIBUTES_FIELD_NUMBER;
+      hash = (53 * hash) + getBackendJwtApplicationAttributesList().hashCode();
+    }
     hash = (29 * hash) + unknownFields.hashCode();
     memoizedHashCode = hash;
     return hash;
@@ -1707,6 +2171,22 @@ private static final long serialVersionUID = 0L;
       }
       endpointType_ = "";
 
+      allowedIssuers_ = com.google.protobuf.LazyStringArrayList.EMPTY;
+      bitField0_ = (bitField0_ & ~0x00000020);
+      allowedAudiences_ = com.google.protobuf.LazyStringArrayList.EMPTY;
+      bitField0_ = (bitField0_ & ~0x00000040);
+      maxConcurrentRequestsPerApplication_ = 0;
+
+      backendJwtEnabled_ = false;
+
+      backendJwtHeader_ = "";
+
+      backendJwtSigningAlgorithm_ = "";
+
+      backendJwtEnableUserClaims_ = false;
+
+      backendJwtApplicationAttributes_ = com.google.protobuf.LazyStringArrayList.EMPTY;
+      bitField0_ = (bitField0_ & ~0x00000080);
       return this;
     }
 
@@ -1811,6 +2291,26 @@ private static final long serialVersionUID = 0L;
         result.graphqlComplexityInfo_ = graphqlComplexityInfoBuilder_.build();
       }
       result.endpointType_ = endpointType_;
+      if (((bitField0_ & 0x00000020) != 0)) {
+        allowedIssuers_ = allowedIssuers_.getUnmodifiableView();
+        bitField0_ = (bitField0_ & ~0x00000020);
+      }
+      result.allowedIssuers_ = allowedIssuers_;
+      if (((bitField0_ & 0x00000040) != 0)) {
+        allowedAudiences_ = allowedAudiences_.getUnmodifiableView();
+        bitField0_ = (bitField0_ & ~0x00000040);
+      }
+      result.allowedAudiences_ = allowedAudiences_;
+      result.maxConcurrentRequestsPerApplication_ = maxConcurrentRequestsPerApplication_;
+      result.backendJwtEnabled_ = backendJwtEnabled_;
+      result.backendJwtHeader_ = backendJwtHeader_;
+      result.backendJwtSigningAlgorithm_ = backendJwtSigningAlgorithm_;
+      result.backendJwtEnableUserClaims_ = backendJwtEnableUserClaims_;
+      if (((bitField0_ & 0x00000080) != 0)) {
+        backendJwtApplicationAttributes_ = backendJwtApplicationAttributes_.getUnmodifiableView();
+        bitField0_ = (bitField0_ & ~0x00000080);
+      }
+      result.backendJwtApplicationAttributes_ = backendJwtApplicationAttributes_;
       onBuilt();
       return result;
     }
@@ -2063,6 +2563,53 @@ private static final long serialVersionUID = 0L;
         endpointType_ = other.endpointType_;
         onChanged();
       }
+      if (!other.allowedIssuers_.isEmpty()) {
+        if (allowedIssuers_.isEmpty()) {
+          allowedIssuers_ = other.allowedIssuers_;
+          bitField0_ = (bitField0_ & ~0x00000020);
+        } else {
+          ensureAllowedIssuersIsMutable();
+          allowedIssuers_.addAll(other.allowedIssuers_);
+        }
+        onChanged();
+      }
+      if (!other.allowedAudiences_.isEmpty()) {
+        if (allowedAudiences_.isEmpty()) {
+          allowedAudiences_ = other.allowedAudiences_;
+          bitField0_ = (bitField0_ & ~0x00000040);
+        } else {
+          ensureAllowedAudiencesIsMutable();
+          allowedAudiences_.addAll(other.allowedAudiences_);
+        }
+        onChanged();
+      }
+      if (other.getMaxConcurrentRequestsPerApplication() != 0) {
+        setMaxConcurrentRequestsPerApplication(other.getMaxConcurrentRequestsPerApplication());
+      }
+      if (other.getBackendJwtEnabled() != false) {
+        setBackendJwtEnabled(other.getBackendJwtEnabled());
+      }
+      if (!other.getBackendJwtHeader().isEmpty()) {
+        backendJwtHeader_ = other.backendJwtHeader_;
+        onChanged();
+      }
+      if (!other.getBackendJwtSigningAlgorithm().isEmpty()) {
+        backendJwtSigningAlgorithm_ = other.backendJwtSigningAlgorithm_;
+        onChanged();
+      }
+      if (other.getBackendJwtEnableUserClaims() != false) {
+        setBackendJwtEnableUserClaims(other.getBackendJwtEnableUserClaims());
+      }
+      if (!other.backendJwtApplicationAttributes_.isEmpty()) {
+        if (backendJwtApplicationAttributes_.isEmpty()) {
+          backendJwtApplicationAttributes_ = other.backendJwtApplicationAttributes_;
+          bitField0_ = (bitField0_ & ~0x00000080);
+        } else {
+          ensureBackendJwtApplicationAttributesIsMutable();
+          backendJwtApplicationAttributes_.addAll(other.backendJwtApplicationAttributes_);
+        }
+        onChanged();
+      }
       this.mergeUnknownFields(other.unknownFields);
       onChanged();
       return this;
@@ -4806,6 +5353,765 @@ private static final long serialVersionUID = 0L;
       onChanged();
       return this;
     }
+
+    private com.google.protobuf.LazyStringList allowedIssuers_ = com.google.protobuf.LazyStringArrayList.EMPTY;
+    private void ensureAllowedIssuersIsMutable() {
+      if (!((bitField0_ & 0x00000020) != 0)) {
+        allowedIssuers_ = new com.google.protobuf.LazyStringArrayList(allowedIssuers_);
+        bitField0_ |= 0x00000020;
+       }
+    }
+    /**
+     * <pre>
+     * Token issuers accepted by the API. Any issuer configured in the enforcer is accepted if empty.
+     * </pre>
+     *
+     * <code>repeated string allowedIssuers = 26;</code>
+     * @return A list containing the allowedIssuers.
+     */
+    public com.google.protobuf.ProtocolStringList
+        getAllowedIssuersList() {
+      return allowedIssuers_.getUnmodifiableView();
+    }
+    /**
+     * <pre>
+     * Token issuers accepted by the API. Any issuer configured in the enforcer is accepted if empty.
+     * </pre>
+     *
+     * <code>repeated string allowedIssuers = 26;</code>
+     * @return The count of allowedIssuers.
+     */
+    public int getAllowedIssuersCount() {
+      return allowedIssuers_.size();
+    }
+    /**
+     * <pre>
+     * Token issuers accepted by the API. Any issuer configured in the enforcer is accepted if empty.
+     * </pre>
+     *
+     * <code>repeated string allowedIssuers = 26;</code>
+     * @param index The index of the element to return.
+     * @return The allowedIssuers at the given index.
+     */
+    public java.lang.String getAllowedIssuers(int index) {
+      return allowedIssuers_.get(index);
+    }
+    /**
+     * <pre>
+     * Token issuers accepted by the API. Any issuer configured in the enforcer is accepted if empty.
+     * </pre>
+     *
+     * <code>repeated string allowedIssuers = 26;</code>
+     * @param index The index of the value to return.
+     * @return The bytes of the allowedIssuers at the given index.
+     */
+    public com.google.protobuf.ByteString
+        getAllowedIssuersBytes(int index) {
+      return allowedIssuers_.getByteString(index);
+    }
+    /**
+     * <pre>
+     * Token issuers accepted by the API. Any issuer configured in the enforcer is accepted if empty.
+     * </pre>
+     *
+     * <code>repeated string allowedIssuers = 26;</code>
+     * @param index The index to set the value at.
+     * @param value The allowedIssuers to set.
+     * @return This builder for chaining.
+     */
+    public Builder setAllowedIssuers(
+        int index, java.lang.String value) {
+      if (value == null) {
+    throw new NullPointerException();
+  }
+  ensureAllowedIssuersIsMutable();
+      allowedIssuers_.set(index, value);
+      onChanged();
+      return this;
+    }
+    /**
+     * <pre>
+     * Token issuers accepted by the API. Any issuer configured in the enforcer is accepted if empty.
+     * </pre>
+     *
+     * <code>repeated string allowedIssuers = 26;</code>
+     * @param value The allowedIssuers to add.
+     * @return This builder for chaining.
+     */
+    public Builder addAllowedIssuers(
+        java.lang.String value) {
+      if (value == null) {
+    throw new NullPointerException();
+  }
+  ensureAllowedIssuersIsMutable();
+      allowedIssuers_.add(value);
+      onChanged();
+      return this;
+    }
+    /**
+     * <pre>
+     * Token issuers accepted by the API. Any issuer configured in the enforcer is accepted if empty.
+     * </pre>
+     *
+     * <code>repeated string allowedIssuers = 26;</code>
+     * @param values The allowedIssuers to add.
+     * @return This builder for chaining.
+     */
+    public Builder addAllAllowedIssuers(
+        java.lang.Iterable<java.lang.String> values) {
+      ensureAllowedIssuersIsMutable();
+      com.google.protobuf.AbstractMessageLite.Builder.addAll(
+          values, allowedIssuers_);
+      onChanged();
+      return this;
+    }
+    /**
+     * <pre>
+     * Token issuers accepted by the API. Any issuer configured in the enforcer is accepted if empty.
+     * </pre>
+     *
+     * <code>repeated string allowedIssuers = 26;</code>
+     * @return This builder for chaining.
+     */
+    public Builder clearAllowedIssuers() {
+      allowedIssuers_ = com.google.protobuf.LazyStringArrayList.EMPTY;
+      bitField0_ = (bitField0_ & ~0x00000020);
+      onChanged();
+      return this;
+    }
+    /**
+     * <pre>
+     * Token issuers accepted by the API. Any issuer configured in the enforcer is accepted if empty.
+     * </pre>
+     *
+     * <code>repeated string allowedIssuers = 26;</code>
+     * @param value The bytes of the allowedIssuers to add.
+     * @return This builder for chaining.
+     */
+    public Builder addAllowedIssuersBytes(
+        com.google.protobuf.ByteString value) {
+      if (value == null) {
+    throw new NullPointerException();
+  }
+  checkByteStringIsUtf8(value);
+      ensureAllowedIssuersIsMutable();
+      allowedIssuers_.add(value);
+      onChanged();
+      return this;
+    }
+
+    private com.google.protobuf.LazyStringList allowedAudiences_ = com.google.protobuf.LazyStringArrayList.EMPTY;
+    private void ensureAllowedAudiencesIsMutable() {
+      if (!((bitField0_ & 0x00000040) != 0)) {
+        allowedAudiences_ = new com.google.protobuf.LazyStringArrayList(allowedAudiences_);
+        bitField0_ |= 0x00000040;
+       }
+    }
+    /**
+     * <pre>
+     * Audiences accepted by the API. The token should contain at least one of them, if not empty.
+     * </pre>
+     *
+     * <code>repeated string allowedAudiences = 27;</code>
+     * @return A list containing the allowedAudiences.
+     */
+    public com.google.protobuf.ProtocolStringList
+        getAllowedAudiencesList() {
+      return allowedAudiences_.getUnmodifiableView();
+    }
+    /**
+     * <pre>
+     * Audiences accepted by the API. The token should contain at least one of them, if not empty.
+     * </pre>
+     *
+     * <code>repeated string allowedAudiences = 27;</code>
+     * @return The count of allowedAudiences.
+     */
+    public int getAllowedAudiencesCount() {
+      return allowedAudiences_.size();
+    }
+    /**
+     * <pre>
+     * Audiences accepted by the API. The token should contain at least one of them, if not empty.
+     * </pre>
+     *
+     * <code>repeated string allowedAudiences = 27;</code>
+     * @param index The index of the element to return.
+     * @return The allowedAudiences at the given index.
+     */
+    public java.lang.String getAllowedAudiences(int index) {
+      return allowedAudiences_.get(index);
+    }
+    /**
+     * <pre>
+     * Audiences accepted by the API. The token should contain at least one of them, if not empty.
+     * </pre>
+     *
+     * <code>repeated string allowedAudiences = 27;</code>
+     * @param index The index of the value to return.
+     * @return The bytes of the allowedAudiences at the given index.
+     */
+    public com.google.protobuf.ByteString
+        getAllowedAudiencesBytes(int index) {
+      return allowedAudiences_.getByteString(index);
+    }
+    /**
+     * <pre>
+     * Audiences accepted by the API. The token should contain at least one of them, if not empty.
+     * </pre>
+     *
+     * <code>repeated string allowedAudiences = 27;</code>
+     * @param index The index to set the value at.
+     * @param value The allowedAudiences to set.
+     * @return This builder for chaining.
+     */
+    public Builder setAllowedAudiences(
+        int index, java.lang.String value) {
+      if (value == null) {
+    throw new NullPointerException();
+  }
+  ensureAllowedAudiencesIsMutable();
+      allowedAudiences_.set(index, value);
+      onChanged();
+      return this;
+    }
+    /**
+     * <pre>
+     * Audiences accepted by the API. The token should contain at least one of them, if not empty.
+     * </pre>
+     *
+     * <code>repeated string allowedAudiences = 27;</code>
+     * @param value The allowedAudiences to add.
+     * @return This builder for chaining.
+     */
+    public Builder addAllowedAudiences(
+        java.lang.String value) {
+      if (value == null) {
+    throw new NullPointerException();
+  }
+  ensureAllowedAudiencesIsMutable();
+      allowedAudiences_.add(value);
+      onChanged();
+      return this;
+    }
+    /**
+     * <pre>
+     * Audiences accepted by the API. The token should contain at least one of them, if not empty.
+     * </pre>
+     *
+     * <code>repeated string allowedAudiences = 27;</code>
+     * @param values The allowedAudiences to add.
+     * @return This builder for chaining.
+     */
+    public Builder addAllAllowedAudiences(
+        java.lang.Iterable<java.lang.String> values) {
+      ensureAllowedAudiencesIsMutable();
+      com.google.protobuf.AbstractMessageLite.Builder.addAll(
+          values, allowedAudiences_);
+      onChanged();
+      return this;
+    }
+    /**
+     * <pre>
+     * Audiences accepted by the API. The token should contain at least one of them, if not empty.
+     * </pre>
+     *
+     * <code>repeated string allowedAudiences = 27;</code>
+     * @return This builder for chaining.
+     */
+    public Builder clearAllowedAudiences() {
+      allowedAudiences_ = com.google.protobuf.LazyStringArrayList.EMPTY;
+      bitField0_ = (bitField0_ & ~0x00000040);
+      onChanged();
+      return this;
+    }
+    /**
+     * <pre>
+     * Audiences accepted by the API. The token should contain at least one of them, if not empty.
+     * </pre>
+     *
+     * <code>repeated string allowedAudiences = 27;</code>
+     * @param value The bytes of the allowedAudiences to add.
+     * @return This builder for chaining.
+     */
+    public Builder addAllowedAudiencesBytes(
+        com.google.protobuf.ByteString value) {
+      if (value == null) {
+    throw new NullPointerException();
+  }
+  checkByteStringIsUtf8(value);
+      ensureAllowedAudiencesIsMutable();
+      allowedAudiences_.add(value);
+      onChanged();
+      return this;
+    }
+
+    private int maxConcurrentRequestsPerApplication_ ;
+    /**
+     * <pre>
+     * Maximum number of in-flight requests allowed for an application. Unlimited if 0.
+     * </pre>
+     *
+     * <code>uint32 maxConcurrentRequestsPerApplication = 28;</code>
+     * @return The maxConcurrentRequestsPerApplication.
+     */
+    @java.lang.Override
+    public int getMaxConcurrentRequestsPerApplication() {
+      return maxConcurrentRequestsPerApplication_;
+    }
+    /**
+     * <pre>
+     * Maximum number of in-flight requests allowed for an application. Unlimited if 0.
+     * </pre>
+     *
+     * <code>uint32 maxConcurrentRequestsPerApplication = 28;</code>
+     * @param value The maxConcurrentRequestsPerApplication to set.
+     * @return This builder for chaining.
+     */
+    public Builder setMaxConcurrentRequestsPerApplication(int value) {
+      
+      maxConcurrentRequestsPerApplication_ = value;
+      onChanged();
+      return this;
+    }
+    /**
+     * <pre>
+     * Maximum number of in-flight requests allowed for an application. Unlimited if 0.
+     * </pre>
+     *
+     * <code>uint32 maxConcurrentRequestsPerApplication = 28;</code>
+     * @return This builder for chaining.
+     */
+    public Builder clearMaxConcurrentRequestsPerApplication() {
+      
+      maxConcurrentRequestsPerApplication_ = 0;
+      onChanged();
+      return this;
+    }
+
+    private boolean backendJwtEnabled_ ;
+    /**
+     * <pre>
+     * Whether a JWT describing the consumer is sent to the backend.
+     * </pre>
+     *
+     * <code>bool backendJwtEnabled = 29;</code>
+     * @return The backendJwtEnabled.
+     */
+    @java.lang.Override
+    public boolean getBackendJwtEnabled() {
+      return backendJwtEnabled_;
+    }
+    /**
+     * <pre>
+     * Whether a JWT describing the consumer is sent to the backend.
+     * </pre>
+     *
+     * <code>bool backendJwtEnabled = 29;</code>
+     * @param value The backendJwtEnabled to set.
+     * @return This builder for chaining.
+     */
+    public Builder setBackendJwtEnabled(boolean value) {
+      
+      backendJwtEnabled_ = value;
+      onChanged();
+      return this;
+    }
+    /**
+     * <pre>
+     * Whether a JWT describing the consumer is sent to the backend.
+     * </pre>
+     *
+     * <code>bool backendJwtEnabled = 29;</code>
+     * @return This builder for chaining.
+     */
+    public Builder clearBackendJwtEnabled() {
+      
+      backendJwtEnabled_ = false;
+      onChanged();
+      return this;
+    }
+
+    private java.lang.Object backendJwtHeader_ = "";
+    /**
+     * <pre>
+     * Header of the backend JWT.
+     * </pre>
+     *
+     * <code>string backendJwtHeader = 30;</code>
+     * @return The backendJwtHeader.
+     */
+    public java.lang.String getBackendJwtHeader() {
+      java.lang.Object ref = backendJwtHeader_;
+      if (!(ref instanceof java.lang.String)) {
+        com.google.protobuf.ByteString bs =
+            (com.google.protobuf.ByteString) ref;
+        java.lang.String s = bs.toStringUtf8();
+        backendJwtHeader_ = s;
+        return s;
+      } else {
+        return (java.lang.String) ref;
+      }
+    }
+    /**
+     * <pre>
+     * Header of the backend JWT.
+     * </pre>
+     *
+     * <code>string backendJwtHeader = 30;</code>
+     * @return The bytes for backendJwtHeader.
+     */
+    public com.google.protobuf.ByteString
+        getBackendJwtHeaderBytes() {
+      java.lang.Object ref = backendJwtHeader_;
+      if (ref instanceof String) {
+        com.google.protobuf.ByteString b = 
+            com.google.protobuf.ByteString.copyFromUtf8(
+                (java.lang.String) ref);
+        backendJwtHeader_ = b;
+        return b;
+      } else {
+        return (com.google.protobuf.ByteString) ref;
+      }
+    }
+    /**
+     * <pre>
+     * Header of the backend JWT.
+     * </pre>
+     *
+     * <code>string backendJwtHeader = 30;</code>
+     * @param value The backendJwtHeader to set.
+     * @return This builder for chaining.
+     */
+    public Builder setBackendJwtHeader(
+        java.lang.String value) {
+      if (value == null) {
+    throw new NullPointerException();
+  }
+  
+      backendJwtHeader_ = value;
+      onChanged();
+      return this;
+    }
+    /**
+     * <pre>
+     * Header of the backend JWT.
+     * </pre>
+     *
+     * <code>string backendJwtHeader = 30;</code>
+     * @return This builder for chaining.
+     */
+    public Builder clearBackendJwtHeader() {
+      
+      backendJwtHeader_ = getDefaultInstance().getBackendJwtHeader();
+      onChanged();
+      return this;
+    }
+    /**
+     * <pre>
+     * Header of the backend JWT.
+     * </pre>
+     *
+     * <code>string backendJwtHeader = 30;</code>
+     * @param value The bytes for backendJwtHeader to set.
+     * @return This builder for chaining.
+     */
+    public Builder setBackendJwtHeaderBytes(
+        com.google.protobuf.ByteString value) {
+      if (value == null) {
+    throw new NullPointerException();
+  }
+  checkByteStringIsUtf8(value);
+      
+      backendJwtHeader_ = value;
+      onChanged();
+      return this;
+    }
+
+    private java.lang.Object backendJwtSigningAlgorithm_ = "";
+    /**
+     * <pre>
+     * Algorithm used to sign the backend JWT.
+     * </pre>
+     *
+     * <code>string backendJwtSigningAlgorithm = 31;</code>
+     * @return The backendJwtSigningAlgorithm.
+     */
+    public java.lang.String getBackendJwtSigningAlgorithm() {
+      java.lang.Object ref = backendJwtSigningAlgorithm_;
+      if (!(ref instanceof java.lang.String)) {
+        com.google.protobuf.ByteString bs =
+            (com.google.protobuf.ByteString) ref;
+        java.lang.String s = bs.toStringUtf8();
+        backendJwtSigningAlgorithm_ = s;
+        return s;
+      } else {
+        return (java.lang.String) ref;
+      }
+    }
+    /**
+     * <pre>
+     * Algorithm used to sign the backend JWT.
+     * </pre>
+     *
+     * <code>string backendJwtSigningAlgorithm = 31;</code>
+     * @return The bytes for backendJwtSigningAlgorithm.
+     */
+    public com.google.protobuf.ByteString
+        getBackendJwtSigningAlgorithmBytes() {
+      java.lang.Object ref = backendJwtSigningAlgorithm_;
+      if (ref instanceof String) {
+        com.google.protobuf.ByteString b = 
+            com.google.protobuf.ByteString.copyFromUtf8(
+                (java.lang.String) ref);
+        backendJwtSigningAlgorithm_ = b;
+        return b;
+      } else {
+        return (com.google.protobuf.ByteString) ref;
+      }
+    }
+    /**
+     * <pre>
+     * Algorithm used to sign the backend JWT.
+     * </pre>
+     *
+     * <code>string backendJwtSigningAlgorithm = 31;</code>
+     * @param value The backendJwtSigningAlgorithm to set.
+     * @return This builder for chaining.
+     */
+    public Builder setBackendJwtSigningAlgorithm(
+        java.lang.String value) {
+      if (value == null) {
+    throw new NullPointerException();
+  }
+  
+      backendJwtSigningAlgorithm_ = value;
+      onChanged();
+      return this;
+    }
+    /**
+     * <pre>
+     * Algorithm used to sign the backend JWT.
+     * </pre>
+     *
+     * <code>string backendJwtSigningAlgorithm = 31;</code>
+     * @return This builder for chaining.
+     */
+    public Builder clearBackendJwtSigningAlgorithm() {
+      
+      backendJwtSigningAlgorithm_ = getDefaultInstance().getBackendJwtSigningAlgorithm();
+      onChanged();
+      return this;
+    }
+    /**
+     * <pre>
+     * Algorithm used to sign the backend JWT.
+     * </pre>
+     *
+     * <code>string backendJwtSigningAlgorithm = 31;</code>
+     * @param value The bytes for backendJwtSigningAlgorithm to set.
+     * @return This builder for chaining.
+     */
+    public Builder setBackendJwtSigningAlgorithmBytes(
+        com.google.protobuf.ByteString value) {
+      if (value == null) {
+    throw new NullPointerException();
+  }
+  checkByteStringIsUtf8(value);
+      
+      backendJwtSigningAlgorithm_ = value;
+      onChanged();
+      return this;
+    }
+
+    private boolean backendJwtEnableUserClaims_ ;
+    /**
+     * <pre>
+     * Whether the claims of the user are included in the backend JWT.
+     * </pre>
+     *
+     * <code>bool backendJwtEnableUserClaims = 32;</code>
+     * @return The backendJwtEnableUserClaims.
+     */
+    @java.lang.Override
+    public boolean getBackendJwtEnableUserClaims() {
+      return backendJwtEnableUserClaims_;
+    }
+    /**
+     * <pre>
+     * Whether the claims of the user are included in the backend JWT.
+     * </pre>
+     *
+     * <code>bool backendJwtEnableUserClaims = 32;</code>
+     * @param value The backendJwtEnableUserClaims to set.
+     * @return This builder for chaining.
+     */
+    public Builder setBackendJwtEnableUserClaims(boolean value) {
+      
+      backendJwtEnableUserClaims_ = value;
+      onChanged();
+      return this;
+    }
+    /**
+     * <pre>
+     * Whether the claims of the user are included in the backend JWT.
+     * </pre>
+     *
+     * <code>bool backendJwtEnableUserClaims = 32;</code>
+     * @return This builder for chaining.
+     */
+    public Builder clearBackendJwtEnableUserClaims() {
+      
+      backendJwtEnableUserClaims_ = false;
+      onChanged();
+      return this;
+    }
+
+    private com.google.protobuf.LazyStringList backendJwtApplicationAttributes_ = com.google.protobuf.LazyStringArrayList.EMPTY;
+    private void ensureBackendJwtApplicationAttributesIsMutable() {
+      if (!((bitField0_ & 0x00000080) != 0)) {
+        backendJwtApplicationAttributes_ = new com.google.protobuf.LazyStringArrayList(backendJwtApplicationAttributes_);
+        bitField0_ |= 0x00000080;
+       }
+    }
+    /**
+     * <pre>
+     * Application attributes included as claims in the backend JWT. All the attributes are included if empty.
+     * </pre>
+     *
+     * <code>repeated string backendJwtApplicationAttributes = 33;</code>
+     * @return A list containing the backendJwtApplicationAttributes.
+     */
+    public com.google.protobuf.ProtocolStringList
+        getBackendJwtApplicationAttributesList() {
+      return backendJwtApplicationAttributes_.getUnmodifiableView();
+    }
+    /**
+     * <pre>
+     * Application attributes included as claims in the backend JWT. All the attributes are included if empty.
+     * </pre>
+     *
+     * <code>repeated string backendJwtApplicationAttributes = 33;</code>
+     * @return The count of backendJwtApplicationAttributes.
+     */
+    public int getBackendJwtApplicationAttributesCount() {
+      return backendJwtApplicationAttributes_.size();
+    }
+    /**
+     * <pre>
+     * Application attributes included as claims in the backend JWT. All the attributes are included if empty.
+     * </pre>
+     *
+     * <code>repeated string backendJwtApplicationAttributes = 33;</code>
+     * @param index The index of the element to return.
+     * @return The backendJwtApplicationAttributes at the given index.
+     */
+    public java.lang.String getBackendJwtApplicationAttributes(int index) {
+      return backendJwtApplicationAttributes_.get(index);
+    }
+    /**
+     * <pre>
+     * Application attributes included as claims in the backend JWT. All the attributes are included if empty.
+     * </pre>
+     *
+     * <code>repeated string backendJwtApplicationAttributes = 33;</code>
+     * @param index The index of the value to return.
+     * @return The bytes of the backendJwtApplicationAttributes at the given index.
+     */
+    public com.google.protobuf.ByteString
+        getBackendJwtApplicationAttributesBytes(int index) {
+      return backendJwtApplicationAttributes_.getByteString(index);
+    }
+    /**
+     * <pre>
+     * Application attributes included as claims in the backend JWT. All the attributes are included if empty.
+     * </pre>
+     *
+     * <code>repeated string backendJwtApplicationAttributes = 33;</code>
+     * @param index The index to set the value at.
+     * @param value The backendJwtApplicationAttributes to set.
+     * @return This builder for chaining.
+     */
+    public Builder setBackendJwtApplicationAttributes(
+        int index, java.lang.String value) {
+      if (value == null) {
+    throw new NullPointerException();
+  }
+  ensureBackendJwtApplicationAttributesIsMutable();
+      backendJwtApplicationAttributes_.set(index, value);
+      onChanged();
+      return this;
+    }
+    /**
+     * <pre>
+     * Application attributes included as claims in the backend JWT. All the attributes are included if empty.
+     * </pre>
+     *
+     * <code>repeated string backendJwtApplicationAttributes = 33;</code>
+     * @param value The backendJwtApplicationAttributes to add.
+     * @return This builder for chaining.
+     */
+    public Builder addBackendJwtApplicationAttributes(
+        java.lang.String value) {
+      if (value == null) {
+    throw new NullPointerException();
+  }
+  ensureBackendJwtApplicationAttributesIsMutable();
+      backendJwtApplicationAttributes_.add(value);
+      onChanged();
+      return this;
+    }
+    /**
+     * <pre>
+     * Application attributes included as claims in the backend JWT. All the attributes are included if empty.
+     * </pre>
+     *
+     * <code>repeated string backendJwtApplicationAttributes = 33;</code>
+     * @param values The backendJwtApplicationAttributes to add.
+     * @return This builder for chaining.
+     */
+    public Builder addAllBackendJwtApplicationAttributes(
+        java.lang.Iterable<java.lang.String> values) {
+      ensureBackendJwtApplicationAttributesIsMutable();
+      com.google.protobuf.AbstractMessageLite.Builder.addAll(
+          values, backendJwtApplicationAttributes_);
+      onChanged();
+      return this;
+    }
+    /**
+     * <pre>
+     * Application attributes included as claims in the backend JWT. All the attributes are included if empty.
+     * </pre>
+     *
+     * <code>repeated string backendJwtApplicationAttributes = 33;</code>
+     * @return This builder for chaining.
+     */
+    public Builder clearBackendJwtApplicationAttributes() {
+      backendJwtApplicationAttributes_ = com.google.protobuf.LazyStringArrayList.EMPTY;
+      bitField0_ = (bitField0_ & ~0x00000080);
+      onChanged();
+      return this;
+    }
+    /**
+     * <pre>
+     * Application attributes included as claims in the backend JWT. All the attributes are included if empty.
+     * </pre>
+     *
+     * <code>repeated string backendJwtApplicationAttributes = 33;</code>
+     * @param value The bytes of the backendJwtApplicationAttributes to add.
+     * @return This builder for chaining.
+     */
+    public Builder addBackendJwtApplicationAttributesBytes(
+        com.google.protobuf.ByteString value) {
+      if (value == null) {
+    throw new NullPointerException();
+  }
+  checkByteStringIsUtf8(value);
+      ensureBackendJwtApplicationAttributesIsMutable();
+      backendJwtApplicationAttributes_.add(value);
+      onChanged();
+      return this;
+    }
     @java.lang.Override
     public final Builder setUnknownFields(
         final com.google.protobuf.UnknownFieldSet unknownFields) {
@@ -357,4 +357,205 @@ public interface ApiOrBuilder extends
    */
   com.google.protobuf.ByteString
       getEndpointTypeBytes();
+
+  /**
+   * <pre>
+   * Token issuers accepted by the API. Any issuer configured in the enforcer is accepted if empty.
+   * </pre>
+   *
+   * <code>repeated string allowedIssuers = 26;</code>
+   * @return A list containing the allowedIssuers.
+   */
+  java.util.List<java.lang.String>
+      getAllowedIssuersList();
+  /**
+   * <pre>
+   * Token issuers accepted by the API. Any issuer configured in the enforcer is accepted if empty.
+   * </pre>
+   *
+   * <code>repeated string allowedIssuers = 26;</code>
+   * @return The count of allowedIssuers.
+   */
+  int getAllowedIssuersCount();
+  /**
+   * <pre>
+   * Token issuers accepted by the API. Any issuer configured in the enforcer is accepted if empty.
+   * </pre>
+   *
+   * <code>repeated string allowedIssuers = 26;</code>
+   * @param index The index of the element to return.
+   * @return The allowedIssuers at the given index.
+   */
+  java.lang.String getAllowedIssuers(int index);
+  /**
+   * <pre>
+   * Token issuers accepted by the API. Any issuer configured in the enforcer is accepted if empty.
+   * </pre>
+   *
+   * <code>repeated string allowedIssuers = 26;</code>
+   * @param index The index of the value to return.
+   * @return The bytes of the allowedIssuers at the given index.
+   */
+  com.google.protobuf.ByteString
+      getAllowedIssuersBytes(int index);
+
+  /**
+   * <pre>
+   * Audiences accepted by the API. The token should contain at least one of them, if not empty.
+   * </pre>
+   *
+   * <code>repeated string allowedAudiences = 27;</code>
+   * @return A list containing the allowedAudiences.
+   */
+  java.util.List<java.lang.String>
+      getAllowedAudiencesList();
+  /**
+   * <pre>
+   * Audiences accepted by the API. The token should contain at least one of them, if not empty.
+   * </pre>
+   *
+   * <code>repeated string allowedAudiences = 27;</code>
+   * @return The count of allowedAudiences.
+   */
+  int getAllowedAudiencesCount();
+  /**
+   * <pre>
+   * Audiences accepted by the API. The token should contain at least one of them, if not empty.
+   * </pre>
+   *
+   * <code>repeated string allowedAudiences = 27;</code>
+   * @param index The index of the element to return.
+   * @return The allowedAudiences at the given index.
+   */
+  java.lang.String getAllowedAudiences(int index);
+  /**
+   * <pre>
+   * Audiences accepted by the API. The token should contain at least one of them, if not empty.
+   * </pre>
+   *
+   * <code>repeated string allowedAudiences = 27;</code>
+   * @param index The index of the value to return.
+   * @return The bytes of the allowedAudiences at the given index.
+   */
+  com.google.protobuf.ByteString
+      getAllowedAudiencesBytes(int index);
+
+  /**
+   * <pre>
+   * Maximum number of in-flight requests allowed for an application. Unlimited if 0.
+   * </pre>
+   *
+   * <code>uint32 maxConcurrentRequestsPerApplication = 28;</code>
+   * @return The maxConcurrentRequestsPerApplication.
+   */
+  int getMaxConcurrentRequestsPerApplication();
+
+  /**
+   * <pre>
+   * Whether a JWT describing the consumer is sent to the backend.
+   * </pre>
+   *
+   * <code>bool backendJwtEnabled = 29;</code>
+   * @return The backendJwtEnabled.
+   */
+  boolean getBackendJwtEnabled();
+
+  /**
+
+   * <pre>
+
+   * Header of the backend JWT.
+
+   * </pre>
+
+   *
+   * <code>string backendJwtHeader = 30;</code>
+   * @return The backendJwtHeader.
+   */
+  java.lang.String getBackendJwtHeader();
+  /**
+   * <pre>
+   * Header of the backend JWT.
+   * </pre>
+   *
+   * <code>string backendJwtHeader = 30;</code>
+   * @return The bytes for backendJwtHeader.
+   */
+  com.google.protobuf.ByteString
+      getBackendJwtHeaderBytes();
+
+  /**
+
+   * <pre>
+
+   * Algorithm used to sign the backend JWT.
+
+   * </pre>
+
+   *
+   * <code>string backendJwtSigningAlgorithm = 31;</code>
+   * @return The backendJwtSigningAlgorithm.
+   */
+  java.lang.String getBackendJwtSigningAlgorithm();
+  /**
+   * <pre>
+   * Algorithm used to sign the backend JWT.
+   * </pre>
+   *
+   * <code>string backendJwtSigningAlgorithm = 31;</code>
+   * @return The bytes for backendJwtSigningAlgorithm.
+   */
+  com.google.protobuf.ByteString
+      getBackendJwtSigningAlgorithmBytes();
+
+  /**
+   * <pre>
+   * Whether the claims of the user are included in the backend JWT.
+   * </pre>
+   *
+   * <code>bool backendJwtEnableUserClaims = 32;</code>
+   * @return The backendJwtEnableUserClaims.
+   */
+  boolean getBackendJwtEnableUserClaims();
+
+  /**
+   * <pre>
+   * Application attributes included as claims in the backend JWT. All the attributes are included if empty.
+   * </pre>
+   *
+   * <code>repeated string backendJwtApplicationAttributes = 33;</code>
+   * @return A list containing the backendJwtApplicationAttributes.
+   */
+  java.util.List<java.lang.String>
+      getBackendJwtApplicationAttributesList();
+  /**
+   * <pre>
+   * Application attributes included as claims in the backend JWT. All the attributes are included if empty.
+   * </pre>
+   *
+   * <code>repeated string backendJwtApplicationAttributes = 33;</code>
+   * @return The count of backendJwtApplicationAttributes.
+   */
+  int getBackendJwtApplicationAttributesCount();
+  /**
+   * <pre>
+   * Application attributes included as claims in the backend JWT. All the attributes are included if empty.
+   * </pre>
+   *
+   * <code>repeated string backendJwtApplicationAttributes = 33;</code>
+   * @param index The index of the element to return.
+   * @return The backendJwtApplicationAttributes at the given index.
+   */
+  java.lang.String getBackendJwtApplicationAttributes(int index);
+  /**
+   * <pre>
+   * Application attributes included as claims in the backend JWT. All the attributes are included if empty.
+   * </pre>
+   *
+   * <code>repeated string backendJwtApplicationAttributes = 33;</code>
+   * @param index The index of the value to return.
+   * @return The bytes of the backendJwtApplicationAttributes at the given index.
+   */
+  com.google.protobuf.ByteString
+      getBackendJwtApplicationAttributesBytes(int index);
 }
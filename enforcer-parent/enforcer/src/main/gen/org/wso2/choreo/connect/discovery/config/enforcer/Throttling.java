@@ -99,6 +99,11 @@ private static final long serialVersionUID = 0L;
 
             break;
           }
+          case 64: {
+
+            enableConcurrencyLimits_ = input.readBool();
+            break;
+          }
           default: {
             if (!parseUnknownField(
                 input, unknownFields, extensionRegistry, tag)) {
@@ -277,6 +282,17 @@ private static final long serialVersionUID = 0L;
     return getPublisher();
   }
 
+  public static final int ENABLE_CONCURRENCY_LIMITS_FIELD_NUMBER = 8;
+  private boolean enableConcurrencyLimits_;
+  /**
+   * <code>bool enable_concurrency_limits = 8;</code>
+   * @return The enableConcurrencyLimits.
+   */
+  @java.lang.Override
+  public boolean getEnableConcurrencyLimits() {
+    return enableConcurrencyLimits_;
+  }
+
   private byte memoizedIsInitialized = -1;
   @java.lang.Override
   public final boolean isInitialized() {
@@ -312,6 +328,9 @@ private static final long serialVersionUID = 0L;
     if (publisher_ != null) {
       output.writeMessage(7, getPublisher());
     }
+    if (enableConcurrencyLimits_ != false) {
+      output.writeBool(8, enableConcurrencyLimits_);
+    }
     unknownFields.writeTo(output);
   }
 
@@ -347,6 +366,10 @@ private static final long serialVersionUID = 0L;
       size += com.google.protobuf.CodedOutputStream
         .computeMessageSize(7, getPublisher());
     }
+    if (enableConcurrencyLimits_ != false) {
+      size += com.google.protobuf.CodedOutputStream
+        .computeBoolSize(8, enableConcurrencyLimits_);
+    }
     size += unknownFields.getSerializedSize();
     memoizedSize = size;
     return size;
@@ -379,6 +402,8 @@ private static final long serialVersionUID = 0L;
       if (!getPublisher()
           .equals(other.getPublisher())) return false;
     }
+    if (getEnableConcurrencyLimits()
+        != other.getEnableConcurrencyLimits()) return false;
     if (!unknownFields.equals(other.unknownFields)) return false;
     return true;
   }
@@ -410,6 +435,9 @@ private static final long serialVersionUID = 0L;
       hash = (37 * hash) + PUBLISHER_FIELD_NUMBER;
       hash = (53 * hash) + getPublisher().hashCode();
     }
+    hash = (37 * hash) + ENABLE_CONCURRENCY_LIMITS_FIELD_NUMBER;
+    hash = (53 * hash) + com.google.protobuf.Internal.hashBoolean(
+        getEnableConcurrencyLimits());
     hash = (29 * hash) + unknownFields.hashCode();
     memoizedHashCode = hash;
     return hash;
@@ -565,6 +593,8 @@ private static final long serialVersionUID = 0L;
         publisher_ = null;
         publisherBuilder_ = null;
       }
+      enableConcurrencyLimits_ = false;
+
       return this;
     }
 
@@ -602,6 +632,7 @@ private static final long serialVersionUID = 0L;
       } else {
         result.publisher_ = publisherBuilder_.build();
       }
+      result.enableConcurrencyLimits_ = enableConcurrencyLimits_;
       onBuilt();
       return result;
     }
@@ -673,6 +704,9 @@ private static final long serialVersionUID = 0L;
       if (other.hasPublisher()) {
         mergePublisher(other.getPublisher());
       }
+      if (other.getEnableConcurrencyLimits() != false) {
+        setEnableConcurrencyLimits(other.getEnableConcurrencyLimits());
+      }
       this.mergeUnknownFields(other.unknownFields);
       onChanged();
       return this;
@@ -1096,6 +1130,37 @@ private static final long serialVersionUID = 0L;
       }
       return publisherBuilder_;
     }
+
+    private boolean enableConcurrencyLimits_ ;
+    /**
+     * <code>bool enable_concurrency_limits = 8;</code>
+     * @return The enableConcurrencyLimits.
+     */
+    @java.lang.Override
+    public boolean getEnableConcurrencyLimits() {
+      return enableConcurrencyLimits_;
+    }
+    /**
+     * <code>bool enable_concurrency_limits = 8;</code>
+     * @param value The enableConcurrencyLimits to set.
+     * @return This builder for chaining.
+     */
+    public Builder setEnableConcurrencyLimits(boolean value) {
+      
+      enableConcurrencyLimits_ = value;
+      onChanged();
+      return this;
+    }
+    /**
+     * <code>bool enable_concurrency_limits = 8;</code>
+     * @return This builder for chaining.
+     */
+    public Builder clearEnableConcurrencyLimits() {
+      
+      enableConcurrencyLimits_ = false;
+      onChanged();
+      return this;
+    }
     @java.lang.Override
     public final Builder setUnknownFields(
         final com.google.protobuf.UnknownFieldSet unknownFields) {
@@ -69,4 +69,10 @@ public interface ThrottlingOrBuilder extends
    * <code>.wso2.discovery.config.enforcer.BinaryPublisher publisher = 7;</code>
    */
   org.wso2.choreo.connect.discovery.config.enforcer.BinaryPublisherOrBuilder getPublisherOrBuilder();
+
+  /**
+   * <code>bool enable_concurrency_limits = 8;</code>
+   * @return The enableConcurrencyLimits.
+   */
+  boolean getEnableConcurrencyLimits();
 }
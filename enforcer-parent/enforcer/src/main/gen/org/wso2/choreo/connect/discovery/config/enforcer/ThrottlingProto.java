@@ -31,7 +31,7 @@ public final class ThrottlingProto {
       "\n/wso2/discovery/config/enforcer/throttl" +
       "ing.proto\022\036wso2.discovery.config.enforce" +
       "r\0325wso2/discovery/config/enforcer/binary" +
-      "_publisher.proto\"\273\002\n\nThrottling\022&\n\036enabl" +
+      "_publisher.proto\"\336\002\n\nThrottling\022&\n\036enabl" +
       "e_global_event_publishing\030\001 \001(\010\022 \n\030enabl" +
       "e_header_conditions\030\002 \001(\010\022%\n\035enable_quer" +
       "y_param_conditions\030\003 \001(\010\022#\n\033enable_jwt_c" +
@@ -39,11 +39,12 @@ public final class ThrottlingProto {
       "initial_context_factory\030\005 \001(\t\022#\n\033jms_con" +
       "nection_provider_url\030\006 \001(\t\022B\n\tpublisher\030" +
       "\007 \001(\0132/.wso2.discovery.config.enforcer.B" +
-      "inaryPublisherB\226\001\n1org.wso2.choreo.conne" +
-      "ct.discovery.config.enforcerB\017Throttling" +
-      "ProtoP\001ZNgithub.com/envoyproxy/go-contro" +
-      "l-plane/wso2/discovery/config/enforcer;e" +
-      "nforcerb\006proto3"
+      "inaryPublisher\022!\n\031enable_concurrency_lim" +
+      "its\030\010 \001(\010B\226\001\n1org.wso2.choreo.connect.di" +
+      "scovery.config.enforcerB\017ThrottlingProto" +
+      "P\001ZNgithub.com/envoyproxy/go-control-pla" +
+      "ne/wso2/discovery/config/enforcer;enforc" +
+      "erb\006proto3"
     };
     descriptor = com.google.protobuf.Descriptors.FileDescriptor
       .internalBuildGeneratedFileFrom(descriptorData,
@@ -55,7 +56,7 @@ public final class ThrottlingProto {
     internal_static_wso2_discovery_config_enforcer_Throttling_fieldAccessorTable = new
       com.google.protobuf.GeneratedMessageV3.FieldAccessorTable(
         internal_static_wso2_discovery_config_enforcer_Throttling_descriptor,
-        new java.lang.String[] { "EnableGlobalEventPublishing", "EnableHeaderConditions", "EnableQueryParamConditions", "EnableJwtClaimConditions", "JmsConnectionInitialContextFactory", "JmsConnectionProviderUrl", "Publisher", });
+        new java.lang.String[] { "EnableGlobalEventPublishing", "EnableHeaderConditions", "EnableQueryParamConditions", "EnableJwtClaimConditions", "JmsConnectionInitialContextFactory", "JmsConnectionProviderUrl", "Publisher", "EnableConcurrencyLimits", });
     org.wso2.choreo.connect.discovery.config.enforcer.BinaryPublisherProto.getDescriptor();
   }
 
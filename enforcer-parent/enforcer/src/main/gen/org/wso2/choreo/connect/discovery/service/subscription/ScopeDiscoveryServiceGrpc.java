@@ -0,0 +1,287 @@
+package org.wso2.choreo.connect.discovery.service.subscription;
+
+import static io.grpc.MethodDescriptor.generateFullMethodName;
+import static io.grpc.stub.ClientCalls.asyncBidiStreamingCall;
+import static io.grpc.stub.ClientCalls.asyncClientStreamingCall;
+import static io.grpc.stub.ClientCalls.asyncServerStreamingCall;
+import static io.grpc.stub.ClientCalls.asyncUnaryCall;
+import static io.grpc.stub.ClientCalls.blockingServerStreamingCall;
+import static io.grpc.stub.ClientCalls.blockingUnaryCall;
+import static io.grpc.stub.ClientCalls.futureUnaryCall;
+import static io.grpc.stub.ServerCalls.asyncBidiStreamingCall;
+import static io.grpc.stub.ServerCalls.asyncClientStreamingCall;
+import static io.grpc.stub.ServerCalls.asyncServerStreamingCall;
+import static io.grpc.stub.ServerCalls.asyncUnaryCall;
+import static io.grpc.stub.ServerCalls.asyncUnimplementedStreamingCall;
+import static io.grpc.stub.ServerCalls.asyncUnimplementedUnaryCall;
+
+/**
+ * <pre>
+ * [#protodoc-title: ScopeDS]
+ * </pre>
+ */
+@javax.annotation.Generated(
+    value = "by gRPC proto compiler",
+    comments = "Source: wso2/discovery/service/subscription/scope_ds.proto")
+public final class ScopeDiscoveryServiceGrpc {
+
+  private ScopeDiscoveryServiceGrpc() {}
+
+  public static final String SERVICE_NAME = "discovery.service.subscription.ScopeDiscoveryService";
+
+  // Static method descriptors that strictly reflect the proto.
+  private static volatile io.grpc.MethodDescriptor<io.envoyproxy.envoy.service.discovery.v3.DiscoveryRequest,
+      io.envoyproxy.envoy.service.discovery.v3.DiscoveryResponse> getStreamScopesMethod;
+
+  @io.grpc.stub.annotations.RpcMethod(
+      fullMethodName = SERVICE_NAME + '/' + "StreamScopes",
+      requestType = io.envoyproxy.envoy.service.discovery.v3.DiscoveryRequest.class,
+      responseType = io.envoyproxy.envoy.service.discovery.v3.DiscoveryResponse.class,
+      methodType = io.grpc.MethodDescriptor.MethodType.BIDI_STREAMING)
+  public static io.grpc.MethodDescriptor<io.envoyproxy.envoy.service.discovery.v3.DiscoveryRequest,
+      io.envoyproxy.envoy.service.discovery.v3.DiscoveryResponse> getStreamScopesMethod() {
+    io.grpc.MethodDescriptor<io.envoyproxy.envoy.service.discovery.v3.DiscoveryRequest, io.envoyproxy.envoy.service.discovery.v3.DiscoveryResponse> getStreamScopesMethod;
+    if ((getStreamScopesMethod = ScopeDiscoveryServiceGrpc.getStreamScopesMethod) == null) {
+      synchronized (ScopeDiscoveryServiceGrpc.class) {
+        if ((getStreamScopesMethod = ScopeDiscoveryServiceGrpc.getStreamScopesMethod) == null) {
+          ScopeDiscoveryServiceGrpc.getStreamScopesMethod = getStreamScopesMethod =
+              io.grpc.MethodDescriptor.<io.envoyproxy.envoy.service.discovery.v3.DiscoveryRequest, io.envoyproxy.envoy.service.discovery.v3.DiscoveryResponse>newBuilder()
+              .setType(io.grpc.MethodDescriptor.MethodType.BIDI_STREAMING)
+              .setFullMethodName(generateFullMethodName(SERVICE_NAME, "StreamScopes"))
+              .setSampledToLocalTracing(true)
+              .setRequestMarshaller(io.grpc.protobuf.ProtoUtils.marshaller(
+                  io.envoyproxy.envoy.service.discovery.v3.DiscoveryRequest.getDefaultInstance()))
+              .setResponseMarshaller(io.grpc.protobuf.ProtoUtils.marshaller(
+                  io.envoyproxy.envoy.service.discovery.v3.DiscoveryResponse.getDefaultInstance()))
+              .setSchemaDescriptor(new ScopeDiscoveryServiceMethodDescriptorSupplier("StreamScopes"))
+              .build();
+        }
+      }
+    }
+    return getStreamScopesMethod;
+  }
+
+  /**
+   * Creates a new async stub that supports all call types for the service
+   */
+  public static ScopeDiscoveryServiceStub newStub(io.grpc.Channel channel) {
+    io.grpc.stub.AbstractStub.StubFactory<ScopeDiscoveryServiceStub> factory =
+      new io.grpc.stub.AbstractStub.StubFactory<ScopeDiscoveryServiceStub>() {
+        @java.lang.Override
+        public ScopeDiscoveryServiceStub newStub(io.grpc.Channel channel, io.grpc.CallOptions callOptions) {
+          return new ScopeDiscoveryServiceStub(channel, callOptions);
+        }
+      };
+    return ScopeDiscoveryServiceStub.newStub(factory, channel);
+  }
+
+  /**
+   * Creates a new blocking-style stub that supports unary and streaming output calls on the service
+   */
+  public static ScopeDiscoveryServiceBlockingStub newBlockingStub(
+      io.grpc.Channel channel) {
+    io.grpc.stub.AbstractStub.StubFactory<ScopeDiscoveryServiceBlockingStub> factory =
+      new io.grpc.stub.AbstractStub.StubFactory<ScopeDiscoveryServiceBlockingStub>() {
+        @java.lang.Override
+        public ScopeDiscoveryServiceBlockingStub newStub(io.grpc.Channel channel, io.grpc.CallOptions callOptions) {
+          return new ScopeDiscoveryServiceBlockingStub(channel, callOptions);
+        }
+      };
+    return ScopeDiscoveryServiceBlockingStub.newStub(factory, channel);
+  }
+
+  /**
+   * Creates a new ListenableFuture-style stub that supports unary calls on the service
+   */
+  public static ScopeDiscoveryServiceFutureStub newFutureStub(
+      io.grpc.Channel channel) {
+    io.grpc.stub.AbstractStub.StubFactory<ScopeDiscoveryServiceFutureStub> factory =
+      new io.grpc.stub.AbstractStub.StubFactory<ScopeDiscoveryServiceFutureStub>() {
+        @java.lang.Override
+        public ScopeDiscoveryServiceFutureStub newStub(io.grpc.Channel channel, io.grpc.CallOptions callOptions) {
+          return new ScopeDiscoveryServiceFutureStub(channel, callOptions);
+        }
+      };
+    return ScopeDiscoveryServiceFutureStub.newStub(factory, channel);
+  }
+
+  /**
+   * <pre>
+   * [#protodoc-title: ScopeDS]
+   * </pre>
+   */
+  public static abstract class ScopeDiscoveryServiceImplBase implements io.grpc.BindableService {
+
+    /**
+     */
+    public io.grpc.stub.StreamObserver<io.envoyproxy.envoy.service.discovery.v3.DiscoveryRequest> streamScopes(
+        io.grpc.stub.StreamObserver<io.envoyproxy.envoy.service.discovery.v3.DiscoveryResponse> responseObserver) {
+      return asyncUnimplementedStreamingCall(getStreamScopesMethod(), responseObserver);
+    }
+
+    @java.lang.Override public final io.grpc.ServerServiceDefinition bindService() {
+      return io.grpc.ServerServiceDefinition.builder(getServiceDescriptor())
+          .addMethod(
+            getStreamScopesMethod(),
+            asyncBidiStreamingCall(
+              new MethodHandlers<
+                io.envoyproxy.envoy.service.discovery.v3.DiscoveryRequest,
+                io.envoyproxy.envoy.service.discovery.v3.DiscoveryResponse>(
+                  this, METHODID_STREAM_SCOPES)))
+          .build();
+    }
+  }
+
+  /**
+   * <pre>
+   * [#protodoc-title: ScopeDS]
+   * </pre>
+   */
+  public static final class ScopeDiscoveryServiceStub extends io.grpc.stub.AbstractAsyncStub<ScopeDiscoveryServiceStub> {
+    private ScopeDiscoveryServiceStub(
+        io.grpc.Channel channel, io.grpc.CallOptions callOptions) {
+      super(channel, callOptions);
+    }
+
+    @java.lang.Override
+    protected ScopeDiscoveryServiceStub build(
+        io.grpc.Channel channel, io.grpc.CallOptions callOptions) {
+      return new ScopeDiscoveryServiceStub(channel, callOptions);
+    }
+
+    /**
+     */
+    public io.grpc.stub.StreamObserver<io.envoyproxy.envoy.service.discovery.v3.DiscoveryRequest> streamScopes(
+        io.grpc.stub.StreamObserver<io.envoyproxy.envoy.service.discovery.v3.DiscoveryResponse> responseObserver) {
+      return asyncBidiStreamingCall(
+          getChannel().newCall(getStreamScopesMethod(), getCallOptions()), responseObserver);
+    }
+  }
+
+  /**
+   * <pre>
+   * [#protodoc-title: ScopeDS]
+   * </pre>
+   */
+  public static final class ScopeDiscoveryServiceBlockingStub extends io.grpc.stub.AbstractBlockingStub<ScopeDiscoveryServiceBlockingStub> {
+    private ScopeDiscoveryServiceBlockingStub(
+        io.grpc.Channel channel, io.grpc.CallOptions callOptions) {
+      super(channel, callOptions);
+    }
+
+    @java.lang.Override
+    protected ScopeDiscoveryServiceBlockingStub build(
+        io.grpc.Channel channel, io.grpc.CallOptions callOptions) {
+      return new ScopeDiscoveryServiceBlockingStub(channel, callOptions);
+    }
+  }
+
+  /**
+   * <pre>
+   * [#protodoc-title: ScopeDS]
+   * </pre>
+   */
+  public static final class ScopeDiscoveryServiceFutureStub extends io.grpc.stub.AbstractFutureStub<ScopeDiscoveryServiceFutureStub> {
+    private ScopeDiscoveryServiceFutureStub(
+        io.grpc.Channel channel, io.grpc.CallOptions callOptions) {
+      super(channel, callOptions);
+    }
+
+    @java.lang.Override
+    protected ScopeDiscoveryServiceFutureStub build(
+        io.grpc.Channel channel, io.grpc.CallOptions callOptions) {
+      return new ScopeDiscoveryServiceFutureStub(channel, callOptions);
+    }
+  }
+
+  private static final int METHODID_STREAM_SCOPES = 0;
+
+  private static final class MethodHandlers<Req, Resp> implements
+      io.grpc.stub.ServerCalls.UnaryMethod<Req, Resp>,
+      io.grpc.stub.ServerCalls.ServerStreamingMethod<Req, Resp>,
+      io.grpc.stub.ServerCalls.ClientStreamingMethod<Req, Resp>,
+      io.grpc.stub.ServerCalls.BidiStreamingMethod<Req, Resp> {
+    private final ScopeDiscoveryServiceImplBase serviceImpl;
+    private final int methodId;
+
+    MethodHandlers(ScopeDiscoveryServiceImplBase serviceImpl, int methodId) {
+      this.serviceImpl = serviceImpl;
+      this.methodId = methodId;
+    }
+
+    @java.lang.Override
+    @java.lang.SuppressWarnings("unchecked")
+    public void invoke(Req request, io.grpc.stub.StreamObserver<Resp> responseObserver) {
+      switch (methodId) {
+        default:
+          throw new AssertionError();
+      }
+    }
+
+    @java.lang.Override
+    @java.lang.SuppressWarnings("unchecked")
+    public io.grpc.stub.StreamObserver<Req> invoke(
+        io.grpc.stub.StreamObserver<Resp> responseObserver) {
+      switch (methodId) {
+        case METHODID_STREAM_SCOPES:
+          return (io.grpc.stub.StreamObserver<Req>) serviceImpl.streamScopes(
+              (io.grpc.stub.StreamObserver<io.envoyproxy.envoy.service.discovery.v3.DiscoveryResponse>) responseObserver);
+        default:
+          throw new AssertionError();
+      }
+    }
+  }
+
+  private static abstract class ScopeDiscoveryServiceBaseDescriptorSupplier
+      implements io.grpc.protobuf.ProtoFileDescriptorSupplier, io.grpc.protobuf.ProtoServiceDescriptorSupplier {
+    ScopeDiscoveryServiceBaseDescriptorSupplier() {}
+
+    @java.lang.Override
+    public com.google.protobuf.Descriptors.FileDescriptor getFileDescriptor() {
+      return org.wso2.choreo.connect.discovery.service.subscription.ScopeDSProto.getDescriptor();
+    }
+
+    @java.lang.Override
+    public com.google.protobuf.Descriptors.ServiceDescriptor getServiceDescriptor() {
+      return getFileDescriptor().findServiceByName("ScopeDiscoveryService");
+    }
+  }
+
+  private static final class ScopeDiscoveryServiceFileDescriptorSupplier
+      extends ScopeDiscoveryServiceBaseDescriptorSupplier {
+    ScopeDiscoveryServiceFileDescriptorSupplier() {}
+  }
+
+  private static final class ScopeDiscoveryServiceMethodDescriptorSupplier
+      extends ScopeDiscoveryServiceBaseDescriptorSupplier
+      implements io.grpc.protobuf.ProtoMethodDescriptorSupplier {
+    private final String methodName;
+
+    ScopeDiscoveryServiceMethodDescriptorSupplier(String methodName) {
+      this.methodName = methodName;
+    }
+
+    @java.lang.Override
+    public com.google.protobuf.Descriptors.MethodDescriptor getMethodDescriptor() {
+      return getServiceDescriptor().findMethodByName(methodName);
+    }
+  }
+
+  private static volatile io.grpc.ServiceDescriptor serviceDescriptor;
+
+  public static io.grpc.ServiceDescriptor getServiceDescriptor() {
+    io.grpc.ServiceDescriptor result = serviceDescriptor;
+    if (result == null) {
+      synchronized (ScopeDiscoveryServiceGrpc.class) {
+        result = serviceDescriptor;
+        if (result == null) {
+          serviceDescriptor = result = io.grpc.ServiceDescriptor.newBuilder(SERVICE_NAME)
+              .setSchemaDescriptor(new ScopeDiscoveryServiceFileDescriptorSupplier())
+              .addMethod(getStreamScopesMethod())
+              .build();
+        }
+      }
+    }
+    return result;
+  }
+}
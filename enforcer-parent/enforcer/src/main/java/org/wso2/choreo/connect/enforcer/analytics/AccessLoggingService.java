@@ -37,6 +37,7 @@ import org.wso2.choreo.connect.enforcer.metrics.MetricsUtils;
 import org.wso2.choreo.connect.enforcer.server.Constants;
 import org.wso2.choreo.connect.enforcer.server.EnforcerThreadPoolExecutor;
 import org.wso2.choreo.connect.enforcer.server.NativeThreadFactory;
+import org.wso2.choreo.connect.enforcer.throttle.ConcurrencyLimiter;
 import org.wso2.choreo.connect.enforcer.util.TLSUtils;
 
 import java.io.IOException;
@@ -74,6 +75,9 @@ public class AccessLoggingService extends AccessLogServiceGrpc.AccessLogServiceI
                 if (ConfigHolder.getInstance().getConfig().getMetricsConfig().isMetricsEnabled()) {
                     MetricsUtils.handlePublishingMetrics(message);
                 }
+                if (ConfigHolder.getInstance().getConfig().getThrottleConfig().isConcurrencyLimitsEnabled()) {
+                    ConcurrencyLimiter.getInstance().handleGRPCLogMsg(message);
+                }
             }
 
             @Override
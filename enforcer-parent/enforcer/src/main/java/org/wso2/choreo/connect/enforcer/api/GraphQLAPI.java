@@ -36,6 +36,7 @@ import org.wso2.choreo.connect.enforcer.commons.Filter;
 import org.wso2.choreo.connect.enforcer.commons.logging.ErrorDetails;
 import org.wso2.choreo.connect.enforcer.commons.logging.LoggingConstants;
 import org.wso2.choreo.connect.enforcer.commons.model.APIConfig;
+import org.wso2.choreo.connect.enforcer.commons.model.BackendJWTConfig;
 import org.wso2.choreo.connect.enforcer.commons.model.EndpointCluster;
 import org.wso2.choreo.connect.enforcer.commons.model.EndpointSecurity;
 import org.wso2.choreo.connect.enforcer.commons.model.GraphQLSchemaDTO;
@@ -52,7 +53,10 @@ import org.wso2.choreo.connect.enforcer.graphql.GraphQLPayloadUtils;
 import org.wso2.choreo.connect.enforcer.graphql.GraphQLQueryAnalysisFilter;
 import org.wso2.choreo.connect.enforcer.security.AuthFilter;
 import org.wso2.choreo.connect.enforcer.security.mtls.MtlsUtils;
+import org.wso2.choreo.connect.enforcer.throttle.ConcurrencyLimitFilter;
 import org.wso2.choreo.connect.enforcer.throttle.ThrottleFilter;
+import org.wso2.choreo.connect.enforcer.throttle.quotasync.QuotaSyncClient;
+import org.wso2.choreo.connect.enforcer.throttle.quotasync.QuotaSyncThrottleFilter;
 import org.wso2.choreo.connect.enforcer.util.FilterUtils;
 
 import java.security.KeyStore;
@@ -171,7 +175,13 @@ public class GraphQLAPI implements API {
                 .organizationId(api.getOrganizationId()).endpoints(endpoints).resources(resources)
                 .securitySchemeDefinitions(securitySchemeDefinitions).graphQLSchemaDTO(graphQLSchemaDTO)
                 .trustStore(trustStore).mtlsCertificateTiers(mtlsCertificateTiers).mutualSSL(mutualSSL)
-                .applicationSecurity(applicationSecurity).build();
+                .applicationSecurity(applicationSecurity)
+                .allowedIssuers(new ArrayList<>(api.getAllowedIssuersList()))
+                .allowedAudiences(new ArrayList<>(api.getAllowedAudiencesList()))
+                .maxConcurrentRequestsPerApplication(api.getMaxConcurrentRequestsPerApplication())
+                .backendJWTConfig(new BackendJWTConfig(api.getBackendJwtEnabled(), api.getBackendJwtHeader(),
+                        api.getBackendJwtSigningAlgorithm(), api.getBackendJwtEnableUserClaims(),
+                        new ArrayList<>(api.getBackendJwtApplicationAttributesList()))).build();
         initFilters();
         return basePath;
     }
@@ -263,6 +273,21 @@ public class GraphQLAPI implements API {
         throttleFilter.init(apiConfig, null);
         this.filters.add(throttleFilter);
 
+        // enforce the rate limits of the subscription policies across the enforcer replicas
+        if (QuotaSyncClient.isEnabled()) {
+            QuotaSyncThrottleFilter quotaSyncThrottleFilter = new QuotaSyncThrottleFilter();
+            quotaSyncThrottleFilter.init(apiConfig, null);
+            this.filters.add(quotaSyncThrottleFilter);
+        }
+
+        // enable concurrency limit filter
+        if (ConfigHolder.getInstance().getConfig().getThrottleConfig().isConcurrencyLimitsEnabled() &&
+                apiConfig.getMaxConcurrentRequestsPerApplication() > 0) {
+            ConcurrencyLimitFilter concurrencyLimitFilter = new ConcurrencyLimitFilter();
+            concurrencyLimitFilter.init(apiConfig, null);
+            this.filters.add(concurrencyLimitFilter);
+        }
+
         loadCustomFilters(apiConfig);
 
         // CORS filter is added as the first filter, and it is not customizable.
@@ -30,6 +30,7 @@ import org.wso2.choreo.connect.discovery.api.SecurityScheme;
 import org.wso2.choreo.connect.enforcer.analytics.AnalyticsFilter;
 import org.wso2.choreo.connect.enforcer.commons.Filter;
 import org.wso2.choreo.connect.enforcer.commons.model.APIConfig;
+import org.wso2.choreo.connect.enforcer.commons.model.BackendJWTConfig;
 import org.wso2.choreo.connect.enforcer.commons.model.EndpointCluster;
 import org.wso2.choreo.connect.enforcer.commons.model.EndpointSecurity;
 import org.wso2.choreo.connect.enforcer.commons.model.MockedApiConfig;
@@ -45,13 +46,18 @@ import org.wso2.choreo.connect.enforcer.config.dto.MutualSSLDto;
 import org.wso2.choreo.connect.enforcer.constants.APIConstants;
 import org.wso2.choreo.connect.enforcer.constants.Constants;
 import org.wso2.choreo.connect.enforcer.constants.HttpConstants;
+import org.wso2.choreo.connect.enforcer.constants.MetadataConstants;
 import org.wso2.choreo.connect.enforcer.cors.CorsFilter;
 import org.wso2.choreo.connect.enforcer.interceptor.MediationPolicyFilter;
 import org.wso2.choreo.connect.enforcer.security.AuthFilter;
 import org.wso2.choreo.connect.enforcer.security.mtls.MtlsUtils;
+import org.wso2.choreo.connect.enforcer.throttle.ConcurrencyLimitFilter;
 import org.wso2.choreo.connect.enforcer.throttle.ThrottleFilter;
+import org.wso2.choreo.connect.enforcer.throttle.quotasync.QuotaSyncClient;
+import org.wso2.choreo.connect.enforcer.throttle.quotasync.QuotaSyncThrottleFilter;
 import org.wso2.choreo.connect.enforcer.util.FilterUtils;
 import org.wso2.choreo.connect.enforcer.util.MockImplUtils;
+import org.wso2.choreo.connect.enforcer.websub.WebSubSignatureFilter;
 
 import java.security.KeyStore;
 import java.security.KeyStoreException;
@@ -181,7 +187,13 @@ public class RestAPI implements API {
                 .endpoints(endpoints).endpointSecurity(endpointSecurity).mockedApi(api.getIsMockedApi())
                 .trustStore(trustStore).organizationId(api.getOrganizationId())
                 .mtlsCertificateTiers(mtlsCertificateTiers).mutualSSL(mutualSSL)
-                .applicationSecurity(applicationSecurity).endpointType(endpointType).build();
+                .applicationSecurity(applicationSecurity).endpointType(endpointType)
+                .allowedIssuers(new ArrayList<>(api.getAllowedIssuersList()))
+                .allowedAudiences(new ArrayList<>(api.getAllowedAudiencesList()))
+                .maxConcurrentRequestsPerApplication(api.getMaxConcurrentRequestsPerApplication())
+                .backendJWTConfig(new BackendJWTConfig(api.getBackendJwtEnabled(), api.getBackendJwtHeader(),
+                        api.getBackendJwtSigningAlgorithm(), api.getBackendJwtEnableUserClaims(),
+                        new ArrayList<>(api.getBackendJwtApplicationAttributesList()))).build();
 
         initFilters();
         return basePath;
@@ -222,6 +234,13 @@ public class RestAPI implements API {
             if (analyticsEnabled) {
                 AnalyticsFilter.getInstance().handleSuccessRequest(requestContext);
             }
+            // The request count limits of the router are applied to the application authenticated here, hence
+            // the application is not taken from a header set by the client.
+            if (requestContext.getAuthenticationContext() != null &&
+                    requestContext.getAuthenticationContext().getApplicationUUID() != null) {
+                requestContext.addMetadataToMap(MetadataConstants.APP_UUID_KEY,
+                        requestContext.getAuthenticationContext().getApplicationUUID());
+            }
             // set metadata for interceptors
             responseObject.setMetaDataMap(requestContext.getMetadataMap());
             if (requestContext.getMatchedAPI().isMockedApi()) {
@@ -306,8 +325,28 @@ public class RestAPI implements API {
         throttleFilter.init(apiConfig, null);
         this.filters.add(throttleFilter);
 
+        // enforce the rate limits of the subscription policies across the enforcer replicas
+        if (QuotaSyncClient.isEnabled()) {
+            QuotaSyncThrottleFilter quotaSyncThrottleFilter = new QuotaSyncThrottleFilter();
+            quotaSyncThrottleFilter.init(apiConfig, null);
+            this.filters.add(quotaSyncThrottleFilter);
+        }
+
+        // enable concurrency limit filter
+        if (ConfigHolder.getInstance().getConfig().getThrottleConfig().isConcurrencyLimitsEnabled() &&
+                apiConfig.getMaxConcurrentRequestsPerApplication() > 0) {
+            ConcurrencyLimitFilter concurrencyLimitFilter = new ConcurrencyLimitFilter();
+            concurrencyLimitFilter.init(apiConfig, null);
+            this.filters.add(concurrencyLimitFilter);
+        }
+
         loadCustomFilters(apiConfig);
 
+        // The events published to the hub of a WebSub API are verified before any other filter, except CORS.
+        if (APIConstants.ApiType.WEBSUB.equals(apiConfig.getApiType())) {
+            this.filters.add(0, new WebSubSignatureFilter());
+        }
+
         // CORS filter is added as the first filter, and it is not customizable.
         CorsFilter corsFilter = new CorsFilter();
         this.filters.add(0, corsFilter);
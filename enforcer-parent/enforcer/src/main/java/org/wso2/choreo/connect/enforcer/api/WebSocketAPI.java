@@ -30,6 +30,7 @@ import org.wso2.choreo.connect.enforcer.commons.Filter;
 import org.wso2.choreo.connect.enforcer.commons.logging.ErrorDetails;
 import org.wso2.choreo.connect.enforcer.commons.logging.LoggingConstants;
 import org.wso2.choreo.connect.enforcer.commons.model.APIConfig;
+import org.wso2.choreo.connect.enforcer.commons.model.BackendJWTConfig;
 import org.wso2.choreo.connect.enforcer.commons.model.EndpointCluster;
 import org.wso2.choreo.connect.enforcer.commons.model.EndpointSecurity;
 import org.wso2.choreo.connect.enforcer.commons.model.RequestContext;
@@ -40,6 +41,7 @@ import org.wso2.choreo.connect.enforcer.constants.APIConstants;
 import org.wso2.choreo.connect.enforcer.cors.CorsFilter;
 import org.wso2.choreo.connect.enforcer.interceptor.MediationPolicyFilter;
 import org.wso2.choreo.connect.enforcer.security.AuthFilter;
+import org.wso2.choreo.connect.enforcer.throttle.ConcurrencyLimitFilter;
 import org.wso2.choreo.connect.enforcer.throttle.ThrottleConstants;
 import org.wso2.choreo.connect.enforcer.throttle.ThrottleFilter;
 import org.wso2.choreo.connect.enforcer.util.FilterUtils;
@@ -143,7 +145,13 @@ public class WebSocketAPI implements API {
                 .apiSecurity(apiSecurity).tier(api.getTier()).endpointSecurity(endpointSecurity)
                 .authHeader(api.getAuthorizationHeader()).disableSecurity(api.getDisableSecurity())
                 .organizationId(api.getOrganizationId()).endpoints(endpoints).resources(resources)
-                .securitySchemeDefinitions(securitySchemes).build();
+                .securitySchemeDefinitions(securitySchemes)
+                .allowedIssuers(new ArrayList<>(api.getAllowedIssuersList()))
+                .allowedAudiences(new ArrayList<>(api.getAllowedAudiencesList()))
+                .maxConcurrentRequestsPerApplication(api.getMaxConcurrentRequestsPerApplication())
+                .backendJWTConfig(new BackendJWTConfig(api.getBackendJwtEnabled(), api.getBackendJwtHeader(),
+                        api.getBackendJwtSigningAlgorithm(), api.getBackendJwtEnableUserClaims(),
+                        new ArrayList<>(api.getBackendJwtApplicationAttributesList()))).build();
         initFilters();
         initUpgradeFilters();
         return basePath;
@@ -247,6 +255,13 @@ public class WebSocketAPI implements API {
             throttleFilter.init(apiConfig, null);
             this.filters.add(throttleFilter);
         }
+        // Concurrency limit filter if concurrency limits are enabled
+        if (ConfigHolder.getInstance().getConfig().getThrottleConfig().isConcurrencyLimitsEnabled() &&
+                apiConfig.getMaxConcurrentRequestsPerApplication() > 0) {
+            ConcurrencyLimitFilter concurrencyLimitFilter = new ConcurrencyLimitFilter();
+            concurrencyLimitFilter.init(apiConfig, null);
+            this.filters.add(concurrencyLimitFilter);
+        }
         // WebSocketMetadata filter
         WebSocketMetaDataFilter metaDataFilter = new WebSocketMetaDataFilter();
         metaDataFilter.init(apiConfig, null);
@@ -58,6 +58,7 @@ import org.wso2.choreo.connect.discovery.config.enforcer.Tracing;
 import org.wso2.choreo.connect.enforcer.commons.exception.EnforcerException;
 import org.wso2.choreo.connect.enforcer.commons.logging.ErrorDetails;
 import org.wso2.choreo.connect.enforcer.commons.logging.LoggingConstants;
+import org.wso2.choreo.connect.enforcer.commons.model.BackendJWTConfig;
 import org.wso2.choreo.connect.enforcer.config.dto.AdminRestServerDto;
 import org.wso2.choreo.connect.enforcer.config.dto.AnalyticsDTO;
 import org.wso2.choreo.connect.enforcer.config.dto.AnalyticsReceiverConfigDTO;
@@ -101,6 +102,7 @@ import java.util.ArrayList;
 import java.util.Arrays;
 import java.util.List;
 import java.util.Map;
+import java.util.concurrent.ConcurrentHashMap;
 import java.util.regex.Matcher;
 import java.util.regex.Pattern;
 
@@ -125,6 +127,8 @@ public class ConfigHolder {
     private TrustManagerFactory trustManagerFactory = null;
     private ArrayList<ExtendedTokenIssuerDto> configIssuerList;
     private boolean controlPlaneEnabled;
+    private JWTGenerator jwtGenerator;
+    private final Map<String, JWTConfigurationDto> apiJwtConfigurations = new ConcurrentHashMap<>();
 
     private static final String dtoPackageName = EnforcerConfig.class.getPackageName();
     private static final String apimDTOPackageName = "org.wso2.carbon.apimgt";
@@ -332,6 +336,7 @@ public class ConfigHolder {
         throttleConfig.setHeaderConditionsEnabled(throttling.getEnableHeaderConditions());
         throttleConfig.setQueryConditionsEnabled(throttling.getEnableQueryParamConditions());
         throttleConfig.setJwtClaimConditionsEnabled(throttling.getEnableJwtClaimConditions());
+        throttleConfig.setConcurrencyLimitsEnabled(throttling.getEnableConcurrencyLimits());
         throttleConfig.setJmsConnectionInitialContextFactory(throttling.getJmsConnectionInitialContextFactory());
         throttleConfig.setJmsConnectionProviderUrl(throttling.getJmsConnectionProviderUrl());
         config.setThrottleConfig(throttleConfig);
@@ -510,6 +515,13 @@ public class ConfigHolder {
     }
 
     private void populateJWTGeneratorConfigurations(JWTGenerator jwtGenerator) {
+        this.jwtGenerator = jwtGenerator;
+        apiJwtConfigurations.clear();
+        config.setJwtConfigurationDto(buildJWTConfiguration(jwtGenerator));
+        populateBackendJWKSConfiguration(jwtGenerator);
+    }
+
+    private JWTConfigurationDto buildJWTConfiguration(JWTGenerator jwtGenerator) {
         JWTConfigurationDto jwtConfigurationDto = new JWTConfigurationDto();
         jwtConfigurationDto.setEnabled(jwtGenerator.getEnable());
         jwtConfigurationDto.setJwtHeader(jwtGenerator.getHeader());
@@ -530,8 +542,7 @@ public class ConfigHolder {
             String err = "Error in loading keypair for Backend JWTs: " + e;
             logger.error(err, ErrorDetails.errorLog(LoggingConstants.Severity.CRITICAL, 5400));
         }
-        config.setJwtConfigurationDto(jwtConfigurationDto);
-        populateBackendJWKSConfiguration(jwtGenerator);
+        return jwtConfigurationDto;
     }
 
     private void populateBackendJWKSConfiguration(JWTGenerator jwtGenerator) {
@@ -780,4 +791,25 @@ public class ConfigHolder {
     public boolean isControlPlaneEnabled() {
         return controlPlaneEnabled;
     }
+
+    /**
+     * Returns the backend JWT configuration of an API. The given settings override the JWT generator
+     * configuration of the enforcer, which is returned if the API does not have the settings. The configurations
+     * are cached, as the APIs are expected to share a few combinations of the settings.
+     *
+     * @param backendJWTConfig backend JWT settings of the API
+     * @return backend JWT configuration of the API
+     */
+    public JWTConfigurationDto getJwtConfigurationDto(BackendJWTConfig backendJWTConfig) {
+        if (backendJWTConfig == null || StringUtils.isEmpty(backendJWTConfig.getHeader()) || jwtGenerator == null) {
+            return config.getJwtConfigurationDto();
+        }
+        String key = String.join(":", String.valueOf(backendJWTConfig.isEnabled()), backendJWTConfig.getHeader(),
+                backendJWTConfig.getSigningAlgorithm(), String.valueOf(backendJWTConfig.isEnableUserClaims()));
+        return apiJwtConfigurations.computeIfAbsent(key, k -> buildJWTConfiguration(jwtGenerator.toBuilder()
+                .setEnable(backendJWTConfig.isEnabled())
+                .setHeader(backendJWTConfig.getHeader())
+                .setSigningAlgorithm(backendJWTConfig.getSigningAlgorithm())
+                .setEnableUserClaims(backendJWTConfig.isEnableUserClaims()).build()));
+    }
 }
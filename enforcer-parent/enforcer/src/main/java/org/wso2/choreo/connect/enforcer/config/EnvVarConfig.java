@@ -40,6 +40,8 @@ public class EnvVarConfig {
     public static final String XDS_MAX_RETRIES = "XDS_MAX_RETRIES";
     public static final String XDS_RETRY_PERIOD = "XDS_RETRY_PERIOD";
     public static final String HOSTNAME = "HOSTNAME";
+    public static final String QUOTA_SYNC_ENABLED = "QUOTA_SYNC_ENABLED";
+    public static final String QUOTA_SYNC_REPORT_INTERVAL = "QUOTA_SYNC_REPORT_INTERVAL";
 
     // Since the container is running in linux container, path separator is not needed.
     private static final String DEFAULT_TRUSTED_CA_CERTS_PATH = "/home/wso2/security/truststore";
@@ -55,6 +57,9 @@ public class EnvVarConfig {
     public static final String DEFAULT_XDS_MAX_RETRIES = Integer.toString(Constants.MAX_XDS_RETRIES);
     public static final String DEFAULT_XDS_RETRY_PERIOD = Integer.toString(Constants.XDS_DEFAULT_RETRY);
     public static final String DEFAULT_HOSTNAME = "Unassigned";
+    public static final String DEFAULT_QUOTA_SYNC_ENABLED = "false";
+    // in milliseconds
+    public static final String DEFAULT_QUOTA_SYNC_REPORT_INTERVAL = "1000";
 
     private static EnvVarConfig instance;
     private final String trustedAdapterCertsPath;
@@ -74,6 +79,8 @@ public class EnvVarConfig {
     private final String xdsMaxRetries;
     private final String xdsRetryPeriod;
     private final String instanceIdentifier;
+    private final String quotaSyncEnabled;
+    private final String quotaSyncReportInterval;
 
     private EnvVarConfig() {
         trustedAdapterCertsPath = retrieveEnvVarOrDefault(TRUSTED_CA_CERTS_PATH,
@@ -99,6 +106,9 @@ public class EnvVarConfig {
         // HOSTNAME environment property is readily available in docker and kubernetes, and it represents the Pod
         // name in Kubernetes context, containerID in docker context.
         instanceIdentifier = retrieveEnvVarOrDefault(HOSTNAME, DEFAULT_HOSTNAME);
+        quotaSyncEnabled = retrieveEnvVarOrDefault(QUOTA_SYNC_ENABLED, DEFAULT_QUOTA_SYNC_ENABLED);
+        quotaSyncReportInterval = retrieveEnvVarOrDefault(QUOTA_SYNC_REPORT_INTERVAL,
+                DEFAULT_QUOTA_SYNC_REPORT_INTERVAL);
     }
 
     public static EnvVarConfig getInstance() {
@@ -180,4 +190,12 @@ public class EnvVarConfig {
     public String getInstanceIdentifier() {
         return instanceIdentifier;
     }
+
+    public boolean isQuotaSyncEnabled() {
+        return Boolean.parseBoolean(quotaSyncEnabled);
+    }
+
+    public long getQuotaSyncReportInterval() {
+        return Long.parseLong(quotaSyncReportInterval);
+    }
 }
@@ -18,6 +18,7 @@
 
 package org.wso2.choreo.connect.enforcer.config.dto;
 
+import com.nimbusds.jose.jwk.JWKSet;
 import org.wso2.carbon.apimgt.common.gateway.dto.TokenIssuerDto;
 
 /**
@@ -27,6 +28,7 @@ public class ExtendedTokenIssuerDto extends TokenIssuerDto {
     private String name;
     private boolean validateSubscriptions;
     private String alias;
+    private JWKSet jwkSet;
 
 
     public ExtendedTokenIssuerDto(String issuer) {
@@ -56,5 +58,17 @@ public class ExtendedTokenIssuerDto extends TokenIssuerDto {
     public void setCertificateAlias(String alias) {
         this.alias = alias;
     }
-}
 
+    /**
+     * Returns the JWKS of the issuer distributed by the adapter, if any.
+     *
+     * @return JWKS of the issuer, or null if the JWKS is not distributed
+     */
+    public JWKSet getJwkSet() {
+        return jwkSet;
+    }
+
+    public void setJwkSet(JWKSet jwkSet) {
+        this.jwkSet = jwkSet;
+    }
+}
@@ -30,6 +30,7 @@ public class ThrottleConfigDto {
     private boolean isHeaderConditionsEnabled;
     private boolean isQueryConditionsEnabled;
     private boolean isJwtClaimConditionsEnabled;
+    private boolean isConcurrencyLimitsEnabled;
     private String jmsConnectionInitialContextFactory;
     private String jmsConnectionProviderUrl;
     private ThrottleAgentConfigDto throttleAgent;
@@ -66,6 +67,14 @@ public class ThrottleConfigDto {
         isJwtClaimConditionsEnabled = jwtClaimConditionsEnabled;
     }
 
+    public boolean isConcurrencyLimitsEnabled() {
+        return isConcurrencyLimitsEnabled;
+    }
+
+    public void setConcurrencyLimitsEnabled(boolean concurrencyLimitsEnabled) {
+        isConcurrencyLimitsEnabled = concurrencyLimitsEnabled;
+    }
+
     public ThrottleAgentConfigDto getThrottleAgent() {
         return throttleAgent;
     }
@@ -33,6 +33,8 @@ public class APIConstants {
     public static final String GW_RES_METHOD_PARAM = "method";
     public static final String GW_VERSION_PARAM = "version";
     public static final String GW_API_NAME_PARAM = "name";
+    // set only if the subscription validation of the API overrides the one of the token issuers
+    public static final String GW_SUBSCRIPTION_VALIDATION_PARAM = "subscriptionValidation";
     public static final String PROTOTYPED_LIFE_CYCLE_STATUS = "PROTOTYPED";
     public static final String PUBLISHED_LIFE_CYCLE_STATUS = "PUBLISHED";
     public static final String UNLIMITED_TIER = "Unlimited";
@@ -274,6 +276,7 @@ public class APIConstants {
         public static final String CERTIFICATE_VALUE = "certificate_value";
         public static final String CERTIFICATE_TYPE_JWKS_ENDPOINT = "JWKS";
         public static final String CERTIFICATE_TYPE_PEM_FILE = "PEM";
+        public static final String JWKS = "jwks";
         public static final String EVENT_PUBLISHER_CONFIGURATIONS = "EventPublisherConfiguration";
         public static final String KEY_MANAGER_TYPE_HEADER = "X-WSO2-KEY-MANAGER";
         public static final String ACCESS_TOKEN = "accessToken";
@@ -411,6 +414,7 @@ public class APIConstants {
         public static final String WEB_SOCKET = "WS";
         public static final String GRAPHQL = "GRAPHQL";
         public static final String REST = "HTTP";
+        public static final String WEBSUB = "WEBSUB";
     }
 
 
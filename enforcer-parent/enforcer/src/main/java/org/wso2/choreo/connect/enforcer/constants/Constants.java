@@ -35,6 +35,7 @@ public class Constants {
             "type.googleapis.com/wso2.discovery.subscription.SubscriptionPolicyList";
     public static final String APPLICATION_KEY_MAPPING_LIST_TYPE_URL =
             "type.googleapis.com/wso2.discovery.subscription.ApplicationKeyMappingList";
+    public static final String SCOPE_LIST_TYPE_URL = "type.googleapis.com/wso2.discovery.subscription.ScopeList";
     public static final String KEY_MANAGER_TYPE_URL =
             "type.googleapis.com/wso2.discovery.keymgt.KeyManagerConfig";
     public static final String REVOKED_TOKEN_TYPE_URL =
@@ -41,6 +41,9 @@ public class MetadataConstants {
     public static final String APP_KEY_TYPE_KEY = WSO2_METADATA_PREFIX + "application-key-type";
     public static final String APP_NAME_KEY = WSO2_METADATA_PREFIX + "application-name";
     public static final String APP_OWNER_KEY = WSO2_METADATA_PREFIX + "application-owner";
+    // Custom attributes of the application, referenced by the header policies of the router and the throttle
+    // policies
+    public static final String APP_ATTRIBUTE_KEY_PREFIX = "application.attributes.";
 
     public static final String CORRELATION_ID_KEY = WSO2_METADATA_PREFIX + "correlation-id";
     public static final String REGION_KEY = WSO2_METADATA_PREFIX + "region";
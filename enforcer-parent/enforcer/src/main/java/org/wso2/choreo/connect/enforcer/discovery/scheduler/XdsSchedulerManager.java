@@ -27,6 +27,7 @@ import org.wso2.choreo.connect.enforcer.discovery.ApplicationPolicyDiscoveryClie
 import org.wso2.choreo.connect.enforcer.discovery.ConfigDiscoveryClient;
 import org.wso2.choreo.connect.enforcer.discovery.KeyManagerDiscoveryClient;
 import org.wso2.choreo.connect.enforcer.discovery.RevokedTokenDiscoveryClient;
+import org.wso2.choreo.connect.enforcer.discovery.ScopeDiscoveryClient;
 import org.wso2.choreo.connect.enforcer.discovery.SubscriptionDiscoveryClient;
 import org.wso2.choreo.connect.enforcer.discovery.SubscriptionPolicyDiscoveryClient;
 import org.wso2.choreo.connect.enforcer.discovery.ThrottleDataDiscoveryClient;
@@ -54,6 +55,7 @@ public class XdsSchedulerManager {
     private ScheduledFuture<?> configDiscoveryScheduledFuture;
     private ScheduledFuture<?> applicationPolicyDiscoveryScheduledFuture;
     private ScheduledFuture<?> subscriptionPolicyDiscoveryScheduledFuture;
+    private ScheduledFuture<?> scopeDiscoveryScheduledFuture;
 
     public static XdsSchedulerManager getInstance() {
         if (instance == null) {
@@ -107,6 +109,19 @@ public class XdsSchedulerManager {
         }
     }
 
+    public synchronized void startScopeDiscoveryScheduling() {
+        if (scopeDiscoveryScheduledFuture == null || scopeDiscoveryScheduledFuture.isDone()) {
+            scopeDiscoveryScheduledFuture = discoveryClientScheduler
+                    .scheduleWithFixedDelay(ScopeDiscoveryClient.getInstance(), 1, retryPeriod, TimeUnit.SECONDS);
+        }
+    }
+
+    public synchronized void stopScopeDiscoveryScheduling() {
+        if (scopeDiscoveryScheduledFuture != null && !scopeDiscoveryScheduledFuture.isDone()) {
+            scopeDiscoveryScheduledFuture.cancel(false);
+        }
+    }
+
     public synchronized void startApplicationKeyMappingDiscoveryScheduling() {
         if (applicationKeyMappingDiscoveryScheduledFuture == null || applicationKeyMappingDiscoveryScheduledFuture
                 .isDone()) {
@@ -19,6 +19,7 @@
 package org.wso2.choreo.connect.enforcer.keymgt;
 
 import com.google.gson.Gson;
+import com.nimbusds.jose.jwk.JWKSet;
 import org.apache.commons.lang.StringUtils;
 import org.apache.logging.log4j.LogManager;
 import org.apache.logging.log4j.Logger;
@@ -40,6 +41,7 @@ import java.io.InputStream;
 import java.security.cert.Certificate;
 import java.security.cert.CertificateException;
 import java.security.cert.CertificateFactory;
+import java.text.ParseException;
 import java.util.ArrayList;
 import java.util.Base64;
 import java.util.HashMap;
@@ -132,6 +134,16 @@ public class KeyManagerHolder {
                         tokenIssuerDto.setJwksConfigurationDTO(jwksConfigurationDTO);
                     }
                 }
+                // JWKS fetched and cached by the adapter, hence the JWKS is not fetched for the first request
+                Object jwks = configuration.get(APIConstants.KeyManager.JWKS);
+                if (jwks instanceof String && StringUtils.isNotEmpty((String) jwks)) {
+                    try {
+                        tokenIssuerDto.setJwkSet(JWKSet.parse((String) jwks));
+                    } catch (ParseException e) {
+                        logger.error("Error parsing the JWKS of the issuer " + issuer + ". Error cause: " +
+                                e.getMessage(), ErrorDetails.errorLog(LoggingConstants.Severity.MINOR, 6201));
+                    }
+                }
                 Object certificateType = configuration.get(APIConstants.KeyManager.CERTIFICATE_TYPE);
                 Object certificateValue = configuration.get(APIConstants.KeyManager.CERTIFICATE_VALUE);
                 if (certificateType != null && StringUtils.isNotEmpty((String) certificateType) &&
@@ -0,0 +1,105 @@
+/*
+ * Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package org.wso2.choreo.connect.enforcer.models;
+
+import org.wso2.choreo.connect.enforcer.common.CacheableEntity;
+
+import java.util.ArrayList;
+import java.util.List;
+
+/**
+ * Entity for keeping a Scope and the roles bound to it.
+ */
+public class Scope implements CacheableEntity<String> {
+
+    private String name;
+    private String displayName;
+    private String description;
+    private List<String> roles = new ArrayList<>();
+    private int tenantId;
+    private String tenantDomain;
+
+    public String getName() {
+        return name;
+    }
+
+    public void setName(String name) {
+        this.name = name;
+    }
+
+    public String getDisplayName() {
+        return displayName;
+    }
+
+    public void setDisplayName(String displayName) {
+        this.displayName = displayName;
+    }
+
+    public String getDescription() {
+        return description;
+    }
+
+    public void setDescription(String description) {
+        this.description = description;
+    }
+
+    /**
+     * Returns the roles granted the scope. Any role is granted the scope if the list is empty.
+     *
+     * @return roles bound to the scope
+     */
+    public List<String> getRoles() {
+        return roles;
+    }
+
+    public void setRoles(List<String> roles) {
+        this.roles = roles;
+    }
+
+    public int getTenantId() {
+        return tenantId;
+    }
+
+    public void setTenantId(int tenantId) {
+        this.tenantId = tenantId;
+    }
+
+    public String getTenantDomain() {
+        return tenantDomain;
+    }
+
+    public void setTenantDomain(String tenantDomain) {
+        this.tenantDomain = tenantDomain;
+    }
+
+    @Override
+    public String getCacheKey() {
+        return tenantDomain + DELEM_PERIOD + name;
+    }
+
+    @Override
+    public String toString() {
+        return "Scope{" +
+                "name='" + name + '\'' +
+                ", displayName='" + displayName + '\'' +
+                ", roles=" + roles +
+                ", tenantDomain='" + tenantDomain + '\'' +
+                '}';
+    }
+}
@@ -91,6 +91,13 @@ public class KeyValidator {
             }
         }
 
+        String apiTenantDomain = FilterUtils.getTenantDomainFromRequestURL(validationContext.getContext());
+        if (apiTenantDomain == null) {
+            apiTenantDomain = APIConstants.SUPER_TENANT_DOMAIN_NAME;
+        }
+        SubscriptionDataStore datastore = SubscriptionDataHolder.getInstance()
+                .getTenantSubscriptionStore(apiTenantDomain);
+
         List<ResourceConfig> matchedResources;
         // when it is a graphQL api multiple matching resources will be returned.
         matchedResources = validationContext.getMatchingResourceConfigs();
@@ -111,6 +118,11 @@ public class KeyValidator {
                         needToValidate = true; // Resource has scopes, hence token scopes requires scope validation
                         for (String scope : pair.getValue()) {
                             if (scopesSet.contains(scope)) {
+                                // a scope deleted in the control plane is not granted by the tokens issued earlier
+                                if (datastore != null && datastore.isScopeDeleted(apiTenantDomain, scope)) {
+                                    log.debug("Scope {} is deleted in the control plane.", scope);
+                                    continue;
+                                }
                                 scopesValidated = true;
                                 break;
                             }
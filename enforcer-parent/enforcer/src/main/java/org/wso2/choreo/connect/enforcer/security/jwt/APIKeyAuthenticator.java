@@ -29,7 +29,6 @@ import org.wso2.carbon.apimgt.common.gateway.constants.GraphQLConstants;
 import org.wso2.carbon.apimgt.common.gateway.dto.JWTConfigurationDto;
 import org.wso2.carbon.apimgt.common.gateway.dto.JWTInfoDto;
 import org.wso2.carbon.apimgt.common.gateway.dto.JWTValidationInfo;
-import org.wso2.carbon.apimgt.common.gateway.jwtgenerator.AbstractAPIMgtGatewayJWTGenerator;
 import org.wso2.choreo.connect.enforcer.common.CacheProvider;
 import org.wso2.choreo.connect.enforcer.commons.exception.APISecurityException;
 import org.wso2.choreo.connect.enforcer.commons.logging.ErrorDetails;
@@ -66,7 +65,6 @@ public class APIKeyAuthenticator extends APIKeyHandler {
 
     private static String certAlias;
     private static boolean apiKeySubValidationEnabled;
-    private AbstractAPIMgtGatewayJWTGenerator jwtGenerator;
     private final boolean isGatewayTokenCacheEnabled;
     private static final int IPV4_ADDRESS_BIT_LENGTH = 32;
     private static final int IPV6_ADDRESS_BIT_LENGTH = 128;
@@ -75,9 +73,6 @@ public class APIKeyAuthenticator extends APIKeyHandler {
         log.debug("API key authenticator initialized.");
         EnforcerConfig enforcerConfig = ConfigHolder.getInstance().getConfig();
         this.isGatewayTokenCacheEnabled = enforcerConfig.getCacheDto().isEnabled();
-        if (enforcerConfig.getJwtConfigurationDto().isEnabled()) {
-            this.jwtGenerator = BackendJwtUtils.getApiMgtGatewayJWTGenerator();
-        }
         for (ExtendedTokenIssuerDto tokenIssuer : enforcerConfig.getIssuersMap().values()) {
             if (APIConstants.KeyManager.APIM_APIKEY_ISSUER.equals(tokenIssuer.getName())) {
                 certAlias = tokenIssuer.getCertificateAlias();
@@ -209,11 +204,13 @@ public class APIKeyAuthenticator extends APIKeyHandler {
 
                 validateAPIKeyRestrictions(payload, requestContext, apiContext, apiVersion);
                 APIKeyValidationInfoDTO validationInfoDto;
-                if (ConfigHolder.getInstance().isControlPlaneEnabled()) {
+                if (ConfigHolder.getInstance().isControlPlaneEnabled()
+                        && FilterUtils.isSubscriptionValidationEnabled(requestContext, true)) {
                     log.debug("Validating subscription for API Key against subscription store."
                             + " context: {} version: {}", apiContext, apiVersion);
                     validationInfoDto = KeyValidator.validateSubscription(apiUuid, apiContext, payload);
-                } else if (apiKeySubValidationEnabled) {
+                } else if (!ConfigHolder.getInstance().isControlPlaneEnabled()
+                        && FilterUtils.isSubscriptionValidationEnabled(requestContext, apiKeySubValidationEnabled)) {
                     log.debug("Validating subscription for API Key using JWT claims against invoked API info."
                             + " context: {} version: {}", apiContext, apiVersion);
                     validationInfoDto = getAPIKeyValidationDTO(requestContext, payload);
@@ -277,11 +274,11 @@ public class APIKeyAuthenticator extends APIKeyHandler {
                 // Generate or get backend JWT
                 String endUserToken = null;
                 JWTConfigurationDto jwtConfigurationDto = ConfigHolder.getInstance().
-                        getConfig().getJwtConfigurationDto();
+                        getJwtConfigurationDto(requestContext.getMatchedAPI().getBackendJWTConfig());
                 if (jwtConfigurationDto.isEnabled()) {
                     JWTInfoDto jwtInfoDto = FilterUtils
                             .generateJWTInfoDto(null, validationInfo, validationInfoDto, requestContext);
-                    endUserToken = BackendJwtUtils.generateAndRetrieveJWTToken(jwtGenerator, tokenIdentifier,
+                    endUserToken = BackendJwtUtils.generateAndRetrieveJWTToken(jwtConfigurationDto, tokenIdentifier,
                             jwtInfoDto, isGatewayTokenCacheEnabled);
                     // Set generated jwt token as a response header
                     requestContext.addOrModifyHeaders(jwtConfigurationDto.getJwtHeader(), endUserToken);
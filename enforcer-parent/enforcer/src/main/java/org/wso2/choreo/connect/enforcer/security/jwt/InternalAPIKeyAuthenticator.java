@@ -28,7 +28,6 @@ import org.apache.logging.log4j.ThreadContext;
 import org.wso2.carbon.apimgt.common.gateway.dto.JWTConfigurationDto;
 import org.wso2.carbon.apimgt.common.gateway.dto.JWTInfoDto;
 import org.wso2.carbon.apimgt.common.gateway.dto.JWTValidationInfo;
-import org.wso2.carbon.apimgt.common.gateway.jwtgenerator.AbstractAPIMgtGatewayJWTGenerator;
 import org.wso2.choreo.connect.enforcer.common.CacheProvider;
 import org.wso2.choreo.connect.enforcer.commons.exception.APISecurityException;
 import org.wso2.choreo.connect.enforcer.commons.logging.ErrorDetails;
@@ -64,16 +63,12 @@ public class InternalAPIKeyAuthenticator extends APIKeyHandler {
 
     private static String certAlias;
     private String securityParam;
-    private AbstractAPIMgtGatewayJWTGenerator jwtGenerator;
     private final boolean isGatewayTokenCacheEnabled;
 
     public InternalAPIKeyAuthenticator(String securityParam) {
         this.securityParam = securityParam;
         EnforcerConfig enforcerConfig = ConfigHolder.getInstance().getConfig();
         this.isGatewayTokenCacheEnabled = enforcerConfig.getCacheDto().isEnabled();
-        if (enforcerConfig.getJwtConfigurationDto().isEnabled()) {
-            this.jwtGenerator = BackendJwtUtils.getApiMgtGatewayJWTGenerator();
-        }
         for (ExtendedTokenIssuerDto tokenIssuer : enforcerConfig.getIssuersMap().values()) {
             if (APIConstants.KeyManager.APIM_PUBLISHER_ISSUER.equals(tokenIssuer.getName())) {
                 certAlias = tokenIssuer.getCertificateAlias();
@@ -257,14 +252,14 @@ public class InternalAPIKeyAuthenticator extends APIKeyHandler {
 
                     // Generate or get backend JWT
                     JWTConfigurationDto jwtConfigurationDto = ConfigHolder.getInstance().
-                            getConfig().getJwtConfigurationDto();
+                            getJwtConfigurationDto(requestContext.getMatchedAPI().getBackendJWTConfig());
                     if (jwtConfigurationDto.isEnabled()) {
                         JWTValidationInfo validationInfo = new JWTValidationInfo();
                         validationInfo.setUser(payload.getSubject());
                         JWTInfoDto jwtInfoDto = FilterUtils
                                 .generateJWTInfoDto(null, validationInfo, apiKeyValidationInfoDTO, requestContext);
-                        String endUserToken = BackendJwtUtils.generateAndRetrieveJWTToken(jwtGenerator, tokenIdentifier,
-                                jwtInfoDto, isGatewayTokenCacheEnabled);
+                        String endUserToken = BackendJwtUtils.generateAndRetrieveJWTToken(jwtConfigurationDto,
+                                tokenIdentifier, jwtInfoDto, isGatewayTokenCacheEnabled);
                         // Set generated jwt token as a response header
                         requestContext.addOrModifyHeaders(jwtConfigurationDto.getJwtHeader(), endUserToken);
                     }
@@ -30,10 +30,10 @@ import org.wso2.carbon.apimgt.common.gateway.constants.GraphQLConstants;
 import org.wso2.carbon.apimgt.common.gateway.dto.JWTConfigurationDto;
 import org.wso2.carbon.apimgt.common.gateway.dto.JWTInfoDto;
 import org.wso2.carbon.apimgt.common.gateway.dto.JWTValidationInfo;
-import org.wso2.carbon.apimgt.common.gateway.jwtgenerator.AbstractAPIMgtGatewayJWTGenerator;
 import org.wso2.choreo.connect.enforcer.common.CacheProvider;
 import org.wso2.choreo.connect.enforcer.commons.exception.APISecurityException;
 import org.wso2.choreo.connect.enforcer.commons.exception.EnforcerException;
+import org.wso2.choreo.connect.enforcer.commons.model.APIConfig;
 import org.wso2.choreo.connect.enforcer.commons.model.AuthenticationContext;
 import org.wso2.choreo.connect.enforcer.commons.model.RequestContext;
 import org.wso2.choreo.connect.enforcer.commons.model.ResourceConfig;
@@ -75,14 +75,10 @@ public class JWTAuthenticator implements Authenticator {
     private static final Logger log = LogManager.getLogger(JWTAuthenticator.class);
     private final JWTValidator jwtValidator = new JWTValidator();
     private final boolean isGatewayTokenCacheEnabled;
-    private AbstractAPIMgtGatewayJWTGenerator jwtGenerator;
 
     public JWTAuthenticator() {
         EnforcerConfig enforcerConfig = ConfigHolder.getInstance().getConfig();
         this.isGatewayTokenCacheEnabled = enforcerConfig.getCacheDto().isEnabled();
-        if (enforcerConfig.getJwtConfigurationDto().isEnabled()) {
-            this.jwtGenerator = BackendJwtUtils.getApiMgtGatewayJWTGenerator();
-        }
     }
 
     @Override
@@ -191,13 +187,15 @@ public class JWTAuthenticator implements Authenticator {
             JWTValidationInfo validationInfo = getJwtValidationInfo(signedJWTInfo, jwtTokenIdentifier);
             if (validationInfo != null) {
                 if (validationInfo.isValid()) {
+                    validateIssuerAndAudience(requestContext.getMatchedAPI(), validationInfo.getIssuer(), claims);
                     // Validate subscriptions
                     APIKeyValidationInfoDTO apiKeyValidationInfoDTO = new APIKeyValidationInfoDTO();
                     EnforcerConfig configuration = ConfigHolder.getInstance().getConfig();
                     ExtendedTokenIssuerDto issuerDto = configuration.getIssuersMap().get(validationInfo.getIssuer());
                     Scope validateSubscriptionSpanScope = null;
                     try {
-                        if (issuerDto.isValidateSubscriptions()) {
+                        if (FilterUtils.isSubscriptionValidationEnabled(requestContext,
+                                issuerDto.isValidateSubscriptions())) {
                             if (Utils.tracingEnabled()) {
                                 validateSubscriptionSpan = Utils
                                         .startSpan(TracingConstants.SUBSCRIPTION_VALIDATION_SPAN, tracer);
@@ -296,12 +294,12 @@ public class JWTAuthenticator implements Authenticator {
 
                     // Generate or get backend JWT
                     String endUserToken = null;
-                    JWTConfigurationDto backendJwtConfig = ConfigHolder.getInstance().getConfig().
-                            getJwtConfigurationDto();
+                    JWTConfigurationDto backendJwtConfig = ConfigHolder.getInstance().
+                            getJwtConfigurationDto(requestContext.getMatchedAPI().getBackendJWTConfig());
                     if (backendJwtConfig.isEnabled()) {
                         JWTInfoDto jwtInfoDto = FilterUtils.generateJWTInfoDto(null, validationInfo,
                                 apiKeyValidationInfoDTO, requestContext);
-                        endUserToken = BackendJwtUtils.generateAndRetrieveJWTToken(jwtGenerator, jwtTokenIdentifier,
+                        endUserToken = BackendJwtUtils.generateAndRetrieveJWTToken(backendJwtConfig, jwtTokenIdentifier,
                                 jwtInfoDto, isGatewayTokenCacheEnabled);
                         // Set generated jwt token as a response header
                         requestContext.addOrModifyHeaders(backendJwtConfig.getJwtHeader(), endUserToken);
@@ -355,6 +353,36 @@ public class JWTAuthenticator implements Authenticator {
     }
 
 
+    /**
+     * Validate the issuer and the audience of the token, if the API restricts the issuers and the audiences
+     * accepted by the API.
+     *
+     * @param apiConfig Matched API
+     * @param issuer    Issuer of the validated token
+     * @param claims    JWT claims of the token
+     * @throws APISecurityException if the issuer or the audience is not accepted by the API
+     */
+    private void validateIssuerAndAudience(APIConfig apiConfig, String issuer, JWTClaimsSet claims)
+            throws APISecurityException {
+        List<String> allowedIssuers = apiConfig.getAllowedIssuers();
+        if (allowedIssuers != null && !allowedIssuers.isEmpty() && !allowedIssuers.contains(issuer)) {
+            log.debug("Token issuer {} is not allowed for the API {}:{}", issuer, apiConfig.getName(),
+                    apiConfig.getVersion());
+            throw new APISecurityException(APIConstants.StatusCodes.UNAUTHENTICATED.getCode(),
+                    APISecurityConstants.API_AUTH_INVALID_CREDENTIALS, "Invalid JWT token");
+        }
+        List<String> allowedAudiences = apiConfig.getAllowedAudiences();
+        if (allowedAudiences != null && !allowedAudiences.isEmpty()) {
+            List<String> audiences = claims.getAudience();
+            if (audiences == null || audiences.stream().noneMatch(allowedAudiences::contains)) {
+                log.debug("Token audiences {} are not allowed for the API {}:{}", audiences, apiConfig.getName(),
+                        apiConfig.getVersion());
+                throw new APISecurityException(APIConstants.StatusCodes.UNAUTHENTICATED.getCode(),
+                        APISecurityConstants.API_AUTH_INVALID_CREDENTIALS, "Invalid JWT token");
+            }
+        }
+    }
+
     /**
      * Validate scopes bound to the resource of the API being invoked against the scopes specified
      * in the JWT token payload.
@@ -116,7 +116,10 @@ public class JWTValidator {
                         .isNotEmpty(tokenIssuer.getJwksConfigurationDTO().getUrl())) {
                     // Check JWKSet Available in Cache
                     if (jwkSet == null) {
-                        jwkSet = retrieveJWKSet(tokenIssuer);
+                        // use the JWKS distributed by the adapter, which is fetched again only if the key is not
+                        // found (i.e. the keys are rotated)
+                        jwkSet = tokenIssuer.getJwkSet() != null ? tokenIssuer.getJwkSet() :
+                                retrieveJWKSet(tokenIssuer);
                     }
                     if (jwkSet.getKeyByKeyId(keyID) == null) {
                         jwkSet = retrieveJWKSet(tokenIssuer);
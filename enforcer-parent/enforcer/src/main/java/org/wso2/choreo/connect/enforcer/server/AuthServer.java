@@ -52,6 +52,7 @@ import org.wso2.choreo.connect.enforcer.throttle.ThrottleAgent;
 import org.wso2.choreo.connect.enforcer.throttle.ThrottleConstants;
 import org.wso2.choreo.connect.enforcer.throttle.ThrottleDataHolder;
 import org.wso2.choreo.connect.enforcer.throttle.ThrottleEventListener;
+import org.wso2.choreo.connect.enforcer.throttle.quotasync.QuotaSyncClient;
 import org.wso2.choreo.connect.enforcer.tracing.TracerFactory;
 import org.wso2.choreo.connect.enforcer.tracing.TracingException;
 import org.wso2.choreo.connect.enforcer.tracing.Utils;
@@ -111,7 +112,8 @@ public class AuthServer {
 
             // Enable global filters
             if (enforcerConfig.getAnalyticsConfig().isEnabled() ||
-                    enforcerConfig.getMetricsConfig().isMetricsEnabled()) {
+                    enforcerConfig.getMetricsConfig().isMetricsEnabled() ||
+                    enforcerConfig.getThrottleConfig().isConcurrencyLimitsEnabled()) {
                 AccessLoggingService accessLoggingService = new AccessLoggingService();
                 accessLoggingService.init();
                 if (enforcerConfig.getMetricsConfig().isMetricsEnabled()) {
@@ -146,6 +148,9 @@ public class AuthServer {
             KeyManagerHolder.getInstance().init();
             RevokedJWTDataHolder.getInstance().init();
             ThrottleDataHolder.getInstance().init();
+            if (QuotaSyncClient.isEnabled()) {
+                QuotaSyncClient.getInstance().start();
+            }
 
             // Create a new server to listen on port 8082
             RestServer restServer = new RestServer();
@@ -37,6 +37,7 @@ import org.wso2.choreo.connect.enforcer.constants.AdapterConstants;
 import org.wso2.choreo.connect.enforcer.constants.HttpConstants;
 import org.wso2.choreo.connect.enforcer.graphql.GraphQLPayloadUtils;
 import org.wso2.choreo.connect.enforcer.util.FilterUtils;
+import org.wso2.choreo.connect.enforcer.websub.WebSubConstants;
 
 import java.util.ArrayList;
 import java.util.Map;
@@ -141,10 +142,41 @@ public class HttpRequestHandler implements RequestHandler<CheckRequest, Response
                 resourceConfigs.add(resourceConfig);
             }
         }
-        return new RequestContext.Builder(requestPath).matchedResourceConfigs(resourceConfigs).requestMethod(method)
+        RequestContext requestContext = new RequestContext.Builder(requestPath)
+                .matchedResourceConfigs(resourceConfigs).requestMethod(method)
                 .certificate(certificate).matchedAPI(api.getAPIConfig()).headers(headers).requestID(requestID)
                 .address(address).prodClusterHeader(prodCluster).sandClusterHeader(sandCluster)
                 .requestTimeStamp(requestTimeInMillis).pathTemplate(pathTemplate).requestPayload(requestPayload)
                 .build();
+        populateWebSubSignature(requestContext, request);
+        String subscriptionValidation = request.getAttributes().getContextExtensionsMap()
+                .get(APIConstants.GW_SUBSCRIPTION_VALIDATION_PARAM);
+        if (subscriptionValidation != null) {
+            requestContext.getProperties().put(APIConstants.GW_SUBSCRIPTION_VALIDATION_PARAM,
+                    Boolean.parseBoolean(subscriptionValidation));
+        }
+        return requestContext;
+    }
+
+    /**
+     * Populates the signature config of the events receiver of a WebSub API, along with the raw body of the event
+     * which the signature is computed over.
+     */
+    private void populateWebSubSignature(RequestContext requestContext, CheckRequest request) {
+        Map<String, String> contextExtensions = request.getAttributes().getContextExtensionsMap();
+        String secret = contextExtensions.get(WebSubConstants.SECRET_CONTEXT_EXTENSION);
+        if (secret == null) {
+            return;
+        }
+        requestContext.getProperties().put(WebSubConstants.SECRET_PROPERTY, secret);
+        requestContext.getProperties().put(WebSubConstants.SIGNING_ALGORITHM_PROPERTY,
+                contextExtensions.get(WebSubConstants.SIGNING_ALGORITHM_CONTEXT_EXTENSION));
+        requestContext.getProperties().put(WebSubConstants.SIGNATURE_HEADER_PROPERTY,
+                contextExtensions.get(WebSubConstants.SIGNATURE_HEADER_CONTEXT_EXTENSION));
+        ByteString body = request.getAttributes().getRequest().getHttp().getRawBody();
+        if (body.isEmpty()) {
+            body = request.getAttributes().getRequest().getHttp().getBodyBytes();
+        }
+        requestContext.getProperties().put(WebSubConstants.BODY_PROPERTY, body.toByteArray());
     }
 }
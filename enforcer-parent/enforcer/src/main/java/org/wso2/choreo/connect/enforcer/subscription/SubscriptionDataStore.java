@@ -24,6 +24,7 @@ import org.wso2.choreo.connect.enforcer.models.ApiPolicy;
 import org.wso2.choreo.connect.enforcer.models.Application;
 import org.wso2.choreo.connect.enforcer.models.ApplicationKeyMapping;
 import org.wso2.choreo.connect.enforcer.models.ApplicationPolicy;
+import org.wso2.choreo.connect.enforcer.models.Scope;
 import org.wso2.choreo.connect.enforcer.models.Subscription;
 import org.wso2.choreo.connect.enforcer.models.SubscriptionPolicy;
 
@@ -107,6 +108,8 @@ public interface SubscriptionDataStore {
     void addApplicationKeyMappings(
             List<org.wso2.choreo.connect.discovery.subscription.ApplicationKeyMapping> applicationKeyMappingList);
 
+    void addScopes(List<org.wso2.choreo.connect.discovery.subscription.Scope> scopeList);
+
     void addOrUpdateApplication(Application application);
 
     void addOrUpdateSubscription(Subscription subscription);
@@ -200,4 +203,23 @@ public interface SubscriptionDataStore {
      */
     List<SubscriptionPolicy> getMatchingSubscriptionPolicies(String policyName);
 
+    /**
+     * Gets a {@link Scope} of the control plane along with the roles bound to it.
+     *
+     * @param tenantDomain tenant domain of the scope
+     * @param scopeName    name of the scope
+     * @return {@link Scope} or null if the scope is not known
+     */
+    Scope getScope(String tenantDomain, String scopeName);
+
+    /**
+     * Checks whether a scope is deleted in the control plane after it was received by the enforcer. Such a scope
+     * does not grant access even if it is present in a token issued earlier.
+     *
+     * @param tenantDomain tenant domain of the scope
+     * @param scopeName    name of the scope
+     * @return true if the scope is deleted
+     */
+    boolean isScopeDeleted(String tenantDomain, String scopeName);
+
 }
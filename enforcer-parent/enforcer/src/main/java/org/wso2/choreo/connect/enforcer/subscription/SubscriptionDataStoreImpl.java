@@ -27,6 +27,7 @@ import org.wso2.choreo.connect.enforcer.discovery.ApiListDiscoveryClient;
 import org.wso2.choreo.connect.enforcer.discovery.ApplicationDiscoveryClient;
 import org.wso2.choreo.connect.enforcer.discovery.ApplicationKeyMappingDiscoveryClient;
 import org.wso2.choreo.connect.enforcer.discovery.ApplicationPolicyDiscoveryClient;
+import org.wso2.choreo.connect.enforcer.discovery.ScopeDiscoveryClient;
 import org.wso2.choreo.connect.enforcer.discovery.SubscriptionDiscoveryClient;
 import org.wso2.choreo.connect.enforcer.discovery.SubscriptionPolicyDiscoveryClient;
 import org.wso2.choreo.connect.enforcer.models.API;
@@ -35,6 +36,7 @@ import org.wso2.choreo.connect.enforcer.models.Application;
 import org.wso2.choreo.connect.enforcer.models.ApplicationKeyMapping;
 import org.wso2.choreo.connect.enforcer.models.ApplicationKeyMappingCacheKey;
 import org.wso2.choreo.connect.enforcer.models.ApplicationPolicy;
+import org.wso2.choreo.connect.enforcer.models.Scope;
 import org.wso2.choreo.connect.enforcer.models.Subscription;
 import org.wso2.choreo.connect.enforcer.models.SubscriptionPolicy;
 
@@ -72,6 +74,9 @@ public class SubscriptionDataStoreImpl implements SubscriptionDataStore {
     private Map<String, SubscriptionPolicy> subscriptionPolicyMap;
     private Map<String, ApplicationPolicy> appPolicyMap;
     private Map<String, Subscription> subscriptionMap;
+    private Map<String, Scope> scopeMap;
+    // scopes removed from the scope catalog of the control plane, which are still present in the issued tokens
+    private Set<String> deletedScopes;
     private String tenantDomain = APIConstants.SUPER_TENANT_DOMAIN_NAME;
 
     SubscriptionDataStoreImpl() {
@@ -90,6 +95,8 @@ public class SubscriptionDataStoreImpl implements SubscriptionDataStore {
         this.appPolicyMap = new ConcurrentHashMap<>();
         this.apiPolicyMap = new ConcurrentHashMap<>();
         this.subscriptionMap = new ConcurrentHashMap<>();
+        this.scopeMap = new ConcurrentHashMap<>();
+        this.deletedScopes = ConcurrentHashMap.newKeySet();
         initializeLoadingTasks();
     }
 
@@ -146,6 +153,7 @@ public class SubscriptionDataStoreImpl implements SubscriptionDataStore {
         ApplicationPolicyDiscoveryClient.getInstance().watchApplicationPolicies();
         SubscriptionPolicyDiscoveryClient.getInstance().watchSubscriptionPolicies();
         ApplicationKeyMappingDiscoveryClient.getInstance().watchApplicationKeyMappings();
+        ScopeDiscoveryClient.getInstance().watchScopes();
     }
 
     public void addSubscriptions(List<org.wso2.choreo.connect.discovery.subscription.Subscription> subscriptionList) {
@@ -282,6 +290,32 @@ public class SubscriptionDataStoreImpl implements SubscriptionDataStore {
         this.applicationKeyMappingMap = newApplicationKeyMappingMap;
     }
 
+    public void addScopes(List<org.wso2.choreo.connect.discovery.subscription.Scope> scopeList) {
+        Map<String, Scope> newScopeMap = new ConcurrentHashMap<>();
+
+        for (org.wso2.choreo.connect.discovery.subscription.Scope scope : scopeList) {
+            Scope newScope = new Scope();
+            newScope.setName(scope.getName());
+            newScope.setDisplayName(scope.getDisplayName());
+            newScope.setDescription(scope.getDescription());
+            newScope.setRoles(new ArrayList<>(scope.getRolesList()));
+            newScope.setTenantId(scope.getTenantId());
+            newScope.setTenantDomain(scope.getTenantDomain());
+
+            newScopeMap.put(newScope.getCacheKey(), newScope);
+        }
+        for (String scopeKey : scopeMap.keySet()) {
+            if (!newScopeMap.containsKey(scopeKey)) {
+                deletedScopes.add(scopeKey);
+            }
+        }
+        deletedScopes.removeAll(newScopeMap.keySet());
+        if (log.isDebugEnabled()) {
+            log.debug("Total Scopes in new cache: {}, deleted scopes: {}", newScopeMap.size(), deletedScopes.size());
+        }
+        this.scopeMap = newScopeMap;
+    }
+
     @Override
     public void addOrUpdateSubscription(Subscription subscription) {
 
@@ -520,4 +554,14 @@ public class SubscriptionDataStoreImpl implements SubscriptionDataStore {
         }
         return subscriptionPolicies;
     }
+
+    @Override
+    public Scope getScope(String tenantDomain, String scopeName) {
+        return scopeMap.get(tenantDomain + DELEM_PERIOD + scopeName);
+    }
+
+    @Override
+    public boolean isScopeDeleted(String tenantDomain, String scopeName) {
+        return deletedScopes.contains(tenantDomain + DELEM_PERIOD + scopeName);
+    }
 }
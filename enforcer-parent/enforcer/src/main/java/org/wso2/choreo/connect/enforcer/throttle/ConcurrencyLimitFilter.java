@@ -0,0 +1,67 @@
+/*
+ * Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package org.wso2.choreo.connect.enforcer.throttle;
+
+import org.apache.logging.log4j.LogManager;
+import org.apache.logging.log4j.Logger;
+import org.wso2.choreo.connect.enforcer.commons.Filter;
+import org.wso2.choreo.connect.enforcer.commons.model.APIConfig;
+import org.wso2.choreo.connect.enforcer.commons.model.AuthenticationContext;
+import org.wso2.choreo.connect.enforcer.commons.model.RequestContext;
+import org.wso2.choreo.connect.enforcer.util.FilterUtils;
+
+import java.util.Map;
+
+/**
+ * Rejects the requests of an application, once the application reaches the maximum number of in-flight requests
+ * allowed for the API.
+ */
+public class ConcurrencyLimitFilter implements Filter {
+    private static final Logger log = LogManager.getLogger(ConcurrencyLimitFilter.class);
+
+    private APIConfig apiConfig;
+
+    @Override
+    public void init(APIConfig apiConfig, Map<String, String> configProperties) {
+        this.apiConfig = apiConfig;
+    }
+
+    @Override
+    public boolean handleRequest(RequestContext requestContext) {
+        int limit = apiConfig.getMaxConcurrentRequestsPerApplication();
+        AuthenticationContext authContext = requestContext.getAuthenticationContext();
+        if (limit <= 0 || authContext == null || authContext.getApplicationUUID() == null
+                || AuthenticationContext.UNKNOWN_VALUE.equals(authContext.getApplicationUUID())) {
+            return true;
+        }
+        String key = apiConfig.getUuid() + ":" + authContext.getApplicationUUID();
+        if (ConcurrencyLimiter.getInstance().tryAcquire(requestContext.getRequestID(), key, limit)) {
+            return true;
+        }
+        log.debug("Request is rejected as the application {} has reached the maximum of {} concurrent requests " +
+                "for the API {}:{}", authContext.getApplicationName(), limit, apiConfig.getName(),
+                apiConfig.getVersion());
+        FilterUtils.setThrottleErrorToContext(requestContext,
+                ThrottleConstants.CONCURRENCY_LIMIT_EXCEEDED_ERROR_CODE,
+                ThrottleConstants.THROTTLE_OUT_MESSAGE,
+                ThrottleConstants.CONCURRENCY_LIMIT_EXCEEDED_DESCRIPTION);
+        requestContext.getProperties().put(ThrottleConstants.THROTTLE_OUT_REASON,
+                ThrottleConstants.THROTTLE_OUT_REASON_CONCURRENCY_LIMIT_EXCEEDED);
+        return false;
+    }
+}
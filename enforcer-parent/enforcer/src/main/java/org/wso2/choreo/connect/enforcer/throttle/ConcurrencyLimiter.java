@@ -0,0 +1,148 @@
+/*
+ * Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package org.wso2.choreo.connect.enforcer.throttle;
+
+import io.envoyproxy.envoy.data.accesslog.v3.HTTPAccessLogEntry;
+import io.envoyproxy.envoy.service.accesslog.v3.StreamAccessLogsMessage;
+import org.apache.logging.log4j.LogManager;
+import org.apache.logging.log4j.Logger;
+
+import java.util.Iterator;
+import java.util.Map;
+import java.util.concurrent.ConcurrentHashMap;
+import java.util.concurrent.Executors;
+import java.util.concurrent.ScheduledExecutorService;
+import java.util.concurrent.TimeUnit;
+
+/**
+ * Counts the in-flight requests of the applications to enforce the concurrency limits of the APIs. A request is
+ * counted once it is allowed by the enforcer, and it is released when the access log entry of the request is
+ * received from the router.
+ */
+public class ConcurrencyLimiter {
+    private static final Logger log = LogManager.getLogger(ConcurrencyLimiter.class);
+    // Requests are released after this period even if the access log entry is not received (ie: the router restarts).
+    private static final long LEASE_EXPIRY_MILLIS = TimeUnit.HOURS.toMillis(1);
+    private static final long LEASE_CLEANUP_INTERVAL_SECONDS = 60;
+    private static volatile ConcurrencyLimiter instance;
+
+    private final Map<String, Integer> inFlightRequests = new ConcurrentHashMap<>();
+    private final Map<String, Lease> leases = new ConcurrentHashMap<>();
+
+    private ConcurrencyLimiter() {
+    }
+
+    public static ConcurrencyLimiter getInstance() {
+        if (instance == null) {
+            synchronized (ConcurrencyLimiter.class) {
+                if (instance == null) {
+                    instance = new ConcurrencyLimiter();
+                    ScheduledExecutorService cleanupScheduler = Executors.newSingleThreadScheduledExecutor(r -> {
+                        Thread thread = new Thread(r, "concurrency-limiter-cleanup");
+                        thread.setDaemon(true);
+                        return thread;
+                    });
+                    cleanupScheduler.scheduleWithFixedDelay(instance::releaseExpiredLeases,
+                            LEASE_CLEANUP_INTERVAL_SECONDS, LEASE_CLEANUP_INTERVAL_SECONDS, TimeUnit.SECONDS);
+                }
+            }
+        }
+        return instance;
+    }
+
+    /**
+     * Counts the request as an in-flight request of the given key, if the limit is not reached.
+     *
+     * @param requestId ID of the request, which is used to release the request
+     * @param key       key of the counter (ie: API and application)
+     * @param limit     maximum number of in-flight requests of the key
+     * @return true if the request is allowed
+     */
+    public boolean tryAcquire(String requestId, String key, int limit) {
+        if (leases.containsKey(requestId)) {
+            // The request is already counted (ie: the request is retried by the router)
+            return true;
+        }
+        boolean[] acquired = {false};
+        inFlightRequests.compute(key, (k, count) -> {
+            int current = count == null ? 0 : count;
+            if (current >= limit) {
+                return count;
+            }
+            acquired[0] = true;
+            return current + 1;
+        });
+        if (acquired[0]) {
+            leases.put(requestId, new Lease(key, System.currentTimeMillis()));
+        }
+        return acquired[0];
+    }
+
+    /**
+     * Releases the in-flight request, if the request is counted.
+     *
+     * @param requestId ID of the request
+     */
+    public void release(String requestId) {
+        Lease lease = leases.remove(requestId);
+        if (lease != null) {
+            inFlightRequests.computeIfPresent(lease.key, (k, count) -> count > 1 ? count - 1 : null);
+        }
+    }
+
+    /**
+     * Releases the requests of the access log entries received from the router.
+     *
+     * @param message access log entries
+     */
+    public void handleGRPCLogMsg(StreamAccessLogsMessage message) {
+        if (leases.isEmpty()) {
+            return;
+        }
+        for (HTTPAccessLogEntry logEntry : message.getHttpLogs().getLogEntryList()) {
+            release(logEntry.getRequest().getRequestId());
+        }
+    }
+
+    public int getInFlightRequests(String key) {
+        return inFlightRequests.getOrDefault(key, 0);
+    }
+
+    private void releaseExpiredLeases() {
+        long expiredBefore = System.currentTimeMillis() - LEASE_EXPIRY_MILLIS;
+        Iterator<Map.Entry<String, Lease>> iterator = leases.entrySet().iterator();
+        while (iterator.hasNext()) {
+            Map.Entry<String, Lease> entry = iterator.next();
+            if (entry.getValue().acquiredAt < expiredBefore) {
+                log.debug("Releasing the in-flight request {} as the access log entry is not received.",
+                        entry.getKey());
+                release(entry.getKey());
+            }
+        }
+    }
+
+    private static class Lease {
+        private final String key;
+        private final long acquiredAt;
+
+        private Lease(String key, long acquiredAt) {
+            this.key = key;
+            this.acquiredAt = acquiredAt;
+        }
+    }
+}
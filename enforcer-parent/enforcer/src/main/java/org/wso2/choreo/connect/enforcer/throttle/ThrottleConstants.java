@@ -30,11 +30,14 @@ public class ThrottleConstants {
     public static final int CUSTOM_POLICY_THROTTLE_OUT_ERROR_CODE = 900806;
     // This value is used to assign when the actual throttle policy is not properly assigned. If this
     public static final int THROTTLE_CONDITION_UNKNOWN = 900807;
+    public static final int CONCURRENCY_LIMIT_EXCEEDED_ERROR_CODE = 900808;
 
     public static final String THROTTLE_OUT_MESSAGE = "Message throttled out";
     public static final String THROTTLE_OUT_DESCRIPTION = "You have exceeded your quota";
     public static final String BLOCKING_MESSAGE = "Message blocked";
     public static final String BLOCKING_DESCRIPTION = "You have been blocked from accessing the resource";
+    public static final String CONCURRENCY_LIMIT_EXCEEDED_DESCRIPTION =
+            "You have exceeded the maximum number of concurrent requests";
 
     public static final String THROTTLE_OUT_REASON_API_LIMIT_EXCEEDED = "API_LIMIT_EXCEEDED";
     public static final String THROTTLE_OUT_REASON_RESOURCE_LIMIT_EXCEEDED = "RESOURCE_LIMIT_EXCEEDED";
@@ -42,6 +45,7 @@ public class ThrottleConstants {
     public static final String THROTTLE_OUT_REASON_APPLICATION_LIMIT_EXCEEDED = "APPLICATION_LIMIT_EXCEEDED";
     public static final String THROTTLE_OUT_REASON_CUSTOM_LIMIT_EXCEED = "CUSTOM_POLICY_LIMIT_EXCEED";
     public static final String THROTTLE_OUT_REASON_REQUEST_BLOCKED = "REQUEST_BLOCKED";
+    public static final String THROTTLE_OUT_REASON_CONCURRENCY_LIMIT_EXCEEDED = "CONCURRENCY_LIMIT_EXCEEDED";
 
     public static final String UNLIMITED_TIER = "Unlimited";
     public static final String IP = "ip";
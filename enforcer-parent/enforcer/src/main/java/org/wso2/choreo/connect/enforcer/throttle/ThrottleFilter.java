@@ -51,6 +51,8 @@ import java.util.ArrayList;
 import java.util.HashMap;
 import java.util.Map;
 
+import static org.wso2.choreo.connect.enforcer.constants.MetadataConstants.APP_ATTRIBUTE_KEY_PREFIX;
+
 /**
  * This is the filter handling the authentication for the requests flowing through the gateway.
  */
@@ -504,6 +506,13 @@ public class ThrottleFilter implements Filter {
             }
         }
 
+        Map<String, String> applicationAttributes =
+                requestContext.getAuthenticationContext().getApplicationAttributes();
+        if (applicationAttributes != null) {
+            applicationAttributes.forEach((name, value) ->
+                    jsonObMap.put(APP_ATTRIBUTE_KEY_PREFIX + name, value));
+        }
+
         // If custom throttle properties exist, add custom properties to properties map.
         if (!customPropertyString.equals("null")) {
             String[] customPropertyList = customPropertyString.split(" ");
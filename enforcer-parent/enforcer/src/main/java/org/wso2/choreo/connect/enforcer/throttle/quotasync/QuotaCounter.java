@@ -0,0 +1,107 @@
+/*
+ * Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package org.wso2.choreo.connect.enforcer.throttle.quotasync;
+
+import com.google.protobuf.CodedInputStream;
+import com.google.protobuf.CodedOutputStream;
+
+import java.io.IOException;
+
+/**
+ * Count of the requests of a throttle key within a fixed window, as the QuotaCounter message of the quota sync
+ * service of the adapter. The enforcer reports the count of its replica, while the adapter distributes the count
+ * of all the replicas.
+ */
+public class QuotaCounter {
+    private static final int KEY_FIELD = 1;
+    private static final int WINDOW_START_MILLIS_FIELD = 2;
+    private static final int WINDOW_MILLIS_FIELD = 3;
+    private static final int COUNT_FIELD = 4;
+
+    private final String key;
+    private final long windowStartMillis;
+    private final long windowMillis;
+    private final long count;
+
+    public QuotaCounter(String key, long windowStartMillis, long windowMillis, long count) {
+        this.key = key;
+        this.windowStartMillis = windowStartMillis;
+        this.windowMillis = windowMillis;
+        this.count = count;
+    }
+
+    public String getKey() {
+        return key;
+    }
+
+    public long getWindowStartMillis() {
+        return windowStartMillis;
+    }
+
+    public long getWindowMillis() {
+        return windowMillis;
+    }
+
+    public long getCount() {
+        return count;
+    }
+
+    int getSerializedSize() {
+        return CodedOutputStream.computeStringSize(KEY_FIELD, key)
+                + CodedOutputStream.computeInt64Size(WINDOW_START_MILLIS_FIELD, windowStartMillis)
+                + CodedOutputStream.computeInt64Size(WINDOW_MILLIS_FIELD, windowMillis)
+                + CodedOutputStream.computeInt64Size(COUNT_FIELD, count);
+    }
+
+    void writeTo(CodedOutputStream output) throws IOException {
+        output.writeString(KEY_FIELD, key);
+        output.writeInt64(WINDOW_START_MILLIS_FIELD, windowStartMillis);
+        output.writeInt64(WINDOW_MILLIS_FIELD, windowMillis);
+        output.writeInt64(COUNT_FIELD, count);
+    }
+
+    /**
+     * Reads a counter from the input, which is limited to the length of the counter message.
+     */
+    static QuotaCounter parseFrom(CodedInputStream input) throws IOException {
+        String key = "";
+        long windowStartMillis = 0;
+        long windowMillis = 0;
+        long count = 0;
+        int tag;
+        while ((tag = input.readTag()) != 0) {
+            switch (tag >>> 3) {
+                case KEY_FIELD:
+                    key = input.readStringRequireUtf8();
+                    break;
+                case WINDOW_START_MILLIS_FIELD:
+                    windowStartMillis = input.readInt64();
+                    break;
+                case WINDOW_MILLIS_FIELD:
+                    windowMillis = input.readInt64();
+                    break;
+                case COUNT_FIELD:
+                    count = input.readInt64();
+                    break;
+                default:
+                    input.skipField(tag);
+            }
+        }
+        return new QuotaCounter(key, windowStartMillis, windowMillis, count);
+    }
+}
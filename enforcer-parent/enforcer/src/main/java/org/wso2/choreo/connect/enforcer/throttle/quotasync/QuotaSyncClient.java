@@ -0,0 +1,347 @@
+/*
+ * Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package org.wso2.choreo.connect.enforcer.throttle.quotasync;
+
+import com.google.protobuf.CodedInputStream;
+import com.google.protobuf.CodedOutputStream;
+import com.google.protobuf.WireFormat;
+import io.grpc.CallOptions;
+import io.grpc.ManagedChannel;
+import io.grpc.MethodDescriptor;
+import io.grpc.stub.ClientCalls;
+import io.grpc.stub.StreamObserver;
+import org.apache.logging.log4j.LogManager;
+import org.apache.logging.log4j.Logger;
+import org.wso2.choreo.connect.enforcer.commons.logging.ErrorDetails;
+import org.wso2.choreo.connect.enforcer.commons.logging.LoggingConstants;
+import org.wso2.choreo.connect.enforcer.config.ConfigHolder;
+import org.wso2.choreo.connect.enforcer.config.EnvVarConfig;
+import org.wso2.choreo.connect.enforcer.util.GRPCUtils;
+
+import java.io.ByteArrayInputStream;
+import java.io.IOException;
+import java.io.InputStream;
+import java.io.UncheckedIOException;
+import java.util.ArrayList;
+import java.util.List;
+import java.util.Map;
+import java.util.concurrent.ConcurrentHashMap;
+import java.util.concurrent.Executors;
+import java.util.concurrent.ScheduledExecutorService;
+import java.util.concurrent.TimeUnit;
+
+/**
+ * Client of the quota sync service of the adapter. The requests counted by this enforcer replica are reported to
+ * the adapter periodically, and the counts aggregated across the replicas of the gateway (ENFORCER_LABEL) are
+ * received back. Hence a limit is enforced approximately globally, by the count of all the replicas reported so far
+ * and the count of this replica not reported yet.
+ */
+public class QuotaSyncClient {
+    private static final Logger log = LogManager.getLogger(QuotaSyncClient.class);
+    private static final MethodDescriptor<QuotaUsageReport, QuotaUsageAggregate> STREAM_QUOTA_USAGE_METHOD =
+            MethodDescriptor.<QuotaUsageReport, QuotaUsageAggregate>newBuilder()
+                    .setType(MethodDescriptor.MethodType.BIDI_STREAMING)
+                    .setFullMethodName(MethodDescriptor.generateFullMethodName(
+                            "discovery.service.throttle.QuotaSyncService", "StreamQuotaUsage"))
+                    .setRequestMarshaller(new ReportMarshaller())
+                    .setResponseMarshaller(new AggregateMarshaller())
+                    .build();
+    private static volatile QuotaSyncClient instance;
+
+    private final String replicaId;
+    private final String label;
+    private final String host;
+    private final int port;
+    // counters of this replica, by the throttle key
+    private final Map<String, LocalCounter> localCounters = new ConcurrentHashMap<>();
+    // counters aggregated across the replicas by the adapter, by the throttle key
+    private final Map<String, QuotaCounter> aggregatedCounters = new ConcurrentHashMap<>();
+    private ManagedChannel channel;
+    private volatile StreamObserver<QuotaUsageReport> reportObserver;
+
+    private QuotaSyncClient(EnvVarConfig envVarConfig) {
+        this.replicaId = envVarConfig.getInstanceIdentifier();
+        this.label = envVarConfig.getEnforcerLabel();
+        this.host = envVarConfig.getAdapterHost();
+        this.port = Integer.parseInt(envVarConfig.getAdapterXdsPort());
+    }
+
+    public static QuotaSyncClient getInstance() {
+        if (instance == null) {
+            synchronized (QuotaSyncClient.class) {
+                if (instance == null) {
+                    instance = new QuotaSyncClient(ConfigHolder.getInstance().getEnvVarConfig());
+                }
+            }
+        }
+        return instance;
+    }
+
+    /**
+     * Returns whether the counts are synced across the enforcer replicas, as enabled by QUOTA_SYNC_ENABLED.
+     */
+    public static boolean isEnabled() {
+        return ConfigHolder.getInstance().getEnvVarConfig().isQuotaSyncEnabled();
+    }
+
+    /**
+     * Starts reporting the counters to the adapter periodically. The stream to the adapter is reconnected at the
+     * next report, if it is closed.
+     */
+    public void start() {
+        long interval = ConfigHolder.getInstance().getEnvVarConfig().getQuotaSyncReportInterval();
+        ScheduledExecutorService reportScheduler = Executors.newSingleThreadScheduledExecutor(r -> {
+            Thread thread = new Thread(r, "quota-sync-reporter");
+            thread.setDaemon(true);
+            return thread;
+        });
+        reportScheduler.scheduleWithFixedDelay(this::report, interval, interval, TimeUnit.MILLISECONDS);
+        log.info("Quota sync is enabled for the enforcer {} of the label {}, with a report interval of {} ms",
+                replicaId, label, interval);
+    }
+
+    /**
+     * Counts the request against the key, if the count of the key across the replicas within the current window
+     * is below the limit.
+     *
+     * @param key          throttle key
+     * @param windowMillis length of the window of the limit
+     * @param limit        maximum number of the requests of the key within a window
+     * @return true if the request is allowed
+     */
+    public boolean tryAcquire(String key, long windowMillis, long limit) {
+        long now = System.currentTimeMillis();
+        long windowStart = now - now % windowMillis;
+        LocalCounter counter = localCounters.computeIfAbsent(key, k -> new LocalCounter());
+        synchronized (counter) {
+            if (counter.windowStart != windowStart || counter.windowMillis != windowMillis) {
+                counter.reset(windowStart, windowMillis);
+            }
+            long globalCount = counter.count - counter.reportedCount;
+            QuotaCounter aggregated = aggregatedCounters.get(key);
+            if (aggregated != null && aggregated.getWindowStartMillis() == windowStart) {
+                globalCount += aggregated.getCount();
+            } else {
+                globalCount += counter.reportedCount;
+            }
+            if (globalCount >= limit) {
+                return false;
+            }
+            counter.count++;
+            return true;
+        }
+    }
+
+    /**
+     * Returns the end of the current window of the given length, in epoch milliseconds.
+     */
+    public static long getWindowEnd(long windowMillis) {
+        long now = System.currentTimeMillis();
+        return now - now % windowMillis + windowMillis;
+    }
+
+    private void report() {
+        try {
+            long now = System.currentTimeMillis();
+            List<QuotaCounter> changed = new ArrayList<>();
+            localCounters.forEach((key, counter) -> {
+                synchronized (counter) {
+                    if (counter.windowStart + counter.windowMillis <= now) {
+                        localCounters.remove(key);
+                    } else if (counter.count > counter.reportedCount) {
+                        changed.add(new QuotaCounter(key, counter.windowStart, counter.windowMillis, counter.count));
+                        counter.reportedCount = counter.count;
+                    }
+                }
+            });
+            aggregatedCounters.values().removeIf(aggregated ->
+                    aggregated.getWindowStartMillis() + aggregated.getWindowMillis() <= now);
+            if (changed.isEmpty() && reportObserver != null) {
+                return;
+            }
+            // the first report subscribes the replica to the aggregates, even if there are no counters
+            getReportObserver().onNext(new QuotaUsageReport(replicaId, label, changed));
+        } catch (RuntimeException e) {
+            log.error("Error while reporting the quota usage to the adapter. {}", e.getMessage(),
+                    ErrorDetails.errorLog(LoggingConstants.Severity.MINOR, 6905), e);
+            closeStream();
+        }
+    }
+
+    private synchronized StreamObserver<QuotaUsageReport> getReportObserver() {
+        if (reportObserver == null) {
+            if (GRPCUtils.isReInitRequired(channel)) {
+                channel = GRPCUtils.createSecuredChannel(log, host, port);
+            }
+            reportObserver = ClientCalls.asyncBidiStreamingCall(
+                    channel.newCall(STREAM_QUOTA_USAGE_METHOD, CallOptions.DEFAULT), new AggregateObserver());
+        }
+        return reportObserver;
+    }
+
+    private synchronized void closeStream() {
+        reportObserver = null;
+    }
+
+    private void onAggregate(QuotaUsageAggregate aggregate) {
+        for (QuotaCounter counter : aggregate.counters) {
+            aggregatedCounters.merge(counter.getKey(), counter, (existing, received) ->
+                    received.getWindowStartMillis() >= existing.getWindowStartMillis() ? received : existing);
+        }
+        log.debug("Received {} aggregated counters of {} replicas", aggregate.counters.size(), aggregate.replicas);
+    }
+
+    private class AggregateObserver implements StreamObserver<QuotaUsageAggregate> {
+        @Override
+        public void onNext(QuotaUsageAggregate aggregate) {
+            onAggregate(aggregate);
+        }
+
+        @Override
+        public void onError(Throwable throwable) {
+            log.error("Quota sync stream to the adapter is closed, hence the limits are enforced per replica until "
+                            + "reconnected. {}", throwable.getMessage(),
+                    ErrorDetails.errorLog(LoggingConstants.Severity.MINOR, 6906));
+            closeStream();
+        }
+
+        @Override
+        public void onCompleted() {
+            log.info("Quota sync stream to the adapter is completed");
+            closeStream();
+        }
+    }
+
+    /**
+     * Count of a key by this replica within the current window, and the count already reported to the adapter.
+     */
+    private static class LocalCounter {
+        private long windowStart;
+        private long windowMillis;
+        private long count;
+        private long reportedCount;
+
+        private void reset(long windowStart, long windowMillis) {
+            this.windowStart = windowStart;
+            this.windowMillis = windowMillis;
+            this.count = 0;
+            this.reportedCount = 0;
+        }
+    }
+
+    /**
+     * QuotaUsageReport message of the quota sync service.
+     */
+    static class QuotaUsageReport {
+        private final String replicaId;
+        private final String label;
+        private final List<QuotaCounter> counters;
+
+        QuotaUsageReport(String replicaId, String label, List<QuotaCounter> counters) {
+            this.replicaId = replicaId;
+            this.label = label;
+            this.counters = counters;
+        }
+    }
+
+    /**
+     * QuotaUsageAggregate message of the quota sync service.
+     */
+    static class QuotaUsageAggregate {
+        private final List<QuotaCounter> counters;
+        private final int replicas;
+
+        QuotaUsageAggregate(List<QuotaCounter> counters, int replicas) {
+            this.counters = counters;
+            this.replicas = replicas;
+        }
+    }
+
+    /**
+     * Serializes the reports in the protobuf wire format of the QuotaUsageReport message.
+     */
+    static class ReportMarshaller implements MethodDescriptor.Marshaller<QuotaUsageReport> {
+        @Override
+        public InputStream stream(QuotaUsageReport report) {
+            int size = CodedOutputStream.computeStringSize(1, report.replicaId)
+                    + CodedOutputStream.computeStringSize(2, report.label);
+            for (QuotaCounter counter : report.counters) {
+                size += CodedOutputStream.computeTagSize(3)
+                        + CodedOutputStream.computeUInt32SizeNoTag(counter.getSerializedSize())
+                        + counter.getSerializedSize();
+            }
+            byte[] bytes = new byte[size];
+            CodedOutputStream output = CodedOutputStream.newInstance(bytes);
+            try {
+                output.writeString(1, report.replicaId);
+                output.writeString(2, report.label);
+                for (QuotaCounter counter : report.counters) {
+                    output.writeTag(3, WireFormat.WIRETYPE_LENGTH_DELIMITED);
+                    output.writeUInt32NoTag(counter.getSerializedSize());
+                    counter.writeTo(output);
+                }
+                output.checkNoSpaceLeft();
+            } catch (IOException e) {
+                throw new UncheckedIOException(e);
+            }
+            return new ByteArrayInputStream(bytes);
+        }
+
+        @Override
+        public QuotaUsageReport parse(InputStream stream) {
+            throw new UnsupportedOperationException("Quota usage reports are only sent by the enforcer");
+        }
+    }
+
+    /**
+     * Parses the aggregates in the protobuf wire format of the QuotaUsageAggregate message.
+     */
+    static class AggregateMarshaller implements MethodDescriptor.Marshaller<QuotaUsageAggregate> {
+        @Override
+        public InputStream stream(QuotaUsageAggregate aggregate) {
+            throw new UnsupportedOperationException("Quota usage aggregates are only sent by the adapter");
+        }
+
+        @Override
+        public QuotaUsageAggregate parse(InputStream stream) {
+            CodedInputStream input = CodedInputStream.newInstance(stream);
+            List<QuotaCounter> counters = new ArrayList<>();
+            int replicas = 0;
+            try {
+                int tag;
+                while ((tag = input.readTag()) != 0) {
+                    switch (tag >>> 3) {
+                        case 1:
+                            int limit = input.pushLimit(input.readRawVarint32());
+                            counters.add(QuotaCounter.parseFrom(input));
+                            input.popLimit(limit);
+                            break;
+                        case 2:
+                            replicas = input.readInt32();
+                            break;
+                        default:
+                            input.skipField(tag);
+                    }
+                }
+            } catch (IOException e) {
+                throw new UncheckedIOException(e);
+            }
+            return new QuotaUsageAggregate(counters, replicas);
+        }
+    }
+}
@@ -0,0 +1,115 @@
+/*
+ * Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package org.wso2.choreo.connect.enforcer.throttle.quotasync;
+
+import org.apache.logging.log4j.LogManager;
+import org.apache.logging.log4j.Logger;
+import org.wso2.choreo.connect.enforcer.commons.Filter;
+import org.wso2.choreo.connect.enforcer.commons.model.APIConfig;
+import org.wso2.choreo.connect.enforcer.commons.model.AuthenticationContext;
+import org.wso2.choreo.connect.enforcer.commons.model.RequestContext;
+import org.wso2.choreo.connect.enforcer.models.SubscriptionPolicy;
+import org.wso2.choreo.connect.enforcer.subscription.SubscriptionDataHolder;
+import org.wso2.choreo.connect.enforcer.throttle.ThrottleConstants;
+import org.wso2.choreo.connect.enforcer.throttle.utils.ThrottleUtils;
+import org.wso2.choreo.connect.enforcer.util.FilterUtils;
+
+import java.util.Locale;
+import java.util.Map;
+import java.util.concurrent.TimeUnit;
+
+/**
+ * Enforces the rate limit of the subscription policy (ie: 10 requests per minute) of a subscription across the
+ * enforcer replicas of the gateway, by the counts synced via the adapter. Hence the rate limit is enforced without a
+ * traffic manager, approximately, as the counts of the other replicas are received once per report interval.
+ */
+public class QuotaSyncThrottleFilter implements Filter {
+    private static final Logger log = LogManager.getLogger(QuotaSyncThrottleFilter.class);
+
+    private APIConfig apiConfig;
+
+    @Override
+    public void init(APIConfig apiConfig, Map<String, String> configProperties) {
+        this.apiConfig = apiConfig;
+    }
+
+    @Override
+    public boolean handleRequest(RequestContext requestContext) {
+        AuthenticationContext authContext = requestContext.getAuthenticationContext();
+        if (authContext == null || authContext.getTier() == null || authContext.getApplicationUUID() == null
+                || AuthenticationContext.UNKNOWN_VALUE.equals(authContext.getApplicationUUID())) {
+            return true;
+        }
+        SubscriptionPolicy policy = SubscriptionDataHolder.getInstance().getTenantSubscriptionStore()
+                .getSubscriptionPolicyByName(authContext.getTier());
+        if (policy == null || policy.getRateLimitTimeUnit() == null || policy.getRateLimitCount() <= 0) {
+            return true;
+        }
+        long windowMillis = getWindowMillis(policy.getRateLimitTimeUnit());
+        if (windowMillis <= 0) {
+            log.debug("Rate limit of the subscription policy {} is not synced, as the time unit {} is unknown",
+                    policy.getName(), policy.getRateLimitTimeUnit());
+            return true;
+        }
+        String key = apiConfig.getUuid() + ":" + authContext.getApplicationUUID();
+        if (QuotaSyncClient.getInstance().tryAcquire(key, windowMillis, policy.getRateLimitCount())) {
+            return true;
+        }
+        log.debug("Request is rejected as the application {} has reached the rate limit of {} requests per {} of " +
+                "the subscription policy {} for the API {}:{}", authContext.getApplicationName(),
+                policy.getRateLimitCount(), policy.getRateLimitTimeUnit(), policy.getName(), apiConfig.getName(),
+                apiConfig.getVersion());
+        if (!authContext.isStopOnQuotaReach()) {
+            log.debug("Proceeding since stopOnQuotaReach is false");
+            return true;
+        }
+        FilterUtils.setThrottleErrorToContext(requestContext,
+                ThrottleConstants.SUBSCRIPTION_THROTTLE_OUT_ERROR_CODE,
+                ThrottleConstants.THROTTLE_OUT_MESSAGE,
+                ThrottleConstants.THROTTLE_OUT_DESCRIPTION);
+        requestContext.getProperties().put(ThrottleConstants.THROTTLE_OUT_REASON,
+                ThrottleConstants.THROTTLE_OUT_REASON_SUBSCRIPTION_LIMIT_EXCEEDED);
+        ThrottleUtils.setRetryAfterHeader(requestContext, QuotaSyncClient.getWindowEnd(windowMillis));
+        return false;
+    }
+
+    /**
+     * Returns the length of the window of the rate limit time unit of a subscription policy (ie: sec or min), or 0
+     * if the time unit is unknown.
+     */
+    static long getWindowMillis(String timeUnit) {
+        switch (timeUnit.toLowerCase(Locale.ROOT)) {
+            case "sec":
+            case "second":
+            case "seconds":
+                return TimeUnit.SECONDS.toMillis(1);
+            case "min":
+            case "minute":
+            case "minutes":
+                return TimeUnit.MINUTES.toMillis(1);
+            case "hour":
+            case "hours":
+                return TimeUnit.HOURS.toMillis(1);
+            case "day":
+            case "days":
+                return TimeUnit.DAYS.toMillis(1);
+            default:
+                return 0;
+        }
+    }
+}
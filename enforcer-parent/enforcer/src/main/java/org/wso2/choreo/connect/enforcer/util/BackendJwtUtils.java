@@ -42,27 +42,31 @@ import java.util.HashMap;
 import java.util.Iterator;
 import java.util.Map;
 import java.util.ServiceLoader;
+import java.util.concurrent.ConcurrentHashMap;
 
 /**
  * Contains Util methods related to backend JWT generation.
  */
 public class BackendJwtUtils {
     private static final Logger log = LogManager.getLogger(BackendJwtUtils.class);
+    private static final Map<JWTConfigurationDto, AbstractAPIMgtGatewayJWTGenerator> jwtGenerators =
+            new ConcurrentHashMap<>();
 
     /**
      * Generates or gets the Cached Backend JWT token.
      *
-     * @param jwtGenerator the jwtGenerator instance to use if generating the token
+     * @param jwtConfigurationDto the backend JWT configuration of the API
      * @param tokenSignature token signature to use in the cache key
      * @param jwtInfoDto information to include in the jwt
      * @param isGatewayTokenCacheEnabled whether gateway token cache is enabled
      * @return backend jwt token
      * @throws APISecurityException if an error occurs while generating the token
      */
-    public static String generateAndRetrieveJWTToken(AbstractAPIMgtGatewayJWTGenerator jwtGenerator,
+    public static String generateAndRetrieveJWTToken(JWTConfigurationDto jwtConfigurationDto,
                                                      String tokenSignature, JWTInfoDto jwtInfoDto,
                                                boolean isGatewayTokenCacheEnabled) throws APISecurityException {
         log.debug("Inside generateAndRetrieveJWTToken");
+        AbstractAPIMgtGatewayJWTGenerator jwtGenerator = getJWTGenerator(jwtConfigurationDto);
         String endUserToken = null;
         boolean valid = false;
         String jwtTokenCacheKey = jwtInfoDto.getApiContext().concat(":").concat(jwtInfoDto.getVersion()).concat(":")
@@ -95,9 +99,6 @@ public class BackendJwtUtils {
     private static String generateToken(AbstractAPIMgtGatewayJWTGenerator jwtGenerator, JWTInfoDto jwtInfoDto,
                    boolean isGatewayTokenCacheEnabled, String jwtTokenCacheKey) throws APISecurityException {
         String endUserToken;
-        JWTConfigurationDto jwtConfigurationDto = ConfigHolder.getInstance().getConfig().
-                getJwtConfigurationDto();
-        jwtGenerator.setJWTConfigurationDto(jwtConfigurationDto);
         try {
             endUserToken = jwtGenerator.generateToken(jwtInfoDto);
             if (isGatewayTokenCacheEnabled) {
@@ -112,6 +113,23 @@ public class BackendJwtUtils {
         return endUserToken;
     }
 
+    /**
+     * Returns the backend JWT generator of the given configuration. A generator is created per configuration, as
+     * the configuration of a generator is shared by the concurrent requests.
+     *
+     * @param jwtConfigurationDto backend JWT configuration
+     * @return an instance of the JWT Generator given in the config, null if it could not be loaded
+     */
+    private static AbstractAPIMgtGatewayJWTGenerator getJWTGenerator(JWTConfigurationDto jwtConfigurationDto) {
+        return jwtGenerators.computeIfAbsent(jwtConfigurationDto, configurationDto -> {
+            AbstractAPIMgtGatewayJWTGenerator jwtGenerator = getApiMgtGatewayJWTGenerator();
+            if (jwtGenerator != null) {
+                jwtGenerator.setJWTConfigurationDto(configurationDto);
+            }
+            return jwtGenerator;
+        });
+    }
+
     /**
      * Load the specified backend JWT Generator.
      *
@@ -43,6 +43,7 @@ import org.wso2.choreo.connect.enforcer.commons.exception.EnforcerException;
 import org.wso2.choreo.connect.enforcer.commons.logging.ErrorDetails;
 import org.wso2.choreo.connect.enforcer.commons.logging.LoggingConstants;
 import org.wso2.choreo.connect.enforcer.commons.model.AuthenticationContext;
+import org.wso2.choreo.connect.enforcer.commons.model.BackendJWTConfig;
 import org.wso2.choreo.connect.enforcer.commons.model.RequestContext;
 import org.wso2.choreo.connect.enforcer.commons.model.SecuritySchemaConfig;
 import org.wso2.choreo.connect.enforcer.config.ConfigHolder;
@@ -51,6 +52,7 @@ import org.wso2.choreo.connect.enforcer.config.dto.MutualSSLDto;
 import org.wso2.choreo.connect.enforcer.constants.APIConstants;
 import org.wso2.choreo.connect.enforcer.constants.APISecurityConstants;
 import org.wso2.choreo.connect.enforcer.constants.JwtConstants;
+import org.wso2.choreo.connect.enforcer.constants.MetadataConstants;
 import org.wso2.choreo.connect.enforcer.dto.APIKeyValidationInfoDTO;
 import org.wso2.choreo.connect.enforcer.throttle.ThrottleConstants;
 
@@ -301,6 +303,12 @@ public class FilterUtils {
             authContext.setIsContentAware(apiKeyValidationInfoDTO.isContentAware());
             authContext.setApiUUID(apiKeyValidationInfoDTO.getApiUUID());
             authContext.setRawToken(rawToken);
+            if (apiKeyValidationInfoDTO.getAppAttributes() != null) {
+                authContext.setApplicationAttributes(apiKeyValidationInfoDTO.getAppAttributes());
+                // the attributes are referenced by the header policies of the router
+                apiKeyValidationInfoDTO.getAppAttributes().forEach((name, value) -> requestContext
+                        .addMetadataToMap(MetadataConstants.APP_ATTRIBUTE_KEY_PREFIX + name, value));
+            }
         }
         if (isOauth) {
             authContext.setConsumerKey(jwtValidationInfo.getConsumerKey());
@@ -401,12 +409,14 @@ public class FilterUtils {
         String apiVersion = requestContext.getMatchedAPI().getVersion();
         jwtInfoDto.setApiContext(apiContext);
         jwtInfoDto.setVersion(apiVersion);
-        constructJWTContent(subscribedAPI, apiKeyValidationInfoDTO, jwtInfoDto);
+        constructJWTContent(subscribedAPI, apiKeyValidationInfoDTO, jwtInfoDto,
+                requestContext.getMatchedAPI().getBackendJWTConfig());
         return jwtInfoDto;
     }
 
     private static void constructJWTContent(JSONObject subscribedAPI,
-                                            APIKeyValidationInfoDTO apiKeyValidationInfoDTO, JWTInfoDto jwtInfoDto) {
+                                            APIKeyValidationInfoDTO apiKeyValidationInfoDTO, JWTInfoDto jwtInfoDto,
+                                            BackendJWTConfig backendJWTConfig) {
 
         Map<String, Object> claims = getClaimsFromJWTValidationInfo(jwtInfoDto);
         if (claims != null) {
@@ -433,7 +443,8 @@ public class FilterUtils {
             jwtInfoDto.setApiName(apiKeyValidationInfoDTO.getApiName());
             jwtInfoDto.setEndUserTenantId(0);
             jwtInfoDto.setApplicationUUId(apiKeyValidationInfoDTO.getApplicationUUID());
-            jwtInfoDto.setAppAttributes(apiKeyValidationInfoDTO.getAppAttributes());
+            jwtInfoDto.setAppAttributes(filterAppAttributes(apiKeyValidationInfoDTO.getAppAttributes(),
+                    backendJWTConfig));
         } else if (subscribedAPI != null) {
             // If the user is subscribed to the API
             String apiName = subscribedAPI.getAsString(JwtConstants.API_NAME);
@@ -458,6 +469,25 @@ public class FilterUtils {
         }
     }
 
+    /**
+     * Returns the application attributes included in the backend JWT of the API. All the attributes are included
+     * if the API does not restrict them.
+     */
+    private static Map<String, String> filterAppAttributes(Map<String, String> appAttributes,
+                                                           BackendJWTConfig backendJWTConfig) {
+        if (appAttributes == null || backendJWTConfig == null
+                || backendJWTConfig.getApplicationAttributes().isEmpty()) {
+            return appAttributes;
+        }
+        Map<String, String> filteredAttributes = new HashMap<>();
+        for (String attribute : backendJWTConfig.getApplicationAttributes()) {
+            if (appAttributes.containsKey(attribute)) {
+                filteredAttributes.put(attribute, appAttributes.get(attribute));
+            }
+        }
+        return filteredAttributes;
+    }
+
     private static Map<String, Object> getClaimsFromJWTValidationInfo(JWTInfoDto jwtInfoDto) {
 
         if (jwtInfoDto.getJwtValidationInfo() != null) {
@@ -659,6 +689,23 @@ public class FilterUtils {
         }
     }
 
+    /**
+     * Returns whether the subscriptions are validated for the invoked API. The subscription validation of the API,
+     * if set by the adapter, overrides the subscription validation of the token issuer.
+     *
+     * @param requestContext request context of the invoked API
+     * @param defaultValue   subscription validation of the token issuer
+     * @return whether the subscriptions are validated
+     */
+    public static boolean isSubscriptionValidationEnabled(RequestContext requestContext, boolean defaultValue) {
+        Object subscriptionValidation = requestContext.getProperties()
+                .get(APIConstants.GW_SUBSCRIPTION_VALIDATION_PARAM);
+        if (subscriptionValidation instanceof Boolean) {
+            return (Boolean) subscriptionValidation;
+        }
+        return defaultValue;
+    }
+
     /**
      * HTTP client option constants that is used with the util function {@link #getHttpClient(String, KeyStore, Map)
      * getHttpClient}
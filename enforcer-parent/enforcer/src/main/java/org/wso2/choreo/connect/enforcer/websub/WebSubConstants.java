@@ -0,0 +1,38 @@
+/*
+ * Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package org.wso2.choreo.connect.enforcer.websub;
+
+/**
+ * Holds the constants of the signature verification of the WebSub events.
+ */
+public class WebSubConstants {
+    // Context extensions set by the adapter for the events receiver of a WebSub API
+    public static final String SECRET_CONTEXT_EXTENSION = "webSubSecret";
+    public static final String SIGNING_ALGORITHM_CONTEXT_EXTENSION = "webSubSigningAlgorithm";
+    public static final String SIGNATURE_HEADER_CONTEXT_EXTENSION = "webSubSignatureHeader";
+
+    // Properties of the request context, which carry the signature config and the raw body to the filter
+    public static final String SECRET_PROPERTY = "webSubSecret";
+    public static final String SIGNING_ALGORITHM_PROPERTY = "webSubSigningAlgorithm";
+    public static final String SIGNATURE_HEADER_PROPERTY = "webSubSignatureHeader";
+    public static final String BODY_PROPERTY = "webSubBody";
+
+    public static final String INVALID_SIGNATURE_MESSAGE = "Invalid Signature";
+    public static final String INVALID_SIGNATURE_DESCRIPTION =
+            "The signature of the event is missing or does not match the body of the event";
+}
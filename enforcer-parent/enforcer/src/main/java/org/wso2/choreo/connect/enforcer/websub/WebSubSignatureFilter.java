@@ -0,0 +1,103 @@
+/*
+ * Copyright (c) 2022, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package org.wso2.choreo.connect.enforcer.websub;
+
+import org.apache.commons.codec.binary.Hex;
+import org.apache.logging.log4j.LogManager;
+import org.apache.logging.log4j.Logger;
+import org.wso2.choreo.connect.enforcer.commons.Filter;
+import org.wso2.choreo.connect.enforcer.commons.model.RequestContext;
+import org.wso2.choreo.connect.enforcer.constants.APIConstants;
+import org.wso2.choreo.connect.enforcer.constants.APISecurityConstants;
+
+import java.nio.charset.StandardCharsets;
+import java.security.GeneralSecurityException;
+import java.security.MessageDigest;
+import java.util.Locale;
+import javax.crypto.Mac;
+import javax.crypto.spec.SecretKeySpec;
+
+/**
+ * WebSubSignatureFilter verifies the HMAC signature of the events published to the hub of a WebSub API, as the
+ * events receiver of the hub is not secured with tokens. The signature is read from the signature header in the
+ * form of method=signature (i.e. sha256=...), and the events without a valid signature are rejected.
+ */
+public class WebSubSignatureFilter implements Filter {
+
+    private static final Logger logger = LogManager.getLogger(WebSubSignatureFilter.class);
+
+    @Override
+    public boolean handleRequest(RequestContext requestContext) {
+        Object secret = requestContext.getProperties().get(WebSubConstants.SECRET_PROPERTY);
+        if (secret == null) {
+            return true;
+        }
+        String algorithm = String.valueOf(requestContext.getProperties()
+                .get(WebSubConstants.SIGNING_ALGORITHM_PROPERTY));
+        String signatureHeader = String.valueOf(requestContext.getProperties()
+                .get(WebSubConstants.SIGNATURE_HEADER_PROPERTY));
+        byte[] body = (byte[]) requestContext.getProperties().get(WebSubConstants.BODY_PROPERTY);
+        String signature = requestContext.getHeaders().get(signatureHeader);
+        if (isValidSignature(String.valueOf(secret), algorithm, signature, body)) {
+            return true;
+        }
+        logger.debug("Event of the WebSub API {} is rejected, as the signature is not valid",
+                requestContext.getMatchedAPI().getName());
+        requestContext.getProperties().put(APIConstants.MessageFormat.STATUS_CODE,
+                APIConstants.StatusCodes.UNAUTHENTICATED.getCode());
+        requestContext.getProperties().put(APIConstants.MessageFormat.ERROR_CODE,
+                APISecurityConstants.API_AUTH_INVALID_CREDENTIALS);
+        requestContext.getProperties().put(APIConstants.MessageFormat.ERROR_MESSAGE,
+                WebSubConstants.INVALID_SIGNATURE_MESSAGE);
+        requestContext.getProperties().put(APIConstants.MessageFormat.ERROR_DESCRIPTION,
+                WebSubConstants.INVALID_SIGNATURE_DESCRIPTION);
+        return false;
+    }
+
+    /**
+     * Verifies the signature of the body, computed with the secret of the hub.
+     *
+     * @param secret    secret of the hub
+     * @param algorithm signing algorithm (SHA1, SHA256, SHA384 or SHA512)
+     * @param signature value of the signature header (i.e. sha256=...)
+     * @param body      body of the event
+     * @return whether the signature is valid
+     */
+    static boolean isValidSignature(String secret, String algorithm, String signature, byte[] body) {
+        if (signature == null || body == null) {
+            return false;
+        }
+        String expectedMethod = algorithm.toLowerCase(Locale.ROOT);
+        String[] methodAndSignature = signature.trim().split("=", 2);
+        if (methodAndSignature.length != 2 || !expectedMethod.equalsIgnoreCase(methodAndSignature[0])) {
+            return false;
+        }
+        try {
+            String macAlgorithm = "Hmac" + algorithm.toUpperCase(Locale.ROOT);
+            Mac mac = Mac.getInstance(macAlgorithm);
+            mac.init(new SecretKeySpec(secret.getBytes(StandardCharsets.UTF_8), macAlgorithm));
+            byte[] expected = Hex.encodeHexString(mac.doFinal(body)).getBytes(StandardCharsets.UTF_8);
+            byte[] actual = methodAndSignature[1].toLowerCase(Locale.ROOT).getBytes(StandardCharsets.UTF_8);
+            return MessageDigest.isEqual(expected, actual);
+        } catch (GeneralSecurityException e) {
+            logger.debug("Error while computing the signature of the WebSub event with the algorithm {}",
+                    algorithm, e);
+            return false;
+        }
+    }
+}